@@ -2,6 +2,8 @@ package main
 
 import (
 	"fmt"
+	"net"
+	"strings"
 	"time"
 
 	"vigi/internal/config"
@@ -15,6 +17,9 @@ type Config struct {
 	Port      string `env:"SERVER_PORT" validate:"required,port" default:"8034"`
 	ClientURL string `env:"CLIENT_URL" validate:"url" default:"http://localhost:3000"`
 
+	// Reverse proxies allowed to set the client IP
+	TrustedProxies string `env:"TRUSTED_PROXIES"`
+
 	// Database configuration
 	DBHost string `env:"DB_HOST"`                           // validated in Validate()
 	DBPort string `env:"DB_PORT"`                           // validated in Validate()
@@ -58,6 +63,34 @@ type Config struct {
 	// Usesend Configuration
 	UsesendAPIKey string `env:"USESEND_API_KEY"`
 	UsesendDomain string `env:"USESEND_DOMAIN"`
+
+	// Sender address of the emails sent to status page subscribers
+	StatusPageEmailFrom string `env:"STATUS_PAGE_EMAIL_FROM" default:"Vigi <status@vigi.run>"`
+
+	// Sender address of password reset and email verification emails
+	AuthEmailFrom string `env:"AUTH_EMAIL_FROM" default:"Vigi <no-reply@vigi.run>"`
+
+	// Lifetime of password reset and email verification links
+	PasswordResetTTL     time.Duration `env:"PASSWORD_RESET_TTL" default:"1h"`
+	EmailVerificationTTL time.Duration `env:"EMAIL_VERIFICATION_TTL" default:"48h"`
+
+	// Metrics configuration
+	MetricsToken string `env:"METRICS_TOKEN"`
+
+	// Encryption at rest configuration
+	EncryptionKey          string `env:"ENCRYPTION_KEY"`
+	EncryptionPreviousKeys string `env:"ENCRYPTION_PREVIOUS_KEYS"`
+
+	// OpenID Connect single sign-on configuration
+	OIDCIssuerURL        string `env:"OIDC_ISSUER_URL" validate:"omitempty,url"`
+	OIDCClientID         string `env:"OIDC_CLIENT_ID"`
+	OIDCClientSecret     string `env:"OIDC_CLIENT_SECRET"`
+	OIDCRedirectURL      string `env:"OIDC_REDIRECT_URL" validate:"omitempty,url"`
+	OIDCScopes           string `env:"OIDC_SCOPES" default:"openid,email,profile"`
+	OIDCGroupsClaim      string `env:"OIDC_GROUPS_CLAIM" default:"groups"`
+	OIDCRoleMapping      string `env:"OIDC_ROLE_MAPPING"`
+	OIDCProviderName     string `env:"OIDC_PROVIDER_NAME" default:"SSO"`
+	DisablePasswordLogin bool   `env:"DISABLE_PASSWORD_LOGIN" default:"false"`
 }
 
 // LoadAndValidate loads and validates the API service configuration
@@ -98,6 +131,19 @@ func Validate(cfg *Config) error {
 		return fmt.Errorf("database validation failed: %w", err)
 	}
 
+	// Validate trusted proxies
+	for _, proxy := range strings.Split(cfg.TrustedProxies, ",") {
+		proxy = strings.TrimSpace(proxy)
+		if proxy == "" {
+			continue
+		}
+		if net.ParseIP(proxy) == nil {
+			if _, _, err := net.ParseCIDR(proxy); err != nil {
+				return fmt.Errorf("TRUSTED_PROXIES contains an invalid IP or CIDR: %s", proxy)
+			}
+		}
+	}
+
 	// Validate bruteforce settings
 	if cfg.BruteforceMaxAttempts <= 0 {
 		return fmt.Errorf("BRUTEFORCE_MAX_ATTEMPTS must be greater than 0")
@@ -109,6 +155,23 @@ func Validate(cfg *Config) error {
 		return fmt.Errorf("BRUTEFORCE_LOCKOUT must be a positive duration")
 	}
 
+	// Validate account email link lifetimes
+	if cfg.PasswordResetTTL <= 0 {
+		return fmt.Errorf("PASSWORD_RESET_TTL must be a positive duration")
+	}
+	if cfg.EmailVerificationTTL <= 0 {
+		return fmt.Errorf("EMAIL_VERIFICATION_TTL must be a positive duration")
+	}
+
+	// Validate SSO settings
+	if cfg.OIDCIssuerURL != "" || cfg.OIDCClientID != "" {
+		if cfg.OIDCIssuerURL == "" || cfg.OIDCClientID == "" || cfg.OIDCRedirectURL == "" {
+			return fmt.Errorf("OIDC_ISSUER_URL, OIDC_CLIENT_ID and OIDC_REDIRECT_URL must be set together")
+		}
+	} else if cfg.DisablePasswordLogin {
+		return fmt.Errorf("DISABLE_PASSWORD_LOGIN requires OIDC single sign-on to be configured")
+	}
+
 	return nil
 }
 
@@ -116,34 +179,51 @@ func Validate(cfg *Config) error {
 // This is needed for backward compatibility with existing code
 func (c *Config) ToInternalConfig() *config.Config {
 	return &config.Config{
-		Port:                  c.Port,
-		ClientURL:             c.ClientURL,
-		DBHost:                c.DBHost,
-		DBPort:                c.DBPort,
-		DBName:                c.DBName,
-		DBUser:                c.DBUser,
-		DBPass:                c.DBPass,
-		DBType:                c.DBType,
-		Mode:                  c.Mode,
-		LogLevel:              c.LogLevel,
-		Timezone:              c.Timezone,
-		RedisHost:             c.RedisHost,
-		RedisPort:             c.RedisPort,
-		RedisPassword:         c.RedisPassword,
-		RedisDB:               c.RedisDB,
-		QueueConcurrency:      c.QueueConcurrency,
-		ProducerConcurrency:   c.ProducerConcurrency,
-		BruteforceMaxAttempts: c.BruteforceMaxAttempts,
-		BruteforceWindow:      c.BruteforceWindow,
-		BruteforceLockout:     c.BruteforceLockout,
-		ServiceName:           c.ServiceName,
-		S3Endpoint:            c.S3Endpoint,
-		S3Bucket:              c.S3Bucket,
-		S3Region:              c.S3Region,
-		S3AccessKey:           c.S3AccessKey,
-		S3SecretKey:           c.S3SecretKey,
-		S3DisableSSL:          c.S3DisableSSL,
-		UsesendAPIKey:         c.UsesendAPIKey,
-		UsesendDomain:         c.UsesendDomain,
+		Port:                   c.Port,
+		ClientURL:              c.ClientURL,
+		TrustedProxies:         c.TrustedProxies,
+		DBHost:                 c.DBHost,
+		DBPort:                 c.DBPort,
+		DBName:                 c.DBName,
+		DBUser:                 c.DBUser,
+		DBPass:                 c.DBPass,
+		DBType:                 c.DBType,
+		Mode:                   c.Mode,
+		LogLevel:               c.LogLevel,
+		Timezone:               c.Timezone,
+		RedisHost:              c.RedisHost,
+		RedisPort:              c.RedisPort,
+		RedisPassword:          c.RedisPassword,
+		RedisDB:                c.RedisDB,
+		QueueConcurrency:       c.QueueConcurrency,
+		ProducerConcurrency:    c.ProducerConcurrency,
+		BruteforceMaxAttempts:  c.BruteforceMaxAttempts,
+		BruteforceWindow:       c.BruteforceWindow,
+		BruteforceLockout:      c.BruteforceLockout,
+		ServiceName:            c.ServiceName,
+		S3Endpoint:             c.S3Endpoint,
+		S3Bucket:               c.S3Bucket,
+		S3Region:               c.S3Region,
+		S3AccessKey:            c.S3AccessKey,
+		S3SecretKey:            c.S3SecretKey,
+		S3DisableSSL:           c.S3DisableSSL,
+		UsesendAPIKey:          c.UsesendAPIKey,
+		UsesendDomain:          c.UsesendDomain,
+		StatusPageEmailFrom:    c.StatusPageEmailFrom,
+		AuthEmailFrom:          c.AuthEmailFrom,
+		PasswordResetTTL:       c.PasswordResetTTL,
+		EmailVerificationTTL:   c.EmailVerificationTTL,
+		MetricsToken:           c.MetricsToken,
+		EncryptionKey:          c.EncryptionKey,
+		EncryptionPreviousKeys: c.EncryptionPreviousKeys,
+		OIDCIssuerURL:          c.OIDCIssuerURL,
+		OIDCClientID:           c.OIDCClientID,
+		OIDCClientSecret:       c.OIDCClientSecret,
+		OIDCRedirectURL:        c.OIDCRedirectURL,
+		OIDCScopes:             c.OIDCScopes,
+		OIDCGroupsClaim:        c.OIDCGroupsClaim,
+		OIDCRoleMapping:        c.OIDCRoleMapping,
+		OIDCProviderName:       c.OIDCProviderName,
+		DisablePasswordLogin:   c.DisablePasswordLogin,
 	}
 }
@@ -21,28 +21,41 @@ import (
 	"vigi/internal/modules/cleanup"
 	"vigi/internal/modules/client"
 	"vigi/internal/modules/domain_status_page"
+	"vigi/internal/modules/dunning"
+	"vigi/internal/modules/escalation"
 	"vigi/internal/modules/events"
 	"vigi/internal/modules/healthcheck"
 	"vigi/internal/modules/heartbeat"
+	"vigi/internal/modules/incident"
 	"vigi/internal/modules/inter"
 	"vigi/internal/modules/invoice"
 	"vigi/internal/modules/maintenance"
+	"vigi/internal/modules/metrics"
 	"vigi/internal/modules/middleware"
 	"vigi/internal/modules/monitor"
+	"vigi/internal/modules/monitor_dependency"
+	"vigi/internal/modules/monitor_group"
 	"vigi/internal/modules/monitor_maintenance"
 	"vigi/internal/modules/monitor_notification"
 	"vigi/internal/modules/monitor_status_page"
 	"vigi/internal/modules/monitor_tag"
 	"vigi/internal/modules/monitor_tls_info"
 	"vigi/internal/modules/notification_channel"
+	"vigi/internal/modules/notification_delivery"
 	"vigi/internal/modules/notification_sent_history"
 	"vigi/internal/modules/organization"
+	"vigi/internal/modules/probe"
+	"vigi/internal/modules/probe_agent"
+	"vigi/internal/modules/producer"
 	"vigi/internal/modules/proxy"
 	"vigi/internal/modules/queue"
 	"vigi/internal/modules/recurring_invoice"
+	"vigi/internal/modules/retention"
 	"vigi/internal/modules/setting"
+	"vigi/internal/modules/sso"
 	"vigi/internal/modules/stats"
 	"vigi/internal/modules/status_page"
+	"vigi/internal/modules/status_page_subscriber"
 	"vigi/internal/modules/storage"
 	"vigi/internal/modules/tag"
 	"vigi/internal/modules/webhook"
@@ -50,6 +63,7 @@ import (
 	"vigi/internal/utils"
 	"vigi/internal/version"
 
+	"github.com/redis/go-redis/v9"
 	"go.uber.org/dig"
 	"go.uber.org/zap"
 )
@@ -89,6 +103,7 @@ func main() {
 	// Provide dependencies
 	container.Provide(func() *config.Config { return internalCfg })
 	container.Provide(internal.ProvideLogger)
+	container.Provide(infra.ProvideKeyring)
 	container.Provide(internal.ProvideServer)
 	container.Provide(websocket.NewServer)
 
@@ -117,7 +132,10 @@ func main() {
 	healthcheck.RegisterDependencies(container)
 	bruteforce.RegisterDependencies(container, internalCfg)
 	auth.RegisterDependencies(container, internalCfg)
+	sso.RegisterDependencies(container)
 	notification_channel.RegisterDependencies(container, internalCfg)
+	notification_delivery.RegisterDependencies(container, internalCfg)
+	escalation.RegisterDependencies(container, internalCfg)
 	monitor_notification.RegisterDependencies(container, internalCfg)
 	proxy.RegisterDependencies(container, internalCfg)
 	setting.RegisterDependencies(container, internalCfg)
@@ -125,13 +143,20 @@ func main() {
 	monitor_tls_info.RegisterDependencies(container, internalCfg)
 	certificate.RegisterDependencies(container)
 	stats.RegisterDependencies(container, internalCfg)
+	retention.RegisterDependencies(container, internalCfg)
 	monitor_maintenance.RegisterDependencies(container, internalCfg)
 	maintenance.RegisterDependencies(container, internalCfg)
 	status_page.RegisterDependencies(container, internalCfg)
+	incident.RegisterDependencies(container, internalCfg)
+	status_page_subscriber.RegisterDependencies(container, internalCfg)
 	monitor_status_page.RegisterDependencies(container, internalCfg)
 	domain_status_page.RegisterDependencies(container, internalCfg)
 	tag.RegisterDependencies(container, internalCfg)
 	monitor_tag.RegisterDependencies(container, internalCfg)
+	monitor_dependency.RegisterDependencies(container, internalCfg)
+	monitor_group.RegisterDependencies(container, internalCfg)
+	probe.RegisterDependencies(container, internalCfg)
+	probe_agent.RegisterDependencies(container)
 	badge.RegisterDependencies(container, internalCfg)
 	backoffice.RegisterDependencies(container)
 	queue.RegisterDependencies(container, internalCfg)
@@ -142,6 +167,7 @@ func main() {
 	invoice.RegisterDependencies(container, internalCfg)
 	inter.RegisterDependencies(container)
 	recurring_invoice.RegisterDependencies(container, internalCfg)
+	dunning.RegisterDependencies(container, internalCfg)
 	webhook.RegisterDependencies(container, internalCfg)
 
 	middleware.RegisterDependencies(container)
@@ -158,13 +184,70 @@ func main() {
 
 	// Start cleanup cron job(s)
 	err = container.Invoke(func(
-		heartbeatService heartbeat.Service,
-		settingService setting.Service,
+		retentionService retention.Service,
 		notificationHistoryService notification_sent_history.Service,
 		tlsInfoService monitor_tls_info.Service,
 		logger *zap.SugaredLogger,
 	) {
-		cleanup.StartCleanupCron(heartbeatService, settingService, notificationHistoryService, tlsInfoService, logger)
+		cleanup.StartCleanupCron(retentionService, notificationHistoryService, tlsInfoService, logger)
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Start the recurring invoice scheduler, leader-elected across API replicas
+	var (
+		recurringInvoiceLeader    *producer.LeaderElection
+		recurringInvoiceScheduler *recurring_invoice.Scheduler
+	)
+	err = container.Invoke(func(
+		redisClient *redis.Client,
+		recurringInvoiceService *recurring_invoice.Service,
+		logger *zap.SugaredLogger,
+	) {
+		recurringInvoiceLeader = producer.NewLeaderElectionWithKey(redisClient, recurring_invoice.SchedulerLeaderKey, producer.NewNodeID(), logger)
+		recurringInvoiceLeader.Start(context.Background())
+
+		recurringInvoiceScheduler = recurring_invoice.NewScheduler(recurringInvoiceService, recurringInvoiceLeader, logger)
+		recurringInvoiceScheduler.Start(context.Background())
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Start the invoice dunning worker, leader-elected across API replicas
+	var (
+		dunningLeader *producer.LeaderElection
+		dunningWorker *dunning.Worker
+	)
+	err = container.Invoke(func(
+		redisClient *redis.Client,
+		dunningService *dunning.Service,
+		logger *zap.SugaredLogger,
+	) {
+		dunningLeader = producer.NewLeaderElectionWithKey(redisClient, dunning.WorkerLeaderKey, producer.NewNodeID(), logger)
+		dunningLeader.Start(context.Background())
+
+		dunningWorker = dunning.NewWorker(dunningService, dunningLeader, logger)
+		dunningWorker.Start(context.Background())
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Point the Inter webhooks at the current CLIENT_URL in the background
+	err = container.Invoke(func(redisClient *redis.Client, interService *inter.Service, logger *zap.SugaredLogger) {
+		go inter.SyncWebhooks(context.Background(), redisClient, interService, logger)
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Expose asynq queue depths on /metrics
+	err = container.Invoke(func(queueService queue.Service, logger *zap.SugaredLogger) {
+		if err := metrics.RegisterQueueCollector(queueService, logger); err != nil {
+			logger.Warnw("Failed to register queue metrics collector", "error", err)
+		}
 	})
 	if err != nil {
 		log.Fatal(err)
@@ -197,6 +280,40 @@ func main() {
 		log.Fatal(err)
 	}
 
+	// Send queued notifications, the listener above registers the channel providers
+	var deliveryWorker *notification_channel.DeliveryWorker
+	err = container.Invoke(func(w *notification_channel.DeliveryWorker) error {
+		deliveryWorker = w
+		return w.Start()
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Open and resolve status page incidents when monitors go down and recover
+	err = container.Invoke(func(listener *status_page.IncidentEventListener, eventBus events.EventBus) {
+		listener.Subscribe(eventBus)
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Notify status page subscribers about status changes, incidents and maintenance
+	err = container.Invoke(func(listener *status_page_subscriber.SubscriberEventListener, eventBus events.EventBus) {
+		listener.Subscribe(eventBus)
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Roll monitor status changes up into their groups
+	err = container.Invoke(func(listener *monitor_group.StatusEventListener, eventBus events.EventBus) {
+		listener.Subscribe(eventBus)
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	// Start the monitor event listener
 	err = container.Invoke(func(listener *monitor.MonitorEventListener, eventBus events.EventBus) {
 		listener.Subscribe(eventBus)
@@ -236,6 +353,12 @@ func main() {
 		// Wait for shutdown signal
 		<-sigChan
 		logger.Info("Shutdown signal received, starting graceful shutdown...")
+		recurringInvoiceScheduler.Stop()
+		recurringInvoiceLeader.Stop()
+		dunningWorker.Stop()
+		dunningLeader.Stop()
+		deliveryWorker.Stop()
+
 		// Close event bus
 		if err := eventBus.Close(); err != nil {
 			logger.Errorw("Failed to close event bus", "error", err)
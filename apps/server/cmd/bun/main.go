@@ -28,6 +28,7 @@ func main() {
 		Name: "bun",
 		Commands: []*cli.Command{
 			newDBCommand(),
+			newSecretsCommand(),
 		},
 	}
 
@@ -217,19 +218,35 @@ func newDBCommand() *cli.Command {
 }
 
 func runWithDB(fn func(*bun.DB) error) error {
-	// Load configuration
+	return runWithConfig(func(_ *config.DBConfig, db *bun.DB) error {
+		return fn(db)
+	})
+}
+
+func runWithConfig(fn func(*config.DBConfig, *bun.DB) error) error {
+	cfg, err := loadDBConfig()
+	if err != nil {
+		return err
+	}
+	return runWithLoadedConfig(cfg, fn)
+}
+
+func loadDBConfig() (*config.DBConfig, error) {
 	cfg, err := config.LoadConfig[config.DBConfig]("../..")
 	if err != nil {
-		return fmt.Errorf("failed to load config: %w", err)
+		return nil, fmt.Errorf("failed to load config: %w", err)
 	}
 
 	err = config.ValidateDatabaseCustomRules(&cfg)
 	if err != nil {
-		return fmt.Errorf("failed to validate database custom rules: %w", err)
+		return nil, fmt.Errorf("failed to validate database custom rules: %w", err)
 	}
+	return &cfg, nil
+}
 
+func runWithLoadedConfig(cfg *config.DBConfig, fn func(*config.DBConfig, *bun.DB) error) error {
 	// Connect to database
-	db, err := connectToDatabase(&cfg)
+	db, err := connectToDatabase(cfg)
 	if err != nil {
 		return fmt.Errorf("failed to connect to database: %w", err)
 	}
@@ -241,7 +258,7 @@ func runWithDB(fn func(*bun.DB) error) error {
 		bundebug.FromEnv(),
 	))
 
-	return fn(db)
+	return fn(cfg, db)
 }
 
 func connectToDatabase(cfg *config.DBConfig) (*bun.DB, error) {
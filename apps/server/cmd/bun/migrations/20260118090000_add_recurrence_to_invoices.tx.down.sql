@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_recurring_invoices_next_generation_date;
+DROP INDEX IF EXISTS idx_invoices_recurrence;
+ALTER TABLE invoices DROP COLUMN recurrence_period;
+ALTER TABLE invoices DROP COLUMN recurring_invoice_id;
@@ -0,0 +1,7 @@
+ALTER TABLE invoices
+ADD COLUMN recurring_invoice_id UUID;
+ALTER TABLE invoices
+ADD COLUMN recurrence_period TIMESTAMP;
+-- One generated invoice per recurring invoice and period keeps generation idempotent
+CREATE UNIQUE INDEX IF NOT EXISTS idx_invoices_recurrence ON invoices(recurring_invoice_id, recurrence_period);
+CREATE INDEX IF NOT EXISTS idx_recurring_invoices_next_generation_date ON recurring_invoices(status, next_generation_date);
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS invoice_dunning_policies (
+    id UUID PRIMARY KEY,
+    organization_id UUID NOT NULL UNIQUE,
+    enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    steps JSONB,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_invoice_dunning_policies_enabled ON invoice_dunning_policies(enabled);
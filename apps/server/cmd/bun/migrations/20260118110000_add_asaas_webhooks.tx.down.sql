@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_webhook_events_provider_external_id;
+ALTER TABLE webhook_events DROP COLUMN external_id;
+
+ALTER TABLE asaas_configs DROP COLUMN webhook_token;
@@ -0,0 +1,4 @@
+ALTER TABLE asaas_configs ADD COLUMN webhook_token TEXT;
+
+ALTER TABLE webhook_events ADD COLUMN external_id TEXT;
+CREATE UNIQUE INDEX idx_webhook_events_provider_external_id ON webhook_events(provider, external_id);
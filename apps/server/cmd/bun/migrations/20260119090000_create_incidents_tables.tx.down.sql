@@ -0,0 +1,5 @@
+ALTER TABLE status_pages DROP COLUMN auto_incidents;
+
+DROP TABLE IF EXISTS incident_updates;
+DROP TABLE IF EXISTS incident_monitors;
+DROP TABLE IF EXISTS incidents;
@@ -0,0 +1,35 @@
+CREATE TABLE IF NOT EXISTS incidents (
+    id UUID PRIMARY KEY,
+    org_id UUID,
+    title VARCHAR(255) NOT NULL,
+    severity VARCHAR(20) NOT NULL DEFAULT 'minor',
+    state VARCHAR(20) NOT NULL DEFAULT 'investigating',
+    auto_created BOOLEAN NOT NULL DEFAULT false,
+    started_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    resolved_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS incident_monitors (
+    incident_id UUID NOT NULL,
+    monitor_id UUID NOT NULL,
+    PRIMARY KEY (incident_id, monitor_id),
+    FOREIGN KEY (incident_id) REFERENCES incidents(id) ON DELETE CASCADE,
+    FOREIGN KEY (monitor_id) REFERENCES monitors(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS incident_updates (
+    id UUID PRIMARY KEY,
+    incident_id UUID NOT NULL,
+    state VARCHAR(20) NOT NULL,
+    message TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (incident_id) REFERENCES incidents(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_incidents_org_state ON incidents(org_id, state);
+CREATE INDEX IF NOT EXISTS idx_incident_monitors_monitor_id ON incident_monitors(monitor_id);
+CREATE INDEX IF NOT EXISTS idx_incident_updates_incident_created ON incident_updates(incident_id, created_at);
+
+ALTER TABLE status_pages ADD COLUMN auto_incidents BOOLEAN NOT NULL DEFAULT false;
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS status_page_subscribers (
+    id UUID PRIMARY KEY,
+    status_page_id UUID NOT NULL,
+    type VARCHAR(20) NOT NULL,
+    email VARCHAR(255),
+    webhook_url TEXT,
+    confirmed BOOLEAN NOT NULL DEFAULT false,
+    confirm_token VARCHAR(64) NOT NULL,
+    unsubscribe_token VARCHAR(64) NOT NULL,
+    confirmed_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (status_page_id) REFERENCES status_pages(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_status_page_subscribers_status_page_id ON status_page_subscribers(status_page_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_status_page_subscribers_confirm_token ON status_page_subscribers(confirm_token);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_status_page_subscribers_unsubscribe_token ON status_page_subscribers(unsubscribe_token);
@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS notification_deliveries (
+    id UUID PRIMARY KEY,
+    org_id UUID,
+    notification_id UUID NOT NULL,
+    monitor_id UUID NOT NULL,
+    event VARCHAR(50) NOT NULL,
+    message TEXT NOT NULL,
+    heartbeat TEXT,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    response_code INTEGER,
+    last_attempt_at TIMESTAMP,
+    sent_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (notification_id) REFERENCES notification_channels(id) ON DELETE CASCADE,
+    FOREIGN KEY (monitor_id) REFERENCES monitors(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_notification_deliveries_org_created ON notification_deliveries(org_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_notification_deliveries_org_status ON notification_deliveries(org_id, status);
+CREATE INDEX IF NOT EXISTS idx_notification_deliveries_notification_id ON notification_deliveries(notification_id);
+CREATE INDEX IF NOT EXISTS idx_notification_deliveries_monitor_id ON notification_deliveries(monitor_id);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS escalations;
+DROP TABLE IF EXISTS escalation_policy_monitors;
+DROP TABLE IF EXISTS escalation_policies;
@@ -0,0 +1,40 @@
+CREATE TABLE IF NOT EXISTS escalation_policies (
+    id UUID PRIMARY KEY,
+    org_id UUID,
+    name VARCHAR(255) NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT true,
+    steps TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS escalation_policy_monitors (
+    policy_id UUID NOT NULL,
+    monitor_id UUID NOT NULL,
+    PRIMARY KEY (policy_id, monitor_id),
+    FOREIGN KEY (policy_id) REFERENCES escalation_policies(id) ON DELETE CASCADE,
+    FOREIGN KEY (monitor_id) REFERENCES monitors(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS escalations (
+    id UUID PRIMARY KEY,
+    org_id UUID,
+    policy_id UUID NOT NULL,
+    monitor_id UUID NOT NULL,
+    heartbeat_id UUID,
+    status VARCHAR(20) NOT NULL DEFAULT 'active',
+    current_tier INTEGER NOT NULL DEFAULT 0,
+    started_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    acknowledged_at TIMESTAMP,
+    acknowledged_by UUID,
+    resolved_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (policy_id) REFERENCES escalation_policies(id) ON DELETE CASCADE,
+    FOREIGN KEY (monitor_id) REFERENCES monitors(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_escalation_policies_org_id ON escalation_policies(org_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_escalation_policy_monitors_monitor_id ON escalation_policy_monitors(monitor_id);
+CREATE INDEX IF NOT EXISTS idx_escalations_org_status ON escalations(org_id, status);
+CREATE INDEX IF NOT EXISTS idx_escalations_monitor_status ON escalations(monitor_id, status);
@@ -0,0 +1,116 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/uptrace/bun"
+)
+
+// Stats of every period used to share one row per (monitor, timestamp), so the
+// minutely, hourly and daily buckets starting at the same instant overwrote each
+// other. The existing rows cannot be told apart: each holds whichever bucket was
+// written last for its timestamp. They are all marked minutely, so a row at an
+// hour or day boundary may carry a wider bucket until it ages out. Hourly and
+// daily rollups are rebuilt from raw heartbeats by the retention job.
+
+var retentionTables = []string{
+	`CREATE TABLE IF NOT EXISTS retention_policies (
+    id UUID PRIMARY KEY,
+    org_id UUID NOT NULL,
+    monitor_id UUID,
+    heartbeat_days INTEGER NOT NULL,
+    minutely_days INTEGER NOT NULL DEFAULT 0,
+    hourly_days INTEGER NOT NULL DEFAULT 0,
+    daily_days INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (org_id) REFERENCES organizations(id) ON DELETE CASCADE,
+    FOREIGN KEY (monitor_id) REFERENCES monitors(id) ON DELETE CASCADE
+)`,
+	`CREATE UNIQUE INDEX IF NOT EXISTS idx_retention_policies_org ON retention_policies(org_id) WHERE monitor_id IS NULL`,
+	`CREATE UNIQUE INDEX IF NOT EXISTS idx_retention_policies_monitor ON retention_policies(monitor_id) WHERE monitor_id IS NOT NULL`,
+	// Day up to which the hourly and daily rollups of a monitor were rebuilt from raw heartbeats
+	`CREATE TABLE IF NOT EXISTS retention_compactions (
+    monitor_id UUID PRIMARY KEY,
+    compacted_until TIMESTAMP NOT NULL,
+    FOREIGN KEY (monitor_id) REFERENCES monitors(id) ON DELETE CASCADE
+)`,
+}
+
+// statsColumns are the columns of stats before the period was added
+const statsColumns = `id, monitor_id, timestamp, ping, ping_min, ping_max, up, down, maintenance, created_at, updated_at`
+
+// The unique constraint of stats is declared inline on SQLite, which can only
+// drop it by rebuilding the table
+var sqliteStatsWithPeriod = []string{
+	`CREATE TABLE stats_new (
+    id UUID PRIMARY KEY,
+    monitor_id UUID NOT NULL,
+    timestamp TIMESTAMP NOT NULL,
+    ping DOUBLE PRECISION NOT NULL DEFAULT 0,
+    ping_min DOUBLE PRECISION NOT NULL DEFAULT 0,
+    ping_max DOUBLE PRECISION NOT NULL DEFAULT 0,
+    up INTEGER NOT NULL DEFAULT 0,
+    down INTEGER NOT NULL DEFAULT 0,
+    maintenance INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    period VARCHAR(10) NOT NULL DEFAULT 'minutely',
+    FOREIGN KEY (monitor_id) REFERENCES monitors(id) ON DELETE CASCADE
+)`,
+	`INSERT INTO stats_new (` + statsColumns + `) SELECT ` + statsColumns + ` FROM stats`,
+	`DROP TABLE stats`,
+	`ALTER TABLE stats_new RENAME TO stats`,
+	`CREATE INDEX IF NOT EXISTS idx_stats_monitor_timestamp ON stats(monitor_id, timestamp)`,
+	`CREATE UNIQUE INDEX IF NOT EXISTS idx_stats_monitor_period_timestamp ON stats(monitor_id, period, timestamp)`,
+}
+
+var sqliteStatsWithoutPeriod = []string{
+	`CREATE TABLE stats_old (
+    id UUID PRIMARY KEY,
+    monitor_id UUID NOT NULL,
+    timestamp TIMESTAMP NOT NULL,
+    ping DOUBLE PRECISION NOT NULL DEFAULT 0,
+    ping_min DOUBLE PRECISION NOT NULL DEFAULT 0,
+    ping_max DOUBLE PRECISION NOT NULL DEFAULT 0,
+    up INTEGER NOT NULL DEFAULT 0,
+    down INTEGER NOT NULL DEFAULT 0,
+    maintenance INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (monitor_id) REFERENCES monitors(id) ON DELETE CASCADE,
+    UNIQUE(monitor_id, timestamp)
+)`,
+	`INSERT INTO stats_old (` + statsColumns + `) SELECT ` + statsColumns + ` FROM stats WHERE period = 'minutely'`,
+	`DROP TABLE stats`,
+	`ALTER TABLE stats_old RENAME TO stats`,
+	`CREATE INDEX IF NOT EXISTS idx_stats_monitor_timestamp ON stats(monitor_id, timestamp)`,
+}
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		queries := sqliteStatsWithPeriod
+		if !isSQLite(db) {
+			queries = []string{
+				`ALTER TABLE stats ADD COLUMN period VARCHAR(10) NOT NULL DEFAULT 'minutely'`,
+				`ALTER TABLE stats DROP CONSTRAINT IF EXISTS stats_monitor_id_timestamp_key`,
+				`CREATE UNIQUE INDEX IF NOT EXISTS idx_stats_monitor_period_timestamp ON stats(monitor_id, period, timestamp)`,
+			}
+		}
+		return execTx(ctx, db, append(queries, retentionTables...)...)
+	}, func(ctx context.Context, db *bun.DB) error {
+		queries := sqliteStatsWithoutPeriod
+		if !isSQLite(db) {
+			queries = []string{
+				`DROP INDEX IF EXISTS idx_stats_monitor_period_timestamp`,
+				`DELETE FROM stats WHERE period <> 'minutely'`,
+				`ALTER TABLE stats ADD CONSTRAINT stats_monitor_id_timestamp_key UNIQUE (monitor_id, timestamp)`,
+				`ALTER TABLE stats DROP COLUMN period`,
+			}
+		}
+		return execTx(ctx, db, append([]string{
+			`DROP TABLE IF EXISTS retention_compactions`,
+			`DROP TABLE IF EXISTS retention_policies`,
+		}, queries...)...)
+	})
+}
@@ -0,0 +1,24 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/uptrace/bun"
+)
+
+// Encrypted secrets carry a wrapped data key and are longer than the plain text
+// values the column was sized for. SQLite does not enforce VARCHAR lengths, so
+// there is nothing to widen there.
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		if isSQLite(db) {
+			return nil
+		}
+		return execTx(ctx, db, `ALTER TABLE proxies ALTER COLUMN password TYPE TEXT`)
+	}, func(ctx context.Context, db *bun.DB) error {
+		if isSQLite(db) {
+			return nil
+		}
+		return execTx(ctx, db, `ALTER TABLE proxies ALTER COLUMN password TYPE VARCHAR(255)`)
+	})
+}
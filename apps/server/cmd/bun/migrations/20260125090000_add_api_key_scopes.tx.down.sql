@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_api_keys_org_id;
+
+ALTER TABLE api_keys DROP COLUMN last_used_route;
+ALTER TABLE api_keys DROP COLUMN last_used_ip;
+ALTER TABLE api_keys DROP COLUMN allowed_ips;
+ALTER TABLE api_keys DROP COLUMN scopes;
+ALTER TABLE api_keys DROP COLUMN user_id;
+ALTER TABLE api_keys DROP COLUMN org_id;
@@ -0,0 +1,25 @@
+-- API keys are bound to one organization and act on behalf of the member who
+-- created them.
+ALTER TABLE api_keys ADD COLUMN org_id UUID REFERENCES organizations(id) ON DELETE CASCADE;
+ALTER TABLE api_keys ADD COLUMN user_id UUID REFERENCES users(id) ON DELETE CASCADE;
+
+-- Comma separated scopes, e.g. "monitors:read,push"
+ALTER TABLE api_keys ADD COLUMN scopes TEXT NOT NULL DEFAULT '';
+-- Comma separated IP addresses or CIDR ranges, empty allows any address
+ALTER TABLE api_keys ADD COLUMN allowed_ips TEXT NOT NULL DEFAULT '';
+
+-- Audit of the last request made with the key
+ALTER TABLE api_keys ADD COLUMN last_used_ip VARCHAR(45);
+ALTER TABLE api_keys ADD COLUMN last_used_route VARCHAR(255);
+
+CREATE INDEX IF NOT EXISTS idx_api_keys_org_id ON api_keys(org_id);
+
+-- Keys created before this belonged to the whole installation. With a single
+-- organization they keep working: they are bound to it, act on behalf of its
+-- first admin and keep access to every scope. With several organizations there
+-- is no telling which one a key was meant for, so they are rejected until recreated.
+UPDATE api_keys
+SET org_id = (SELECT id FROM organizations LIMIT 1),
+    user_id = (SELECT user_id FROM organization_users WHERE role = 'admin' ORDER BY created_at LIMIT 1),
+    scopes = 'monitors:read,monitors:write,push,invoices:read,invoices:write,status_pages:read'
+WHERE org_id IS NULL AND (SELECT COUNT(*) FROM organizations) = 1;
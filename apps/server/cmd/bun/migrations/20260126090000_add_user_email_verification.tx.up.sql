@@ -0,0 +1,7 @@
+-- Users confirm their email address through a signed link sent on registration.
+-- Accounts that existed before are trusted as verified.
+ALTER TABLE users ADD COLUMN email_verified BOOLEAN NOT NULL DEFAULT FALSE;
+UPDATE users SET email_verified = TRUE;
+
+-- Refresh tokens issued before this time are rejected, set by password resets
+ALTER TABLE users ADD COLUMN tokens_revoked_at TIMESTAMP WITH TIME ZONE;
@@ -0,0 +1 @@
+ALTER TABLE stats DROP COLUMN degraded;
@@ -0,0 +1,2 @@
+-- Degraded heartbeats count as up and are also tracked on their own
+ALTER TABLE stats ADD COLUMN degraded INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_monitor_dependencies_parent_id;
+
+--bun:split
+
+DROP TABLE IF EXISTS monitor_dependencies;
@@ -0,0 +1,21 @@
+ALTER TABLE status_pages DROP COLUMN group_ids;
+
+--bun:split
+
+ALTER TABLE maintenances DROP COLUMN group_ids;
+
+--bun:split
+
+DROP INDEX IF EXISTS idx_monitor_group_monitors_monitor_id;
+
+--bun:split
+
+DROP INDEX IF EXISTS idx_monitor_groups_org_id;
+
+--bun:split
+
+DROP TABLE IF EXISTS monitor_group_monitors;
+
+--bun:split
+
+DROP TABLE IF EXISTS monitor_groups;
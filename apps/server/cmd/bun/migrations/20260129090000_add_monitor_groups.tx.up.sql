@@ -0,0 +1,42 @@
+CREATE TABLE IF NOT EXISTS monitor_groups (
+    id UUID PRIMARY KEY,
+    org_id UUID,
+    parent_id UUID,
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    policy VARCHAR(20) NOT NULL DEFAULT 'all_up',
+    quorum INTEGER NOT NULL DEFAULT 0,
+    status INTEGER NOT NULL DEFAULT 2,
+    notification_ids TEXT NOT NULL DEFAULT '[]',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (parent_id) REFERENCES monitor_groups(id) ON DELETE SET NULL,
+    CHECK (parent_id IS NULL OR parent_id <> id)
+);
+
+--bun:split
+
+CREATE TABLE IF NOT EXISTS monitor_group_monitors (
+    group_id UUID NOT NULL,
+    monitor_id UUID NOT NULL,
+    PRIMARY KEY (group_id, monitor_id),
+    FOREIGN KEY (group_id) REFERENCES monitor_groups(id) ON DELETE CASCADE,
+    FOREIGN KEY (monitor_id) REFERENCES monitors(id) ON DELETE CASCADE
+);
+
+--bun:split
+
+CREATE INDEX IF NOT EXISTS idx_monitor_groups_org_id ON monitor_groups(org_id);
+
+--bun:split
+
+CREATE INDEX IF NOT EXISTS idx_monitor_group_monitors_monitor_id ON monitor_group_monitors(monitor_id);
+
+--bun:split
+
+-- Maintenance windows and status pages can target whole groups, stored as JSON arrays of group IDs
+ALTER TABLE maintenances ADD COLUMN group_ids TEXT NOT NULL DEFAULT '[]';
+
+--bun:split
+
+ALTER TABLE status_pages ADD COLUMN group_ids TEXT NOT NULL DEFAULT '[]';
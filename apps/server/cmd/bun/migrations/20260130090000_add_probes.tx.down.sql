@@ -0,0 +1,17 @@
+ALTER TABLE heartbeats DROP COLUMN probe_id;
+
+--bun:split
+
+DROP TABLE IF EXISTS probe_results;
+
+--bun:split
+
+DROP TABLE IF EXISTS monitor_probes;
+
+--bun:split
+
+DROP INDEX IF EXISTS idx_probes_org_id;
+
+--bun:split
+
+DROP TABLE IF EXISTS probes;
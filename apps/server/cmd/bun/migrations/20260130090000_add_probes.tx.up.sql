@@ -0,0 +1,44 @@
+CREATE TABLE IF NOT EXISTS probes (
+    id UUID PRIMARY KEY,
+    org_id UUID,
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    last_seen_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+--bun:split
+
+CREATE INDEX IF NOT EXISTS idx_probes_org_id ON probes(org_id);
+
+--bun:split
+
+-- The locations a monitor is checked from, stored as a JSON array of probe IDs
+CREATE TABLE IF NOT EXISTS monitor_probes (
+    monitor_id UUID PRIMARY KEY,
+    probe_ids TEXT NOT NULL DEFAULT '[]',
+    down_threshold INTEGER NOT NULL DEFAULT 1,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (monitor_id) REFERENCES monitors(id) ON DELETE CASCADE
+);
+
+--bun:split
+
+-- Latest result of every probe for every monitor, used to count the locations a monitor is down from
+CREATE TABLE IF NOT EXISTS probe_results (
+    monitor_id UUID NOT NULL,
+    probe_id UUID NOT NULL,
+    status INTEGER NOT NULL,
+    msg TEXT,
+    ping INTEGER NOT NULL DEFAULT 0,
+    time TIMESTAMP NOT NULL,
+    PRIMARY KEY (monitor_id, probe_id),
+    FOREIGN KEY (monitor_id) REFERENCES monitors(id) ON DELETE CASCADE,
+    FOREIGN KEY (probe_id) REFERENCES probes(id) ON DELETE CASCADE
+);
+
+--bun:split
+
+ALTER TABLE heartbeats ADD COLUMN probe_id UUID;
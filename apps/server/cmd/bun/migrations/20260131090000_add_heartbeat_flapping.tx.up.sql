@@ -0,0 +1 @@
+ALTER TABLE heartbeats ADD COLUMN flapping BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1 @@
+ALTER TABLE status_page_subscribers ADD COLUMN confirmation_sent_at TIMESTAMP;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_incidents_open_auto_monitor;
+
+ALTER TABLE incidents DROP COLUMN open_auto_monitor_id;
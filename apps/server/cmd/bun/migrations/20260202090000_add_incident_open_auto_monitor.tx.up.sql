@@ -0,0 +1,7 @@
+-- Holds the monitor of an open automatic incident and is cleared on resolution.
+-- The unique index keeps concurrent API replicas from opening two automatic
+-- incidents for the same monitor. Incidents opened before this migration are
+-- not backfilled; the open incident lookup still covers them.
+ALTER TABLE incidents ADD COLUMN open_auto_monitor_id UUID;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_incidents_open_auto_monitor ON incidents(open_auto_monitor_id) WHERE open_auto_monitor_id IS NOT NULL;
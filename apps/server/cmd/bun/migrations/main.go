@@ -1,6 +1,12 @@
 package migrations
 
-import "github.com/uptrace/bun/migrate"
+import (
+	"context"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect"
+	"github.com/uptrace/bun/migrate"
+)
 
 var Migrations = migrate.NewMigrations()
 
@@ -9,3 +15,21 @@ func init() {
 		panic(err)
 	}
 }
+
+// isSQLite reports whether db is SQLite, whose ALTER TABLE cannot change
+// columns or constraints in place
+func isSQLite(db *bun.DB) bool {
+	return db.Dialect().Name() == dialect.SQLite
+}
+
+// execTx runs the queries in order in a single transaction
+func execTx(ctx context.Context, db *bun.DB, queries ...string) error {
+	return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		for _, query := range queries {
+			if _, err := tx.ExecContext(ctx, query); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,210 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"vigi/internal/config"
+	"vigi/internal/modules/monitor"
+	"vigi/internal/modules/notification_channel"
+	"vigi/internal/secret"
+
+	"github.com/uptrace/bun"
+	"github.com/urfave/cli/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// secretColumn is a column holding a secret, or a JSON document with secret
+// fields when fields is set
+type secretColumn struct {
+	table  string
+	column string
+	fields []string
+}
+
+var secretColumns = []secretColumn{
+	{table: "proxies", column: "password"},
+	{table: "inter_configurations", column: "client_secret"},
+	{table: "inter_configurations", column: "cert_key"},
+	{table: "asaas_configs", column: "api_key"},
+	{table: "asaas_configs", column: "webhook_token"},
+	{table: "monitors", column: "config", fields: monitor.SecretConfigFields},
+	{table: "notification_channels", column: "config", fields: notification_channel.SecretConfigFields},
+}
+
+// mongoSecretColumns are the collections and fields holding secrets on MongoDB,
+// where the bank integrations are not available
+var mongoSecretColumns = []secretColumn{
+	{table: "proxies", column: "password"},
+	{table: "monitor", column: "config", fields: monitor.SecretConfigFields},
+	{table: "notification_channel", column: "config", fields: notification_channel.SecretConfigFields},
+}
+
+func newSecretsCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "secrets",
+		Usage: "secrets encrypted at rest",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "rotate",
+				Usage: "encrypt plain text secrets and re-wrap the ones sealed with ENCRYPTION_PREVIOUS_KEYS using ENCRYPTION_KEY",
+				Action: func(c *cli.Context) error {
+					cfg, err := loadDBConfig()
+					if err != nil {
+						return err
+					}
+					keyring, err := loadKeyring(cfg)
+					if err != nil {
+						return err
+					}
+					if !keyring.Enabled() {
+						return fmt.Errorf("ENCRYPTION_KEY is required to rotate secrets")
+					}
+
+					if cfg.DBType == "mongo" || cfg.DBType == "mongodb" {
+						return rotateMongo(c.Context, cfg, keyring)
+					}
+
+					return runWithLoadedConfig(cfg, func(_ *config.DBConfig, db *bun.DB) error {
+						return db.RunInTx(c.Context, nil, func(ctx context.Context, tx bun.Tx) error {
+							for _, sc := range secretColumns {
+								rotated, total, err := rotateColumn(ctx, tx, keyring, sc)
+								if err != nil {
+									return fmt.Errorf("failed to rotate %s.%s: %w", sc.table, sc.column, err)
+								}
+								fmt.Printf("%s.%s: rotated %d of %d values\n", sc.table, sc.column, rotated, total)
+							}
+							return nil
+						})
+					})
+				},
+			},
+		},
+	}
+}
+
+func loadKeyring(cfg *config.DBConfig) (*secret.Keyring, error) {
+	var previous []string
+	if cfg.EncryptionPreviousKeys != "" {
+		previous = strings.Split(cfg.EncryptionPreviousKeys, ",")
+	}
+	return secret.NewKeyring(cfg.EncryptionKey, previous)
+}
+
+func rotateColumn(ctx context.Context, tx bun.Tx, keyring *secret.Keyring, sc secretColumn) (int, int, error) {
+	var rows []struct {
+		ID    string         `bun:"id"`
+		Value sql.NullString `bun:"value"`
+	}
+	err := tx.NewSelect().
+		TableExpr(sc.table).
+		ColumnExpr("id").
+		ColumnExpr("? AS value", bun.Ident(sc.column)).
+		Scan(ctx, &rows)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	rotated := 0
+	for _, row := range rows {
+		if !row.Value.Valid {
+			continue
+		}
+
+		value, changed, err := rotateValue(keyring, sc, row.Value.String)
+		if err != nil {
+			return rotated, len(rows), fmt.Errorf("row %s: %w", row.ID, err)
+		}
+		if !changed {
+			continue
+		}
+
+		_, err = tx.NewUpdate().
+			Table(sc.table).
+			Set("? = ?", bun.Ident(sc.column), value).
+			Where("id = ?", row.ID).
+			Exec(ctx)
+		if err != nil {
+			return rotated, len(rows), fmt.Errorf("row %s: %w", row.ID, err)
+		}
+		rotated++
+	}
+
+	return rotated, len(rows), nil
+}
+
+// rotateMongo rotates the secrets stored on MongoDB. Transactions need a
+// replica set there, so documents are updated one at a time: rotation is
+// idempotent and an interrupted run can simply be started again.
+func rotateMongo(ctx context.Context, cfg *config.DBConfig, keyring *secret.Keyring) error {
+	uri := fmt.Sprintf("mongodb://%s:%s@%s:%s/%s?authSource=admin",
+		cfg.DBUser,
+		cfg.DBPass,
+		cfg.DBHost,
+		cfg.DBPort,
+		cfg.DBName,
+	)
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer client.Disconnect(ctx) // nolint:errcheck
+
+	db := client.Database(cfg.DBName)
+	for _, sc := range mongoSecretColumns {
+		rotated, total, err := rotateCollection(ctx, db.Collection(sc.table), keyring, sc)
+		if err != nil {
+			return fmt.Errorf("failed to rotate %s.%s: %w", sc.table, sc.column, err)
+		}
+		fmt.Printf("%s.%s: rotated %d of %d values\n", sc.table, sc.column, rotated, total)
+	}
+	return nil
+}
+
+func rotateCollection(ctx context.Context, collection *mongo.Collection, keyring *secret.Keyring, sc secretColumn) (int, int, error) {
+	cursor, err := collection.Find(ctx, bson.M{sc.column: bson.M{"$type": "string"}},
+		options.Find().SetProjection(bson.M{sc.column: 1}))
+	if err != nil {
+		return 0, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	rotated, total := 0, 0
+	for cursor.Next(ctx) {
+		var document bson.M
+		if err := cursor.Decode(&document); err != nil {
+			return rotated, total, err
+		}
+		total++
+
+		stored, _ := document[sc.column].(string)
+		value, changed, err := rotateValue(keyring, sc, stored)
+		if err != nil {
+			return rotated, total, fmt.Errorf("document %v: %w", document["_id"], err)
+		}
+		if !changed {
+			continue
+		}
+
+		_, err = collection.UpdateOne(ctx,
+			bson.M{"_id": document["_id"]},
+			bson.M{"$set": bson.M{sc.column: value}},
+		)
+		if err != nil {
+			return rotated, total, fmt.Errorf("document %v: %w", document["_id"], err)
+		}
+		rotated++
+	}
+
+	return rotated, total, cursor.Err()
+}
+
+func rotateValue(keyring *secret.Keyring, sc secretColumn, value string) (string, bool, error) {
+	if sc.fields != nil {
+		return keyring.RotateFields(value, sc.fields)
+	}
+	return keyring.Rotate(value)
+}
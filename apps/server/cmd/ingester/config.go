@@ -33,6 +33,10 @@ type Config struct {
 	QueueConcurrency int `env:"QUEUE_CONCURRENCY" validate:"min=1" default:"128"`
 
 	ServiceName string `env:"SERVICE_NAME" validate:"required,min=1" default:"vigi:ingester"`
+
+	// Metrics configuration
+	MetricsPort  string `env:"METRICS_PORT" validate:"omitempty,port" default:"9093"`
+	MetricsToken string `env:"METRICS_TOKEN"`
 }
 
 // LoadAndValidate loads and validates the Ingester service configuration
@@ -100,5 +104,7 @@ func (c *Config) ToInternalConfig() *config.Config {
 		RedisDB:          c.RedisDB,
 		QueueConcurrency: c.QueueConcurrency,
 		ServiceName:      c.ServiceName,
+		MetricsPort:      c.MetricsPort,
+		MetricsToken:     c.MetricsToken,
 	}
 }
@@ -13,6 +13,9 @@ import (
 	"vigi/internal/modules/events"
 	"vigi/internal/modules/heartbeat"
 	"vigi/internal/modules/ingester"
+	"vigi/internal/modules/metrics"
+	"vigi/internal/modules/monitor_dependency"
+	"vigi/internal/modules/probe"
 	"vigi/internal/modules/monitor_maintenance"
 	"vigi/internal/modules/monitor_tls_info"
 	"vigi/internal/modules/notification_sent_history"
@@ -102,6 +105,8 @@ func main() {
 	monitor_tls_info.RegisterDependencies(container, internalCfg)
 	certificate.RegisterDependencies(container)
 	monitor_maintenance.RegisterDependencies(container, internalCfg)
+	monitor_dependency.RegisterDependencies(container, internalCfg)
+	probe.RegisterDependencies(container, internalCfg)
 	stats.RegisterDependencies(container, internalCfg)
 	setting.RegisterDependencies(container, internalCfg)
 
@@ -113,6 +118,7 @@ func main() {
 			impl.RegisterEventHandlers(bus)
 		}
 	})
+	container.Invoke(metrics.SubscribeMonitorEvents)
 
 	// Start the ingester
 	err = container.Invoke(func(
@@ -128,6 +134,9 @@ func main() {
 
 		logger.Info("Ingester started successfully")
 
+		// Expose Prometheus metrics
+		metricsServer := metrics.StartServer(internalCfg.MetricsPort, internalCfg.MetricsToken, logger)
+
 		// Wait for termination signal
 		sigChan := make(chan os.Signal, 1)
 		signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
@@ -138,6 +147,12 @@ func main() {
 		logger.Info("Shutdown signal received, stopping ingester...")
 		ing.Stop()
 
+		if metricsServer != nil {
+			if err := metricsServer.Close(); err != nil {
+				logger.Errorw("Failed to stop metrics server", "error", err)
+			}
+		}
+
 		// Close event bus
 		if err := eventBus.Close(); err != nil {
 			logger.Errorw("Failed to close event bus", "error", err)
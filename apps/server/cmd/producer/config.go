@@ -33,6 +33,14 @@ type Config struct {
 	ProducerConcurrency int `env:"PRODUCER_CONCURRENCY" validate:"min=1,max=128" default:"10"`
 
 	ServiceName string `env:"SERVICE_NAME" validate:"required,min=1" default:"vigi:producer"`
+
+	// Metrics configuration
+	MetricsPort  string `env:"METRICS_PORT" validate:"omitempty,port" default:"9091"`
+	MetricsToken string `env:"METRICS_TOKEN"`
+
+	// Encryption at rest configuration
+	EncryptionKey          string `env:"ENCRYPTION_KEY"`
+	EncryptionPreviousKeys string `env:"ENCRYPTION_PREVIOUS_KEYS"`
 }
 
 // LoadAndValidate loads and validates the Producer service configuration
@@ -80,20 +88,24 @@ func Validate(cfg *Config) error {
 // This is needed for backward compatibility with existing code
 func (c *Config) ToInternalConfig() *config.Config {
 	return &config.Config{
-		DBHost:              c.DBHost,
-		DBPort:              c.DBPort,
-		DBName:              c.DBName,
-		DBUser:              c.DBUser,
-		DBPass:              c.DBPass,
-		DBType:              c.DBType,
-		Mode:                c.Mode,
-		LogLevel:            c.LogLevel,
-		Timezone:            c.Timezone,
-		RedisHost:           c.RedisHost,
-		RedisPort:           c.RedisPort,
-		RedisPassword:       c.RedisPassword,
-		RedisDB:             c.RedisDB,
-		ProducerConcurrency: c.ProducerConcurrency,
-		ServiceName:         c.ServiceName,
+		DBHost:                 c.DBHost,
+		DBPort:                 c.DBPort,
+		DBName:                 c.DBName,
+		DBUser:                 c.DBUser,
+		DBPass:                 c.DBPass,
+		DBType:                 c.DBType,
+		Mode:                   c.Mode,
+		LogLevel:               c.LogLevel,
+		Timezone:               c.Timezone,
+		RedisHost:              c.RedisHost,
+		RedisPort:              c.RedisPort,
+		RedisPassword:          c.RedisPassword,
+		RedisDB:                c.RedisDB,
+		ProducerConcurrency:    c.ProducerConcurrency,
+		ServiceName:            c.ServiceName,
+		MetricsPort:            c.MetricsPort,
+		MetricsToken:           c.MetricsToken,
+		EncryptionKey:          c.EncryptionKey,
+		EncryptionPreviousKeys: c.EncryptionPreviousKeys,
 	}
 }
@@ -13,12 +13,17 @@ import (
 	"vigi/internal/modules/healthcheck"
 	"vigi/internal/modules/heartbeat"
 	"vigi/internal/modules/maintenance"
+	"vigi/internal/modules/metrics"
 	"vigi/internal/modules/monitor"
+	"vigi/internal/modules/monitor_dependency"
+	"vigi/internal/modules/monitor_group"
 	"vigi/internal/modules/monitor_maintenance"
 	"vigi/internal/modules/monitor_notification"
 	"vigi/internal/modules/monitor_tag"
 	"vigi/internal/modules/monitor_tls_info"
+	"vigi/internal/modules/notification_channel"
 	"vigi/internal/modules/notification_sent_history"
+	"vigi/internal/modules/probe"
 	"vigi/internal/modules/producer"
 	"vigi/internal/modules/proxy"
 	"vigi/internal/modules/setting"
@@ -48,6 +53,7 @@ func main() {
 	container.Provide(func() *config.Config { return internalCfg })
 
 	container.Provide(internal.ProvideLogger)
+	container.Provide(infra.ProvideKeyring)
 
 	switch internalCfg.DBType {
 	case "postgres", "postgresql", "mysql", "sqlite":
@@ -72,11 +78,16 @@ func main() {
 	healthcheck.RegisterDependencies(container) // Provides ExecutorRegistry
 	tag.RegisterDependencies(container, internalCfg)
 	monitor_tag.RegisterDependencies(container, internalCfg)
+	monitor_dependency.RegisterDependencies(container, internalCfg)
+	monitor_group.RegisterDependencies(container, internalCfg)
+	probe.RegisterDependencies(container, internalCfg)
 	monitor.RegisterDependencies(container, internalCfg)
 	proxy.RegisterDependencies(container, internalCfg)
 	maintenance.RegisterDependencies(container, internalCfg)
 	monitor_maintenance.RegisterDependencies(container, internalCfg)
 	monitor_notification.RegisterDependencies(container, internalCfg)
+	// Monitor groups check their notification channels
+	notification_channel.RegisterDependencies(container, internalCfg)
 	setting.RegisterDependencies(container, internalCfg)
 	notification_sent_history.RegisterDependencies(container, internalCfg)
 	monitor_tls_info.RegisterDependencies(container, internalCfg)
@@ -103,6 +114,9 @@ func main() {
 
 		logger.Info("Producer started successfully")
 
+		// Expose Prometheus metrics
+		metricsServer := metrics.StartServer(internalCfg.MetricsPort, internalCfg.MetricsToken, logger)
+
 		// Wait for termination signal
 		sigChan := make(chan os.Signal, 1)
 		signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
@@ -113,6 +127,12 @@ func main() {
 		logger.Info("Shutdown signal received, stopping producer...")
 		prod.Stop()
 
+		if metricsServer != nil {
+			if err := metricsServer.Close(); err != nil {
+				logger.Errorw("Failed to stop metrics server", "error", err)
+			}
+		}
+
 		// Close event bus
 		if err := eventBus.Close(); err != nil {
 			logger.Errorw("Failed to close event bus", "error", err)
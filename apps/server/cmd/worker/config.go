@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"net/url"
 
 	"vigi/internal/config"
 
@@ -25,6 +26,19 @@ type Config struct {
 	QueueConcurrency int `env:"QUEUE_CONCURRENCY" validate:"min=1" default:"128"`
 
 	ServiceName string `env:"SERVICE_NAME" validate:"required,min=1" default:"vigi:worker"`
+
+	// Metrics configuration
+	MetricsPort  string `env:"METRICS_PORT" validate:"omitempty,port" default:"9092"`
+	MetricsToken string `env:"METRICS_TOKEN"`
+
+	// Probe agent configuration. With a token the worker runs as a probe agent
+	// that pulls its checks from the API instead of consuming the queue
+	ProbeToken        string `env:"PROBE_TOKEN"`
+	ProbeAPIURL       string `env:"PROBE_API_URL" validate:"omitempty,url"`
+	ProbeSyncInterval int    `env:"PROBE_SYNC_INTERVAL" validate:"min=5" default:"30"`
+	// The token and the proxy credentials of the checks travel with every request,
+	// plain HTTP is only accepted when explicitly allowed, e.g. for local testing
+	ProbeAllowInsecure bool `env:"PROBE_ALLOW_INSECURE" default:"false"`
 }
 
 // LoadAndValidate loads and validates the Worker service configuration
@@ -51,6 +65,19 @@ func Validate(cfg *Config) error {
 		return err
 	}
 
+	if cfg.ProbeToken != "" {
+		if cfg.ProbeAPIURL == "" {
+			return fmt.Errorf("PROBE_API_URL is required when PROBE_TOKEN is set")
+		}
+		apiURL, err := url.Parse(cfg.ProbeAPIURL)
+		if err != nil {
+			return fmt.Errorf("PROBE_API_URL is invalid: %w", err)
+		}
+		if apiURL.Scheme != "https" && !cfg.ProbeAllowInsecure {
+			return fmt.Errorf("PROBE_API_URL must use https, set PROBE_ALLOW_INSECURE=true to allow %s", apiURL.Scheme)
+		}
+	}
+
 	// Additional queue validation
 	if cfg.QueueConcurrency < 1 {
 		return fmt.Errorf("QUEUE_CONCURRENCY must be at least 1")
@@ -72,5 +99,12 @@ func (c *Config) ToInternalConfig() *config.Config {
 		RedisDB:          c.RedisDB,
 		QueueConcurrency: c.QueueConcurrency,
 		ServiceName:      c.ServiceName,
+		MetricsPort:      c.MetricsPort,
+		MetricsToken:     c.MetricsToken,
 	}
 }
+
+// IsProbeAgent reports whether the worker runs as a probe agent
+func (c *Config) IsProbeAgent() bool {
+	return c.ProbeToken != ""
+}
@@ -7,12 +7,15 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 	"vigi/internal"
 	"vigi/internal/config"
 	"vigi/internal/infra"
 	"vigi/internal/modules/events"
 	"vigi/internal/modules/healthcheck"
+	"vigi/internal/modules/healthcheck/executor"
 	"vigi/internal/modules/heartbeat"
+	"vigi/internal/modules/metrics"
 	"vigi/internal/modules/worker"
 	"vigi/internal/version"
 
@@ -32,12 +35,17 @@ func main() {
 	// Set timezone
 	os.Setenv("TZ", cfg.Timezone)
 
-	// Create DI container
-	container := dig.New()
-
 	// Convert to internal config format for dependency injection
 	internalCfg := cfg.ToInternalConfig()
 
+	if cfg.IsProbeAgent() {
+		runProbeAgent(cfg, internalCfg)
+		return
+	}
+
+	// Create DI container
+	container := dig.New()
+
 	// Provide configuration
 	container.Provide(func() *config.Config { return internalCfg })
 
@@ -75,6 +83,9 @@ func main() {
 
 		logger.Info("Worker started successfully")
 
+		// Expose Prometheus metrics
+		metricsServer := metrics.StartServer(internalCfg.MetricsPort, internalCfg.MetricsToken, logger)
+
 		// Wait for termination signal
 		sigChan := make(chan os.Signal, 1)
 		signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
@@ -85,6 +96,12 @@ func main() {
 		logger.Info("Shutdown signal received, stopping worker...")
 		w.Stop()
 
+		if metricsServer != nil {
+			if err := metricsServer.Close(); err != nil {
+				logger.Errorw("Failed to stop metrics server", "error", err)
+			}
+		}
+
 		// Close event bus
 		if err := eventBus.Close(); err != nil {
 			logger.Errorw("Failed to close event bus", "error", err)
@@ -99,3 +116,56 @@ func main() {
 		log.Fatalf("Worker error: %v", err)
 	}
 }
+
+// runProbeAgent runs the checks assigned to a probe location. The agent talks
+// to the API over HTTPS only, so it needs neither Redis nor the database.
+func runProbeAgent(cfg *Config, internalCfg *config.Config) {
+	container := dig.New()
+
+	container.Provide(func() *config.Config { return internalCfg })
+	container.Provide(internal.ProvideLogger)
+	container.Provide(executor.NewMemoryStateStore)
+	container.Provide(executor.NewExecutorRegistry)
+
+	err := container.Invoke(func(
+		execRegistry *executor.ExecutorRegistry,
+		logger *zap.SugaredLogger,
+	) error {
+		agent := worker.NewAgent(worker.AgentConfig{
+			APIURL:       cfg.ProbeAPIURL,
+			Token:        cfg.ProbeToken,
+			SyncInterval: time.Duration(cfg.ProbeSyncInterval) * time.Second,
+		}, execRegistry, logger)
+
+		if err := agent.Start(context.Background()); err != nil {
+			return fmt.Errorf("failed to start probe agent: %w", err)
+		}
+
+		// Expose Prometheus metrics
+		metricsServer := metrics.StartServer(internalCfg.MetricsPort, internalCfg.MetricsToken, logger)
+
+		// Wait for termination signal
+		sigChan := make(chan os.Signal, 1)
+		signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+
+		logger.Info("Probe agent is running. Press Ctrl+C to stop.")
+		<-sigChan
+
+		logger.Info("Shutdown signal received, stopping probe agent...")
+		agent.Stop()
+
+		if metricsServer != nil {
+			if err := metricsServer.Close(); err != nil {
+				logger.Errorw("Failed to stop metrics server", "error", err)
+			}
+		}
+
+		logger.Info("Probe agent stopped gracefully")
+
+		return nil
+	})
+
+	if err != nil {
+		log.Fatalf("Probe agent error: %v", err)
+	}
+}
@@ -19,12 +19,22 @@ type DBConfig struct {
 	DBUser string `env:"DB_USER"`                           // validated in validateCustomRules
 	DBPass string `env:"DB_PASS"`                           // validated in validateCustomRules
 	DBType string `env:"DB_TYPE" validate:"required,db_type"`
+
+	// Master keys used by the secrets rotate command
+	EncryptionKey          string `env:"ENCRYPTION_KEY"`
+	EncryptionPreviousKeys string `env:"ENCRYPTION_PREVIOUS_KEYS"`
 }
 
 type Config struct {
 	Port      string `env:"SERVER_PORT" validate:"required,port" default:"8034"`
 	ClientURL string `env:"CLIENT_URL" validate:"url" default:"http://localhost:3000"`
 
+	// Comma separated IPs or CIDRs of the reverse proxies in front of the API
+	// Only requests coming from them may set the client IP with X-Forwarded-For or X-Real-IP
+	// When empty, the client IP is the address of the TCP connection
+	// Example: 10.0.0.0/8,172.16.0.0/12
+	TrustedProxies string `env:"TRUSTED_PROXIES"`
+
 	DBHost string `env:"DB_HOST"`                           // validated in validateCustomRules
 	DBPort string `env:"DB_PORT"`                           // validated in validateCustomRules
 	DBName string `env:"DB_NAME" validate:"required,min=1"` // validated in validateCustomRules
@@ -83,6 +93,62 @@ type Config struct {
 	// Usesend Configuration
 	UsesendAPIKey string `env:"USESEND_API_KEY"`
 	UsesendDomain string `env:"USESEND_DOMAIN"`
+
+	// Sender address of the emails sent to status page subscribers
+	StatusPageEmailFrom string `env:"STATUS_PAGE_EMAIL_FROM" default:"Vigi <status@vigi.run>"`
+
+	// Sender address of password reset and email verification emails
+	AuthEmailFrom string `env:"AUTH_EMAIL_FROM" default:"Vigi <no-reply@vigi.run>"`
+
+	// How long password reset and email verification links stay valid
+	PasswordResetTTL     time.Duration `env:"PASSWORD_RESET_TTL" default:"1h"`
+	EmailVerificationTTL time.Duration `env:"EMAIL_VERIFICATION_TTL" default:"48h"`
+
+	// Metrics configuration
+	// Port of the standalone Prometheus /metrics server used by producer, worker and ingester
+	// The API exposes /metrics on its own router instead, only when MetricsToken is set
+	MetricsPort string `env:"METRICS_PORT"`
+
+	// Bearer token required to scrape /metrics, optional on the standalone servers
+	MetricsToken string `env:"METRICS_TOKEN"`
+
+	// Encryption at rest configuration
+	// Base64 encoded 32 byte master key used to encrypt stored secrets
+	// Generate one with: openssl rand -base64 32
+	// When empty, secrets are stored in plain text
+	EncryptionKey string `env:"ENCRYPTION_KEY"`
+
+	// Comma separated master keys that were replaced by ENCRYPTION_KEY
+	// They are only used to decrypt values until `bun secrets rotate` ran
+	EncryptionPreviousKeys string `env:"ENCRYPTION_PREVIOUS_KEYS"`
+
+	// OpenID Connect single sign-on configuration
+	// SSO is enabled when OIDC_ISSUER_URL and OIDC_CLIENT_ID are set
+	// The issuer must expose /.well-known/openid-configuration
+	OIDCIssuerURL    string `env:"OIDC_ISSUER_URL" validate:"omitempty,url"`
+	OIDCClientID     string `env:"OIDC_CLIENT_ID"`
+	OIDCClientSecret string `env:"OIDC_CLIENT_SECRET"`
+
+	// Public URL of the callback endpoint registered at the IdP
+	// Example: https://vigi.example.com/api/v1/auth/oidc/callback
+	OIDCRedirectURL string `env:"OIDC_REDIRECT_URL" validate:"omitempty,url"`
+
+	// Comma separated scopes requested from the IdP
+	OIDCScopes string `env:"OIDC_SCOPES" default:"openid,email,profile"`
+
+	// Name of the ID token claim holding the user's groups
+	OIDCGroupsClaim string `env:"OIDC_GROUPS_CLAIM" default:"groups"`
+
+	// Comma separated group to organization role mappings
+	// Format: <group>=<organization slug>:<admin|member>
+	// Example: ops=acme:admin,developers=acme:member
+	OIDCRoleMapping string `env:"OIDC_ROLE_MAPPING"`
+
+	// Label of the SSO button on the login page
+	OIDCProviderName string `env:"OIDC_PROVIDER_NAME" default:"SSO"`
+
+	// Disable email/password login and registration, leaving SSO as the only way in
+	DisablePasswordLogin bool `env:"DISABLE_PASSWORD_LOGIN" default:"false"`
 }
 
 var validate = validator.New()
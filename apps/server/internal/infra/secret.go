@@ -0,0 +1,28 @@
+package infra
+
+import (
+	"fmt"
+	"strings"
+	"vigi/internal/config"
+	"vigi/internal/secret"
+
+	"go.uber.org/zap"
+)
+
+func ProvideKeyring(cfg *config.Config, logger *zap.SugaredLogger) (*secret.Keyring, error) {
+	var previous []string
+	if cfg.EncryptionPreviousKeys != "" {
+		previous = strings.Split(cfg.EncryptionPreviousKeys, ",")
+	}
+
+	keyring, err := secret.NewKeyring(cfg.EncryptionKey, previous)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load encryption keys: %w", err)
+	}
+
+	if !keyring.Enabled() {
+		logger.Warn("ENCRYPTION_KEY is not set, secrets are stored in plain text")
+	}
+
+	return keyring, nil
+}
@@ -21,11 +21,12 @@ func NewController(service Service) *Controller {
 
 // CreateAPIKey creates a new API key
 // @Summary Create API key
-// @Description Create a new API key
+// @Description Create a new API key bound to the current organization
 // @Tags api-keys
 // @Accept json
 // @Produce json
 // @Security JwtAuth
+// @Security OrgIdAuth
 // @Param request body CreateAPIKeyDto true "API key creation data"
 // @Success 201 {object} utils.ApiResponse[APIKeyWithTokenResponse]
 // @Failure 400 {object} utils.APIError
@@ -59,9 +60,22 @@ func (c *Controller) CreateAPIKey(ctx *gin.Context) {
 		return
 	}
 
+	if err := validateScopes(req.Scopes); err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.NewFailResponse(err.Error()))
+		return
+	}
+	if err := validateAllowedIPs(req.AllowedIPs); err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.NewFailResponse(err.Error()))
+		return
+	}
+
 	// Convert DTO to service request
 	serviceReq := &CreateRequest{
+		OrgID:         ctx.GetString("orgId"),
+		UserID:        ctx.GetString("userId"),
 		Name:          req.Name,
+		Scopes:        req.Scopes,
+		AllowedIPs:    req.AllowedIPs,
 		ExpiresAt:     req.ExpiresAt,
 		MaxUsageCount: req.MaxUsageCount,
 	}
@@ -77,17 +91,18 @@ func (c *Controller) CreateAPIKey(ctx *gin.Context) {
 
 // GetAPIKeys gets all API keys
 // @Summary Get API keys
-// @Description Get all API keys
+// @Description Get all API keys of the current organization
 // @Tags api-keys
 // @Produce json
 // @Security JwtAuth
+// @Security OrgIdAuth
 // @Success 200 {object} utils.ApiResponse[[]APIKeyResponse]
 // @Failure 500 {object} utils.APIError
 // @Router /api-keys [get]
 func (c *Controller) GetAPIKeys(ctx *gin.Context) {
 	// MARK: GetAPIKeys
 
-	apiKeys, err := c.service.FindAll(ctx)
+	apiKeys, err := c.service.FindAll(ctx, ctx.GetString("orgId"))
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse(err.Error()))
 		return
@@ -107,6 +122,7 @@ func (c *Controller) GetAPIKeys(ctx *gin.Context) {
 // @Tags api-keys
 // @Produce json
 // @Security JwtAuth
+// @Security OrgIdAuth
 // @Param id path string true "API key ID"
 // @Success 200 {object} utils.ApiResponse[APIKeyResponse]
 // @Failure 404 {object} utils.APIError
@@ -116,7 +132,7 @@ func (c *Controller) GetAPIKey(ctx *gin.Context) {
 	// MARK: GetAPIKey
 
 	id := ctx.Param("id")
-	apiKey, err := c.service.FindByID(ctx, id)
+	apiKey, err := c.service.FindByID(ctx, id, ctx.GetString("orgId"))
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse(err.Error()))
 		return
@@ -136,6 +152,7 @@ func (c *Controller) GetAPIKey(ctx *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security JwtAuth
+// @Security OrgIdAuth
 // @Param id path string true "API key ID"
 // @Param request body UpdateAPIKeyDto true "API key update data"
 // @Success 200 {object} utils.ApiResponse[APIKeyResponse]
@@ -172,14 +189,30 @@ func (c *Controller) UpdateAPIKey(ctx *gin.Context) {
 		return
 	}
 
+	// Validate scopes and allowlist if provided
+	if req.Scopes != nil {
+		if err := validateScopes(*req.Scopes); err != nil {
+			ctx.JSON(http.StatusBadRequest, utils.NewFailResponse(err.Error()))
+			return
+		}
+	}
+	if req.AllowedIPs != nil {
+		if err := validateAllowedIPs(*req.AllowedIPs); err != nil {
+			ctx.JSON(http.StatusBadRequest, utils.NewFailResponse(err.Error()))
+			return
+		}
+	}
+
 	// Convert DTO to service request
 	serviceReq := &UpdateRequest{
 		Name:          req.Name,
+		Scopes:        req.Scopes,
+		AllowedIPs:    req.AllowedIPs,
 		ExpiresAt:     req.ExpiresAt,
 		MaxUsageCount: req.MaxUsageCount,
 	}
 
-	apiKey, err := c.service.Update(ctx, id, serviceReq)
+	apiKey, err := c.service.Update(ctx, id, ctx.GetString("orgId"), serviceReq)
 	if err != nil {
 		if err.Error() == "API key not found" {
 			ctx.JSON(http.StatusNotFound, utils.NewFailResponse("API key not found"))
@@ -197,6 +230,7 @@ func (c *Controller) UpdateAPIKey(ctx *gin.Context) {
 // @Description Delete an API key
 // @Tags api-keys
 // @Security JwtAuth
+// @Security OrgIdAuth
 // @Param id path string true "API key ID"
 // @Success 204 "No Content"
 // @Failure 404 {object} utils.APIError
@@ -206,7 +240,7 @@ func (c *Controller) DeleteAPIKey(ctx *gin.Context) {
 	// MARK: DeleteAPIKey
 
 	id := ctx.Param("id")
-	err := c.service.Delete(ctx, id)
+	err := c.service.Delete(ctx, id, ctx.GetString("orgId"))
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse(err.Error()))
 		return
@@ -217,7 +251,7 @@ func (c *Controller) DeleteAPIKey(ctx *gin.Context) {
 
 // GetAPIKeyConfig gets API key configuration
 // @Summary Get API key configuration
-// @Description Get API key configuration including prefix
+// @Description Get API key configuration including prefix and available scopes
 // @Tags api-keys
 // @Produce json
 // @Success 200 {object} utils.ApiResponse[APIKeyConfigResponse]
@@ -225,8 +259,14 @@ func (c *Controller) DeleteAPIKey(ctx *gin.Context) {
 func (c *Controller) GetAPIKeyConfig(ctx *gin.Context) {
 	// MARK: GetAPIKeyConfig
 
+	scopes := make([]string, len(AllScopes))
+	for i, scope := range AllScopes {
+		scopes[i] = string(scope)
+	}
+
 	config := &APIKeyConfigResponse{
 		Prefix: ApiKeyPrefix,
+		Scopes: scopes,
 	}
 	ctx.JSON(http.StatusOK, utils.NewSuccessResponse("API key configuration retrieved successfully", config))
 }
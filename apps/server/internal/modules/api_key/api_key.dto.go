@@ -8,6 +8,8 @@ import (
 // swagger:model
 type CreateAPIKeyDto struct {
 	Name          string     `json:"name" validate:"required,min=1,max=255"`
+	Scopes        []string   `json:"scopes" validate:"required,min=1"`
+	AllowedIPs    []string   `json:"allowed_ips,omitempty" validate:"omitempty"`
 	ExpiresAt     *time.Time `json:"expires_at,omitempty" validate:"omitempty"`
 	MaxUsageCount *int64     `json:"max_usage_count,omitempty" validate:"omitempty,min=1"`
 }
@@ -16,6 +18,8 @@ type CreateAPIKeyDto struct {
 // swagger:model
 type UpdateAPIKeyDto struct {
 	Name          *string     `json:"name,omitempty" validate:"omitempty,min=1,max=255"`
+	Scopes        *[]string   `json:"scopes,omitempty" validate:"omitempty,min=1"`
+	AllowedIPs    *[]string   `json:"allowed_ips,omitempty" validate:"omitempty"`
 	ExpiresAt     *time.Time  `json:"expires_at,omitempty" validate:"omitempty"`
 	MaxUsageCount *int64      `json:"max_usage_count,omitempty" validate:"omitempty,min=1"`
 }
@@ -26,7 +30,13 @@ type APIKeyResponse struct {
 	ID            string     `json:"id" validate:"required"`
 	Name          string     `json:"name" validate:"required"`
 	DisplayKey    string     `json:"display_key" validate:"required"` // Masked key for display
+	OrgID         string     `json:"org_id" validate:"required"`
+	UserID        string     `json:"user_id" validate:"required"`
+	Scopes        []string   `json:"scopes" validate:"required"`
+	AllowedIPs    []string   `json:"allowed_ips" validate:"required"`
 	LastUsed      *time.Time `json:"last_used"`
+	LastUsedIP    *string    `json:"last_used_ip"`
+	LastUsedRoute *string    `json:"last_used_route"`
 	ExpiresAt     *time.Time `json:"expires_at"`
 	UsageCount    int64      `json:"usage_count" validate:"required"`
 	MaxUsageCount *int64     `json:"max_usage_count"`
@@ -37,7 +47,8 @@ type APIKeyResponse struct {
 // APIKeyConfigResponse represents the API key configuration
 // swagger:model
 type APIKeyConfigResponse struct {
-	Prefix string `json:"prefix" validate:"required"`
+	Prefix string   `json:"prefix" validate:"required"`
+	Scopes []string `json:"scopes" validate:"required"`
 }
 
 // APIKeyWithTokenResponse represents the response when creating an API key (includes the token)
@@ -53,7 +64,13 @@ func (m *Model) ToAPIKeyResponse() *APIKeyResponse {
 		ID:            m.ID,
 		Name:          m.Name,
 		DisplayKey:    m.DisplayKey,
+		OrgID:         m.OrgID,
+		UserID:        m.UserID,
+		Scopes:        m.Scopes,
+		AllowedIPs:    m.AllowedIPs,
 		LastUsed:      m.LastUsed,
+		LastUsedIP:    m.LastUsedIP,
+		LastUsedRoute: m.LastUsedRoute,
 		ExpiresAt:     m.ExpiresAt,
 		UsageCount:    m.UsageCount,
 		MaxUsageCount: m.MaxUsageCount,
@@ -27,10 +27,16 @@ func setupTestDB(t *testing.T) *bun.DB {
 	_, err = db.Exec(`
 		CREATE TABLE api_keys (
 			id TEXT PRIMARY KEY,
+			org_id TEXT,
+			user_id TEXT,
 			name TEXT NOT NULL,
 			key_hash TEXT NOT NULL,
 			display_key TEXT NOT NULL,
+			scopes TEXT NOT NULL DEFAULT '',
+			allowed_ips TEXT NOT NULL DEFAULT '',
 			last_used DATETIME,
+			last_used_ip TEXT,
+			last_used_route TEXT,
 			expires_at DATETIME,
 			usage_count INTEGER NOT NULL DEFAULT 0,
 			max_usage_count INTEGER,
@@ -57,6 +63,9 @@ func TestIntegration_CreateAndValidateAPIKey(t *testing.T) {
 
 	// Create API key
 	req := &CreateRequest{
+		OrgID:         testOrgID,
+		UserID:        testUserID,
+		Scopes:        testScopes,
 		Name:          "Integration Test Key",
 		ExpiresAt:     nil,
 		MaxUsageCount: nil,
@@ -71,13 +80,13 @@ func TestIntegration_CreateAndValidateAPIKey(t *testing.T) {
 	assert.True(t, len(result.DisplayKey) > 0)
 
 	// Validate the created key
-	validated, err := service.ValidateKey(ctx, result.Token)
+	validated, err := service.ValidateKey(ctx, result.Token, testUsage)
 	require.NoError(t, err)
 	assert.Equal(t, result.ID, validated.ID)
 	assert.Equal(t, result.Name, validated.Name)
 
 	// Fetch updated values from database to verify usage count was incremented
-	updatedKey, err := service.FindByID(ctx, result.ID)
+	updatedKey, err := service.FindByID(ctx, result.ID, testOrgID)
 	require.NoError(t, err)
 	assert.Equal(t, int64(1), updatedKey.UsageCount)
 	assert.NotNil(t, updatedKey.LastUsed)
@@ -94,6 +103,9 @@ func TestIntegration_ExpiredKeyValidation(t *testing.T) {
 	// Create API key with expiration in the past
 	expiredTime := time.Now().Add(-1 * time.Hour)
 	req := &CreateRequest{
+		OrgID:         testOrgID,
+		UserID:        testUserID,
+		Scopes:        testScopes,
 		Name:          "Expired Test Key",
 		ExpiresAt:     &expiredTime,
 		MaxUsageCount: nil,
@@ -103,7 +115,7 @@ func TestIntegration_ExpiredKeyValidation(t *testing.T) {
 	require.NoError(t, err)
 
 	// Try to validate expired key
-	validated, err := service.ValidateKey(ctx, result.Token)
+	validated, err := service.ValidateKey(ctx, result.Token, testUsage)
 	assert.Error(t, err)
 	assert.Nil(t, validated)
 	assert.Contains(t, err.Error(), "expired")
@@ -120,6 +132,9 @@ func TestIntegration_UsageLimitEnforcement(t *testing.T) {
 	// Create API key with usage limit
 	maxUsage := int64(2)
 	req := &CreateRequest{
+		OrgID:         testOrgID,
+		UserID:        testUserID,
+		Scopes:        testScopes,
 		Name:          "Limited Test Key",
 		ExpiresAt:     nil,
 		MaxUsageCount: &maxUsage,
@@ -129,25 +144,25 @@ func TestIntegration_UsageLimitEnforcement(t *testing.T) {
 	require.NoError(t, err)
 
 	// First validation should succeed
-	_, err = service.ValidateKey(ctx, result.Token)
+	_, err = service.ValidateKey(ctx, result.Token, testUsage)
 	require.NoError(t, err)
 
 	// Fetch updated values from database
-	updatedKey, err := service.FindByID(ctx, result.ID)
+	updatedKey, err := service.FindByID(ctx, result.ID, testOrgID)
 	require.NoError(t, err)
 	assert.Equal(t, int64(1), updatedKey.UsageCount)
 
 	// Second validation should succeed
-	_, err = service.ValidateKey(ctx, result.Token)
+	_, err = service.ValidateKey(ctx, result.Token, testUsage)
 	require.NoError(t, err)
 
 	// Fetch updated values from database
-	updatedKey, err = service.FindByID(ctx, result.ID)
+	updatedKey, err = service.FindByID(ctx, result.ID, testOrgID)
 	require.NoError(t, err)
 	assert.Equal(t, int64(2), updatedKey.UsageCount)
 
 	// Third validation should fail (exceeded limit)
-	validated, err := service.ValidateKey(ctx, result.Token)
+	validated, err := service.ValidateKey(ctx, result.Token, testUsage)
 	assert.Error(t, err)
 	assert.Nil(t, validated)
 	assert.Contains(t, err.Error(), "usage limit exceeded")
@@ -165,6 +180,9 @@ func TestIntegration_MiddlewareWithRealDB(t *testing.T) {
 
 	// Create a real API key
 	req := &CreateRequest{
+		OrgID:         testOrgID,
+		UserID:        testUserID,
+		Scopes:        testScopes,
 		Name:          "Middleware Test Key",
 		ExpiresAt:     nil,
 		MaxUsageCount: nil,
@@ -177,9 +195,10 @@ func TestIntegration_MiddlewareWithRealDB(t *testing.T) {
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 	c.Request = httptest.NewRequest("GET", "/protected", nil)
+	c.Request.RemoteAddr = "203.0.113.10:4321"
 	c.Request.Header.Set("X-API-Key", result.Token)
 
-	middleware.Auth()(c)
+	middleware.Auth(ScopeMonitorsRead)(c)
 
 	// Verify middleware succeeded
 	assert.Equal(t, http.StatusOK, w.Code)
@@ -195,10 +214,14 @@ func TestIntegration_MiddlewareWithRealDB(t *testing.T) {
 	assert.Equal(t, "api_key", authType)
 
 	// Verify usage count was incremented in database
-	updatedKey, err := service.FindByID(ctx, result.ID)
+	updatedKey, err := service.FindByID(ctx, result.ID, testOrgID)
 	require.NoError(t, err)
 	assert.Equal(t, int64(1), updatedKey.UsageCount)
 	assert.NotNil(t, updatedKey.LastUsed)
+	require.NotNil(t, updatedKey.LastUsedIP)
+	assert.Equal(t, "203.0.113.10", *updatedKey.LastUsedIP)
+	require.NotNil(t, updatedKey.LastUsedRoute)
+	assert.Equal(t, "GET /protected", *updatedKey.LastUsedRoute)
 }
 
 func TestIntegration_UpdateLastUsed(t *testing.T) {
@@ -211,6 +234,9 @@ func TestIntegration_UpdateLastUsed(t *testing.T) {
 
 	// Create API key
 	req := &CreateRequest{
+		OrgID:         testOrgID,
+		UserID:        testUserID,
+		Scopes:        testScopes,
 		Name:          "Usage Test Key",
 		ExpiresAt:     nil,
 		MaxUsageCount: nil,
@@ -220,17 +246,17 @@ func TestIntegration_UpdateLastUsed(t *testing.T) {
 	require.NoError(t, err)
 
 	// Verify initial state
-	initialKey, err := service.FindByID(ctx, result.ID)
+	initialKey, err := service.FindByID(ctx, result.ID, testOrgID)
 	require.NoError(t, err)
 	assert.Equal(t, int64(0), initialKey.UsageCount)
 	assert.Nil(t, initialKey.LastUsed)
 
 	// Validate key (should update usage)
-	_, err = service.ValidateKey(ctx, result.Token)
+	_, err = service.ValidateKey(ctx, result.Token, testUsage)
 	require.NoError(t, err)
 
 	// Verify usage was updated by fetching from database
-	dbKey, err := service.FindByID(ctx, result.ID)
+	dbKey, err := service.FindByID(ctx, result.ID, testOrgID)
 	require.NoError(t, err)
 	assert.Equal(t, int64(1), dbKey.UsageCount)
 	assert.NotNil(t, dbKey.LastUsed)
@@ -248,6 +274,9 @@ func TestIntegration_CreateWithExpiration(t *testing.T) {
 	// Create API key with future expiration
 	futureTime := time.Now().Add(1 * time.Hour)
 	req := &CreateRequest{
+		OrgID:         testOrgID,
+		UserID:        testUserID,
+		Scopes:        testScopes,
 		Name:          "Future Expiry Key",
 		ExpiresAt:     &futureTime,
 		MaxUsageCount: nil,
@@ -257,7 +286,7 @@ func TestIntegration_CreateWithExpiration(t *testing.T) {
 	require.NoError(t, err)
 
 	// Should be able to validate before expiration
-	validated, err := service.ValidateKey(ctx, result.Token)
+	validated, err := service.ValidateKey(ctx, result.Token, testUsage)
 	require.NoError(t, err)
 	assert.Equal(t, result.ID, validated.ID)
 	assert.NotNil(t, validated.ExpiresAt)
@@ -275,6 +304,9 @@ func TestIntegration_CreateWithUsageLimit(t *testing.T) {
 	// Create API key with usage limit
 	maxUsage := int64(5)
 	req := &CreateRequest{
+		OrgID:         testOrgID,
+		UserID:        testUserID,
+		Scopes:        testScopes,
 		Name:          "Usage Limited Key",
 		ExpiresAt:     nil,
 		MaxUsageCount: &maxUsage,
@@ -284,7 +316,7 @@ func TestIntegration_CreateWithUsageLimit(t *testing.T) {
 	require.NoError(t, err)
 
 	// Verify the key was created with usage limit
-	createdKey, err := service.FindByID(ctx, result.ID)
+	createdKey, err := service.FindByID(ctx, result.ID, testOrgID)
 	require.NoError(t, err)
 	assert.Equal(t, maxUsage, *createdKey.MaxUsageCount)
 	assert.Equal(t, int64(0), createdKey.UsageCount)
@@ -300,9 +332,9 @@ func TestIntegration_FindAllWithRealData(t *testing.T) {
 
 	// Create multiple API keys
 	keys := []*CreateRequest{
-		{Name: "Key 1", ExpiresAt: nil, MaxUsageCount: nil},
-		{Name: "Key 2", ExpiresAt: nil, MaxUsageCount: nil},
-		{Name: "Key 3", ExpiresAt: nil, MaxUsageCount: nil},
+		{OrgID: testOrgID, UserID: testUserID, Scopes: testScopes, Name: "Key 1", ExpiresAt: nil, MaxUsageCount: nil},
+		{OrgID: testOrgID, UserID: testUserID, Scopes: testScopes, Name: "Key 2", ExpiresAt: nil, MaxUsageCount: nil},
+		{OrgID: testOrgID, UserID: testUserID, Scopes: testScopes, Name: "Key 3", ExpiresAt: nil, MaxUsageCount: nil},
 	}
 
 	var createdKeys []*APIKeyWithToken
@@ -313,7 +345,7 @@ func TestIntegration_FindAllWithRealData(t *testing.T) {
 	}
 
 	// Find all keys
-	allKeys, err := service.FindAll(ctx)
+	allKeys, err := service.FindAll(ctx, testOrgID)
 	require.NoError(t, err)
 
 	// Verify all keys were found
@@ -7,10 +7,16 @@ import (
 // Model represents an API key in the domain
 type Model struct {
 	ID             string     `json:"id"`
+	OrgID          string     `json:"org_id"`
+	UserID         string     `json:"user_id"` // Member who created the key, its access never exceeds theirs
 	Name           string     `json:"name"`
 	KeyHash        string     `json:"-"` // Never expose the hash
 	DisplayKey     string     `json:"display_key"` // Masked key for display (e.g. "pk_1234...5678")
+	Scopes         []string   `json:"scopes"`
+	AllowedIPs     []string   `json:"allowed_ips"` // IPs or CIDR ranges, empty allows any address
 	LastUsed       *time.Time `json:"last_used"`
+	LastUsedIP     *string    `json:"last_used_ip"`
+	LastUsedRoute  *string    `json:"last_used_route"`
 	ExpiresAt      *time.Time `json:"expires_at"`
 	UsageCount     int64      `json:"usage_count"`
 	MaxUsageCount  *int64     `json:"max_usage_count"`
@@ -20,9 +26,13 @@ type Model struct {
 
 // CreateModel represents data needed to create an API key
 type CreateModel struct {
+	OrgID          string     `json:"org_id"`
+	UserID         string     `json:"user_id"`
 	Name           string     `json:"name"`
 	KeyHash        string     `json:"-"` // Can be empty on initial create
 	DisplayKey     string     `json:"-"` // Can be empty on initial create
+	Scopes         []string   `json:"scopes"`
+	AllowedIPs     []string   `json:"allowed_ips,omitempty"`
 	ExpiresAt      *time.Time `json:"expires_at,omitempty"`
 	MaxUsageCount  *int64     `json:"max_usage_count,omitempty"`
 }
@@ -30,14 +40,21 @@ type CreateModel struct {
 // UpdateModel represents data that can be updated for an API key
 type UpdateModel struct {
 	Name          *string    `json:"name,omitempty"`
+	Scopes        *[]string  `json:"scopes,omitempty"`
+	AllowedIPs    *[]string  `json:"allowed_ips,omitempty"`
 	ExpiresAt     *time.Time `json:"expires_at,omitempty"`
 	MaxUsageCount *int64     `json:"max_usage_count,omitempty"`
 }
 
+// Usage describes the request an API key was used for
+type Usage struct {
+	IP    string
+	Route string
+}
+
 
 // APIKeyWithToken represents an API key with its plain text token (only returned on creation)
 type APIKeyWithToken struct {
 	Model
 	Token string `json:"token"` // Only present when creating a new key
 }
-
@@ -14,10 +14,16 @@ import (
 
 type mongoModel struct {
 	ID            primitive.ObjectID `bson:"_id"`
+	OrgID         string             `bson:"org_id"`
+	UserID        string             `bson:"user_id"`
 	Name          string             `bson:"name"`
 	KeyHash       string             `bson:"key_hash"`
 	DisplayKey    string             `bson:"display_key"`
+	Scopes        []string           `bson:"scopes"`
+	AllowedIPs    []string           `bson:"allowed_ips"`
 	LastUsed      *time.Time         `bson:"last_used"`
+	LastUsedIP    *string            `bson:"last_used_ip"`
+	LastUsedRoute *string            `bson:"last_used_route"`
 	ExpiresAt     *time.Time         `bson:"expires_at"`
 	UsageCount    int64              `bson:"usage_count"`
 	MaxUsageCount *int64             `bson:"max_usage_count"`
@@ -27,18 +33,35 @@ type mongoModel struct {
 
 type mongoUpdateModel struct {
 	Name          *string    `bson:"name,omitempty"`
+	Scopes        *[]string  `bson:"scopes,omitempty"`
+	AllowedIPs    *[]string  `bson:"allowed_ips,omitempty"`
 	ExpiresAt     *time.Time `bson:"expires_at,omitempty"`
 	MaxUsageCount *int64     `bson:"max_usage_count,omitempty"`
 	UpdatedAt     *time.Time `bson:"updatedAt,omitempty"`
 }
 
 func toDomainModel(mm *mongoModel) *Model {
+	scopes := mm.Scopes
+	if scopes == nil {
+		scopes = []string{}
+	}
+	allowedIPs := mm.AllowedIPs
+	if allowedIPs == nil {
+		allowedIPs = []string{}
+	}
+
 	return &Model{
 		ID:            mm.ID.Hex(),
+		OrgID:         mm.OrgID,
+		UserID:        mm.UserID,
 		Name:          mm.Name,
 		KeyHash:       mm.KeyHash,
 		DisplayKey:    mm.DisplayKey,
+		Scopes:        scopes,
+		AllowedIPs:    allowedIPs,
 		LastUsed:      mm.LastUsed,
+		LastUsedIP:    mm.LastUsedIP,
+		LastUsedRoute: mm.LastUsedRoute,
 		ExpiresAt:     mm.ExpiresAt,
 		UsageCount:    mm.UsageCount,
 		MaxUsageCount: mm.MaxUsageCount,
@@ -67,9 +90,13 @@ func (r *RepositoryImpl) Create(ctx context.Context, apiKey *CreateModel) (*APIK
 
 	mm := &mongoModel{
 		ID:            apiKeyID,
+		OrgID:         apiKey.OrgID,
+		UserID:        apiKey.UserID,
 		Name:          apiKey.Name,
 		KeyHash:       apiKey.KeyHash,
 		DisplayKey:    apiKey.DisplayKey,
+		Scopes:        apiKey.Scopes,
+		AllowedIPs:    apiKey.AllowedIPs,
 		LastUsed:      nil,
 		ExpiresAt:     apiKey.ExpiresAt,
 		UsageCount:    0,
@@ -109,9 +136,9 @@ func (r *RepositoryImpl) FindByID(ctx context.Context, id string) (*Model, error
 }
 
 // MARK: FindAll
-func (r *RepositoryImpl) FindAll(ctx context.Context) ([]*Model, error) {
+func (r *RepositoryImpl) FindAll(ctx context.Context, orgID string) ([]*Model, error) {
 	opts := options.Find().SetSort(bson.M{"createdAt": -1})
-	cursor, err := r.collection.Find(ctx, bson.M{}, opts)
+	cursor, err := r.collection.Find(ctx, bson.M{"org_id": orgID}, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -143,6 +170,12 @@ func (r *RepositoryImpl) Update(ctx context.Context, id string, update *UpdateMo
 	if update.Name != nil {
 		updateDoc.Name = update.Name
 	}
+	if update.Scopes != nil {
+		updateDoc.Scopes = update.Scopes
+	}
+	if update.AllowedIPs != nil {
+		updateDoc.AllowedIPs = update.AllowedIPs
+	}
 	if update.ExpiresAt != nil {
 		updateDoc.ExpiresAt = update.ExpiresAt
 	}
@@ -180,7 +213,7 @@ func (r *RepositoryImpl) Delete(ctx context.Context, id string) error {
 }
 
 // MARK: UpdateLastUsed
-func (r *RepositoryImpl) UpdateLastUsed(ctx context.Context, id string) error {
+func (r *RepositoryImpl) UpdateLastUsed(ctx context.Context, id string, usage *Usage) error {
 	objectID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
 		return err
@@ -190,7 +223,11 @@ func (r *RepositoryImpl) UpdateLastUsed(ctx context.Context, id string) error {
 		ctx,
 		bson.M{"_id": objectID},
 		bson.M{
-			"$set": bson.M{"last_used": time.Now()},
+			"$set": bson.M{
+				"last_used":       time.Now(),
+				"last_used_ip":    usage.IP,
+				"last_used_route": usage.Route,
+			},
 			"$inc": bson.M{"usage_count": 1},
 		},
 	)
@@ -6,6 +6,13 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+// OrganizationMiddleware is implemented by organization.Middleware, which
+// cannot be imported here without an import cycle
+type OrganizationMiddleware interface {
+	RequireOrganization() gin.HandlerFunc
+	RequireAdmin() gin.HandlerFunc
+}
+
 type Route struct {
 	controller *Controller
 	middleware *auth.MiddlewareProvider
@@ -21,19 +28,21 @@ func NewRoute(
 	}
 }
 
-func (r *Route) ConnectRoute(router *gin.RouterGroup, controller *Controller) {
+func (r *Route) ConnectRoute(router *gin.RouterGroup, controller *Controller, orgMiddleware OrganizationMiddleware) {
 	apiKeys := router.Group("api-keys")
 
 	// Config endpoint doesn't require authentication
 	apiKeys.GET("config", controller.GetAPIKeyConfig)
 
 	// All other API key management endpoints require JWT authentication
+	// and manage the keys of the current organization, admins only for changes
 	apiKeys.Use(r.middleware.Auth())
+	apiKeys.Use(orgMiddleware.RequireOrganization())
 	{
-		apiKeys.POST("", controller.CreateAPIKey)
 		apiKeys.GET("", controller.GetAPIKeys)
 		apiKeys.GET(":id", controller.GetAPIKey)
-		apiKeys.PUT(":id", controller.UpdateAPIKey)
-		apiKeys.DELETE(":id", controller.DeleteAPIKey)
+		apiKeys.POST("", orgMiddleware.RequireAdmin(), controller.CreateAPIKey)
+		apiKeys.PUT(":id", orgMiddleware.RequireAdmin(), controller.UpdateAPIKey)
+		apiKeys.DELETE(":id", orgMiddleware.RequireAdmin(), controller.DeleteAPIKey)
 	}
 }
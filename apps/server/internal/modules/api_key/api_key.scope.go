@@ -0,0 +1,124 @@
+package api_key
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// Scope is a permission granted to an API key. Scopes ending in ":read" only
+// cover safe methods (GET, HEAD), every other scope covers all methods, so a
+// ":write" scope also grants read access.
+type Scope string
+
+const (
+	ScopeMonitorsRead    Scope = "monitors:read"
+	ScopeMonitorsWrite   Scope = "monitors:write"
+	ScopePush            Scope = "push"
+	ScopeInvoicesRead    Scope = "invoices:read"
+	ScopeInvoicesWrite   Scope = "invoices:write"
+	ScopeStatusPagesRead Scope = "status_pages:read"
+)
+
+// AllScopes lists every scope a key can be given
+var AllScopes = []Scope{
+	ScopeMonitorsRead,
+	ScopeMonitorsWrite,
+	ScopePush,
+	ScopeInvoicesRead,
+	ScopeInvoicesWrite,
+	ScopeStatusPagesRead,
+}
+
+func isValidScope(scope string) bool {
+	for _, s := range AllScopes {
+		if string(s) == scope {
+			return true
+		}
+	}
+	return false
+}
+
+func (s Scope) coversMethod(method string) bool {
+	if strings.HasSuffix(string(s), ":read") {
+		return method == http.MethodGet || method == http.MethodHead
+	}
+	return true
+}
+
+// HasScope reports whether the key was given scope
+func (m *Model) HasScope(scope Scope) bool {
+	for _, s := range m.Scopes {
+		if s == string(scope) {
+			return true
+		}
+	}
+	return false
+}
+
+// Allows reports whether the key may call a route accepting any of scopes
+// with the given HTTP method. A route without scopes is closed to API keys.
+func (m *Model) Allows(method string, scopes []Scope) bool {
+	for _, scope := range scopes {
+		if m.HasScope(scope) && scope.coversMethod(method) {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsIP reports whether ip matches the key's allowlist. An empty
+// allowlist accepts every address.
+func (m *Model) AllowsIP(ip string) bool {
+	if len(m.AllowedIPs) == 0 {
+		return true
+	}
+
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		return false
+	}
+
+	for _, entry := range m.AllowedIPs {
+		if strings.Contains(entry, "/") {
+			if _, network, err := net.ParseCIDR(entry); err == nil && network.Contains(addr) {
+				return true
+			}
+			continue
+		}
+		if allowed := net.ParseIP(entry); allowed != nil && allowed.Equal(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// validateScopes checks that scopes is a non empty list of known scopes
+func validateScopes(scopes []string) error {
+	if len(scopes) == 0 {
+		return fmt.Errorf("at least one scope is required")
+	}
+	for _, scope := range scopes {
+		if !isValidScope(scope) {
+			return fmt.Errorf("unknown scope %q", scope)
+		}
+	}
+	return nil
+}
+
+// validateAllowedIPs checks that every entry is an IP address or a CIDR range
+func validateAllowedIPs(entries []string) error {
+	for _, entry := range entries {
+		if strings.Contains(entry, "/") {
+			if _, _, err := net.ParseCIDR(entry); err != nil {
+				return fmt.Errorf("invalid CIDR range %q", entry)
+			}
+			continue
+		}
+		if net.ParseIP(entry) == nil {
+			return fmt.Errorf("invalid IP address %q", entry)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package api_key
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestModel_Allows(t *testing.T) {
+	tests := []struct {
+		name     string
+		granted  []string
+		method   string
+		required []Scope
+		expected bool
+	}{
+		{name: "read scope allows GET", granted: []string{"monitors:read"}, method: "GET", required: []Scope{ScopeMonitorsRead, ScopeMonitorsWrite}, expected: true},
+		{name: "read scope allows HEAD", granted: []string{"monitors:read"}, method: "HEAD", required: []Scope{ScopeMonitorsRead}, expected: true},
+		{name: "read scope denies PUT", granted: []string{"monitors:read"}, method: "PUT", required: []Scope{ScopeMonitorsRead, ScopeMonitorsWrite}, expected: false},
+		{name: "write scope allows GET", granted: []string{"invoices:write"}, method: "GET", required: []Scope{ScopeInvoicesRead, ScopeInvoicesWrite}, expected: true},
+		{name: "write scope allows PATCH", granted: []string{"invoices:write"}, method: "PATCH", required: []Scope{ScopeInvoicesRead, ScopeInvoicesWrite}, expected: true},
+		{name: "push scope allows POST", granted: []string{"push"}, method: "POST", required: []Scope{ScopePush}, expected: true},
+		{name: "other resource", granted: []string{"monitors:write"}, method: "GET", required: []Scope{ScopeStatusPagesRead}, expected: false},
+		{name: "no required scopes", granted: []string{"monitors:write"}, method: "GET", required: nil, expected: false},
+		{name: "no granted scopes", granted: nil, method: "GET", required: []Scope{ScopeMonitorsRead}, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &Model{Scopes: tt.granted}
+			assert.Equal(t, tt.expected, m.Allows(tt.method, tt.required))
+		})
+	}
+}
+
+func TestModel_AllowsIP(t *testing.T) {
+	tests := []struct {
+		name       string
+		allowedIPs []string
+		ip         string
+		expected   bool
+	}{
+		{name: "empty allowlist", allowedIPs: nil, ip: "203.0.113.10", expected: true},
+		{name: "exact IPv4", allowedIPs: []string{"203.0.113.10"}, ip: "203.0.113.10", expected: true},
+		{name: "other IPv4", allowedIPs: []string{"203.0.113.10"}, ip: "203.0.113.11", expected: false},
+		{name: "IPv4 range", allowedIPs: []string{"10.0.0.0/8"}, ip: "10.20.30.40", expected: true},
+		{name: "outside range", allowedIPs: []string{"10.0.0.0/8"}, ip: "11.0.0.1", expected: false},
+		{name: "IPv6 range", allowedIPs: []string{"2001:db8::/32"}, ip: "2001:db8::42", expected: true},
+		{name: "unparsable client IP", allowedIPs: []string{"10.0.0.0/8"}, ip: "", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &Model{AllowedIPs: tt.allowedIPs}
+			assert.Equal(t, tt.expected, m.AllowsIP(tt.ip))
+		})
+	}
+}
+
+func TestValidateScopes(t *testing.T) {
+	assert.NoError(t, validateScopes([]string{"monitors:read", "push"}))
+	assert.Error(t, validateScopes(nil))
+	assert.Error(t, validateScopes([]string{"monitors:delete"}))
+}
+
+func TestValidateAllowedIPs(t *testing.T) {
+	assert.NoError(t, validateAllowedIPs(nil))
+	assert.NoError(t, validateAllowedIPs([]string{"192.0.2.1", "10.0.0.0/8", "2001:db8::/32"}))
+	assert.Error(t, validateAllowedIPs([]string{"10.0.0.0/33"}))
+	assert.Error(t, validateAllowedIPs([]string{"example.com"}))
+}
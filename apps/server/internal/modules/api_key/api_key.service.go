@@ -16,16 +16,20 @@ import (
 // Service defines the interface for API key business logic
 type Service interface {
 	Create(ctx context.Context, req *CreateRequest) (*APIKeyWithToken, error)
-	FindAll(ctx context.Context) ([]*Model, error)
-	FindByID(ctx context.Context, id string) (*Model, error)
-	Update(ctx context.Context, id string, req *UpdateRequest) (*Model, error)
-	Delete(ctx context.Context, id string) error
-	ValidateKey(ctx context.Context, key string) (*Model, error)
+	FindAll(ctx context.Context, orgID string) ([]*Model, error)
+	FindByID(ctx context.Context, id string, orgID string) (*Model, error)
+	Update(ctx context.Context, id string, orgID string, req *UpdateRequest) (*Model, error)
+	Delete(ctx context.Context, id string, orgID string) error
+	ValidateKey(ctx context.Context, key string, usage *Usage) (*Model, error)
 }
 
 // CreateRequest represents the request to create an API key
 type CreateRequest struct {
+	OrgID         string
+	UserID        string
 	Name          string
+	Scopes        []string
+	AllowedIPs    []string
 	ExpiresAt     *time.Time
 	MaxUsageCount *int64
 }
@@ -33,10 +37,15 @@ type CreateRequest struct {
 // UpdateRequest represents the request to update an API key
 type UpdateRequest struct {
 	Name          *string
+	Scopes        *[]string
+	AllowedIPs    *[]string
 	ExpiresAt     *time.Time
 	MaxUsageCount *int64
 }
 
+// ErrIPNotAllowed is returned for valid keys used outside their IP allowlist
+var ErrIPNotAllowed = errors.New("API key is not allowed from this IP address")
+
 type ServiceImpl struct {
 	repo   Repository
 	logger *zap.SugaredLogger
@@ -52,13 +61,27 @@ func NewService(repo Repository, logger *zap.SugaredLogger) Service {
 
 // MARK: Create
 func (s *ServiceImpl) Create(ctx context.Context, req *CreateRequest) (*APIKeyWithToken, error) {
-	s.logger.Infow("Creating API key", "name", req.Name)
+	s.logger.Infow("Creating API key", "name", req.Name, "orgId", req.OrgID, "scopes", req.Scopes)
+
+	if req.OrgID == "" || req.UserID == "" {
+		return nil, errors.New("API key must belong to an organization and a user")
+	}
+	if err := validateScopes(req.Scopes); err != nil {
+		return nil, err
+	}
+	if err := validateAllowedIPs(req.AllowedIPs); err != nil {
+		return nil, err
+	}
 
 	// Phase 1: Create record with placeholder values to get database ID
 	createModel := &CreateModel{
+		OrgID:         req.OrgID,
+		UserID:        req.UserID,
 		Name:          req.Name,
 		KeyHash:       "", // Empty initially
 		DisplayKey:    "", // Empty initially
+		Scopes:        req.Scopes,
+		AllowedIPs:    req.AllowedIPs,
 		ExpiresAt:     req.ExpiresAt,
 		MaxUsageCount: req.MaxUsageCount,
 	}
@@ -97,19 +120,27 @@ func (s *ServiceImpl) Create(ctx context.Context, req *CreateRequest) (*APIKeyWi
 }
 
 // MARK: FindAll
-func (s *ServiceImpl) FindAll(ctx context.Context) ([]*Model, error) {
-	return s.repo.FindAll(ctx)
+func (s *ServiceImpl) FindAll(ctx context.Context, orgID string) ([]*Model, error) {
+	return s.repo.FindAll(ctx, orgID)
 }
 
 // MARK: FindByID
-func (s *ServiceImpl) FindByID(ctx context.Context, id string) (*Model, error) {
-	return s.repo.FindByID(ctx, id)
+// FindByID returns nil when the key does not exist or belongs to another organization
+func (s *ServiceImpl) FindByID(ctx context.Context, id string, orgID string) (*Model, error) {
+	apiKey, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if apiKey == nil || apiKey.OrgID != orgID {
+		return nil, nil
+	}
+	return apiKey, nil
 }
 
 // MARK: Update
-func (s *ServiceImpl) Update(ctx context.Context, id string, req *UpdateRequest) (*Model, error) {
+func (s *ServiceImpl) Update(ctx context.Context, id string, orgID string, req *UpdateRequest) (*Model, error) {
 	// First, verify the API key exists
-	apiKey, err := s.repo.FindByID(ctx, id)
+	apiKey, err := s.FindByID(ctx, id, orgID)
 	if err != nil {
 		return nil, err
 	}
@@ -117,8 +148,21 @@ func (s *ServiceImpl) Update(ctx context.Context, id string, req *UpdateRequest)
 		return nil, errors.New("API key not found")
 	}
 
+	if req.Scopes != nil {
+		if err := validateScopes(*req.Scopes); err != nil {
+			return nil, err
+		}
+	}
+	if req.AllowedIPs != nil {
+		if err := validateAllowedIPs(*req.AllowedIPs); err != nil {
+			return nil, err
+		}
+	}
+
 	updateModel := &UpdateModel{
 		Name:          req.Name,
+		Scopes:        req.Scopes,
+		AllowedIPs:    req.AllowedIPs,
 		ExpiresAt:     req.ExpiresAt,
 		MaxUsageCount: req.MaxUsageCount,
 	}
@@ -127,21 +171,25 @@ func (s *ServiceImpl) Update(ctx context.Context, id string, req *UpdateRequest)
 }
 
 // MARK: Delete
-func (s *ServiceImpl) Delete(ctx context.Context, id string) error {
+func (s *ServiceImpl) Delete(ctx context.Context, id string, orgID string) error {
 	// First, verify the API key exists
-	apiKey, err := s.repo.FindByID(ctx, id)
+	apiKey, err := s.FindByID(ctx, id, orgID)
 	if err != nil {
 		return err
 	}
 	if apiKey == nil {
-		s.logger.Warnw("API key not found", "id", id)
+		// Never delete keys of other organizations
+		s.logger.Warnw("API key not found", "id", id, "orgId", orgID)
+		return nil
 	}
 
 	return s.repo.Delete(ctx, id)
 }
 
 // MARK: ValidateKey
-func (s *ServiceImpl) ValidateKey(ctx context.Context, key string) (*Model, error) {
+// ValidateKey authenticates key for a request coming from usage.IP and
+// records the request as the key's last use
+func (s *ServiceImpl) ValidateKey(ctx context.Context, key string, usage *Usage) (*Model, error) {
 	s.logger.Debugw("Validating API key", "key", maskAPIKey(key))
 
 	// Parse the API key token to extract ID and actual key
@@ -181,8 +229,19 @@ func (s *ServiceImpl) ValidateKey(ctx context.Context, key string) (*Model, erro
 		return nil, errors.New("invalid API key")
 	}
 
-	// Update last used timestamp and usage count
-	err = s.repo.UpdateLastUsed(ctx, apiKey.ID)
+	// Keys created before organization binding have no owner to act for
+	if apiKey.OrgID == "" || apiKey.UserID == "" {
+		s.logger.Warnw("API key is not bound to an organization", "apiKeyId", apiKey.ID)
+		return nil, errors.New("API key is not bound to an organization")
+	}
+
+	if !apiKey.AllowsIP(usage.IP) {
+		s.logger.Warnw("API key used from a disallowed IP", "apiKeyId", apiKey.ID, "ip", usage.IP)
+		return nil, ErrIPNotAllowed
+	}
+
+	// Update last used timestamp, usage count and request details
+	err = s.repo.UpdateLastUsed(ctx, apiKey.ID, usage)
 	if err != nil {
 		// Log error but don't fail the validation
 		// This is a non-critical operation
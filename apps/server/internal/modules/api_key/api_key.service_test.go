@@ -14,6 +14,13 @@ import (
 	"go.uber.org/zap"
 )
 
+var (
+	testOrgID  = "11111111-1111-1111-1111-111111111111"
+	testUserID = "22222222-2222-2222-2222-222222222222"
+	testScopes = []string{string(ScopeMonitorsRead)}
+	testUsage  = &Usage{IP: "203.0.113.10", Route: "GET /api/v1/monitors"}
+)
+
 func setupServiceTestDB(t *testing.T) *bun.DB {
 	sqldb, err := sql.Open(sqliteshim.ShimName, ":memory:")
 	require.NoError(t, err)
@@ -37,6 +44,9 @@ func TestServiceImpl_ValidateKey_Success(t *testing.T) {
 
 	// Create an API key first
 	req := &CreateRequest{
+		OrgID:         testOrgID,
+		UserID:        testUserID,
+		Scopes:        testScopes,
 		Name:          "Test Key",
 		ExpiresAt:     nil,
 		MaxUsageCount: nil,
@@ -47,14 +57,14 @@ func TestServiceImpl_ValidateKey_Success(t *testing.T) {
 	require.NotNil(t, result)
 
 	// Now validate the created key
-	validated, err := service.ValidateKey(ctx, result.Token)
+	validated, err := service.ValidateKey(ctx, result.Token, testUsage)
 	assert.NoError(t, err)
 	assert.NotNil(t, validated)
 	assert.Equal(t, result.ID, validated.ID)
 	assert.Equal(t, result.Name, validated.Name)
 
 	// Verify usage count was incremented
-	updatedKey, err := service.FindByID(ctx, result.ID)
+	updatedKey, err := service.FindByID(ctx, result.ID, testOrgID)
 	require.NoError(t, err)
 	assert.Equal(t, int64(1), updatedKey.UsageCount)
 	assert.NotNil(t, updatedKey.LastUsed)
@@ -71,6 +81,9 @@ func TestServiceImpl_ValidateKey_ExpiredKey(t *testing.T) {
 	// Create an API key with expiration in the past
 	expiredTime := time.Now().Add(-1 * time.Hour)
 	req := &CreateRequest{
+		OrgID:         testOrgID,
+		UserID:        testUserID,
+		Scopes:        testScopes,
 		Name:          "Expired Key",
 		ExpiresAt:     &expiredTime,
 		MaxUsageCount: nil,
@@ -81,7 +94,7 @@ func TestServiceImpl_ValidateKey_ExpiredKey(t *testing.T) {
 	require.NotNil(t, result)
 
 	// Try to validate the expired key
-	validated, err := service.ValidateKey(ctx, result.Token)
+	validated, err := service.ValidateKey(ctx, result.Token, testUsage)
 	assert.Error(t, err)
 	assert.Nil(t, validated)
 	assert.Contains(t, err.Error(), "expired")
@@ -98,6 +111,9 @@ func TestServiceImpl_ValidateKey_MaxUsageExceeded(t *testing.T) {
 	// Create an API key with usage limit
 	maxUsage := int64(2)
 	req := &CreateRequest{
+		OrgID:         testOrgID,
+		UserID:        testUserID,
+		Scopes:        testScopes,
 		Name:          "Limited Key",
 		ExpiresAt:     nil,
 		MaxUsageCount: &maxUsage,
@@ -108,15 +124,15 @@ func TestServiceImpl_ValidateKey_MaxUsageExceeded(t *testing.T) {
 	require.NotNil(t, result)
 
 	// First validation should succeed
-	_, err = service.ValidateKey(ctx, result.Token)
+	_, err = service.ValidateKey(ctx, result.Token, testUsage)
 	assert.NoError(t, err)
 
 	// Second validation should succeed
-	_, err = service.ValidateKey(ctx, result.Token)
+	_, err = service.ValidateKey(ctx, result.Token, testUsage)
 	assert.NoError(t, err)
 
 	// Third validation should fail (exceeded limit)
-	validated, err := service.ValidateKey(ctx, result.Token)
+	validated, err := service.ValidateKey(ctx, result.Token, testUsage)
 	assert.Error(t, err)
 	assert.Nil(t, validated)
 	assert.Contains(t, err.Error(), "usage limit exceeded")
@@ -131,7 +147,7 @@ func TestServiceImpl_ValidateKey_InvalidFormat(t *testing.T) {
 	ctx := context.Background()
 	invalidToken := "invalid-token-format"
 
-	result, err := service.ValidateKey(ctx, invalidToken)
+	result, err := service.ValidateKey(ctx, invalidToken, testUsage)
 	assert.Error(t, err)
 	assert.Nil(t, result)
 	assert.Contains(t, err.Error(), "invalid API key")
@@ -147,6 +163,9 @@ func TestServiceImpl_ValidateKey_InvalidHash(t *testing.T) {
 
 	// Create a valid API key
 	req := &CreateRequest{
+		OrgID:         testOrgID,
+		UserID:        testUserID,
+		Scopes:        testScopes,
 		Name:          "Test Key",
 		ExpiresAt:     nil,
 		MaxUsageCount: nil,
@@ -160,7 +179,7 @@ func TestServiceImpl_ValidateKey_InvalidHash(t *testing.T) {
 	fakeToken := ApiKeyPrefix + "eyJpZCI6InRlc3Qta2V5LWlkIiwia2V5Ijoid3Jvbmcta2V5In0="
 
 	// Try to validate with wrong key
-	validated, err := service.ValidateKey(ctx, fakeToken)
+	validated, err := service.ValidateKey(ctx, fakeToken, testUsage)
 	assert.Error(t, err)
 	assert.Nil(t, validated)
 	assert.Contains(t, err.Error(), "invalid API key")
@@ -175,7 +194,7 @@ func TestServiceImpl_ValidateKey_NotFound(t *testing.T) {
 	ctx := context.Background()
 	nonExistentToken := ApiKeyPrefix + "eyJpZCI6Im5vbi1leGlzdGVudC1pZCIsImtleSI6InRlc3QtYWN0dWFsLWtleSJ9"
 
-	result, err := service.ValidateKey(ctx, nonExistentToken)
+	result, err := service.ValidateKey(ctx, nonExistentToken, testUsage)
 	assert.Error(t, err)
 	assert.Nil(t, result)
 	assert.Contains(t, err.Error(), "invalid API key")
@@ -189,6 +208,9 @@ func TestServiceImpl_Create_Success(t *testing.T) {
 
 	ctx := context.Background()
 	req := &CreateRequest{
+		OrgID:         testOrgID,
+		UserID:        testUserID,
+		Scopes:        testScopes,
 		Name:          "Test API Key",
 		ExpiresAt:     nil,
 		MaxUsageCount: nil,
@@ -215,15 +237,15 @@ func TestServiceImpl_FindAll(t *testing.T) {
 	ctx := context.Background()
 
 	// Create multiple API keys
-	req1 := &CreateRequest{Name: "Key 1"}
-	req2 := &CreateRequest{Name: "Key 2"}
+	req1 := &CreateRequest{OrgID: testOrgID, UserID: testUserID, Scopes: testScopes, Name: "Key 1"}
+	req2 := &CreateRequest{OrgID: testOrgID, UserID: testUserID, Scopes: testScopes, Name: "Key 2"}
 
 	_, err := service.Create(ctx, req1)
 	require.NoError(t, err)
 	_, err = service.Create(ctx, req2)
 	require.NoError(t, err)
 
-	result, err := service.FindAll(ctx)
+	result, err := service.FindAll(ctx, testOrgID)
 	assert.NoError(t, err)
 	assert.Len(t, result, 2)
 
@@ -245,12 +267,12 @@ func TestServiceImpl_FindByID(t *testing.T) {
 	ctx := context.Background()
 
 	// Create an API key
-	req := &CreateRequest{Name: "Test Key"}
+	req := &CreateRequest{OrgID: testOrgID, UserID: testUserID, Scopes: testScopes, Name: "Test Key"}
 	created, err := service.Create(ctx, req)
 	require.NoError(t, err)
 
 	// Find it by ID
-	result, err := service.FindByID(ctx, created.ID)
+	result, err := service.FindByID(ctx, created.ID, testOrgID)
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
 	assert.Equal(t, created.ID, result.ID)
@@ -266,7 +288,7 @@ func TestServiceImpl_Update(t *testing.T) {
 	ctx := context.Background()
 
 	// Create an API key
-	req := &CreateRequest{Name: "Original Name"}
+	req := &CreateRequest{OrgID: testOrgID, UserID: testUserID, Scopes: testScopes, Name: "Original Name"}
 	created, err := service.Create(ctx, req)
 	require.NoError(t, err)
 
@@ -275,7 +297,7 @@ func TestServiceImpl_Update(t *testing.T) {
 		Name: stringPtr("Updated Name"),
 	}
 
-	result, err := service.Update(ctx, created.ID, updateReq)
+	result, err := service.Update(ctx, created.ID, testOrgID, updateReq)
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
 	assert.Equal(t, "Updated Name", result.Name)
@@ -291,20 +313,20 @@ func TestServiceImpl_Delete(t *testing.T) {
 	ctx := context.Background()
 
 	// Create an API key
-	req := &CreateRequest{Name: "Test Key"}
+	req := &CreateRequest{OrgID: testOrgID, UserID: testUserID, Scopes: testScopes, Name: "Test Key"}
 	created, err := service.Create(ctx, req)
 	require.NoError(t, err)
 
 	// Verify it exists
-	_, err = service.FindByID(ctx, created.ID)
+	_, err = service.FindByID(ctx, created.ID, testOrgID)
 	assert.NoError(t, err)
 
 	// Delete it
-	err = service.Delete(ctx, created.ID)
+	err = service.Delete(ctx, created.ID, testOrgID)
 	assert.NoError(t, err)
 
 	// Verify it's gone
-	deletedKey, err := service.FindByID(ctx, created.ID)
+	deletedKey, err := service.FindByID(ctx, created.ID, testOrgID)
 	assert.NoError(t, err)
 	assert.Nil(t, deletedKey)
 }
@@ -313,3 +335,141 @@ func TestServiceImpl_Delete(t *testing.T) {
 func stringPtr(s string) *string {
 	return &s
 }
+
+func TestServiceImpl_Create_Validation(t *testing.T) {
+	db := setupServiceTestDB(t)
+	repo := NewSQLRepository(db)
+	logger := zap.NewNop().Sugar()
+	service := NewService(repo, logger)
+
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		req  *CreateRequest
+	}{
+		{name: "no organization", req: &CreateRequest{UserID: testUserID, Scopes: testScopes, Name: "Key"}},
+		{name: "no scopes", req: &CreateRequest{OrgID: testOrgID, UserID: testUserID, Name: "Key"}},
+		{name: "unknown scope", req: &CreateRequest{OrgID: testOrgID, UserID: testUserID, Scopes: []string{"admin"}, Name: "Key"}},
+		{name: "invalid allowlist", req: &CreateRequest{OrgID: testOrgID, UserID: testUserID, Scopes: testScopes, AllowedIPs: []string{"not-an-ip"}, Name: "Key"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := service.Create(ctx, tt.req)
+			assert.Error(t, err)
+			assert.Nil(t, result)
+		})
+	}
+
+	all, err := repo.FindAll(ctx, testOrgID)
+	require.NoError(t, err)
+	assert.Empty(t, all)
+}
+
+func TestServiceImpl_OrganizationIsolation(t *testing.T) {
+	db := setupServiceTestDB(t)
+	repo := NewSQLRepository(db)
+	logger := zap.NewNop().Sugar()
+	service := NewService(repo, logger)
+
+	ctx := context.Background()
+	otherOrgID := "33333333-3333-3333-3333-333333333333"
+
+	created, err := service.Create(ctx, &CreateRequest{OrgID: testOrgID, UserID: testUserID, Scopes: testScopes, Name: "Org Key"})
+	require.NoError(t, err)
+
+	others, err := service.FindAll(ctx, otherOrgID)
+	require.NoError(t, err)
+	assert.Empty(t, others)
+
+	found, err := service.FindByID(ctx, created.ID, otherOrgID)
+	require.NoError(t, err)
+	assert.Nil(t, found)
+
+	_, err = service.Update(ctx, created.ID, otherOrgID, &UpdateRequest{Name: stringPtr("Hijacked")})
+	assert.EqualError(t, err, "API key not found")
+
+	require.NoError(t, service.Delete(ctx, created.ID, otherOrgID))
+	stillThere, err := service.FindByID(ctx, created.ID, testOrgID)
+	require.NoError(t, err)
+	require.NotNil(t, stillThere)
+	assert.Equal(t, "Org Key", stillThere.Name)
+}
+
+func TestServiceImpl_ValidateKey_AllowedIPs(t *testing.T) {
+	db := setupServiceTestDB(t)
+	repo := NewSQLRepository(db)
+	logger := zap.NewNop().Sugar()
+	service := NewService(repo, logger)
+
+	ctx := context.Background()
+
+	created, err := service.Create(ctx, &CreateRequest{
+		OrgID:      testOrgID,
+		UserID:     testUserID,
+		Scopes:     testScopes,
+		AllowedIPs: []string{"198.51.100.0/24", "2001:db8::1"},
+		Name:       "Allowlisted Key",
+	})
+	require.NoError(t, err)
+
+	_, err = service.ValidateKey(ctx, created.Token, &Usage{IP: "203.0.113.10", Route: "GET /api/v1/monitors"})
+	assert.ErrorIs(t, err, ErrIPNotAllowed)
+
+	validated, err := service.ValidateKey(ctx, created.Token, &Usage{IP: "198.51.100.7", Route: "GET /api/v1/monitors/:id"})
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, validated.ID)
+
+	_, err = service.ValidateKey(ctx, created.Token, &Usage{IP: "2001:db8::1", Route: "GET /api/v1/monitors"})
+	require.NoError(t, err)
+
+	// Only allowed requests are recorded
+	updated, err := service.FindByID(ctx, created.ID, testOrgID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), updated.UsageCount)
+	require.NotNil(t, updated.LastUsedIP)
+	assert.Equal(t, "2001:db8::1", *updated.LastUsedIP)
+	assert.Equal(t, []string{"198.51.100.0/24", "2001:db8::1"}, updated.AllowedIPs)
+}
+
+func TestServiceImpl_ValidateKey_UnboundKey(t *testing.T) {
+	db := setupServiceTestDB(t)
+	repo := NewSQLRepository(db)
+	logger := zap.NewNop().Sugar()
+	service := NewService(repo, logger)
+
+	ctx := context.Background()
+
+	created, err := service.Create(ctx, &CreateRequest{OrgID: testOrgID, UserID: testUserID, Scopes: testScopes, Name: "Legacy Key"})
+	require.NoError(t, err)
+
+	// Keys created before organization binding have no organization
+	_, err = db.NewUpdate().Model((*sqlModel)(nil)).Set("org_id = NULL").Where("id = ?", created.ID).Exec(ctx)
+	require.NoError(t, err)
+
+	validated, err := service.ValidateKey(ctx, created.Token, testUsage)
+	assert.Error(t, err)
+	assert.Nil(t, validated)
+}
+
+func TestServiceImpl_Update_Scopes(t *testing.T) {
+	db := setupServiceTestDB(t)
+	repo := NewSQLRepository(db)
+	logger := zap.NewNop().Sugar()
+	service := NewService(repo, logger)
+
+	ctx := context.Background()
+
+	created, err := service.Create(ctx, &CreateRequest{OrgID: testOrgID, UserID: testUserID, Scopes: testScopes, Name: "Key"})
+	require.NoError(t, err)
+
+	scopes := []string{string(ScopeMonitorsWrite), string(ScopePush)}
+	updated, err := service.Update(ctx, created.ID, testOrgID, &UpdateRequest{Scopes: &scopes})
+	require.NoError(t, err)
+	assert.Equal(t, scopes, updated.Scopes)
+
+	empty := []string{}
+	_, err = service.Update(ctx, created.ID, testOrgID, &UpdateRequest{Scopes: &empty})
+	assert.Error(t, err)
+}
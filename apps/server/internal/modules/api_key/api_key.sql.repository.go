@@ -2,6 +2,7 @@ package api_key
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -12,10 +13,16 @@ type sqlModel struct {
 	bun.BaseModel `bun:"table:api_keys,alias:ak"`
 
 	ID             string     `bun:"id,pk"`
+	OrgID          string     `bun:"org_id,nullzero"`
+	UserID         string     `bun:"user_id,nullzero"`
 	Name           string     `bun:"name,notnull"`
 	KeyHash        string     `bun:"key_hash,notnull"`
 	DisplayKey     string     `bun:"display_key,notnull"`
+	Scopes         string     `bun:"scopes,notnull,default:''"`
+	AllowedIPs     string     `bun:"allowed_ips,notnull,default:''"`
 	LastUsed       *time.Time `bun:"last_used"`
+	LastUsedIP     *string    `bun:"last_used_ip"`
+	LastUsedRoute  *string    `bun:"last_used_route"`
 	ExpiresAt      *time.Time `bun:"expires_at"`
 	UsageCount     int64      `bun:"usage_count,notnull,default:0"`
 	MaxUsageCount  *int64     `bun:"max_usage_count"`
@@ -32,10 +39,16 @@ func toDomainModelFromSQL(sm *sqlModel) *Model {
 	
 	return &Model{
 		ID:            sm.ID,
+		OrgID:         sm.OrgID,
+		UserID:        sm.UserID,
 		Name:          sm.Name,
 		KeyHash:       sm.KeyHash,
 		DisplayKey:    displayKey,
+		Scopes:        splitList(sm.Scopes),
+		AllowedIPs:    splitList(sm.AllowedIPs),
 		LastUsed:      sm.LastUsed,
+		LastUsedIP:    sm.LastUsedIP,
+		LastUsedRoute: sm.LastUsedRoute,
 		ExpiresAt:     sm.ExpiresAt,
 		UsageCount:    sm.UsageCount,
 		MaxUsageCount: sm.MaxUsageCount,
@@ -47,10 +60,16 @@ func toDomainModelFromSQL(sm *sqlModel) *Model {
 func toSQLModel(m *Model) *sqlModel {
 	return &sqlModel{
 		ID:            m.ID,
+		OrgID:         m.OrgID,
+		UserID:        m.UserID,
 		Name:          m.Name,
 		KeyHash:       m.KeyHash,
 		DisplayKey:    m.DisplayKey,
+		Scopes:        joinList(m.Scopes),
+		AllowedIPs:    joinList(m.AllowedIPs),
 		LastUsed:      m.LastUsed,
+		LastUsedIP:    m.LastUsedIP,
+		LastUsedRoute: m.LastUsedRoute,
 		ExpiresAt:     m.ExpiresAt,
 		UsageCount:    m.UsageCount,
 		MaxUsageCount: m.MaxUsageCount,
@@ -59,6 +78,19 @@ func toSQLModel(m *Model) *sqlModel {
 	}
 }
 
+// Scopes and allowed IPs never contain commas, so they are stored as
+// comma separated lists
+func splitList(value string) []string {
+	if value == "" {
+		return []string{}
+	}
+	return strings.Split(value, ",")
+}
+
+func joinList(values []string) string {
+	return strings.Join(values, ",")
+}
+
 type SQLRepositoryImpl struct {
 	db *bun.DB
 }
@@ -75,9 +107,13 @@ func (r *SQLRepositoryImpl) Create(ctx context.Context, apiKey *CreateModel) (*A
 
 	sm := &sqlModel{
 		ID:            apiKeyID,
+		OrgID:         apiKey.OrgID,
+		UserID:        apiKey.UserID,
 		Name:          apiKey.Name,
 		KeyHash:       apiKey.KeyHash,
 		DisplayKey:    apiKey.DisplayKey,
+		Scopes:        joinList(apiKey.Scopes),
+		AllowedIPs:    joinList(apiKey.AllowedIPs),
 		LastUsed:      nil,
 		ExpiresAt:     apiKey.ExpiresAt,
 		UsageCount:    0,
@@ -112,9 +148,9 @@ func (r *SQLRepositoryImpl) FindByID(ctx context.Context, id string) (*Model, er
 }
 
 // MARK: FindAll
-func (r *SQLRepositoryImpl) FindAll(ctx context.Context) ([]*Model, error) {
+func (r *SQLRepositoryImpl) FindAll(ctx context.Context, orgID string) ([]*Model, error) {
 	var sms []*sqlModel
-	err := r.db.NewSelect().Model(&sms).Order("created_at DESC").Scan(ctx)
+	err := r.db.NewSelect().Model(&sms).Where("org_id = ?", orgID).Order("created_at DESC").Scan(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -136,6 +172,12 @@ func (r *SQLRepositoryImpl) Update(ctx context.Context, id string, update *Updat
 	if update.Name != nil {
 		query = query.Set("name = ?", *update.Name)
 	}
+	if update.Scopes != nil {
+		query = query.Set("scopes = ?", joinList(*update.Scopes))
+	}
+	if update.AllowedIPs != nil {
+		query = query.Set("allowed_ips = ?", joinList(*update.AllowedIPs))
+	}
 	if update.ExpiresAt != nil {
 		query = query.Set("expires_at = ?", *update.ExpiresAt)
 	}
@@ -160,9 +202,11 @@ func (r *SQLRepositoryImpl) Delete(ctx context.Context, id string) error {
 }
 
 // MARK: UpdateLastUsed
-func (r *SQLRepositoryImpl) UpdateLastUsed(ctx context.Context, id string) error {
+func (r *SQLRepositoryImpl) UpdateLastUsed(ctx context.Context, id string, usage *Usage) error {
 	_, err := r.db.NewUpdate().Model((*sqlModel)(nil)).
 		Set("last_used = ?", time.Now()).
+		Set("last_used_ip = ?", usage.IP).
+		Set("last_used_route = ?", usage.Route).
 		Set("usage_count = usage_count + 1").
 		Where("id = ?", id).
 		Exec(ctx)
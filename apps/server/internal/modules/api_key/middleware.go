@@ -1,6 +1,7 @@
 package api_key
 
 import (
+	"errors"
 	"net/http"
 	"vigi/internal/utils"
 	"strings"
@@ -22,7 +23,10 @@ func NewMiddlewareProvider(service Service) *MiddlewareProvider {
 
 // Auth is a middleware that verifies API key authentication
 // This should be used as the final middleware in a chain for API key-only endpoints
-func (p *MiddlewareProvider) Auth() gin.HandlerFunc {
+// The key must hold one of scopes for the request method, so routes that pass
+// no scopes are closed to API keys. The key acts on behalf of the member who
+// created it and only within its organization.
+func (p *MiddlewareProvider) Auth(scopes ...Scope) gin.HandlerFunc {
 	return func(c *gin.Context) {
 	// Get the X-API-Key header
 	authHeader := c.GetHeader("X-API-Key")
@@ -40,15 +44,50 @@ func (p *MiddlewareProvider) Auth() gin.HandlerFunc {
 		}
 
 		// Validate the API key
-		apiKey, err := p.service.ValidateKey(c.Request.Context(), authHeader)
+		route := c.FullPath()
+		if route == "" {
+			route = c.Request.URL.Path
+		}
+		usage := &Usage{
+			IP:    c.ClientIP(),
+			Route: c.Request.Method + " " + route,
+		}
+		apiKey, err := p.service.ValidateKey(c.Request.Context(), authHeader, usage)
 		if err != nil {
+			if errors.Is(err, ErrIPNotAllowed) {
+				c.JSON(http.StatusForbidden, utils.NewFailResponse(err.Error()))
+				c.Abort()
+				return
+			}
 			c.JSON(http.StatusUnauthorized, utils.NewFailResponse("Invalid or expired API key"))
 			c.Abort()
 			return
 		}
 
+		// Check the key may call this route
+		if !apiKey.Allows(c.Request.Method, scopes) {
+			c.JSON(http.StatusForbidden, utils.NewFailResponse("API key is missing the required scope"))
+			c.Abort()
+			return
+		}
+
+		// Bind the request to the key's organization
+		if orgID := c.GetHeader("X-Organization-ID"); orgID != "" && orgID != apiKey.OrgID {
+			c.JSON(http.StatusForbidden, utils.NewFailResponse("API key belongs to another organization"))
+			c.Abort()
+			return
+		}
+		if strings.Contains(c.FullPath(), "/organizations/:id") && c.Param("id") != apiKey.OrgID {
+			c.JSON(http.StatusForbidden, utils.NewFailResponse("API key belongs to another organization"))
+			c.Abort()
+			return
+		}
+		c.Request.Header.Set("X-Organization-ID", apiKey.OrgID)
+
 		// Set API key information in the context
 		c.Set("apiKeyId", apiKey.ID)
+		c.Set("apiKeyScopes", apiKey.Scopes)
+		c.Set("userId", apiKey.UserID)
 		c.Set("authType", "api_key")
 
 		c.Next()
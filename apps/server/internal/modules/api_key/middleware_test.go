@@ -26,37 +26,37 @@ func (m *MockService) Create(ctx context.Context, req *CreateRequest) (*APIKeyWi
 	return args.Get(0).(*APIKeyWithToken), args.Error(1)
 }
 
-func (m *MockService) FindAll(ctx context.Context) ([]*Model, error) {
-	args := m.Called(ctx)
+func (m *MockService) FindAll(ctx context.Context, orgID string) ([]*Model, error) {
+	args := m.Called(ctx, orgID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).([]*Model), args.Error(1)
 }
 
-func (m *MockService) FindByID(ctx context.Context, id string) (*Model, error) {
-	args := m.Called(ctx, id)
+func (m *MockService) FindByID(ctx context.Context, id string, orgID string) (*Model, error) {
+	args := m.Called(ctx, id, orgID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*Model), args.Error(1)
 }
 
-func (m *MockService) Update(ctx context.Context, id string, req *UpdateRequest) (*Model, error) {
-	args := m.Called(ctx, id, req)
+func (m *MockService) Update(ctx context.Context, id string, orgID string, req *UpdateRequest) (*Model, error) {
+	args := m.Called(ctx, id, orgID, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*Model), args.Error(1)
 }
 
-func (m *MockService) Delete(ctx context.Context, id string) error {
-	args := m.Called(ctx, id)
+func (m *MockService) Delete(ctx context.Context, id string, orgID string) error {
+	args := m.Called(ctx, id, orgID)
 	return args.Error(0)
 }
 
-func (m *MockService) ValidateKey(ctx context.Context, key string) (*Model, error) {
-	args := m.Called(ctx, key)
+func (m *MockService) ValidateKey(ctx context.Context, key string, usage *Usage) (*Model, error) {
+	args := m.Called(ctx, key, usage)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -121,18 +121,21 @@ func TestMiddlewareProvider_Auth_ValidKey(t *testing.T) {
 	validToken := ApiKeyPrefix + "eyJpZCI6InRlc3Qta2V5LWlkIiwia2V5IjoidGVzdC1hY3R1YWwta2V5In0="
 	apiKey := &Model{
 		ID:         "test-key-id",
+		OrgID:      "test-org-id",
+		UserID:     "test-user-id",
 		Name:       "Test Key",
 		DisplayKey: "pk_test...",
+		Scopes:     []string{string(ScopeMonitorsRead)},
 	}
 
-	mockSvc.On("ValidateKey", mock.Anything, validToken).Return(apiKey, nil)
+	mockSvc.On("ValidateKey", mock.Anything, validToken, mock.Anything).Return(apiKey, nil)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 	c.Request = httptest.NewRequest("GET", "/", nil)
 	c.Request.Header.Set("X-API-Key", validToken)
 
-	middleware.Auth()(c)
+	middleware.Auth(ScopeMonitorsRead)(c)
 
 	assert.Equal(t, http.StatusOK, w.Code)
 	assert.False(t, c.IsAborted())
@@ -156,7 +159,7 @@ func TestMiddlewareProvider_Auth_ExpiredKey(t *testing.T) {
 
 	expiredToken := ApiKeyPrefix + "eyJpZCI6ImV4cGlyZWQta2V5LWlkIiwia2V5IjoidGVzdC1hY3R1YWwta2V5In0="
 
-	mockSvc.On("ValidateKey", mock.Anything, expiredToken).Return(nil, errors.New("API key has expired"))
+	mockSvc.On("ValidateKey", mock.Anything, expiredToken, mock.Anything).Return(nil, errors.New("API key has expired"))
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -185,7 +188,7 @@ func TestMiddlewareProvider_Auth_InvalidKey(t *testing.T) {
 
 	invalidToken := ApiKeyPrefix + "eyJpZCI6ImludmFsaWQta2V5LWlkIiwia2V5IjoidGVzdC1hY3R1YWwta2V5In0="
 
-	mockSvc.On("ValidateKey", mock.Anything, invalidToken).Return(nil, errors.New("Invalid API key"))
+	mockSvc.On("ValidateKey", mock.Anything, invalidToken, mock.Anything).Return(nil, errors.New("Invalid API key"))
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -215,18 +218,21 @@ func TestMiddlewareProvider_Auth_ContextValues(t *testing.T) {
 	validToken := ApiKeyPrefix + "eyJpZCI6InRlc3Qta2V5LWlkIiwia2V5IjoidGVzdC1hY3R1YWwta2V5In0="
 	apiKey := &Model{
 		ID:         "context-test-key",
+		OrgID:      "context-org-id",
+		UserID:     "context-user-id",
 		Name:       "Context Test Key",
 		DisplayKey: "pk_context...",
+		Scopes:     []string{string(ScopeMonitorsRead)},
 	}
 
-	mockSvc.On("ValidateKey", mock.Anything, validToken).Return(apiKey, nil)
+	mockSvc.On("ValidateKey", mock.Anything, validToken, mock.Anything).Return(apiKey, nil)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 	c.Request = httptest.NewRequest("GET", "/", nil)
 	c.Request.Header.Set("X-API-Key", validToken)
 
-	middleware.Auth()(c)
+	middleware.Auth(ScopeMonitorsRead)(c)
 
 	// Verify specific context values
 	apiKeyId, exists := c.Get("apiKeyId")
@@ -237,9 +243,10 @@ func TestMiddlewareProvider_Auth_ContextValues(t *testing.T) {
 	assert.True(t, exists)
 	assert.Equal(t, "api_key", authType)
 
-	// Verify no other auth-related context values are set
-	_, jwtExists := c.Get("userId")
-	assert.False(t, jwtExists)
+	// The key acts on behalf of its owner within its organization
+	assert.Equal(t, "context-user-id", c.GetString("userId"))
+	assert.Equal(t, "context-org-id", c.Request.Header.Get("X-Organization-ID"))
+	assert.Equal(t, []string{string(ScopeMonitorsRead)}, c.GetStringSlice("apiKeyScopes"))
 
 	mockSvc.AssertExpectations(t)
 }
@@ -251,7 +258,7 @@ func TestMiddlewareProvider_Auth_ServiceError(t *testing.T) {
 
 	validToken := ApiKeyPrefix + "eyJpZCI6InNlcnZpY2UtZXJyb3Ita2V5Iiwia2V5IjoidGVzdC1hY3R1YWwta2V5In0="
 
-	mockSvc.On("ValidateKey", mock.Anything, validToken).Return(nil, errors.New("service error"))
+	mockSvc.On("ValidateKey", mock.Anything, validToken, mock.Anything).Return(nil, errors.New("service error"))
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -272,3 +279,99 @@ func TestMiddlewareProvider_Auth_ServiceError(t *testing.T) {
 
 	mockSvc.AssertExpectations(t)
 }
+
+func TestMiddlewareProvider_Auth_Scopes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	validToken := ApiKeyPrefix + "eyJpZCI6InNjb3BlLWtleSIsImtleSI6InRlc3QtYWN0dWFsLWtleSJ9"
+
+	tests := []struct {
+		name         string
+		method       string
+		granted      []string
+		required     []Scope
+		expectedCode int
+	}{
+		{name: "read scope on GET", method: "GET", granted: []string{"monitors:read"}, required: []Scope{ScopeMonitorsRead, ScopeMonitorsWrite}, expectedCode: http.StatusOK},
+		{name: "read scope on POST", method: "POST", granted: []string{"monitors:read"}, required: []Scope{ScopeMonitorsRead, ScopeMonitorsWrite}, expectedCode: http.StatusForbidden},
+		{name: "write scope on GET", method: "GET", granted: []string{"monitors:write"}, required: []Scope{ScopeMonitorsRead, ScopeMonitorsWrite}, expectedCode: http.StatusOK},
+		{name: "write scope on DELETE", method: "DELETE", granted: []string{"monitors:write"}, required: []Scope{ScopeMonitorsRead, ScopeMonitorsWrite}, expectedCode: http.StatusOK},
+		{name: "unrelated scope", method: "GET", granted: []string{"invoices:write"}, required: []Scope{ScopeMonitorsRead}, expectedCode: http.StatusForbidden},
+		{name: "route without scopes", method: "GET", granted: []string{"monitors:read", "monitors:write", "push"}, required: nil, expectedCode: http.StatusForbidden},
+		{name: "push scope", method: "POST", granted: []string{"push"}, required: []Scope{ScopePush}, expectedCode: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := new(MockService)
+			middleware := NewMiddlewareProvider(mockSvc)
+
+			mockSvc.On("ValidateKey", mock.Anything, validToken, mock.Anything).Return(&Model{
+				ID:     "scope-key",
+				OrgID:  "scope-org",
+				UserID: "scope-user",
+				Scopes: tt.granted,
+			}, nil)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(tt.method, "/", nil)
+			c.Request.Header.Set("X-API-Key", validToken)
+
+			middleware.Auth(tt.required...)(c)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			assert.Equal(t, tt.expectedCode != http.StatusOK, c.IsAborted())
+		})
+	}
+}
+
+func TestMiddlewareProvider_Auth_OtherOrganization(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockSvc := new(MockService)
+	middleware := NewMiddlewareProvider(mockSvc)
+
+	validToken := ApiKeyPrefix + "eyJpZCI6Im9yZy1rZXkiLCJrZXkiOiJ0ZXN0LWFjdHVhbC1rZXkifQ=="
+	mockSvc.On("ValidateKey", mock.Anything, validToken, mock.Anything).Return(&Model{
+		ID:     "org-key",
+		OrgID:  "org-a",
+		UserID: "user-a",
+		Scopes: []string{string(ScopeMonitorsRead)},
+	}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/", nil)
+	c.Request.Header.Set("X-API-Key", validToken)
+	c.Request.Header.Set("X-Organization-ID", "org-b")
+
+	middleware.Auth(ScopeMonitorsRead)(c)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.True(t, c.IsAborted())
+	_, exists := c.Get("userId")
+	assert.False(t, exists)
+}
+
+func TestMiddlewareProvider_Auth_IPNotAllowed(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockSvc := new(MockService)
+	middleware := NewMiddlewareProvider(mockSvc)
+
+	validToken := ApiKeyPrefix + "eyJpZCI6ImlwLWtleSIsImtleSI6InRlc3QtYWN0dWFsLWtleSJ9"
+	mockSvc.On("ValidateKey", mock.Anything, validToken, mock.MatchedBy(func(usage *Usage) bool {
+		return usage.IP == "192.0.2.1" && usage.Route == "GET /"
+	})).Return(nil, ErrIPNotAllowed)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/", nil)
+	c.Request.RemoteAddr = "192.0.2.1:1234"
+	c.Request.Header.Set("X-API-Key", validToken)
+
+	middleware.Auth(ScopeMonitorsRead)(c)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.True(t, c.IsAborted())
+	mockSvc.AssertExpectations(t)
+}
@@ -54,6 +54,17 @@ type AsaasPaymentResponse struct {
 	Status string `json:"status"`
 }
 
+type AsaasPayment struct {
+	ID                string  `json:"id"`
+	Customer          string  `json:"customer"`
+	Status            string  `json:"status"` // PENDING, RECEIVED, CONFIRMED, OVERDUE, REFUNDED...
+	BillingType       string  `json:"billingType"`
+	Value             float64 `json:"value"`
+	DueDate           string  `json:"dueDate"`
+	PaymentDate       string  `json:"paymentDate,omitempty"`
+	ExternalReference string  `json:"externalReference,omitempty"`
+}
+
 // --- Methods ---
 
 func (c *AsaasClient) GetCustomerByDoc(cpfCnpj string) (*AsaasCustomer, error) {
@@ -146,6 +157,53 @@ func (c *AsaasClient) CreatePayment(payment AsaasPaymentRequest) (*AsaasPaymentR
 	return &created, nil
 }
 
+func (c *AsaasClient) GetPayment(paymentID string) (*AsaasPayment, error) {
+	reqUrl := fmt.Sprintf("%s/payments/%s", c.baseURL, url.PathEscape(paymentID))
+	req, err := http.NewRequest("GET", reqUrl, nil)
+	if err != nil {
+		return nil, err
+	}
+	c.setHeaders(req)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("asaas api get payment error: %s", resp.Status)
+	}
+
+	var payment AsaasPayment
+	if err := json.NewDecoder(resp.Body).Decode(&payment); err != nil {
+		return nil, err
+	}
+
+	return &payment, nil
+}
+
+func (c *AsaasClient) DeletePayment(paymentID string) error {
+	reqUrl := fmt.Sprintf("%s/payments/%s", c.baseURL, url.PathEscape(paymentID))
+	req, err := http.NewRequest("DELETE", reqUrl, nil)
+	if err != nil {
+		return err
+	}
+	c.setHeaders(req)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("asaas api delete payment error: %s", resp.Status)
+	}
+
+	return nil
+}
+
 type AsaasPixQrCodeResponse struct {
 	EncodedImage string `json:"encodedImage"`
 	Payload      string `json:"payload"`
@@ -21,11 +21,24 @@ func NewController(service *Service, logger *zap.SugaredLogger) *Controller {
 	}
 }
 
-func (c *Controller) SaveConfig(ctx *gin.Context) {
-	orgIDStr := ctx.Param("id")
-	orgID, err := uuid.Parse(orgIDStr)
+// organizationID returns the organization in the path, which must be the one
+// the caller's membership was checked against
+func organizationID(ctx *gin.Context) (uuid.UUID, bool) {
+	orgID, err := uuid.Parse(ctx.Param("id"))
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, utils.NewFailResponse("Invalid organization ID"))
+		return uuid.Nil, false
+	}
+	if orgID.String() != ctx.GetString("orgId") {
+		ctx.JSON(http.StatusForbidden, utils.NewFailResponse("You are not a member of this organization"))
+		return uuid.Nil, false
+	}
+	return orgID, true
+}
+
+func (c *Controller) SaveConfig(ctx *gin.Context) {
+	orgID, ok := organizationID(ctx)
+	if !ok {
 		return
 	}
 
@@ -69,10 +82,8 @@ func (c *Controller) SaveConfig(ctx *gin.Context) {
 }
 
 func (c *Controller) GetConfig(ctx *gin.Context) {
-	orgIDStr := ctx.Param("id")
-	orgID, err := uuid.Parse(orgIDStr)
-	if err != nil {
-		ctx.JSON(http.StatusBadRequest, utils.NewFailResponse("Invalid organization ID"))
+	orgID, ok := organizationID(ctx)
+	if !ok {
 		return
 	}
 
@@ -93,14 +104,12 @@ func (c *Controller) GenerateCharge(ctx *gin.Context) {
 		return
 	}
 
-	orgIDStr := ctx.Param("id")
-	orgID, err := uuid.Parse(orgIDStr)
-	if err != nil {
-		ctx.JSON(http.StatusBadRequest, utils.NewFailResponse("Invalid organization ID"))
+	orgID, ok := organizationID(ctx)
+	if !ok {
 		return
 	}
 
-	err = c.service.CreateCharge(ctx.Request.Context(), orgID, dto)
+	err := c.service.CreateCharge(ctx.Request.Context(), orgID, dto)
 	if err != nil {
 		c.logger.Errorw("Failed to create asaas charge", "error", err)
 		ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse(err.Error()))
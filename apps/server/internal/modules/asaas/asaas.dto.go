@@ -1,13 +1,15 @@
 package asaas
 
 type CreateAsaasConfigDTO struct {
-	ApiKey      string `json:"apiKey" validate:"required"`
-	Environment string `json:"environment" validate:"required,oneof=sandbox production"`
+	ApiKey       string `json:"apiKey" validate:"required"`
+	Environment  string `json:"environment" validate:"required,oneof=sandbox production"`
+	WebhookToken string `json:"webhookToken"`
 }
 
 type UpdateAsaasConfigDTO struct {
-	ApiKey      *string `json:"apiKey"`
-	Environment *string `json:"environment" validate:"omitempty,oneof=sandbox production"`
+	ApiKey       *string `json:"apiKey"`
+	Environment  *string `json:"environment" validate:"omitempty,oneof=sandbox production"`
+	WebhookToken *string `json:"webhookToken"`
 }
 
 type GenerateChargeDTO struct {
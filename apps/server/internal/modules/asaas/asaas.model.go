@@ -13,7 +13,14 @@ type AsaasConfig struct {
 	ID             uuid.UUID `bun:"id,pk,type:uuid,default:uuid_generate_v4()"`
 	OrganizationID uuid.UUID `bun:"organization_id,type:uuid"`
 	ApiKey         string    `bun:"api_key"`
-	Environment    string    `bun:"environment"` // 'sandbox' or 'production'
+	Environment    string    `bun:"environment"`            // 'sandbox' or 'production'
+	WebhookToken   string    `bun:"webhook_token,nullzero"` // sent by Asaas in the asaas-access-token header
 	CreatedAt      time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp"`
 	UpdatedAt      time.Time `bun:"updated_at,nullzero,notnull,default:current_timestamp"`
 }
+
+type AsaasWebhookPayload struct {
+	ID      string       `json:"id"`
+	Event   string       `json:"event"`
+	Payment AsaasPayment `json:"payment"`
+}
@@ -0,0 +1,36 @@
+package asaas
+
+import (
+	"context"
+	"net/http"
+
+	"vigi/internal/modules/invoice"
+	"vigi/internal/modules/payment"
+
+	"github.com/google/uuid"
+)
+
+// Provider exposes Asaas as a payment.PaymentProvider
+type Provider struct {
+	service *Service
+}
+
+func NewProvider(service *Service) *Provider {
+	return &Provider{service: service}
+}
+
+func (p *Provider) CreateCharge(ctx context.Context, organizationID uuid.UUID, inv *invoice.Invoice) (*payment.Charge, error) {
+	return p.service.IssueCharge(ctx, organizationID, inv)
+}
+
+func (p *Provider) CancelCharge(ctx context.Context, organizationID uuid.UUID, chargeID string) error {
+	return p.service.CancelCharge(ctx, organizationID, chargeID)
+}
+
+func (p *Provider) FetchStatus(ctx context.Context, organizationID uuid.UUID, chargeID string) (*payment.Charge, error) {
+	return p.service.FetchCharge(ctx, organizationID, chargeID)
+}
+
+func (p *Provider) ParseWebhook(ctx context.Context, organizationID uuid.UUID, header http.Header, payload []byte) ([]payment.ChargeEvent, error) {
+	return p.service.ParseWebhook(ctx, organizationID, header, payload)
+}
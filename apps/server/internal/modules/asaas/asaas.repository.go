@@ -3,6 +3,7 @@ package asaas
 import (
 	"context"
 	"database/sql"
+	"vigi/internal/secret"
 
 	"github.com/google/uuid"
 	"github.com/uptrace/bun"
@@ -15,20 +16,62 @@ type Repository interface {
 }
 
 type RepositoryImpl struct {
-	db *bun.DB
+	db      *bun.DB
+	keyring *secret.Keyring
 }
 
-func NewRepository(db *bun.DB) Repository {
-	return &RepositoryImpl{db: db}
+func NewRepository(db *bun.DB, keyring *secret.Keyring) Repository {
+	return &RepositoryImpl{db: db, keyring: keyring}
+}
+
+// seal encrypts the credentials of config in place and returns a function
+// putting the plain text values back once the query ran
+func (r *RepositoryImpl) seal(config *AsaasConfig) (func(), error) {
+	apiKey, webhookToken := config.ApiKey, config.WebhookToken
+
+	encryptedKey, err := r.keyring.Encrypt(apiKey)
+	if err != nil {
+		return nil, err
+	}
+	encryptedToken, err := r.keyring.Encrypt(webhookToken)
+	if err != nil {
+		return nil, err
+	}
+
+	config.ApiKey, config.WebhookToken = encryptedKey, encryptedToken
+	return func() {
+		config.ApiKey, config.WebhookToken = apiKey, webhookToken
+	}, nil
+}
+
+func (r *RepositoryImpl) open(config *AsaasConfig) error {
+	var err error
+	if config.ApiKey, err = r.keyring.Decrypt(config.ApiKey); err != nil {
+		return err
+	}
+	config.WebhookToken, err = r.keyring.Decrypt(config.WebhookToken)
+	return err
 }
 
 func (r *RepositoryImpl) Create(ctx context.Context, config *AsaasConfig) error {
-	_, err := r.db.NewInsert().Model(config).Exec(ctx)
+	restore, err := r.seal(config)
+	if err != nil {
+		return err
+	}
+	defer restore()
+
+	_, err = r.db.NewInsert().Model(config).Exec(ctx)
 	return err
 }
 
 func (r *RepositoryImpl) Update(ctx context.Context, config *AsaasConfig) error {
-	_, err := r.db.NewUpdate().Model(config).WherePK().Exec(ctx)
+	restore, err := r.seal(config)
+	if err != nil {
+		return err
+	}
+	defer restore()
+
+	_, err = r.db.NewUpdate().Model(config).WherePK().Exec(ctx)
 	return err
 }
 
@@ -41,5 +84,8 @@ func (r *RepositoryImpl) GetByOrganizationID(ctx context.Context, organizationID
 		}
 		return nil, err
 	}
+	if err := r.open(&config); err != nil {
+		return nil, err
+	}
 	return &config, nil
 }
@@ -2,19 +2,22 @@ package asaas
 
 import (
 	"vigi/internal/modules/middleware"
+	"vigi/internal/modules/organization"
 
 	"github.com/gin-gonic/gin"
 )
 
 type Route struct {
-	controller *Controller
-	middleware *middleware.AuthChain
+	controller    *Controller
+	middleware    *middleware.AuthChain
+	orgMiddleware *organization.Middleware
 }
 
-func NewRoute(controller *Controller, middleware *middleware.AuthChain) *Route {
+func NewRoute(controller *Controller, middleware *middleware.AuthChain, orgMiddleware *organization.Middleware) *Route {
 	return &Route{
-		controller: controller,
-		middleware: middleware,
+		controller:    controller,
+		middleware:    middleware,
+		orgMiddleware: orgMiddleware,
 	}
 }
 
@@ -22,6 +25,8 @@ func (r *Route) ConnectRoute(rg *gin.RouterGroup) {
 	orgRouter := rg.Group("organizations/:id/integrations/asaas")
 	// Use AuthChain to require authentication
 	orgRouter.Use(r.middleware.AllAuth())
+	orgRouter.Use(r.orgMiddleware.RequireOrganization())
+	orgRouter.Use(r.orgMiddleware.RequireAdmin())
 
 	orgRouter.POST("", r.controller.SaveConfig)
 	orgRouter.GET("", r.controller.GetConfig)
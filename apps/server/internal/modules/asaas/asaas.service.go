@@ -2,17 +2,25 @@ package asaas
 
 import (
 	"context"
+	"crypto/subtle"
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"strings"
 	"time"
 
 	"vigi/internal/modules/client"
 	"vigi/internal/modules/invoice"
+	"vigi/internal/modules/payment"
+	"vigi/internal/secret"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
+// ProviderName is the Organization.BankProvider value for Asaas
+const ProviderName = "asaas"
+
 type Service struct {
 	repo           Repository
 	invoiceService *invoice.Service
@@ -39,10 +47,17 @@ func (s *Service) CreateConfig(ctx context.Context, organizationID uuid.UUID, dt
 		OrganizationID: organizationID,
 		ApiKey:         dto.ApiKey,
 		Environment:    dto.Environment,
+		WebhookToken:   dto.WebhookToken,
 	}
 	if err := s.repo.Create(ctx, config); err != nil {
 		return nil, err
 	}
+	if config.ApiKey != "" {
+		config.ApiKey = secret.Mask
+	}
+	if config.WebhookToken != "" {
+		config.WebhookToken = secret.Mask
+	}
 	return config, nil
 }
 
@@ -53,7 +68,10 @@ func (s *Service) GetConfig(ctx context.Context, organizationID uuid.UUID) (*Asa
 	}
 	if config != nil {
 		if config.ApiKey != "" {
-			config.ApiKey = "********"
+			config.ApiKey = secret.Mask
+		}
+		if config.WebhookToken != "" {
+			config.WebhookToken = secret.Mask
 		}
 	}
 	return config, nil
@@ -68,19 +86,25 @@ func (s *Service) UpdateConfig(ctx context.Context, organizationID uuid.UUID, dt
 		return nil, nil
 	}
 
-	if dto.ApiKey != nil && *dto.ApiKey != "********" {
+	if dto.ApiKey != nil && *dto.ApiKey != secret.Mask {
 		config.ApiKey = *dto.ApiKey
 	}
 	if dto.Environment != nil {
 		config.Environment = *dto.Environment
 	}
+	if dto.WebhookToken != nil && *dto.WebhookToken != secret.Mask {
+		config.WebhookToken = *dto.WebhookToken
+	}
 
 	if err := s.repo.Update(ctx, config); err != nil {
 		return nil, err
 	}
 
 	if config.ApiKey != "" {
-		config.ApiKey = "********"
+		config.ApiKey = secret.Mask
+	}
+	if config.WebhookToken != "" {
+		config.WebhookToken = secret.Mask
 	}
 	return config, nil
 }
@@ -103,16 +127,40 @@ func (s *Service) CreateCharge(ctx context.Context, organizationID uuid.UUID, dt
 		return fmt.Errorf("invoice does not belong to organization")
 	}
 
+	charge, err := s.IssueCharge(ctx, organizationID, inv)
+	if err != nil {
+		return err
+	}
+
+	provider := ProviderName
+	updateDto := invoice.UpdateInvoiceDTO{
+		BankInvoiceID:     &charge.ID,
+		BankInvoiceStatus: &charge.BankStatus,
+		BankProvider:      &provider,
+	}
+	if charge.PixPayload != "" {
+		updateDto.BankPixPayload = &charge.PixPayload
+	}
+
+	if _, err := s.invoiceService.Update(ctx, invoiceID, updateDto); err != nil {
+		return fmt.Errorf("failed to update invoice: %w", err)
+	}
+
+	return nil
+}
+
+// IssueCharge creates a PIX charge at Asaas for the invoice, registering its client as customer if needed
+func (s *Service) IssueCharge(ctx context.Context, organizationID uuid.UUID, inv *invoice.Invoice) (*payment.Charge, error) {
 	// 1. Get Client
 	cli, err := s.clientService.GetByID(ctx, inv.ClientID)
 	if err != nil {
-		return fmt.Errorf("client not found: %w", err)
+		return nil, fmt.Errorf("client not found: %w", err)
 	}
 
 	// 2. Get Config
 	config, err := s.repo.GetByOrganizationID(ctx, organizationID)
 	if err != nil || config == nil {
-		return fmt.Errorf("asaas not configured")
+		return nil, fmt.Errorf("asaas not configured")
 	}
 
 	apiClient := NewAsaasClient(config)
@@ -123,12 +171,12 @@ func (s *Service) CreateCharge(ctx context.Context, organizationID uuid.UUID, dt
 		cpfCnpj = sanitizeNumeric(*cli.IDNumber)
 	}
 	if cpfCnpj == "" {
-		return fmt.Errorf("client document (CPF/CNPJ) is required for Asaas")
+		return nil, fmt.Errorf("client document (CPF/CNPJ) is required for Asaas")
 	}
 
 	customer, err := apiClient.GetCustomerByDoc(cpfCnpj)
 	if err != nil {
-		return fmt.Errorf("failed to fetch asaas customer: %w", err)
+		return nil, fmt.Errorf("failed to fetch asaas customer: %w", err)
 	}
 
 	if customer == nil {
@@ -156,7 +204,7 @@ func (s *Service) CreateCharge(ctx context.Context, organizationID uuid.UUID, dt
 
 		created, err := apiClient.CreateCustomer(newCustomer)
 		if err != nil {
-			return fmt.Errorf("failed to create asaas customer: %w", err)
+			return nil, fmt.Errorf("failed to create asaas customer: %w", err)
 		}
 		customer = created
 	}
@@ -182,11 +230,15 @@ func (s *Service) CreateCharge(ctx context.Context, organizationID uuid.UUID, dt
 
 	resp, err := apiClient.CreatePayment(paymentReq)
 	if err != nil {
-		return fmt.Errorf("failed to create asaas payment: %w", err)
+		return nil, fmt.Errorf("failed to create asaas payment: %w", err)
 	}
 
 	// 5. Fetch QR Code for PIX
-	var pixPayload *string
+	charge := &payment.Charge{
+		ID:         resp.ID,
+		Status:     payment.ChargeStatusPending,
+		BankStatus: "CREATED",
+	}
 	pixInfo, err := apiClient.GetPixQrCode(resp.ID)
 	if err != nil {
 		// Log error but don't fail generic flow? Or fail?
@@ -197,24 +249,98 @@ func (s *Service) CreateCharge(ctx context.Context, organizationID uuid.UUID, dt
 		// Try to continue, maybe later we can retry?
 		// Actually, let's treat it as non-fatal but highly undesirable.
 	} else {
-		pixPayload = &pixInfo.Payload
+		charge.PixPayload = pixInfo.Payload
 	}
 
-	// 6. Update Invoice
-	bankStatus := "CREATED"
-	updateDto := invoice.UpdateInvoiceDTO{
-		BankInvoiceID:     &resp.ID,
-		BankInvoiceStatus: &bankStatus,
-		BankPixPayload:    pixPayload,
+	return charge, nil
+}
+
+// FetchCharge returns the current state of an Asaas payment
+func (s *Service) FetchCharge(ctx context.Context, organizationID uuid.UUID, paymentID string) (*payment.Charge, error) {
+	apiClient, err := s.apiClient(ctx, organizationID)
+	if err != nil {
+		return nil, err
 	}
 
-	if _, err := s.invoiceService.Update(ctx, invoiceID, updateDto); err != nil {
-		return fmt.Errorf("failed to update invoice: %w", err)
+	asaasPayment, err := apiClient.GetPayment(paymentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get asaas payment: %w", err)
 	}
 
+	charge := &payment.Charge{
+		ID:         asaasPayment.ID,
+		Status:     chargeStatus(asaasPayment.Status),
+		BankStatus: asaasPayment.Status,
+	}
+	if charge.Status == payment.ChargeStatusPending || charge.Status == payment.ChargeStatusOverdue {
+		if pixInfo, err := apiClient.GetPixQrCode(asaasPayment.ID); err == nil {
+			charge.PixPayload = pixInfo.Payload
+		}
+	}
+	return charge, nil
+}
+
+// CancelCharge removes a pending payment at Asaas
+func (s *Service) CancelCharge(ctx context.Context, organizationID uuid.UUID, paymentID string) error {
+	apiClient, err := s.apiClient(ctx, organizationID)
+	if err != nil {
+		return err
+	}
+	if err := apiClient.DeletePayment(paymentID); err != nil {
+		return fmt.Errorf("failed to delete asaas payment: %w", err)
+	}
 	return nil
 }
 
+// ParseWebhook verifies the asaas-access-token header against the organization's webhook token
+// and returns the current state of the payment named by the payload, if the event is one that changes invoices
+func (s *Service) ParseWebhook(ctx context.Context, organizationID uuid.UUID, header http.Header, payload []byte) ([]payment.ChargeEvent, error) {
+	config, err := s.repo.GetByOrganizationID(ctx, organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get asaas config: %w", err)
+	}
+	if config == nil || config.WebhookToken == "" {
+		return nil, fmt.Errorf("%w: asaas webhook token not configured", payment.ErrUnauthorizedWebhook)
+	}
+	token := header.Get("asaas-access-token")
+	if subtle.ConstantTimeCompare([]byte(token), []byte(config.WebhookToken)) != 1 {
+		return nil, fmt.Errorf("%w: invalid asaas webhook token", payment.ErrUnauthorizedWebhook)
+	}
+
+	var body AsaasWebhookPayload
+	if err := json.Unmarshal(payload, &body); err != nil {
+		return nil, fmt.Errorf("%w: %v", payment.ErrInvalidWebhookPayload, err)
+	}
+
+	if _, ok := paymentStatusForEvent(body); !ok || body.Payment.ID == "" {
+		return nil, nil
+	}
+
+	// The status is confirmed with the API rather than taken from the payload, so a
+	// leaked webhook token alone is not enough to settle an invoice
+	apiClient := NewAsaasClient(config)
+	asaasPayment, err := apiClient.GetPayment(body.Payment.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get asaas payment: %w", err)
+	}
+
+	return []payment.ChargeEvent{{
+		ID:         body.ID,
+		ChargeID:   asaasPayment.ID,
+		Reference:  asaasPayment.ExternalReference,
+		Status:     chargeStatus(asaasPayment.Status),
+		BankStatus: asaasPayment.Status,
+	}}, nil
+}
+
+func (s *Service) apiClient(ctx context.Context, organizationID uuid.UUID) (*AsaasClient, error) {
+	config, err := s.repo.GetByOrganizationID(ctx, organizationID)
+	if err != nil || config == nil {
+		return nil, fmt.Errorf("asaas not configured")
+	}
+	return NewAsaasClient(config), nil
+}
+
 func sanitizeNumeric(s string) string {
 	return strings.Map(func(r rune) rune {
 		if r >= '0' && r <= '9' {
@@ -0,0 +1,50 @@
+package asaas
+
+import (
+	"strings"
+
+	"vigi/internal/modules/payment"
+)
+
+const (
+	EventPaymentReceived  = "PAYMENT_RECEIVED"
+	EventPaymentConfirmed = "PAYMENT_CONFIRMED"
+	EventPaymentOverdue   = "PAYMENT_OVERDUE"
+	EventPaymentRefunded  = "PAYMENT_REFUNDED"
+)
+
+const (
+	PaymentStatusReceived       = "RECEIVED"
+	PaymentStatusReceivedInCash = "RECEIVED_IN_CASH"
+	PaymentStatusConfirmed      = "CONFIRMED"
+	PaymentStatusOverdue        = "OVERDUE"
+	PaymentStatusRefunded       = "REFUNDED"
+)
+
+// paymentStatusForEvent returns the payment status carried by a webhook event.
+// Only the events that change an invoice are handled; any other event returns false.
+func paymentStatusForEvent(payload AsaasWebhookPayload) (string, bool) {
+	switch payload.Event {
+	case EventPaymentReceived, EventPaymentConfirmed, EventPaymentOverdue, EventPaymentRefunded:
+		if payload.Payment.Status != "" {
+			return payload.Payment.Status, true
+		}
+		return strings.TrimPrefix(payload.Event, "PAYMENT_"), true
+	default:
+		return "", false
+	}
+}
+
+// chargeStatus maps an Asaas payment status onto the provider independent charge status
+func chargeStatus(paymentStatus string) payment.ChargeStatus {
+	switch paymentStatus {
+	case PaymentStatusReceived, PaymentStatusReceivedInCash, PaymentStatusConfirmed:
+		return payment.ChargeStatusPaid
+	case PaymentStatusOverdue:
+		return payment.ChargeStatusOverdue
+	case PaymentStatusRefunded:
+		return payment.ChargeStatusRefunded
+	default:
+		return payment.ChargeStatusPending
+	}
+}
@@ -0,0 +1,31 @@
+package asaas
+
+import (
+	"testing"
+
+	"vigi/internal/modules/payment"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaymentStatusForEvent(t *testing.T) {
+	status, ok := paymentStatusForEvent(AsaasWebhookPayload{Event: EventPaymentReceived, Payment: AsaasPayment{Status: PaymentStatusReceivedInCash}})
+	assert.True(t, ok)
+	assert.Equal(t, PaymentStatusReceivedInCash, status)
+
+	status, ok = paymentStatusForEvent(AsaasWebhookPayload{Event: EventPaymentOverdue})
+	assert.True(t, ok)
+	assert.Equal(t, PaymentStatusOverdue, status)
+
+	_, ok = paymentStatusForEvent(AsaasWebhookPayload{Event: "PAYMENT_CREATED", Payment: AsaasPayment{Status: "PENDING"}})
+	assert.False(t, ok)
+}
+
+func TestChargeStatus(t *testing.T) {
+	assert.Equal(t, payment.ChargeStatusPaid, chargeStatus(PaymentStatusReceived))
+	assert.Equal(t, payment.ChargeStatusPaid, chargeStatus(PaymentStatusReceivedInCash))
+	assert.Equal(t, payment.ChargeStatusPaid, chargeStatus(PaymentStatusConfirmed))
+	assert.Equal(t, payment.ChargeStatusOverdue, chargeStatus(PaymentStatusOverdue))
+	assert.Equal(t, payment.ChargeStatusRefunded, chargeStatus(PaymentStatusRefunded))
+	assert.Equal(t, payment.ChargeStatusPending, chargeStatus("PENDING"))
+}
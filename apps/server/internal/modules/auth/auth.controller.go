@@ -64,6 +64,7 @@ func (c *Controller) validateWithDetails(dto interface{}) error {
 // @Param       body body     RegisterDto  true  "Registration data"
 // @Success		201	{object}	utils.ApiResponse[LoginResponse]
 // @Failure		400	{object}	utils.APIError[any]
+// @Failure		403	{object}	utils.APIError[any]
 // @Failure		500	{object}	utils.APIError[any]
 func (c *Controller) Register(ctx *gin.Context) {
 	var dto RegisterDto
@@ -81,6 +82,10 @@ func (c *Controller) Register(ctx *gin.Context) {
 	response, err := c.service.Register(ctx, dto)
 	if err != nil {
 		c.logger.Errorw("Failed to register admin", "error", err)
+		if errors.Is(err, ErrPasswordLoginDisabled) {
+			ctx.JSON(http.StatusForbidden, utils.NewFailResponse(err.Error()))
+			return
+		}
 		if err.Error() == "admin already exists" {
 			ctx.JSON(http.StatusBadRequest, utils.NewFailResponse(err.Error()))
 			return
@@ -101,6 +106,7 @@ func (c *Controller) Register(ctx *gin.Context) {
 // @Success		200	{object}	utils.ApiResponse[LoginResponse]
 // @Failure		400	{object}	utils.APIError[any]
 // @Failure		401	{object}	utils.APIError[any]
+// @Failure		403	{object}	utils.APIError[any]
 // @Failure		500	{object}	utils.APIError[any]
 func (c *Controller) Login(ctx *gin.Context) {
 	var dto LoginDto
@@ -117,6 +123,10 @@ func (c *Controller) Login(ctx *gin.Context) {
 	response, err := c.service.Login(ctx, dto)
 	if err != nil {
 		c.logger.Errorw("Failed to login admin", "error", err)
+		if errors.Is(err, ErrPasswordLoginDisabled) {
+			ctx.JSON(http.StatusForbidden, utils.NewFailResponse(err.Error()))
+			return
+		}
 		ctx.JSON(http.StatusUnauthorized, utils.NewFailResponse(err.Error()))
 		return
 	}
@@ -337,3 +347,143 @@ func (c *Controller) DisableTwoFA(ctx *gin.Context) {
 	}
 	ctx.JSON(http.StatusOK, utils.NewSuccessResponse[any]("2FA disabled successfully", nil))
 }
+
+// @Router		/auth/password/forgot [post]
+// @Summary		Request a password reset email
+// @Description	Always accepted, whether or not an account uses the email
+// @Tags			Auth
+// @Produce		json
+// @Accept		json
+// @Param       body body     ForgotPasswordDto  true  "Account email"
+// @Success		202	{object}	utils.ApiResponse[any]
+// @Failure		400	{object}	utils.APIError[any]
+// @Failure		403	{object}	utils.APIError[any]
+// @Failure		429	{object}	utils.APIError[any]
+func (c *Controller) ForgotPassword(ctx *gin.Context) {
+	var dto ForgotPasswordDto
+	if err := ctx.ShouldBindJSON(&dto); err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.NewFailResponse(err.Error()))
+		return
+	}
+
+	if err := c.validateWithDetails(dto); err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.NewFailResponse(err.Error()))
+		return
+	}
+
+	if err := c.service.RequestPasswordReset(ctx, dto.Email); err != nil {
+		if errors.Is(err, ErrPasswordLoginDisabled) {
+			ctx.JSON(http.StatusForbidden, utils.NewFailResponse(err.Error()))
+			return
+		}
+		// Not reported to the client, it would reveal that the account exists
+		c.logger.Errorw("Failed to send password reset email", "error", err)
+	}
+
+	ctx.JSON(http.StatusAccepted, utils.NewSuccessResponse[any]("If an account uses this email, a reset link was sent", nil))
+}
+
+// @Router		/auth/password/reset [post]
+// @Summary		Reset password with an emailed token
+// @Description	Signs the user out of every session
+// @Tags			Auth
+// @Produce		json
+// @Accept		json
+// @Param       body body     ResetPasswordDto  true  "Reset token and new password"
+// @Success		200	{object}	utils.ApiResponse[any]
+// @Failure		400	{object}	utils.APIError[any]
+// @Failure		403	{object}	utils.APIError[any]
+// @Failure		429	{object}	utils.APIError[any]
+// @Failure		500	{object}	utils.APIError[any]
+func (c *Controller) ResetPassword(ctx *gin.Context) {
+	var dto ResetPasswordDto
+	if err := ctx.ShouldBindJSON(&dto); err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.NewFailResponse(err.Error()))
+		return
+	}
+
+	if err := c.validateWithDetails(dto); err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.NewFailResponse(err.Error()))
+		return
+	}
+
+	if err := c.service.ResetPassword(ctx, dto); err != nil {
+		switch {
+		case errors.Is(err, ErrInvalidActionToken):
+			ctx.JSON(http.StatusBadRequest, utils.NewFailResponse(err.Error()))
+		case errors.Is(err, ErrPasswordLoginDisabled):
+			ctx.JSON(http.StatusForbidden, utils.NewFailResponse(err.Error()))
+		default:
+			c.logger.Errorw("Failed to reset password", "error", err)
+			ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse(err.Error()))
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.NewSuccessResponse[any]("Password reset successfully", nil))
+}
+
+// @Router		/auth/email/verify [post]
+// @Summary		Verify email with an emailed token
+// @Tags			Auth
+// @Produce		json
+// @Accept		json
+// @Param       body body     VerifyEmailDto  true  "Verification token"
+// @Success		200	{object}	utils.ApiResponse[any]
+// @Failure		400	{object}	utils.APIError[any]
+// @Failure		429	{object}	utils.APIError[any]
+// @Failure		500	{object}	utils.APIError[any]
+func (c *Controller) VerifyEmail(ctx *gin.Context) {
+	var dto VerifyEmailDto
+	if err := ctx.ShouldBindJSON(&dto); err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.NewFailResponse(err.Error()))
+		return
+	}
+
+	if err := c.validateWithDetails(dto); err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.NewFailResponse(err.Error()))
+		return
+	}
+
+	if err := c.service.VerifyEmail(ctx, dto.Token); err != nil {
+		if errors.Is(err, ErrInvalidActionToken) {
+			ctx.JSON(http.StatusBadRequest, utils.NewFailResponse(err.Error()))
+			return
+		}
+		c.logger.Errorw("Failed to verify email", "error", err)
+		ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.NewSuccessResponse[any]("Email verified successfully", nil))
+}
+
+// @Router	/auth/email/verification [post]
+// @Summary	Resend the email verification link
+// @Tags		Auth
+// @Produce	json
+// @Security JwtAuth
+// @Success	202	{object}	utils.ApiResponse[any]
+// @Failure	400	{object}	utils.APIError[any]
+// @Failure	401	{object}	utils.APIError[any]
+// @Failure	429	{object}	utils.APIError[any]
+// @Failure	500	{object}	utils.APIError[any]
+func (c *Controller) SendEmailVerification(ctx *gin.Context) {
+	userId, exists := ctx.Get("userId")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, utils.NewFailResponse("Unauthorized"))
+		return
+	}
+
+	if err := c.service.SendEmailVerification(ctx, userId.(string)); err != nil {
+		if errors.Is(err, ErrEmailVerified) {
+			ctx.JSON(http.StatusBadRequest, utils.NewFailResponse(err.Error()))
+			return
+		}
+		c.logger.Errorw("Failed to send verification email", "error", err)
+		ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, utils.NewSuccessResponse[any]("Verification email sent", nil))
+}
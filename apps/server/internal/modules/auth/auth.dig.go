@@ -2,6 +2,7 @@ package auth
 
 import (
 	"vigi/internal/config"
+	"vigi/internal/pkg/usesend"
 	"vigi/internal/utils"
 
 	"go.uber.org/dig"
@@ -15,8 +16,8 @@ func RegisterDependencies(container *dig.Container, cfg *config.Config) {
 	container.Provide(NewTokenMaker)
 
 	// Register service with config
-	container.Provide(func(repo Repository, tokenMaker *TokenMaker, logger *zap.SugaredLogger) Service {
-		return NewService(repo, tokenMaker, logger, cfg)
+	container.Provide(func(repo Repository, tokenMaker *TokenMaker, usesendClient *usesend.Client, logger *zap.SugaredLogger) Service {
+		return NewService(repo, tokenMaker, usesendClient, logger, cfg)
 	})
 	container.Provide(NewController)
 	container.Provide(NewMiddlewareProvider)
@@ -88,3 +88,16 @@ type UpdateProfileDto struct {
 	Name     string `json:"name" validate:"required,min=3"`
 	ImageURL string `json:"image_url" validate:"omitempty,url"`
 }
+
+type ForgotPasswordDto struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type ResetPasswordDto struct {
+	Token       string `json:"token" validate:"required"`
+	NewPassword string `json:"newPassword" validate:"required,password"`
+}
+
+type VerifyEmailDto struct {
+	Token string `json:"token" validate:"required"`
+}
@@ -0,0 +1,94 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html/template"
+	"net/url"
+	"strings"
+	"time"
+	"vigi/internal/pkg/usesend"
+)
+
+var emailTemplate = template.Must(template.New("email").Parse(`<!DOCTYPE html>
+<html>
+<body style="margin:0;padding:24px;background:#f4f4f5;font-family:Arial,Helvetica,sans-serif;color:#18181b;">
+  <div style="max-width:560px;margin:0 auto;background:#ffffff;border-radius:8px;padding:32px;">
+    <h1 style="margin:0 0 16px;font-size:20px;">{{.Subject}}</h1>
+    <p style="margin:0 0 24px;font-size:15px;line-height:1.5;">{{.Message}}</p>
+    <a href="{{.ActionURL}}" style="display:inline-block;padding:10px 20px;background:#18181b;color:#ffffff;border-radius:6px;text-decoration:none;font-size:14px;">{{.ActionLabel}}</a>
+    <p style="margin:32px 0 0;font-size:12px;color:#71717a;">
+      This link expires in {{.ExpiresIn}}. If you did not request this email, you can ignore it.
+    </p>
+  </div>
+</body>
+</html>`))
+
+type emailData struct {
+	Subject     string
+	Message     string
+	ActionURL   string
+	ActionLabel string
+	ExpiresIn   string
+}
+
+func renderEmail(data emailData) (string, error) {
+	var buf bytes.Buffer
+	if err := emailTemplate.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// sendActionEmail emails the user a link to the web app page handling the token
+func (s *ServiceImpl) sendActionEmail(ctx context.Context, user *Model, tokenType string, ttl time.Duration) error {
+	token, err := s.tokenMaker.CreateActionToken(ctx, user, tokenType, ttl)
+	if err != nil {
+		return err
+	}
+
+	data := emailData{ExpiresIn: formatTTL(ttl)}
+	var path string
+	switch tokenType {
+	case TokenTypePasswordReset:
+		path = "/reset-password"
+		data.Subject = "Reset your password"
+		data.Message = "We received a request to reset the password of your Vigi account. Choose a new password with the link below."
+		data.ActionLabel = "Reset password"
+	case TokenTypeEmailVerification:
+		path = "/verify-email"
+		data.Subject = "Verify your email address"
+		data.Message = "Please confirm that this is the email address of your Vigi account."
+		data.ActionLabel = "Verify email"
+	}
+	data.ActionURL = strings.TrimSuffix(s.cfg.ClientURL, "/") + path + "?token=" + url.QueryEscape(token)
+
+	html, err := renderEmail(data)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.emailClient.SendEmail(ctx, usesend.SendEmailRequest{
+		To:      user.Email,
+		From:    s.cfg.AuthEmailFrom,
+		Subject: data.Subject,
+		HTML:    html,
+		Tags: map[string]string{
+			"type": tokenType,
+		},
+	})
+	return err
+}
+
+func formatTTL(ttl time.Duration) string {
+	switch {
+	case ttl == time.Hour:
+		return "1 hour"
+	case ttl%time.Hour == 0:
+		return fmt.Sprintf("%d hours", ttl/time.Hour)
+	case ttl%time.Minute == 0:
+		return fmt.Sprintf("%d minutes", ttl/time.Minute)
+	}
+	return ttl.String()
+}
@@ -10,28 +10,33 @@ const (
 )
 
 type Model struct {
-	ID             string    `json:"id"`
-	Email          string    `json:"email"`
-	Name           string    `json:"name"`
-	ImageURL       string    `json:"imageUrl"`
-	Password       string    `json:"-"`
-	Active         bool      `json:"active"`
-	TwoFASecret    string    `json:"-"`
-	TwoFAStatus    bool      `json:"twofa_status"`
-	TwoFALastToken string    `json:"-"`
-	Role           string    `json:"role"`
-	CreatedAt      time.Time `json:"createdAt"`
-	UpdatedAt      time.Time `json:"updatedAt"`
+	ID             string `json:"id"`
+	Email          string `json:"email"`
+	Name           string `json:"name"`
+	ImageURL       string `json:"imageUrl"`
+	Password       string `json:"-"`
+	Active         bool   `json:"active"`
+	TwoFASecret    string `json:"-"`
+	TwoFAStatus    bool   `json:"twofa_status"`
+	TwoFALastToken string `json:"-"`
+	Role           string `json:"role"`
+	EmailVerified  bool   `json:"emailVerified"`
+	// Refresh tokens issued before this time are rejected
+	TokensRevokedAt *time.Time `json:"-"`
+	CreatedAt       time.Time  `json:"createdAt"`
+	UpdatedAt       time.Time  `json:"updatedAt"`
 }
 
 type UpdateModel struct {
-	Email          *string `json:"email"`
-	Name           *string `json:"name"`
-	ImageURL       *string `json:"imageUrl"`
-	Password       *string `json:"password"`
-	Active         *bool   `json:"active"`
-	TwoFASecret    *string `json:"twofa_secret"`
-	TwoFAStatus    *bool   `json:"twofa_status"`
-	TwoFALastToken *string `json:"twofa_last_token"`
-	Role           *string `json:"role"`
+	Email           *string    `json:"email"`
+	Name            *string    `json:"name"`
+	ImageURL        *string    `json:"imageUrl"`
+	Password        *string    `json:"password"`
+	Active          *bool      `json:"active"`
+	TwoFASecret     *string    `json:"twofa_secret"`
+	TwoFAStatus     *bool      `json:"twofa_status"`
+	TwoFALastToken  *string    `json:"twofa_last_token"`
+	Role            *string    `json:"role"`
+	EmailVerified   *bool      `json:"email_verified"`
+	TokensRevokedAt *time.Time `json:"tokens_revoked_at"`
 }
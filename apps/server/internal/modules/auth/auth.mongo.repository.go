@@ -12,42 +12,48 @@ import (
 )
 
 type mongoModel struct {
-	ID             primitive.ObjectID `bson:"_id"`
-	Email          string             `bson:"email"`
-	Password       string             `bson:"password"`
-	Active         bool               `bson:"active"`
-	TwoFASecret    string             `bson:"twofa_secret"`
-	TwoFAStatus    bool               `bson:"twofa_status"`
-	TwoFALastToken string             `bson:"twofa_last_token"`
-	Role           string             `bson:"role"`
-	CreatedAt      time.Time          `bson:"createdAt"`
-	UpdatedAt      time.Time          `bson:"updatedAt"`
+	ID              primitive.ObjectID `bson:"_id"`
+	Email           string             `bson:"email"`
+	Password        string             `bson:"password"`
+	Active          bool               `bson:"active"`
+	TwoFASecret     string             `bson:"twofa_secret"`
+	TwoFAStatus     bool               `bson:"twofa_status"`
+	TwoFALastToken  string             `bson:"twofa_last_token"`
+	Role            string             `bson:"role"`
+	EmailVerified   bool               `bson:"email_verified"`
+	TokensRevokedAt *time.Time         `bson:"tokens_revoked_at,omitempty"`
+	CreatedAt       time.Time          `bson:"createdAt"`
+	UpdatedAt       time.Time          `bson:"updatedAt"`
 }
 
 type mongoUpdateModel struct {
-	Email          *string    `bson:"email,omitempty"`
-	Password       *string    `bson:"password,omitempty"`
-	Active         *bool      `bson:"active,omitempty"`
-	TwoFASecret    *string    `bson:"twofa_secret,omitempty"`
-	TwoFAStatus    *bool      `bson:"twofa_status,omitempty"`
-	TwoFALastToken *string    `bson:"twofa_last_token,omitempty"`
-	Role           *string    `bson:"role,omitempty"`
-	CreatedAt      *time.Time `bson:"createdAt,omitempty"`
-	UpdatedAt      *time.Time `bson:"updatedAt,omitempty"`
+	Email           *string    `bson:"email,omitempty"`
+	Password        *string    `bson:"password,omitempty"`
+	Active          *bool      `bson:"active,omitempty"`
+	TwoFASecret     *string    `bson:"twofa_secret,omitempty"`
+	TwoFAStatus     *bool      `bson:"twofa_status,omitempty"`
+	TwoFALastToken  *string    `bson:"twofa_last_token,omitempty"`
+	Role            *string    `bson:"role,omitempty"`
+	EmailVerified   *bool      `bson:"email_verified,omitempty"`
+	TokensRevokedAt *time.Time `bson:"tokens_revoked_at,omitempty"`
+	CreatedAt       *time.Time `bson:"createdAt,omitempty"`
+	UpdatedAt       *time.Time `bson:"updatedAt,omitempty"`
 }
 
 func toDomainModel(mm *mongoModel) *Model {
 	return &Model{
-		ID:             mm.ID.Hex(),
-		Email:          mm.Email,
-		Password:       mm.Password,
-		Active:         mm.Active,
-		TwoFASecret:    mm.TwoFASecret,
-		TwoFAStatus:    mm.TwoFAStatus,
-		TwoFALastToken: mm.TwoFALastToken,
-		Role:           mm.Role,
-		CreatedAt:      mm.CreatedAt,
-		UpdatedAt:      mm.UpdatedAt,
+		ID:              mm.ID.Hex(),
+		Email:           mm.Email,
+		Password:        mm.Password,
+		Active:          mm.Active,
+		TwoFASecret:     mm.TwoFASecret,
+		TwoFAStatus:     mm.TwoFAStatus,
+		TwoFALastToken:  mm.TwoFALastToken,
+		Role:            mm.Role,
+		EmailVerified:   mm.EmailVerified,
+		TokensRevokedAt: mm.TokensRevokedAt,
+		CreatedAt:       mm.CreatedAt,
+		UpdatedAt:       mm.UpdatedAt,
 	}
 }
 
@@ -73,6 +79,7 @@ func (r *RepositoryImpl) Create(ctx context.Context, user *Model) (*Model, error
 		TwoFAStatus:    user.TwoFAStatus,
 		TwoFALastToken: user.TwoFALastToken,
 		Role:           user.Role,
+		EmailVerified:  user.EmailVerified,
 		CreatedAt:      time.Now(),
 		UpdatedAt:      time.Now(),
 	}
@@ -123,13 +130,15 @@ func (r *RepositoryImpl) Update(ctx context.Context, id string, entity *UpdateMo
 	}
 
 	mu := &mongoUpdateModel{
-		Email:          entity.Email,
-		Password:       entity.Password,
-		Active:         entity.Active,
-		TwoFASecret:    entity.TwoFASecret,
-		TwoFAStatus:    entity.TwoFAStatus,
-		TwoFALastToken: entity.TwoFALastToken,
-		Role:           entity.Role,
+		Email:           entity.Email,
+		Password:        entity.Password,
+		Active:          entity.Active,
+		TwoFASecret:     entity.TwoFASecret,
+		TwoFAStatus:     entity.TwoFAStatus,
+		TwoFALastToken:  entity.TwoFALastToken,
+		Role:            entity.Role,
+		EmailVerified:   entity.EmailVerified,
+		TokensRevokedAt: entity.TokensRevokedAt,
 	}
 
 	set := buildSetMapFromUpdateModel(mu)
@@ -195,6 +204,12 @@ func buildSetMapFromUpdateModel(mu *mongoUpdateModel) bson.M {
 	if mu.Role != nil {
 		set["role"] = *mu.Role
 	}
+	if mu.EmailVerified != nil {
+		set["email_verified"] = *mu.EmailVerified
+	}
+	if mu.TokensRevokedAt != nil {
+		set["tokens_revoked_at"] = *mu.TokensRevokedAt
+	}
 	if mu.CreatedAt != nil {
 		set["createdAt"] = *mu.CreatedAt
 	}
@@ -1,26 +1,79 @@
 package auth
 
 import (
+	"net/http"
+	"time"
+	"vigi/internal/config"
 	"vigi/internal/modules/bruteforce"
 
 	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const (
+	// Emails sent per account and client before further requests are refused
+	emailRateLimit  = 5
+	emailRateWindow = time.Hour
 )
 
 type Route struct {
 	controller      *Controller
 	middleware      *MiddlewareProvider
 	bruteforceGuard *bruteforce.Guard
+	// Counts every accepted request, so emails can't be used to flood an inbox
+	passwordResetGuard     *bruteforce.Guard
+	emailVerificationGuard *bruteforce.Guard
+	// Counts rejected tokens, like the login guard counts rejected passwords
+	actionTokenGuard *bruteforce.Guard
 }
 
 func NewRoute(
 	controller *Controller,
 	middleware *MiddlewareProvider,
 	bruteforceGuard *bruteforce.Guard,
+	bruteforceService bruteforce.Service,
+	cfg *config.Config,
+	logger *zap.SugaredLogger,
 ) *Route {
+	emailLimit := bruteforce.Config{
+		MaxAttempts:     emailRateLimit,
+		Window:          emailRateWindow,
+		Lockout:         emailRateWindow,
+		FailureStatuses: []int{http.StatusAccepted},
+	}
+	userKey := func(c *gin.Context) (string, error) {
+		return c.GetString("userId"), nil
+	}
+
 	return &Route{
-		controller,
-		middleware,
-		bruteforceGuard,
+		controller:      controller,
+		middleware:      middleware,
+		bruteforceGuard: bruteforceGuard,
+		passwordResetGuard: bruteforce.New(
+			emailLimit,
+			bruteforceService,
+			bruteforce.KeyWithPrefix("password-reset", bruteforce.KeyByIPAndBodyField("email")),
+			logger,
+		),
+		emailVerificationGuard: bruteforce.New(
+			emailLimit,
+			bruteforceService,
+			bruteforce.KeyWithPrefix("email-verification", userKey),
+			logger,
+		),
+		actionTokenGuard: bruteforce.New(
+			bruteforce.Config{
+				MaxAttempts:     cfg.BruteforceMaxAttempts,
+				Window:          cfg.BruteforceWindow,
+				Lockout:         cfg.BruteforceLockout,
+				FailureStatuses: []int{http.StatusBadRequest},
+			},
+			bruteforceService,
+			bruteforce.KeyWithPrefix("action-token", func(c *gin.Context) (string, error) {
+				return c.ClientIP(), nil
+			}),
+			logger,
+		),
 	}
 }
 
@@ -32,10 +85,15 @@ func (r *Route) ConnectRoute(router *gin.RouterGroup, controller *Controller) {
 
 	auth.POST("/refresh", controller.RefreshToken)
 
+	auth.POST("/password/forgot", r.passwordResetGuard.Middleware(), controller.ForgotPassword)
+	auth.POST("/password/reset", r.actionTokenGuard.Middleware(), controller.ResetPassword)
+	auth.POST("/email/verify", r.actionTokenGuard.Middleware(), controller.VerifyEmail)
+
 	auth.Use(r.middleware.Auth())
 	auth.POST("/2fa/setup", controller.SetupTwoFA)
 	auth.POST("/2fa/verify", controller.VerifyTwoFA)
 	auth.POST("/2fa/disable", controller.DisableTwoFA)
 	auth.PUT("/password", controller.UpdatePassword)
 	auth.PUT("/profile", controller.UpdateProfile)
+	auth.POST("/email/verification", r.emailVerificationGuard.Middleware(), controller.SendEmailVerification)
 }
@@ -2,15 +2,33 @@ package auth
 
 import (
 	"context"
+	"crypto/subtle"
 	"errors"
 	"time"
 	"vigi/internal/config"
+	"vigi/internal/pkg/usesend"
 
 	"github.com/pquerna/otp/totp"
 	"go.uber.org/zap"
 	"golang.org/x/crypto/bcrypt"
 )
 
+// ErrPasswordLoginDisabled is returned by Register and Login when
+// DISABLE_PASSWORD_LOGIN leaves single sign-on as the only way in
+var ErrPasswordLoginDisabled = errors.New("password login is disabled")
+
+var (
+	// ErrInvalidActionToken is returned for password reset and email
+	// verification tokens that are malformed, expired or already used
+	ErrInvalidActionToken = errors.New("invalid or expired link")
+	ErrEmailVerified      = errors.New("email is already verified")
+)
+
+// emailClient is the part of the usesend client used to deliver emails
+type emailClient interface {
+	SendEmail(ctx context.Context, req usesend.SendEmailRequest) (*usesend.SendEmailResponse, error)
+}
+
 type Service interface {
 	Register(ctx context.Context, dto RegisterDto) (*LoginResponse, error)
 	Login(ctx context.Context, dto LoginDto) (*LoginResponse, error)
@@ -22,30 +40,45 @@ type Service interface {
 	SetupTwoFA(ctx context.Context, userId, password string) (secret string, provisioningURI string, err error)
 	VerifyTwoFA(ctx context.Context, userId, code string) (bool, error)
 	DisableTwoFA(ctx context.Context, userId, password string) error
+
+	// RequestPasswordReset emails a reset link. Unknown emails are ignored so
+	// the response does not reveal which accounts exist.
+	RequestPasswordReset(ctx context.Context, email string) error
+	// ResetPassword sets a new password and revokes the existing refresh tokens
+	ResetPassword(ctx context.Context, dto ResetPasswordDto) error
+	SendEmailVerification(ctx context.Context, userId string) error
+	VerifyEmail(ctx context.Context, token string) error
 }
 
 type ServiceImpl struct {
-	repo       Repository
-	tokenMaker *TokenMaker
-	logger     *zap.SugaredLogger
-	cfg        *config.Config
+	repo        Repository
+	tokenMaker  *TokenMaker
+	emailClient emailClient
+	logger      *zap.SugaredLogger
+	cfg         *config.Config
 }
 
 func NewService(
 	repo Repository,
 	tokenMaker *TokenMaker,
+	usesendClient *usesend.Client,
 	logger *zap.SugaredLogger,
 	cfg *config.Config,
 ) Service {
 	return &ServiceImpl{
-		repo:       repo,
-		tokenMaker: tokenMaker,
-		logger:     logger.Named("[auth-service]"),
-		cfg:        cfg,
+		repo:        repo,
+		tokenMaker:  tokenMaker,
+		emailClient: usesendClient,
+		logger:      logger.Named("[auth-service]"),
+		cfg:         cfg,
 	}
 }
 
 func (s *ServiceImpl) Register(ctx context.Context, dto RegisterDto) (*LoginResponse, error) {
+	if s.cfg.DisablePasswordLogin {
+		return nil, ErrPasswordLoginDisabled
+	}
+
 	if s.cfg.EnableSingleAdmin {
 		count, err := s.repo.FindAllCount(ctx)
 		if err != nil {
@@ -103,6 +136,11 @@ func (s *ServiceImpl) Register(ctx context.Context, dto RegisterDto) (*LoginResp
 		return nil, err
 	}
 
+	// The account is usable right away, a failed email only delays verification
+	if err := s.sendActionEmail(ctx, user, TokenTypeEmailVerification, s.cfg.EmailVerificationTTL); err != nil {
+		s.logger.Errorw("Failed to send verification email", "userId", user.ID, "error", err)
+	}
+
 	// Generate access token
 	accessToken, err := s.tokenMaker.CreateAccessToken(ctx, user)
 	if err != nil {
@@ -123,6 +161,10 @@ func (s *ServiceImpl) Register(ctx context.Context, dto RegisterDto) (*LoginResp
 }
 
 func (s *ServiceImpl) Login(ctx context.Context, dto LoginDto) (*LoginResponse, error) {
+	if s.cfg.DisablePasswordLogin {
+		return nil, ErrPasswordLoginDisabled
+	}
+
 	// Find admin by email
 	user, err := s.repo.FindByEmail(ctx, dto.Email)
 	if err != nil {
@@ -185,6 +227,13 @@ func (s *ServiceImpl) RefreshToken(ctx context.Context, refreshToken string) (*L
 		return nil, errors.New("user not found")
 	}
 
+	// Refresh tokens issued before a password reset are revoked. IssuedAt has
+	// second precision, so compare against the truncated revocation time.
+	if user.TokensRevokedAt != nil && claims.IssuedAt != nil &&
+		claims.IssuedAt.Time.Before(user.TokensRevokedAt.Truncate(time.Second)) {
+		return nil, errors.New("invalid refresh token")
+	}
+
 	// Generate new access token
 	accessToken, err := s.tokenMaker.CreateAccessToken(ctx, user)
 	if err != nil {
@@ -344,3 +393,98 @@ func (s *ServiceImpl) DisableTwoFA(ctx context.Context, userId, password string)
 
 	return nil
 }
+
+func (s *ServiceImpl) RequestPasswordReset(ctx context.Context, email string) error {
+	if s.cfg.DisablePasswordLogin {
+		return ErrPasswordLoginDisabled
+	}
+
+	user, err := s.repo.FindByEmail(ctx, email)
+	if err != nil {
+		return err
+	}
+	if user == nil || !user.Active {
+		s.logger.Infow("Password reset requested for unknown or inactive account")
+		return nil
+	}
+
+	return s.sendActionEmail(ctx, user, TokenTypePasswordReset, s.cfg.PasswordResetTTL)
+}
+
+func (s *ServiceImpl) ResetPassword(ctx context.Context, dto ResetPasswordDto) error {
+	if s.cfg.DisablePasswordLogin {
+		return ErrPasswordLoginDisabled
+	}
+
+	user, err := s.userForActionToken(ctx, dto.Token, TokenTypePasswordReset)
+	if err != nil {
+		return err
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(dto.NewPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return errors.New("failed to hash new password")
+	}
+
+	// Receiving the link proves ownership of the email address
+	password := string(hashedPassword)
+	verified := true
+	revokedAt := time.Now().UTC()
+	err = s.repo.Update(ctx, user.ID, &UpdateModel{
+		Password:        &password,
+		EmailVerified:   &verified,
+		TokensRevokedAt: &revokedAt,
+	})
+	if err != nil {
+		return errors.New("failed to update password")
+	}
+
+	s.logger.Infow("Password reset", "userId", user.ID)
+	return nil
+}
+
+func (s *ServiceImpl) SendEmailVerification(ctx context.Context, userId string) error {
+	user, err := s.repo.FindByID(ctx, userId)
+	if err != nil || user == nil {
+		return errors.New("user not found")
+	}
+	if user.EmailVerified {
+		return ErrEmailVerified
+	}
+
+	return s.sendActionEmail(ctx, user, TokenTypeEmailVerification, s.cfg.EmailVerificationTTL)
+}
+
+func (s *ServiceImpl) VerifyEmail(ctx context.Context, token string) error {
+	user, err := s.userForActionToken(ctx, token, TokenTypeEmailVerification)
+	if err != nil {
+		return err
+	}
+
+	verified := true
+	if err := s.repo.Update(ctx, user.ID, &UpdateModel{EmailVerified: &verified}); err != nil {
+		return errors.New("failed to verify email")
+	}
+
+	return nil
+}
+
+// userForActionToken returns the user an emailed token was issued to, as long
+// as the state it was issued for did not change since
+func (s *ServiceImpl) userForActionToken(ctx context.Context, token string, tokenType string) (*Model, error) {
+	claims, err := s.tokenMaker.VerifyToken(ctx, token, tokenType)
+	if err != nil || claims.Type != tokenType {
+		return nil, ErrInvalidActionToken
+	}
+
+	user, err := s.repo.FindByID(ctx, claims.UserID)
+	if err != nil || user == nil || !user.Active {
+		return nil, ErrInvalidActionToken
+	}
+
+	if subtle.ConstantTimeCompare([]byte(claims.Fingerprint), []byte(ActionFingerprint(user, tokenType))) != 1 {
+		return nil, ErrInvalidActionToken
+	}
+
+	return user, nil
+}
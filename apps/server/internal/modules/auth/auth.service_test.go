@@ -2,13 +2,18 @@ package auth
 
 import (
 	"context"
+	"regexp"
 	"testing"
+	"time"
 	"vigi/internal/config"
 	"vigi/internal/modules/shared"
+	"vigi/internal/pkg/usesend"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
 )
 
 // MockRepository
@@ -105,7 +110,42 @@ func createTestService(t *testing.T, repo Repository, cfg *config.Config, settin
 	settingService.On("GetByKey", mock.Anything, "REFRESH_TOKEN_SECRET_KEY").Return(&shared.SettingModel{Value: "12345678901234567890123456789012"}, nil).Maybe()
 
 	tokenMaker := NewTokenMaker(settingService, sugar)
-	return NewService(repo, tokenMaker, sugar, cfg)
+	emailClient := &MockEmailClient{}
+	emailClient.On("SendEmail", mock.Anything, mock.Anything).Return(&usesend.SendEmailResponse{}, nil).Maybe()
+
+	return &ServiceImpl{
+		repo:        repo,
+		tokenMaker:  tokenMaker,
+		emailClient: emailClient,
+		logger:      sugar.Named("[auth-service]"),
+		cfg:         cfg,
+	}
+}
+
+// MockEmailClient records the emails sent by the service
+type MockEmailClient struct {
+	mock.Mock
+}
+
+func (m *MockEmailClient) SendEmail(ctx context.Context, req usesend.SendEmailRequest) (*usesend.SendEmailResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usesend.SendEmailResponse), args.Error(1)
+}
+
+var emailTokenPattern = regexp.MustCompile(`token=([\w.-]+)`)
+
+// sentToken returns the token of the link in the last email sent by the service
+func sentToken(t *testing.T, service Service) string {
+	t.Helper()
+	emailClient := service.(*ServiceImpl).emailClient.(*MockEmailClient)
+	require.NotEmpty(t, emailClient.Calls)
+	req := emailClient.Calls[len(emailClient.Calls)-1].Arguments.Get(1).(usesend.SendEmailRequest)
+	match := emailTokenPattern.FindStringSubmatch(req.HTML)
+	require.Len(t, match, 2)
+	return match[1]
 }
 
 func TestServiceImpl_Register_SingleAdminMode(t *testing.T) {
@@ -176,3 +216,159 @@ func TestServiceImpl_Register_SingleAdminMode(t *testing.T) {
 		})
 	}
 }
+
+func TestServiceImpl_PasswordLoginDisabled(t *testing.T) {
+	mockRepo := new(MockRepository)
+	cfg := &config.Config{DisablePasswordLogin: true}
+	service := createTestService(t, mockRepo, cfg, new(MockAuthTestSettingService))
+
+	_, err := service.Register(context.Background(), RegisterDto{Email: "test@example.com", Password: "Password123!", Name: "Test"})
+	assert.ErrorIs(t, err, ErrPasswordLoginDisabled)
+
+	_, err = service.Login(context.Background(), LoginDto{Email: "test@example.com", Password: "Password123!"})
+	assert.ErrorIs(t, err, ErrPasswordLoginDisabled)
+
+	mockRepo.AssertNotCalled(t, "FindByEmail", mock.Anything, mock.Anything)
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestServiceImpl_PasswordReset(t *testing.T) {
+	ctx := context.Background()
+	cfg := &config.Config{ClientURL: "https://vigi.example.com", PasswordResetTTL: time.Hour}
+	user := &Model{ID: "user-1", Email: "user@example.com", Password: "old-hash", Active: true}
+
+	t.Run("unknown email sends nothing", func(t *testing.T) {
+		mockRepo := &MockRepository{}
+		service := createTestService(t, mockRepo, cfg, &MockAuthTestSettingService{})
+		mockRepo.On("FindByEmail", mock.Anything, "nobody@example.com").Return(nil, nil)
+
+		assert.NoError(t, service.RequestPasswordReset(ctx, "nobody@example.com"))
+		service.(*ServiceImpl).emailClient.(*MockEmailClient).AssertNotCalled(t, "SendEmail", mock.Anything, mock.Anything)
+	})
+
+	t.Run("reset with emailed token", func(t *testing.T) {
+		mockRepo := &MockRepository{}
+		service := createTestService(t, mockRepo, cfg, &MockAuthTestSettingService{})
+		mockRepo.On("FindByEmail", mock.Anything, user.Email).Return(user, nil)
+		mockRepo.On("FindByID", mock.Anything, user.ID).Return(user, nil)
+
+		require.NoError(t, service.RequestPasswordReset(ctx, user.Email))
+		token := sentToken(t, service)
+
+		var update *UpdateModel
+		mockRepo.On("Update", mock.Anything, user.ID, mock.Anything).Run(func(args mock.Arguments) {
+			update = args.Get(2).(*UpdateModel)
+		}).Return(nil)
+
+		require.NoError(t, service.ResetPassword(ctx, ResetPasswordDto{Token: token, NewPassword: "NewPassword1!"}))
+		require.NotNil(t, update)
+		assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(*update.Password), []byte("NewPassword1!")))
+		assert.True(t, *update.EmailVerified)
+		assert.WithinDuration(t, time.Now(), *update.TokensRevokedAt, time.Second)
+	})
+
+	t.Run("token is single use", func(t *testing.T) {
+		mockRepo := &MockRepository{}
+		service := createTestService(t, mockRepo, cfg, &MockAuthTestSettingService{})
+		mockRepo.On("FindByEmail", mock.Anything, user.Email).Return(user, nil)
+
+		require.NoError(t, service.RequestPasswordReset(ctx, user.Email))
+		token := sentToken(t, service)
+
+		// The password changed since the token was issued
+		changed := *user
+		changed.Password = "new-hash"
+		mockRepo.On("FindByID", mock.Anything, user.ID).Return(&changed, nil)
+
+		err := service.ResetPassword(ctx, ResetPasswordDto{Token: token, NewPassword: "NewPassword1!"})
+		assert.ErrorIs(t, err, ErrInvalidActionToken)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("other token types are rejected", func(t *testing.T) {
+		mockRepo := &MockRepository{}
+		settings := &MockAuthTestSettingService{}
+		service := createTestService(t, mockRepo, cfg, settings)
+		mockRepo.On("FindByID", mock.Anything, user.ID).Return(user, nil)
+
+		tokenMaker := NewTokenMaker(settings, zap.NewNop().Sugar())
+		verification, err := tokenMaker.CreateActionToken(ctx, user, TokenTypeEmailVerification, time.Hour)
+		require.NoError(t, err)
+		access, err := tokenMaker.CreateAccessToken(ctx, user)
+		require.NoError(t, err)
+
+		for _, token := range []string{verification, access, "not-a-token"} {
+			err := service.ResetPassword(ctx, ResetPasswordDto{Token: token, NewPassword: "NewPassword1!"})
+			assert.ErrorIs(t, err, ErrInvalidActionToken)
+		}
+	})
+}
+
+func TestServiceImpl_VerifyEmail(t *testing.T) {
+	ctx := context.Background()
+	cfg := &config.Config{ClientURL: "https://vigi.example.com", EmailVerificationTTL: 48 * time.Hour}
+	user := &Model{ID: "user-1", Email: "user@example.com", Active: true}
+
+	mockRepo := &MockRepository{}
+	service := createTestService(t, mockRepo, cfg, &MockAuthTestSettingService{})
+	mockRepo.On("FindByID", mock.Anything, user.ID).Return(user, nil).Once()
+
+	require.NoError(t, service.SendEmailVerification(ctx, user.ID))
+	token := sentToken(t, service)
+
+	mockRepo.On("FindByID", mock.Anything, user.ID).Return(user, nil).Once()
+	mockRepo.On("Update", mock.Anything, user.ID, mock.MatchedBy(func(update *UpdateModel) bool {
+		return update.EmailVerified != nil && *update.EmailVerified && update.Password == nil
+	})).Return(nil).Once()
+	require.NoError(t, service.VerifyEmail(ctx, token))
+
+	// Once verified, the link and resending are rejected
+	verified := *user
+	verified.EmailVerified = true
+	mockRepo.On("FindByID", mock.Anything, user.ID).Return(&verified, nil)
+	assert.ErrorIs(t, service.VerifyEmail(ctx, token), ErrInvalidActionToken)
+	assert.ErrorIs(t, service.SendEmailVerification(ctx, user.ID), ErrEmailVerified)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestServiceImpl_RefreshToken_Revoked(t *testing.T) {
+	ctx := context.Background()
+	settings := &MockAuthTestSettingService{}
+	mockRepo := &MockRepository{}
+	service := createTestService(t, mockRepo, &config.Config{}, settings)
+
+	user := &Model{ID: "user-1", Email: "user@example.com", Active: true}
+	refreshToken, err := NewTokenMaker(settings, zap.NewNop().Sugar()).CreateRefreshToken(ctx, user)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		revokedAt *time.Time
+		expectErr bool
+	}{
+		{name: "never revoked", revokedAt: nil},
+		{name: "revoked before issue", revokedAt: ptrTime(time.Now().Add(-time.Hour))},
+		{name: "revoked after issue", revokedAt: ptrTime(time.Now().Add(2 * time.Second)), expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			revoked := *user
+			revoked.TokensRevokedAt = tt.revokedAt
+			mockRepo.ExpectedCalls = nil
+			mockRepo.On("FindByID", mock.Anything, user.ID).Return(&revoked, nil)
+
+			_, err := service.RefreshToken(ctx, refreshToken)
+			if tt.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func ptrTime(t time.Time) *time.Time {
+	return &t
+}
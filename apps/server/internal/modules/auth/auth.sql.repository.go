@@ -11,51 +11,57 @@ import (
 type sqlModel struct {
 	bun.BaseModel `bun:"table:users,alias:u"`
 
-	ID             string    `bun:"id,pk"`
-	Email          string    `bun:"email,unique,notnull"`
-	Name           string    `bun:"name"`
-	ImageURL       string    `bun:"image_url"`
-	Password       string    `bun:"password,notnull"`
-	Active         bool      `bun:"active,notnull,default:true"`
-	TwoFASecret    string    `bun:"twofa_secret"`
-	TwoFAStatus    bool      `bun:"twofa_status,notnull,default:false"`
-	TwoFALastToken string    `bun:"twofa_last_token"`
-	Role           string    `bun:"role"`
-	CreatedAt      time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp"`
-	UpdatedAt      time.Time `bun:"updated_at,nullzero,notnull,default:current_timestamp"`
+	ID              string     `bun:"id,pk"`
+	Email           string     `bun:"email,unique,notnull"`
+	Name            string     `bun:"name"`
+	ImageURL        string     `bun:"image_url"`
+	Password        string     `bun:"password,notnull"`
+	Active          bool       `bun:"active,notnull,default:true"`
+	TwoFASecret     string     `bun:"twofa_secret"`
+	TwoFAStatus     bool       `bun:"twofa_status,notnull,default:false"`
+	TwoFALastToken  string     `bun:"twofa_last_token"`
+	Role            string     `bun:"role"`
+	EmailVerified   bool       `bun:"email_verified,notnull,default:false"`
+	TokensRevokedAt *time.Time `bun:"tokens_revoked_at"`
+	CreatedAt       time.Time  `bun:"created_at,nullzero,notnull,default:current_timestamp"`
+	UpdatedAt       time.Time  `bun:"updated_at,nullzero,notnull,default:current_timestamp"`
 }
 
 func toDomainModelFromSQL(sm *sqlModel) *Model {
 	return &Model{
-		ID:             sm.ID,
-		Email:          sm.Email,
-		Name:           sm.Name,
-		ImageURL:       sm.ImageURL,
-		Password:       sm.Password,
-		Active:         sm.Active,
-		TwoFASecret:    sm.TwoFASecret,
-		TwoFAStatus:    sm.TwoFAStatus,
-		TwoFALastToken: sm.TwoFALastToken,
-		Role:           sm.Role,
-		CreatedAt:      sm.CreatedAt,
-		UpdatedAt:      sm.UpdatedAt,
+		ID:              sm.ID,
+		Email:           sm.Email,
+		Name:            sm.Name,
+		ImageURL:        sm.ImageURL,
+		Password:        sm.Password,
+		Active:          sm.Active,
+		TwoFASecret:     sm.TwoFASecret,
+		TwoFAStatus:     sm.TwoFAStatus,
+		TwoFALastToken:  sm.TwoFALastToken,
+		Role:            sm.Role,
+		EmailVerified:   sm.EmailVerified,
+		TokensRevokedAt: sm.TokensRevokedAt,
+		CreatedAt:       sm.CreatedAt,
+		UpdatedAt:       sm.UpdatedAt,
 	}
 }
 
 func toSQLModel(m *Model) *sqlModel {
 	return &sqlModel{
-		ID:             m.ID,
-		Email:          m.Email,
-		Name:           m.Name,
-		ImageURL:       m.ImageURL,
-		Password:       m.Password,
-		Active:         m.Active,
-		TwoFASecret:    m.TwoFASecret,
-		TwoFAStatus:    m.TwoFAStatus,
-		TwoFALastToken: m.TwoFALastToken,
-		Role:           m.Role,
-		CreatedAt:      m.CreatedAt,
-		UpdatedAt:      m.UpdatedAt,
+		ID:              m.ID,
+		Email:           m.Email,
+		Name:            m.Name,
+		ImageURL:        m.ImageURL,
+		Password:        m.Password,
+		Active:          m.Active,
+		TwoFASecret:     m.TwoFASecret,
+		TwoFAStatus:     m.TwoFAStatus,
+		TwoFALastToken:  m.TwoFALastToken,
+		Role:            m.Role,
+		EmailVerified:   m.EmailVerified,
+		TokensRevokedAt: m.TokensRevokedAt,
+		CreatedAt:       m.CreatedAt,
+		UpdatedAt:       m.UpdatedAt,
 	}
 }
 
@@ -79,6 +85,7 @@ func (r *SQLRepositoryImpl) Create(ctx context.Context, user *Model) (*Model, er
 		TwoFAStatus:    user.TwoFAStatus,
 		TwoFALastToken: user.TwoFALastToken,
 		Role:           user.Role,
+		EmailVerified:  user.EmailVerified,
 		CreatedAt:      time.Now(),
 		UpdatedAt:      time.Now(),
 	}
@@ -176,6 +183,14 @@ func (r *SQLRepositoryImpl) Update(ctx context.Context, id string, entity *Updat
 		query = query.Set("role = ?", *entity.Role)
 		hasUpdates = true
 	}
+	if entity.EmailVerified != nil {
+		query = query.Set("email_verified = ?", *entity.EmailVerified)
+		hasUpdates = true
+	}
+	if entity.TokensRevokedAt != nil {
+		query = query.Set("tokens_revoked_at = ?", *entity.TokensRevokedAt)
+		hasUpdates = true
+	}
 
 	if !hasUpdates {
 		return nil
@@ -2,6 +2,8 @@ package auth
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"time"
@@ -16,11 +18,19 @@ var (
 	ErrExpiredToken = errors.New("token has expired")
 )
 
+// Single use tokens sent by email
+const (
+	TokenTypePasswordReset     = "password_reset"
+	TokenTypeEmailVerification = "email_verification"
+)
+
 type Claims struct {
 	UserID string `json:"userId"`
 	Email  string `json:"email"`
 	Role   string `json:"role"`
-	Type   string `json:"type"` // "access" or "refresh"
+	Type   string `json:"type"` // "access", "refresh" or one of the emailed token types
+	// Fingerprint of the user state an emailed token applies to
+	Fingerprint string `json:"fp,omitempty"`
 	jwt.RegisteredClaims
 }
 
@@ -90,6 +100,51 @@ func (maker *TokenMaker) CreateRefreshToken(ctx context.Context, user *Model) (s
 	return maker.createToken(user, "refresh", refreshExpiry, secretSetting.Value)
 }
 
+// CreateActionToken creates a password reset or email verification token.
+// The token carries a fingerprint of the state it changes, so it stops being
+// valid once it was used.
+func (maker *TokenMaker) CreateActionToken(ctx context.Context, user *Model, tokenType string, duration time.Duration) (string, error) {
+	secretSetting, err := maker.settingService.GetByKey(ctx, "ACCESS_TOKEN_SECRET_KEY")
+	if err != nil {
+		return "", fmt.Errorf("failed to get access token secret key: %w", err)
+	}
+	if secretSetting == nil {
+		return "", fmt.Errorf("access token secret key setting not found")
+	}
+
+	now := time.Now().UTC()
+	claims := &Claims{
+		UserID:      user.ID,
+		Email:       user.Email,
+		Role:        user.Role,
+		Type:        tokenType,
+		Fingerprint: ActionFingerprint(user, tokenType),
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(now.Add(duration)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(secretSetting.Value))
+}
+
+// ActionFingerprint hashes the user state an emailed token is bound to: the
+// password hash for resets, the email and its verification for verifications
+func ActionFingerprint(user *Model, tokenType string) string {
+	var state string
+	switch tokenType {
+	case TokenTypePasswordReset:
+		state = user.Password
+	case TokenTypeEmailVerification:
+		state = fmt.Sprintf("%s:%t", user.Email, user.EmailVerified)
+	}
+
+	sum := sha256.Sum256([]byte(tokenType + ":" + user.ID + ":" + state))
+	return hex.EncodeToString(sum[:])
+}
+
 func (maker *TokenMaker) createToken(user *Model, tokenType string, duration time.Duration, secretKey string) (string, error) {
 	claims := &Claims{
 		UserID: user.ID,
@@ -113,7 +168,7 @@ func (maker *TokenMaker) VerifyToken(ctx context.Context, tokenString string, to
 
 	// Get the appropriate secret key based on token type
 	switch tokenType {
-	case "access":
+	case "access", TokenTypePasswordReset, TokenTypeEmailVerification:
 		secretSetting, err := maker.settingService.GetByKey(ctx, "ACCESS_TOKEN_SECRET_KEY")
 		if err != nil {
 			return nil, fmt.Errorf("failed to get access token secret key: %w", err)
@@ -153,6 +208,11 @@ func (maker *TokenMaker) VerifyToken(ctx context.Context, tokenString string, to
 	if !ok || !token.Valid {
 		return nil, ErrInvalidToken
 	}
+	// Access and emailed tokens share a key, an emailed token must not be
+	// usable as an access token and the other way around
+	if claims.Type != tokenType {
+		return nil, ErrInvalidToken
+	}
 
 	return claims, nil
 }
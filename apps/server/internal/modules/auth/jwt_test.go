@@ -655,6 +655,39 @@ func TestTokenMaker_VerifyToken_WrongTokenType(t *testing.T) {
 	mockSettingService.AssertExpectations(t)
 }
 
+func TestTokenMaker_VerifyToken_ActionTokenAsAccessToken(t *testing.T) {
+	mockSettingService := &MockSettingService{}
+	logger := zap.NewNop().Sugar()
+	tokenMaker := NewTokenMaker(mockSettingService, logger)
+
+	ctx := context.Background()
+	user := &Model{
+		ID:    "user123",
+		Email: "test@example.com",
+	}
+
+	mockSettingService.On("GetByKey", ctx, "ACCESS_TOKEN_SECRET_KEY").Return(&shared.SettingModel{
+		Key:   "ACCESS_TOKEN_SECRET_KEY",
+		Value: "test-secret-key",
+	}, nil)
+
+	// Both are signed with the access token key
+	actionToken, err := tokenMaker.CreateActionToken(ctx, user, TokenTypeEmailVerification, time.Hour)
+	assert.NoError(t, err)
+
+	claims, err := tokenMaker.VerifyToken(ctx, actionToken, "access")
+	assert.Nil(t, claims)
+	assert.Equal(t, ErrInvalidToken, err)
+
+	claims, err = tokenMaker.VerifyToken(ctx, actionToken, TokenTypePasswordReset)
+	assert.Nil(t, claims)
+	assert.Equal(t, ErrInvalidToken, err)
+
+	claims, err = tokenMaker.VerifyToken(ctx, actionToken, TokenTypeEmailVerification)
+	assert.NoError(t, err)
+	assert.Equal(t, TokenTypeEmailVerification, claims.Type)
+}
+
 func TestTokenMaker_VerifyToken_InvalidSigningMethod(t *testing.T) {
 	mockSettingService := &MockSettingService{}
 	logger := zap.NewNop().Sugar()
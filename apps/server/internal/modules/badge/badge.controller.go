@@ -49,12 +49,18 @@ func (c *Controller) parseQueryOptions(ctx *gin.Context) *BadgeOptions {
 	if downLabel := ctx.Query("downLabel"); downLabel != "" {
 		options.DownLabel = downLabel
 	}
+	if degradedLabel := ctx.Query("degradedLabel"); degradedLabel != "" {
+		options.DegradedLabel = degradedLabel
+	}
 	if upColor := ctx.Query("upColor"); upColor != "" {
 		options.UpColor = upColor
 	}
 	if downColor := ctx.Query("downColor"); downColor != "" {
 		options.DownColor = downColor
 	}
+	if degradedColor := ctx.Query("degradedColor"); degradedColor != "" {
+		options.DegradedColor = degradedColor
+	}
 
 	// Parse text customization options
 	if labelPrefix := ctx.Query("labelPrefix"); labelPrefix != "" {
@@ -98,6 +104,8 @@ func (c *Controller) parseQueryOptions(ctx *gin.Context) *BadgeOptions {
 // @Param			downLabel	query	string	false	"Label when monitor is down"
 // @Param			upColor		query	string	false	"Color when monitor is up"
 // @Param			downColor	query	string	false	"Color when monitor is down"
+// @Param			degradedLabel	query	string	false	"Label when monitor is degraded"
+// @Param			degradedColor	query	string	false	"Color when monitor is degraded"
 // @Success		200	{string}	string	"SVG badge"
 // @Failure		400	{object}	utils.APIError[any]
 // @Failure		404	{object}	utils.APIError[any]
@@ -333,3 +341,53 @@ func (c *Controller) GetResponseBadge(ctx *gin.Context) {
 	ctx.Header("Cache-Control", "no-cache, no-store, must-revalidate")
 	ctx.String(http.StatusOK, svg)
 }
+
+// @Router		/badge/group/{groupId}/status [get]
+// @Summary		Get monitor group status badge
+// @Tags			Badges
+// @Produce		image/svg+xml
+// @Param			groupId		path	string	true	"Monitor group ID"
+// @Param			style		query	string	false	"Badge style (flat, flat-square, plastic, for-the-badge, social)"
+// @Param			label		query	string	false	"Custom label, defaults to the group name"
+// @Param			upLabel		query	string	false	"Label when the group is up"
+// @Param			downLabel	query	string	false	"Label when the group is down"
+// @Param			upColor		query	string	false	"Color when the group is up"
+// @Param			downColor	query	string	false	"Color when the group is down"
+// @Param			degradedLabel	query	string	false	"Label when the group is degraded"
+// @Param			degradedColor	query	string	false	"Color when the group is degraded"
+// @Success		200	{string}	string	"SVG badge"
+// @Failure		400	{object}	utils.APIError[any]
+// @Failure		404	{object}	utils.APIError[any]
+// @Failure		500	{object}	utils.APIError[any]
+func (c *Controller) GetGroupStatusBadge(ctx *gin.Context) {
+	groupID := ctx.Param("groupId")
+	if groupID == "" {
+		ctx.JSON(http.StatusBadRequest, utils.NewFailResponse("Group ID is required"))
+		return
+	}
+
+	// Check if group is public (shown on a status page)
+	isPublic, err := c.service.IsGroupPublic(ctx, groupID)
+	if err != nil {
+		c.logger.Errorw("Failed to check if monitor group is public", "error", err, "groupID", groupID)
+		ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse("Internal server error"))
+		return
+	}
+	if !isPublic {
+		ctx.JSON(http.StatusNotFound, utils.NewFailResponse("Monitor group not found or not public"))
+		return
+	}
+
+	options := c.parseQueryOptions(ctx)
+
+	svg, err := c.service.GenerateGroupStatusBadge(ctx, groupID, options)
+	if err != nil {
+		c.logger.Errorw("Failed to generate group status badge", "error", err, "groupID", groupID)
+		ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse("Failed to generate badge"))
+		return
+	}
+
+	ctx.Header("Content-Type", "image/svg+xml")
+	ctx.Header("Cache-Control", "no-cache, no-store, must-revalidate")
+	ctx.String(http.StatusOK, svg)
+}
@@ -46,10 +46,12 @@ type BadgeOptions struct {
 	LabelColor string     `json:"label_color"`
 
 	// Status badge options
-	UpLabel   string `json:"up_label"`
-	DownLabel string `json:"down_label"`
-	UpColor   string `json:"up_color"`
-	DownColor string `json:"down_color"`
+	UpLabel       string `json:"up_label"`
+	DownLabel     string `json:"down_label"`
+	DegradedLabel string `json:"degraded_label"`
+	UpColor       string `json:"up_color"`
+	DownColor     string `json:"down_color"`
+	DegradedColor string `json:"degraded_color"`
 
 	// Text customization options
 	LabelPrefix string `json:"label_prefix"`
@@ -69,14 +71,16 @@ func DefaultBadgeOptions() *BadgeOptions {
 		Style:      BadgeStyleFlat,
 		Color:      "#007ec6", // Modern blue for general use
 		LabelColor: "#555",
-		UpLabel:    "Up",
-		DownLabel:  "Down",
-		UpColor:    "#4c1",    // Green for up status
-		DownColor:  "#e05d44", // Red for down status
-		Label:      "",
-		Suffix:     "",
-		WarnDays:   14,
-		DownDays:   7,
+		UpLabel:       "Up",
+		DownLabel:     "Down",
+		DegradedLabel: "Degraded",
+		UpColor:       "#4c1",    // Green for up status
+		DownColor:     "#e05d44", // Red for down status
+		DegradedColor: "#dfb317", // Yellow for degraded status
+		Label:         "",
+		Suffix:        "",
+		WarnDays:      14,
+		DownDays:      7,
 	}
 }
 
@@ -84,7 +88,7 @@ func DefaultBadgeOptions() *BadgeOptions {
 type MonitorBadgeData struct {
 	ID     string `json:"id"`
 	Name   string `json:"name"`
-	Status int    `json:"status"` // 0=down, 1=up, 2=pending, 3=maintenance
+	Status int    `json:"status"` // 0=down, 1=up, 2=pending, 3=maintenance, 4=degraded
 	Active bool   `json:"active"`
 
 	// Statistics
@@ -116,6 +120,8 @@ func (m *MonitorBadgeData) GetStatusText(options *BadgeOptions) string {
 		return "Pending"
 	case 3:
 		return "Maintenance"
+	case 4:
+		return options.DegradedLabel
 	default:
 		return "Unknown"
 	}
@@ -136,6 +142,8 @@ func (m *MonitorBadgeData) GetStatusColor(options *BadgeOptions) string {
 		return "#fe7d37"
 	case 3:
 		return "#7c69ef"
+	case 4:
+		return options.DegradedColor
 	default:
 		return "#9f9f9f"
 	}
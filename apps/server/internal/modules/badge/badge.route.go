@@ -34,5 +34,8 @@ func (r *Route) ConnectRoute(rg *gin.RouterGroup, controller *Controller) {
 
 		// Response time badge
 		badge.GET("/:monitorId/response", r.controller.GetResponseBadge)
+
+		// Monitor group status badge
+		badge.GET("/group/:groupId/status", r.controller.GetGroupStatusBadge)
 	}
 }
@@ -7,9 +7,11 @@ import (
 	"time"
 	"vigi/internal/modules/heartbeat"
 	"vigi/internal/modules/monitor"
+	"vigi/internal/modules/monitor_group"
 	"vigi/internal/modules/monitor_status_page"
 	"vigi/internal/modules/monitor_tls_info"
 	"vigi/internal/modules/stats"
+	"vigi/internal/modules/status_page"
 
 	"go.uber.org/zap"
 )
@@ -20,10 +22,12 @@ type Service interface {
 	GeneratePingBadge(ctx context.Context, monitorID string, duration int, options *BadgeOptions) (string, error)
 	GenerateCertExpBadge(ctx context.Context, monitorID string, options *BadgeOptions) (string, error)
 	GenerateResponseBadge(ctx context.Context, monitorID string, options *BadgeOptions) (string, error)
+	GenerateGroupStatusBadge(ctx context.Context, groupID string, options *BadgeOptions) (string, error)
 
 	// Helper methods
 	GetMonitorBadgeData(ctx context.Context, monitorID string) (*MonitorBadgeData, error)
 	IsMonitorPublic(ctx context.Context, monitorID string) (bool, error)
+	IsGroupPublic(ctx context.Context, groupID string) (bool, error)
 }
 
 type ServiceImpl struct {
@@ -32,6 +36,8 @@ type ServiceImpl struct {
 	statsService             stats.Service
 	tlsInfoService           monitor_tls_info.Service
 	monitorStatusPageService monitor_status_page.Service
+	monitorGroupService      monitor_group.Service
+	statusPageService        status_page.Service
 	svgGenerator             *SVGBadgeGenerator
 	logger                   *zap.SugaredLogger
 }
@@ -42,6 +48,8 @@ func NewService(
 	statsService stats.Service,
 	tlsInfoService monitor_tls_info.Service,
 	monitorStatusPageService monitor_status_page.Service,
+	monitorGroupService monitor_group.Service,
+	statusPageService status_page.Service,
 	logger *zap.SugaredLogger,
 ) Service {
 	return &ServiceImpl{
@@ -50,6 +58,8 @@ func NewService(
 		statsService:             statsService,
 		tlsInfoService:           tlsInfoService,
 		monitorStatusPageService: monitorStatusPageService,
+		monitorGroupService:      monitorGroupService,
+		statusPageService:        statusPageService,
 		svgGenerator:             NewSVGBadgeGenerator(),
 		logger:                   logger.Named("[badge-service]"),
 	}
@@ -85,6 +95,23 @@ func (s *ServiceImpl) IsMonitorPublic(ctx context.Context, monitorID string) (bo
 	return len(statusPages) > 0, nil
 }
 
+func (s *ServiceImpl) IsGroupPublic(ctx context.Context, groupID string) (bool, error) {
+	group, err := s.monitorGroupService.FindByID(ctx, groupID, "")
+	if err != nil {
+		return false, err
+	}
+	if group == nil {
+		return false, nil
+	}
+
+	// Group is public if it's a section of at least one status page
+	statusPages, err := s.statusPageService.FindByGroupID(ctx, groupID)
+	if err != nil {
+		return false, err
+	}
+	return len(statusPages) > 0, nil
+}
+
 func (s *ServiceImpl) GetMonitorBadgeData(ctx context.Context, monitorID string) (*MonitorBadgeData, error) {
 	// Get monitor basic info
 	monitorModel, err := s.monitorService.FindByID(ctx, monitorID, "")
@@ -301,6 +328,47 @@ func (s *ServiceImpl) GenerateStatusBadge(ctx context.Context, monitorID string,
 	return s.svgGenerator.GenerateBadge(badge), nil
 }
 
+func (s *ServiceImpl) GenerateGroupStatusBadge(ctx context.Context, groupID string, options *BadgeOptions) (string, error) {
+	group, err := s.monitorGroupService.FindByID(ctx, groupID, "")
+	if err != nil {
+		return "", fmt.Errorf("failed to get monitor group: %w", err)
+	}
+	if group == nil {
+		return "", fmt.Errorf("monitor group not found")
+	}
+
+	status, err := s.monitorGroupService.GetStatus(ctx, groupID, "")
+	if err != nil {
+		return "", fmt.Errorf("failed to get monitor group status: %w", err)
+	}
+	if status == nil {
+		return "", fmt.Errorf("monitor group not found")
+	}
+
+	// Groups are never paused, the rolled-up status maps onto the monitor badge colors
+	data := &MonitorBadgeData{
+		ID:     group.ID,
+		Name:   group.Name,
+		Status: int(status.Status),
+		Active: true,
+	}
+
+	badge := &Badge{
+		Type:       BadgeTypeStatus,
+		Style:      options.Style,
+		Label:      FormatLabel(getLabel(options.Label, group.Name), options.LabelPrefix, options.LabelSuffix),
+		Value:      data.GetStatusText(options),
+		Color:      data.GetStatusColor(options),
+		LabelColor: options.LabelColor,
+	}
+
+	if options.Color != "" && options.Color != DefaultBadgeOptions().Color {
+		badge.Color = options.Color
+	}
+
+	return s.svgGenerator.GenerateBadge(badge), nil
+}
+
 func (s *ServiceImpl) GenerateUptimeBadge(ctx context.Context, monitorID string, duration int, options *BadgeOptions) (string, error) {
 	data, err := s.getMonitorWithStats(ctx, monitorID, duration)
 	if err != nil {
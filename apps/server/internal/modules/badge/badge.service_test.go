@@ -8,10 +8,12 @@ import (
 	"vigi/internal/modules/events"
 	"vigi/internal/modules/heartbeat"
 	"vigi/internal/modules/monitor"
+	"vigi/internal/modules/monitor_group"
 	"vigi/internal/modules/monitor_status_page"
 	"vigi/internal/modules/monitor_tls_info"
 	"vigi/internal/modules/shared"
 	"vigi/internal/modules/stats"
+	"vigi/internal/modules/status_page"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -81,6 +83,11 @@ func (m *MockMonitorService) ValidateMonitorConfig(monitorType string, configJSO
 	return args.Error(0)
 }
 
+func (m *MockMonitorService) RestoreSecrets(ctx context.Context, id string, config string, orgID string) (string, error) {
+	args := m.Called(ctx, id, config, orgID)
+	return args.String(0), args.Error(1)
+}
+
 func (m *MockMonitorService) GetHeartbeats(ctx context.Context, id string, limit, page int, important *bool, reverse bool, orgID string) ([]*heartbeat.Model, error) {
 	args := m.Called(ctx, id, limit, page, important, reverse, orgID)
 	return args.Get(0).([]*heartbeat.Model), args.Error(1)
@@ -130,6 +137,14 @@ func (m *MockMonitorService) FindActivePaginated(ctx context.Context, page int,
 	return args.Get(0).([]*shared.Monitor), args.Error(1)
 }
 
+func (m *MockMonitorService) FindAllPaginated(ctx context.Context, page int, limit int) ([]*shared.Monitor, error) {
+	args := m.Called(ctx, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*shared.Monitor), args.Error(1)
+}
+
 type MockHeartbeatService struct {
 	mock.Mock
 }
@@ -180,6 +195,24 @@ func (m *MockHeartbeatService) DeleteByMonitorID(ctx context.Context, monitorID
 	return args.Error(0)
 }
 
+func (m *MockHeartbeatService) FindOldestByMonitorID(ctx context.Context, monitorID string) (*heartbeat.Model, error) {
+	args := m.Called(ctx, monitorID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*heartbeat.Model), args.Error(1)
+}
+
+func (m *MockHeartbeatService) FindByMonitorIDAndTimeRange(ctx context.Context, monitorID string, since, until time.Time) ([]*heartbeat.Model, error) {
+	args := m.Called(ctx, monitorID, since, until)
+	return args.Get(0).([]*heartbeat.Model), args.Error(1)
+}
+
+func (m *MockHeartbeatService) DeleteByMonitorIDOlderThan(ctx context.Context, monitorID string, cutoff time.Time) (int64, error) {
+	args := m.Called(ctx, monitorID, cutoff)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 type MockStatsService struct {
 	mock.Mock
 }
@@ -213,6 +246,16 @@ func (m *MockStatsService) DeleteByMonitorID(ctx context.Context, monitorID stri
 	return args.Error(0)
 }
 
+func (m *MockStatsService) RebuildRollups(ctx context.Context, monitorID string, heartbeats []*stats.HeartbeatPayload, since, until time.Time) (int, error) {
+	args := m.Called(ctx, monitorID, heartbeats, since, until)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockStatsService) DeleteOlderThan(ctx context.Context, monitorID string, period stats.StatPeriod, cutoff time.Time) (int64, error) {
+	args := m.Called(ctx, monitorID, period, cutoff)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 type MockTLSInfoService struct {
 	mock.Mock
 }
@@ -348,6 +391,42 @@ func (m *MockMonitorStatusPageService) DeleteAllMonitorsForStatusPage(ctx contex
 	return args.Error(0)
 }
 
+// MockMonitorGroupService only implements the lookups the badge service needs
+type MockMonitorGroupService struct {
+	monitor_group.Service
+	mock.Mock
+}
+
+func (m *MockMonitorGroupService) FindByID(ctx context.Context, id string, orgID string) (*monitor_group.Model, error) {
+	args := m.Called(ctx, id, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*monitor_group.Model), args.Error(1)
+}
+
+func (m *MockMonitorGroupService) GetStatus(ctx context.Context, id string, orgID string) (*monitor_group.StatusDto, error) {
+	args := m.Called(ctx, id, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*monitor_group.StatusDto), args.Error(1)
+}
+
+// MockStatusPageService only implements the lookups the badge service needs
+type MockStatusPageService struct {
+	status_page.Service
+	mock.Mock
+}
+
+func (m *MockStatusPageService) FindByGroupID(ctx context.Context, groupID string) ([]*status_page.Model, error) {
+	args := m.Called(ctx, groupID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*status_page.Model), args.Error(1)
+}
+
 // Test setup helper
 func setupBadgeService() (*ServiceImpl, *MockMonitorService, *MockHeartbeatService, *MockStatsService, *MockTLSInfoService, *MockMonitorStatusPageService) {
 	mockMonitorService := &MockMonitorService{}
@@ -363,6 +442,8 @@ func setupBadgeService() (*ServiceImpl, *MockMonitorService, *MockHeartbeatServi
 		mockStatsService,
 		mockTLSInfoService,
 		mockMonitorStatusPageService,
+		&MockMonitorGroupService{},
+		&MockStatusPageService{},
 		logger,
 	).(*ServiceImpl)
 
@@ -420,6 +501,8 @@ func TestNewService(t *testing.T) {
 		mockStatsService,
 		mockTLSInfoService,
 		mockMonitorStatusPageService,
+		&MockMonitorGroupService{},
+		&MockStatusPageService{},
 		logger,
 	)
 
@@ -877,6 +960,93 @@ func TestServiceImpl_GenerateStatusBadge(t *testing.T) {
 	})
 }
 
+func setupGroupBadgeService() (*ServiceImpl, *MockMonitorGroupService, *MockStatusPageService) {
+	mockMonitorGroupService := &MockMonitorGroupService{}
+	mockStatusPageService := &MockStatusPageService{}
+
+	service := NewService(
+		&MockMonitorService{},
+		&MockHeartbeatService{},
+		&MockStatsService{},
+		&MockTLSInfoService{},
+		&MockMonitorStatusPageService{},
+		mockMonitorGroupService,
+		mockStatusPageService,
+		zap.NewNop().Sugar(),
+	).(*ServiceImpl)
+
+	return service, mockMonitorGroupService, mockStatusPageService
+}
+
+func TestServiceImpl_IsGroupPublic(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("group is public when shown on a status page", func(t *testing.T) {
+		service, mockMonitorGroupService, mockStatusPageService := setupGroupBadgeService()
+
+		mockMonitorGroupService.On("FindByID", ctx, "group123", "").Return(&monitor_group.Model{ID: "group123"}, nil)
+		mockStatusPageService.On("FindByGroupID", ctx, "group123").Return([]*status_page.Model{{ID: "page1"}}, nil)
+
+		result, err := service.IsGroupPublic(ctx, "group123")
+
+		assert.NoError(t, err)
+		assert.True(t, result)
+	})
+
+	t.Run("group is not public when on no status page", func(t *testing.T) {
+		service, mockMonitorGroupService, mockStatusPageService := setupGroupBadgeService()
+
+		mockMonitorGroupService.On("FindByID", ctx, "group123", "").Return(&monitor_group.Model{ID: "group123"}, nil)
+		mockStatusPageService.On("FindByGroupID", ctx, "group123").Return([]*status_page.Model{}, nil)
+
+		result, err := service.IsGroupPublic(ctx, "group123")
+
+		assert.NoError(t, err)
+		assert.False(t, result)
+	})
+
+	t.Run("group not found", func(t *testing.T) {
+		service, mockMonitorGroupService, mockStatusPageService := setupGroupBadgeService()
+
+		mockMonitorGroupService.On("FindByID", ctx, "group123", "").Return(nil, nil)
+
+		result, err := service.IsGroupPublic(ctx, "group123")
+
+		assert.NoError(t, err)
+		assert.False(t, result)
+		mockStatusPageService.AssertNotCalled(t, "FindByGroupID", mock.Anything, mock.Anything)
+	})
+}
+
+func TestServiceImpl_GenerateGroupStatusBadge(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("uses the rolled-up status and the group name as label", func(t *testing.T) {
+		service, mockMonitorGroupService, _ := setupGroupBadgeService()
+
+		mockMonitorGroupService.On("FindByID", ctx, "group123", "").Return(&monitor_group.Model{ID: "group123", Name: "Payments"}, nil)
+		mockMonitorGroupService.On("GetStatus", ctx, "group123", "").Return(&monitor_group.StatusDto{GroupID: "group123", Status: shared.MonitorStatusDegraded}, nil)
+
+		result, err := service.GenerateGroupStatusBadge(ctx, "group123", DefaultBadgeOptions())
+
+		assert.NoError(t, err)
+		assert.Contains(t, result, "Payments")
+		assert.Contains(t, result, DefaultBadgeOptions().DegradedLabel)
+		mockMonitorGroupService.AssertExpectations(t)
+	})
+
+	t.Run("group not found", func(t *testing.T) {
+		service, mockMonitorGroupService, _ := setupGroupBadgeService()
+
+		mockMonitorGroupService.On("FindByID", ctx, "group123", "").Return(nil, nil)
+
+		result, err := service.GenerateGroupStatusBadge(ctx, "group123", DefaultBadgeOptions())
+
+		assert.Error(t, err)
+		assert.Empty(t, result)
+	})
+}
+
 func TestServiceImpl_GenerateUptimeBadge(t *testing.T) {
 	ctx := context.Background()
 
@@ -1414,6 +1584,14 @@ func TestMonitorBadgeData_GetStatusText(t *testing.T) {
 			},
 			expected: "Maintenance",
 		},
+		{
+			name: "degraded monitor",
+			data: &MonitorBadgeData{
+				Status: int(shared.MonitorStatusDegraded),
+				Active: true,
+			},
+			expected: options.DegradedLabel,
+		},
 		{
 			name: "inactive monitor",
 			data: &MonitorBadgeData{
@@ -1480,6 +1658,14 @@ func TestMonitorBadgeData_GetStatusColor(t *testing.T) {
 			},
 			expected: "#7c69ef",
 		},
+		{
+			name: "degraded monitor",
+			data: &MonitorBadgeData{
+				Status: int(shared.MonitorStatusDegraded),
+				Active: true,
+			},
+			expected: options.DegradedColor,
+		},
 		{
 			name: "inactive monitor",
 			data: &MonitorBadgeData{
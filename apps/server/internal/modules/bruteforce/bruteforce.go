@@ -194,3 +194,15 @@ func KeyByIPAndBodyField(field string) KeyExtractor {
 		return ip, nil
 	}
 }
+
+// KeyWithPrefix namespaces the keys of another extractor so guards protecting
+// different endpoints keep separate counters
+func KeyWithPrefix(prefix string, ke KeyExtractor) KeyExtractor {
+	return func(c *gin.Context) (string, error) {
+		key, err := ke(c)
+		if err != nil || key == "" {
+			key = c.ClientIP()
+		}
+		return prefix + ":" + key, nil
+	}
+}
@@ -137,6 +137,25 @@ func TestKeyByIPAndBodyField_Error(t *testing.T) {
 	assert.Equal(t, "1.1.1.1", key)
 }
 
+func TestKeyWithPrefix(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	body := map[string]string{"email": "user@example.com"}
+	b, _ := json.Marshal(body)
+	r := httptest.NewRequest("POST", "/", bytes.NewReader(b))
+	r.Header.Set("Content-Type", "application/json")
+	r.RemoteAddr = "1.2.3.4:5678"
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = r
+	key, err := KeyWithPrefix("password-reset", KeyByIPAndBodyField("email"))(c)
+	assert.NoError(t, err)
+	assert.Equal(t, "password-reset:1.2.3.4:user@example.com", key)
+
+	empty := func(*gin.Context) (string, error) { return "", nil }
+	key, err = KeyWithPrefix("password-reset", empty)(c)
+	assert.NoError(t, err)
+	assert.Equal(t, "password-reset:1.2.3.4", key)
+}
+
 type errReader struct{}
 
 func (e *errReader) Read(p []byte) (n int, err error) {
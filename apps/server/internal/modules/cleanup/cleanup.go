@@ -2,37 +2,22 @@ package cleanup
 
 import (
 	"context"
-	"strconv"
 	"time"
 
-	"vigi/internal/modules/heartbeat"
 	"vigi/internal/modules/monitor_tls_info"
 	"vigi/internal/modules/notification_sent_history"
-	"vigi/internal/modules/setting"
+	"vigi/internal/modules/retention"
 
 	"github.com/robfig/cron/v3"
 	"go.uber.org/zap"
 )
 
-func cleanupHeartbeats(heartbeatService heartbeat.Service, settingService setting.Service, logger *zap.SugaredLogger) {
-	keepDays := 365 // default fallback
-	settingModel, err := settingService.GetByKey(context.Background(), "KEEP_DATA_PERIOD_DAYS")
-	if err != nil {
-		logger.Errorw("Failed to fetch KEEP_DATA_PERIOD_DAYS setting", "error", err)
-	} else if settingModel != nil {
-		if v, err := strconv.Atoi(settingModel.Value); err == nil {
-			keepDays = v
-		} else {
-			logger.Errorw("Invalid KEEP_DATA_PERIOD_DAYS value", "value", settingModel.Value, "error", err)
-		}
-	}
-	cutoff := time.Now().UTC().AddDate(0, 0, -keepDays)
-	deleted, err := heartbeatService.DeleteOlderThan(context.Background(), cutoff)
-	if err != nil {
-		logger.Errorw("Failed to delete old heartbeats", "error", err)
-		return
+// enforceRetention compacts and deletes heartbeats and stats according to the retention
+// policy of every monitor
+func enforceRetention(retentionService retention.Service, logger *zap.SugaredLogger) {
+	if err := retentionService.Enforce(context.Background(), time.Now()); err != nil {
+		logger.Errorw("Failed to enforce data retention", "error", err)
 	}
-	logger.Infow("Deleted old heartbeats", "count", deleted, "cutoff", cutoff)
 }
 
 func cleanupNotificationHistory(notificationHistoryService notification_sent_history.Service, logger *zap.SugaredLogger) {
@@ -65,8 +50,7 @@ func cleanupMonitorTLSInfo(tlsInfoService monitor_tls_info.Service, logger *zap.
 
 // StartCleanupCron starts the general cleanup cron job(s).
 func StartCleanupCron(
-	heartbeatService heartbeat.Service,
-	settingService setting.Service,
+	retentionService retention.Service,
 	notificationHistoryService notification_sent_history.Service,
 	tlsInfoService monitor_tls_info.Service,
 	logger *zap.SugaredLogger,
@@ -74,7 +58,7 @@ func StartCleanupCron(
 	c := cron.New()
 
 	c.AddFunc("0 * * * *", func() {
-		cleanupHeartbeats(heartbeatService, settingService, logger)
+		enforceRetention(retentionService, logger)
 	})
 
 	c.AddFunc("0 * * * *", func() {
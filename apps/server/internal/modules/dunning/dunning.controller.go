@@ -0,0 +1,102 @@
+package dunning
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"vigi/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const defaultPreviewDays = 30
+
+type Controller struct {
+	service *Service
+}
+
+func NewController(service *Service) *Controller {
+	return &Controller{service: service}
+}
+
+func organizationID(ctx *gin.Context) (uuid.UUID, bool) {
+	orgID, err := uuid.Parse(ctx.GetString("orgId"))
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, utils.NewFailResponse("Invalid Organization ID"))
+		return uuid.Nil, false
+	}
+	return orgID, true
+}
+
+func (c *Controller) GetPolicy(ctx *gin.Context) {
+	orgID, ok := organizationID(ctx)
+	if !ok {
+		return
+	}
+
+	policy, err := c.service.GetPolicy(ctx, orgID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse("Internal server error"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.NewSuccessResponse("success", policy))
+}
+
+func (c *Controller) UpdatePolicy(ctx *gin.Context) {
+	orgID, ok := organizationID(ctx)
+	if !ok {
+		return
+	}
+
+	var dto UpdatePolicyDTO
+	if err := ctx.ShouldBindJSON(&dto); err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.NewFailResponse(err.Error()))
+		return
+	}
+
+	if err := utils.Validate.Struct(dto); err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.NewFailResponse(err.Error()))
+		return
+	}
+
+	policy, err := c.service.UpdatePolicy(ctx, orgID, dto)
+	if err != nil {
+		if errors.Is(err, ErrDuplicateEmailType) {
+			ctx.JSON(http.StatusBadRequest, utils.NewFailResponse(err.Error()))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse("Failed to update dunning policy"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.NewSuccessResponse("updated", policy))
+}
+
+func (c *Controller) Preview(ctx *gin.Context) {
+	orgID, ok := organizationID(ctx)
+	if !ok {
+		return
+	}
+
+	days := defaultPreviewDays
+	if daysParam := ctx.Query("days"); daysParam != "" {
+		parsed, err := strconv.Atoi(daysParam)
+		if err != nil || parsed < 1 || parsed > 365 {
+			ctx.JSON(http.StatusBadRequest, utils.NewFailResponse("days must be between 1 and 365"))
+			return
+		}
+		days = parsed
+	}
+
+	schedule, err := c.service.Preview(ctx, orgID, time.Now(), days)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse("Internal server error"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.NewSuccessResponse("success", schedule))
+}
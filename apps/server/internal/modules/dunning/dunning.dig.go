@@ -0,0 +1,22 @@
+package dunning
+
+import (
+	"vigi/internal/config"
+	"vigi/internal/modules/invoice"
+
+	"go.uber.org/dig"
+)
+
+func RegisterDependencies(container *dig.Container, cfg *config.Config) {
+	if cfg.DBType == "mongo" || cfg.DBType == "mongodb" {
+		// Not implemented
+	} else {
+		container.Provide(NewSQLRepository)
+		container.Provide(func(r *SQLRepository) Repository { return r })
+	}
+
+	container.Provide(func(s *invoice.Service) InvoiceService { return s })
+	container.Provide(NewService)
+	container.Provide(NewController)
+	container.Provide(NewRoute)
+}
@@ -0,0 +1,6 @@
+package dunning
+
+type UpdatePolicyDTO struct {
+	Enabled *bool  `json:"enabled"`
+	Steps   []Step `json:"steps" validate:"omitempty,max=4,dive"`
+}
@@ -0,0 +1,88 @@
+package dunning
+
+import (
+	"context"
+	"time"
+	"vigi/internal/modules/invoice"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// Anchor is the invoice date a dunning step is scheduled relative to
+type Anchor string
+
+const (
+	// AnchorCreated schedules relative to the invoice issue date (or creation time when unset)
+	AnchorCreated Anchor = "CREATED"
+	// AnchorDueDate schedules relative to the invoice due date
+	AnchorDueDate Anchor = "DUE_DATE"
+)
+
+// Step sends one invoice email OffsetDays after (or before, when negative) its anchor
+type Step struct {
+	Anchor     Anchor                   `json:"anchor" validate:"required,oneof=CREATED DUE_DATE" example:"DUE_DATE"`
+	OffsetDays int                      `json:"offsetDays" validate:"gte=-365,lte=365" example:"7"`
+	EmailType  invoice.InvoiceEmailType `json:"emailType" validate:"required,oneof=created first second third" example:"third"`
+}
+
+// Policy is the per-organization dunning configuration applied to SENT invoices
+type Policy struct {
+	bun.BaseModel `bun:"table:invoice_dunning_policies,alias:idp"`
+
+	ID             uuid.UUID `bun:"id,pk,type:uuid,default:uuid_generate_v4()" json:"id"`
+	OrganizationID uuid.UUID `bun:"organization_id,type:uuid,notnull" json:"organizationId"`
+	Enabled        bool      `bun:"enabled,notnull" json:"enabled"`
+	Steps          []Step    `bun:"steps,type:jsonb" json:"steps"`
+	CreatedAt      time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp" json:"createdAt"`
+	UpdatedAt      time.Time `bun:"updated_at,nullzero,notnull,default:current_timestamp" json:"updatedAt"`
+}
+
+var _ bun.BeforeAppendModelHook = (*Policy)(nil)
+
+func (p *Policy) BeforeAppendModel(ctx context.Context, query bun.Query) error {
+	switch query.(type) {
+	case *bun.InsertQuery:
+		if p.ID == uuid.Nil {
+			p.ID = uuid.New()
+		}
+		p.CreatedAt = time.Now()
+		p.UpdatedAt = time.Now()
+	case *bun.UpdateQuery:
+		p.UpdatedAt = time.Now()
+	}
+	return nil
+}
+
+// DefaultSteps is the schedule suggested to organizations without a policy:
+// on creation, 3 days before due, on the due date and 7 days overdue
+func DefaultSteps() []Step {
+	return []Step{
+		{Anchor: AnchorCreated, OffsetDays: 0, EmailType: invoice.InvoiceEmailTypeCreated},
+		{Anchor: AnchorDueDate, OffsetDays: -3, EmailType: invoice.InvoiceEmailTypeFirst},
+		{Anchor: AnchorDueDate, OffsetDays: 0, EmailType: invoice.InvoiceEmailTypeSecond},
+		{Anchor: AnchorDueDate, OffsetDays: 7, EmailType: invoice.InvoiceEmailTypeThird},
+	}
+}
+
+type ScheduledEmailStatus string
+
+const (
+	// ScheduledEmailStatusSent means the email is already in the invoice email history
+	ScheduledEmailStatusSent ScheduledEmailStatus = "SENT"
+	// ScheduledEmailStatusDue means the email will go out on the next worker run
+	ScheduledEmailStatusDue ScheduledEmailStatus = "DUE"
+	// ScheduledEmailStatusUpcoming means the email is scheduled in the future
+	ScheduledEmailStatusUpcoming ScheduledEmailStatus = "UPCOMING"
+	// ScheduledEmailStatusSkipped means a later step superseded the email before it was sent
+	ScheduledEmailStatusSkipped ScheduledEmailStatus = "SKIPPED"
+)
+
+// ScheduledEmail is a single dunning email planned for an invoice
+type ScheduledEmail struct {
+	InvoiceID     uuid.UUID                `json:"invoiceId"`
+	InvoiceNumber string                   `json:"invoiceNumber"`
+	EmailType     invoice.InvoiceEmailType `json:"emailType"`
+	ScheduledAt   time.Time                `json:"scheduledAt"`
+	Status        ScheduledEmailStatus     `json:"status"`
+}
@@ -0,0 +1,14 @@
+package dunning
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type Repository interface {
+	Create(ctx context.Context, policy *Policy) error
+	Update(ctx context.Context, policy *Policy) error
+	GetByOrganizationID(ctx context.Context, orgID uuid.UUID) (*Policy, error)
+	GetEnabled(ctx context.Context) ([]*Policy, error)
+}
@@ -0,0 +1,32 @@
+package dunning
+
+import (
+	"vigi/internal/modules/middleware"
+	"vigi/internal/modules/organization"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Route struct {
+	controller    *Controller
+	orgMiddleware *organization.Middleware
+}
+
+func NewRoute(controller *Controller, orgMiddleware *organization.Middleware) *Route {
+	return &Route{
+		controller:    controller,
+		orgMiddleware: orgMiddleware,
+	}
+}
+
+func (r *Route) ConnectRoute(router *gin.RouterGroup, authChain *middleware.AuthChain) {
+	group := router.Group("/dunning-policy")
+	group.Use(authChain.AllAuth())
+	group.Use(r.orgMiddleware.RequireOrganization())
+	group.Use(r.orgMiddleware.RequireAdmin())
+	{
+		group.GET("", r.controller.GetPolicy)
+		group.PUT("", r.controller.UpdatePolicy)
+		group.GET("/preview", r.controller.Preview)
+	}
+}
@@ -0,0 +1,77 @@
+package dunning
+
+import (
+	"sort"
+	"time"
+	"vigi/internal/modules/invoice"
+)
+
+// scheduledAt returns when a step fires for the given invoice. Steps anchored on the
+// due date do not fire for invoices without one.
+func (s Step) scheduledAt(inv *invoice.Invoice) (time.Time, bool) {
+	var anchor time.Time
+	switch s.Anchor {
+	case AnchorCreated:
+		anchor = inv.CreatedAt
+		if inv.Date != nil {
+			anchor = *inv.Date
+		}
+	case AnchorDueDate:
+		if inv.DueDate == nil {
+			return time.Time{}, false
+		}
+		anchor = *inv.DueDate
+	default:
+		return time.Time{}, false
+	}
+	return anchor.AddDate(0, 0, s.OffsetDays), true
+}
+
+// buildSchedule plans the dunning emails of an invoice. Emails already present in the
+// history are SENT. Of the steps whose time has passed only the latest one is DUE; older
+// unsent steps are SKIPPED so a late run never sends a burst of stale reminders.
+func buildSchedule(steps []Step, inv *invoice.Invoice, history []*invoice.InvoiceEmail, now time.Time) []*ScheduledEmail {
+	sent := make(map[invoice.InvoiceEmailType]bool, len(history))
+	for _, email := range history {
+		sent[email.Type] = true
+	}
+
+	schedule := make([]*ScheduledEmail, 0, len(steps))
+	for _, step := range steps {
+		at, ok := step.scheduledAt(inv)
+		if !ok {
+			continue
+		}
+		schedule = append(schedule, &ScheduledEmail{
+			InvoiceID:     inv.ID,
+			InvoiceNumber: inv.Number,
+			EmailType:     step.EmailType,
+			ScheduledAt:   at,
+		})
+	}
+	sort.SliceStable(schedule, func(i, j int) bool {
+		return schedule[i].ScheduledAt.Before(schedule[j].ScheduledAt)
+	})
+
+	latestDue := -1
+	for i, email := range schedule {
+		if !email.ScheduledAt.After(now) {
+			latestDue = i
+		}
+	}
+
+	for i, email := range schedule {
+		switch {
+		case sent[email.EmailType]:
+			email.Status = ScheduledEmailStatusSent
+		case email.ScheduledAt.After(now):
+			email.Status = ScheduledEmailStatusUpcoming
+		case i == latestDue:
+			email.Status = ScheduledEmailStatusDue
+		default:
+			email.Status = ScheduledEmailStatusSkipped
+		}
+	}
+
+	return schedule
+}
@@ -0,0 +1,208 @@
+package dunning
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+	"vigi/internal/modules/invoice"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var ErrDuplicateEmailType = errors.New("each email type can only be used by one dunning step")
+
+// InvoiceService is the subset of invoice.Service used to apply dunning policies
+type InvoiceService interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*invoice.Invoice, error)
+	GetByOrganizationID(ctx context.Context, orgID uuid.UUID, filter invoice.InvoiceFilter) ([]*invoice.Invoice, int, error)
+	GetEmailHistory(ctx context.Context, id uuid.UUID) ([]*invoice.InvoiceEmail, error)
+	SendCreatedEmail(ctx context.Context, id uuid.UUID) error
+	SendFirstEmail(ctx context.Context, id uuid.UUID) error
+	SendSecondReminder(ctx context.Context, id uuid.UUID) error
+	SendThirdReminder(ctx context.Context, id uuid.UUID) error
+}
+
+type Service struct {
+	repo           Repository
+	invoiceService InvoiceService
+	logger         *zap.SugaredLogger
+}
+
+func NewService(repo Repository, invoiceService InvoiceService, logger *zap.SugaredLogger) *Service {
+	return &Service{
+		repo:           repo,
+		invoiceService: invoiceService,
+		logger:         logger.Named("[dunning-service]"),
+	}
+}
+
+// GetPolicy returns the organization's policy, or a disabled default policy if none is saved
+func (s *Service) GetPolicy(ctx context.Context, orgID uuid.UUID) (*Policy, error) {
+	policy, err := s.repo.GetByOrganizationID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if policy == nil {
+		return &Policy{OrganizationID: orgID, Enabled: false, Steps: DefaultSteps()}, nil
+	}
+	return policy, nil
+}
+
+func (s *Service) UpdatePolicy(ctx context.Context, orgID uuid.UUID, dto UpdatePolicyDTO) (*Policy, error) {
+	policy, err := s.repo.GetByOrganizationID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	isNew := policy == nil
+	if isNew {
+		policy = &Policy{OrganizationID: orgID, Steps: DefaultSteps()}
+	}
+
+	if dto.Enabled != nil {
+		policy.Enabled = *dto.Enabled
+	}
+	if dto.Steps != nil {
+		seen := make(map[invoice.InvoiceEmailType]bool, len(dto.Steps))
+		for _, step := range dto.Steps {
+			if seen[step.EmailType] {
+				return nil, ErrDuplicateEmailType
+			}
+			seen[step.EmailType] = true
+		}
+		policy.Steps = dto.Steps
+	}
+
+	if isNew {
+		err = s.repo.Create(ctx, policy)
+	} else {
+		err = s.repo.Update(ctx, policy)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// Preview lists the dunning emails still to be sent for the organization's SENT invoices
+// within the next `days` days, including those due on the next worker run
+func (s *Service) Preview(ctx context.Context, orgID uuid.UUID, now time.Time, days int) ([]*ScheduledEmail, error) {
+	policy, err := s.GetPolicy(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	invoices, err := s.sentInvoices(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	horizon := now.AddDate(0, 0, days)
+	preview := make([]*ScheduledEmail, 0)
+	for _, inv := range invoices {
+		history, err := s.invoiceService.GetEmailHistory(ctx, inv.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, email := range buildSchedule(policy.Steps, inv, history, now) {
+			if email.Status == ScheduledEmailStatusDue ||
+				(email.Status == ScheduledEmailStatusUpcoming && !email.ScheduledAt.After(horizon)) {
+				preview = append(preview, email)
+			}
+		}
+	}
+
+	sort.SliceStable(preview, func(i, j int) bool {
+		return preview[i].ScheduledAt.Before(preview[j].ScheduledAt)
+	})
+	return preview, nil
+}
+
+// Run applies every enabled policy and returns the number of emails sent
+func (s *Service) Run(ctx context.Context, now time.Time) (int, error) {
+	policies, err := s.repo.GetEnabled(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var errs []error
+	sent := 0
+	for _, policy := range policies {
+		n, err := s.applyPolicy(ctx, policy, now)
+		sent += n
+		if err != nil {
+			errs = append(errs, fmt.Errorf("organization %s: %w", policy.OrganizationID, err))
+		}
+	}
+	return sent, errors.Join(errs...)
+}
+
+func (s *Service) applyPolicy(ctx context.Context, policy *Policy, now time.Time) (int, error) {
+	invoices, err := s.sentInvoices(ctx, policy.OrganizationID)
+	if err != nil {
+		return 0, err
+	}
+
+	var errs []error
+	sent := 0
+	for _, inv := range invoices {
+		history, err := s.invoiceService.GetEmailHistory(ctx, inv.ID)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invoice %s: %w", inv.ID, err))
+			continue
+		}
+
+		for _, email := range buildSchedule(policy.Steps, inv, history, now) {
+			if email.Status != ScheduledEmailStatusDue {
+				continue
+			}
+			ok, err := s.send(ctx, inv.ID, email.EmailType)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("invoice %s: %w", inv.ID, err))
+				continue
+			}
+			if !ok {
+				continue
+			}
+			s.logger.Infow("Sent dunning email", "invoice_id", inv.ID, "type", email.EmailType)
+			sent++
+		}
+	}
+	return sent, errors.Join(errs...)
+}
+
+// send re-reads the invoice right before sending so invoices paid or cancelled since
+// the scan are skipped. The email itself is recorded in the invoice email history.
+func (s *Service) send(ctx context.Context, invoiceID uuid.UUID, emailType invoice.InvoiceEmailType) (bool, error) {
+	current, err := s.invoiceService.GetByID(ctx, invoiceID)
+	if err != nil {
+		return false, err
+	}
+	if current.Status != invoice.InvoiceStatusSent {
+		return false, nil
+	}
+
+	switch emailType {
+	case invoice.InvoiceEmailTypeCreated:
+		err = s.invoiceService.SendCreatedEmail(ctx, invoiceID)
+	case invoice.InvoiceEmailTypeFirst:
+		err = s.invoiceService.SendFirstEmail(ctx, invoiceID)
+	case invoice.InvoiceEmailTypeSecond:
+		err = s.invoiceService.SendSecondReminder(ctx, invoiceID)
+	case invoice.InvoiceEmailTypeThird:
+		err = s.invoiceService.SendThirdReminder(ctx, invoiceID)
+	default:
+		err = fmt.Errorf("unsupported email type %q", emailType)
+	}
+	return err == nil, err
+}
+
+// sentInvoices returns all invoices awaiting payment; PAID, CANCELLED and DRAFT are never dunned
+func (s *Service) sentInvoices(ctx context.Context, orgID uuid.UUID) ([]*invoice.Invoice, error) {
+	status := invoice.InvoiceStatusSent
+	invoices, _, err := s.invoiceService.GetByOrganizationID(ctx, orgID, invoice.InvoiceFilter{Status: &status})
+	return invoices, err
+}
@@ -0,0 +1,241 @@
+package dunning
+
+import (
+	"context"
+	"testing"
+	"time"
+	"vigi/internal/modules/invoice"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type fakeRepository struct {
+	policies map[uuid.UUID]*Policy
+}
+
+func newFakeRepository(policies ...*Policy) *fakeRepository {
+	repo := &fakeRepository{policies: make(map[uuid.UUID]*Policy)}
+	for _, p := range policies {
+		repo.policies[p.OrganizationID] = p
+	}
+	return repo
+}
+
+func (r *fakeRepository) Create(ctx context.Context, policy *Policy) error {
+	r.policies[policy.OrganizationID] = policy
+	return nil
+}
+
+func (r *fakeRepository) Update(ctx context.Context, policy *Policy) error {
+	r.policies[policy.OrganizationID] = policy
+	return nil
+}
+
+func (r *fakeRepository) GetByOrganizationID(ctx context.Context, orgID uuid.UUID) (*Policy, error) {
+	return r.policies[orgID], nil
+}
+
+func (r *fakeRepository) GetEnabled(ctx context.Context) ([]*Policy, error) {
+	var enabled []*Policy
+	for _, p := range r.policies {
+		if p.Enabled {
+			enabled = append(enabled, p)
+		}
+	}
+	return enabled, nil
+}
+
+type fakeInvoiceService struct {
+	invoices map[uuid.UUID]*invoice.Invoice
+	history  map[uuid.UUID][]*invoice.InvoiceEmail
+	sent     []invoice.InvoiceEmailType
+}
+
+func newFakeInvoiceService(invoices ...*invoice.Invoice) *fakeInvoiceService {
+	svc := &fakeInvoiceService{
+		invoices: make(map[uuid.UUID]*invoice.Invoice),
+		history:  make(map[uuid.UUID][]*invoice.InvoiceEmail),
+	}
+	for _, inv := range invoices {
+		svc.invoices[inv.ID] = inv
+	}
+	return svc
+}
+
+func (f *fakeInvoiceService) GetByID(ctx context.Context, id uuid.UUID) (*invoice.Invoice, error) {
+	return f.invoices[id], nil
+}
+
+func (f *fakeInvoiceService) GetByOrganizationID(ctx context.Context, orgID uuid.UUID, filter invoice.InvoiceFilter) ([]*invoice.Invoice, int, error) {
+	var result []*invoice.Invoice
+	for _, inv := range f.invoices {
+		if inv.OrganizationID == orgID && (filter.Status == nil || inv.Status == *filter.Status) {
+			result = append(result, inv)
+		}
+	}
+	return result, len(result), nil
+}
+
+func (f *fakeInvoiceService) GetEmailHistory(ctx context.Context, id uuid.UUID) ([]*invoice.InvoiceEmail, error) {
+	return f.history[id], nil
+}
+
+func (f *fakeInvoiceService) record(id uuid.UUID, emailType invoice.InvoiceEmailType) error {
+	f.sent = append(f.sent, emailType)
+	f.history[id] = append(f.history[id], &invoice.InvoiceEmail{InvoiceID: id.String(), Type: emailType})
+	return nil
+}
+
+func (f *fakeInvoiceService) SendCreatedEmail(ctx context.Context, id uuid.UUID) error {
+	return f.record(id, invoice.InvoiceEmailTypeCreated)
+}
+
+func (f *fakeInvoiceService) SendFirstEmail(ctx context.Context, id uuid.UUID) error {
+	return f.record(id, invoice.InvoiceEmailTypeFirst)
+}
+
+func (f *fakeInvoiceService) SendSecondReminder(ctx context.Context, id uuid.UUID) error {
+	return f.record(id, invoice.InvoiceEmailTypeSecond)
+}
+
+func (f *fakeInvoiceService) SendThirdReminder(ctx context.Context, id uuid.UUID) error {
+	return f.record(id, invoice.InvoiceEmailTypeThird)
+}
+
+func newInvoice(orgID uuid.UUID, status invoice.InvoiceStatus, issued, due time.Time) *invoice.Invoice {
+	return &invoice.Invoice{
+		ID:             uuid.New(),
+		OrganizationID: orgID,
+		Number:         "INV-1",
+		Status:         status,
+		Date:           &issued,
+		DueDate:        &due,
+	}
+}
+
+func TestBuildSchedule(t *testing.T) {
+	orgID := uuid.New()
+	issued := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+	due := issued.AddDate(0, 0, 10)
+	inv := newInvoice(orgID, invoice.InvoiceStatusSent, issued, due)
+
+	t.Run("only the latest passed step is due", func(t *testing.T) {
+		now := due.Add(time.Hour) // created, first and second have passed
+		schedule := buildSchedule(DefaultSteps(), inv, nil, now)
+
+		require.Len(t, schedule, 4)
+		assert.Equal(t, ScheduledEmailStatusSkipped, schedule[0].Status)
+		assert.Equal(t, ScheduledEmailStatusSkipped, schedule[1].Status)
+		assert.Equal(t, ScheduledEmailStatusDue, schedule[2].Status)
+		assert.Equal(t, invoice.InvoiceEmailTypeSecond, schedule[2].EmailType)
+		assert.Equal(t, ScheduledEmailStatusUpcoming, schedule[3].Status)
+		assert.Equal(t, due.AddDate(0, 0, 7), schedule[3].ScheduledAt)
+	})
+
+	t.Run("emails in history are sent", func(t *testing.T) {
+		now := due.AddDate(0, 0, -3)
+		history := []*invoice.InvoiceEmail{{Type: invoice.InvoiceEmailTypeFirst}}
+		schedule := buildSchedule(DefaultSteps(), inv, history, now)
+
+		assert.Equal(t, ScheduledEmailStatusSkipped, schedule[0].Status)
+		assert.Equal(t, ScheduledEmailStatusSent, schedule[1].Status)
+		assert.Equal(t, ScheduledEmailStatusUpcoming, schedule[2].Status)
+	})
+
+	t.Run("due date steps are ignored without a due date", func(t *testing.T) {
+		noDue := &invoice.Invoice{ID: uuid.New(), Date: &issued}
+		schedule := buildSchedule(DefaultSteps(), noDue, nil, issued.Add(time.Minute))
+
+		require.Len(t, schedule, 1)
+		assert.Equal(t, invoice.InvoiceEmailTypeCreated, schedule[0].EmailType)
+		assert.Equal(t, ScheduledEmailStatusDue, schedule[0].Status)
+	})
+}
+
+func TestService_Run(t *testing.T) {
+	orgID := uuid.New()
+	issued := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+	due := issued.AddDate(0, 0, 10)
+	now := due.AddDate(0, 0, 8)
+
+	overdue := newInvoice(orgID, invoice.InvoiceStatusSent, issued, due)
+	paid := newInvoice(orgID, invoice.InvoiceStatusPaid, issued, due)
+	cancelled := newInvoice(orgID, invoice.InvoiceStatusCancelled, issued, due)
+
+	invoices := newFakeInvoiceService(overdue, paid, cancelled)
+	repo := newFakeRepository(&Policy{OrganizationID: orgID, Enabled: true, Steps: DefaultSteps()})
+	service := NewService(repo, invoices, zap.NewNop().Sugar())
+
+	sent, err := service.Run(context.Background(), now)
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	assert.Equal(t, []invoice.InvoiceEmailType{invoice.InvoiceEmailTypeThird}, invoices.sent)
+	assert.Len(t, invoices.history[overdue.ID], 1)
+	assert.Empty(t, invoices.history[paid.ID])
+	assert.Empty(t, invoices.history[cancelled.ID])
+
+	// Running again does not resend what is already in the history
+	sent, err = service.Run(context.Background(), now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 0, sent)
+}
+
+func TestService_Run_SkipsDisabledPolicies(t *testing.T) {
+	orgID := uuid.New()
+	issued := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+	inv := newInvoice(orgID, invoice.InvoiceStatusSent, issued, issued.AddDate(0, 0, 10))
+
+	invoices := newFakeInvoiceService(inv)
+	repo := newFakeRepository(&Policy{OrganizationID: orgID, Enabled: false, Steps: DefaultSteps()})
+	service := NewService(repo, invoices, zap.NewNop().Sugar())
+
+	sent, err := service.Run(context.Background(), issued.AddDate(0, 1, 0))
+	require.NoError(t, err)
+	assert.Equal(t, 0, sent)
+	assert.Empty(t, invoices.sent)
+}
+
+func TestService_Preview(t *testing.T) {
+	orgID := uuid.New()
+	issued := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+	due := issued.AddDate(0, 0, 10)
+	inv := newInvoice(orgID, invoice.InvoiceStatusSent, issued, due)
+
+	invoices := newFakeInvoiceService(inv)
+	invoices.history[inv.ID] = []*invoice.InvoiceEmail{{Type: invoice.InvoiceEmailTypeCreated}}
+	service := NewService(newFakeRepository(), invoices, zap.NewNop().Sugar())
+
+	preview, err := service.Preview(context.Background(), orgID, issued.Add(time.Hour), 10)
+	require.NoError(t, err)
+
+	// The third reminder (due + 7 days) falls outside the 10 day window
+	require.Len(t, preview, 2)
+	assert.Equal(t, invoice.InvoiceEmailTypeFirst, preview[0].EmailType)
+	assert.Equal(t, invoice.InvoiceEmailTypeSecond, preview[1].EmailType)
+	assert.Equal(t, ScheduledEmailStatusUpcoming, preview[0].Status)
+	assert.Empty(t, invoices.sent)
+}
+
+func TestService_UpdatePolicy(t *testing.T) {
+	orgID := uuid.New()
+	service := NewService(newFakeRepository(), newFakeInvoiceService(), zap.NewNop().Sugar())
+
+	policy, err := service.GetPolicy(context.Background(), orgID)
+	require.NoError(t, err)
+	assert.False(t, policy.Enabled)
+	assert.Equal(t, DefaultSteps(), policy.Steps)
+
+	enabled := true
+	policy, err = service.UpdatePolicy(context.Background(), orgID, UpdatePolicyDTO{Enabled: &enabled})
+	require.NoError(t, err)
+	assert.True(t, policy.Enabled)
+
+	_, err = service.UpdatePolicy(context.Background(), orgID, UpdatePolicyDTO{Steps: []Step{
+		{Anchor: AnchorDueDate, OffsetDays: 0, EmailType: invoice.InvoiceEmailTypeFirst},
+		{Anchor: AnchorDueDate, OffsetDays: 5, EmailType: invoice.InvoiceEmailTypeFirst},
+	}})
+	assert.ErrorIs(t, err, ErrDuplicateEmailType)
+}
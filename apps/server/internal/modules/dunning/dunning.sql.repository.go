@@ -0,0 +1,47 @@
+package dunning
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type SQLRepository struct {
+	db *bun.DB
+}
+
+func NewSQLRepository(db *bun.DB) *SQLRepository {
+	return &SQLRepository{db: db}
+}
+
+func (r *SQLRepository) Create(ctx context.Context, policy *Policy) error {
+	_, err := r.db.NewInsert().Model(policy).Exec(ctx)
+	return err
+}
+
+func (r *SQLRepository) Update(ctx context.Context, policy *Policy) error {
+	_, err := r.db.NewUpdate().Model(policy).WherePK().Exec(ctx)
+	return err
+}
+
+func (r *SQLRepository) GetByOrganizationID(ctx context.Context, orgID uuid.UUID) (*Policy, error) {
+	policy := new(Policy)
+	if err := r.db.NewSelect().Model(policy).Where("organization_id = ?", orgID).Scan(ctx); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return policy, nil
+}
+
+func (r *SQLRepository) GetEnabled(ctx context.Context) ([]*Policy, error) {
+	var policies []*Policy
+	if err := r.db.NewSelect().Model(&policies).Where("enabled = ?", true).Scan(ctx); err != nil {
+		return nil, err
+	}
+	return policies, nil
+}
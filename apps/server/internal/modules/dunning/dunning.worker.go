@@ -0,0 +1,89 @@
+package dunning
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// WorkerLeaderKey is the Redis key used to elect the node that sends dunning emails
+	WorkerLeaderKey = "vigi:invoice-dunning:leader"
+	// WorkerInterval is how often dunning policies are applied
+	WorkerInterval = 15 * time.Minute
+)
+
+// Leader reports whether the current node holds leadership
+type Leader interface {
+	IsLeader() bool
+}
+
+// Worker periodically applies the organizations' dunning policies.
+// Only the elected leader sends, so running several API replicas is safe.
+type Worker struct {
+	service  *Service
+	leader   Leader
+	interval time.Duration
+	logger   *zap.SugaredLogger
+	stopChan chan struct{}
+	doneChan chan struct{}
+}
+
+// NewWorker creates a dunning worker
+func NewWorker(service *Service, leader Leader, logger *zap.SugaredLogger) *Worker {
+	return &Worker{
+		service:  service,
+		leader:   leader,
+		interval: WorkerInterval,
+		logger:   logger.Named("[dunning-worker]"),
+		stopChan: make(chan struct{}),
+		doneChan: make(chan struct{}),
+	}
+}
+
+// Start begins applying dunning policies in the background
+func (w *Worker) Start(ctx context.Context) {
+	w.logger.Infow("Starting dunning worker", "interval", w.interval)
+
+	go func() {
+		defer close(w.doneChan)
+
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				w.RunOnce(ctx)
+			case <-w.stopChan:
+				w.logger.Info("Stopping dunning worker")
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops the worker and waits for the current run to finish
+func (w *Worker) Stop() {
+	close(w.stopChan)
+	<-w.doneChan
+}
+
+// RunOnce sends all due dunning emails if this node is the leader
+func (w *Worker) RunOnce(ctx context.Context) {
+	if !w.leader.IsLeader() {
+		return
+	}
+
+	sent, err := w.service.Run(ctx, time.Now())
+	if err != nil {
+		w.logger.Errorw("Failed to send some dunning emails", "sent", sent, "error", err)
+		return
+	}
+	if sent > 0 {
+		w.logger.Infow("Sent dunning emails", "count", sent)
+	}
+}
@@ -0,0 +1,271 @@
+package escalation
+
+import (
+	"errors"
+	"net/http"
+	"vigi/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type Controller struct {
+	service Service
+	logger  *zap.SugaredLogger
+}
+
+func NewController(
+	service Service,
+	logger *zap.SugaredLogger,
+) *Controller {
+	return &Controller{
+		service: service,
+		logger:  logger.Named("[escalation-controller]"),
+	}
+}
+
+// @Router    /escalation-policies [get]
+// @Summary   Get escalation policies
+// @Tags      Escalation Policies
+// @Produce   json
+// @Security  JwtAuth
+// @Security  ApiKeyAuth
+// @Param     page  query    int     false  "Page number" default(0)
+// @Param     limit query    int     false  "Items per page" default(10)
+// @Success   200  {object}  utils.ApiResponse[[]Policy]
+// @Failure   400  {object}  utils.APIError[any]
+// @Failure   500  {object}  utils.APIError[any]
+func (c *Controller) FindPolicies(ctx *gin.Context) {
+	page, limit, ok := pagination(ctx)
+	if !ok {
+		return
+	}
+
+	orgID := ctx.GetString("orgId")
+
+	policies, err := c.service.FindPolicies(ctx, page, limit, orgID)
+	if err != nil {
+		c.logger.Errorw("Failed to get escalation policies", "error", err)
+		ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse("Internal server error"))
+		return
+	}
+	ctx.JSON(http.StatusOK, utils.NewSuccessResponse("success", policies))
+}
+
+// @Router    /escalation-policies [post]
+// @Summary   Create an escalation policy
+// @Tags      Escalation Policies
+// @Accept    json
+// @Produce   json
+// @Security  JwtAuth
+// @Security  ApiKeyAuth
+// @Param     body body PolicyDTO true "Escalation policy"
+// @Success   201  {object} utils.ApiResponse[Policy]
+// @Failure   400  {object} utils.APIError[any]
+// @Failure   409  {object} utils.APIError[any]
+// @Failure   500  {object} utils.APIError[any]
+func (c *Controller) CreatePolicy(ctx *gin.Context) {
+	dto, ok := bindPolicyDTO(ctx)
+	if !ok {
+		return
+	}
+
+	orgID := ctx.GetString("orgId")
+
+	policy, err := c.service.CreatePolicy(ctx, dto, orgID)
+	if err != nil {
+		c.handlePolicyError(ctx, err, "Failed to create escalation policy")
+		return
+	}
+	ctx.JSON(http.StatusCreated, utils.NewSuccessResponse("Escalation policy created successfully", policy))
+}
+
+// @Router    /escalation-policies/{id} [get]
+// @Summary   Get escalation policy by ID
+// @Tags      Escalation Policies
+// @Produce   json
+// @Security  JwtAuth
+// @Security  ApiKeyAuth
+// @Param     id   path      string  true  "Policy ID"
+// @Success   200  {object}  utils.ApiResponse[Policy]
+// @Failure   404  {object}  utils.APIError[any]
+// @Failure   500  {object}  utils.APIError[any]
+func (c *Controller) FindPolicyByID(ctx *gin.Context) {
+	id := ctx.Param("id")
+	orgID := ctx.GetString("orgId")
+
+	policy, err := c.service.FindPolicyByID(ctx, id, orgID)
+	if err != nil {
+		c.logger.Errorw("Failed to get escalation policy", "error", err, "id", id)
+		ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse("Internal server error"))
+		return
+	}
+	if policy == nil {
+		ctx.JSON(http.StatusNotFound, utils.NewFailResponse("Escalation policy not found"))
+		return
+	}
+	ctx.JSON(http.StatusOK, utils.NewSuccessResponse("success", policy))
+}
+
+// @Router    /escalation-policies/{id} [put]
+// @Summary   Update an escalation policy
+// @Tags      Escalation Policies
+// @Accept    json
+// @Produce   json
+// @Security  JwtAuth
+// @Security  ApiKeyAuth
+// @Param     id   path string    true "Policy ID"
+// @Param     body body PolicyDTO true "Escalation policy"
+// @Success   200  {object} utils.ApiResponse[Policy]
+// @Failure   400  {object} utils.APIError[any]
+// @Failure   404  {object} utils.APIError[any]
+// @Failure   409  {object} utils.APIError[any]
+// @Failure   500  {object} utils.APIError[any]
+func (c *Controller) UpdatePolicy(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	dto, ok := bindPolicyDTO(ctx)
+	if !ok {
+		return
+	}
+
+	orgID := ctx.GetString("orgId")
+
+	policy, err := c.service.UpdatePolicy(ctx, id, dto, orgID)
+	if err != nil {
+		c.handlePolicyError(ctx, err, "Failed to update escalation policy")
+		return
+	}
+	if policy == nil {
+		ctx.JSON(http.StatusNotFound, utils.NewFailResponse("Escalation policy not found"))
+		return
+	}
+	ctx.JSON(http.StatusOK, utils.NewSuccessResponse("Escalation policy updated successfully", policy))
+}
+
+// @Router    /escalation-policies/{id} [delete]
+// @Summary   Delete an escalation policy
+// @Tags      Escalation Policies
+// @Produce   json
+// @Security  JwtAuth
+// @Security  ApiKeyAuth
+// @Param     id   path      string  true  "Policy ID"
+// @Success   200  {object}  utils.ApiResponse[any]
+// @Failure   500  {object}  utils.APIError[any]
+func (c *Controller) DeletePolicy(ctx *gin.Context) {
+	id := ctx.Param("id")
+	orgID := ctx.GetString("orgId")
+
+	if err := c.service.DeletePolicy(ctx, id, orgID); err != nil {
+		c.logger.Errorw("Failed to delete escalation policy", "error", err, "id", id)
+		ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse("Internal server error"))
+		return
+	}
+	ctx.JSON(http.StatusOK, utils.NewSuccessResponse[any]("Escalation policy deleted successfully", nil))
+}
+
+// @Router    /escalations [get]
+// @Summary   Get escalations
+// @Tags      Escalations
+// @Produce   json
+// @Security  JwtAuth
+// @Security  ApiKeyAuth
+// @Param     status query   string  false  "Filter by status (active, acknowledged, resolved)"
+// @Param     page   query   int     false  "Page number" default(0)
+// @Param     limit  query   int     false  "Items per page" default(10)
+// @Success   200  {object}  utils.ApiResponse[[]Model]
+// @Failure   400  {object}  utils.APIError[any]
+// @Failure   500  {object}  utils.APIError[any]
+func (c *Controller) FindAll(ctx *gin.Context) {
+	page, limit, ok := pagination(ctx)
+	if !ok {
+		return
+	}
+	status := ctx.Query("status")
+
+	orgID := ctx.GetString("orgId")
+
+	escalations, err := c.service.FindAll(ctx, page, limit, status, orgID)
+	if err != nil {
+		c.logger.Errorw("Failed to get escalations", "error", err)
+		ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse("Internal server error"))
+		return
+	}
+	ctx.JSON(http.StatusOK, utils.NewSuccessResponse("success", escalations))
+}
+
+// @Router    /escalations/{id}/acknowledge [post]
+// @Summary   Acknowledge an escalation
+// @Description Acknowledging stops the escalation, no further tier is notified
+// @Tags      Escalations
+// @Produce   json
+// @Security  JwtAuth
+// @Security  ApiKeyAuth
+// @Param     id   path      string  true  "Escalation ID"
+// @Success   200  {object}  utils.ApiResponse[Model]
+// @Failure   404  {object}  utils.APIError[any]
+// @Failure   409  {object}  utils.APIError[any]
+// @Failure   500  {object}  utils.APIError[any]
+func (c *Controller) Acknowledge(ctx *gin.Context) {
+	id := ctx.Param("id")
+	userID := ctx.GetString("userId")
+	orgID := ctx.GetString("orgId")
+
+	escalation, err := c.service.Acknowledge(ctx, id, userID, orgID)
+	if err != nil {
+		if errors.Is(err, ErrNotActive) {
+			ctx.JSON(http.StatusConflict, utils.NewFailResponse(err.Error()))
+			return
+		}
+		c.logger.Errorw("Failed to acknowledge escalation", "error", err, "id", id)
+		ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse("Internal server error"))
+		return
+	}
+	if escalation == nil {
+		ctx.JSON(http.StatusNotFound, utils.NewFailResponse("Escalation not found"))
+		return
+	}
+	ctx.JSON(http.StatusOK, utils.NewSuccessResponse("Escalation acknowledged", escalation))
+}
+
+func (c *Controller) handlePolicyError(ctx *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, ErrInvalidSteps),
+		errors.Is(err, ErrMonitorNotFound),
+		errors.Is(err, ErrChannelNotFound):
+		ctx.JSON(http.StatusBadRequest, utils.NewFailResponse(err.Error()))
+	case errors.Is(err, ErrMonitorHasPolicy):
+		ctx.JSON(http.StatusConflict, utils.NewFailResponse(err.Error()))
+	default:
+		c.logger.Errorw(message, "error", err)
+		ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse("Internal server error"))
+	}
+}
+
+func bindPolicyDTO(ctx *gin.Context) (*PolicyDTO, bool) {
+	var dto PolicyDTO
+	if err := ctx.ShouldBindJSON(&dto); err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.NewFailResponse(err.Error()))
+		return nil, false
+	}
+
+	if err := utils.Validate.Struct(&dto); err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.NewFailResponse(err.Error()))
+		return nil, false
+	}
+	return &dto, true
+}
+
+func pagination(ctx *gin.Context) (int, int, bool) {
+	page, err := utils.GetQueryInt(ctx, "page", 0)
+	if err != nil || page < 0 {
+		ctx.JSON(http.StatusBadRequest, utils.NewFailResponse("Invalid page parameter"))
+		return 0, 0, false
+	}
+	limit, err := utils.GetQueryInt(ctx, "limit", 10)
+	if err != nil || limit < 1 {
+		ctx.JSON(http.StatusBadRequest, utils.NewFailResponse("Invalid limit parameter"))
+		return 0, 0, false
+	}
+	return page, limit, true
+}
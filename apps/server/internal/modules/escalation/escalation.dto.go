@@ -0,0 +1,13 @@
+package escalation
+
+type StepDTO struct {
+	DelayMinutes    int      `json:"delay_minutes" validate:"min=0" example:"15"`
+	NotificationIDs []string `json:"notification_ids" validate:"required,min=1"`
+}
+
+type PolicyDTO struct {
+	Name       string     `json:"name" validate:"required,min=1" example:"On-call"`
+	Active     *bool      `json:"active,omitempty"`
+	Steps      []*StepDTO `json:"steps" validate:"required,min=1,dive"`
+	MonitorIDs []string   `json:"monitor_ids"`
+}
@@ -0,0 +1,45 @@
+package escalation
+
+import "time"
+
+const (
+	StatusActive       = "active"
+	StatusAcknowledged = "acknowledged"
+	StatusResolved     = "resolved"
+)
+
+// Policy notifies its steps one tier after the other while a monitor stays down
+type Policy struct {
+	ID         string    `json:"id"`
+	OrgID      string    `json:"org_id"`
+	Name       string    `json:"name"`
+	Active     bool      `json:"active"`
+	Steps      []*Step   `json:"steps"`
+	MonitorIDs []string  `json:"monitor_ids"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// Step is a tier of a policy. DelayMinutes counts from the moment the monitor went
+// down, the first step is always notified immediately.
+type Step struct {
+	DelayMinutes    int      `json:"delay_minutes" bson:"delay_minutes"`
+	NotificationIDs []string `json:"notification_ids" bson:"notification_ids"`
+}
+
+// Model is the escalation of a single outage through a policy
+type Model struct {
+	ID             string     `json:"id"`
+	OrgID          string     `json:"org_id"`
+	PolicyID       string     `json:"policy_id"`
+	MonitorID      string     `json:"monitor_id"`
+	HeartbeatID    string     `json:"heartbeat_id"`
+	Status         string     `json:"status"`
+	CurrentTier    int        `json:"current_tier"`
+	StartedAt      time.Time  `json:"started_at"`
+	AcknowledgedAt *time.Time `json:"acknowledged_at,omitempty"`
+	AcknowledgedBy string     `json:"acknowledged_by,omitempty"`
+	ResolvedAt     *time.Time `json:"resolved_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
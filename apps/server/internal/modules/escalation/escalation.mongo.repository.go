@@ -0,0 +1,336 @@
+package escalation
+
+import (
+	"context"
+	"errors"
+	"time"
+	"vigi/internal/config"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoPolicy struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty"`
+	OrgID      string             `bson:"org_id"`
+	Name       string             `bson:"name"`
+	Active     bool               `bson:"active"`
+	Steps      []*Step            `bson:"steps"`
+	MonitorIDs []string           `bson:"monitor_ids"`
+	CreatedAt  time.Time          `bson:"created_at"`
+	UpdatedAt  time.Time          `bson:"updated_at"`
+}
+
+type mongoModel struct {
+	ID             primitive.ObjectID `bson:"_id,omitempty"`
+	OrgID          string             `bson:"org_id"`
+	PolicyID       string             `bson:"policy_id"`
+	MonitorID      string             `bson:"monitor_id"`
+	HeartbeatID    string             `bson:"heartbeat_id,omitempty"`
+	Status         string             `bson:"status"`
+	CurrentTier    int                `bson:"current_tier"`
+	StartedAt      time.Time          `bson:"started_at"`
+	AcknowledgedAt *time.Time         `bson:"acknowledged_at,omitempty"`
+	AcknowledgedBy string             `bson:"acknowledged_by,omitempty"`
+	ResolvedAt     *time.Time         `bson:"resolved_at,omitempty"`
+	CreatedAt      time.Time          `bson:"created_at"`
+	UpdatedAt      time.Time          `bson:"updated_at"`
+}
+
+func toDomainPolicy(mp *mongoPolicy) *Policy {
+	steps := mp.Steps
+	if steps == nil {
+		steps = []*Step{}
+	}
+	monitorIDs := mp.MonitorIDs
+	if monitorIDs == nil {
+		monitorIDs = []string{}
+	}
+
+	return &Policy{
+		ID:         mp.ID.Hex(),
+		OrgID:      mp.OrgID,
+		Name:       mp.Name,
+		Active:     mp.Active,
+		Steps:      steps,
+		MonitorIDs: monitorIDs,
+		CreatedAt:  mp.CreatedAt,
+		UpdatedAt:  mp.UpdatedAt,
+	}
+}
+
+func toDomainModel(mm *mongoModel) *Model {
+	return &Model{
+		ID:             mm.ID.Hex(),
+		OrgID:          mm.OrgID,
+		PolicyID:       mm.PolicyID,
+		MonitorID:      mm.MonitorID,
+		HeartbeatID:    mm.HeartbeatID,
+		Status:         mm.Status,
+		CurrentTier:    mm.CurrentTier,
+		StartedAt:      mm.StartedAt,
+		AcknowledgedAt: mm.AcknowledgedAt,
+		AcknowledgedBy: mm.AcknowledgedBy,
+		ResolvedAt:     mm.ResolvedAt,
+		CreatedAt:      mm.CreatedAt,
+		UpdatedAt:      mm.UpdatedAt,
+	}
+}
+
+type MongoRepository struct {
+	client      *mongo.Client
+	db          *mongo.Database
+	policies    *mongo.Collection
+	escalations *mongo.Collection
+}
+
+func NewMongoRepository(client *mongo.Client, cfg *config.Config) Repository {
+	db := client.Database(cfg.DBName)
+	policies := db.Collection("escalation_policies")
+	escalations := db.Collection("escalations")
+
+	// Create indexes
+	go func() {
+		_, _ = policies.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+			{Keys: bson.D{{Key: "org_id", Value: 1}}},
+			{Keys: bson.D{{Key: "monitor_ids", Value: 1}}},
+		})
+		_, _ = escalations.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+			{Keys: bson.D{{Key: "org_id", Value: 1}, {Key: "status", Value: 1}}},
+			{Keys: bson.D{{Key: "monitor_id", Value: 1}, {Key: "status", Value: 1}}},
+			{Keys: bson.D{{Key: "policy_id", Value: 1}}},
+		})
+	}()
+
+	return &MongoRepository{
+		client:      client,
+		db:          db,
+		policies:    policies,
+		escalations: escalations,
+	}
+}
+
+func (r *MongoRepository) CreatePolicy(ctx context.Context, policy *Policy) (*Policy, error) {
+	now := time.Now().UTC()
+	mp := &mongoPolicy{
+		ID:         primitive.NewObjectID(),
+		OrgID:      policy.OrgID,
+		Name:       policy.Name,
+		Active:     policy.Active,
+		Steps:      policy.Steps,
+		MonitorIDs: uniqueIDs(policy.MonitorIDs),
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	if _, err := r.policies.InsertOne(ctx, mp); err != nil {
+		return nil, err
+	}
+	return toDomainPolicy(mp), nil
+}
+
+func (r *MongoRepository) FindPolicyByID(ctx context.Context, id string, orgID string) (*Policy, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := bson.M{"_id": objectID}
+	if orgID != "" {
+		filter["org_id"] = orgID
+	}
+	return r.findPolicy(ctx, filter)
+}
+
+func (r *MongoRepository) FindPolicies(ctx context.Context, page int, limit int, orgID string) ([]*Policy, error) {
+	skip := int64(page * limit)
+	limit64 := int64(limit)
+
+	opts := &options.FindOptions{
+		Skip:  &skip,
+		Limit: &limit64,
+		Sort:  bson.D{{Key: "created_at", Value: -1}},
+	}
+
+	cursor, err := r.policies.Find(ctx, bson.M{"org_id": orgID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var mps []*mongoPolicy
+	if err := cursor.All(ctx, &mps); err != nil {
+		return nil, err
+	}
+
+	policies := make([]*Policy, 0, len(mps))
+	for _, mp := range mps {
+		policies = append(policies, toDomainPolicy(mp))
+	}
+	return policies, nil
+}
+
+func (r *MongoRepository) FindPolicyByMonitorID(ctx context.Context, monitorID string) (*Policy, error) {
+	return r.findPolicy(ctx, bson.M{"monitor_ids": monitorID})
+}
+
+func (r *MongoRepository) UpdatePolicy(ctx context.Context, policy *Policy) error {
+	objectID, err := primitive.ObjectIDFromHex(policy.ID)
+	if err != nil {
+		return err
+	}
+
+	update := bson.M{"$set": bson.M{
+		"name":        policy.Name,
+		"active":      policy.Active,
+		"steps":       policy.Steps,
+		"monitor_ids": uniqueIDs(policy.MonitorIDs),
+		"updated_at":  time.Now().UTC(),
+	}}
+	_, err = r.policies.UpdateOne(ctx, bson.M{"_id": objectID}, update)
+	return err
+}
+
+func (r *MongoRepository) DeletePolicy(ctx context.Context, id string, orgID string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	res, err := r.policies.DeleteOne(ctx, bson.M{"_id": objectID, "org_id": orgID})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return nil
+	}
+
+	_, err = r.escalations.DeleteMany(ctx, bson.M{"policy_id": id})
+	return err
+}
+
+func (r *MongoRepository) Create(ctx context.Context, escalation *Model) (*Model, error) {
+	now := time.Now().UTC()
+	mm := &mongoModel{
+		ID:             primitive.NewObjectID(),
+		OrgID:          escalation.OrgID,
+		PolicyID:       escalation.PolicyID,
+		MonitorID:      escalation.MonitorID,
+		HeartbeatID:    escalation.HeartbeatID,
+		Status:         escalation.Status,
+		CurrentTier:    escalation.CurrentTier,
+		StartedAt:      escalation.StartedAt,
+		AcknowledgedAt: escalation.AcknowledgedAt,
+		AcknowledgedBy: escalation.AcknowledgedBy,
+		ResolvedAt:     escalation.ResolvedAt,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	if mm.StartedAt.IsZero() {
+		mm.StartedAt = now
+	}
+
+	if _, err := r.escalations.InsertOne(ctx, mm); err != nil {
+		return nil, err
+	}
+	return toDomainModel(mm), nil
+}
+
+func (r *MongoRepository) FindByID(ctx context.Context, id string, orgID string) (*Model, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := bson.M{"_id": objectID}
+	if orgID != "" {
+		filter["org_id"] = orgID
+	}
+	return r.findOne(ctx, filter, options.FindOne())
+}
+
+func (r *MongoRepository) FindAll(ctx context.Context, page int, limit int, status string, orgID string) ([]*Model, error) {
+	skip := int64(page * limit)
+	limit64 := int64(limit)
+
+	opts := &options.FindOptions{
+		Skip:  &skip,
+		Limit: &limit64,
+		Sort:  bson.D{{Key: "started_at", Value: -1}},
+	}
+
+	filter := bson.M{"org_id": orgID}
+	if status != "" {
+		filter["status"] = status
+	}
+
+	cursor, err := r.escalations.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var mms []*mongoModel
+	if err := cursor.All(ctx, &mms); err != nil {
+		return nil, err
+	}
+
+	models := make([]*Model, 0, len(mms))
+	for _, mm := range mms {
+		models = append(models, toDomainModel(mm))
+	}
+	return models, nil
+}
+
+func (r *MongoRepository) FindOpenByMonitorID(ctx context.Context, monitorID string) (*Model, error) {
+	filter := bson.M{
+		"monitor_id": monitorID,
+		"status":     bson.M{"$in": []string{StatusActive, StatusAcknowledged}},
+	}
+	opts := options.FindOne().SetSort(bson.D{{Key: "started_at", Value: -1}})
+	return r.findOne(ctx, filter, opts)
+}
+
+func (r *MongoRepository) Update(ctx context.Context, escalation *Model) error {
+	objectID, err := primitive.ObjectIDFromHex(escalation.ID)
+	if err != nil {
+		return err
+	}
+
+	update := bson.M{"$set": bson.M{
+		"status":          escalation.Status,
+		"current_tier":    escalation.CurrentTier,
+		"acknowledged_at": escalation.AcknowledgedAt,
+		"acknowledged_by": escalation.AcknowledgedBy,
+		"resolved_at":     escalation.ResolvedAt,
+		"updated_at":      time.Now().UTC(),
+	}}
+	_, err = r.escalations.UpdateOne(ctx, bson.M{"_id": objectID}, update)
+	return err
+}
+
+func (r *MongoRepository) findPolicy(ctx context.Context, filter bson.M) (*Policy, error) {
+	var mp mongoPolicy
+	err := r.policies.FindOne(ctx, filter).Decode(&mp)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toDomainPolicy(&mp), nil
+}
+
+func (r *MongoRepository) findOne(ctx context.Context, filter bson.M, opts *options.FindOneOptions) (*Model, error) {
+	var mm mongoModel
+	err := r.escalations.FindOne(ctx, filter, opts).Decode(&mm)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toDomainModel(&mm), nil
+}
@@ -0,0 +1,23 @@
+package escalation
+
+import "context"
+
+type Repository interface {
+	CreatePolicy(ctx context.Context, policy *Policy) (*Policy, error)
+	FindPolicyByID(ctx context.Context, id string, orgID string) (*Policy, error)
+	FindPolicies(ctx context.Context, page int, limit int, orgID string) ([]*Policy, error)
+	// FindPolicyByMonitorID returns the policy the monitor is attached to
+	FindPolicyByMonitorID(ctx context.Context, monitorID string) (*Policy, error)
+	// UpdatePolicy replaces the name, active flag, steps and monitors of the policy
+	UpdatePolicy(ctx context.Context, policy *Policy) error
+	// DeletePolicy deletes the policy together with its escalations
+	DeletePolicy(ctx context.Context, id string, orgID string) error
+
+	Create(ctx context.Context, escalation *Model) (*Model, error)
+	FindByID(ctx context.Context, id string, orgID string) (*Model, error)
+	FindAll(ctx context.Context, page int, limit int, status string, orgID string) ([]*Model, error)
+	// FindOpenByMonitorID returns the active or acknowledged escalation of the monitor
+	FindOpenByMonitorID(ctx context.Context, monitorID string) (*Model, error)
+	// Update persists the status, tier and acknowledgement of the escalation
+	Update(ctx context.Context, escalation *Model) error
+}
@@ -0,0 +1,43 @@
+package escalation
+
+import (
+	"vigi/internal/modules/middleware"
+	"vigi/internal/modules/organization"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Route struct {
+	controller    *Controller
+	middleware    *middleware.AuthChain
+	orgMiddleware *organization.Middleware
+}
+
+func NewRoute(controller *Controller, middleware *middleware.AuthChain, orgMiddleware *organization.Middleware) *Route {
+	return &Route{
+		controller:    controller,
+		middleware:    middleware,
+		orgMiddleware: orgMiddleware,
+	}
+}
+
+func (r *Route) ConnectRoute(rg *gin.RouterGroup, controller *Controller) {
+	policies := rg.Group("escalation-policies")
+	policies.Use(r.middleware.AllAuth())
+	policies.Use(r.orgMiddleware.RequireOrganization())
+	{
+		policies.GET("", r.controller.FindPolicies)
+		policies.POST("", r.controller.CreatePolicy)
+		policies.GET("/:id", r.controller.FindPolicyByID)
+		policies.PUT("/:id", r.controller.UpdatePolicy)
+		policies.DELETE("/:id", r.controller.DeletePolicy)
+	}
+
+	escalations := rg.Group("escalations")
+	escalations.Use(r.middleware.AllAuth())
+	escalations.Use(r.orgMiddleware.RequireOrganization())
+	{
+		escalations.GET("", r.controller.FindAll)
+		escalations.POST("/:id/acknowledge", r.controller.Acknowledge)
+	}
+}
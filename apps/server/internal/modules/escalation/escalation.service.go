@@ -0,0 +1,341 @@
+package escalation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"vigi/internal/modules/heartbeat"
+	"vigi/internal/modules/monitor"
+	"vigi/internal/modules/notification_delivery"
+	"vigi/internal/modules/queue"
+	"vigi/internal/modules/shared"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// TaskTypeEscalate is the task type that notifies the next tier of an escalation
+	TaskTypeEscalate = "notification:escalate"
+
+	escalateMaxRetry = 3
+)
+
+var (
+	ErrInvalidSteps     = errors.New("the first step must have no delay and delays must increase with every step")
+	ErrMonitorHasPolicy = errors.New("monitor is already attached to another escalation policy")
+	ErrNotActive        = errors.New("escalation is not active")
+	ErrMonitorNotFound  = errors.New("one or more monitors were not found")
+	ErrChannelNotFound  = errors.New("one or more notification channels were not found")
+)
+
+// EscalateTaskPayload is the payload of escalate tasks
+type EscalateTaskPayload struct {
+	EscalationID string `json:"escalation_id"`
+	Tier         int    `json:"tier"`
+}
+
+// NotificationChannelService is the part of notification_channel.Service the
+// escalation service needs
+type NotificationChannelService interface {
+	AllExist(ctx context.Context, ids []string, orgID string) (bool, error)
+}
+
+type Service interface {
+	CreatePolicy(ctx context.Context, dto *PolicyDTO, orgID string) (*Policy, error)
+	FindPolicyByID(ctx context.Context, id string, orgID string) (*Policy, error)
+	FindPolicies(ctx context.Context, page int, limit int, orgID string) ([]*Policy, error)
+	UpdatePolicy(ctx context.Context, id string, dto *PolicyDTO, orgID string) (*Policy, error)
+	DeletePolicy(ctx context.Context, id string, orgID string) error
+
+	FindByID(ctx context.Context, id string, orgID string) (*Model, error)
+	FindAll(ctx context.Context, page int, limit int, status string, orgID string) ([]*Model, error)
+	// Acknowledge stops the escalation, no further tier is notified
+	Acknowledge(ctx context.Context, id string, userID string, orgID string) (*Model, error)
+
+	// HandleHeartbeat routes an important heartbeat through the escalation policy of its
+	// monitor. It returns false when the monitor has no active policy, in which case the
+	// notification channels linked to the monitor are notified as usual.
+	HandleHeartbeat(ctx context.Context, hb *heartbeat.Model) (bool, error)
+	// Escalate notifies the given tier if the escalation is still active
+	Escalate(ctx context.Context, escalationID string, tier int) error
+}
+
+type ServiceImpl struct {
+	repository       Repository
+	deliveryService  notification_delivery.Service
+	heartbeatService heartbeat.Service
+	queueService     queue.Service
+	monitorService   monitor.Service
+	channelService   NotificationChannelService
+	logger           *zap.SugaredLogger
+}
+
+func NewService(
+	repository Repository,
+	deliveryService notification_delivery.Service,
+	heartbeatService heartbeat.Service,
+	queueService queue.Service,
+	monitorService monitor.Service,
+	channelService NotificationChannelService,
+	logger *zap.SugaredLogger,
+) Service {
+	return &ServiceImpl{
+		repository:       repository,
+		deliveryService:  deliveryService,
+		heartbeatService: heartbeatService,
+		queueService:     queueService,
+		monitorService:   monitorService,
+		channelService:   channelService,
+		logger:           logger.Named("[escalation-service]"),
+	}
+}
+
+func (s *ServiceImpl) CreatePolicy(ctx context.Context, dto *PolicyDTO, orgID string) (*Policy, error) {
+	policy := &Policy{OrgID: orgID}
+	if err := s.applyDTO(ctx, policy, dto); err != nil {
+		return nil, err
+	}
+	return s.repository.CreatePolicy(ctx, policy)
+}
+
+func (s *ServiceImpl) FindPolicyByID(ctx context.Context, id string, orgID string) (*Policy, error) {
+	return s.repository.FindPolicyByID(ctx, id, orgID)
+}
+
+func (s *ServiceImpl) FindPolicies(ctx context.Context, page int, limit int, orgID string) ([]*Policy, error) {
+	return s.repository.FindPolicies(ctx, page, limit, orgID)
+}
+
+func (s *ServiceImpl) UpdatePolicy(ctx context.Context, id string, dto *PolicyDTO, orgID string) (*Policy, error) {
+	policy, err := s.repository.FindPolicyByID(ctx, id, orgID)
+	if err != nil || policy == nil {
+		return nil, err
+	}
+
+	if err := s.applyDTO(ctx, policy, dto); err != nil {
+		return nil, err
+	}
+	if err := s.repository.UpdatePolicy(ctx, policy); err != nil {
+		return nil, err
+	}
+	return s.repository.FindPolicyByID(ctx, id, orgID)
+}
+
+func (s *ServiceImpl) DeletePolicy(ctx context.Context, id string, orgID string) error {
+	return s.repository.DeletePolicy(ctx, id, orgID)
+}
+
+func (s *ServiceImpl) FindByID(ctx context.Context, id string, orgID string) (*Model, error) {
+	return s.repository.FindByID(ctx, id, orgID)
+}
+
+func (s *ServiceImpl) FindAll(ctx context.Context, page int, limit int, status string, orgID string) ([]*Model, error) {
+	return s.repository.FindAll(ctx, page, limit, status, orgID)
+}
+
+func (s *ServiceImpl) Acknowledge(ctx context.Context, id string, userID string, orgID string) (*Model, error) {
+	escalation, err := s.repository.FindByID(ctx, id, orgID)
+	if err != nil || escalation == nil {
+		return nil, err
+	}
+	if escalation.Status != StatusActive {
+		return nil, ErrNotActive
+	}
+
+	now := time.Now().UTC()
+	escalation.Status = StatusAcknowledged
+	escalation.AcknowledgedAt = &now
+	escalation.AcknowledgedBy = userID
+	if err := s.repository.Update(ctx, escalation); err != nil {
+		return nil, err
+	}
+	return escalation, nil
+}
+
+func (s *ServiceImpl) HandleHeartbeat(ctx context.Context, hb *heartbeat.Model) (bool, error) {
+	policy, err := s.repository.FindPolicyByMonitorID(ctx, hb.MonitorID)
+	if err != nil {
+		return false, err
+	}
+	if policy == nil || !policy.Active || len(policy.Steps) == 0 {
+		return false, nil
+	}
+
+	open, err := s.repository.FindOpenByMonitorID(ctx, hb.MonitorID)
+	if err != nil {
+		return true, err
+	}
+
+	switch hb.Status {
+	case shared.MonitorStatusDown:
+		// Repeated DOWN heartbeats belong to the outage already being escalated
+		if open != nil {
+			return true, nil
+		}
+
+		escalation, err := s.repository.Create(ctx, &Model{
+			OrgID:       policy.OrgID,
+			PolicyID:    policy.ID,
+			MonitorID:   hb.MonitorID,
+			HeartbeatID: hb.ID,
+			Status:      StatusActive,
+			StartedAt:   time.Now().UTC(),
+		})
+		if err != nil {
+			return true, err
+		}
+		return true, s.escalate(ctx, escalation, policy, 1, hb)
+
+	case shared.MonitorStatusUp, shared.MonitorStatusDegraded:
+		// Everyone who was told about the outage hears about the recovery, a degraded
+		// monitor answers again so it ends the outage as well
+		tiers := 1
+		if open != nil {
+			now := time.Now().UTC()
+			open.Status = StatusResolved
+			open.ResolvedAt = &now
+			if err := s.repository.Update(ctx, open); err != nil {
+				return true, err
+			}
+			tiers = max(open.CurrentTier, 1)
+		}
+		var errs []error
+		for _, step := range policy.Steps[:min(tiers, len(policy.Steps))] {
+			errs = append(errs, s.dispatch(ctx, policy.OrgID, hb.MonitorID, step, hb.Msg, hb))
+		}
+		return true, errors.Join(errs...)
+
+	default:
+		return true, s.dispatch(ctx, policy.OrgID, hb.MonitorID, policy.Steps[0], hb.Msg, hb)
+	}
+}
+
+func (s *ServiceImpl) Escalate(ctx context.Context, escalationID string, tier int) error {
+	escalation, err := s.repository.FindByID(ctx, escalationID, "")
+	if err != nil {
+		return err
+	}
+	// Acknowledged, resolved or deleted escalations stop here
+	if escalation == nil || escalation.Status != StatusActive || escalation.CurrentTier >= tier {
+		return nil
+	}
+
+	policy, err := s.repository.FindPolicyByID(ctx, escalation.PolicyID, "")
+	if err != nil {
+		return err
+	}
+	if policy == nil || !policy.Active || tier > len(policy.Steps) {
+		return nil
+	}
+
+	var hb *heartbeat.Model
+	if escalation.HeartbeatID != "" {
+		hb, err = s.heartbeatService.FindByID(ctx, escalation.HeartbeatID)
+		if err != nil {
+			s.logger.Warnw("Failed to load the heartbeat of an escalation", "escalationID", escalation.ID, "error", err)
+		}
+	}
+
+	return s.escalate(ctx, escalation, policy, tier, hb)
+}
+
+// escalate notifies a tier and schedules the next one
+func (s *ServiceImpl) escalate(ctx context.Context, escalation *Model, policy *Policy, tier int, hb *heartbeat.Model) error {
+	escalation.CurrentTier = tier
+	if err := s.repository.Update(ctx, escalation); err != nil {
+		return err
+	}
+
+	message := "Monitor is still down"
+	if hb != nil {
+		message = hb.Msg
+	}
+	if tier > 1 {
+		message = fmt.Sprintf("Escalated to tier %d, still down after %d minutes: %s", tier, policy.Steps[tier-1].DelayMinutes, message)
+	}
+
+	dispatchErr := s.dispatch(ctx, escalation.OrgID, escalation.MonitorID, policy.Steps[tier-1], message, hb)
+
+	if tier < len(policy.Steps) {
+		next := escalation.StartedAt.Add(time.Duration(policy.Steps[tier].DelayMinutes) * time.Minute)
+		delay := max(time.Until(next), 0)
+		opts := &queue.EnqueueOptions{
+			Queue:     notification_delivery.QueueName,
+			MaxRetry:  escalateMaxRetry,
+			Timeout:   time.Minute,
+			Retention: 24 * time.Hour,
+			ProcessIn: &delay,
+		}
+		payload := EscalateTaskPayload{EscalationID: escalation.ID, Tier: tier + 1}
+		if _, err := s.queueService.Enqueue(ctx, TaskTypeEscalate, payload, opts); err != nil {
+			return errors.Join(dispatchErr, fmt.Errorf("failed to schedule tier %d: %w", tier+1, err))
+		}
+	}
+
+	return dispatchErr
+}
+
+func (s *ServiceImpl) dispatch(ctx context.Context, orgID string, monitorID string, step *Step, message string, hb *heartbeat.Model) error {
+	var errs []error
+	for _, notificationID := range step.NotificationIDs {
+		_, err := s.deliveryService.Dispatch(ctx, &notification_delivery.Model{
+			OrgID:          orgID,
+			NotificationID: notificationID,
+			MonitorID:      monitorID,
+			Event:          notification_delivery.EventHeartbeat,
+			Message:        message,
+			Heartbeat:      hb,
+		})
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+func (s *ServiceImpl) applyDTO(ctx context.Context, policy *Policy, dto *PolicyDTO) error {
+	steps := make([]*Step, 0, len(dto.Steps))
+	for i, step := range dto.Steps {
+		if (i == 0 && step.DelayMinutes != 0) || (i > 0 && step.DelayMinutes <= dto.Steps[i-1].DelayMinutes) {
+			return ErrInvalidSteps
+		}
+		exist, err := s.channelService.AllExist(ctx, step.NotificationIDs, policy.OrgID)
+		if err != nil {
+			return err
+		}
+		if !exist {
+			return ErrChannelNotFound
+		}
+		steps = append(steps, &Step{DelayMinutes: step.DelayMinutes, NotificationIDs: step.NotificationIDs})
+	}
+
+	monitorIDs := uniqueIDs(dto.MonitorIDs)
+	if len(monitorIDs) > 0 {
+		monitors, err := s.monitorService.FindByIDs(ctx, monitorIDs, policy.OrgID)
+		if err != nil {
+			return err
+		}
+		if len(monitors) != len(monitorIDs) {
+			return ErrMonitorNotFound
+		}
+	}
+
+	// A monitor follows a single policy, otherwise its tiers would interleave
+	for _, monitorID := range monitorIDs {
+		attached, err := s.repository.FindPolicyByMonitorID(ctx, monitorID)
+		if err != nil {
+			return err
+		}
+		if attached != nil && attached.ID != policy.ID {
+			return ErrMonitorHasPolicy
+		}
+	}
+
+	policy.Name = dto.Name
+	policy.Active = true
+	if dto.Active != nil {
+		policy.Active = *dto.Active
+	}
+	policy.Steps = steps
+	policy.MonitorIDs = monitorIDs
+	return nil
+}
@@ -0,0 +1,494 @@
+package escalation
+
+import (
+	"context"
+	"testing"
+	"time"
+	"vigi/internal/modules/heartbeat"
+	"vigi/internal/modules/monitor"
+	"vigi/internal/modules/notification_delivery"
+	"vigi/internal/modules/queue"
+	"vigi/internal/modules/shared"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+// MockRepository
+type MockRepository struct {
+	mock.Mock
+}
+
+func (m *MockRepository) CreatePolicy(ctx context.Context, policy *Policy) (*Policy, error) {
+	args := m.Called(ctx, policy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Policy), args.Error(1)
+}
+
+func (m *MockRepository) FindPolicyByID(ctx context.Context, id string, orgID string) (*Policy, error) {
+	args := m.Called(ctx, id, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Policy), args.Error(1)
+}
+
+func (m *MockRepository) FindPolicies(ctx context.Context, page int, limit int, orgID string) ([]*Policy, error) {
+	args := m.Called(ctx, page, limit, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*Policy), args.Error(1)
+}
+
+func (m *MockRepository) FindPolicyByMonitorID(ctx context.Context, monitorID string) (*Policy, error) {
+	args := m.Called(ctx, monitorID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Policy), args.Error(1)
+}
+
+func (m *MockRepository) UpdatePolicy(ctx context.Context, policy *Policy) error {
+	args := m.Called(ctx, policy)
+	return args.Error(0)
+}
+
+func (m *MockRepository) DeletePolicy(ctx context.Context, id string, orgID string) error {
+	args := m.Called(ctx, id, orgID)
+	return args.Error(0)
+}
+
+func (m *MockRepository) Create(ctx context.Context, escalation *Model) (*Model, error) {
+	args := m.Called(ctx, escalation)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Model), args.Error(1)
+}
+
+func (m *MockRepository) FindByID(ctx context.Context, id string, orgID string) (*Model, error) {
+	args := m.Called(ctx, id, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Model), args.Error(1)
+}
+
+func (m *MockRepository) FindAll(ctx context.Context, page int, limit int, status string, orgID string) ([]*Model, error) {
+	args := m.Called(ctx, page, limit, status, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*Model), args.Error(1)
+}
+
+func (m *MockRepository) FindOpenByMonitorID(ctx context.Context, monitorID string) (*Model, error) {
+	args := m.Called(ctx, monitorID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Model), args.Error(1)
+}
+
+func (m *MockRepository) Update(ctx context.Context, escalation *Model) error {
+	args := m.Called(ctx, escalation)
+	return args.Error(0)
+}
+
+// MockDeliveryService
+type MockDeliveryService struct {
+	mock.Mock
+}
+
+func (m *MockDeliveryService) Dispatch(ctx context.Context, delivery *notification_delivery.Model) (*notification_delivery.Model, error) {
+	args := m.Called(ctx, delivery)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*notification_delivery.Model), args.Error(1)
+}
+
+func (m *MockDeliveryService) FindByID(ctx context.Context, id string, orgID string) (*notification_delivery.Model, error) {
+	args := m.Called(ctx, id, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*notification_delivery.Model), args.Error(1)
+}
+
+func (m *MockDeliveryService) FindAll(ctx context.Context, page int, limit int, filter *notification_delivery.FindFilter, orgID string) ([]*notification_delivery.Model, error) {
+	args := m.Called(ctx, page, limit, filter, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*notification_delivery.Model), args.Error(1)
+}
+
+func (m *MockDeliveryService) Resend(ctx context.Context, id string, orgID string) (*notification_delivery.Model, error) {
+	args := m.Called(ctx, id, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*notification_delivery.Model), args.Error(1)
+}
+
+func (m *MockDeliveryService) RecordAttempt(ctx context.Context, delivery *notification_delivery.Model, sendErr error, final bool) error {
+	args := m.Called(ctx, delivery, sendErr, final)
+	return args.Error(0)
+}
+
+// MockHeartbeatService only implements the methods used by the escalation service
+type MockHeartbeatService struct {
+	mock.Mock
+	heartbeat.Service
+}
+
+func (m *MockHeartbeatService) FindByID(ctx context.Context, id string) (*heartbeat.Model, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*heartbeat.Model), args.Error(1)
+}
+
+// MockQueueService only implements the methods used by the escalation service
+type MockQueueService struct {
+	mock.Mock
+	queue.Service
+}
+
+func (m *MockQueueService) Enqueue(ctx context.Context, taskType string, payload interface{}, opts *queue.EnqueueOptions) (*queue.TaskInfo, error) {
+	args := m.Called(ctx, taskType, payload, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*queue.TaskInfo), args.Error(1)
+}
+
+// fakeMonitorService only implements the lookups the escalation service needs
+type fakeMonitorService struct {
+	monitor.Service
+	monitors map[string]*monitor.Model
+}
+
+func (s *fakeMonitorService) FindByIDs(ctx context.Context, ids []string, orgID string) ([]*monitor.Model, error) {
+	var result []*monitor.Model
+	for _, id := range ids {
+		if m, ok := s.monitors[id]; ok && m.OrgID == orgID {
+			result = append(result, m)
+		}
+	}
+	return result, nil
+}
+
+// fakeChannelService knows the channels of org-1
+type fakeChannelService struct {
+	channels map[string]bool
+}
+
+func (s *fakeChannelService) AllExist(ctx context.Context, ids []string, orgID string) (bool, error) {
+	for _, id := range ids {
+		if orgID != "org-1" || !s.channels[id] {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+type testMocks struct {
+	repo         *MockRepository
+	delivery     *MockDeliveryService
+	heartbeat    *MockHeartbeatService
+	queueService *MockQueueService
+}
+
+func setupService() (*ServiceImpl, *testMocks) {
+	mocks := &testMocks{
+		repo:         &MockRepository{},
+		delivery:     &MockDeliveryService{},
+		heartbeat:    &MockHeartbeatService{},
+		queueService: &MockQueueService{},
+	}
+	monitorService := &fakeMonitorService{monitors: map[string]*monitor.Model{
+		"monitor-1": {ID: "monitor-1", OrgID: "org-1"},
+		"monitor-2": {ID: "monitor-2", OrgID: "org-2"},
+	}}
+	channelService := &fakeChannelService{channels: map[string]bool{"slack": true, "pagerduty": true, "phone": true}}
+	service := NewService(mocks.repo, mocks.delivery, mocks.heartbeat, mocks.queueService, monitorService, channelService, zap.NewNop().Sugar()).(*ServiceImpl)
+	return service, mocks
+}
+
+func threeTierPolicy() *Policy {
+	return &Policy{
+		ID:     "policy-1",
+		OrgID:  "org-1",
+		Name:   "On-call",
+		Active: true,
+		Steps: []*Step{
+			{DelayMinutes: 0, NotificationIDs: []string{"slack"}},
+			{DelayMinutes: 10, NotificationIDs: []string{"pagerduty"}},
+			{DelayMinutes: 30, NotificationIDs: []string{"phone"}},
+		},
+		MonitorIDs: []string{"monitor-1"},
+	}
+}
+
+func dispatchedTo(notificationID string) interface{} {
+	return mock.MatchedBy(func(d *notification_delivery.Model) bool {
+		return d.NotificationID == notificationID
+	})
+}
+
+func TestServiceImpl_HandleHeartbeat(t *testing.T) {
+	ctx := context.Background()
+	down := &heartbeat.Model{ID: "hb-1", MonitorID: "monitor-1", Status: shared.MonitorStatusDown, Msg: "timeout"}
+	up := &heartbeat.Model{ID: "hb-2", MonitorID: "monitor-1", Status: shared.MonitorStatusUp, Msg: "200 OK"}
+
+	t.Run("falls back to linked channels without a policy", func(t *testing.T) {
+		service, mocks := setupService()
+		mocks.repo.On("FindPolicyByMonitorID", ctx, "monitor-1").Return(nil, nil)
+
+		handled, err := service.HandleHeartbeat(ctx, down)
+
+		assert.NoError(t, err)
+		assert.False(t, handled)
+	})
+
+	t.Run("falls back to linked channels when the policy is inactive", func(t *testing.T) {
+		service, mocks := setupService()
+		policy := threeTierPolicy()
+		policy.Active = false
+		mocks.repo.On("FindPolicyByMonitorID", ctx, "monitor-1").Return(policy, nil)
+
+		handled, err := service.HandleHeartbeat(ctx, down)
+
+		assert.NoError(t, err)
+		assert.False(t, handled)
+	})
+
+	t.Run("notifies the first tier and schedules the second when a monitor goes down", func(t *testing.T) {
+		service, mocks := setupService()
+		startedAt := time.Now().UTC()
+		mocks.repo.On("FindPolicyByMonitorID", ctx, "monitor-1").Return(threeTierPolicy(), nil)
+		mocks.repo.On("FindOpenByMonitorID", ctx, "monitor-1").Return(nil, nil)
+		mocks.repo.On("Create", ctx, mock.MatchedBy(func(e *Model) bool {
+			return e.PolicyID == "policy-1" && e.HeartbeatID == "hb-1" && e.Status == StatusActive
+		})).Return(&Model{ID: "esc-1", OrgID: "org-1", PolicyID: "policy-1", MonitorID: "monitor-1", Status: StatusActive, StartedAt: startedAt}, nil)
+		mocks.repo.On("Update", ctx, mock.MatchedBy(func(e *Model) bool { return e.CurrentTier == 1 })).Return(nil)
+		mocks.delivery.On("Dispatch", ctx, mock.MatchedBy(func(d *notification_delivery.Model) bool {
+			return d.NotificationID == "slack" && d.Message == "timeout" && d.OrgID == "org-1"
+		})).Return(&notification_delivery.Model{ID: "d-1"}, nil)
+		mocks.queueService.On("Enqueue", ctx, TaskTypeEscalate, EscalateTaskPayload{EscalationID: "esc-1", Tier: 2}, mock.MatchedBy(func(opts *queue.EnqueueOptions) bool {
+			return opts.ProcessIn != nil && *opts.ProcessIn > 9*time.Minute && *opts.ProcessIn <= 10*time.Minute
+		})).Return(&queue.TaskInfo{ID: "task-1"}, nil)
+
+		handled, err := service.HandleHeartbeat(ctx, down)
+
+		assert.NoError(t, err)
+		assert.True(t, handled)
+		mocks.delivery.AssertNumberOfCalls(t, "Dispatch", 1)
+		mocks.queueService.AssertExpectations(t)
+	})
+
+	t.Run("does not start a second escalation for the same outage", func(t *testing.T) {
+		service, mocks := setupService()
+		mocks.repo.On("FindPolicyByMonitorID", ctx, "monitor-1").Return(threeTierPolicy(), nil)
+		mocks.repo.On("FindOpenByMonitorID", ctx, "monitor-1").Return(&Model{ID: "esc-1", Status: StatusActive, CurrentTier: 2}, nil)
+
+		handled, err := service.HandleHeartbeat(ctx, down)
+
+		assert.NoError(t, err)
+		assert.True(t, handled)
+		mocks.repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		mocks.delivery.AssertNotCalled(t, "Dispatch", mock.Anything, mock.Anything)
+	})
+
+	t.Run("resolves the escalation and notifies the tiers that were reached on recovery", func(t *testing.T) {
+		service, mocks := setupService()
+		open := &Model{ID: "esc-1", Status: StatusAcknowledged, CurrentTier: 2}
+		mocks.repo.On("FindPolicyByMonitorID", ctx, "monitor-1").Return(threeTierPolicy(), nil)
+		mocks.repo.On("FindOpenByMonitorID", ctx, "monitor-1").Return(open, nil)
+		mocks.repo.On("Update", ctx, open).Return(nil)
+		mocks.delivery.On("Dispatch", ctx, dispatchedTo("slack")).Return(&notification_delivery.Model{}, nil)
+		mocks.delivery.On("Dispatch", ctx, dispatchedTo("pagerduty")).Return(&notification_delivery.Model{}, nil)
+
+		handled, err := service.HandleHeartbeat(ctx, up)
+
+		assert.NoError(t, err)
+		assert.True(t, handled)
+		assert.Equal(t, StatusResolved, open.Status)
+		assert.NotNil(t, open.ResolvedAt)
+		mocks.delivery.AssertNumberOfCalls(t, "Dispatch", 2)
+		mocks.delivery.AssertNotCalled(t, "Dispatch", ctx, dispatchedTo("phone"))
+	})
+}
+
+func TestServiceImpl_Escalate(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("stops at an acknowledged escalation", func(t *testing.T) {
+		service, mocks := setupService()
+		mocks.repo.On("FindByID", ctx, "esc-1", "").Return(&Model{ID: "esc-1", Status: StatusAcknowledged, CurrentTier: 1}, nil)
+
+		err := service.Escalate(ctx, "esc-1", 2)
+
+		assert.NoError(t, err)
+		mocks.delivery.AssertNotCalled(t, "Dispatch", mock.Anything, mock.Anything)
+		mocks.queueService.AssertNotCalled(t, "Enqueue", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("ignores tiers that were already notified", func(t *testing.T) {
+		service, mocks := setupService()
+		mocks.repo.On("FindByID", ctx, "esc-1", "").Return(&Model{ID: "esc-1", Status: StatusActive, CurrentTier: 2}, nil)
+
+		err := service.Escalate(ctx, "esc-1", 2)
+
+		assert.NoError(t, err)
+		mocks.delivery.AssertNotCalled(t, "Dispatch", mock.Anything, mock.Anything)
+	})
+
+	t.Run("notifies the last tier without scheduling another one", func(t *testing.T) {
+		service, mocks := setupService()
+		escalation := &Model{
+			ID: "esc-1", OrgID: "org-1", PolicyID: "policy-1", MonitorID: "monitor-1", HeartbeatID: "hb-1",
+			Status: StatusActive, CurrentTier: 2, StartedAt: time.Now().Add(-30 * time.Minute),
+		}
+		mocks.repo.On("FindByID", ctx, "esc-1", "").Return(escalation, nil)
+		mocks.repo.On("FindPolicyByID", ctx, "policy-1", "").Return(threeTierPolicy(), nil)
+		mocks.heartbeat.On("FindByID", ctx, "hb-1").Return(&heartbeat.Model{ID: "hb-1", Status: shared.MonitorStatusDown, Msg: "timeout"}, nil)
+		mocks.repo.On("Update", ctx, escalation).Return(nil)
+		mocks.delivery.On("Dispatch", ctx, mock.MatchedBy(func(d *notification_delivery.Model) bool {
+			return d.NotificationID == "phone" && d.Heartbeat != nil && d.Message == "Escalated to tier 3, still down after 30 minutes: timeout"
+		})).Return(&notification_delivery.Model{}, nil)
+
+		err := service.Escalate(ctx, "esc-1", 3)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, escalation.CurrentTier)
+		mocks.delivery.AssertExpectations(t)
+		mocks.queueService.AssertNotCalled(t, "Enqueue", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestServiceImpl_Acknowledge(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("acknowledges an active escalation", func(t *testing.T) {
+		service, mocks := setupService()
+		escalation := &Model{ID: "esc-1", Status: StatusActive}
+		mocks.repo.On("FindByID", ctx, "esc-1", "org-1").Return(escalation, nil)
+		mocks.repo.On("Update", ctx, escalation).Return(nil)
+
+		result, err := service.Acknowledge(ctx, "esc-1", "user-1", "org-1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, StatusAcknowledged, result.Status)
+		assert.Equal(t, "user-1", result.AcknowledgedBy)
+		assert.NotNil(t, result.AcknowledgedAt)
+	})
+
+	t.Run("rejects resolved escalations", func(t *testing.T) {
+		service, mocks := setupService()
+		mocks.repo.On("FindByID", ctx, "esc-1", "org-1").Return(&Model{ID: "esc-1", Status: StatusResolved}, nil)
+
+		result, err := service.Acknowledge(ctx, "esc-1", "user-1", "org-1")
+
+		assert.ErrorIs(t, err, ErrNotActive)
+		assert.Nil(t, result)
+	})
+}
+
+func TestServiceImpl_CreatePolicy(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("rejects a delayed first step", func(t *testing.T) {
+		service, _ := setupService()
+
+		_, err := service.CreatePolicy(ctx, &PolicyDTO{
+			Name:  "On-call",
+			Steps: []*StepDTO{{DelayMinutes: 5, NotificationIDs: []string{"slack"}}},
+		}, "org-1")
+
+		assert.ErrorIs(t, err, ErrInvalidSteps)
+	})
+
+	t.Run("rejects delays that do not increase", func(t *testing.T) {
+		service, _ := setupService()
+
+		_, err := service.CreatePolicy(ctx, &PolicyDTO{
+			Name: "On-call",
+			Steps: []*StepDTO{
+				{DelayMinutes: 0, NotificationIDs: []string{"slack"}},
+				{DelayMinutes: 10, NotificationIDs: []string{"pagerduty"}},
+				{DelayMinutes: 10, NotificationIDs: []string{"phone"}},
+			},
+		}, "org-1")
+
+		assert.ErrorIs(t, err, ErrInvalidSteps)
+	})
+
+	t.Run("rejects monitors attached to another policy", func(t *testing.T) {
+		service, mocks := setupService()
+		mocks.repo.On("FindPolicyByMonitorID", ctx, "monitor-1").Return(&Policy{ID: "policy-2"}, nil)
+
+		_, err := service.CreatePolicy(ctx, &PolicyDTO{
+			Name:       "On-call",
+			Steps:      []*StepDTO{{DelayMinutes: 0, NotificationIDs: []string{"slack"}}},
+			MonitorIDs: []string{"monitor-1"},
+		}, "org-1")
+
+		assert.ErrorIs(t, err, ErrMonitorHasPolicy)
+	})
+
+	t.Run("rejects monitors of another organization", func(t *testing.T) {
+		service, mocks := setupService()
+
+		_, err := service.CreatePolicy(ctx, &PolicyDTO{
+			Name:       "On-call",
+			Steps:      []*StepDTO{{DelayMinutes: 0, NotificationIDs: []string{"slack"}}},
+			MonitorIDs: []string{"monitor-1", "monitor-2"},
+		}, "org-1")
+
+		assert.ErrorIs(t, err, ErrMonitorNotFound)
+		mocks.repo.AssertNotCalled(t, "CreatePolicy", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects unknown notification channels", func(t *testing.T) {
+		service, mocks := setupService()
+
+		_, err := service.CreatePolicy(ctx, &PolicyDTO{
+			Name: "On-call",
+			Steps: []*StepDTO{
+				{DelayMinutes: 0, NotificationIDs: []string{"slack"}},
+				{DelayMinutes: 10, NotificationIDs: []string{"other-org-webhook"}},
+			},
+		}, "org-1")
+
+		assert.ErrorIs(t, err, ErrChannelNotFound)
+		mocks.repo.AssertNotCalled(t, "CreatePolicy", mock.Anything, mock.Anything)
+	})
+
+	t.Run("creates an active policy by default", func(t *testing.T) {
+		service, mocks := setupService()
+		mocks.repo.On("FindPolicyByMonitorID", ctx, "monitor-1").Return(nil, nil)
+		mocks.repo.On("CreatePolicy", ctx, mock.MatchedBy(func(p *Policy) bool {
+			return p.OrgID == "org-1" && p.Active && len(p.Steps) == 2 && p.Steps[1].DelayMinutes == 15
+		})).Return(&Policy{ID: "policy-1"}, nil)
+
+		policy, err := service.CreatePolicy(ctx, &PolicyDTO{
+			Name: "On-call",
+			Steps: []*StepDTO{
+				{DelayMinutes: 0, NotificationIDs: []string{"slack"}},
+				{DelayMinutes: 15, NotificationIDs: []string{"pagerduty"}},
+			},
+			MonitorIDs: []string{"monitor-1"},
+		}, "org-1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "policy-1", policy.ID)
+	})
+}
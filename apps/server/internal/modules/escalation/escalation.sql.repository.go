@@ -0,0 +1,402 @@
+package escalation
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type sqlPolicy struct {
+	bun.BaseModel `bun:"table:escalation_policies,alias:ep"`
+
+	ID        string    `bun:"id,pk"`
+	OrgID     string    `bun:"org_id"`
+	Name      string    `bun:"name,notnull"`
+	Active    bool      `bun:"active,notnull,default:true"`
+	Steps     string    `bun:"steps,notnull"`
+	CreatedAt time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp"`
+	UpdatedAt time.Time `bun:"updated_at,nullzero,notnull,default:current_timestamp"`
+}
+
+type sqlPolicyMonitor struct {
+	bun.BaseModel `bun:"table:escalation_policy_monitors,alias:epm"`
+
+	PolicyID  string `bun:"policy_id,pk"`
+	MonitorID string `bun:"monitor_id,pk"`
+}
+
+type sqlModel struct {
+	bun.BaseModel `bun:"table:escalations,alias:e"`
+
+	ID             string     `bun:"id,pk"`
+	OrgID          string     `bun:"org_id"`
+	PolicyID       string     `bun:"policy_id,notnull"`
+	MonitorID      string     `bun:"monitor_id,notnull"`
+	HeartbeatID    *string    `bun:"heartbeat_id"`
+	Status         string     `bun:"status,notnull"`
+	CurrentTier    int        `bun:"current_tier,notnull,default:0"`
+	StartedAt      time.Time  `bun:"started_at,notnull"`
+	AcknowledgedAt *time.Time `bun:"acknowledged_at"`
+	AcknowledgedBy *string    `bun:"acknowledged_by"`
+	ResolvedAt     *time.Time `bun:"resolved_at"`
+	CreatedAt      time.Time  `bun:"created_at,nullzero,notnull,default:current_timestamp"`
+	UpdatedAt      time.Time  `bun:"updated_at,nullzero,notnull,default:current_timestamp"`
+}
+
+func toDomainPolicyFromSQL(sp *sqlPolicy) (*Policy, error) {
+	steps := []*Step{}
+	if sp.Steps != "" {
+		if err := json.Unmarshal([]byte(sp.Steps), &steps); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Policy{
+		ID:         sp.ID,
+		OrgID:      sp.OrgID,
+		Name:       sp.Name,
+		Active:     sp.Active,
+		Steps:      steps,
+		MonitorIDs: []string{},
+		CreatedAt:  sp.CreatedAt,
+		UpdatedAt:  sp.UpdatedAt,
+	}, nil
+}
+
+func toSQLPolicy(p *Policy) (*sqlPolicy, error) {
+	steps, err := json.Marshal(p.Steps)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sqlPolicy{
+		ID:        p.ID,
+		OrgID:     p.OrgID,
+		Name:      p.Name,
+		Active:    p.Active,
+		Steps:     string(steps),
+		CreatedAt: p.CreatedAt,
+		UpdatedAt: p.UpdatedAt,
+	}, nil
+}
+
+func toDomainModelFromSQL(sm *sqlModel) *Model {
+	m := &Model{
+		ID:             sm.ID,
+		OrgID:          sm.OrgID,
+		PolicyID:       sm.PolicyID,
+		MonitorID:      sm.MonitorID,
+		Status:         sm.Status,
+		CurrentTier:    sm.CurrentTier,
+		StartedAt:      sm.StartedAt,
+		AcknowledgedAt: sm.AcknowledgedAt,
+		ResolvedAt:     sm.ResolvedAt,
+		CreatedAt:      sm.CreatedAt,
+		UpdatedAt:      sm.UpdatedAt,
+	}
+	if sm.HeartbeatID != nil {
+		m.HeartbeatID = *sm.HeartbeatID
+	}
+	if sm.AcknowledgedBy != nil {
+		m.AcknowledgedBy = *sm.AcknowledgedBy
+	}
+	return m
+}
+
+func toSQLModel(m *Model) *sqlModel {
+	sm := &sqlModel{
+		ID:             m.ID,
+		OrgID:          m.OrgID,
+		PolicyID:       m.PolicyID,
+		MonitorID:      m.MonitorID,
+		Status:         m.Status,
+		CurrentTier:    m.CurrentTier,
+		StartedAt:      m.StartedAt,
+		AcknowledgedAt: m.AcknowledgedAt,
+		ResolvedAt:     m.ResolvedAt,
+		CreatedAt:      m.CreatedAt,
+		UpdatedAt:      m.UpdatedAt,
+	}
+	if m.HeartbeatID != "" {
+		sm.HeartbeatID = &m.HeartbeatID
+	}
+	if m.AcknowledgedBy != "" {
+		sm.AcknowledgedBy = &m.AcknowledgedBy
+	}
+	return sm
+}
+
+type SQLRepositoryImpl struct {
+	db *bun.DB
+}
+
+func NewSQLRepository(db *bun.DB) Repository {
+	return &SQLRepositoryImpl{db: db}
+}
+
+func (r *SQLRepositoryImpl) CreatePolicy(ctx context.Context, policy *Policy) (*Policy, error) {
+	sp, err := toSQLPolicy(policy)
+	if err != nil {
+		return nil, err
+	}
+	sp.ID = uuid.New().String()
+	sp.CreatedAt = time.Now()
+	sp.UpdatedAt = time.Now()
+
+	err = r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.NewInsert().Model(sp).Exec(ctx); err != nil {
+			return err
+		}
+		return insertPolicyMonitors(ctx, tx, sp.ID, policy.MonitorIDs)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := toDomainPolicyFromSQL(sp)
+	if err != nil {
+		return nil, err
+	}
+	created.MonitorIDs = uniqueIDs(policy.MonitorIDs)
+	return created, nil
+}
+
+func (r *SQLRepositoryImpl) FindPolicyByID(ctx context.Context, id string, orgID string) (*Policy, error) {
+	return r.findPolicy(ctx, func(q *bun.SelectQuery) *bun.SelectQuery {
+		q = q.Where("id = ?", id)
+		if orgID != "" {
+			q = q.Where("org_id = ?", orgID)
+		}
+		return q
+	})
+}
+
+func (r *SQLRepositoryImpl) FindPolicies(ctx context.Context, page int, limit int, orgID string) ([]*Policy, error) {
+	var sps []*sqlPolicy
+	err := r.db.NewSelect().Model(&sps).
+		Where("org_id = ?", orgID).
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(page * limit).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return r.withMonitors(ctx, sps)
+}
+
+func (r *SQLRepositoryImpl) FindPolicyByMonitorID(ctx context.Context, monitorID string) (*Policy, error) {
+	attached := r.db.NewSelect().Model((*sqlPolicyMonitor)(nil)).
+		Column("policy_id").
+		Where("monitor_id = ?", monitorID)
+
+	return r.findPolicy(ctx, func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q.Where("id IN (?)", attached)
+	})
+}
+
+func (r *SQLRepositoryImpl) UpdatePolicy(ctx context.Context, policy *Policy) error {
+	sp, err := toSQLPolicy(policy)
+	if err != nil {
+		return err
+	}
+
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		_, err := tx.NewUpdate().Model((*sqlPolicy)(nil)).
+			Set("name = ?", sp.Name).
+			Set("active = ?", sp.Active).
+			Set("steps = ?", sp.Steps).
+			Set("updated_at = ?", time.Now()).
+			Where("id = ?", sp.ID).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		if _, err := tx.NewDelete().Model((*sqlPolicyMonitor)(nil)).Where("policy_id = ?", sp.ID).Exec(ctx); err != nil {
+			return err
+		}
+		return insertPolicyMonitors(ctx, tx, sp.ID, policy.MonitorIDs)
+	})
+}
+
+func (r *SQLRepositoryImpl) DeletePolicy(ctx context.Context, id string, orgID string) error {
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		res, err := tx.NewDelete().Model((*sqlPolicy)(nil)).Where("id = ?", id).Where("org_id = ?", orgID).Exec(ctx)
+		if err != nil {
+			return err
+		}
+		if n, _ := res.RowsAffected(); n == 0 {
+			return nil
+		}
+
+		// Not every backend enforces the foreign key cascades
+		if _, err := tx.NewDelete().Model((*sqlPolicyMonitor)(nil)).Where("policy_id = ?", id).Exec(ctx); err != nil {
+			return err
+		}
+		_, err = tx.NewDelete().Model((*sqlModel)(nil)).Where("policy_id = ?", id).Exec(ctx)
+		return err
+	})
+}
+
+func (r *SQLRepositoryImpl) Create(ctx context.Context, escalation *Model) (*Model, error) {
+	sm := toSQLModel(escalation)
+	sm.ID = uuid.New().String()
+	sm.CreatedAt = time.Now()
+	sm.UpdatedAt = time.Now()
+	if sm.StartedAt.IsZero() {
+		sm.StartedAt = sm.CreatedAt
+	}
+
+	if _, err := r.db.NewInsert().Model(sm).Exec(ctx); err != nil {
+		return nil, err
+	}
+	return toDomainModelFromSQL(sm), nil
+}
+
+func (r *SQLRepositoryImpl) FindByID(ctx context.Context, id string, orgID string) (*Model, error) {
+	return r.findOne(ctx, func(q *bun.SelectQuery) *bun.SelectQuery {
+		q = q.Where("id = ?", id)
+		if orgID != "" {
+			q = q.Where("org_id = ?", orgID)
+		}
+		return q
+	})
+}
+
+func (r *SQLRepositoryImpl) FindAll(ctx context.Context, page int, limit int, status string, orgID string) ([]*Model, error) {
+	query := r.db.NewSelect().Model((*sqlModel)(nil)).Where("org_id = ?", orgID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	query = query.Order("started_at DESC").
+		Limit(limit).
+		Offset(page * limit)
+
+	var sms []*sqlModel
+	if err := query.Scan(ctx, &sms); err != nil {
+		return nil, err
+	}
+
+	models := make([]*Model, 0, len(sms))
+	for _, sm := range sms {
+		models = append(models, toDomainModelFromSQL(sm))
+	}
+	return models, nil
+}
+
+func (r *SQLRepositoryImpl) FindOpenByMonitorID(ctx context.Context, monitorID string) (*Model, error) {
+	return r.findOne(ctx, func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q.Where("monitor_id = ?", monitorID).
+			Where("status IN (?)", bun.In([]string{StatusActive, StatusAcknowledged})).
+			Order("started_at DESC")
+	})
+}
+
+func (r *SQLRepositoryImpl) Update(ctx context.Context, escalation *Model) error {
+	sm := toSQLModel(escalation)
+	_, err := r.db.NewUpdate().Model((*sqlModel)(nil)).
+		Set("status = ?", sm.Status).
+		Set("current_tier = ?", sm.CurrentTier).
+		Set("acknowledged_at = ?", sm.AcknowledgedAt).
+		Set("acknowledged_by = ?", sm.AcknowledgedBy).
+		Set("resolved_at = ?", sm.ResolvedAt).
+		Set("updated_at = ?", time.Now()).
+		Where("id = ?", sm.ID).
+		Exec(ctx)
+	return err
+}
+
+func (r *SQLRepositoryImpl) findPolicy(ctx context.Context, where func(q *bun.SelectQuery) *bun.SelectQuery) (*Policy, error) {
+	sp := new(sqlPolicy)
+	err := where(r.db.NewSelect().Model(sp)).Limit(1).Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	policies, err := r.withMonitors(ctx, []*sqlPolicy{sp})
+	if err != nil {
+		return nil, err
+	}
+	return policies[0], nil
+}
+
+func (r *SQLRepositoryImpl) findOne(ctx context.Context, where func(q *bun.SelectQuery) *bun.SelectQuery) (*Model, error) {
+	sm := new(sqlModel)
+	err := where(r.db.NewSelect().Model(sm)).Limit(1).Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toDomainModelFromSQL(sm), nil
+}
+
+// withMonitors converts policies to domain models with their monitors loaded
+func (r *SQLRepositoryImpl) withMonitors(ctx context.Context, sps []*sqlPolicy) ([]*Policy, error) {
+	policies := make([]*Policy, 0, len(sps))
+	if len(sps) == 0 {
+		return policies, nil
+	}
+
+	byID := make(map[string]*Policy, len(sps))
+	ids := make([]string, 0, len(sps))
+	for _, sp := range sps {
+		p, err := toDomainPolicyFromSQL(sp)
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, p)
+		byID[p.ID] = p
+		ids = append(ids, p.ID)
+	}
+
+	var links []*sqlPolicyMonitor
+	err := r.db.NewSelect().Model(&links).Where("policy_id IN (?)", bun.In(ids)).Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, link := range links {
+		if p, ok := byID[link.PolicyID]; ok {
+			p.MonitorIDs = append(p.MonitorIDs, link.MonitorID)
+		}
+	}
+	return policies, nil
+}
+
+func insertPolicyMonitors(ctx context.Context, tx bun.Tx, policyID string, monitorIDs []string) error {
+	ids := uniqueIDs(monitorIDs)
+	if len(ids) == 0 {
+		return nil
+	}
+
+	links := make([]*sqlPolicyMonitor, 0, len(ids))
+	for _, monitorID := range ids {
+		links = append(links, &sqlPolicyMonitor{PolicyID: policyID, MonitorID: monitorID})
+	}
+	_, err := tx.NewInsert().Model(&links).Exec(ctx)
+	return err
+}
+
+func uniqueIDs(ids []string) []string {
+	seen := make(map[string]bool, len(ids))
+	result := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		result = append(result, id)
+	}
+	return result
+}
@@ -22,6 +22,14 @@ const (
 	CertificateExpiry EventType = "certificate.expiry"
 	// ImportantHeartbeat is emitted when a heartbeat is important for notification purposes
 	ImportantHeartbeat EventType = "important.heartbeat"
+	// IncidentCreated is emitted when an incident is opened
+	IncidentCreated EventType = "incident.created"
+	// IncidentUpdated is emitted when an update is posted to an incident timeline
+	IncidentUpdated EventType = "incident.updated"
+	// MaintenanceScheduled is emitted when a maintenance is created
+	MaintenanceScheduled EventType = "maintenance.scheduled"
+	// MonitorGroupStatusChanged is emitted when the rolled-up status of a monitor group changes
+	MonitorGroupStatusChanged EventType = "monitor_group.status.changed"
 )
 
 // Event represents a generic event with a type and payload
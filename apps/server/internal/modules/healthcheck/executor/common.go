@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/url"
+	"strings"
 	"vigi/internal/modules/shared"
 	"vigi/internal/utils"
 	"time"
@@ -34,6 +35,58 @@ func DownResult(err error, startTime, endTime time.Time) *Result {
 	}
 }
 
+// Helper to create a degraded result
+func DegradedResult(reason string, startTime, endTime time.Time) *Result {
+	return &Result{
+		Status:    shared.MonitorStatusDegraded,
+		Message:   reason,
+		StartTime: startTime,
+		EndTime:   endTime,
+	}
+}
+
+// DegradedThresholds are soft limits embedded in executor configs. A check that
+// passes but crosses one of them is reported as degraded instead of up, zero
+// disables a threshold
+type DegradedThresholds struct {
+	// DegradedResponseTime is the response time, or query duration for databases, in milliseconds
+	DegradedResponseTime int `json:"degraded_response_time,omitempty" validate:"omitempty,min=0" example:"2000"`
+	// DegradedTLSDays is the number of days left on the certificate
+	DegradedTLSDays int `json:"degraded_tls_days,omitempty" validate:"omitempty,min=0" example:"14"`
+}
+
+// Apply downgrades an up result to degraded when a threshold is crossed and
+// returns any other result untouched
+func (d DegradedThresholds) Apply(result *Result) *Result {
+	if result == nil || result.Status != shared.MonitorStatusUp {
+		return result
+	}
+
+	var reasons []string
+	if d.DegradedResponseTime > 0 {
+		elapsed := result.EndTime.Sub(result.StartTime).Milliseconds()
+		if elapsed > int64(d.DegradedResponseTime) {
+			reasons = append(reasons, fmt.Sprintf("response time %dms exceeds %dms", elapsed, d.DegradedResponseTime))
+		}
+	}
+	if d.DegradedTLSDays > 0 && result.TLSInfo != nil && result.TLSInfo.CertInfo != nil {
+		if days := result.TLSInfo.CertInfo.DaysRemaining; days < d.DegradedTLSDays {
+			reasons = append(reasons, fmt.Sprintf("certificate expires in %d days, below %d", days, d.DegradedTLSDays))
+		}
+	}
+	if len(reasons) == 0 {
+		return result
+	}
+
+	degraded := DegradedResult(
+		fmt.Sprintf("Degraded: %s (%s)", strings.Join(reasons, ", "), result.Message),
+		result.StartTime,
+		result.EndTime,
+	)
+	degraded.TLSInfo = result.TLSInfo
+	return degraded
+}
+
 // ValidateConnectionString validates database connection strings for various database types
 func ValidateConnectionString(connectionString string, supportedSchemes []string) error {
 	return ValidateConnectionStringWithOptions(connectionString, supportedSchemes, false)
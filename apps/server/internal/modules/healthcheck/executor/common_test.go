@@ -1,8 +1,11 @@
 package executor
 
 import (
-	"vigi/internal/utils"
 	"testing"
+	"time"
+	"vigi/internal/modules/certificate"
+	"vigi/internal/modules/shared"
+	"vigi/internal/utils"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -487,3 +490,92 @@ func TestGenericUnmarshal_VariousTypes(t *testing.T) {
 func stringPtr(s string) *string {
 	return &s
 }
+
+func TestDegradedThresholds_Apply(t *testing.T) {
+	start := time.Now()
+	upResult := func(elapsed time.Duration, daysRemaining int) *Result {
+		result := &Result{
+			Status:    shared.MonitorStatusUp,
+			Message:   "200 - 200 OK",
+			StartTime: start,
+			EndTime:   start.Add(elapsed),
+		}
+		if daysRemaining >= 0 {
+			result.TLSInfo = &certificate.TLSInfo{
+				Valid:    true,
+				CertInfo: &shared.CertificateInfo{DaysRemaining: daysRemaining},
+			}
+		}
+		return result
+	}
+
+	tests := []struct {
+		name            string
+		thresholds      DegradedThresholds
+		result          *Result
+		expectedStatus  shared.MonitorStatus
+		expectedMessage string
+	}{
+		{
+			name:           "disabled thresholds keep the result up",
+			thresholds:     DegradedThresholds{},
+			result:         upResult(9*time.Second, 1),
+			expectedStatus: shared.MonitorStatusUp,
+		},
+		{
+			name:           "response time under the threshold",
+			thresholds:     DegradedThresholds{DegradedResponseTime: 2000},
+			result:         upResult(500*time.Millisecond, -1),
+			expectedStatus: shared.MonitorStatusUp,
+		},
+		{
+			name:            "slow response is degraded",
+			thresholds:      DegradedThresholds{DegradedResponseTime: 2000},
+			result:          upResult(9*time.Second, -1),
+			expectedStatus:  shared.MonitorStatusDegraded,
+			expectedMessage: "response time 9000ms exceeds 2000ms",
+		},
+		{
+			name:            "certificate close to expiry is degraded",
+			thresholds:      DegradedThresholds{DegradedTLSDays: 14},
+			result:          upResult(100*time.Millisecond, 3),
+			expectedStatus:  shared.MonitorStatusDegraded,
+			expectedMessage: "certificate expires in 3 days, below 14",
+		},
+		{
+			name:           "tls threshold without certificate",
+			thresholds:     DegradedThresholds{DegradedTLSDays: 14},
+			result:         upResult(100*time.Millisecond, -1),
+			expectedStatus: shared.MonitorStatusUp,
+		},
+		{
+			name:       "down result is left untouched",
+			thresholds: DegradedThresholds{DegradedResponseTime: 1},
+			result: DownResult(
+				assert.AnError, start, start.Add(time.Minute),
+			),
+			expectedStatus: shared.MonitorStatusDown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.thresholds.Apply(tt.result)
+			assert.Equal(t, tt.expectedStatus, result.Status)
+			assert.Equal(t, tt.result.TLSInfo, result.TLSInfo)
+			if tt.expectedMessage != "" {
+				assert.Contains(t, result.Message, tt.expectedMessage)
+				assert.Contains(t, result.Message, tt.result.Message)
+			}
+		})
+	}
+}
+
+func TestDegradedThresholds_Unmarshal(t *testing.T) {
+	cfg, err := GenericUnmarshal[PostgresConfig](`{"database_connection_string": "postgres://u:p@localhost:5432/db", "degraded_response_time": 1500}`)
+	assert.NoError(t, err)
+	assert.Equal(t, 1500, cfg.DegradedResponseTime)
+
+	cfg.DegradedResponseTime = -1
+	assert.Error(t, GenericValidator(cfg))
+}
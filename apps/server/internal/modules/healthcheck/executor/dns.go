@@ -1,12 +1,19 @@
 package executor
 
 import (
+	"bytes"
 	"context"
+	"crypto/tls"
 	"fmt"
+	"io"
 	"net"
-	"vigi/internal/modules/shared"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
+	"vigi/internal/modules/shared"
 
 	"github.com/miekg/dns"
 	"go.uber.org/zap"
@@ -17,16 +24,86 @@ type DNSConfig struct {
 	ResolverServer string `json:"resolver_server" validate:"required,ip" example:"1.1.1.1"`
 	Port           int    `json:"port" validate:"required,min=1,max=65535" example:"53"`
 	ResolveType    string `json:"resolve_type" validate:"required,oneof=A AAAA CAA CNAME MX NS PTR SOA SRV TXT" example:"A"`
+
+	// Transport to the resolver, DNS over TLS and DNS over HTTPS verify the resolver
+	// certificate against tls_server_name, or the resolver IP when it is empty
+	Transport       string `json:"transport,omitempty" validate:"omitempty,oneof=udp tcp dot doh" example:"udp"`
+	TLSServerName   string `json:"tls_server_name,omitempty" example:"cloudflare-dns.com"`
+	DohPath         string `json:"doh_path,omitempty" validate:"omitempty,startswith=/" example:"/dns-query"`
+	IgnoreTlsErrors bool   `json:"ignore_tls_errors,omitempty" example:"false"`
+
+	// ExpectedValues are compared with the records according to MatchMode: exact
+	// requires the same set of records, contains requires every expected record and
+	// regex requires every record to match one of the patterns
+	ExpectedValues []string `json:"expected_values,omitempty" validate:"omitempty,dive,required" example:"93.184.215.14"`
+	MatchMode      string   `json:"match_mode,omitempty" validate:"omitempty,oneof=exact contains regex" example:"exact"`
+
+	// DetectSoaSerialChange reports the monitor down for one check when the serial
+	// of the zone changes
+	DetectSoaSerialChange bool `json:"detect_soa_serial_change,omitempty" example:"false"`
+	// DNSSEC validates the signatures of the answer up to the root trust anchor
+	DNSSEC bool `json:"dnssec,omitempty" example:"false"`
+
+	// Soft limits that report a passing check as degraded
+	DegradedThresholds
+}
+
+const defaultDohPath = "/dns-query"
+
+var dnsRecordTypes = map[string]uint16{
+	"A":     dns.TypeA,
+	"AAAA":  dns.TypeAAAA,
+	"CAA":   dns.TypeCAA,
+	"CNAME": dns.TypeCNAME,
+	"MX":    dns.TypeMX,
+	"NS":    dns.TypeNS,
+	"PTR":   dns.TypePTR,
+	"SOA":   dns.TypeSOA,
+	"SRV":   dns.TypeSRV,
+	"TXT":   dns.TypeTXT,
+}
+
+// soaSerialStaleAfter drops the serial of monitors that stopped being checked
+const soaSerialStaleAfter = 7 * 24 * time.Hour
+
+func soaSerialStateKey(monitorID string) string {
+	return "dns-soa-serial:" + monitorID
 }
 
 type DNSExecutor struct {
-	logger *zap.SugaredLogger
+	logger       *zap.SugaredLogger
+	state        StateStore
+	trustAnchors map[string][]*dns.DS
 }
 
-func NewDNSExecutor(logger *zap.SugaredLogger) *DNSExecutor {
+func NewDNSExecutor(logger *zap.SugaredLogger, state StateStore) *DNSExecutor {
 	return &DNSExecutor{
-		logger: logger,
+		logger:       logger,
+		state:        state,
+		trustAnchors: rootTrustAnchors(),
+	}
+}
+
+// swapSoaSerial stores the serial seen by this check and returns the one seen
+// by the previous check of the monitor, whichever worker ran it
+func (d *DNSExecutor) swapSoaSerial(ctx context.Context, monitorID string, serial uint32) (uint32, bool) {
+	key := soaSerialStateKey(monitorID)
+	value, seen, err := d.state.Get(ctx, key)
+	if err != nil {
+		d.logger.Warnf("Failed to load SOA serial of monitor %s: %v", monitorID, err)
+	}
+	if err := d.state.Set(ctx, key, strconv.FormatUint(uint64(serial), 10), soaSerialStaleAfter); err != nil {
+		d.logger.Warnf("Failed to store SOA serial of monitor %s: %v", monitorID, err)
+	}
+	if !seen {
+		return 0, false
+	}
+
+	previous, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return 0, false
 	}
+	return uint32(previous), true
 }
 
 func (s *DNSExecutor) Unmarshal(configJSON string) (any, error) {
@@ -38,7 +115,31 @@ func (s *DNSExecutor) Validate(configJSON string) error {
 	if err != nil {
 		return err
 	}
-	return GenericValidator(cfg.(*DNSConfig))
+
+	config := cfg.(*DNSConfig)
+
+	if err := GenericValidator(config); err != nil {
+		return err
+	}
+
+	if config.MatchMode != "" && len(config.ExpectedValues) == 0 {
+		return fmt.Errorf("expected_values are required when match_mode is set")
+	}
+	if len(config.ExpectedValues) > 0 && config.MatchMode == "" {
+		return fmt.Errorf("match_mode is required when expected_values are set")
+	}
+	if config.MatchMode == "regex" {
+		for _, pattern := range config.ExpectedValues {
+			if _, err := regexp.Compile(pattern); err != nil {
+				return fmt.Errorf("invalid expected value pattern %q: %w", pattern, err)
+			}
+		}
+	}
+	if config.DetectSoaSerialChange && config.ResolveType != "SOA" {
+		return fmt.Errorf("detect_soa_serial_change requires resolve_type 'SOA'")
+	}
+
+	return nil
 }
 
 func (d *DNSExecutor) Execute(ctx context.Context, m *Monitor, proxyModel *Proxy) *Result {
@@ -50,150 +151,10 @@ func (d *DNSExecutor) Execute(ctx context.Context, m *Monitor, proxyModel *Proxy
 
 	d.logger.Debugf("execute dns cfg: %+v", cfg)
 
-	// Create custom resolver with specified DNS server
-	r := &net.Resolver{
-		PreferGo: true,
-		Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
-			dialer := &net.Dialer{
-				Timeout: time.Duration(m.Timeout) * time.Second,
-			}
-			return dialer.DialContext(ctx, network, fmt.Sprintf("%s:%d", cfg.ResolverServer, cfg.Port))
-		},
-	}
-
 	startTime := time.Now().UTC()
-	var recordsFound bool
-	var message string
-
-	switch strings.ToUpper(cfg.ResolveType) {
-	case "A":
-		var ips []net.IP
-		ips, err = r.LookupIP(ctx, "ip4", cfg.Host)
-		if err == nil && len(ips) > 0 {
-			recordsFound = true
-			ipStrings := make([]string, len(ips))
-			for i, ip := range ips {
-				ipStrings[i] = ip.String()
-			}
-			message = fmt.Sprintf("A records: %s", strings.Join(ipStrings, ", "))
-		}
-	case "AAAA":
-		var ips []net.IP
-		ips, err = r.LookupIP(ctx, "ip6", cfg.Host)
-		if err == nil && len(ips) > 0 {
-			recordsFound = true
-			ipStrings := make([]string, len(ips))
-			for i, ip := range ips {
-				ipStrings[i] = ip.String()
-			}
-			message = fmt.Sprintf("AAAA records: %s", strings.Join(ipStrings, ", "))
-		}
-	case "CNAME":
-		var cname string
-		cname, err = r.LookupCNAME(ctx, cfg.Host)
-		if err == nil && cname != "" {
-			recordsFound = true
-			message = fmt.Sprintf("CNAME: %s", cname)
-		}
-	case "MX":
-		var mxRecords []*net.MX
-		mxRecords, err = r.LookupMX(ctx, cfg.Host)
-		if err == nil && len(mxRecords) > 0 {
-			recordsFound = true
-			mxStrings := make([]string, len(mxRecords))
-			for i, mx := range mxRecords {
-				mxStrings[i] = fmt.Sprintf("%s (priority: %d)", mx.Host, mx.Pref)
-			}
-			message = fmt.Sprintf("MX records: %s", strings.Join(mxStrings, ", "))
-		}
-	case "NS":
-		var nsRecords []*net.NS
-		nsRecords, err = r.LookupNS(ctx, cfg.Host)
-		if err == nil && len(nsRecords) > 0 {
-			recordsFound = true
-			nsStrings := make([]string, len(nsRecords))
-			for i, ns := range nsRecords {
-				nsStrings[i] = ns.Host
-			}
-			message = fmt.Sprintf("NS records: %s", strings.Join(nsStrings, ", "))
-		}
-	case "TXT":
-		var txtRecords []string
-		txtRecords, err = r.LookupTXT(ctx, cfg.Host)
-		if err == nil && len(txtRecords) > 0 {
-			recordsFound = true
-			message = fmt.Sprintf("TXT records: %s", strings.Join(txtRecords, "; "))
-		}
-	case "PTR":
-		// For PTR records, we need to reverse the IP address
-		// This is a simplified version - in production you might want more robust handling
-		var names []string
-		names, err = r.LookupAddr(ctx, cfg.Host)
-		if err == nil && len(names) > 0 {
-			recordsFound = true
-			message = fmt.Sprintf("PTR records: %s", strings.Join(names, ", "))
-		}
-	case "SRV":
-		// SRV records require a service and protocol, which we'll extract from the host
-		// Format: _service._proto.name
-		var srvCname string
-		var srvRecords []*net.SRV
-		srvCname, srvRecords, err = r.LookupSRV(ctx, "", "", cfg.Host)
-		if err == nil && len(srvRecords) > 0 {
-			recordsFound = true
-			srvStrings := make([]string, len(srvRecords))
-			for i, srv := range srvRecords {
-				srvStrings[i] = fmt.Sprintf("%s:%d (priority: %d, weight: %d)", srv.Target, srv.Port, srv.Priority, srv.Weight)
-			}
-			message = fmt.Sprintf("SRV records (cname: %s): %s", srvCname, strings.Join(srvStrings, ", "))
-		}
-	case "CAA":
-		// Use miekg/dns for CAA record lookup
-		msg := new(dns.Msg)
-		msg.SetQuestion(dns.Fqdn(cfg.Host), dns.TypeCAA)
-
-		client := new(dns.Client)
-		client.Timeout = time.Duration(m.Timeout) * time.Second
-
-		var resp *dns.Msg
-		resp, _, err = client.Exchange(msg, fmt.Sprintf("%s:%d", cfg.ResolverServer, cfg.Port))
-
-		if err == nil && resp != nil && len(resp.Answer) > 0 {
-			var caaStrings []string
-			for _, ans := range resp.Answer {
-				if caa, ok := ans.(*dns.CAA); ok {
-					caaStrings = append(caaStrings, fmt.Sprintf("%d %s %q", caa.Flag, caa.Tag, caa.Value))
-				}
-			}
-			if len(caaStrings) > 0 {
-				recordsFound = true
-				message = fmt.Sprintf("CAA records: %s", strings.Join(caaStrings, "; "))
-			}
-		}
-	case "SOA":
-		// Use miekg/dns for SOA record lookup
-		msg := new(dns.Msg)
-		msg.SetQuestion(dns.Fqdn(cfg.Host), dns.TypeSOA)
-
-		client := new(dns.Client)
-		client.Timeout = time.Duration(m.Timeout) * time.Second
-
-		var resp *dns.Msg
-		resp, _, err = client.Exchange(msg, fmt.Sprintf("%s:%d", cfg.ResolverServer, cfg.Port))
 
-		if err == nil && resp != nil && len(resp.Answer) > 0 {
-			for _, ans := range resp.Answer {
-				if soa, ok := ans.(*dns.SOA); ok {
-					recordsFound = true
-					message = fmt.Sprintf("SOA: Primary NS: %s, Admin: %s, Serial: %d, Refresh: %d, Retry: %d, Expire: %d, Min TTL: %d",
-						soa.Ns, soa.Mbox, soa.Serial, soa.Refresh, soa.Retry, soa.Expire, soa.Minttl)
-					break
-				}
-			}
-		}
-	default:
-		err = fmt.Errorf("unsupported record type: %s", cfg.ResolveType)
-	}
+	recordType := strings.ToUpper(cfg.ResolveType)
+	records, err := d.lookup(ctx, cfg, recordType, time.Duration(m.Timeout)*time.Second)
 
 	endTime := time.Now().UTC()
 
@@ -207,7 +168,7 @@ func (d *DNSExecutor) Execute(ctx context.Context, m *Monitor, proxyModel *Proxy
 		}
 	}
 
-	if !recordsFound {
+	if len(records) == 0 {
 		return &Result{
 			Status:    shared.MonitorStatusDown,
 			Message:   fmt.Sprintf("No %s records found for %s", cfg.ResolveType, cfg.Host),
@@ -216,12 +177,331 @@ func (d *DNSExecutor) Execute(ctx context.Context, m *Monitor, proxyModel *Proxy
 		}
 	}
 
+	message := formatDNSRecords(recordType, records)
+
+	if err := assertDNSRecords(recordType, records, cfg); err != nil {
+		d.logger.Infof("DNS assertion failed: %s, %s", m.Name, err.Error())
+		return &Result{
+			Status:    shared.MonitorStatusDown,
+			Message:   fmt.Sprintf("%v, %s", err, message),
+			StartTime: startTime,
+			EndTime:   endTime,
+		}
+	}
+
+	if cfg.DetectSoaSerialChange {
+		if soa, ok := records[0].(*dns.SOA); ok {
+			previous, seen := d.swapSoaSerial(ctx, m.ID, soa.Serial)
+			if seen && previous != soa.Serial {
+				d.logger.Infof("DNS SOA serial changed: %s, %d -> %d", m.Name, previous, soa.Serial)
+				return &Result{
+					Status:    shared.MonitorStatusDown,
+					Message:   fmt.Sprintf("SOA serial changed from %d to %d", previous, soa.Serial),
+					StartTime: startTime,
+					EndTime:   endTime,
+				}
+			}
+		}
+	}
+
+	if cfg.DNSSEC {
+		message += " (DNSSEC validated)"
+	}
+
 	d.logger.Infof("DNS lookup successful: %s, %s", m.Name, message)
 
-	return &Result{
+	return cfg.Apply(&Result{
 		Status:    shared.MonitorStatusUp,
 		Message:   message,
 		StartTime: startTime,
 		EndTime:   endTime,
+	})
+}
+
+// lookup returns the records of the requested type, validated with DNSSEC when enabled
+func (d *DNSExecutor) lookup(ctx context.Context, cfg *DNSConfig, recordType string, timeout time.Duration) ([]dns.RR, error) {
+	qtype, ok := dnsRecordTypes[recordType]
+	if !ok {
+		return nil, fmt.Errorf("unsupported record type: %s", cfg.ResolveType)
+	}
+
+	name := cfg.Host
+	if qtype == dns.TypePTR {
+		// PTR lookups take the IP address, the reverse name is built here
+		if reverse, err := dns.ReverseAddr(cfg.Host); err == nil {
+			name = reverse
+		}
+	}
+	name = dns.Fqdn(name)
+
+	query := func(name string, qtype uint16) (*dns.Msg, error) {
+		return d.exchange(ctx, cfg, name, qtype, timeout)
+	}
+
+	resp, err := query(name, qtype)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Rcode != dns.RcodeSuccess {
+		return nil, fmt.Errorf("resolver answered %s for %s", dns.RcodeToString[resp.Rcode], name)
+	}
+
+	if cfg.DNSSEC {
+		validator := &dnssecValidator{
+			query:   query,
+			anchors: d.trustAnchors,
+			now:     time.Now(),
+		}
+		if err := validator.validateAnswer(resp); err != nil {
+			return nil, fmt.Errorf("DNSSEC validation failed: %w", err)
+		}
+	}
+
+	var records []dns.RR
+	for _, rr := range resp.Answer {
+		if rr.Header().Rrtype == qtype {
+			records = append(records, rr)
+		}
+	}
+
+	// Like the system resolver, a name without CNAME is its own canonical name
+	if qtype == dns.TypeCNAME && len(records) == 0 && hasAddressRecords(resp) {
+		records = append(records, &dns.CNAME{
+			Hdr:    dns.RR_Header{Name: name, Rrtype: dns.TypeCNAME, Class: dns.ClassINET},
+			Target: name,
+		})
+	}
+
+	return records, nil
+}
+
+func hasAddressRecords(resp *dns.Msg) bool {
+	for _, rr := range resp.Answer {
+		if rr.Header().Rrtype == dns.TypeA || rr.Header().Rrtype == dns.TypeAAAA {
+			return true
+		}
+	}
+	return false
+}
+
+// exchange sends one query over the configured transport
+func (d *DNSExecutor) exchange(ctx context.Context, cfg *DNSConfig, name string, qtype uint16, timeout time.Duration) (*dns.Msg, error) {
+	msg := new(dns.Msg)
+	msg.SetQuestion(name, qtype)
+	msg.RecursionDesired = true
+	if cfg.DNSSEC {
+		msg.SetEdns0(4096, true)
+	}
+
+	address := net.JoinHostPort(cfg.ResolverServer, strconv.Itoa(cfg.Port))
+
+	switch cfg.Transport {
+	case "doh":
+		return d.exchangeDoH(ctx, cfg, msg, address, timeout)
+	case "dot":
+		client := &dns.Client{Net: "tcp-tls", Timeout: timeout, TLSConfig: dnsTLSConfig(cfg)}
+		resp, _, err := client.ExchangeContext(ctx, msg, address)
+		return resp, err
+	case "tcp":
+		client := &dns.Client{Net: "tcp", Timeout: timeout}
+		resp, _, err := client.ExchangeContext(ctx, msg, address)
+		return resp, err
+	default:
+		client := &dns.Client{Net: "udp", Timeout: timeout}
+		resp, _, err := client.ExchangeContext(ctx, msg, address)
+		if err == nil && resp.Truncated {
+			// The answer does not fit in a datagram, retry over TCP
+			client.Net = "tcp"
+			resp, _, err = client.ExchangeContext(ctx, msg, address)
+		}
+		return resp, err
+	}
+}
+
+// exchangeDoH sends the query as an RFC 8484 POST request
+func (d *DNSExecutor) exchangeDoH(ctx context.Context, cfg *DNSConfig, msg *dns.Msg, address string, timeout time.Duration) (*dns.Msg, error) {
+	// The ID is 0 so HTTP caches can share answers
+	msg.Id = 0
+	packed, err := msg.Pack()
+	if err != nil {
+		return nil, err
+	}
+
+	path := cfg.DohPath
+	if path == "" {
+		path = defaultDohPath
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://"+address+path, bytes.NewReader(packed))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/dns-message")
+	req.Header.Set("Accept", "application/dns-message")
+
+	client := &http.Client{
+		Timeout:   timeout,
+		Transport: &http.Transport{TLSClientConfig: dnsTLSConfig(cfg)},
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("DoH server answered with status code %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, dns.MaxMsgSize))
+	if err != nil {
+		return nil, err
+	}
+
+	answer := new(dns.Msg)
+	if err := answer.Unpack(body); err != nil {
+		return nil, fmt.Errorf("invalid DoH answer: %w", err)
+	}
+	return answer, nil
+}
+
+func dnsTLSConfig(cfg *DNSConfig) *tls.Config {
+	serverName := cfg.TLSServerName
+	if serverName == "" {
+		serverName = cfg.ResolverServer
+	}
+	return &tls.Config{
+		ServerName:         serverName,
+		InsecureSkipVerify: cfg.IgnoreTlsErrors,
+	}
+}
+
+// dnsRecordValue is the textual value of a record used by assertions, e.g.
+// "10 mail.example.com" for MX records
+func dnsRecordValue(rr dns.RR) string {
+	switch record := rr.(type) {
+	case *dns.A:
+		return record.A.String()
+	case *dns.AAAA:
+		return record.AAAA.String()
+	case *dns.CNAME:
+		return record.Target
+	case *dns.MX:
+		return fmt.Sprintf("%d %s", record.Preference, record.Mx)
+	case *dns.NS:
+		return record.Ns
+	case *dns.PTR:
+		return record.Ptr
+	case *dns.TXT:
+		return strings.Join(record.Txt, "")
+	case *dns.SRV:
+		return fmt.Sprintf("%d %d %d %s", record.Priority, record.Weight, record.Port, record.Target)
+	case *dns.CAA:
+		return fmt.Sprintf("%d %s %q", record.Flag, record.Tag, record.Value)
+	case *dns.SOA:
+		return fmt.Sprintf("%s %s %d %d %d %d %d", record.Ns, record.Mbox, record.Serial, record.Refresh, record.Retry, record.Expire, record.Minttl)
+	default:
+		return strings.TrimPrefix(rr.String(), rr.Header().String())
+	}
+}
+
+// normalizeDNSValue makes record values and expected values comparable: IPs are
+// canonicalized and names are compared case-insensitively without the trailing dot
+func normalizeDNSValue(recordType string, value string) string {
+	switch recordType {
+	case "A", "AAAA":
+		if ip := net.ParseIP(strings.TrimSpace(value)); ip != nil {
+			return ip.String()
+		}
+		return strings.TrimSpace(value)
+	case "TXT", "CAA":
+		return value
+	}
+
+	fields := strings.Fields(value)
+	for i, field := range fields {
+		fields[i] = strings.TrimSuffix(strings.ToLower(field), ".")
+	}
+	return strings.Join(fields, " ")
+}
+
+func assertDNSRecords(recordType string, records []dns.RR, cfg *DNSConfig) error {
+	if cfg.MatchMode == "" || len(cfg.ExpectedValues) == 0 {
+		return nil
+	}
+
+	actual := make([]string, 0, len(records))
+	for _, rr := range records {
+		value := normalizeDNSValue(recordType, dnsRecordValue(rr))
+		if !slices.Contains(actual, value) {
+			actual = append(actual, value)
+		}
+	}
+
+	switch cfg.MatchMode {
+	case "exact":
+		expected := make([]string, 0, len(cfg.ExpectedValues))
+		for _, value := range cfg.ExpectedValues {
+			value = normalizeDNSValue(recordType, value)
+			if !slices.Contains(expected, value) {
+				expected = append(expected, value)
+			}
+		}
+		slices.Sort(expected)
+		sortedActual := slices.Clone(actual)
+		slices.Sort(sortedActual)
+		if !slices.Equal(expected, sortedActual) {
+			return fmt.Errorf("expected %s records [%s] but got [%s]", recordType, strings.Join(expected, ", "), strings.Join(sortedActual, ", "))
+		}
+	case "contains":
+		for _, value := range cfg.ExpectedValues {
+			if !slices.Contains(actual, normalizeDNSValue(recordType, value)) {
+				return fmt.Errorf("expected %s record %q is missing", recordType, value)
+			}
+		}
+	case "regex":
+		patterns := make([]*regexp.Regexp, 0, len(cfg.ExpectedValues))
+		for _, value := range cfg.ExpectedValues {
+			pattern, err := regexp.Compile(value)
+			if err != nil {
+				return fmt.Errorf("invalid expected value pattern %q: %w", value, err)
+			}
+			patterns = append(patterns, pattern)
+		}
+		for _, value := range actual {
+			matched := slices.ContainsFunc(patterns, func(pattern *regexp.Regexp) bool {
+				return pattern.MatchString(value)
+			})
+			if !matched {
+				return fmt.Errorf("%s record %q does not match the expected patterns", recordType, value)
+			}
+		}
+	}
+
+	return nil
+}
+
+func formatDNSRecords(recordType string, records []dns.RR) string {
+	values := make([]string, 0, len(records))
+	for _, rr := range records {
+		switch record := rr.(type) {
+		case *dns.CNAME:
+			return fmt.Sprintf("CNAME: %s", record.Target)
+		case *dns.SOA:
+			return fmt.Sprintf("SOA: Primary NS: %s, Admin: %s, Serial: %d, Refresh: %d, Retry: %d, Expire: %d, Min TTL: %d",
+				record.Ns, record.Mbox, record.Serial, record.Refresh, record.Retry, record.Expire, record.Minttl)
+		case *dns.MX:
+			values = append(values, fmt.Sprintf("%s (priority: %d)", record.Mx, record.Preference))
+		case *dns.SRV:
+			values = append(values, fmt.Sprintf("%s:%d (priority: %d, weight: %d)", record.Target, record.Port, record.Priority, record.Weight))
+		default:
+			values = append(values, dnsRecordValue(rr))
+		}
+	}
+
+	separator := ", "
+	if recordType == "TXT" || recordType == "CAA" {
+		separator = "; "
 	}
+	return fmt.Sprintf("%s records: %s", recordType, strings.Join(values, separator))
 }
@@ -0,0 +1,221 @@
+package executor
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+// dnssecMaxDepth bounds the chain of trust, deeper chains are treated as broken
+const dnssecMaxDepth = 16
+
+// rootTrustAnchors are the DS records of the root zone KSKs published by IANA
+// (KSK-2017 and KSK-2024)
+func rootTrustAnchors() map[string][]*dns.DS {
+	header := dns.RR_Header{Name: ".", Rrtype: dns.TypeDS, Class: dns.ClassINET}
+	return map[string][]*dns.DS{
+		".": {
+			{Hdr: header, KeyTag: 20326, Algorithm: dns.RSASHA256, DigestType: dns.SHA256,
+				Digest: "E06D44B80B8F1D39A95C0B0D7C65D08458E880409BBC683457104237C7F8EC8D"},
+			{Hdr: header, KeyTag: 38696, Algorithm: dns.RSASHA256, DigestType: dns.SHA256,
+				Digest: "683D2D0ACB8C9B712A1948B27F741219298D0A450D612C483AF444A4C0FB2B16"},
+		},
+	}
+}
+
+// dnssecValidator validates the signatures of an answer and the chain of trust of
+// the signing zones, from the zone up to a trust anchor
+type dnssecValidator struct {
+	query   func(name string, qtype uint16) (*dns.Msg, error)
+	anchors map[string][]*dns.DS
+	now     time.Time
+	keys    map[string][]*dns.DNSKEY
+}
+
+// validateAnswer checks that every RRset of the answer is signed by a trusted key
+func (v *dnssecValidator) validateAnswer(resp *dns.Msg) error {
+	rrsets, sigs := splitRRsets(resp.Answer)
+	if len(rrsets) == 0 {
+		return nil
+	}
+
+	for key, rrset := range rrsets {
+		if err := v.verifyRRset(rrset, sigs[key], 0); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// verifyRRset checks that one of the signatures of the RRset is valid and made by a
+// trusted key of its signer zone
+func (v *dnssecValidator) verifyRRset(rrset []dns.RR, sigs []*dns.RRSIG, depth int) error {
+	header := rrset[0].Header()
+	name := fmt.Sprintf("%s %s", header.Name, dns.TypeToString[header.Rrtype])
+	if len(sigs) == 0 {
+		return fmt.Errorf("%s is not signed", name)
+	}
+
+	var lastErr error
+	for _, sig := range sigs {
+		// A zone only signs names at or below its apex
+		if !dns.IsSubDomain(sig.SignerName, header.Name) {
+			lastErr = fmt.Errorf("%s is signed by unrelated zone %s", name, sig.SignerName)
+			continue
+		}
+		if !sig.ValidityPeriod(v.now) {
+			lastErr = fmt.Errorf("signature of %s is expired or not yet valid", name)
+			continue
+		}
+
+		keys, err := v.zoneKeys(sig.SignerName, depth+1)
+		if err != nil {
+			return err
+		}
+		for _, key := range keys {
+			if key.KeyTag() != sig.KeyTag || key.Algorithm != sig.Algorithm {
+				continue
+			}
+			if err := sig.Verify(key, rrset); err == nil {
+				return nil
+			}
+		}
+		lastErr = fmt.Errorf("no valid signature of %s by %s", name, sig.SignerName)
+	}
+	return lastErr
+}
+
+// zoneKeys returns the DNSKEY set of a zone once it is authenticated by a DS record
+// of its parent, or by a trust anchor
+func (v *dnssecValidator) zoneKeys(zone string, depth int) ([]*dns.DNSKEY, error) {
+	zone = dns.CanonicalName(zone)
+	if keys, ok := v.keys[zone]; ok {
+		return keys, nil
+	}
+	if depth > dnssecMaxDepth {
+		return nil, fmt.Errorf("chain of trust of %s is too long", zone)
+	}
+
+	resp, err := v.query(zone, dns.TypeDNSKEY)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query DNSKEY of %s: %w", zone, err)
+	}
+	rrsets, sigs := splitRRsets(resp.Answer)
+	keySetKey := rrsetKey(zone, dns.TypeDNSKEY)
+	keySet := rrsets[keySetKey]
+	if len(keySet) == 0 {
+		return nil, fmt.Errorf("zone %s has no DNSKEY", zone)
+	}
+
+	dsSet, err := v.delegationSigners(zone, depth)
+	if err != nil {
+		return nil, err
+	}
+
+	// The DNSKEY set must be signed by a key the parent vouches for
+	var trusted []*dns.DNSKEY
+	for _, rr := range keySet {
+		key := rr.(*dns.DNSKEY)
+		for _, ds := range dsSet {
+			if matchesDS(key, ds) {
+				trusted = append(trusted, key)
+				break
+			}
+		}
+	}
+	if len(trusted) == 0 {
+		return nil, fmt.Errorf("no DNSKEY of %s matches its DS records", zone)
+	}
+
+	verified := false
+	for _, sig := range sigs[keySetKey] {
+		if !sig.ValidityPeriod(v.now) {
+			continue
+		}
+		for _, key := range trusted {
+			if key.KeyTag() == sig.KeyTag && key.Algorithm == sig.Algorithm && sig.Verify(key, keySet) == nil {
+				verified = true
+				break
+			}
+		}
+		if verified {
+			break
+		}
+	}
+	if !verified {
+		return nil, fmt.Errorf("DNSKEY set of %s is not signed by a trusted key", zone)
+	}
+
+	keys := make([]*dns.DNSKEY, 0, len(keySet))
+	for _, rr := range keySet {
+		keys = append(keys, rr.(*dns.DNSKEY))
+	}
+	if v.keys == nil {
+		v.keys = make(map[string][]*dns.DNSKEY)
+	}
+	v.keys[zone] = keys
+	return keys, nil
+}
+
+// delegationSigners returns the trust anchors of the zone, or its DS records
+// validated with the keys of the parent zone
+func (v *dnssecValidator) delegationSigners(zone string, depth int) ([]*dns.DS, error) {
+	if anchors, ok := v.anchors[zone]; ok {
+		return anchors, nil
+	}
+	if zone == "." {
+		return nil, fmt.Errorf("no trust anchor for the root zone")
+	}
+
+	resp, err := v.query(zone, dns.TypeDS)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query DS of %s: %w", zone, err)
+	}
+	rrsets, sigs := splitRRsets(resp.Answer)
+	key := rrsetKey(zone, dns.TypeDS)
+	dsSet := rrsets[key]
+	if len(dsSet) == 0 {
+		return nil, fmt.Errorf("zone %s has no DS record, it is not signed", zone)
+	}
+
+	if err := v.verifyRRset(dsSet, sigs[key], depth); err != nil {
+		return nil, err
+	}
+
+	records := make([]*dns.DS, 0, len(dsSet))
+	for _, rr := range dsSet {
+		records = append(records, rr.(*dns.DS))
+	}
+	return records, nil
+}
+
+func matchesDS(key *dns.DNSKEY, ds *dns.DS) bool {
+	if key.KeyTag() != ds.KeyTag || key.Algorithm != ds.Algorithm {
+		return false
+	}
+	computed := key.ToDS(ds.DigestType)
+	return computed != nil && strings.EqualFold(computed.Digest, ds.Digest)
+}
+
+func rrsetKey(name string, rrtype uint16) string {
+	return dns.CanonicalName(name) + "/" + dns.TypeToString[rrtype]
+}
+
+// splitRRsets groups records by owner and type and their signatures by the type
+// they cover
+func splitRRsets(records []dns.RR) (map[string][]dns.RR, map[string][]*dns.RRSIG) {
+	rrsets := make(map[string][]dns.RR)
+	sigs := make(map[string][]*dns.RRSIG)
+	for _, rr := range records {
+		if sig, ok := rr.(*dns.RRSIG); ok {
+			key := rrsetKey(sig.Hdr.Name, sig.TypeCovered)
+			sigs[key] = append(sigs[key], sig)
+			continue
+		}
+		key := rrsetKey(rr.Header().Name, rr.Header().Rrtype)
+		rrsets[key] = append(rrsets[key], rr)
+	}
+	return rrsets, sigs
+}
@@ -2,18 +2,29 @@ package executor
 
 import (
 	"context"
-	"vigi/internal/modules/shared"
+	"crypto"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
 	"time"
+	"vigi/internal/modules/shared"
 
+	"github.com/miekg/dns"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 )
 
 func TestDNSExecutor_Unmarshal(t *testing.T) {
 	// Setup
 	logger := zap.NewNop().Sugar()
-	executor := NewDNSExecutor(logger)
+	executor := NewDNSExecutor(logger, NewMemoryStateStore())
 
 	tests := []struct {
 		name          string
@@ -69,7 +80,7 @@ func TestDNSExecutor_Unmarshal(t *testing.T) {
 func TestDNSExecutor_Validate(t *testing.T) {
 	// Setup
 	logger := zap.NewNop().Sugar()
-	executor := NewDNSExecutor(logger)
+	executor := NewDNSExecutor(logger, NewMemoryStateStore())
 
 	tests := []struct {
 		name          string
@@ -191,7 +202,7 @@ func TestDNSExecutor_Validate(t *testing.T) {
 func TestDNSExecutor_Execute(t *testing.T) {
 	// Setup
 	logger := zap.NewNop().Sugar()
-	executor := NewDNSExecutor(logger)
+	executor := NewDNSExecutor(logger, NewMemoryStateStore())
 
 	tests := []struct {
 		name           string
@@ -337,7 +348,7 @@ func TestDNSExecutor_Execute(t *testing.T) {
 func TestDNSExecutor_Execute_DifferentRecordTypes(t *testing.T) {
 	// Setup
 	logger := zap.NewNop().Sugar()
-	executor := NewDNSExecutor(logger)
+	executor := NewDNSExecutor(logger, NewMemoryStateStore())
 
 	// Test different record types with a known domain
 	recordTypes := []string{"A", "AAAA", "MX", "NS", "TXT"}
@@ -372,7 +383,7 @@ func TestDNSExecutor_Execute_DifferentRecordTypes(t *testing.T) {
 func TestDNSExecutor_Execute_WithProxy(t *testing.T) {
 	// Setup
 	logger := zap.NewNop().Sugar()
-	executor := NewDNSExecutor(logger)
+	executor := NewDNSExecutor(logger, NewMemoryStateStore())
 
 	monitor := &Monitor{
 		ID:       "monitor1",
@@ -409,9 +420,484 @@ func TestNewDNSExecutor(t *testing.T) {
 	logger := zap.NewNop().Sugar()
 
 	// Test executor creation
-	executor := NewDNSExecutor(logger)
+	executor := NewDNSExecutor(logger, NewMemoryStateStore())
 
 	// Verify executor is properly initialized
 	assert.NotNil(t, executor)
 	assert.NotNil(t, executor.logger)
 }
+
+func TestDNSExecutor_Validate_Assertions(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+	executor := NewDNSExecutor(logger, NewMemoryStateStore())
+
+	base := `"host": "example.com", "resolver_server": "8.8.8.8", "port": 53`
+
+	tests := []struct {
+		name          string
+		config        string
+		expectedError bool
+	}{
+		{
+			name:          "exact values",
+			config:        `{` + base + `, "resolve_type": "A", "match_mode": "exact", "expected_values": ["93.184.215.14"]}`,
+			expectedError: false,
+		},
+		{
+			name:          "values without match mode",
+			config:        `{` + base + `, "resolve_type": "A", "expected_values": ["93.184.215.14"]}`,
+			expectedError: true,
+		},
+		{
+			name:          "match mode without values",
+			config:        `{` + base + `, "resolve_type": "A", "match_mode": "contains"}`,
+			expectedError: true,
+		},
+		{
+			name:          "invalid match mode",
+			config:        `{` + base + `, "resolve_type": "A", "match_mode": "prefix", "expected_values": ["93."]}`,
+			expectedError: true,
+		},
+		{
+			name:          "invalid regex",
+			config:        `{` + base + `, "resolve_type": "TXT", "match_mode": "regex", "expected_values": ["v=spf1 (["]}`,
+			expectedError: true,
+		},
+		{
+			name:          "soa serial change on SOA",
+			config:        `{` + base + `, "resolve_type": "SOA", "detect_soa_serial_change": true}`,
+			expectedError: false,
+		},
+		{
+			name:          "soa serial change on A",
+			config:        `{` + base + `, "resolve_type": "A", "detect_soa_serial_change": true}`,
+			expectedError: true,
+		},
+		{
+			name:          "doh transport",
+			config:        `{` + base + `, "resolve_type": "A", "transport": "doh", "tls_server_name": "dns.google", "doh_path": "/dns-query"}`,
+			expectedError: false,
+		},
+		{
+			name:          "invalid transport",
+			config:        `{` + base + `, "resolve_type": "A", "transport": "quic"}`,
+			expectedError: true,
+		},
+		{
+			name:          "doh path without leading slash",
+			config:        `{` + base + `, "resolve_type": "A", "transport": "doh", "doh_path": "dns-query"}`,
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := executor.Validate(tt.config)
+			if tt.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestDNSExecutor_Execute_Assertions(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+	executor := NewDNSExecutor(logger, NewMemoryStateStore())
+
+	server := newDNSTestServer(t)
+	server.add(
+		"www.example.test. 300 IN A 192.0.2.1",
+		"www.example.test. 300 IN A 192.0.2.2",
+		"example.test. 300 IN MX 10 mail.example.test.",
+		"example.test. 300 IN MX 20 backup.example.test.",
+		"example.test. 300 IN TXT \"v=spf1 include:_spf.example.test -all\"",
+		"alias.example.test. 300 IN CNAME www.example.test.",
+	)
+	port := server.startUDP(t)
+
+	tests := []struct {
+		name           string
+		config         string
+		expectedStatus shared.MonitorStatus
+		expectMessage  string
+	}{
+		{
+			name:           "exact A records in any order",
+			config:         `"host": "www.example.test", "resolve_type": "A", "match_mode": "exact", "expected_values": ["192.0.2.2", "192.0.2.1"]`,
+			expectedStatus: shared.MonitorStatusUp,
+			expectMessage:  "A records: 192.0.2.1, 192.0.2.2",
+		},
+		{
+			name:           "hijacked A record",
+			config:         `"host": "www.example.test", "resolve_type": "A", "match_mode": "exact", "expected_values": ["192.0.2.1"]`,
+			expectedStatus: shared.MonitorStatusDown,
+			expectMessage:  "expected A records [192.0.2.1] but got [192.0.2.1, 192.0.2.2]",
+		},
+		{
+			name:           "contains MX without trailing dot",
+			config:         `"host": "example.test", "resolve_type": "MX", "match_mode": "contains", "expected_values": ["10 MAIL.example.test"]`,
+			expectedStatus: shared.MonitorStatusUp,
+			expectMessage:  "MX records:",
+		},
+		{
+			name:           "missing MX",
+			config:         `"host": "example.test", "resolve_type": "MX", "match_mode": "contains", "expected_values": ["10 mx.other.test"]`,
+			expectedStatus: shared.MonitorStatusDown,
+			expectMessage:  `expected MX record "10 mx.other.test" is missing`,
+		},
+		{
+			name:           "TXT matches regex",
+			config:         `"host": "example.test", "resolve_type": "TXT", "match_mode": "regex", "expected_values": ["^v=spf1 .* -all$"]`,
+			expectedStatus: shared.MonitorStatusUp,
+			expectMessage:  "TXT records: v=spf1",
+		},
+		{
+			name:           "A record outside the allowed range",
+			config:         `"host": "www.example.test", "resolve_type": "A", "match_mode": "regex", "expected_values": ["^192\\.0\\.2\\.1$"]`,
+			expectedStatus: shared.MonitorStatusDown,
+			expectMessage:  `A record "192.0.2.2" does not match`,
+		},
+		{
+			name:           "CNAME target",
+			config:         `"host": "alias.example.test", "resolve_type": "CNAME", "match_mode": "exact", "expected_values": ["www.example.test."]`,
+			expectedStatus: shared.MonitorStatusUp,
+			expectMessage:  "CNAME: www.example.test.",
+		},
+		{
+			name:           "non-existent name",
+			config:         `"host": "missing.example.test", "resolve_type": "A"`,
+			expectedStatus: shared.MonitorStatusDown,
+			expectMessage:  "NXDOMAIN",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			monitor := &Monitor{
+				ID:      "monitor-assertions",
+				Name:    "Test DNS Monitor",
+				Timeout: 2,
+				Config:  fmt.Sprintf(`{"resolver_server": "127.0.0.1", "port": %d, %s}`, port, tt.config),
+			}
+			require.NoError(t, executor.Validate(monitor.Config))
+
+			result := executor.Execute(context.Background(), monitor, nil)
+			assert.Equal(t, tt.expectedStatus, result.Status, result.Message)
+			assert.Contains(t, result.Message, tt.expectMessage)
+		})
+	}
+}
+
+func TestDNSExecutor_Execute_Transports(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+	executor := NewDNSExecutor(logger, NewMemoryStateStore())
+
+	server := newDNSTestServer(t)
+	server.add("www.example.test. 300 IN A 192.0.2.1")
+	port := server.startUDP(t)
+	server.startTCP(t, port)
+	dotPort := server.startDoT(t)
+	dohPort := server.startDoH(t)
+
+	tests := []struct {
+		name           string
+		port           int
+		config         string
+		expectedStatus shared.MonitorStatus
+	}{
+		{name: "udp", port: port, config: `"transport": "udp"`, expectedStatus: shared.MonitorStatusUp},
+		{name: "tcp", port: port, config: `"transport": "tcp"`, expectedStatus: shared.MonitorStatusUp},
+		{name: "dot", port: dotPort, config: `"transport": "dot", "ignore_tls_errors": true`, expectedStatus: shared.MonitorStatusUp},
+		{name: "doh", port: dohPort, config: `"transport": "doh", "ignore_tls_errors": true`, expectedStatus: shared.MonitorStatusUp},
+		{name: "dot with untrusted certificate", port: dotPort, config: `"transport": "dot", "tls_server_name": "dns.example.test"`, expectedStatus: shared.MonitorStatusDown},
+		{name: "doh with wrong path", port: dohPort, config: `"transport": "doh", "ignore_tls_errors": true, "doh_path": "/resolve"`, expectedStatus: shared.MonitorStatusDown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			monitor := &Monitor{
+				ID:      "monitor-transports",
+				Name:    "Test DNS Monitor",
+				Timeout: 2,
+				Config: fmt.Sprintf(`{"host": "www.example.test", "resolver_server": "127.0.0.1", "port": %d, "resolve_type": "A", %s}`,
+					tt.port, tt.config),
+			}
+			require.NoError(t, executor.Validate(monitor.Config))
+
+			result := executor.Execute(context.Background(), monitor, nil)
+			assert.Equal(t, tt.expectedStatus, result.Status, result.Message)
+			if tt.expectedStatus == shared.MonitorStatusUp {
+				assert.Contains(t, result.Message, "A records: 192.0.2.1")
+			}
+		})
+	}
+}
+
+func TestDNSExecutor_Execute_SoaSerialChange(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+
+	// Consecutive checks land on different workers sharing the state store
+	state := NewMemoryStateStore()
+	workers := []*DNSExecutor{NewDNSExecutor(logger, state), NewDNSExecutor(logger, state)}
+
+	server := newDNSTestServer(t)
+	port := server.startUDP(t)
+
+	monitor := &Monitor{
+		ID:      "monitor-soa",
+		Name:    "Test DNS Monitor",
+		Timeout: 2,
+		Config: fmt.Sprintf(`{"host": "example.test", "resolver_server": "127.0.0.1", "port": %d, "resolve_type": "SOA", "detect_soa_serial_change": true}`,
+			port),
+	}
+
+	polls := []struct {
+		serial         int
+		expectedStatus shared.MonitorStatus
+		expectMessage  string
+	}{
+		{serial: 2026010101, expectedStatus: shared.MonitorStatusUp, expectMessage: "Serial: 2026010101"},
+		{serial: 2026010101, expectedStatus: shared.MonitorStatusUp, expectMessage: "Serial: 2026010101"},
+		{serial: 2026010102, expectedStatus: shared.MonitorStatusDown, expectMessage: "SOA serial changed from 2026010101 to 2026010102"},
+		{serial: 2026010102, expectedStatus: shared.MonitorStatusUp, expectMessage: "Serial: 2026010102"},
+	}
+
+	for i, poll := range polls {
+		server.replace(dns.TypeSOA, fmt.Sprintf("example.test. 300 IN SOA ns1.example.test. hostmaster.example.test. %d 7200 3600 1209600 300", poll.serial))
+
+		result := workers[i%len(workers)].Execute(context.Background(), monitor, nil)
+		assert.Equal(t, poll.expectedStatus, result.Status, result.Message)
+		assert.Contains(t, result.Message, poll.expectMessage)
+	}
+}
+
+func TestDNSExecutor_Execute_DNSSEC(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+	executor := NewDNSExecutor(logger, NewMemoryStateStore())
+
+	server := newDNSTestServer(t)
+
+	// example.test. is the trust anchor, sub.example.test. is delegated from it
+	parentKey, parentSigner := newDNSTestKey(t, "example.test.")
+	childKey, childSigner := newDNSTestKey(t, "sub.example.test.")
+	executor.trustAnchors = map[string][]*dns.DS{"example.test.": {parentKey.ToDS(dns.SHA256)}}
+
+	server.addSigned(parentKey, parentSigner, parentKey.String())
+	server.addSigned(parentKey, parentSigner, "www.example.test. 300 IN A 192.0.2.1")
+	server.addSigned(parentKey, parentSigner, childKey.ToDS(dns.SHA256).String())
+	server.addSigned(childKey, childSigner, childKey.String())
+	server.addSigned(childKey, childSigner, "www.sub.example.test. 300 IN A 192.0.2.10")
+	server.add("unsigned.example.test. 300 IN A 192.0.2.20")
+
+	// Answer with a record that does not match its signature
+	server.addSigned(parentKey, parentSigner, "forged.example.test. 300 IN A 192.0.2.30")
+	server.replace(dns.TypeA, "forged.example.test. 300 IN A 203.0.113.66")
+
+	port := server.startUDP(t)
+	server.startTCP(t, port)
+
+	tests := []struct {
+		name           string
+		host           string
+		expectedStatus shared.MonitorStatus
+		expectMessage  string
+	}{
+		{name: "signed by the anchored zone", host: "www.example.test", expectedStatus: shared.MonitorStatusUp, expectMessage: "DNSSEC validated"},
+		{name: "signed by a delegated zone", host: "www.sub.example.test", expectedStatus: shared.MonitorStatusUp, expectMessage: "DNSSEC validated"},
+		{name: "unsigned record", host: "unsigned.example.test", expectedStatus: shared.MonitorStatusDown, expectMessage: "is not signed"},
+		{name: "forged record", host: "forged.example.test", expectedStatus: shared.MonitorStatusDown, expectMessage: "no valid signature"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			monitor := &Monitor{
+				ID:      "monitor-dnssec",
+				Name:    "Test DNS Monitor",
+				Timeout: 2,
+				Config: fmt.Sprintf(`{"host": %q, "resolver_server": "127.0.0.1", "port": %d, "resolve_type": "A", "dnssec": true}`,
+					tt.host, port),
+			}
+
+			result := executor.Execute(context.Background(), monitor, nil)
+			assert.Equal(t, tt.expectedStatus, result.Status, result.Message)
+			assert.Contains(t, result.Message, tt.expectMessage)
+		})
+	}
+}
+
+// dnsTestServer is an in-process DNS server answering from a fixed set of records
+type dnsTestServer struct {
+	mu      sync.Mutex
+	records []dns.RR
+}
+
+func newDNSTestServer(t *testing.T) *dnsTestServer {
+	t.Helper()
+	return &dnsTestServer{}
+}
+
+func (s *dnsTestServer) add(records ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, record := range records {
+		rr, err := dns.NewRR(record)
+		if err != nil {
+			panic(err)
+		}
+		s.records = append(s.records, rr)
+	}
+}
+
+// replace swaps the records of the given type and owner, their signatures are kept
+func (s *dnsTestServer) replace(rrtype uint16, record string) {
+	rr, err := dns.NewRR(record)
+	if err != nil {
+		panic(err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	kept := s.records[:0]
+	for _, existing := range s.records {
+		if existing.Header().Rrtype != rrtype || !strings.EqualFold(existing.Header().Name, rr.Header().Name) {
+			kept = append(kept, existing)
+		}
+	}
+	s.records = append(kept, rr)
+}
+
+// addSigned adds a record and its RRSIG made with the zone key
+func (s *dnsTestServer) addSigned(key *dns.DNSKEY, signer crypto.Signer, record string) {
+	rr, err := dns.NewRR(record)
+	if err != nil {
+		panic(err)
+	}
+
+	sig := &dns.RRSIG{
+		Hdr:        dns.RR_Header{Name: rr.Header().Name, Rrtype: dns.TypeRRSIG, Class: dns.ClassINET, Ttl: rr.Header().Ttl},
+		Algorithm:  key.Algorithm,
+		Expiration: uint32(time.Now().Add(time.Hour).Unix()),
+		Inception:  uint32(time.Now().Add(-time.Hour).Unix()),
+		KeyTag:     key.KeyTag(),
+		SignerName: key.Hdr.Name,
+	}
+	if err := sig.Sign(signer, []dns.RR{rr}); err != nil {
+		panic(err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records = append(s.records, rr, sig)
+}
+
+func (s *dnsTestServer) answer(req *dns.Msg) *dns.Msg {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	resp := new(dns.Msg)
+	resp.SetReply(req)
+	question := req.Question[0]
+
+	nameExists := false
+	for _, rr := range s.records {
+		if !strings.EqualFold(rr.Header().Name, question.Name) {
+			continue
+		}
+		nameExists = true
+
+		rrtype := rr.Header().Rrtype
+		if sig, ok := rr.(*dns.RRSIG); ok {
+			rrtype = sig.TypeCovered
+		}
+		if rrtype == question.Qtype || rrtype == dns.TypeCNAME {
+			resp.Answer = append(resp.Answer, rr)
+		}
+	}
+	if !nameExists && question.Qtype != dns.TypeSOA {
+		resp.Rcode = dns.RcodeNameError
+	}
+	return resp
+}
+
+func (s *dnsTestServer) ServeDNS(w dns.ResponseWriter, req *dns.Msg) {
+	_ = w.WriteMsg(s.answer(req))
+}
+
+func (s *dnsTestServer) serve(t *testing.T, server *dns.Server) {
+	t.Helper()
+	started := make(chan struct{})
+	server.Handler = s
+	server.NotifyStartedFunc = func() { close(started) }
+	go func() { _ = server.ActivateAndServe() }()
+	<-started
+	t.Cleanup(func() { _ = server.Shutdown() })
+}
+
+func (s *dnsTestServer) startUDP(t *testing.T) int {
+	t.Helper()
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s.serve(t, &dns.Server{PacketConn: conn})
+	return conn.LocalAddr().(*net.UDPAddr).Port
+}
+
+func (s *dnsTestServer) startTCP(t *testing.T, port int) {
+	t.Helper()
+	listener, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+	require.NoError(t, err)
+	s.serve(t, &dns.Server{Listener: listener})
+}
+
+func (s *dnsTestServer) startDoT(t *testing.T) int {
+	t.Helper()
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", testTLSConfig(t))
+	require.NoError(t, err)
+	s.serve(t, &dns.Server{Listener: listener, Net: "tcp-tls"})
+	return listener.Addr().(*net.TCPAddr).Port
+}
+
+func (s *dnsTestServer) startDoH(t *testing.T) int {
+	t.Helper()
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/dns-query" || r.Header.Get("Content-Type") != "application/dns-message" {
+			http.NotFound(w, r)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		req := new(dns.Msg)
+		if err := req.Unpack(body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		packed, _ := s.answer(req).Pack()
+		w.Header().Set("Content-Type", "application/dns-message")
+		_, _ = w.Write(packed)
+	}))
+	server.StartTLS()
+	t.Cleanup(server.Close)
+	return server.Listener.Addr().(*net.TCPAddr).Port
+}
+
+// testTLSConfig borrows the self-signed certificate of httptest
+func testTLSConfig(t *testing.T) *tls.Config {
+	t.Helper()
+	server := httptest.NewUnstartedServer(http.NotFoundHandler())
+	server.StartTLS()
+	defer server.Close()
+	return &tls.Config{Certificates: server.TLS.Certificates}
+}
+
+func newDNSTestKey(t *testing.T, zone string) (*dns.DNSKEY, crypto.Signer) {
+	t.Helper()
+	key := &dns.DNSKEY{
+		Hdr:       dns.RR_Header{Name: zone, Rrtype: dns.TypeDNSKEY, Class: dns.ClassINET, Ttl: 300},
+		Flags:     dns.ZONE | dns.SEP,
+		Protocol:  3,
+		Algorithm: dns.ECDSAP256SHA256,
+	}
+	private, err := key.Generate(256)
+	require.NoError(t, err)
+	return key, private.(crypto.Signer)
+}
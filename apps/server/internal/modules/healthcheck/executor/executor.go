@@ -52,19 +52,21 @@ type ExecutorRegistry struct {
 
 func NewExecutorRegistry(
 	logger *zap.SugaredLogger,
+	state StateStore,
 ) *ExecutorRegistry {
 	registry := make(map[string]Executor)
 
 	registry["http"] = NewHTTPExecutor(logger)
 	registry["http-keyword"] = NewHTTPExecutor(logger)
 	registry["http-json-query"] = NewHTTPExecutor(logger)
+	registry["http-transaction"] = NewHTTPTransactionExecutor(logger)
 	registry["push"] = NewPushExecutor(logger)
 	registry["tcp"] = NewTCPExecutor(logger)
 	registry["ping"] = NewPingExecutor(logger)
-	registry["dns"] = NewDNSExecutor(logger)
+	registry["dns"] = NewDNSExecutor(logger, state)
 	registry["docker"] = NewDockerExecutor(logger)
 	registry["grpc-keyword"] = NewGRPCExecutor(logger)
-	registry["snmp"] = NewSnmpExecutor(logger)
+	registry["snmp"] = NewSnmpExecutor(logger, state)
 	registry["mongodb"] = NewMongoDBExecutor(logger)
 	registry["mysql"] = NewMySQLExecutor(logger)
 	registry["postgres"] = NewPostgresExecutor(logger)
@@ -56,10 +56,28 @@ func (m *ExecutorMockHeartbeatService) DeleteByMonitorID(ctx context.Context, mo
 	return args.Error(0)
 }
 
+func (m *ExecutorMockHeartbeatService) FindOldestByMonitorID(ctx context.Context, monitorID string) (*heartbeat.Model, error) {
+	args := m.Called(ctx, monitorID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*heartbeat.Model), args.Error(1)
+}
+
+func (m *ExecutorMockHeartbeatService) FindByMonitorIDAndTimeRange(ctx context.Context, monitorID string, since, until time.Time) ([]*heartbeat.Model, error) {
+	args := m.Called(ctx, monitorID, since, until)
+	return args.Get(0).([]*heartbeat.Model), args.Error(1)
+}
+
+func (m *ExecutorMockHeartbeatService) DeleteByMonitorIDOlderThan(ctx context.Context, monitorID string, cutoff time.Time) (int64, error) {
+	args := m.Called(ctx, monitorID, cutoff)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 func TestExecutorRegistry_GetExecutor(t *testing.T) {
 	// Setup
 	logger := zap.NewNop().Sugar()
-	registry := NewExecutorRegistry(logger)
+	registry := NewExecutorRegistry(logger, NewMemoryStateStore())
 
 	tests := []struct {
 		name          string
@@ -119,7 +137,7 @@ func TestExecutorRegistry_GetExecutor(t *testing.T) {
 func TestExecutorRegistry_ValidateConfig(t *testing.T) {
 	// Setup
 	logger := zap.NewNop().Sugar()
-	registry := NewExecutorRegistry(logger)
+	registry := NewExecutorRegistry(logger, NewMemoryStateStore())
 
 	tests := []struct {
 		name          string
@@ -264,7 +282,7 @@ func TestExecutorRegistry_ValidateConfig(t *testing.T) {
 func TestExecutorRegistry_ValidateConfig_Error_Logging(t *testing.T) {
 	// Setup with a logger that can be captured
 	logger := zap.NewNop().Sugar()
-	registry := NewExecutorRegistry(logger)
+	registry := NewExecutorRegistry(logger, NewMemoryStateStore())
 
 	// Test that errors are properly logged
 	err := registry.ValidateConfig("http", `{"invalid": "config"}`)
@@ -275,7 +293,7 @@ func TestExecutorRegistry_ValidateConfig_Error_Logging(t *testing.T) {
 func TestExecutorRegistry_Execute(t *testing.T) {
 	// Setup
 	logger := zap.NewNop().Sugar()
-	registry := NewExecutorRegistry(logger)
+	registry := NewExecutorRegistry(logger, NewMemoryStateStore())
 
 	tests := []struct {
 		name            string
@@ -412,7 +430,7 @@ func TestNewExecutorRegistry(t *testing.T) {
 	logger := zap.NewNop().Sugar()
 
 	// Test registry creation
-	registry := NewExecutorRegistry(logger)
+	registry := NewExecutorRegistry(logger, NewMemoryStateStore())
 
 	// Verify registry is properly initialized
 	assert.NotNil(t, registry)
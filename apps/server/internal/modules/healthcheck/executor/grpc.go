@@ -31,6 +31,9 @@ type GRPCConfig struct {
 	GrpcBody        string `json:"grpcBody"`
 	Keyword         string `json:"keyword"`
 	InvertKeyword   bool   `json:"invertKeyword"`
+
+	// Soft limits that report a passing check as degraded
+	DegradedThresholds
 }
 
 type GRPCExecutor struct {
@@ -112,12 +115,12 @@ func (g *GRPCExecutor) Execute(ctx context.Context, m *Monitor, proxyModel *Prox
 
 		if keywordFound == expectedFound {
 			g.logger.Infof("gRPC call successful with keyword check: %s", m.Name)
-			return &Result{
+			return cfg.Apply(&Result{
 				Status:    shared.MonitorStatusUp,
 				Message:   fmt.Sprintf("%s, keyword [%s] %s found", responseData, cfg.Keyword, map[bool]string{true: "is", false: "not"}[keywordFound]),
 				StartTime: startTime,
 				EndTime:   endTime,
-			}
+			})
 		} else {
 			g.logger.Debugf("gRPC response [%s], but keyword [%s] is %s in [%s]", response, cfg.Keyword, map[bool]string{true: "present", false: "not"}[keywordFound], response)
 			return &Result{
@@ -131,12 +134,12 @@ func (g *GRPCExecutor) Execute(ctx context.Context, m *Monitor, proxyModel *Prox
 
 	// No keyword check, just return success
 	g.logger.Infof("gRPC call successful: %s", m.Name)
-	return &Result{
+	return cfg.Apply(&Result{
 		Status:    shared.MonitorStatusUp,
 		Message:   fmt.Sprintf("gRPC call successful: %s", responseData),
 		StartTime: startTime,
 		EndTime:   endTime,
-	}
+	})
 }
 
 // executeGRPCCall performs a real gRPC call with dynamic protobuf handling
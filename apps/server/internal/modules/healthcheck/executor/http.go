@@ -147,6 +147,9 @@ type HTTPConfig struct {
 	TlsCert           string `json:"tlsCert,omitempty"`
 	TlsKey            string `json:"tlsKey,omitempty"`
 	TlsCa             string `json:"tlsCa,omitempty"`
+
+	// Soft limits that report a passing check as degraded
+	DegradedThresholds
 }
 
 type HTTPExecutor struct {
@@ -709,11 +712,11 @@ func (h *HTTPExecutor) Execute(ctx context.Context, m *Monitor, proxyModel *Prox
 		}
 	}
 
-	return &Result{
+	return cfg.Apply(&Result{
 		Status:    shared.MonitorStatusUp,
 		Message:   fmt.Sprintf("%d - %s", resp.StatusCode, resp.Status),
 		StartTime: startTime,
 		EndTime:   endTime,
 		TLSInfo:   tlsInfo,
-	}
+	})
 }
@@ -0,0 +1,344 @@
+package executor
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+	"vigi/internal/modules/certificate"
+	"vigi/internal/modules/shared"
+
+	"github.com/tidwall/gjson"
+	"go.uber.org/zap"
+)
+
+var (
+	// transactionVariablePattern matches {{name}} placeholders in the URL, headers and body of a step
+	transactionVariablePattern = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+	transactionVariableName    = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+// HTTPTransactionConfig runs an ordered list of HTTP requests sharing cookies and
+// variables, the monitor is down as soon as one step fails
+type HTTPTransactionConfig struct {
+	Steps           []HTTPTransactionStep `json:"steps" validate:"required,min=1,max=20,dive"`
+	MaxRedirects    int                   `json:"max_redirects" validate:"omitempty,min=0"`
+	IgnoreTlsErrors bool                  `json:"ignore_tls_errors"`
+	CheckCertExpiry bool                  `json:"check_cert_expiry"`
+}
+
+type HTTPTransactionStep struct {
+	Name                string   `json:"name" validate:"required,max=100" example:"login"`
+	Url                 string   `json:"url" validate:"required" example:"https://api.example.com/items/{{item_id}}"`
+	Method              string   `json:"method" validate:"required,oneof=GET POST PUT DELETE PATCH HEAD OPTIONS"`
+	Headers             string   `json:"headers,omitempty" validate:"omitempty,json"`
+	Encoding            string   `json:"encoding,omitempty" validate:"omitempty,oneof=json form xml text"`
+	Body                string   `json:"body,omitempty"`
+	AcceptedStatusCodes []string `json:"accepted_statuscodes" validate:"required,dive,oneof=2XX 3XX 4XX 5XX"`
+
+	// Assertions, evaluated like the http-keyword and http-json-query monitors
+	Keyword       string `json:"keyword,omitempty"`
+	InvertKeyword bool   `json:"invert_keyword,omitempty"`
+	JsonQuery     string `json:"json_query,omitempty"`
+	JsonCondition string `json:"json_condition,omitempty" validate:"omitempty,oneof='==' '!=' '>' '<' '>=' '<='"`
+	ExpectedValue string `json:"expected_value,omitempty"`
+
+	Extract []HTTPTransactionExtract `json:"extract,omitempty" validate:"omitempty,max=20,dive"`
+}
+
+// HTTPTransactionExtract stores a value of the response in a variable available to
+// the following steps. Expression is a gjson path for json, a pattern for regex (the
+// first capture group when there is one) and a header name for header.
+type HTTPTransactionExtract struct {
+	Variable   string `json:"variable" validate:"required,max=64" example:"token"`
+	Source     string `json:"source" validate:"required,oneof=json regex header" example:"json"`
+	Expression string `json:"expression" validate:"required" example:"data.access_token"`
+}
+
+type HTTPTransactionExecutor struct {
+	logger *zap.SugaredLogger
+}
+
+func NewHTTPTransactionExecutor(logger *zap.SugaredLogger) *HTTPTransactionExecutor {
+	return &HTTPTransactionExecutor{
+		logger: logger,
+	}
+}
+
+func (s *HTTPTransactionExecutor) Unmarshal(configJSON string) (any, error) {
+	return GenericUnmarshal[HTTPTransactionConfig](configJSON)
+}
+
+func (s *HTTPTransactionExecutor) Validate(configJSON string) error {
+	cfgAny, err := s.Unmarshal(configJSON)
+	if err != nil {
+		return err
+	}
+	cfg := cfgAny.(*HTTPTransactionConfig)
+	if err := GenericValidator(cfg); err != nil {
+		return err
+	}
+
+	// Variables can only be used once an earlier step extracted them
+	defined := make(map[string]bool)
+	for i, step := range cfg.Steps {
+		used := transactionVariables(step.Url, step.Headers, step.Body)
+		for _, name := range used {
+			if !defined[name] {
+				return fmt.Errorf("step %d uses variable %s before it is extracted", i+1, name)
+			}
+		}
+
+		rawURL := transactionVariablePattern.ReplaceAllString(step.Url, "x")
+		parsed, err := url.ParseRequestURI(rawURL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return fmt.Errorf("step %d: url must be an absolute http or https URL", i+1)
+		}
+
+		for _, extract := range step.Extract {
+			if !transactionVariableName.MatchString(extract.Variable) {
+				return fmt.Errorf("step %d: invalid variable name %q", i+1, extract.Variable)
+			}
+			if extract.Source == "regex" {
+				if _, err := regexp.Compile(extract.Expression); err != nil {
+					return fmt.Errorf("step %d: invalid pattern for %s: %w", i+1, extract.Variable, err)
+				}
+			}
+			defined[extract.Variable] = true
+		}
+	}
+	return nil
+}
+
+// transactionStepResult is the outcome of one step, its latency is reported in the
+// heartbeat message
+type transactionStepResult struct {
+	name    string
+	latency time.Duration
+}
+
+func (h *HTTPTransactionExecutor) Execute(ctx context.Context, m *Monitor, proxyModel *Proxy) *Result {
+	cfgAny, err := h.Unmarshal(m.Config)
+	if err != nil {
+		return DownResult(err, time.Now().UTC(), time.Now().UTC())
+	}
+	cfg := cfgAny.(*HTTPTransactionConfig)
+
+	h.logger.Debugf("execute http transaction: %s, %d steps", m.Name, len(cfg.Steps))
+
+	baseTransport := &http.Transport{}
+	if cfg.IgnoreTlsErrors {
+		baseTransport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	}
+	tlsInterceptor := NewTLSInterceptor(buildProxyTransport(baseTransport, proxyModel))
+
+	// Cookies set by a step, such as a session after login, are sent by the next ones
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return DownResult(fmt.Errorf("failed to create cookie jar: %w", err), time.Now().UTC(), time.Now().UTC())
+	}
+
+	maxRedirects := cfg.MaxRedirects
+	client := &http.Client{
+		Timeout:   time.Duration(m.Timeout) * time.Second,
+		Transport: tlsInterceptor,
+		Jar:       jar,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if maxRedirects == 0 {
+				return fmt.Errorf("redirects disabled: max_redirects set to 0")
+			}
+			if len(via) > maxRedirects {
+				return fmt.Errorf("too many redirects: followed %d redirects, maximum allowed is %d", len(via), maxRedirects)
+			}
+			return nil
+		},
+	}
+
+	variables := make(map[string]string)
+	results := make([]transactionStepResult, 0, len(cfg.Steps))
+	var tlsInfo *certificate.TLSInfo
+
+	startTime := time.Now().UTC()
+	for i, step := range cfg.Steps {
+		stepStart := time.Now()
+		err := h.runStep(ctx, client, step, variables)
+		results = append(results, transactionStepResult{name: step.Name, latency: time.Since(stepStart)})
+
+		// The certificate of the first HTTPS step is the one tracked for expiry
+		if tlsInfo == nil && strings.HasPrefix(strings.ToLower(step.Url), "https://") {
+			tlsInfo = tlsInterceptor.GetTLSInfo()
+		}
+
+		if err != nil {
+			h.logger.Infof("HTTP transaction step failed: %s, step %d: %s", m.Name, i+1, err.Error())
+			return &Result{
+				Status:    shared.MonitorStatusDown,
+				Message:   fmt.Sprintf("Step %d (%s) failed: %v [%s]", i+1, step.Name, err, formatTransactionLatencies(results)),
+				StartTime: startTime,
+				EndTime:   time.Now().UTC(),
+				TLSInfo:   tlsInfo,
+			}
+		}
+	}
+	endTime := time.Now().UTC()
+
+	return &Result{
+		Status:    shared.MonitorStatusUp,
+		Message:   fmt.Sprintf("%d steps passed in %dms [%s]", len(results), endTime.Sub(startTime).Milliseconds(), formatTransactionLatencies(results)),
+		StartTime: startTime,
+		EndTime:   endTime,
+		TLSInfo:   tlsInfo,
+	}
+}
+
+// runStep sends the request of a step, checks its assertions and stores the
+// extracted variables
+func (h *HTTPTransactionExecutor) runStep(ctx context.Context, client *http.Client, step HTTPTransactionStep, variables map[string]string) error {
+	var bodyReader io.Reader
+	if step.Body != "" {
+		bodyReader = strings.NewReader(expandTransactionVariables(step.Body, variables))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, step.Method, expandTransactionVariables(step.Url, variables), bodyReader)
+	if err != nil {
+		return err
+	}
+	setDefaultHeaders(req)
+
+	switch step.Encoding {
+	case "json":
+		req.Header.Set("Content-Type", "application/json")
+	case "form":
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	case "xml":
+		req.Header.Set("Content-Type", "application/xml")
+	case "text":
+		req.Header.Set("Content-Type", "text/plain")
+	}
+
+	if step.Headers != "" {
+		headersMap := make(map[string]string)
+		if err := json.Unmarshal([]byte(step.Headers), &headersMap); err != nil {
+			return fmt.Errorf("invalid headers json: %w", err)
+		}
+		for k, v := range headersMap {
+			req.Header.Set(k, expandTransactionVariables(v, variables))
+		}
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if !isStatusAccepted(resp.StatusCode, step.AcceptedStatusCodes) {
+		return fmt.Errorf("HTTP status %d", resp.StatusCode)
+	}
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %v", err)
+	}
+	responseBody := string(bodyBytes)
+
+	if step.Keyword != "" && !checkKeyword(responseBody, step.Keyword, step.InvertKeyword) {
+		if step.InvertKeyword {
+			return fmt.Errorf("keyword '%s' found in response (expected absent)", step.Keyword)
+		}
+		return fmt.Errorf("keyword '%s' not found in response", step.Keyword)
+	}
+
+	if step.JsonQuery != "" || step.ExpectedValue != "" {
+		isValid, err := checkJsonQuery(responseBody, step.JsonQuery, step.JsonCondition, step.ExpectedValue)
+		if err != nil {
+			return fmt.Errorf("JSON query validation error: %v", err)
+		}
+		if !isValid {
+			condition := step.JsonCondition
+			if condition == "" {
+				condition = "=="
+			}
+			return fmt.Errorf("JSON query '%s' with condition '%s' and expected value '%s' failed",
+				step.JsonQuery, condition, step.ExpectedValue)
+		}
+	}
+
+	for _, extract := range step.Extract {
+		value, err := extractTransactionValue(extract, resp.Header, responseBody)
+		if err != nil {
+			return fmt.Errorf("failed to extract %s: %v", extract.Variable, err)
+		}
+		variables[extract.Variable] = value
+	}
+	return nil
+}
+
+func extractTransactionValue(extract HTTPTransactionExtract, header http.Header, body string) (string, error) {
+	switch extract.Source {
+	case "json":
+		result := gjson.Get(body, extract.Expression)
+		if !result.Exists() {
+			return "", fmt.Errorf("JSON query path not found: %s", extract.Expression)
+		}
+		return result.String(), nil
+	case "regex":
+		pattern, err := regexp.Compile(extract.Expression)
+		if err != nil {
+			return "", err
+		}
+		match := pattern.FindStringSubmatch(body)
+		if match == nil {
+			return "", fmt.Errorf("pattern %s does not match the response", extract.Expression)
+		}
+		if len(match) > 1 {
+			return match[1], nil
+		}
+		return match[0], nil
+	case "header":
+		value := header.Get(extract.Expression)
+		if value == "" {
+			return "", fmt.Errorf("header %s not found", extract.Expression)
+		}
+		return value, nil
+	default:
+		return "", fmt.Errorf("unsupported source: %s", extract.Source)
+	}
+}
+
+// expandTransactionVariables replaces {{name}} placeholders, unknown names are left as is
+func expandTransactionVariables(value string, variables map[string]string) string {
+	return transactionVariablePattern.ReplaceAllStringFunc(value, func(placeholder string) string {
+		name := transactionVariablePattern.FindStringSubmatch(placeholder)[1]
+		if v, ok := variables[name]; ok {
+			return v
+		}
+		return placeholder
+	})
+}
+
+// transactionVariables lists the variable names used in the given templates
+func transactionVariables(templates ...string) []string {
+	var names []string
+	for _, template := range templates {
+		for _, match := range transactionVariablePattern.FindAllStringSubmatch(template, -1) {
+			names = append(names, match[1])
+		}
+	}
+	return names
+}
+
+func formatTransactionLatencies(results []transactionStepResult) string {
+	parts := make([]string, 0, len(results))
+	for _, r := range results {
+		parts = append(parts, fmt.Sprintf("%s: %dms", r.name, r.latency.Milliseconds()))
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,261 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"vigi/internal/modules/shared"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestHTTPTransactionExecutor_Validate(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+	executor := NewHTTPTransactionExecutor(logger)
+
+	tests := []struct {
+		name          string
+		config        string
+		expectedError bool
+	}{
+		{
+			name: "login then fetch",
+			config: `{"steps": [
+				{"name": "login", "url": "https://api.example.com/login", "method": "POST", "accepted_statuscodes": ["2XX"],
+				 "extract": [{"variable": "token", "source": "json", "expression": "access_token"}]},
+				{"name": "fetch", "url": "https://api.example.com/items", "method": "GET", "accepted_statuscodes": ["2XX"],
+				 "headers": "{\"Authorization\": \"Bearer {{token}}\"}"}
+			]}`,
+			expectedError: false,
+		},
+		{
+			name:          "no steps",
+			config:        `{"steps": []}`,
+			expectedError: true,
+		},
+		{
+			name:          "missing step name",
+			config:        `{"steps": [{"url": "https://api.example.com", "method": "GET", "accepted_statuscodes": ["2XX"]}]}`,
+			expectedError: true,
+		},
+		{
+			name:          "relative url",
+			config:        `{"steps": [{"name": "a", "url": "/items", "method": "GET", "accepted_statuscodes": ["2XX"]}]}`,
+			expectedError: true,
+		},
+		{
+			name: "variable used before extraction",
+			config: `{"steps": [
+				{"name": "fetch", "url": "https://api.example.com/items/{{id}}", "method": "GET", "accepted_statuscodes": ["2XX"]}
+			]}`,
+			expectedError: true,
+		},
+		{
+			name: "variable in the url host",
+			config: `{"steps": [
+				{"name": "discover", "url": "https://api.example.com", "method": "GET", "accepted_statuscodes": ["2XX"],
+				 "extract": [{"variable": "region", "source": "header", "expression": "X-Region"}]},
+				{"name": "fetch", "url": "https://{{region}}.api.example.com/items", "method": "GET", "accepted_statuscodes": ["2XX"]}
+			]}`,
+			expectedError: false,
+		},
+		{
+			name: "invalid variable name",
+			config: `{"steps": [
+				{"name": "login", "url": "https://api.example.com", "method": "GET", "accepted_statuscodes": ["2XX"],
+				 "extract": [{"variable": "access-token", "source": "json", "expression": "token"}]}
+			]}`,
+			expectedError: true,
+		},
+		{
+			name: "invalid extract pattern",
+			config: `{"steps": [
+				{"name": "login", "url": "https://api.example.com", "method": "GET", "accepted_statuscodes": ["2XX"],
+				 "extract": [{"variable": "csrf", "source": "regex", "expression": "name=\"csrf\" value=\"(["}]}
+			]}`,
+			expectedError: true,
+		},
+		{
+			name: "invalid extract source",
+			config: `{"steps": [
+				{"name": "login", "url": "https://api.example.com", "method": "GET", "accepted_statuscodes": ["2XX"],
+				 "extract": [{"variable": "csrf", "source": "cookie", "expression": "csrf"}]}
+			]}`,
+			expectedError: true,
+		},
+		{
+			name:          "invalid json condition",
+			config:        `{"steps": [{"name": "a", "url": "https://api.example.com", "method": "GET", "accepted_statuscodes": ["2XX"], "json_condition": "~="}]}`,
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := executor.Validate(tt.config)
+			if tt.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestHTTPTransactionExecutor_Execute(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+	executor := NewHTTPTransactionExecutor(logger)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/login", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "s3cr3t", Path: "/"})
+		w.Header().Set("X-Request-Id", "req-42")
+		fmt.Fprint(w, `{"access_token": "tok-123", "user": {"id": 7}}`)
+	})
+	mux.HandleFunc("/users/7/items", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer tok-123" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `<ul><li data-id="item-9">Item</li></ul>`)
+	})
+	mux.HandleFunc("/items/item-9", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"status": "in_stock", "quantity": 12}`)
+	})
+	mux.HandleFunc("/logout", func(w http.ResponseWriter, r *http.Request) {
+		cookie, err := r.Cookie("session")
+		if err != nil || cookie.Value != "s3cr3t" || r.Header.Get("X-Request-Id") != "req-42" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	login := `{"name": "login", "url": "%[1]s/login", "method": "POST", "encoding": "json",
+		"body": "{\"user\": \"monitor\"}", "accepted_statuscodes": ["2XX"],
+		"extract": [
+			{"variable": "token", "source": "json", "expression": "access_token"},
+			{"variable": "user_id", "source": "json", "expression": "user.id"},
+			{"variable": "request_id", "source": "header", "expression": "X-Request-Id"}
+		]}`
+	fetch := `{"name": "fetch", "url": "%[1]s/users/{{user_id}}/items", "method": "GET", "accepted_statuscodes": ["2XX"],
+		"headers": "{\"Authorization\": \"Bearer {{token}}\"}", "keyword": "<li",
+		"extract": [{"variable": "item", "source": "regex", "expression": "data-id=\"([^\"]+)\""}]}`
+	item := `{"name": "item", "url": "%[1]s/items/{{item}}", "method": "GET", "accepted_statuscodes": ["2XX"],
+		"json_query": "quantity", "json_condition": ">", "expected_value": "%[2]s"}`
+	logout := `{"name": "logout", "url": "%[1]s/logout", "method": "POST", "accepted_statuscodes": ["2XX"],
+		"headers": "{\"X-Request-Id\": \"{{request_id}}\"}"}`
+
+	steps := func(templates ...string) string {
+		return "[" + strings.Join(templates, ",") + "]"
+	}
+
+	tests := []struct {
+		name           string
+		steps          string
+		expectedStatus shared.MonitorStatus
+		expectMessage  []string
+	}{
+		{
+			name:           "full flow",
+			steps:          steps(login, fetch, item, logout),
+			expectedStatus: shared.MonitorStatusUp,
+			expectMessage:  []string{"4 steps passed in", "login: ", "fetch: ", "item: ", "logout: "},
+		},
+		{
+			name:           "json assertion fails",
+			steps:          steps(login, fetch, strings.Replace(item, "%[2]s", "20", 1), logout),
+			expectedStatus: shared.MonitorStatusDown,
+			expectMessage:  []string{"Step 3 (item) failed: JSON query 'quantity' with condition '>' and expected value '20' failed", "[login: ", "item: "},
+		},
+		{
+			name:           "missing authorization",
+			steps:          steps(login, strings.Replace(fetch, "{{token}}", "wrong", 1)),
+			expectedStatus: shared.MonitorStatusDown,
+			expectMessage:  []string{"Step 2 (fetch) failed: HTTP status 401"},
+		},
+		{
+			name:           "extraction fails",
+			steps:          steps(strings.Replace(login, "user.id", "user.uuid", 1), fetch),
+			expectedStatus: shared.MonitorStatusDown,
+			expectMessage:  []string{"Step 1 (login) failed: failed to extract user_id: JSON query path not found: user.uuid"},
+		},
+		{
+			name:           "keyword fails",
+			steps:          steps(login, strings.Replace(fetch, `"keyword": "<li"`, `"keyword": "<table"`, 1)),
+			expectedStatus: shared.MonitorStatusDown,
+			expectMessage:  []string{"Step 2 (fetch) failed: keyword '<table' not found in response"},
+		},
+		{
+			name:           "cookies are kept between steps",
+			steps:          steps(login, logout),
+			expectedStatus: shared.MonitorStatusUp,
+			expectMessage:  []string{"2 steps passed"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			monitor := &Monitor{
+				ID:      "monitor-transaction",
+				Name:    "Test Transaction Monitor",
+				Type:    "http-transaction",
+				Timeout: 5,
+				Config:  fmt.Sprintf(`{"steps": %s}`, fmt.Sprintf(tt.steps, server.URL, "10")),
+			}
+			require.NoError(t, executor.Validate(monitor.Config))
+
+			result := executor.Execute(context.Background(), monitor, nil)
+			assert.Equal(t, tt.expectedStatus, result.Status, result.Message)
+			for _, expected := range tt.expectMessage {
+				assert.Contains(t, result.Message, expected)
+			}
+		})
+	}
+}
+
+func TestExpandTransactionVariables(t *testing.T) {
+	variables := map[string]string{"token": "abc", "id": "7"}
+
+	assert.Equal(t, "Bearer abc", expandTransactionVariables("Bearer {{token}}", variables))
+	assert.Equal(t, "/users/7/items/7", expandTransactionVariables("/users/{{ id }}/items/{{id}}", variables))
+	assert.Equal(t, "{{missing}}", expandTransactionVariables("{{missing}}", variables))
+	assert.Equal(t, []string{"token", "id"}, transactionVariables("Bearer {{token}}", "", "/{{ id }}"))
+}
+
+func TestHTTPTransactionExecutor_Execute_TLSInfo(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+	executor := NewHTTPTransactionExecutor(logger)
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "ok")
+	}))
+	defer server.Close()
+
+	monitor := &Monitor{
+		ID:      "monitor-transaction-tls",
+		Name:    "Test Transaction Monitor",
+		Type:    "http-transaction",
+		Timeout: 5,
+		Config: fmt.Sprintf(`{"ignore_tls_errors": true, "steps": [
+			{"name": "home", "url": "%s/", "method": "GET", "accepted_statuscodes": ["2XX"], "keyword": "ok"}
+		]}`, server.URL),
+	}
+	require.NoError(t, executor.Validate(monitor.Config))
+
+	result := executor.Execute(context.Background(), monitor, nil)
+	assert.Equal(t, shared.MonitorStatusUp, result.Status, result.Message)
+	require.NotNil(t, result.TLSInfo)
+	assert.NotNil(t, result.TLSInfo.CertInfo)
+}
@@ -21,6 +21,9 @@ type MongoDBConfig struct {
 	Command          string `json:"command" example:"{\"ping\": 1}"`
 	JsonPath         string `json:"jsonPath" example:"$"`
 	ExpectedValue    string `json:"expectedValue" example:""`
+
+	// Soft limits that report a passing check as degraded
+	DegradedThresholds
 }
 
 type MongoDBExecutor struct {
@@ -103,12 +106,12 @@ func (m *MongoDBExecutor) Execute(ctx context.Context, monitor *Monitor, proxyMo
 	// If no JSON path is provided, consider it successful
 	if cfg.JsonPath == "" {
 		m.logger.Infof("MongoDB command successful: %s", monitor.Name)
-		return &Result{
+		return cfg.Apply(&Result{
 			Status:    shared.MonitorStatusUp,
 			Message:   "Command executed successfully",
 			StartTime: startTime,
 			EndTime:   endTime,
-		}
+		})
 	}
 
 	// Evaluate JSON path
@@ -138,12 +141,12 @@ func (m *MongoDBExecutor) Execute(ctx context.Context, monitor *Monitor, proxyMo
 	if cfg.ExpectedValue != "" {
 		if m.isValueEqual(evaluatedResult, cfg.ExpectedValue) {
 			m.logger.Infof("MongoDB expected value matched: %s", monitor.Name)
-			return &Result{
+			return cfg.Apply(&Result{
 				Status:    shared.MonitorStatusUp,
 				Message:   "Command executed successfully and expected value was found",
 				StartTime: startTime,
 				EndTime:   endTime,
-			}
+			})
 		} else {
 			resultStr := fmt.Sprintf("%v", evaluatedResult)
 			m.logger.Infof("MongoDB expected value mismatch: %s, got %s, expected %s", monitor.Name, resultStr, cfg.ExpectedValue)
@@ -158,12 +161,12 @@ func (m *MongoDBExecutor) Execute(ctx context.Context, monitor *Monitor, proxyMo
 
 	// JSON path evaluation successful
 	m.logger.Infof("MongoDB JSON path evaluation successful: %s", monitor.Name)
-	return &Result{
+	return cfg.Apply(&Result{
 		Status:    shared.MonitorStatusUp,
 		Message:   "Command executed successfully and the jsonata expression produces a result",
 		StartTime: startTime,
 		EndTime:   endTime,
-	}
+	})
 }
 
 // runMongoDBCommand connects to MongoDB and executes the given command
@@ -23,6 +23,9 @@ type PingConfig struct {
 	PacketSize        int    `json:"packet_size" validate:"omitempty,min=0,max=65507" example:"32"`
 	Count             int    `json:"count" validate:"omitempty,min=1,max=100" example:"1"`
 	PerRequestTimeout int    `json:"per_request_timeout" validate:"omitempty,min=1,max=60" example:"2"`
+
+	// Soft limits that report a passing check as degraded
+	DegradedThresholds
 }
 
 type PingExecutor struct {
@@ -116,12 +119,12 @@ func (p *PingExecutor) Execute(ctx context.Context, m *Monitor, proxyModel *Prox
 
 	p.logger.Infof("Ping successful: %s, RTT: %v", m.Name, rtt)
 
-	return &Result{
+	return cfg.Apply(&Result{
 		Status:    shared.MonitorStatusUp,
 		Message:   fmt.Sprintf("Ping successful, RTT: %v", rtt),
 		StartTime: startTime,
 		EndTime:   endTime,
-	}
+	})
 }
 
 // tryNativePing attempts to use native ICMP implementation with enhanced stability
@@ -2,9 +2,12 @@ package executor
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"math"
 	"vigi/internal/modules/shared"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gosnmp/gosnmp"
@@ -14,21 +17,100 @@ import (
 type SnmpConfig struct {
 	Host             string `json:"host" validate:"required" example:"127.0.0.1"`
 	Port             uint16 `json:"port" example:"161"`
-	Community        string `json:"community" validate:"required" example:"public"`
+	Community        string `json:"community" validate:"required_unless=SnmpVersion v3" example:"public"`
 	SnmpVersion      string `json:"snmp_version" validate:"required,oneof=v1 v2c v3" example:"v2c"`
-	Oid              string `json:"oid" validate:"required" example:"1.3.6.1.4.1.1.9.6.1.101"`
+	Oid              string `json:"oid" example:"1.3.6.1.4.1.1.9.6.1.101"`
 	JsonPath         string `json:"json_path" example:"$"`
 	JsonPathOperator string `json:"json_path_operator" validate:"omitempty,oneof=eq ne lt gt le ge" example:"eq"`
 	ExpectedValue    string `json:"expected_value" example:""`
+
+	// SNMPv3 user security model, ignored for v1 and v2c
+	SecurityLevel  string `json:"security_level,omitempty" validate:"omitempty,oneof=noAuthNoPriv authNoPriv authPriv" example:"authPriv"`
+	Username       string `json:"username,omitempty" example:"monitor"`
+	AuthProtocol   string `json:"auth_protocol,omitempty" validate:"omitempty,oneof=MD5 SHA SHA-256 SHA-512" example:"SHA-256"`
+	AuthPassphrase string `json:"auth_passphrase,omitempty" example:""`
+	// AES-256 uses the Blumenthal key extension (net-snmp), AES-256C the Reeder
+	// key extension used by Cisco devices
+	PrivProtocol   string `json:"priv_protocol,omitempty" validate:"omitempty,oneof=DES AES AES-256 AES-256C" example:"AES"`
+	PrivPassphrase string `json:"priv_passphrase,omitempty" example:""`
+	ContextName    string `json:"context_name,omitempty" example:""`
+
+	// Checks replace the single oid/expected_value comparison when set
+	Checks []SnmpCheck `json:"checks,omitempty" validate:"omitempty,max=50,dive"`
+}
+
+// SnmpCheck is a threshold rule on one OID, or on every OID of a subtree when Walk is set
+type SnmpCheck struct {
+	Oid      string `json:"oid" validate:"required" example:"1.3.6.1.2.1.2.2.1.8"`
+	Label    string `json:"label,omitempty" example:"ifOperStatus"`
+	Walk     bool   `json:"walk,omitempty" example:"true"`
+	Operator string `json:"operator" validate:"required,oneof=eq ne lt gt le ge" example:"eq"`
+	Value    string `json:"value" validate:"required" example:"1"`
+	// Rate compares the per second rate of a counter between two polls instead of its value
+	Rate bool `json:"rate,omitempty" example:"false"`
+}
+
+// USM derives its keys from the passphrases, RFC 3414 requires at least 8 characters
+const snmpMinPassphraseLength = 8
+
+// counterSample is the value of a counter OID at a poll
+type counterSample struct {
+	Value uint64    `json:"value"`
+	At    time.Time `json:"at"`
+}
+
+// counterStaleAfter drops the counters of monitors that stopped being polled
+const counterStaleAfter = time.Hour
+
+func counterStateKey(monitorID string) string {
+	return "snmp-counters:" + monitorID
 }
 
 type SnmpExecutor struct {
 	logger *zap.SugaredLogger
+	state  StateStore
+	now    func() time.Time
 }
 
-func NewSnmpExecutor(logger *zap.SugaredLogger) *SnmpExecutor {
+func NewSnmpExecutor(logger *zap.SugaredLogger, state StateStore) *SnmpExecutor {
 	return &SnmpExecutor{
 		logger: logger,
+		state:  state,
+		now:    time.Now,
+	}
+}
+
+// previousCounters returns the counters stored by the previous poll of the
+// monitor, whichever worker ran it
+func (s *SnmpExecutor) previousCounters(ctx context.Context, monitorID string) map[string]counterSample {
+	value, ok, err := s.state.Get(ctx, counterStateKey(monitorID))
+	if err != nil {
+		s.logger.Warnf("Failed to load SNMP counters of monitor %s: %v", monitorID, err)
+		return nil
+	}
+	if !ok {
+		return nil
+	}
+
+	var samples map[string]counterSample
+	if err := json.Unmarshal([]byte(value), &samples); err != nil {
+		s.logger.Warnf("Failed to decode SNMP counters of monitor %s: %v", monitorID, err)
+		return nil
+	}
+	return samples
+}
+
+func (s *SnmpExecutor) storeCounters(ctx context.Context, monitorID string, samples map[string]counterSample) {
+	if len(samples) == 0 {
+		return
+	}
+	value, err := json.Marshal(samples)
+	if err != nil {
+		s.logger.Warnf("Failed to encode SNMP counters of monitor %s: %v", monitorID, err)
+		return
+	}
+	if err := s.state.Set(ctx, counterStateKey(monitorID), string(value), counterStaleAfter); err != nil {
+		s.logger.Warnf("Failed to store SNMP counters of monitor %s: %v", monitorID, err)
 	}
 }
 
@@ -41,7 +123,50 @@ func (s *SnmpExecutor) Validate(configJSON string) error {
 	if err != nil {
 		return err
 	}
-	return GenericValidator(cfg.(*SnmpConfig))
+
+	config := cfg.(*SnmpConfig)
+
+	if err := GenericValidator(config); err != nil {
+		return err
+	}
+
+	if config.Oid == "" && len(config.Checks) == 0 {
+		return fmt.Errorf("oid is required when no checks are configured")
+	}
+
+	if config.SnmpVersion == "v3" {
+		return s.validateV3(config)
+	}
+	return nil
+}
+
+func (s *SnmpExecutor) validateV3(cfg *SnmpConfig) error {
+	if strings.TrimSpace(cfg.Username) == "" {
+		return fmt.Errorf("username is required when snmp_version is 'v3'")
+	}
+	if cfg.SecurityLevel == "" {
+		return fmt.Errorf("security_level is required when snmp_version is 'v3'")
+	}
+
+	if cfg.SecurityLevel == "authNoPriv" || cfg.SecurityLevel == "authPriv" {
+		if cfg.AuthProtocol == "" {
+			return fmt.Errorf("auth_protocol is required when security_level is '%s'", cfg.SecurityLevel)
+		}
+		if len(cfg.AuthPassphrase) < snmpMinPassphraseLength {
+			return fmt.Errorf("auth_passphrase must be at least %d characters", snmpMinPassphraseLength)
+		}
+	}
+
+	if cfg.SecurityLevel == "authPriv" {
+		if cfg.PrivProtocol == "" {
+			return fmt.Errorf("priv_protocol is required when security_level is 'authPriv'")
+		}
+		if len(cfg.PrivPassphrase) < snmpMinPassphraseLength {
+			return fmt.Errorf("priv_passphrase must be at least %d characters", snmpMinPassphraseLength)
+		}
+	}
+
+	return nil
 }
 
 func (s *SnmpExecutor) Execute(ctx context.Context, m *Monitor, proxyModel *Proxy) *Result {
@@ -56,19 +181,12 @@ func (s *SnmpExecutor) Execute(ctx context.Context, m *Monitor, proxyModel *Prox
 		cfg.Port = 161
 	}
 
-	s.logger.Debugf("execute snmp cfg: %+v", cfg)
+	s.logger.Debugf("execute snmp: host=%s port=%d version=%s oid=%s", cfg.Host, cfg.Port, cfg.SnmpVersion, cfg.Oid)
 
 	startTime := time.Now().UTC()
 
 	// Create SNMP connection
-	snmpClient := &gosnmp.GoSNMP{
-		Target:    cfg.Host,
-		Port:      cfg.Port,
-		Community: cfg.Community,
-		Version:   s.parseSnmpVersion(cfg.SnmpVersion),
-		Timeout:   time.Duration(m.Timeout) * time.Second,
-		Retries:   m.MaxRetries,
-	}
+	snmpClient := s.newClient(cfg, m)
 
 	err = snmpClient.Connect()
 	if err != nil {
@@ -83,6 +201,10 @@ func (s *SnmpExecutor) Execute(ctx context.Context, m *Monitor, proxyModel *Prox
 	}
 	defer snmpClient.Conn.Close()
 
+	if len(cfg.Checks) > 0 {
+		return s.executeChecks(ctx, cfg, snmpClient, m, startTime)
+	}
+
 	// Perform SNMP GET request
 	oids := []string{cfg.Oid}
 	result, err := snmpClient.Get(oids)
@@ -170,6 +292,233 @@ func (s *SnmpExecutor) Execute(ctx context.Context, m *Monitor, proxyModel *Prox
 	}
 }
 
+func (s *SnmpExecutor) executeChecks(ctx context.Context, cfg *SnmpConfig, client *gosnmp.GoSNMP, m *Monitor, startTime time.Time) *Result {
+	now := s.now()
+	samples := make(map[string]counterSample)
+	var failures []string
+	checked := 0
+	baseline := false
+
+	// Samples are stored only after a complete poll so a failed poll keeps the previous baseline
+	previous := s.previousCounters(ctx, m.ID)
+
+	for _, check := range cfg.Checks {
+		name := check.Label
+		if name == "" {
+			name = check.Oid
+		}
+
+		variables, err := s.fetch(client, check)
+		if err != nil {
+			s.logger.Infof("SNMP query failed: %s, %s, %s", m.Name, check.Oid, err.Error())
+			return &Result{
+				Status:    shared.MonitorStatusDown,
+				Message:   fmt.Sprintf("SNMP query of %s failed: %v", name, err),
+				StartTime: startTime,
+				EndTime:   time.Now().UTC(),
+			}
+		}
+		if len(variables) == 0 {
+			failures = append(failures, fmt.Sprintf("%s: no values returned", name))
+			continue
+		}
+
+		for _, variable := range variables {
+			label := name
+			if check.Walk {
+				// Identify the row of the walked table, e.g. ifOperStatus.3
+				label = name + strings.TrimPrefix(strings.TrimPrefix(variable.Name, "."), strings.TrimPrefix(check.Oid, "."))
+			}
+
+			switch variable.Type {
+			case gosnmp.NoSuchObject, gosnmp.NoSuchInstance, gosnmp.EndOfMibView, gosnmp.Null:
+				failures = append(failures, fmt.Sprintf("%s: no such object", label))
+				continue
+			}
+
+			value := s.convertSnmpValueToString(variable.Value, variable.Type)
+			if check.Rate {
+				counter, ok := counterValue(variable)
+				if !ok {
+					failures = append(failures, fmt.Sprintf("%s: %s is not a counter", label, variable.Type))
+					continue
+				}
+				samples[variable.Name] = counterSample{Value: counter, At: now}
+
+				rate, ok := counterRate(previous[variable.Name], counter, variable.Type, now)
+				if !ok {
+					baseline = true
+					continue
+				}
+				value = strconv.FormatFloat(rate, 'f', 2, 64)
+			}
+
+			checked++
+			if !s.evaluateCondition(value, check.Operator, check.Value) {
+				failures = append(failures, fmt.Sprintf("%s = %s (expected %s %s)", label, value, check.Operator, check.Value))
+			}
+		}
+	}
+
+	s.storeCounters(ctx, m.ID, samples)
+	endTime := time.Now().UTC()
+
+	if len(failures) > 0 {
+		s.logger.Infof("SNMP checks failed: %s, %s", m.Name, strings.Join(failures, "; "))
+		return &Result{
+			Status:    shared.MonitorStatusDown,
+			Message:   fmt.Sprintf("%d SNMP check(s) failed: %s", len(failures), summarizeFailures(failures)),
+			StartTime: startTime,
+			EndTime:   endTime,
+		}
+	}
+
+	message := fmt.Sprintf("%d SNMP value(s) passed all checks", checked)
+	if baseline {
+		message += ", rates are available from the next poll"
+	}
+	s.logger.Infof("SNMP checks passed: %s, %s", m.Name, message)
+	return &Result{
+		Status:    shared.MonitorStatusUp,
+		Message:   message,
+		StartTime: startTime,
+		EndTime:   endTime,
+	}
+}
+
+// fetch gets the value of a check's OID, or every value of its subtree when walking
+func (s *SnmpExecutor) fetch(client *gosnmp.GoSNMP, check SnmpCheck) ([]gosnmp.SnmpPDU, error) {
+	if check.Walk {
+		// GETBULK does not exist in SNMPv1
+		if client.Version == gosnmp.Version1 {
+			return client.WalkAll(check.Oid)
+		}
+		return client.BulkWalkAll(check.Oid)
+	}
+
+	result, err := client.Get([]string{check.Oid})
+	if err != nil {
+		return nil, err
+	}
+	return result.Variables, nil
+}
+
+func counterValue(variable gosnmp.SnmpPDU) (uint64, bool) {
+	switch variable.Type {
+	case gosnmp.Counter32, gosnmp.Counter64:
+		return gosnmp.ToBigInt(variable.Value).Uint64(), true
+	default:
+		return 0, false
+	}
+}
+
+// counterRate returns the per second increase of a counter since the previous sample.
+// Counter32 wraps around at 2^32, any other decrease means the agent restarted and
+// the counter needs a new baseline.
+func counterRate(previous counterSample, current uint64, counterType gosnmp.Asn1BER, now time.Time) (float64, bool) {
+	elapsed := now.Sub(previous.At).Seconds()
+	if previous.At.IsZero() || elapsed <= 0 {
+		return 0, false
+	}
+
+	var delta uint64
+	switch {
+	case current >= previous.Value:
+		delta = current - previous.Value
+	case counterType == gosnmp.Counter32 && previous.Value <= math.MaxUint32:
+		delta = current + (math.MaxUint32 - previous.Value) + 1
+	default:
+		return 0, false
+	}
+	return float64(delta) / elapsed, true
+}
+
+// summarizeFailures keeps the message readable when a walk fails on many rows
+func summarizeFailures(failures []string) string {
+	const maxListed = 5
+	if len(failures) <= maxListed {
+		return strings.Join(failures, "; ")
+	}
+	return fmt.Sprintf("%s; and %d more", strings.Join(failures[:maxListed], "; "), len(failures)-maxListed)
+}
+
+func (s *SnmpExecutor) newClient(cfg *SnmpConfig, m *Monitor) *gosnmp.GoSNMP {
+	client := &gosnmp.GoSNMP{
+		Target:    cfg.Host,
+		Port:      cfg.Port,
+		Community: cfg.Community,
+		Version:   s.parseSnmpVersion(cfg.SnmpVersion),
+		Timeout:   time.Duration(m.Timeout) * time.Second,
+		Retries:   m.MaxRetries,
+	}
+
+	if client.Version == gosnmp.Version3 {
+		flags := s.parseSecurityLevel(cfg.SecurityLevel)
+		usm := &gosnmp.UsmSecurityParameters{
+			UserName:               cfg.Username,
+			AuthenticationProtocol: gosnmp.NoAuth,
+			PrivacyProtocol:        gosnmp.NoPriv,
+		}
+		// Protocols above the security level are left out, the agent would reject them
+		if flags&gosnmp.AuthNoPriv != 0 {
+			usm.AuthenticationProtocol = s.parseAuthProtocol(cfg.AuthProtocol)
+			usm.AuthenticationPassphrase = cfg.AuthPassphrase
+		}
+		if flags&gosnmp.AuthPriv == gosnmp.AuthPriv {
+			usm.PrivacyProtocol = s.parsePrivProtocol(cfg.PrivProtocol)
+			usm.PrivacyPassphrase = cfg.PrivPassphrase
+		}
+
+		client.SecurityModel = gosnmp.UserSecurityModel
+		client.MsgFlags = flags
+		client.SecurityParameters = usm
+		client.ContextName = cfg.ContextName
+	}
+
+	return client
+}
+
+func (s *SnmpExecutor) parseSecurityLevel(level string) gosnmp.SnmpV3MsgFlags {
+	switch level {
+	case "authNoPriv":
+		return gosnmp.AuthNoPriv
+	case "authPriv":
+		return gosnmp.AuthPriv
+	default:
+		return gosnmp.NoAuthNoPriv
+	}
+}
+
+func (s *SnmpExecutor) parseAuthProtocol(protocol string) gosnmp.SnmpV3AuthProtocol {
+	switch protocol {
+	case "MD5":
+		return gosnmp.MD5
+	case "SHA":
+		return gosnmp.SHA
+	case "SHA-256":
+		return gosnmp.SHA256
+	case "SHA-512":
+		return gosnmp.SHA512
+	default:
+		return gosnmp.NoAuth
+	}
+}
+
+func (s *SnmpExecutor) parsePrivProtocol(protocol string) gosnmp.SnmpV3PrivProtocol {
+	switch protocol {
+	case "DES":
+		return gosnmp.DES
+	case "AES":
+		return gosnmp.AES
+	case "AES-256":
+		return gosnmp.AES256
+	case "AES-256C":
+		return gosnmp.AES256C
+	default:
+		return gosnmp.NoPriv
+	}
+}
+
 func (s *SnmpExecutor) parseSnmpVersion(version string) gosnmp.SnmpVersion {
 	switch version {
 	case "v1":
@@ -2,14 +2,24 @@ package executor
 
 import (
 	"context"
+	"fmt"
+	"math"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
+	"time"
+	"vigi/internal/modules/shared"
 
+	"github.com/gosnmp/gosnmp"
 	"go.uber.org/zap"
 )
 
 func TestSnmpExecutor_Validate(t *testing.T) {
 	logger := zap.NewNop().Sugar()
-	executor := NewSnmpExecutor(logger)
+	executor := NewSnmpExecutor(logger, NewMemoryStateStore())
 
 	tests := []struct {
 		name      string
@@ -80,6 +90,146 @@ func TestSnmpExecutor_Validate(t *testing.T) {
 			}`,
 			wantError: true,
 		},
+		{
+			name: "v3 without community",
+			config: `{
+				"host": "127.0.0.1",
+				"snmp_version": "v3",
+				"oid": "1.3.6.1.2.1.1.1.0",
+				"security_level": "authPriv",
+				"username": "monitor",
+				"auth_protocol": "SHA-256",
+				"auth_passphrase": "authpass123",
+				"priv_protocol": "AES",
+				"priv_passphrase": "privpass123",
+				"context_name": "vlan-10"
+			}`,
+			wantError: false,
+		},
+		{
+			name: "v3 noAuthNoPriv",
+			config: `{
+				"host": "127.0.0.1",
+				"snmp_version": "v3",
+				"oid": "1.3.6.1.2.1.1.1.0",
+				"security_level": "noAuthNoPriv",
+				"username": "monitor"
+			}`,
+			wantError: false,
+		},
+		{
+			name: "v3 missing username",
+			config: `{
+				"host": "127.0.0.1",
+				"snmp_version": "v3",
+				"oid": "1.3.6.1.2.1.1.1.0",
+				"security_level": "noAuthNoPriv"
+			}`,
+			wantError: true,
+		},
+		{
+			name: "v3 missing security level",
+			config: `{
+				"host": "127.0.0.1",
+				"snmp_version": "v3",
+				"oid": "1.3.6.1.2.1.1.1.0",
+				"username": "monitor"
+			}`,
+			wantError: true,
+		},
+		{
+			name: "v3 invalid security level",
+			config: `{
+				"host": "127.0.0.1",
+				"snmp_version": "v3",
+				"oid": "1.3.6.1.2.1.1.1.0",
+				"security_level": "authOnly",
+				"username": "monitor"
+			}`,
+			wantError: true,
+		},
+		{
+			name: "v3 authNoPriv missing auth protocol",
+			config: `{
+				"host": "127.0.0.1",
+				"snmp_version": "v3",
+				"oid": "1.3.6.1.2.1.1.1.0",
+				"security_level": "authNoPriv",
+				"username": "monitor",
+				"auth_passphrase": "authpass123"
+			}`,
+			wantError: true,
+		},
+		{
+			name: "v3 invalid auth protocol",
+			config: `{
+				"host": "127.0.0.1",
+				"snmp_version": "v3",
+				"oid": "1.3.6.1.2.1.1.1.0",
+				"security_level": "authNoPriv",
+				"username": "monitor",
+				"auth_protocol": "SHA-1024",
+				"auth_passphrase": "authpass123"
+			}`,
+			wantError: true,
+		},
+		{
+			name: "v3 short auth passphrase",
+			config: `{
+				"host": "127.0.0.1",
+				"snmp_version": "v3",
+				"oid": "1.3.6.1.2.1.1.1.0",
+				"security_level": "authNoPriv",
+				"username": "monitor",
+				"auth_protocol": "MD5",
+				"auth_passphrase": "short"
+			}`,
+			wantError: true,
+		},
+		{
+			name: "v3 authPriv missing priv protocol",
+			config: `{
+				"host": "127.0.0.1",
+				"snmp_version": "v3",
+				"oid": "1.3.6.1.2.1.1.1.0",
+				"security_level": "authPriv",
+				"username": "monitor",
+				"auth_protocol": "SHA",
+				"auth_passphrase": "authpass123",
+				"priv_passphrase": "privpass123"
+			}`,
+			wantError: true,
+		},
+		{
+			name: "v3 invalid priv protocol",
+			config: `{
+				"host": "127.0.0.1",
+				"snmp_version": "v3",
+				"oid": "1.3.6.1.2.1.1.1.0",
+				"security_level": "authPriv",
+				"username": "monitor",
+				"auth_protocol": "SHA",
+				"auth_passphrase": "authpass123",
+				"priv_protocol": "3DES",
+				"priv_passphrase": "privpass123"
+			}`,
+			wantError: true,
+		},
+		{
+			name: "v3 short priv passphrase",
+			config: `{
+				"host": "127.0.0.1",
+				"snmp_version": "v3",
+				"oid": "1.3.6.1.2.1.1.1.0",
+				"security_level": "authPriv",
+				"username": "monitor",
+				"auth_protocol": "SHA",
+				"auth_passphrase": "authpass123",
+				"priv_protocol": "AES-256",
+				"priv_passphrase": "short"
+			}`,
+			wantError: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -94,7 +244,7 @@ func TestSnmpExecutor_Validate(t *testing.T) {
 
 func TestSnmpExecutor_Unmarshal(t *testing.T) {
 	logger := zap.NewNop().Sugar()
-	executor := NewSnmpExecutor(logger)
+	executor := NewSnmpExecutor(logger, NewMemoryStateStore())
 
 	config := `{
 		"host": "127.0.0.1",
@@ -141,7 +291,7 @@ func TestSnmpExecutor_Unmarshal(t *testing.T) {
 
 func TestSnmpExecutor_parseSnmpVersion(t *testing.T) {
 	logger := zap.NewNop().Sugar()
-	executor := NewSnmpExecutor(logger)
+	executor := NewSnmpExecutor(logger, NewMemoryStateStore())
 
 	tests := []struct {
 		input    string
@@ -165,7 +315,7 @@ func TestSnmpExecutor_parseSnmpVersion(t *testing.T) {
 
 func TestSnmpExecutor_evaluateCondition(t *testing.T) {
 	logger := zap.NewNop().Sugar()
-	executor := NewSnmpExecutor(logger)
+	executor := NewSnmpExecutor(logger, NewMemoryStateStore())
 
 	tests := []struct {
 		name     string
@@ -197,7 +347,7 @@ func TestSnmpExecutor_evaluateCondition(t *testing.T) {
 
 func TestSnmpExecutor_Execute_InvalidConfig(t *testing.T) {
 	logger := zap.NewNop().Sugar()
-	executor := NewSnmpExecutor(logger)
+	executor := NewSnmpExecutor(logger, NewMemoryStateStore())
 
 	monitor := &Monitor{
 		Name:    "test-monitor",
@@ -219,7 +369,7 @@ func TestSnmpExecutor_Execute_InvalidConfig(t *testing.T) {
 
 func TestSnmpExecutor_Execute_DefaultPort(t *testing.T) {
 	logger := zap.NewNop().Sugar()
-	executor := NewSnmpExecutor(logger)
+	executor := NewSnmpExecutor(logger, NewMemoryStateStore())
 
 	// This test will fail at connection but should validate the config parsing
 	monitor := &Monitor{
@@ -248,3 +398,651 @@ func TestSnmpExecutor_Execute_DefaultPort(t *testing.T) {
 		// The actual port validation happens in Execute, so we just check that parsing worked
 	}
 }
+
+func TestSnmpExecutor_newClient_V3(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+	executor := NewSnmpExecutor(logger, NewMemoryStateStore())
+
+	tests := []struct {
+		name      string
+		cfg       *SnmpConfig
+		wantFlags gosnmp.SnmpV3MsgFlags
+		wantAuth  gosnmp.SnmpV3AuthProtocol
+		wantPriv  gosnmp.SnmpV3PrivProtocol
+	}{
+		{
+			name: "authPriv",
+			cfg: &SnmpConfig{SecurityLevel: "authPriv", AuthProtocol: "SHA-512", AuthPassphrase: "authpass123",
+				PrivProtocol: "AES-256", PrivPassphrase: "privpass123"},
+			wantFlags: gosnmp.AuthPriv,
+			wantAuth:  gosnmp.SHA512,
+			wantPriv:  gosnmp.AES256,
+		},
+		{
+			name: "authNoPriv ignores privacy settings",
+			cfg: &SnmpConfig{SecurityLevel: "authNoPriv", AuthProtocol: "MD5", AuthPassphrase: "authpass123",
+				PrivProtocol: "DES", PrivPassphrase: "privpass123"},
+			wantFlags: gosnmp.AuthNoPriv,
+			wantAuth:  gosnmp.MD5,
+			wantPriv:  gosnmp.NoPriv,
+		},
+		{
+			name: "noAuthNoPriv ignores auth settings",
+			cfg: &SnmpConfig{SecurityLevel: "noAuthNoPriv", AuthProtocol: "SHA", AuthPassphrase: "authpass123",
+				PrivProtocol: "AES", PrivPassphrase: "privpass123"},
+			wantFlags: gosnmp.NoAuthNoPriv,
+			wantAuth:  gosnmp.NoAuth,
+			wantPriv:  gosnmp.NoPriv,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.cfg.Host = "127.0.0.1"
+			tt.cfg.Port = 161
+			tt.cfg.SnmpVersion = "v3"
+			tt.cfg.Username = "monitor"
+			tt.cfg.ContextName = "vlan-10"
+
+			client := executor.newClient(tt.cfg, &Monitor{Timeout: 1})
+
+			if client.SecurityModel != gosnmp.UserSecurityModel {
+				t.Errorf("SecurityModel = %v, want %v", client.SecurityModel, gosnmp.UserSecurityModel)
+			}
+			if client.MsgFlags != tt.wantFlags {
+				t.Errorf("MsgFlags = %v, want %v", client.MsgFlags, tt.wantFlags)
+			}
+			if client.ContextName != "vlan-10" {
+				t.Errorf("ContextName = %v, want %v", client.ContextName, "vlan-10")
+			}
+
+			usm, ok := client.SecurityParameters.(*gosnmp.UsmSecurityParameters)
+			if !ok {
+				t.Fatalf("SecurityParameters = %T, want *gosnmp.UsmSecurityParameters", client.SecurityParameters)
+			}
+			if usm.UserName != "monitor" {
+				t.Errorf("UserName = %v, want %v", usm.UserName, "monitor")
+			}
+			if usm.AuthenticationProtocol != tt.wantAuth {
+				t.Errorf("AuthenticationProtocol = %v, want %v", usm.AuthenticationProtocol, tt.wantAuth)
+			}
+			if usm.PrivacyProtocol != tt.wantPriv {
+				t.Errorf("PrivacyProtocol = %v, want %v", usm.PrivacyProtocol, tt.wantPriv)
+			}
+		})
+	}
+}
+
+func TestSnmpExecutor_Execute_V3(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+	executor := NewSnmpExecutor(logger, NewMemoryStateStore())
+
+	const oid = "1.3.6.1.2.1.1.1.0"
+
+	tests := []struct {
+		name       string
+		agent      *gosnmp.UsmSecurityParameters
+		agentFlags gosnmp.SnmpV3MsgFlags
+		config     string
+		wantStatus shared.MonitorStatus
+	}{
+		{
+			name:       "noAuthNoPriv",
+			agent:      &gosnmp.UsmSecurityParameters{UserName: "monitor"},
+			agentFlags: gosnmp.NoAuthNoPriv,
+			config:     `"security_level": "noAuthNoPriv", "username": "monitor"`,
+			wantStatus: shared.MonitorStatusUp,
+		},
+		{
+			name: "authNoPriv with MD5",
+			agent: &gosnmp.UsmSecurityParameters{UserName: "monitor",
+				AuthenticationProtocol: gosnmp.MD5, AuthenticationPassphrase: "authpass123"},
+			agentFlags: gosnmp.AuthNoPriv,
+			config:     `"security_level": "authNoPriv", "username": "monitor", "auth_protocol": "MD5", "auth_passphrase": "authpass123"`,
+			wantStatus: shared.MonitorStatusUp,
+		},
+		{
+			name: "authPriv with SHA and DES",
+			agent: &gosnmp.UsmSecurityParameters{UserName: "monitor",
+				AuthenticationProtocol: gosnmp.SHA, AuthenticationPassphrase: "authpass123",
+				PrivacyProtocol: gosnmp.DES, PrivacyPassphrase: "privpass123"},
+			agentFlags: gosnmp.AuthPriv,
+			config: `"security_level": "authPriv", "username": "monitor", "auth_protocol": "SHA", "auth_passphrase": "authpass123",
+				"priv_protocol": "DES", "priv_passphrase": "privpass123"`,
+			wantStatus: shared.MonitorStatusUp,
+		},
+		{
+			name: "authPriv with SHA-256 and AES",
+			agent: &gosnmp.UsmSecurityParameters{UserName: "monitor",
+				AuthenticationProtocol: gosnmp.SHA256, AuthenticationPassphrase: "authpass123",
+				PrivacyProtocol: gosnmp.AES, PrivacyPassphrase: "privpass123"},
+			agentFlags: gosnmp.AuthPriv,
+			config: `"security_level": "authPriv", "username": "monitor", "auth_protocol": "SHA-256", "auth_passphrase": "authpass123",
+				"priv_protocol": "AES", "priv_passphrase": "privpass123"`,
+			wantStatus: shared.MonitorStatusUp,
+		},
+		{
+			name: "authPriv with SHA-512 and AES-256",
+			agent: &gosnmp.UsmSecurityParameters{UserName: "monitor",
+				AuthenticationProtocol: gosnmp.SHA512, AuthenticationPassphrase: "authpass123",
+				PrivacyProtocol: gosnmp.AES256, PrivacyPassphrase: "privpass123"},
+			agentFlags: gosnmp.AuthPriv,
+			config: `"security_level": "authPriv", "username": "monitor", "auth_protocol": "SHA-512", "auth_passphrase": "authpass123",
+				"priv_protocol": "AES-256", "priv_passphrase": "privpass123"`,
+			wantStatus: shared.MonitorStatusUp,
+		},
+		{
+			name: "wrong auth passphrase",
+			agent: &gosnmp.UsmSecurityParameters{UserName: "monitor",
+				AuthenticationProtocol: gosnmp.SHA256, AuthenticationPassphrase: "authpass123"},
+			agentFlags: gosnmp.AuthNoPriv,
+			config:     `"security_level": "authNoPriv", "username": "monitor", "auth_protocol": "SHA-256", "auth_passphrase": "wrongpass123"`,
+			wantStatus: shared.MonitorStatusDown,
+		},
+		{
+			name: "wrong priv passphrase",
+			agent: &gosnmp.UsmSecurityParameters{UserName: "monitor",
+				AuthenticationProtocol: gosnmp.SHA256, AuthenticationPassphrase: "authpass123",
+				PrivacyProtocol: gosnmp.AES, PrivacyPassphrase: "privpass123"},
+			agentFlags: gosnmp.AuthPriv,
+			config: `"security_level": "authPriv", "username": "monitor", "auth_protocol": "SHA-256", "auth_passphrase": "authpass123",
+				"priv_protocol": "AES", "priv_passphrase": "wrongpass123"`,
+			wantStatus: shared.MonitorStatusDown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agent := startSnmpAgent(t, newSnmpV3Decoder(t, tt.agent, tt.agentFlags))
+			agent.set(oid, gosnmp.OctetString, []byte("vigi test agent"))
+
+			monitor := &Monitor{
+				Name:    "test-monitor",
+				Timeout: 1,
+				Config: fmt.Sprintf(`{
+					"host": "127.0.0.1",
+					"port": %d,
+					"snmp_version": "v3",
+					"oid": %q,
+					"json_path": "$",
+					"json_path_operator": "eq",
+					"expected_value": "vigi test agent",
+					%s
+				}`, agent.port, oid, tt.config),
+			}
+
+			if err := executor.Validate(monitor.Config); err != nil {
+				t.Fatalf("Validate() error = %v", err)
+			}
+
+			result := executor.Execute(context.Background(), monitor, nil)
+			if result.Status != tt.wantStatus {
+				t.Errorf("Execute() status = %v, want %v, message: %s", result.Status, tt.wantStatus, result.Message)
+			}
+			if tt.wantStatus == shared.MonitorStatusUp && !strings.Contains(result.Message, "vigi test agent") {
+				t.Errorf("Execute() message = %q, want it to contain the agent value", result.Message)
+			}
+		})
+	}
+}
+
+func TestSnmpExecutor_Validate_Checks(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+	executor := NewSnmpExecutor(logger, NewMemoryStateStore())
+
+	tests := []struct {
+		name      string
+		config    string
+		wantError bool
+	}{
+		{
+			name: "checks without oid",
+			config: `{
+				"host": "127.0.0.1",
+				"community": "public",
+				"snmp_version": "v2c",
+				"checks": [
+					{"oid": "1.3.6.1.2.1.2.2.1.8", "label": "ifOperStatus", "walk": true, "operator": "eq", "value": "1"},
+					{"oid": "1.3.6.1.2.1.2.2.1.14", "walk": true, "rate": true, "operator": "lt", "value": "1"}
+				]
+			}`,
+			wantError: false,
+		},
+		{
+			name: "neither oid nor checks",
+			config: `{
+				"host": "127.0.0.1",
+				"community": "public",
+				"snmp_version": "v2c"
+			}`,
+			wantError: true,
+		},
+		{
+			name: "check missing oid",
+			config: `{
+				"host": "127.0.0.1",
+				"community": "public",
+				"snmp_version": "v2c",
+				"checks": [{"operator": "eq", "value": "1"}]
+			}`,
+			wantError: true,
+		},
+		{
+			name: "check invalid operator",
+			config: `{
+				"host": "127.0.0.1",
+				"community": "public",
+				"snmp_version": "v2c",
+				"checks": [{"oid": "1.3.6.1.2.1.1.3.0", "operator": "between", "value": "1"}]
+			}`,
+			wantError: true,
+		},
+		{
+			name: "check missing value",
+			config: `{
+				"host": "127.0.0.1",
+				"community": "public",
+				"snmp_version": "v2c",
+				"checks": [{"oid": "1.3.6.1.2.1.1.3.0", "operator": "gt"}]
+			}`,
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := executor.Validate(tt.config)
+			if (err != nil) != tt.wantError {
+				t.Errorf("Validate() error = %v, wantError %v", err, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestSnmpExecutor_Execute_Checks(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+	executor := NewSnmpExecutor(logger, NewMemoryStateStore())
+
+	agent := startSnmpAgent(t, &gosnmp.GoSNMP{Version: gosnmp.Version2c, Community: "public"})
+	agent.set("1.3.6.1.4.1.2021.10.1.5.1", gosnmp.Integer, 42)
+	agent.set("1.3.6.1.2.1.2.2.1.8.1", gosnmp.Integer, 1)
+	agent.set("1.3.6.1.2.1.2.2.1.8.2", gosnmp.Integer, 1)
+	agent.set("1.3.6.1.2.1.2.2.1.8.10", gosnmp.Integer, 1)
+	agent.set("1.3.6.1.2.1.2.2.1.9.1", gosnmp.TimeTicks, uint32(100))
+
+	tests := []struct {
+		name        string
+		versions    []string
+		checks      string
+		setup       func()
+		wantStatus  shared.MonitorStatus
+		wantMessage string
+	}{
+		{
+			name:        "get threshold",
+			checks:      `{"oid": "1.3.6.1.4.1.2021.10.1.5.1", "label": "cpu", "operator": "lt", "value": "80"}`,
+			wantStatus:  shared.MonitorStatusUp,
+			wantMessage: "1 SNMP value(s) passed all checks",
+		},
+		{
+			name:        "walk every row passes",
+			checks:      `{"oid": "1.3.6.1.2.1.2.2.1.8", "label": "ifOperStatus", "walk": true, "operator": "eq", "value": "1"}`,
+			wantStatus:  shared.MonitorStatusUp,
+			wantMessage: "3 SNMP value(s) passed all checks",
+		},
+		{
+			name: "walk with a failing row",
+			checks: `{"oid": "1.3.6.1.2.1.2.2.1.8", "label": "ifOperStatus", "walk": true, "operator": "eq", "value": "1"},
+				{"oid": "1.3.6.1.4.1.2021.10.1.5.1", "label": "cpu", "operator": "lt", "value": "80"}`,
+			setup:       func() { agent.set("1.3.6.1.2.1.2.2.1.8.10", gosnmp.Integer, 2) },
+			wantStatus:  shared.MonitorStatusDown,
+			wantMessage: "ifOperStatus.10 = 2 (expected eq 1)",
+		},
+		{
+			name:        "get threshold fails",
+			checks:      `{"oid": "1.3.6.1.4.1.2021.10.1.5.1", "label": "cpu", "operator": "lt", "value": "40"}`,
+			wantStatus:  shared.MonitorStatusDown,
+			wantMessage: "cpu = 42 (expected lt 40)",
+		},
+		{
+			name:        "missing object",
+			checks:      `{"oid": "1.3.6.1.4.1.2021.10.1.5.9", "label": "cpu", "operator": "lt", "value": "80"}`,
+			wantStatus:  shared.MonitorStatusDown,
+			wantMessage: "cpu: no such object",
+		},
+		{
+			name:        "empty subtree",
+			checks:      `{"oid": "1.3.6.1.2.1.31.1.1.1.6", "label": "ifHCInOctets", "walk": true, "operator": "gt", "value": "0"}`,
+			wantStatus:  shared.MonitorStatusDown,
+			wantMessage: "ifHCInOctets: no values returned",
+		},
+		{
+			name:        "rate of a non counter",
+			checks:      `{"oid": "1.3.6.1.2.1.2.2.1.9.1", "label": "ifLastChange", "rate": true, "operator": "lt", "value": "1"}`,
+			wantStatus:  shared.MonitorStatusDown,
+			wantMessage: "ifLastChange: TimeTicks is not a counter",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.setup != nil {
+				tt.setup()
+			}
+
+			// v1 walks with GETNEXT, later versions with GETBULK
+			for _, version := range []string{"v1", "v2c"} {
+				monitor := &Monitor{
+					ID:      "monitor-" + version,
+					Name:    "test-monitor",
+					Timeout: 1,
+					Config: fmt.Sprintf(`{
+						"host": "127.0.0.1",
+						"port": %d,
+						"community": "public",
+						"snmp_version": %q,
+						"checks": [%s]
+					}`, agent.port, version, tt.checks),
+				}
+
+				if err := executor.Validate(monitor.Config); err != nil {
+					t.Fatalf("Validate() error = %v", err)
+				}
+
+				result := executor.Execute(context.Background(), monitor, nil)
+				if result.Status != tt.wantStatus {
+					t.Errorf("%s: Execute() status = %v, want %v, message: %s", version, result.Status, tt.wantStatus, result.Message)
+				}
+				if !strings.Contains(result.Message, tt.wantMessage) {
+					t.Errorf("%s: Execute() message = %q, want it to contain %q", version, result.Message, tt.wantMessage)
+				}
+			}
+		})
+	}
+}
+
+func TestSnmpExecutor_Execute_CounterRates(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+
+	// Consecutive polls land on different workers sharing the state store
+	state := NewMemoryStateStore()
+	workers := []*SnmpExecutor{NewSnmpExecutor(logger, state), NewSnmpExecutor(logger, state)}
+
+	clock := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, executor := range workers {
+		executor.now = func() time.Time { return clock }
+	}
+
+	agent := startSnmpAgent(t, &gosnmp.GoSNMP{Version: gosnmp.Version2c, Community: "public"})
+	agent.set("1.3.6.1.2.1.2.2.1.14.1", gosnmp.Counter32, uint32(100))
+	agent.set("1.3.6.1.2.1.2.2.1.14.2", gosnmp.Counter32, uint32(math.MaxUint32-4))
+
+	monitor := &Monitor{
+		ID:      "monitor-rates",
+		Name:    "test-monitor",
+		Timeout: 1,
+		Config: fmt.Sprintf(`{
+			"host": "127.0.0.1",
+			"port": %d,
+			"community": "public",
+			"snmp_version": "v2c",
+			"checks": [
+				{"oid": "1.3.6.1.2.1.2.2.1.14", "label": "ifInErrors", "walk": true, "rate": true, "operator": "lt", "value": "1"}
+			]
+		}`, agent.port),
+	}
+
+	polls := []struct {
+		name        string
+		elapsed     time.Duration
+		counters    [2]uint32
+		wantStatus  shared.MonitorStatus
+		wantMessage string
+	}{
+		{
+			name:        "first poll collects the baseline",
+			counters:    [2]uint32{100, math.MaxUint32 - 4},
+			wantStatus:  shared.MonitorStatusUp,
+			wantMessage: "rates are available from the next poll",
+		},
+		{
+			name:        "slow increase and counter wrap",
+			elapsed:     10 * time.Second,
+			counters:    [2]uint32{105, 2},
+			wantStatus:  shared.MonitorStatusUp,
+			wantMessage: "2 SNMP value(s) passed all checks",
+		},
+		{
+			name:        "errors per second above threshold",
+			elapsed:     10 * time.Second,
+			counters:    [2]uint32{155, 2},
+			wantStatus:  shared.MonitorStatusDown,
+			wantMessage: "ifInErrors.1 = 5.00 (expected lt 1)",
+		},
+	}
+
+	for i, poll := range polls {
+		t.Run(poll.name, func(t *testing.T) {
+			clock = clock.Add(poll.elapsed)
+			agent.set("1.3.6.1.2.1.2.2.1.14.1", gosnmp.Counter32, poll.counters[0])
+			agent.set("1.3.6.1.2.1.2.2.1.14.2", gosnmp.Counter32, poll.counters[1])
+
+			result := workers[i%len(workers)].Execute(context.Background(), monitor, nil)
+			if result.Status != poll.wantStatus {
+				t.Errorf("Execute() status = %v, want %v, message: %s", result.Status, poll.wantStatus, result.Message)
+			}
+			if !strings.Contains(result.Message, poll.wantMessage) {
+				t.Errorf("Execute() message = %q, want it to contain %q", result.Message, poll.wantMessage)
+			}
+		})
+	}
+}
+
+func TestCounterRate(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 10, 0, time.UTC)
+	tenSecondsAgo := now.Add(-10 * time.Second)
+
+	tests := []struct {
+		name        string
+		previous    counterSample
+		current     uint64
+		counterType gosnmp.Asn1BER
+		wantRate    float64
+		wantOk      bool
+	}{
+		{"no baseline", counterSample{}, 100, gosnmp.Counter32, 0, false},
+		{"increase", counterSample{Value: 100, At: tenSecondsAgo}, 150, gosnmp.Counter32, 5, true},
+		{"unchanged", counterSample{Value: 100, At: tenSecondsAgo}, 100, gosnmp.Counter64, 0, true},
+		{"counter32 wrap", counterSample{Value: math.MaxUint32 - 9, At: tenSecondsAgo}, 10, gosnmp.Counter32, 2, true},
+		{"counter64 reset", counterSample{Value: 1000, At: tenSecondsAgo}, 10, gosnmp.Counter64, 0, false},
+		{"same poll time", counterSample{Value: 100, At: now}, 150, gosnmp.Counter32, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rate, ok := counterRate(tt.previous, tt.current, tt.counterType, now)
+			if ok != tt.wantOk || rate != tt.wantRate {
+				t.Errorf("counterRate() = %v, %v, want %v, %v", rate, ok, tt.wantRate, tt.wantOk)
+			}
+		})
+	}
+}
+
+// snmpTestAgent is a minimal SNMP agent stand-in on localhost. It answers GET,
+// GETNEXT and GETBULK requests from its MIB, and the engine discovery of SNMPv3
+// clients. Packets that cannot be decoded with its credentials are dropped like a
+// real agent would.
+type snmpTestAgent struct {
+	mu   sync.Mutex
+	mib  map[string]gosnmp.SnmpPDU
+	port uint16
+}
+
+func (a *snmpTestAgent) set(oid string, valueType gosnmp.Asn1BER, value any) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.mib["."+strings.TrimPrefix(oid, ".")] = gosnmp.SnmpPDU{Name: "." + strings.TrimPrefix(oid, "."), Type: valueType, Value: value}
+}
+
+func (a *snmpTestAgent) respond(request *gosnmp.SnmpPacket) []gosnmp.SnmpPDU {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	oids := make([]string, 0, len(a.mib))
+	for oid := range a.mib {
+		oids = append(oids, oid)
+	}
+	sort.Slice(oids, func(i, j int) bool { return compareOids(oids[i], oids[j]) < 0 })
+
+	next := func(oid string, count int) []gosnmp.SnmpPDU {
+		var variables []gosnmp.SnmpPDU
+		for _, candidate := range oids {
+			if len(variables) == count {
+				break
+			}
+			if compareOids(candidate, oid) > 0 {
+				variables = append(variables, a.mib[candidate])
+			}
+		}
+		if len(variables) == 0 {
+			variables = append(variables, gosnmp.SnmpPDU{Name: oid, Type: gosnmp.EndOfMibView})
+		}
+		return variables
+	}
+
+	var variables []gosnmp.SnmpPDU
+	for _, requested := range request.Variables {
+		switch request.PDUType {
+		case gosnmp.GetNextRequest:
+			variables = append(variables, next(requested.Name, 1)...)
+		case gosnmp.GetBulkRequest:
+			variables = append(variables, next(requested.Name, int(request.MaxRepetitions))...)
+		default:
+			if variable, ok := a.mib[requested.Name]; ok {
+				variables = append(variables, variable)
+			} else {
+				variables = append(variables, gosnmp.SnmpPDU{Name: requested.Name, Type: gosnmp.NoSuchObject})
+			}
+		}
+	}
+	return variables
+}
+
+func compareOids(a, b string) int {
+	aParts := strings.Split(strings.Trim(a, "."), ".")
+	bParts := strings.Split(strings.Trim(b, "."), ".")
+	for i := 0; i < len(aParts) && i < len(bParts); i++ {
+		aNum, _ := strconv.Atoi(aParts[i])
+		bNum, _ := strconv.Atoi(bParts[i])
+		if aNum != bNum {
+			return aNum - bNum
+		}
+	}
+	return len(aParts) - len(bParts)
+}
+
+// newSnmpV3Decoder sets up the engine of an SNMPv3 agent stand-in for the given user
+func newSnmpV3Decoder(t *testing.T, usm *gosnmp.UsmSecurityParameters, flags gosnmp.SnmpV3MsgFlags) *gosnmp.GoSNMP {
+	t.Helper()
+
+	usm.AuthoritativeEngineID = "\x80\x00\x1f\x88\x04vigi-test"
+	usm.AuthoritativeEngineBoots = 1
+	usm.AuthoritativeEngineTime = 100
+	if err := usm.InitSecurityKeys(); err != nil {
+		t.Fatalf("failed to init agent keys: %v", err)
+	}
+
+	return &gosnmp.GoSNMP{
+		Version:            gosnmp.Version3,
+		SecurityModel:      gosnmp.UserSecurityModel,
+		MsgFlags:           flags,
+		SecurityParameters: usm,
+	}
+}
+
+// startSnmpAgent starts an agent stand-in that decodes requests with the version
+// and credentials of the decoder
+func startSnmpAgent(t *testing.T, decoder *gosnmp.GoSNMP) *snmpTestAgent {
+	t.Helper()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	agent := &snmpTestAgent{
+		mib:  make(map[string]gosnmp.SnmpPDU),
+		port: uint16(conn.LocalAddr().(*net.UDPAddr).Port),
+	}
+
+	go func() {
+		buf := make([]byte, 65535)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+
+			request, err := decoder.SnmpDecodePacket(append([]byte(nil), buf[:n]...))
+			if err != nil {
+				continue
+			}
+
+			response := &gosnmp.SnmpPacket{
+				Version:   request.Version,
+				Community: request.Community,
+				MsgID:     request.MsgID,
+				RequestID: request.RequestID,
+				PDUType:   gosnmp.GetResponse,
+			}
+
+			if request.Version != gosnmp.Version3 {
+				if request.Community != decoder.Community {
+					continue
+				}
+				response.Variables = agent.respond(request)
+			} else {
+				usm := decoder.SecurityParameters.(*gosnmp.UsmSecurityParameters)
+				requestUsm := request.SecurityParameters.(*gosnmp.UsmSecurityParameters)
+				flags := decoder.MsgFlags & gosnmp.AuthPriv
+
+				response.SecurityModel = gosnmp.UserSecurityModel
+				response.ContextEngineID = usm.AuthoritativeEngineID
+				response.ContextName = request.ContextName
+
+				if requestUsm.UserName == "" {
+					// Engine discovery, report usmStatsUnknownEngineIDs with our engine
+					response.MsgFlags = gosnmp.NoAuthNoPriv
+					response.PDUType = gosnmp.Report
+					response.SecurityParameters = &gosnmp.UsmSecurityParameters{
+						AuthoritativeEngineID:    usm.AuthoritativeEngineID,
+						AuthoritativeEngineBoots: usm.AuthoritativeEngineBoots,
+						AuthoritativeEngineTime:  usm.AuthoritativeEngineTime,
+					}
+					response.Variables = []gosnmp.SnmpPDU{
+						{Name: ".1.3.6.1.6.3.15.1.1.4.0", Type: gosnmp.Counter32, Value: uint32(1)},
+					}
+				} else {
+					if requestUsm.UserName != usm.UserName || request.MsgFlags&gosnmp.AuthPriv != flags {
+						continue
+					}
+					response.MsgFlags = flags
+					response.SecurityParameters = usm.Copy()
+					if err := usm.InitPacket(response); err != nil {
+						continue
+					}
+					response.Variables = agent.respond(request)
+				}
+			}
+
+			out, err := response.MarshalMsg()
+			if err != nil {
+				continue
+			}
+			_, _ = conn.WriteTo(out, addr)
+		}
+	}()
+
+	return agent
+}
@@ -18,6 +18,9 @@ import (
 type SQLServerConfig struct {
 	DatabaseConnectionString string `json:"database_connection_string" validate:"required" example:"Server=localhost,1433;Database=master;User Id=sa;Password=password;Encrypt=false;TrustServerCertificate=true;Connection Timeout=30"`
 	DatabaseQuery            string `json:"database_query" validate:"omitempty" example:"SELECT 1"`
+
+	// Soft limits that report a passing check as degraded
+	DegradedThresholds
 }
 
 type SQLServerExecutor struct {
@@ -333,12 +336,12 @@ func (s *SQLServerExecutor) Execute(ctx context.Context, m *Monitor, proxyModel
 	ping := endTime.Sub(startTime).Milliseconds()
 
 	s.logger.Infof("SQL Server query successful: %s, ping: %dms", m.Name, ping)
-	return &Result{
+	return cfg.Apply(&Result{
 		Status:    shared.MonitorStatusUp,
 		Message:   fmt.Sprintf("Query successful, ping: %dms, columns: %d, rows: %d", ping, len(columns), rowCount),
 		StartTime: startTime,
 		EndTime:   endTime,
-	}
+	})
 }
 
 func (s *SQLServerExecutor) parseConnectionString(connectionString string) (string, error) {
@@ -0,0 +1,87 @@
+package executor
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// StateStore keeps values carried from one check of a monitor to the next.
+// Consecutive checks of a monitor usually run on different workers, so workers
+// share the store through Redis. A probe agent runs every check assigned to it
+// itself and keeps its state in memory.
+type StateStore interface {
+	// Get returns the value stored under key, false when there is none
+	Get(ctx context.Context, key string) (string, bool, error)
+	// Set stores value under key, dropping it once ttl elapsed
+	Set(ctx context.Context, key string, value string, ttl time.Duration) error
+}
+
+const stateKeyPrefix = "vigi:check-state:"
+
+type redisStateStore struct {
+	client *redis.Client
+}
+
+// NewRedisStateStore returns a StateStore shared by every process using client
+func NewRedisStateStore(client *redis.Client) StateStore {
+	return &redisStateStore{client: client}
+}
+
+func (s *redisStateStore) Get(ctx context.Context, key string) (string, bool, error) {
+	value, err := s.client.Get(ctx, stateKeyPrefix+key).Result()
+	if err == redis.Nil {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return value, true, nil
+}
+
+func (s *redisStateStore) Set(ctx context.Context, key string, value string, ttl time.Duration) error {
+	return s.client.Set(ctx, stateKeyPrefix+key, value, ttl).Err()
+}
+
+type memoryState struct {
+	value     string
+	expiresAt time.Time
+}
+
+type memoryStateStore struct {
+	mu      sync.Mutex
+	entries map[string]memoryState
+	now     func() time.Time
+}
+
+// NewMemoryStateStore returns a StateStore local to the process
+func NewMemoryStateStore() StateStore {
+	return &memoryStateStore{entries: make(map[string]memoryState), now: time.Now}
+}
+
+func (s *memoryStateStore) Get(_ context.Context, key string) (string, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.entries[key]
+	if !ok || !s.now().Before(entry.expiresAt) {
+		return "", false, nil
+	}
+	return entry.value, true, nil
+}
+
+func (s *memoryStateStore) Set(_ context.Context, key string, value string, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	for k, entry := range s.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(s.entries, k)
+		}
+	}
+	s.entries[key] = memoryState{value: value, expiresAt: now.Add(ttl)}
+	return nil
+}
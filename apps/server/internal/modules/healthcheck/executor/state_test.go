@@ -0,0 +1,61 @@
+package executor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedisStateStore(t *testing.T) {
+	mr, err := miniredis.Run()
+	require.NoError(t, err)
+	defer mr.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer client.Close()
+
+	ctx := context.Background()
+	worker1, worker2 := NewRedisStateStore(client), NewRedisStateStore(client)
+
+	_, ok, err := worker1.Get(ctx, "monitor-1")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	require.NoError(t, worker1.Set(ctx, "monitor-1", "42", time.Minute))
+
+	value, ok, err := worker2.Get(ctx, "monitor-1")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "42", value)
+
+	mr.FastForward(2 * time.Minute)
+	_, ok, err = worker2.Get(ctx, "monitor-1")
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestMemoryStateStore_Expires(t *testing.T) {
+	clock := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := &memoryStateStore{entries: make(map[string]memoryState), now: func() time.Time { return clock }}
+	ctx := context.Background()
+
+	require.NoError(t, store.Set(ctx, "monitor-1", "42", time.Minute))
+	value, ok, err := store.Get(ctx, "monitor-1")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "42", value)
+
+	clock = clock.Add(2 * time.Minute)
+	_, ok, err = store.Get(ctx, "monitor-1")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	// Expired entries are evicted on the next write
+	require.NoError(t, store.Set(ctx, "monitor-2", "1", time.Minute))
+	assert.NotContains(t, store.entries, "monitor-1")
+}
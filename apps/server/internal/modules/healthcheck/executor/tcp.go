@@ -1,18 +1,52 @@
 package executor
 
 import (
+	"bufio"
 	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
+	"io"
 	"net"
-	"vigi/internal/modules/shared"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
+	"vigi/internal/modules/certificate"
+	"vigi/internal/modules/shared"
 
 	"go.uber.org/zap"
 )
 
+// tcpMaxRead bounds how much is read while waiting for a banner or an expect pattern
+const tcpMaxRead = 64 * 1024
+
 type TCPConfig struct {
 	Host string `json:"host" validate:"required" example:"example.com"`
 	Port int    `json:"port" validate:"required,min=1,max=65535" example:"80"`
+
+	// TLSMode is none, implicit (TLS right after connecting, e.g. SMTPS on 465) or
+	// starttls (the protocol upgrades a plain connection, e.g. SMTP on 587)
+	TLSMode          string `json:"tls_mode,omitempty" validate:"omitempty,oneof=none implicit starttls" example:"none"`
+	StartTLSProtocol string `json:"starttls_protocol,omitempty" validate:"omitempty,oneof=smtp imap pop3 ftp" example:"smtp"`
+	TLSServerName    string `json:"tls_server_name,omitempty" example:"mail.example.com"`
+	IgnoreTlsErrors  bool   `json:"ignore_tls_errors,omitempty" example:"false"`
+	CheckCertExpiry  bool   `json:"check_cert_expiry,omitempty" example:"false"`
+
+	// Banner is a regex the greeting of the server must match
+	Banner string `json:"banner,omitempty" example:"^220 .*ESMTP"`
+	// Script runs after the connection (and TLS) is established
+	Script []TCPScriptStep `json:"script,omitempty" validate:"omitempty,max=20,dive"`
+
+	// Soft limits that report a passing check as degraded
+	DegradedThresholds
+}
+
+// TCPScriptStep writes Send, then reads until Expect matches. Send understands the
+// escapes \r, \n, \t, \\ and \xHH so line protocols can be scripted from the UI.
+type TCPScriptStep struct {
+	Send   string `json:"send,omitempty" example:"EHLO vigi\\r\\n"`
+	Expect string `json:"expect,omitempty" example:"^250 "`
 }
 
 type TCPExecutor struct {
@@ -30,11 +64,60 @@ func (s *TCPExecutor) Unmarshal(configJSON string) (any, error) {
 }
 
 func (s *TCPExecutor) Validate(configJSON string) error {
-	cfg, err := s.Unmarshal(configJSON)
+	cfgAny, err := s.Unmarshal(configJSON)
 	if err != nil {
 		return err
 	}
-	return GenericValidator(cfg.(*TCPConfig))
+	cfg := cfgAny.(*TCPConfig)
+	if err := GenericValidator(cfg); err != nil {
+		return err
+	}
+
+	if cfg.TLSMode == "starttls" && cfg.StartTLSProtocol == "" {
+		return fmt.Errorf("starttls_protocol is required when tls_mode is starttls")
+	}
+	if cfg.TLSMode != "starttls" && cfg.StartTLSProtocol != "" {
+		return fmt.Errorf("starttls_protocol requires tls_mode starttls")
+	}
+	for i, step := range cfg.Script {
+		if step.Send == "" && step.Expect == "" {
+			return fmt.Errorf("script step %d needs send or expect", i+1)
+		}
+		if _, err := unescapeTCPSend(step.Send); err != nil {
+			return fmt.Errorf("script step %d: invalid send: %w", i+1, err)
+		}
+	}
+	_, err = compileTCPPatterns(cfg)
+	return err
+}
+
+// tcpPatterns are the banner and expect patterns of a config, compiled once per
+// check. Steps without an expect pattern have a nil entry.
+type tcpPatterns struct {
+	banner *regexp.Regexp
+	expect []*regexp.Regexp
+}
+
+func compileTCPPatterns(cfg *TCPConfig) (*tcpPatterns, error) {
+	patterns := &tcpPatterns{expect: make([]*regexp.Regexp, len(cfg.Script))}
+	if cfg.Banner != "" {
+		banner, err := regexp.Compile(cfg.Banner)
+		if err != nil {
+			return nil, fmt.Errorf("invalid banner pattern: %w", err)
+		}
+		patterns.banner = banner
+	}
+	for i, step := range cfg.Script {
+		if step.Expect == "" {
+			continue
+		}
+		expect, err := regexp.Compile(step.Expect)
+		if err != nil {
+			return nil, fmt.Errorf("script step %d: invalid expect pattern: %w", i+1, err)
+		}
+		patterns.expect[i] = expect
+	}
+	return patterns, nil
 }
 
 func (t *TCPExecutor) Execute(ctx context.Context, m *Monitor, proxyModel *Proxy) *Result {
@@ -46,37 +129,344 @@ func (t *TCPExecutor) Execute(ctx context.Context, m *Monitor, proxyModel *Proxy
 
 	t.logger.Debugf("execute tcp cfg: %+v", cfg)
 
-	address := fmt.Sprintf("%s:%d", cfg.Host, cfg.Port)
+	// Patterns are checked when the monitor is saved, a config stored before
+	// that still fails the check instead of the worker
+	patterns, err := compileTCPPatterns(cfg)
+	if err != nil {
+		return DownResult(err, time.Now().UTC(), time.Now().UTC())
+	}
+
+	address := net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port))
+	timeout := time.Duration(m.Timeout) * time.Second
 
 	startTime := time.Now().UTC()
 
 	// Create a custom dialer with timeout
 	dialer := &net.Dialer{
-		Timeout: time.Duration(m.Timeout) * time.Second,
+		Timeout: timeout,
 	}
 
 	conn, err := dialer.DialContext(ctx, "tcp", address)
-	endTime := time.Now().UTC()
-
 	if err != nil {
 		t.logger.Infof("TCP connection failed: %s, %s", m.Name, err.Error())
 		return &Result{
 			Status:    shared.MonitorStatusDown,
 			Message:   fmt.Sprintf("TCP connection failed: %v", err),
 			StartTime: startTime,
-			EndTime:   endTime,
+			EndTime:   time.Now().UTC(),
 		}
 	}
+	defer conn.Close()
+
+	// Without a TLS handshake, banner or script connecting is all there is to check
+	if !cfg.usesTLS() && cfg.Banner == "" && len(cfg.Script) == 0 {
+		t.logger.Infof("TCP connection successful: %s", m.Name)
+		return cfg.Apply(&Result{
+			Status:    shared.MonitorStatusUp,
+			Message:   fmt.Sprintf("TCP port %d is open", cfg.Port),
+			StartTime: startTime,
+			EndTime:   time.Now().UTC(),
+		})
+	}
+
+	deadline := time.Now().Add(timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		return DownResult(fmt.Errorf("failed to set connection deadline: %w", err), startTime, time.Now().UTC())
+	}
+
+	session := &tcpSession{conn: conn, reader: bufio.NewReader(conn)}
+	message, tlsInfo, err := t.converse(session, cfg, patterns)
+	endTime := time.Now().UTC()
 
-	// Close the connection immediately as we only need to test connectivity
-	conn.Close()
+	if err != nil {
+		t.logger.Infof("TCP check failed: %s, %s", m.Name, err.Error())
+		return &Result{
+			Status:    shared.MonitorStatusDown,
+			Message:   err.Error(),
+			StartTime: startTime,
+			EndTime:   endTime,
+			TLSInfo:   tlsInfo,
+		}
+	}
 
-	t.logger.Infof("TCP connection successful: %s", m.Name)
+	t.logger.Infof("TCP check successful: %s", m.Name)
 
-	return &Result{
+	return cfg.Apply(&Result{
 		Status:    shared.MonitorStatusUp,
-		Message:   fmt.Sprintf("TCP port %d is open", cfg.Port),
+		Message:   message,
 		StartTime: startTime,
 		EndTime:   endTime,
+		TLSInfo:   tlsInfo,
+	})
+}
+
+func (cfg *TCPConfig) usesTLS() bool {
+	return cfg.TLSMode == "implicit" || cfg.TLSMode == "starttls"
+}
+
+// tcpSession is the connection being checked, replaced by its TLS client once
+// the handshake is done
+type tcpSession struct {
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+// converse runs the TLS handshake, banner check and script, in the order the
+// protocol expects them
+func (t *TCPExecutor) converse(session *tcpSession, cfg *TCPConfig, patterns *tcpPatterns) (string, *certificate.TLSInfo, error) {
+	var tlsInfo *certificate.TLSInfo
+	var details []string
+
+	switch cfg.TLSMode {
+	case "implicit":
+		info, version, err := session.startTLS(cfg)
+		if err != nil {
+			return "", nil, err
+		}
+		tlsInfo = info
+		details = append(details, version)
+
+		if patterns.banner != nil {
+			if err := session.expectBanner(patterns.banner); err != nil {
+				return "", tlsInfo, err
+			}
+		}
+	case "starttls":
+		// The greeting is sent in clear text before the upgrade
+		greeting, err := negotiateStartTLS(session, cfg.StartTLSProtocol)
+		if err != nil {
+			return "", nil, err
+		}
+		if patterns.banner != nil && !patterns.banner.MatchString(greeting) {
+			return "", nil, fmt.Errorf("banner %q does not match %s", truncateTCPOutput(greeting), cfg.Banner)
+		}
+
+		info, version, err := session.startTLS(cfg)
+		if err != nil {
+			return "", nil, err
+		}
+		tlsInfo = info
+		details = append(details, version)
+	default:
+		if patterns.banner != nil {
+			if err := session.expectBanner(patterns.banner); err != nil {
+				return "", nil, err
+			}
+		}
+	}
+
+	if cfg.Banner != "" {
+		details = append(details, "banner matched")
+	}
+
+	for i, step := range cfg.Script {
+		if err := session.runStep(step, patterns.expect[i]); err != nil {
+			return "", tlsInfo, fmt.Errorf("script step %d: %w", i+1, err)
+		}
+	}
+	if len(cfg.Script) > 0 {
+		details = append(details, fmt.Sprintf("%d script step(s) passed", len(cfg.Script)))
+	}
+
+	return fmt.Sprintf("TCP port %d is open, %s", cfg.Port, strings.Join(details, ", ")), tlsInfo, nil
+}
+
+// startTLS upgrades the session and captures the certificate of the server
+func (s *tcpSession) startTLS(cfg *TCPConfig) (*certificate.TLSInfo, string, error) {
+	serverName := cfg.TLSServerName
+	if serverName == "" {
+		serverName = cfg.Host
+	}
+
+	tlsConn := tls.Client(s.conn, &tls.Config{
+		ServerName:         serverName,
+		InsecureSkipVerify: cfg.IgnoreTlsErrors,
+	})
+	if err := tlsConn.Handshake(); err != nil {
+		return nil, "", fmt.Errorf("TLS handshake failed: %v", err)
+	}
+
+	s.conn = tlsConn
+	s.reader = bufio.NewReader(tlsConn)
+
+	state := tlsConn.ConnectionState()
+	return certificate.ExtractCertificateFromTLSConn(tlsConn), tls.VersionName(state.Version), nil
+}
+
+func (s *tcpSession) expectBanner(pattern *regexp.Regexp) error {
+	banner, err := s.readUntil(pattern)
+	if err != nil {
+		return fmt.Errorf("banner %q does not match %s: %v", truncateTCPOutput(banner), pattern, err)
+	}
+	return nil
+}
+
+// runStep sends the payload of the step, then waits for expect when it is not nil
+func (s *tcpSession) runStep(step TCPScriptStep, expect *regexp.Regexp) error {
+	if step.Send != "" {
+		payload, err := unescapeTCPSend(step.Send)
+		if err != nil {
+			return err
+		}
+		if _, err := s.conn.Write(payload); err != nil {
+			return fmt.Errorf("failed to send: %v", err)
+		}
+	}
+
+	if expect != nil {
+		received, err := s.readUntil(expect)
+		if err != nil {
+			return fmt.Errorf("expected %s but got %q: %v", step.Expect, truncateTCPOutput(received), err)
+		}
+	}
+	return nil
+}
+
+// readUntil reads until the data received matches the pattern
+func (s *tcpSession) readUntil(pattern *regexp.Regexp) (string, error) {
+	var received []byte
+	chunk := make([]byte, 4096)
+	for {
+		n, err := s.reader.Read(chunk)
+		received = append(received, chunk[:n]...)
+		if pattern.Match(received) {
+			return string(received), nil
+		}
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return string(received), fmt.Errorf("connection closed by server")
+			}
+			return string(received), err
+		}
+		if len(received) >= tcpMaxRead {
+			return string(received), fmt.Errorf("no match in the first %d bytes", tcpMaxRead)
+		}
+	}
+}
+
+// readReply reads the lines of a reply until last reports the final line
+func (s *tcpSession) readReply(last func(line string) bool) (string, error) {
+	var reply strings.Builder
+	for reply.Len() < tcpMaxRead {
+		line, err := s.reader.ReadString('\n')
+		reply.WriteString(line)
+		if err != nil {
+			return reply.String(), err
+		}
+		if last(strings.TrimRight(line, "\r\n")) {
+			return reply.String(), nil
+		}
+	}
+	return reply.String(), fmt.Errorf("reply is longer than %d bytes", tcpMaxRead)
+}
+
+// negotiateStartTLS reads the greeting and asks the server to upgrade the connection,
+// it returns the greeting
+func negotiateStartTLS(session *tcpSession, protocol string) (string, error) {
+	// SMTP and FTP replies span several lines, "250-" continues and "250 " ends them
+	codeReply := func(line string) bool { return len(line) < 4 || line[3] == ' ' }
+	singleLine := func(string) bool { return true }
+
+	exchange := func(command string, last func(string) bool, success string) error {
+		if _, err := session.conn.Write([]byte(command)); err != nil {
+			return fmt.Errorf("STARTTLS negotiation failed: %v", err)
+		}
+		reply, err := session.readReply(last)
+		if err != nil {
+			return fmt.Errorf("STARTTLS negotiation failed: %v", err)
+		}
+		if !strings.HasPrefix(reply, success) {
+			return fmt.Errorf("server refused %s: %s", strings.TrimSpace(command), strings.TrimSpace(reply))
+		}
+		return nil
+	}
+
+	var greeting string
+	var err error
+	switch protocol {
+	case "smtp", "ftp":
+		greeting, err = session.readReply(codeReply)
+		if err == nil && !strings.HasPrefix(greeting, "220") {
+			err = fmt.Errorf("unexpected greeting")
+		}
+	case "imap":
+		greeting, err = session.readReply(singleLine)
+		if err == nil && !strings.HasPrefix(greeting, "* OK") {
+			err = fmt.Errorf("unexpected greeting")
+		}
+	case "pop3":
+		greeting, err = session.readReply(singleLine)
+		if err == nil && !strings.HasPrefix(greeting, "+OK") {
+			err = fmt.Errorf("unexpected greeting")
+		}
+	default:
+		return "", fmt.Errorf("unsupported STARTTLS protocol: %s", protocol)
+	}
+	if err != nil {
+		return greeting, fmt.Errorf("STARTTLS negotiation failed: %v: %q", err, truncateTCPOutput(greeting))
+	}
+
+	switch protocol {
+	case "smtp":
+		if err := exchange("EHLO vigi\r\n", codeReply, "250"); err != nil {
+			return greeting, err
+		}
+		err = exchange("STARTTLS\r\n", codeReply, "220")
+	case "ftp":
+		err = exchange("AUTH TLS\r\n", codeReply, "234")
+	case "imap":
+		err = exchange("a001 STARTTLS\r\n", func(line string) bool { return strings.HasPrefix(line, "a001 ") }, "a001 OK")
+	case "pop3":
+		err = exchange("STLS\r\n", singleLine, "+OK")
+	}
+	return greeting, err
+}
+
+// unescapeTCPSend decodes the escapes supported in script send values
+func unescapeTCPSend(value string) ([]byte, error) {
+	var out []byte
+	for i := 0; i < len(value); i++ {
+		if value[i] != '\\' {
+			out = append(out, value[i])
+			continue
+		}
+		if i+1 >= len(value) {
+			return nil, fmt.Errorf("trailing backslash")
+		}
+		i++
+		switch value[i] {
+		case 'r':
+			out = append(out, '\r')
+		case 'n':
+			out = append(out, '\n')
+		case 't':
+			out = append(out, '\t')
+		case '\\':
+			out = append(out, '\\')
+		case 'x':
+			if i+2 >= len(value) {
+				return nil, fmt.Errorf("incomplete \\x escape")
+			}
+			b, err := strconv.ParseUint(value[i+1:i+3], 16, 8)
+			if err != nil {
+				return nil, fmt.Errorf("invalid \\x escape %q", value[i-1:i+3])
+			}
+			out = append(out, byte(b))
+			i += 2
+		default:
+			return nil, fmt.Errorf("unknown escape \\%c", value[i])
+		}
+	}
+	return out, nil
+}
+
+func truncateTCPOutput(output string) string {
+	output = strings.TrimSpace(output)
+	if len(output) > 200 {
+		return output[:200] + "..."
 	}
+	return output
 }
@@ -0,0 +1,432 @@
+package executor
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+	"vigi/internal/modules/shared"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestTCPExecutor_Validate(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+	executor := NewTCPExecutor(logger)
+
+	tests := []struct {
+		name          string
+		config        string
+		expectedError bool
+	}{
+		{
+			name:          "plain port",
+			config:        `{"host": "example.com", "port": 80}`,
+			expectedError: false,
+		},
+		{
+			name:          "missing port",
+			config:        `{"host": "example.com"}`,
+			expectedError: true,
+		},
+		{
+			name:          "implicit TLS",
+			config:        `{"host": "smtp.example.com", "port": 465, "tls_mode": "implicit", "check_cert_expiry": true}`,
+			expectedError: false,
+		},
+		{
+			name:          "starttls with protocol",
+			config:        `{"host": "smtp.example.com", "port": 587, "tls_mode": "starttls", "starttls_protocol": "smtp"}`,
+			expectedError: false,
+		},
+		{
+			name:          "starttls without protocol",
+			config:        `{"host": "smtp.example.com", "port": 587, "tls_mode": "starttls"}`,
+			expectedError: true,
+		},
+		{
+			name:          "starttls protocol without starttls",
+			config:        `{"host": "smtp.example.com", "port": 587, "starttls_protocol": "smtp"}`,
+			expectedError: true,
+		},
+		{
+			name:          "unknown starttls protocol",
+			config:        `{"host": "ldap.example.com", "port": 389, "tls_mode": "starttls", "starttls_protocol": "ldap"}`,
+			expectedError: true,
+		},
+		{
+			name:          "invalid banner pattern",
+			config:        `{"host": "example.com", "port": 21, "banner": "^220 ("}`,
+			expectedError: true,
+		},
+		{
+			name:          "script",
+			config:        `{"host": "example.com", "port": 25, "script": [{"expect": "^220"}, {"send": "QUIT\\r\\n", "expect": "^221"}]}`,
+			expectedError: false,
+		},
+		{
+			name:          "empty script step",
+			config:        `{"host": "example.com", "port": 25, "script": [{}]}`,
+			expectedError: true,
+		},
+		{
+			name:          "invalid send escape",
+			config:        `{"host": "example.com", "port": 25, "script": [{"send": "\\q"}]}`,
+			expectedError: true,
+		},
+		{
+			name:          "invalid expect pattern",
+			config:        `{"host": "example.com", "port": 25, "script": [{"expect": "[a-"}]}`,
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := executor.Validate(tt.config)
+			if tt.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestTCPExecutor_Execute(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+	executor := NewTCPExecutor(logger)
+	tlsConfig := testTLSConfig(t)
+
+	smtpPort := startTCPTestServer(t, func(conn net.Conn) {
+		reader := bufio.NewReader(conn)
+		fmt.Fprint(conn, "220 mail.example.test ESMTP ready\r\n")
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			switch strings.TrimSpace(line) {
+			case "EHLO vigi":
+				fmt.Fprint(conn, "250-mail.example.test\r\n250 STARTTLS\r\n")
+			case "QUIT":
+				fmt.Fprint(conn, "221 Bye\r\n")
+				return
+			default:
+				fmt.Fprint(conn, "502 Command not implemented\r\n")
+			}
+		}
+	})
+
+	implicitTLSPort := startTCPTestServer(t, func(conn net.Conn) {
+		tlsConn := tls.Server(conn, tlsConfig)
+		if err := tlsConn.Handshake(); err != nil {
+			return
+		}
+		fmt.Fprint(tlsConn, "* OK IMAP4rev1 ready\r\n")
+		_, _ = bufio.NewReader(tlsConn).ReadString('\n')
+	})
+
+	tests := []struct {
+		name           string
+		config         string
+		expectedStatus shared.MonitorStatus
+		expectMessage  string
+		expectTLSInfo  bool
+	}{
+		{
+			name:           "open port",
+			config:         fmt.Sprintf(`{"host": "127.0.0.1", "port": %d}`, smtpPort),
+			expectedStatus: shared.MonitorStatusUp,
+			expectMessage:  fmt.Sprintf("TCP port %d is open", smtpPort),
+		},
+		{
+			name:           "closed port",
+			config:         fmt.Sprintf(`{"host": "127.0.0.1", "port": %d}`, closedTCPPort(t)),
+			expectedStatus: shared.MonitorStatusDown,
+			expectMessage:  "TCP connection failed",
+		},
+		{
+			name:           "banner matches",
+			config:         fmt.Sprintf(`{"host": "127.0.0.1", "port": %d, "banner": "^220 .*ESMTP"}`, smtpPort),
+			expectedStatus: shared.MonitorStatusUp,
+			expectMessage:  "banner matched",
+		},
+		{
+			name:           "banner does not match",
+			config:         fmt.Sprintf(`{"host": "127.0.0.1", "port": %d, "banner": "^220 .*FTP"}`, smtpPort),
+			expectedStatus: shared.MonitorStatusDown,
+			expectMessage:  `banner "220 mail.example.test ESMTP ready" does not match`,
+		},
+		{
+			name: "script passes",
+			config: fmt.Sprintf(`{"host": "127.0.0.1", "port": %d, "script": [
+				{"expect": "^220 "},
+				{"send": "EHLO vigi\\r\\n", "expect": "250 STARTTLS"},
+				{"send": "QUIT\\r\\n", "expect": "^221"}
+			]}`, smtpPort),
+			expectedStatus: shared.MonitorStatusUp,
+			expectMessage:  "3 script step(s) passed",
+		},
+		{
+			name: "script fails",
+			config: fmt.Sprintf(`{"host": "127.0.0.1", "port": %d, "script": [
+				{"expect": "^220 "},
+				{"send": "VRFY postmaster\\r\\n", "expect": "^250"}
+			]}`, smtpPort),
+			expectedStatus: shared.MonitorStatusDown,
+			expectMessage:  `script step 2: expected ^250 but got "502 Command not implemented"`,
+		},
+		{
+			name: "implicit TLS",
+			config: fmt.Sprintf(`{"host": "127.0.0.1", "port": %d, "tls_mode": "implicit", "ignore_tls_errors": true, "banner": "^\\* OK"}`,
+				implicitTLSPort),
+			expectedStatus: shared.MonitorStatusUp,
+			expectMessage:  "TLS 1.3, banner matched",
+			expectTLSInfo:  true,
+		},
+		{
+			name:           "implicit TLS with untrusted certificate",
+			config:         fmt.Sprintf(`{"host": "127.0.0.1", "port": %d, "tls_mode": "implicit"}`, implicitTLSPort),
+			expectedStatus: shared.MonitorStatusDown,
+			expectMessage:  "TLS handshake failed",
+		},
+		{
+			name:           "implicit TLS on a plain text port",
+			config:         fmt.Sprintf(`{"host": "127.0.0.1", "port": %d, "tls_mode": "implicit", "ignore_tls_errors": true}`, smtpPort),
+			expectedStatus: shared.MonitorStatusDown,
+			expectMessage:  "TLS handshake failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			monitor := &Monitor{
+				ID:      "monitor-tcp",
+				Name:    "Test TCP Monitor",
+				Timeout: 2,
+				Config:  tt.config,
+			}
+			require.NoError(t, executor.Validate(monitor.Config))
+
+			result := executor.Execute(context.Background(), monitor, nil)
+			assert.Equal(t, tt.expectedStatus, result.Status, result.Message)
+			assert.Contains(t, result.Message, tt.expectMessage)
+			if tt.expectTLSInfo {
+				require.NotNil(t, result.TLSInfo)
+				require.NotNil(t, result.TLSInfo.CertInfo)
+				assert.False(t, result.TLSInfo.Valid)
+			} else {
+				assert.Nil(t, result.TLSInfo)
+			}
+		})
+	}
+}
+
+func TestTCPExecutor_Execute_InvalidPattern(t *testing.T) {
+	executor := NewTCPExecutor(zap.NewNop().Sugar())
+
+	// Saved before patterns were validated, the check fails instead of the worker
+	for _, config := range []string{
+		fmt.Sprintf(`{"host": "127.0.0.1", "port": %d, "banner": "^220 ("}`, closedTCPPort(t)),
+		fmt.Sprintf(`{"host": "127.0.0.1", "port": %d, "script": [{"expect": "[a-"}]}`, closedTCPPort(t)),
+	} {
+		result := executor.Execute(context.Background(), &Monitor{Name: "Test TCP Monitor", Timeout: 2, Config: config}, nil)
+		assert.Equal(t, shared.MonitorStatusDown, result.Status)
+		assert.Contains(t, result.Message, "invalid")
+	}
+}
+
+func TestTCPExecutor_Execute_StartTLS(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+	executor := NewTCPExecutor(logger)
+	tlsConfig := testTLSConfig(t)
+
+	// upgrade reads the commands of the client and upgrades the connection when the
+	// STARTTLS command of the protocol is received
+	upgrade := func(greeting string, replies map[string]string, startTLS string, accept string) func(net.Conn) {
+		return func(conn net.Conn) {
+			reader := bufio.NewReader(conn)
+			fmt.Fprint(conn, greeting)
+			for {
+				line, err := reader.ReadString('\n')
+				if err != nil {
+					return
+				}
+				command := strings.TrimSpace(line)
+				if command == startTLS {
+					fmt.Fprint(conn, accept)
+					break
+				}
+				fmt.Fprint(conn, replies[command])
+			}
+
+			tlsConn := tls.Server(conn, tlsConfig)
+			if err := tlsConn.Handshake(); err != nil {
+				return
+			}
+			tlsReader := bufio.NewReader(tlsConn)
+			for {
+				line, err := tlsReader.ReadString('\n')
+				if err != nil {
+					return
+				}
+				fmt.Fprintf(tlsConn, "ECHO %s", line)
+			}
+		}
+	}
+
+	tests := []struct {
+		name           string
+		protocol       string
+		server         func(net.Conn)
+		banner         string
+		expectedStatus shared.MonitorStatus
+		expectMessage  string
+	}{
+		{
+			name:     "smtp",
+			protocol: "smtp",
+			server: upgrade("220-mail.example.test ESMTP\r\n220 ready\r\n",
+				map[string]string{"EHLO vigi": "250-mail.example.test\r\n250-PIPELINING\r\n250 STARTTLS\r\n"},
+				"STARTTLS", "220 Go ahead\r\n"),
+			banner:         "ESMTP",
+			expectedStatus: shared.MonitorStatusUp,
+			expectMessage:  "TLS 1.3, banner matched, 1 script step(s) passed",
+		},
+		{
+			name:           "imap",
+			protocol:       "imap",
+			server:         upgrade("* OK IMAP4rev1 ready\r\n", nil, "a001 STARTTLS", "a001 OK Begin TLS negotiation\r\n"),
+			expectedStatus: shared.MonitorStatusUp,
+			expectMessage:  "TLS 1.3, 1 script step(s) passed",
+		},
+		{
+			name:           "pop3",
+			protocol:       "pop3",
+			server:         upgrade("+OK POP3 ready\r\n", nil, "STLS", "+OK Begin TLS\r\n"),
+			expectedStatus: shared.MonitorStatusUp,
+			expectMessage:  "TLS 1.3",
+		},
+		{
+			name:           "ftp",
+			protocol:       "ftp",
+			server:         upgrade("220 FTP ready\r\n", nil, "AUTH TLS", "234 Proceed\r\n"),
+			expectedStatus: shared.MonitorStatusUp,
+			expectMessage:  "TLS 1.3",
+		},
+		{
+			name:     "smtp server without STARTTLS",
+			protocol: "smtp",
+			server: upgrade("220 mail.example.test ESMTP\r\n",
+				map[string]string{"EHLO vigi": "250 mail.example.test\r\n", "STARTTLS": "502 Not supported\r\n"},
+				"", ""),
+			expectedStatus: shared.MonitorStatusDown,
+			expectMessage:  "server refused STARTTLS: 502 Not supported",
+		},
+		{
+			name:           "wrong greeting",
+			protocol:       "imap",
+			server:         upgrade("220 mail.example.test ESMTP\r\n", nil, "", ""),
+			expectedStatus: shared.MonitorStatusDown,
+			expectMessage:  "STARTTLS negotiation failed: unexpected greeting",
+		},
+		{
+			name:     "banner does not match",
+			protocol: "smtp",
+			server: upgrade("220 mail.example.test ESMTP\r\n",
+				map[string]string{"EHLO vigi": "250 STARTTLS\r\n"}, "STARTTLS", "220 Go ahead\r\n"),
+			banner:         "Postfix",
+			expectedStatus: shared.MonitorStatusDown,
+			expectMessage:  "does not match Postfix",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			port := startTCPTestServer(t, tt.server)
+			monitor := &Monitor{
+				ID:      "monitor-starttls",
+				Name:    "Test TCP Monitor",
+				Timeout: 2,
+				Config: fmt.Sprintf(`{"host": "127.0.0.1", "port": %d, "tls_mode": "starttls", "starttls_protocol": %q,
+					"ignore_tls_errors": true, "banner": %q, "script": [{"send": "NOOP\\r\\n", "expect": "ECHO NOOP"}]}`,
+					port, tt.protocol, tt.banner),
+			}
+			require.NoError(t, executor.Validate(monitor.Config))
+
+			result := executor.Execute(context.Background(), monitor, nil)
+			assert.Equal(t, tt.expectedStatus, result.Status, result.Message)
+			assert.Contains(t, result.Message, tt.expectMessage)
+			if tt.expectedStatus == shared.MonitorStatusUp {
+				assert.NotNil(t, result.TLSInfo)
+			}
+		})
+	}
+}
+
+func TestUnescapeTCPSend(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		wantErr  bool
+	}{
+		{input: "QUIT\\r\\n", expected: "QUIT\r\n"},
+		{input: "a\\tb\\\\c", expected: "a\tb\\c"},
+		{input: "\\x00\\x1b[0m", expected: "\x00\x1b[0m"},
+		{input: "plain", expected: "plain"},
+		{input: "\\x4", wantErr: true},
+		{input: "\\xzz", wantErr: true},
+		{input: "\\q", wantErr: true},
+		{input: "end\\", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := unescapeTCPSend(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, string(got))
+		})
+	}
+}
+
+// startTCPTestServer accepts connections on a local port and serves each with handle
+func startTCPTestServer(t *testing.T, handle func(net.Conn)) int {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				handle(conn)
+			}()
+		}
+	}()
+	return listener.Addr().(*net.TCPAddr).Port
+}
+
+// closedTCPPort returns a local port nothing listens on
+func closedTCPPort(t *testing.T) int {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := listener.Addr().(*net.TCPAddr).Port
+	require.NoError(t, listener.Close())
+	return port
+}
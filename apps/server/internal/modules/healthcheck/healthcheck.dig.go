@@ -9,5 +9,6 @@ import (
 func RegisterDependencies(container *dig.Container) {
 	container.Provide(NewHealthCheck)
 	container.Provide(NewEventListener)
+	container.Provide(executor.NewRedisStateStore)
 	container.Provide(executor.NewExecutorRegistry)
 }
@@ -3,8 +3,11 @@ package healthcheck
 import (
 	"fmt"
 	"net/http"
+	"vigi/internal/modules/api_key"
 	"vigi/internal/modules/heartbeat"
+	"vigi/internal/modules/middleware"
 	"vigi/internal/modules/monitor"
+	"vigi/internal/modules/organization"
 	"vigi/internal/modules/queue"
 	"vigi/internal/utils"
 	"strconv"
@@ -53,6 +56,8 @@ func RegisterPushEndpoint(
 	monitorService monitor.Service,
 	heartbeatService heartbeat.Service,
 	queueService queue.Service,
+	authChain *middleware.AuthChain,
+	orgMiddleware *organization.Middleware,
 	logger *zap.SugaredLogger,
 ) {
 	router.GET("/push/:token", func(ctx *gin.Context) {
@@ -71,67 +76,91 @@ func RegisterPushEndpoint(
 			ctx.JSON(http.StatusNotFound, utils.NewFailResponse("Monitor not found for pushToken"))
 			return
 		}
-		if !monitor.Active {
-			logger.Errorw("Monitor is not active", "monitor", monitor)
-			ctx.JSON(http.StatusBadRequest, utils.NewFailResponse("Monitor is not active"))
-			return
-		}
 
-		msg := ctx.DefaultQuery("msg", "OK")
-		statusStr := ctx.DefaultQuery("status", "1")
+		pushHeartbeat(ctx, monitor, queueService, logger)
+	})
 
-		// Parse status
-		statusInt, err := strconv.Atoi(statusStr)
+	// Same as the token endpoint, for callers holding an API key with the push scope
+	keyed := router.Group("monitors")
+	keyed.Use(authChain.AllAuth(api_key.ScopePush))
+	keyed.Use(orgMiddleware.RequireOrganization())
+	keyed.POST(":id/push", func(ctx *gin.Context) {
+		monitor, err := monitorService.FindByID(ctx, ctx.Param("id"), ctx.GetString("orgId"))
 		if err != nil {
-			statusInt = 1
-		}
-		status := shared.MonitorStatus(statusInt)
-
-		now := time.Now().UTC()
-
-		// Enqueue to ingester instead of processing directly
-		payload := PushIngesterPayload{
-			MonitorID:          monitor.ID,
-			MonitorName:        monitor.Name,
-			MonitorType:        monitor.Type,
-			MonitorInterval:    monitor.Interval,
-			MonitorTimeout:     monitor.Timeout,
-			MonitorMaxRetries:  monitor.MaxRetries,
-			MonitorRetryInt:    monitor.RetryInterval,
-			MonitorResendInt:   monitor.ResendInterval,
-			MonitorConfig:      monitor.Config,
-			Status:             status,
-			Message:            msg,
-			PingMs:             0, // Push monitors don't have meaningful ping times
-			StartTime:          now,
-			EndTime:            now,
-			IsUnderMaintenance: false, // Push monitors don't have maintenance windows in the same way
-			TLSInfo:            nil,
-			CheckCertExpiry:    false,
-		}
-
-		opts := &queue.EnqueueOptions{
-			Queue:     "ingester",
-			MaxRetry:  3,
-			Timeout:   2 * time.Minute,
-			Retention: 1 * time.Hour,
+			logger.Errorw("Failed to find monitor to push", "monitorId", ctx.Param("id"), "error", err)
+			ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse("Failed to find monitor"))
+			return
 		}
-
-		// Use EnqueueUnique to prevent duplicate push heartbeat ingestion
-		// The unique key includes monitor ID and timestamp to prevent duplicate submissions
-		uniqueKey := fmt.Sprintf("ingest:push:%s:%d", monitor.ID, now.UnixNano())
-		ttl := 5 * time.Minute // Short TTL for push monitors to allow frequent updates
-
-		_, err = queueService.EnqueueUnique(ctx, "monitor:ingest", payload, uniqueKey, ttl, opts)
-		if err != nil {
-			logger.Errorw("Failed to enqueue push heartbeat to ingester",
-				"monitor_id", monitor.ID,
-				"error", err,
-			)
-			ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse("Failed to process push heartbeat"))
+		if monitor == nil || monitor.Type != "push" {
+			ctx.JSON(http.StatusNotFound, utils.NewFailResponse("Push monitor not found"))
 			return
 		}
 
-		ctx.JSON(http.StatusOK, gin.H{"ok": "true"})
+		pushHeartbeat(ctx, monitor, queueService, logger)
 	})
 }
+
+func pushHeartbeat(ctx *gin.Context, monitor *monitor.Model, queueService queue.Service, logger *zap.SugaredLogger) {
+	if !monitor.Active {
+		logger.Errorw("Monitor is not active", "monitor", monitor)
+		ctx.JSON(http.StatusBadRequest, utils.NewFailResponse("Monitor is not active"))
+		return
+	}
+
+	msg := ctx.DefaultQuery("msg", "OK")
+	statusStr := ctx.DefaultQuery("status", "1")
+
+	// Parse status
+	statusInt, err := strconv.Atoi(statusStr)
+	if err != nil {
+		statusInt = 1
+	}
+	status := shared.MonitorStatus(statusInt)
+
+	now := time.Now().UTC()
+
+	// Enqueue to ingester instead of processing directly
+	payload := PushIngesterPayload{
+		MonitorID:          monitor.ID,
+		MonitorName:        monitor.Name,
+		MonitorType:        monitor.Type,
+		MonitorInterval:    monitor.Interval,
+		MonitorTimeout:     monitor.Timeout,
+		MonitorMaxRetries:  monitor.MaxRetries,
+		MonitorRetryInt:    monitor.RetryInterval,
+		MonitorResendInt:   monitor.ResendInterval,
+		MonitorConfig:      monitor.Config,
+		Status:             status,
+		Message:            msg,
+		PingMs:             0, // Push monitors don't have meaningful ping times
+		StartTime:          now,
+		EndTime:            now,
+		IsUnderMaintenance: false, // Push monitors don't have maintenance windows in the same way
+		TLSInfo:            nil,
+		CheckCertExpiry:    false,
+	}
+
+	opts := &queue.EnqueueOptions{
+		Queue:     "ingester",
+		MaxRetry:  3,
+		Timeout:   2 * time.Minute,
+		Retention: 1 * time.Hour,
+	}
+
+	// Use EnqueueUnique to prevent duplicate push heartbeat ingestion
+	// The unique key includes monitor ID and timestamp to prevent duplicate submissions
+	uniqueKey := fmt.Sprintf("ingest:push:%s:%d", monitor.ID, now.UnixNano())
+	ttl := 5 * time.Minute // Short TTL for push monitors to allow frequent updates
+
+	_, err = queueService.EnqueueUnique(ctx, "monitor:ingest", payload, uniqueKey, ttl, opts)
+	if err != nil {
+		logger.Errorw("Failed to enqueue push heartbeat to ingester",
+			"monitor_id", monitor.ID,
+			"error", err,
+		)
+		ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse("Failed to process push heartbeat"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"ok": "true"})
+}
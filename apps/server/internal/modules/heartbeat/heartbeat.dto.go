@@ -16,4 +16,6 @@ type CreateUpdateDto struct {
 	Time      time.Time     `json:"time"`
 	EndTime   time.Time     `json:"end_time"`
 	Notified  bool          `json:"notified"`
+	Flapping  bool          `json:"flapping"`
+	ProbeID   string        `json:"probe_id,omitempty"`
 }
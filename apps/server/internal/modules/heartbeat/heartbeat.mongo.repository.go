@@ -26,6 +26,8 @@ type mongoModel struct {
 	Time      time.Time          `bson:"time"`
 	EndTime   time.Time          `bson:"end_time"`
 	Notified  bool               `bson:"notified"`
+	Flapping  bool               `bson:"flapping"`
+	ProbeID   string             `bson:"probe_id,omitempty"`
 }
 
 type RepositoryImpl struct {
@@ -48,6 +50,8 @@ func toDomainModel(mm *mongoModel) *Model {
 		Time:      mm.Time,
 		EndTime:   mm.EndTime,
 		Notified:  mm.Notified,
+		Flapping:  mm.Flapping,
+		ProbeID:   mm.ProbeID,
 	}
 }
 
@@ -86,6 +90,8 @@ func (r *RepositoryImpl) Create(ctx context.Context, entity *Model) (*Model, err
 		Time:      entity.Time,
 		EndTime:   entity.EndTime,
 		Notified:  entity.Notified,
+		Flapping:  entity.Flapping,
+		ProbeID:   entity.ProbeID,
 	}
 
 	_, err = r.collection.InsertOne(ctx, mm)
@@ -321,3 +327,65 @@ func (r *RepositoryImpl) DeleteByMonitorID(ctx context.Context, monitorID string
 	_, err = r.collection.DeleteMany(ctx, filter)
 	return err
 }
+
+func (r *RepositoryImpl) FindOldestByMonitorID(ctx context.Context, monitorID string) (*Model, error) {
+	objectID, err := primitive.ObjectIDFromHex(monitorID)
+	if err != nil {
+		return nil, err
+	}
+
+	var mm mongoModel
+	opts := options.FindOne().SetSort(bson.M{"time": 1})
+	err = r.collection.FindOne(ctx, bson.M{"monitor_id": objectID}, opts).Decode(&mm)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toDomainModel(&mm), nil
+}
+
+func (r *RepositoryImpl) FindByMonitorIDAndTimeRange(ctx context.Context, monitorID string, since, until time.Time) ([]*Model, error) {
+	objectID, err := primitive.ObjectIDFromHex(monitorID)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := bson.M{
+		"monitor_id": objectID,
+		"time":       bson.M{"$gte": since, "$lt": until},
+	}
+	cursor, err := r.collection.Find(ctx, filter, options.Find().SetSort(bson.M{"time": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	models := make([]*Model, 0)
+	for cursor.Next(ctx) {
+		var mm mongoModel
+		if err := cursor.Decode(&mm); err != nil {
+			return nil, err
+		}
+		models = append(models, toDomainModel(&mm))
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+	return models, nil
+}
+
+func (r *RepositoryImpl) DeleteByMonitorIDOlderThan(ctx context.Context, monitorID string, cutoff time.Time) (int64, error) {
+	objectID, err := primitive.ObjectIDFromHex(monitorID)
+	if err != nil {
+		return 0, err
+	}
+
+	filter := bson.M{"monitor_id": objectID, "time": bson.M{"$lt": cutoff}}
+	result, err := r.collection.DeleteMany(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
@@ -28,4 +28,8 @@ type Repository interface {
 	) (map[string]float64, error)
 	DeleteOlderThan(ctx context.Context, cutoff time.Time) (int64, error)
 	DeleteByMonitorID(ctx context.Context, monitorID string) error
+
+	FindOldestByMonitorID(ctx context.Context, monitorID string) (*Model, error)
+	FindByMonitorIDAndTimeRange(ctx context.Context, monitorID string, since, until time.Time) ([]*Model, error)
+	DeleteByMonitorIDOlderThan(ctx context.Context, monitorID string, cutoff time.Time) (int64, error)
 }
@@ -18,6 +18,12 @@ type Service interface {
 	DeleteOlderThan(ctx context.Context, cutoff time.Time) (int64, error)
 	FindByMonitorIDPaginated(ctx context.Context, monitorID string, limit, page int, important *bool, reverse bool) ([]*Model, error)
 	DeleteByMonitorID(ctx context.Context, monitorID string) error
+
+	// FindOldestByMonitorID returns the first heartbeat of the monitor still stored, nil when there is none
+	FindOldestByMonitorID(ctx context.Context, monitorID string) (*Model, error)
+	// FindByMonitorIDAndTimeRange returns the monitor heartbeats in [since, until) in chronological order
+	FindByMonitorIDAndTimeRange(ctx context.Context, monitorID string, since, until time.Time) ([]*Model, error)
+	DeleteByMonitorIDOlderThan(ctx context.Context, monitorID string, cutoff time.Time) (int64, error)
 }
 
 type ServiceImpl struct {
@@ -51,6 +57,8 @@ func (mr *ServiceImpl) Create(ctx context.Context, entity *CreateUpdateDto) (*Mo
 		Time:      entity.Time,
 		EndTime:   entity.EndTime,
 		Notified:  entity.Notified,
+		Flapping:  entity.Flapping,
+		ProbeID:   entity.ProbeID,
 	}
 
 	created, err := mr.repository.Create(ctx, createModel)
@@ -102,3 +110,15 @@ func (mr *ServiceImpl) FindByMonitorIDPaginated(ctx context.Context, monitorID s
 func (mr *ServiceImpl) DeleteByMonitorID(ctx context.Context, monitorID string) error {
 	return mr.repository.DeleteByMonitorID(ctx, monitorID)
 }
+
+func (mr *ServiceImpl) FindOldestByMonitorID(ctx context.Context, monitorID string) (*Model, error) {
+	return mr.repository.FindOldestByMonitorID(ctx, monitorID)
+}
+
+func (mr *ServiceImpl) FindByMonitorIDAndTimeRange(ctx context.Context, monitorID string, since, until time.Time) ([]*Model, error) {
+	return mr.repository.FindByMonitorIDAndTimeRange(ctx, monitorID, since, until)
+}
+
+func (mr *ServiceImpl) DeleteByMonitorIDOlderThan(ctx context.Context, monitorID string, cutoff time.Time) (int64, error) {
+	return mr.repository.DeleteByMonitorIDOlderThan(ctx, monitorID, cutoff)
+}
@@ -2,6 +2,8 @@ package heartbeat
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"time"
 
 	"vigi/internal/modules/shared"
@@ -25,6 +27,8 @@ type sqlModel struct {
 	Time      time.Time `bun:"time,nullzero,notnull,default:current_timestamp"`
 	EndTime   time.Time `bun:"end_time,nullzero"`
 	Notified  bool      `bun:"notified,notnull,default:false"`
+	Flapping  bool      `bun:"flapping,notnull,default:false"`
+	ProbeID   *string   `bun:"probe_id"`
 }
 
 func toDomainModelFromSQL(sm *sqlModel) *Model {
@@ -41,6 +45,8 @@ func toDomainModelFromSQL(sm *sqlModel) *Model {
 		Time:      sm.Time,
 		EndTime:   sm.EndTime,
 		Notified:  sm.Notified,
+		Flapping:  sm.Flapping,
+		ProbeID:   getStringFromPointer(sm.ProbeID),
 	}
 }
 
@@ -58,9 +64,25 @@ func toSQLModel(m *Model) *sqlModel {
 		Time:      m.Time,
 		EndTime:   m.EndTime,
 		Notified:  m.Notified,
+		Flapping:  m.Flapping,
+		ProbeID:   getPointerFromString(m.ProbeID),
 	}
 }
 
+func getStringFromPointer(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func getPointerFromString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
 type SQLRepositoryImpl struct {
 	db *bun.DB
 }
@@ -233,3 +255,51 @@ func (r *SQLRepositoryImpl) DeleteByMonitorID(ctx context.Context, monitorID str
 		Exec(ctx)
 	return err
 }
+
+func (r *SQLRepositoryImpl) FindOldestByMonitorID(ctx context.Context, monitorID string) (*Model, error) {
+	sm := new(sqlModel)
+	err := r.db.NewSelect().
+		Model(sm).
+		Where("monitor_id = ?", monitorID).
+		Order("time ASC").
+		Limit(1).
+		Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toDomainModelFromSQL(sm), nil
+}
+
+func (r *SQLRepositoryImpl) FindByMonitorIDAndTimeRange(ctx context.Context, monitorID string, since, until time.Time) ([]*Model, error) {
+	var sms []*sqlModel
+	err := r.db.NewSelect().
+		Model(&sms).
+		Where("monitor_id = ? AND time >= ? AND time < ?", monitorID, since, until).
+		Order("time ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	models := make([]*Model, 0, len(sms))
+	for _, sm := range sms {
+		models = append(models, toDomainModelFromSQL(sm))
+	}
+	return models, nil
+}
+
+func (r *SQLRepositoryImpl) DeleteByMonitorIDOlderThan(ctx context.Context, monitorID string, cutoff time.Time) (int64, error) {
+	result, err := r.db.NewDelete().
+		Model((*sqlModel)(nil)).
+		Where("monitor_id = ? AND time < ?", monitorID, cutoff).
+		Exec(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	return rowsAffected, nil
+}
@@ -0,0 +1,235 @@
+package incident
+
+import (
+	"errors"
+	"net/http"
+	"vigi/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type Controller struct {
+	service Service
+	logger  *zap.SugaredLogger
+}
+
+func NewController(
+	service Service,
+	logger *zap.SugaredLogger,
+) *Controller {
+	return &Controller{
+		service: service,
+		logger:  logger.Named("[incident-controller]"),
+	}
+}
+
+// @Router    /incidents [get]
+// @Summary   Get incidents
+// @Tags      Incidents
+// @Produce   json
+// @Security  JwtAuth
+// @Security  ApiKeyAuth
+// @Param     state query    string  false  "Filter by state"
+// @Param     page  query    int     false  "Page number" default(0)
+// @Param     limit query    int     false  "Items per page" default(10)
+// @Success   200  {object}  utils.ApiResponse[[]Model]
+// @Failure   400  {object}  utils.APIError[any]
+// @Failure   500  {object}  utils.APIError[any]
+func (c *Controller) FindAll(ctx *gin.Context) {
+	page, err := utils.GetQueryInt(ctx, "page", 0)
+	if err != nil || page < 0 {
+		ctx.JSON(http.StatusBadRequest, utils.NewFailResponse("Invalid page parameter"))
+		return
+	}
+	limit, err := utils.GetQueryInt(ctx, "limit", 10)
+	if err != nil || limit < 1 {
+		ctx.JSON(http.StatusBadRequest, utils.NewFailResponse("Invalid limit parameter"))
+		return
+	}
+	state := ctx.Query("state")
+
+	orgID := ctx.GetString("orgId")
+
+	incidents, err := c.service.FindAll(ctx, page, limit, state, orgID)
+	if err != nil {
+		c.logger.Errorw("Failed to get incidents", "error", err)
+		ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse("Internal server error"))
+		return
+	}
+	ctx.JSON(http.StatusOK, utils.NewSuccessResponse("success", incidents))
+}
+
+// @Router    /incidents [post]
+// @Summary   Open an incident
+// @Tags      Incidents
+// @Accept    json
+// @Produce   json
+// @Security  JwtAuth
+// @Security  ApiKeyAuth
+// @Param     body body CreateIncidentDTO true "Incident object"
+// @Success   201  {object} utils.ApiResponse[Model]
+// @Failure   400  {object} utils.APIError[any]
+// @Failure   500  {object} utils.APIError[any]
+func (c *Controller) Create(ctx *gin.Context) {
+	var dto CreateIncidentDTO
+	if err := ctx.ShouldBindJSON(&dto); err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.NewFailResponse(err.Error()))
+		return
+	}
+
+	if err := utils.Validate.Struct(&dto); err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.NewFailResponse(err.Error()))
+		return
+	}
+
+	orgID := ctx.GetString("orgId")
+
+	created, err := c.service.Create(ctx, &dto, orgID)
+	if errors.Is(err, ErrMonitorNotFound) {
+		ctx.JSON(http.StatusBadRequest, utils.NewFailResponse(err.Error()))
+		return
+	}
+	if err != nil {
+		c.logger.Errorw("Failed to create incident", "error", err)
+		ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse("Internal server error"))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, utils.NewSuccessResponse("Incident created successfully", created))
+}
+
+// @Router    /incidents/{id} [get]
+// @Summary   Get an incident by ID
+// @Tags      Incidents
+// @Produce   json
+// @Security  JwtAuth
+// @Security  ApiKeyAuth
+// @Param     id   path      string  true  "Incident ID"
+// @Success   200  {object}  utils.ApiResponse[Model]
+// @Failure   404  {object}  utils.APIError[any]
+// @Failure   500  {object}  utils.APIError[any]
+func (c *Controller) FindByID(ctx *gin.Context) {
+	id := ctx.Param("id")
+	orgID := ctx.GetString("orgId")
+
+	incident, err := c.service.FindByID(ctx, id, orgID)
+	if err != nil {
+		c.logger.Errorw("Failed to get incident by id", "error", err, "id", id)
+		ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse("Internal server error"))
+		return
+	}
+	if incident == nil {
+		ctx.JSON(http.StatusNotFound, utils.NewFailResponse("Incident not found"))
+		return
+	}
+	ctx.JSON(http.StatusOK, utils.NewSuccessResponse("success", incident))
+}
+
+// @Router    /incidents/{id} [patch]
+// @Summary   Update an incident
+// @Tags      Incidents
+// @Accept    json
+// @Produce   json
+// @Security  JwtAuth
+// @Security  ApiKeyAuth
+// @Param     id   path      string  true  "Incident ID"
+// @Param     body body UpdateIncidentDTO true "Incident object"
+// @Success   200  {object}  utils.ApiResponse[Model]
+// @Failure   400  {object}  utils.APIError[any]
+// @Failure   404  {object}  utils.APIError[any]
+// @Failure   500  {object}  utils.APIError[any]
+func (c *Controller) Update(ctx *gin.Context) {
+	id := ctx.Param("id")
+	var dto UpdateIncidentDTO
+	if err := ctx.ShouldBindJSON(&dto); err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.NewFailResponse(err.Error()))
+		return
+	}
+
+	if err := utils.Validate.Struct(&dto); err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.NewFailResponse(err.Error()))
+		return
+	}
+
+	orgID := ctx.GetString("orgId")
+
+	updated, err := c.service.Update(ctx, id, &dto, orgID)
+	if errors.Is(err, ErrMonitorNotFound) {
+		ctx.JSON(http.StatusBadRequest, utils.NewFailResponse(err.Error()))
+		return
+	}
+	if err != nil {
+		c.logger.Errorw("Failed to update incident", "error", err, "id", id)
+		ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse("Internal server error"))
+		return
+	}
+	if updated == nil {
+		ctx.JSON(http.StatusNotFound, utils.NewFailResponse("Incident not found"))
+		return
+	}
+	ctx.JSON(http.StatusOK, utils.NewSuccessResponse("Incident updated successfully", updated))
+}
+
+// @Router    /incidents/{id}/updates [post]
+// @Summary   Post an incident update
+// @Description Adds a timeline update and moves the incident to the given state
+// @Tags      Incidents
+// @Accept    json
+// @Produce   json
+// @Security  JwtAuth
+// @Security  ApiKeyAuth
+// @Param     id   path      string  true  "Incident ID"
+// @Param     body body CreateIncidentUpdateDTO true "Update object"
+// @Success   201  {object}  utils.ApiResponse[Model]
+// @Failure   400  {object}  utils.APIError[any]
+// @Failure   404  {object}  utils.APIError[any]
+// @Failure   500  {object}  utils.APIError[any]
+func (c *Controller) AddUpdate(ctx *gin.Context) {
+	id := ctx.Param("id")
+	var dto CreateIncidentUpdateDTO
+	if err := ctx.ShouldBindJSON(&dto); err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.NewFailResponse(err.Error()))
+		return
+	}
+
+	if err := utils.Validate.Struct(&dto); err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.NewFailResponse(err.Error()))
+		return
+	}
+
+	orgID := ctx.GetString("orgId")
+
+	updated, err := c.service.AddUpdate(ctx, id, &dto, orgID)
+	if err != nil {
+		c.logger.Errorw("Failed to add incident update", "error", err, "id", id)
+		ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse("Internal server error"))
+		return
+	}
+	if updated == nil {
+		ctx.JSON(http.StatusNotFound, utils.NewFailResponse("Incident not found"))
+		return
+	}
+	ctx.JSON(http.StatusCreated, utils.NewSuccessResponse("Incident update posted successfully", updated))
+}
+
+// @Router    /incidents/{id} [delete]
+// @Summary   Delete an incident
+// @Tags      Incidents
+// @Produce   json
+// @Security  JwtAuth
+// @Security  ApiKeyAuth
+// @Param     id   path      string  true  "Incident ID"
+// @Success   200  {object}  utils.ApiResponse[any]
+// @Failure   500  {object}  utils.APIError[any]
+func (c *Controller) Delete(ctx *gin.Context) {
+	id := ctx.Param("id")
+	orgID := ctx.GetString("orgId")
+
+	if err := c.service.Delete(ctx, id, orgID); err != nil {
+		c.logger.Errorw("Failed to delete incident", "error", err, "id", id)
+		ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse("Internal server error"))
+		return
+	}
+	ctx.JSON(http.StatusOK, utils.NewSuccessResponse[any]("Incident deleted successfully", nil))
+}
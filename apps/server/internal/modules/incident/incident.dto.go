@@ -0,0 +1,20 @@
+package incident
+
+type CreateIncidentDTO struct {
+	Title      string   `json:"title" validate:"required,min=3"`
+	Severity   string   `json:"severity" validate:"required,oneof=minor major critical"`
+	State      string   `json:"state" validate:"omitempty,oneof=investigating identified monitoring resolved"`
+	Message    string   `json:"message" validate:"required"`
+	MonitorIDs []string `json:"monitor_ids" validate:"required,min=1"`
+}
+
+type UpdateIncidentDTO struct {
+	Title      *string   `json:"title,omitempty" validate:"omitempty,min=3"`
+	Severity   *string   `json:"severity,omitempty" validate:"omitempty,oneof=minor major critical"`
+	MonitorIDs *[]string `json:"monitor_ids,omitempty" validate:"omitempty,min=1"`
+}
+
+type CreateIncidentUpdateDTO struct {
+	State   string `json:"state" validate:"required,oneof=investigating identified monitoring resolved"`
+	Message string `json:"message" validate:"required"`
+}
@@ -0,0 +1,49 @@
+package incident
+
+import "time"
+
+const (
+	SeverityMinor    = "minor"
+	SeverityMajor    = "major"
+	SeverityCritical = "critical"
+)
+
+const (
+	StateInvestigating = "investigating"
+	StateIdentified    = "identified"
+	StateMonitoring    = "monitoring"
+	StateResolved      = "resolved"
+)
+
+type Model struct {
+	ID          string            `json:"id"`
+	OrgID       string            `json:"org_id"`
+	Title       string            `json:"title"`
+	Severity    string            `json:"severity"`
+	State       string            `json:"state"`
+	AutoCreated bool              `json:"auto_created"`
+	MonitorIDs  []string          `json:"monitor_ids"`
+	Updates     []*IncidentUpdate `json:"updates"`
+	StartedAt   time.Time         `json:"started_at"`
+	ResolvedAt  *time.Time        `json:"resolved_at,omitempty"`
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+}
+
+// UpdateModel holds the fields of a partial incident update. ResolvedAt is written
+// whenever State is set, so reopening an incident clears it.
+type UpdateModel struct {
+	Title      *string
+	Severity   *string
+	State      *string
+	ResolvedAt *time.Time
+}
+
+// IncidentUpdate is a timestamped entry on the incident timeline
+type IncidentUpdate struct {
+	ID         string    `json:"id"`
+	IncidentID string    `json:"incident_id"`
+	State      string    `json:"state"`
+	Message    string    `json:"message"`
+	CreatedAt  time.Time `json:"created_at"`
+}
@@ -0,0 +1,335 @@
+package incident
+
+import (
+	"context"
+	"errors"
+	"time"
+	"vigi/internal/config"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoModel struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty"`
+	OrgID       string             `bson:"org_id"`
+	Title       string             `bson:"title"`
+	Severity    string             `bson:"severity"`
+	State       string             `bson:"state"`
+	AutoCreated bool               `bson:"auto_created"`
+	MonitorIDs  []string           `bson:"monitor_ids"`
+	StartedAt   time.Time          `bson:"started_at"`
+	ResolvedAt  *time.Time         `bson:"resolved_at"`
+	CreatedAt   time.Time          `bson:"created_at"`
+	UpdatedAt   time.Time          `bson:"updated_at"`
+	// OpenAutoMonitorID is unset on resolution, see openAutoMonitorID
+	OpenAutoMonitorID string `bson:"open_auto_monitor_id,omitempty"`
+}
+
+type mongoIncidentUpdate struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty"`
+	IncidentID string             `bson:"incident_id"`
+	State      string             `bson:"state"`
+	Message    string             `bson:"message"`
+	CreatedAt  time.Time          `bson:"created_at"`
+}
+
+func toDomainModel(mm *mongoModel) *Model {
+	monitorIDs := mm.MonitorIDs
+	if monitorIDs == nil {
+		monitorIDs = []string{}
+	}
+
+	return &Model{
+		ID:          mm.ID.Hex(),
+		OrgID:       mm.OrgID,
+		Title:       mm.Title,
+		Severity:    mm.Severity,
+		State:       mm.State,
+		AutoCreated: mm.AutoCreated,
+		MonitorIDs:  monitorIDs,
+		StartedAt:   mm.StartedAt,
+		ResolvedAt:  mm.ResolvedAt,
+		CreatedAt:   mm.CreatedAt,
+		UpdatedAt:   mm.UpdatedAt,
+	}
+}
+
+type MongoRepository struct {
+	client            *mongo.Client
+	db                *mongo.Database
+	collection        *mongo.Collection
+	updatesCollection *mongo.Collection
+}
+
+func NewMongoRepository(client *mongo.Client, cfg *config.Config) Repository {
+	db := client.Database(cfg.DBName)
+	collection := db.Collection("incidents")
+	updatesCollection := db.Collection("incident_updates")
+
+	// Create indexes
+	go func() {
+		_, _ = collection.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+			{Keys: bson.D{{Key: "org_id", Value: 1}, {Key: "state", Value: 1}}},
+			{Keys: bson.D{{Key: "monitor_ids", Value: 1}}},
+			{
+				Keys: bson.D{{Key: "open_auto_monitor_id", Value: 1}},
+				Options: options.Index().
+					SetUnique(true).
+					SetPartialFilterExpression(bson.M{"open_auto_monitor_id": bson.M{"$exists": true}}),
+			},
+		})
+		_, _ = updatesCollection.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+			Keys: bson.D{{Key: "incident_id", Value: 1}, {Key: "created_at", Value: -1}},
+		})
+	}()
+
+	return &MongoRepository{
+		client:            client,
+		db:                db,
+		collection:        collection,
+		updatesCollection: updatesCollection,
+	}
+}
+
+func (r *MongoRepository) Create(ctx context.Context, incident *Model) (*Model, error) {
+	now := time.Now().UTC()
+	mm := &mongoModel{
+		ID:          primitive.NewObjectID(),
+		OrgID:       incident.OrgID,
+		Title:       incident.Title,
+		Severity:    incident.Severity,
+		State:       incident.State,
+		AutoCreated: incident.AutoCreated,
+		MonitorIDs:  uniqueIDs(incident.MonitorIDs),
+		StartedAt:   incident.StartedAt,
+		ResolvedAt:  incident.ResolvedAt,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+
+		OpenAutoMonitorID: openAutoMonitorID(incident),
+	}
+	if mm.StartedAt.IsZero() {
+		mm.StartedAt = now
+	}
+
+	if _, err := r.collection.InsertOne(ctx, mm); err != nil {
+		if mm.OpenAutoMonitorID != "" && mongo.IsDuplicateKeyError(err) {
+			return nil, ErrAutoIncidentOpen
+		}
+		return nil, err
+	}
+	return toDomainModel(mm), nil
+}
+
+func (r *MongoRepository) FindByID(ctx context.Context, id string, orgID string) (*Model, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := bson.M{"_id": objectID}
+	if orgID != "" {
+		filter["org_id"] = orgID
+	}
+
+	var mm mongoModel
+	err = r.collection.FindOne(ctx, filter).Decode(&mm)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toDomainModel(&mm), nil
+}
+
+func (r *MongoRepository) FindAll(ctx context.Context, page int, limit int, state string, orgID string) ([]*Model, error) {
+	skip := int64(page * limit)
+	limit64 := int64(limit)
+
+	opts := &options.FindOptions{
+		Skip:  &skip,
+		Limit: &limit64,
+		Sort:  bson.D{{Key: "started_at", Value: -1}},
+	}
+
+	filter := bson.M{"org_id": orgID}
+	if state != "" {
+		filter["state"] = state
+	}
+
+	return r.find(ctx, filter, opts)
+}
+
+func (r *MongoRepository) Update(ctx context.Context, id string, incident *UpdateModel, orgID string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	updatePayload := bson.M{}
+
+	if incident.Title != nil {
+		updatePayload["title"] = *incident.Title
+	}
+	if incident.Severity != nil {
+		updatePayload["severity"] = *incident.Severity
+	}
+	if incident.State != nil {
+		updatePayload["state"] = *incident.State
+		updatePayload["resolved_at"] = incident.ResolvedAt
+	}
+
+	if len(updatePayload) == 0 {
+		return nil
+	}
+
+	updatePayload["updated_at"] = time.Now().UTC()
+
+	filter := bson.M{"_id": objectID}
+	if orgID != "" {
+		filter["org_id"] = orgID
+	}
+
+	update := bson.M{"$set": updatePayload}
+	if incident.State != nil && incident.ResolvedAt != nil {
+		update["$unset"] = bson.M{"open_auto_monitor_id": ""}
+	}
+
+	_, err = r.collection.UpdateOne(ctx, filter, update)
+	return err
+}
+
+func (r *MongoRepository) Delete(ctx context.Context, id string, orgID string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	res, err := r.collection.DeleteOne(ctx, bson.M{"_id": objectID, "org_id": orgID})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return nil
+	}
+
+	_, err = r.updatesCollection.DeleteMany(ctx, bson.M{"incident_id": id})
+	return err
+}
+
+func (r *MongoRepository) SetMonitors(ctx context.Context, id string, monitorIDs []string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	update := bson.M{"$set": bson.M{
+		"monitor_ids": uniqueIDs(monitorIDs),
+		"updated_at":  time.Now().UTC(),
+	}}
+	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": objectID}, update)
+	return err
+}
+
+func (r *MongoRepository) FindByMonitorIDs(ctx context.Context, orgID string, monitorIDs []string, resolvedSince time.Time) ([]*Model, error) {
+	if len(monitorIDs) == 0 {
+		return []*Model{}, nil
+	}
+
+	filter := bson.M{
+		"org_id":      orgID,
+		"monitor_ids": bson.M{"$in": monitorIDs},
+		"$or": bson.A{
+			bson.M{"resolved_at": nil},
+			bson.M{"resolved_at": bson.M{"$gte": resolvedSince}},
+		},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "started_at", Value: -1}})
+
+	return r.find(ctx, filter, opts)
+}
+
+func (r *MongoRepository) FindOpenByMonitorID(ctx context.Context, monitorID string) ([]*Model, error) {
+	filter := bson.M{
+		"monitor_ids": monitorID,
+		"resolved_at": nil,
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "started_at", Value: -1}})
+
+	return r.find(ctx, filter, opts)
+}
+
+func (r *MongoRepository) CreateUpdate(ctx context.Context, update *IncidentUpdate) (*IncidentUpdate, error) {
+	mu := &mongoIncidentUpdate{
+		ID:         primitive.NewObjectID(),
+		IncidentID: update.IncidentID,
+		State:      update.State,
+		Message:    update.Message,
+		CreatedAt:  time.Now().UTC(),
+	}
+
+	if _, err := r.updatesCollection.InsertOne(ctx, mu); err != nil {
+		return nil, err
+	}
+
+	return &IncidentUpdate{
+		ID:         mu.ID.Hex(),
+		IncidentID: mu.IncidentID,
+		State:      mu.State,
+		Message:    mu.Message,
+		CreatedAt:  mu.CreatedAt,
+	}, nil
+}
+
+func (r *MongoRepository) FindUpdates(ctx context.Context, incidentIDs []string) ([]*IncidentUpdate, error) {
+	if len(incidentIDs) == 0 {
+		return []*IncidentUpdate{}, nil
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cursor, err := r.updatesCollection.Find(ctx, bson.M{"incident_id": bson.M{"$in": incidentIDs}}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var mus []*mongoIncidentUpdate
+	if err := cursor.All(ctx, &mus); err != nil {
+		return nil, err
+	}
+
+	updates := make([]*IncidentUpdate, 0, len(mus))
+	for _, mu := range mus {
+		updates = append(updates, &IncidentUpdate{
+			ID:         mu.ID.Hex(),
+			IncidentID: mu.IncidentID,
+			State:      mu.State,
+			Message:    mu.Message,
+			CreatedAt:  mu.CreatedAt,
+		})
+	}
+	return updates, nil
+}
+
+func (r *MongoRepository) find(ctx context.Context, filter bson.M, opts *options.FindOptions) ([]*Model, error) {
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var mms []*mongoModel
+	if err := cursor.All(ctx, &mms); err != nil {
+		return nil, err
+	}
+
+	models := make([]*Model, 0, len(mms))
+	for _, mm := range mms {
+		models = append(models, toDomainModel(mm))
+	}
+	return models, nil
+}
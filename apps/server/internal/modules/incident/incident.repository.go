@@ -0,0 +1,40 @@
+package incident
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrAutoIncidentOpen is returned by Create when the monitor already has an open
+// automatic incident, e.g. created by another API replica handling the same event
+var ErrAutoIncidentOpen = errors.New("monitor already has an open automatic incident")
+
+// openAutoMonitorID returns the monitor an open automatic incident was created for.
+// Repositories store it until the incident is resolved and keep it unique, so a
+// monitor never gets two automatic incidents at once.
+func openAutoMonitorID(incident *Model) string {
+	if !incident.AutoCreated || incident.ResolvedAt != nil || len(incident.MonitorIDs) != 1 {
+		return ""
+	}
+	return incident.MonitorIDs[0]
+}
+
+type Repository interface {
+	Create(ctx context.Context, incident *Model) (*Model, error)
+	FindByID(ctx context.Context, id string, orgID string) (*Model, error)
+	FindAll(ctx context.Context, page int, limit int, state string, orgID string) ([]*Model, error)
+	Update(ctx context.Context, id string, incident *UpdateModel, orgID string) error
+	Delete(ctx context.Context, id string, orgID string) error
+
+	// SetMonitors replaces the monitors affected by an incident
+	SetMonitors(ctx context.Context, id string, monitorIDs []string) error
+	// FindByMonitorIDs returns incidents of the organization affecting any of the monitors
+	// that are still open or were resolved after resolvedSince
+	FindByMonitorIDs(ctx context.Context, orgID string, monitorIDs []string, resolvedSince time.Time) ([]*Model, error)
+	// FindOpenByMonitorID returns the unresolved incidents affecting a monitor
+	FindOpenByMonitorID(ctx context.Context, monitorID string) ([]*Model, error)
+
+	CreateUpdate(ctx context.Context, update *IncidentUpdate) (*IncidentUpdate, error)
+	FindUpdates(ctx context.Context, incidentIDs []string) ([]*IncidentUpdate, error)
+}
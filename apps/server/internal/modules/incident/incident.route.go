@@ -0,0 +1,37 @@
+package incident
+
+import (
+	"vigi/internal/modules/middleware"
+	"vigi/internal/modules/organization"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Route struct {
+	controller    *Controller
+	middleware    *middleware.AuthChain
+	orgMiddleware *organization.Middleware
+}
+
+func NewRoute(controller *Controller, middleware *middleware.AuthChain, orgMiddleware *organization.Middleware) *Route {
+	return &Route{
+		controller:    controller,
+		middleware:    middleware,
+		orgMiddleware: orgMiddleware,
+	}
+}
+
+func (r *Route) ConnectRoute(rg *gin.RouterGroup, controller *Controller) {
+	router := rg.Group("incidents")
+
+	router.Use(r.middleware.AllAuth())
+	router.Use(r.orgMiddleware.RequireOrganization())
+	{
+		router.GET("", r.controller.FindAll)
+		router.POST("", r.controller.Create)
+		router.GET("/:id", r.controller.FindByID)
+		router.PATCH("/:id", r.controller.Update)
+		router.DELETE("/:id", r.controller.Delete)
+		router.POST("/:id/updates", r.controller.AddUpdate)
+	}
+}
@@ -0,0 +1,342 @@
+package incident
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"vigi/internal/modules/events"
+	"vigi/internal/modules/monitor"
+
+	"go.uber.org/zap"
+)
+
+// ResolvedHistoryWindow is how long resolved incidents keep showing on status pages
+const ResolvedHistoryWindow = 7 * 24 * time.Hour
+
+var ErrMonitorNotFound = errors.New("one or more monitors were not found")
+
+type Service interface {
+	Create(ctx context.Context, dto *CreateIncidentDTO, orgID string) (*Model, error)
+	FindByID(ctx context.Context, id string, orgID string) (*Model, error)
+	FindAll(ctx context.Context, page int, limit int, state string, orgID string) ([]*Model, error)
+	Update(ctx context.Context, id string, dto *UpdateIncidentDTO, orgID string) (*Model, error)
+	Delete(ctx context.Context, id string, orgID string) error
+
+	// AddUpdate posts a timeline update and moves the incident to its state
+	AddUpdate(ctx context.Context, id string, dto *CreateIncidentUpdateDTO, orgID string) (*Model, error)
+
+	// FindForMonitors returns the open and recently resolved incidents of the organization
+	// affecting any of the monitors
+	FindForMonitors(ctx context.Context, orgID string, monitorIDs []string) ([]*Model, error)
+	// OpenForMonitor opens an automatic incident for a monitor that went down,
+	// unless an automatic incident is already open for it
+	OpenForMonitor(ctx context.Context, orgID string, monitorID string, monitorName string) (*Model, error)
+	// ResolveForMonitor resolves the open automatic incidents of a monitor that recovered
+	ResolveForMonitor(ctx context.Context, monitorID string, monitorName string) error
+}
+
+type ServiceImpl struct {
+	repository     Repository
+	monitorService monitor.Service
+	eventBus       events.EventBus
+	logger         *zap.SugaredLogger
+}
+
+func NewService(
+	repository Repository,
+	monitorService monitor.Service,
+	eventBus events.EventBus,
+	logger *zap.SugaredLogger,
+) Service {
+	return &ServiceImpl{
+		repository:     repository,
+		monitorService: monitorService,
+		eventBus:       eventBus,
+		logger:         logger.Named("[incident-service]"),
+	}
+}
+
+func (s *ServiceImpl) Create(ctx context.Context, dto *CreateIncidentDTO, orgID string) (*Model, error) {
+	monitorIDs, err := s.validateMonitors(ctx, dto.MonitorIDs, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	state := dto.State
+	if state == "" {
+		state = StateInvestigating
+	}
+
+	now := time.Now().UTC()
+	model := &Model{
+		OrgID:      orgID,
+		Title:      dto.Title,
+		Severity:   dto.Severity,
+		State:      state,
+		MonitorIDs: monitorIDs,
+		StartedAt:  now,
+	}
+	if state == StateResolved {
+		model.ResolvedAt = &now
+	}
+
+	return s.create(ctx, model, dto.Message)
+}
+
+func (s *ServiceImpl) FindByID(ctx context.Context, id string, orgID string) (*Model, error) {
+	model, err := s.repository.FindByID(ctx, id, orgID)
+	if err != nil || model == nil {
+		return model, err
+	}
+
+	if err := s.attachUpdates(ctx, []*Model{model}); err != nil {
+		return nil, err
+	}
+	return model, nil
+}
+
+func (s *ServiceImpl) FindAll(ctx context.Context, page int, limit int, state string, orgID string) ([]*Model, error) {
+	models, err := s.repository.FindAll(ctx, page, limit, state, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.attachUpdates(ctx, models); err != nil {
+		return nil, err
+	}
+	return models, nil
+}
+
+func (s *ServiceImpl) Update(ctx context.Context, id string, dto *UpdateIncidentDTO, orgID string) (*Model, error) {
+	existing, err := s.repository.FindByID(ctx, id, orgID)
+	if err != nil || existing == nil {
+		return nil, err
+	}
+
+	var monitorIDs []string
+	if dto.MonitorIDs != nil {
+		monitorIDs, err = s.validateMonitors(ctx, *dto.MonitorIDs, orgID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = s.repository.Update(ctx, id, &UpdateModel{
+		Title:    dto.Title,
+		Severity: dto.Severity,
+	}, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	if dto.MonitorIDs != nil {
+		if err := s.repository.SetMonitors(ctx, id, monitorIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.FindByID(ctx, id, orgID)
+}
+
+func (s *ServiceImpl) Delete(ctx context.Context, id string, orgID string) error {
+	return s.repository.Delete(ctx, id, orgID)
+}
+
+func (s *ServiceImpl) AddUpdate(ctx context.Context, id string, dto *CreateIncidentUpdateDTO, orgID string) (*Model, error) {
+	existing, err := s.repository.FindByID(ctx, id, orgID)
+	if err != nil || existing == nil {
+		return nil, err
+	}
+
+	if err := s.addUpdate(ctx, existing, dto.State, dto.Message); err != nil {
+		return nil, err
+	}
+
+	return s.FindByID(ctx, id, orgID)
+}
+
+func (s *ServiceImpl) FindForMonitors(ctx context.Context, orgID string, monitorIDs []string) ([]*Model, error) {
+	models, err := s.repository.FindByMonitorIDs(ctx, orgID, monitorIDs, time.Now().UTC().Add(-ResolvedHistoryWindow))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.attachUpdates(ctx, models); err != nil {
+		return nil, err
+	}
+	return models, nil
+}
+
+func (s *ServiceImpl) OpenForMonitor(ctx context.Context, orgID string, monitorID string, monitorName string) (*Model, error) {
+	open, err := s.repository.FindOpenByMonitorID(ctx, monitorID)
+	if err != nil {
+		return nil, err
+	}
+	for _, incident := range open {
+		if incident.AutoCreated {
+			return nil, nil
+		}
+	}
+
+	model := &Model{
+		OrgID:       orgID,
+		Title:       fmt.Sprintf("%s is down", monitorName),
+		Severity:    SeverityMajor,
+		State:       StateInvestigating,
+		AutoCreated: true,
+		MonitorIDs:  []string{monitorID},
+		StartedAt:   time.Now().UTC(),
+	}
+	message := fmt.Sprintf("Automated monitoring detected that %s is not responding. We are investigating.", monitorName)
+
+	created, err := s.create(ctx, model, message)
+	if errors.Is(err, ErrAutoIncidentOpen) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Infow("Opened incident for monitor", "incidentID", created.ID, "monitorID", monitorID)
+	return created, nil
+}
+
+func (s *ServiceImpl) ResolveForMonitor(ctx context.Context, monitorID string, monitorName string) error {
+	open, err := s.repository.FindOpenByMonitorID(ctx, monitorID)
+	if err != nil {
+		return err
+	}
+
+	message := fmt.Sprintf("%s has recovered and is operating normally.", monitorName)
+	for _, incident := range open {
+		if !incident.AutoCreated {
+			continue
+		}
+		if err := s.addUpdate(ctx, incident, StateResolved, message); err != nil {
+			return err
+		}
+		s.logger.Infow("Resolved incident for monitor", "incidentID", incident.ID, "monitorID", monitorID)
+	}
+	return nil
+}
+
+func (s *ServiceImpl) create(ctx context.Context, model *Model, message string) (*Model, error) {
+	created, err := s.repository.Create(ctx, model)
+	if err != nil {
+		return nil, err
+	}
+
+	update, err := s.repository.CreateUpdate(ctx, &IncidentUpdate{
+		IncidentID: created.ID,
+		State:      created.State,
+		Message:    message,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	created.Updates = []*IncidentUpdate{update}
+
+	s.eventBus.Publish(events.Event{
+		Type:    events.IncidentCreated,
+		Payload: created,
+	})
+
+	return created, nil
+}
+
+func (s *ServiceImpl) addUpdate(ctx context.Context, incident *Model, state string, message string) error {
+	// Keep the original resolution time when a resolved incident gets another update
+	resolvedAt := incident.ResolvedAt
+	if state != StateResolved {
+		resolvedAt = nil
+	} else if resolvedAt == nil {
+		now := time.Now().UTC()
+		resolvedAt = &now
+	}
+
+	err := s.repository.Update(ctx, incident.ID, &UpdateModel{
+		State:      &state,
+		ResolvedAt: resolvedAt,
+	}, incident.OrgID)
+	if err != nil {
+		return err
+	}
+
+	update, err := s.repository.CreateUpdate(ctx, &IncidentUpdate{
+		IncidentID: incident.ID,
+		State:      state,
+		Message:    message,
+	})
+	if err != nil {
+		return err
+	}
+
+	incident.State = state
+	incident.ResolvedAt = resolvedAt
+	incident.Updates = append([]*IncidentUpdate{update}, incident.Updates...)
+
+	s.eventBus.Publish(events.Event{
+		Type:    events.IncidentUpdated,
+		Payload: incident,
+	})
+
+	return nil
+}
+
+// attachUpdates loads the timelines of the incidents, newest update first
+func (s *ServiceImpl) attachUpdates(ctx context.Context, models []*Model) error {
+	if len(models) == 0 {
+		return nil
+	}
+
+	byID := make(map[string]*Model, len(models))
+	ids := make([]string, 0, len(models))
+	for _, m := range models {
+		m.Updates = []*IncidentUpdate{}
+		byID[m.ID] = m
+		ids = append(ids, m.ID)
+	}
+
+	updates, err := s.repository.FindUpdates(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for _, update := range updates {
+		if m, ok := byID[update.IncidentID]; ok {
+			m.Updates = append(m.Updates, update)
+		}
+	}
+	return nil
+}
+
+// validateMonitors deduplicates the monitor IDs and checks they all belong to the organization
+func (s *ServiceImpl) validateMonitors(ctx context.Context, ids []string, orgID string) ([]string, error) {
+	ids = uniqueIDs(ids)
+	if len(ids) == 0 {
+		return ids, nil
+	}
+
+	monitors, err := s.monitorService.FindByIDs(ctx, ids, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if len(monitors) != len(ids) {
+		return nil, ErrMonitorNotFound
+	}
+	return ids, nil
+}
+
+func uniqueIDs(ids []string) []string {
+	result := make([]string, 0, len(ids))
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		result = append(result, id)
+	}
+	return result
+}
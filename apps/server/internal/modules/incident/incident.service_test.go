@@ -0,0 +1,340 @@
+package incident
+
+import (
+	"context"
+	"testing"
+	"time"
+	"vigi/internal/modules/events"
+	"vigi/internal/modules/monitor"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+// MockRepository
+type MockRepository struct {
+	mock.Mock
+}
+
+func (m *MockRepository) Create(ctx context.Context, incident *Model) (*Model, error) {
+	args := m.Called(ctx, incident)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Model), args.Error(1)
+}
+
+func (m *MockRepository) FindByID(ctx context.Context, id string, orgID string) (*Model, error) {
+	args := m.Called(ctx, id, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Model), args.Error(1)
+}
+
+func (m *MockRepository) FindAll(ctx context.Context, page int, limit int, state string, orgID string) ([]*Model, error) {
+	args := m.Called(ctx, page, limit, state, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*Model), args.Error(1)
+}
+
+func (m *MockRepository) Update(ctx context.Context, id string, incident *UpdateModel, orgID string) error {
+	args := m.Called(ctx, id, incident, orgID)
+	return args.Error(0)
+}
+
+func (m *MockRepository) Delete(ctx context.Context, id string, orgID string) error {
+	args := m.Called(ctx, id, orgID)
+	return args.Error(0)
+}
+
+func (m *MockRepository) SetMonitors(ctx context.Context, id string, monitorIDs []string) error {
+	args := m.Called(ctx, id, monitorIDs)
+	return args.Error(0)
+}
+
+func (m *MockRepository) FindByMonitorIDs(ctx context.Context, orgID string, monitorIDs []string, resolvedSince time.Time) ([]*Model, error) {
+	args := m.Called(ctx, orgID, monitorIDs, resolvedSince)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*Model), args.Error(1)
+}
+
+func (m *MockRepository) FindOpenByMonitorID(ctx context.Context, monitorID string) ([]*Model, error) {
+	args := m.Called(ctx, monitorID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*Model), args.Error(1)
+}
+
+func (m *MockRepository) CreateUpdate(ctx context.Context, update *IncidentUpdate) (*IncidentUpdate, error) {
+	args := m.Called(ctx, update)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*IncidentUpdate), args.Error(1)
+}
+
+func (m *MockRepository) FindUpdates(ctx context.Context, incidentIDs []string) ([]*IncidentUpdate, error) {
+	args := m.Called(ctx, incidentIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*IncidentUpdate), args.Error(1)
+}
+
+// MockEventBus
+type MockEventBus struct {
+	mock.Mock
+}
+
+func (m *MockEventBus) Subscribe(eventType events.EventType, handler events.EventHandler) {
+	m.Called(eventType, handler)
+}
+
+func (m *MockEventBus) Publish(event events.Event) {
+	m.Called(event)
+}
+
+func (m *MockEventBus) Close() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+// fakeMonitorService only implements the lookups the incident service needs
+type fakeMonitorService struct {
+	monitor.Service
+	monitors map[string]*monitor.Model
+}
+
+func (s *fakeMonitorService) FindByIDs(ctx context.Context, ids []string, orgID string) ([]*monitor.Model, error) {
+	var result []*monitor.Model
+	for _, id := range ids {
+		if m, ok := s.monitors[id]; ok && m.OrgID == orgID {
+			result = append(result, m)
+		}
+	}
+	return result, nil
+}
+
+func setupService() (*ServiceImpl, *MockRepository) {
+	repo := &MockRepository{}
+	eventBus := &MockEventBus{}
+	eventBus.On("Publish", mock.Anything).Return()
+	monitorService := &fakeMonitorService{monitors: map[string]*monitor.Model{
+		"monitor1": {ID: "monitor1", OrgID: "org1"},
+		"other":    {ID: "other", OrgID: "org2"},
+	}}
+	service := NewService(repo, monitorService, eventBus, zap.NewNop().Sugar()).(*ServiceImpl)
+	return service, repo
+}
+
+func TestServiceImpl_Create(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("defaults to investigating and records the initial update", func(t *testing.T) {
+		service, repo := setupService()
+
+		dto := &CreateIncidentDTO{
+			Title:      "API outage",
+			Severity:   SeverityCritical,
+			Message:    "We are looking into it",
+			MonitorIDs: []string{"monitor1"},
+		}
+
+		repo.On("Create", ctx, mock.MatchedBy(func(m *Model) bool {
+			return m.State == StateInvestigating && m.ResolvedAt == nil && m.OrgID == "org1" && !m.AutoCreated
+		})).Return(&Model{ID: "incident1", OrgID: "org1", State: StateInvestigating}, nil)
+		repo.On("CreateUpdate", ctx, mock.MatchedBy(func(u *IncidentUpdate) bool {
+			return u.IncidentID == "incident1" && u.State == StateInvestigating && u.Message == "We are looking into it"
+		})).Return(&IncidentUpdate{ID: "update1", IncidentID: "incident1"}, nil)
+
+		created, err := service.Create(ctx, dto, "org1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "incident1", created.ID)
+		assert.Len(t, created.Updates, 1)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("resolved incident gets a resolution time", func(t *testing.T) {
+		service, repo := setupService()
+
+		dto := &CreateIncidentDTO{
+			Title:      "Past outage",
+			Severity:   SeverityMinor,
+			State:      StateResolved,
+			Message:    "Backfilled",
+			MonitorIDs: []string{"monitor1"},
+		}
+
+		repo.On("Create", ctx, mock.MatchedBy(func(m *Model) bool {
+			return m.ResolvedAt != nil
+		})).Return(&Model{ID: "incident1", State: StateResolved}, nil)
+		repo.On("CreateUpdate", ctx, mock.Anything).Return(&IncidentUpdate{ID: "update1"}, nil)
+
+		_, err := service.Create(ctx, dto, "org1")
+
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("rejects monitors of another organization", func(t *testing.T) {
+		service, repo := setupService()
+
+		dto := &CreateIncidentDTO{
+			Title:      "API outage",
+			Severity:   SeverityCritical,
+			Message:    "We are looking into it",
+			MonitorIDs: []string{"monitor1", "other"},
+		}
+
+		created, err := service.Create(ctx, dto, "org1")
+
+		assert.ErrorIs(t, err, ErrMonitorNotFound)
+		assert.Nil(t, created)
+		repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestServiceImpl_Update(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("rejects monitors of another organization", func(t *testing.T) {
+		service, repo := setupService()
+
+		repo.On("FindByID", ctx, "incident1", "org1").Return(&Model{ID: "incident1", OrgID: "org1"}, nil)
+
+		monitorIDs := []string{"other"}
+		updated, err := service.Update(ctx, "incident1", &UpdateIncidentDTO{MonitorIDs: &monitorIDs}, "org1")
+
+		assert.ErrorIs(t, err, ErrMonitorNotFound)
+		assert.Nil(t, updated)
+		repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		repo.AssertNotCalled(t, "SetMonitors", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestServiceImpl_AddUpdate(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("resolving sets the resolution time", func(t *testing.T) {
+		service, repo := setupService()
+
+		existing := &Model{ID: "incident1", OrgID: "org1", State: StateMonitoring}
+		repo.On("FindByID", ctx, "incident1", "org1").Return(existing, nil)
+		repo.On("Update", ctx, "incident1", mock.MatchedBy(func(u *UpdateModel) bool {
+			return *u.State == StateResolved && u.ResolvedAt != nil
+		}), "org1").Return(nil)
+		repo.On("CreateUpdate", ctx, mock.Anything).Return(&IncidentUpdate{ID: "update1"}, nil)
+		repo.On("FindUpdates", ctx, []string{"incident1"}).Return([]*IncidentUpdate{}, nil)
+
+		result, err := service.AddUpdate(ctx, "incident1", &CreateIncidentUpdateDTO{State: StateResolved, Message: "Fixed"}, "org1")
+
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("reopening clears the resolution time", func(t *testing.T) {
+		service, repo := setupService()
+
+		resolvedAt := time.Now().UTC()
+		existing := &Model{ID: "incident1", OrgID: "org1", State: StateResolved, ResolvedAt: &resolvedAt}
+		repo.On("FindByID", ctx, "incident1", "org1").Return(existing, nil)
+		repo.On("Update", ctx, "incident1", mock.MatchedBy(func(u *UpdateModel) bool {
+			return *u.State == StateInvestigating && u.ResolvedAt == nil
+		}), "org1").Return(nil)
+		repo.On("CreateUpdate", ctx, mock.Anything).Return(&IncidentUpdate{ID: "update1"}, nil)
+		repo.On("FindUpdates", ctx, []string{"incident1"}).Return([]*IncidentUpdate{}, nil)
+
+		_, err := service.AddUpdate(ctx, "incident1", &CreateIncidentUpdateDTO{State: StateInvestigating, Message: "Back again"}, "org1")
+
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		service, repo := setupService()
+
+		repo.On("FindByID", ctx, "missing", "org1").Return(nil, nil)
+
+		result, err := service.AddUpdate(ctx, "missing", &CreateIncidentUpdateDTO{State: StateResolved, Message: "Fixed"}, "org1")
+
+		assert.NoError(t, err)
+		assert.Nil(t, result)
+		repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestServiceImpl_OpenForMonitor(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("opens an automatic incident", func(t *testing.T) {
+		service, repo := setupService()
+
+		repo.On("FindOpenByMonitorID", ctx, "monitor1").Return([]*Model{{ID: "manual", AutoCreated: false}}, nil)
+		repo.On("Create", ctx, mock.MatchedBy(func(m *Model) bool {
+			return m.AutoCreated && m.Title == "API is down" && m.Severity == SeverityMajor && m.OrgID == "org1"
+		})).Return(&Model{ID: "incident1", State: StateInvestigating, AutoCreated: true}, nil)
+		repo.On("CreateUpdate", ctx, mock.Anything).Return(&IncidentUpdate{ID: "update1"}, nil)
+
+		created, err := service.OpenForMonitor(ctx, "org1", "monitor1", "API")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "incident1", created.ID)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("skips when an automatic incident is already open", func(t *testing.T) {
+		service, repo := setupService()
+
+		repo.On("FindOpenByMonitorID", ctx, "monitor1").Return([]*Model{{ID: "incident1", AutoCreated: true}}, nil)
+
+		created, err := service.OpenForMonitor(ctx, "org1", "monitor1", "API")
+
+		assert.NoError(t, err)
+		assert.Nil(t, created)
+		repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("skips when another replica opened the incident first", func(t *testing.T) {
+		service, repo := setupService()
+
+		repo.On("FindOpenByMonitorID", ctx, "monitor1").Return([]*Model{}, nil)
+		repo.On("Create", ctx, mock.Anything).Return(nil, ErrAutoIncidentOpen)
+
+		created, err := service.OpenForMonitor(ctx, "org1", "monitor1", "API")
+
+		assert.NoError(t, err)
+		assert.Nil(t, created)
+		repo.AssertNotCalled(t, "CreateUpdate", mock.Anything, mock.Anything)
+	})
+}
+
+func TestServiceImpl_ResolveForMonitor(t *testing.T) {
+	ctx := context.Background()
+	service, repo := setupService()
+
+	repo.On("FindOpenByMonitorID", ctx, "monitor1").Return([]*Model{
+		{ID: "auto", OrgID: "org1", AutoCreated: true},
+		{ID: "manual", OrgID: "org1", AutoCreated: false},
+	}, nil)
+	repo.On("Update", ctx, "auto", mock.MatchedBy(func(u *UpdateModel) bool {
+		return *u.State == StateResolved && u.ResolvedAt != nil
+	}), "org1").Return(nil)
+	repo.On("CreateUpdate", ctx, mock.MatchedBy(func(u *IncidentUpdate) bool {
+		return u.IncidentID == "auto" && u.State == StateResolved
+	})).Return(&IncidentUpdate{ID: "update1"}, nil)
+
+	err := service.ResolveForMonitor(ctx, "monitor1", "API")
+
+	assert.NoError(t, err)
+	repo.AssertExpectations(t)
+	repo.AssertNotCalled(t, "Update", ctx, "manual", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,355 @@
+package incident
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type sqlModel struct {
+	bun.BaseModel `bun:"table:incidents,alias:i"`
+
+	ID          string     `bun:"id,pk"`
+	OrgID       string     `bun:"org_id"`
+	Title       string     `bun:"title,notnull"`
+	Severity    string     `bun:"severity,notnull"`
+	State       string     `bun:"state,notnull"`
+	AutoCreated bool       `bun:"auto_created,notnull,default:false"`
+	StartedAt   time.Time  `bun:"started_at,notnull"`
+	ResolvedAt  *time.Time `bun:"resolved_at"`
+	CreatedAt   time.Time  `bun:"created_at,nullzero,notnull,default:current_timestamp"`
+	UpdatedAt   time.Time  `bun:"updated_at,nullzero,notnull,default:current_timestamp"`
+	// OpenAutoMonitorID is cleared on resolution, see openAutoMonitorID
+	OpenAutoMonitorID *string `bun:"open_auto_monitor_id"`
+}
+
+type sqlIncidentMonitor struct {
+	bun.BaseModel `bun:"table:incident_monitors,alias:im"`
+
+	IncidentID string `bun:"incident_id,pk"`
+	MonitorID  string `bun:"monitor_id,pk"`
+}
+
+type sqlIncidentUpdate struct {
+	bun.BaseModel `bun:"table:incident_updates,alias:iu"`
+
+	ID         string    `bun:"id,pk"`
+	IncidentID string    `bun:"incident_id,notnull"`
+	State      string    `bun:"state,notnull"`
+	Message    string    `bun:"message,notnull"`
+	CreatedAt  time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp"`
+}
+
+func toDomainModelFromSQL(sm *sqlModel) *Model {
+	return &Model{
+		ID:          sm.ID,
+		OrgID:       sm.OrgID,
+		Title:       sm.Title,
+		Severity:    sm.Severity,
+		State:       sm.State,
+		AutoCreated: sm.AutoCreated,
+		MonitorIDs:  []string{},
+		StartedAt:   sm.StartedAt,
+		ResolvedAt:  sm.ResolvedAt,
+		CreatedAt:   sm.CreatedAt,
+		UpdatedAt:   sm.UpdatedAt,
+	}
+}
+
+func toSQLModel(m *Model) *sqlModel {
+	return &sqlModel{
+		ID:          m.ID,
+		OrgID:       m.OrgID,
+		Title:       m.Title,
+		Severity:    m.Severity,
+		State:       m.State,
+		AutoCreated: m.AutoCreated,
+		StartedAt:   m.StartedAt,
+		ResolvedAt:  m.ResolvedAt,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+	}
+}
+
+type SQLRepositoryImpl struct {
+	db *bun.DB
+}
+
+func NewSQLRepository(db *bun.DB) Repository {
+	return &SQLRepositoryImpl{db: db}
+}
+
+func (r *SQLRepositoryImpl) Create(ctx context.Context, incident *Model) (*Model, error) {
+	sm := toSQLModel(incident)
+	sm.ID = uuid.New().String()
+	sm.CreatedAt = time.Now()
+	sm.UpdatedAt = time.Now()
+	if sm.StartedAt.IsZero() {
+		sm.StartedAt = sm.CreatedAt
+	}
+
+	if monitorID := openAutoMonitorID(incident); monitorID != "" {
+		sm.OpenAutoMonitorID = &monitorID
+	}
+
+	err := r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		res, err := tx.NewInsert().Model(sm).
+			On("CONFLICT (open_auto_monitor_id) WHERE open_auto_monitor_id IS NOT NULL DO NOTHING").
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+		if rows, err := res.RowsAffected(); err != nil {
+			return err
+		} else if rows == 0 {
+			return ErrAutoIncidentOpen
+		}
+		return insertMonitors(ctx, tx, sm.ID, incident.MonitorIDs)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	created := toDomainModelFromSQL(sm)
+	created.MonitorIDs = uniqueIDs(incident.MonitorIDs)
+	return created, nil
+}
+
+func (r *SQLRepositoryImpl) FindByID(ctx context.Context, id string, orgID string) (*Model, error) {
+	sm := new(sqlModel)
+	query := r.db.NewSelect().Model(sm).Where("id = ?", id)
+	if orgID != "" {
+		query = query.Where("org_id = ?", orgID)
+	}
+
+	err := query.Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	models, err := r.withMonitors(ctx, []*sqlModel{sm})
+	if err != nil {
+		return nil, err
+	}
+	return models[0], nil
+}
+
+func (r *SQLRepositoryImpl) FindAll(ctx context.Context, page int, limit int, state string, orgID string) ([]*Model, error) {
+	query := r.db.NewSelect().Model((*sqlModel)(nil)).Where("org_id = ?", orgID)
+	if state != "" {
+		query = query.Where("state = ?", state)
+	}
+
+	query = query.Order("started_at DESC").
+		Limit(limit).
+		Offset(page * limit)
+
+	var sms []*sqlModel
+	if err := query.Scan(ctx, &sms); err != nil {
+		return nil, err
+	}
+	return r.withMonitors(ctx, sms)
+}
+
+func (r *SQLRepositoryImpl) Update(ctx context.Context, id string, incident *UpdateModel, orgID string) error {
+	query := r.db.NewUpdate().Model((*sqlModel)(nil)).Where("id = ?", id)
+	if orgID != "" {
+		query = query.Where("org_id = ?", orgID)
+	}
+
+	hasUpdates := false
+
+	if incident.Title != nil {
+		query = query.Set("title = ?", *incident.Title)
+		hasUpdates = true
+	}
+	if incident.Severity != nil {
+		query = query.Set("severity = ?", *incident.Severity)
+		hasUpdates = true
+	}
+	if incident.State != nil {
+		query = query.Set("state = ?", *incident.State)
+		query = query.Set("resolved_at = ?", incident.ResolvedAt)
+		if incident.ResolvedAt != nil {
+			query = query.Set("open_auto_monitor_id = NULL")
+		}
+		hasUpdates = true
+	}
+
+	if !hasUpdates {
+		return nil
+	}
+
+	query = query.Set("updated_at = ?", time.Now())
+
+	_, err := query.Exec(ctx)
+	return err
+}
+
+func (r *SQLRepositoryImpl) Delete(ctx context.Context, id string, orgID string) error {
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		res, err := tx.NewDelete().Model((*sqlModel)(nil)).Where("id = ?", id).Where("org_id = ?", orgID).Exec(ctx)
+		if err != nil {
+			return err
+		}
+		if n, _ := res.RowsAffected(); n == 0 {
+			return nil
+		}
+
+		// Not every backend enforces the foreign key cascades
+		if _, err := tx.NewDelete().Model((*sqlIncidentMonitor)(nil)).Where("incident_id = ?", id).Exec(ctx); err != nil {
+			return err
+		}
+		_, err = tx.NewDelete().Model((*sqlIncidentUpdate)(nil)).Where("incident_id = ?", id).Exec(ctx)
+		return err
+	})
+}
+
+func (r *SQLRepositoryImpl) SetMonitors(ctx context.Context, id string, monitorIDs []string) error {
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.NewDelete().Model((*sqlIncidentMonitor)(nil)).Where("incident_id = ?", id).Exec(ctx); err != nil {
+			return err
+		}
+		return insertMonitors(ctx, tx, id, monitorIDs)
+	})
+}
+
+func (r *SQLRepositoryImpl) FindByMonitorIDs(ctx context.Context, orgID string, monitorIDs []string, resolvedSince time.Time) ([]*Model, error) {
+	if len(monitorIDs) == 0 {
+		return []*Model{}, nil
+	}
+
+	affected := r.db.NewSelect().Model((*sqlIncidentMonitor)(nil)).
+		Column("incident_id").
+		Where("monitor_id IN (?)", bun.In(monitorIDs))
+
+	var sms []*sqlModel
+	err := r.db.NewSelect().Model(&sms).
+		Where("org_id = ?", orgID).
+		Where("id IN (?)", affected).
+		WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.Where("resolved_at IS NULL").WhereOr("resolved_at >= ?", resolvedSince)
+		}).
+		Order("started_at DESC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return r.withMonitors(ctx, sms)
+}
+
+func (r *SQLRepositoryImpl) FindOpenByMonitorID(ctx context.Context, monitorID string) ([]*Model, error) {
+	affected := r.db.NewSelect().Model((*sqlIncidentMonitor)(nil)).
+		Column("incident_id").
+		Where("monitor_id = ?", monitorID)
+
+	var sms []*sqlModel
+	err := r.db.NewSelect().Model(&sms).
+		Where("id IN (?)", affected).
+		Where("resolved_at IS NULL").
+		Order("started_at DESC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return r.withMonitors(ctx, sms)
+}
+
+func (r *SQLRepositoryImpl) CreateUpdate(ctx context.Context, update *IncidentUpdate) (*IncidentUpdate, error) {
+	su := &sqlIncidentUpdate{
+		ID:         uuid.New().String(),
+		IncidentID: update.IncidentID,
+		State:      update.State,
+		Message:    update.Message,
+		CreatedAt:  time.Now(),
+	}
+
+	if _, err := r.db.NewInsert().Model(su).Exec(ctx); err != nil {
+		return nil, err
+	}
+
+	return &IncidentUpdate{
+		ID:         su.ID,
+		IncidentID: su.IncidentID,
+		State:      su.State,
+		Message:    su.Message,
+		CreatedAt:  su.CreatedAt,
+	}, nil
+}
+
+func (r *SQLRepositoryImpl) FindUpdates(ctx context.Context, incidentIDs []string) ([]*IncidentUpdate, error) {
+	if len(incidentIDs) == 0 {
+		return []*IncidentUpdate{}, nil
+	}
+
+	var sus []*sqlIncidentUpdate
+	err := r.db.NewSelect().Model(&sus).
+		Where("incident_id IN (?)", bun.In(incidentIDs)).
+		Order("created_at DESC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	updates := make([]*IncidentUpdate, 0, len(sus))
+	for _, su := range sus {
+		updates = append(updates, &IncidentUpdate{
+			ID:         su.ID,
+			IncidentID: su.IncidentID,
+			State:      su.State,
+			Message:    su.Message,
+			CreatedAt:  su.CreatedAt,
+		})
+	}
+	return updates, nil
+}
+
+// withMonitors converts incidents to domain models with their affected monitors loaded
+func (r *SQLRepositoryImpl) withMonitors(ctx context.Context, sms []*sqlModel) ([]*Model, error) {
+	models := make([]*Model, 0, len(sms))
+	if len(sms) == 0 {
+		return models, nil
+	}
+
+	byID := make(map[string]*Model, len(sms))
+	ids := make([]string, 0, len(sms))
+	for _, sm := range sms {
+		m := toDomainModelFromSQL(sm)
+		models = append(models, m)
+		byID[m.ID] = m
+		ids = append(ids, m.ID)
+	}
+
+	var links []*sqlIncidentMonitor
+	err := r.db.NewSelect().Model(&links).Where("incident_id IN (?)", bun.In(ids)).Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, link := range links {
+		if m, ok := byID[link.IncidentID]; ok {
+			m.MonitorIDs = append(m.MonitorIDs, link.MonitorID)
+		}
+	}
+	return models, nil
+}
+
+func insertMonitors(ctx context.Context, tx bun.Tx, incidentID string, monitorIDs []string) error {
+	if len(monitorIDs) == 0 {
+		return nil
+	}
+
+	links := make([]*sqlIncidentMonitor, 0, len(monitorIDs))
+	for _, monitorID := range uniqueIDs(monitorIDs) {
+		links = append(links, &sqlIncidentMonitor{IncidentID: incidentID, MonitorID: monitorID})
+	}
+
+	_, err := tx.NewInsert().Model(&links).Exec(ctx)
+	return err
+}
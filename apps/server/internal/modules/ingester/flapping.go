@@ -0,0 +1,117 @@
+package ingester
+
+import (
+	"fmt"
+	"vigi/internal/modules/heartbeat"
+	"vigi/internal/modules/shared"
+)
+
+const (
+	// FlapWindowSize is the number of recent heartbeats, the current one
+	// included, the state change percentage is computed over
+	FlapWindowSize = 21
+
+	// FlapStartThreshold is the state change percentage at which a monitor
+	// starts flapping
+	FlapStartThreshold = 50.0
+
+	// FlapStopThreshold is the state change percentage under which a flapping
+	// monitor settles. The gap with FlapStartThreshold keeps a monitor close to
+	// the limit from going in and out of flapping on every beat.
+	FlapStopThreshold = 25.0
+
+	// FlappingMessagePrefix marks the heartbeat a monitor started flapping on
+	FlappingMessagePrefix = "Flapping"
+
+	// SettledMessagePrefix marks the heartbeat a flapping monitor settled on
+	SettledMessagePrefix = "Stopped flapping"
+)
+
+// applyFlapDetection marks the heartbeat as flapping when the status of the
+// monitor changes too often. recentBeats holds the previous heartbeats, newest
+// first. Only the start and the end of flapping are notified, the transitions
+// in between are not. It returns whether the heartbeat should be notified.
+func (h *IngesterTaskHandler) applyFlapDetection(hb *heartbeat.CreateUpdateDto, recentBeats []*heartbeat.Model, shouldNotify bool) bool {
+	wasFlapping := len(recentBeats) > 0 && recentBeats[0].Flapping
+
+	// A maintenance window neither starts nor ends flapping
+	if hb.Status == shared.MonitorStatusMaintenance {
+		hb.Flapping = wasFlapping
+		return shouldNotify
+	}
+
+	statuses := make([]shared.MonitorStatus, 0, len(recentBeats)+1)
+	for i := len(recentBeats) - 1; i >= 0; i-- {
+		statuses = append(statuses, recentBeats[i].Status)
+	}
+	statuses = append(statuses, hb.Status)
+
+	percent := statusChangePercent(statuses)
+	hb.Flapping = isFlapping(wasFlapping, percent)
+
+	switch {
+	case hb.Flapping && !wasFlapping:
+		h.logger.Infow("Monitor started flapping",
+			"monitor_id", hb.MonitorID,
+			"state_change_percent", percent,
+		)
+		hb.Msg = fmt.Sprintf("%s, status changed in %.0f%% of the last %d checks: %s", FlappingMessagePrefix, percent, FlapWindowSize, hb.Msg)
+		hb.Important = true
+		hb.Notified = true
+		return true
+	case hb.Flapping:
+		hb.Notified = false
+		return false
+	case wasFlapping:
+		h.logger.Infow("Monitor stopped flapping",
+			"monitor_id", hb.MonitorID,
+			"state_change_percent", percent,
+		)
+		hb.Msg = fmt.Sprintf("%s, status changed in %.0f%% of the last %d checks: %s", SettledMessagePrefix, percent, FlapWindowSize, hb.Msg)
+		hb.Important = true
+		hb.Notified = true
+		return true
+	}
+	return shouldNotify
+}
+
+// isFlapping applies the start and stop thresholds to the state change percentage
+func isFlapping(wasFlapping bool, percent float64) bool {
+	if wasFlapping {
+		return percent >= FlapStopThreshold
+	}
+	return percent >= FlapStartThreshold
+}
+
+// statusChangePercent returns the weighted percentage of state changes in
+// statuses, ordered from oldest to newest. Recent changes weigh more, from 0.8
+// for the oldest transition of the window to 1.2 for the newest, so a monitor
+// that has become stable settles sooner. Pending and maintenance beats are not
+// states of their own and are left out. A window that is not full yet cannot
+// reach 100%, so new monitors do not flap on their first checks.
+func statusChangePercent(statuses []shared.MonitorStatus) float64 {
+	states := make([]shared.MonitorStatus, 0, len(statuses))
+	for _, status := range statuses {
+		if status == shared.MonitorStatusPending || status == shared.MonitorStatusMaintenance {
+			continue
+		}
+		states = append(states, status)
+	}
+	if len(states) > FlapWindowSize {
+		states = states[len(states)-FlapWindowSize:]
+	}
+
+	transitions := FlapWindowSize - 1
+	// The newest transition always gets the highest weight
+	offset := FlapWindowSize - len(states)
+
+	total := 0.0
+	for i := 1; i < len(states); i++ {
+		if states[i] == states[i-1] {
+			continue
+		}
+		position := offset + i - 1
+		total += 0.8 + 0.4*float64(position)/float64(transitions-1)
+	}
+	return total * 100 / float64(transitions)
+}
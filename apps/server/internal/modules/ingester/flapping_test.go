@@ -0,0 +1,169 @@
+package ingester
+
+import (
+	"strings"
+	"testing"
+	"vigi/internal/modules/heartbeat"
+	"vigi/internal/modules/shared"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+const (
+	up   = shared.MonitorStatusUp
+	down = shared.MonitorStatusDown
+)
+
+// alternating returns n statuses switching between up and down, oldest first
+func alternating(n int) []shared.MonitorStatus {
+	statuses := make([]shared.MonitorStatus, n)
+	for i := range statuses {
+		statuses[i] = up
+		if i%2 == 1 {
+			statuses[i] = down
+		}
+	}
+	return statuses
+}
+
+// repeated returns n times the same status
+func repeated(status shared.MonitorStatus, n int) []shared.MonitorStatus {
+	statuses := make([]shared.MonitorStatus, n)
+	for i := range statuses {
+		statuses[i] = status
+	}
+	return statuses
+}
+
+// recent turns statuses ordered oldest first into heartbeats ordered newest first
+func recent(statuses []shared.MonitorStatus, flapping bool) []*heartbeat.Model {
+	beats := make([]*heartbeat.Model, 0, len(statuses))
+	for i := len(statuses) - 1; i >= 0; i-- {
+		beats = append(beats, &heartbeat.Model{Status: statuses[i]})
+	}
+	if len(beats) > 0 {
+		beats[0].Flapping = flapping
+	}
+	return beats
+}
+
+func TestStatusChangePercent(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []shared.MonitorStatus
+		percent  float64
+	}{
+		{
+			name:     "stable monitor",
+			statuses: repeated(up, FlapWindowSize),
+			percent:  0,
+		},
+		{
+			name:     "change on every check",
+			statuses: alternating(FlapWindowSize),
+			percent:  100,
+		},
+		{
+			name:     "only the newest window counts",
+			statuses: append(alternating(FlapWindowSize), repeated(down, FlapWindowSize)...),
+			percent:  0,
+		},
+		{
+			name:     "newest change weighs the most",
+			statuses: append(repeated(up, FlapWindowSize-1), down),
+			percent:  6,
+		},
+		{
+			name:     "oldest change weighs the least",
+			statuses: append([]shared.MonitorStatus{down}, repeated(up, FlapWindowSize-1)...),
+			percent:  4,
+		},
+		{
+			name:     "partial window cannot reach the start threshold",
+			statuses: alternating(5),
+			percent:  23.368,
+		},
+		{
+			name:     "pending and maintenance beats are left out",
+			statuses: []shared.MonitorStatus{up, shared.MonitorStatusPending, up, shared.MonitorStatusMaintenance, up},
+			percent:  0,
+		},
+		{
+			name:     "degraded is a state of its own",
+			statuses: append(repeated(up, FlapWindowSize-1), shared.MonitorStatusDegraded),
+			percent:  6,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.percent, statusChangePercent(tt.statuses), 0.001)
+		})
+	}
+}
+
+func TestIsFlapping(t *testing.T) {
+	assert.False(t, isFlapping(false, FlapStartThreshold-1))
+	assert.True(t, isFlapping(false, FlapStartThreshold))
+
+	// Between the thresholds a monitor keeps its state
+	between := (FlapStartThreshold + FlapStopThreshold) / 2
+	assert.False(t, isFlapping(false, between))
+	assert.True(t, isFlapping(true, between))
+
+	assert.False(t, isFlapping(true, FlapStopThreshold-1))
+}
+
+func TestApplyFlapDetection(t *testing.T) {
+	h := &IngesterTaskHandler{logger: zap.NewNop().Sugar()}
+
+	t.Run("stable monitor keeps the notification decision", func(t *testing.T) {
+		hb := &heartbeat.CreateUpdateDto{Status: down, Msg: "timeout"}
+
+		assert.True(t, h.applyFlapDetection(hb, recent(repeated(up, FlapWindowSize-1), false), true))
+		assert.False(t, hb.Flapping)
+		assert.Equal(t, "timeout", hb.Msg)
+	})
+
+	t.Run("starting to flap sends one alert", func(t *testing.T) {
+		hb := &heartbeat.CreateUpdateDto{Status: down, Msg: "timeout"}
+
+		shouldNotify := h.applyFlapDetection(hb, recent(alternating(FlapWindowSize-1), false), false)
+		assert.True(t, shouldNotify)
+		assert.True(t, hb.Flapping)
+		assert.True(t, hb.Important)
+		assert.True(t, hb.Notified)
+		assert.True(t, strings.HasPrefix(hb.Msg, FlappingMessagePrefix))
+		assert.True(t, strings.HasSuffix(hb.Msg, ": timeout"))
+	})
+
+	t.Run("transitions while flapping are suppressed", func(t *testing.T) {
+		hb := &heartbeat.CreateUpdateDto{Status: down, Msg: "timeout", Important: true, Notified: true}
+
+		assert.False(t, h.applyFlapDetection(hb, recent(alternating(FlapWindowSize-1), true), true))
+		assert.True(t, hb.Flapping)
+		assert.False(t, hb.Notified)
+		assert.Equal(t, "timeout", hb.Msg)
+	})
+
+	t.Run("settling sends a summary", func(t *testing.T) {
+		// A few changes long ago stay below the stop threshold
+		statuses := append(alternating(4), repeated(up, FlapWindowSize-5)...)
+		hb := &heartbeat.CreateUpdateDto{Status: up, Msg: "200 - OK"}
+
+		shouldNotify := h.applyFlapDetection(hb, recent(statuses, true), false)
+		assert.True(t, shouldNotify)
+		assert.False(t, hb.Flapping)
+		assert.True(t, hb.Important)
+		assert.True(t, hb.Notified)
+		assert.True(t, strings.HasPrefix(hb.Msg, SettledMessagePrefix))
+	})
+
+	t.Run("maintenance keeps the flapping state", func(t *testing.T) {
+		hb := &heartbeat.CreateUpdateDto{Status: shared.MonitorStatusMaintenance}
+
+		assert.False(t, h.applyFlapDetection(hb, recent(repeated(up, FlapWindowSize-1), true), false))
+		assert.True(t, hb.Flapping)
+	})
+}
@@ -7,7 +7,10 @@ import (
 	"vigi/internal/modules/certificate"
 	"vigi/internal/modules/events"
 	"vigi/internal/modules/heartbeat"
+	"vigi/internal/modules/metrics"
+	"vigi/internal/modules/monitor_dependency"
 	"vigi/internal/modules/monitor_maintenance"
+	"vigi/internal/modules/probe"
 	"vigi/internal/modules/shared"
 	"strings"
 	"time"
@@ -19,6 +22,10 @@ import (
 const (
 	// TaskTypeIngester is the task type for ingesting health check results
 	TaskTypeIngester = "monitor:ingest"
+
+	// UnreachableMessagePrefix marks failed heartbeats of a monitor whose
+	// parent monitor is down
+	UnreachableMessagePrefix = "Unreachable because parent monitor is down"
 )
 
 // IngesterTaskPayload is the payload for ingester tasks
@@ -40,6 +47,7 @@ type IngesterTaskPayload struct {
 	IsUnderMaintenance bool                 `json:"is_under_maintenance"`
 	TLSInfo            *certificate.TLSInfo `json:"tls_info,omitempty"`
 	CheckCertExpiry    bool                 `json:"check_cert_expiry"`
+	ProbeID            string               `json:"probe_id,omitempty"`
 }
 
 // IngesterTaskHandler handles ingester tasks from the queue
@@ -47,6 +55,8 @@ type IngesterTaskHandler struct {
 	heartbeatService          heartbeat.Service
 	certificateService        certificate.Service
 	monitorMaintenanceService monitor_maintenance.Service
+	monitorDependencyService  monitor_dependency.Service
+	probeService              probe.Service
 	eventBus                  events.EventBus
 	logger                    *zap.SugaredLogger
 }
@@ -56,6 +66,8 @@ func NewIngesterTaskHandler(
 	heartbeatService heartbeat.Service,
 	certificateService certificate.Service,
 	monitorMaintenanceService monitor_maintenance.Service,
+	monitorDependencyService monitor_dependency.Service,
+	probeService probe.Service,
 	eventBus events.EventBus,
 	logger *zap.SugaredLogger,
 ) *IngesterTaskHandler {
@@ -63,6 +75,8 @@ func NewIngesterTaskHandler(
 		heartbeatService:          heartbeatService,
 		certificateService:        certificateService,
 		monitorMaintenanceService: monitorMaintenanceService,
+		monitorDependencyService:  monitorDependencyService,
+		probeService:              probeService,
 		eventBus:                  eventBus,
 		logger:                    logger.With("component", "ingester_handler"),
 	}
@@ -86,6 +100,7 @@ func (h *IngesterTaskHandler) ProcessTask(ctx context.Context, task *asynq.Task)
 
 	// Process the heartbeat
 	if err := h.processHeartbeat(ctx, &payload); err != nil {
+		metrics.IngesterErrors.Inc()
 		h.logger.Errorw("Failed to process heartbeat",
 			"monitor_id", payload.MonitorID,
 			"error", err,
@@ -102,16 +117,56 @@ func (h *IngesterTaskHandler) ProcessTask(ctx context.Context, task *asynq.Task)
 	return nil
 }
 
+// applyProbeSummary replaces the status of a probe result with the status of all
+// locations, the monitor is down once the down threshold of locations is reached
+func (h *IngesterTaskHandler) applyProbeSummary(ctx context.Context, payload *IngesterTaskPayload) error {
+	// Results of a location that missed two checks no longer count
+	maxAge := time.Duration(2*payload.MonitorInterval+payload.MonitorTimeout) * time.Second
+
+	summary, err := h.probeService.RecordResult(ctx, &probe.Result{
+		MonitorID: payload.MonitorID,
+		ProbeID:   payload.ProbeID,
+		Status:    payload.Status,
+		Msg:       payload.Message,
+		Ping:      payload.PingMs,
+		Time:      payload.StartTime,
+	}, maxAge)
+	if err != nil {
+		return err
+	}
+	if summary.Locations == 0 {
+		// The probe was unassigned while the result was queued
+		return nil
+	}
+
+	reportedDown := payload.Status == shared.MonitorStatusDown
+	payload.Status = summary.Status
+	if summary.Down > 0 {
+		message := fmt.Sprintf("Down from %d of %d locations", summary.Down, summary.Locations)
+		if reportedDown && payload.Message != "" {
+			message = fmt.Sprintf("%s: %s", message, payload.Message)
+		}
+		payload.Message = message
+	}
+	return nil
+}
+
 // isImportantBeat checks if the beat is important (status changed)
 func (h *IngesterTaskHandler) isImportantBeat(prevBeatStatus, currBeatStatus shared.MonitorStatus) bool {
 	up := shared.MonitorStatusUp
 	down := shared.MonitorStatusDown
 	pending := shared.MonitorStatusPending
+	degraded := shared.MonitorStatusDegraded
 
 	return (prevBeatStatus == up && currBeatStatus == down) ||
 		(prevBeatStatus == down && currBeatStatus == up) ||
 		(prevBeatStatus == pending && currBeatStatus == up) ||
-		(prevBeatStatus == pending && currBeatStatus == down)
+		(prevBeatStatus == pending && currBeatStatus == down) ||
+		(prevBeatStatus == up && currBeatStatus == degraded) ||
+		(prevBeatStatus == degraded && currBeatStatus == up) ||
+		(prevBeatStatus == down && currBeatStatus == degraded) ||
+		(prevBeatStatus == degraded && currBeatStatus == down) ||
+		(prevBeatStatus == pending && currBeatStatus == degraded)
 }
 
 // isImportantForNotification checks if the beat should trigger notification
@@ -119,16 +174,28 @@ func (h *IngesterTaskHandler) isImportantForNotification(prevBeatStatus, currBea
 	up := shared.MonitorStatusUp
 	down := shared.MonitorStatusDown
 	pending := shared.MonitorStatusPending
+	degraded := shared.MonitorStatusDegraded
 
 	return (prevBeatStatus == up && currBeatStatus == down) ||
 		(prevBeatStatus == down && currBeatStatus == up) ||
-		(prevBeatStatus == pending && currBeatStatus == down)
+		(prevBeatStatus == pending && currBeatStatus == down) ||
+		(prevBeatStatus == up && currBeatStatus == degraded) ||
+		(prevBeatStatus == degraded && currBeatStatus == up) ||
+		(prevBeatStatus == down && currBeatStatus == degraded) ||
+		(prevBeatStatus == degraded && currBeatStatus == down)
 }
 
 // processHeartbeat processes and stores the heartbeat
 func (h *IngesterTaskHandler) processHeartbeat(ctx context.Context, payload *IngesterTaskPayload) error {
-	// Get the previous heartbeat
-	previousBeats, err := h.heartbeatService.FindByMonitorIDPaginated(ctx, payload.MonitorID, 1, 0, nil, false)
+	// A result from a probe location stands for every location of the monitor
+	if payload.ProbeID != "" && !payload.IsUnderMaintenance {
+		if err := h.applyProbeSummary(ctx, payload); err != nil {
+			return err
+		}
+	}
+
+	// Get the recent heartbeats, the newest one is the previous heartbeat
+	recentBeats, err := h.heartbeatService.FindByMonitorIDPaginated(ctx, payload.MonitorID, FlapWindowSize-1, 0, nil, false)
 	var previousBeat *heartbeat.Model = nil
 	if err != nil {
 		h.logger.Errorw("Failed to get previous heartbeat for monitor",
@@ -136,8 +203,8 @@ func (h *IngesterTaskHandler) processHeartbeat(ctx context.Context, payload *Ing
 			"error", err,
 		)
 	}
-	if len(previousBeats) > 0 {
-		previousBeat = previousBeats[0]
+	if len(recentBeats) > 0 {
+		previousBeat = recentBeats[0]
 	}
 
 	isFirstBeat := previousBeat == nil
@@ -154,6 +221,7 @@ func (h *IngesterTaskHandler) processHeartbeat(ctx context.Context, payload *Ing
 		Time:      payload.StartTime,
 		EndTime:   payload.EndTime,
 		Notified:  false,
+		ProbeID:   payload.ProbeID,
 	}
 
 	if !isFirstBeat {
@@ -199,6 +267,27 @@ func (h *IngesterTaskHandler) processHeartbeat(ctx context.Context, payload *Ing
 		}
 	}
 
+	// An unstable monitor alerts once when it starts flapping instead of on every transition
+	shouldNotify = h.applyFlapDetection(hb, recentBeats, shouldNotify)
+
+	// A failing child of a down parent is expected to fail, so it is marked as
+	// unreachable and kept out of notifications to avoid an alert storm
+	if hb.Status == shared.MonitorStatusDown || hb.Status == shared.MonitorStatusPending {
+		if parentID := h.findDownParent(ctx, payload.MonitorID); parentID != "" {
+			h.logger.Debugw("Suppressing notification, parent monitor is down",
+				"monitor_name", payload.MonitorName,
+				"parent_id", parentID,
+			)
+			hb.Msg = fmt.Sprintf("%s: %s", UnreachableMessagePrefix, hb.Msg)
+			shouldNotify = false
+			hb.Notified = false
+		}
+	} else if shouldNotify && !isFirstBeat && previousBeat.Status == shared.MonitorStatusDown && h.wasUnreachable(ctx, payload.MonitorID) {
+		// The outage was never announced, so its recovery is not either
+		shouldNotify = false
+		hb.Notified = false
+	}
+
 	// Log status
 	if payload.Status == shared.MonitorStatusUp {
 		h.logger.Debugw("Monitor up",
@@ -207,6 +296,13 @@ func (h *IngesterTaskHandler) processHeartbeat(ctx context.Context, payload *Ing
 			"interval", payload.MonitorInterval,
 			"type", payload.MonitorType,
 		)
+	} else if payload.Status == shared.MonitorStatusDegraded {
+		h.logger.Debugw("Monitor degraded",
+			"monitor_name", payload.MonitorName,
+			"ping_ms", payload.PingMs,
+			"interval", payload.MonitorInterval,
+			"type", payload.MonitorType,
+		)
 	} else if payload.Status == shared.MonitorStatusPending {
 		h.logger.Debugw("Monitor pending",
 			"monitor_name", payload.MonitorName,
@@ -230,8 +326,9 @@ func (h *IngesterTaskHandler) processHeartbeat(ctx context.Context, payload *Ing
 		)
 	}
 
-	// Update TLS info and check certificate expiry for HTTPS monitors
-	if payload.TLSInfo != nil && strings.HasPrefix(strings.ToLower(payload.MonitorType), "http") {
+	// Update TLS info and check certificate expiry for HTTPS and TLS-enabled TCP monitors
+	monitorType := strings.ToLower(payload.MonitorType)
+	if payload.TLSInfo != nil && (strings.HasPrefix(monitorType, "http") || monitorType == "tcp") {
 		// Update TLS info (this handles certificate change detection and notification history cleanup)
 		if err := h.certificateService.UpdateTLSInfo(ctx, payload.MonitorID, payload.TLSInfo); err != nil {
 			h.logger.Errorw("Failed to update TLS info for monitor",
@@ -263,6 +360,8 @@ func (h *IngesterTaskHandler) processHeartbeat(ctx context.Context, payload *Ing
 		return fmt.Errorf("failed to create heartbeat: %w", err)
 	}
 
+	metrics.ObserveHeartbeat(payload.MonitorID, payload.MonitorType, hb.Status, payload.PingMs)
+
 	// Publish events
 	if isFirstBeat || previousBeat.Status != hb.Status {
 		h.eventBus.Publish(events.Event{
@@ -280,3 +379,46 @@ func (h *IngesterTaskHandler) processHeartbeat(ctx context.Context, payload *Ing
 
 	return nil
 }
+
+// findDownParent returns the ID of the first parent monitor whose latest
+// heartbeat is down, or an empty string when every parent is reachable
+func (h *IngesterTaskHandler) findDownParent(ctx context.Context, monitorID string) string {
+	parentIDs, err := h.monitorDependencyService.FindParentIDs(ctx, monitorID)
+	if err != nil {
+		h.logger.Errorw("Failed to get parent monitors",
+			"monitor_id", monitorID,
+			"error", err,
+		)
+		return ""
+	}
+
+	for _, parentID := range parentIDs {
+		beats, err := h.heartbeatService.FindByMonitorIDPaginated(ctx, parentID, 1, 0, nil, false)
+		if err != nil {
+			h.logger.Errorw("Failed to get latest heartbeat for parent monitor",
+				"monitor_id", monitorID,
+				"parent_id", parentID,
+				"error", err,
+			)
+			continue
+		}
+		if len(beats) > 0 && beats[0].Status == shared.MonitorStatusDown {
+			return parentID
+		}
+	}
+
+	return ""
+}
+
+// wasUnreachable reports whether the outage the monitor is recovering from
+// started while a parent monitor was down and was therefore never notified
+func (h *IngesterTaskHandler) wasUnreachable(ctx context.Context, monitorID string) bool {
+	important := true
+	beats, err := h.heartbeatService.FindByMonitorIDPaginated(ctx, monitorID, 1, 0, &important, false)
+	if err != nil || len(beats) == 0 {
+		return false
+	}
+
+	last := beats[0]
+	return !last.Notified && strings.HasPrefix(last.Msg, UnreachableMessagePrefix)
+}
@@ -4,7 +4,9 @@ import (
 	"vigi/internal/modules/certificate"
 	"vigi/internal/modules/events"
 	"vigi/internal/modules/heartbeat"
+	"vigi/internal/modules/monitor_dependency"
 	"vigi/internal/modules/monitor_maintenance"
+	"vigi/internal/modules/probe"
 
 	"github.com/hibiken/asynq"
 	"go.uber.org/dig"
@@ -25,6 +27,8 @@ func ProvideIngesterTaskHandler(
 	heartbeatService heartbeat.Service,
 	certificateService certificate.Service,
 	monitorMaintenanceService monitor_maintenance.Service,
+	monitorDependencyService monitor_dependency.Service,
+	probeService probe.Service,
 	eventBus events.EventBus,
 	logger *zap.SugaredLogger,
 ) *IngesterTaskHandler {
@@ -32,6 +36,8 @@ func ProvideIngesterTaskHandler(
 		heartbeatService,
 		certificateService,
 		monitorMaintenanceService,
+		monitorDependencyService,
+		probeService,
 		eventBus,
 		logger,
 	)
@@ -1,7 +1,6 @@
 package inter
 
 import (
-	"io"
 	"net/http"
 	"vigi/internal/utils"
 
@@ -137,28 +136,3 @@ func (c *Controller) GenerateCharge(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, utils.NewSuccessResponse[any]("Charge created successfully", nil))
 }
-
-func (c *Controller) HandleWebhook(ctx *gin.Context) {
-	body, err := io.ReadAll(ctx.Request.Body)
-	if err != nil {
-		ctx.JSON(http.StatusBadRequest, utils.NewFailResponse("failed to read body"))
-		return
-	}
-
-	if len(body) == 0 {
-		ctx.JSON(http.StatusBadRequest, utils.NewFailResponse("empty body"))
-		return
-	}
-
-	if err := c.service.HandleWebhook(ctx.Request.Context(), body); err != nil {
-		c.logger.Errorw("Failed to handle webhook", "error", err)
-		// Return 200 even on error to avoid Inter retrying endlessly if it's an internal error that won't be fixed by retrying same payload immediately?
-		// Or 500?
-		// Usually 500 triggers retry.
-		// If DB is down, we want retry.
-		ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse(err.Error()))
-		return
-	}
-
-	ctx.Status(http.StatusOK)
-}
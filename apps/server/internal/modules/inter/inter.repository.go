@@ -9,6 +9,7 @@ import (
 type Repository interface {
 	Create(ctx context.Context, config *InterConfig) error
 	GetByOrganizationID(ctx context.Context, organizationID uuid.UUID) (*InterConfig, error)
+	FindAll(ctx context.Context) ([]*InterConfig, error)
 	Update(ctx context.Context, config *InterConfig) error
 	Delete(ctx context.Context, organizationID uuid.UUID) error
 }
@@ -2,22 +2,27 @@ package inter
 
 import (
 	"context"
-	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 	"time"
 
 	"vigi/internal/config"
 	"vigi/internal/modules/client"
 	"vigi/internal/modules/invoice"
+	"vigi/internal/modules/payment"
+	"vigi/internal/secret"
 
 	"github.com/google/uuid"
 )
 
+// ProviderName is the Organization.BankProvider value for Inter
+const ProviderName = "inter"
+
 type Service struct {
 	repo           Repository
-	webhookRepo    WebhookRepository
 	invoiceService *invoice.Service
 	clientService  *client.Service
 	cfg            *config.Config
@@ -25,14 +30,12 @@ type Service struct {
 
 func NewService(
 	repo Repository,
-	webhookRepo WebhookRepository,
 	invoiceService *invoice.Service,
 	clientService *client.Service,
 	cfg *config.Config,
 ) *Service {
 	return &Service{
 		repo:           repo,
-		webhookRepo:    webhookRepo,
 		invoiceService: invoiceService,
 		clientService:  clientService,
 		cfg:            cfg,
@@ -78,13 +81,13 @@ func (s *Service) GetConfig(ctx context.Context, organizationID uuid.UUID) (*Int
 	if config != nil {
 		// Mask sensitive data
 		if config.ClientSecret != "" {
-			config.ClientSecret = "********"
+			config.ClientSecret = secret.Mask
 		}
 		if config.Certificate != "" {
-			config.Certificate = "********"
+			config.Certificate = secret.Mask
 		}
 		if config.Key != "" {
-			config.Key = "********"
+			config.Key = secret.Mask
 		}
 	}
 	return config, nil
@@ -103,15 +106,15 @@ func (s *Service) UpdateConfig(ctx context.Context, organizationID uuid.UUID, dt
 		config.ClientID = *dto.ClientID
 	}
 	// Only update client secret if it's not the masked value
-	if dto.ClientSecret != nil && *dto.ClientSecret != "********" {
+	if dto.ClientSecret != nil && *dto.ClientSecret != secret.Mask {
 		config.ClientSecret = *dto.ClientSecret
 	}
 	// Only update certificate if it's not the masked value
-	if dto.Certificate != nil && *dto.Certificate != "********" {
+	if dto.Certificate != nil && *dto.Certificate != secret.Mask {
 		config.Certificate = *dto.Certificate
 	}
 	// Only update key if it's not the masked value
-	if dto.Key != nil && *dto.Key != "********" {
+	if dto.Key != nil && *dto.Key != secret.Mask {
 		config.Key = *dto.Key
 	}
 	if dto.AccountNumber != nil {
@@ -132,13 +135,13 @@ func (s *Service) UpdateConfig(ctx context.Context, organizationID uuid.UUID, dt
 
 	// Mask config before returning
 	if config.ClientSecret != "" {
-		config.ClientSecret = "********"
+		config.ClientSecret = secret.Mask
 	}
 	if config.Certificate != "" {
-		config.Certificate = "********"
+		config.Certificate = secret.Mask
 	}
 	if config.Key != "" {
-		config.Key = "********"
+		config.Key = secret.Mask
 	}
 
 	return config, nil
@@ -162,28 +165,59 @@ func (s *Service) CreateCharge(ctx context.Context, organizationID uuid.UUID, dt
 		return fmt.Errorf("invoice does not belong to organization")
 	}
 
+	charge, err := s.IssueCharge(ctx, organizationID, inv)
+	if err != nil {
+		return err
+	}
+
+	provider := ProviderName
+	updateDto := invoice.UpdateInvoiceDTO{
+		BankInvoiceID:     &charge.ID,
+		BankInvoiceStatus: &charge.BankStatus,
+		BankProvider:      &provider,
+	}
+	if charge.PixPayload != "" {
+		updateDto.BankPixPayload = &charge.PixPayload
+	}
+	if charge.BoletoBarcode != "" {
+		updateDto.BankBoletoBarcode = &charge.BoletoBarcode
+	}
+	if charge.BoletoDigitableLine != "" {
+		updateDto.BankBoletoDigitableLine = &charge.BoletoDigitableLine
+	}
+
+	_, err = s.invoiceService.Update(ctx, invoiceID, updateDto)
+	if err != nil {
+		return fmt.Errorf("failed to update invoice with bank info: %w", err)
+	}
+
+	return nil
+}
+
+// IssueCharge registers a boleto with PIX at Inter for the invoice
+func (s *Service) IssueCharge(ctx context.Context, organizationID uuid.UUID, inv *invoice.Invoice) (*payment.Charge, error) {
 	// Fetch Client
 	cli, err := s.clientService.GetByID(ctx, inv.ClientID)
 	if err != nil {
-		return fmt.Errorf("failed to get client: %w", err)
+		return nil, fmt.Errorf("failed to get client: %w", err)
 	}
 	if cli == nil {
-		return fmt.Errorf("client not found")
+		return nil, fmt.Errorf("client not found")
 	}
 
 	// Fetch Inter Config (Raw)
 	config, err := s.repo.GetByOrganizationID(ctx, organizationID)
 	if err != nil {
-		return fmt.Errorf("failed to get inter config: %w", err)
+		return nil, fmt.Errorf("failed to get inter config: %w", err)
 	}
 	if config == nil {
-		return fmt.Errorf("inter integration not configured")
+		return nil, fmt.Errorf("inter integration not configured")
 	}
 
 	// Prepare Inter Client
 	interClient, err := NewInterClient(config)
 	if err != nil {
-		return fmt.Errorf("failed to create inter client: %w", err)
+		return nil, fmt.Errorf("failed to create inter client: %w", err)
 	}
 
 	// Prepare Payer
@@ -325,209 +359,138 @@ func (s *Service) CreateCharge(ctx context.Context, organizationID uuid.UUID, dt
 	// Call Inter
 	resp, err := interClient.CreateCharge(reqData)
 	if err != nil {
-		return fmt.Errorf("inter api error: %w", err)
+		return nil, fmt.Errorf("inter api error: %w", err)
 	}
 
-	bankInvID := resp.CodigoSolicitacao
-	bankStatus := "CREATED"
+	charge := &payment.Charge{
+		ID:         resp.CodigoSolicitacao,
+		Status:     payment.ChargeStatusPending,
+		BankStatus: "CREATED",
+	}
 
-	// Fetch PIX info
-	var pixPayload *string
-	pixInfo, err := interClient.GetCharge(bankInvID)
+	// Fetch PIX and boleto info
+	chargeInfo, err := interClient.GetCharge(charge.ID)
 	if err != nil {
 		// Log warning but don't fail, similar to Asaas
 		fmt.Printf("Warning: failed to get inter pix info: %v\n", err)
 	} else {
-		if pixInfo.Pix.PixCopiaECola != "" {
-			pixPayload = &pixInfo.Pix.PixCopiaECola
-		}
+		charge.PixPayload = chargeInfo.Pix.PixCopiaECola
+		charge.BoletoBarcode = chargeInfo.Boleto.CodigoBarras
+		charge.BoletoDigitableLine = chargeInfo.Boleto.LinhaDigitavel
 	}
 
-	updateDto := invoice.UpdateInvoiceDTO{
-		BankInvoiceID:     &bankInvID,
-		BankInvoiceStatus: &bankStatus,
-		BankPixPayload:    pixPayload,
-	}
-
-	_, err = s.invoiceService.Update(ctx, invoiceID, updateDto)
-	if err != nil {
-		return fmt.Errorf("failed to update invoice with bank info: %w", err)
-	}
-
-	return nil
+	return charge, nil
 }
 
-func (s *Service) registerWebhook(config *InterConfig) error {
-	if s.cfg.ClientURL == "" {
-		return fmt.Errorf("CLIENT_URL is not configured")
+// FetchCharge returns the current state of an Inter charge
+func (s *Service) FetchCharge(ctx context.Context, organizationID uuid.UUID, requestCode string) (*payment.Charge, error) {
+	interClient, err := s.interClient(ctx, organizationID)
+	if err != nil {
+		return nil, err
 	}
-	webhookUrl := s.cfg.ClientURL + "/api/v1/integrations/inter/webhook"
 
-	client, err := NewInterClient(config)
+	chargeInfo, err := interClient.GetCharge(requestCode)
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("failed to get charge info from inter: %w", err)
 	}
 
-	return client.RegisterWebhook(webhookUrl)
+	return &payment.Charge{
+		ID:                  requestCode,
+		Status:              chargeStatus(chargeInfo.Cobranca.Situacao),
+		BankStatus:          chargeInfo.Cobranca.Situacao,
+		PixPayload:          chargeInfo.Pix.PixCopiaECola,
+		BoletoBarcode:       chargeInfo.Boleto.CodigoBarras,
+		BoletoDigitableLine: chargeInfo.Boleto.LinhaDigitavel,
+	}, nil
 }
 
-func (s *Service) HandleWebhook(ctx context.Context, payload []byte) error {
-	// 1. Log event
-	event := &WebhookEvent{
-		ID:        uuid.New(),
-		Provider:  "inter",
-		Payload:   string(payload),
-		Processed: false,
+// CancelCharge cancels an Inter charge
+func (s *Service) CancelCharge(ctx context.Context, organizationID uuid.UUID, requestCode string) error {
+	interClient, err := s.interClient(ctx, organizationID)
+	if err != nil {
+		return err
 	}
-	if err := s.webhookRepo.Create(ctx, event); err != nil {
-		return fmt.Errorf("failed to log webhook event: %w", err)
+	if err := interClient.CancelCharge(requestCode, "ACERTOS"); err != nil {
+		return fmt.Errorf("failed to cancel inter charge: %w", err)
 	}
+	return nil
+}
 
-	// 2. Parse
+// ParseWebhook returns the charge events of an Inter webhook. Inter does not sign webhook
+// calls, so the status of each charge is confirmed with the API instead of trusting the payload.
+func (s *Service) ParseWebhook(ctx context.Context, organizationID uuid.UUID, header http.Header, payload []byte) ([]payment.ChargeEvent, error) {
 	var webhookEvents InterWebhookPayload
 	if err := json.Unmarshal(payload, &webhookEvents); err != nil {
-		errMsg := fmt.Sprintf("failed to parse payload: %v", err)
-		event.Error = &errMsg
-		_ = s.webhookRepo.Update(ctx, event)
-		return nil // Return nil to avoid Inter retrying invalid payload
+		return nil, fmt.Errorf("%w: %v", payment.ErrInvalidWebhookPayload, err)
 	}
 
-	// 3. Process
+	var events []payment.ChargeEvent
 	for _, item := range webhookEvents {
-		// Log helpful info
-		fmt.Printf("Processing Inte webhook event: %v\n", item)
-
-		if item.Situacao == "RECEBIDO" || item.Situacao == "PAGO" {
-			targetID := item.CodigoSolicitacao
-			if targetID == "" {
-				// Fallback to NossoNumero if CodigoSolicitacao is missing
-				targetID = item.NossoNumero
-			}
-
-			if targetID == "" {
-				continue
-			}
-
-			// Find invoice
-			inv, err := s.invoiceService.GetByBankID(ctx, targetID)
-			if err != nil {
-				if err == sql.ErrNoRows {
-					// Not found, maybe log?
-					continue
-				}
-				// Other error
-				errMsg := fmt.Sprintf("db error: %v", err)
-				event.Error = &errMsg
-				_ = s.webhookRepo.Update(ctx, event)
-				return nil
-			}
+		targetID := item.CodigoSolicitacao
+		if targetID == "" {
+			continue
+		}
 
-			if inv != nil {
-				// Update event resource
-				event.ResourceID = &inv.ID
-
-				// Update Invoice Status
-				status := invoice.InvoiceStatusPaid
-				updateDto := invoice.UpdateInvoiceDTO{
-					Status:            &status,
-					BankInvoiceStatus: &item.Situacao,
-				}
-				_, err = s.invoiceService.Update(ctx, inv.ID, updateDto)
-				if err != nil {
-					errMsg := fmt.Sprintf("failed to update invoice %s: %v", inv.ID, err)
-					event.Error = &errMsg
-					_ = s.webhookRepo.Update(ctx, event)
-					return nil
-				}
-			}
-		} else if item.Situacao == "CANCELADO" || item.Situacao == "BAIXADO" {
-			// Handle cancellation logic if needed
-			// For now just update bank status
-			targetID := item.CodigoSolicitacao
-			if targetID == "" {
-				targetID = item.NossoNumero
-			}
-			if targetID != "" {
-				inv, err := s.invoiceService.GetByBankID(ctx, targetID)
-				if err == nil && inv != nil {
-					event.ResourceID = &inv.ID
-					updateDto := invoice.UpdateInvoiceDTO{
-						BankInvoiceStatus: &item.Situacao,
-					}
-					_, _ = s.invoiceService.Update(ctx, inv.ID, updateDto)
-				}
-			}
+		charge, err := s.FetchCharge(ctx, organizationID, targetID)
+		if err != nil {
+			return nil, err
 		}
-	}
 
-	event.Processed = true
-	if err := s.webhookRepo.Update(ctx, event); err != nil {
-		return fmt.Errorf("failed to update event status: %w", err)
+		events = append(events, payment.ChargeEvent{
+			ChargeID:   charge.ID,
+			Status:     charge.Status,
+			BankStatus: charge.BankStatus,
+		})
 	}
 
-	return nil
+	return events, nil
 }
 
-func (s *Service) SyncCharge(ctx context.Context, organizationID uuid.UUID, invoiceID uuid.UUID) error {
-	inv, err := s.invoiceService.GetByID(ctx, invoiceID)
-	if err != nil {
-		return fmt.Errorf("failed to get invoice: %w", err)
-	}
-	if inv == nil {
-		return fmt.Errorf("invoice not found")
-	}
-	if inv.OrganizationID != organizationID {
-		return fmt.Errorf("invoice does not belong to organization")
-	}
-	if inv.BankInvoiceID == nil {
-		return fmt.Errorf("invoice has no bank invoice id")
-	}
-
-	// Fetch Inter Config (Raw)
+func (s *Service) interClient(ctx context.Context, organizationID uuid.UUID) (*InterClient, error) {
 	config, err := s.repo.GetByOrganizationID(ctx, organizationID)
 	if err != nil {
-		return fmt.Errorf("failed to get inter config: %w", err)
+		return nil, fmt.Errorf("failed to get inter config: %w", err)
 	}
 	if config == nil {
-		return fmt.Errorf("inter integration not configured")
+		return nil, fmt.Errorf("inter integration not configured")
 	}
 
-	// Prepare Inter Client
 	interClient, err := NewInterClient(config)
 	if err != nil {
-		return fmt.Errorf("failed to create inter client: %w", err)
+		return nil, fmt.Errorf("failed to create inter client: %w", err)
 	}
+	return interClient, nil
+}
 
-	// Fetch Charge Info
-	chargeInfo, err := interClient.GetCharge(*inv.BankInvoiceID)
+// RegisterWebhooks points the webhook of every configured Inter account at its
+// organization scoped URL
+func (s *Service) RegisterWebhooks(ctx context.Context) error {
+	configs, err := s.repo.FindAll(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to get charge info from inter: %w", err)
+		return fmt.Errorf("failed to list inter configs: %w", err)
 	}
 
-	// Update Invoice
-	updateDto := invoice.UpdateInvoiceDTO{}
-
-	if chargeInfo.Pix.PixCopiaECola != "" {
-		updateDto.BankPixPayload = &chargeInfo.Pix.PixCopiaECola
-	}
-
-	if chargeInfo.Boleto.CodigoBarras != "" {
-		updateDto.BankBoletoBarcode = &chargeInfo.Boleto.CodigoBarras
-	}
-	if chargeInfo.Boleto.LinhaDigitavel != "" {
-		updateDto.BankBoletoDigitableLine = &chargeInfo.Boleto.LinhaDigitavel
+	var errs []error
+	for _, config := range configs {
+		if err := s.registerWebhook(config); err != nil {
+			errs = append(errs, fmt.Errorf("organization %s: %w", config.OrganizationID, err))
+		}
 	}
+	return errors.Join(errs...)
+}
 
-	if chargeInfo.Cobranca.Situacao != "" {
-		updateDto.BankInvoiceStatus = &chargeInfo.Cobranca.Situacao
-		// Map Inter status to internal status if needed
+func (s *Service) registerWebhook(config *InterConfig) error {
+	if s.cfg.ClientURL == "" {
+		return fmt.Errorf("CLIENT_URL is not configured")
 	}
+	webhookUrl := fmt.Sprintf("%s/api/v1/payments/%s/webhook/%s", s.cfg.ClientURL, ProviderName, config.OrganizationID)
 
-	if _, err := s.invoiceService.Update(ctx, invoiceID, updateDto); err != nil {
-		return fmt.Errorf("failed to update invoice details: %w", err)
+	client, err := NewInterClient(config)
+	if err != nil {
+		return err
 	}
 
-	return nil
+	return client.RegisterWebhook(webhookUrl)
 }
 
 func sanitizeCpfCnpj(s string) string {
@@ -2,23 +2,61 @@ package inter
 
 import (
 	"context"
+	"vigi/internal/secret"
 
 	"github.com/google/uuid"
 	"github.com/uptrace/bun"
 )
 
 type SQLRepository struct {
-	db *bun.DB
+	db      *bun.DB
+	keyring *secret.Keyring
 }
 
-func NewSQLRepository(db *bun.DB) *SQLRepository {
+func NewSQLRepository(db *bun.DB, keyring *secret.Keyring) *SQLRepository {
 	return &SQLRepository{
-		db: db,
+		db:      db,
+		keyring: keyring,
 	}
 }
 
+// seal encrypts the credentials of config in place and returns a function
+// putting the plain text values back once the query ran
+func (r *SQLRepository) seal(config *InterConfig) (func(), error) {
+	clientSecret, key := config.ClientSecret, config.Key
+
+	encryptedSecret, err := r.keyring.Encrypt(clientSecret)
+	if err != nil {
+		return nil, err
+	}
+	encryptedKey, err := r.keyring.Encrypt(key)
+	if err != nil {
+		return nil, err
+	}
+
+	config.ClientSecret, config.Key = encryptedSecret, encryptedKey
+	return func() {
+		config.ClientSecret, config.Key = clientSecret, key
+	}, nil
+}
+
+func (r *SQLRepository) open(config *InterConfig) error {
+	var err error
+	if config.ClientSecret, err = r.keyring.Decrypt(config.ClientSecret); err != nil {
+		return err
+	}
+	config.Key, err = r.keyring.Decrypt(config.Key)
+	return err
+}
+
 func (r *SQLRepository) Create(ctx context.Context, config *InterConfig) error {
-	_, err := r.db.NewInsert().Model(config).Exec(ctx)
+	restore, err := r.seal(config)
+	if err != nil {
+		return err
+	}
+	defer restore()
+
+	_, err = r.db.NewInsert().Model(config).Exec(ctx)
 	return err
 }
 
@@ -28,11 +66,33 @@ func (r *SQLRepository) GetByOrganizationID(ctx context.Context, organizationID
 	if err != nil {
 		return nil, err
 	}
+	if err := r.open(config); err != nil {
+		return nil, err
+	}
 	return config, nil
 }
 
+func (r *SQLRepository) FindAll(ctx context.Context) ([]*InterConfig, error) {
+	var configs []*InterConfig
+	if err := r.db.NewSelect().Model(&configs).Scan(ctx); err != nil {
+		return nil, err
+	}
+	for _, config := range configs {
+		if err := r.open(config); err != nil {
+			return nil, err
+		}
+	}
+	return configs, nil
+}
+
 func (r *SQLRepository) Update(ctx context.Context, config *InterConfig) error {
-	_, err := r.db.NewUpdate().Model(config).WherePK().Exec(ctx)
+	restore, err := r.seal(config)
+	if err != nil {
+		return err
+	}
+	defer restore()
+
+	_, err = r.db.NewUpdate().Model(config).WherePK().Exec(ctx)
 	return err
 }
 
@@ -0,0 +1,17 @@
+package inter
+
+import "vigi/internal/modules/payment"
+
+// chargeStatus maps an Inter charge situation onto the provider independent charge status
+func chargeStatus(situacao string) payment.ChargeStatus {
+	switch situacao {
+	case "RECEBIDO", "PAGO", "MARCADO_RECEBIDO":
+		return payment.ChargeStatusPaid
+	case "ATRASADO":
+		return payment.ChargeStatusOverdue
+	case "CANCELADO", "EXPIRADO", "BAIXADO":
+		return payment.ChargeStatusCancelled
+	default:
+		return payment.ChargeStatusPending
+	}
+}
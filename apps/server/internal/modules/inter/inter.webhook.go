@@ -0,0 +1,55 @@
+package inter
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+const (
+	// webhookURLKey is the Redis key holding the CLIENT_URL the Inter webhooks were last registered with
+	webhookURLKey = "vigi:inter:webhook-url"
+	// webhookSyncLockKey keeps API replicas starting together from registering the webhooks twice
+	webhookSyncLockKey = "vigi:inter:webhook-sync"
+	webhookSyncLockTTL = 10 * time.Minute
+)
+
+// SyncWebhooks registers the webhook of every Inter account again when CLIENT_URL
+// changed since the last sync. Accounts set up before webhooks were scoped by
+// organization still post to the removed /integrations/inter/webhook route, so
+// the first start after the upgrade moves them to /payments/inter/webhook/:organizationId.
+// Failed registrations leave the sync pending for the next start.
+func SyncWebhooks(ctx context.Context, client *redis.Client, service *Service, logger *zap.SugaredLogger) {
+	logger = logger.Named("[inter-webhook-sync]")
+	clientURL := service.cfg.ClientURL
+	if clientURL == "" {
+		return
+	}
+
+	synced, err := client.Get(ctx, webhookURLKey).Result()
+	if err != nil && err != redis.Nil {
+		logger.Warnw("Failed to read the registered Inter webhook URL", "error", err)
+		return
+	}
+	if synced == clientURL {
+		return
+	}
+
+	locked, err := client.SetNX(ctx, webhookSyncLockKey, clientURL, webhookSyncLockTTL).Result()
+	if err != nil || !locked {
+		return
+	}
+	defer client.Del(ctx, webhookSyncLockKey)
+
+	if err := service.RegisterWebhooks(ctx); err != nil {
+		logger.Errorw("Failed to register Inter webhooks", "error", err)
+		return
+	}
+	if err := client.Set(ctx, webhookURLKey, clientURL, 0).Err(); err != nil {
+		logger.Warnw("Failed to record the registered Inter webhook URL", "error", err)
+		return
+	}
+	logger.Infow("Registered Inter webhooks", "client_url", clientURL)
+}
@@ -242,6 +242,42 @@ func (c *InterClient) GetCharge(requestCode string) (*InterGetChargeResponse, er
 	return &result, nil
 }
 
+type InterCancelRequest struct {
+	MotivoCancelamento string `json:"motivoCancelamento"`
+}
+
+func (c *InterClient) CancelCharge(requestCode, reason string) error {
+	if err := c.Authenticate(); err != nil {
+		return err
+	}
+
+	jsonData, err := json.Marshal(InterCancelRequest{MotivoCancelamento: reason})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", c.baseURL+"/cobranca/v3/cobrancas/"+requestCode+"/cancelar", bytes.NewReader(jsonData))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to cancel charge: %d - %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
 type WebhookRequest struct {
 	WebhookUrl string `json:"webhookUrl"`
 }
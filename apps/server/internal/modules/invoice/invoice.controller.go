@@ -371,3 +371,50 @@ func (c *Controller) GetStats(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, utils.NewSuccessResponse("success", stats))
 }
+
+func (c *Controller) GetPDF(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.NewFailResponse("Invalid ID"))
+		return
+	}
+
+	entity, err := c.service.GetByID(ctx.Request.Context(), id)
+	if err != nil || entity == nil || entity.OrganizationID.String() != ctx.GetString("orgId") {
+		ctx.JSON(http.StatusNotFound, utils.NewFailResponse("Invoice not found"))
+		return
+	}
+
+	pdf, err := c.service.RenderPDF(ctx.Request.Context(), entity)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse("Failed to render invoice PDF"))
+		return
+	}
+
+	c.writePDF(ctx, entity, pdf)
+}
+
+func (c *Controller) GetPublicPDF(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.NewFailResponse("Invalid ID"))
+		return
+	}
+
+	entity, pdf, err := c.service.RenderPublicPDF(ctx.Request.Context(), id)
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, utils.NewFailResponse("Invoice not found"))
+		return
+	}
+
+	c.writePDF(ctx, entity, pdf)
+}
+
+func (c *Controller) writePDF(ctx *gin.Context, entity *Invoice, pdf []byte) {
+	disposition := "attachment"
+	if ctx.Query("inline") == "true" {
+		disposition = "inline"
+	}
+	ctx.Header("Content-Disposition", fmt.Sprintf("%s; filename=%q", disposition, pdfFilename(entity)))
+	ctx.Data(http.StatusOK, "application/pdf", pdf)
+}
@@ -28,6 +28,10 @@ type CreateInvoiceDTO struct {
 	BankInvoiceStatus *string                `json:"bankInvoiceStatus"`
 	Discount          float64                `json:"discount" validate:"gte=0"`
 	Items             []CreateInvoiceItemDTO `json:"items" validate:"required,min=1,dive"`
+
+	// Set internally when the invoice is generated from a recurring invoice
+	RecurringInvoiceID *uuid.UUID `json:"-"`
+	RecurrencePeriod   *time.Time `json:"-"`
 }
 
 type UpdateInvoiceDTO struct {
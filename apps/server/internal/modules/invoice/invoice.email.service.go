@@ -2,6 +2,7 @@ package invoice
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
 	"strings"
 	"time"
@@ -153,6 +154,16 @@ func (s *Service) sendInvoiceEmail(ctx context.Context, id uuid.UUID, emailType
 		},
 	}
 
+	if pdf, err := s.renderPDF(ctx, invoice, true); err != nil {
+		// Still send the email, the invoice is also available through the public link
+		fmt.Printf("Error rendering invoice pdf: %v\n", err)
+	} else {
+		req.Attachments = []usesend.Attachment{{
+			Filename: pdfFilename(invoice),
+			Content:  base64.StdEncoding.EncodeToString(pdf),
+		}}
+	}
+
 	resp, err := s.usesendClient.SendEmail(ctx, req)
 	if err != nil {
 		fmt.Printf("Error sending email: %v\n", err)
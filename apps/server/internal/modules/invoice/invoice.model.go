@@ -80,6 +80,8 @@ type Invoice struct {
 	BankBoletoBarcode       *string        `bun:"bank_boleto_barcode" json:"bankBoletoBarcode"`
 	BankBoletoDigitableLine *string        `bun:"bank_boleto_digitable_line" json:"bankBoletoDigitableLine"`
 	Currency                string         `bun:"currency,notnull,default:'BRL'" json:"currency"`
+	RecurringInvoiceID      *uuid.UUID     `bun:"recurring_invoice_id,type:uuid,nullzero" json:"recurringInvoiceId"`
+	RecurrencePeriod        *time.Time     `bun:"recurrence_period" json:"recurrencePeriod"`
 
 	Items []*InvoiceItem `bun:"rel:has-many,join:id=invoice_id" json:"items"`
 
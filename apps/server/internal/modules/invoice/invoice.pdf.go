@@ -0,0 +1,497 @@
+package invoice
+
+import (
+	"bytes"
+	"fmt"
+	"image/png"
+	"math"
+	"net/http"
+	"strings"
+
+	"vigi/internal/modules/client"
+	"vigi/internal/modules/organization"
+
+	"github.com/boombuler/barcode"
+	"github.com/boombuler/barcode/qr"
+	"github.com/boombuler/barcode/twooffive"
+	"github.com/go-pdf/fpdf"
+)
+
+const (
+	pdfPageWidth   = 210.0
+	pdfMargin      = 15.0
+	pdfContentWide = pdfPageWidth - 2*pdfMargin
+	pdfLogoMaxW    = 40.0
+	pdfLogoMaxH    = 20.0
+	pdfQRCodeSize  = 40.0
+)
+
+var currencySymbols = map[string]string{
+	"BRL": "R$",
+	"USD": "US$",
+	"EUR": "€",
+	"GBP": "£",
+}
+
+// pdfDocument holds everything needed to render an invoice PDF. Logo is optional
+// and must be a PNG, JPEG or GIF image.
+type pdfDocument struct {
+	Invoice      *Invoice
+	Organization *organization.Organization
+	Client       *client.Client
+	Logo         []byte
+}
+
+// renderInvoicePDF renders an A4 invoice with the organization branding, the client address,
+// the line items and totals, and the Pix QR code or boleto digitable line when available
+func renderInvoicePDF(doc pdfDocument) ([]byte, error) {
+	inv := doc.Invoice
+
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(pdfMargin, pdfMargin, pdfMargin)
+	pdf.SetAutoPageBreak(true, pdfMargin+5)
+	pdf.SetTitle(fmt.Sprintf("Fatura %s", inv.Number), true)
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	orgName := ""
+	if doc.Organization != nil {
+		orgName = doc.Organization.Name
+		pdf.SetAuthor(orgName, true)
+	}
+
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-pdfMargin)
+		pdf.SetFont("Helvetica", "", 8)
+		pdf.SetTextColor(156, 163, 175)
+		pdf.CellFormat(pdfContentWide/2, 5, tr(orgName), "", 0, "L", false, 0, "")
+		pdf.CellFormat(pdfContentWide/2, 5, fmt.Sprintf("%d/{nb}", pdf.PageNo()), "", 0, "R", false, 0, "")
+	})
+	pdf.AliasNbPages("")
+	pdf.AddPage()
+
+	renderPDFHeader(pdf, tr, doc)
+	renderPDFBillTo(pdf, tr, doc.Client)
+	renderPDFItems(pdf, tr, inv)
+	renderPDFTotals(pdf, tr, inv)
+	if err := renderPDFPayment(pdf, tr, inv); err != nil {
+		return nil, err
+	}
+	renderPDFNotes(pdf, tr, inv)
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, fmt.Errorf("failed to render invoice pdf: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func renderPDFHeader(pdf *fpdf.Fpdf, tr func(string) string, doc pdfDocument) {
+	inv := doc.Invoice
+	top := pdf.GetY()
+
+	// Left: logo and organization name
+	nameY := top
+	if len(doc.Logo) > 0 {
+		if imageType := logoImageType(doc.Logo); imageType != "" {
+			opts := fpdf.ImageOptions{ImageType: imageType, ReadDpi: true}
+			info := pdf.RegisterImageOptionsReader("logo", opts, bytes.NewReader(doc.Logo))
+			if pdf.Ok() && info != nil {
+				w, h := fitImage(info.Width(), info.Height(), pdfLogoMaxW, pdfLogoMaxH)
+				pdf.ImageOptions("logo", pdfMargin, top, w, h, false, opts, 0, "")
+				nameY = top + h + 2
+			} else {
+				// A broken logo should not prevent the invoice from rendering
+				pdf.ClearError()
+			}
+		}
+	}
+	if doc.Organization != nil {
+		pdf.SetXY(pdfMargin, nameY)
+		pdf.SetFont("Helvetica", "B", 14)
+		pdf.SetTextColor(17, 24, 39)
+		pdf.CellFormat(pdfContentWide/2, 7, tr(doc.Organization.Name), "", 0, "L", false, 0, "")
+	}
+	leftBottom := nameY + 7
+
+	// Right: invoice number, dates and status
+	rightX := pdfMargin + pdfContentWide/2
+	pdf.SetXY(rightX, top)
+	pdf.SetFont("Helvetica", "B", 20)
+	pdf.SetTextColor(14, 165, 233)
+	pdf.CellFormat(pdfContentWide/2, 10, "FATURA", "", 2, "R", false, 0, "")
+
+	pdf.SetFont("Helvetica", "", 10)
+	pdf.SetTextColor(75, 85, 99)
+	lines := []string{fmt.Sprintf("Nº %s", inv.Number)}
+	if inv.Date != nil {
+		lines = append(lines, fmt.Sprintf("Emissão: %s", inv.Date.Format("02/01/2006")))
+	}
+	if inv.DueDate != nil {
+		lines = append(lines, fmt.Sprintf("Vencimento: %s", inv.DueDate.Format("02/01/2006")))
+	}
+	lines = append(lines, fmt.Sprintf("Situação: %s", statusLabel(inv.Status)))
+	for _, line := range lines {
+		pdf.SetX(rightX)
+		pdf.CellFormat(pdfContentWide/2, 5, tr(line), "", 2, "R", false, 0, "")
+	}
+
+	pdf.SetY(math.Max(leftBottom, pdf.GetY()) + 6)
+	pdf.SetDrawColor(229, 231, 235)
+	pdf.Line(pdfMargin, pdf.GetY(), pdfPageWidth-pdfMargin, pdf.GetY())
+	pdf.Ln(6)
+}
+
+func renderPDFBillTo(pdf *fpdf.Fpdf, tr func(string) string, cli *client.Client) {
+	if cli == nil {
+		return
+	}
+
+	pdf.SetFont("Helvetica", "B", 9)
+	pdf.SetTextColor(107, 114, 128)
+	pdf.CellFormat(pdfContentWide, 5, tr("COBRAR DE"), "", 1, "L", false, 0, "")
+
+	pdf.SetFont("Helvetica", "B", 11)
+	pdf.SetTextColor(17, 24, 39)
+	pdf.CellFormat(pdfContentWide, 6, tr(cli.Name), "", 1, "L", false, 0, "")
+
+	pdf.SetFont("Helvetica", "", 10)
+	pdf.SetTextColor(75, 85, 99)
+	for _, line := range clientAddressLines(cli) {
+		pdf.CellFormat(pdfContentWide, 5, tr(line), "", 1, "L", false, 0, "")
+	}
+	pdf.Ln(6)
+}
+
+// clientAddressLines formats the document number and address of a client, skipping empty fields
+func clientAddressLines(cli *client.Client) []string {
+	var lines []string
+	if v := deref(cli.IDNumber); v != "" {
+		lines = append(lines, "CPF/CNPJ: "+v)
+	}
+
+	street := joinNonEmpty(", ", deref(cli.Address1), deref(cli.AddressNumber))
+	lines = appendNonEmpty(lines, joinNonEmpty(" - ", street, deref(cli.Address2)))
+	lines = appendNonEmpty(lines, deref(cli.Neighborhood))
+	lines = appendNonEmpty(lines, joinNonEmpty(" - ", deref(cli.City), deref(cli.State)))
+	if v := deref(cli.PostalCode); v != "" {
+		lines = append(lines, "CEP: "+v)
+	}
+	return lines
+}
+
+var pdfItemColumns = []struct {
+	title string
+	width float64
+	align string
+}{
+	{"Descrição", 80, "L"},
+	{"Qtd", 20, "R"},
+	{"Preço unit.", 30, "R"},
+	{"Desconto", 25, "R"},
+	{"Total", 25, "R"},
+}
+
+func renderPDFItems(pdf *fpdf.Fpdf, tr func(string) string, inv *Invoice) {
+	header := func() {
+		pdf.SetFont("Helvetica", "B", 9)
+		pdf.SetFillColor(243, 244, 246)
+		pdf.SetTextColor(55, 65, 81)
+		for _, col := range pdfItemColumns {
+			pdf.CellFormat(col.width, 8, tr(col.title), "", 0, col.align, true, 0, "")
+		}
+		pdf.Ln(-1)
+	}
+	header()
+
+	_, pageHeight := pdf.GetPageSize()
+	_, _, _, bottomMargin := pdf.GetMargins()
+
+	pdf.SetFont("Helvetica", "", 9)
+	pdf.SetTextColor(17, 24, 39)
+	for _, item := range inv.Items {
+		descLines := pdf.SplitText(tr(item.Description), pdfItemColumns[0].width-2)
+		rowHeight := math.Max(float64(len(descLines))*5, 7)
+
+		if pdf.GetY()+rowHeight > pageHeight-bottomMargin {
+			pdf.AddPage()
+			header()
+			pdf.SetFont("Helvetica", "", 9)
+			pdf.SetTextColor(17, 24, 39)
+		}
+
+		x, y := pdf.GetX(), pdf.GetY()
+		pdf.MultiCell(pdfItemColumns[0].width, 5, strings.Join(descLines, "\n"), "", "L", false)
+		pdf.SetXY(x+pdfItemColumns[0].width, y)
+
+		values := []string{
+			formatQuantity(float64(item.Quantity)),
+			formatMoney(float64(item.UnitPrice), inv.Currency),
+			formatMoney(float64(item.Discount), inv.Currency),
+			formatMoney(float64(item.Total), inv.Currency),
+		}
+		for i, value := range values {
+			col := pdfItemColumns[i+1]
+			pdf.CellFormat(col.width, 5, tr(value), "", 0, col.align, false, 0, "")
+		}
+
+		pdf.SetXY(pdfMargin, y+rowHeight)
+		pdf.SetDrawColor(229, 231, 235)
+		pdf.Line(pdfMargin, pdf.GetY(), pdfPageWidth-pdfMargin, pdf.GetY())
+		pdf.Ln(1)
+	}
+	pdf.Ln(4)
+}
+
+func renderPDFTotals(pdf *fpdf.Fpdf, tr func(string) string, inv *Invoice) {
+	var subtotal, itemDiscounts float64
+	for _, item := range inv.Items {
+		subtotal += float64(item.Quantity) * float64(item.UnitPrice)
+		itemDiscounts += float64(item.Discount)
+	}
+
+	labelWidth, valueWidth := 40.0, 35.0
+	x := pdfPageWidth - pdfMargin - labelWidth - valueWidth
+	row := func(label, value string, bold bool) {
+		style := ""
+		if bold {
+			style = "B"
+		}
+		pdf.SetX(x)
+		pdf.SetFont("Helvetica", style, 10)
+		pdf.CellFormat(labelWidth, 6, tr(label), "", 0, "L", false, 0, "")
+		pdf.CellFormat(valueWidth, 6, tr(value), "", 1, "R", false, 0, "")
+	}
+
+	pdf.SetTextColor(75, 85, 99)
+	row("Subtotal", formatMoney(subtotal, inv.Currency), false)
+	if itemDiscounts > 0 {
+		row("Descontos nos itens", "-"+formatMoney(itemDiscounts, inv.Currency), false)
+	}
+	if inv.Discount > 0 {
+		row("Desconto", "-"+formatMoney(float64(inv.Discount), inv.Currency), false)
+	}
+	pdf.SetTextColor(17, 24, 39)
+	row("Total", formatMoney(float64(inv.Total), inv.Currency), true)
+	pdf.Ln(6)
+}
+
+func renderPDFPayment(pdf *fpdf.Fpdf, tr func(string) string, inv *Invoice) error {
+	pixPayload := deref(inv.BankPixPayload)
+	digitableLine := deref(inv.BankBoletoDigitableLine)
+	if pixPayload == "" && digitableLine == "" {
+		return nil
+	}
+	if inv.Status == InvoiceStatusPaid || inv.Status == InvoiceStatusCancelled {
+		return nil
+	}
+
+	_, pageHeight := pdf.GetPageSize()
+	if pdf.GetY()+pdfQRCodeSize+25 > pageHeight-pdfMargin {
+		pdf.AddPage()
+	}
+
+	pdf.SetFont("Helvetica", "B", 11)
+	pdf.SetTextColor(17, 24, 39)
+	pdf.CellFormat(pdfContentWide, 7, tr("Pagamento"), "", 1, "L", false, 0, "")
+
+	if pixPayload != "" {
+		qrCode, err := barcodePNG(func() (barcode.Barcode, error) {
+			return qr.Encode(pixPayload, qr.M, qr.Auto)
+		}, 300, 300)
+		if err != nil {
+			return fmt.Errorf("failed to encode pix qr code: %w", err)
+		}
+
+		opts := fpdf.ImageOptions{ImageType: "PNG"}
+		pdf.RegisterImageOptionsReader("pix", opts, bytes.NewReader(qrCode))
+		y := pdf.GetY()
+		pdf.ImageOptions("pix", pdfMargin, y, pdfQRCodeSize, pdfQRCodeSize, false, opts, 0, "")
+
+		textX := pdfMargin + pdfQRCodeSize + 5
+		pdf.SetXY(textX, y)
+		pdf.SetFont("Helvetica", "B", 10)
+		pdf.CellFormat(0, 6, tr("Pix copia e cola"), "", 2, "L", false, 0, "")
+		pdf.SetFont("Courier", "", 7)
+		pdf.SetTextColor(75, 85, 99)
+		pdf.MultiCell(pdfContentWide-pdfQRCodeSize-5, 3.5, pixPayload, "", "L", false)
+		pdf.SetY(math.Max(pdf.GetY(), y+pdfQRCodeSize) + 4)
+	}
+
+	if digitableLine != "" {
+		pdf.SetFont("Helvetica", "B", 10)
+		pdf.SetTextColor(17, 24, 39)
+		pdf.CellFormat(pdfContentWide, 6, tr("Boleto - linha digitável"), "", 1, "L", false, 0, "")
+		pdf.SetFont("Courier", "", 10)
+		pdf.CellFormat(pdfContentWide, 6, digitableLine, "", 1, "L", false, 0, "")
+
+		if barcodeValue := deref(inv.BankBoletoBarcode); barcodeValue != "" {
+			image, err := barcodePNG(func() (barcode.Barcode, error) {
+				return twooffive.Encode(barcodeValue, true)
+			}, 1030, 100)
+			if err == nil {
+				opts := fpdf.ImageOptions{ImageType: "PNG"}
+				pdf.RegisterImageOptionsReader("boleto", opts, bytes.NewReader(image))
+				pdf.ImageOptions("boleto", pdfMargin, pdf.GetY()+2, 103, 13, false, opts, 0, "")
+				pdf.Ln(17)
+			}
+		}
+	}
+	pdf.Ln(4)
+	return nil
+}
+
+func renderPDFNotes(pdf *fpdf.Fpdf, tr func(string) string, inv *Invoice) {
+	sections := []struct{ title, text string }{
+		{"Observações", inv.Notes},
+		{"Termos", inv.Terms},
+	}
+	for _, section := range sections {
+		if strings.TrimSpace(section.text) == "" {
+			continue
+		}
+		pdf.SetFont("Helvetica", "B", 10)
+		pdf.SetTextColor(17, 24, 39)
+		pdf.CellFormat(pdfContentWide, 6, tr(section.title), "", 1, "L", false, 0, "")
+		pdf.SetFont("Helvetica", "", 9)
+		pdf.SetTextColor(75, 85, 99)
+		pdf.MultiCell(pdfContentWide, 4.5, tr(section.text), "", "L", false)
+		pdf.Ln(4)
+	}
+}
+
+func barcodePNG(encode func() (barcode.Barcode, error), width, height int) ([]byte, error) {
+	code, err := encode()
+	if err != nil {
+		return nil, err
+	}
+	scaled, err := barcode.Scale(code, width, height)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, scaled); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// formatMoney formats an amount in the invoice currency. BRL uses Brazilian separators
+// (R$ 1.234,56); other currencies use 1,234.56.
+func formatMoney(amount float64, currency string) string {
+	if currency == "" {
+		currency = "BRL"
+	}
+	symbol, ok := currencySymbols[currency]
+	if !ok {
+		symbol = currency
+	}
+
+	thousands, decimal := ",", "."
+	if currency == "BRL" {
+		thousands, decimal = ".", ","
+	}
+
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	cents := int64(math.Round(amount * 100))
+	whole := groupThousands(cents/100, thousands)
+	return fmt.Sprintf("%s%s %s%s%02d", sign, symbol, whole, decimal, cents%100)
+}
+
+func groupThousands(n int64, sep string) string {
+	digits := fmt.Sprintf("%d", n)
+	var b strings.Builder
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteString(sep)
+		}
+		b.WriteRune(d)
+	}
+	return b.String()
+}
+
+func formatQuantity(q float64) string {
+	if q == math.Trunc(q) {
+		return fmt.Sprintf("%.0f", q)
+	}
+	return strings.TrimRight(strings.TrimRight(fmt.Sprintf("%.3f", q), "0"), ".")
+}
+
+func statusLabel(status InvoiceStatus) string {
+	switch status {
+	case InvoiceStatusDraft:
+		return "Rascunho"
+	case InvoiceStatusSent:
+		return "Em aberto"
+	case InvoiceStatusPaid:
+		return "Paga"
+	case InvoiceStatusCancelled:
+		return "Cancelada"
+	default:
+		return string(status)
+	}
+}
+
+// logoImageType returns the fpdf image type of a logo, or "" when the format is not supported
+func logoImageType(data []byte) string {
+	switch http.DetectContentType(data) {
+	case "image/png":
+		return "PNG"
+	case "image/jpeg":
+		return "JPG"
+	case "image/gif":
+		return "GIF"
+	default:
+		return ""
+	}
+}
+
+// fitImage scales an image to fit in maxW x maxH keeping its aspect ratio
+func fitImage(w, h, maxW, maxH float64) (float64, float64) {
+	if w <= 0 || h <= 0 {
+		return maxW, maxH
+	}
+	scale := math.Min(maxW/w, maxH/h)
+	return w * scale, h * scale
+}
+
+func pdfFilename(inv *Invoice) string {
+	name := strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r == '"' || r < 32 {
+			return '-'
+		}
+		return r
+	}, inv.Number)
+	if name == "" {
+		name = inv.ID.String()
+	}
+	return fmt.Sprintf("fatura-%s.pdf", name)
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return strings.TrimSpace(*s)
+}
+
+func joinNonEmpty(sep string, parts ...string) string {
+	var nonEmpty []string
+	for _, p := range parts {
+		if p != "" {
+			nonEmpty = append(nonEmpty, p)
+		}
+	}
+	return strings.Join(nonEmpty, sep)
+}
+
+func appendNonEmpty(lines []string, line string) []string {
+	if line == "" {
+		return lines
+	}
+	return append(lines, line)
+}
@@ -0,0 +1,154 @@
+package invoice
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	logoFetchTimeout = 5 * time.Second
+	logoMaxBytes     = 2 << 20
+	logoCacheTTL     = time.Hour
+)
+
+// logoCache keeps the logos fetched for invoice PDFs, failed fetches included,
+// so rendering does not reach the logo URL every time
+type logoCache struct {
+	mu      sync.Mutex
+	entries map[string]cachedLogo
+}
+
+type cachedLogo struct {
+	data      []byte
+	fetchedAt time.Time
+}
+
+func newLogoCache() *logoCache {
+	return &logoCache{entries: make(map[string]cachedLogo)}
+}
+
+// get returns the cached logo for url and whether it is still fresh
+func (c *logoCache) get(url string, now time.Time) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[url]
+	if !ok || now.Sub(entry.fetchedAt) > logoCacheTTL {
+		return nil, false
+	}
+	return entry.data, true
+}
+
+func (c *logoCache) set(url string, data []byte, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, entry := range c.entries {
+		if now.Sub(entry.fetchedAt) > logoCacheTTL {
+			delete(c.entries, key)
+		}
+	}
+	c.entries[url] = cachedLogo{data: data, fetchedAt: now}
+}
+
+// RenderPDF renders the invoice as a PDF with the branding of its organization.
+// Callers check that the invoice belongs to the caller's organization first.
+func (s *Service) RenderPDF(ctx context.Context, invoice *Invoice) ([]byte, error) {
+	return s.renderPDF(ctx, invoice, true)
+}
+
+// RenderPublicPDF renders the invoice for the public link. Anyone holding the
+// link can call it, so the logo is only taken from the cache and never fetched.
+func (s *Service) RenderPublicPDF(ctx context.Context, id uuid.UUID) (*Invoice, []byte, error) {
+	invoice, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if invoice == nil {
+		return nil, nil, fmt.Errorf("invoice not found")
+	}
+
+	pdf, err := s.renderPDF(ctx, invoice, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	return invoice, pdf, nil
+}
+
+func (s *Service) renderPDF(ctx context.Context, invoice *Invoice, fetchLogo bool) ([]byte, error) {
+	org, err := s.orgRepo.FindByID(ctx, invoice.OrganizationID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch organization: %w", err)
+	}
+
+	clientEntity := invoice.Client
+	if clientEntity == nil {
+		clientEntity, err = s.clientRepo.GetByID(ctx, invoice.ClientID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch client: %w", err)
+		}
+	}
+
+	doc := pdfDocument{
+		Invoice:      invoice,
+		Organization: org,
+		Client:       clientEntity,
+	}
+	if org != nil && org.ImageURL != "" {
+		doc.Logo = s.logo(ctx, org.ImageURL, fetchLogo)
+	}
+
+	return renderInvoicePDF(doc)
+}
+
+// logo returns the logo at url, from the cache when possible. The logo is
+// decoration, an unreachable image just leaves it out.
+func (s *Service) logo(ctx context.Context, url string, fetch bool) []byte {
+	now := time.Now()
+	if data, ok := s.logos.get(url, now); ok || !fetch {
+		return data
+	}
+
+	data, err := s.fetchLogo(ctx, url)
+	if err != nil {
+		data = nil
+	}
+	s.logos.set(url, data, now)
+	return data
+}
+
+func (s *Service) fetchLogo(ctx context.Context, url string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, logoFetchTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.logoClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status fetching logo: %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, logoMaxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > logoMaxBytes {
+		return nil, fmt.Errorf("logo exceeds %d bytes", logoMaxBytes)
+	}
+	if logoImageType(data) == "" {
+		return nil, fmt.Errorf("unsupported logo format")
+	}
+	return data, nil
+}
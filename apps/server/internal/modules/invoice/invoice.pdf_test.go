@@ -0,0 +1,124 @@
+package invoice
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"vigi/internal/modules/client"
+	"vigi/internal/modules/organization"
+	"vigi/internal/pkg/safehttp"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func strPtr(s string) *string { return &s }
+
+func TestFormatMoney(t *testing.T) {
+	tests := []struct {
+		amount   float64
+		currency string
+		expected string
+	}{
+		{1234.56, "BRL", "R$ 1.234,56"},
+		{0, "BRL", "R$ 0,00"},
+		{1000000, "", "R$ 1.000.000,00"},
+		{99.999, "USD", "US$ 100.00"},
+		{-12.5, "EUR", "-€ 12.50"},
+		{1500, "JPY", "JPY 1,500.00"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, formatMoney(tt.amount, tt.currency))
+	}
+}
+
+func TestClientAddressLines(t *testing.T) {
+	cli := &client.Client{
+		IDNumber:      strPtr("12.345.678/0001-90"),
+		Address1:      strPtr("Rua das Flores"),
+		AddressNumber: strPtr("100"),
+		Neighborhood:  strPtr("Centro"),
+		City:          strPtr("São Paulo"),
+		State:         strPtr("SP"),
+		PostalCode:    strPtr("01000-000"),
+	}
+
+	assert.Equal(t, []string{
+		"CPF/CNPJ: 12.345.678/0001-90",
+		"Rua das Flores, 100",
+		"Centro",
+		"São Paulo - SP",
+		"CEP: 01000-000",
+	}, clientAddressLines(cli))
+}
+
+func TestRenderInvoicePDF(t *testing.T) {
+	now := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+	due := now.AddDate(0, 0, 10)
+	inv := &Invoice{
+		ID:       uuid.New(),
+		Number:   "2026/001",
+		Status:   InvoiceStatusSent,
+		Date:     &now,
+		DueDate:  &due,
+		Currency: "BRL",
+		Discount: 10,
+		Total:    190,
+		Notes:    "Obrigado pela preferência!",
+		Items: []*InvoiceItem{
+			{Description: "Hospedagem mensal", Quantity: 2, UnitPrice: 100, Total: 200},
+		},
+		BankPixPayload:          strPtr("00020126580014br.gov.bcb.pix0136123e4567-e12b-12d1-a456-4266554400005204000053039865802BR5913Fulano de Tal6008BRASILIA62070503***63041D3D"),
+		BankBoletoDigitableLine: strPtr("34191.79001 01043.510047 91020.150008 5 91070026000"),
+		BankBoletoBarcode:       strPtr("34195910700260000001790010435100479102015000"),
+	}
+
+	pdf, err := renderInvoicePDF(pdfDocument{
+		Invoice:      inv,
+		Organization: &organization.Organization{Name: "Acme Ltda"},
+		Client:       &client.Client{Name: "Cliente Exemplo"},
+		Logo:         []byte("not an image"),
+	})
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(pdf, []byte("%PDF")))
+	assert.Equal(t, "fatura-2026-001.pdf", pdfFilename(inv))
+}
+
+func TestServiceLogo(t *testing.T) {
+	png := []byte("\x89PNG\r\n\x1a\n")
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write(png)
+	}))
+	defer server.Close()
+
+	s := &Service{logoClient: server.Client(), logos: newLogoCache()}
+
+	// The public link never fetches
+	assert.Nil(t, s.logo(t.Context(), server.URL, false))
+	assert.Equal(t, 0, requests)
+
+	assert.Equal(t, png, s.logo(t.Context(), server.URL, true))
+	assert.Equal(t, png, s.logo(t.Context(), server.URL, true))
+	assert.Equal(t, png, s.logo(t.Context(), server.URL, false))
+	assert.Equal(t, 1, requests)
+}
+
+func TestServiceLogo_RefusesInternalAddress(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer server.Close()
+
+	s := &Service{logoClient: safehttp.NewClient(logoFetchTimeout), logos: newLogoCache()}
+
+	assert.Nil(t, s.logo(t.Context(), server.URL, true))
+	assert.Equal(t, 0, requests)
+}
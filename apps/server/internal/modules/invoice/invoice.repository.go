@@ -2,6 +2,7 @@ package invoice
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -10,6 +11,7 @@ type Repository interface {
 	Create(ctx context.Context, entity *Invoice) error
 	GetByID(ctx context.Context, id uuid.UUID) (*Invoice, error)
 	GetByBankID(ctx context.Context, bankID string) (*Invoice, error)
+	GetByRecurrence(ctx context.Context, recurringInvoiceID uuid.UUID, period time.Time) (*Invoice, error)
 	GetByOrganizationID(ctx context.Context, orgID uuid.UUID, filter InvoiceFilter) ([]*Invoice, int, error)
 	Update(ctx context.Context, entity *Invoice) error
 	Delete(ctx context.Context, id uuid.UUID) error
@@ -1,6 +1,7 @@
 package invoice
 
 import (
+	"vigi/internal/modules/api_key"
 	"vigi/internal/modules/middleware"
 	"vigi/internal/modules/organization"
 
@@ -22,10 +23,11 @@ func NewRoute(controller *Controller, orgMiddleware *organization.Middleware) *R
 func (r *Route) ConnectRoute(router *gin.RouterGroup, authChain *middleware.AuthChain) {
 	// Public routes
 	// router.GET("/public/invoices/:id", r.controller.GetPublicInvoice)
+	router.GET("/public/invoices/:id/pdf", r.controller.GetPublicPDF)
 
 	// Organization-scoped routes
 	orgGroup := router.Group("/organizations/:id")
-	orgGroup.Use(authChain.AllAuth())
+	orgGroup.Use(authChain.AllAuth(api_key.ScopeInvoicesRead, api_key.ScopeInvoicesWrite))
 	{
 		orgGroup.POST("/invoices", r.controller.Create)
 		orgGroup.GET("/invoices", r.controller.GetByOrganizationID)
@@ -34,10 +36,11 @@ func (r *Route) ConnectRoute(router *gin.RouterGroup, authChain *middleware.Auth
 
 	// Entity routes
 	entityGroup := router.Group("/invoices")
-	entityGroup.Use(authChain.AllAuth())
+	entityGroup.Use(authChain.AllAuth(api_key.ScopeInvoicesRead, api_key.ScopeInvoicesWrite))
 	entityGroup.Use(r.orgMiddleware.RequireOrganization())
 	{
 		entityGroup.GET("/:id", r.controller.GetByID)
+		entityGroup.GET("/:id/pdf", r.controller.GetPDF)
 		entityGroup.PATCH("/:id", r.controller.Update)
 		entityGroup.DELETE("/:id", r.controller.Delete)
 
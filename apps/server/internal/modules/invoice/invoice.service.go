@@ -2,13 +2,18 @@ package invoice
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"net/http"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 	"vigi/internal/config"
 	"vigi/internal/modules/client"
 	"vigi/internal/modules/organization"
+	"vigi/internal/pkg/safehttp"
 	"vigi/internal/pkg/usesend"
 
 	"github.com/google/uuid"
@@ -38,6 +43,10 @@ type Service struct {
 	emailRepo     EmailRepository
 	usesendClient *usesend.Client
 	cfg           *config.Config
+	// The logo URL is set by users, so it is fetched with a client that
+	// cannot reach internal addresses
+	logoClient *http.Client
+	logos      *logoCache
 }
 
 func NewService(repo Repository, clientRepo client.Repository, orgRepo organization.OrganizationRepository, emailRepo EmailRepository, usesendClient *usesend.Client, cfg *config.Config) *Service {
@@ -48,6 +57,8 @@ func NewService(repo Repository, clientRepo client.Repository, orgRepo organizat
 		emailRepo:     emailRepo,
 		usesendClient: usesendClient,
 		cfg:           cfg,
+		logoClient:    safehttp.NewClient(logoFetchTimeout),
+		logos:         newLogoCache(),
 	}
 }
 
@@ -77,22 +88,24 @@ func (s *Service) Create(ctx context.Context, orgID uuid.UUID, dto CreateInvoice
 	}
 
 	entity := &Invoice{
-		OrganizationID:    orgID,
-		ClientID:          dto.ClientID,
-		Number:            dto.Number,
-		Status:            InvoiceStatusDraft,
-		Date:              dto.Date,
-		DueDate:           dto.DueDate,
-		Terms:             dto.Terms,
-		Notes:             dto.Notes,
-		Total:             SafeFloat(total),
-		Discount:          SafeFloat(dto.Discount),
-		NFID:              dto.NFID,
-		NFStatus:          dto.NFStatus,
-		NFLink:            dto.NFLink,
-		BankInvoiceID:     dto.BankInvoiceID,
-		BankInvoiceStatus: dto.BankInvoiceStatus,
-		Items:             items,
+		OrganizationID:     orgID,
+		ClientID:           dto.ClientID,
+		Number:             dto.Number,
+		Status:             InvoiceStatusDraft,
+		Date:               dto.Date,
+		DueDate:            dto.DueDate,
+		Terms:              dto.Terms,
+		Notes:              dto.Notes,
+		Total:              SafeFloat(total),
+		Discount:           SafeFloat(dto.Discount),
+		NFID:               dto.NFID,
+		NFStatus:           dto.NFStatus,
+		NFLink:             dto.NFLink,
+		BankInvoiceID:      dto.BankInvoiceID,
+		BankInvoiceStatus:  dto.BankInvoiceStatus,
+		RecurringInvoiceID: dto.RecurringInvoiceID,
+		RecurrencePeriod:   dto.RecurrencePeriod,
+		Items:              items,
 	}
 
 	if err := s.repo.Create(ctx, entity); err != nil {
@@ -128,6 +141,19 @@ func (s *Service) GetByBankID(ctx context.Context, bankID string) (*Invoice, err
 	return s.repo.GetByBankID(ctx, bankID)
 }
 
+// GetByRecurrence returns the invoice generated from a recurring invoice for the given period,
+// or nil if none has been generated yet
+func (s *Service) GetByRecurrence(ctx context.Context, recurringInvoiceID uuid.UUID, period time.Time) (*Invoice, error) {
+	entity, err := s.repo.GetByRecurrence(ctx, recurringInvoiceID, period)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return entity, nil
+}
+
 func (s *Service) GetByOrganizationID(ctx context.Context, orgID uuid.UUID, filter InvoiceFilter) ([]*Invoice, int, error) {
 	return s.repo.GetByOrganizationID(ctx, orgID, filter)
 }
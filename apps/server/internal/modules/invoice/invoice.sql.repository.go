@@ -49,6 +49,17 @@ func (r *SQLRepository) GetByBankID(ctx context.Context, bankID string) (*Invoic
 	return entity, nil
 }
 
+func (r *SQLRepository) GetByRecurrence(ctx context.Context, recurringInvoiceID uuid.UUID, period time.Time) (*Invoice, error) {
+	entity := new(Invoice)
+	if err := r.db.NewSelect().Model(entity).Relation("Items").
+		Where("recurring_invoice_id = ?", recurringInvoiceID).
+		Where("recurrence_period = ?", period).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
+
 func (r *SQLRepository) GetByOrganizationID(ctx context.Context, orgID uuid.UUID, filter InvoiceFilter) ([]*Invoice, int, error) {
 	var entities []*Invoice
 	query := r.db.NewSelect().Model(&entities).Relation("Items").Where("organization_id = ?", orgID)
@@ -1,6 +1,7 @@
 package maintenance
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"vigi/internal/utils"
@@ -104,6 +105,10 @@ func (ic *Controller) Create(ctx *gin.Context) {
 	entity.OrgID = orgID
 
 	created, err := ic.service.Create(ctx, entity)
+	if errors.Is(err, ErrGroupNotFound) {
+		ctx.JSON(http.StatusBadRequest, utils.NewFailResponse(err.Error()))
+		return
+	}
 	if err != nil {
 		ic.logger.Errorw("Failed to create maintenance", "error", err)
 		ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse("Internal server error"))
@@ -168,6 +173,7 @@ func (ic *Controller) FindByID(ctx *gin.Context) {
 		CreatedAt:     entity.CreatedAt,
 		UpdatedAt:     entity.UpdatedAt,
 		MonitorIds:    monitorIds,
+		GroupIds:      entity.GroupIds,
 	}
 
 	ctx.JSON(http.StatusOK, utils.NewSuccessResponse("success", response))
@@ -203,6 +209,10 @@ func (ic *Controller) UpdateFull(ctx *gin.Context) {
 	orgID := ctx.GetString("orgId")
 
 	updated, err := ic.service.UpdateFull(ctx, id, &entity, orgID)
+	if errors.Is(err, ErrGroupNotFound) {
+		ctx.JSON(http.StatusBadRequest, utils.NewFailResponse(err.Error()))
+		return
+	}
 	if err != nil {
 		ic.logger.Errorw("Failed to update maintenance", "error", err)
 		ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse("Internal server error"))
@@ -237,6 +247,10 @@ func (ic *Controller) UpdatePartial(ctx *gin.Context) {
 	orgID := ctx.GetString("orgId")
 
 	updated, err := ic.service.UpdatePartial(ctx, id, &entity, orgID)
+	if errors.Is(err, ErrGroupNotFound) {
+		ctx.JSON(http.StatusBadRequest, utils.NewFailResponse(err.Error()))
+		return
+	}
 	if err != nil {
 		ic.logger.Errorw("Failed to update maintenance", "error", err)
 		ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse("Internal server error"))
@@ -19,6 +19,7 @@ type CreateUpdateDto struct {
 	Timezone      *string  `json:"timezone,omitempty"`
 	Duration      *int     `json:"duration,omitempty" validate:"omitempty,min=1"`
 	MonitorIds    []string `json:"monitor_ids,omitempty"`
+	GroupIds      []string `json:"group_ids,omitempty"`
 }
 
 type PartialUpdateDto struct {
@@ -38,6 +39,7 @@ type PartialUpdateDto struct {
 	Timezone      *string  `json:"timezone,omitempty"`
 	Duration      *int     `json:"duration,omitempty" validate:"omitempty,min=1"`
 	MonitorIds    []string `json:"monitor_ids,omitempty"`
+	GroupIds      []string `json:"group_ids,omitempty"`
 }
 
 type MaintenanceResponseDto struct {
@@ -59,4 +61,5 @@ type MaintenanceResponseDto struct {
 	CreatedAt     time.Time `json:"created_at"`
 	UpdatedAt     time.Time `json:"updated_at"`
 	MonitorIds    []string  `json:"monitor_ids"`
+	GroupIds      []string  `json:"group_ids"`
 }
@@ -19,6 +19,7 @@ type Model struct {
 	Cron          *string   `json:"cron,omitempty"`
 	Timezone      *string   `json:"timezone,omitempty"`
 	Duration      *int      `json:"duration,omitempty"`
+	GroupIds      []string  `json:"group_ids"`
 	CreatedAt     time.Time `json:"created_at"`
 	UpdatedAt     time.Time `json:"updated_at"`
 }
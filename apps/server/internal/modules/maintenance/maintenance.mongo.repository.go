@@ -28,29 +28,36 @@ type mongoModel struct {
 	Cron          *string            `bson:"cron,omitempty"`
 	Timezone      *string            `bson:"timezone,omitempty"`
 	Duration      *int               `bson:"duration,omitempty"`
+	GroupIds      []string           `bson:"group_ids"`
 	CreatedAt     time.Time          `bson:"created_at"`
 	UpdatedAt     time.Time          `bson:"updated_at"`
 }
 
 type mongoUpdateModel struct {
-	Title         *string `bson:"title,omitempty"`
-	Description   *string `bson:"description,omitempty"`
-	Active        *bool   `bson:"active,omitempty"`
-	Strategy      *string `bson:"strategy,omitempty"`
-	StartDateTime *string `bson:"start_date_time,omitempty"`
-	EndDateTime   *string `bson:"end_date_time,omitempty"`
-	StartTime     *string `bson:"start_time,omitempty"`
-	EndTime       *string `bson:"end_time,omitempty"`
-	Weekdays      []int   `bson:"weekdays,omitempty"`
-	DaysOfMonth   []int   `bson:"days_of_month,omitempty"`
-	IntervalDay   *int    `bson:"interval_day,omitempty"`
-	Cron          *string `bson:"cron,omitempty"`
-	Timezone      *string `bson:"timezone,omitempty"`
-	Duration      *int    `bson:"duration,omitempty"`
-	UpdatedAt     *string `bson:"updated_at,omitempty"`
+	Title         *string  `bson:"title,omitempty"`
+	Description   *string  `bson:"description,omitempty"`
+	Active        *bool    `bson:"active,omitempty"`
+	Strategy      *string  `bson:"strategy,omitempty"`
+	StartDateTime *string  `bson:"start_date_time,omitempty"`
+	EndDateTime   *string  `bson:"end_date_time,omitempty"`
+	StartTime     *string  `bson:"start_time,omitempty"`
+	EndTime       *string  `bson:"end_time,omitempty"`
+	Weekdays      []int    `bson:"weekdays,omitempty"`
+	DaysOfMonth   []int    `bson:"days_of_month,omitempty"`
+	IntervalDay   *int     `bson:"interval_day,omitempty"`
+	Cron          *string  `bson:"cron,omitempty"`
+	Timezone      *string  `bson:"timezone,omitempty"`
+	Duration      *int     `bson:"duration,omitempty"`
+	GroupIds      []string `bson:"group_ids,omitempty"`
+	UpdatedAt     *string  `bson:"updated_at,omitempty"`
 }
 
 func toDomainModel(mm *mongoModel) *Model {
+	groupIds := mm.GroupIds
+	if groupIds == nil {
+		groupIds = []string{}
+	}
+
 	return &Model{
 		ID:            mm.ID.Hex(),
 		OrgID:         mm.OrgID,
@@ -68,6 +75,7 @@ func toDomainModel(mm *mongoModel) *Model {
 		Cron:          mm.Cron,
 		Timezone:      mm.Timezone,
 		Duration:      mm.Duration,
+		GroupIds:      groupIds,
 		CreatedAt:     mm.CreatedAt,
 		UpdatedAt:     mm.UpdatedAt,
 	}
@@ -108,6 +116,7 @@ func (r *MongoRepositoryImpl) Create(ctx context.Context, entity *CreateUpdateDt
 		Cron:          entity.Cron,
 		Timezone:      entity.Timezone,
 		Duration:      entity.Duration,
+		GroupIds:      nonNilGroupIds(entity.GroupIds),
 		CreatedAt:     time.Now(),
 		UpdatedAt:     time.Now(),
 	}
@@ -210,6 +219,7 @@ func (r *MongoRepositoryImpl) UpdateFull(ctx context.Context, id string, entity
 		Cron:          entity.Cron,
 		Timezone:      entity.Timezone,
 		Duration:      entity.Duration,
+		GroupIds:      nonNilGroupIds(entity.GroupIds),
 		UpdatedAt:     time.Now(),
 	}
 
@@ -248,6 +258,7 @@ func (r *MongoRepositoryImpl) UpdatePartial(ctx context.Context, id string, enti
 		Cron:          entity.Cron,
 		Timezone:      entity.Timezone,
 		Duration:      entity.Duration,
+		GroupIds:      entity.GroupIds,
 		UpdatedAt:     &nowStr,
 	}
 
@@ -341,6 +352,30 @@ func (r *MongoRepositoryImpl) GetMaintenancesByMonitorID(ctx context.Context, mo
 	return maintenances, nil
 }
 
+// GetMaintenancesByGroupIDs returns all active maintenances targeting any of the groups
+func (r *MongoRepositoryImpl) GetMaintenancesByGroupIDs(ctx context.Context, groupIDs []string) ([]*Model, error) {
+	if len(groupIDs) == 0 {
+		return nil, nil
+	}
+
+	filter := bson.M{"group_ids": bson.M{"$in": groupIDs}, "active": true}
+	cursor, err := r.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var maintenances []*Model
+	for cursor.Next(ctx) {
+		var mm mongoModel
+		if err := cursor.Decode(&mm); err != nil {
+			return nil, err
+		}
+		maintenances = append(maintenances, toDomainModel(&mm))
+	}
+	return maintenances, nil
+}
+
 func (r *MongoRepositoryImpl) Count(ctx context.Context, orgID string) (int64, error) {
 	filter := bson.M{"org_id": orgID}
 	count, err := r.collection.CountDocuments(ctx, filter)
@@ -349,3 +384,10 @@ func (r *MongoRepositoryImpl) Count(ctx context.Context, orgID string) (int64, e
 	}
 	return count, nil
 }
+
+func nonNilGroupIds(groupIds []string) []string {
+	if groupIds == nil {
+		return []string{}
+	}
+	return groupIds
+}
@@ -12,5 +12,7 @@ type Repository interface {
 
 	SetActive(ctx context.Context, id string, active bool, orgID string) (*Model, error)
 	GetMaintenancesByMonitorID(ctx context.Context, monitorID string) ([]*Model, error)
+	// GetMaintenancesByGroupIDs returns the active maintenances targeting any of the groups
+	GetMaintenancesByGroupIDs(ctx context.Context, groupIDs []string) ([]*Model, error)
 	Count(ctx context.Context, orgID string) (int64, error)
 }
@@ -2,14 +2,19 @@ package maintenance
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"go.uber.org/zap"
 
+	"vigi/internal/modules/events"
 	"vigi/internal/modules/maintenance/utils"
+	"vigi/internal/modules/monitor_group"
 	"vigi/internal/modules/monitor_maintenance"
 )
 
+var ErrGroupNotFound = errors.New("monitor group not found")
+
 type Service interface {
 	Create(ctx context.Context, entity *CreateUpdateDto) (*Model, error)
 	FindByID(ctx context.Context, id string, orgID string) (*Model, error)
@@ -28,11 +33,16 @@ type Service interface {
 
 	// Get monitors for a maintenance
 	GetMonitors(ctx context.Context, id string) ([]string, error)
+
+	// Get monitors for a maintenance, including the members of its groups
+	GetAffectedMonitors(ctx context.Context, maintenance *Model) ([]string, error)
 }
 
 type ServiceImpl struct {
 	repository                Repository
 	monitorMaintenanceService monitor_maintenance.Service
+	monitorGroupService       monitor_group.Service
+	eventBus                  events.EventBus
 	logger                    *zap.SugaredLogger
 	cronGenerator             utils.CronGeneratorInterface
 	timeWindowChecker         utils.TimeWindowCheckerInterface
@@ -43,11 +53,15 @@ type ServiceImpl struct {
 func NewService(
 	repository Repository,
 	monitorMaintenanceService monitor_maintenance.Service,
+	monitorGroupService monitor_group.Service,
+	eventBus events.EventBus,
 	logger *zap.SugaredLogger,
 ) Service {
 	return &ServiceImpl{
 		repository:                repository,
 		monitorMaintenanceService: monitorMaintenanceService,
+		monitorGroupService:       monitorGroupService,
+		eventBus:                  eventBus,
 		logger:                    logger.Named("[maintenance-service]"),
 		cronGenerator:             utils.NewCronGenerator(),
 		timeWindowChecker:         utils.NewTimeWindowChecker(logger),
@@ -89,6 +103,10 @@ func (mr *ServiceImpl) Create(ctx context.Context, entity *CreateUpdateDto) (*Mo
 		mr.logger.Debugf("Calculated duration from start/end times: %d minutes", duration)
 	}
 
+	if err := mr.validateGroups(ctx, entity.GroupIds, entity.OrgID); err != nil {
+		return nil, err
+	}
+
 	// Store times directly without timezone conversion
 	created, err := mr.repository.Create(ctx, entity)
 	if err != nil {
@@ -103,6 +121,11 @@ func (mr *ServiceImpl) Create(ctx context.Context, entity *CreateUpdateDto) (*Mo
 		}
 	}
 
+	mr.eventBus.Publish(events.Event{
+		Type:    events.MaintenanceScheduled,
+		Payload: created,
+	})
+
 	return created, nil
 }
 
@@ -157,6 +180,10 @@ func (mr *ServiceImpl) UpdateFull(ctx context.Context, id string, entity *Create
 		mr.logger.Debugf("Calculated duration from start/end times: %d minutes", duration)
 	}
 
+	if err := mr.validateGroups(ctx, entity.GroupIds, orgID); err != nil {
+		return nil, err
+	}
+
 	// Store times directly without timezone conversion
 	updated, err := mr.repository.UpdateFull(ctx, id, entity, orgID)
 	if err != nil {
@@ -260,6 +287,10 @@ func (mr *ServiceImpl) UpdatePartial(ctx context.Context, id string, entity *Par
 		return nil, err
 	}
 
+	if err := mr.validateGroups(ctx, entity.GroupIds, orgID); err != nil {
+		return nil, err
+	}
+
 	// Store times directly without timezone conversion
 	updated, err := mr.repository.UpdatePartial(ctx, id, entity, orgID)
 	if err != nil {
@@ -382,13 +413,38 @@ func (mr *ServiceImpl) generateCronExpression(dto *CreateUpdateDto) (*string, er
 	return mr.cronGenerator.GenerateCronExpression(dto.Strategy, params)
 }
 
-// GetMaintenancesByMonitorID returns all active maintenances for a given monitor_id
+// GetMaintenancesByMonitorID returns all active maintenances for a given monitor_id,
+// including the ones targeting a group the monitor belongs to
 func (mr *ServiceImpl) GetMaintenancesByMonitorID(ctx context.Context, monitorID string) ([]*Model, error) {
 	models, err := mr.repository.GetMaintenancesByMonitorID(ctx, monitorID)
 	if err != nil {
 		return nil, err
 	}
 
+	groupIDs, err := mr.monitorGroupService.FindGroupIDsByMonitorID(ctx, monitorID)
+	if err != nil {
+		return nil, err
+	}
+	if len(groupIDs) == 0 {
+		return models, nil
+	}
+
+	groupModels, err := mr.repository.GetMaintenancesByGroupIDs(ctx, groupIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(models))
+	for _, model := range models {
+		seen[model.ID] = true
+	}
+	for _, model := range groupModels {
+		if !seen[model.ID] {
+			seen[model.ID] = true
+			models = append(models, model)
+		}
+	}
+
 	return models, nil
 }
 
@@ -396,3 +452,42 @@ func (mr *ServiceImpl) GetMaintenancesByMonitorID(ctx context.Context, monitorID
 func (mr *ServiceImpl) GetMonitors(ctx context.Context, id string) ([]string, error) {
 	return mr.monitorMaintenanceService.GetMonitors(ctx, id)
 }
+
+// GetAffectedMonitors returns the monitors attached to the maintenance together
+// with every monitor of its groups and their subgroups
+func (mr *ServiceImpl) GetAffectedMonitors(ctx context.Context, maintenance *Model) ([]string, error) {
+	monitorIDs, err := mr.monitorMaintenanceService.GetMonitors(ctx, maintenance.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(monitorIDs))
+	for _, id := range monitorIDs {
+		seen[id] = true
+	}
+	for _, groupID := range maintenance.GroupIds {
+		groupMonitorIDs, err := mr.monitorGroupService.FindMonitorIDs(ctx, groupID, maintenance.OrgID)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range groupMonitorIDs {
+			if !seen[id] {
+				seen[id] = true
+				monitorIDs = append(monitorIDs, id)
+			}
+		}
+	}
+
+	return monitorIDs, nil
+}
+
+// validateGroups makes sure every group belongs to the organization
+func (mr *ServiceImpl) validateGroups(ctx context.Context, groupIDs []string, orgID string) error {
+	for _, groupID := range groupIDs {
+		group, err := mr.monitorGroupService.FindByID(ctx, groupID, orgID)
+		if err != nil || group == nil {
+			return ErrGroupNotFound
+		}
+	}
+	return nil
+}
@@ -10,8 +10,11 @@ import (
 	"github.com/stretchr/testify/mock"
 	"go.uber.org/zap"
 
+	"vigi/internal/modules/events"
 	"vigi/internal/modules/maintenance/utils"
+	"vigi/internal/modules/monitor_group"
 	"vigi/internal/modules/monitor_maintenance"
+	"vigi/internal/modules/shared"
 )
 
 // Mock dependencies
@@ -74,6 +77,11 @@ func (m *MockRepository) GetMaintenancesByMonitorID(ctx context.Context, monitor
 	return args.Get(0).([]*Model), args.Error(1)
 }
 
+func (m *MockRepository) GetMaintenancesByGroupIDs(ctx context.Context, groupIDs []string) ([]*Model, error) {
+	args := m.Called(ctx, groupIDs)
+	return args.Get(0).([]*Model), args.Error(1)
+}
+
 func (m *MockRepository) Count(ctx context.Context, orgID string) (int64, error) {
 	args := m.Called(ctx, orgID)
 	return args.Get(0).(int64), args.Error(1)
@@ -128,6 +136,67 @@ func (m *MockMonitorMaintenanceService) GetMonitors(ctx context.Context, mainten
 	return args.Get(0).([]string), args.Error(1)
 }
 
+type MockMonitorGroupService struct {
+	mock.Mock
+}
+
+func (m *MockMonitorGroupService) Create(ctx context.Context, dto *monitor_group.CreateUpdateDto, orgID string) (*monitor_group.Model, error) {
+	args := m.Called(ctx, dto, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*monitor_group.Model), args.Error(1)
+}
+
+func (m *MockMonitorGroupService) FindByID(ctx context.Context, id string, orgID string) (*monitor_group.Model, error) {
+	args := m.Called(ctx, id, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*monitor_group.Model), args.Error(1)
+}
+
+func (m *MockMonitorGroupService) FindAll(ctx context.Context, page int, limit int, q string, orgID string) ([]*monitor_group.Model, error) {
+	args := m.Called(ctx, page, limit, q, orgID)
+	return args.Get(0).([]*monitor_group.Model), args.Error(1)
+}
+
+func (m *MockMonitorGroupService) Update(ctx context.Context, id string, dto *monitor_group.CreateUpdateDto, orgID string) (*monitor_group.Model, error) {
+	args := m.Called(ctx, id, dto, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*monitor_group.Model), args.Error(1)
+}
+
+func (m *MockMonitorGroupService) Delete(ctx context.Context, id string, orgID string) error {
+	args := m.Called(ctx, id, orgID)
+	return args.Error(0)
+}
+
+func (m *MockMonitorGroupService) GetStatus(ctx context.Context, id string, orgID string) (*monitor_group.StatusDto, error) {
+	args := m.Called(ctx, id, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*monitor_group.StatusDto), args.Error(1)
+}
+
+func (m *MockMonitorGroupService) FindMonitorIDs(ctx context.Context, id string, orgID string) ([]string, error) {
+	args := m.Called(ctx, id, orgID)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockMonitorGroupService) FindGroupIDsByMonitorID(ctx context.Context, monitorID string) ([]string, error) {
+	args := m.Called(ctx, monitorID)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockMonitorGroupService) RefreshStatuses(ctx context.Context, monitorID string, status shared.MonitorStatus) ([]*monitor_group.StatusChangedEvent, error) {
+	args := m.Called(ctx, monitorID, status)
+	return args.Get(0).([]*monitor_group.StatusChangedEvent), args.Error(1)
+}
+
 type MockCronGenerator struct {
 	mock.Mock
 }
@@ -202,6 +271,24 @@ func (m *MockValidator) ValidateCronAndDuration(params *utils.ValidationParams)
 	return args.Error(0)
 }
 
+// MockEventBus
+type MockEventBus struct {
+	mock.Mock
+}
+
+func (m *MockEventBus) Subscribe(eventType events.EventType, handler events.EventHandler) {
+	m.Called(eventType, handler)
+}
+
+func (m *MockEventBus) Publish(event events.Event) {
+	m.Called(event)
+}
+
+func (m *MockEventBus) Close() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
 // Helper functions for creating test data
 func createTestService() (*ServiceImpl, *MockRepository, *MockMonitorMaintenanceService, *MockCronGenerator, *MockTimeWindowChecker, *MockTimeUtils, *MockValidator) {
 	mockRepo := &MockRepository{}
@@ -210,12 +297,16 @@ func createTestService() (*ServiceImpl, *MockRepository, *MockMonitorMaintenance
 	mockTimeWindowChecker := &MockTimeWindowChecker{}
 	mockTimeUtils := &MockTimeUtils{}
 	mockValidator := &MockValidator{}
+	mockEventBus := &MockEventBus{}
+	mockEventBus.On("Publish", mock.Anything).Return()
 
 	logger := zap.NewNop().Sugar()
 
 	service := &ServiceImpl{
 		repository:                mockRepo,
 		monitorMaintenanceService: mockMonitorMaintenanceService,
+		monitorGroupService:       &MockMonitorGroupService{},
+		eventBus:                  mockEventBus,
 		logger:                    logger,
 		cronGenerator:             mockCronGenerator,
 		timeWindowChecker:         mockTimeWindowChecker,
@@ -645,6 +736,7 @@ func TestServiceImpl_GetMaintenancesByMonitorID_Success(t *testing.T) {
 	expectedModels := []*Model{createTestModel()}
 
 	mockRepo.On("GetMaintenancesByMonitorID", mock.Anything, "monitor1").Return(expectedModels, nil)
+	service.monitorGroupService.(*MockMonitorGroupService).On("FindGroupIDsByMonitorID", mock.Anything, "monitor1").Return([]string(nil), nil)
 
 	result, err := service.GetMaintenancesByMonitorID(context.Background(), "monitor1")
 
@@ -653,6 +745,28 @@ func TestServiceImpl_GetMaintenancesByMonitorID_Success(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestServiceImpl_GetMaintenancesByMonitorID_IncludesGroupMaintenances(t *testing.T) {
+	service, mockRepo, _, _, _, _, _ := createTestService()
+	mockGroupService := service.monitorGroupService.(*MockMonitorGroupService)
+
+	direct := createTestModel()
+	viaGroup := createTestModel()
+	viaGroup.ID = "group-maintenance"
+	viaGroup.GroupIds = []string{"group1"}
+
+	mockRepo.On("GetMaintenancesByMonitorID", mock.Anything, "monitor1").Return([]*Model{direct}, nil)
+	mockGroupService.On("FindGroupIDsByMonitorID", mock.Anything, "monitor1").Return([]string{"group1", "parent1"}, nil)
+	// The direct maintenance also targets a group, it must only be returned once
+	mockRepo.On("GetMaintenancesByGroupIDs", mock.Anything, []string{"group1", "parent1"}).Return([]*Model{viaGroup, direct}, nil)
+
+	result, err := service.GetMaintenancesByMonitorID(context.Background(), "monitor1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*Model{direct, viaGroup}, result)
+	mockRepo.AssertExpectations(t)
+	mockGroupService.AssertExpectations(t)
+}
+
 // Test GetMonitors method
 func TestServiceImpl_GetMonitors_Success(t *testing.T) {
 	service, _, mockMonitorMaintenanceService, _, _, _, _ := createTestService()
@@ -668,6 +782,43 @@ func TestServiceImpl_GetMonitors_Success(t *testing.T) {
 	mockMonitorMaintenanceService.AssertExpectations(t)
 }
 
+func TestServiceImpl_GetAffectedMonitors_IncludesGroupMembers(t *testing.T) {
+	service, _, mockMonitorMaintenanceService, _, _, _, _ := createTestService()
+	mockGroupService := service.monitorGroupService.(*MockMonitorGroupService)
+
+	maintenance := createTestModel()
+	maintenance.OrgID = "org1"
+	maintenance.GroupIds = []string{"group1"}
+
+	mockMonitorMaintenanceService.On("GetMonitors", mock.Anything, maintenance.ID).Return([]string{"monitor1"}, nil)
+	mockGroupService.On("FindMonitorIDs", mock.Anything, "group1", "org1").Return([]string{"monitor1", "monitor2"}, nil)
+
+	result, err := service.GetAffectedMonitors(context.Background(), maintenance)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"monitor1", "monitor2"}, result)
+	mockGroupService.AssertExpectations(t)
+}
+
+func TestServiceImpl_Create_RejectsGroupOfAnotherOrganization(t *testing.T) {
+	service, mockRepo, _, mockCronGenerator, _, _, mockValidator := createTestService()
+	mockGroupService := service.monitorGroupService.(*MockMonitorGroupService)
+
+	dto := createTestCreateUpdateDto()
+	dto.OrgID = "org1"
+	dto.GroupIds = []string{"foreign-group"}
+
+	mockValidator.On("ValidateCronAndDuration", mock.AnythingOfType("*utils.ValidationParams")).Return(nil)
+	mockCronGenerator.On("GenerateCronExpression", dto.Strategy, mock.AnythingOfType("*utils.CronParams")).Return(nil, nil)
+	mockGroupService.On("FindByID", mock.Anything, "foreign-group", "org1").Return(nil, nil)
+
+	result, err := service.Create(context.Background(), dto)
+
+	assert.ErrorIs(t, err, ErrGroupNotFound)
+	assert.Nil(t, result)
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
 // Test error scenarios
 func TestServiceImpl_Create_RepositoryError(t *testing.T) {
 	service, mockRepo, _, mockCronGenerator, _, _, mockValidator := createTestService()
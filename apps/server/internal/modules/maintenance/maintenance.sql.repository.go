@@ -28,6 +28,7 @@ type sqlModel struct {
 	Cron          *string   `bun:"cron"`
 	Timezone      *string   `bun:"timezone"`
 	Duration      *int      `bun:"duration"`
+	GroupIds      string    `bun:"group_ids"` // Store as JSON string for compatibility
 	CreatedAt     time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp"`
 	UpdatedAt     time.Time `bun:"updated_at,nullzero,notnull,default:current_timestamp"`
 }
@@ -36,6 +37,7 @@ func toDomainModelFromSQL(sm *sqlModel) *Model {
 	// Parse JSON strings back to arrays
 	var weekdays []int
 	var daysOfMonth []int
+	groupIds := []string{}
 
 	if sm.Weekdays != "" {
 		json.Unmarshal([]byte(sm.Weekdays), &weekdays)
@@ -43,6 +45,9 @@ func toDomainModelFromSQL(sm *sqlModel) *Model {
 	if sm.DaysOfMonth != "" {
 		json.Unmarshal([]byte(sm.DaysOfMonth), &daysOfMonth)
 	}
+	if sm.GroupIds != "" && sm.GroupIds != "null" {
+		json.Unmarshal([]byte(sm.GroupIds), &groupIds)
+	}
 
 	return &Model{
 		ID:            sm.ID,
@@ -61,6 +66,7 @@ func toDomainModelFromSQL(sm *sqlModel) *Model {
 		Cron:          sm.Cron,
 		Timezone:      sm.Timezone,
 		Duration:      sm.Duration,
+		GroupIds:      groupIds,
 		CreatedAt:     sm.CreatedAt,
 		UpdatedAt:     sm.UpdatedAt,
 	}
@@ -78,6 +84,7 @@ func (r *SQLRepositoryImpl) Create(ctx context.Context, entity *CreateUpdateDto)
 	// Marshal arrays to JSON strings
 	weekdaysJSON, _ := json.Marshal(entity.Weekdays)
 	daysOfMonthJSON, _ := json.Marshal(entity.DaysOfMonth)
+	groupIdsJSON := marshalGroupIds(entity.GroupIds)
 
 	sm := &sqlModel{
 		ID:            uuid.New().String(),
@@ -96,6 +103,7 @@ func (r *SQLRepositoryImpl) Create(ctx context.Context, entity *CreateUpdateDto)
 		Cron:          entity.Cron,
 		Timezone:      entity.Timezone,
 		Duration:      entity.Duration,
+		GroupIds:      groupIdsJSON,
 		CreatedAt:     time.Now(),
 		UpdatedAt:     time.Now(),
 	}
@@ -153,6 +161,7 @@ func (r *SQLRepositoryImpl) UpdateFull(ctx context.Context, id string, entity *C
 	// Marshal arrays to JSON strings
 	weekdaysJSON, _ := json.Marshal(entity.Weekdays)
 	daysOfMonthJSON, _ := json.Marshal(entity.DaysOfMonth)
+	groupIdsJSON := marshalGroupIds(entity.GroupIds)
 
 	sm := &sqlModel{
 		ID:            id,
@@ -171,6 +180,7 @@ func (r *SQLRepositoryImpl) UpdateFull(ctx context.Context, id string, entity *C
 		Cron:          entity.Cron,
 		Timezone:      entity.Timezone,
 		Duration:      entity.Duration,
+		GroupIds:      groupIdsJSON,
 		UpdatedAt:     time.Now(),
 	}
 
@@ -249,6 +259,10 @@ func (r *SQLRepositoryImpl) UpdatePartial(ctx context.Context, id string, entity
 		query = query.Set("duration = ?", *entity.Duration)
 		hasUpdates = true
 	}
+	if entity.GroupIds != nil {
+		query = query.Set("group_ids = ?", marshalGroupIds(entity.GroupIds))
+		hasUpdates = true
+	}
 
 	if entity.OrgID != nil {
 	}
@@ -316,6 +330,36 @@ func (r *SQLRepositoryImpl) GetMaintenancesByMonitorID(ctx context.Context, moni
 	return models, nil
 }
 
+// GetMaintenancesByGroupIDs returns all active maintenances targeting any of the groups
+func (r *SQLRepositoryImpl) GetMaintenancesByGroupIDs(ctx context.Context, groupIDs []string) ([]*Model, error) {
+	if len(groupIDs) == 0 {
+		return nil, nil
+	}
+
+	var sms []*sqlModel
+	err := r.db.NewSelect().
+		Model(&sms).
+		Where("m.active = ?", true).
+		WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			// group_ids is a JSON array, match the quoted ID inside it
+			for _, groupID := range groupIDs {
+				q = q.WhereOr("m.group_ids LIKE ?", "%\""+groupID+"\"%")
+			}
+			return q
+		}).
+		Order("m.updated_at DESC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var models []*Model
+	for _, sm := range sms {
+		models = append(models, toDomainModelFromSQL(sm))
+	}
+	return models, nil
+}
+
 func (r *SQLRepositoryImpl) Count(ctx context.Context, orgID string) (int64, error) {
 	count, err := r.db.NewSelect().Model((*sqlModel)(nil)).Where("org_id = ?", orgID).Count(ctx)
 	if err != nil {
@@ -323,3 +367,12 @@ func (r *SQLRepositoryImpl) Count(ctx context.Context, orgID string) (int64, err
 	}
 	return int64(count), nil
 }
+
+// marshalGroupIds stores the group IDs as a JSON array, never as null
+func marshalGroupIds(groupIds []string) string {
+	if groupIds == nil {
+		groupIds = []string{}
+	}
+	data, _ := json.Marshal(groupIds)
+	return string(data)
+}
@@ -0,0 +1,187 @@
+package metrics
+
+import (
+	"time"
+	"vigi/internal/infra"
+	"vigi/internal/modules/events"
+	"vigi/internal/modules/shared"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const namespace = "vigi"
+
+var (
+	// MonitorStatus holds the last known status of every monitor processed by the ingester
+	// (0 = down, 1 = up, 2 = pending, 3 = maintenance, 4 = degraded). Series are keyed on the
+	// monitor id only, a name label would start a new series on every rename.
+	MonitorStatus = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "monitor_status",
+		Help:      "Last known status of the monitor (0=down, 1=up, 2=pending, 3=maintenance, 4=degraded).",
+	}, []string{"monitor_id", "monitor_type"})
+
+	// MonitorUp is 1 when the last heartbeat of the monitor was UP or DEGRADED, 0 otherwise
+	MonitorUp = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "monitor_up",
+		Help:      "Whether the last heartbeat of the monitor was UP (1) or not (0).",
+	}, []string{"monitor_id", "monitor_type"})
+
+	// MonitorLatency observes the response time reported by every heartbeat
+	MonitorLatency = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "monitor_latency_seconds",
+		Help:      "Response time of monitor health checks.",
+		Buckets:   []float64{0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+	}, []string{"monitor_id", "monitor_type"})
+
+	// HeartbeatsIngested counts heartbeats stored by the ingester, partitioned by status
+	HeartbeatsIngested = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "ingester",
+		Name:      "heartbeats_total",
+		Help:      "Number of heartbeats processed by the ingester.",
+	}, []string{"status"})
+
+	// IngesterErrors counts ingester tasks that failed to be processed
+	IngesterErrors = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "ingester",
+		Name:      "errors_total",
+		Help:      "Number of ingester tasks that failed to be processed.",
+	})
+
+	// ProducerClaimLag observes the delay between a monitor's due time and the moment a producer claimed it
+	ProducerClaimLag = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "producer",
+		Name:      "claim_lag_seconds",
+		Help:      "Delay between the scheduled due time of a monitor and its claim by a producer.",
+		Buckets:   []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 2, 5, 10, 30, 60},
+	})
+
+	// ProducerEnqueued counts health check tasks enqueued by the producer
+	ProducerEnqueued = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "producer",
+		Name:      "enqueued_total",
+		Help:      "Number of health check tasks enqueued by the producer.",
+	}, []string{"result"})
+
+	// HealthChecksExecuted counts health checks executed by workers, partitioned by monitor type and status
+	HealthChecksExecuted = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "worker",
+		Name:      "checks_total",
+		Help:      "Number of health checks executed by the worker.",
+	}, []string{"monitor_type", "status"})
+
+	// StaleChecksSkipped counts health check tasks dropped by workers because they were too old
+	StaleChecksSkipped = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "worker",
+		Name:      "stale_checks_total",
+		Help:      "Number of health check tasks skipped because they were stale.",
+	})
+
+	// NotificationsSent counts notification deliveries per provider and result
+	NotificationsSent = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "notification",
+		Name:      "sent_total",
+		Help:      "Number of notification sends per provider and result.",
+	}, []string{"provider", "result"})
+)
+
+func init() {
+	prometheus.MustRegister(
+		MonitorStatus,
+		MonitorUp,
+		MonitorLatency,
+		HeartbeatsIngested,
+		IngesterErrors,
+		ProducerClaimLag,
+		ProducerEnqueued,
+		HealthChecksExecuted,
+		StaleChecksSkipped,
+		NotificationsSent,
+	)
+}
+
+// ObserveHeartbeat records the status and latency of a processed heartbeat
+func ObserveHeartbeat(monitorID, monitorType string, status shared.MonitorStatus, pingMs int) {
+	MonitorStatus.WithLabelValues(monitorID, monitorType).Set(float64(status))
+
+	// A degraded monitor still answers its checks, so it counts as up here
+	up := 0.0
+	if status.IsAvailable() {
+		up = 1
+	}
+	MonitorUp.WithLabelValues(monitorID, monitorType).Set(up)
+
+	// Latency is only meaningful for checks that actually ran
+	if status != shared.MonitorStatusMaintenance {
+		MonitorLatency.WithLabelValues(monitorID, monitorType).Observe((time.Duration(pingMs) * time.Millisecond).Seconds())
+	}
+
+	HeartbeatsIngested.WithLabelValues(StatusLabel(status)).Inc()
+}
+
+// ForgetMonitor drops the series of a monitor so deleted monitors stop being exported
+func ForgetMonitor(monitorID string) {
+	labels := prometheus.Labels{"monitor_id": monitorID}
+	MonitorStatus.DeletePartialMatch(labels)
+	MonitorUp.DeletePartialMatch(labels)
+	MonitorLatency.DeletePartialMatch(labels)
+}
+
+// SubscribeMonitorEvents forgets the series of monitors deleted through the API
+func SubscribeMonitorEvents(eventBus events.EventBus) {
+	eventBus.Subscribe(events.MonitorDeleted, func(event events.Event) {
+		if monitorID, ok := infra.UnmarshalEventPayloadValue[string](event); ok && monitorID != "" {
+			ForgetMonitor(monitorID)
+		}
+	})
+}
+
+// ObserveClaimLag records how late a monitor was claimed compared to its due time
+func ObserveClaimLag(dueMs, claimedMs int64) {
+	lag := claimedMs - dueMs
+	if lag < 0 {
+		lag = 0
+	}
+	ProducerClaimLag.Observe((time.Duration(lag) * time.Millisecond).Seconds())
+}
+
+// ObserveHealthCheck records the outcome of a health check executed by a worker
+func ObserveHealthCheck(monitorType string, status shared.MonitorStatus) {
+	HealthChecksExecuted.WithLabelValues(monitorType, StatusLabel(status)).Inc()
+}
+
+// ObserveNotification records the result of a notification send for the given provider
+func ObserveNotification(provider string, err error) {
+	result := "success"
+	if err != nil {
+		result = "failure"
+	}
+	NotificationsSent.WithLabelValues(provider, result).Inc()
+}
+
+// StatusLabel converts a monitor status to its metric label value
+func StatusLabel(status shared.MonitorStatus) string {
+	switch status {
+	case shared.MonitorStatusDown:
+		return "down"
+	case shared.MonitorStatusUp:
+		return "up"
+	case shared.MonitorStatusPending:
+		return "pending"
+	case shared.MonitorStatusMaintenance:
+		return "maintenance"
+	case shared.MonitorStatusDegraded:
+		return "degraded"
+	default:
+		return "unknown"
+	}
+}
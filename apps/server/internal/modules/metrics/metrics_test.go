@@ -0,0 +1,111 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"vigi/internal/modules/queue"
+	"vigi/internal/modules/shared"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type fakeQueueService struct {
+	queue.Service
+	queues []*queue.QueueInfo
+	err    error
+}
+
+func (f *fakeQueueService) ListQueues(ctx context.Context) ([]*queue.QueueInfo, error) {
+	return f.queues, f.err
+}
+
+func TestForgetMonitor(t *testing.T) {
+	ObserveHeartbeat("m-deleted", "http", shared.MonitorStatusUp, 80)
+	ObserveHeartbeat("m-kept", "http", shared.MonitorStatusUp, 80)
+
+	before := []int{
+		testutil.CollectAndCount(MonitorStatus),
+		testutil.CollectAndCount(MonitorUp),
+		testutil.CollectAndCount(MonitorLatency),
+	}
+
+	ForgetMonitor("m-deleted")
+
+	assert.Equal(t, before[0]-1, testutil.CollectAndCount(MonitorStatus))
+	assert.Equal(t, before[1]-1, testutil.CollectAndCount(MonitorUp))
+	assert.Equal(t, before[2]-1, testutil.CollectAndCount(MonitorLatency))
+	assert.Equal(t, 1.0, testutil.ToFloat64(MonitorUp.WithLabelValues("m-kept", "http")))
+}
+
+func TestObserveHeartbeat(t *testing.T) {
+	ObserveHeartbeat("m-1", "http", shared.MonitorStatusDown, 120)
+
+	assert.Equal(t, 0.0, testutil.ToFloat64(MonitorStatus.WithLabelValues("m-1", "http")))
+	assert.Equal(t, 0.0, testutil.ToFloat64(MonitorUp.WithLabelValues("m-1", "http")))
+
+	ObserveHeartbeat("m-1", "http", shared.MonitorStatusUp, 80)
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(MonitorStatus.WithLabelValues("m-1", "http")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(MonitorUp.WithLabelValues("m-1", "http")))
+	assert.GreaterOrEqual(t, testutil.ToFloat64(HeartbeatsIngested.WithLabelValues("up")), 1.0)
+}
+
+func TestObserveNotification(t *testing.T) {
+	before := testutil.ToFloat64(NotificationsSent.WithLabelValues("slack", "failure"))
+
+	ObserveNotification("slack", errors.New("boom"))
+	ObserveNotification("slack", nil)
+
+	assert.Equal(t, before+1, testutil.ToFloat64(NotificationsSent.WithLabelValues("slack", "failure")))
+	assert.GreaterOrEqual(t, testutil.ToFloat64(NotificationsSent.WithLabelValues("slack", "success")), 1.0)
+}
+
+func TestStatusLabel(t *testing.T) {
+	assert.Equal(t, "down", StatusLabel(shared.MonitorStatusDown))
+	assert.Equal(t, "up", StatusLabel(shared.MonitorStatusUp))
+	assert.Equal(t, "pending", StatusLabel(shared.MonitorStatusPending))
+	assert.Equal(t, "maintenance", StatusLabel(shared.MonitorStatusMaintenance))
+	assert.Equal(t, "unknown", StatusLabel(shared.MonitorStatus(42)))
+}
+
+func TestQueueCollector(t *testing.T) {
+	svc := &fakeQueueService{
+		queues: []*queue.QueueInfo{
+			{Queue: "healthcheck", Pending: 3, Active: 2, Paused: true},
+		},
+	}
+	collector := NewQueueCollector(svc, zap.NewNop().Sugar())
+
+	expected := `
+# HELP vigi_queue_paused Whether the asynq queue is paused (1) or not (0).
+# TYPE vigi_queue_paused gauge
+vigi_queue_paused{queue="healthcheck"} 1
+`
+	require.NoError(t, testutil.CollectAndCompare(collector, strings.NewReader(expected), "vigi_queue_paused"))
+	assert.Equal(t, 7, testutil.CollectAndCount(collector))
+
+	svc.err = errors.New("redis down")
+	assert.Equal(t, 0, testutil.CollectAndCount(collector))
+}
+
+func TestHandlerRequiresToken(t *testing.T) {
+	handler := Handler("secret")
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "vigi_")
+}
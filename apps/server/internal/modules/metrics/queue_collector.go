@@ -0,0 +1,84 @@
+package metrics
+
+import (
+	"context"
+	"time"
+	"vigi/internal/modules/queue"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// queueScrapeTimeout bounds how long a scrape may wait on Redis
+const queueScrapeTimeout = 5 * time.Second
+
+var queueSizeDesc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "queue", "tasks"),
+	"Number of tasks in the asynq queue by state.",
+	[]string{"queue", "state"},
+	nil,
+)
+
+var queuePausedDesc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "queue", "paused"),
+	"Whether the asynq queue is paused (1) or not (0).",
+	[]string{"queue"},
+	nil,
+)
+
+// QueueCollector exposes asynq queue depths, read from queue.Service at scrape time
+type QueueCollector struct {
+	queueService queue.Service
+	logger       *zap.SugaredLogger
+}
+
+// NewQueueCollector creates a collector backed by the given queue service
+func NewQueueCollector(queueService queue.Service, logger *zap.SugaredLogger) *QueueCollector {
+	return &QueueCollector{
+		queueService: queueService,
+		logger:       logger.Named("[metrics-queue]"),
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *QueueCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- queueSizeDesc
+	ch <- queuePausedDesc
+}
+
+// Collect implements prometheus.Collector
+func (c *QueueCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), queueScrapeTimeout)
+	defer cancel()
+
+	queues, err := c.queueService.ListQueues(ctx)
+	if err != nil {
+		c.logger.Warnw("Failed to list queues for metrics", "error", err)
+		return
+	}
+
+	for _, q := range queues {
+		states := map[string]int{
+			"pending":   q.Pending,
+			"active":    q.Active,
+			"scheduled": q.Scheduled,
+			"retry":     q.Retry,
+			"archived":  q.Archived,
+			"completed": q.Completed,
+		}
+		for state, value := range states {
+			ch <- prometheus.MustNewConstMetric(queueSizeDesc, prometheus.GaugeValue, float64(value), q.Queue, state)
+		}
+
+		paused := 0.0
+		if q.Paused {
+			paused = 1
+		}
+		ch <- prometheus.MustNewConstMetric(queuePausedDesc, prometheus.GaugeValue, paused, q.Queue)
+	}
+}
+
+// RegisterQueueCollector registers a QueueCollector in the default registry
+func RegisterQueueCollector(queueService queue.Service, logger *zap.SugaredLogger) error {
+	return prometheus.Register(NewQueueCollector(queueService, logger))
+}
@@ -0,0 +1,66 @@
+package metrics
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.uber.org/zap"
+)
+
+// Handler returns the HTTP handler serving the default Prometheus registry.
+// When token is not empty, scrapes must send it as a bearer token.
+func Handler(token string) http.Handler {
+	handler := promhttp.Handler()
+	if token == "" {
+		return handler
+	}
+
+	expected := []byte("Bearer " + token)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), expected) != 1 {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
+
+// GinHandler returns a gin handler serving the default Prometheus registry
+func GinHandler(token string) gin.HandlerFunc {
+	return gin.WrapH(Handler(token))
+}
+
+// StartServer starts a standalone HTTP server exposing /metrics on the given port.
+// It is used by the processes that have no API router (producer, worker, ingester).
+// An empty port disables the server.
+func StartServer(port, token string, logger *zap.SugaredLogger) *http.Server {
+	if port == "" {
+		logger.Info("Metrics server disabled")
+		return nil
+	}
+	if port[0] != ':' {
+		port = ":" + port
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", Handler(token))
+
+	server := &http.Server{
+		Addr:              port,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		logger.Infof("Starting metrics server on port %s", port)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Errorw("Metrics server stopped", "error", err)
+		}
+	}()
+
+	return server
+}
@@ -38,7 +38,9 @@ func NewAuthChain(
 // The middleware automatically routes requests based on header presence:
 // - If X-API-Key header is present: routes to API key authentication
 // - Otherwise: routes to JWT authentication (expects Authorization header with Bearer token)
-func (ac *AuthChain) AllAuth() gin.HandlerFunc {
+// API keys must hold one of scopes for the request method, so routes without
+// scopes only accept JWT authentication.
+func (ac *AuthChain) AllAuth(scopes ...api_key.Scope) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		apiKeyHeader := c.GetHeader("X-API-Key")
 		authHeader := c.GetHeader("Authorization")
@@ -46,7 +48,7 @@ func (ac *AuthChain) AllAuth() gin.HandlerFunc {
 		if apiKeyHeader != "" {
 			// Route to API key authentication
 			ac.logger.Debugw("Routing to API key authentication", "ip", c.ClientIP(), "path", c.Request.URL.Path, "keyPrefix", apiKeyHeader[:min(len(apiKeyHeader), 10)]+"...")
-			ac.apiKeyMiddleware.Auth()(c)
+			ac.apiKeyMiddleware.Auth(scopes...)(c)
 		} else if authHeader != "" {
 			// Route to JWT authentication
 			ac.logger.Debugw("Routing to JWT authentication", "ip", c.ClientIP(), "path", c.Request.URL.Path, "tokenPrefix", authHeader[:min(len(authHeader), 10)]+"...")
@@ -1,14 +1,18 @@
 package monitor
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
 	"strings"
 	"time"
+	"vigi/internal/modules/monitor_dependency"
 	"vigi/internal/modules/monitor_notification"
 	"vigi/internal/modules/monitor_tag"
 	"vigi/internal/modules/monitor_tls_info"
+	"vigi/internal/modules/probe"
+	"vigi/internal/secret"
 	"vigi/internal/utils"
 
 	"github.com/gin-gonic/gin"
@@ -21,6 +25,8 @@ type MonitorController struct {
 	monitorNotificationService monitor_notification.Service
 	monitorTagService          monitor_tag.Service
 	tlsInfoService             monitor_tls_info.Service
+	monitorDependencyService   monitor_dependency.Service
+	probeService               probe.Service
 }
 
 func NewMonitorController(
@@ -29,6 +35,8 @@ func NewMonitorController(
 	monitorNotificationService monitor_notification.Service,
 	monitorTagService monitor_tag.Service,
 	tlsInfoService monitor_tls_info.Service,
+	monitorDependencyService monitor_dependency.Service,
+	probeService probe.Service,
 ) *MonitorController {
 	utils.Validate.RegisterStructValidation(CreateUpdateDtoStructLevelValidation, CreateUpdateDto{})
 
@@ -38,6 +46,8 @@ func NewMonitorController(
 		monitorNotificationService,
 		monitorTagService,
 		tlsInfoService,
+		monitorDependencyService,
+		probeService,
 	}
 }
 
@@ -133,7 +143,7 @@ func (ic *MonitorController) FindAll(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, utils.NewSuccessResponse("success", response))
+	ctx.JSON(http.StatusOK, utils.NewSuccessResponse("success", redactAll(response)))
 }
 
 // @Router		/monitors [post]
@@ -169,6 +179,14 @@ func (ic *MonitorController) Create(ctx *gin.Context) {
 		return
 	}
 
+	if !ic.validateParents(ctx, "", monitor.ParentIds, monitor.OrgID) {
+		return
+	}
+
+	if !ic.validateProbes(ctx, monitor.Type, monitor.ProbeIds, monitor.OrgID) {
+		return
+	}
+
 	createdMonitor, err := ic.monitorService.Create(ctx, monitor)
 	if err != nil {
 		ic.logger.Errorw("Failed to create monitor", "error", err)
@@ -201,7 +219,23 @@ func (ic *MonitorController) Create(ctx *gin.Context) {
 		}
 	}
 
-	ctx.JSON(http.StatusCreated, utils.NewSuccessResponse("Monitor created successfully", createdMonitor))
+	if len(monitor.ParentIds) > 0 {
+		if err := ic.monitorDependencyService.SetParents(ctx, createdMonitor.ID, monitor.ParentIds); err != nil {
+			ic.logger.Errorw("Failed to create monitor dependencies", "error", err)
+			ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse("Internal server error"))
+			return
+		}
+	}
+
+	if len(monitor.ProbeIds) > 0 {
+		if err := ic.probeService.SetMonitorProbes(ctx, createdMonitor.ID, monitor.ProbeIds, monitor.ProbeDownThreshold, monitor.OrgID); err != nil {
+			ic.logger.Errorw("Failed to assign monitor probes", "error", err)
+			ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse("Internal server error"))
+			return
+		}
+	}
+
+	ctx.JSON(http.StatusCreated, utils.NewSuccessResponse("Monitor created successfully", redact(createdMonitor)))
 }
 
 // @Router		/monitors/{id} [get]
@@ -255,24 +289,41 @@ func (ic *MonitorController) FindByID(ctx *gin.Context) {
 		tagIds = append(tagIds, rel.TagID)
 	}
 
-	// Compose response with notification_ids and tag_ids
+	parentIds, err := ic.monitorDependencyService.FindParentIDs(ctx, id)
+	if err != nil {
+		ic.logger.Errorw("Failed to fetch monitor dependencies", "error", err)
+		ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse("Internal server error"))
+		return
+	}
+
+	assignment, err := ic.probeService.FindAssignment(ctx, id)
+	if err != nil {
+		ic.logger.Errorw("Failed to fetch monitor probes", "error", err)
+		ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse("Internal server error"))
+		return
+	}
+
+	// Compose response with notification_ids, tag_ids, parent_ids and probe_ids
 	response := MonitorResponseDto{
-		ID:              monitor.ID,
-		Name:            monitor.Name,
-		Interval:        monitor.Interval,
-		Timeout:         monitor.Timeout,
-		Type:            monitor.Type,
-		Active:          monitor.Active,
-		MaxRetries:      monitor.MaxRetries,
-		RetryInterval:   monitor.RetryInterval,
-		ResendInterval:  monitor.ResendInterval,
-		Status:          int(monitor.Status),
-		CreatedAt:       monitor.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:       monitor.UpdatedAt.Format(time.RFC3339),
-		NotificationIds: notificationIds,
-		TagIds:          tagIds,
-		ProxyId:         monitor.ProxyId,
-		Config:          monitor.Config,
+		ID:                 monitor.ID,
+		Name:               monitor.Name,
+		Interval:           monitor.Interval,
+		Timeout:            monitor.Timeout,
+		Type:               monitor.Type,
+		Active:             monitor.Active,
+		MaxRetries:         monitor.MaxRetries,
+		RetryInterval:      monitor.RetryInterval,
+		ResendInterval:     monitor.ResendInterval,
+		Status:             int(monitor.Status),
+		CreatedAt:          monitor.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:          monitor.UpdatedAt.Format(time.RFC3339),
+		NotificationIds:    notificationIds,
+		TagIds:             tagIds,
+		ParentIds:          parentIds,
+		ProxyId:            monitor.ProxyId,
+		Config:             secret.RedactFields(monitor.Config, SecretConfigFields),
+		ProbeIds:           assignment.ProbeIDs,
+		ProbeDownThreshold: assignment.DownThreshold,
 	}
 
 	ctx.JSON(http.StatusOK, utils.NewSuccessResponse("success", response))
@@ -307,6 +358,15 @@ func (ic *MonitorController) UpdateFull(ctx *gin.Context) {
 		return
 	}
 
+	// Masked secrets are put back first so the config validates as it will be stored
+	config, err := ic.monitorService.RestoreSecrets(ctx, id, monitor.Config, orgID)
+	if err != nil {
+		ic.logger.Errorw("Failed to fetch monitor", "error", err)
+		ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse("Internal server error"))
+		return
+	}
+	monitor.Config = config
+
 	// Validate monitor type and config
 	if err := ic.monitorService.ValidateMonitorConfig(monitor.Type, monitor.Config); err != nil {
 		ctx.JSON(http.StatusBadRequest, utils.NewFailResponse(fmt.Sprintf("Invalid monitor configuration: %v", err)))
@@ -316,6 +376,14 @@ func (ic *MonitorController) UpdateFull(ctx *gin.Context) {
 	// Set OrgID in DTO to ensure it is passed down
 	monitor.OrgID = orgID
 
+	if !ic.validateParents(ctx, id, monitor.ParentIds, orgID) {
+		return
+	}
+
+	if !ic.validateProbes(ctx, monitor.Type, monitor.ProbeIds, orgID) {
+		return
+	}
+
 	updatedMonitor, err := ic.monitorService.UpdateFull(ctx, id, &monitor)
 	if err != nil {
 		ic.logger.Errorw("Failed to update monitor", "error", err)
@@ -363,7 +431,19 @@ func (ic *MonitorController) UpdateFull(ctx *gin.Context) {
 		}
 	}
 
-	ctx.JSON(http.StatusOK, utils.NewSuccessResponse("Monitor updated successfully", updatedMonitor))
+	if err := ic.monitorDependencyService.SetParents(ctx, id, monitor.ParentIds); err != nil {
+		ic.logger.Errorw("Failed to update monitor dependencies", "error", err)
+		ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse("Internal server error"))
+		return
+	}
+
+	if err := ic.probeService.SetMonitorProbes(ctx, id, monitor.ProbeIds, monitor.ProbeDownThreshold, orgID); err != nil {
+		ic.logger.Errorw("Failed to update monitor probes", "error", err)
+		ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse("Internal server error"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.NewSuccessResponse("Monitor updated successfully", redact(updatedMonitor)))
 }
 
 // @Router		/monitors/{id} [patch]
@@ -389,9 +469,29 @@ func (ic *MonitorController) UpdatePartial(ctx *gin.Context) {
 		return
 	}
 
-	// Validate monitor type and config if they are being updated
-	if monitor.Type != nil && monitor.Config != nil {
-		if err := ic.monitorService.ValidateMonitorConfig(*monitor.Type, *monitor.Config); err != nil {
+	// Validate monitor type and config if either is being updated, the other
+	// half comes from the current monitor
+	if monitor.Type != nil || monitor.Config != nil {
+		existing, err := ic.monitorService.FindByID(ctx, id, orgID)
+		if err != nil {
+			ic.logger.Errorw("Failed to fetch monitor", "error", err)
+			ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse("Internal server error"))
+			return
+		}
+		if existing == nil {
+			ctx.JSON(http.StatusNotFound, utils.NewFailResponse("Monitor not found"))
+			return
+		}
+
+		monitorType, config := existing.Type, existing.Config
+		if monitor.Type != nil {
+			monitorType = *monitor.Type
+		}
+		if monitor.Config != nil {
+			config = secret.RestoreFields(*monitor.Config, existing.Config, SecretConfigFields)
+			monitor.Config = &config
+		}
+		if err := ic.monitorService.ValidateMonitorConfig(monitorType, config); err != nil {
 			ctx.JSON(http.StatusBadRequest, utils.NewFailResponse(fmt.Sprintf("Invalid monitor configuration: %v", err)))
 			return
 		}
@@ -400,6 +500,49 @@ func (ic *MonitorController) UpdatePartial(ctx *gin.Context) {
 	// Set OrgID in DTO
 	monitor.OrgID = &orgID
 
+	if monitor.ParentIds != nil && !ic.validateParents(ctx, id, *monitor.ParentIds, orgID) {
+		return
+	}
+
+	// The threshold alone can change, so the missing half comes from the current assignment
+	var probeIDs []string
+	var probeDownThreshold int
+	updateProbes := monitor.ProbeIds != nil || monitor.ProbeDownThreshold != nil
+	if updateProbes {
+		existing, err := ic.monitorService.FindByID(ctx, id, orgID)
+		if err != nil {
+			ic.logger.Errorw("Failed to fetch monitor", "error", err)
+			ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse("Internal server error"))
+			return
+		}
+		if existing == nil {
+			ctx.JSON(http.StatusNotFound, utils.NewFailResponse("Monitor not found"))
+			return
+		}
+
+		assignment, err := ic.probeService.FindAssignment(ctx, id)
+		if err != nil {
+			ic.logger.Errorw("Failed to fetch monitor probes", "error", err)
+			ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse("Internal server error"))
+			return
+		}
+		probeIDs, probeDownThreshold = assignment.ProbeIDs, assignment.DownThreshold
+		if monitor.ProbeIds != nil {
+			probeIDs = *monitor.ProbeIds
+		}
+		if monitor.ProbeDownThreshold != nil {
+			probeDownThreshold = *monitor.ProbeDownThreshold
+		}
+
+		monitorType := existing.Type
+		if monitor.Type != nil {
+			monitorType = *monitor.Type
+		}
+		if !ic.validateProbes(ctx, monitorType, probeIDs, orgID) {
+			return
+		}
+	}
+
 	updatedMonitor, err := ic.monitorService.UpdatePartial(ctx, id, &monitor, false, orgID)
 	if err != nil {
 		ic.logger.Errorw("Failed to update monitor", "error", err)
@@ -485,7 +628,24 @@ func (ic *MonitorController) UpdatePartial(ctx *gin.Context) {
 		}
 	}
 
-	ctx.JSON(http.StatusOK, utils.NewSuccessResponse("Monitor updated successfully", updatedMonitor))
+	// Unlike tags an empty list is meaningful here, it removes every parent
+	if monitor.ParentIds != nil {
+		if err := ic.monitorDependencyService.SetParents(ctx, id, *monitor.ParentIds); err != nil {
+			ic.logger.Errorw("Failed to update monitor dependencies", "error", err)
+			ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse("Internal server error"))
+			return
+		}
+	}
+
+	if updateProbes {
+		if err := ic.probeService.SetMonitorProbes(ctx, id, probeIDs, probeDownThreshold, orgID); err != nil {
+			ic.logger.Errorw("Failed to update monitor probes", "error", err)
+			ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse("Internal server error"))
+			return
+		}
+	}
+
+	ctx.JSON(http.StatusOK, utils.NewSuccessResponse("Monitor updated successfully", redact(updatedMonitor)))
 }
 
 // @Router		/monitors/{id} [delete]
@@ -712,7 +872,7 @@ func (ic *MonitorController) FindByIDs(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, utils.NewSuccessResponse("success", monitors))
+	ctx.JSON(http.StatusOK, utils.NewSuccessResponse("success", redactAll(monitors)))
 }
 
 // @Router /monitors/{id}/reset [post]
@@ -787,3 +947,158 @@ func (ic *MonitorController) GetTLSInfo(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, utils.NewSuccessResponse("success", tlsInfo))
 }
+
+// @Router /monitors/{id}/dependencies [get]
+// @Summary Get the dependency tree of a monitor
+// @Tags Monitors
+// @Produce json
+// @Security BearerAuth
+// @Security OrgIdAuth
+// @Param id path string true "Monitor ID"
+// @Success 200 {object} utils.ApiResponse[DependencyTreeDto]
+// @Failure 404 {object} utils.APIError[any]
+// @Failure 500 {object} utils.APIError[any]
+func (ic *MonitorController) GetDependencyTree(ctx *gin.Context) {
+	id := ctx.Param("id")
+	orgID := ctx.GetString("orgId")
+
+	monitor, err := ic.monitorService.FindByID(ctx, id, orgID)
+	if err != nil {
+		ic.logger.Errorw("Failed to fetch monitor", "monitorID", id, "error", err)
+		ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse("Internal server error"))
+		return
+	}
+	if monitor == nil {
+		ctx.JSON(http.StatusNotFound, utils.NewFailResponse("Monitor not found"))
+		return
+	}
+
+	path := map[string]struct{}{id: {}}
+	parents, err := ic.buildDependencyNodes(ctx, id, orgID, ic.monitorDependencyService.FindParentIDs, path, false)
+	if err != nil {
+		ic.logger.Errorw("Failed to build monitor dependency tree", "monitorID", id, "error", err)
+		ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse("Internal server error"))
+		return
+	}
+	children, err := ic.buildDependencyNodes(ctx, id, orgID, ic.monitorDependencyService.FindChildIDs, path, true)
+	if err != nil {
+		ic.logger.Errorw("Failed to build monitor dependency tree", "monitorID", id, "error", err)
+		ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse("Internal server error"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.NewSuccessResponse("success", DependencyTreeDto{
+		DependencyNodeDto: toDependencyNode(monitor),
+		Parents:           parents,
+		Children:          children,
+	}))
+}
+
+// buildDependencyNodes walks the dependency graph from monitorID in one
+// direction. path holds the monitors on the current branch so a corrupted
+// graph cannot recurse forever; a monitor shared by two branches is listed
+// under both.
+func (ic *MonitorController) buildDependencyNodes(
+	ctx context.Context,
+	monitorID string,
+	orgID string,
+	next func(ctx context.Context, monitorID string) ([]string, error),
+	path map[string]struct{},
+	downward bool,
+) ([]*DependencyNodeDto, error) {
+	ids, err := next(ctx, monitorID)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make([]*DependencyNodeDto, 0, len(ids))
+	for _, nextID := range ids {
+		if _, onPath := path[nextID]; onPath {
+			continue
+		}
+
+		m, err := ic.monitorService.FindByID(ctx, nextID, orgID)
+		if err != nil {
+			return nil, err
+		}
+		if m == nil {
+			continue
+		}
+
+		path[nextID] = struct{}{}
+		related, err := ic.buildDependencyNodes(ctx, nextID, orgID, next, path, downward)
+		delete(path, nextID)
+		if err != nil {
+			return nil, err
+		}
+
+		node := toDependencyNode(m)
+		if downward {
+			node.Children = related
+		} else {
+			node.Parents = related
+		}
+		nodes = append(nodes, &node)
+	}
+
+	return nodes, nil
+}
+
+// validateParents writes a 400 response and returns false when parentIDs
+// reference monitors outside the organization or would create a cycle.
+// An empty monitorID skips the cycle check for monitors not created yet.
+func (ic *MonitorController) validateParents(ctx *gin.Context, monitorID string, parentIDs []string, orgID string) bool {
+	for _, parentID := range parentIDs {
+		parent, err := ic.monitorService.FindByID(ctx, parentID, orgID)
+		if err != nil || parent == nil {
+			ctx.JSON(http.StatusBadRequest, utils.NewFailResponse(fmt.Sprintf("Parent monitor %s not found", parentID)))
+			return false
+		}
+	}
+
+	if monitorID == "" {
+		return true
+	}
+
+	err := ic.monitorDependencyService.ValidateParents(ctx, monitorID, parentIDs)
+	if errors.Is(err, monitor_dependency.ErrSelfDependency) || errors.Is(err, monitor_dependency.ErrDependencyCycle) {
+		ctx.JSON(http.StatusBadRequest, utils.NewFailResponse(err.Error()))
+		return false
+	}
+	if err != nil {
+		ic.logger.Errorw("Failed to validate monitor dependencies", "monitorID", monitorID, "error", err)
+		ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse("Internal server error"))
+		return false
+	}
+
+	return true
+}
+
+// validateProbes writes a 400 response and returns false when probeIDs
+// reference probes outside the organization. Push monitors receive their
+// heartbeats instead of running checks, so they cannot have probes.
+func (ic *MonitorController) validateProbes(ctx *gin.Context, monitorType string, probeIDs []string, orgID string) bool {
+	if len(probeIDs) == 0 {
+		return true
+	}
+
+	if monitorType == "push" {
+		ctx.JSON(http.StatusBadRequest, utils.NewFailResponse("Push monitors cannot be checked from probes"))
+		return false
+	}
+
+	for _, probeID := range probeIDs {
+		p, err := ic.probeService.FindByID(ctx, probeID, orgID)
+		if err != nil {
+			ic.logger.Errorw("Failed to fetch probe", "probeID", probeID, "error", err)
+			ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse("Internal server error"))
+			return false
+		}
+		if p == nil {
+			ctx.JSON(http.StatusBadRequest, utils.NewFailResponse(fmt.Sprintf("Probe %s not found", probeID)))
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,121 @@
+package monitor
+
+import (
+	"context"
+	"vigi/internal/secret"
+)
+
+// encryptedRepository encrypts the secret config fields before they reach
+// the database and decrypts them on the way back.
+type encryptedRepository struct {
+	MonitorRepository
+	keyring *secret.Keyring
+}
+
+func NewEncryptedRepository(repository MonitorRepository, keyring *secret.Keyring) MonitorRepository {
+	return &encryptedRepository{repository, keyring}
+}
+
+func (r *encryptedRepository) encrypt(m *Model) (*Model, error) {
+	config, err := r.keyring.EncryptFields(m.Config, SecretConfigFields)
+	if err != nil {
+		return nil, err
+	}
+	encrypted := *m
+	encrypted.Config = config
+	return &encrypted, nil
+}
+
+func (r *encryptedRepository) decrypt(m *Model, err error) (*Model, error) {
+	if err != nil || m == nil {
+		return m, err
+	}
+	m.Config, err = r.keyring.DecryptFields(m.Config, SecretConfigFields)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (r *encryptedRepository) decryptAll(monitors []*Model, err error) ([]*Model, error) {
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range monitors {
+		if _, err := r.decrypt(m, nil); err != nil {
+			return nil, err
+		}
+	}
+	return monitors, nil
+}
+
+func (r *encryptedRepository) Create(ctx context.Context, monitor *Model) (*Model, error) {
+	encrypted, err := r.encrypt(monitor)
+	if err != nil {
+		return nil, err
+	}
+	return r.decrypt(r.MonitorRepository.Create(ctx, encrypted))
+}
+
+func (r *encryptedRepository) FindByID(ctx context.Context, id string, orgID string) (*Model, error) {
+	return r.decrypt(r.MonitorRepository.FindByID(ctx, id, orgID))
+}
+
+func (r *encryptedRepository) FindByIDs(ctx context.Context, ids []string, orgID string) ([]*Model, error) {
+	return r.decryptAll(r.MonitorRepository.FindByIDs(ctx, ids, orgID))
+}
+
+func (r *encryptedRepository) FindAll(
+	ctx context.Context,
+	page int,
+	limit int,
+	q string,
+	active *bool,
+	status *int,
+	tagIds []string,
+	orgID string,
+) ([]*Model, error) {
+	return r.decryptAll(r.MonitorRepository.FindAll(ctx, page, limit, q, active, status, tagIds, orgID))
+}
+
+func (r *encryptedRepository) FindActive(ctx context.Context) ([]*Model, error) {
+	return r.decryptAll(r.MonitorRepository.FindActive(ctx))
+}
+
+func (r *encryptedRepository) FindActivePaginated(ctx context.Context, page int, limit int) ([]*Model, error) {
+	return r.decryptAll(r.MonitorRepository.FindActivePaginated(ctx, page, limit))
+}
+
+func (r *encryptedRepository) FindAllPaginated(ctx context.Context, page int, limit int) ([]*Model, error) {
+	return r.decryptAll(r.MonitorRepository.FindAllPaginated(ctx, page, limit))
+}
+
+func (r *encryptedRepository) UpdateFull(ctx context.Context, id string, monitor *Model, orgID string) error {
+	encrypted, err := r.encrypt(monitor)
+	if err != nil {
+		return err
+	}
+	return r.MonitorRepository.UpdateFull(ctx, id, encrypted, orgID)
+}
+
+func (r *encryptedRepository) UpdatePartial(ctx context.Context, id string, monitor *UpdateModel, orgID string) error {
+	if monitor.Config == nil {
+		return r.MonitorRepository.UpdatePartial(ctx, id, monitor, orgID)
+	}
+
+	config, err := r.keyring.EncryptFields(*monitor.Config, SecretConfigFields)
+	if err != nil {
+		return err
+	}
+	encrypted := *monitor
+	encrypted.Config = &config
+	return r.MonitorRepository.UpdatePartial(ctx, id, &encrypted, orgID)
+}
+
+func (r *encryptedRepository) FindByProxyId(ctx context.Context, proxyId string) ([]*Model, error) {
+	return r.decryptAll(r.MonitorRepository.FindByProxyId(ctx, proxyId))
+}
+
+func (r *encryptedRepository) FindOneByPushToken(ctx context.Context, pushToken string) (*Model, error) {
+	return r.decrypt(r.MonitorRepository.FindOneByPushToken(ctx, pushToken))
+}
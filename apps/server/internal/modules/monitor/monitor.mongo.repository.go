@@ -572,6 +572,39 @@ func (r *MonitorRepositoryImpl) FindActivePaginated(ctx context.Context, page in
 	return monitors, nil
 }
 
+// FindAllPaginated retrieves monitors of every organization with pagination.
+func (r *MonitorRepositoryImpl) FindAllPaginated(ctx context.Context, page int, limit int) ([]*Model, error) {
+	var monitors []*Model
+
+	skip := int64(page * limit)
+	limit64 := int64(limit)
+
+	options := &options.FindOptions{
+		Skip:  &skip,
+		Limit: &limit64,
+		Sort:  bson.D{{Key: "_id", Value: -1}},
+	}
+
+	cursor, err := r.collection.Find(ctx, bson.M{}, options)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var mm mongoModel
+		if err := cursor.Decode(&mm); err != nil {
+			return nil, err
+		}
+		monitors = append(monitors, toDomainModel(&mm))
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+	return monitors, nil
+}
+
 // RemoveProxyReference sets proxy_id to an empty string for all monitors with the given proxyId.
 func (r *MonitorRepositoryImpl) RemoveProxyReference(ctx context.Context, proxyId string) error {
 	objectID, err := primitive.ObjectIDFromHex(proxyId)
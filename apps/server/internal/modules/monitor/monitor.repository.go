@@ -18,6 +18,7 @@ type MonitorRepository interface {
 	) ([]*Model, error)
 	FindActive(ctx context.Context) ([]*Model, error)
 	FindActivePaginated(ctx context.Context, page int, limit int) ([]*Model, error)
+	FindAllPaginated(ctx context.Context, page int, limit int) ([]*Model, error)
 	UpdateFull(ctx context.Context, id string, monitor *Model, orgID string) error
 	UpdatePartial(ctx context.Context, id string, monitor *UpdateModel, orgID string) error
 	Delete(ctx context.Context, id string, orgID string) error
@@ -1,6 +1,7 @@
 package monitor
 
 import (
+	"vigi/internal/modules/api_key"
 	"vigi/internal/modules/middleware"
 	"vigi/internal/modules/organization"
 
@@ -30,7 +31,7 @@ func (uc *MonitorRoute) ConnectRoute(
 	monitorController *MonitorController,
 ) {
 	router := rg.Group("monitors")
-	router.Use(uc.middleware.AllAuth())
+	router.Use(uc.middleware.AllAuth(api_key.ScopeMonitorsRead, api_key.ScopeMonitorsWrite))
 	router.Use(uc.orgMiddleware.RequireOrganization())
 
 	router.GET("", uc.monitorController.FindAll)
@@ -45,4 +46,5 @@ func (uc *MonitorRoute) ConnectRoute(
 	router.GET(":id/stats/uptime", uc.monitorController.GetUptimeStats)
 	router.GET(":id/stats/points", uc.monitorController.GetStatPoints)
 	router.GET(":id/tls", uc.monitorController.GetTLSInfo)
+	router.GET(":id/dependencies", uc.monitorController.GetDependencyTree)
 }
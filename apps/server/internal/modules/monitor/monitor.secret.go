@@ -0,0 +1,43 @@
+package monitor
+
+import "vigi/internal/secret"
+
+// SecretConfigFields are the config fields holding credentials, whatever the
+// monitor type. They are encrypted at rest and redacted in API responses.
+// Request headers often carry tokens, so every header value is covered, and
+// database connection strings embed the password.
+var SecretConfigFields = []string{
+	"basic_auth_pass",
+	"oauth_client_secret",
+	"tlsKey",
+	"tls_key",
+	"clientKey",
+	"password",
+	"auth_passphrase",
+	"priv_passphrase",
+	"sasl_options.password",
+	"headers.*",
+	"steps.*.headers.*",
+	"database_connection_string",
+	"connection_string",
+	"connectionString",
+	"databaseConnectionString",
+}
+
+// redact returns a copy of the monitor safe to send to clients
+func redact(m *Model) *Model {
+	if m == nil {
+		return nil
+	}
+	redacted := *m
+	redacted.Config = secret.RedactFields(m.Config, SecretConfigFields)
+	return &redacted
+}
+
+func redactAll(monitors []*Model) []*Model {
+	redacted := make([]*Model, 0, len(monitors))
+	for _, m := range monitors {
+		redacted = append(redacted, redact(m))
+	}
+	return redacted
+}
@@ -3,14 +3,18 @@ package monitor
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 	"vigi/internal/modules/events"
 	"vigi/internal/modules/healthcheck/executor"
 	"vigi/internal/modules/heartbeat"
+	"vigi/internal/modules/monitor_dependency"
 	"vigi/internal/modules/monitor_notification"
 	"vigi/internal/modules/monitor_tag"
+	"vigi/internal/modules/probe"
 	"vigi/internal/modules/shared"
 	"vigi/internal/modules/stats"
+	"vigi/internal/secret"
 
 	"go.uber.org/zap"
 )
@@ -22,10 +26,14 @@ type Service interface {
 	FindAll(ctx context.Context, page int, limit int, q string, active *bool, status *int, tagIds []string, orgID string) ([]*Model, error)
 	FindActive(ctx context.Context) ([]*Model, error)
 	FindActivePaginated(ctx context.Context, page int, limit int) ([]*Model, error)
+	// FindAllPaginated returns the monitors of every organization, active or not
+	FindAllPaginated(ctx context.Context, page int, limit int) ([]*Model, error)
 	UpdateFull(ctx context.Context, id string, monitor *CreateUpdateDto) (*Model, error)
 	UpdatePartial(ctx context.Context, id string, monitor *PartialUpdateDto, noPublish bool, orgID string) (*Model, error)
 	Delete(ctx context.Context, id string, orgID string) error
 	ValidateMonitorConfig(monitorType string, configJSON string) error
+	// RestoreSecrets replaces the masked secrets of a config sent back by a client with the stored ones
+	RestoreSecrets(ctx context.Context, id string, config string, orgID string) (string, error)
 
 	GetHeartbeats(ctx context.Context, id string, limit, page int, important *bool, reverse bool, orgID string) ([]*heartbeat.Model, error)
 
@@ -43,6 +51,7 @@ type StatPoint struct {
 	Up          int     `json:"up"`
 	Down        int     `json:"down"`
 	Maintenance int     `json:"maintenance"`
+	Degraded    int     `json:"degraded"`
 	Ping        float64 `json:"ping"`
 	PingMin     float64 `json:"ping_min"`
 	PingMax     float64 `json:"ping_max"`
@@ -55,6 +64,8 @@ type MonitorServiceImpl struct {
 	eventBus                   events.EventBus
 	monitorNotificationService monitor_notification.Service
 	monitorTagService          monitor_tag.Service
+	monitorDependencyService   monitor_dependency.Service
+	probeService               probe.Service
 	executorRegistry           *executor.ExecutorRegistry
 	statPointsService          stats.Service
 	logger                     *zap.SugaredLogger
@@ -66,6 +77,8 @@ func NewMonitorService(
 	eventBus events.EventBus,
 	monitorNotificationService monitor_notification.Service,
 	monitorTagService monitor_tag.Service,
+	monitorDependencyService monitor_dependency.Service,
+	probeService probe.Service,
 	executorRegistry *executor.ExecutorRegistry,
 	statPointsService stats.Service,
 	logger *zap.SugaredLogger,
@@ -76,6 +89,8 @@ func NewMonitorService(
 		eventBus,
 		monitorNotificationService,
 		monitorTagService,
+		monitorDependencyService,
+		probeService,
 		executorRegistry,
 		statPointsService,
 		logger.Named("[monitor-service]"),
@@ -139,7 +154,16 @@ func (mr *MonitorServiceImpl) FindActivePaginated(ctx context.Context, page int,
 	return mr.monitorRepository.FindActivePaginated(ctx, page, limit)
 }
 
+func (mr *MonitorServiceImpl) FindAllPaginated(ctx context.Context, page int, limit int) ([]*Model, error) {
+	return mr.monitorRepository.FindAllPaginated(ctx, page, limit)
+}
+
 func (mr *MonitorServiceImpl) UpdateFull(ctx context.Context, id string, monitor *CreateUpdateDto) (*Model, error) {
+	config, err := mr.RestoreSecrets(ctx, id, monitor.Config, monitor.OrgID)
+	if err != nil {
+		return nil, err
+	}
+
 	model := &Model{
 		ID:             id,
 		Name:           monitor.Name,
@@ -152,13 +176,13 @@ func (mr *MonitorServiceImpl) UpdateFull(ctx context.Context, id string, monitor
 		Active:         monitor.Active,
 		Status:         shared.MonitorStatusUp,
 		UpdatedAt:      time.Now().UTC(),
-		Config:         monitor.Config,
+		Config:         config,
 		ProxyId:        monitor.ProxyId,
 		PushToken:      monitor.PushToken,
 		OrgID:          monitor.OrgID,
 	}
 
-	err := mr.monitorRepository.UpdateFull(ctx, id, model, monitor.OrgID)
+	err = mr.monitorRepository.UpdateFull(ctx, id, model, monitor.OrgID)
 	if err != nil {
 		return nil, err
 	}
@@ -173,6 +197,15 @@ func (mr *MonitorServiceImpl) UpdateFull(ctx context.Context, id string, monitor
 }
 
 func (mr *MonitorServiceImpl) UpdatePartial(ctx context.Context, id string, monitor *PartialUpdateDto, noPublish bool, orgID string) (*Model, error) {
+	config := monitor.Config
+	if config != nil {
+		restored, err := mr.RestoreSecrets(ctx, id, *config, orgID)
+		if err != nil {
+			return nil, err
+		}
+		config = &restored
+	}
+
 	model := &UpdateModel{
 		ID:             &id,
 		Type:           monitor.Type,
@@ -184,7 +217,7 @@ func (mr *MonitorServiceImpl) UpdatePartial(ctx context.Context, id string, moni
 		ResendInterval: monitor.ResendInterval,
 		Active:         monitor.Active,
 		Status:         monitor.Status,
-		Config:         monitor.Config,
+		Config:         config,
 		ProxyId:        monitor.ProxyId,
 		PushToken:      monitor.PushToken,
 		OrgID:          monitor.OrgID,
@@ -212,6 +245,23 @@ func (mr *MonitorServiceImpl) UpdatePartial(ctx context.Context, id string, moni
 	return updatedMonitor, nil
 }
 
+func (mr *MonitorServiceImpl) RestoreSecrets(ctx context.Context, id string, config string, orgID string) (string, error) {
+	if !strings.Contains(config, secret.Mask) {
+		return config, nil
+	}
+
+	existing, err := mr.monitorRepository.FindByID(ctx, id, orgID)
+	if err != nil {
+		return "", err
+	}
+
+	stored := ""
+	if existing != nil {
+		stored = existing.Config
+	}
+	return secret.RestoreFields(config, stored, SecretConfigFields), nil
+}
+
 func (mr *MonitorServiceImpl) Delete(ctx context.Context, id string, orgID string) error {
 	err := mr.monitorRepository.Delete(ctx, id, orgID)
 	if err != nil {
@@ -220,6 +270,8 @@ func (mr *MonitorServiceImpl) Delete(ctx context.Context, id string, orgID strin
 
 	_ = mr.monitorNotificationService.DeleteByMonitorID(ctx, id)
 	_ = mr.monitorTagService.DeleteByMonitorID(ctx, id)
+	_ = mr.monitorDependencyService.DeleteByMonitorID(ctx, id)
+	_ = mr.probeService.DeleteByMonitorID(ctx, id)
 	_ = mr.heartbeatService.DeleteByMonitorID(ctx, id)
 	_ = mr.statPointsService.DeleteByMonitorID(ctx, id)
 
@@ -294,6 +346,7 @@ func (mr *MonitorServiceImpl) GetStatPoints(ctx context.Context, id string, sinc
 			Up:          s.Up,
 			Down:        s.Down,
 			Maintenance: s.Maintenance,
+			Degraded:    s.Degraded,
 			Ping:        s.Ping,
 			PingMin:     s.PingMin,
 			PingMax:     s.PingMax,
@@ -304,11 +357,12 @@ func (mr *MonitorServiceImpl) GetStatPoints(ctx context.Context, id string, sinc
 	stats := mr.statPointsService.StatPointsSummary(statsList)
 
 	return &StatPointsSummaryDto{
-		Points:  points,
-		MaxPing: stats.MaxPing,
-		MinPing: stats.MinPing,
-		AvgPing: stats.AvgPing,
-		Uptime:  stats.Uptime,
+		Points:   points,
+		MaxPing:  stats.MaxPing,
+		MinPing:  stats.MinPing,
+		AvgPing:  stats.AvgPing,
+		Uptime:   stats.Uptime,
+		Degraded: stats.Degraded,
 	}, nil
 }
 
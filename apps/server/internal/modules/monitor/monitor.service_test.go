@@ -11,6 +11,7 @@ import (
 	"vigi/internal/modules/heartbeat"
 	"vigi/internal/modules/monitor_notification"
 	"vigi/internal/modules/monitor_tag"
+	"vigi/internal/modules/probe"
 	"vigi/internal/modules/shared"
 	"vigi/internal/modules/stats"
 
@@ -58,6 +59,14 @@ func (m *MockMonitorRepository) FindActivePaginated(ctx context.Context, page in
 	return args.Get(0).([]*Model), args.Error(1)
 }
 
+func (m *MockMonitorRepository) FindAllPaginated(ctx context.Context, page int, limit int) ([]*Model, error) {
+	args := m.Called(ctx, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*Model), args.Error(1)
+}
+
 func (m *MockMonitorRepository) UpdateFull(ctx context.Context, id string, monitor *Model, orgID string) error {
 	args := m.Called(ctx, id, monitor, orgID)
 	return args.Error(0)
@@ -91,6 +100,11 @@ func (m *MockMonitorRepository) FindOneByPushToken(ctx context.Context, pushToke
 	return args.Get(0).(*Model), args.Error(1)
 }
 
+func (m *MockMonitorRepository) Count(ctx context.Context, orgID string) (int64, error) {
+	args := m.Called(ctx, orgID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 type MockHeartbeatService struct {
 	mock.Mock
 }
@@ -135,6 +149,24 @@ func (m *MockHeartbeatService) DeleteByMonitorID(ctx context.Context, monitorID
 	return args.Error(0)
 }
 
+func (m *MockHeartbeatService) FindOldestByMonitorID(ctx context.Context, monitorID string) (*heartbeat.Model, error) {
+	args := m.Called(ctx, monitorID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*heartbeat.Model), args.Error(1)
+}
+
+func (m *MockHeartbeatService) FindByMonitorIDAndTimeRange(ctx context.Context, monitorID string, since, until time.Time) ([]*heartbeat.Model, error) {
+	args := m.Called(ctx, monitorID, since, until)
+	return args.Get(0).([]*heartbeat.Model), args.Error(1)
+}
+
+func (m *MockHeartbeatService) DeleteByMonitorIDOlderThan(ctx context.Context, monitorID string, cutoff time.Time) (int64, error) {
+	args := m.Called(ctx, monitorID, cutoff)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 type MockEventBus struct {
 	mock.Mock
 }
@@ -221,6 +253,126 @@ func (m *MockMonitorTagService) DeleteByMonitorAndTag(ctx context.Context, monit
 	return args.Error(0)
 }
 
+type MockMonitorDependencyService struct {
+	mock.Mock
+}
+
+func (m *MockMonitorDependencyService) ValidateParents(ctx context.Context, monitorID string, parentIDs []string) error {
+	args := m.Called(ctx, monitorID, parentIDs)
+	return args.Error(0)
+}
+
+func (m *MockMonitorDependencyService) SetParents(ctx context.Context, monitorID string, parentIDs []string) error {
+	args := m.Called(ctx, monitorID, parentIDs)
+	return args.Error(0)
+}
+
+func (m *MockMonitorDependencyService) FindParentIDs(ctx context.Context, monitorID string) ([]string, error) {
+	args := m.Called(ctx, monitorID)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockMonitorDependencyService) FindChildIDs(ctx context.Context, monitorID string) ([]string, error) {
+	args := m.Called(ctx, monitorID)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockMonitorDependencyService) DeleteByMonitorID(ctx context.Context, monitorID string) error {
+	args := m.Called(ctx, monitorID)
+	return args.Error(0)
+}
+
+type MockProbeService struct {
+	mock.Mock
+}
+
+func (m *MockProbeService) Create(ctx context.Context, dto *probe.CreateUpdateDto, orgID string) (*probe.WithToken, error) {
+	args := m.Called(ctx, dto, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*probe.WithToken), args.Error(1)
+}
+
+func (m *MockProbeService) FindByID(ctx context.Context, id string, orgID string) (*probe.Model, error) {
+	args := m.Called(ctx, id, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*probe.Model), args.Error(1)
+}
+
+func (m *MockProbeService) FindAll(ctx context.Context, page int, limit int, q string, orgID string) ([]*probe.Model, error) {
+	args := m.Called(ctx, page, limit, q, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*probe.Model), args.Error(1)
+}
+
+func (m *MockProbeService) Update(ctx context.Context, id string, dto *probe.CreateUpdateDto, orgID string) (*probe.Model, error) {
+	args := m.Called(ctx, id, dto, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*probe.Model), args.Error(1)
+}
+
+func (m *MockProbeService) Delete(ctx context.Context, id string, orgID string) error {
+	args := m.Called(ctx, id, orgID)
+	return args.Error(0)
+}
+
+func (m *MockProbeService) RegenerateToken(ctx context.Context, id string, orgID string) (*probe.WithToken, error) {
+	args := m.Called(ctx, id, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*probe.WithToken), args.Error(1)
+}
+
+func (m *MockProbeService) Authenticate(ctx context.Context, token string) (*probe.Model, error) {
+	args := m.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*probe.Model), args.Error(1)
+}
+
+func (m *MockProbeService) SetMonitorProbes(ctx context.Context, monitorID string, probeIDs []string, downThreshold int, orgID string) error {
+	args := m.Called(ctx, monitorID, probeIDs, downThreshold, orgID)
+	return args.Error(0)
+}
+
+func (m *MockProbeService) FindAssignment(ctx context.Context, monitorID string) (*probe.Assignment, error) {
+	args := m.Called(ctx, monitorID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*probe.Assignment), args.Error(1)
+}
+
+func (m *MockProbeService) FindMonitorIDsByProbeID(ctx context.Context, probeID string) ([]string, error) {
+	args := m.Called(ctx, probeID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockProbeService) DeleteByMonitorID(ctx context.Context, monitorID string) error {
+	args := m.Called(ctx, monitorID)
+	return args.Error(0)
+}
+
+func (m *MockProbeService) RecordResult(ctx context.Context, result *probe.Result, maxAge time.Duration) (*probe.Summary, error) {
+	args := m.Called(ctx, result, maxAge)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*probe.Summary), args.Error(1)
+}
+
 type MockExecutorRegistry struct {
 	mock.Mock
 }
@@ -248,6 +400,16 @@ func (m *MockStatsService) DeleteByMonitorID(ctx context.Context, monitorID stri
 	return args.Error(0)
 }
 
+func (m *MockStatsService) RebuildRollups(ctx context.Context, monitorID string, heartbeats []*stats.HeartbeatPayload, since, until time.Time) (int, error) {
+	args := m.Called(ctx, monitorID, heartbeats, since, until)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockStatsService) DeleteOlderThan(ctx context.Context, monitorID string, period stats.StatPeriod, cutoff time.Time) (int64, error) {
+	args := m.Called(ctx, monitorID, period, cutoff)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 func (m *MockStatsService) FindStatsByMonitorIDAndTimeRangeWithInterval(ctx context.Context, monitorID string, since, until time.Time, period stats.StatPeriod, interval int) ([]*stats.Stat, error) {
 	args := m.Called(ctx, monitorID, since, until, period, interval)
 	return args.Get(0).([]*stats.Stat), args.Error(1)
@@ -283,7 +445,13 @@ func setupMonitorService() (*MonitorServiceImpl, *MockMonitorRepository, *MockHe
 	realEventBus := infra.NewRedisEventBus(redisClient, logger)
 
 	// Create a real ExecutorRegistry since the service expects a pointer to ExecutorRegistry
-	realExecutorRegistry := executor.NewExecutorRegistry(logger)
+	realExecutorRegistry := executor.NewExecutorRegistry(logger, executor.NewMemoryStateStore())
+
+	// Dependency cleanup is best effort and not asserted on
+	mockDependencyService := &MockMonitorDependencyService{}
+	mockDependencyService.On("DeleteByMonitorID", mock.Anything, mock.Anything).Return(nil).Maybe()
+	mockProbeService := &MockProbeService{}
+	mockProbeService.On("DeleteByMonitorID", mock.Anything, mock.Anything).Return(nil).Maybe()
 
 	service := NewMonitorService(
 		mockRepo,
@@ -291,6 +459,8 @@ func setupMonitorService() (*MonitorServiceImpl, *MockMonitorRepository, *MockHe
 		realEventBus,
 		mockNotificationService,
 		mockTagService,
+		mockDependencyService,
+		mockProbeService,
 		realExecutorRegistry,
 		mockStatsService,
 		logger,
@@ -1261,7 +1431,13 @@ func TestNewMonitorService(t *testing.T) {
 
 	// Create real instances for dependencies that expect concrete types
 	realEventBus := infra.NewRedisEventBus(redisClient, logger)
-	realExecutorRegistry := executor.NewExecutorRegistry(logger)
+	realExecutorRegistry := executor.NewExecutorRegistry(logger, executor.NewMemoryStateStore())
+
+	// Dependency cleanup is best effort and not asserted on
+	mockDependencyService := &MockMonitorDependencyService{}
+	mockDependencyService.On("DeleteByMonitorID", mock.Anything, mock.Anything).Return(nil).Maybe()
+	mockProbeService := &MockProbeService{}
+	mockProbeService.On("DeleteByMonitorID", mock.Anything, mock.Anything).Return(nil).Maybe()
 
 	service := NewMonitorService(
 		mockRepo,
@@ -1269,6 +1445,8 @@ func TestNewMonitorService(t *testing.T) {
 		realEventBus,
 		mockNotificationService,
 		mockTagService,
+		mockDependencyService,
+		mockProbeService,
 		realExecutorRegistry,
 		mockStatsService,
 		logger,
@@ -1283,6 +1461,8 @@ func TestNewMonitorService(t *testing.T) {
 	assert.Equal(t, realEventBus, serviceImpl.eventBus)
 	assert.Equal(t, mockNotificationService, serviceImpl.monitorNotificationService)
 	assert.Equal(t, mockTagService, serviceImpl.monitorTagService)
+	assert.Equal(t, mockDependencyService, serviceImpl.monitorDependencyService)
+	assert.Equal(t, mockProbeService, serviceImpl.probeService)
 	assert.Equal(t, realExecutorRegistry, serviceImpl.executorRegistry)
 	assert.Equal(t, mockStatsService, serviceImpl.statPointsService)
 	assert.NotNil(t, serviceImpl.logger)
@@ -247,6 +247,25 @@ func (r *SQLRepositoryImpl) FindActivePaginated(ctx context.Context, page int, l
 	return models, nil
 }
 
+func (r *SQLRepositoryImpl) FindAllPaginated(ctx context.Context, page int, limit int) ([]*Model, error) {
+	var sms []*sqlModel
+	err := r.db.NewSelect().
+		Model(&sms).
+		Order("id DESC").
+		Limit(limit).
+		Offset(page * limit).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var models []*Model
+	for _, sm := range sms {
+		models = append(models, toDomainModelFromSQL(sm))
+	}
+	return models, nil
+}
+
 func (r *SQLRepositoryImpl) UpdateFull(ctx context.Context, id string, monitor *Model, orgID string) error {
 	sm := toSQLModel(monitor)
 	sm.UpdatedAt = time.Now()
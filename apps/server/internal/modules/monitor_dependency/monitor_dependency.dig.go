@@ -0,0 +1,13 @@
+package monitor_dependency
+
+import (
+	"vigi/internal/config"
+	"vigi/internal/utils"
+
+	"go.uber.org/dig"
+)
+
+func RegisterDependencies(container *dig.Container, cfg *config.Config) {
+	utils.RegisterRepositoryByDBType(container, cfg, NewSQLRepository, NewMongoRepository)
+	container.Provide(NewService)
+}
@@ -0,0 +1,12 @@
+package monitor_dependency
+
+import "time"
+
+// Model links a child monitor to one of the parent monitors it depends on.
+type Model struct {
+	ID        string    `json:"id"`
+	MonitorID string    `json:"monitor_id"`
+	ParentID  string    `json:"parent_id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,151 @@
+package monitor_dependency
+
+import (
+	"context"
+	"time"
+	"vigi/internal/config"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoModel struct {
+	ID        primitive.ObjectID `bson:"_id"`
+	MonitorID primitive.ObjectID `bson:"monitor_id"`
+	ParentID  primitive.ObjectID `bson:"parent_id"`
+	CreatedAt time.Time          `bson:"created_at"`
+	UpdatedAt time.Time          `bson:"updated_at"`
+}
+
+func toDomainModelFromMongo(mm *mongoModel) *Model {
+	return &Model{
+		ID:        mm.ID.Hex(),
+		MonitorID: mm.MonitorID.Hex(),
+		ParentID:  mm.ParentID.Hex(),
+		CreatedAt: mm.CreatedAt,
+		UpdatedAt: mm.UpdatedAt,
+	}
+}
+
+type MongoRepositoryImpl struct {
+	client     *mongo.Client
+	db         *mongo.Database
+	collection *mongo.Collection
+}
+
+func NewMongoRepository(client *mongo.Client, cfg *config.Config) Repository {
+	db := client.Database(cfg.DBName)
+	collection := db.Collection("monitor_dependencies")
+
+	_, err := collection.Indexes().CreateMany(context.TODO(), []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "monitor_id", Value: 1},
+				{Key: "parent_id", Value: 1},
+			},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "parent_id", Value: 1}},
+		},
+	})
+
+	if err != nil {
+		panic("Failed to create index for monitor_dependencies: " + err.Error())
+	}
+
+	return &MongoRepositoryImpl{client, db, collection}
+}
+
+func (r *MongoRepositoryImpl) Create(ctx context.Context, model *Model) (*Model, error) {
+	monitorObjectID, err := primitive.ObjectIDFromHex(model.MonitorID)
+	if err != nil {
+		return nil, err
+	}
+
+	parentObjectID, err := primitive.ObjectIDFromHex(model.ParentID)
+	if err != nil {
+		return nil, err
+	}
+
+	mm := &mongoModel{
+		ID:        primitive.NewObjectID(),
+		MonitorID: monitorObjectID,
+		ParentID:  parentObjectID,
+		CreatedAt: time.Now().UTC(),
+		UpdatedAt: time.Now().UTC(),
+	}
+
+	_, err = r.collection.InsertOne(ctx, mm)
+	if err != nil {
+		return nil, err
+	}
+
+	return toDomainModelFromMongo(mm), nil
+}
+
+func (r *MongoRepositoryImpl) FindByMonitorID(ctx context.Context, monitorID string) ([]*Model, error) {
+	return r.findByObjectID(ctx, "monitor_id", monitorID)
+}
+
+func (r *MongoRepositoryImpl) FindByParentID(ctx context.Context, parentID string) ([]*Model, error) {
+	return r.findByObjectID(ctx, "parent_id", parentID)
+}
+
+func (r *MongoRepositoryImpl) findByObjectID(ctx context.Context, field string, id string) ([]*Model, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+	cursor, err := r.collection.Find(ctx, bson.M{field: objectID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	models := make([]*Model, 0)
+	for cursor.Next(ctx) {
+		var entity mongoModel
+		if err := cursor.Decode(&entity); err != nil {
+			return nil, err
+		}
+		models = append(models, toDomainModelFromMongo(&entity))
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (r *MongoRepositoryImpl) Delete(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.collection.DeleteOne(ctx, bson.M{"_id": objectID})
+	return err
+}
+
+func (r *MongoRepositoryImpl) DeleteByMonitorID(ctx context.Context, monitorID string) error {
+	return r.deleteByObjectID(ctx, "monitor_id", monitorID)
+}
+
+func (r *MongoRepositoryImpl) DeleteByParentID(ctx context.Context, parentID string) error {
+	return r.deleteByObjectID(ctx, "parent_id", parentID)
+}
+
+func (r *MongoRepositoryImpl) deleteByObjectID(ctx context.Context, field string, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.collection.DeleteMany(ctx, bson.M{field: objectID})
+	return err
+}
@@ -0,0 +1,14 @@
+package monitor_dependency
+
+import (
+	"context"
+)
+
+type Repository interface {
+	Create(ctx context.Context, model *Model) (*Model, error)
+	FindByMonitorID(ctx context.Context, monitorID string) ([]*Model, error)
+	FindByParentID(ctx context.Context, parentID string) ([]*Model, error)
+	Delete(ctx context.Context, id string) error
+	DeleteByMonitorID(ctx context.Context, monitorID string) error
+	DeleteByParentID(ctx context.Context, parentID string) error
+}
@@ -0,0 +1,162 @@
+package monitor_dependency
+
+import (
+	"context"
+	"errors"
+
+	"go.uber.org/zap"
+)
+
+var (
+	ErrSelfDependency  = errors.New("monitor cannot depend on itself")
+	ErrDependencyCycle = errors.New("monitor dependency would create a cycle")
+)
+
+type Service interface {
+	// ValidateParents checks that monitorID can depend on parentIDs without
+	// depending on itself or closing a cycle.
+	ValidateParents(ctx context.Context, monitorID string, parentIDs []string) error
+	// SetParents replaces the parents of monitorID after validating them.
+	SetParents(ctx context.Context, monitorID string, parentIDs []string) error
+	FindParentIDs(ctx context.Context, monitorID string) ([]string, error)
+	FindChildIDs(ctx context.Context, monitorID string) ([]string, error)
+	// DeleteByMonitorID drops every dependency the monitor takes part in,
+	// both as a child and as a parent.
+	DeleteByMonitorID(ctx context.Context, monitorID string) error
+}
+
+type ServiceImpl struct {
+	repository Repository
+	logger     *zap.SugaredLogger
+}
+
+func NewService(
+	repository Repository,
+	logger *zap.SugaredLogger,
+) Service {
+	return &ServiceImpl{
+		repository,
+		logger.Named("[monitor-dependency-service]"),
+	}
+}
+
+func (s *ServiceImpl) ValidateParents(ctx context.Context, monitorID string, parentIDs []string) error {
+	for _, parentID := range parentIDs {
+		if parentID == monitorID {
+			return ErrSelfDependency
+		}
+	}
+
+	// Adding parent -> monitor closes a cycle when the monitor is already an
+	// ancestor of one of the new parents, so walk up from the parents.
+	visited := make(map[string]struct{})
+	queue := append([]string(nil), parentIDs...)
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		if current == monitorID {
+			return ErrDependencyCycle
+		}
+		if _, seen := visited[current]; seen {
+			continue
+		}
+		visited[current] = struct{}{}
+
+		ancestors, err := s.FindParentIDs(ctx, current)
+		if err != nil {
+			return err
+		}
+		queue = append(queue, ancestors...)
+	}
+
+	return nil
+}
+
+func (s *ServiceImpl) SetParents(ctx context.Context, monitorID string, parentIDs []string) error {
+	parentIDs = dedupe(parentIDs)
+	if err := s.ValidateParents(ctx, monitorID, parentIDs); err != nil {
+		return err
+	}
+
+	existing, err := s.repository.FindByMonitorID(ctx, monitorID)
+	if err != nil {
+		return err
+	}
+
+	wanted := make(map[string]struct{}, len(parentIDs))
+	for _, parentID := range parentIDs {
+		wanted[parentID] = struct{}{}
+	}
+
+	current := make(map[string]struct{}, len(existing))
+	for _, rel := range existing {
+		if _, keep := wanted[rel.ParentID]; !keep {
+			if err := s.repository.Delete(ctx, rel.ID); err != nil {
+				return err
+			}
+			continue
+		}
+		current[rel.ParentID] = struct{}{}
+	}
+
+	for _, parentID := range parentIDs {
+		if _, found := current[parentID]; found {
+			continue
+		}
+		if _, err := s.repository.Create(ctx, &Model{MonitorID: monitorID, ParentID: parentID}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *ServiceImpl) FindParentIDs(ctx context.Context, monitorID string) ([]string, error) {
+	rels, err := s.repository.FindByMonitorID(ctx, monitorID)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(rels))
+	for _, rel := range rels {
+		ids = append(ids, rel.ParentID)
+	}
+	return ids, nil
+}
+
+func (s *ServiceImpl) FindChildIDs(ctx context.Context, monitorID string) ([]string, error) {
+	rels, err := s.repository.FindByParentID(ctx, monitorID)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(rels))
+	for _, rel := range rels {
+		ids = append(ids, rel.MonitorID)
+	}
+	return ids, nil
+}
+
+func (s *ServiceImpl) DeleteByMonitorID(ctx context.Context, monitorID string) error {
+	if err := s.repository.DeleteByMonitorID(ctx, monitorID); err != nil {
+		return err
+	}
+	return s.repository.DeleteByParentID(ctx, monitorID)
+}
+
+func dedupe(ids []string) []string {
+	seen := make(map[string]struct{}, len(ids))
+	result := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if id == "" {
+			continue
+		}
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		result = append(result, id)
+	}
+	return result
+}
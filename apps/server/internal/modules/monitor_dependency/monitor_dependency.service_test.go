@@ -0,0 +1,146 @@
+package monitor_dependency
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type fakeRepository struct {
+	rels   []*Model
+	nextID int
+}
+
+func (r *fakeRepository) Create(ctx context.Context, model *Model) (*Model, error) {
+	r.nextID++
+	created := &Model{ID: fmt.Sprintf("rel-%d", r.nextID), MonitorID: model.MonitorID, ParentID: model.ParentID}
+	r.rels = append(r.rels, created)
+	return created, nil
+}
+
+func (r *fakeRepository) FindByMonitorID(ctx context.Context, monitorID string) ([]*Model, error) {
+	var result []*Model
+	for _, rel := range r.rels {
+		if rel.MonitorID == monitorID {
+			result = append(result, rel)
+		}
+	}
+	return result, nil
+}
+
+func (r *fakeRepository) FindByParentID(ctx context.Context, parentID string) ([]*Model, error) {
+	var result []*Model
+	for _, rel := range r.rels {
+		if rel.ParentID == parentID {
+			result = append(result, rel)
+		}
+	}
+	return result, nil
+}
+
+func (r *fakeRepository) Delete(ctx context.Context, id string) error {
+	return r.remove(func(rel *Model) bool { return rel.ID == id })
+}
+
+func (r *fakeRepository) DeleteByMonitorID(ctx context.Context, monitorID string) error {
+	return r.remove(func(rel *Model) bool { return rel.MonitorID == monitorID })
+}
+
+func (r *fakeRepository) DeleteByParentID(ctx context.Context, parentID string) error {
+	return r.remove(func(rel *Model) bool { return rel.ParentID == parentID })
+}
+
+func (r *fakeRepository) remove(match func(*Model) bool) error {
+	kept := r.rels[:0]
+	for _, rel := range r.rels {
+		if !match(rel) {
+			kept = append(kept, rel)
+		}
+	}
+	r.rels = kept
+	return nil
+}
+
+func newTestService() (Service, *fakeRepository) {
+	repo := &fakeRepository{}
+	return NewService(repo, zap.NewNop().Sugar()), repo
+}
+
+func TestServiceImpl_SetParents(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("replaces parents and drops duplicates", func(t *testing.T) {
+		svc, repo := newTestService()
+
+		require.NoError(t, svc.SetParents(ctx, "web", []string{"router", "dns", "router"}))
+		parents, err := svc.FindParentIDs(ctx, "web")
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"router", "dns"}, parents)
+
+		require.NoError(t, svc.SetParents(ctx, "web", []string{"dns", "vpn"}))
+		parents, err = svc.FindParentIDs(ctx, "web")
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"dns", "vpn"}, parents)
+		assert.Len(t, repo.rels, 2)
+
+		require.NoError(t, svc.SetParents(ctx, "web", nil))
+		assert.Empty(t, repo.rels)
+	})
+
+	t.Run("rejects self dependency", func(t *testing.T) {
+		svc, repo := newTestService()
+
+		err := svc.SetParents(ctx, "web", []string{"web"})
+		assert.ErrorIs(t, err, ErrSelfDependency)
+		assert.Empty(t, repo.rels)
+	})
+
+	t.Run("rejects direct cycle", func(t *testing.T) {
+		svc, _ := newTestService()
+		require.NoError(t, svc.SetParents(ctx, "web", []string{"router"}))
+
+		err := svc.SetParents(ctx, "router", []string{"web"})
+		assert.ErrorIs(t, err, ErrDependencyCycle)
+	})
+
+	t.Run("rejects indirect cycle and keeps existing parents", func(t *testing.T) {
+		svc, _ := newTestService()
+		require.NoError(t, svc.SetParents(ctx, "web", []string{"switch"}))
+		require.NoError(t, svc.SetParents(ctx, "switch", []string{"router"}))
+		require.NoError(t, svc.SetParents(ctx, "router", []string{"isp"}))
+
+		err := svc.SetParents(ctx, "router", []string{"isp", "web"})
+		assert.ErrorIs(t, err, ErrDependencyCycle)
+
+		parents, err := svc.FindParentIDs(ctx, "router")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"isp"}, parents)
+	})
+
+	t.Run("allows diamond", func(t *testing.T) {
+		svc, _ := newTestService()
+		require.NoError(t, svc.SetParents(ctx, "left", []string{"router"}))
+		require.NoError(t, svc.SetParents(ctx, "right", []string{"router"}))
+
+		assert.NoError(t, svc.SetParents(ctx, "web", []string{"left", "right"}))
+	})
+}
+
+func TestServiceImpl_DeleteByMonitorID(t *testing.T) {
+	ctx := context.Background()
+	svc, repo := newTestService()
+	require.NoError(t, svc.SetParents(ctx, "switch", []string{"router"}))
+	require.NoError(t, svc.SetParents(ctx, "web", []string{"switch"}))
+	require.NoError(t, svc.SetParents(ctx, "api", []string{"router"}))
+
+	require.NoError(t, svc.DeleteByMonitorID(ctx, "switch"))
+
+	assert.Len(t, repo.rels, 1)
+	children, err := svc.FindChildIDs(ctx, "router")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"api"}, children)
+}
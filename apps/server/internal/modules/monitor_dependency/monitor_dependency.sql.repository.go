@@ -0,0 +1,102 @@
+package monitor_dependency
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type sqlModel struct {
+	bun.BaseModel `bun:"table:monitor_dependencies,alias:md"`
+
+	ID        string    `bun:"id,pk"`
+	MonitorID string    `bun:"monitor_id,notnull"`
+	ParentID  string    `bun:"parent_id,notnull"`
+	CreatedAt time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp"`
+	UpdatedAt time.Time `bun:"updated_at,nullzero,notnull,default:current_timestamp"`
+}
+
+func toDomainModelFromSQL(sm *sqlModel) *Model {
+	return &Model{
+		ID:        sm.ID,
+		MonitorID: sm.MonitorID,
+		ParentID:  sm.ParentID,
+		CreatedAt: sm.CreatedAt,
+		UpdatedAt: sm.UpdatedAt,
+	}
+}
+
+func toSQLModel(m *Model) *sqlModel {
+	return &sqlModel{
+		ID:        m.ID,
+		MonitorID: m.MonitorID,
+		ParentID:  m.ParentID,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	}
+}
+
+type SQLRepositoryImpl struct {
+	db *bun.DB
+}
+
+func NewSQLRepository(db *bun.DB) Repository {
+	return &SQLRepositoryImpl{db: db}
+}
+
+func (r *SQLRepositoryImpl) Create(ctx context.Context, model *Model) (*Model, error) {
+	sm := toSQLModel(model)
+	sm.ID = uuid.New().String()
+	sm.CreatedAt = time.Now()
+	sm.UpdatedAt = time.Now()
+
+	_, err := r.db.NewInsert().Model(sm).Returning("*").Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return toDomainModelFromSQL(sm), nil
+}
+
+func (r *SQLRepositoryImpl) FindByMonitorID(ctx context.Context, monitorID string) ([]*Model, error) {
+	return r.findWhere(ctx, "monitor_id = ?", monitorID)
+}
+
+func (r *SQLRepositoryImpl) FindByParentID(ctx context.Context, parentID string) ([]*Model, error) {
+	return r.findWhere(ctx, "parent_id = ?", parentID)
+}
+
+func (r *SQLRepositoryImpl) findWhere(ctx context.Context, query string, arg string) ([]*Model, error) {
+	var sms []*sqlModel
+	err := r.db.NewSelect().
+		Model(&sms).
+		Where(query, arg).
+		Order("created_at ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	models := make([]*Model, 0, len(sms))
+	for _, sm := range sms {
+		models = append(models, toDomainModelFromSQL(sm))
+	}
+	return models, nil
+}
+
+func (r *SQLRepositoryImpl) Delete(ctx context.Context, id string) error {
+	_, err := r.db.NewDelete().Model((*sqlModel)(nil)).Where("id = ?", id).Exec(ctx)
+	return err
+}
+
+func (r *SQLRepositoryImpl) DeleteByMonitorID(ctx context.Context, monitorID string) error {
+	_, err := r.db.NewDelete().Model((*sqlModel)(nil)).Where("monitor_id = ?", monitorID).Exec(ctx)
+	return err
+}
+
+func (r *SQLRepositoryImpl) DeleteByParentID(ctx context.Context, parentID string) error {
+	_, err := r.db.NewDelete().Model((*sqlModel)(nil)).Where("parent_id = ?", parentID).Exec(ctx)
+	return err
+}
@@ -0,0 +1,228 @@
+package monitor_group
+
+import (
+	"errors"
+	"net/http"
+	"vigi/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type Controller struct {
+	service Service
+	logger  *zap.SugaredLogger
+}
+
+func NewController(
+	service Service,
+	logger *zap.SugaredLogger,
+) *Controller {
+	return &Controller{
+		service: service,
+		logger:  logger.Named("[monitor-group-controller]"),
+	}
+}
+
+// @Router    /monitor-groups [get]
+// @Summary   Get monitor groups
+// @Tags      Monitor Groups
+// @Produce   json
+// @Security  JwtAuth
+// @Security  ApiKeyAuth
+// @Param     q     query    string  false  "Search query"
+// @Param     page  query    int     false  "Page number" default(0)
+// @Param     limit query    int     false  "Items per page" default(10)
+// @Success   200  {object}  utils.ApiResponse[[]Model]
+// @Failure   400  {object}  utils.APIError[any]
+// @Failure   500  {object}  utils.APIError[any]
+func (c *Controller) FindAll(ctx *gin.Context) {
+	page, err := utils.GetQueryInt(ctx, "page", 0)
+	if err != nil || page < 0 {
+		ctx.JSON(http.StatusBadRequest, utils.NewFailResponse("Invalid page parameter"))
+		return
+	}
+
+	limit, err := utils.GetQueryInt(ctx, "limit", 10)
+	if err != nil || limit < 1 {
+		ctx.JSON(http.StatusBadRequest, utils.NewFailResponse("Invalid limit parameter"))
+		return
+	}
+
+	q := ctx.Query("q")
+	orgID := ctx.GetString("orgId")
+
+	groups, err := c.service.FindAll(ctx, page, limit, q, orgID)
+	if err != nil {
+		c.logger.Errorw("Failed to get monitor groups", "error", err)
+		ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse("Internal server error"))
+		return
+	}
+	ctx.JSON(http.StatusOK, utils.NewSuccessResponse("success", groups))
+}
+
+// @Router    /monitor-groups [post]
+// @Summary   Create a monitor group
+// @Tags      Monitor Groups
+// @Accept    json
+// @Produce   json
+// @Security  JwtAuth
+// @Security  ApiKeyAuth
+// @Param     body body CreateUpdateDto true "Monitor group"
+// @Success   201  {object} utils.ApiResponse[Model]
+// @Failure   400  {object} utils.APIError[any]
+// @Failure   500  {object} utils.APIError[any]
+func (c *Controller) Create(ctx *gin.Context) {
+	dto, ok := bindDTO(ctx)
+	if !ok {
+		return
+	}
+
+	orgID := ctx.GetString("orgId")
+
+	group, err := c.service.Create(ctx, dto, orgID)
+	if err != nil {
+		c.handleError(ctx, err, "Failed to create monitor group")
+		return
+	}
+	ctx.JSON(http.StatusCreated, utils.NewSuccessResponse("Monitor group created successfully", group))
+}
+
+// @Router    /monitor-groups/{id} [get]
+// @Summary   Get monitor group by ID
+// @Tags      Monitor Groups
+// @Produce   json
+// @Security  JwtAuth
+// @Security  ApiKeyAuth
+// @Param     id   path      string  true  "Group ID"
+// @Success   200  {object}  utils.ApiResponse[Model]
+// @Failure   404  {object}  utils.APIError[any]
+// @Failure   500  {object}  utils.APIError[any]
+func (c *Controller) FindByID(ctx *gin.Context) {
+	id := ctx.Param("id")
+	orgID := ctx.GetString("orgId")
+
+	group, err := c.service.FindByID(ctx, id, orgID)
+	if err != nil {
+		c.logger.Errorw("Failed to get monitor group", "error", err, "id", id)
+		ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse("Internal server error"))
+		return
+	}
+	if group == nil {
+		ctx.JSON(http.StatusNotFound, utils.NewFailResponse("Monitor group not found"))
+		return
+	}
+	ctx.JSON(http.StatusOK, utils.NewSuccessResponse("success", group))
+}
+
+// @Router    /monitor-groups/{id} [put]
+// @Summary   Update a monitor group
+// @Tags      Monitor Groups
+// @Accept    json
+// @Produce   json
+// @Security  JwtAuth
+// @Security  ApiKeyAuth
+// @Param     id   path string          true "Group ID"
+// @Param     body body CreateUpdateDto true "Monitor group"
+// @Success   200  {object} utils.ApiResponse[Model]
+// @Failure   400  {object} utils.APIError[any]
+// @Failure   404  {object} utils.APIError[any]
+// @Failure   500  {object} utils.APIError[any]
+func (c *Controller) Update(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	dto, ok := bindDTO(ctx)
+	if !ok {
+		return
+	}
+
+	orgID := ctx.GetString("orgId")
+
+	group, err := c.service.Update(ctx, id, dto, orgID)
+	if err != nil {
+		c.handleError(ctx, err, "Failed to update monitor group")
+		return
+	}
+	if group == nil {
+		ctx.JSON(http.StatusNotFound, utils.NewFailResponse("Monitor group not found"))
+		return
+	}
+	ctx.JSON(http.StatusOK, utils.NewSuccessResponse("Monitor group updated successfully", group))
+}
+
+// @Router    /monitor-groups/{id} [delete]
+// @Summary   Delete a monitor group
+// @Description Subgroups of the deleted group move up to the top level
+// @Tags      Monitor Groups
+// @Produce   json
+// @Security  JwtAuth
+// @Security  ApiKeyAuth
+// @Param     id   path      string  true  "Group ID"
+// @Success   200  {object}  utils.ApiResponse[any]
+// @Failure   500  {object}  utils.APIError[any]
+func (c *Controller) Delete(ctx *gin.Context) {
+	id := ctx.Param("id")
+	orgID := ctx.GetString("orgId")
+
+	if err := c.service.Delete(ctx, id, orgID); err != nil {
+		c.logger.Errorw("Failed to delete monitor group", "error", err, "id", id)
+		ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse("Internal server error"))
+		return
+	}
+	ctx.JSON(http.StatusOK, utils.NewSuccessResponse[any]("Monitor group deleted successfully", nil))
+}
+
+// @Router    /monitor-groups/{id}/status [get]
+// @Summary   Get the rolled-up status of a monitor group
+// @Tags      Monitor Groups
+// @Produce   json
+// @Security  JwtAuth
+// @Security  ApiKeyAuth
+// @Param     id   path      string  true  "Group ID"
+// @Success   200  {object}  utils.ApiResponse[StatusDto]
+// @Failure   404  {object}  utils.APIError[any]
+// @Failure   500  {object}  utils.APIError[any]
+func (c *Controller) GetStatus(ctx *gin.Context) {
+	id := ctx.Param("id")
+	orgID := ctx.GetString("orgId")
+
+	status, err := c.service.GetStatus(ctx, id, orgID)
+	if err != nil {
+		c.logger.Errorw("Failed to get monitor group status", "error", err, "id", id)
+		ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse("Internal server error"))
+		return
+	}
+	if status == nil {
+		ctx.JSON(http.StatusNotFound, utils.NewFailResponse("Monitor group not found"))
+		return
+	}
+	ctx.JSON(http.StatusOK, utils.NewSuccessResponse("success", status))
+}
+
+func (c *Controller) handleError(ctx *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, ErrParentNotFound),
+		errors.Is(err, ErrGroupCycle),
+		errors.Is(err, ErrMonitorNotFound),
+		errors.Is(err, ErrChannelNotFound),
+		errors.Is(err, ErrInvalidQuorum):
+		ctx.JSON(http.StatusBadRequest, utils.NewFailResponse(err.Error()))
+	default:
+		c.logger.Errorw(message, "error", err)
+		ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse("Internal server error"))
+	}
+}
+
+func bindDTO(ctx *gin.Context) (*CreateUpdateDto, bool) {
+	var dto CreateUpdateDto
+	if err := ctx.ShouldBindJSON(&dto); err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.NewFailResponse(err.Error()))
+		return nil, false
+	}
+
+	if err := utils.Validate.Struct(&dto); err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.NewFailResponse(err.Error()))
+		return nil, false
+	}
+	return &dto, true
+}
@@ -0,0 +1,26 @@
+package monitor_group
+
+import "vigi/internal/modules/shared"
+
+type CreateUpdateDto struct {
+	Name            string   `json:"name" validate:"required,min=1,max=255" example:"Payments"`
+	Description     string   `json:"description" validate:"max=500" example:"Everything the checkout depends on"`
+	ParentID        string   `json:"parent_id" example:""`
+	Policy          string   `json:"policy" validate:"required,oneof=all_up any_down quorum" example:"all_up"`
+	Quorum          int      `json:"quorum" validate:"min=0,max=100" example:"50"`
+	MonitorIDs      []string `json:"monitor_ids"`
+	NotificationIDs []string `json:"notification_ids"`
+}
+
+// StatusDto is the rolled-up status of a group with the member counts it was computed from
+type StatusDto struct {
+	GroupID     string               `json:"group_id"`
+	Status      shared.MonitorStatus `json:"status"`
+	Up          int                  `json:"up"`
+	Degraded    int                  `json:"degraded"`
+	Down        int                  `json:"down"`
+	Pending     int                  `json:"pending"`
+	Maintenance int                  `json:"maintenance"`
+	// UpPercentage is the share of up or degraded members among those that count
+	UpPercentage float64 `json:"up_percentage"`
+}
@@ -0,0 +1,61 @@
+package monitor_group
+
+import (
+	"context"
+	"vigi/internal/infra"
+	"vigi/internal/modules/events"
+	"vigi/internal/modules/heartbeat"
+
+	"go.uber.org/dig"
+	"go.uber.org/zap"
+)
+
+// StatusEventListener recomputes the groups of a monitor when its status changes
+type StatusEventListener struct {
+	service  Service
+	eventBus events.EventBus
+	logger   *zap.SugaredLogger
+}
+
+type StatusEventListenerParams struct {
+	dig.In
+	Service  Service
+	EventBus events.EventBus
+	Logger   *zap.SugaredLogger
+}
+
+func NewStatusEventListener(p StatusEventListenerParams) *StatusEventListener {
+	return &StatusEventListener{
+		service:  p.Service,
+		eventBus: p.EventBus,
+		logger:   p.Logger.Named("[monitor-group-listener]"),
+	}
+}
+
+// Subscribe subscribes to MonitorStatusChanged events
+func (l *StatusEventListener) Subscribe(eventBus events.EventBus) {
+	eventBus.Subscribe(events.MonitorStatusChanged, l.handleMonitorStatusChanged)
+}
+
+func (l *StatusEventListener) handleMonitorStatusChanged(event events.Event) {
+	ctx := context.Background()
+
+	hb, ok := infra.UnmarshalEventPayload[heartbeat.Model](event)
+	if !ok {
+		l.logger.Errorf("Failed to unmarshal heartbeat event payload")
+		return
+	}
+
+	changes, err := l.service.RefreshStatuses(ctx, hb.MonitorID, hb.Status)
+	if err != nil {
+		l.logger.Errorf("Failed to refresh groups of monitor %s: %v", hb.MonitorID, err)
+	}
+
+	for _, change := range changes {
+		l.logger.Infof("Group %s changed status from %d to %d", change.GroupID, change.PreviousStatus, change.Status)
+		l.eventBus.Publish(events.Event{
+			Type:    events.MonitorGroupStatusChanged,
+			Payload: change,
+		})
+	}
+}
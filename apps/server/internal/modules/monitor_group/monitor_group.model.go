@@ -0,0 +1,43 @@
+package monitor_group
+
+import (
+	"time"
+	"vigi/internal/modules/shared"
+)
+
+const (
+	// PolicyAllUp is up when every member is up and down when none is available
+	PolicyAllUp = "all_up"
+	// PolicyAnyDown is down as soon as a single member is down
+	PolicyAnyDown = "any_down"
+	// PolicyQuorum is up while at least Quorum percent of the members are available
+	PolicyQuorum = "quorum"
+)
+
+// Model is a set of monitors and subgroups rolled up into a single status
+type Model struct {
+	ID              string               `json:"id"`
+	OrgID           string               `json:"org_id"`
+	ParentID        string               `json:"parent_id,omitempty"`
+	Name            string               `json:"name"`
+	Description     string               `json:"description"`
+	Policy          string               `json:"policy"`
+	Quorum          int                  `json:"quorum"`
+	Status          shared.MonitorStatus `json:"status"`
+	MonitorIDs      []string             `json:"monitor_ids"`
+	NotificationIDs []string             `json:"notification_ids"`
+	CreatedAt       time.Time            `json:"created_at"`
+	UpdatedAt       time.Time            `json:"updated_at"`
+}
+
+// StatusChangedEvent is published when the rolled-up status of a group changes.
+// MonitorID is the member whose status change caused it.
+type StatusChangedEvent struct {
+	GroupID        string               `json:"group_id"`
+	GroupName      string               `json:"group_name"`
+	OrgID          string               `json:"org_id"`
+	MonitorID      string               `json:"monitor_id"`
+	Status         shared.MonitorStatus `json:"status"`
+	PreviousStatus shared.MonitorStatus `json:"previous_status"`
+	Message        string               `json:"message"`
+}
@@ -0,0 +1,217 @@
+package monitor_group
+
+import (
+	"context"
+	"errors"
+	"time"
+	"vigi/internal/config"
+	"vigi/internal/modules/shared"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoModel struct {
+	ID              primitive.ObjectID `bson:"_id,omitempty"`
+	OrgID           string             `bson:"org_id"`
+	ParentID        string             `bson:"parent_id,omitempty"`
+	Name            string             `bson:"name"`
+	Description     string             `bson:"description"`
+	Policy          string             `bson:"policy"`
+	Quorum          int                `bson:"quorum"`
+	Status          int                `bson:"status"`
+	MonitorIDs      []string           `bson:"monitor_ids"`
+	NotificationIDs []string           `bson:"notification_ids"`
+	CreatedAt       time.Time          `bson:"created_at"`
+	UpdatedAt       time.Time          `bson:"updated_at"`
+}
+
+func toDomainModel(mm *mongoModel) *Model {
+	monitorIDs := mm.MonitorIDs
+	if monitorIDs == nil {
+		monitorIDs = []string{}
+	}
+	notificationIDs := mm.NotificationIDs
+	if notificationIDs == nil {
+		notificationIDs = []string{}
+	}
+
+	return &Model{
+		ID:              mm.ID.Hex(),
+		OrgID:           mm.OrgID,
+		ParentID:        mm.ParentID,
+		Name:            mm.Name,
+		Description:     mm.Description,
+		Policy:          mm.Policy,
+		Quorum:          mm.Quorum,
+		Status:          shared.MonitorStatus(mm.Status),
+		MonitorIDs:      monitorIDs,
+		NotificationIDs: notificationIDs,
+		CreatedAt:       mm.CreatedAt,
+		UpdatedAt:       mm.UpdatedAt,
+	}
+}
+
+type MongoRepository struct {
+	client     *mongo.Client
+	db         *mongo.Database
+	collection *mongo.Collection
+}
+
+func NewMongoRepository(client *mongo.Client, cfg *config.Config) Repository {
+	db := client.Database(cfg.DBName)
+	collection := db.Collection("monitor_groups")
+
+	// Create indexes
+	go func() {
+		_, _ = collection.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+			{Keys: bson.D{{Key: "org_id", Value: 1}}},
+			{Keys: bson.D{{Key: "monitor_ids", Value: 1}}},
+			{Keys: bson.D{{Key: "parent_id", Value: 1}}},
+		})
+	}()
+
+	return &MongoRepository{
+		client:     client,
+		db:         db,
+		collection: collection,
+	}
+}
+
+func (r *MongoRepository) Create(ctx context.Context, group *Model) (*Model, error) {
+	now := time.Now().UTC()
+	mm := &mongoModel{
+		ID:              primitive.NewObjectID(),
+		OrgID:           group.OrgID,
+		ParentID:        group.ParentID,
+		Name:            group.Name,
+		Description:     group.Description,
+		Policy:          group.Policy,
+		Quorum:          group.Quorum,
+		Status:          int(group.Status),
+		MonitorIDs:      uniqueIDs(group.MonitorIDs),
+		NotificationIDs: uniqueIDs(group.NotificationIDs),
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+
+	if _, err := r.collection.InsertOne(ctx, mm); err != nil {
+		return nil, err
+	}
+	return toDomainModel(mm), nil
+}
+
+func (r *MongoRepository) FindByID(ctx context.Context, id string, orgID string) (*Model, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := bson.M{"_id": objectID}
+	if orgID != "" {
+		filter["org_id"] = orgID
+	}
+
+	var mm mongoModel
+	if err := r.collection.FindOne(ctx, filter).Decode(&mm); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toDomainModel(&mm), nil
+}
+
+func (r *MongoRepository) FindAll(ctx context.Context, page int, limit int, q string, orgID string) ([]*Model, error) {
+	skip := int64(page * limit)
+	limit64 := int64(limit)
+
+	opts := &options.FindOptions{
+		Skip:  &skip,
+		Limit: &limit64,
+		Sort:  bson.D{{Key: "name", Value: 1}},
+	}
+
+	filter := bson.M{"org_id": orgID}
+	if q != "" {
+		filter["name"] = bson.M{"$regex": q, "$options": "i"}
+	}
+	return r.find(ctx, filter, opts)
+}
+
+func (r *MongoRepository) FindByOrgID(ctx context.Context, orgID string) ([]*Model, error) {
+	return r.find(ctx, bson.M{"org_id": orgID}, options.Find())
+}
+
+func (r *MongoRepository) FindByMonitorID(ctx context.Context, monitorID string) ([]*Model, error) {
+	return r.find(ctx, bson.M{"monitor_ids": monitorID}, options.Find())
+}
+
+func (r *MongoRepository) Update(ctx context.Context, group *Model) error {
+	objectID, err := primitive.ObjectIDFromHex(group.ID)
+	if err != nil {
+		return err
+	}
+
+	update := bson.M{"$set": bson.M{
+		"parent_id":        group.ParentID,
+		"name":             group.Name,
+		"description":      group.Description,
+		"policy":           group.Policy,
+		"quorum":           group.Quorum,
+		"monitor_ids":      uniqueIDs(group.MonitorIDs),
+		"notification_ids": uniqueIDs(group.NotificationIDs),
+		"updated_at":       time.Now().UTC(),
+	}}
+	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": objectID}, update)
+	return err
+}
+
+func (r *MongoRepository) UpdateStatus(ctx context.Context, id string, status shared.MonitorStatus) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": objectID}, bson.M{"$set": bson.M{"status": int(status)}})
+	return err
+}
+
+func (r *MongoRepository) Delete(ctx context.Context, id string, orgID string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	res, err := r.collection.DeleteOne(ctx, bson.M{"_id": objectID, "org_id": orgID})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return nil
+	}
+
+	_, err = r.collection.UpdateMany(ctx, bson.M{"parent_id": id}, bson.M{"$set": bson.M{"parent_id": ""}})
+	return err
+}
+
+func (r *MongoRepository) find(ctx context.Context, filter bson.M, opts *options.FindOptions) ([]*Model, error) {
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var mms []*mongoModel
+	if err := cursor.All(ctx, &mms); err != nil {
+		return nil, err
+	}
+
+	groups := make([]*Model, 0, len(mms))
+	for _, mm := range mms {
+		groups = append(groups, toDomainModel(mm))
+	}
+	return groups, nil
+}
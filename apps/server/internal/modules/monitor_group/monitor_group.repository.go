@@ -0,0 +1,21 @@
+package monitor_group
+
+import (
+	"context"
+	"vigi/internal/modules/shared"
+)
+
+type Repository interface {
+	Create(ctx context.Context, group *Model) (*Model, error)
+	FindByID(ctx context.Context, id string, orgID string) (*Model, error)
+	FindAll(ctx context.Context, page int, limit int, q string, orgID string) ([]*Model, error)
+	// FindByOrgID returns every group of the organization
+	FindByOrgID(ctx context.Context, orgID string) ([]*Model, error)
+	// FindByMonitorID returns the groups the monitor is a direct member of
+	FindByMonitorID(ctx context.Context, monitorID string) ([]*Model, error)
+	// Update replaces the fields, monitors and notifications of the group
+	Update(ctx context.Context, group *Model) error
+	UpdateStatus(ctx context.Context, id string, status shared.MonitorStatus) error
+	// Delete deletes the group, its subgroups move up to the top level
+	Delete(ctx context.Context, id string, orgID string) error
+}
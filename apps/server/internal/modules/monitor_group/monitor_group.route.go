@@ -0,0 +1,36 @@
+package monitor_group
+
+import (
+	"vigi/internal/modules/middleware"
+	"vigi/internal/modules/organization"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Route struct {
+	controller    *Controller
+	middleware    *middleware.AuthChain
+	orgMiddleware *organization.Middleware
+}
+
+func NewRoute(controller *Controller, middleware *middleware.AuthChain, orgMiddleware *organization.Middleware) *Route {
+	return &Route{
+		controller:    controller,
+		middleware:    middleware,
+		orgMiddleware: orgMiddleware,
+	}
+}
+
+func (r *Route) ConnectRoute(rg *gin.RouterGroup, controller *Controller) {
+	groups := rg.Group("monitor-groups")
+	groups.Use(r.middleware.AllAuth())
+	groups.Use(r.orgMiddleware.RequireOrganization())
+	{
+		groups.GET("", r.controller.FindAll)
+		groups.POST("", r.controller.Create)
+		groups.GET("/:id", r.controller.FindByID)
+		groups.PUT("/:id", r.controller.Update)
+		groups.DELETE("/:id", r.controller.Delete)
+		groups.GET("/:id/status", r.controller.GetStatus)
+	}
+}
@@ -0,0 +1,477 @@
+package monitor_group
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"vigi/internal/modules/monitor"
+	"vigi/internal/modules/shared"
+
+	"go.uber.org/zap"
+)
+
+var (
+	ErrParentNotFound  = errors.New("parent group not found")
+	ErrGroupCycle      = errors.New("a group cannot be nested inside itself or one of its subgroups")
+	ErrMonitorNotFound = errors.New("one or more monitors were not found")
+	ErrChannelNotFound = errors.New("one or more notification channels were not found")
+	ErrInvalidQuorum   = errors.New("the quorum policy needs a percentage between 1 and 100")
+)
+
+// NotificationChannelService is the part of notification_channel.Service the
+// group service needs
+type NotificationChannelService interface {
+	AllExist(ctx context.Context, ids []string, orgID string) (bool, error)
+}
+
+type Service interface {
+	Create(ctx context.Context, dto *CreateUpdateDto, orgID string) (*Model, error)
+	FindByID(ctx context.Context, id string, orgID string) (*Model, error)
+	FindAll(ctx context.Context, page int, limit int, q string, orgID string) ([]*Model, error)
+	Update(ctx context.Context, id string, dto *CreateUpdateDto, orgID string) (*Model, error)
+	Delete(ctx context.Context, id string, orgID string) error
+
+	// GetStatus computes the rolled-up status of the group from the current status of its members
+	GetStatus(ctx context.Context, id string, orgID string) (*StatusDto, error)
+	// FindMonitorIDs returns the monitors of the group and of all of its subgroups
+	FindMonitorIDs(ctx context.Context, id string, orgID string) ([]string, error)
+	// FindGroupIDsByMonitorID returns the groups containing the monitor, directly or through a subgroup
+	FindGroupIDsByMonitorID(ctx context.Context, monitorID string) ([]string, error)
+	// RefreshStatuses recomputes the groups of the monitor's organization after its status changed
+	// and stores the statuses that moved. The new monitor status is passed in because the stored
+	// one is updated concurrently by another listener.
+	RefreshStatuses(ctx context.Context, monitorID string, status shared.MonitorStatus) ([]*StatusChangedEvent, error)
+}
+
+type ServiceImpl struct {
+	repository     Repository
+	monitorService monitor.Service
+	channelService NotificationChannelService
+	logger         *zap.SugaredLogger
+}
+
+func NewService(
+	repository Repository,
+	monitorService monitor.Service,
+	channelService NotificationChannelService,
+	logger *zap.SugaredLogger,
+) Service {
+	return &ServiceImpl{
+		repository:     repository,
+		monitorService: monitorService,
+		channelService: channelService,
+		logger:         logger.Named("[monitor-group-service]"),
+	}
+}
+
+func (s *ServiceImpl) Create(ctx context.Context, dto *CreateUpdateDto, orgID string) (*Model, error) {
+	group := &Model{OrgID: orgID, Status: shared.MonitorStatusPending}
+	if err := s.applyDTO(ctx, group, dto); err != nil {
+		return nil, err
+	}
+
+	created, err := s.repository.Create(ctx, group)
+	if err != nil {
+		return nil, err
+	}
+	return created, s.storeStatus(ctx, created)
+}
+
+func (s *ServiceImpl) FindByID(ctx context.Context, id string, orgID string) (*Model, error) {
+	return s.repository.FindByID(ctx, id, orgID)
+}
+
+func (s *ServiceImpl) FindAll(ctx context.Context, page int, limit int, q string, orgID string) ([]*Model, error) {
+	return s.repository.FindAll(ctx, page, limit, q, orgID)
+}
+
+func (s *ServiceImpl) Update(ctx context.Context, id string, dto *CreateUpdateDto, orgID string) (*Model, error) {
+	group, err := s.repository.FindByID(ctx, id, orgID)
+	if err != nil || group == nil {
+		return nil, err
+	}
+
+	if err := s.applyDTO(ctx, group, dto); err != nil {
+		return nil, err
+	}
+	if err := s.repository.Update(ctx, group); err != nil {
+		return nil, err
+	}
+	if err := s.storeStatus(ctx, group); err != nil {
+		return nil, err
+	}
+	return s.repository.FindByID(ctx, id, orgID)
+}
+
+func (s *ServiceImpl) Delete(ctx context.Context, id string, orgID string) error {
+	return s.repository.Delete(ctx, id, orgID)
+}
+
+func (s *ServiceImpl) GetStatus(ctx context.Context, id string, orgID string) (*StatusDto, error) {
+	group, err := s.repository.FindByID(ctx, id, orgID)
+	if err != nil || group == nil {
+		return nil, err
+	}
+
+	r, err := s.loadRollup(ctx, group.OrgID, "", 0)
+	if err != nil {
+		return nil, err
+	}
+	return r.compute(group.ID), nil
+}
+
+func (s *ServiceImpl) FindMonitorIDs(ctx context.Context, id string, orgID string) ([]string, error) {
+	group, err := s.repository.FindByID(ctx, id, orgID)
+	if err != nil || group == nil {
+		return nil, err
+	}
+
+	groups, err := s.repository.FindByOrgID(ctx, group.OrgID)
+	if err != nil {
+		return nil, err
+	}
+	r := newRollup(groups, nil)
+
+	var monitorIDs []string
+	r.walk(group.ID, map[string]bool{}, func(g *Model) {
+		monitorIDs = append(monitorIDs, g.MonitorIDs...)
+	})
+	return uniqueIDs(monitorIDs), nil
+}
+
+func (s *ServiceImpl) FindGroupIDsByMonitorID(ctx context.Context, monitorID string) ([]string, error) {
+	direct, err := s.repository.FindByMonitorID(ctx, monitorID)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[string]bool{}
+	var groupIDs []string
+	for _, group := range direct {
+		// Walk up the parents, a monitor in a subgroup is part of every ancestor
+		for group != nil && !seen[group.ID] {
+			seen[group.ID] = true
+			groupIDs = append(groupIDs, group.ID)
+			if group.ParentID == "" {
+				break
+			}
+			group, err = s.repository.FindByID(ctx, group.ParentID, group.OrgID)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return groupIDs, nil
+}
+
+func (s *ServiceImpl) RefreshStatuses(ctx context.Context, monitorID string, status shared.MonitorStatus) ([]*StatusChangedEvent, error) {
+	direct, err := s.repository.FindByMonitorID(ctx, monitorID)
+	if err != nil || len(direct) == 0 {
+		return nil, err
+	}
+
+	r, err := s.loadRollup(ctx, direct[0].OrgID, monitorID, status)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []*StatusChangedEvent
+	for _, group := range r.groups {
+		result := r.compute(group.ID)
+		if result.Status == group.Status {
+			continue
+		}
+		if err := s.repository.UpdateStatus(ctx, group.ID, result.Status); err != nil {
+			return changes, err
+		}
+		changes = append(changes, &StatusChangedEvent{
+			GroupID:        group.ID,
+			GroupName:      group.Name,
+			OrgID:          group.OrgID,
+			MonitorID:      monitorID,
+			Status:         result.Status,
+			PreviousStatus: group.Status,
+			Message:        statusMessage(group, result),
+		})
+	}
+	return changes, nil
+}
+
+// storeStatus recomputes the status of a group whose members changed
+func (s *ServiceImpl) storeStatus(ctx context.Context, group *Model) error {
+	r, err := s.loadRollup(ctx, group.OrgID, "", 0)
+	if err != nil {
+		return err
+	}
+
+	result := r.compute(group.ID)
+	if result.Status == group.Status {
+		return nil
+	}
+	group.Status = result.Status
+	return s.repository.UpdateStatus(ctx, group.ID, result.Status)
+}
+
+// loadRollup loads every group of the organization with the status of their monitors.
+// When overrideID is set, that monitor's status is replaced by overrideStatus.
+func (s *ServiceImpl) loadRollup(ctx context.Context, orgID string, overrideID string, overrideStatus shared.MonitorStatus) (*rollup, error) {
+	groups, err := s.repository.FindByOrgID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	var monitorIDs []string
+	for _, group := range groups {
+		monitorIDs = append(monitorIDs, group.MonitorIDs...)
+	}
+	monitorIDs = uniqueIDs(monitorIDs)
+
+	statuses := make(map[string]shared.MonitorStatus, len(monitorIDs))
+	if len(monitorIDs) > 0 {
+		monitors, err := s.monitorService.FindByIDs(ctx, monitorIDs, orgID)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range monitors {
+			// Paused monitors don't take part in the rollup
+			if !m.Active {
+				continue
+			}
+			statuses[m.ID] = m.Status
+			if m.ID == overrideID {
+				statuses[m.ID] = overrideStatus
+			}
+		}
+	}
+	return newRollup(groups, statuses), nil
+}
+
+func (s *ServiceImpl) applyDTO(ctx context.Context, group *Model, dto *CreateUpdateDto) error {
+	if dto.Policy == PolicyQuorum && (dto.Quorum < 1 || dto.Quorum > 100) {
+		return ErrInvalidQuorum
+	}
+
+	if dto.ParentID != "" {
+		if dto.ParentID == group.ID {
+			return ErrGroupCycle
+		}
+		parent, err := s.repository.FindByID(ctx, dto.ParentID, group.OrgID)
+		if err != nil {
+			return err
+		}
+		if parent == nil {
+			return ErrParentNotFound
+		}
+
+		// Only an existing group can be an ancestor of its new parent
+		if group.ID != "" {
+			groups, err := s.repository.FindByOrgID(ctx, group.OrgID)
+			if err != nil {
+				return err
+			}
+			if newRollup(groups, nil).isAncestor(group.ID, dto.ParentID) {
+				return ErrGroupCycle
+			}
+		}
+	}
+
+	monitorIDs := uniqueIDs(dto.MonitorIDs)
+	if len(monitorIDs) > 0 {
+		monitors, err := s.monitorService.FindByIDs(ctx, monitorIDs, group.OrgID)
+		if err != nil {
+			return err
+		}
+		if len(monitors) != len(monitorIDs) {
+			return ErrMonitorNotFound
+		}
+	}
+
+	notificationIDs := uniqueIDs(dto.NotificationIDs)
+	exist, err := s.channelService.AllExist(ctx, notificationIDs, group.OrgID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return ErrChannelNotFound
+	}
+
+	group.Name = dto.Name
+	group.Description = dto.Description
+	group.ParentID = dto.ParentID
+	group.Policy = dto.Policy
+	group.Quorum = dto.Quorum
+	group.MonitorIDs = monitorIDs
+	group.NotificationIDs = notificationIDs
+	return nil
+}
+
+// rollup computes group statuses over the group tree of an organization
+type rollup struct {
+	groups   map[string]*Model
+	children map[string][]string
+	statuses map[string]shared.MonitorStatus
+	results  map[string]*StatusDto
+}
+
+func newRollup(groups []*Model, statuses map[string]shared.MonitorStatus) *rollup {
+	r := &rollup{
+		groups:   make(map[string]*Model, len(groups)),
+		children: map[string][]string{},
+		statuses: statuses,
+		results:  map[string]*StatusDto{},
+	}
+	for _, group := range groups {
+		r.groups[group.ID] = group
+	}
+	for _, group := range groups {
+		if _, ok := r.groups[group.ParentID]; ok {
+			r.children[group.ParentID] = append(r.children[group.ParentID], group.ID)
+		}
+	}
+	return r
+}
+
+// compute returns the status of a group, subgroups count as a single member each
+func (r *rollup) compute(id string) *StatusDto {
+	return r.computeGuarded(id, map[string]bool{})
+}
+
+func (r *rollup) computeGuarded(id string, visiting map[string]bool) *StatusDto {
+	if result, ok := r.results[id]; ok {
+		return result
+	}
+	group, ok := r.groups[id]
+	if !ok || visiting[id] {
+		return &StatusDto{GroupID: id, Status: shared.MonitorStatusPending}
+	}
+	visiting[id] = true
+	defer delete(visiting, id)
+
+	var members []shared.MonitorStatus
+	for _, monitorID := range group.MonitorIDs {
+		if status, ok := r.statuses[monitorID]; ok {
+			members = append(members, status)
+		}
+	}
+	for _, childID := range r.children[id] {
+		members = append(members, r.computeGuarded(childID, visiting).Status)
+	}
+
+	result := computeStatus(group, members)
+	r.results[id] = result
+	return result
+}
+
+// walk calls fn for the group and each of its subgroups
+func (r *rollup) walk(id string, seen map[string]bool, fn func(group *Model)) {
+	group, ok := r.groups[id]
+	if !ok || seen[id] {
+		return
+	}
+	seen[id] = true
+	fn(group)
+	for _, childID := range r.children[id] {
+		r.walk(childID, seen, fn)
+	}
+}
+
+// isAncestor reports whether ancestorID is groupID itself or one of its parents
+func (r *rollup) isAncestor(ancestorID string, groupID string) bool {
+	seen := map[string]bool{}
+	for id := groupID; id != "" && !seen[id]; {
+		if id == ancestorID {
+			return true
+		}
+		seen[id] = true
+		group, ok := r.groups[id]
+		if !ok {
+			return false
+		}
+		id = group.ParentID
+	}
+	return false
+}
+
+// computeStatus applies the group policy to the statuses of its members. Pending members and
+// members in maintenance are left out, a group without any other member is pending, or in
+// maintenance when every member is.
+func computeStatus(group *Model, members []shared.MonitorStatus) *StatusDto {
+	result := &StatusDto{GroupID: group.ID}
+	for _, status := range members {
+		switch status {
+		case shared.MonitorStatusUp:
+			result.Up++
+		case shared.MonitorStatusDegraded:
+			result.Degraded++
+		case shared.MonitorStatusDown:
+			result.Down++
+		case shared.MonitorStatusMaintenance:
+			result.Maintenance++
+		default:
+			result.Pending++
+		}
+	}
+
+	counted := result.Up + result.Degraded + result.Down
+	if counted == 0 {
+		result.Status = shared.MonitorStatusPending
+		if result.Maintenance > 0 && result.Pending == 0 {
+			result.Status = shared.MonitorStatusMaintenance
+		}
+		return result
+	}
+
+	available := result.Up + result.Degraded
+	result.UpPercentage = float64(available) * 100 / float64(counted)
+
+	switch group.Policy {
+	case PolicyAnyDown:
+		switch {
+		case result.Down > 0:
+			result.Status = shared.MonitorStatusDown
+		case result.Degraded > 0:
+			result.Status = shared.MonitorStatusDegraded
+		default:
+			result.Status = shared.MonitorStatusUp
+		}
+	case PolicyQuorum:
+		switch {
+		case result.UpPercentage < float64(group.Quorum):
+			result.Status = shared.MonitorStatusDown
+		case result.Up < counted:
+			result.Status = shared.MonitorStatusDegraded
+		default:
+			result.Status = shared.MonitorStatusUp
+		}
+	default:
+		switch {
+		case result.Up == counted:
+			result.Status = shared.MonitorStatusUp
+		case available == 0:
+			result.Status = shared.MonitorStatusDown
+		default:
+			result.Status = shared.MonitorStatusDegraded
+		}
+	}
+	return result
+}
+
+func statusMessage(group *Model, result *StatusDto) string {
+	counted := result.Up + result.Degraded + result.Down
+	return fmt.Sprintf("Group %s is %s, %d of %d members available", group.Name, statusText(result.Status), result.Up+result.Degraded, counted)
+}
+
+func statusText(status shared.MonitorStatus) string {
+	switch status {
+	case shared.MonitorStatusUp:
+		return "up"
+	case shared.MonitorStatusDown:
+		return "down"
+	case shared.MonitorStatusDegraded:
+		return "degraded"
+	case shared.MonitorStatusMaintenance:
+		return "under maintenance"
+	default:
+		return "pending"
+	}
+}
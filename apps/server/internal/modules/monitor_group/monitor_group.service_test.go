@@ -0,0 +1,278 @@
+package monitor_group
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"vigi/internal/modules/monitor"
+	"vigi/internal/modules/shared"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type fakeRepository struct {
+	groups []*Model
+	nextID int
+}
+
+func (r *fakeRepository) Create(ctx context.Context, group *Model) (*Model, error) {
+	r.nextID++
+	created := *group
+	created.ID = fmt.Sprintf("group-%d", r.nextID)
+	r.groups = append(r.groups, &created)
+	return &created, nil
+}
+
+func (r *fakeRepository) FindByID(ctx context.Context, id string, orgID string) (*Model, error) {
+	for _, g := range r.groups {
+		if g.ID == id && (orgID == "" || g.OrgID == orgID) {
+			copied := *g
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeRepository) FindAll(ctx context.Context, page int, limit int, q string, orgID string) ([]*Model, error) {
+	return r.FindByOrgID(ctx, orgID)
+}
+
+func (r *fakeRepository) FindByOrgID(ctx context.Context, orgID string) ([]*Model, error) {
+	var result []*Model
+	for _, g := range r.groups {
+		if g.OrgID == orgID {
+			copied := *g
+			result = append(result, &copied)
+		}
+	}
+	return result, nil
+}
+
+func (r *fakeRepository) FindByMonitorID(ctx context.Context, monitorID string) ([]*Model, error) {
+	var result []*Model
+	for _, g := range r.groups {
+		for _, id := range g.MonitorIDs {
+			if id == monitorID {
+				copied := *g
+				result = append(result, &copied)
+			}
+		}
+	}
+	return result, nil
+}
+
+func (r *fakeRepository) Update(ctx context.Context, group *Model) error {
+	for i, g := range r.groups {
+		if g.ID == group.ID {
+			copied := *group
+			r.groups[i] = &copied
+		}
+	}
+	return nil
+}
+
+func (r *fakeRepository) UpdateStatus(ctx context.Context, id string, status shared.MonitorStatus) error {
+	for _, g := range r.groups {
+		if g.ID == id {
+			g.Status = status
+		}
+	}
+	return nil
+}
+
+func (r *fakeRepository) Delete(ctx context.Context, id string, orgID string) error {
+	return nil
+}
+
+// fakeMonitorService only implements the lookups the group service needs
+type fakeMonitorService struct {
+	monitor.Service
+	monitors map[string]*monitor.Model
+}
+
+func (s *fakeMonitorService) FindByIDs(ctx context.Context, ids []string, orgID string) ([]*monitor.Model, error) {
+	var result []*monitor.Model
+	for _, id := range ids {
+		if m, ok := s.monitors[id]; ok && m.OrgID == orgID {
+			result = append(result, m)
+		}
+	}
+	return result, nil
+}
+
+// fakeChannelService knows a single channel of org-1
+type fakeChannelService struct{}
+
+func (s *fakeChannelService) AllExist(ctx context.Context, ids []string, orgID string) (bool, error) {
+	for _, id := range ids {
+		if id != "slack" || orgID != "org-1" {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func newTestService(monitors ...*monitor.Model) (*ServiceImpl, *fakeRepository) {
+	repo := &fakeRepository{}
+	monitorService := &fakeMonitorService{monitors: map[string]*monitor.Model{}}
+	for _, m := range monitors {
+		monitorService.monitors[m.ID] = m
+	}
+	return NewService(repo, monitorService, &fakeChannelService{}, zap.NewNop().Sugar()).(*ServiceImpl), repo
+}
+
+func testMonitor(id string, status shared.MonitorStatus) *monitor.Model {
+	return &monitor.Model{ID: id, OrgID: "org-1", Active: true, Status: status}
+}
+
+func TestComputeStatus(t *testing.T) {
+	up, down, degraded := shared.MonitorStatusUp, shared.MonitorStatusDown, shared.MonitorStatusDegraded
+	pending, maintenance := shared.MonitorStatusPending, shared.MonitorStatusMaintenance
+
+	tests := []struct {
+		name    string
+		policy  string
+		quorum  int
+		members []shared.MonitorStatus
+		want    shared.MonitorStatus
+	}{
+		{"all up with every member up", PolicyAllUp, 0, []shared.MonitorStatus{up, up}, up},
+		{"all up with one member down", PolicyAllUp, 0, []shared.MonitorStatus{up, down}, degraded},
+		{"all up with every member down", PolicyAllUp, 0, []shared.MonitorStatus{down, down}, down},
+		{"any down with one member down", PolicyAnyDown, 0, []shared.MonitorStatus{up, up, down}, down},
+		{"any down with one member degraded", PolicyAnyDown, 0, []shared.MonitorStatus{up, degraded}, degraded},
+		{"quorum met by every member", PolicyQuorum, 50, []shared.MonitorStatus{up, up}, up},
+		{"quorum met with a member down", PolicyQuorum, 50, []shared.MonitorStatus{up, up, down}, degraded},
+		{"quorum missed", PolicyQuorum, 75, []shared.MonitorStatus{up, down, down}, down},
+		{"pending members are left out", PolicyAnyDown, 0, []shared.MonitorStatus{up, pending}, up},
+		{"no members", PolicyAllUp, 0, nil, pending},
+		{"every member in maintenance", PolicyAllUp, 0, []shared.MonitorStatus{maintenance, maintenance}, maintenance},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := computeStatus(&Model{Policy: tt.policy, Quorum: tt.quorum}, tt.members)
+			assert.Equal(t, tt.want, result.Status)
+		})
+	}
+}
+
+func TestServiceImpl_Create_RejectsUnknownMonitor(t *testing.T) {
+	service, _ := newTestService(testMonitor("m1", shared.MonitorStatusUp))
+
+	_, err := service.Create(context.Background(), &CreateUpdateDto{
+		Name:       "API",
+		Policy:     PolicyAllUp,
+		MonitorIDs: []string{"m1", "missing"},
+	}, "org-1")
+	assert.ErrorIs(t, err, ErrMonitorNotFound)
+}
+
+func TestServiceImpl_Create_RejectsUnknownChannel(t *testing.T) {
+	service, _ := newTestService()
+
+	_, err := service.Create(context.Background(), &CreateUpdateDto{
+		Name:            "API",
+		Policy:          PolicyAllUp,
+		NotificationIDs: []string{"slack", "other-org-webhook"},
+	}, "org-1")
+	assert.ErrorIs(t, err, ErrChannelNotFound)
+}
+
+func TestServiceImpl_Create_RequiresQuorumPercentage(t *testing.T) {
+	service, _ := newTestService()
+
+	_, err := service.Create(context.Background(), &CreateUpdateDto{Name: "API", Policy: PolicyQuorum}, "org-1")
+	assert.ErrorIs(t, err, ErrInvalidQuorum)
+}
+
+func TestServiceImpl_Update_RejectsCycle(t *testing.T) {
+	ctx := context.Background()
+	service, _ := newTestService()
+
+	parent, err := service.Create(ctx, &CreateUpdateDto{Name: "Parent", Policy: PolicyAllUp}, "org-1")
+	require.NoError(t, err)
+	child, err := service.Create(ctx, &CreateUpdateDto{Name: "Child", Policy: PolicyAllUp, ParentID: parent.ID}, "org-1")
+	require.NoError(t, err)
+
+	_, err = service.Update(ctx, parent.ID, &CreateUpdateDto{Name: "Parent", Policy: PolicyAllUp, ParentID: child.ID}, "org-1")
+	assert.ErrorIs(t, err, ErrGroupCycle)
+
+	_, err = service.Update(ctx, parent.ID, &CreateUpdateDto{Name: "Parent", Policy: PolicyAllUp, ParentID: parent.ID}, "org-1")
+	assert.ErrorIs(t, err, ErrGroupCycle)
+}
+
+func TestServiceImpl_Create_StoresComputedStatus(t *testing.T) {
+	service, repo := newTestService(testMonitor("m1", shared.MonitorStatusUp), testMonitor("m2", shared.MonitorStatusDown))
+
+	created, err := service.Create(context.Background(), &CreateUpdateDto{
+		Name:       "API",
+		Policy:     PolicyAnyDown,
+		MonitorIDs: []string{"m1", "m2"},
+	}, "org-1")
+	require.NoError(t, err)
+
+	assert.Equal(t, shared.MonitorStatusDown, created.Status)
+	assert.Equal(t, shared.MonitorStatusDown, repo.groups[0].Status)
+}
+
+func TestServiceImpl_RefreshStatuses_RollsUpThroughSubgroups(t *testing.T) {
+	ctx := context.Background()
+	service, repo := newTestService(
+		testMonitor("db", shared.MonitorStatusUp),
+		testMonitor("web", shared.MonitorStatusUp),
+	)
+
+	parent, err := service.Create(ctx, &CreateUpdateDto{Name: "Platform", Policy: PolicyAnyDown, MonitorIDs: []string{"web"}}, "org-1")
+	require.NoError(t, err)
+	child, err := service.Create(ctx, &CreateUpdateDto{Name: "Storage", Policy: PolicyAllUp, ParentID: parent.ID, MonitorIDs: []string{"db"}}, "org-1")
+	require.NoError(t, err)
+	require.Equal(t, shared.MonitorStatusUp, repo.groups[0].Status)
+
+	// The stored monitor status is still up, the heartbeat status wins
+	changes, err := service.RefreshStatuses(ctx, "db", shared.MonitorStatusDown)
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+
+	byGroup := map[string]*StatusChangedEvent{}
+	for _, change := range changes {
+		byGroup[change.GroupID] = change
+	}
+	assert.Equal(t, shared.MonitorStatusDown, byGroup[child.ID].Status)
+	assert.Equal(t, shared.MonitorStatusDown, byGroup[parent.ID].Status)
+	assert.Equal(t, shared.MonitorStatusUp, byGroup[parent.ID].PreviousStatus)
+	assert.Equal(t, "db", byGroup[parent.ID].MonitorID)
+
+	// Nothing moves when the status is reported again
+	changes, err = service.RefreshStatuses(ctx, "db", shared.MonitorStatusDown)
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+}
+
+func TestServiceImpl_RefreshStatuses_IgnoresUngroupedMonitor(t *testing.T) {
+	service, _ := newTestService(testMonitor("m1", shared.MonitorStatusUp))
+
+	changes, err := service.RefreshStatuses(context.Background(), "m1", shared.MonitorStatusDown)
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+}
+
+func TestServiceImpl_FindGroupIDsByMonitorID_IncludesAncestors(t *testing.T) {
+	ctx := context.Background()
+	service, _ := newTestService(testMonitor("db", shared.MonitorStatusUp))
+
+	parent, err := service.Create(ctx, &CreateUpdateDto{Name: "Platform", Policy: PolicyAllUp}, "org-1")
+	require.NoError(t, err)
+	child, err := service.Create(ctx, &CreateUpdateDto{Name: "Storage", Policy: PolicyAllUp, ParentID: parent.ID, MonitorIDs: []string{"db"}}, "org-1")
+	require.NoError(t, err)
+
+	groupIDs, err := service.FindGroupIDsByMonitorID(ctx, "db")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{parent.ID, child.ID}, groupIDs)
+
+	monitorIDs, err := service.FindMonitorIDs(ctx, parent.ID, "org-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"db"}, monitorIDs)
+}
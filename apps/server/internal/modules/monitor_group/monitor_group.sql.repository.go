@@ -0,0 +1,296 @@
+package monitor_group
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+	"vigi/internal/modules/shared"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type sqlModel struct {
+	bun.BaseModel `bun:"table:monitor_groups,alias:mg"`
+
+	ID              string    `bun:"id,pk"`
+	OrgID           string    `bun:"org_id"`
+	ParentID        *string   `bun:"parent_id"`
+	Name            string    `bun:"name,notnull"`
+	Description     string    `bun:"description"`
+	Policy          string    `bun:"policy,notnull"`
+	Quorum          int       `bun:"quorum,notnull,default:0"`
+	Status          int       `bun:"status,notnull,default:2"`
+	NotificationIDs string    `bun:"notification_ids,notnull"`
+	CreatedAt       time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp"`
+	UpdatedAt       time.Time `bun:"updated_at,nullzero,notnull,default:current_timestamp"`
+}
+
+type sqlGroupMonitor struct {
+	bun.BaseModel `bun:"table:monitor_group_monitors,alias:mgm"`
+
+	GroupID   string `bun:"group_id,pk"`
+	MonitorID string `bun:"monitor_id,pk"`
+}
+
+func toDomainModelFromSQL(sm *sqlModel) (*Model, error) {
+	notificationIDs := []string{}
+	if sm.NotificationIDs != "" {
+		if err := json.Unmarshal([]byte(sm.NotificationIDs), &notificationIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	m := &Model{
+		ID:              sm.ID,
+		OrgID:           sm.OrgID,
+		Name:            sm.Name,
+		Description:     sm.Description,
+		Policy:          sm.Policy,
+		Quorum:          sm.Quorum,
+		Status:          shared.MonitorStatus(sm.Status),
+		MonitorIDs:      []string{},
+		NotificationIDs: notificationIDs,
+		CreatedAt:       sm.CreatedAt,
+		UpdatedAt:       sm.UpdatedAt,
+	}
+	if sm.ParentID != nil {
+		m.ParentID = *sm.ParentID
+	}
+	return m, nil
+}
+
+func toSQLModel(m *Model) (*sqlModel, error) {
+	notificationIDs, err := json.Marshal(uniqueIDs(m.NotificationIDs))
+	if err != nil {
+		return nil, err
+	}
+
+	sm := &sqlModel{
+		ID:              m.ID,
+		OrgID:           m.OrgID,
+		Name:            m.Name,
+		Description:     m.Description,
+		Policy:          m.Policy,
+		Quorum:          m.Quorum,
+		Status:          int(m.Status),
+		NotificationIDs: string(notificationIDs),
+		CreatedAt:       m.CreatedAt,
+		UpdatedAt:       m.UpdatedAt,
+	}
+	if m.ParentID != "" {
+		sm.ParentID = &m.ParentID
+	}
+	return sm, nil
+}
+
+type SQLRepositoryImpl struct {
+	db *bun.DB
+}
+
+func NewSQLRepository(db *bun.DB) Repository {
+	return &SQLRepositoryImpl{db: db}
+}
+
+func (r *SQLRepositoryImpl) Create(ctx context.Context, group *Model) (*Model, error) {
+	sm, err := toSQLModel(group)
+	if err != nil {
+		return nil, err
+	}
+	sm.ID = uuid.New().String()
+	sm.CreatedAt = time.Now()
+	sm.UpdatedAt = time.Now()
+
+	err = r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.NewInsert().Model(sm).Exec(ctx); err != nil {
+			return err
+		}
+		return insertGroupMonitors(ctx, tx, sm.ID, group.MonitorIDs)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := toDomainModelFromSQL(sm)
+	if err != nil {
+		return nil, err
+	}
+	created.MonitorIDs = uniqueIDs(group.MonitorIDs)
+	return created, nil
+}
+
+func (r *SQLRepositoryImpl) FindByID(ctx context.Context, id string, orgID string) (*Model, error) {
+	sm := new(sqlModel)
+	query := r.db.NewSelect().Model(sm).Where("id = ?", id)
+	if orgID != "" {
+		query = query.Where("org_id = ?", orgID)
+	}
+	if err := query.Limit(1).Scan(ctx); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	groups, err := r.withMonitors(ctx, []*sqlModel{sm})
+	if err != nil {
+		return nil, err
+	}
+	return groups[0], nil
+}
+
+func (r *SQLRepositoryImpl) FindAll(ctx context.Context, page int, limit int, q string, orgID string) ([]*Model, error) {
+	query := r.db.NewSelect().Model((*sqlModel)(nil)).Where("org_id = ?", orgID)
+	if q != "" {
+		query = query.Where("LOWER(name) LIKE ?", "%"+q+"%")
+	}
+
+	var sms []*sqlModel
+	err := query.Order("name ASC").
+		Limit(limit).
+		Offset(page*limit).
+		Scan(ctx, &sms)
+	if err != nil {
+		return nil, err
+	}
+	return r.withMonitors(ctx, sms)
+}
+
+func (r *SQLRepositoryImpl) FindByOrgID(ctx context.Context, orgID string) ([]*Model, error) {
+	var sms []*sqlModel
+	if err := r.db.NewSelect().Model(&sms).Where("org_id = ?", orgID).Scan(ctx); err != nil {
+		return nil, err
+	}
+	return r.withMonitors(ctx, sms)
+}
+
+func (r *SQLRepositoryImpl) FindByMonitorID(ctx context.Context, monitorID string) ([]*Model, error) {
+	member := r.db.NewSelect().Model((*sqlGroupMonitor)(nil)).
+		Column("group_id").
+		Where("monitor_id = ?", monitorID)
+
+	var sms []*sqlModel
+	if err := r.db.NewSelect().Model(&sms).Where("id IN (?)", member).Scan(ctx); err != nil {
+		return nil, err
+	}
+	return r.withMonitors(ctx, sms)
+}
+
+func (r *SQLRepositoryImpl) Update(ctx context.Context, group *Model) error {
+	sm, err := toSQLModel(group)
+	if err != nil {
+		return err
+	}
+
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		_, err := tx.NewUpdate().Model((*sqlModel)(nil)).
+			Set("parent_id = ?", sm.ParentID).
+			Set("name = ?", sm.Name).
+			Set("description = ?", sm.Description).
+			Set("policy = ?", sm.Policy).
+			Set("quorum = ?", sm.Quorum).
+			Set("notification_ids = ?", sm.NotificationIDs).
+			Set("updated_at = ?", time.Now()).
+			Where("id = ?", sm.ID).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		if _, err := tx.NewDelete().Model((*sqlGroupMonitor)(nil)).Where("group_id = ?", sm.ID).Exec(ctx); err != nil {
+			return err
+		}
+		return insertGroupMonitors(ctx, tx, sm.ID, group.MonitorIDs)
+	})
+}
+
+func (r *SQLRepositoryImpl) UpdateStatus(ctx context.Context, id string, status shared.MonitorStatus) error {
+	_, err := r.db.NewUpdate().Model((*sqlModel)(nil)).
+		Set("status = ?", int(status)).
+		Where("id = ?", id).
+		Exec(ctx)
+	return err
+}
+
+func (r *SQLRepositoryImpl) Delete(ctx context.Context, id string, orgID string) error {
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		res, err := tx.NewDelete().Model((*sqlModel)(nil)).Where("id = ?", id).Where("org_id = ?", orgID).Exec(ctx)
+		if err != nil {
+			return err
+		}
+		if n, _ := res.RowsAffected(); n == 0 {
+			return nil
+		}
+
+		// Not every backend enforces the foreign key actions
+		if _, err := tx.NewDelete().Model((*sqlGroupMonitor)(nil)).Where("group_id = ?", id).Exec(ctx); err != nil {
+			return err
+		}
+		_, err = tx.NewUpdate().Model((*sqlModel)(nil)).
+			Set("parent_id = NULL").
+			Where("parent_id = ?", id).
+			Exec(ctx)
+		return err
+	})
+}
+
+// withMonitors converts groups to domain models with their monitors loaded
+func (r *SQLRepositoryImpl) withMonitors(ctx context.Context, sms []*sqlModel) ([]*Model, error) {
+	groups := make([]*Model, 0, len(sms))
+	if len(sms) == 0 {
+		return groups, nil
+	}
+
+	byID := make(map[string]*Model, len(sms))
+	ids := make([]string, 0, len(sms))
+	for _, sm := range sms {
+		g, err := toDomainModelFromSQL(sm)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, g)
+		byID[g.ID] = g
+		ids = append(ids, g.ID)
+	}
+
+	var links []*sqlGroupMonitor
+	err := r.db.NewSelect().Model(&links).Where("group_id IN (?)", bun.In(ids)).Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, link := range links {
+		if g, ok := byID[link.GroupID]; ok {
+			g.MonitorIDs = append(g.MonitorIDs, link.MonitorID)
+		}
+	}
+	return groups, nil
+}
+
+func insertGroupMonitors(ctx context.Context, tx bun.Tx, groupID string, monitorIDs []string) error {
+	ids := uniqueIDs(monitorIDs)
+	if len(ids) == 0 {
+		return nil
+	}
+
+	links := make([]*sqlGroupMonitor, 0, len(ids))
+	for _, monitorID := range ids {
+		links = append(links, &sqlGroupMonitor{GroupID: groupID, MonitorID: monitorID})
+	}
+	_, err := tx.NewInsert().Model(&links).Exec(ctx)
+	return err
+}
+
+func uniqueIDs(ids []string) []string {
+	seen := make(map[string]bool, len(ids))
+	result := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		result = append(result, id)
+	}
+	return result
+}
@@ -0,0 +1,190 @@
+package notification_channel
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+	"vigi/internal/config"
+	"vigi/internal/infra"
+	"vigi/internal/modules/escalation"
+	"vigi/internal/modules/metrics"
+	"vigi/internal/modules/monitor"
+	"vigi/internal/modules/notification_delivery"
+
+	"github.com/hibiken/asynq"
+	"go.uber.org/dig"
+	"go.uber.org/zap"
+)
+
+// permanentError marks a delivery failure that retrying cannot fix, such as a
+// deleted channel or an invalid channel config
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+// DeliveryWorker sends queued notification deliveries, asynq retries failed
+// sends with exponential backoff. It also runs the delayed tiers of escalations.
+type DeliveryWorker struct {
+	server            *asynq.Server
+	mux               *asynq.ServeMux
+	service           Service
+	monitorSvc        monitor.Service
+	deliveryService   notification_delivery.Service
+	escalationService escalation.Service
+	logger            *zap.SugaredLogger
+}
+
+type DeliveryWorkerParams struct {
+	dig.In
+	Service           Service
+	MonitorSvc        monitor.Service
+	DeliveryService   notification_delivery.Service
+	EscalationService escalation.Service
+	Config            *config.Config
+	Logger            *zap.SugaredLogger
+}
+
+func NewDeliveryWorker(p DeliveryWorkerParams) *DeliveryWorker {
+	logger := p.Logger.Named("[notification-delivery-worker]")
+
+	redisOpt := asynq.RedisClientOpt{
+		Addr:     fmt.Sprintf("%s:%s", p.Config.RedisHost, p.Config.RedisPort),
+		Password: p.Config.RedisPassword,
+		DB:       p.Config.RedisDB,
+	}
+
+	server := asynq.NewServer(redisOpt, asynq.Config{
+		Concurrency: p.Config.QueueConcurrency,
+		// Only notification deliveries are processed here, health checks and
+		// ingestion keep running in their own services
+		Queues: map[string]int{
+			notification_delivery.QueueName: 1,
+		},
+		RetryDelayFunc: func(n int, err error, task *asynq.Task) time.Duration {
+			return notification_delivery.RetryDelay(n)
+		},
+		ErrorHandler: asynq.ErrorHandlerFunc(func(ctx context.Context, task *asynq.Task, err error) {
+			logger.Warnw("Notification delivery attempt failed",
+				"payload", string(task.Payload()),
+				"error", err,
+			)
+		}),
+		Logger: infra.NewAsynqLogger(p.Logger),
+	})
+
+	return &DeliveryWorker{
+		server:            server,
+		mux:               asynq.NewServeMux(),
+		service:           p.Service,
+		monitorSvc:        p.MonitorSvc,
+		deliveryService:   p.DeliveryService,
+		escalationService: p.EscalationService,
+		logger:            logger,
+	}
+}
+
+// Start starts processing the notification queue
+func (w *DeliveryWorker) Start() error {
+	w.mux.HandleFunc(notification_delivery.TaskTypeSend, w.ProcessTask)
+	w.mux.HandleFunc(escalation.TaskTypeEscalate, w.ProcessEscalateTask)
+	return w.server.Start(w.mux)
+}
+
+// Stop waits for in-flight deliveries and stops the worker
+func (w *DeliveryWorker) Stop() {
+	w.server.Shutdown()
+}
+
+// ProcessTask implements asynq.HandlerFunc
+func (w *DeliveryWorker) ProcessTask(ctx context.Context, task *asynq.Task) error {
+	var payload notification_delivery.TaskPayload
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %v: %w", err, asynq.SkipRetry)
+	}
+
+	// Passing empty string for orgID as this is an internal worker
+	delivery, err := w.deliveryService.FindByID(ctx, payload.DeliveryID, "")
+	if err != nil {
+		return fmt.Errorf("failed to get notification delivery: %w", err)
+	}
+	if delivery == nil {
+		w.logger.Warnf("Notification delivery %s not found, dropping task", payload.DeliveryID)
+		return nil
+	}
+	if delivery.Status == notification_delivery.StatusSent {
+		return nil
+	}
+
+	sendErr := w.send(ctx, delivery)
+
+	var permanent *permanentError
+	isPermanent := errors.As(sendErr, &permanent)
+	retried, _ := asynq.GetRetryCount(ctx)
+	maxRetry, _ := asynq.GetMaxRetry(ctx)
+	final := isPermanent || retried >= maxRetry
+
+	if err := w.deliveryService.RecordAttempt(ctx, delivery, sendErr, final); err != nil {
+		w.logger.Errorf("Failed to record attempt of notification delivery %s: %v", delivery.ID, err)
+	}
+
+	if sendErr == nil {
+		w.logger.Infof("Notification delivery %s sent for monitor: %s", delivery.ID, delivery.MonitorID)
+		return nil
+	}
+	if isPermanent {
+		return fmt.Errorf("%v: %w", sendErr, asynq.SkipRetry)
+	}
+	return sendErr
+}
+
+// ProcessEscalateTask notifies the next tier of an escalation that was not acknowledged
+func (w *DeliveryWorker) ProcessEscalateTask(ctx context.Context, task *asynq.Task) error {
+	var payload escalation.EscalateTaskPayload
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %v: %w", err, asynq.SkipRetry)
+	}
+
+	if err := w.escalationService.Escalate(ctx, payload.EscalationID, payload.Tier); err != nil {
+		return fmt.Errorf("failed to escalate %s to tier %d: %w", payload.EscalationID, payload.Tier, err)
+	}
+	return nil
+}
+
+func (w *DeliveryWorker) send(ctx context.Context, delivery *notification_delivery.Model) error {
+	notificationChannel, err := w.service.FindByID(ctx, delivery.NotificationID, "")
+	if err != nil {
+		return err
+	}
+	if notificationChannel == nil {
+		return &permanentError{fmt.Errorf("notification channel %s not found", delivery.NotificationID)}
+	}
+
+	integration, ok := GetNotificationChannelProvider(notificationChannel.Type)
+	if !ok {
+		return &permanentError{fmt.Errorf("no integration registered for notification type: %s", notificationChannel.Type)}
+	}
+	if notificationChannel.Config == nil {
+		return &permanentError{fmt.Errorf("no config for notification: %s", notificationChannel.Name)}
+	}
+	if err := integration.Validate(*notificationChannel.Config); err != nil {
+		return &permanentError{fmt.Errorf("invalid notification config: %w", err)}
+	}
+
+	monitorModel, err := w.monitorSvc.FindByID(ctx, delivery.MonitorID, "")
+	if err != nil {
+		return err
+	}
+	if monitorModel == nil {
+		return &permanentError{fmt.Errorf("monitor %s not found", delivery.MonitorID)}
+	}
+
+	err = integration.Send(ctx, *notificationChannel.Config, delivery.Message, monitorModel, delivery.Heartbeat)
+	metrics.ObserveNotification(notificationChannel.Type, err)
+	return err
+}
@@ -4,14 +4,19 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 	"vigi/internal/config"
 	"vigi/internal/infra"
 	"vigi/internal/modules/certificate"
+	"vigi/internal/modules/escalation"
 	"vigi/internal/modules/events"
 	"vigi/internal/modules/heartbeat"
 	"vigi/internal/modules/monitor"
+	"vigi/internal/modules/monitor_group"
 	"vigi/internal/modules/monitor_notification"
 	"vigi/internal/modules/notification_channel/providers"
+	"vigi/internal/modules/notification_delivery"
+	"vigi/internal/modules/shared"
 
 	"go.uber.org/dig"
 	"go.uber.org/zap"
@@ -23,6 +28,9 @@ type NotificationEventListener struct {
 	monitorSvc                 monitor.Service
 	heartbeatService           heartbeat.Service
 	monitorNotificationService monitor_notification.Service
+	deliveryService            notification_delivery.Service
+	escalationService          escalation.Service
+	monitorGroupService        monitor_group.Service
 	logger                     *zap.SugaredLogger
 }
 
@@ -32,6 +40,9 @@ type NotificationEventListenerParams struct {
 	MonitorSvc                 monitor.Service
 	HeartbeatService           heartbeat.Service
 	MonitorNotificationService monitor_notification.Service
+	DeliveryService            notification_delivery.Service
+	EscalationService          escalation.Service
+	MonitorGroupService        monitor_group.Service
 	Logger                     *zap.SugaredLogger
 	Config                     *config.Config
 }
@@ -65,14 +76,18 @@ func NewNotificationEventListener(p NotificationEventListenerParams) *Notificati
 		monitorSvc:                 p.MonitorSvc,
 		heartbeatService:           p.HeartbeatService,
 		monitorNotificationService: p.MonitorNotificationService,
+		deliveryService:            p.DeliveryService,
+		escalationService:          p.EscalationService,
+		monitorGroupService:        p.MonitorGroupService,
 		logger:                     p.Logger,
 	}
 }
 
-// Subscribe subscribes to NotifyEvent and sends notifications
+// Subscribe subscribes to NotifyEvent and queues notifications
 func (l *NotificationEventListener) Subscribe(eventBus events.EventBus) {
 	eventBus.Subscribe(events.ImportantHeartbeat, l.handleNotifyEvent)
 	eventBus.Subscribe(events.CertificateExpiry, l.handleCertificateExpiryEvent)
+	eventBus.Subscribe(events.MonitorGroupStatusChanged, l.handleGroupStatusEvent)
 }
 
 func (l *NotificationEventListener) handleNotifyEvent(event events.Event) {
@@ -88,6 +103,15 @@ func (l *NotificationEventListener) handleNotifyEvent(event events.Event) {
 
 	l.logger.Infof("Notification event received for monitor: %s", monitorID)
 
+	// Monitors with an escalation policy notify its tiers instead of every linked channel
+	handled, err := l.escalationService.HandleHeartbeat(ctx, hb)
+	if err != nil {
+		l.logger.Errorf("Failed to escalate notification for monitor: %s, error: %v", monitorID, err)
+	}
+	if handled {
+		return
+	}
+
 	// Get monitor-notification records
 	monitorNotifications, err := l.monitorNotificationService.FindByMonitorID(ctx, monitorID)
 	if err != nil {
@@ -118,28 +142,14 @@ func (l *NotificationEventListener) handleNotifyEvent(event events.Event) {
 	}
 
 	for _, notificationChannel := range notificationChannels {
-		integration, ok := GetNotificationChannelProvider(notificationChannel.Type)
-		if !ok {
-			l.logger.Warnf("No integration registered for notification type: %s", notificationChannel.Type)
-			continue
-		}
-		if notificationChannel.Config == nil {
-			l.logger.Warnf("No config for notification: %s", notificationChannel.Name)
-			continue
-		}
-
-		// validate config
-		if err := integration.Validate(*notificationChannel.Config); err != nil {
-			l.logger.Errorf("Failed to validate notification config: %s, error: %v", notificationChannel.Name, err)
-			continue
-		}
-
-		err := integration.Send(ctx, *notificationChannel.Config, hb.Msg, monitorModel, hb)
-		if err != nil {
-			l.logger.Errorf("Failed to send notification: %s, error: %v", notificationChannel.Name, err)
-		} else {
-			l.logger.Infof("Notification sent to: %s for monitor: %s", notificationChannel.Name, monitorID)
-		}
+		l.dispatch(ctx, &notification_delivery.Model{
+			OrgID:          notificationChannel.OrgID,
+			NotificationID: notificationChannel.ID,
+			MonitorID:      monitorID,
+			Event:          notification_delivery.EventHeartbeat,
+			Message:        hb.Msg,
+			Heartbeat:      hb,
+		})
 	}
 }
 
@@ -189,35 +199,78 @@ func (l *NotificationEventListener) handleCertificateExpiryEvent(event events.Ev
 		return
 	}
 
-	// Send notifications through all configured channels
+	// Create a formatted message for certificate expiry
+	message := l.formatCertificateExpiryMessage(certEvent, monitorModel)
+
+	// Queue notifications for all configured channels (there is no heartbeat for a certificate expiry notification)
 	for _, notificationChannel := range notificationChannels {
-		integration, ok := GetNotificationChannelProvider(notificationChannel.Type)
-		if !ok {
-			l.logger.Warnf("No integration registered for notification type: %s", notificationChannel.Type)
-			continue
-		}
-		if notificationChannel.Config == nil {
-			l.logger.Warnf("No config for notification: %s", notificationChannel.Name)
-			continue
-		}
+		l.dispatch(ctx, &notification_delivery.Model{
+			OrgID:          notificationChannel.OrgID,
+			NotificationID: notificationChannel.ID,
+			MonitorID:      certEvent.MonitorID,
+			Event:          notification_delivery.EventCertificateExpiry,
+			Message:        message,
+		})
+	}
+}
 
-		// Validate config
-		if err := integration.Validate(*notificationChannel.Config); err != nil {
-			l.logger.Errorf("Failed to validate notification config: %s, error: %v", notificationChannel.Name, err)
-			continue
-		}
+func (l *NotificationEventListener) handleGroupStatusEvent(event events.Event) {
+	ctx := context.Background()
 
-		// Create a formatted message for certificate expiry
-		message := l.formatCertificateExpiryMessage(certEvent, monitorModel)
+	change, ok := infra.UnmarshalEventPayload[monitor_group.StatusChangedEvent](event)
+	if !ok {
+		l.logger.Errorf("Failed to unmarshal group status event payload")
+		return
+	}
 
-		// Send notification (we pass nil for heartbeat since this is a certificate expiry notification)
-		err := integration.Send(ctx, *notificationChannel.Config, message, monitorModel, nil)
-		if err != nil {
-			l.logger.Errorf("Failed to send certificate expiry notification: %s, error: %v", notificationChannel.Name, err)
-		} else {
-			l.logger.Infof("Certificate expiry notification sent to: %s for monitor: %s", notificationChannel.Name, certEvent.MonitorID)
+	// Groups only alert on outages and recoveries, pending and maintenance are quiet
+	switch change.Status {
+	case shared.MonitorStatusDown, shared.MonitorStatusDegraded:
+	case shared.MonitorStatusUp:
+		if change.PreviousStatus != shared.MonitorStatusDown && change.PreviousStatus != shared.MonitorStatusDegraded {
+			return
 		}
+	default:
+		return
+	}
+
+	group, err := l.monitorGroupService.FindByID(ctx, change.GroupID, change.OrgID)
+	if err != nil || group == nil {
+		l.logger.Warnf("Monitor group %s not found for notification", change.GroupID)
+		return
+	}
+
+	l.logger.Infof("Group status event received for group: %s", group.ID)
+
+	// The delivery is sent on behalf of the member that changed the group status,
+	// the heartbeat carries the group status so channels render the group outcome
+	hb := &heartbeat.Model{
+		MonitorID: change.MonitorID,
+		Status:    change.Status,
+		Msg:       change.Message,
+		Important: true,
+		Time:      time.Now().UTC(),
+	}
+	for _, notificationID := range group.NotificationIDs {
+		l.dispatch(ctx, &notification_delivery.Model{
+			OrgID:          group.OrgID,
+			NotificationID: notificationID,
+			MonitorID:      change.MonitorID,
+			Event:          notification_delivery.EventGroupStatus,
+			Message:        change.Message,
+			Heartbeat:      hb,
+		})
+	}
+}
+
+// dispatch queues a delivery, the DeliveryWorker sends it and retries on failure
+func (l *NotificationEventListener) dispatch(ctx context.Context, delivery *notification_delivery.Model) {
+	created, err := l.deliveryService.Dispatch(ctx, delivery)
+	if err != nil {
+		l.logger.Errorf("Failed to queue notification: %s for monitor: %s, error: %v", delivery.NotificationID, delivery.MonitorID, err)
+		return
 	}
+	l.logger.Infof("Notification queued: %s to: %s for monitor: %s", created.ID, delivery.NotificationID, delivery.MonitorID)
 }
 
 // formatCertificateExpiryMessage creates a formatted message for certificate expiry notifications
@@ -65,7 +65,7 @@ func (ic *Controller) FindAll(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, utils.NewSuccessResponse("success", response))
+	ctx.JSON(http.StatusOK, utils.NewSuccessResponse("success", redactAll(response)))
 }
 
 // @Router		/notification-channels [post]
@@ -113,7 +113,7 @@ func (ic *Controller) Create(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusCreated, utils.NewSuccessResponse("Notification created successfully", createdNotification))
+	ctx.JSON(http.StatusCreated, utils.NewSuccessResponse("Notification created successfully", redact(createdNotification)))
 }
 
 // @Router		/notification-channels/{id} [get]
@@ -144,7 +144,7 @@ func (ic *Controller) FindByID(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, utils.NewSuccessResponse("success", notification))
+	ctx.JSON(http.StatusOK, utils.NewSuccessResponse("success", redact(notification)))
 }
 
 // @Router		/notification-channels/{id} [put]
@@ -183,7 +183,7 @@ func (ic *Controller) UpdateFull(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, utils.NewSuccessResponse("notification updated successfully", updatedNotification))
+	ctx.JSON(http.StatusOK, utils.NewSuccessResponse("notification updated successfully", redact(updatedNotification)))
 }
 
 // @Router		/notification-channels/{id} [patch]
@@ -223,7 +223,7 @@ func (ic *Controller) UpdatePartial(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, utils.NewSuccessResponse("notification updated successfully", updatedNotification))
+	ctx.JSON(http.StatusOK, utils.NewSuccessResponse("notification updated successfully", redact(updatedNotification)))
 }
 
 // @Router		/notification-channels/{id} [delete]
@@ -260,6 +260,7 @@ func (ic *Controller) Delete(ctx *gin.Context) {
 // @Security  JwtAuth
 // @Security  ApiKeyAuth
 // @Param     body body   CreateUpdateDto  true  "Notification object"
+// @Param     id   query  string  false  "Saved channel whose secrets replace the masked ones"
 // @Success		200	{object}	utils.ApiResponse[any]
 // @Failure		400	{object}	utils.APIError[any]
 // @Failure		500	{object}	utils.APIError[any]
@@ -276,6 +277,16 @@ func (ic *Controller) Test(ctx *gin.Context) {
 		return
 	}
 
+	if id := ctx.Query("id"); id != "" {
+		config, err := ic.service.RestoreSecrets(ctx, id, notificationChannel.Config, ctx.GetString("orgId"))
+		if err != nil {
+			ic.logger.Errorw("Failed to fetch notification", "error", err)
+			ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse("Internal server error"))
+			return
+		}
+		notificationChannel.Config = config
+	}
+
 	integration, ok := GetNotificationChannelProvider(notificationChannel.Type)
 	if !ok {
 		ctx.JSON(http.StatusBadRequest, utils.NewFailResponse("Unsupported notification type"))
@@ -2,6 +2,8 @@ package notification_channel
 
 import (
 	"vigi/internal/config"
+	"vigi/internal/modules/escalation"
+	"vigi/internal/modules/monitor_group"
 	"vigi/internal/utils"
 
 	"go.uber.org/dig"
@@ -9,8 +11,14 @@ import (
 
 func RegisterDependencies(container *dig.Container, cfg *config.Config) {
 	utils.RegisterRepositoryByDBType(container, cfg, NewSQLRepository, NewMongoRepository)
+	container.Decorate(NewEncryptedRepository)
 	container.Provide(NewService)
+	// Escalation policies and monitor groups check their channels through a
+	// narrow interface, this package already depends on theirs
+	container.Provide(func(s Service) escalation.NotificationChannelService { return s })
+	container.Provide(func(s Service) monitor_group.NotificationChannelService { return s })
 	container.Provide(NewController)
 	container.Provide(NewRoute)
 	container.Provide(NewNotificationEventListener)
+	container.Provide(NewDeliveryWorker)
 }
@@ -0,0 +1,87 @@
+package notification_channel
+
+import (
+	"context"
+	"vigi/internal/secret"
+)
+
+// encryptedRepository encrypts the secret config fields before they reach
+// the database and decrypts them on the way back.
+type encryptedRepository struct {
+	Repository
+	keyring *secret.Keyring
+}
+
+func NewEncryptedRepository(repository Repository, keyring *secret.Keyring) Repository {
+	return &encryptedRepository{repository, keyring}
+}
+
+func (r *encryptedRepository) encryptConfig(config *string) (*string, error) {
+	if config == nil {
+		return nil, nil
+	}
+	encrypted, err := r.keyring.EncryptFields(*config, SecretConfigFields)
+	if err != nil {
+		return nil, err
+	}
+	return &encrypted, nil
+}
+
+func (r *encryptedRepository) decrypt(m *Model, err error) (*Model, error) {
+	if err != nil || m == nil || m.Config == nil {
+		return m, err
+	}
+	config, err := r.keyring.DecryptFields(*m.Config, SecretConfigFields)
+	if err != nil {
+		return nil, err
+	}
+	m.Config = &config
+	return m, nil
+}
+
+func (r *encryptedRepository) Create(ctx context.Context, entity *Model) (*Model, error) {
+	config, err := r.encryptConfig(entity.Config)
+	if err != nil {
+		return nil, err
+	}
+	encrypted := *entity
+	encrypted.Config = config
+	return r.decrypt(r.Repository.Create(ctx, &encrypted))
+}
+
+func (r *encryptedRepository) FindByID(ctx context.Context, id string, orgID string) (*Model, error) {
+	return r.decrypt(r.Repository.FindByID(ctx, id, orgID))
+}
+
+func (r *encryptedRepository) FindAll(ctx context.Context, page int, limit int, q string, orgID string) ([]*Model, error) {
+	entities, err := r.Repository.FindAll(ctx, page, limit, q, orgID)
+	if err != nil {
+		return nil, err
+	}
+	for _, entity := range entities {
+		if _, err := r.decrypt(entity, nil); err != nil {
+			return nil, err
+		}
+	}
+	return entities, nil
+}
+
+func (r *encryptedRepository) UpdateFull(ctx context.Context, id string, entity *Model, orgID string) error {
+	config, err := r.encryptConfig(entity.Config)
+	if err != nil {
+		return err
+	}
+	encrypted := *entity
+	encrypted.Config = config
+	return r.Repository.UpdateFull(ctx, id, &encrypted, orgID)
+}
+
+func (r *encryptedRepository) UpdatePartial(ctx context.Context, id string, entity *UpdateModel, orgID string) error {
+	config, err := r.encryptConfig(entity.Config)
+	if err != nil {
+		return err
+	}
+	encrypted := *entity
+	encrypted.Config = config
+	return r.Repository.UpdatePartial(ctx, id, &encrypted, orgID)
+}
@@ -0,0 +1,44 @@
+package notification_channel
+
+import "vigi/internal/secret"
+
+// SecretConfigFields are the provider config fields holding credentials.
+// They are encrypted at rest and redacted in API responses.
+var SecretConfigFields = []string{
+	"password",
+	"token",
+	"api_key",
+	"access_token",
+	"bot_token",
+	"authToken",
+	"application_token",
+	"channel_access_token",
+	"pagerduty_integration_key",
+	"pushbullet_access_token",
+	"pushover_app_token",
+	"pushover_user_key",
+	"twilio_auth_token",
+	"twilio_api_key",
+	"webhook_additional_headers.*",
+}
+
+// redact returns a copy of the channel safe to send to clients
+func redact(m *Model) *Model {
+	if m == nil {
+		return nil
+	}
+	redacted := *m
+	if m.Config != nil {
+		config := secret.RedactFields(*m.Config, SecretConfigFields)
+		redacted.Config = &config
+	}
+	return &redacted
+}
+
+func redactAll(models []*Model) []*Model {
+	redacted := make([]*Model, 0, len(models))
+	for _, m := range models {
+		redacted = append(redacted, redact(m))
+	}
+	return redacted
+}
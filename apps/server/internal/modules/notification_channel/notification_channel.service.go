@@ -2,7 +2,9 @@ package notification_channel
 
 import (
 	"context"
+	"strings"
 	"vigi/internal/modules/monitor_notification"
+	"vigi/internal/secret"
 
 	"go.uber.org/zap"
 )
@@ -14,6 +16,11 @@ type Service interface {
 	UpdateFull(ctx context.Context, id string, entity *CreateUpdateDto, orgID string) (*Model, error)
 	UpdatePartial(ctx context.Context, id string, entity *PartialUpdateDto, orgID string) (*Model, error)
 	Delete(ctx context.Context, id string, orgID string) error
+	// RestoreSecrets replaces the masked secrets of a config sent back by a
+	// client with the ones stored for the channel
+	RestoreSecrets(ctx context.Context, id string, config string, orgID string) (string, error)
+	// AllExist reports whether every channel of ids belongs to the organization
+	AllExist(ctx context.Context, ids []string, orgID string) (bool, error)
 }
 
 type ServiceImpl struct {
@@ -67,6 +74,11 @@ func (mr *ServiceImpl) FindAll(
 }
 
 func (mr *ServiceImpl) UpdateFull(ctx context.Context, id string, entity *CreateUpdateDto, orgID string) (*Model, error) {
+	config, err := mr.RestoreSecrets(ctx, id, entity.Config, orgID)
+	if err != nil {
+		return nil, err
+	}
+
 	updateModel := &Model{
 		ID:        id,
 		OrgID:     orgID,
@@ -74,10 +86,10 @@ func (mr *ServiceImpl) UpdateFull(ctx context.Context, id string, entity *Create
 		Type:      entity.Type,
 		Active:    entity.Active,
 		IsDefault: entity.IsDefault,
-		Config:    &entity.Config,
+		Config:    &config,
 	}
 
-	err := mr.repository.UpdateFull(ctx, id, updateModel, orgID)
+	err = mr.repository.UpdateFull(ctx, id, updateModel, orgID)
 	if err != nil {
 		return nil, err
 	}
@@ -86,6 +98,11 @@ func (mr *ServiceImpl) UpdateFull(ctx context.Context, id string, entity *Create
 }
 
 func (mr *ServiceImpl) UpdatePartial(ctx context.Context, id string, entity *PartialUpdateDto, orgID string) (*Model, error) {
+	config, err := mr.RestoreSecrets(ctx, id, entity.Config, orgID)
+	if err != nil {
+		return nil, err
+	}
+
 	updateModel := &UpdateModel{
 		ID:        &id,
 		OrgID:     orgID,
@@ -93,10 +110,10 @@ func (mr *ServiceImpl) UpdatePartial(ctx context.Context, id string, entity *Par
 		Type:      &entity.Type,
 		Active:    &entity.Active,
 		IsDefault: &entity.IsDefault,
-		Config:    &entity.Config,
+		Config:    &config,
 	}
 
-	err := mr.repository.UpdatePartial(ctx, id, updateModel, orgID)
+	err = mr.repository.UpdatePartial(ctx, id, updateModel, orgID)
 	if err != nil {
 		return nil, err
 	}
@@ -109,6 +126,36 @@ func (mr *ServiceImpl) UpdatePartial(ctx context.Context, id string, entity *Par
 	return updatedModel, nil
 }
 
+func (mr *ServiceImpl) RestoreSecrets(ctx context.Context, id string, config string, orgID string) (string, error) {
+	if !strings.Contains(config, secret.Mask) {
+		return config, nil
+	}
+
+	existing, err := mr.repository.FindByID(ctx, id, orgID)
+	if err != nil {
+		return "", err
+	}
+
+	stored := ""
+	if existing != nil && existing.Config != nil {
+		stored = *existing.Config
+	}
+	return secret.RestoreFields(config, stored, SecretConfigFields), nil
+}
+
+func (mr *ServiceImpl) AllExist(ctx context.Context, ids []string, orgID string) (bool, error) {
+	for _, id := range ids {
+		existing, err := mr.repository.FindByID(ctx, id, orgID)
+		if err != nil {
+			return false, err
+		}
+		if existing == nil {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
 func (mr *ServiceImpl) Delete(ctx context.Context, id string, orgID string) error {
 	err := mr.repository.Delete(ctx, id, orgID)
 	if err != nil {
@@ -76,6 +76,8 @@ func humanReadableStatus(status int) string {
 		return "PENDING"
 	case 3:
 		return "MAINTENANCE"
+	case 4:
+		return "DEGRADED"
 	default:
 		return fmt.Sprintf("Unknown (%d)", status)
 	}
@@ -91,6 +93,8 @@ func humanReadableStatusIcons(status int) string {
 		return "⏳"
 	case 3:
 		return "🚧"
+	case 4:
+		return "⚠️"
 	default:
 		return "❔"
 	}
@@ -106,7 +110,25 @@ func humanReadableStatusColor(status int) int {
 		return 11032055
 	case 3:
 		return 3900150
+	case 4:
+		return 16096779
 	default:
 		return 7041664
 	}
 }
+
+// StatusError is returned when a provider answers with an unexpected HTTP
+// status, the delivery log records the code
+type StatusError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *StatusError) Error() string {
+	return e.Message
+}
+
+// statusErrorf formats an error carrying the HTTP status of the response
+func statusErrorf(statusCode int, format string, args ...any) error {
+	return &StatusError{StatusCode: statusCode, Message: fmt.Sprintf(format, args...)}
+}
@@ -135,7 +135,7 @@ func (s *DiscordSender) Send(
 	defer resp.Body.Close()
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return fmt.Errorf("Discord webhook returned status: %s", resp.Status)
+		return statusErrorf(resp.StatusCode, "Discord webhook returned status: %s", resp.Status)
 	}
 
 	s.logger.Infof("Discord message sent successfully")
@@ -73,6 +73,8 @@ func (g *GoogleChatSender) Send(
 	if m != nil && hb != nil {
 		if hb.Status == shared.MonitorStatusUp {
 			chatHeader["title"] = fmt.Sprintf("✅ %s is back online", m.Name)
+		} else if hb.Status == shared.MonitorStatusDegraded {
+			chatHeader["title"] = fmt.Sprintf("⚠️ %s is degraded", m.Name)
 		} else {
 			chatHeader["title"] = fmt.Sprintf("🔴 %s went down", m.Name)
 		}
@@ -160,7 +162,7 @@ func (g *GoogleChatSender) Send(
 	defer resp.Body.Close()
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return fmt.Errorf("Google Chat API returned status code: %d", resp.StatusCode)
+		return statusErrorf(resp.StatusCode, "Google Chat API returned status code: %d", resp.StatusCode)
 	}
 
 	g.logger.Infof("Google Chat notification sent successfully")
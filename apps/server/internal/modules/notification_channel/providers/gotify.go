@@ -140,7 +140,7 @@ func (g *GotifySender) Send(
 
 	// Check response status
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return fmt.Errorf("Gotify request failed with status %d", resp.StatusCode)
+		return statusErrorf(resp.StatusCode, "Gotify request failed with status %d", resp.StatusCode)
 	}
 
 	g.logger.Infof("Gotify notification sent successfully to %s", serverURL)
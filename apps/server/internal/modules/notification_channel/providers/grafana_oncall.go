@@ -91,6 +91,12 @@ func (g *GrafanaOncallSender) Send(
 				"message": heartbeat.Msg,
 				"state":   "ok",
 			}
+		case shared.MonitorStatusDegraded:
+			payload = map[string]interface{}{
+				"title":   fmt.Sprintf("%s is degraded", monitorName),
+				"message": heartbeat.Msg,
+				"state":   "alerting",
+			}
 		default:
 			// For pending/maintenance states, treat as alerting
 			payload = map[string]interface{}{
@@ -122,7 +128,7 @@ func (g *GrafanaOncallSender) Send(
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("grafana OnCall API returned status %d: %s", resp.StatusCode, string(body))
+		return statusErrorf(resp.StatusCode, "grafana OnCall API returned status %d: %s", resp.StatusCode, string(body))
 	}
 
 	g.logger.Infof("Grafana OnCall notification sent successfully")
@@ -129,10 +129,10 @@ func (s *LineSender) Send(
 		var errorBody map[string]interface{}
 		if err := json.NewDecoder(resp.Body).Decode(&errorBody); err == nil {
 			if msg, ok := errorBody["message"].(string); ok {
-				return fmt.Errorf("LINE API error: %s (status: %d)", msg, resp.StatusCode)
+				return statusErrorf(resp.StatusCode, "LINE API error: %s (status: %d)", msg, resp.StatusCode)
 			}
 		}
-		return fmt.Errorf("LINE API returned status: %s", resp.Status)
+		return statusErrorf(resp.StatusCode, "LINE API returned status: %s", resp.Status)
 	}
 
 	s.logger.Infof("LINE message sent successfully")
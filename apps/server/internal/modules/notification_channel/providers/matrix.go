@@ -141,7 +141,7 @@ func (m *MatrixSender) Send(
 
 	// Check response status
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return fmt.Errorf("Matrix API returned status code: %d", resp.StatusCode)
+		return statusErrorf(resp.StatusCode, "Matrix API returned status code: %d", resp.StatusCode)
 	}
 
 	m.logger.Infof("Matrix message sent successfully to room: %s", cfg.InternalRoomID)
@@ -194,6 +194,14 @@ func (m *MattermostSender) buildRichMessage(cfg *MattermostConfig, message strin
 			}
 			statusText = "up"
 			color = "#32CD32"
+		case shared.MonitorStatusDegraded:
+			statusField = map[string]any{
+				"short": false,
+				"title": "Degraded",
+				"value": heartbeat.Msg,
+			}
+			statusText = "degraded"
+			color = "#FFA500"
 		}
 	}
 
@@ -285,7 +293,7 @@ func (m *MattermostSender) sendMessage(ctx context.Context, webhookURL string, p
 	defer resp.Body.Close()
 
 	if resp.StatusCode >= 400 {
-		return fmt.Errorf("Mattermost API returned status %d", resp.StatusCode)
+		return statusErrorf(resp.StatusCode, "Mattermost API returned status %d", resp.StatusCode)
 	}
 
 	m.logger.Infof("Mattermost notification sent successfully")
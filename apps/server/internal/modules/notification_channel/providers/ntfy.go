@@ -175,7 +175,7 @@ func (e *NTFYSender) Send(
 
 	// Check response status
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return fmt.Errorf("NTFY request failed with status %d: %s", resp.StatusCode, string(body))
+		return statusErrorf(resp.StatusCode, "NTFY request failed with status %d: %s", resp.StatusCode, string(body))
 	}
 
 	e.logger.Infof("NTFY notification sent successfully to %s", cfg.ServerUrl)
@@ -95,7 +95,7 @@ func (o *OpsgenieSender) Send(
 
 	// Handle status changes
 	switch heartbeat.Status {
-	case shared.MonitorStatusDown:
+	case shared.MonitorStatusDown, shared.MonitorStatusDegraded:
 		return o.sendDownAlert(ctx, cfg, baseURL, message, monitor, heartbeat, textMsg)
 	case shared.MonitorStatusUp:
 		return o.sendUpAlert(ctx, cfg, baseURL, message, monitor, heartbeat)
@@ -178,7 +178,7 @@ func (o *OpsgenieSender) postToOpsgenie(ctx context.Context, cfg *OpsgenieConfig
 	defer resp.Body.Close()
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return fmt.Errorf("Opsgenie API returned status code %d", resp.StatusCode)
+		return statusErrorf(resp.StatusCode, "Opsgenie API returned status code %d", resp.StatusCode)
 	}
 
 	o.logger.Infof("Successfully sent Opsgenie notification, status: %d", resp.StatusCode)
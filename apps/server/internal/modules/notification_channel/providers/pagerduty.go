@@ -126,6 +126,8 @@ func (p *PagerDutySender) getTitle(heartbeat *heartbeat.Model) string {
 		return "Vigi Monitor ⏳ Pending"
 	case shared.MonitorStatusMaintenance:
 		return "Vigi Monitor 🔧 Maintenance"
+	case shared.MonitorStatusDegraded:
+		return "Vigi Monitor ⚠️ Degraded"
 	default:
 		return "Vigi Alert"
 	}
@@ -221,7 +223,7 @@ func (p *PagerDutySender) Send(
 
 	// Check response status
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return fmt.Errorf("PagerDuty notification failed with status code: %d", resp.StatusCode)
+		return statusErrorf(resp.StatusCode, "PagerDuty notification failed with status code: %d", resp.StatusCode)
 	}
 
 	p.logger.Infof("PagerDuty notification sent successfully")
@@ -177,7 +177,7 @@ func (p *PagerTreeSender) Send(ctx context.Context, configJSON, message string,
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		var respBody bytes.Buffer
 		respBody.ReadFrom(resp.Body)
-		return fmt.Errorf("PagerTree API returned status %d: %s", resp.StatusCode, respBody.String())
+		return statusErrorf(resp.StatusCode, "PagerTree API returned status %d: %s", resp.StatusCode, respBody.String())
 	}
 
 	p.logger.Infof("PagerTree notification sent successfully - event: %s, monitor: %s (%s)",
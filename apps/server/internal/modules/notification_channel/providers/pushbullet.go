@@ -111,11 +111,11 @@ func (s *PushbulletSender) Send(
 		if err := json.NewDecoder(resp.Body).Decode(&respBody); err == nil {
 			if errMsg, ok := respBody["error"].(map[string]interface{}); ok {
 				if msg, ok := errMsg["message"].(string); ok {
-					return fmt.Errorf("pushbullet API error: %s (status: %d)", msg, resp.StatusCode)
+					return statusErrorf(resp.StatusCode, "pushbullet API error: %s (status: %d)", msg, resp.StatusCode)
 				}
 			}
 		}
-		return fmt.Errorf("pushbullet API returned status: %d", resp.StatusCode)
+		return statusErrorf(resp.StatusCode, "pushbullet API returned status: %d", resp.StatusCode)
 	}
 
 	s.logger.Infof("Pushbullet notification sent successfully")
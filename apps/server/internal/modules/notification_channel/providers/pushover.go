@@ -140,7 +140,7 @@ func (p *PushoverSender) Send(
 
 	// Check response status
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return fmt.Errorf("Pushover API returned status code: %d", resp.StatusCode)
+		return statusErrorf(resp.StatusCode, "Pushover API returned status code: %d", resp.StatusCode)
 	}
 
 	p.logger.Infof("Pushover notification sent successfully")
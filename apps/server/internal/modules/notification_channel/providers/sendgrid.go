@@ -115,7 +115,7 @@ func (s *SendGridSender) Send(
 			"status", response.StatusCode,
 			"body", response.Body,
 			"headers", response.Headers)
-		return fmt.Errorf("SendGrid API error: %d - %s", response.StatusCode, response.Body)
+		return statusErrorf(response.StatusCode, "SendGrid API error: %d - %s", response.StatusCode, response.Body)
 	}
 
 	s.logger.Infow("SendGrid email sent successfully",
@@ -113,7 +113,7 @@ func (s *SignalSender) Send(
 
 	// Check response status
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return fmt.Errorf("Signal API returned status code: %d", resp.StatusCode)
+		return statusErrorf(resp.StatusCode, "Signal API returned status code: %d", resp.StatusCode)
 	}
 
 	s.logger.Infof("Signal message sent successfully to %s", cfg.SignalURL)
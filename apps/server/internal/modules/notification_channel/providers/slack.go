@@ -159,6 +159,8 @@ func (s *SlackSender) getStatusColor(status heartbeat.MonitorStatus) string {
 		return "#daa038"
 	case shared.MonitorStatusMaintenance:
 		return "#808080"
+	case shared.MonitorStatusDegraded:
+		return "#f59e0b"
 	default:
 		return "#808080"
 	}
@@ -270,7 +272,7 @@ func (s *SlackSender) Send(
 
 	// Check response status
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return fmt.Errorf("Slack webhook returned status: %s", resp.Status)
+		return statusErrorf(resp.StatusCode, "Slack webhook returned status: %s", resp.Status)
 	}
 
 	s.logger.Infof("Slack message sent successfully")
@@ -125,7 +125,7 @@ func (s *TelegramSender) Send(
 	defer resp.Body.Close()
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return fmt.Errorf("telegram API returned status: %s, body: %s", resp.Status, resp.Body)
+		return statusErrorf(resp.StatusCode, "telegram API returned status: %s, body: %s", resp.Status, resp.Body)
 	}
 
 	return nil
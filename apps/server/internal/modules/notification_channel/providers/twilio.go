@@ -94,10 +94,10 @@ func (s *TwilioSender) Send(
 		var respBody map[string]interface{}
 		if err := json.NewDecoder(resp.Body).Decode(&respBody); err == nil {
 			if msg, ok := respBody["message"].(string); ok {
-				return fmt.Errorf("twilio API error: %s (status: %d)", msg, resp.StatusCode)
+				return statusErrorf(resp.StatusCode, "twilio API error: %s (status: %d)", msg, resp.StatusCode)
 			}
 		}
-		return fmt.Errorf("twilio API returned status: %d", resp.StatusCode)
+		return statusErrorf(resp.StatusCode, "twilio API returned status: %d", resp.StatusCode)
 	}
 
 	s.logger.Infof("Twilio SMS sent successfully to: %s", cfg.ToNumber)
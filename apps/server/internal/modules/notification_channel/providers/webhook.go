@@ -173,7 +173,7 @@ func (w *WebhookSender) Send(
 	// Check response status
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("webhook returned status %d: %s", resp.StatusCode, string(bodyBytes))
+		return statusErrorf(resp.StatusCode, "webhook returned status %d: %s", resp.StatusCode, string(bodyBytes))
 	}
 
 	w.logger.Infof("Webhook notification sent successfully to: %s", cfg.WebhookURL)
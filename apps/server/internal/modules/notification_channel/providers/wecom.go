@@ -119,7 +119,7 @@ func (w *WeComSender) Send(
 
 	// Check for successful response status
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("WeCom API returned status %d", resp.StatusCode)
+		return statusErrorf(resp.StatusCode, "WeCom API returned status %d", resp.StatusCode)
 	}
 
 	w.logger.Infof("WeCom notification sent successfully")
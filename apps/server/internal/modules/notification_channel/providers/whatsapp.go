@@ -160,10 +160,10 @@ func (w *WhatsAppSender) sendToPhoneNumber(
 		var errorResponse map[string]interface{}
 		if err := json.Unmarshal(bodyBytes, &errorResponse); err == nil {
 			if errorMsg, ok := errorResponse["message"].(string); ok {
-				return fmt.Errorf("WAHA API error: %s (status: %d)", errorMsg, resp.StatusCode)
+				return statusErrorf(resp.StatusCode, "WAHA API error: %s (status: %d)", errorMsg, resp.StatusCode)
 			}
 		}
-		return fmt.Errorf("WAHA API returned status: %d", resp.StatusCode)
+		return statusErrorf(resp.StatusCode, "WAHA API returned status: %d", resp.StatusCode)
 	}
 
 	// Parse response to check for success
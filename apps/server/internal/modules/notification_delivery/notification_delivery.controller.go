@@ -0,0 +1,127 @@
+package notification_delivery
+
+import (
+	"errors"
+	"net/http"
+	"vigi/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type Controller struct {
+	service Service
+	logger  *zap.SugaredLogger
+}
+
+func NewController(
+	service Service,
+	logger *zap.SugaredLogger,
+) *Controller {
+	return &Controller{
+		service: service,
+		logger:  logger.Named("[notification-delivery-controller]"),
+	}
+}
+
+// @Router    /notification-deliveries [get]
+// @Summary   Get notification deliveries
+// @Tags      Notification Deliveries
+// @Produce   json
+// @Security  JwtAuth
+// @Security  ApiKeyAuth
+// @Param     status          query string false "Filter by status (pending, retrying, sent, failed)"
+// @Param     notification_id query string false "Filter by notification channel ID"
+// @Param     monitor_id      query string false "Filter by monitor ID"
+// @Param     page            query int    false "Page number" default(0)
+// @Param     limit           query int    false "Items per page" default(10)
+// @Success   200  {object}  utils.ApiResponse[[]Model]
+// @Failure   400  {object}  utils.APIError[any]
+// @Failure   500  {object}  utils.APIError[any]
+func (c *Controller) FindAll(ctx *gin.Context) {
+	page, err := utils.GetQueryInt(ctx, "page", 0)
+	if err != nil || page < 0 {
+		ctx.JSON(http.StatusBadRequest, utils.NewFailResponse("Invalid page parameter"))
+		return
+	}
+	limit, err := utils.GetQueryInt(ctx, "limit", 10)
+	if err != nil || limit < 1 {
+		ctx.JSON(http.StatusBadRequest, utils.NewFailResponse("Invalid limit parameter"))
+		return
+	}
+
+	filter := &FindFilter{
+		Status:         ctx.Query("status"),
+		NotificationID: ctx.Query("notification_id"),
+		MonitorID:      ctx.Query("monitor_id"),
+	}
+
+	orgID := ctx.GetString("orgId")
+
+	deliveries, err := c.service.FindAll(ctx, page, limit, filter, orgID)
+	if err != nil {
+		c.logger.Errorw("Failed to get notification deliveries", "error", err)
+		ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse("Internal server error"))
+		return
+	}
+	ctx.JSON(http.StatusOK, utils.NewSuccessResponse("success", deliveries))
+}
+
+// @Router    /notification-deliveries/{id} [get]
+// @Summary   Get notification delivery by ID
+// @Tags      Notification Deliveries
+// @Produce   json
+// @Security  JwtAuth
+// @Security  ApiKeyAuth
+// @Param     id   path      string  true  "Delivery ID"
+// @Success   200  {object}  utils.ApiResponse[Model]
+// @Failure   404  {object}  utils.APIError[any]
+// @Failure   500  {object}  utils.APIError[any]
+func (c *Controller) FindByID(ctx *gin.Context) {
+	id := ctx.Param("id")
+	orgID := ctx.GetString("orgId")
+
+	delivery, err := c.service.FindByID(ctx, id, orgID)
+	if err != nil {
+		c.logger.Errorw("Failed to get notification delivery", "error", err, "id", id)
+		ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse("Internal server error"))
+		return
+	}
+	if delivery == nil {
+		ctx.JSON(http.StatusNotFound, utils.NewFailResponse("Notification delivery not found"))
+		return
+	}
+	ctx.JSON(http.StatusOK, utils.NewSuccessResponse("success", delivery))
+}
+
+// @Router    /notification-deliveries/{id}/resend [post]
+// @Summary   Resend a failed notification delivery
+// @Tags      Notification Deliveries
+// @Produce   json
+// @Security  JwtAuth
+// @Security  ApiKeyAuth
+// @Param     id   path      string  true  "Delivery ID"
+// @Success   200  {object}  utils.ApiResponse[Model]
+// @Failure   404  {object}  utils.APIError[any]
+// @Failure   409  {object}  utils.APIError[any]
+// @Failure   500  {object}  utils.APIError[any]
+func (c *Controller) Resend(ctx *gin.Context) {
+	id := ctx.Param("id")
+	orgID := ctx.GetString("orgId")
+
+	delivery, err := c.service.Resend(ctx, id, orgID)
+	if err != nil {
+		if errors.Is(err, ErrNotResendable) {
+			ctx.JSON(http.StatusConflict, utils.NewFailResponse(err.Error()))
+			return
+		}
+		c.logger.Errorw("Failed to resend notification delivery", "error", err, "id", id)
+		ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse("Internal server error"))
+		return
+	}
+	if delivery == nil {
+		ctx.JSON(http.StatusNotFound, utils.NewFailResponse("Notification delivery not found"))
+		return
+	}
+	ctx.JSON(http.StatusOK, utils.NewSuccessResponse("Notification delivery queued", delivery))
+}
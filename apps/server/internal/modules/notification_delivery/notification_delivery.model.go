@@ -0,0 +1,71 @@
+package notification_delivery
+
+import (
+	"encoding/json"
+	"time"
+	"vigi/internal/modules/heartbeat"
+)
+
+const (
+	StatusPending  = "pending"
+	StatusRetrying = "retrying"
+	StatusSent     = "sent"
+	StatusFailed   = "failed"
+)
+
+const (
+	EventHeartbeat         = "heartbeat"
+	EventCertificateExpiry = "certificate_expiry"
+	EventGroupStatus       = "group_status"
+)
+
+// Model is a single notification sent through a notification channel on behalf of a monitor
+type Model struct {
+	ID             string           `json:"id"`
+	OrgID          string           `json:"org_id"`
+	NotificationID string           `json:"notification_id"`
+	MonitorID      string           `json:"monitor_id"`
+	Event          string           `json:"event"`
+	Message        string           `json:"message"`
+	Heartbeat      *heartbeat.Model `json:"heartbeat,omitempty"`
+	Status         string           `json:"status"`
+	Attempts       int              `json:"attempts"`
+	LastError      string           `json:"last_error,omitempty"`
+	ResponseCode   *int             `json:"response_code,omitempty"`
+	LastAttemptAt  *time.Time       `json:"last_attempt_at,omitempty"`
+	SentAt         *time.Time       `json:"sent_at,omitempty"`
+	CreatedAt      time.Time        `json:"created_at"`
+	UpdatedAt      time.Time        `json:"updated_at"`
+}
+
+// FindFilter narrows down the deliveries returned by FindAll, empty fields are ignored
+type FindFilter struct {
+	Status         string
+	NotificationID string
+	MonitorID      string
+}
+
+// encodeHeartbeat stores the heartbeat snapshot as JSON so a retry or a resend
+// still renders the original message after the heartbeat itself is cleaned up
+func encodeHeartbeat(hb *heartbeat.Model) *string {
+	if hb == nil {
+		return nil
+	}
+	data, err := json.Marshal(hb)
+	if err != nil {
+		return nil
+	}
+	s := string(data)
+	return &s
+}
+
+func decodeHeartbeat(data *string) *heartbeat.Model {
+	if data == nil || *data == "" {
+		return nil
+	}
+	var hb heartbeat.Model
+	if err := json.Unmarshal([]byte(*data), &hb); err != nil {
+		return nil
+	}
+	return &hb
+}
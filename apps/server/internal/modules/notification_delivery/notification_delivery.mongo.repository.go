@@ -0,0 +1,186 @@
+package notification_delivery
+
+import (
+	"context"
+	"errors"
+	"time"
+	"vigi/internal/config"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoModel struct {
+	ID             primitive.ObjectID `bson:"_id,omitempty"`
+	OrgID          string             `bson:"org_id"`
+	NotificationID string             `bson:"notification_id"`
+	MonitorID      string             `bson:"monitor_id"`
+	Event          string             `bson:"event"`
+	Message        string             `bson:"message"`
+	Heartbeat      *string            `bson:"heartbeat,omitempty"`
+	Status         string             `bson:"status"`
+	Attempts       int                `bson:"attempts"`
+	LastError      string             `bson:"last_error,omitempty"`
+	ResponseCode   *int               `bson:"response_code,omitempty"`
+	LastAttemptAt  *time.Time         `bson:"last_attempt_at,omitempty"`
+	SentAt         *time.Time         `bson:"sent_at,omitempty"`
+	CreatedAt      time.Time          `bson:"created_at"`
+	UpdatedAt      time.Time          `bson:"updated_at"`
+}
+
+func toDomainModel(mm *mongoModel) *Model {
+	return &Model{
+		ID:             mm.ID.Hex(),
+		OrgID:          mm.OrgID,
+		NotificationID: mm.NotificationID,
+		MonitorID:      mm.MonitorID,
+		Event:          mm.Event,
+		Message:        mm.Message,
+		Heartbeat:      decodeHeartbeat(mm.Heartbeat),
+		Status:         mm.Status,
+		Attempts:       mm.Attempts,
+		LastError:      mm.LastError,
+		ResponseCode:   mm.ResponseCode,
+		LastAttemptAt:  mm.LastAttemptAt,
+		SentAt:         mm.SentAt,
+		CreatedAt:      mm.CreatedAt,
+		UpdatedAt:      mm.UpdatedAt,
+	}
+}
+
+type MongoRepository struct {
+	client     *mongo.Client
+	db         *mongo.Database
+	collection *mongo.Collection
+}
+
+func NewMongoRepository(client *mongo.Client, cfg *config.Config) Repository {
+	db := client.Database(cfg.DBName)
+	collection := db.Collection("notification_deliveries")
+
+	// Create indexes
+	go func() {
+		_, _ = collection.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+			{Keys: bson.D{{Key: "org_id", Value: 1}, {Key: "created_at", Value: -1}}},
+			{Keys: bson.D{{Key: "org_id", Value: 1}, {Key: "status", Value: 1}}},
+			{Keys: bson.D{{Key: "notification_id", Value: 1}}},
+			{Keys: bson.D{{Key: "monitor_id", Value: 1}}},
+		})
+	}()
+
+	return &MongoRepository{
+		client:     client,
+		db:         db,
+		collection: collection,
+	}
+}
+
+func (r *MongoRepository) Create(ctx context.Context, delivery *Model) (*Model, error) {
+	now := time.Now().UTC()
+	mm := &mongoModel{
+		ID:             primitive.NewObjectID(),
+		OrgID:          delivery.OrgID,
+		NotificationID: delivery.NotificationID,
+		MonitorID:      delivery.MonitorID,
+		Event:          delivery.Event,
+		Message:        delivery.Message,
+		Heartbeat:      encodeHeartbeat(delivery.Heartbeat),
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		LastError:      delivery.LastError,
+		ResponseCode:   delivery.ResponseCode,
+		LastAttemptAt:  delivery.LastAttemptAt,
+		SentAt:         delivery.SentAt,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+
+	if _, err := r.collection.InsertOne(ctx, mm); err != nil {
+		return nil, err
+	}
+	return toDomainModel(mm), nil
+}
+
+func (r *MongoRepository) FindByID(ctx context.Context, id string, orgID string) (*Model, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := bson.M{"_id": objectID}
+	if orgID != "" {
+		filter["org_id"] = orgID
+	}
+
+	var mm mongoModel
+	err = r.collection.FindOne(ctx, filter).Decode(&mm)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toDomainModel(&mm), nil
+}
+
+func (r *MongoRepository) FindAll(ctx context.Context, page int, limit int, filter *FindFilter, orgID string) ([]*Model, error) {
+	skip := int64(page * limit)
+	limit64 := int64(limit)
+
+	opts := &options.FindOptions{
+		Skip:  &skip,
+		Limit: &limit64,
+		Sort:  bson.D{{Key: "created_at", Value: -1}},
+	}
+
+	query := bson.M{"org_id": orgID}
+	if filter != nil {
+		if filter.Status != "" {
+			query["status"] = filter.Status
+		}
+		if filter.NotificationID != "" {
+			query["notification_id"] = filter.NotificationID
+		}
+		if filter.MonitorID != "" {
+			query["monitor_id"] = filter.MonitorID
+		}
+	}
+
+	cursor, err := r.collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var mms []*mongoModel
+	if err := cursor.All(ctx, &mms); err != nil {
+		return nil, err
+	}
+
+	models := make([]*Model, 0, len(mms))
+	for _, mm := range mms {
+		models = append(models, toDomainModel(mm))
+	}
+	return models, nil
+}
+
+func (r *MongoRepository) UpdateStatus(ctx context.Context, delivery *Model) error {
+	objectID, err := primitive.ObjectIDFromHex(delivery.ID)
+	if err != nil {
+		return err
+	}
+
+	update := bson.M{"$set": bson.M{
+		"status":          delivery.Status,
+		"attempts":        delivery.Attempts,
+		"last_error":      delivery.LastError,
+		"response_code":   delivery.ResponseCode,
+		"last_attempt_at": delivery.LastAttemptAt,
+		"sent_at":         delivery.SentAt,
+		"updated_at":      time.Now().UTC(),
+	}}
+	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": objectID}, update)
+	return err
+}
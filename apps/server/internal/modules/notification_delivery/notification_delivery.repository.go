@@ -0,0 +1,11 @@
+package notification_delivery
+
+import "context"
+
+type Repository interface {
+	Create(ctx context.Context, delivery *Model) (*Model, error)
+	FindByID(ctx context.Context, id string, orgID string) (*Model, error)
+	FindAll(ctx context.Context, page int, limit int, filter *FindFilter, orgID string) ([]*Model, error)
+	// UpdateStatus persists the status, attempt count and outcome of the last attempt
+	UpdateStatus(ctx context.Context, delivery *Model) error
+}
@@ -0,0 +1,34 @@
+package notification_delivery
+
+import (
+	"vigi/internal/modules/middleware"
+	"vigi/internal/modules/organization"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Route struct {
+	controller    *Controller
+	middleware    *middleware.AuthChain
+	orgMiddleware *organization.Middleware
+}
+
+func NewRoute(controller *Controller, middleware *middleware.AuthChain, orgMiddleware *organization.Middleware) *Route {
+	return &Route{
+		controller:    controller,
+		middleware:    middleware,
+		orgMiddleware: orgMiddleware,
+	}
+}
+
+func (r *Route) ConnectRoute(rg *gin.RouterGroup, controller *Controller) {
+	router := rg.Group("notification-deliveries")
+
+	router.Use(r.middleware.AllAuth())
+	router.Use(r.orgMiddleware.RequireOrganization())
+	{
+		router.GET("", r.controller.FindAll)
+		router.GET("/:id", r.controller.FindByID)
+		router.POST("/:id/resend", r.controller.Resend)
+	}
+}
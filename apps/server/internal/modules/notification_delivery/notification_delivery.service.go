@@ -0,0 +1,145 @@
+package notification_delivery
+
+import (
+	"context"
+	"errors"
+	"time"
+	"vigi/internal/modules/notification_channel/providers"
+	"vigi/internal/modules/queue"
+
+	"go.uber.org/zap"
+)
+
+var ErrNotResendable = errors.New("only failed deliveries can be resent")
+
+const taskTimeout = time.Minute
+
+type Service interface {
+	// Dispatch records a pending delivery and enqueues it for sending
+	Dispatch(ctx context.Context, delivery *Model) (*Model, error)
+	FindByID(ctx context.Context, id string, orgID string) (*Model, error)
+	FindAll(ctx context.Context, page int, limit int, filter *FindFilter, orgID string) ([]*Model, error)
+	// Resend enqueues a failed delivery again
+	Resend(ctx context.Context, id string, orgID string) (*Model, error)
+	// RecordAttempt stores the outcome of a send attempt. A failed attempt leaves the
+	// delivery retrying unless it was the final one.
+	RecordAttempt(ctx context.Context, delivery *Model, sendErr error, final bool) error
+}
+
+type ServiceImpl struct {
+	repository   Repository
+	queueService queue.Service
+	logger       *zap.SugaredLogger
+}
+
+func NewService(
+	repository Repository,
+	queueService queue.Service,
+	logger *zap.SugaredLogger,
+) Service {
+	return &ServiceImpl{
+		repository:   repository,
+		queueService: queueService,
+		logger:       logger.Named("[notification-delivery-service]"),
+	}
+}
+
+func (s *ServiceImpl) Dispatch(ctx context.Context, delivery *Model) (*Model, error) {
+	delivery.Status = StatusPending
+	delivery.Attempts = 0
+
+	created, err := s.repository.Create(ctx, delivery)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.enqueue(ctx, created); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+func (s *ServiceImpl) FindByID(ctx context.Context, id string, orgID string) (*Model, error) {
+	return s.repository.FindByID(ctx, id, orgID)
+}
+
+func (s *ServiceImpl) FindAll(ctx context.Context, page int, limit int, filter *FindFilter, orgID string) ([]*Model, error) {
+	return s.repository.FindAll(ctx, page, limit, filter, orgID)
+}
+
+func (s *ServiceImpl) Resend(ctx context.Context, id string, orgID string) (*Model, error) {
+	delivery, err := s.repository.FindByID(ctx, id, orgID)
+	if err != nil || delivery == nil {
+		return nil, err
+	}
+	if delivery.Status != StatusFailed {
+		return nil, ErrNotResendable
+	}
+
+	// Attempts keep counting across resends, the log shows the whole history
+	delivery.Status = StatusPending
+	if err := s.repository.UpdateStatus(ctx, delivery); err != nil {
+		return nil, err
+	}
+
+	if err := s.enqueue(ctx, delivery); err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
+
+func (s *ServiceImpl) RecordAttempt(ctx context.Context, delivery *Model, sendErr error, final bool) error {
+	now := time.Now().UTC()
+	delivery.Attempts++
+	delivery.LastAttemptAt = &now
+
+	if sendErr == nil {
+		delivery.Status = StatusSent
+		delivery.SentAt = &now
+		delivery.LastError = ""
+		delivery.ResponseCode = nil
+	} else {
+		delivery.Status = StatusRetrying
+		if final {
+			delivery.Status = StatusFailed
+		}
+		delivery.LastError = sendErr.Error()
+		delivery.ResponseCode = responseCodeFromError(sendErr)
+	}
+
+	return s.repository.UpdateStatus(ctx, delivery)
+}
+
+func (s *ServiceImpl) enqueue(ctx context.Context, delivery *Model) error {
+	opts := &queue.EnqueueOptions{
+		Queue:     QueueName,
+		MaxRetry:  MaxRetry,
+		Timeout:   taskTimeout,
+		Retention: 24 * time.Hour,
+	}
+
+	_, err := s.queueService.Enqueue(ctx, TaskTypeSend, TaskPayload{DeliveryID: delivery.ID}, opts)
+	if err == nil {
+		return nil
+	}
+
+	// Without a task nothing would ever pick the delivery up again, surface it as failed
+	// so it shows up in the log and can be resent
+	s.logger.Errorw("Failed to enqueue notification delivery", "deliveryID", delivery.ID, "error", err)
+	delivery.Status = StatusFailed
+	delivery.LastError = err.Error()
+	if updateErr := s.repository.UpdateStatus(ctx, delivery); updateErr != nil {
+		s.logger.Errorw("Failed to mark notification delivery as failed", "deliveryID", delivery.ID, "error", updateErr)
+	}
+	return err
+}
+
+// responseCodeFromError returns the HTTP status code reported by a provider, if any
+func responseCodeFromError(err error) *int {
+	var statusErr *providers.StatusError
+	if !errors.As(err, &statusErr) {
+		return nil
+	}
+	code := statusErr.StatusCode
+	return &code
+}
@@ -0,0 +1,290 @@
+package notification_delivery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+	"vigi/internal/modules/notification_channel/providers"
+	"vigi/internal/modules/queue"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+// MockRepository
+type MockRepository struct {
+	mock.Mock
+}
+
+func (m *MockRepository) Create(ctx context.Context, delivery *Model) (*Model, error) {
+	args := m.Called(ctx, delivery)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Model), args.Error(1)
+}
+
+func (m *MockRepository) FindByID(ctx context.Context, id string, orgID string) (*Model, error) {
+	args := m.Called(ctx, id, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Model), args.Error(1)
+}
+
+func (m *MockRepository) FindAll(ctx context.Context, page int, limit int, filter *FindFilter, orgID string) ([]*Model, error) {
+	args := m.Called(ctx, page, limit, filter, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*Model), args.Error(1)
+}
+
+func (m *MockRepository) UpdateStatus(ctx context.Context, delivery *Model) error {
+	args := m.Called(ctx, delivery)
+	return args.Error(0)
+}
+
+// MockQueueService
+type MockQueueService struct {
+	mock.Mock
+}
+
+func (m *MockQueueService) Enqueue(ctx context.Context, taskType string, payload interface{}, opts *queue.EnqueueOptions) (*queue.TaskInfo, error) {
+	args := m.Called(ctx, taskType, payload, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*queue.TaskInfo), args.Error(1)
+}
+
+func (m *MockQueueService) EnqueueUnique(ctx context.Context, taskType string, payload interface{}, uniqueKey string, ttl time.Duration, opts *queue.EnqueueOptions) (*queue.TaskInfo, error) {
+	args := m.Called(ctx, taskType, payload, uniqueKey, ttl, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*queue.TaskInfo), args.Error(1)
+}
+
+func (m *MockQueueService) GetQueueInfo(ctx context.Context, queueName string) (*queue.QueueInfo, error) {
+	return nil, nil
+}
+
+func (m *MockQueueService) ListQueues(ctx context.Context) ([]*queue.QueueInfo, error) {
+	return nil, nil
+}
+
+func (m *MockQueueService) GetTaskInfo(ctx context.Context, queueName, taskID string) (*queue.TaskInfo, error) {
+	return nil, nil
+}
+
+func (m *MockQueueService) DeleteTask(ctx context.Context, queueName, taskID string) error {
+	return nil
+}
+
+func (m *MockQueueService) CancelTask(ctx context.Context, taskID string) error {
+	return nil
+}
+
+func (m *MockQueueService) PauseQueue(ctx context.Context, queueName string) error {
+	return nil
+}
+
+func (m *MockQueueService) UnpauseQueue(ctx context.Context, queueName string) error {
+	return nil
+}
+
+func (m *MockQueueService) ListPendingTasks(ctx context.Context, queueName string, pageSize, pageNum int) ([]*queue.TaskInfo, error) {
+	return nil, nil
+}
+
+func (m *MockQueueService) ListActiveTasks(ctx context.Context, queueName string, pageSize, pageNum int) ([]*queue.TaskInfo, error) {
+	return nil, nil
+}
+
+func (m *MockQueueService) ListScheduledTasks(ctx context.Context, queueName string, pageSize, pageNum int) ([]*queue.TaskInfo, error) {
+	return nil, nil
+}
+
+func (m *MockQueueService) Close() error {
+	return nil
+}
+
+func setupService() (*ServiceImpl, *MockRepository, *MockQueueService) {
+	repo := &MockRepository{}
+	queueService := &MockQueueService{}
+	service := NewService(repo, queueService, zap.NewNop().Sugar()).(*ServiceImpl)
+	return service, repo, queueService
+}
+
+func matchesQueueOptions(opts *queue.EnqueueOptions) bool {
+	return opts.Queue == QueueName && opts.MaxRetry == MaxRetry
+}
+
+func TestServiceImpl_Dispatch(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("records a pending delivery and enqueues it", func(t *testing.T) {
+		service, repo, queueService := setupService()
+
+		repo.On("Create", ctx, mock.MatchedBy(func(d *Model) bool {
+			return d.Status == StatusPending && d.Attempts == 0
+		})).Return(&Model{ID: "delivery-1", Status: StatusPending}, nil)
+		queueService.On("Enqueue", ctx, TaskTypeSend, TaskPayload{DeliveryID: "delivery-1"}, mock.MatchedBy(matchesQueueOptions)).
+			Return(&queue.TaskInfo{ID: "task-1"}, nil)
+
+		delivery, err := service.Dispatch(ctx, &Model{NotificationID: "channel-1", MonitorID: "monitor-1"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "delivery-1", delivery.ID)
+		repo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything)
+		queueService.AssertExpectations(t)
+	})
+
+	t.Run("marks the delivery as failed when it cannot be enqueued", func(t *testing.T) {
+		service, repo, queueService := setupService()
+
+		repo.On("Create", ctx, mock.Anything).Return(&Model{ID: "delivery-1", Status: StatusPending}, nil)
+		queueService.On("Enqueue", ctx, TaskTypeSend, mock.Anything, mock.Anything).Return(nil, errors.New("redis unavailable"))
+		repo.On("UpdateStatus", ctx, mock.MatchedBy(func(d *Model) bool {
+			return d.Status == StatusFailed && d.LastError == "redis unavailable"
+		})).Return(nil)
+
+		delivery, err := service.Dispatch(ctx, &Model{})
+
+		assert.Error(t, err)
+		assert.Nil(t, delivery)
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestServiceImpl_Resend(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("requeues a failed delivery", func(t *testing.T) {
+		service, repo, queueService := setupService()
+
+		repo.On("FindByID", ctx, "delivery-1", "org-1").Return(&Model{ID: "delivery-1", Status: StatusFailed, Attempts: 9}, nil)
+		repo.On("UpdateStatus", ctx, mock.MatchedBy(func(d *Model) bool {
+			return d.Status == StatusPending && d.Attempts == 9
+		})).Return(nil)
+		queueService.On("Enqueue", ctx, TaskTypeSend, TaskPayload{DeliveryID: "delivery-1"}, mock.Anything).
+			Return(&queue.TaskInfo{ID: "task-1"}, nil)
+
+		delivery, err := service.Resend(ctx, "delivery-1", "org-1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, StatusPending, delivery.Status)
+		repo.AssertExpectations(t)
+		queueService.AssertExpectations(t)
+	})
+
+	t.Run("rejects deliveries that have not failed", func(t *testing.T) {
+		service, repo, queueService := setupService()
+
+		repo.On("FindByID", ctx, "delivery-1", "org-1").Return(&Model{ID: "delivery-1", Status: StatusRetrying}, nil)
+
+		delivery, err := service.Resend(ctx, "delivery-1", "org-1")
+
+		assert.ErrorIs(t, err, ErrNotResendable)
+		assert.Nil(t, delivery)
+		queueService.AssertNotCalled(t, "Enqueue", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("returns nil when the delivery does not exist", func(t *testing.T) {
+		service, repo, _ := setupService()
+
+		repo.On("FindByID", ctx, "missing", "org-1").Return(nil, nil)
+
+		delivery, err := service.Resend(ctx, "missing", "org-1")
+
+		assert.NoError(t, err)
+		assert.Nil(t, delivery)
+	})
+}
+
+func TestServiceImpl_RecordAttempt(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("marks a successful attempt as sent", func(t *testing.T) {
+		service, repo, _ := setupService()
+		code := 500
+		delivery := &Model{ID: "delivery-1", Status: StatusRetrying, Attempts: 2, LastError: "boom", ResponseCode: &code}
+		repo.On("UpdateStatus", ctx, delivery).Return(nil)
+
+		err := service.RecordAttempt(ctx, delivery, nil, false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, StatusSent, delivery.Status)
+		assert.Equal(t, 3, delivery.Attempts)
+		assert.NotNil(t, delivery.SentAt)
+		assert.Empty(t, delivery.LastError)
+		assert.Nil(t, delivery.ResponseCode)
+	})
+
+	t.Run("keeps retrying after a failed attempt", func(t *testing.T) {
+		service, repo, _ := setupService()
+		delivery := &Model{ID: "delivery-1", Status: StatusPending}
+		repo.On("UpdateStatus", ctx, delivery).Return(nil)
+
+		err := service.RecordAttempt(ctx, delivery, &providers.StatusError{StatusCode: 503, Message: "Slack API returned status code: 503"}, false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, StatusRetrying, delivery.Status)
+		assert.Equal(t, 1, delivery.Attempts)
+		assert.Equal(t, "Slack API returned status code: 503", delivery.LastError)
+		if assert.NotNil(t, delivery.ResponseCode) {
+			assert.Equal(t, 503, *delivery.ResponseCode)
+		}
+	})
+
+	t.Run("marks the final failed attempt as failed", func(t *testing.T) {
+		service, repo, _ := setupService()
+		delivery := &Model{ID: "delivery-1", Status: StatusRetrying, Attempts: MaxRetry}
+		repo.On("UpdateStatus", ctx, delivery).Return(nil)
+
+		err := service.RecordAttempt(ctx, delivery, errors.New("dial tcp: connection refused"), true)
+
+		assert.NoError(t, err)
+		assert.Equal(t, StatusFailed, delivery.Status)
+		assert.Equal(t, MaxRetry+1, delivery.Attempts)
+		assert.Nil(t, delivery.ResponseCode)
+		assert.Nil(t, delivery.SentAt)
+	})
+}
+
+func TestResponseCodeFromError(t *testing.T) {
+	statusErr := &providers.StatusError{StatusCode: 429, Message: "PagerDuty notification failed with status code: 429"}
+
+	tests := []struct {
+		name     string
+		err      error
+		expected *int
+	}{
+		{"provider status", statusErr, intPtr(429)},
+		{"wrapped provider status", fmt.Errorf("send failed: %w", statusErr), intPtr(429)},
+		{"status only in the message", errors.New("Gotify request failed with status 502"), nil},
+		{"network error", errors.New("dial tcp 10.0.0.1:443: i/o timeout"), nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, responseCodeFromError(tt.err))
+		})
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	assert.GreaterOrEqual(t, RetryDelay(0), retryBaseDelay)
+	assert.Less(t, RetryDelay(0), 2*retryBaseDelay)
+	assert.GreaterOrEqual(t, RetryDelay(3), 8*retryBaseDelay)
+	assert.GreaterOrEqual(t, RetryDelay(50), retryMaxDelay)
+	assert.LessOrEqual(t, RetryDelay(50), retryMaxDelay+retryMaxDelay/5)
+}
+
+func intPtr(v int) *int {
+	return &v
+}
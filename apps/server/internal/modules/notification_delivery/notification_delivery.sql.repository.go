@@ -0,0 +1,159 @@
+package notification_delivery
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type sqlModel struct {
+	bun.BaseModel `bun:"table:notification_deliveries,alias:nd"`
+
+	ID             string     `bun:"id,pk"`
+	OrgID          string     `bun:"org_id"`
+	NotificationID string     `bun:"notification_id,notnull"`
+	MonitorID      string     `bun:"monitor_id,notnull"`
+	Event          string     `bun:"event,notnull"`
+	Message        string     `bun:"message,notnull"`
+	Heartbeat      *string    `bun:"heartbeat"`
+	Status         string     `bun:"status,notnull"`
+	Attempts       int        `bun:"attempts,notnull,default:0"`
+	LastError      *string    `bun:"last_error"`
+	ResponseCode   *int       `bun:"response_code"`
+	LastAttemptAt  *time.Time `bun:"last_attempt_at"`
+	SentAt         *time.Time `bun:"sent_at"`
+	CreatedAt      time.Time  `bun:"created_at,nullzero,notnull,default:current_timestamp"`
+	UpdatedAt      time.Time  `bun:"updated_at,nullzero,notnull,default:current_timestamp"`
+}
+
+func toDomainModelFromSQL(sm *sqlModel) *Model {
+	m := &Model{
+		ID:             sm.ID,
+		OrgID:          sm.OrgID,
+		NotificationID: sm.NotificationID,
+		MonitorID:      sm.MonitorID,
+		Event:          sm.Event,
+		Message:        sm.Message,
+		Heartbeat:      decodeHeartbeat(sm.Heartbeat),
+		Status:         sm.Status,
+		Attempts:       sm.Attempts,
+		ResponseCode:   sm.ResponseCode,
+		LastAttemptAt:  sm.LastAttemptAt,
+		SentAt:         sm.SentAt,
+		CreatedAt:      sm.CreatedAt,
+		UpdatedAt:      sm.UpdatedAt,
+	}
+	if sm.LastError != nil {
+		m.LastError = *sm.LastError
+	}
+	return m
+}
+
+func toSQLModel(m *Model) *sqlModel {
+	sm := &sqlModel{
+		ID:             m.ID,
+		OrgID:          m.OrgID,
+		NotificationID: m.NotificationID,
+		MonitorID:      m.MonitorID,
+		Event:          m.Event,
+		Message:        m.Message,
+		Heartbeat:      encodeHeartbeat(m.Heartbeat),
+		Status:         m.Status,
+		Attempts:       m.Attempts,
+		ResponseCode:   m.ResponseCode,
+		LastAttemptAt:  m.LastAttemptAt,
+		SentAt:         m.SentAt,
+		CreatedAt:      m.CreatedAt,
+		UpdatedAt:      m.UpdatedAt,
+	}
+	if m.LastError != "" {
+		sm.LastError = &m.LastError
+	}
+	return sm
+}
+
+type SQLRepositoryImpl struct {
+	db *bun.DB
+}
+
+func NewSQLRepository(db *bun.DB) Repository {
+	return &SQLRepositoryImpl{db: db}
+}
+
+func (r *SQLRepositoryImpl) Create(ctx context.Context, delivery *Model) (*Model, error) {
+	sm := toSQLModel(delivery)
+	sm.ID = uuid.New().String()
+	sm.CreatedAt = time.Now()
+	sm.UpdatedAt = time.Now()
+
+	if _, err := r.db.NewInsert().Model(sm).Exec(ctx); err != nil {
+		return nil, err
+	}
+	return toDomainModelFromSQL(sm), nil
+}
+
+func (r *SQLRepositoryImpl) FindByID(ctx context.Context, id string, orgID string) (*Model, error) {
+	sm := new(sqlModel)
+	query := r.db.NewSelect().Model(sm).Where("id = ?", id)
+	if orgID != "" {
+		query = query.Where("org_id = ?", orgID)
+	}
+
+	err := query.Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toDomainModelFromSQL(sm), nil
+}
+
+func (r *SQLRepositoryImpl) FindAll(ctx context.Context, page int, limit int, filter *FindFilter, orgID string) ([]*Model, error) {
+	query := r.db.NewSelect().Model((*sqlModel)(nil)).Where("org_id = ?", orgID)
+	if filter != nil {
+		if filter.Status != "" {
+			query = query.Where("status = ?", filter.Status)
+		}
+		if filter.NotificationID != "" {
+			query = query.Where("notification_id = ?", filter.NotificationID)
+		}
+		if filter.MonitorID != "" {
+			query = query.Where("monitor_id = ?", filter.MonitorID)
+		}
+	}
+
+	query = query.Order("created_at DESC").
+		Limit(limit).
+		Offset(page * limit)
+
+	var sms []*sqlModel
+	if err := query.Scan(ctx, &sms); err != nil {
+		return nil, err
+	}
+
+	models := make([]*Model, 0, len(sms))
+	for _, sm := range sms {
+		models = append(models, toDomainModelFromSQL(sm))
+	}
+	return models, nil
+}
+
+func (r *SQLRepositoryImpl) UpdateStatus(ctx context.Context, delivery *Model) error {
+	sm := toSQLModel(delivery)
+	_, err := r.db.NewUpdate().Model((*sqlModel)(nil)).
+		Set("status = ?", sm.Status).
+		Set("attempts = ?", sm.Attempts).
+		Set("last_error = ?", sm.LastError).
+		Set("response_code = ?", sm.ResponseCode).
+		Set("last_attempt_at = ?", sm.LastAttemptAt).
+		Set("sent_at = ?", sm.SentAt).
+		Set("updated_at = ?", time.Now()).
+		Where("id = ?", delivery.ID).
+		Exec(ctx)
+	return err
+}
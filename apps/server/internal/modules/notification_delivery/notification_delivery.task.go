@@ -0,0 +1,35 @@
+package notification_delivery
+
+import (
+	"math/rand"
+	"time"
+)
+
+const (
+	// TaskTypeSend is the task type for sending a notification delivery
+	TaskTypeSend = "notification:send"
+	// QueueName is the queue notification deliveries are processed from
+	QueueName = "notifications"
+	// MaxRetry is how many times a failed delivery is retried before it is marked as failed
+	MaxRetry = 8
+
+	retryBaseDelay = 10 * time.Second
+	retryMaxDelay  = 30 * time.Minute
+)
+
+// TaskPayload is the payload of notification send tasks
+type TaskPayload struct {
+	DeliveryID string `json:"delivery_id"`
+}
+
+// RetryDelay returns the exponential backoff before retry n of a delivery:
+// 10s, 20s, 40s... capped at 30 minutes, with up to 20% jitter so a provider
+// outage does not turn into a thundering herd when it recovers
+func RetryDelay(n int) time.Duration {
+	delay := retryMaxDelay
+	if n < 16 {
+		delay = min(retryBaseDelay<<n, retryMaxDelay)
+	}
+	jitter := time.Duration(rand.Int63n(int64(delay) / 5))
+	return delay + jitter
+}
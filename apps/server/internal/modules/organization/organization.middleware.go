@@ -68,3 +68,16 @@ func (m *Middleware) RequireOrganization() gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// RequireAdmin allows only organization admins. It must run after RequireOrganization.
+func (m *Middleware) RequireAdmin() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetString("orgRole") != string(RoleAdmin) {
+			c.JSON(http.StatusForbidden, utils.NewFailResponse("Organization admin role required"))
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -1,9 +1,12 @@
 package payment
 
 import (
+	"errors"
+	"io"
 	"net/http"
 
 	"vigi/internal/modules/middleware"
+	"vigi/internal/modules/organization"
 	"vigi/internal/utils"
 
 	"github.com/gin-gonic/gin"
@@ -23,16 +26,22 @@ func NewController(service *Service, logger *zap.SugaredLogger) *Controller {
 	}
 }
 
-func (c *Controller) RegisterRoutes(router *gin.RouterGroup, authChain *middleware.AuthChain) {
+func (c *Controller) RegisterRoutes(router *gin.RouterGroup, authChain *middleware.AuthChain, orgMiddleware *organization.Middleware) {
 	group := router.Group("/invoices")
 	group.Use(authChain.AllAuth())
+	group.Use(orgMiddleware.RequireOrganization())
 
 	// POST /api/v1/invoices/:id/charge
 	group.POST("/:id/charge", c.GenerateCharge)
+	group.POST("/:id/charge/sync", c.SyncCharge)
+	group.POST("/:id/charge/cancel", c.CancelCharge)
 
 	// Public Routes
 	router.GET("/public/invoices/:id", c.GetPublicInvoice)
 	router.POST("/public/invoices/:id/charge", c.GeneratePublicCharge)
+
+	// Provider webhooks, verified by each provider
+	router.POST("/payments/:provider/webhook/:organizationId", c.HandleWebhook)
 }
 
 // ... existing code ...
@@ -118,48 +127,124 @@ func (c *Controller) GenerateCharge(ctx *gin.Context) {
 		return
 	}
 
-	userID := ctx.GetString("userId")
-	if userID == "" {
-		// If middleware didn't set it, maybe look for other context vars or error out
-		// Depending on AuthChain implementation, it sets "userId" or "claims".
-		// Let's assume AuthChain sets "organizationId" if API Key is used?
-		// Or if JWT, it sets "userId" and we need to fetch user to get org?
-		// Wait, organizationId is what we need.
-		// Let's check if we can get organizationId from context directly if middleware sets it.
-		// If not, we need to inspect the token.
-		ctx.JSON(http.StatusUnauthorized, utils.NewFailResponse("unauthorized"))
+	orgID, err := uuid.Parse(ctx.GetString("orgId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.NewFailResponse("invalid organization id"))
 		return
 	}
 
-	// Assuming userID is what we have. But we need OrganizationID.
-	// If using API Key, OrganizationID might be set.
-	// If using JWT, we have UserID.
-
-	// Let's try to get OrganizationID from header if AuthChain allows it via OrgIdAuth?
-	// But AuthChain AllAuth() handles logic.
+	if err := c.service.GenerateCharge(ctx.Request.Context(), orgID, invoiceID); err != nil {
+		c.logger.Errorw("failed to generate charge", "error", err)
+		ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse(err.Error()))
+		return
+	}
 
-	// Let's fallback to looking at how other controllers get OrganizationID.
-	// We'll trust the header "X-Organization-ID" if using JWT?
-	// Or maybe the token has it?
+	ctx.JSON(http.StatusOK, utils.NewSuccessResponse[any]("Charge generated successfully", nil))
+}
 
-	// Temporary fix: Get OrganizationID from header as OrganizationController does in Swagger docs mentions OrgIdAuth.
-	orgIDStr := ctx.GetHeader("X-Organization-ID")
-	if orgIDStr == "" {
-		ctx.JSON(http.StatusBadRequest, utils.NewFailResponse("organization id header required"))
+// SyncCharge godoc
+// @Summary Sync the charge of an invoice
+// @Description Fetches the charge status from the bank provider and applies it to the invoice
+// @Tags Payment
+// @Produce json
+// @Param id path string true "Invoice ID"
+// @Success 200 {object} utils.ApiResponse[invoice.Invoice]
+// @Failure 400 {object} utils.Response "Bad Request"
+// @Failure 500 {object} utils.Response "Internal Server Error"
+// @Router /invoices/{id}/charge/sync [post]
+func (c *Controller) SyncCharge(ctx *gin.Context) {
+	orgID, invoiceID, ok := c.parseInvoiceRequest(ctx)
+	if !ok {
 		return
 	}
 
-	orgID, err := uuid.Parse(orgIDStr)
+	entity, err := c.service.SyncCharge(ctx.Request.Context(), orgID, invoiceID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, utils.NewFailResponse("invalid organization id"))
+		c.logger.Errorw("failed to sync charge", "invoice_id", invoiceID, "error", err)
+		ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse(err.Error()))
 		return
 	}
 
-	if err := c.service.GenerateCharge(ctx.Request.Context(), orgID, invoiceID); err != nil {
-		c.logger.Errorw("failed to generate charge", "error", err)
+	ctx.JSON(http.StatusOK, utils.NewSuccessResponse("Charge synced successfully", entity))
+}
+
+// CancelCharge godoc
+// @Summary Cancel the charge of an invoice
+// @Description Cancels the charge at the bank provider. The invoice keeps its status.
+// @Tags Payment
+// @Produce json
+// @Param id path string true "Invoice ID"
+// @Success 200 {object} utils.ApiResponse[invoice.Invoice]
+// @Failure 400 {object} utils.Response "Bad Request"
+// @Failure 500 {object} utils.Response "Internal Server Error"
+// @Router /invoices/{id}/charge/cancel [post]
+func (c *Controller) CancelCharge(ctx *gin.Context) {
+	orgID, invoiceID, ok := c.parseInvoiceRequest(ctx)
+	if !ok {
+		return
+	}
+
+	entity, err := c.service.CancelCharge(ctx.Request.Context(), orgID, invoiceID)
+	if err != nil {
+		c.logger.Errorw("failed to cancel charge", "invoice_id", invoiceID, "error", err)
 		ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse(err.Error()))
 		return
 	}
 
-	ctx.JSON(http.StatusOK, utils.NewSuccessResponse[any]("Charge generated successfully", nil))
+	ctx.JSON(http.StatusOK, utils.NewSuccessResponse("Charge cancelled successfully", entity))
+}
+
+func (c *Controller) HandleWebhook(ctx *gin.Context) {
+	orgID, err := uuid.Parse(ctx.Param("organizationId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.NewFailResponse("Invalid organization ID"))
+		return
+	}
+
+	body, err := io.ReadAll(ctx.Request.Body)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.NewFailResponse("failed to read body"))
+		return
+	}
+
+	if len(body) == 0 {
+		ctx.JSON(http.StatusBadRequest, utils.NewFailResponse("empty body"))
+		return
+	}
+
+	provider := ctx.Param("provider")
+	if err := c.service.HandleWebhook(ctx.Request.Context(), provider, orgID, ctx.Request.Header, body); err != nil {
+		switch {
+		case errors.Is(err, ErrUnknownProvider):
+			ctx.JSON(http.StatusNotFound, utils.NewFailResponse("Unknown provider"))
+		case errors.Is(err, ErrUnauthorizedWebhook):
+			c.logger.Warnw("Rejected webhook", "provider", provider, "organization_id", orgID, "error", err)
+			ctx.JSON(http.StatusUnauthorized, utils.NewFailResponse("Unauthorized"))
+		default:
+			// 500 makes the provider retry the delivery
+			c.logger.Errorw("Failed to handle webhook", "provider", provider, "error", err)
+			ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse(err.Error()))
+		}
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
+
+// parseInvoiceRequest reads the invoice ID path param and the organization checked by
+// RequireOrganization, writing the error response when either is invalid
+func (c *Controller) parseInvoiceRequest(ctx *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	invoiceID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.NewFailResponse("Invalid ID"))
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	orgID, err := uuid.Parse(ctx.GetString("orgId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.NewFailResponse("invalid organization id"))
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return orgID, invoiceID, true
 }
@@ -0,0 +1,90 @@
+package payment
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"vigi/internal/modules/invoice"
+
+	"github.com/google/uuid"
+)
+
+// FakeProviderTokenHeader carries FakeProvider.Token on webhook calls
+const FakeProviderTokenHeader = "X-Webhook-Token"
+
+// FakeProvider is an in-memory PaymentProvider for tests. Its webhook payloads are
+// JSON encoded lists of ChargeEvent.
+type FakeProvider struct {
+	// Token, when set, must be sent in the FakeProviderTokenHeader of webhook calls
+	Token string
+
+	mu      sync.Mutex
+	charges map[string]*Charge
+	nextID  int
+}
+
+func NewFakeProvider() *FakeProvider {
+	return &FakeProvider{charges: make(map[string]*Charge)}
+}
+
+func (p *FakeProvider) CreateCharge(ctx context.Context, organizationID uuid.UUID, inv *invoice.Invoice) (*Charge, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.nextID++
+	charge := &Charge{
+		ID:         fmt.Sprintf("fake_%d", p.nextID),
+		Status:     ChargeStatusPending,
+		BankStatus: string(ChargeStatusPending),
+		PixPayload: "fake-pix-" + inv.Number,
+	}
+	p.charges[charge.ID] = charge
+
+	copied := *charge
+	return &copied, nil
+}
+
+func (p *FakeProvider) CancelCharge(ctx context.Context, organizationID uuid.UUID, chargeID string) error {
+	return p.SetStatus(chargeID, ChargeStatusCancelled)
+}
+
+func (p *FakeProvider) FetchStatus(ctx context.Context, organizationID uuid.UUID, chargeID string) (*Charge, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	charge, ok := p.charges[chargeID]
+	if !ok {
+		return nil, fmt.Errorf("charge %s not found", chargeID)
+	}
+	copied := *charge
+	return &copied, nil
+}
+
+func (p *FakeProvider) ParseWebhook(ctx context.Context, organizationID uuid.UUID, header http.Header, payload []byte) ([]ChargeEvent, error) {
+	if p.Token != "" && header.Get(FakeProviderTokenHeader) != p.Token {
+		return nil, ErrUnauthorizedWebhook
+	}
+
+	var events []ChargeEvent
+	if err := json.Unmarshal(payload, &events); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidWebhookPayload, err)
+	}
+	return events, nil
+}
+
+// SetStatus changes a charge as if it had changed at the gateway
+func (p *FakeProvider) SetStatus(chargeID string, status ChargeStatus) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	charge, ok := p.charges[chargeID]
+	if !ok {
+		return fmt.Errorf("charge %s not found", chargeID)
+	}
+	charge.Status = status
+	charge.BankStatus = string(status)
+	return nil
+}
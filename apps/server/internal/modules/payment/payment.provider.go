@@ -0,0 +1,54 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"vigi/internal/modules/invoice"
+
+	"github.com/google/uuid"
+)
+
+// ErrUnauthorizedWebhook is returned by ParseWebhook when the call could not be verified
+// as coming from the provider
+var ErrUnauthorizedWebhook = errors.New("unauthorized webhook")
+
+// ChargeStatus is the provider independent state of a charge
+type ChargeStatus string
+
+const (
+	ChargeStatusPending   ChargeStatus = "PENDING"
+	ChargeStatusPaid      ChargeStatus = "PAID"
+	ChargeStatusOverdue   ChargeStatus = "OVERDUE"
+	ChargeStatusRefunded  ChargeStatus = "REFUNDED"
+	ChargeStatusCancelled ChargeStatus = "CANCELLED"
+)
+
+// Charge is a charge as known by a payment provider
+type Charge struct {
+	ID                  string
+	Status              ChargeStatus
+	BankStatus          string // provider status as-is, stored on the invoice
+	PixPayload          string
+	BoletoBarcode       string
+	BoletoDigitableLine string
+}
+
+// ChargeEvent is a charge status change reported by a provider webhook
+type ChargeEvent struct {
+	ID         string // provider event ID, used to ignore redeliveries
+	ChargeID   string
+	Reference  string // invoice ID sent along with the charge, when echoed back by the provider
+	Status     ChargeStatus
+	BankStatus string
+}
+
+// PaymentProvider is implemented by each payment gateway and registered under the
+// name stored in Organization.BankProvider
+type PaymentProvider interface {
+	CreateCharge(ctx context.Context, organizationID uuid.UUID, inv *invoice.Invoice) (*Charge, error)
+	CancelCharge(ctx context.Context, organizationID uuid.UUID, chargeID string) error
+	FetchStatus(ctx context.Context, organizationID uuid.UUID, chargeID string) (*Charge, error)
+	ParseWebhook(ctx context.Context, organizationID uuid.UUID, header http.Header, payload []byte) ([]ChargeEvent, error)
+}
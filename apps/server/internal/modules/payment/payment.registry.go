@@ -0,0 +1,12 @@
+package payment
+
+var PaymentProviderRegistry = make(map[string]PaymentProvider)
+
+func RegisterPaymentProvider(name string, provider PaymentProvider) {
+	PaymentProviderRegistry[name] = provider
+}
+
+func GetPaymentProvider(name string) (PaymentProvider, bool) {
+	p, ok := PaymentProviderRegistry[name]
+	return p, ok
+}
@@ -2,10 +2,11 @@ package payment
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"net/http"
 
-	"vigi/internal/modules/asaas"
-	"vigi/internal/modules/inter"
 	"vigi/internal/modules/invoice"
 	"vigi/internal/modules/organization"
 
@@ -13,26 +14,37 @@ import (
 	"go.uber.org/zap"
 )
 
+var (
+	ErrUnknownProvider = errors.New("unknown payment provider")
+	// ErrInvalidWebhookPayload is wrapped by providers when a webhook payload cannot be parsed.
+	// Such payloads are stored and acknowledged, since retrying them would not help.
+	ErrInvalidWebhookPayload = errors.New("invalid webhook payload")
+)
+
+// InvoiceService is the subset of invoice.Service used to record charges on invoices
+type InvoiceService interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*invoice.Invoice, error)
+	GetByBankID(ctx context.Context, bankID string) (*invoice.Invoice, error)
+	Update(ctx context.Context, id uuid.UUID, dto invoice.UpdateInvoiceDTO) (*invoice.Invoice, error)
+}
+
 type Service struct {
 	orgRepo        organization.OrganizationRepository
-	invoiceService *invoice.Service
-	interService   *inter.Service
-	asaasService   *asaas.Service
+	invoiceService InvoiceService
+	webhookRepo    WebhookRepository
 	logger         *zap.SugaredLogger
 }
 
 func NewService(
 	orgRepo organization.OrganizationRepository,
-	invoiceService *invoice.Service,
-	interService *inter.Service,
-	asaasService *asaas.Service,
+	invoiceService InvoiceService,
+	webhookRepo WebhookRepository,
 	logger *zap.SugaredLogger,
 ) *Service {
 	return &Service{
 		orgRepo:        orgRepo,
 		invoiceService: invoiceService,
-		interService:   interService,
-		asaasService:   asaasService,
+		webhookRepo:    webhookRepo,
 		logger:         logger.Named("[payment-service]"),
 	}
 }
@@ -52,47 +64,43 @@ func (s *Service) GenerateCharge(ctx context.Context, orgID uuid.UUID, invoiceID
 		return fmt.Errorf("organization not found")
 	}
 
-	provider := ""
+	providerName := ""
 	if org.BankProvider != nil {
-		provider = *org.BankProvider
+		providerName = *org.BankProvider
 	}
 
-	if provider == "" {
+	if providerName == "" {
 		return fmt.Errorf("bank provider not configured for organization")
 	}
 
-	s.logger.Infow("Generating charge", "invoice_id", invoiceID, "provider", provider)
+	provider, ok := GetPaymentProvider(providerName)
+	if !ok {
+		return fmt.Errorf("unsupported bank provider: %s", providerName)
+	}
 
-	// 2. Dispatch to provider service
-	switch provider {
-	case "inter":
-		dto := inter.GenerateChargeDTO{InvoiceID: invoiceID}
-		if err := s.interService.CreateCharge(ctx, orgID, dto); err != nil {
-			return err
-		}
-	case "asaas":
-		dto := asaas.GenerateChargeDTO{InvoiceID: invoiceID}
-		if err := s.asaasService.CreateCharge(ctx, orgID, dto); err != nil {
-			return err
-		}
-	default:
-		return fmt.Errorf("unsupported bank provider: %s", provider)
+	inv, err := s.getOrganizationInvoice(ctx, orgID, invUUID)
+	if err != nil {
+		return err
 	}
 
-	// 3. Update Invoice with BankProvider
-	// Note: The specific services (inter/asaas) already update the invoice with bank_id and status.
-	// We just need to add the provider name.
-	// We can update it now.
+	s.logger.Infow("Generating charge", "invoice_id", invoiceID, "provider", providerName)
+
+	// 2. Create the charge at the provider
+	charge, err := provider.CreateCharge(ctx, orgID, inv)
+	if err != nil {
+		return err
+	}
 
+	// 3. Record it on the invoice
 	updateDto := invoice.UpdateInvoiceDTO{
-		BankProvider: &provider,
+		BankInvoiceID:     &charge.ID,
+		BankInvoiceStatus: &charge.BankStatus,
+		BankProvider:      &providerName,
 	}
-	// Note: UpdateInvoiceDTO needs to have BankProvider field added first!
-	// I'll check/add it.
+	setChargeDetails(&updateDto, charge)
 
 	if _, err := s.invoiceService.Update(ctx, invUUID, updateDto); err != nil {
-		s.logger.Errorw("Failed to update invoice bank provider", "error", err)
-		// Don't fail the whole request since charge was generated
+		return fmt.Errorf("failed to update invoice with bank info: %w", err)
 	}
 
 	return nil
@@ -129,22 +137,269 @@ func (s *Service) GetPublicInvoice(ctx context.Context, id uuid.UUID) (*invoice.
 	// NOTE: We REMOVED auto-generation on load to allow user to click manual button as requested.
 	// But we keep the sync logic if the charge exists but data is missing.
 
+	if inv.BankInvoiceID != nil && inv.BankPixPayload == nil {
+		if updatedInv, err := s.syncCharge(ctx, inv); err != nil {
+			s.logger.Warnw("Sync of charge failed", "invoice_id", id, "error", err)
+		} else {
+			inv = updatedInv
+		}
+	}
+
+	return inv, nil
+}
+
+// SyncCharge fetches the charge of an invoice from its provider and applies its status,
+// for when a webhook was missed
+func (s *Service) SyncCharge(ctx context.Context, orgID uuid.UUID, invoiceID uuid.UUID) (*invoice.Invoice, error) {
+	inv, err := s.getOrganizationInvoice(ctx, orgID, invoiceID)
+	if err != nil {
+		return nil, err
+	}
+	return s.syncCharge(ctx, inv)
+}
+
+// CancelCharge cancels the charge of an unpaid invoice at its provider. The invoice itself
+// keeps its status, so a new charge can be generated for it.
+func (s *Service) CancelCharge(ctx context.Context, orgID uuid.UUID, invoiceID uuid.UUID) (*invoice.Invoice, error) {
+	inv, err := s.getOrganizationInvoice(ctx, orgID, invoiceID)
+	if err != nil {
+		return nil, err
+	}
+	if inv.Status == invoice.InvoiceStatusPaid {
+		return nil, fmt.Errorf("cannot cancel the charge of a paid invoice")
+	}
+
+	provider, err := s.invoiceProvider(inv)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := provider.CancelCharge(ctx, orgID, *inv.BankInvoiceID); err != nil {
+		return nil, fmt.Errorf("failed to cancel charge: %w", err)
+	}
+
+	bankStatus := string(ChargeStatusCancelled)
+	updated, err := s.invoiceService.Update(ctx, inv.ID, invoice.UpdateInvoiceDTO{BankInvoiceStatus: &bankStatus})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update invoice: %w", err)
+	}
+	return updated, nil
+}
+
+// HandleWebhook verifies and applies a webhook sent by a provider for an organization.
+// Events are stored by their provider ID, so redeliveries are acknowledged without being applied twice.
+func (s *Service) HandleWebhook(ctx context.Context, providerName string, orgID uuid.UUID, header http.Header, payload []byte) error {
+	provider, ok := GetPaymentProvider(providerName)
+	if !ok {
+		return ErrUnknownProvider
+	}
+
+	events, err := provider.ParseWebhook(ctx, orgID, header, payload)
+	if err != nil {
+		if !errors.Is(err, ErrInvalidWebhookPayload) {
+			return err
+		}
+
+		errMsg := err.Error()
+		event := &WebhookEvent{
+			ID:       uuid.New(),
+			Provider: providerName,
+			Payload:  string(payload),
+			Error:    &errMsg,
+		}
+		if _, err := s.webhookRepo.Create(ctx, event); err != nil {
+			return fmt.Errorf("failed to log webhook event: %w", err)
+		}
+		return nil // Return nil to avoid the provider retrying invalid payload
+	}
+
+	for _, event := range events {
+		if err := s.handleChargeEvent(ctx, providerName, orgID, event, payload); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) handleChargeEvent(ctx context.Context, providerName string, orgID uuid.UUID, event ChargeEvent, payload []byte) error {
+	externalID := event.ID
+	if externalID == "" {
+		externalID = event.ChargeID + ":" + event.BankStatus
+	}
+
+	record := &WebhookEvent{
+		ID:         uuid.New(),
+		Provider:   providerName,
+		ExternalID: &externalID,
+		Payload:    string(payload),
+		Processed:  false,
+	}
+
+	created, err := s.webhookRepo.Create(ctx, record)
+	if err != nil {
+		return fmt.Errorf("failed to log webhook event: %w", err)
+	}
+	if !created {
+		existing, err := s.webhookRepo.GetByExternalID(ctx, providerName, externalID)
+		if err != nil {
+			return fmt.Errorf("failed to get webhook event: %w", err)
+		}
+		if existing == nil || existing.Processed {
+			s.logger.Infow("Ignoring duplicate webhook event", "provider", providerName, "event_id", externalID)
+			return nil
+		}
+		// A previous delivery failed before finishing, process it again
+		record = existing
+		record.Error = nil
+	}
+
+	if err := s.applyChargeEvent(ctx, orgID, event, record); err != nil {
+		errMsg := err.Error()
+		record.Error = &errMsg
+		_ = s.webhookRepo.Update(ctx, record)
+		return err
+	}
+
+	record.Processed = true
+	if err := s.webhookRepo.Update(ctx, record); err != nil {
+		return fmt.Errorf("failed to update event status: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) applyChargeEvent(ctx context.Context, orgID uuid.UUID, event ChargeEvent, record *WebhookEvent) error {
+	inv, err := s.findChargeInvoice(ctx, event)
+	if err != nil {
+		return fmt.Errorf("failed to get invoice: %w", err)
+	}
+	if inv == nil {
+		s.logger.Warnw("No invoice found for charge", "provider", record.Provider, "charge_id", event.ChargeID)
+		return nil
+	}
+	if inv.OrganizationID != orgID {
+		errMsg := fmt.Sprintf("invoice %s does not belong to organization", inv.ID)
+		record.Error = &errMsg
+		return nil
+	}
+
+	record.ResourceID = &inv.ID
+	charge := &Charge{
+		ID:         event.ChargeID,
+		Status:     event.Status,
+		BankStatus: event.BankStatus,
+	}
+	if _, err := s.applyCharge(ctx, inv, charge); err != nil {
+		return fmt.Errorf("failed to update invoice %s: %w", inv.ID, err)
+	}
+	return nil
+}
+
+// findChargeInvoice finds the invoice a charge was created for. The invoice ID reference
+// covers invoices whose bank ID could not be saved after charging.
+func (s *Service) findChargeInvoice(ctx context.Context, event ChargeEvent) (*invoice.Invoice, error) {
+	if event.ChargeID != "" {
+		inv, err := s.invoiceService.GetByBankID(ctx, event.ChargeID)
+		if err == nil {
+			return inv, nil
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+	}
+
+	invoiceID, err := uuid.Parse(event.Reference)
+	if err != nil {
+		return nil, nil
+	}
+	inv, err := s.invoiceService.GetByID(ctx, invoiceID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	// The invoice was charged again since, this charge is no longer the one that counts
 	if inv.BankInvoiceID != nil {
-		// Sync charge details if missing (specifically for Inter)
-		isInter := inv.BankProvider != nil && *inv.BankProvider == "inter"
-		missingDetails := inv.BankPixPayload == nil || inv.BankBoletoBarcode == nil
-
-		if isInter && missingDetails {
-			if err := s.interService.SyncCharge(ctx, inv.OrganizationID, inv.ID); err != nil {
-				s.logger.Warnw("Sync of charge failed", "invoice_id", id, "error", err)
-			} else {
-				// Reload invoice
-				if updatedInv, err := s.invoiceService.GetByID(ctx, id); err == nil {
-					inv = updatedInv
-				}
-			}
+		return nil, nil
+	}
+	return inv, nil
+}
+
+func (s *Service) syncCharge(ctx context.Context, inv *invoice.Invoice) (*invoice.Invoice, error) {
+	provider, err := s.invoiceProvider(inv)
+	if err != nil {
+		return nil, err
+	}
+
+	charge, err := provider.FetchStatus(ctx, inv.OrganizationID, *inv.BankInvoiceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch charge: %w", err)
+	}
+
+	updated, err := s.applyCharge(ctx, inv, charge)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update invoice details: %w", err)
+	}
+	return updated, nil
+}
+
+// applyCharge records the charge status and payment details on the invoice
+func (s *Service) applyCharge(ctx context.Context, inv *invoice.Invoice, charge *Charge) (*invoice.Invoice, error) {
+	updateDto := invoice.UpdateInvoiceDTO{}
+	setChargeDetails(&updateDto, charge)
+
+	if inv.BankInvoiceID == nil && charge.ID != "" {
+		updateDto.BankInvoiceID = &charge.ID
+	}
+
+	if status, apply := invoiceStatusForCharge(inv.Status, charge.Status); apply {
+		updateDto.Status = status
+		if charge.BankStatus != "" {
+			updateDto.BankInvoiceStatus = &charge.BankStatus
 		}
 	}
 
+	return s.invoiceService.Update(ctx, inv.ID, updateDto)
+}
+
+func setChargeDetails(dto *invoice.UpdateInvoiceDTO, charge *Charge) {
+	if charge.PixPayload != "" {
+		dto.BankPixPayload = &charge.PixPayload
+	}
+	if charge.BoletoBarcode != "" {
+		dto.BankBoletoBarcode = &charge.BoletoBarcode
+	}
+	if charge.BoletoDigitableLine != "" {
+		dto.BankBoletoDigitableLine = &charge.BoletoDigitableLine
+	}
+}
+
+func (s *Service) invoiceProvider(inv *invoice.Invoice) (PaymentProvider, error) {
+	if inv.BankInvoiceID == nil {
+		return nil, fmt.Errorf("invoice has no bank invoice id")
+	}
+	if inv.BankProvider == nil || *inv.BankProvider == "" {
+		return nil, fmt.Errorf("invoice has no bank provider")
+	}
+	provider, ok := GetPaymentProvider(*inv.BankProvider)
+	if !ok {
+		return nil, fmt.Errorf("unsupported bank provider: %s", *inv.BankProvider)
+	}
+	return provider, nil
+}
+
+func (s *Service) getOrganizationInvoice(ctx context.Context, orgID uuid.UUID, invoiceID uuid.UUID) (*invoice.Invoice, error) {
+	inv, err := s.invoiceService.GetByID(ctx, invoiceID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("invoice not found")
+		}
+		return nil, fmt.Errorf("failed to get invoice: %w", err)
+	}
+	if inv.OrganizationID != orgID {
+		return nil, fmt.Errorf("invoice does not belong to organization")
+	}
 	return inv, nil
 }
@@ -0,0 +1,278 @@
+package payment
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"vigi/internal/modules/invoice"
+	"vigi/internal/modules/organization"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const fakeProviderName = "fake"
+
+type fakeOrganizationRepository struct {
+	organization.OrganizationRepository
+	orgs map[string]*organization.Organization
+}
+
+func (r *fakeOrganizationRepository) FindByID(ctx context.Context, id string) (*organization.Organization, error) {
+	return r.orgs[id], nil
+}
+
+type fakeInvoiceService struct {
+	invoices map[uuid.UUID]*invoice.Invoice
+}
+
+func (f *fakeInvoiceService) GetByID(ctx context.Context, id uuid.UUID) (*invoice.Invoice, error) {
+	inv, ok := f.invoices[id]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return inv, nil
+}
+
+func (f *fakeInvoiceService) GetByBankID(ctx context.Context, bankID string) (*invoice.Invoice, error) {
+	for _, inv := range f.invoices {
+		if inv.BankInvoiceID != nil && *inv.BankInvoiceID == bankID {
+			return inv, nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+func (f *fakeInvoiceService) Update(ctx context.Context, id uuid.UUID, dto invoice.UpdateInvoiceDTO) (*invoice.Invoice, error) {
+	inv, ok := f.invoices[id]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	if dto.Status != nil {
+		inv.Status = *dto.Status
+	}
+	if dto.BankInvoiceID != nil {
+		inv.BankInvoiceID = dto.BankInvoiceID
+	}
+	if dto.BankInvoiceStatus != nil {
+		inv.BankInvoiceStatus = dto.BankInvoiceStatus
+	}
+	if dto.BankProvider != nil {
+		inv.BankProvider = dto.BankProvider
+	}
+	if dto.BankPixPayload != nil {
+		inv.BankPixPayload = dto.BankPixPayload
+	}
+	return inv, nil
+}
+
+type fakeWebhookRepository struct {
+	events map[string]*WebhookEvent
+}
+
+func (r *fakeWebhookRepository) Create(ctx context.Context, event *WebhookEvent) (bool, error) {
+	if event.ExternalID == nil {
+		return true, nil
+	}
+	key := event.Provider + "/" + *event.ExternalID
+	if _, ok := r.events[key]; ok {
+		return false, nil
+	}
+	r.events[key] = event
+	return true, nil
+}
+
+func (r *fakeWebhookRepository) Update(ctx context.Context, event *WebhookEvent) error {
+	return nil
+}
+
+func (r *fakeWebhookRepository) GetByExternalID(ctx context.Context, provider, externalID string) (*WebhookEvent, error) {
+	return r.events[provider+"/"+externalID], nil
+}
+
+type testEnv struct {
+	service  *Service
+	provider *FakeProvider
+	invoices *fakeInvoiceService
+	webhooks *fakeWebhookRepository
+	orgID    uuid.UUID
+	invoice  *invoice.Invoice
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	t.Helper()
+
+	provider := NewFakeProvider()
+	RegisterPaymentProvider(fakeProviderName, provider)
+	t.Cleanup(func() { delete(PaymentProviderRegistry, fakeProviderName) })
+
+	orgID := uuid.New()
+	providerName := fakeProviderName
+	orgs := &fakeOrganizationRepository{orgs: map[string]*organization.Organization{
+		orgID.String(): {ID: orgID.String(), BankProvider: &providerName},
+	}}
+
+	inv := &invoice.Invoice{ID: uuid.New(), OrganizationID: orgID, Number: "INV-1", Status: invoice.InvoiceStatusSent}
+	invoices := &fakeInvoiceService{invoices: map[uuid.UUID]*invoice.Invoice{inv.ID: inv}}
+	webhooks := &fakeWebhookRepository{events: make(map[string]*WebhookEvent)}
+
+	return &testEnv{
+		service:  NewService(orgs, invoices, webhooks, zap.NewNop().Sugar()),
+		provider: provider,
+		invoices: invoices,
+		webhooks: webhooks,
+		orgID:    orgID,
+		invoice:  inv,
+	}
+}
+
+func webhookPayload(t *testing.T, events ...ChargeEvent) []byte {
+	t.Helper()
+	payload, err := json.Marshal(events)
+	require.NoError(t, err)
+	return payload
+}
+
+func TestService_GenerateCharge(t *testing.T) {
+	env := newTestEnv(t)
+
+	err := env.service.GenerateCharge(context.Background(), env.orgID, env.invoice.ID.String())
+	require.NoError(t, err)
+
+	require.NotNil(t, env.invoice.BankInvoiceID)
+	assert.Equal(t, fakeProviderName, *env.invoice.BankProvider)
+	assert.Equal(t, string(ChargeStatusPending), *env.invoice.BankInvoiceStatus)
+	assert.Equal(t, "fake-pix-INV-1", *env.invoice.BankPixPayload)
+}
+
+func TestService_GenerateCharge_UnsupportedProvider(t *testing.T) {
+	env := newTestEnv(t)
+	delete(PaymentProviderRegistry, fakeProviderName)
+
+	err := env.service.GenerateCharge(context.Background(), env.orgID, env.invoice.ID.String())
+	assert.ErrorContains(t, err, "unsupported bank provider")
+	assert.Nil(t, env.invoice.BankInvoiceID)
+}
+
+func TestService_HandleWebhook(t *testing.T) {
+	env := newTestEnv(t)
+	env.provider.Token = "secret"
+	ctx := context.Background()
+	require.NoError(t, env.service.GenerateCharge(ctx, env.orgID, env.invoice.ID.String()))
+
+	header := http.Header{}
+	header.Set(FakeProviderTokenHeader, "secret")
+	payload := webhookPayload(t, ChargeEvent{ID: "evt_1", ChargeID: *env.invoice.BankInvoiceID, Status: ChargeStatusPaid, BankStatus: "RECEIVED"})
+
+	t.Run("rejects unverified calls", func(t *testing.T) {
+		err := env.service.HandleWebhook(ctx, fakeProviderName, env.orgID, http.Header{}, payload)
+		assert.ErrorIs(t, err, ErrUnauthorizedWebhook)
+		assert.Equal(t, invoice.InvoiceStatusSent, env.invoice.Status)
+	})
+
+	t.Run("applies the charge status", func(t *testing.T) {
+		require.NoError(t, env.service.HandleWebhook(ctx, fakeProviderName, env.orgID, header, payload))
+		assert.Equal(t, invoice.InvoiceStatusPaid, env.invoice.Status)
+		assert.Equal(t, "RECEIVED", *env.invoice.BankInvoiceStatus)
+
+		event := env.webhooks.events[fakeProviderName+"/evt_1"]
+		require.NotNil(t, event)
+		assert.True(t, event.Processed)
+		assert.Equal(t, env.invoice.ID, *event.ResourceID)
+	})
+
+	t.Run("ignores redelivered events", func(t *testing.T) {
+		env.invoice.Status = invoice.InvoiceStatusSent
+		require.NoError(t, env.service.HandleWebhook(ctx, fakeProviderName, env.orgID, header, payload))
+		assert.Equal(t, invoice.InvoiceStatusSent, env.invoice.Status)
+	})
+
+	t.Run("ignores invoices of other organizations", func(t *testing.T) {
+		otherOrg := uuid.New()
+		payload := webhookPayload(t, ChargeEvent{ID: "evt_2", ChargeID: *env.invoice.BankInvoiceID, Status: ChargeStatusRefunded})
+		require.NoError(t, env.service.HandleWebhook(ctx, fakeProviderName, otherOrg, header, payload))
+		assert.Equal(t, invoice.InvoiceStatusSent, env.invoice.Status)
+		assert.NotNil(t, env.webhooks.events[fakeProviderName+"/evt_2"].Error)
+	})
+
+	t.Run("acknowledges invalid payloads", func(t *testing.T) {
+		assert.NoError(t, env.service.HandleWebhook(ctx, fakeProviderName, env.orgID, header, []byte("not json")))
+	})
+
+	t.Run("unknown provider", func(t *testing.T) {
+		err := env.service.HandleWebhook(ctx, "unknown", env.orgID, header, payload)
+		assert.ErrorIs(t, err, ErrUnknownProvider)
+	})
+}
+
+func TestService_SyncCharge(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	require.NoError(t, env.service.GenerateCharge(ctx, env.orgID, env.invoice.ID.String()))
+
+	require.NoError(t, env.provider.SetStatus(*env.invoice.BankInvoiceID, ChargeStatusOverdue))
+	inv, err := env.service.SyncCharge(ctx, env.orgID, env.invoice.ID)
+	require.NoError(t, err)
+	assert.Equal(t, invoice.InvoiceStatusSent, inv.Status)
+	assert.Equal(t, string(ChargeStatusOverdue), *inv.BankInvoiceStatus)
+
+	require.NoError(t, env.provider.SetStatus(*env.invoice.BankInvoiceID, ChargeStatusPaid))
+	inv, err = env.service.SyncCharge(ctx, env.orgID, env.invoice.ID)
+	require.NoError(t, err)
+	assert.Equal(t, invoice.InvoiceStatusPaid, inv.Status)
+
+	_, err = env.service.SyncCharge(ctx, uuid.New(), env.invoice.ID)
+	assert.Error(t, err)
+}
+
+func TestService_CancelCharge(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	require.NoError(t, env.service.GenerateCharge(ctx, env.orgID, env.invoice.ID.String()))
+
+	inv, err := env.service.CancelCharge(ctx, env.orgID, env.invoice.ID)
+	require.NoError(t, err)
+	assert.Equal(t, invoice.InvoiceStatusSent, inv.Status)
+	assert.Equal(t, string(ChargeStatusCancelled), *inv.BankInvoiceStatus)
+
+	charge, err := env.provider.FetchStatus(ctx, env.orgID, *inv.BankInvoiceID)
+	require.NoError(t, err)
+	assert.Equal(t, ChargeStatusCancelled, charge.Status)
+
+	env.invoice.Status = invoice.InvoiceStatusPaid
+	_, err = env.service.CancelCharge(ctx, env.orgID, env.invoice.ID)
+	assert.Error(t, err)
+}
+
+func TestInvoiceStatusForCharge(t *testing.T) {
+	paid := invoice.InvoiceStatusPaid
+	cancelled := invoice.InvoiceStatusCancelled
+
+	tests := []struct {
+		name     string
+		current  invoice.InvoiceStatus
+		status   ChargeStatus
+		expected *invoice.InvoiceStatus
+		apply    bool
+	}{
+		{"paid charge pays invoice", invoice.InvoiceStatusSent, ChargeStatusPaid, &paid, true},
+		{"late paid is ignored", invoice.InvoiceStatusPaid, ChargeStatusPaid, nil, false},
+		{"overdue keeps status", invoice.InvoiceStatusSent, ChargeStatusOverdue, nil, true},
+		{"late overdue is ignored", invoice.InvoiceStatusPaid, ChargeStatusOverdue, nil, false},
+		{"cancelled charge keeps status", invoice.InvoiceStatusSent, ChargeStatusCancelled, nil, true},
+		{"refund cancels paid invoice", invoice.InvoiceStatusPaid, ChargeStatusRefunded, &cancelled, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, apply := invoiceStatusForCharge(tt.current, tt.status)
+			assert.Equal(t, tt.apply, apply)
+			assert.Equal(t, tt.expected, status)
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package payment
+
+import "vigi/internal/modules/invoice"
+
+// invoiceStatusForCharge returns the invoice status for a charge status (nil keeps the
+// current one) and whether the change should be applied at all. Providers do not guarantee
+// delivery order, so late notifications never move a paid or cancelled invoice back.
+func invoiceStatusForCharge(current invoice.InvoiceStatus, status ChargeStatus) (*invoice.InvoiceStatus, bool) {
+	var next invoice.InvoiceStatus
+
+	switch status {
+	case ChargeStatusPaid:
+		if current == invoice.InvoiceStatusPaid {
+			return nil, false
+		}
+		next = invoice.InvoiceStatusPaid
+	case ChargeStatusRefunded:
+		next = invoice.InvoiceStatusCancelled
+	default:
+		// PENDING, OVERDUE and CANCELLED charges only change the bank status
+		if current == invoice.InvoiceStatusPaid || current == invoice.InvoiceStatusCancelled {
+			return nil, false
+		}
+		return nil, true
+	}
+
+	return &next, true
+}
@@ -1,4 +1,4 @@
-package inter
+package payment
 
 import (
 	"time"
@@ -12,6 +12,7 @@ type WebhookEvent struct {
 
 	ID         uuid.UUID  `bun:"id,pk,type:uuid"`
 	Provider   string     `bun:"provider,notnull"`
+	ExternalID *string    `bun:"external_id"`
 	Payload    string     `bun:"payload,notnull,type:text"`
 	Processed  bool       `bun:"processed,default:false"`
 	Error      *string    `bun:"error"`
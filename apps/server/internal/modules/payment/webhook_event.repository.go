@@ -0,0 +1,58 @@
+package payment
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/uptrace/bun"
+)
+
+type WebhookRepository interface {
+	// Create stores the event and reports false if an event with the same
+	// provider and external ID was already stored
+	Create(ctx context.Context, event *WebhookEvent) (bool, error)
+	Update(ctx context.Context, event *WebhookEvent) error
+	GetByExternalID(ctx context.Context, provider, externalID string) (*WebhookEvent, error)
+}
+
+type SQLWebhookRepository struct {
+	db *bun.DB
+}
+
+func NewSQLWebhookRepository(db *bun.DB) *SQLWebhookRepository {
+	return &SQLWebhookRepository{
+		db: db,
+	}
+}
+
+func (r *SQLWebhookRepository) Create(ctx context.Context, event *WebhookEvent) (bool, error) {
+	res, err := r.db.NewInsert().Model(event).On("CONFLICT (provider, external_id) DO NOTHING").Exec(ctx)
+	if err != nil {
+		return false, err
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
+}
+
+func (r *SQLWebhookRepository) Update(ctx context.Context, event *WebhookEvent) error {
+	_, err := r.db.NewUpdate().Model(event).WherePK().Exec(ctx)
+	return err
+}
+
+func (r *SQLWebhookRepository) GetByExternalID(ctx context.Context, provider, externalID string) (*WebhookEvent, error) {
+	event := new(WebhookEvent)
+	err := r.db.NewSelect().Model(event).
+		Where("provider = ?", provider).
+		Where("external_id = ?", externalID).
+		Scan(ctx)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return event, nil
+}
@@ -0,0 +1,53 @@
+package probe
+
+import (
+	"errors"
+	"net/http"
+	"vigi/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// TokenHeader carries the token of a probe agent
+const TokenHeader = "X-Probe-Token"
+
+// Middleware authenticates probe agents
+type Middleware struct {
+	service Service
+	logger  *zap.SugaredLogger
+}
+
+func NewMiddleware(service Service, logger *zap.SugaredLogger) *Middleware {
+	return &Middleware{
+		service: service,
+		logger:  logger.Named("[probe-middleware]"),
+	}
+}
+
+// Auth rejects requests without a valid probe token. The probe is stored in
+// the context under "probe" and its organization under "orgId".
+func (m *Middleware) Auth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := c.GetHeader(TokenHeader)
+		if token == "" {
+			c.JSON(http.StatusUnauthorized, utils.NewFailResponse(TokenHeader+" header is required"))
+			c.Abort()
+			return
+		}
+
+		probe, err := m.service.Authenticate(c.Request.Context(), token)
+		if err != nil {
+			if !errors.Is(err, ErrInvalidToken) {
+				m.logger.Errorw("Failed to authenticate probe", "error", err)
+			}
+			c.JSON(http.StatusUnauthorized, utils.NewFailResponse("Invalid probe token"))
+			c.Abort()
+			return
+		}
+
+		c.Set("probe", probe)
+		c.Set("orgId", probe.OrgID)
+		c.Next()
+	}
+}
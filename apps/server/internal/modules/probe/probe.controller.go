@@ -0,0 +1,217 @@
+package probe
+
+import (
+	"net/http"
+	"vigi/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type Controller struct {
+	service Service
+	logger  *zap.SugaredLogger
+}
+
+func NewController(
+	service Service,
+	logger *zap.SugaredLogger,
+) *Controller {
+	return &Controller{
+		service: service,
+		logger:  logger.Named("[probe-controller]"),
+	}
+}
+
+// @Router    /probes [get]
+// @Summary   Get probes
+// @Tags      Probes
+// @Produce   json
+// @Security  JwtAuth
+// @Security  ApiKeyAuth
+// @Param     q     query    string  false  "Search query"
+// @Param     page  query    int     false  "Page number" default(0)
+// @Param     limit query    int     false  "Items per page" default(10)
+// @Success   200  {object}  utils.ApiResponse[[]Model]
+// @Failure   400  {object}  utils.APIError[any]
+// @Failure   500  {object}  utils.APIError[any]
+func (c *Controller) FindAll(ctx *gin.Context) {
+	page, err := utils.GetQueryInt(ctx, "page", 0)
+	if err != nil || page < 0 {
+		ctx.JSON(http.StatusBadRequest, utils.NewFailResponse("Invalid page parameter"))
+		return
+	}
+
+	limit, err := utils.GetQueryInt(ctx, "limit", 10)
+	if err != nil || limit < 1 {
+		ctx.JSON(http.StatusBadRequest, utils.NewFailResponse("Invalid limit parameter"))
+		return
+	}
+
+	q := ctx.Query("q")
+	orgID := ctx.GetString("orgId")
+
+	probes, err := c.service.FindAll(ctx, page, limit, q, orgID)
+	if err != nil {
+		c.logger.Errorw("Failed to get probes", "error", err)
+		ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse("Internal server error"))
+		return
+	}
+	ctx.JSON(http.StatusOK, utils.NewSuccessResponse("success", probes))
+}
+
+// @Router    /probes [post]
+// @Summary   Create a probe
+// @Description The token is only returned in this response, the agent authenticates with it
+// @Tags      Probes
+// @Accept    json
+// @Produce   json
+// @Security  JwtAuth
+// @Security  ApiKeyAuth
+// @Param     body body CreateUpdateDto true "Probe"
+// @Success   201  {object} utils.ApiResponse[WithToken]
+// @Failure   400  {object} utils.APIError[any]
+// @Failure   500  {object} utils.APIError[any]
+func (c *Controller) Create(ctx *gin.Context) {
+	dto, ok := bindDTO(ctx)
+	if !ok {
+		return
+	}
+
+	orgID := ctx.GetString("orgId")
+
+	probe, err := c.service.Create(ctx, dto, orgID)
+	if err != nil {
+		c.logger.Errorw("Failed to create probe", "error", err)
+		ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse("Internal server error"))
+		return
+	}
+	ctx.JSON(http.StatusCreated, utils.NewSuccessResponse("Probe created successfully", probe))
+}
+
+// @Router    /probes/{id} [get]
+// @Summary   Get probe by ID
+// @Tags      Probes
+// @Produce   json
+// @Security  JwtAuth
+// @Security  ApiKeyAuth
+// @Param     id   path      string  true  "Probe ID"
+// @Success   200  {object}  utils.ApiResponse[Model]
+// @Failure   404  {object}  utils.APIError[any]
+// @Failure   500  {object}  utils.APIError[any]
+func (c *Controller) FindByID(ctx *gin.Context) {
+	id := ctx.Param("id")
+	orgID := ctx.GetString("orgId")
+
+	probe, err := c.service.FindByID(ctx, id, orgID)
+	if err != nil {
+		c.logger.Errorw("Failed to get probe", "error", err, "id", id)
+		ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse("Internal server error"))
+		return
+	}
+	if probe == nil {
+		ctx.JSON(http.StatusNotFound, utils.NewFailResponse("Probe not found"))
+		return
+	}
+	ctx.JSON(http.StatusOK, utils.NewSuccessResponse("success", probe))
+}
+
+// @Router    /probes/{id} [put]
+// @Summary   Update a probe
+// @Tags      Probes
+// @Accept    json
+// @Produce   json
+// @Security  JwtAuth
+// @Security  ApiKeyAuth
+// @Param     id   path string          true "Probe ID"
+// @Param     body body CreateUpdateDto true "Probe"
+// @Success   200  {object} utils.ApiResponse[Model]
+// @Failure   400  {object} utils.APIError[any]
+// @Failure   404  {object} utils.APIError[any]
+// @Failure   500  {object} utils.APIError[any]
+func (c *Controller) Update(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	dto, ok := bindDTO(ctx)
+	if !ok {
+		return
+	}
+
+	orgID := ctx.GetString("orgId")
+
+	probe, err := c.service.Update(ctx, id, dto, orgID)
+	if err != nil {
+		c.logger.Errorw("Failed to update probe", "error", err, "id", id)
+		ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse("Internal server error"))
+		return
+	}
+	if probe == nil {
+		ctx.JSON(http.StatusNotFound, utils.NewFailResponse("Probe not found"))
+		return
+	}
+	ctx.JSON(http.StatusOK, utils.NewSuccessResponse("Probe updated successfully", probe))
+}
+
+// @Router    /probes/{id} [delete]
+// @Summary   Delete a probe
+// @Description Monitors assigned to the probe are no longer checked from its location
+// @Tags      Probes
+// @Produce   json
+// @Security  JwtAuth
+// @Security  ApiKeyAuth
+// @Param     id   path      string  true  "Probe ID"
+// @Success   200  {object}  utils.ApiResponse[any]
+// @Failure   500  {object}  utils.APIError[any]
+func (c *Controller) Delete(ctx *gin.Context) {
+	id := ctx.Param("id")
+	orgID := ctx.GetString("orgId")
+
+	if err := c.service.Delete(ctx, id, orgID); err != nil {
+		c.logger.Errorw("Failed to delete probe", "error", err, "id", id)
+		ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse("Internal server error"))
+		return
+	}
+	ctx.JSON(http.StatusOK, utils.NewSuccessResponse[any]("Probe deleted successfully", nil))
+}
+
+// @Router    /probes/{id}/token [post]
+// @Summary   Regenerate the token of a probe
+// @Description The previous token stops working, the agent must be restarted with the new one
+// @Tags      Probes
+// @Produce   json
+// @Security  JwtAuth
+// @Security  ApiKeyAuth
+// @Param     id   path      string  true  "Probe ID"
+// @Success   200  {object}  utils.ApiResponse[WithToken]
+// @Failure   404  {object}  utils.APIError[any]
+// @Failure   500  {object}  utils.APIError[any]
+func (c *Controller) RegenerateToken(ctx *gin.Context) {
+	id := ctx.Param("id")
+	orgID := ctx.GetString("orgId")
+
+	probe, err := c.service.RegenerateToken(ctx, id, orgID)
+	if err != nil {
+		c.logger.Errorw("Failed to regenerate probe token", "error", err, "id", id)
+		ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse("Internal server error"))
+		return
+	}
+	if probe == nil {
+		ctx.JSON(http.StatusNotFound, utils.NewFailResponse("Probe not found"))
+		return
+	}
+	ctx.JSON(http.StatusOK, utils.NewSuccessResponse("Probe token regenerated successfully", probe))
+}
+
+func bindDTO(ctx *gin.Context) (*CreateUpdateDto, bool) {
+	var dto CreateUpdateDto
+	if err := ctx.ShouldBindJSON(&dto); err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.NewFailResponse(err.Error()))
+		return nil, false
+	}
+
+	if err := utils.Validate.Struct(&dto); err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.NewFailResponse(err.Error()))
+		return nil, false
+	}
+	return &dto, true
+}
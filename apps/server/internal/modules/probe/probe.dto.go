@@ -0,0 +1,6 @@
+package probe
+
+type CreateUpdateDto struct {
+	Name        string `json:"name" validate:"required,min=1,max=255" example:"Frankfurt"`
+	Description string `json:"description" validate:"max=500" example:"Hetzner FSN1"`
+}
@@ -0,0 +1,58 @@
+package probe
+
+import (
+	"time"
+	"vigi/internal/modules/shared"
+)
+
+// TokenPrefix marks probe tokens so they are not mistaken for API keys
+const TokenPrefix = "vp_"
+
+// TokenRandomBytes is the amount of randomness in a probe token
+const TokenRandomBytes = 32
+
+// Model is a location running a probe agent
+type Model struct {
+	ID          string     `json:"id"`
+	OrgID       string     `json:"org_id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	TokenHash   string     `json:"-"` // Never expose the hash
+	LastSeenAt  *time.Time `json:"last_seen_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// WithToken is a probe with its plain text token, only returned when the token is issued
+type WithToken struct {
+	Model
+	Token string `json:"token"`
+}
+
+// Assignment lists the probes a monitor is checked from. The monitor is down
+// once DownThreshold of them report it down.
+type Assignment struct {
+	MonitorID     string   `json:"monitor_id"`
+	ProbeIDs      []string `json:"probe_ids"`
+	DownThreshold int      `json:"down_threshold"`
+}
+
+// Result is the latest result a probe reported for a monitor
+type Result struct {
+	MonitorID string               `json:"monitor_id"`
+	ProbeID   string               `json:"probe_id"`
+	Status    shared.MonitorStatus `json:"status"`
+	Msg       string               `json:"msg"`
+	Ping      int                  `json:"ping"`
+	Time      time.Time            `json:"time"`
+}
+
+// Summary combines the fresh results of every location of a monitor
+type Summary struct {
+	Status    shared.MonitorStatus `json:"status"`
+	Down      int                  `json:"down"`
+	Degraded  int                  `json:"degraded"`
+	Reporting int                  `json:"reporting"`
+	Locations int                  `json:"locations"`
+	Threshold int                  `json:"threshold"`
+}
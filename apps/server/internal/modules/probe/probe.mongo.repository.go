@@ -0,0 +1,362 @@
+package probe
+
+import (
+	"context"
+	"errors"
+	"time"
+	"vigi/internal/config"
+	"vigi/internal/modules/shared"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoModel struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty"`
+	OrgID       string             `bson:"org_id"`
+	Name        string             `bson:"name"`
+	Description string             `bson:"description"`
+	TokenHash   string             `bson:"token_hash"`
+	LastSeenAt  *time.Time         `bson:"last_seen_at,omitempty"`
+	CreatedAt   time.Time          `bson:"created_at"`
+	UpdatedAt   time.Time          `bson:"updated_at"`
+}
+
+type mongoAssignment struct {
+	MonitorID     string    `bson:"monitor_id"`
+	ProbeIDs      []string  `bson:"probe_ids"`
+	DownThreshold int       `bson:"down_threshold"`
+	UpdatedAt     time.Time `bson:"updated_at"`
+}
+
+type mongoResult struct {
+	MonitorID string    `bson:"monitor_id"`
+	ProbeID   string    `bson:"probe_id"`
+	Status    int       `bson:"status"`
+	Msg       string    `bson:"msg"`
+	Ping      int       `bson:"ping"`
+	Time      time.Time `bson:"time"`
+}
+
+func toDomainModel(mm *mongoModel) *Model {
+	return &Model{
+		ID:          mm.ID.Hex(),
+		OrgID:       mm.OrgID,
+		Name:        mm.Name,
+		Description: mm.Description,
+		TokenHash:   mm.TokenHash,
+		LastSeenAt:  mm.LastSeenAt,
+		CreatedAt:   mm.CreatedAt,
+		UpdatedAt:   mm.UpdatedAt,
+	}
+}
+
+type MongoRepository struct {
+	client      *mongo.Client
+	db          *mongo.Database
+	collection  *mongo.Collection
+	assignments *mongo.Collection
+	results     *mongo.Collection
+}
+
+func NewMongoRepository(client *mongo.Client, cfg *config.Config) Repository {
+	db := client.Database(cfg.DBName)
+	collection := db.Collection("probes")
+	assignments := db.Collection("monitor_probes")
+	results := db.Collection("probe_results")
+
+	// Create indexes
+	go func() {
+		ctx := context.Background()
+		_, _ = collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+			{Keys: bson.D{{Key: "org_id", Value: 1}}},
+			{Keys: bson.D{{Key: "token_hash", Value: 1}}, Options: options.Index().SetUnique(true)},
+		})
+		_, _ = assignments.Indexes().CreateMany(ctx, []mongo.IndexModel{
+			{Keys: bson.D{{Key: "monitor_id", Value: 1}}, Options: options.Index().SetUnique(true)},
+			{Keys: bson.D{{Key: "probe_ids", Value: 1}}},
+		})
+		_, _ = results.Indexes().CreateOne(ctx, mongo.IndexModel{
+			Keys:    bson.D{{Key: "monitor_id", Value: 1}, {Key: "probe_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		})
+	}()
+
+	return &MongoRepository{
+		client:      client,
+		db:          db,
+		collection:  collection,
+		assignments: assignments,
+		results:     results,
+	}
+}
+
+func (r *MongoRepository) Create(ctx context.Context, probe *Model) (*Model, error) {
+	now := time.Now().UTC()
+	mm := &mongoModel{
+		ID:          primitive.NewObjectID(),
+		OrgID:       probe.OrgID,
+		Name:        probe.Name,
+		Description: probe.Description,
+		TokenHash:   probe.TokenHash,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	if _, err := r.collection.InsertOne(ctx, mm); err != nil {
+		return nil, err
+	}
+	return toDomainModel(mm), nil
+}
+
+func (r *MongoRepository) FindByID(ctx context.Context, id string, orgID string) (*Model, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := bson.M{"_id": objectID}
+	if orgID != "" {
+		filter["org_id"] = orgID
+	}
+	return r.findOne(ctx, filter)
+}
+
+func (r *MongoRepository) FindAll(ctx context.Context, page int, limit int, q string, orgID string) ([]*Model, error) {
+	skip := int64(page * limit)
+	limit64 := int64(limit)
+
+	opts := &options.FindOptions{
+		Skip:  &skip,
+		Limit: &limit64,
+		Sort:  bson.D{{Key: "name", Value: 1}},
+	}
+
+	filter := bson.M{"org_id": orgID}
+	if q != "" {
+		filter["name"] = bson.M{"$regex": q, "$options": "i"}
+	}
+	return r.find(ctx, filter, opts)
+}
+
+func (r *MongoRepository) FindByIDs(ctx context.Context, ids []string, orgID string) ([]*Model, error) {
+	objectIDs := make([]primitive.ObjectID, 0, len(ids))
+	for _, id := range ids {
+		objectID, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			continue
+		}
+		objectIDs = append(objectIDs, objectID)
+	}
+	if len(objectIDs) == 0 {
+		return []*Model{}, nil
+	}
+
+	filter := bson.M{"_id": bson.M{"$in": objectIDs}}
+	if orgID != "" {
+		filter["org_id"] = orgID
+	}
+	return r.find(ctx, filter, options.Find())
+}
+
+func (r *MongoRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*Model, error) {
+	return r.findOne(ctx, bson.M{"token_hash": tokenHash})
+}
+
+func (r *MongoRepository) Update(ctx context.Context, probe *Model) error {
+	return r.updateByID(ctx, probe.ID, bson.M{
+		"name":        probe.Name,
+		"description": probe.Description,
+		"updated_at":  time.Now().UTC(),
+	})
+}
+
+func (r *MongoRepository) UpdateTokenHash(ctx context.Context, id string, tokenHash string) error {
+	return r.updateByID(ctx, id, bson.M{
+		"token_hash": tokenHash,
+		"updated_at": time.Now().UTC(),
+	})
+}
+
+func (r *MongoRepository) UpdateLastSeen(ctx context.Context, id string, seenAt time.Time) error {
+	return r.updateByID(ctx, id, bson.M{"last_seen_at": seenAt})
+}
+
+func (r *MongoRepository) Delete(ctx context.Context, id string, orgID string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	res, err := r.collection.DeleteOne(ctx, bson.M{"_id": objectID, "org_id": orgID})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return nil
+	}
+
+	if _, err := r.results.DeleteMany(ctx, bson.M{"probe_id": id}); err != nil {
+		return err
+	}
+	if _, err := r.assignments.UpdateMany(ctx, bson.M{"probe_ids": id}, bson.M{"$pull": bson.M{"probe_ids": id}}); err != nil {
+		return err
+	}
+	_, err = r.assignments.DeleteMany(ctx, bson.M{"probe_ids": bson.M{"$size": 0}})
+	return err
+}
+
+func (r *MongoRepository) SetAssignment(ctx context.Context, assignment *Assignment) error {
+	probeIDs := uniqueIDs(assignment.ProbeIDs)
+
+	if _, err := r.results.DeleteMany(ctx, bson.M{
+		"monitor_id": assignment.MonitorID,
+		"probe_id":   bson.M{"$nin": probeIDs},
+	}); err != nil {
+		return err
+	}
+
+	if len(probeIDs) == 0 {
+		_, err := r.assignments.DeleteOne(ctx, bson.M{"monitor_id": assignment.MonitorID})
+		return err
+	}
+
+	ma := &mongoAssignment{
+		MonitorID:     assignment.MonitorID,
+		ProbeIDs:      probeIDs,
+		DownThreshold: assignment.DownThreshold,
+		UpdatedAt:     time.Now().UTC(),
+	}
+	_, err := r.assignments.ReplaceOne(ctx, bson.M{"monitor_id": assignment.MonitorID}, ma, options.Replace().SetUpsert(true))
+	return err
+}
+
+func (r *MongoRepository) FindAssignment(ctx context.Context, monitorID string) (*Assignment, error) {
+	var ma mongoAssignment
+	if err := r.assignments.FindOne(ctx, bson.M{"monitor_id": monitorID}).Decode(&ma); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	probeIDs := ma.ProbeIDs
+	if probeIDs == nil {
+		probeIDs = []string{}
+	}
+	return &Assignment{
+		MonitorID:     ma.MonitorID,
+		ProbeIDs:      probeIDs,
+		DownThreshold: ma.DownThreshold,
+	}, nil
+}
+
+func (r *MongoRepository) FindMonitorIDsByProbeID(ctx context.Context, probeID string) ([]string, error) {
+	cursor, err := r.assignments.Find(ctx, bson.M{"probe_ids": probeID})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var mas []*mongoAssignment
+	if err := cursor.All(ctx, &mas); err != nil {
+		return nil, err
+	}
+
+	monitorIDs := make([]string, 0, len(mas))
+	for _, ma := range mas {
+		monitorIDs = append(monitorIDs, ma.MonitorID)
+	}
+	return monitorIDs, nil
+}
+
+func (r *MongoRepository) DeleteByMonitorID(ctx context.Context, monitorID string) error {
+	if _, err := r.assignments.DeleteOne(ctx, bson.M{"monitor_id": monitorID}); err != nil {
+		return err
+	}
+	_, err := r.results.DeleteMany(ctx, bson.M{"monitor_id": monitorID})
+	return err
+}
+
+func (r *MongoRepository) UpsertResult(ctx context.Context, result *Result) error {
+	mr := &mongoResult{
+		MonitorID: result.MonitorID,
+		ProbeID:   result.ProbeID,
+		Status:    int(result.Status),
+		Msg:       result.Msg,
+		Ping:      result.Ping,
+		Time:      result.Time,
+	}
+
+	filter := bson.M{"monitor_id": result.MonitorID, "probe_id": result.ProbeID}
+	_, err := r.results.ReplaceOne(ctx, filter, mr, options.Replace().SetUpsert(true))
+	return err
+}
+
+func (r *MongoRepository) FindResultsByMonitorID(ctx context.Context, monitorID string) ([]*Result, error) {
+	cursor, err := r.results.Find(ctx, bson.M{"monitor_id": monitorID})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var mrs []*mongoResult
+	if err := cursor.All(ctx, &mrs); err != nil {
+		return nil, err
+	}
+
+	results := make([]*Result, 0, len(mrs))
+	for _, mr := range mrs {
+		results = append(results, &Result{
+			MonitorID: mr.MonitorID,
+			ProbeID:   mr.ProbeID,
+			Status:    shared.MonitorStatus(mr.Status),
+			Msg:       mr.Msg,
+			Ping:      mr.Ping,
+			Time:      mr.Time,
+		})
+	}
+	return results, nil
+}
+
+func (r *MongoRepository) updateByID(ctx context.Context, id string, set bson.M) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": objectID}, bson.M{"$set": set})
+	return err
+}
+
+func (r *MongoRepository) findOne(ctx context.Context, filter bson.M) (*Model, error) {
+	var mm mongoModel
+	if err := r.collection.FindOne(ctx, filter).Decode(&mm); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toDomainModel(&mm), nil
+}
+
+func (r *MongoRepository) find(ctx context.Context, filter bson.M, opts *options.FindOptions) ([]*Model, error) {
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var mms []*mongoModel
+	if err := cursor.All(ctx, &mms); err != nil {
+		return nil, err
+	}
+
+	probes := make([]*Model, 0, len(mms))
+	for _, mm := range mms {
+		probes = append(probes, toDomainModel(mm))
+	}
+	return probes, nil
+}
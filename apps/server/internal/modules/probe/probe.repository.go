@@ -0,0 +1,29 @@
+package probe
+
+import (
+	"context"
+	"time"
+)
+
+type Repository interface {
+	Create(ctx context.Context, probe *Model) (*Model, error)
+	FindByID(ctx context.Context, id string, orgID string) (*Model, error)
+	FindAll(ctx context.Context, page int, limit int, q string, orgID string) ([]*Model, error)
+	FindByIDs(ctx context.Context, ids []string, orgID string) ([]*Model, error)
+	FindByTokenHash(ctx context.Context, tokenHash string) (*Model, error)
+	Update(ctx context.Context, probe *Model) error
+	UpdateTokenHash(ctx context.Context, id string, tokenHash string) error
+	UpdateLastSeen(ctx context.Context, id string, seenAt time.Time) error
+	// Delete deletes the probe and removes it from the monitors it was assigned to
+	Delete(ctx context.Context, id string, orgID string) error
+
+	// SetAssignment replaces the probes of the monitor, an empty list removes the assignment
+	SetAssignment(ctx context.Context, assignment *Assignment) error
+	// FindAssignment returns nil when the monitor is not assigned to any probe
+	FindAssignment(ctx context.Context, monitorID string) (*Assignment, error)
+	FindMonitorIDsByProbeID(ctx context.Context, probeID string) ([]string, error)
+	DeleteByMonitorID(ctx context.Context, monitorID string) error
+
+	UpsertResult(ctx context.Context, result *Result) error
+	FindResultsByMonitorID(ctx context.Context, monitorID string) ([]*Result, error)
+}
@@ -0,0 +1,36 @@
+package probe
+
+import (
+	"vigi/internal/modules/middleware"
+	"vigi/internal/modules/organization"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Route struct {
+	controller    *Controller
+	middleware    *middleware.AuthChain
+	orgMiddleware *organization.Middleware
+}
+
+func NewRoute(controller *Controller, middleware *middleware.AuthChain, orgMiddleware *organization.Middleware) *Route {
+	return &Route{
+		controller:    controller,
+		middleware:    middleware,
+		orgMiddleware: orgMiddleware,
+	}
+}
+
+func (r *Route) ConnectRoute(rg *gin.RouterGroup, controller *Controller) {
+	probes := rg.Group("probes")
+	probes.Use(r.middleware.AllAuth())
+	probes.Use(r.orgMiddleware.RequireOrganization())
+	{
+		probes.GET("", r.controller.FindAll)
+		probes.POST("", r.controller.Create)
+		probes.GET("/:id", r.controller.FindByID)
+		probes.PUT("/:id", r.controller.Update)
+		probes.DELETE("/:id", r.controller.Delete)
+		probes.POST("/:id/token", r.controller.RegenerateToken)
+	}
+}
@@ -0,0 +1,265 @@
+package probe
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"vigi/internal/modules/shared"
+
+	"go.uber.org/zap"
+)
+
+// lastSeenResolution limits how often an agent's requests are written to last_seen_at
+const lastSeenResolution = 30 * time.Second
+
+var (
+	ErrInvalidToken  = errors.New("invalid probe token")
+	ErrProbeNotFound = errors.New("one or more probes were not found")
+)
+
+type Service interface {
+	Create(ctx context.Context, dto *CreateUpdateDto, orgID string) (*WithToken, error)
+	FindByID(ctx context.Context, id string, orgID string) (*Model, error)
+	FindAll(ctx context.Context, page int, limit int, q string, orgID string) ([]*Model, error)
+	Update(ctx context.Context, id string, dto *CreateUpdateDto, orgID string) (*Model, error)
+	Delete(ctx context.Context, id string, orgID string) error
+	// RegenerateToken issues a new token for the probe, the previous one stops working
+	RegenerateToken(ctx context.Context, id string, orgID string) (*WithToken, error)
+	// Authenticate returns the probe owning the token and records that it was seen
+	Authenticate(ctx context.Context, token string) (*Model, error)
+
+	// SetMonitorProbes replaces the probes the monitor is checked from. The probes must
+	// belong to orgID. The threshold is kept between 1 and the number of probes.
+	SetMonitorProbes(ctx context.Context, monitorID string, probeIDs []string, downThreshold int, orgID string) error
+	// FindAssignment returns an empty assignment when the monitor is checked by the central workers
+	FindAssignment(ctx context.Context, monitorID string) (*Assignment, error)
+	FindMonitorIDsByProbeID(ctx context.Context, probeID string) ([]string, error)
+	DeleteByMonitorID(ctx context.Context, monitorID string) error
+
+	// RecordResult stores the result of a probe and summarizes the results of every
+	// location of the monitor. Results older than maxAge are left out.
+	RecordResult(ctx context.Context, result *Result, maxAge time.Duration) (*Summary, error)
+}
+
+type ServiceImpl struct {
+	repository Repository
+	logger     *zap.SugaredLogger
+}
+
+func NewService(
+	repository Repository,
+	logger *zap.SugaredLogger,
+) Service {
+	return &ServiceImpl{
+		repository: repository,
+		logger:     logger.Named("[probe-service]"),
+	}
+}
+
+func (s *ServiceImpl) Create(ctx context.Context, dto *CreateUpdateDto, orgID string) (*WithToken, error) {
+	token, tokenHash, err := generateToken()
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := s.repository.Create(ctx, &Model{
+		OrgID:       orgID,
+		Name:        dto.Name,
+		Description: dto.Description,
+		TokenHash:   tokenHash,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &WithToken{Model: *created, Token: token}, nil
+}
+
+func (s *ServiceImpl) FindByID(ctx context.Context, id string, orgID string) (*Model, error) {
+	return s.repository.FindByID(ctx, id, orgID)
+}
+
+func (s *ServiceImpl) FindAll(ctx context.Context, page int, limit int, q string, orgID string) ([]*Model, error) {
+	return s.repository.FindAll(ctx, page, limit, q, orgID)
+}
+
+func (s *ServiceImpl) Update(ctx context.Context, id string, dto *CreateUpdateDto, orgID string) (*Model, error) {
+	probe, err := s.repository.FindByID(ctx, id, orgID)
+	if err != nil || probe == nil {
+		return nil, err
+	}
+
+	probe.Name = dto.Name
+	probe.Description = dto.Description
+	if err := s.repository.Update(ctx, probe); err != nil {
+		return nil, err
+	}
+	return s.repository.FindByID(ctx, id, orgID)
+}
+
+func (s *ServiceImpl) Delete(ctx context.Context, id string, orgID string) error {
+	return s.repository.Delete(ctx, id, orgID)
+}
+
+func (s *ServiceImpl) RegenerateToken(ctx context.Context, id string, orgID string) (*WithToken, error) {
+	probe, err := s.repository.FindByID(ctx, id, orgID)
+	if err != nil || probe == nil {
+		return nil, err
+	}
+
+	token, tokenHash, err := generateToken()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repository.UpdateTokenHash(ctx, id, tokenHash); err != nil {
+		return nil, err
+	}
+
+	probe.TokenHash = tokenHash
+	return &WithToken{Model: *probe, Token: token}, nil
+}
+
+func (s *ServiceImpl) Authenticate(ctx context.Context, token string) (*Model, error) {
+	if !strings.HasPrefix(token, TokenPrefix) {
+		return nil, ErrInvalidToken
+	}
+
+	probe, err := s.repository.FindByTokenHash(ctx, hashToken(token))
+	if err != nil {
+		return nil, err
+	}
+	if probe == nil {
+		return nil, ErrInvalidToken
+	}
+
+	now := time.Now().UTC()
+	if probe.LastSeenAt == nil || now.Sub(*probe.LastSeenAt) >= lastSeenResolution {
+		if err := s.repository.UpdateLastSeen(ctx, probe.ID, now); err != nil {
+			s.logger.Warnw("Failed to record probe activity", "probe_id", probe.ID, "error", err)
+		} else {
+			probe.LastSeenAt = &now
+		}
+	}
+	return probe, nil
+}
+
+func (s *ServiceImpl) SetMonitorProbes(ctx context.Context, monitorID string, probeIDs []string, downThreshold int, orgID string) error {
+	probeIDs = uniqueIDs(probeIDs)
+
+	probes, err := s.repository.FindByIDs(ctx, probeIDs, orgID)
+	if err != nil {
+		return err
+	}
+	if len(probes) != len(probeIDs) {
+		return ErrProbeNotFound
+	}
+
+	return s.repository.SetAssignment(ctx, &Assignment{
+		MonitorID:     monitorID,
+		ProbeIDs:      probeIDs,
+		DownThreshold: clampThreshold(downThreshold, len(probeIDs)),
+	})
+}
+
+func (s *ServiceImpl) FindAssignment(ctx context.Context, monitorID string) (*Assignment, error) {
+	assignment, err := s.repository.FindAssignment(ctx, monitorID)
+	if err != nil {
+		return nil, err
+	}
+	if assignment == nil {
+		return &Assignment{MonitorID: monitorID, ProbeIDs: []string{}}, nil
+	}
+	return assignment, nil
+}
+
+func (s *ServiceImpl) FindMonitorIDsByProbeID(ctx context.Context, probeID string) ([]string, error) {
+	return s.repository.FindMonitorIDsByProbeID(ctx, probeID)
+}
+
+func (s *ServiceImpl) DeleteByMonitorID(ctx context.Context, monitorID string) error {
+	return s.repository.DeleteByMonitorID(ctx, monitorID)
+}
+
+func (s *ServiceImpl) RecordResult(ctx context.Context, result *Result, maxAge time.Duration) (*Summary, error) {
+	if err := s.repository.UpsertResult(ctx, result); err != nil {
+		return nil, fmt.Errorf("failed to store probe result: %w", err)
+	}
+
+	assignment, err := s.FindAssignment(ctx, result.MonitorID)
+	if err != nil {
+		return nil, err
+	}
+	results, err := s.repository.FindResultsByMonitorID(ctx, result.MonitorID)
+	if err != nil {
+		return nil, err
+	}
+	return summarize(assignment, results, result.Time, maxAge), nil
+}
+
+// summarize counts the fresh results of the assigned probes. The monitor is down
+// once the threshold is reached and degraded while only some locations fail.
+func summarize(assignment *Assignment, results []*Result, now time.Time, maxAge time.Duration) *Summary {
+	assigned := make(map[string]bool, len(assignment.ProbeIDs))
+	for _, id := range assignment.ProbeIDs {
+		assigned[id] = true
+	}
+
+	summary := &Summary{
+		Status:    shared.MonitorStatusUp,
+		Locations: len(assignment.ProbeIDs),
+		Threshold: clampThreshold(assignment.DownThreshold, len(assignment.ProbeIDs)),
+	}
+	for _, r := range results {
+		if !assigned[r.ProbeID] || now.Sub(r.Time) > maxAge {
+			continue
+		}
+		summary.Reporting++
+		switch r.Status {
+		case shared.MonitorStatusDown:
+			summary.Down++
+		case shared.MonitorStatusDegraded:
+			summary.Degraded++
+		}
+	}
+
+	switch {
+	case summary.Reporting == 0:
+		summary.Status = shared.MonitorStatusPending
+	case summary.Down >= summary.Threshold:
+		summary.Status = shared.MonitorStatusDown
+	case summary.Down > 0 || summary.Degraded > 0:
+		summary.Status = shared.MonitorStatusDegraded
+	}
+	return summary
+}
+
+func clampThreshold(threshold int, locations int) int {
+	if threshold > locations {
+		threshold = locations
+	}
+	if threshold < 1 {
+		threshold = 1
+	}
+	return threshold
+}
+
+// generateToken returns a new probe token and the hash stored to look it up.
+// The token carries enough entropy that a plain SHA-256 is safe to index on.
+func generateToken() (string, string, error) {
+	bytes := make([]byte, TokenRandomBytes)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", "", fmt.Errorf("error generating probe token: %w", err)
+	}
+
+	token := TokenPrefix + hex.EncodeToString(bytes)
+	return token, hashToken(token), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
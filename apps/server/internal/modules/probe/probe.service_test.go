@@ -0,0 +1,290 @@
+package probe
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+	"vigi/internal/modules/shared"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type fakeRepository struct {
+	probes      []*Model
+	assignments map[string]*Assignment
+	results     map[string]*Result
+	nextID      int
+}
+
+func newFakeRepository() *fakeRepository {
+	return &fakeRepository{
+		assignments: make(map[string]*Assignment),
+		results:     make(map[string]*Result),
+	}
+}
+
+func (r *fakeRepository) Create(ctx context.Context, probe *Model) (*Model, error) {
+	r.nextID++
+	created := *probe
+	created.ID = fmt.Sprintf("probe-%d", r.nextID)
+	r.probes = append(r.probes, &created)
+	return &created, nil
+}
+
+func (r *fakeRepository) FindByID(ctx context.Context, id string, orgID string) (*Model, error) {
+	for _, p := range r.probes {
+		if p.ID == id && (orgID == "" || p.OrgID == orgID) {
+			return p, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeRepository) FindAll(ctx context.Context, page int, limit int, q string, orgID string) ([]*Model, error) {
+	return r.probes, nil
+}
+
+func (r *fakeRepository) FindByIDs(ctx context.Context, ids []string, orgID string) ([]*Model, error) {
+	var found []*Model
+	for _, id := range ids {
+		if p, _ := r.FindByID(ctx, id, orgID); p != nil {
+			found = append(found, p)
+		}
+	}
+	return found, nil
+}
+
+func (r *fakeRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*Model, error) {
+	for _, p := range r.probes {
+		if p.TokenHash == tokenHash {
+			return p, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeRepository) Update(ctx context.Context, probe *Model) error {
+	return nil
+}
+
+func (r *fakeRepository) UpdateTokenHash(ctx context.Context, id string, tokenHash string) error {
+	p, _ := r.FindByID(ctx, id, "")
+	p.TokenHash = tokenHash
+	return nil
+}
+
+func (r *fakeRepository) UpdateLastSeen(ctx context.Context, id string, seenAt time.Time) error {
+	p, _ := r.FindByID(ctx, id, "")
+	p.LastSeenAt = &seenAt
+	return nil
+}
+
+func (r *fakeRepository) Delete(ctx context.Context, id string, orgID string) error {
+	return nil
+}
+
+func (r *fakeRepository) SetAssignment(ctx context.Context, assignment *Assignment) error {
+	if len(assignment.ProbeIDs) == 0 {
+		delete(r.assignments, assignment.MonitorID)
+		return nil
+	}
+	r.assignments[assignment.MonitorID] = assignment
+	return nil
+}
+
+func (r *fakeRepository) FindAssignment(ctx context.Context, monitorID string) (*Assignment, error) {
+	return r.assignments[monitorID], nil
+}
+
+func (r *fakeRepository) FindMonitorIDsByProbeID(ctx context.Context, probeID string) ([]string, error) {
+	return nil, nil
+}
+
+func (r *fakeRepository) DeleteByMonitorID(ctx context.Context, monitorID string) error {
+	delete(r.assignments, monitorID)
+	return nil
+}
+
+func (r *fakeRepository) UpsertResult(ctx context.Context, result *Result) error {
+	r.results[result.MonitorID+"/"+result.ProbeID] = result
+	return nil
+}
+
+func (r *fakeRepository) FindResultsByMonitorID(ctx context.Context, monitorID string) ([]*Result, error) {
+	var results []*Result
+	for _, result := range r.results {
+		if result.MonitorID == monitorID {
+			results = append(results, result)
+		}
+	}
+	return results, nil
+}
+
+func newTestService() (*ServiceImpl, *fakeRepository) {
+	repo := newFakeRepository()
+	return NewService(repo, zap.NewNop().Sugar()).(*ServiceImpl), repo
+}
+
+func TestService_CreateAndAuthenticate(t *testing.T) {
+	ctx := context.Background()
+	service, repo := newTestService()
+
+	created, err := service.Create(ctx, &CreateUpdateDto{Name: "eu-west"}, "org-1")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(created.Token, TokenPrefix))
+	assert.Equal(t, hashToken(created.Token), created.TokenHash)
+
+	probe, err := service.Authenticate(ctx, created.Token)
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, probe.ID)
+	assert.NotNil(t, repo.probes[0].LastSeenAt)
+
+	_, err = service.Authenticate(ctx, "vp_unknown")
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	_, err = service.Authenticate(ctx, "sk_"+created.Token)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestService_RegenerateToken(t *testing.T) {
+	ctx := context.Background()
+	service, _ := newTestService()
+
+	created, err := service.Create(ctx, &CreateUpdateDto{Name: "eu-west"}, "org-1")
+	require.NoError(t, err)
+
+	regenerated, err := service.RegenerateToken(ctx, created.ID, "org-1")
+	require.NoError(t, err)
+	assert.NotEqual(t, created.Token, regenerated.Token)
+
+	_, err = service.Authenticate(ctx, created.Token)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	probe, err := service.Authenticate(ctx, regenerated.Token)
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, probe.ID)
+}
+
+func TestService_SetMonitorProbes(t *testing.T) {
+	ctx := context.Background()
+	service, repo := newTestService()
+
+	first, _ := service.Create(ctx, &CreateUpdateDto{Name: "eu-west"}, "org-1")
+	second, _ := service.Create(ctx, &CreateUpdateDto{Name: "us-east"}, "org-1")
+	other, _ := service.Create(ctx, &CreateUpdateDto{Name: "elsewhere"}, "org-2")
+
+	t.Run("clamps the threshold to the number of probes", func(t *testing.T) {
+		err := service.SetMonitorProbes(ctx, "mon-1", []string{first.ID, second.ID, first.ID}, 5, "org-1")
+		require.NoError(t, err)
+
+		assignment := repo.assignments["mon-1"]
+		assert.Equal(t, []string{first.ID, second.ID}, assignment.ProbeIDs)
+		assert.Equal(t, 2, assignment.DownThreshold)
+	})
+
+	t.Run("rejects probes of another organization", func(t *testing.T) {
+		err := service.SetMonitorProbes(ctx, "mon-1", []string{first.ID, other.ID}, 1, "org-1")
+		assert.ErrorIs(t, err, ErrProbeNotFound)
+	})
+
+	t.Run("empty list removes the assignment", func(t *testing.T) {
+		require.NoError(t, service.SetMonitorProbes(ctx, "mon-1", nil, 0, "org-1"))
+
+		assignment, err := service.FindAssignment(ctx, "mon-1")
+		require.NoError(t, err)
+		assert.Empty(t, assignment.ProbeIDs)
+	})
+}
+
+func TestSummarize(t *testing.T) {
+	now := time.Now().UTC()
+	maxAge := 2 * time.Minute
+	assignment := &Assignment{MonitorID: "mon-1", ProbeIDs: []string{"a", "b", "c"}, DownThreshold: 2}
+
+	result := func(probeID string, status shared.MonitorStatus, age time.Duration) *Result {
+		return &Result{MonitorID: "mon-1", ProbeID: probeID, Status: status, Time: now.Add(-age)}
+	}
+
+	tests := []struct {
+		name     string
+		results  []*Result
+		status   shared.MonitorStatus
+		down     int
+		reported int
+	}{
+		{
+			name:     "all locations up",
+			results:  []*Result{result("a", shared.MonitorStatusUp, 0), result("b", shared.MonitorStatusUp, 0), result("c", shared.MonitorStatusUp, 0)},
+			status:   shared.MonitorStatusUp,
+			reported: 3,
+		},
+		{
+			name:     "below the threshold is degraded",
+			results:  []*Result{result("a", shared.MonitorStatusDown, 0), result("b", shared.MonitorStatusUp, 0), result("c", shared.MonitorStatusUp, 0)},
+			status:   shared.MonitorStatusDegraded,
+			down:     1,
+			reported: 3,
+		},
+		{
+			name:     "threshold reached is down",
+			results:  []*Result{result("a", shared.MonitorStatusDown, 0), result("b", shared.MonitorStatusDown, 0), result("c", shared.MonitorStatusUp, 0)},
+			status:   shared.MonitorStatusDown,
+			down:     2,
+			reported: 3,
+		},
+		{
+			name:     "stale results are left out",
+			results:  []*Result{result("a", shared.MonitorStatusDown, 0), result("b", shared.MonitorStatusDown, time.Hour), result("c", shared.MonitorStatusUp, 0)},
+			status:   shared.MonitorStatusDegraded,
+			down:     1,
+			reported: 2,
+		},
+		{
+			name:     "unassigned probes are left out",
+			results:  []*Result{result("a", shared.MonitorStatusDown, 0), result("z", shared.MonitorStatusDown, 0)},
+			status:   shared.MonitorStatusDegraded,
+			down:     1,
+			reported: 1,
+		},
+		{
+			name:    "no fresh results is pending",
+			results: []*Result{result("a", shared.MonitorStatusDown, time.Hour)},
+			status:  shared.MonitorStatusPending,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			summary := summarize(assignment, tt.results, now, maxAge)
+			assert.Equal(t, tt.status, summary.Status)
+			assert.Equal(t, tt.down, summary.Down)
+			assert.Equal(t, tt.reported, summary.Reporting)
+			assert.Equal(t, 3, summary.Locations)
+			assert.Equal(t, 2, summary.Threshold)
+		})
+	}
+}
+
+func TestService_RecordResult(t *testing.T) {
+	ctx := context.Background()
+	service, _ := newTestService()
+
+	first, _ := service.Create(ctx, &CreateUpdateDto{Name: "eu-west"}, "org-1")
+	second, _ := service.Create(ctx, &CreateUpdateDto{Name: "us-east"}, "org-1")
+	require.NoError(t, service.SetMonitorProbes(ctx, "mon-1", []string{first.ID, second.ID}, 2, "org-1"))
+
+	now := time.Now().UTC()
+	summary, err := service.RecordResult(ctx, &Result{MonitorID: "mon-1", ProbeID: first.ID, Status: shared.MonitorStatusDown, Time: now}, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, shared.MonitorStatusDegraded, summary.Status)
+
+	summary, err = service.RecordResult(ctx, &Result{MonitorID: "mon-1", ProbeID: second.ID, Status: shared.MonitorStatusDown, Time: now}, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, shared.MonitorStatusDown, summary.Status)
+	assert.Equal(t, 2, summary.Down)
+	assert.Equal(t, 2, summary.Locations)
+}
@@ -0,0 +1,364 @@
+package probe
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+	"vigi/internal/modules/shared"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type sqlModel struct {
+	bun.BaseModel `bun:"table:probes,alias:p"`
+
+	ID          string     `bun:"id,pk"`
+	OrgID       string     `bun:"org_id"`
+	Name        string     `bun:"name,notnull"`
+	Description string     `bun:"description"`
+	TokenHash   string     `bun:"token_hash,notnull"`
+	LastSeenAt  *time.Time `bun:"last_seen_at"`
+	CreatedAt   time.Time  `bun:"created_at,nullzero,notnull,default:current_timestamp"`
+	UpdatedAt   time.Time  `bun:"updated_at,nullzero,notnull,default:current_timestamp"`
+}
+
+type sqlAssignment struct {
+	bun.BaseModel `bun:"table:monitor_probes,alias:mp"`
+
+	MonitorID     string    `bun:"monitor_id,pk"`
+	ProbeIDs      string    `bun:"probe_ids,notnull"`
+	DownThreshold int       `bun:"down_threshold,notnull"`
+	UpdatedAt     time.Time `bun:"updated_at,nullzero,notnull,default:current_timestamp"`
+}
+
+type sqlResult struct {
+	bun.BaseModel `bun:"table:probe_results,alias:pr"`
+
+	MonitorID string    `bun:"monitor_id,pk"`
+	ProbeID   string    `bun:"probe_id,pk"`
+	Status    int       `bun:"status,notnull"`
+	Msg       string    `bun:"msg"`
+	Ping      int       `bun:"ping,notnull"`
+	Time      time.Time `bun:"time,notnull"`
+}
+
+func toDomainModelFromSQL(sm *sqlModel) *Model {
+	return &Model{
+		ID:          sm.ID,
+		OrgID:       sm.OrgID,
+		Name:        sm.Name,
+		Description: sm.Description,
+		TokenHash:   sm.TokenHash,
+		LastSeenAt:  sm.LastSeenAt,
+		CreatedAt:   sm.CreatedAt,
+		UpdatedAt:   sm.UpdatedAt,
+	}
+}
+
+func toAssignmentFromSQL(sa *sqlAssignment) (*Assignment, error) {
+	probeIDs := []string{}
+	if sa.ProbeIDs != "" {
+		if err := json.Unmarshal([]byte(sa.ProbeIDs), &probeIDs); err != nil {
+			return nil, err
+		}
+	}
+	return &Assignment{
+		MonitorID:     sa.MonitorID,
+		ProbeIDs:      probeIDs,
+		DownThreshold: sa.DownThreshold,
+	}, nil
+}
+
+type SQLRepositoryImpl struct {
+	db *bun.DB
+}
+
+func NewSQLRepository(db *bun.DB) Repository {
+	return &SQLRepositoryImpl{db: db}
+}
+
+func (r *SQLRepositoryImpl) Create(ctx context.Context, probe *Model) (*Model, error) {
+	sm := &sqlModel{
+		ID:          uuid.New().String(),
+		OrgID:       probe.OrgID,
+		Name:        probe.Name,
+		Description: probe.Description,
+		TokenHash:   probe.TokenHash,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	if _, err := r.db.NewInsert().Model(sm).Exec(ctx); err != nil {
+		return nil, err
+	}
+	return toDomainModelFromSQL(sm), nil
+}
+
+func (r *SQLRepositoryImpl) FindByID(ctx context.Context, id string, orgID string) (*Model, error) {
+	sm := new(sqlModel)
+	query := r.db.NewSelect().Model(sm).Where("id = ?", id)
+	if orgID != "" {
+		query = query.Where("org_id = ?", orgID)
+	}
+	return r.scanOne(ctx, query, sm)
+}
+
+func (r *SQLRepositoryImpl) FindAll(ctx context.Context, page int, limit int, q string, orgID string) ([]*Model, error) {
+	query := r.db.NewSelect().Model((*sqlModel)(nil)).Where("org_id = ?", orgID)
+	if q != "" {
+		query = query.Where("LOWER(name) LIKE ?", "%"+q+"%")
+	}
+
+	var sms []*sqlModel
+	err := query.Order("name ASC").
+		Limit(limit).
+		Offset(page*limit).
+		Scan(ctx, &sms)
+	if err != nil {
+		return nil, err
+	}
+	return toDomainModels(sms), nil
+}
+
+func (r *SQLRepositoryImpl) FindByIDs(ctx context.Context, ids []string, orgID string) ([]*Model, error) {
+	if len(ids) == 0 {
+		return []*Model{}, nil
+	}
+
+	var sms []*sqlModel
+	query := r.db.NewSelect().Model(&sms).Where("id IN (?)", bun.In(ids))
+	if orgID != "" {
+		query = query.Where("org_id = ?", orgID)
+	}
+	if err := query.Scan(ctx); err != nil {
+		return nil, err
+	}
+	return toDomainModels(sms), nil
+}
+
+func (r *SQLRepositoryImpl) FindByTokenHash(ctx context.Context, tokenHash string) (*Model, error) {
+	sm := new(sqlModel)
+	return r.scanOne(ctx, r.db.NewSelect().Model(sm).Where("token_hash = ?", tokenHash), sm)
+}
+
+func (r *SQLRepositoryImpl) Update(ctx context.Context, probe *Model) error {
+	_, err := r.db.NewUpdate().Model((*sqlModel)(nil)).
+		Set("name = ?", probe.Name).
+		Set("description = ?", probe.Description).
+		Set("updated_at = ?", time.Now()).
+		Where("id = ?", probe.ID).
+		Exec(ctx)
+	return err
+}
+
+func (r *SQLRepositoryImpl) UpdateTokenHash(ctx context.Context, id string, tokenHash string) error {
+	_, err := r.db.NewUpdate().Model((*sqlModel)(nil)).
+		Set("token_hash = ?", tokenHash).
+		Set("updated_at = ?", time.Now()).
+		Where("id = ?", id).
+		Exec(ctx)
+	return err
+}
+
+func (r *SQLRepositoryImpl) UpdateLastSeen(ctx context.Context, id string, seenAt time.Time) error {
+	_, err := r.db.NewUpdate().Model((*sqlModel)(nil)).
+		Set("last_seen_at = ?", seenAt).
+		Where("id = ?", id).
+		Exec(ctx)
+	return err
+}
+
+func (r *SQLRepositoryImpl) Delete(ctx context.Context, id string, orgID string) error {
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		res, err := tx.NewDelete().Model((*sqlModel)(nil)).Where("id = ?", id).Where("org_id = ?", orgID).Exec(ctx)
+		if err != nil {
+			return err
+		}
+		if n, _ := res.RowsAffected(); n == 0 {
+			return nil
+		}
+
+		// Not every backend enforces the foreign key actions
+		if _, err := tx.NewDelete().Model((*sqlResult)(nil)).Where("probe_id = ?", id).Exec(ctx); err != nil {
+			return err
+		}
+
+		var sas []*sqlAssignment
+		err = tx.NewSelect().Model(&sas).Where("probe_ids LIKE ?", "%\""+id+"\"%").Scan(ctx)
+		if err != nil {
+			return err
+		}
+		for _, sa := range sas {
+			assignment, err := toAssignmentFromSQL(sa)
+			if err != nil {
+				return err
+			}
+			assignment.ProbeIDs = removeID(assignment.ProbeIDs, id)
+			if err := setAssignment(ctx, tx, assignment); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *SQLRepositoryImpl) SetAssignment(ctx context.Context, assignment *Assignment) error {
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		return setAssignment(ctx, tx, assignment)
+	})
+}
+
+func (r *SQLRepositoryImpl) FindAssignment(ctx context.Context, monitorID string) (*Assignment, error) {
+	sa := new(sqlAssignment)
+	err := r.db.NewSelect().Model(sa).Where("monitor_id = ?", monitorID).Limit(1).Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toAssignmentFromSQL(sa)
+}
+
+func (r *SQLRepositoryImpl) FindMonitorIDsByProbeID(ctx context.Context, probeID string) ([]string, error) {
+	var sas []*sqlAssignment
+	err := r.db.NewSelect().Model(&sas).Where("probe_ids LIKE ?", "%\""+probeID+"\"%").Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	monitorIDs := make([]string, 0, len(sas))
+	for _, sa := range sas {
+		monitorIDs = append(monitorIDs, sa.MonitorID)
+	}
+	return monitorIDs, nil
+}
+
+func (r *SQLRepositoryImpl) DeleteByMonitorID(ctx context.Context, monitorID string) error {
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.NewDelete().Model((*sqlAssignment)(nil)).Where("monitor_id = ?", monitorID).Exec(ctx); err != nil {
+			return err
+		}
+		_, err := tx.NewDelete().Model((*sqlResult)(nil)).Where("monitor_id = ?", monitorID).Exec(ctx)
+		return err
+	})
+}
+
+func (r *SQLRepositoryImpl) UpsertResult(ctx context.Context, result *Result) error {
+	sr := &sqlResult{
+		MonitorID: result.MonitorID,
+		ProbeID:   result.ProbeID,
+		Status:    int(result.Status),
+		Msg:       result.Msg,
+		Ping:      result.Ping,
+		Time:      result.Time,
+	}
+
+	_, err := r.db.NewInsert().Model(sr).
+		On("CONFLICT (monitor_id, probe_id) DO UPDATE").
+		Set("status = EXCLUDED.status").
+		Set("msg = EXCLUDED.msg").
+		Set("ping = EXCLUDED.ping").
+		Set("time = EXCLUDED.time").
+		Exec(ctx)
+	return err
+}
+
+func (r *SQLRepositoryImpl) FindResultsByMonitorID(ctx context.Context, monitorID string) ([]*Result, error) {
+	var srs []*sqlResult
+	if err := r.db.NewSelect().Model(&srs).Where("monitor_id = ?", monitorID).Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	results := make([]*Result, 0, len(srs))
+	for _, sr := range srs {
+		results = append(results, &Result{
+			MonitorID: sr.MonitorID,
+			ProbeID:   sr.ProbeID,
+			Status:    shared.MonitorStatus(sr.Status),
+			Msg:       sr.Msg,
+			Ping:      sr.Ping,
+			Time:      sr.Time,
+		})
+	}
+	return results, nil
+}
+
+func (r *SQLRepositoryImpl) scanOne(ctx context.Context, query *bun.SelectQuery, sm *sqlModel) (*Model, error) {
+	if err := query.Limit(1).Scan(ctx); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toDomainModelFromSQL(sm), nil
+}
+
+// setAssignment replaces the row of the monitor, results of probes that were
+// unassigned are dropped so they no longer count
+func setAssignment(ctx context.Context, tx bun.Tx, assignment *Assignment) error {
+	probeIDs := uniqueIDs(assignment.ProbeIDs)
+
+	if _, err := tx.NewDelete().Model((*sqlAssignment)(nil)).Where("monitor_id = ?", assignment.MonitorID).Exec(ctx); err != nil {
+		return err
+	}
+
+	results := tx.NewDelete().Model((*sqlResult)(nil)).Where("monitor_id = ?", assignment.MonitorID)
+	if len(probeIDs) > 0 {
+		results = results.Where("probe_id NOT IN (?)", bun.In(probeIDs))
+	}
+	if _, err := results.Exec(ctx); err != nil {
+		return err
+	}
+
+	if len(probeIDs) == 0 {
+		return nil
+	}
+
+	encoded, err := json.Marshal(probeIDs)
+	if err != nil {
+		return err
+	}
+	sa := &sqlAssignment{
+		MonitorID:     assignment.MonitorID,
+		ProbeIDs:      string(encoded),
+		DownThreshold: assignment.DownThreshold,
+		UpdatedAt:     time.Now(),
+	}
+	_, err = tx.NewInsert().Model(sa).Exec(ctx)
+	return err
+}
+
+func toDomainModels(sms []*sqlModel) []*Model {
+	probes := make([]*Model, 0, len(sms))
+	for _, sm := range sms {
+		probes = append(probes, toDomainModelFromSQL(sm))
+	}
+	return probes
+}
+
+func uniqueIDs(ids []string) []string {
+	seen := make(map[string]bool, len(ids))
+	result := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		result = append(result, id)
+	}
+	return result
+}
+
+func removeID(ids []string, id string) []string {
+	result := make([]string, 0, len(ids))
+	for _, other := range ids {
+		if other != id {
+			result = append(result, other)
+		}
+	}
+	return result
+}
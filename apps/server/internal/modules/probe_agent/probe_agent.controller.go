@@ -0,0 +1,84 @@
+package probe_agent
+
+import (
+	"errors"
+	"net/http"
+	"vigi/internal/modules/probe"
+	"vigi/internal/modules/worker"
+	"vigi/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type Controller struct {
+	service Service
+	logger  *zap.SugaredLogger
+}
+
+func NewController(
+	service Service,
+	logger *zap.SugaredLogger,
+) *Controller {
+	return &Controller{
+		service: service,
+		logger:  logger.Named("[probe-agent-controller]"),
+	}
+}
+
+// @Router    /probe-agent/checks [get]
+// @Summary   Get the checks assigned to the calling probe
+// @Tags      Probe Agent
+// @Produce   json
+// @Param     X-Probe-Token header string true "Probe token"
+// @Success   200  {object}  utils.ApiResponse[[]worker.HealthCheckTaskPayload]
+// @Failure   401  {object}  utils.APIError[any]
+// @Failure   500  {object}  utils.APIError[any]
+func (c *Controller) FindChecks(ctx *gin.Context) {
+	p := ctx.MustGet("probe").(*probe.Model)
+
+	checks, err := c.service.FindChecks(ctx, p)
+	if err != nil {
+		c.logger.Errorw("Failed to get probe checks", "probe_id", p.ID, "error", err)
+		ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse("Internal server error"))
+		return
+	}
+	ctx.JSON(http.StatusOK, utils.NewSuccessResponse("success", checks))
+}
+
+// @Router    /probe-agent/results [post]
+// @Summary   Submit a check result from the calling probe
+// @Tags      Probe Agent
+// @Accept    json
+// @Produce   json
+// @Param     X-Probe-Token header string             true "Probe token"
+// @Param     body          body   worker.AgentResult true "Check result"
+// @Success   202  {object}  utils.ApiResponse[any]
+// @Failure   400  {object}  utils.APIError[any]
+// @Failure   401  {object}  utils.APIError[any]
+// @Failure   403  {object}  utils.APIError[any]
+// @Failure   500  {object}  utils.APIError[any]
+func (c *Controller) SubmitResult(ctx *gin.Context) {
+	p := ctx.MustGet("probe").(*probe.Model)
+
+	var result worker.AgentResult
+	if err := ctx.ShouldBindJSON(&result); err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.NewFailResponse(err.Error()))
+		return
+	}
+	if err := utils.Validate.Struct(&result); err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.NewFailResponse(err.Error()))
+		return
+	}
+
+	if err := c.service.SubmitResult(ctx, p, &result); err != nil {
+		if errors.Is(err, ErrMonitorNotAssigned) {
+			ctx.JSON(http.StatusForbidden, utils.NewFailResponse(err.Error()))
+			return
+		}
+		c.logger.Errorw("Failed to submit probe result", "probe_id", p.ID, "monitor_id", result.MonitorID, "error", err)
+		ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse("Internal server error"))
+		return
+	}
+	ctx.JSON(http.StatusAccepted, utils.NewSuccessResponse[any]("Result accepted", nil))
+}
@@ -0,0 +1,11 @@
+package probe_agent
+
+import (
+	"go.uber.org/dig"
+)
+
+func RegisterDependencies(container *dig.Container) {
+	container.Provide(NewService)
+	container.Provide(NewController)
+	container.Provide(NewRoute)
+}
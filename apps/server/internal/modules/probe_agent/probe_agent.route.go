@@ -0,0 +1,29 @@
+package probe_agent
+
+import (
+	"vigi/internal/modules/probe"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Route struct {
+	controller      *Controller
+	probeMiddleware *probe.Middleware
+}
+
+func NewRoute(controller *Controller, probeMiddleware *probe.Middleware) *Route {
+	return &Route{
+		controller:      controller,
+		probeMiddleware: probeMiddleware,
+	}
+}
+
+// ConnectRoute registers the endpoints used by probe agents, they authenticate with a probe token
+func (r *Route) ConnectRoute(rg *gin.RouterGroup, controller *Controller) {
+	agent := rg.Group("probe-agent")
+	agent.Use(r.probeMiddleware.Auth())
+	{
+		agent.GET("/checks", r.controller.FindChecks)
+		agent.POST("/results", r.controller.SubmitResult)
+	}
+}
@@ -0,0 +1,273 @@
+package probe_agent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"vigi/internal/modules/certificate"
+	"vigi/internal/modules/maintenance"
+	"vigi/internal/modules/monitor"
+	"vigi/internal/modules/probe"
+	"vigi/internal/modules/proxy"
+	"vigi/internal/modules/queue"
+	"vigi/internal/modules/shared"
+	"vigi/internal/modules/worker"
+
+	"go.uber.org/zap"
+)
+
+// ErrMonitorNotAssigned is returned for results of monitors the probe does not check
+var ErrMonitorNotAssigned = errors.New("monitor is not assigned to this probe")
+
+// IngesterTaskPayload matches the payload structure for ingester tasks
+type IngesterTaskPayload struct {
+	MonitorID          string               `json:"monitor_id"`
+	MonitorName        string               `json:"monitor_name"`
+	MonitorType        string               `json:"monitor_type"`
+	MonitorInterval    int                  `json:"monitor_interval"`
+	MonitorTimeout     int                  `json:"monitor_timeout"`
+	MonitorMaxRetries  int                  `json:"monitor_max_retries"`
+	MonitorRetryInt    int                  `json:"monitor_retry_interval"`
+	MonitorResendInt   int                  `json:"monitor_resend_interval"`
+	MonitorConfig      string               `json:"monitor_config"`
+	Status             shared.MonitorStatus `json:"status"`
+	Message            string               `json:"message"`
+	PingMs             int                  `json:"ping_ms"`
+	StartTime          time.Time            `json:"start_time"`
+	EndTime            time.Time            `json:"end_time"`
+	IsUnderMaintenance bool                 `json:"is_under_maintenance"`
+	TLSInfo            *certificate.TLSInfo `json:"tls_info,omitempty"`
+	CheckCertExpiry    bool                 `json:"check_cert_expiry"`
+	ProbeID            string               `json:"probe_id,omitempty"`
+}
+
+type Service interface {
+	// FindChecks returns the active monitors assigned to the probe as health check payloads
+	FindChecks(ctx context.Context, p *probe.Model) ([]worker.HealthCheckTaskPayload, error)
+	// SubmitResult hands a result of the probe to the ingester
+	SubmitResult(ctx context.Context, p *probe.Model, result *worker.AgentResult) error
+}
+
+type ServiceImpl struct {
+	probeService       probe.Service
+	monitorService     monitor.Service
+	proxyService       proxy.Service
+	maintenanceService maintenance.Service
+	queueService       queue.Service
+	logger             *zap.SugaredLogger
+}
+
+func NewService(
+	probeService probe.Service,
+	monitorService monitor.Service,
+	proxyService proxy.Service,
+	maintenanceService maintenance.Service,
+	queueService queue.Service,
+	logger *zap.SugaredLogger,
+) Service {
+	return &ServiceImpl{
+		probeService:       probeService,
+		monitorService:     monitorService,
+		proxyService:       proxyService,
+		maintenanceService: maintenanceService,
+		queueService:       queueService,
+		logger:             logger.Named("[probe-agent-service]"),
+	}
+}
+
+func (s *ServiceImpl) FindChecks(ctx context.Context, p *probe.Model) ([]worker.HealthCheckTaskPayload, error) {
+	monitorIDs, err := s.probeService.FindMonitorIDsByProbeID(ctx, p.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	monitors, err := s.monitorService.FindByIDs(ctx, monitorIDs, p.OrgID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	checks := make([]worker.HealthCheckTaskPayload, 0, len(monitors))
+	for _, mon := range monitors {
+		if !mon.Active {
+			continue
+		}
+
+		isUnderMaintenance, err := s.isUnderMaintenance(ctx, mon.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		checks = append(checks, worker.HealthCheckTaskPayload{
+			MonitorID:          mon.ID,
+			MonitorName:        mon.Name,
+			MonitorType:        mon.Type,
+			Interval:           mon.Interval,
+			Timeout:            mon.Timeout,
+			MaxRetries:         mon.MaxRetries,
+			RetryInterval:      mon.RetryInterval,
+			ResendInterval:     mon.ResendInterval,
+			Config:             mon.Config,
+			Proxy:              s.findProxy(ctx, mon),
+			ScheduledAt:        now,
+			IsUnderMaintenance: isUnderMaintenance,
+			CheckCertExpiry:    checkCertExpiry(mon),
+		})
+	}
+	return checks, nil
+}
+
+func (s *ServiceImpl) SubmitResult(ctx context.Context, p *probe.Model, result *worker.AgentResult) error {
+	assignment, err := s.probeService.FindAssignment(ctx, result.MonitorID)
+	if err != nil {
+		return err
+	}
+	if !contains(assignment.ProbeIDs, p.ID) {
+		return ErrMonitorNotAssigned
+	}
+
+	mon, err := s.monitorService.FindByID(ctx, result.MonitorID, p.OrgID)
+	if err != nil {
+		return err
+	}
+	if mon == nil {
+		return ErrMonitorNotAssigned
+	}
+
+	// The maintenance window may have started after the agent pulled its checks
+	isUnderMaintenance, err := s.isUnderMaintenance(ctx, mon.ID)
+	if err != nil {
+		return err
+	}
+	status := result.Status
+	message := result.Message
+	if isUnderMaintenance {
+		status = shared.MonitorStatusMaintenance
+		message = "Monitor under maintenance"
+	}
+	startTime, endTime := checkTimes(result, mon.Timeout, time.Now().UTC())
+
+	payload := IngesterTaskPayload{
+		MonitorID:          mon.ID,
+		MonitorName:        mon.Name,
+		MonitorType:        mon.Type,
+		MonitorInterval:    mon.Interval,
+		MonitorTimeout:     mon.Timeout,
+		MonitorMaxRetries:  mon.MaxRetries,
+		MonitorRetryInt:    mon.RetryInterval,
+		MonitorResendInt:   mon.ResendInterval,
+		MonitorConfig:      mon.Config,
+		Status:             status,
+		Message:            message,
+		PingMs:             result.PingMs,
+		StartTime:          startTime,
+		EndTime:            endTime,
+		IsUnderMaintenance: isUnderMaintenance,
+		TLSInfo:            result.TLSInfo,
+		CheckCertExpiry:    checkCertExpiry(mon),
+		ProbeID:            p.ID,
+	}
+
+	opts := &queue.EnqueueOptions{
+		Queue:     "ingester",
+		MaxRetry:  3,
+		Timeout:   2 * time.Minute,
+		Retention: 1 * time.Hour,
+	}
+
+	// Every probe submits its own result for the same check, so the probe is part of the key.
+	// The start time of the agent tells a resubmitted result from the next check.
+	uniqueKey := fmt.Sprintf("ingest:%s:%s:%d", mon.ID, p.ID, result.StartTime.UnixNano())
+	ttl := 1 * time.Second
+
+	if _, err := s.queueService.EnqueueUnique(ctx, worker.TaskTypeIngester, payload, uniqueKey, ttl, opts); err != nil {
+		return fmt.Errorf("failed to enqueue ingester task: %w", err)
+	}
+	return nil
+}
+
+// checkTimes places a check at the time its result was received. The clock of
+// an agent cannot be trusted, so only the duration it measured is kept, bounded
+// by the monitor timeout in seconds.
+func checkTimes(result *worker.AgentResult, timeout int, receivedAt time.Time) (time.Time, time.Time) {
+	duration := result.EndTime.Sub(result.StartTime)
+	if maxDuration := time.Duration(timeout) * time.Second; duration > maxDuration {
+		duration = maxDuration
+	}
+	if duration < 0 {
+		duration = 0
+	}
+	return receivedAt.Add(-duration), receivedAt
+}
+
+func (s *ServiceImpl) isUnderMaintenance(ctx context.Context, monitorID string) (bool, error) {
+	maintenances, err := s.maintenanceService.GetMaintenancesByMonitorID(ctx, monitorID)
+	if err != nil {
+		return false, err
+	}
+
+	for _, m := range maintenances {
+		underMaintenance, err := s.maintenanceService.IsUnderMaintenance(ctx, m)
+		if err != nil {
+			s.logger.Warnw("Failed to get maintenance status", "maintenance_id", m.ID, "error", err)
+			continue
+		}
+		if underMaintenance {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (s *ServiceImpl) findProxy(ctx context.Context, mon *monitor.Model) *worker.ProxyData {
+	if mon.ProxyId == "" {
+		return nil
+	}
+
+	proxyModel, err := s.proxyService.FindByID(ctx, mon.ProxyId, "")
+	if err != nil || proxyModel == nil {
+		s.logger.Warnw("Failed to fetch proxy, continuing without it",
+			"monitor_id", mon.ID,
+			"proxy_id", mon.ProxyId,
+			"error", err)
+		return nil
+	}
+
+	return &worker.ProxyData{
+		ID:       proxyModel.ID,
+		Protocol: proxyModel.Protocol,
+		Host:     proxyModel.Host,
+		Port:     proxyModel.Port,
+		Auth:     proxyModel.Auth,
+		Username: proxyModel.Username,
+		Password: proxyModel.Password,
+	}
+}
+
+// checkCertExpiry reads the certificate expiry flag of monitors that support TLS (http, tcp)
+func checkCertExpiry(mon *monitor.Model) bool {
+	monType := strings.ToLower(mon.Type)
+	if mon.Config == "" || !(strings.HasPrefix(monType, "http") || monType == "tcp") {
+		return false
+	}
+
+	var config struct {
+		CheckCertExpiry bool `json:"check_cert_expiry"`
+	}
+	if err := json.Unmarshal([]byte(mon.Config), &config); err != nil {
+		return false
+	}
+	return config.CheckCertExpiry
+}
+
+func contains(ids []string, id string) bool {
+	for _, other := range ids {
+		if other == id {
+			return true
+		}
+	}
+	return false
+}
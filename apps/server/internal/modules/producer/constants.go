@@ -23,6 +23,7 @@ const (
 // Lua scripts for atomic operations
 const (
 	// CLAIM: move due items (score <= now_ms) from due → lease with lease expiry.
+	// Returns a flat list of id, due_ms pairs so callers can measure scheduling lag.
 	claimLua = `
 local due   = KEYS[1]
 local lease = KEYS[2]
@@ -30,13 +31,13 @@ local now   = tonumber(ARGV[1])
 local limit = tonumber(ARGV[2])
 local lms   = tonumber(ARGV[3])
 
-local ids = redis.call('ZRANGEBYSCORE', due, '-inf', now, 'LIMIT', 0, limit)
-if #ids == 0 then return ids end
-for i=1,#ids do
-  redis.call('ZREM', due, ids[i])
-  redis.call('ZADD', lease, now + lms, ids[i])
+local items = redis.call('ZRANGEBYSCORE', due, '-inf', now, 'WITHSCORES', 'LIMIT', 0, limit)
+if #items == 0 then return items end
+for i=1,#items,2 do
+  redis.call('ZREM', due, items[i])
+  redis.call('ZADD', lease, now + lms, items[i])
 end
-return ids
+return items
 `
 
 	// RESCHEDULE: move a claimed item lease → due at next_ts_ms
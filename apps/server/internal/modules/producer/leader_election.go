@@ -3,6 +3,7 @@ package producer
 import (
 	"context"
 	"fmt"
+	"os"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -22,17 +23,34 @@ const (
 type LeaderElection struct {
 	client   *redis.Client
 	logger   *zap.SugaredLogger
+	key      string
 	nodeID   string
 	isLeader bool
 	stopChan chan struct{}
 	doneChan chan struct{}
 }
 
-// NewLeaderElection creates a new leader election instance
+// NewNodeID returns a node identifier unique to this process (hostname + PID)
+func NewNodeID() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+	return fmt.Sprintf("%s-%d", hostname, os.Getpid())
+}
+
+// NewLeaderElection creates a new leader election instance for the producer
 func NewLeaderElection(client *redis.Client, nodeID string, logger *zap.SugaredLogger) *LeaderElection {
+	return NewLeaderElectionWithKey(client, LeaderKey, nodeID, logger)
+}
+
+// NewLeaderElectionWithKey creates a leader election instance competing on a custom key,
+// allowing other singleton jobs to elect a leader independently of the producer
+func NewLeaderElectionWithKey(client *redis.Client, key string, nodeID string, logger *zap.SugaredLogger) *LeaderElection {
 	return &LeaderElection{
 		client:   client,
-		logger:   logger.With("component", "leader_election"),
+		logger:   logger.With("component", "leader_election", "key", key),
+		key:      key,
 		nodeID:   nodeID,
 		isLeader: false,
 		stopChan: make(chan struct{}),
@@ -87,7 +105,7 @@ func (le *LeaderElection) IsLeader() bool {
 // tryBecomeLeader attempts to acquire or renew leadership
 func (le *LeaderElection) tryBecomeLeader(ctx context.Context) {
 	// Try to set the key with NX (only if not exists) and EX (expiration)
-	success, err := le.client.SetNX(ctx, LeaderKey, le.nodeID, LeaderTTL).Result()
+	success, err := le.client.SetNX(ctx, le.key, le.nodeID, LeaderTTL).Result()
 	if err != nil {
 		le.logger.Errorw("Failed to acquire leadership", "error", err)
 		le.setLeaderStatus(false)
@@ -104,7 +122,7 @@ func (le *LeaderElection) tryBecomeLeader(ctx context.Context) {
 	}
 
 	// Key already exists, check if we are the current leader
-	currentLeader, err := le.client.Get(ctx, LeaderKey).Result()
+	currentLeader, err := le.client.Get(ctx, le.key).Result()
 	if err != nil {
 		if err != redis.Nil {
 			le.logger.Errorw("Failed to check current leader", "error", err)
@@ -115,7 +133,7 @@ func (le *LeaderElection) tryBecomeLeader(ctx context.Context) {
 
 	if currentLeader == le.nodeID {
 		// We are already the leader, renew the lock
-		err = le.client.Expire(ctx, LeaderKey, LeaderTTL).Err()
+		err = le.client.Expire(ctx, le.key, LeaderTTL).Err()
 		if err != nil {
 			le.logger.Errorw("Failed to renew leadership", "error", err)
 			le.setLeaderStatus(false)
@@ -146,7 +164,7 @@ func (le *LeaderElection) releaseLeadership(ctx context.Context) {
 		end
 	`
 
-	_, err := le.client.Eval(ctx, script, []string{LeaderKey}, le.nodeID).Result()
+	_, err := le.client.Eval(ctx, script, []string{le.key}, le.nodeID).Result()
 	if err != nil {
 		le.logger.Errorw("Failed to release leadership", "error", err)
 	} else {
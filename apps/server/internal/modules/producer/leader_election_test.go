@@ -27,6 +27,28 @@ func TestLeaderElection_NewLeaderElection(t *testing.T) {
 	assert.NotNil(t, le.doneChan)
 }
 
+func TestLeaderElection_NewLeaderElectionWithKey(t *testing.T) {
+	client, mr := setupTestRedis(t)
+	defer mr.Close()
+
+	logger := zap.NewNop().Sugar()
+	ctx := context.Background()
+
+	producerLeader := NewLeaderElection(client, "node1", logger)
+	jobLeader := NewLeaderElectionWithKey(client, "vigi:test-job:leader", "node2", logger)
+
+	producerLeader.tryBecomeLeader(ctx)
+	jobLeader.tryBecomeLeader(ctx)
+
+	// Both nodes lead independently since they compete on different keys
+	assert.True(t, producerLeader.IsLeader())
+	assert.True(t, jobLeader.IsLeader())
+
+	value, err := client.Get(ctx, "vigi:test-job:leader").Result()
+	require.NoError(t, err)
+	assert.Equal(t, "node2", value)
+}
+
 func TestLeaderElection_TryBecomeLeader(t *testing.T) {
 	t.Run("successfully become leader", func(t *testing.T) {
 		client, mr := setupTestRedis(t)
@@ -39,6 +39,7 @@ func TestRunLeadershipMonitor(t *testing.T) {
 			nil,
 			nil,
 			nil,
+			nil,
 			le,
 			cfg,
 			logger,
@@ -91,6 +92,7 @@ func TestRunLeadershipMonitor(t *testing.T) {
 			nil,
 			nil,
 			nil,
+			nil,
 			le,
 			cfg,
 			logger,
@@ -151,6 +153,7 @@ func TestStartJobProcessing(t *testing.T) {
 			nil,
 			nil,
 			nil,
+			nil,
 			le,
 			cfg,
 			logger,
@@ -193,6 +196,7 @@ func TestStartMonitorSyncing(t *testing.T) {
 			nil,
 			nil,
 			nil,
+			nil,
 			le,
 			cfg,
 			logger,
@@ -241,6 +245,7 @@ func TestStartMonitorSyncing(t *testing.T) {
 			nil,
 			nil,
 			nil,
+			nil,
 			le,
 			cfg,
 			logger,
@@ -283,6 +288,7 @@ func TestStopMonitorSyncing(t *testing.T) {
 			nil,
 			nil,
 			nil,
+			nil,
 			le,
 			cfg,
 			logger,
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	"vigi/internal/modules/metrics"
 	"vigi/internal/modules/queue"
 	"vigi/internal/modules/shared"
 	"vigi/internal/modules/worker"
@@ -14,6 +15,7 @@ import (
 
 // claimDueMonitors atomically claims a batch of due monitors from the due queue
 // It moves monitors from the due set (where score <= nowMs) to the lease set with a lease expiry
+// and records how long each monitor waited past its due time before being claimed
 func (p *Producer) claimDueMonitors(ctx context.Context, nowMs int64, maxMonitors int, leaseTTLMs int64) ([]string, error) {
 	result, err := claimScript.Run(
 		ctx,
@@ -26,7 +28,12 @@ func (p *Producer) claimDueMonitors(ctx context.Context, nowMs int64, maxMonitor
 	if err != nil {
 		return nil, err
 	}
-	return toStringSlice(result), nil
+
+	ids, dueTimes := toClaimedPairs(result)
+	for _, dueMs := range dueTimes {
+		metrics.ObserveClaimLag(dueMs, nowMs)
+	}
+	return ids, nil
 }
 
 // runProducer is the main producer loop
@@ -147,6 +154,17 @@ func (p *Producer) processMonitor(ctx context.Context, monitorID string, nowMs i
 		return 0, nil
 	}
 
+	// Monitors assigned to probe locations are run by the probe agents, they
+	// stay scheduled so the central workers take over if the probes are removed
+	assignment, err := p.probeService.FindAssignment(ctx, monitorID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find probe assignment: %w", err)
+	}
+	if len(assignment.ProbeIDs) > 0 {
+		p.logger.Debugw("Skipping monitor checked from probe locations", "monitor_id", monitorID)
+		return mon.Interval, nil
+	}
+
 	isUnderMaintenance, err := p.isUnderMaintenance(ctx, monitorID)
 	if err != nil {
 		p.logger.Errorw("Failed to check if monitor is under maintenance", "monitor_id", monitorID, "error", err)
@@ -257,12 +275,16 @@ func (p *Producer) processMonitor(ctx context.Context, monitorID string, nowMs i
 			p.logger.Debugw("Monitor task already queued (duplicate prevented)",
 				"monitor_id", mon.ID,
 				"duration", time.Since(start))
+			metrics.ProducerEnqueued.WithLabelValues("duplicate").Inc()
 			return mon.Interval, nil
 		}
 		// This is a real error
+		metrics.ProducerEnqueued.WithLabelValues("error").Inc()
 		return 0, fmt.Errorf("failed to enqueue health check: %w", err)
 	}
 
+	metrics.ProducerEnqueued.WithLabelValues("success").Inc()
+
 	p.logger.Infow("Enqueued health check",
 		"monitor_id", mon.ID,
 		"monitor_name", mon.Name,
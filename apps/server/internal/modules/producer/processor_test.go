@@ -7,6 +7,7 @@ import (
 
 	"vigi/internal/modules/maintenance"
 	"vigi/internal/modules/monitor"
+	"vigi/internal/modules/probe"
 	"vigi/internal/modules/proxy"
 	"vigi/internal/modules/queue"
 	"vigi/internal/modules/worker"
@@ -111,12 +112,14 @@ func TestProcessMonitor(t *testing.T) {
 		logger := zap.NewNop().Sugar()
 		mockMonitorSvc := new(MockMonitorService)
 		mockMaintenanceSvc := new(MockMaintenanceService)
+		mockProbeSvc := new(MockProbeService)
 		mockQueueSvc := new(MockQueueService)
 
 		producer := &Producer{
 			logger:             logger,
 			monitorService:     mockMonitorSvc,
 			maintenanceService: mockMaintenanceSvc,
+			probeService:       mockProbeSvc,
 			queueService:       mockQueueSvc,
 		}
 
@@ -134,6 +137,7 @@ func TestProcessMonitor(t *testing.T) {
 		}
 
 		mockMonitorSvc.On("FindByID", ctx, "mon-1", "").Return(mon, nil)
+		mockProbeSvc.On("FindAssignment", ctx, "mon-1").Return(&probe.Assignment{MonitorID: "mon-1"}, nil)
 		mockMaintenanceSvc.On("GetMaintenancesByMonitorID", ctx, "mon-1").Return([]*maintenance.Model{}, nil)
 		mockQueueSvc.On("EnqueueUnique", ctx, worker.TaskTypeHealthCheck, mock.AnythingOfType("worker.HealthCheckTaskPayload"), "healthcheck:mon-1", mock.AnythingOfType("time.Duration"), mock.AnythingOfType("*queue.EnqueueOptions")).Return(&queue.TaskInfo{ID: "task-123"}, nil)
 
@@ -142,6 +146,7 @@ func TestProcessMonitor(t *testing.T) {
 		assert.Equal(t, 60, interval)
 
 		mockMonitorSvc.AssertExpectations(t)
+		mockProbeSvc.AssertExpectations(t)
 		mockMaintenanceSvc.AssertExpectations(t)
 		mockQueueSvc.AssertExpectations(t)
 	})
@@ -172,6 +177,38 @@ func TestProcessMonitor(t *testing.T) {
 		mockMonitorSvc.AssertExpectations(t)
 	})
 
+	t.Run("skip monitor checked from probe locations", func(t *testing.T) {
+		logger := zap.NewNop().Sugar()
+		mockMonitorSvc := new(MockMonitorService)
+		mockProbeSvc := new(MockProbeService)
+
+		// No queue service, enqueueing the check would panic
+		producer := &Producer{
+			logger:         logger,
+			monitorService: mockMonitorSvc,
+			probeService:   mockProbeSvc,
+		}
+
+		ctx := context.Background()
+		mon := &monitor.Model{
+			ID:       "mon-1",
+			Name:     "Probe Monitor",
+			Type:     "http",
+			Active:   true,
+			Interval: 60,
+		}
+
+		mockMonitorSvc.On("FindByID", ctx, "mon-1", "").Return(mon, nil)
+		mockProbeSvc.On("FindAssignment", ctx, "mon-1").Return(&probe.Assignment{MonitorID: "mon-1", ProbeIDs: []string{"probe-1"}, DownThreshold: 1}, nil)
+
+		interval, err := producer.processMonitor(ctx, "mon-1", 1234567890)
+		assert.NoError(t, err)
+		assert.Equal(t, 60, interval)
+
+		mockMonitorSvc.AssertExpectations(t)
+		mockProbeSvc.AssertExpectations(t)
+	})
+
 	t.Run("monitor not found", func(t *testing.T) {
 		logger := zap.NewNop().Sugar()
 		mockMonitorSvc := new(MockMonitorService)
@@ -195,6 +232,7 @@ func TestProcessMonitor(t *testing.T) {
 		logger := zap.NewNop().Sugar()
 		mockMonitorSvc := new(MockMonitorService)
 		mockMaintenanceSvc := new(MockMaintenanceService)
+		mockProbeSvc := new(MockProbeService)
 		mockProxySvc := new(MockProxyService)
 		mockQueueSvc := new(MockQueueService)
 
@@ -202,6 +240,7 @@ func TestProcessMonitor(t *testing.T) {
 			logger:             logger,
 			monitorService:     mockMonitorSvc,
 			maintenanceService: mockMaintenanceSvc,
+			probeService:       mockProbeSvc,
 			proxyService:       mockProxySvc,
 			queueService:       mockQueueSvc,
 		}
@@ -231,6 +270,7 @@ func TestProcessMonitor(t *testing.T) {
 		}
 
 		mockMonitorSvc.On("FindByID", ctx, "mon-1", "").Return(mon, nil)
+		mockProbeSvc.On("FindAssignment", ctx, "mon-1").Return(&probe.Assignment{MonitorID: "mon-1"}, nil)
 		mockMaintenanceSvc.On("GetMaintenancesByMonitorID", ctx, "mon-1").Return([]*maintenance.Model{}, nil)
 		mockProxySvc.On("FindByID", ctx, "proxy-1", "").Return(proxyModel, nil)
 		mockQueueSvc.On("EnqueueUnique", ctx, worker.TaskTypeHealthCheck, mock.MatchedBy(func(payload worker.HealthCheckTaskPayload) bool {
@@ -242,6 +282,7 @@ func TestProcessMonitor(t *testing.T) {
 		assert.Equal(t, 60, interval)
 
 		mockMonitorSvc.AssertExpectations(t)
+		mockProbeSvc.AssertExpectations(t)
 		mockMaintenanceSvc.AssertExpectations(t)
 		mockProxySvc.AssertExpectations(t)
 		mockQueueSvc.AssertExpectations(t)
@@ -251,12 +292,14 @@ func TestProcessMonitor(t *testing.T) {
 		logger := zap.NewNop().Sugar()
 		mockMonitorSvc := new(MockMonitorService)
 		mockMaintenanceSvc := new(MockMaintenanceService)
+		mockProbeSvc := new(MockProbeService)
 		mockQueueSvc := new(MockQueueService)
 
 		producer := &Producer{
 			logger:             logger,
 			monitorService:     mockMonitorSvc,
 			maintenanceService: mockMaintenanceSvc,
+			probeService:       mockProbeSvc,
 			queueService:       mockQueueSvc,
 		}
 
@@ -274,6 +317,7 @@ func TestProcessMonitor(t *testing.T) {
 		}
 
 		mockMonitorSvc.On("FindByID", ctx, "mon-1", "").Return(mon, nil)
+		mockProbeSvc.On("FindAssignment", ctx, "mon-1").Return(&probe.Assignment{MonitorID: "mon-1"}, nil)
 		mockMaintenanceSvc.On("GetMaintenancesByMonitorID", ctx, "mon-1").Return(maintenances, nil)
 		mockMaintenanceSvc.On("IsUnderMaintenance", ctx, maintenances[0]).Return(true, nil)
 		mockQueueSvc.On("EnqueueUnique", ctx, worker.TaskTypeHealthCheck, mock.MatchedBy(func(payload worker.HealthCheckTaskPayload) bool {
@@ -285,6 +329,7 @@ func TestProcessMonitor(t *testing.T) {
 		assert.Equal(t, 60, interval)
 
 		mockMonitorSvc.AssertExpectations(t)
+		mockProbeSvc.AssertExpectations(t)
 		mockMaintenanceSvc.AssertExpectations(t)
 		mockQueueSvc.AssertExpectations(t)
 	})
@@ -293,12 +338,14 @@ func TestProcessMonitor(t *testing.T) {
 		logger := zap.NewNop().Sugar()
 		mockMonitorSvc := new(MockMonitorService)
 		mockMaintenanceSvc := new(MockMaintenanceService)
+		mockProbeSvc := new(MockProbeService)
 		mockQueueSvc := new(MockQueueService)
 
 		producer := &Producer{
 			logger:             logger,
 			monitorService:     mockMonitorSvc,
 			maintenanceService: mockMaintenanceSvc,
+			probeService:       mockProbeSvc,
 			queueService:       mockQueueSvc,
 		}
 
@@ -312,6 +359,7 @@ func TestProcessMonitor(t *testing.T) {
 		}
 
 		mockMonitorSvc.On("FindByID", ctx, "mon-1", "").Return(mon, nil)
+		mockProbeSvc.On("FindAssignment", ctx, "mon-1").Return(&probe.Assignment{MonitorID: "mon-1"}, nil)
 		mockMaintenanceSvc.On("GetMaintenancesByMonitorID", ctx, "mon-1").Return([]*maintenance.Model{}, nil)
 		mockQueueSvc.On("EnqueueUnique", ctx, worker.TaskTypeHealthCheck, mock.AnythingOfType("worker.HealthCheckTaskPayload"), "healthcheck:mon-1", mock.AnythingOfType("time.Duration"), mock.AnythingOfType("*queue.EnqueueOptions")).Return(nil, errors.New("task ID conflicts with existing task"))
 
@@ -320,6 +368,7 @@ func TestProcessMonitor(t *testing.T) {
 		assert.Equal(t, 60, interval)
 
 		mockMonitorSvc.AssertExpectations(t)
+		mockProbeSvc.AssertExpectations(t)
 		mockMaintenanceSvc.AssertExpectations(t)
 		mockQueueSvc.AssertExpectations(t)
 	})
@@ -347,12 +396,14 @@ func TestProcessMonitor(t *testing.T) {
 		logger := zap.NewNop().Sugar()
 		mockMonitorSvc := new(MockMonitorService)
 		mockMaintenanceSvc := new(MockMaintenanceService)
+		mockProbeSvc := new(MockProbeService)
 		mockQueueSvc := new(MockQueueService)
 
 		producer := &Producer{
 			logger:             logger,
 			monitorService:     mockMonitorSvc,
 			maintenanceService: mockMaintenanceSvc,
+			probeService:       mockProbeSvc,
 			queueService:       mockQueueSvc,
 		}
 
@@ -367,6 +418,7 @@ func TestProcessMonitor(t *testing.T) {
 		}
 
 		mockMonitorSvc.On("FindByID", ctx, "mon-1", "").Return(mon, nil)
+		mockProbeSvc.On("FindAssignment", ctx, "mon-1").Return(&probe.Assignment{MonitorID: "mon-1"}, nil)
 		mockMaintenanceSvc.On("GetMaintenancesByMonitorID", ctx, "mon-1").Return([]*maintenance.Model{}, nil)
 		mockQueueSvc.On("EnqueueUnique", ctx, worker.TaskTypeHealthCheck, mock.MatchedBy(func(payload worker.HealthCheckTaskPayload) bool {
 			return payload.CheckCertExpiry == true
@@ -377,6 +429,7 @@ func TestProcessMonitor(t *testing.T) {
 		assert.Equal(t, 60, interval)
 
 		mockMonitorSvc.AssertExpectations(t)
+		mockProbeSvc.AssertExpectations(t)
 		mockMaintenanceSvc.AssertExpectations(t)
 		mockQueueSvc.AssertExpectations(t)
 	})
@@ -385,12 +438,14 @@ func TestProcessMonitor(t *testing.T) {
 		logger := zap.NewNop().Sugar()
 		mockMonitorSvc := new(MockMonitorService)
 		mockMaintenanceSvc := new(MockMaintenanceService)
+		mockProbeSvc := new(MockProbeService)
 		mockQueueSvc := new(MockQueueService)
 
 		producer := &Producer{
 			logger:             logger,
 			monitorService:     mockMonitorSvc,
 			maintenanceService: mockMaintenanceSvc,
+			probeService:       mockProbeSvc,
 			queueService:       mockQueueSvc,
 		}
 
@@ -405,6 +460,7 @@ func TestProcessMonitor(t *testing.T) {
 		}
 
 		mockMonitorSvc.On("FindByID", ctx, "mon-1", "").Return(mon, nil)
+		mockProbeSvc.On("FindAssignment", ctx, "mon-1").Return(&probe.Assignment{MonitorID: "mon-1"}, nil)
 		mockMaintenanceSvc.On("GetMaintenancesByMonitorID", ctx, "mon-1").Return([]*maintenance.Model{}, nil)
 		mockQueueSvc.On("EnqueueUnique", ctx, worker.TaskTypeHealthCheck, mock.MatchedBy(func(payload worker.HealthCheckTaskPayload) bool {
 			return payload.CheckCertExpiry == true
@@ -415,6 +471,7 @@ func TestProcessMonitor(t *testing.T) {
 		assert.Equal(t, 60, interval)
 
 		mockMonitorSvc.AssertExpectations(t)
+		mockProbeSvc.AssertExpectations(t)
 		mockMaintenanceSvc.AssertExpectations(t)
 		mockQueueSvc.AssertExpectations(t)
 	})
@@ -423,12 +480,14 @@ func TestProcessMonitor(t *testing.T) {
 		logger := zap.NewNop().Sugar()
 		mockMonitorSvc := new(MockMonitorService)
 		mockMaintenanceSvc := new(MockMaintenanceService)
+		mockProbeSvc := new(MockProbeService)
 		mockQueueSvc := new(MockQueueService)
 
 		producer := &Producer{
 			logger:             logger,
 			monitorService:     mockMonitorSvc,
 			maintenanceService: mockMaintenanceSvc,
+			probeService:       mockProbeSvc,
 			queueService:       mockQueueSvc,
 		}
 
@@ -442,6 +501,7 @@ func TestProcessMonitor(t *testing.T) {
 		}
 
 		mockMonitorSvc.On("FindByID", ctx, "mon-1", "").Return(mon, nil)
+		mockProbeSvc.On("FindAssignment", ctx, "mon-1").Return(&probe.Assignment{MonitorID: "mon-1"}, nil)
 		mockMaintenanceSvc.On("GetMaintenancesByMonitorID", ctx, "mon-1").Return([]*maintenance.Model{}, nil)
 		mockQueueSvc.On("EnqueueUnique", ctx, worker.TaskTypeHealthCheck, mock.MatchedBy(func(payload worker.HealthCheckTaskPayload) bool {
 			return payload.CheckCertExpiry == false
@@ -452,6 +512,7 @@ func TestProcessMonitor(t *testing.T) {
 		assert.Equal(t, 60, interval)
 
 		mockMonitorSvc.AssertExpectations(t)
+		mockProbeSvc.AssertExpectations(t)
 		mockMaintenanceSvc.AssertExpectations(t)
 		mockQueueSvc.AssertExpectations(t)
 	})
@@ -10,6 +10,7 @@ import (
 	"vigi/internal/modules/maintenance"
 	"vigi/internal/modules/monitor"
 	"vigi/internal/modules/monitor_notification"
+	"vigi/internal/modules/probe"
 	"vigi/internal/modules/proxy"
 	"vigi/internal/modules/queue"
 	"vigi/internal/modules/shared"
@@ -28,6 +29,7 @@ func NewProducer(
 	monitorNotificationSvc monitor_notification.Service,
 	settingService shared.SettingService,
 	heartbeatService heartbeat.Service,
+	probeService probe.Service,
 	leaderElection *LeaderElection,
 	cfg *config.Config,
 	logger *zap.SugaredLogger,
@@ -49,6 +51,7 @@ func NewProducer(
 		monitorNotificationSvc:  monitorNotificationSvc,
 		settingService:          settingService,
 		heartbeatService:        heartbeatService,
+		probeService:            probeService,
 		logger:                  logger.With("component", "producer"),
 		ctx:                     ctx,
 		cancel:                  cancel,
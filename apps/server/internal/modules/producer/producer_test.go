@@ -37,6 +37,7 @@ func TestNewProducer(t *testing.T) {
 			nil,
 			nil,
 			nil,
+			nil,
 			le,
 			cfg,
 			logger,
@@ -74,6 +75,7 @@ func TestNewProducer(t *testing.T) {
 			nil,
 			nil,
 			nil,
+			nil,
 			le,
 			cfg,
 			logger,
@@ -110,6 +112,7 @@ func TestProducerStartStop(t *testing.T) {
 			nil,
 			nil,
 			nil,
+			nil,
 			le,
 			cfg,
 			logger,
@@ -153,6 +156,7 @@ func TestProducerContext(t *testing.T) {
 			nil,
 			nil,
 			nil,
+			nil,
 			le,
 			cfg,
 			logger,
@@ -205,6 +209,7 @@ func TestProducerMonitorIntervals(t *testing.T) {
 			nil,
 			nil,
 			nil,
+			nil,
 			le,
 			cfg,
 			logger,
@@ -239,6 +244,7 @@ func TestProducerMonitorIntervals(t *testing.T) {
 			nil,
 			nil,
 			nil,
+			nil,
 			le,
 			cfg,
 			logger,
@@ -9,6 +9,7 @@ import (
 	"vigi/internal/modules/heartbeat"
 	"vigi/internal/modules/maintenance"
 	"vigi/internal/modules/monitor"
+	"vigi/internal/modules/probe"
 	"vigi/internal/modules/proxy"
 	"vigi/internal/modules/queue"
 	"vigi/internal/modules/stats"
@@ -96,6 +97,14 @@ func (m *MockMonitorService) FindActivePaginated(ctx context.Context, page int,
 	return nil, args.Error(1)
 }
 
+func (m *MockMonitorService) FindAllPaginated(ctx context.Context, page int, limit int) ([]*monitor.Model, error) {
+	args := m.Called(ctx, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*monitor.Model), args.Error(1)
+}
+
 func (m *MockMonitorService) UpdateFull(ctx context.Context, id string, dto *monitor.CreateUpdateDto) (*monitor.Model, error) {
 	args := m.Called(ctx, id, dto)
 	if args.Get(0) == nil {
@@ -122,6 +131,11 @@ func (m *MockMonitorService) ValidateMonitorConfig(monitorType string, configJSO
 	return args.Error(0)
 }
 
+func (m *MockMonitorService) RestoreSecrets(ctx context.Context, id string, config string, orgID string) (string, error) {
+	args := m.Called(ctx, id, config, orgID)
+	return args.String(0), args.Error(1)
+}
+
 func (m *MockMonitorService) GetHeartbeats(ctx context.Context, id string, limit, page int, important *bool, reverse bool, orgID string) ([]*heartbeat.Model, error) {
 	args := m.Called(ctx, id, limit, page, important, reverse, orgID)
 	if args.Get(0) == nil {
@@ -251,6 +265,14 @@ func (m *MockMaintenanceService) GetMonitors(ctx context.Context, id string) ([]
 	return args.Get(0).([]string), args.Error(1)
 }
 
+func (m *MockMaintenanceService) GetAffectedMonitors(ctx context.Context, maintenance *maintenance.Model) ([]string, error) {
+	args := m.Called(ctx, maintenance)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
 // MockProxyService for testing
 type MockProxyService struct {
 	mock.Mock
@@ -301,6 +323,98 @@ func (m *MockProxyService) UpdatePartial(ctx context.Context, id string, dto *pr
 	return args.Get(0).(*proxy.Model), args.Error(1)
 }
 
+// MockProbeService for testing
+type MockProbeService struct {
+	mock.Mock
+}
+
+func (m *MockProbeService) Create(ctx context.Context, dto *probe.CreateUpdateDto, orgID string) (*probe.WithToken, error) {
+	args := m.Called(ctx, dto, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*probe.WithToken), args.Error(1)
+}
+
+func (m *MockProbeService) FindByID(ctx context.Context, id string, orgID string) (*probe.Model, error) {
+	args := m.Called(ctx, id, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*probe.Model), args.Error(1)
+}
+
+func (m *MockProbeService) FindAll(ctx context.Context, page int, limit int, q string, orgID string) ([]*probe.Model, error) {
+	args := m.Called(ctx, page, limit, q, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*probe.Model), args.Error(1)
+}
+
+func (m *MockProbeService) Update(ctx context.Context, id string, dto *probe.CreateUpdateDto, orgID string) (*probe.Model, error) {
+	args := m.Called(ctx, id, dto, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*probe.Model), args.Error(1)
+}
+
+func (m *MockProbeService) Delete(ctx context.Context, id string, orgID string) error {
+	args := m.Called(ctx, id, orgID)
+	return args.Error(0)
+}
+
+func (m *MockProbeService) RegenerateToken(ctx context.Context, id string, orgID string) (*probe.WithToken, error) {
+	args := m.Called(ctx, id, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*probe.WithToken), args.Error(1)
+}
+
+func (m *MockProbeService) Authenticate(ctx context.Context, token string) (*probe.Model, error) {
+	args := m.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*probe.Model), args.Error(1)
+}
+
+func (m *MockProbeService) SetMonitorProbes(ctx context.Context, monitorID string, probeIDs []string, downThreshold int, orgID string) error {
+	args := m.Called(ctx, monitorID, probeIDs, downThreshold, orgID)
+	return args.Error(0)
+}
+
+func (m *MockProbeService) FindAssignment(ctx context.Context, monitorID string) (*probe.Assignment, error) {
+	args := m.Called(ctx, monitorID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*probe.Assignment), args.Error(1)
+}
+
+func (m *MockProbeService) FindMonitorIDsByProbeID(ctx context.Context, probeID string) ([]string, error) {
+	args := m.Called(ctx, probeID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockProbeService) DeleteByMonitorID(ctx context.Context, monitorID string) error {
+	args := m.Called(ctx, monitorID)
+	return args.Error(0)
+}
+
+func (m *MockProbeService) RecordResult(ctx context.Context, result *probe.Result, maxAge time.Duration) (*probe.Summary, error) {
+	args := m.Called(ctx, result, maxAge)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*probe.Summary), args.Error(1)
+}
+
 // MockQueueService for testing
 type MockQueueService struct {
 	mock.Mock
@@ -478,3 +592,13 @@ func (m *MockStatsService) DeleteByMonitorID(ctx context.Context, monitorID stri
 	args := m.Called(ctx, monitorID)
 	return args.Error(0)
 }
+
+func (m *MockStatsService) RebuildRollups(ctx context.Context, monitorID string, heartbeats []*stats.HeartbeatPayload, since, until time.Time) (int, error) {
+	args := m.Called(ctx, monitorID, heartbeats, since, until)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockStatsService) DeleteOlderThan(ctx context.Context, monitorID string, period stats.StatPeriod, cutoff time.Time) (int64, error) {
+	args := m.Called(ctx, monitorID, period, cutoff)
+	return args.Get(0).(int64), args.Error(1)
+}
@@ -12,6 +12,7 @@ import (
 	"vigi/internal/modules/maintenance"
 	"vigi/internal/modules/monitor"
 	"vigi/internal/modules/monitor_notification"
+	"vigi/internal/modules/probe"
 	"vigi/internal/modules/proxy"
 	"vigi/internal/modules/queue"
 	"vigi/internal/modules/shared"
@@ -27,6 +28,7 @@ type Producer struct {
 	monitorNotificationSvc  monitor_notification.Service
 	settingService          shared.SettingService
 	heartbeatService        heartbeat.Service
+	probeService            probe.Service
 	logger                  *zap.SugaredLogger
 	ctx                     context.Context
 	cancel                  context.CancelFunc
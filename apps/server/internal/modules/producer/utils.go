@@ -2,6 +2,7 @@ package producer
 
 import (
 	"fmt"
+	"strconv"
 	"time"
 )
 
@@ -42,3 +43,19 @@ func toStringSlice(v any) []string {
 	}
 	return out
 }
+
+// toClaimedPairs converts a flat Redis WITHSCORES result into ids and their due times
+func toClaimedPairs(v any) ([]string, []int64) {
+	flat := toStringSlice(v)
+	ids := make([]string, 0, len(flat)/2)
+	dueTimes := make([]int64, 0, len(flat)/2)
+	for i := 0; i+1 < len(flat); i += 2 {
+		ids = append(ids, flat[i])
+		dueMs, err := strconv.ParseFloat(flat[i+1], 64)
+		if err != nil {
+			continue
+		}
+		dueTimes = append(dueTimes, int64(dueMs))
+	}
+	return ids, dueTimes
+}
@@ -111,6 +111,42 @@ func TestToStringSlice(t *testing.T) {
 	}
 }
 
+func TestToClaimedPairs(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        interface{}
+		expectedIDs  []string
+		expectedDues []int64
+	}{
+		{
+			name:         "id and score pairs",
+			input:        []interface{}{"monitor1", "1700000000000", "monitor2", "1700000001000"},
+			expectedIDs:  []string{"monitor1", "monitor2"},
+			expectedDues: []int64{1700000000000, 1700000001000},
+		},
+		{
+			name:         "unparseable score keeps id",
+			input:        []interface{}{"monitor1", "abc"},
+			expectedIDs:  []string{"monitor1"},
+			expectedDues: []int64{},
+		},
+		{
+			name:         "empty result",
+			input:        []interface{}{},
+			expectedIDs:  []string{},
+			expectedDues: []int64{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ids, dues := toClaimedPairs(tt.input)
+			assert.Equal(t, tt.expectedIDs, ids)
+			assert.Equal(t, tt.expectedDues, dues)
+		})
+	}
+}
+
 func TestRedisNowMs(t *testing.T) {
 	t.Run("successful Redis time fetch", func(t *testing.T) {
 		// Create a mock Redis client
@@ -2,6 +2,7 @@ package proxy
 
 import (
 	"net/http"
+	"vigi/internal/secret"
 	"vigi/internal/utils"
 
 	"github.com/gin-gonic/gin"
@@ -62,7 +63,7 @@ func (ic *Controller) FindAll(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, utils.NewSuccessResponse("success", entities))
+	ctx.JSON(http.StatusOK, utils.NewSuccessResponse("success", redactAll(entities)))
 }
 
 // @Router		/proxies [post]
@@ -97,7 +98,7 @@ func (ic *Controller) Create(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusCreated, utils.NewSuccessResponse("Proxy created successfully", created))
+	ctx.JSON(http.StatusCreated, utils.NewSuccessResponse("Proxy created successfully", redact(created)))
 }
 
 // @Router		/proxies/{id} [get]
@@ -126,7 +127,7 @@ func (ic *Controller) FindByID(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, utils.NewSuccessResponse("success", entity))
+	ctx.JSON(http.StatusOK, utils.NewSuccessResponse("success", redact(entity)))
 }
 
 // @Router		/proxies/{id} [put]
@@ -165,7 +166,7 @@ func (ic *Controller) UpdateFull(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, utils.NewSuccessResponse("proxy updated successfully", updated))
+	ctx.JSON(http.StatusOK, utils.NewSuccessResponse("proxy updated successfully", redact(updated)))
 }
 
 // @Router		/proxies/{id} [patch]
@@ -196,7 +197,7 @@ func (ic *Controller) UpdatePartial(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, utils.NewSuccessResponse("proxy updated successfully", updated))
+	ctx.JSON(http.StatusOK, utils.NewSuccessResponse("proxy updated successfully", redact(updated)))
 }
 
 // @Router		/proxies/{id} [delete]
@@ -221,3 +222,21 @@ func (ic *Controller) Delete(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, utils.NewSuccessResponse[any]("Proxy deleted successfully", nil))
 }
+
+// redact returns a copy of the proxy safe to send to clients
+func redact(entity *Model) *Model {
+	if entity == nil {
+		return nil
+	}
+	redacted := *entity
+	redacted.Password = secret.Redact(entity.Password)
+	return &redacted
+}
+
+func redactAll(entities []*Model) []*Model {
+	redacted := make([]*Model, 0, len(entities))
+	for _, entity := range entities {
+		redacted = append(redacted, redact(entity))
+	}
+	return redacted
+}
@@ -9,6 +9,7 @@ import (
 
 func RegisterDependencies(container *dig.Container, cfg *config.Config) {
 	utils.RegisterRepositoryByDBType(container, cfg, NewSQLRepository, NewMongoRepository)
+	container.Decorate(NewEncryptedRepository)
 	container.Provide(NewService)
 	container.Provide(NewController)
 	container.Provide(NewRoute)
@@ -0,0 +1,79 @@
+package proxy
+
+import (
+	"context"
+	"vigi/internal/secret"
+)
+
+// encryptedRepository encrypts proxy passwords before they reach the
+// database and decrypts them on the way back.
+type encryptedRepository struct {
+	Repository
+	keyring *secret.Keyring
+}
+
+func NewEncryptedRepository(repository Repository, keyring *secret.Keyring) Repository {
+	return &encryptedRepository{repository, keyring}
+}
+
+func (r *encryptedRepository) decrypt(m *Model, err error) (*Model, error) {
+	if err != nil || m == nil {
+		return m, err
+	}
+	m.Password, err = r.keyring.Decrypt(m.Password)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (r *encryptedRepository) Create(ctx context.Context, entity *Model) (*Model, error) {
+	password, err := r.keyring.Encrypt(entity.Password)
+	if err != nil {
+		return nil, err
+	}
+	encrypted := *entity
+	encrypted.Password = password
+	return r.decrypt(r.Repository.Create(ctx, &encrypted))
+}
+
+func (r *encryptedRepository) FindByID(ctx context.Context, id string, orgID string) (*Model, error) {
+	return r.decrypt(r.Repository.FindByID(ctx, id, orgID))
+}
+
+func (r *encryptedRepository) FindAll(ctx context.Context, page int, limit int, q string, orgID string) ([]*Model, error) {
+	entities, err := r.Repository.FindAll(ctx, page, limit, q, orgID)
+	if err != nil {
+		return nil, err
+	}
+	for _, entity := range entities {
+		if _, err := r.decrypt(entity, nil); err != nil {
+			return nil, err
+		}
+	}
+	return entities, nil
+}
+
+func (r *encryptedRepository) UpdateFull(ctx context.Context, id string, entity *Model, orgID string) (*Model, error) {
+	password, err := r.keyring.Encrypt(entity.Password)
+	if err != nil {
+		return nil, err
+	}
+	encrypted := *entity
+	encrypted.Password = password
+	return r.decrypt(r.Repository.UpdateFull(ctx, id, &encrypted, orgID))
+}
+
+func (r *encryptedRepository) UpdatePartial(ctx context.Context, id string, entity *UpdateModel, orgID string) (*Model, error) {
+	if entity.Password == nil {
+		return r.decrypt(r.Repository.UpdatePartial(ctx, id, entity, orgID))
+	}
+
+	password, err := r.keyring.Encrypt(*entity.Password)
+	if err != nil {
+		return nil, err
+	}
+	encrypted := *entity
+	encrypted.Password = &password
+	return r.decrypt(r.Repository.UpdatePartial(ctx, id, &encrypted, orgID))
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"vigi/internal/modules/events"
 	"vigi/internal/modules/monitor"
+	"vigi/internal/secret"
 
 	"go.uber.org/dig"
 	"go.uber.org/zap"
@@ -64,6 +65,18 @@ func (mr *ServiceImpl) FindAll(ctx context.Context, page int, limit int, q strin
 }
 
 func (mr *ServiceImpl) UpdateFull(ctx context.Context, id string, entity *CreateUpdateDto, orgID string) (*Model, error) {
+	password := entity.Password
+	if password == secret.Mask {
+		existing, err := mr.repository.FindByID(ctx, id, orgID)
+		if err != nil {
+			return nil, err
+		}
+		password = ""
+		if existing != nil {
+			password = existing.Password
+		}
+	}
+
 	model := &Model{
 		OrgID:    orgID,
 		Protocol: entity.Protocol,
@@ -71,7 +84,7 @@ func (mr *ServiceImpl) UpdateFull(ctx context.Context, id string, entity *Create
 		Port:     entity.Port,
 		Auth:     entity.Auth,
 		Username: entity.Username,
-		Password: entity.Password,
+		Password: password,
 	}
 	updated, err := mr.repository.UpdateFull(ctx, id, model, orgID)
 	if err != nil {
@@ -89,13 +102,19 @@ func (mr *ServiceImpl) UpdateFull(ctx context.Context, id string, entity *Create
 }
 
 func (mr *ServiceImpl) UpdatePartial(ctx context.Context, id string, entity *PartialUpdateDto, orgID string) (*Model, error) {
+	// The masked password sent back by clients keeps the stored one
+	password := entity.Password
+	if password != nil && *password == secret.Mask {
+		password = nil
+	}
+
 	updateModel := &UpdateModel{
 		Protocol: entity.Protocol,
 		Host:     entity.Host,
 		Port:     entity.Port,
 		Auth:     entity.Auth,
 		Username: entity.Username,
-		Password: entity.Password,
+		Password: password,
 	}
 	updated, err := mr.repository.UpdatePartial(ctx, id, updateModel, orgID)
 	if err != nil {
@@ -138,6 +138,11 @@ func (m *MockMonitorService) ValidateMonitorConfig(monitorType string, configJSO
 	return args.Error(0)
 }
 
+func (m *MockMonitorService) RestoreSecrets(ctx context.Context, id string, config string, orgID string) (string, error) {
+	args := m.Called(ctx, id, config, orgID)
+	return args.String(0), args.Error(1)
+}
+
 func (m *MockMonitorService) GetHeartbeats(ctx context.Context, id string, limit, page int, important *bool, reverse bool, orgID string) ([]*heartbeat.Model, error) {
 	args := m.Called(ctx, id, limit, page, important, reverse, orgID)
 	if args.Get(0) == nil {
@@ -196,6 +201,14 @@ func (m *MockMonitorService) FindActivePaginated(ctx context.Context, page int,
 	return args.Get(0).([]*shared.Monitor), args.Error(1)
 }
 
+func (m *MockMonitorService) FindAllPaginated(ctx context.Context, page int, limit int) ([]*shared.Monitor, error) {
+	args := m.Called(ctx, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*shared.Monitor), args.Error(1)
+}
+
 func TestNewService(t *testing.T) {
 	// Setup
 	mockRepo := new(MockRepository)
@@ -2,6 +2,7 @@ package recurring_invoice
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -12,4 +13,6 @@ type Repository interface {
 	GetByOrganizationID(ctx context.Context, orgID uuid.UUID, filter RecurringInvoiceFilter) ([]*RecurringInvoice, int, error)
 	Update(ctx context.Context, entity *RecurringInvoice) error
 	Delete(ctx context.Context, id uuid.UUID) error
+	GetDue(ctx context.Context, now time.Time, limit int) ([]*RecurringInvoice, error)
+	UpdateNextGenerationDate(ctx context.Context, id uuid.UUID, next time.Time) error
 }
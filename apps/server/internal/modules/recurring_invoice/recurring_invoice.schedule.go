@@ -0,0 +1,71 @@
+package recurring_invoice
+
+import "time"
+
+const (
+	FrequencyDaily   = "DAILY"
+	FrequencyWeekly  = "WEEKLY"
+	FrequencyMonthly = "MONTHLY"
+	FrequencyYearly  = "YEARLY"
+)
+
+// nextOccurrence returns the generation date that follows the given one according to
+// Frequency/Interval, honouring DayOfWeek (0 = Sunday) for weekly schedules and
+// DayOfMonth/Month for monthly and yearly schedules. Days past the end of a month are
+// clamped to its last day (e.g. day 31 in February).
+func nextOccurrence(r *RecurringInvoice, from time.Time) time.Time {
+	interval := r.Interval
+	if interval < 1 {
+		interval = 1
+	}
+
+	switch r.Frequency {
+	case FrequencyDaily:
+		return from.AddDate(0, 0, interval)
+	case FrequencyWeekly:
+		next := from.AddDate(0, 0, interval*7)
+		if r.DayOfWeek != nil && *r.DayOfWeek >= 0 && *r.DayOfWeek <= 6 {
+			diff := (*r.DayOfWeek - int(next.Weekday()) + 7) % 7
+			next = next.AddDate(0, 0, diff)
+		}
+		return next
+	case FrequencyYearly:
+		month := from.Month()
+		if r.Month != nil && *r.Month >= 1 && *r.Month <= 12 {
+			month = time.Month(*r.Month)
+		}
+		return dateInMonth(from, from.Year()+interval, month, dayOfMonth(r, from))
+	default:
+		// MONTHLY, and the fallback for unknown frequencies
+		year, month := from.Year(), from.Month()+time.Month(interval)
+		return dateInMonth(from, year, month, dayOfMonth(r, from))
+	}
+}
+
+// advanceNextGenerationDate moves the schedule past both the generated period and now.
+// Periods missed while the scheduler was not running are skipped rather than backfilled.
+func advanceNextGenerationDate(r *RecurringInvoice, period, now time.Time) time.Time {
+	next := nextOccurrence(r, period)
+	for !next.After(now) {
+		next = nextOccurrence(r, next)
+	}
+	return next
+}
+
+func dayOfMonth(r *RecurringInvoice, from time.Time) int {
+	if r.DayOfMonth != nil && *r.DayOfMonth >= 1 && *r.DayOfMonth <= 31 {
+		return *r.DayOfMonth
+	}
+	return from.Day()
+}
+
+// dateInMonth builds a date in the given year/month keeping the time of day of ref,
+// clamping day to the last day of that month. Months beyond 12 roll over into later years.
+func dateInMonth(ref time.Time, year int, month time.Month, day int) time.Time {
+	firstOfMonth := time.Date(year, month, 1, ref.Hour(), ref.Minute(), ref.Second(), ref.Nanosecond(), ref.Location())
+	lastDay := firstOfMonth.AddDate(0, 1, -1).Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return firstOfMonth.AddDate(0, 0, day-1)
+}
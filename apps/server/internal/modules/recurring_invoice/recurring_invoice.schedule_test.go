@@ -0,0 +1,148 @@
+package recurring_invoice
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func intPtr(v int) *int { return &v }
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 9, 30, 0, 0, time.UTC)
+}
+
+func TestNextOccurrence(t *testing.T) {
+	tests := []struct {
+		name      string
+		recurring RecurringInvoice
+		from      time.Time
+		expected  time.Time
+	}{
+		{
+			name:      "daily with interval",
+			recurring: RecurringInvoice{Frequency: FrequencyDaily, Interval: 3},
+			from:      date(2026, time.January, 30),
+			expected:  date(2026, time.February, 2),
+		},
+		{
+			name:      "weekly keeps weekday",
+			recurring: RecurringInvoice{Frequency: FrequencyWeekly, Interval: 2},
+			from:      date(2026, time.January, 5),
+			expected:  date(2026, time.January, 19),
+		},
+		{
+			name:      "weekly aligns to day of week",
+			recurring: RecurringInvoice{Frequency: FrequencyWeekly, Interval: 1, DayOfWeek: intPtr(int(time.Friday))},
+			from:      date(2026, time.January, 5), // Monday
+			expected:  date(2026, time.January, 16),
+		},
+		{
+			name:      "monthly uses day of month",
+			recurring: RecurringInvoice{Frequency: FrequencyMonthly, Interval: 1, DayOfMonth: intPtr(5)},
+			from:      date(2026, time.January, 5),
+			expected:  date(2026, time.February, 5),
+		},
+		{
+			name:      "monthly clamps to end of month",
+			recurring: RecurringInvoice{Frequency: FrequencyMonthly, Interval: 1, DayOfMonth: intPtr(31)},
+			from:      date(2026, time.January, 31),
+			expected:  date(2026, time.February, 28),
+		},
+		{
+			name:      "monthly restores day after short month",
+			recurring: RecurringInvoice{Frequency: FrequencyMonthly, Interval: 1, DayOfMonth: intPtr(31)},
+			from:      date(2026, time.February, 28),
+			expected:  date(2026, time.March, 31),
+		},
+		{
+			name:      "monthly rolls over year",
+			recurring: RecurringInvoice{Frequency: FrequencyMonthly, Interval: 3},
+			from:      date(2026, time.November, 10),
+			expected:  date(2027, time.February, 10),
+		},
+		{
+			name:      "yearly uses month and day",
+			recurring: RecurringInvoice{Frequency: FrequencyYearly, Interval: 1, Month: intPtr(3), DayOfMonth: intPtr(15)},
+			from:      date(2026, time.March, 15),
+			expected:  date(2027, time.March, 15),
+		},
+		{
+			name:      "yearly leap day clamps",
+			recurring: RecurringInvoice{Frequency: FrequencyYearly, Interval: 1},
+			from:      date(2028, time.February, 29),
+			expected:  date(2029, time.February, 28),
+		},
+		{
+			name:      "zero interval defaults to one",
+			recurring: RecurringInvoice{Frequency: FrequencyDaily, Interval: 0},
+			from:      date(2026, time.January, 1),
+			expected:  date(2026, time.January, 2),
+		},
+		{
+			name:      "unknown frequency defaults to monthly",
+			recurring: RecurringInvoice{Frequency: "HOURLY", Interval: 1},
+			from:      date(2026, time.January, 10),
+			expected:  date(2026, time.February, 10),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, nextOccurrence(&tt.recurring, tt.from))
+		})
+	}
+}
+
+func TestAdvanceNextGenerationDate(t *testing.T) {
+	t.Run("advances one period when on schedule", func(t *testing.T) {
+		r := &RecurringInvoice{Frequency: FrequencyMonthly, Interval: 1}
+		next := advanceNextGenerationDate(r, date(2026, time.January, 10), date(2026, time.January, 10).Add(time.Minute))
+		assert.Equal(t, date(2026, time.February, 10), next)
+	})
+
+	t.Run("skips periods missed while not running", func(t *testing.T) {
+		r := &RecurringInvoice{Frequency: FrequencyMonthly, Interval: 1}
+		next := advanceNextGenerationDate(r, date(2026, time.January, 10), date(2026, time.April, 2))
+		assert.Equal(t, date(2026, time.April, 10), next)
+	})
+
+	t.Run("early manual generation moves to the following period", func(t *testing.T) {
+		r := &RecurringInvoice{Frequency: FrequencyWeekly, Interval: 1}
+		next := advanceNextGenerationDate(r, date(2026, time.January, 19), date(2026, time.January, 12))
+		assert.Equal(t, date(2026, time.January, 26), next)
+	})
+}
+
+func TestBuildInvoiceDTO(t *testing.T) {
+	issue := date(2026, time.January, 1)
+	due := issue.AddDate(0, 0, 10)
+	period := date(2026, time.February, 5)
+	now := period.Add(time.Minute)
+
+	r := &RecurringInvoice{
+		ID:       uuid.New(),
+		ClientID: uuid.New(),
+		Number:   "REC-001",
+		Date:     &issue,
+		DueDate:  &due,
+		Discount: 5,
+		Items: []*RecurringInvoiceItem{
+			{Description: "Hosting", Quantity: 2, UnitPrice: 50},
+		},
+	}
+
+	dto := buildInvoiceDTO(r, period, now)
+
+	assert.Equal(t, "REC-001-20260205", dto.Number)
+	assert.Equal(t, r.ClientID, dto.ClientID)
+	assert.Equal(t, now, *dto.Date)
+	assert.Equal(t, now.AddDate(0, 0, 10), *dto.DueDate)
+	assert.Equal(t, r.ID, *dto.RecurringInvoiceID)
+	assert.Equal(t, period, *dto.RecurrencePeriod)
+	assert.Equal(t, 5.0, dto.Discount)
+	assert.Len(t, dto.Items, 1)
+	assert.Equal(t, 100.0, dto.Items[0].Quantity*dto.Items[0].UnitPrice)
+}
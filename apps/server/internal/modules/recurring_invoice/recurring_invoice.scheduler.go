@@ -0,0 +1,89 @@
+package recurring_invoice
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// SchedulerLeaderKey is the Redis key used to elect the node that generates recurring invoices
+	SchedulerLeaderKey = "vigi:recurring-invoice:leader"
+	// SchedulerInterval is how often due recurring invoices are checked
+	SchedulerInterval = time.Minute
+)
+
+// Leader reports whether the current node holds leadership
+type Leader interface {
+	IsLeader() bool
+}
+
+// Scheduler periodically generates invoices for due recurring invoices.
+// Only the elected leader generates, so running several API replicas is safe.
+type Scheduler struct {
+	service  *Service
+	leader   Leader
+	interval time.Duration
+	logger   *zap.SugaredLogger
+	stopChan chan struct{}
+	doneChan chan struct{}
+}
+
+// NewScheduler creates a recurring invoice scheduler
+func NewScheduler(service *Service, leader Leader, logger *zap.SugaredLogger) *Scheduler {
+	return &Scheduler{
+		service:  service,
+		leader:   leader,
+		interval: SchedulerInterval,
+		logger:   logger.Named("[recurring-invoice-scheduler]"),
+		stopChan: make(chan struct{}),
+		doneChan: make(chan struct{}),
+	}
+}
+
+// Start begins checking for due recurring invoices in the background
+func (s *Scheduler) Start(ctx context.Context) {
+	s.logger.Infow("Starting recurring invoice scheduler", "interval", s.interval)
+
+	go func() {
+		defer close(s.doneChan)
+
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				s.RunOnce(ctx)
+			case <-s.stopChan:
+				s.logger.Info("Stopping recurring invoice scheduler")
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops the scheduler and waits for the current run to finish
+func (s *Scheduler) Stop() {
+	close(s.stopChan)
+	<-s.doneChan
+}
+
+// RunOnce generates all due recurring invoices if this node is the leader
+func (s *Scheduler) RunOnce(ctx context.Context) {
+	if !s.leader.IsLeader() {
+		return
+	}
+
+	generated, err := s.service.GenerateDue(ctx, time.Now())
+	if err != nil {
+		s.logger.Errorw("Failed to generate some recurring invoices", "generated", generated, "error", err)
+		return
+	}
+	if generated > 0 {
+		s.logger.Infow("Generated recurring invoices", "count", generated)
+	}
+}
@@ -2,6 +2,7 @@ package recurring_invoice
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 	"vigi/internal/modules/invoice"
@@ -9,6 +10,9 @@ import (
 	"github.com/google/uuid"
 )
 
+// dueBatchSize bounds how many recurring invoices are generated per scheduler tick
+const dueBatchSize = 100
+
 type Service struct {
 	repo           Repository
 	invoiceService *invoice.Service
@@ -189,35 +193,85 @@ func (s *Service) Delete(ctx context.Context, id uuid.UUID) error {
 	return s.repo.Delete(ctx, id)
 }
 
+// GenerateInvoice generates the invoice for the current period of a recurring invoice
+// and advances its NextGenerationDate
 func (s *Service) GenerateInvoice(ctx context.Context, id uuid.UUID) (*invoice.Invoice, error) {
-	// 1. Fetch Recurring Invoice
 	recurring, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
+	return s.generate(ctx, recurring, time.Now())
+}
+
+// GenerateDue generates invoices for ACTIVE recurring invoices whose NextGenerationDate
+// has passed. It returns the number of recurring invoices processed successfully.
+func (s *Service) GenerateDue(ctx context.Context, now time.Time) (int, error) {
+	due, err := s.repo.GetDue(ctx, now.UTC(), dueBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	var errs []error
+	generated := 0
+	for _, recurring := range due {
+		if _, err := s.generate(ctx, recurring, now); err != nil {
+			errs = append(errs, fmt.Errorf("recurring invoice %s: %w", recurring.ID, err))
+			continue
+		}
+		generated++
+	}
+
+	return generated, errors.Join(errs...)
+}
 
-	// 2. Calculate Date and Due Date
-	// Logic: Date = Now. DueDate = Now + (Rec.DueDate - Rec.Date)
-	// If original dates are nil, default to Now + 3 days or something, but usually they are required or logic handles it.
-	// As per model: Date and DueDate are pointers.
-	now := time.Now()
-	var dueDate time.Time
+// generate creates the invoice for the recurring invoice's current period and moves
+// NextGenerationDate forward. Generated invoices are keyed by (recurring invoice, period),
+// so running it again for an already generated period only advances the schedule.
+func (s *Service) generate(ctx context.Context, recurring *RecurringInvoice, now time.Time) (*invoice.Invoice, error) {
+	period := now
+	if recurring.NextGenerationDate != nil {
+		period = *recurring.NextGenerationDate
+	}
+	period = period.UTC().Truncate(time.Second)
 
+	generated, err := s.invoiceService.GetByRecurrence(ctx, recurring.ID, period)
+	if err != nil {
+		return nil, err
+	}
+
+	if generated == nil {
+		generated, err = s.invoiceService.Create(ctx, recurring.OrganizationID, buildInvoiceDTO(recurring, period, now))
+		if err != nil {
+			// Another run may have generated this period concurrently
+			existing, lookupErr := s.invoiceService.GetByRecurrence(ctx, recurring.ID, period)
+			if lookupErr != nil || existing == nil {
+				return nil, err
+			}
+			generated = existing
+		}
+	}
+
+	next := advanceNextGenerationDate(recurring, period, now)
+	if err := s.repo.UpdateNextGenerationDate(ctx, recurring.ID, next); err != nil {
+		return generated, fmt.Errorf("invoice generated but failed to advance next generation date: %w", err)
+	}
+	recurring.NextGenerationDate = &next
+
+	return generated, nil
+}
+
+// buildInvoiceDTO maps a recurring invoice onto the invoice generated for a period.
+// The due date keeps the same offset from the issue date as the recurring template.
+func buildInvoiceDTO(recurring *RecurringInvoice, period, now time.Time) invoice.CreateInvoiceDTO {
+	dueDate := now
 	if recurring.Date != nil && recurring.DueDate != nil {
-		// Calculate duration/offset
-		offset := recurring.DueDate.Sub(*recurring.Date)
-		dueDate = now.Add(offset)
-	} else {
-		// Default to 7 days if unknown? Or per user request "dia 5, e a data de vencimento ta + 10 dias" => implies offset.
-		// If data is missing, we set DueDate = Date (immediate) or standard offset.
-		dueDate = now
+		dueDate = now.Add(recurring.DueDate.Sub(*recurring.Date))
 	}
 
-	// 3. Prepare Invoice Items
-	invoiceItems := make([]invoice.CreateInvoiceItemDTO, 0, len(recurring.Items))
+	items := make([]invoice.CreateInvoiceItemDTO, 0, len(recurring.Items))
 	for _, item := range recurring.Items {
-		invoiceItems = append(invoiceItems, invoice.CreateInvoiceItemDTO{
-			CatalogItemID: item.CatalogItemID, // Direct mapping if pointer types match
+		items = append(items, invoice.CreateInvoiceItemDTO{
+			CatalogItemID: item.CatalogItemID,
 			Description:   item.Description,
 			Quantity:      float64(item.Quantity),
 			UnitPrice:     float64(item.UnitPrice),
@@ -225,83 +279,16 @@ func (s *Service) GenerateInvoice(ctx context.Context, id uuid.UUID) (*invoice.I
 		})
 	}
 
-	// 4. Generate Number?
-	// Invoice service usually handles number generation if not provided?
-	// CreateInvoiceDTO requires Number string.
-	// We need to generate a new number. "INV-" + timestamp? or let service handle it?
-	// Existing UpdateInvoiceDTO has Number *string. Create has Number string.
-	// Check recurring.Number. It might be the "template" number? No, recurring invoice has its own number "REC-001".
-	// Generated invoice needs new number.
-	// For now, I'll generate a temp number and let user edit, or use random.
-	// Better: Use "REC-{Ref}-" + timestamp or something.
-	// Let's use a placeholder and ideally the service or a number generator handles it.
-	// NOTE: Client repo usually has "GetNextNumber".
-	// I will generate a simple unique number for now: "INV-" + uuid prefix or similar.
-	// Actually, the user asked for "Proximo Fatura".
-	// Let's use format "INV-{Random}" for now.
-	newNumber := fmt.Sprintf("INV-%d", time.Now().Unix())
-
-	// 5. Create Invoice
-	dto := invoice.CreateInvoiceDTO{
-		ClientID: recurring.ClientID,
-		Number:   newNumber,
-		Date:     &now,
-		DueDate:  &dueDate,
-		Items:    invoiceItems,
-		Terms:    recurring.Terms, // Passed as string, not pointer
-		Notes:    recurring.Notes, // Passed as string, not pointer
-		Discount: float64(recurring.Discount),
-	}
-
-	newInvoice, err := s.invoiceService.Create(ctx, recurring.OrganizationID, dto)
-	if err != nil {
-		return nil, err
+	return invoice.CreateInvoiceDTO{
+		ClientID:           recurring.ClientID,
+		Number:             fmt.Sprintf("%s-%s", recurring.Number, period.Format("20060102")),
+		Date:               &now,
+		DueDate:            &dueDate,
+		Items:              items,
+		Terms:              recurring.Terms,
+		Notes:              recurring.Notes,
+		Discount:           float64(recurring.Discount),
+		RecurringInvoiceID: &recurring.ID,
+		RecurrencePeriod:   &period,
 	}
-
-	// 6. Update NextGenerationDate
-	// Calculate next date based on Frequency/Interval
-	if recurring.NextGenerationDate == nil {
-		// If nil, start from now
-		current := time.Now()
-		recurring.NextGenerationDate = &current
-	}
-
-	// Determine next date
-	// Simple logic: Add interval based on frequency
-	var nextDate time.Time
-	// Use recurring.NextGenerationDate as base or Now?
-	// "edita a proxima cobran'ca" implies moving the schedule forward.
-	// If we run it early, should we push the next one? Yes.
-	baseDate := *recurring.NextGenerationDate
-	if baseDate.Before(now) {
-		baseDate = now
-	}
-
-	// Add logic for frequency
-	switch recurring.Frequency {
-	case "DAILY":
-		nextDate = baseDate.AddDate(0, 0, recurring.Interval)
-	case "WEEKLY":
-		nextDate = baseDate.AddDate(0, 0, recurring.Interval*7)
-	case "MONTHLY":
-		nextDate = baseDate.AddDate(0, recurring.Interval, 0)
-	case "YEARLY":
-		nextDate = baseDate.AddDate(recurring.Interval, 0, 0)
-	default:
-		nextDate = baseDate.AddDate(0, 1, 0) // Default monthly
-	}
-
-	recurring.NextGenerationDate = &nextDate
-	// Also update UpdatedAt? Handled by hook.
-
-	if err := s.repo.Update(ctx, recurring); err != nil {
-		// Log warning? Invoice already created.
-		// Return error?
-		return newInvoice, nil // Return success even if schedule update fails? No, better warn.
-		// For strictness, return nil, err? But invoice exists.
-		// I will return the invoice and nil error, but ideally transactional.
-		// Ignoring transaction for now as it crosses services.
-	}
-
-	return newInvoice, nil
 }
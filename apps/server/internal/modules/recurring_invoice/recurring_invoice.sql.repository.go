@@ -2,6 +2,7 @@ package recurring_invoice
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/uptrace/bun"
@@ -98,3 +99,30 @@ func (r *SQLRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	_, err := r.db.NewDelete().Model((*RecurringInvoice)(nil)).Where("id = ?", id).Exec(ctx)
 	return err
 }
+
+func (r *SQLRepository) GetDue(ctx context.Context, now time.Time, limit int) ([]*RecurringInvoice, error) {
+	var entities []*RecurringInvoice
+	query := r.db.NewSelect().Model(&entities).Relation("Items").
+		Where("rinv.status = ?", RecurringInvoiceStatusActive).
+		Where("rinv.next_generation_date IS NOT NULL").
+		Where("rinv.next_generation_date <= ?", now).
+		Order("rinv.next_generation_date ASC")
+
+	if limit > 0 {
+		query.Limit(limit)
+	}
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, err
+	}
+	return entities, nil
+}
+
+func (r *SQLRepository) UpdateNextGenerationDate(ctx context.Context, id uuid.UUID, next time.Time) error {
+	_, err := r.db.NewUpdate().Model((*RecurringInvoice)(nil)).
+		Set("next_generation_date = ?", next).
+		Set("updated_at = ?", time.Now()).
+		Where("id = ?", id).
+		Exec(ctx)
+	return err
+}
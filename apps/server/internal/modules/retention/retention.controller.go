@@ -0,0 +1,211 @@
+package retention
+
+import (
+	"errors"
+	"net/http"
+	"vigi/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type Controller struct {
+	service Service
+	logger  *zap.SugaredLogger
+}
+
+func NewController(
+	service Service,
+	logger *zap.SugaredLogger,
+) *Controller {
+	return &Controller{
+		service: service,
+		logger:  logger.Named("[retention-controller]"),
+	}
+}
+
+// @Router    /retention-policy [get]
+// @Summary   Get the data retention policy of the organization
+// @Tags      Retention
+// @Produce   json
+// @Security  JwtAuth
+// @Security  ApiKeyAuth
+// @Success   200  {object}  utils.ApiResponse[Policy]
+// @Failure   500  {object}  utils.APIError[any]
+func (c *Controller) GetPolicy(ctx *gin.Context) {
+	orgID := ctx.GetString("orgId")
+
+	policy, err := c.service.GetPolicy(ctx, orgID)
+	if err != nil {
+		c.logger.Errorw("Failed to get retention policy", "error", err)
+		ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse("Internal server error"))
+		return
+	}
+	ctx.JSON(http.StatusOK, utils.NewSuccessResponse("success", policy))
+}
+
+// @Router    /retention-policy [put]
+// @Summary   Update the data retention policy of the organization
+// @Tags      Retention
+// @Accept    json
+// @Produce   json
+// @Security  JwtAuth
+// @Security  ApiKeyAuth
+// @Param     body body PolicyDTO true "Retention policy"
+// @Success   200  {object} utils.ApiResponse[Policy]
+// @Failure   400  {object} utils.APIError[any]
+// @Failure   500  {object} utils.APIError[any]
+func (c *Controller) UpdatePolicy(ctx *gin.Context) {
+	dto, ok := bindPolicyDTO(ctx)
+	if !ok {
+		return
+	}
+
+	orgID := ctx.GetString("orgId")
+
+	policy, err := c.service.UpdatePolicy(ctx, dto, orgID)
+	if err != nil {
+		c.handleError(ctx, err, "Failed to update retention policy")
+		return
+	}
+	ctx.JSON(http.StatusOK, utils.NewSuccessResponse("Retention policy updated successfully", policy))
+}
+
+// @Router    /retention-policy [delete]
+// @Summary   Reset the data retention policy of the organization to the instance defaults
+// @Tags      Retention
+// @Produce   json
+// @Security  JwtAuth
+// @Security  ApiKeyAuth
+// @Success   200  {object} utils.ApiResponse[any]
+// @Failure   500  {object} utils.APIError[any]
+func (c *Controller) ResetPolicy(ctx *gin.Context) {
+	orgID := ctx.GetString("orgId")
+
+	if err := c.service.ResetPolicy(ctx, orgID); err != nil {
+		c.logger.Errorw("Failed to reset retention policy", "error", err)
+		ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse("Internal server error"))
+		return
+	}
+	ctx.JSON(http.StatusOK, utils.NewSuccessResponse[any]("Retention policy reset successfully", nil))
+}
+
+// @Router    /retention-policy/monitors [get]
+// @Summary   Get the monitors overriding the organization retention policy
+// @Tags      Retention
+// @Produce   json
+// @Security  JwtAuth
+// @Security  ApiKeyAuth
+// @Success   200  {object}  utils.ApiResponse[[]Policy]
+// @Failure   500  {object}  utils.APIError[any]
+func (c *Controller) FindMonitorPolicies(ctx *gin.Context) {
+	orgID := ctx.GetString("orgId")
+
+	policies, err := c.service.FindMonitorPolicies(ctx, orgID)
+	if err != nil {
+		c.logger.Errorw("Failed to get monitor retention policies", "error", err)
+		ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse("Internal server error"))
+		return
+	}
+	ctx.JSON(http.StatusOK, utils.NewSuccessResponse("success", policies))
+}
+
+// @Router    /retention-policy/monitors/{id} [get]
+// @Summary   Get the retention policy that applies to a monitor
+// @Tags      Retention
+// @Produce   json
+// @Security  JwtAuth
+// @Security  ApiKeyAuth
+// @Param     id   path      string  true  "Monitor ID"
+// @Success   200  {object}  utils.ApiResponse[Policy]
+// @Failure   404  {object}  utils.APIError[any]
+// @Failure   500  {object}  utils.APIError[any]
+func (c *Controller) GetMonitorPolicy(ctx *gin.Context) {
+	id := ctx.Param("id")
+	orgID := ctx.GetString("orgId")
+
+	policy, err := c.service.GetMonitorPolicy(ctx, id, orgID)
+	if err != nil {
+		c.handleError(ctx, err, "Failed to get monitor retention policy")
+		return
+	}
+	ctx.JSON(http.StatusOK, utils.NewSuccessResponse("success", policy))
+}
+
+// @Router    /retention-policy/monitors/{id} [put]
+// @Summary   Override the retention policy for a monitor
+// @Tags      Retention
+// @Accept    json
+// @Produce   json
+// @Security  JwtAuth
+// @Security  ApiKeyAuth
+// @Param     id   path     string    true  "Monitor ID"
+// @Param     body body     PolicyDTO true  "Retention policy"
+// @Success   200  {object} utils.ApiResponse[Policy]
+// @Failure   400  {object} utils.APIError[any]
+// @Failure   404  {object} utils.APIError[any]
+// @Failure   500  {object} utils.APIError[any]
+func (c *Controller) UpdateMonitorPolicy(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	dto, ok := bindPolicyDTO(ctx)
+	if !ok {
+		return
+	}
+
+	orgID := ctx.GetString("orgId")
+
+	policy, err := c.service.UpdateMonitorPolicy(ctx, id, dto, orgID)
+	if err != nil {
+		c.handleError(ctx, err, "Failed to update monitor retention policy")
+		return
+	}
+	ctx.JSON(http.StatusOK, utils.NewSuccessResponse("Retention policy updated successfully", policy))
+}
+
+// @Router    /retention-policy/monitors/{id} [delete]
+// @Summary   Remove the retention override of a monitor
+// @Tags      Retention
+// @Produce   json
+// @Security  JwtAuth
+// @Security  ApiKeyAuth
+// @Param     id   path     string  true  "Monitor ID"
+// @Success   200  {object} utils.ApiResponse[any]
+// @Failure   500  {object} utils.APIError[any]
+func (c *Controller) DeleteMonitorPolicy(ctx *gin.Context) {
+	id := ctx.Param("id")
+	orgID := ctx.GetString("orgId")
+
+	if err := c.service.DeleteMonitorPolicy(ctx, id, orgID); err != nil {
+		c.logger.Errorw("Failed to delete monitor retention policy", "error", err, "id", id)
+		ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse("Internal server error"))
+		return
+	}
+	ctx.JSON(http.StatusOK, utils.NewSuccessResponse[any]("Retention policy deleted successfully", nil))
+}
+
+func (c *Controller) handleError(ctx *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, ErrInvalidRetention):
+		ctx.JSON(http.StatusBadRequest, utils.NewFailResponse(err.Error()))
+	case errors.Is(err, ErrMonitorNotFound):
+		ctx.JSON(http.StatusNotFound, utils.NewFailResponse("Monitor not found"))
+	default:
+		c.logger.Errorw(message, "error", err)
+		ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse("Internal server error"))
+	}
+}
+
+func bindPolicyDTO(ctx *gin.Context) (*PolicyDTO, bool) {
+	var dto PolicyDTO
+	if err := ctx.ShouldBindJSON(&dto); err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.NewFailResponse(err.Error()))
+		return nil, false
+	}
+
+	if err := utils.Validate.Struct(&dto); err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.NewFailResponse(err.Error()))
+		return nil, false
+	}
+	return &dto, true
+}
@@ -0,0 +1,23 @@
+package retention
+
+import (
+	"vigi/internal/config"
+	"vigi/internal/modules/heartbeat"
+	"vigi/internal/modules/monitor"
+	"vigi/internal/modules/setting"
+	"vigi/internal/modules/stats"
+	"vigi/internal/utils"
+
+	"go.uber.org/dig"
+)
+
+func RegisterDependencies(container *dig.Container, cfg *config.Config) {
+	utils.RegisterRepositoryByDBType(container, cfg, NewSQLRepository, NewMongoRepository)
+	container.Provide(func(s monitor.Service) MonitorService { return s })
+	container.Provide(func(s heartbeat.Service) HeartbeatService { return s })
+	container.Provide(func(s stats.Service) StatsService { return s })
+	container.Provide(func(s setting.Service) SettingService { return s })
+	container.Provide(NewService)
+	container.Provide(NewController)
+	container.Provide(NewRoute)
+}
@@ -0,0 +1,8 @@
+package retention
+
+type PolicyDTO struct {
+	HeartbeatDays int `json:"heartbeat_days" validate:"min=1,max=3650" example:"90"`
+	MinutelyDays  int `json:"minutely_days" validate:"min=0,max=3650" example:"30"`
+	HourlyDays    int `json:"hourly_days" validate:"min=0,max=3650" example:"365"`
+	DailyDays     int `json:"daily_days" validate:"min=0,max=3650" example:"0"`
+}
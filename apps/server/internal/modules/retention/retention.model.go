@@ -0,0 +1,50 @@
+package retention
+
+import (
+	"math"
+	"time"
+	"vigi/internal/modules/stats"
+)
+
+// DefaultHeartbeatDays is used when neither a policy nor the KEEP_DATA_PERIOD_DAYS setting is set
+const DefaultHeartbeatDays = 365
+
+// Policy sets for how many days the monitoring data is kept. A policy applies to a
+// whole organization or, when MonitorID is set, overrides it for a single monitor.
+// Stats of a period with zero days are kept forever.
+type Policy struct {
+	ID            string    `json:"id"`
+	OrgID         string    `json:"org_id"`
+	MonitorID     string    `json:"monitor_id,omitempty"`
+	HeartbeatDays int       `json:"heartbeat_days"`
+	MinutelyDays  int       `json:"minutely_days"`
+	HourlyDays    int       `json:"hourly_days"`
+	DailyDays     int       `json:"daily_days"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// StatDays returns the retention of every stat period
+func (p *Policy) StatDays() map[stats.StatPeriod]int {
+	return map[stats.StatPeriod]int{
+		stats.StatMinutely: p.MinutelyDays,
+		stats.StatHourly:   p.HourlyDays,
+		stats.StatDaily:    p.DailyDays,
+	}
+}
+
+// valid reports whether every rollup outlives the finer data it is built from, so
+// long-range reports never lose data before a coarser period has it
+func (p *Policy) valid() bool {
+	keep := func(days int) int {
+		if days == 0 {
+			return math.MaxInt
+		}
+		return days
+	}
+
+	hourly := keep(p.HourlyDays)
+	return hourly >= p.HeartbeatDays &&
+		hourly >= keep(p.MinutelyDays) &&
+		keep(p.DailyDays) >= hourly
+}
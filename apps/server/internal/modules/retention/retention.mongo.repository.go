@@ -0,0 +1,190 @@
+package retention
+
+import (
+	"context"
+	"errors"
+	"time"
+	"vigi/internal/config"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoModel struct {
+	ID            primitive.ObjectID `bson:"_id,omitempty"`
+	OrgID         string             `bson:"org_id"`
+	MonitorID     string             `bson:"monitor_id,omitempty"`
+	HeartbeatDays int                `bson:"heartbeat_days"`
+	MinutelyDays  int                `bson:"minutely_days"`
+	HourlyDays    int                `bson:"hourly_days"`
+	DailyDays     int                `bson:"daily_days"`
+	CreatedAt     time.Time          `bson:"created_at"`
+	UpdatedAt     time.Time          `bson:"updated_at"`
+}
+
+type mongoCompaction struct {
+	MonitorID      string    `bson:"monitor_id"`
+	CompactedUntil time.Time `bson:"compacted_until"`
+}
+
+func toDomainModel(mm *mongoModel) *Policy {
+	return &Policy{
+		ID:            mm.ID.Hex(),
+		OrgID:         mm.OrgID,
+		MonitorID:     mm.MonitorID,
+		HeartbeatDays: mm.HeartbeatDays,
+		MinutelyDays:  mm.MinutelyDays,
+		HourlyDays:    mm.HourlyDays,
+		DailyDays:     mm.DailyDays,
+		CreatedAt:     mm.CreatedAt,
+		UpdatedAt:     mm.UpdatedAt,
+	}
+}
+
+type MongoRepository struct {
+	client      *mongo.Client
+	db          *mongo.Database
+	policies    *mongo.Collection
+	compactions *mongo.Collection
+}
+
+func NewMongoRepository(client *mongo.Client, cfg *config.Config) Repository {
+	db := client.Database(cfg.DBName)
+	policies := db.Collection("retention_policies")
+	compactions := db.Collection("retention_compactions")
+
+	// Create indexes
+	go func() {
+		_, _ = policies.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+			{Keys: bson.D{{Key: "org_id", Value: 1}, {Key: "monitor_id", Value: 1}}},
+		})
+		_, _ = compactions.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+			Keys:    bson.D{{Key: "monitor_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		})
+	}()
+
+	return &MongoRepository{
+		client:      client,
+		db:          db,
+		policies:    policies,
+		compactions: compactions,
+	}
+}
+
+func (r *MongoRepository) Create(ctx context.Context, policy *Policy) (*Policy, error) {
+	now := time.Now().UTC()
+	mm := &mongoModel{
+		ID:            primitive.NewObjectID(),
+		OrgID:         policy.OrgID,
+		MonitorID:     policy.MonitorID,
+		HeartbeatDays: policy.HeartbeatDays,
+		MinutelyDays:  policy.MinutelyDays,
+		HourlyDays:    policy.HourlyDays,
+		DailyDays:     policy.DailyDays,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+
+	if _, err := r.policies.InsertOne(ctx, mm); err != nil {
+		return nil, err
+	}
+	return toDomainModel(mm), nil
+}
+
+func (r *MongoRepository) Update(ctx context.Context, policy *Policy) error {
+	objectID, err := primitive.ObjectIDFromHex(policy.ID)
+	if err != nil {
+		return err
+	}
+
+	update := bson.M{"$set": bson.M{
+		"heartbeat_days": policy.HeartbeatDays,
+		"minutely_days":  policy.MinutelyDays,
+		"hourly_days":    policy.HourlyDays,
+		"daily_days":     policy.DailyDays,
+		"updated_at":     time.Now().UTC(),
+	}}
+	_, err = r.policies.UpdateOne(ctx, bson.M{"_id": objectID, "org_id": policy.OrgID}, update)
+	return err
+}
+
+func (r *MongoRepository) Delete(ctx context.Context, id string, orgID string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.policies.DeleteOne(ctx, bson.M{"_id": objectID, "org_id": orgID})
+	return err
+}
+
+func (r *MongoRepository) FindByOrgID(ctx context.Context, orgID string) (*Policy, error) {
+	return r.findOne(ctx, bson.M{"org_id": orgID, "monitor_id": bson.M{"$exists": false}})
+}
+
+func (r *MongoRepository) FindByMonitorID(ctx context.Context, monitorID string, orgID string) (*Policy, error) {
+	return r.findOne(ctx, bson.M{"org_id": orgID, "monitor_id": monitorID})
+}
+
+func (r *MongoRepository) FindMonitorPolicies(ctx context.Context, orgID string) ([]*Policy, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+	return r.find(ctx, bson.M{"org_id": orgID, "monitor_id": bson.M{"$exists": true}}, opts)
+}
+
+func (r *MongoRepository) FindAll(ctx context.Context) ([]*Policy, error) {
+	return r.find(ctx, bson.M{}, options.Find())
+}
+
+func (r *MongoRepository) FindCompactedUntil(ctx context.Context, monitorID string) (time.Time, error) {
+	var mc mongoCompaction
+	err := r.compactions.FindOne(ctx, bson.M{"monitor_id": monitorID}).Decode(&mc)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return time.Time{}, nil
+		}
+		return time.Time{}, err
+	}
+	return mc.CompactedUntil.UTC(), nil
+}
+
+func (r *MongoRepository) SetCompactedUntil(ctx context.Context, monitorID string, until time.Time) error {
+	_, err := r.compactions.UpdateOne(ctx,
+		bson.M{"monitor_id": monitorID},
+		bson.M{"$set": bson.M{"compacted_until": until}},
+		options.Update().SetUpsert(true),
+	)
+	return err
+}
+
+func (r *MongoRepository) findOne(ctx context.Context, filter bson.M) (*Policy, error) {
+	var mm mongoModel
+	if err := r.policies.FindOne(ctx, filter).Decode(&mm); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toDomainModel(&mm), nil
+}
+
+func (r *MongoRepository) find(ctx context.Context, filter bson.M, opts *options.FindOptions) ([]*Policy, error) {
+	cursor, err := r.policies.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var mms []*mongoModel
+	if err := cursor.All(ctx, &mms); err != nil {
+		return nil, err
+	}
+
+	policies := make([]*Policy, 0, len(mms))
+	for _, mm := range mms {
+		policies = append(policies, toDomainModel(mm))
+	}
+	return policies, nil
+}
@@ -0,0 +1,22 @@
+package retention
+
+import (
+	"context"
+	"time"
+)
+
+type Repository interface {
+	Create(ctx context.Context, policy *Policy) (*Policy, error)
+	// Update replaces the retention days of the policy
+	Update(ctx context.Context, policy *Policy) error
+	Delete(ctx context.Context, id string, orgID string) error
+	// FindByOrgID returns the organization wide policy
+	FindByOrgID(ctx context.Context, orgID string) (*Policy, error)
+	FindByMonitorID(ctx context.Context, monitorID string, orgID string) (*Policy, error)
+	FindMonitorPolicies(ctx context.Context, orgID string) ([]*Policy, error)
+	FindAll(ctx context.Context) ([]*Policy, error)
+
+	// FindCompactedUntil returns the day rollups of the monitor were rebuilt until, zero when never
+	FindCompactedUntil(ctx context.Context, monitorID string) (time.Time, error)
+	SetCompactedUntil(ctx context.Context, monitorID string, until time.Time) error
+}
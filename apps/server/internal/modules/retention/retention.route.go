@@ -0,0 +1,39 @@
+package retention
+
+import (
+	"vigi/internal/modules/middleware"
+	"vigi/internal/modules/organization"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Route struct {
+	controller    *Controller
+	middleware    *middleware.AuthChain
+	orgMiddleware *organization.Middleware
+}
+
+func NewRoute(controller *Controller, middleware *middleware.AuthChain, orgMiddleware *organization.Middleware) *Route {
+	return &Route{
+		controller:    controller,
+		middleware:    middleware,
+		orgMiddleware: orgMiddleware,
+	}
+}
+
+func (r *Route) ConnectRoute(rg *gin.RouterGroup, controller *Controller) {
+	policy := rg.Group("retention-policy")
+	policy.Use(r.middleware.AllAuth())
+	policy.Use(r.orgMiddleware.RequireOrganization())
+	policy.Use(r.orgMiddleware.RequireAdmin())
+	{
+		policy.GET("", r.controller.GetPolicy)
+		policy.PUT("", r.controller.UpdatePolicy)
+		policy.DELETE("", r.controller.ResetPolicy)
+
+		policy.GET("/monitors", r.controller.FindMonitorPolicies)
+		policy.GET("/monitors/:id", r.controller.GetMonitorPolicy)
+		policy.PUT("/monitors/:id", r.controller.UpdateMonitorPolicy)
+		policy.DELETE("/monitors/:id", r.controller.DeleteMonitorPolicy)
+	}
+}
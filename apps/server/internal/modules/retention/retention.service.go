@@ -0,0 +1,345 @@
+package retention
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+	"vigi/internal/modules/heartbeat"
+	"vigi/internal/modules/monitor"
+	"vigi/internal/modules/setting"
+	"vigi/internal/modules/stats"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// KeepDataSettingKey is the instance wide heartbeat retention used by organizations without a policy
+	KeepDataSettingKey = "KEEP_DATA_PERIOD_DAYS"
+
+	monitorPageSize = 100
+	day             = 24 * time.Hour
+)
+
+var (
+	ErrInvalidRetention = errors.New("hourly stats must be kept at least as long as heartbeats and minutely stats, and daily stats at least as long as hourly stats")
+	ErrMonitorNotFound  = errors.New("monitor not found")
+)
+
+// MonitorService is the part of monitor.Service the retention job needs
+type MonitorService interface {
+	FindByID(ctx context.Context, id string, orgID string) (*monitor.Model, error)
+	FindAllPaginated(ctx context.Context, page int, limit int) ([]*monitor.Model, error)
+}
+
+// HeartbeatService is the part of heartbeat.Service the retention job needs
+type HeartbeatService interface {
+	FindOldestByMonitorID(ctx context.Context, monitorID string) (*heartbeat.Model, error)
+	FindByMonitorIDAndTimeRange(ctx context.Context, monitorID string, since, until time.Time) ([]*heartbeat.Model, error)
+	DeleteByMonitorIDOlderThan(ctx context.Context, monitorID string, cutoff time.Time) (int64, error)
+}
+
+// StatsService is the part of stats.Service the retention job needs
+type StatsService interface {
+	RebuildRollups(ctx context.Context, monitorID string, heartbeats []*stats.HeartbeatPayload, since, until time.Time) (int, error)
+	DeleteOlderThan(ctx context.Context, monitorID string, period stats.StatPeriod, cutoff time.Time) (int64, error)
+}
+
+// SettingService is the part of setting.Service the retention job needs
+type SettingService interface {
+	GetByKey(ctx context.Context, key string) (*setting.Model, error)
+}
+
+type Service interface {
+	// GetPolicy returns the organization policy, or the instance defaults when it has none
+	GetPolicy(ctx context.Context, orgID string) (*Policy, error)
+	UpdatePolicy(ctx context.Context, dto *PolicyDTO, orgID string) (*Policy, error)
+	// ResetPolicy deletes the organization policy so the instance defaults apply again
+	ResetPolicy(ctx context.Context, orgID string) error
+
+	FindMonitorPolicies(ctx context.Context, orgID string) ([]*Policy, error)
+	// GetMonitorPolicy returns the policy that applies to the monitor, its own or the organization one
+	GetMonitorPolicy(ctx context.Context, monitorID string, orgID string) (*Policy, error)
+	UpdateMonitorPolicy(ctx context.Context, monitorID string, dto *PolicyDTO, orgID string) (*Policy, error)
+	DeleteMonitorPolicy(ctx context.Context, monitorID string, orgID string) error
+
+	// Enforce rebuilds the rollups of every monitor up to the current day, then deletes
+	// the heartbeats and stats that are past the retention of their policy
+	Enforce(ctx context.Context, now time.Time) error
+}
+
+type ServiceImpl struct {
+	repository       Repository
+	monitorService   MonitorService
+	heartbeatService HeartbeatService
+	statsService     StatsService
+	settingService   SettingService
+	logger           *zap.SugaredLogger
+}
+
+func NewService(
+	repository Repository,
+	monitorService MonitorService,
+	heartbeatService HeartbeatService,
+	statsService StatsService,
+	settingService SettingService,
+	logger *zap.SugaredLogger,
+) Service {
+	return &ServiceImpl{
+		repository:       repository,
+		monitorService:   monitorService,
+		heartbeatService: heartbeatService,
+		statsService:     statsService,
+		settingService:   settingService,
+		logger:           logger.Named("[retention-service]"),
+	}
+}
+
+func (s *ServiceImpl) GetPolicy(ctx context.Context, orgID string) (*Policy, error) {
+	policy, err := s.repository.FindByOrgID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if policy != nil {
+		return policy, nil
+	}
+	return s.defaultPolicy(ctx, orgID), nil
+}
+
+func (s *ServiceImpl) UpdatePolicy(ctx context.Context, dto *PolicyDTO, orgID string) (*Policy, error) {
+	existing, err := s.repository.FindByOrgID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	return s.save(ctx, existing, &Policy{OrgID: orgID}, dto)
+}
+
+func (s *ServiceImpl) ResetPolicy(ctx context.Context, orgID string) error {
+	existing, err := s.repository.FindByOrgID(ctx, orgID)
+	if err != nil || existing == nil {
+		return err
+	}
+	return s.repository.Delete(ctx, existing.ID, orgID)
+}
+
+func (s *ServiceImpl) FindMonitorPolicies(ctx context.Context, orgID string) ([]*Policy, error) {
+	return s.repository.FindMonitorPolicies(ctx, orgID)
+}
+
+func (s *ServiceImpl) GetMonitorPolicy(ctx context.Context, monitorID string, orgID string) (*Policy, error) {
+	if err := s.checkMonitor(ctx, monitorID, orgID); err != nil {
+		return nil, err
+	}
+
+	policy, err := s.repository.FindByMonitorID(ctx, monitorID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if policy != nil {
+		return policy, nil
+	}
+	return s.GetPolicy(ctx, orgID)
+}
+
+func (s *ServiceImpl) UpdateMonitorPolicy(ctx context.Context, monitorID string, dto *PolicyDTO, orgID string) (*Policy, error) {
+	if err := s.checkMonitor(ctx, monitorID, orgID); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repository.FindByMonitorID(ctx, monitorID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	return s.save(ctx, existing, &Policy{OrgID: orgID, MonitorID: monitorID}, dto)
+}
+
+func (s *ServiceImpl) DeleteMonitorPolicy(ctx context.Context, monitorID string, orgID string) error {
+	existing, err := s.repository.FindByMonitorID(ctx, monitorID, orgID)
+	if err != nil || existing == nil {
+		return err
+	}
+	return s.repository.Delete(ctx, existing.ID, orgID)
+}
+
+func (s *ServiceImpl) Enforce(ctx context.Context, now time.Time) error {
+	policies, err := s.repository.FindAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	orgPolicies := make(map[string]*Policy)
+	monitorPolicies := make(map[string]*Policy)
+	for _, policy := range policies {
+		if policy.MonitorID != "" {
+			monitorPolicies[policy.MonitorID] = policy
+		} else {
+			orgPolicies[policy.OrgID] = policy
+		}
+	}
+	fallback := s.defaultPolicy(ctx, "")
+
+	for page := 0; ; page++ {
+		monitors, err := s.monitorService.FindAllPaginated(ctx, page, monitorPageSize)
+		if err != nil {
+			return err
+		}
+
+		for _, m := range monitors {
+			policy := monitorPolicies[m.ID]
+			if policy == nil {
+				policy = orgPolicies[m.OrgID]
+			}
+			if policy == nil {
+				policy = fallback
+			}
+
+			if err := s.enforceMonitor(ctx, m.ID, policy, now); err != nil {
+				s.logger.Errorw("Failed to apply retention policy", "monitor_id", m.ID, "error", err)
+			}
+		}
+
+		if len(monitors) < monitorPageSize {
+			return nil
+		}
+	}
+}
+
+func (s *ServiceImpl) enforceMonitor(ctx context.Context, monitorID string, policy *Policy, now time.Time) error {
+	today := now.UTC().Truncate(day)
+
+	// Heartbeats are only dropped once the rollups of their day are complete
+	if err := s.compact(ctx, monitorID, today); err != nil {
+		return err
+	}
+
+	cutoff := today.AddDate(0, 0, -policy.HeartbeatDays)
+	deleted, err := s.heartbeatService.DeleteByMonitorIDOlderThan(ctx, monitorID, cutoff)
+	if err != nil {
+		return err
+	}
+	if deleted > 0 {
+		s.logger.Infow("Deleted old heartbeats", "monitor_id", monitorID, "count", deleted, "cutoff", cutoff)
+	}
+
+	for period, days := range policy.StatDays() {
+		if days == 0 {
+			continue
+		}
+
+		cutoff := today.AddDate(0, 0, -days)
+		deleted, err := s.statsService.DeleteOlderThan(ctx, monitorID, period, cutoff)
+		if err != nil {
+			return err
+		}
+		if deleted > 0 {
+			s.logger.Infow("Deleted old stats", "monitor_id", monitorID, "period", period, "count", deleted, "cutoff", cutoff)
+		}
+	}
+	return nil
+}
+
+// compact rebuilds the hourly and daily rollups of every complete day before until
+// that was not compacted yet
+func (s *ServiceImpl) compact(ctx context.Context, monitorID string, until time.Time) error {
+	from, err := s.repository.FindCompactedUntil(ctx, monitorID)
+	if err != nil {
+		return err
+	}
+
+	if from.IsZero() {
+		oldest, err := s.heartbeatService.FindOldestByMonitorID(ctx, monitorID)
+		if err != nil {
+			return err
+		}
+		if oldest == nil {
+			// Nothing stored yet, so there is nothing to lose either
+			return nil
+		}
+		from = oldest.Time.UTC().Truncate(day)
+	}
+
+	for start := from; start.Before(until); start = start.Add(day) {
+		end := start.Add(day)
+
+		heartbeats, err := s.heartbeatService.FindByMonitorIDAndTimeRange(ctx, monitorID, start, end)
+		if err != nil {
+			return err
+		}
+
+		if len(heartbeats) > 0 {
+			payloads := make([]*stats.HeartbeatPayload, 0, len(heartbeats))
+			for _, hb := range heartbeats {
+				payloads = append(payloads, &stats.HeartbeatPayload{
+					MonitorID: hb.MonitorID,
+					Status:    int(hb.Status),
+					Ping:      hb.Ping,
+					Time:      hb.Time.Unix(),
+				})
+			}
+
+			written, err := s.statsService.RebuildRollups(ctx, monitorID, payloads, start, end)
+			if err != nil {
+				return err
+			}
+			if written > 0 {
+				s.logger.Infow("Rebuilt missing rollups", "monitor_id", monitorID, "day", start, "buckets", written)
+			}
+		}
+
+		if err := s.repository.SetCompactedUntil(ctx, monitorID, end); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *ServiceImpl) save(ctx context.Context, existing *Policy, policy *Policy, dto *PolicyDTO) (*Policy, error) {
+	if existing != nil {
+		policy = existing
+	}
+	policy.HeartbeatDays = dto.HeartbeatDays
+	policy.MinutelyDays = dto.MinutelyDays
+	policy.HourlyDays = dto.HourlyDays
+	policy.DailyDays = dto.DailyDays
+
+	if !policy.valid() {
+		return nil, ErrInvalidRetention
+	}
+
+	if existing == nil {
+		return s.repository.Create(ctx, policy)
+	}
+	if err := s.repository.Update(ctx, policy); err != nil {
+		return nil, err
+	}
+	policy.UpdatedAt = time.Now().UTC()
+	return policy, nil
+}
+
+func (s *ServiceImpl) checkMonitor(ctx context.Context, monitorID string, orgID string) error {
+	m, err := s.monitorService.FindByID(ctx, monitorID, orgID)
+	if err != nil {
+		return err
+	}
+	if m == nil {
+		return ErrMonitorNotFound
+	}
+	return nil
+}
+
+// defaultPolicy keeps heartbeats for KEEP_DATA_PERIOD_DAYS and stats forever
+func (s *ServiceImpl) defaultPolicy(ctx context.Context, orgID string) *Policy {
+	keepDays := DefaultHeartbeatDays
+	settingModel, err := s.settingService.GetByKey(ctx, KeepDataSettingKey)
+	if err != nil {
+		s.logger.Errorw("Failed to fetch KEEP_DATA_PERIOD_DAYS setting", "error", err)
+	} else if settingModel != nil {
+		if v, err := strconv.Atoi(settingModel.Value); err == nil && v > 0 {
+			keepDays = v
+		} else {
+			s.logger.Errorw("Invalid KEEP_DATA_PERIOD_DAYS value", "value", settingModel.Value, "error", err)
+		}
+	}
+
+	return &Policy{OrgID: orgID, HeartbeatDays: keepDays}
+}
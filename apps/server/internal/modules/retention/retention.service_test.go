@@ -0,0 +1,339 @@
+package retention
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"vigi/internal/modules/heartbeat"
+	"vigi/internal/modules/monitor"
+	"vigi/internal/modules/setting"
+	"vigi/internal/modules/shared"
+	"vigi/internal/modules/stats"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// MockRepository
+type MockRepository struct {
+	mock.Mock
+}
+
+func (m *MockRepository) Create(ctx context.Context, policy *Policy) (*Policy, error) {
+	args := m.Called(ctx, policy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Policy), args.Error(1)
+}
+
+func (m *MockRepository) Update(ctx context.Context, policy *Policy) error {
+	args := m.Called(ctx, policy)
+	return args.Error(0)
+}
+
+func (m *MockRepository) Delete(ctx context.Context, id string, orgID string) error {
+	args := m.Called(ctx, id, orgID)
+	return args.Error(0)
+}
+
+func (m *MockRepository) FindByOrgID(ctx context.Context, orgID string) (*Policy, error) {
+	args := m.Called(ctx, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Policy), args.Error(1)
+}
+
+func (m *MockRepository) FindByMonitorID(ctx context.Context, monitorID string, orgID string) (*Policy, error) {
+	args := m.Called(ctx, monitorID, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Policy), args.Error(1)
+}
+
+func (m *MockRepository) FindMonitorPolicies(ctx context.Context, orgID string) ([]*Policy, error) {
+	args := m.Called(ctx, orgID)
+	return args.Get(0).([]*Policy), args.Error(1)
+}
+
+func (m *MockRepository) FindAll(ctx context.Context) ([]*Policy, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]*Policy), args.Error(1)
+}
+
+func (m *MockRepository) FindCompactedUntil(ctx context.Context, monitorID string) (time.Time, error) {
+	args := m.Called(ctx, monitorID)
+	return args.Get(0).(time.Time), args.Error(1)
+}
+
+func (m *MockRepository) SetCompactedUntil(ctx context.Context, monitorID string, until time.Time) error {
+	args := m.Called(ctx, monitorID, until)
+	return args.Error(0)
+}
+
+// MockMonitorService
+type MockMonitorService struct {
+	mock.Mock
+}
+
+func (m *MockMonitorService) FindByID(ctx context.Context, id string, orgID string) (*monitor.Model, error) {
+	args := m.Called(ctx, id, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*monitor.Model), args.Error(1)
+}
+
+func (m *MockMonitorService) FindAllPaginated(ctx context.Context, page int, limit int) ([]*monitor.Model, error) {
+	args := m.Called(ctx, page, limit)
+	return args.Get(0).([]*monitor.Model), args.Error(1)
+}
+
+// MockHeartbeatService
+type MockHeartbeatService struct {
+	mock.Mock
+}
+
+func (m *MockHeartbeatService) FindOldestByMonitorID(ctx context.Context, monitorID string) (*heartbeat.Model, error) {
+	args := m.Called(ctx, monitorID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*heartbeat.Model), args.Error(1)
+}
+
+func (m *MockHeartbeatService) FindByMonitorIDAndTimeRange(ctx context.Context, monitorID string, since, until time.Time) ([]*heartbeat.Model, error) {
+	args := m.Called(ctx, monitorID, since, until)
+	return args.Get(0).([]*heartbeat.Model), args.Error(1)
+}
+
+func (m *MockHeartbeatService) DeleteByMonitorIDOlderThan(ctx context.Context, monitorID string, cutoff time.Time) (int64, error) {
+	args := m.Called(ctx, monitorID, cutoff)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// MockStatsService
+type MockStatsService struct {
+	mock.Mock
+}
+
+func (m *MockStatsService) RebuildRollups(ctx context.Context, monitorID string, heartbeats []*stats.HeartbeatPayload, since, until time.Time) (int, error) {
+	args := m.Called(ctx, monitorID, heartbeats, since, until)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockStatsService) DeleteOlderThan(ctx context.Context, monitorID string, period stats.StatPeriod, cutoff time.Time) (int64, error) {
+	args := m.Called(ctx, monitorID, period, cutoff)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// MockSettingService
+type MockSettingService struct {
+	mock.Mock
+}
+
+func (m *MockSettingService) GetByKey(ctx context.Context, key string) (*setting.Model, error) {
+	args := m.Called(ctx, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*setting.Model), args.Error(1)
+}
+
+type testMocks struct {
+	repo      *MockRepository
+	monitors  *MockMonitorService
+	heartbeat *MockHeartbeatService
+	stats     *MockStatsService
+	settings  *MockSettingService
+}
+
+func setupService() (*ServiceImpl, *testMocks) {
+	mocks := &testMocks{
+		repo:      &MockRepository{},
+		monitors:  &MockMonitorService{},
+		heartbeat: &MockHeartbeatService{},
+		stats:     &MockStatsService{},
+		settings:  &MockSettingService{},
+	}
+	service := NewService(mocks.repo, mocks.monitors, mocks.heartbeat, mocks.stats, mocks.settings, zap.NewNop().Sugar()).(*ServiceImpl)
+	return service, mocks
+}
+
+func date(month time.Month, day int) time.Time {
+	return time.Date(2026, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestPolicy_Valid(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy Policy
+		valid  bool
+	}{
+		{"stats kept forever", Policy{HeartbeatDays: 30}, true},
+		{"coarser periods kept longer", Policy{HeartbeatDays: 30, MinutelyDays: 60, HourlyDays: 365, DailyDays: 0}, true},
+		{"same retention everywhere", Policy{HeartbeatDays: 90, MinutelyDays: 90, HourlyDays: 90, DailyDays: 90}, true},
+		{"hourly shorter than heartbeats", Policy{HeartbeatDays: 90, HourlyDays: 30}, false},
+		{"hourly shorter than minutely", Policy{HeartbeatDays: 7, MinutelyDays: 60, HourlyDays: 30}, false},
+		{"minutely forever but hourly limited", Policy{HeartbeatDays: 7, HourlyDays: 30}, false},
+		{"daily shorter than hourly", Policy{HeartbeatDays: 7, HourlyDays: 365, DailyDays: 90}, false},
+		{"daily limited while hourly forever", Policy{HeartbeatDays: 7, MinutelyDays: 30, DailyDays: 365}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.valid, tt.policy.valid())
+		})
+	}
+}
+
+func TestServiceImpl_Policies(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("defaults to the instance setting without a policy", func(t *testing.T) {
+		service, mocks := setupService()
+		mocks.repo.On("FindByOrgID", ctx, "org-1").Return(nil, nil)
+		mocks.settings.On("GetByKey", ctx, KeepDataSettingKey).Return(&setting.Model{Key: KeepDataSettingKey, Value: "90"}, nil)
+
+		policy, err := service.GetPolicy(ctx, "org-1")
+		require.NoError(t, err)
+		assert.Equal(t, &Policy{OrgID: "org-1", HeartbeatDays: 90}, policy)
+	})
+
+	t.Run("creates the organization policy", func(t *testing.T) {
+		service, mocks := setupService()
+		mocks.repo.On("FindByOrgID", ctx, "org-1").Return(nil, nil)
+		mocks.repo.On("Create", ctx, &Policy{OrgID: "org-1", HeartbeatDays: 30, MinutelyDays: 30, HourlyDays: 365}).
+			Return(&Policy{ID: "policy-1", OrgID: "org-1", HeartbeatDays: 30, MinutelyDays: 30, HourlyDays: 365}, nil)
+
+		policy, err := service.UpdatePolicy(ctx, &PolicyDTO{HeartbeatDays: 30, MinutelyDays: 30, HourlyDays: 365}, "org-1")
+		require.NoError(t, err)
+		assert.Equal(t, "policy-1", policy.ID)
+		mocks.repo.AssertExpectations(t)
+	})
+
+	t.Run("rejects rollups dropped before their source", func(t *testing.T) {
+		service, mocks := setupService()
+		mocks.repo.On("FindByOrgID", ctx, "org-1").Return(&Policy{ID: "policy-1", OrgID: "org-1", HeartbeatDays: 30}, nil)
+
+		_, err := service.UpdatePolicy(ctx, &PolicyDTO{HeartbeatDays: 30, HourlyDays: 7}, "org-1")
+		assert.ErrorIs(t, err, ErrInvalidRetention)
+		mocks.repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("monitor without an override inherits the organization policy", func(t *testing.T) {
+		service, mocks := setupService()
+		orgPolicy := &Policy{ID: "policy-1", OrgID: "org-1", HeartbeatDays: 14}
+		mocks.monitors.On("FindByID", ctx, "monitor-1", "org-1").Return(&monitor.Model{ID: "monitor-1", OrgID: "org-1"}, nil)
+		mocks.repo.On("FindByMonitorID", ctx, "monitor-1", "org-1").Return(nil, nil)
+		mocks.repo.On("FindByOrgID", ctx, "org-1").Return(orgPolicy, nil)
+
+		policy, err := service.GetMonitorPolicy(ctx, "monitor-1", "org-1")
+		require.NoError(t, err)
+		assert.Equal(t, orgPolicy, policy)
+	})
+
+	t.Run("monitor of another organization", func(t *testing.T) {
+		service, mocks := setupService()
+		mocks.monitors.On("FindByID", ctx, "monitor-1", "org-2").Return(nil, nil)
+
+		_, err := service.UpdateMonitorPolicy(ctx, "monitor-1", &PolicyDTO{HeartbeatDays: 7}, "org-2")
+		assert.ErrorIs(t, err, ErrMonitorNotFound)
+	})
+}
+
+func TestServiceImpl_Enforce(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, time.March, 10, 15, 30, 0, 0, time.UTC)
+	today := date(time.March, 10)
+
+	t.Run("compacts then applies the policy of every monitor", func(t *testing.T) {
+		service, mocks := setupService()
+
+		mocks.repo.On("FindAll", ctx).Return([]*Policy{
+			{ID: "p-org-1", OrgID: "org-1", HeartbeatDays: 2, MinutelyDays: 7, HourlyDays: 90},
+			{ID: "p-monitor-2", OrgID: "org-2", MonitorID: "monitor-2", HeartbeatDays: 60, DailyDays: 0},
+		}, nil)
+		mocks.settings.On("GetByKey", ctx, KeepDataSettingKey).Return(&setting.Model{Key: KeepDataSettingKey, Value: "30"}, nil)
+		mocks.monitors.On("FindAllPaginated", ctx, 0, monitorPageSize).Return([]*monitor.Model{
+			{ID: "monitor-1", OrgID: "org-1"},
+			{ID: "monitor-2", OrgID: "org-2"},
+			{ID: "monitor-3", OrgID: "org-3"},
+		}, nil)
+
+		// monitor-1 was never compacted, its oldest heartbeat is two days old
+		checks := []*heartbeat.Model{
+			{MonitorID: "monitor-1", Status: shared.MonitorStatusUp, Ping: 12, Time: time.Date(2026, time.March, 8, 10, 0, 0, 0, time.UTC)},
+			{MonitorID: "monitor-1", Status: shared.MonitorStatusDown, Time: time.Date(2026, time.March, 8, 10, 1, 0, 0, time.UTC)},
+		}
+		mocks.repo.On("FindCompactedUntil", ctx, "monitor-1").Return(time.Time{}, nil)
+		mocks.heartbeat.On("FindOldestByMonitorID", ctx, "monitor-1").Return(checks[0], nil)
+		mocks.heartbeat.On("FindByMonitorIDAndTimeRange", ctx, "monitor-1", date(time.March, 8), date(time.March, 9)).Return(checks, nil)
+		mocks.heartbeat.On("FindByMonitorIDAndTimeRange", ctx, "monitor-1", date(time.March, 9), today).Return([]*heartbeat.Model{}, nil)
+		mocks.stats.On("RebuildRollups", ctx, "monitor-1", []*stats.HeartbeatPayload{
+			{MonitorID: "monitor-1", Status: 1, Ping: 12, Time: checks[0].Time.Unix()},
+			{MonitorID: "monitor-1", Status: 0, Time: checks[1].Time.Unix()},
+		}, date(time.March, 8), date(time.March, 9)).Return(2, nil)
+		mocks.repo.On("SetCompactedUntil", ctx, "monitor-1", date(time.March, 9)).Return(nil).Once()
+		mocks.repo.On("SetCompactedUntil", ctx, "monitor-1", today).Return(nil).Once()
+		mocks.heartbeat.On("DeleteByMonitorIDOlderThan", ctx, "monitor-1", date(time.March, 8)).Return(int64(120), nil)
+		mocks.stats.On("DeleteOlderThan", ctx, "monitor-1", stats.StatMinutely, date(time.March, 3)).Return(int64(0), nil)
+		mocks.stats.On("DeleteOlderThan", ctx, "monitor-1", stats.StatHourly, time.Date(2025, time.December, 10, 0, 0, 0, 0, time.UTC)).Return(int64(0), nil)
+
+		// monitor-2 has its own policy and is compacted up to today
+		mocks.repo.On("FindCompactedUntil", ctx, "monitor-2").Return(today, nil)
+		mocks.heartbeat.On("DeleteByMonitorIDOlderThan", ctx, "monitor-2", date(time.January, 9)).Return(int64(0), nil)
+
+		// monitor-3 has no policy and no heartbeats
+		mocks.repo.On("FindCompactedUntil", ctx, "monitor-3").Return(time.Time{}, nil)
+		mocks.heartbeat.On("FindOldestByMonitorID", ctx, "monitor-3").Return(nil, nil)
+		mocks.heartbeat.On("DeleteByMonitorIDOlderThan", ctx, "monitor-3", date(time.February, 8)).Return(int64(0), nil)
+
+		require.NoError(t, service.Enforce(ctx, now))
+		mocks.repo.AssertExpectations(t)
+		mocks.heartbeat.AssertExpectations(t)
+		mocks.stats.AssertExpectations(t)
+	})
+
+	t.Run("keeps heartbeats when the rollups could not be rebuilt", func(t *testing.T) {
+		service, mocks := setupService()
+
+		mocks.repo.On("FindAll", ctx).Return([]*Policy{}, nil)
+		mocks.settings.On("GetByKey", ctx, KeepDataSettingKey).Return(nil, nil)
+		mocks.monitors.On("FindAllPaginated", ctx, 0, monitorPageSize).Return([]*monitor.Model{{ID: "monitor-1", OrgID: "org-1"}}, nil)
+
+		check := &heartbeat.Model{MonitorID: "monitor-1", Status: shared.MonitorStatusUp, Time: date(time.March, 9).Add(time.Hour)}
+		mocks.repo.On("FindCompactedUntil", ctx, "monitor-1").Return(date(time.March, 9), nil)
+		mocks.heartbeat.On("FindByMonitorIDAndTimeRange", ctx, "monitor-1", date(time.March, 9), today).Return([]*heartbeat.Model{check}, nil)
+		mocks.stats.On("RebuildRollups", ctx, "monitor-1", mock.Anything, date(time.March, 9), today).Return(0, errors.New("database is locked"))
+
+		require.NoError(t, service.Enforce(ctx, now))
+		mocks.repo.AssertNotCalled(t, "SetCompactedUntil", mock.Anything, mock.Anything, mock.Anything)
+		mocks.heartbeat.AssertNotCalled(t, "DeleteByMonitorIDOlderThan", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("walks every page of monitors", func(t *testing.T) {
+		service, mocks := setupService()
+
+		fullPage := make([]*monitor.Model, monitorPageSize)
+		for i := range fullPage {
+			fullPage[i] = &monitor.Model{ID: "monitor", OrgID: "org-1"}
+		}
+
+		mocks.repo.On("FindAll", ctx).Return([]*Policy{}, nil)
+		mocks.settings.On("GetByKey", ctx, KeepDataSettingKey).Return(nil, nil)
+		mocks.monitors.On("FindAllPaginated", ctx, 0, monitorPageSize).Return(fullPage, nil)
+		mocks.monitors.On("FindAllPaginated", ctx, 1, monitorPageSize).Return([]*monitor.Model{}, nil)
+		mocks.repo.On("FindCompactedUntil", ctx, "monitor").Return(today, nil)
+		mocks.heartbeat.On("DeleteByMonitorIDOlderThan", ctx, "monitor", today.AddDate(0, 0, -DefaultHeartbeatDays)).Return(int64(0), nil)
+
+		require.NoError(t, service.Enforce(ctx, now))
+		mocks.monitors.AssertExpectations(t)
+		mocks.heartbeat.AssertNumberOfCalls(t, "DeleteByMonitorIDOlderThan", monitorPageSize)
+	})
+}
@@ -0,0 +1,185 @@
+package retention
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type sqlModel struct {
+	bun.BaseModel `bun:"table:retention_policies,alias:rp"`
+
+	ID            string    `bun:"id,pk"`
+	OrgID         string    `bun:"org_id,notnull"`
+	MonitorID     *string   `bun:"monitor_id"`
+	HeartbeatDays int       `bun:"heartbeat_days,notnull"`
+	MinutelyDays  int       `bun:"minutely_days,notnull,default:0"`
+	HourlyDays    int       `bun:"hourly_days,notnull,default:0"`
+	DailyDays     int       `bun:"daily_days,notnull,default:0"`
+	CreatedAt     time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp"`
+	UpdatedAt     time.Time `bun:"updated_at,nullzero,notnull,default:current_timestamp"`
+}
+
+type sqlCompaction struct {
+	bun.BaseModel `bun:"table:retention_compactions,alias:rc"`
+
+	MonitorID      string    `bun:"monitor_id,pk"`
+	CompactedUntil time.Time `bun:"compacted_until,notnull"`
+}
+
+func toDomainModelFromSQL(sm *sqlModel) *Policy {
+	p := &Policy{
+		ID:            sm.ID,
+		OrgID:         sm.OrgID,
+		HeartbeatDays: sm.HeartbeatDays,
+		MinutelyDays:  sm.MinutelyDays,
+		HourlyDays:    sm.HourlyDays,
+		DailyDays:     sm.DailyDays,
+		CreatedAt:     sm.CreatedAt,
+		UpdatedAt:     sm.UpdatedAt,
+	}
+	if sm.MonitorID != nil {
+		p.MonitorID = *sm.MonitorID
+	}
+	return p
+}
+
+func toSQLModel(p *Policy) *sqlModel {
+	sm := &sqlModel{
+		ID:            p.ID,
+		OrgID:         p.OrgID,
+		HeartbeatDays: p.HeartbeatDays,
+		MinutelyDays:  p.MinutelyDays,
+		HourlyDays:    p.HourlyDays,
+		DailyDays:     p.DailyDays,
+		CreatedAt:     p.CreatedAt,
+		UpdatedAt:     p.UpdatedAt,
+	}
+	if p.MonitorID != "" {
+		sm.MonitorID = &p.MonitorID
+	}
+	return sm
+}
+
+type SQLRepositoryImpl struct {
+	db *bun.DB
+}
+
+func NewSQLRepository(db *bun.DB) Repository {
+	return &SQLRepositoryImpl{db: db}
+}
+
+func (r *SQLRepositoryImpl) Create(ctx context.Context, policy *Policy) (*Policy, error) {
+	sm := toSQLModel(policy)
+	sm.ID = uuid.New().String()
+	sm.CreatedAt = time.Now()
+	sm.UpdatedAt = time.Now()
+
+	if _, err := r.db.NewInsert().Model(sm).Exec(ctx); err != nil {
+		return nil, err
+	}
+	return toDomainModelFromSQL(sm), nil
+}
+
+func (r *SQLRepositoryImpl) Update(ctx context.Context, policy *Policy) error {
+	_, err := r.db.NewUpdate().
+		Model((*sqlModel)(nil)).
+		Set("heartbeat_days = ?", policy.HeartbeatDays).
+		Set("minutely_days = ?", policy.MinutelyDays).
+		Set("hourly_days = ?", policy.HourlyDays).
+		Set("daily_days = ?", policy.DailyDays).
+		Set("updated_at = ?", time.Now()).
+		Where("id = ?", policy.ID).
+		Where("org_id = ?", policy.OrgID).
+		Exec(ctx)
+	return err
+}
+
+func (r *SQLRepositoryImpl) Delete(ctx context.Context, id string, orgID string) error {
+	_, err := r.db.NewDelete().
+		Model((*sqlModel)(nil)).
+		Where("id = ?", id).
+		Where("org_id = ?", orgID).
+		Exec(ctx)
+	return err
+}
+
+func (r *SQLRepositoryImpl) FindByOrgID(ctx context.Context, orgID string) (*Policy, error) {
+	return r.findOne(ctx, func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q.Where("org_id = ?", orgID).Where("monitor_id IS NULL")
+	})
+}
+
+func (r *SQLRepositoryImpl) FindByMonitorID(ctx context.Context, monitorID string, orgID string) (*Policy, error) {
+	return r.findOne(ctx, func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q.Where("monitor_id = ?", monitorID).Where("org_id = ?", orgID)
+	})
+}
+
+func (r *SQLRepositoryImpl) FindMonitorPolicies(ctx context.Context, orgID string) ([]*Policy, error) {
+	var sms []*sqlModel
+	err := r.db.NewSelect().
+		Model(&sms).
+		Where("org_id = ?", orgID).
+		Where("monitor_id IS NOT NULL").
+		Order("created_at ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return toDomainModels(sms), nil
+}
+
+func (r *SQLRepositoryImpl) FindAll(ctx context.Context) ([]*Policy, error) {
+	var sms []*sqlModel
+	if err := r.db.NewSelect().Model(&sms).Scan(ctx); err != nil {
+		return nil, err
+	}
+	return toDomainModels(sms), nil
+}
+
+func (r *SQLRepositoryImpl) FindCompactedUntil(ctx context.Context, monitorID string) (time.Time, error) {
+	sc := new(sqlCompaction)
+	err := r.db.NewSelect().Model(sc).Where("monitor_id = ?", monitorID).Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return time.Time{}, nil
+		}
+		return time.Time{}, err
+	}
+	return sc.CompactedUntil.UTC(), nil
+}
+
+func (r *SQLRepositoryImpl) SetCompactedUntil(ctx context.Context, monitorID string, until time.Time) error {
+	sc := &sqlCompaction{MonitorID: monitorID, CompactedUntil: until}
+	_, err := r.db.NewInsert().
+		Model(sc).
+		On("CONFLICT (monitor_id) DO UPDATE").
+		Set("compacted_until = EXCLUDED.compacted_until").
+		Exec(ctx)
+	return err
+}
+
+func (r *SQLRepositoryImpl) findOne(ctx context.Context, where func(q *bun.SelectQuery) *bun.SelectQuery) (*Policy, error) {
+	sm := new(sqlModel)
+	err := where(r.db.NewSelect().Model(sm)).Limit(1).Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toDomainModelFromSQL(sm), nil
+}
+
+func toDomainModels(sms []*sqlModel) []*Policy {
+	policies := make([]*Policy, 0, len(sms))
+	for _, sm := range sms {
+		policies = append(policies, toDomainModelFromSQL(sm))
+	}
+	return policies
+}
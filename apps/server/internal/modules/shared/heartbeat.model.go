@@ -11,8 +11,15 @@ const (
 	MonitorStatusUp
 	MonitorStatusPending
 	MonitorStatusMaintenance
+	// MonitorStatusDegraded is a passing check that crossed one of its soft thresholds
+	MonitorStatusDegraded
 )
 
+// IsAvailable reports whether the monitor answered its check, degraded or not
+func (s MonitorStatus) IsAvailable() bool {
+	return s == MonitorStatusUp || s == MonitorStatusDegraded
+}
+
 type HeartBeatModel struct {
 	ID        string        `json:"id"`
 	MonitorID string        `json:"monitor_id"`
@@ -26,6 +33,8 @@ type HeartBeatModel struct {
 	Time      time.Time     `json:"time"`
 	EndTime   time.Time     `json:"end_time"`
 	Notified  bool          `json:"notified"`
+	Flapping  bool          `json:"flapping"`           // Status changed too often in the recent checks, transitions are not notified
+	ProbeID   string        `json:"probe_id,omitempty"` // Probe location that ran the check, empty for central workers
 }
 
 type HeartBeatChartPoint struct {
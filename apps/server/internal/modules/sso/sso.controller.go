@@ -0,0 +1,144 @@
+package sso
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"vigi/internal/config"
+	"vigi/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const (
+	// Cookie holding the AuthRequest between the login and callback requests
+	requestCookie     = "vigi_oidc_request"
+	requestCookiePath = "/api/v1/auth/oidc"
+	requestCookieTTL  = 600
+)
+
+type Controller struct {
+	service      Service
+	clientURL    string
+	secureCookie bool
+	logger       *zap.SugaredLogger
+}
+
+func NewController(
+	service Service,
+	cfg *config.Config,
+	logger *zap.SugaredLogger,
+) *Controller {
+	return &Controller{
+		service:      service,
+		clientURL:    strings.TrimSuffix(cfg.ClientURL, "/"),
+		secureCookie: strings.HasPrefix(cfg.OIDCRedirectURL, "https://"),
+		logger:       logger.Named("[sso-controller]"),
+	}
+}
+
+// @Router		/auth/oidc/config [get]
+// @Summary		Get the available sign-in methods
+// @Tags			Auth
+// @Produce		json
+// @Success		200	{object}	utils.ApiResponse[ConfigResponse]
+func (c *Controller) GetConfig(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, utils.NewSuccessResponse("success", c.service.Config()))
+}
+
+// @Router		/auth/oidc/login [get]
+// @Summary		Start a single sign-on login
+// @Description	Redirects the browser to the identity provider
+// @Tags			Auth
+// @Success		302
+// @Failure		404	{object}	utils.APIError[any]
+// @Failure		502	{object}	utils.APIError[any]
+func (c *Controller) Login(ctx *gin.Context) {
+	request, authURL, err := c.service.Begin(ctx)
+	if err != nil {
+		if errors.Is(err, ErrDisabled) {
+			ctx.JSON(http.StatusNotFound, utils.NewFailResponse(err.Error()))
+			return
+		}
+		c.logger.Errorw("Failed to start single sign-on", "error", err)
+		ctx.JSON(http.StatusBadGateway, utils.NewFailResponse("Identity provider is unavailable"))
+		return
+	}
+
+	encoded, err := json.Marshal(request)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse("Internal server error"))
+		return
+	}
+
+	c.setRequestCookie(ctx, base64.RawURLEncoding.EncodeToString(encoded), requestCookieTTL)
+	ctx.Redirect(http.StatusFound, authURL)
+}
+
+// @Router		/auth/oidc/callback [get]
+// @Summary		Finish a single sign-on login
+// @Description	Called by the identity provider. Redirects to the web app with a refresh token in the URL fragment, or an error.
+// @Tags			Auth
+// @Param		code	query	string	false	"Authorization code"
+// @Param		state	query	string	true	"State of the login request"
+// @Param		error	query	string	false	"Error returned by the identity provider"
+// @Success		302
+func (c *Controller) Callback(ctx *gin.Context) {
+	request := c.readRequestCookie(ctx)
+	// The request is single use
+	c.setRequestCookie(ctx, "", -1)
+
+	if idpError := ctx.Query("error"); idpError != "" {
+		c.logger.Warnw("Identity provider rejected the login", "error", idpError, "description", ctx.Query("error_description"))
+		c.redirectToClient(ctx, url.Values{"error": {"Login was rejected by the identity provider"}})
+		return
+	}
+
+	response, err := c.service.Complete(ctx, request, ctx.Query("state"), ctx.Query("code"))
+	if err != nil {
+		c.logger.Errorw("Failed to complete single sign-on", "error", err)
+
+		message := "Single sign-on failed"
+		switch {
+		case errors.Is(err, ErrStateMismatch), errors.Is(err, ErrMissingEmail),
+			errors.Is(err, ErrEmailUnverified), errors.Is(err, ErrUserInactive),
+			errors.Is(err, ErrAccountExists):
+			message = err.Error()
+		}
+		c.redirectToClient(ctx, url.Values{"error": {message}})
+		return
+	}
+
+	c.redirectToClient(ctx, url.Values{"refresh_token": {response.RefreshToken}})
+}
+
+// redirectToClient sends the browser to the web app. Values are passed in
+// the fragment so tokens never reach server logs or Referer headers.
+func (c *Controller) redirectToClient(ctx *gin.Context, values url.Values) {
+	ctx.Redirect(http.StatusFound, c.clientURL+"/login/sso#"+values.Encode())
+}
+
+func (c *Controller) setRequestCookie(ctx *gin.Context, value string, maxAge int) {
+	ctx.SetSameSite(http.SameSiteLaxMode)
+	ctx.SetCookie(requestCookie, value, maxAge, requestCookiePath, "", c.secureCookie, true)
+}
+
+func (c *Controller) readRequestCookie(ctx *gin.Context) *AuthRequest {
+	value, err := ctx.Cookie(requestCookie)
+	if err != nil {
+		return nil
+	}
+	decoded, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil
+	}
+	var request AuthRequest
+	if err := json.Unmarshal(decoded, &request); err != nil {
+		return nil
+	}
+	return &request
+}
@@ -0,0 +1,16 @@
+package sso
+
+import (
+	"vigi/internal/modules/auth"
+	"vigi/internal/modules/organization"
+
+	"go.uber.org/dig"
+)
+
+func RegisterDependencies(container *dig.Container) {
+	container.Provide(func(repo organization.OrganizationRepository) MembershipRepository { return repo })
+	container.Provide(func(tokenMaker *auth.TokenMaker) TokenIssuer { return tokenMaker })
+	container.Provide(NewService)
+	container.Provide(NewController)
+	container.Provide(NewRoute)
+}
@@ -0,0 +1,8 @@
+package sso
+
+// ConfigResponse tells the login page which sign-in methods are available
+type ConfigResponse struct {
+	Enabled              bool   `json:"enabled"`
+	ProviderName         string `json:"providerName"`
+	PasswordLoginEnabled bool   `json:"passwordLoginEnabled"`
+}
@@ -0,0 +1,139 @@
+package sso
+
+import (
+	"fmt"
+	"strings"
+	"vigi/internal/modules/organization"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// AuthRequest holds the values generated when a login starts. It is kept
+// in a short lived cookie until the IdP redirects back to the callback.
+type AuthRequest struct {
+	State    string `json:"state"`
+	Nonce    string `json:"nonce"`
+	Verifier string `json:"verifier"`
+}
+
+// Identity is the user described by a verified ID token
+type Identity struct {
+	Subject       string
+	Email         string
+	EmailVerified *bool
+	Name          string
+	Picture       string
+	// Groups is nil when the token has no groups claim
+	Groups []string
+}
+
+// RoleMapping grants Role in the organization with slug OrgSlug to members
+// of the IdP group Group
+type RoleMapping struct {
+	Group   string
+	OrgSlug string
+	Role    organization.Role
+}
+
+func identityFromClaims(claims jwt.MapClaims, groupsClaim string) *Identity {
+	identity := &Identity{
+		Subject: stringClaim(claims, "sub"),
+		Email:   strings.ToLower(strings.TrimSpace(stringClaim(claims, "email"))),
+		Name:    stringClaim(claims, "name"),
+		Picture: stringClaim(claims, "picture"),
+		Groups:  listClaim(claims, groupsClaim),
+	}
+	if verified, ok := claims["email_verified"].(bool); ok {
+		identity.EmailVerified = &verified
+	}
+	if identity.Name == "" {
+		identity.Name = stringClaim(claims, "preferred_username")
+	}
+	return identity
+}
+
+func stringClaim(claims jwt.MapClaims, name string) string {
+	value, _ := claims[name].(string)
+	return value
+}
+
+// listClaim reads a claim holding a list or a single string. Dotted names
+// reach into nested objects, e.g. "realm_access.roles".
+func listClaim(claims jwt.MapClaims, name string) []string {
+	if name == "" {
+		return nil
+	}
+
+	var value any = map[string]any(claims)
+	for _, part := range strings.Split(name, ".") {
+		object, ok := value.(map[string]any)
+		if !ok {
+			return nil
+		}
+		value = object[part]
+	}
+
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	default:
+		return nil
+	}
+}
+
+// ParseRoleMappings parses comma separated <group>=<organization slug>:<role>
+// entries
+func ParseRoleMappings(value string) ([]RoleMapping, error) {
+	var mappings []RoleMapping
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		group, target, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid role mapping %q, expected <group>=<organization>:<role>", entry)
+		}
+		slug, role, ok := strings.Cut(target, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid role mapping %q, expected <group>=<organization>:<role>", entry)
+		}
+
+		group, slug, role = strings.TrimSpace(group), strings.TrimSpace(slug), strings.TrimSpace(role)
+		if group == "" || slug == "" {
+			return nil, fmt.Errorf("invalid role mapping %q, group and organization are required", entry)
+		}
+		if role != string(organization.RoleAdmin) && role != string(organization.RoleMember) {
+			return nil, fmt.Errorf("invalid role %q in role mapping %q, expected admin or member", role, entry)
+		}
+
+		mappings = append(mappings, RoleMapping{Group: group, OrgSlug: slug, Role: organization.Role(role)})
+	}
+	return mappings, nil
+}
+
+// desiredRoles resolves the role the user should have in every mapped
+// organization. Organizations missing from the result are mapped but none
+// of the user's groups grant access to them.
+func desiredRoles(mappings []RoleMapping, groups []string) map[string]organization.Role {
+	roles := map[string]organization.Role{}
+	for _, mapping := range mappings {
+		if !containsString(groups, mapping.Group) {
+			continue
+		}
+		if current, ok := roles[mapping.OrgSlug]; ok && current == organization.RoleAdmin {
+			continue
+		}
+		roles[mapping.OrgSlug] = mapping.Role
+	}
+	return roles
+}
@@ -0,0 +1,292 @@
+package sso
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testClientID     = "vigi"
+	testClientSecret = "client-secret"
+	testRedirectURL  = "http://localhost:8034/api/v1/auth/oidc/callback"
+)
+
+type pendingCode struct {
+	challenge string
+	nonce     string
+	claims    jwt.MapClaims
+}
+
+// mockIssuer is a minimal OpenID provider serving discovery, keys and the
+// token endpoint. authorize stands in for the user signing in at the IdP.
+type mockIssuer struct {
+	server *httptest.Server
+	key    *rsa.PrivateKey
+	kid    string
+
+	mu    sync.Mutex
+	codes map[string]pendingCode
+}
+
+func newMockIssuer(t *testing.T) *mockIssuer {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	issuer := &mockIssuer{key: key, kid: "test-key", codes: map[string]pendingCode{}}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"issuer":                                issuer.server.URL,
+			"authorization_endpoint":                issuer.server.URL + "/authorize",
+			"token_endpoint":                        issuer.server.URL + "/token",
+			"jwks_uri":                              issuer.server.URL + "/jwks",
+			"token_endpoint_auth_methods_supported": []string{"client_secret_basic"},
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": issuer.kid,
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/token", issuer.handleToken)
+
+	issuer.server = httptest.NewServer(mux)
+	t.Cleanup(issuer.server.Close)
+	return issuer
+}
+
+func (m *mockIssuer) handleToken(w http.ResponseWriter, r *http.Request) {
+	fail := func(code string) {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(map[string]string{"error": code})
+	}
+
+	clientID, secret, ok := r.BasicAuth()
+	if !ok || clientID != testClientID || secret != testClientSecret {
+		fail("invalid_client")
+		return
+	}
+	if r.PostFormValue("grant_type") != "authorization_code" || r.PostFormValue("redirect_uri") != testRedirectURL {
+		fail("invalid_request")
+		return
+	}
+
+	m.mu.Lock()
+	pending, ok := m.codes[r.PostFormValue("code")]
+	delete(m.codes, r.PostFormValue("code"))
+	m.mu.Unlock()
+
+	if !ok || codeChallenge(r.PostFormValue("code_verifier")) != pending.challenge {
+		fail("invalid_grant")
+		return
+	}
+
+	claims := jwt.MapClaims{
+		"iss":   m.server.URL,
+		"aud":   testClientID,
+		"iat":   time.Now().Unix(),
+		"exp":   time.Now().Add(5 * time.Minute).Unix(),
+		"nonce": pending.nonce,
+	}
+	for name, value := range pending.claims {
+		claims[name] = value
+	}
+
+	_ = json.NewEncoder(w).Encode(map[string]string{
+		"access_token": "access",
+		"token_type":   "Bearer",
+		"id_token":     m.sign(claims),
+	})
+}
+
+func (m *mockIssuer) sign(claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = m.kid
+	signed, err := token.SignedString(m.key)
+	if err != nil {
+		panic(err)
+	}
+	return signed
+}
+
+// authorize signs the user in at the IdP and returns the code and state the
+// browser brings back to the callback
+func (m *mockIssuer) authorize(t *testing.T, authURL string, claims jwt.MapClaims) (string, string) {
+	t.Helper()
+
+	parsed, err := url.Parse(authURL)
+	require.NoError(t, err)
+	query := parsed.Query()
+	require.Equal(t, "S256", query.Get("code_challenge_method"))
+
+	code, err := randomString()
+	require.NoError(t, err)
+
+	m.mu.Lock()
+	m.codes[code] = pendingCode{challenge: query.Get("code_challenge"), nonce: query.Get("nonce"), claims: claims}
+	m.mu.Unlock()
+
+	return code, query.Get("state")
+}
+
+func newTestProvider(issuer *mockIssuer) *Provider {
+	return NewProvider(issuer.server.URL+"/", testClientID, testClientSecret, testRedirectURL, []string{"email", "profile"}, nil)
+}
+
+func TestProvider_AuthCodeURL(t *testing.T) {
+	issuer := newMockIssuer(t)
+	provider := newTestProvider(issuer)
+
+	authURL, err := provider.AuthCodeURL(context.Background(), "state", "nonce", "verifier")
+	require.NoError(t, err)
+
+	parsed, err := url.Parse(authURL)
+	require.NoError(t, err)
+	query := parsed.Query()
+
+	assert.Equal(t, issuer.server.URL+"/authorize", parsed.Scheme+"://"+parsed.Host+parsed.Path)
+	assert.Equal(t, "code", query.Get("response_type"))
+	assert.Equal(t, testClientID, query.Get("client_id"))
+	assert.Equal(t, testRedirectURL, query.Get("redirect_uri"))
+	assert.Equal(t, "openid email profile", query.Get("scope"))
+	assert.Equal(t, "state", query.Get("state"))
+	assert.Equal(t, "nonce", query.Get("nonce"))
+	assert.Equal(t, codeChallenge("verifier"), query.Get("code_challenge"))
+}
+
+func TestProvider_ExchangeAndVerify(t *testing.T) {
+	issuer := newMockIssuer(t)
+	provider := newTestProvider(issuer)
+	ctx := context.Background()
+
+	authURL, err := provider.AuthCodeURL(ctx, "state", "nonce", "verifier")
+	require.NoError(t, err)
+	code, _ := issuer.authorize(t, authURL, jwt.MapClaims{"sub": "user-1", "email": "jane@example.com"})
+
+	t.Run("wrong verifier", func(t *testing.T) {
+		_, err := provider.Exchange(ctx, code, "other-verifier")
+		assert.ErrorIs(t, err, ErrExchange)
+	})
+
+	// The failed attempt consumed the code, sign in again
+	code, _ = issuer.authorize(t, authURL, jwt.MapClaims{"sub": "user-1", "email": "jane@example.com"})
+	rawIDToken, err := provider.Exchange(ctx, code, "verifier")
+	require.NoError(t, err)
+
+	claims, err := provider.Verify(ctx, rawIDToken, "nonce")
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", claims["sub"])
+	assert.Equal(t, "jane@example.com", claims["email"])
+
+	t.Run("nonce mismatch", func(t *testing.T) {
+		_, err := provider.Verify(ctx, rawIDToken, "other-nonce")
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+}
+
+func TestProvider_Verify(t *testing.T) {
+	issuer := newMockIssuer(t)
+	provider := newTestProvider(issuer)
+	ctx := context.Background()
+
+	valid := func() jwt.MapClaims {
+		return jwt.MapClaims{
+			"iss":   issuer.server.URL,
+			"aud":   testClientID,
+			"sub":   "user-1",
+			"iat":   time.Now().Unix(),
+			"exp":   time.Now().Add(5 * time.Minute).Unix(),
+			"nonce": "nonce",
+		}
+	}
+
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		token  func() string
+		expect bool
+	}{
+		{name: "valid", token: func() string { return issuer.sign(valid()) }, expect: true},
+		{name: "wrong issuer", token: func() string {
+			claims := valid()
+			claims["iss"] = "https://evil.example.com"
+			return issuer.sign(claims)
+		}},
+		{name: "wrong audience", token: func() string {
+			claims := valid()
+			claims["aud"] = "other-client"
+			return issuer.sign(claims)
+		}},
+		{name: "issued to another client", token: func() string {
+			claims := valid()
+			claims["aud"] = []string{testClientID, "other-client"}
+			claims["azp"] = "other-client"
+			return issuer.sign(claims)
+		}},
+		{name: "expired", token: func() string {
+			claims := valid()
+			claims["exp"] = time.Now().Add(-time.Hour).Unix()
+			return issuer.sign(claims)
+		}},
+		{name: "missing expiry", token: func() string {
+			claims := valid()
+			delete(claims, "exp")
+			return issuer.sign(claims)
+		}},
+		{name: "unknown key", token: func() string {
+			token := jwt.NewWithClaims(jwt.SigningMethodRS256, valid())
+			token.Header["kid"] = issuer.kid
+			signed, _ := token.SignedString(otherKey)
+			return signed
+		}},
+		{name: "hmac signed", token: func() string {
+			token := jwt.NewWithClaims(jwt.SigningMethodHS256, valid())
+			signed, _ := token.SignedString([]byte(testClientSecret))
+			return signed
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := provider.Verify(ctx, tt.token(), "nonce")
+			if tt.expect {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, ErrInvalidToken)
+			}
+		})
+	}
+}
+
+func TestProvider_DiscoveryFailure(t *testing.T) {
+	issuer := newMockIssuer(t)
+	provider := NewProvider(issuer.server.URL+"/tenant", testClientID, "", testRedirectURL, nil, nil)
+
+	_, err := provider.AuthCodeURL(context.Background(), "state", "nonce", "verifier")
+	assert.ErrorIs(t, err, ErrDiscovery)
+}
@@ -0,0 +1,20 @@
+package sso
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+type Route struct {
+	controller *Controller
+}
+
+func NewRoute(controller *Controller) *Route {
+	return &Route{controller: controller}
+}
+
+func (r *Route) ConnectRoute(router *gin.RouterGroup) {
+	oidc := router.Group("/auth/oidc")
+	oidc.GET("/config", r.controller.GetConfig)
+	oidc.GET("/login", r.controller.Login)
+	oidc.GET("/callback", r.controller.Callback)
+}
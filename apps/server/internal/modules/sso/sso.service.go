@@ -0,0 +1,310 @@
+package sso
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"vigi/internal/config"
+	"vigi/internal/modules/auth"
+	"vigi/internal/modules/organization"
+
+	"go.uber.org/zap"
+)
+
+var (
+	ErrDisabled        = errors.New("single sign-on is not configured")
+	ErrStateMismatch   = errors.New("invalid or expired login request")
+	ErrMissingEmail    = errors.New("the identity provider did not return an email address")
+	ErrEmailUnverified = errors.New("the identity provider reports the email address as unverified")
+	ErrUserInactive    = errors.New("user account is disabled")
+	ErrAccountExists   = errors.New("an account with this email already exists and the identity provider did not verify the email address")
+)
+
+// TokenIssuer creates the API session tokens once the IdP vouched for a user
+type TokenIssuer interface {
+	CreateAccessToken(ctx context.Context, user *auth.Model) (string, error)
+	CreateRefreshToken(ctx context.Context, user *auth.Model) (string, error)
+}
+
+// MembershipRepository is the part of the organization repository used to
+// sync group claims into organization roles
+type MembershipRepository interface {
+	FindBySlug(ctx context.Context, slug string) (*organization.Organization, error)
+	FindMembership(ctx context.Context, orgID, userID string) (*organization.OrganizationUser, error)
+	AddMember(ctx context.Context, orgUser *organization.OrganizationUser) error
+	UpdateMemberRole(ctx context.Context, orgID, userID string, role organization.Role) error
+	RemoveMember(ctx context.Context, orgID, userID string) error
+	FindMembers(ctx context.Context, orgID string) ([]*organization.OrganizationUser, error)
+}
+
+type Service interface {
+	Config() *ConfigResponse
+	Begin(ctx context.Context) (*AuthRequest, string, error)
+	Complete(ctx context.Context, request *AuthRequest, state, code string) (*auth.LoginResponse, error)
+}
+
+type ServiceImpl struct {
+	provider             *Provider
+	providerName         string
+	groupsClaim          string
+	mappings             []RoleMapping
+	passwordLoginEnabled bool
+	users                auth.Repository
+	memberships          MembershipRepository
+	tokens               TokenIssuer
+	logger               *zap.SugaredLogger
+}
+
+func NewService(
+	cfg *config.Config,
+	users auth.Repository,
+	memberships MembershipRepository,
+	tokens TokenIssuer,
+	logger *zap.SugaredLogger,
+) (Service, error) {
+	mappings, err := ParseRoleMappings(cfg.OIDCRoleMapping)
+	if err != nil {
+		return nil, fmt.Errorf("OIDC_ROLE_MAPPING: %w", err)
+	}
+
+	var provider *Provider
+	if cfg.OIDCIssuerURL != "" && cfg.OIDCClientID != "" {
+		provider = NewProvider(
+			cfg.OIDCIssuerURL,
+			cfg.OIDCClientID,
+			cfg.OIDCClientSecret,
+			cfg.OIDCRedirectURL,
+			splitScopes(cfg.OIDCScopes),
+			nil,
+		)
+	}
+
+	return &ServiceImpl{
+		provider:             provider,
+		providerName:         cfg.OIDCProviderName,
+		groupsClaim:          cfg.OIDCGroupsClaim,
+		mappings:             mappings,
+		passwordLoginEnabled: !cfg.DisablePasswordLogin,
+		users:                users,
+		memberships:          memberships,
+		tokens:               tokens,
+		logger:               logger.Named("[sso-service]"),
+	}, nil
+}
+
+func (s *ServiceImpl) Config() *ConfigResponse {
+	return &ConfigResponse{
+		Enabled:              s.provider != nil,
+		ProviderName:         s.providerName,
+		PasswordLoginEnabled: s.passwordLoginEnabled,
+	}
+}
+
+// Begin starts a login, returning the values to keep until the callback and
+// the IdP URL to redirect the browser to
+func (s *ServiceImpl) Begin(ctx context.Context) (*AuthRequest, string, error) {
+	if s.provider == nil {
+		return nil, "", ErrDisabled
+	}
+
+	request := &AuthRequest{}
+	for _, value := range []*string{&request.State, &request.Nonce, &request.Verifier} {
+		random, err := randomString()
+		if err != nil {
+			return nil, "", err
+		}
+		*value = random
+	}
+
+	authURL, err := s.provider.AuthCodeURL(ctx, request.State, request.Nonce, request.Verifier)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return request, authURL, nil
+}
+
+// Complete finishes a login started by Begin. The user is created on first
+// login and their organization roles are synced from the group claim.
+func (s *ServiceImpl) Complete(ctx context.Context, request *AuthRequest, state, code string) (*auth.LoginResponse, error) {
+	if s.provider == nil {
+		return nil, ErrDisabled
+	}
+	if request == nil || request.State == "" || subtle.ConstantTimeCompare([]byte(request.State), []byte(state)) != 1 {
+		return nil, ErrStateMismatch
+	}
+
+	rawIDToken, err := s.provider.Exchange(ctx, code, request.Verifier)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, err := s.provider.Verify(ctx, rawIDToken, request.Nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	identity := identityFromClaims(claims, s.groupsClaim)
+	user, err := s.provisionUser(ctx, identity)
+	if err != nil {
+		return nil, err
+	}
+
+	s.syncMemberships(ctx, user.ID, identity.Groups)
+
+	accessToken, err := s.tokens.CreateAccessToken(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	refreshToken, err := s.tokens.CreateRefreshToken(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	return &auth.LoginResponse{
+		User:         user,
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+// provisionUser finds the user matching the identity by email or creates
+// one. Users created here have no password and can only sign in through SSO.
+// An existing account is only linked when the provider verified the email,
+// otherwise anyone able to claim the address at the provider would take it over.
+func (s *ServiceImpl) provisionUser(ctx context.Context, identity *Identity) (*auth.Model, error) {
+	if identity.Email == "" {
+		return nil, ErrMissingEmail
+	}
+	if identity.EmailVerified != nil && !*identity.EmailVerified {
+		return nil, ErrEmailUnverified
+	}
+
+	existing, err := s.users.FindByEmail(ctx, identity.Email)
+	if err == nil && existing != nil {
+		if identity.EmailVerified == nil || !*identity.EmailVerified {
+			return nil, ErrAccountExists
+		}
+		if !existing.Active {
+			return nil, ErrUserInactive
+		}
+		return existing, nil
+	}
+
+	// Like registration, the first user of the instance becomes admin
+	count, err := s.users.FindAllCount(ctx)
+	if err != nil {
+		return nil, err
+	}
+	role := auth.RoleUser
+	if count == 0 {
+		role = auth.RoleAdmin
+	}
+
+	name := identity.Name
+	if name == "" {
+		name = identity.Email
+	}
+
+	now := time.Now().UTC()
+	user, err := s.users.Create(ctx, &auth.Model{
+		Email:         identity.Email,
+		Name:          name,
+		ImageURL:      identity.Picture,
+		Active:        true,
+		Role:          role,
+		EmailVerified: identity.EmailVerified != nil && *identity.EmailVerified,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Infow("Provisioned user from single sign-on", "userId", user.ID, "subject", identity.Subject)
+	return user, nil
+}
+
+// syncMemberships makes the user's roles in every mapped organization match
+// their groups. Organizations that are not mapped are left untouched, and so
+// is everything when the token carries no groups claim, as some providers
+// only send it for some clients or scopes. The last admin of an organization
+// is never demoted or removed, so a mapping change cannot lock everyone out.
+// Failures are logged so a misconfigured mapping does not block logins.
+func (s *ServiceImpl) syncMemberships(ctx context.Context, userID string, groups []string) {
+	if len(s.mappings) == 0 {
+		return
+	}
+	if groups == nil {
+		s.logger.Warnw("Token has no groups claim, organization memberships left unchanged", "userId", userID, "claim", s.groupsClaim)
+		return
+	}
+
+	roles := desiredRoles(s.mappings, groups)
+	synced := map[string]bool{}
+
+	for _, mapping := range s.mappings {
+		if synced[mapping.OrgSlug] {
+			continue
+		}
+		synced[mapping.OrgSlug] = true
+
+		org, err := s.memberships.FindBySlug(ctx, mapping.OrgSlug)
+		if err != nil || org == nil {
+			s.logger.Warnw("Organization of role mapping not found", "slug", mapping.OrgSlug, "error", err)
+			continue
+		}
+
+		// A lookup error means the user is not a member yet
+		membership, err := s.memberships.FindMembership(ctx, org.ID, userID)
+		isMember := err == nil && membership != nil
+		role, granted := roles[mapping.OrgSlug]
+
+		if isMember && membership.Role == organization.RoleAdmin && (!granted || role != organization.RoleAdmin) {
+			last, err := s.isLastAdmin(ctx, org.ID, userID)
+			if err != nil || last {
+				s.logger.Warnw("Keeping the last admin of the organization", "orgId", org.ID, "userId", userID, "error", err)
+				continue
+			}
+		}
+
+		var syncErr error
+		switch {
+		case granted && !isMember:
+			syncErr = s.memberships.AddMember(ctx, &organization.OrganizationUser{
+				OrganizationID: org.ID,
+				UserID:         userID,
+				Role:           role,
+			})
+		case granted && membership.Role != role:
+			syncErr = s.memberships.UpdateMemberRole(ctx, org.ID, userID, role)
+		case !granted && isMember:
+			syncErr = s.memberships.RemoveMember(ctx, org.ID, userID)
+		}
+
+		if syncErr != nil {
+			s.logger.Errorw("Failed to sync organization membership", "orgId", org.ID, "userId", userID, "error", syncErr)
+		}
+	}
+}
+
+// isLastAdmin reports whether userID is the only admin of the organization
+func (s *ServiceImpl) isLastAdmin(ctx context.Context, orgID, userID string) (bool, error) {
+	members, err := s.memberships.FindMembers(ctx, orgID)
+	if err != nil {
+		return false, err
+	}
+	for _, member := range members {
+		if member.Role == organization.RoleAdmin && member.UserID != userID {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func splitScopes(value string) []string {
+	return strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ' ' })
+}
@@ -0,0 +1,407 @@
+package sso
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"vigi/internal/config"
+	"vigi/internal/modules/auth"
+	"vigi/internal/modules/organization"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type MockUserRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserRepository) Create(ctx context.Context, user *auth.Model) (*auth.Model, error) {
+	args := m.Called(ctx, user)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*auth.Model), args.Error(1)
+}
+
+func (m *MockUserRepository) FindByEmail(ctx context.Context, email string) (*auth.Model, error) {
+	args := m.Called(ctx, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*auth.Model), args.Error(1)
+}
+
+func (m *MockUserRepository) FindByID(ctx context.Context, id string) (*auth.Model, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*auth.Model), args.Error(1)
+}
+
+func (m *MockUserRepository) FindAllCount(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockUserRepository) FindAll(ctx context.Context) ([]*auth.Model, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*auth.Model), args.Error(1)
+}
+
+func (m *MockUserRepository) Update(ctx context.Context, id string, entity *auth.UpdateModel) error {
+	args := m.Called(ctx, id, entity)
+	return args.Error(0)
+}
+
+type MockMembershipRepository struct {
+	mock.Mock
+}
+
+func (m *MockMembershipRepository) FindBySlug(ctx context.Context, slug string) (*organization.Organization, error) {
+	args := m.Called(ctx, slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*organization.Organization), args.Error(1)
+}
+
+func (m *MockMembershipRepository) FindMembership(ctx context.Context, orgID, userID string) (*organization.OrganizationUser, error) {
+	args := m.Called(ctx, orgID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*organization.OrganizationUser), args.Error(1)
+}
+
+func (m *MockMembershipRepository) AddMember(ctx context.Context, orgUser *organization.OrganizationUser) error {
+	args := m.Called(ctx, orgUser)
+	return args.Error(0)
+}
+
+func (m *MockMembershipRepository) UpdateMemberRole(ctx context.Context, orgID, userID string, role organization.Role) error {
+	args := m.Called(ctx, orgID, userID, role)
+	return args.Error(0)
+}
+
+func (m *MockMembershipRepository) RemoveMember(ctx context.Context, orgID, userID string) error {
+	args := m.Called(ctx, orgID, userID)
+	return args.Error(0)
+}
+
+func (m *MockMembershipRepository) FindMembers(ctx context.Context, orgID string) ([]*organization.OrganizationUser, error) {
+	args := m.Called(ctx, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*organization.OrganizationUser), args.Error(1)
+}
+
+type MockTokenIssuer struct{}
+
+func (MockTokenIssuer) CreateAccessToken(ctx context.Context, user *auth.Model) (string, error) {
+	return "access-" + user.ID, nil
+}
+
+func (MockTokenIssuer) CreateRefreshToken(ctx context.Context, user *auth.Model) (string, error) {
+	return "refresh-" + user.ID, nil
+}
+
+func newTestService(t *testing.T, issuer *mockIssuer, roleMapping string, users *MockUserRepository, memberships *MockMembershipRepository) Service {
+	t.Helper()
+
+	cfg := &config.Config{
+		OIDCIssuerURL:    issuer.server.URL,
+		OIDCClientID:     testClientID,
+		OIDCClientSecret: testClientSecret,
+		OIDCRedirectURL:  testRedirectURL,
+		OIDCScopes:       "openid,email,profile",
+		OIDCGroupsClaim:  "groups",
+		OIDCRoleMapping:  roleMapping,
+		OIDCProviderName: "Mock",
+	}
+
+	service, err := NewService(cfg, users, memberships, MockTokenIssuer{}, zap.NewNop().Sugar())
+	require.NoError(t, err)
+	return service
+}
+
+// login runs the whole flow against the mock issuer
+func login(t *testing.T, service Service, issuer *mockIssuer, claims jwt.MapClaims) (*auth.LoginResponse, error) {
+	t.Helper()
+
+	request, authURL, err := service.Begin(context.Background())
+	require.NoError(t, err)
+
+	code, state := issuer.authorize(t, authURL, claims)
+	return service.Complete(context.Background(), request, state, code)
+}
+
+func TestService_Complete_ProvisionsUser(t *testing.T) {
+	issuer := newMockIssuer(t)
+	users := new(MockUserRepository)
+	service := newTestService(t, issuer, "", users, new(MockMembershipRepository))
+
+	users.On("FindByEmail", mock.Anything, "jane@example.com").Return(nil, sql.ErrNoRows)
+	users.On("FindAllCount", mock.Anything).Return(int64(3), nil)
+	users.On("Create", mock.Anything, mock.MatchedBy(func(user *auth.Model) bool {
+		return user.Email == "jane@example.com" && user.Name == "Jane" && user.Password == "" && user.Role == auth.RoleUser && user.Active
+	})).Return(&auth.Model{ID: "user-1", Email: "jane@example.com", Name: "Jane", Role: auth.RoleUser, Active: true}, nil)
+
+	response, err := login(t, service, issuer, jwt.MapClaims{
+		"sub":            "idp-1",
+		"email":          "Jane@Example.com",
+		"email_verified": true,
+		"name":           "Jane",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "user-1", response.User.ID)
+	assert.Equal(t, "access-user-1", response.AccessToken)
+	assert.Equal(t, "refresh-user-1", response.RefreshToken)
+	users.AssertExpectations(t)
+}
+
+func TestService_Complete_ExistingUser(t *testing.T) {
+	issuer := newMockIssuer(t)
+	users := new(MockUserRepository)
+	service := newTestService(t, issuer, "", users, new(MockMembershipRepository))
+
+	users.On("FindByEmail", mock.Anything, "jane@example.com").
+		Return(&auth.Model{ID: "user-1", Email: "jane@example.com", Active: true}, nil)
+
+	response, err := login(t, service, issuer, jwt.MapClaims{"sub": "idp-1", "email": "jane@example.com", "email_verified": true})
+	require.NoError(t, err)
+
+	assert.Equal(t, "user-1", response.User.ID)
+	users.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestService_Complete_Rejections(t *testing.T) {
+	tests := []struct {
+		name        string
+		claims      jwt.MapClaims
+		user        *auth.Model
+		expectedErr error
+	}{
+		{
+			name:        "missing email",
+			claims:      jwt.MapClaims{"sub": "idp-1"},
+			expectedErr: ErrMissingEmail,
+		},
+		{
+			name:        "unverified email",
+			claims:      jwt.MapClaims{"sub": "idp-1", "email": "jane@example.com", "email_verified": false},
+			expectedErr: ErrEmailUnverified,
+		},
+		{
+			name:        "existing user without verified email",
+			claims:      jwt.MapClaims{"sub": "idp-1", "email": "jane@example.com"},
+			user:        &auth.Model{ID: "user-1", Email: "jane@example.com", Active: true},
+			expectedErr: ErrAccountExists,
+		},
+		{
+			name:        "inactive user",
+			claims:      jwt.MapClaims{"sub": "idp-1", "email": "jane@example.com", "email_verified": true},
+			user:        &auth.Model{ID: "user-1", Email: "jane@example.com", Active: false},
+			expectedErr: ErrUserInactive,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issuer := newMockIssuer(t)
+			users := new(MockUserRepository)
+			service := newTestService(t, issuer, "", users, new(MockMembershipRepository))
+
+			if tt.user != nil {
+				users.On("FindByEmail", mock.Anything, tt.user.Email).Return(tt.user, nil)
+			}
+
+			_, err := login(t, service, issuer, tt.claims)
+			assert.ErrorIs(t, err, tt.expectedErr)
+			users.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestService_Complete_StateMismatch(t *testing.T) {
+	issuer := newMockIssuer(t)
+	service := newTestService(t, issuer, "", new(MockUserRepository), new(MockMembershipRepository))
+
+	request, authURL, err := service.Begin(context.Background())
+	require.NoError(t, err)
+	code, _ := issuer.authorize(t, authURL, jwt.MapClaims{"sub": "idp-1", "email": "jane@example.com"})
+
+	_, err = service.Complete(context.Background(), request, "forged-state", code)
+	assert.ErrorIs(t, err, ErrStateMismatch)
+
+	_, err = service.Complete(context.Background(), nil, request.State, code)
+	assert.ErrorIs(t, err, ErrStateMismatch)
+}
+
+func TestService_Complete_SyncsMemberships(t *testing.T) {
+	issuer := newMockIssuer(t)
+	users := new(MockUserRepository)
+	memberships := new(MockMembershipRepository)
+	service := newTestService(t, issuer, "ops=acme:admin,devs=acme:member,devs=globex:member,qa=initech:member", users, memberships)
+
+	users.On("FindByEmail", mock.Anything, "jane@example.com").
+		Return(&auth.Model{ID: "user-1", Email: "jane@example.com", Active: true}, nil)
+
+	memberships.On("FindBySlug", mock.Anything, "acme").Return(&organization.Organization{ID: "org-acme"}, nil)
+	memberships.On("FindBySlug", mock.Anything, "globex").Return(&organization.Organization{ID: "org-globex"}, nil)
+	memberships.On("FindBySlug", mock.Anything, "initech").Return(&organization.Organization{ID: "org-initech"}, nil)
+
+	// Member of acme gets promoted, globex is joined, initech is left
+	memberships.On("FindMembership", mock.Anything, "org-acme", "user-1").
+		Return(&organization.OrganizationUser{OrganizationID: "org-acme", UserID: "user-1", Role: organization.RoleMember}, nil)
+	memberships.On("FindMembership", mock.Anything, "org-globex", "user-1").Return(nil, sql.ErrNoRows)
+	memberships.On("FindMembership", mock.Anything, "org-initech", "user-1").
+		Return(&organization.OrganizationUser{OrganizationID: "org-initech", UserID: "user-1", Role: organization.RoleMember}, nil)
+
+	memberships.On("UpdateMemberRole", mock.Anything, "org-acme", "user-1", organization.RoleAdmin).Return(nil)
+	memberships.On("AddMember", mock.Anything, mock.MatchedBy(func(orgUser *organization.OrganizationUser) bool {
+		return orgUser.OrganizationID == "org-globex" && orgUser.UserID == "user-1" && orgUser.Role == organization.RoleMember
+	})).Return(nil)
+	memberships.On("RemoveMember", mock.Anything, "org-initech", "user-1").Return(nil)
+
+	_, err := login(t, service, issuer, jwt.MapClaims{
+		"sub":            "idp-1",
+		"email":          "jane@example.com",
+		"email_verified": true,
+		"groups":         []string{"ops", "devs"},
+	})
+	require.NoError(t, err)
+
+	memberships.AssertExpectations(t)
+}
+
+func TestService_Complete_MissingGroupsClaim(t *testing.T) {
+	issuer := newMockIssuer(t)
+	users := new(MockUserRepository)
+	memberships := new(MockMembershipRepository)
+	service := newTestService(t, issuer, "ops=acme:admin", users, memberships)
+
+	users.On("FindByEmail", mock.Anything, "jane@example.com").
+		Return(&auth.Model{ID: "user-1", Email: "jane@example.com", Active: true}, nil)
+
+	_, err := login(t, service, issuer, jwt.MapClaims{
+		"sub":            "idp-1",
+		"email":          "jane@example.com",
+		"email_verified": true,
+	})
+	require.NoError(t, err)
+
+	// Without the claim nothing is known about the groups, memberships stay as they are
+	memberships.AssertNotCalled(t, "FindBySlug", mock.Anything, mock.Anything)
+	memberships.AssertNotCalled(t, "RemoveMember", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestService_Complete_KeepsLastAdmin(t *testing.T) {
+	issuer := newMockIssuer(t)
+	users := new(MockUserRepository)
+	memberships := new(MockMembershipRepository)
+	service := newTestService(t, issuer, "ops=acme:admin,ops=globex:admin", users, memberships)
+
+	users.On("FindByEmail", mock.Anything, "jane@example.com").
+		Return(&auth.Model{ID: "user-1", Email: "jane@example.com", Active: true}, nil)
+
+	memberships.On("FindBySlug", mock.Anything, "acme").Return(&organization.Organization{ID: "org-acme"}, nil)
+	memberships.On("FindBySlug", mock.Anything, "globex").Return(&organization.Organization{ID: "org-globex"}, nil)
+	memberships.On("FindMembership", mock.Anything, "org-acme", "user-1").
+		Return(&organization.OrganizationUser{OrganizationID: "org-acme", UserID: "user-1", Role: organization.RoleAdmin}, nil)
+	memberships.On("FindMembership", mock.Anything, "org-globex", "user-1").
+		Return(&organization.OrganizationUser{OrganizationID: "org-globex", UserID: "user-1", Role: organization.RoleAdmin}, nil)
+
+	// acme has no other admin, globex has one
+	memberships.On("FindMembers", mock.Anything, "org-acme").Return([]*organization.OrganizationUser{
+		{UserID: "user-1", Role: organization.RoleAdmin},
+		{UserID: "user-2", Role: organization.RoleMember},
+	}, nil)
+	memberships.On("FindMembers", mock.Anything, "org-globex").Return([]*organization.OrganizationUser{
+		{UserID: "user-1", Role: organization.RoleAdmin},
+		{UserID: "user-2", Role: organization.RoleAdmin},
+	}, nil)
+	memberships.On("RemoveMember", mock.Anything, "org-globex", "user-1").Return(nil)
+
+	_, err := login(t, service, issuer, jwt.MapClaims{
+		"sub":            "idp-1",
+		"email":          "jane@example.com",
+		"email_verified": true,
+		"groups":         []string{},
+	})
+	require.NoError(t, err)
+
+	memberships.AssertExpectations(t)
+	memberships.AssertNotCalled(t, "RemoveMember", mock.Anything, "org-acme", "user-1")
+}
+
+func TestService_Config(t *testing.T) {
+	issuer := newMockIssuer(t)
+	service := newTestService(t, issuer, "", new(MockUserRepository), new(MockMembershipRepository))
+
+	assert.Equal(t, &ConfigResponse{Enabled: true, ProviderName: "Mock", PasswordLoginEnabled: true}, service.Config())
+
+	disabled, err := NewService(&config.Config{}, new(MockUserRepository), new(MockMembershipRepository), MockTokenIssuer{}, zap.NewNop().Sugar())
+	require.NoError(t, err)
+	assert.False(t, disabled.Config().Enabled)
+
+	_, _, err = disabled.Begin(context.Background())
+	assert.ErrorIs(t, err, ErrDisabled)
+}
+
+func TestParseRoleMappings(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		expected    []RoleMapping
+		expectError bool
+	}{
+		{name: "empty", value: ""},
+		{
+			name:  "multiple entries",
+			value: "ops=acme:admin, devs = acme:member",
+			expected: []RoleMapping{
+				{Group: "ops", OrgSlug: "acme", Role: organization.RoleAdmin},
+				{Group: "devs", OrgSlug: "acme", Role: organization.RoleMember},
+			},
+		},
+		{name: "missing role", value: "ops=acme", expectError: true},
+		{name: "missing group", value: "=acme:admin", expectError: true},
+		{name: "unknown role", value: "ops=acme:owner", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mappings, err := ParseRoleMappings(tt.value)
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, mappings)
+		})
+	}
+}
+
+func TestListClaim(t *testing.T) {
+	claims := jwt.MapClaims{
+		"groups":       []any{"ops", "devs", 3},
+		"role":         "admin",
+		"realm_access": map[string]any{"roles": []any{"viewer"}},
+	}
+
+	assert.Equal(t, []string{"ops", "devs"}, listClaim(claims, "groups"))
+	assert.Equal(t, []string{"admin"}, listClaim(claims, "role"))
+	assert.Equal(t, []string{"viewer"}, listClaim(claims, "realm_access.roles"))
+	assert.Nil(t, listClaim(claims, "missing"))
+	assert.Nil(t, listClaim(claims, "role.nested"))
+}
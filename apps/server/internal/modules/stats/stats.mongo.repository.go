@@ -90,6 +90,7 @@ type mongoModel struct {
 	Up          int                `bson:"up"`
 	Down        int                `bson:"down"`
 	Maintenance int                `bson:"maintenance"`
+	Degraded    int                `bson:"degraded"`
 }
 
 func toDomainModel(mm *mongoModel) *Stat {
@@ -103,6 +104,7 @@ func toDomainModel(mm *mongoModel) *Stat {
 		Up:          mm.Up,
 		Down:        mm.Down,
 		Maintenance: mm.Maintenance,
+		Degraded:    mm.Degraded,
 	}
 }
 
@@ -161,6 +163,7 @@ func (r *MongoRepository) UpsertStat(ctx context.Context, stat *Stat, period Sta
 		Up:          stat.Up,
 		Down:        stat.Down,
 		Maintenance: stat.Maintenance,
+		Degraded:    stat.Degraded,
 	}
 
 	filter := bson.M{"monitor_id": mm.MonitorID, "timestamp": mm.Timestamp}
@@ -173,6 +176,7 @@ func (r *MongoRepository) UpsertStat(ctx context.Context, stat *Stat, period Sta
 				"up":          mm.Up,
 				"down":        mm.Down,
 				"maintenance": mm.Maintenance,
+				"degraded":    mm.Degraded,
 			},
 		}
 	_, err = coll.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
@@ -236,3 +240,17 @@ func (r *MongoRepository) DeleteByMonitorID(ctx context.Context, monitorID strin
 
 	return nil
 }
+
+func (r *MongoRepository) DeleteOlderThan(ctx context.Context, monitorID string, period StatPeriod, cutoff time.Time) (int64, error) {
+	objectID, err := primitive.ObjectIDFromHex(monitorID)
+	if err != nil {
+		return 0, fmt.Errorf("invalid monitorID: %w", err)
+	}
+
+	filter := bson.M{"monitor_id": objectID, "timestamp": bson.M{"$lt": cutoff}}
+	result, err := r.getStatCollection(period).DeleteMany(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
@@ -10,4 +10,5 @@ type Repository interface {
 	UpsertStat(ctx context.Context, stat *Stat, period StatPeriod) error
 	FindStatsByMonitorIDAndTimeRange(ctx context.Context, monitorID string, since, until time.Time, period StatPeriod) ([]*Stat, error)
 	DeleteByMonitorID(ctx context.Context, monitorID string) error
+	DeleteOlderThan(ctx context.Context, monitorID string, period StatPeriod, cutoff time.Time) (int64, error)
 }
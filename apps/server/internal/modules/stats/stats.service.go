@@ -3,6 +3,7 @@ package stats
 import (
 	"context"
 	"fmt"
+	"sort"
 	"vigi/internal/infra"
 	"vigi/internal/modules/events"
 	"vigi/internal/modules/shared"
@@ -25,6 +26,11 @@ type Service interface {
 	FindStatsByMonitorIDAndTimeRangeWithInterval(ctx context.Context, monitorID string, since, until time.Time, period StatPeriod, monitorInterval int) ([]*Stat, error)
 	StatPointsSummary(statsList []*Stat) *Stats
 	DeleteByMonitorID(ctx context.Context, monitorID string) error
+
+	// RebuildRollups makes sure hourly and daily stats exist for the given heartbeats
+	RebuildRollups(ctx context.Context, monitorID string, heartbeats []*HeartbeatPayload, since, until time.Time) (int, error)
+	// DeleteOlderThan deletes the monitor stats of a period with a bucket before cutoff
+	DeleteOlderThan(ctx context.Context, monitorID string, period StatPeriod, cutoff time.Time) (int64, error)
 }
 
 type ServiceImpl struct {
@@ -38,7 +44,7 @@ func NewService(repo Repository, logger *zap.SugaredLogger) Service {
 
 func (s *ServiceImpl) flatStatus(status int) int {
 	switch status {
-	case 1, 3: // MonitorStatusUp, MonitorStatusMaintenance
+	case 1, 3, 4: // MonitorStatusUp, MonitorStatusMaintenance, MonitorStatusDegraded
 		return 1 // MonitorStatusUp
 	case 0, 2: // MonitorStatusDown, MonitorStatusPending
 		return 0 // MonitorStatusDown
@@ -47,17 +53,18 @@ func (s *ServiceImpl) flatStatus(status int) int {
 	}
 }
 
-func (s *ServiceImpl) AggregateHeartbeat(ctx context.Context, hb *HeartbeatPayload) error {
-	periods := []struct {
-		Period StatPeriod
-		Bucket time.Duration
-	}{
-		{StatMinutely, time.Minute},
-		{StatHourly, time.Hour},
-		{StatDaily, 24 * time.Hour},
-	}
+// rollupPeriods are the stat periods a heartbeat is aggregated into, with their bucket size
+var rollupPeriods = []struct {
+	Period StatPeriod
+	Bucket time.Duration
+}{
+	{StatMinutely, time.Minute},
+	{StatHourly, time.Hour},
+	{StatDaily, 24 * time.Hour},
+}
 
-	for _, p := range periods {
+func (s *ServiceImpl) AggregateHeartbeat(ctx context.Context, hb *HeartbeatPayload) error {
+	for _, p := range rollupPeriods {
 		bucketTime := time.Unix(hb.Time, 0).Truncate(p.Bucket)
 
 		stat, err := s.repo.GetOrCreateStat(ctx, hb.MonitorID, bucketTime, p.Period)
@@ -65,51 +72,114 @@ func (s *ServiceImpl) AggregateHeartbeat(ctx context.Context, hb *HeartbeatPaylo
 			return err
 		}
 
-		statToUpsert := *stat // copy
+		statToUpsert := s.applyHeartbeat(*stat, hb)
 
-		// Up/Down logic (flattened)
-		if s.flatStatus(hb.Status) == 1 { // MonitorStatusUp
-			statToUpsert.Up = stat.Up + 1
-			// Only update ping stats for true UP
-			if hb.Status == 1 { // MonitorStatusUp
-				fPing := float64(hb.Ping)
-				if stat.Up == 0 {
+		// Upsert stat
+		if err := s.repo.UpsertStat(ctx, &statToUpsert, p.Period); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyHeartbeat returns a copy of stat with the heartbeat counted in
+func (s *ServiceImpl) applyHeartbeat(stat Stat, hb *HeartbeatPayload) Stat {
+	statToUpsert := stat // copy
+
+	// Up/Down logic (flattened)
+	if s.flatStatus(hb.Status) == 1 { // MonitorStatusUp
+		statToUpsert.Up = stat.Up + 1
+		// Only update ping stats for checks that actually ran
+		if hb.Status == 1 || hb.Status == 4 { // MonitorStatusUp, MonitorStatusDegraded
+			fPing := float64(hb.Ping)
+			if stat.Up == 0 {
+				statToUpsert.PingMin = fPing
+				statToUpsert.Ping = fPing
+				statToUpsert.PingMax = fPing
+			} else {
+				statToUpsert.Ping = (stat.Ping*float64(stat.Up) + fPing) / float64(stat.Up+1)
+
+				// Update ping min if new ping is lower
+				if fPing < stat.PingMin || stat.PingMin == 0 {
 					statToUpsert.PingMin = fPing
-					statToUpsert.Ping = fPing
+				} else {
+					statToUpsert.PingMin = stat.PingMin
+				}
+
+				// Update ping max if new ping is higher
+				if fPing > stat.PingMax {
 					statToUpsert.PingMax = fPing
 				} else {
-					statToUpsert.Ping = (stat.Ping*float64(stat.Up) + fPing) / float64(stat.Up+1)
-
-					// Update ping min if new ping is lower
-					if fPing < stat.PingMin || stat.PingMin == 0 {
-						statToUpsert.PingMin = fPing
-					} else {
-						statToUpsert.PingMin = stat.PingMin
-					}
-
-					// Update ping max if new ping is higher
-					if fPing > stat.PingMax {
-						statToUpsert.PingMax = fPing
-					} else {
-						statToUpsert.PingMax = stat.PingMax
-					}
+					statToUpsert.PingMax = stat.PingMax
 				}
 			}
-		} else if s.flatStatus(hb.Status) == 0 { // MonitorStatusDown
-			statToUpsert.Down = stat.Down + 1
+		}
+	} else if s.flatStatus(hb.Status) == 0 { // MonitorStatusDown
+		statToUpsert.Down = stat.Down + 1
+	}
+
+	// Aggregate maintenance status separately
+	if hb.Status == 3 { // MonitorStatusMaintenance
+		statToUpsert.Maintenance = stat.Maintenance + 1
+	}
+
+	// Degraded beats count as up and are also tracked on their own
+	if hb.Status == 4 { // MonitorStatusDegraded
+		statToUpsert.Degraded = stat.Degraded + 1
+	}
+
+	return statToUpsert
+}
+
+// RebuildRollups recomputes the hourly and daily stats of the monitor between since and
+// until from its raw heartbeats. A bucket is only written when it is missing or counts
+// fewer heartbeats than the raw data, so rollups aggregated live are never replaced by
+// a partial recount. It returns the number of buckets written.
+func (s *ServiceImpl) RebuildRollups(ctx context.Context, monitorID string, heartbeats []*HeartbeatPayload, since, until time.Time) (int, error) {
+	written := 0
+	for _, p := range rollupPeriods {
+		if p.Period == StatMinutely {
+			continue
 		}
 
-		// Aggregate maintenance status separately
-		if hb.Status == 3 { // MonitorStatusMaintenance
-			statToUpsert.Maintenance = stat.Maintenance + 1
+		buckets := make(map[int64]*Stat)
+		keys := make([]int64, 0)
+		for _, hb := range heartbeats {
+			bucketTime := time.Unix(hb.Time, 0).UTC().Truncate(p.Bucket)
+			if bucketTime.Before(since) || !bucketTime.Before(until) {
+				continue
+			}
+
+			key := bucketTime.Unix()
+			stat, ok := buckets[key]
+			if !ok {
+				stat = &Stat{MonitorID: monitorID, Timestamp: bucketTime}
+				buckets[key] = stat
+				keys = append(keys, key)
+			}
+			*stat = s.applyHeartbeat(*stat, hb)
 		}
+		sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
 
-		// Upsert stat
-		if err := s.repo.UpsertStat(ctx, &statToUpsert, p.Period); err != nil {
-			return err
+		for _, key := range keys {
+			rebuilt := buckets[key]
+
+			existing, err := s.repo.GetOrCreateStat(ctx, monitorID, rebuilt.Timestamp, p.Period)
+			if err != nil {
+				return written, err
+			}
+			if existing.Up+existing.Down >= rebuilt.Up+rebuilt.Down {
+				continue
+			}
+
+			rebuilt.ID = existing.ID
+			if err := s.repo.UpsertStat(ctx, rebuilt, p.Period); err != nil {
+				return written, err
+			}
+			written++
 		}
 	}
-	return nil
+	return written, nil
 }
 
 func (s *ServiceImpl) RegisterEventHandlers(eventBus events.EventBus) {
@@ -185,6 +255,7 @@ func (s *ServiceImpl) FindStatsByMonitorIDAndTimeRangeWithInterval(ctx context.C
 				Up:          0,
 				Down:        0,
 				Maintenance: 0,
+				Degraded:    0,
 			})
 		}
 	}
@@ -243,6 +314,7 @@ func (s *ServiceImpl) groupStatsByInterval(minuteStats []*Stat, since, until tim
 				Up:          0,
 				Down:        0,
 				Maintenance: 0,
+				Degraded:    0,
 			})
 		}
 	}
@@ -263,11 +335,12 @@ func (s *ServiceImpl) aggregateStats(stats []*Stat, timestamp time.Time, monitor
 			Up:          0,
 			Down:        0,
 			Maintenance: 0,
+			Degraded:    0,
 		}
 	}
 
 	var totalPing, minPing, maxPing float64
-	var totalUp, totalDown, totalMaintenance int
+	var totalUp, totalDown, totalMaintenance, totalDegraded int
 	var pingCount int
 	var hasValidPing bool
 
@@ -275,6 +348,7 @@ func (s *ServiceImpl) aggregateStats(stats []*Stat, timestamp time.Time, monitor
 		totalUp += stat.Up
 		totalDown += stat.Down
 		totalMaintenance += stat.Maintenance
+		totalDegraded += stat.Degraded
 
 		// Only include stats with valid ping values (> 0) for ping calculations
 		if stat.Up > 0 && stat.Ping > 0 {
@@ -318,6 +392,7 @@ func (s *ServiceImpl) aggregateStats(stats []*Stat, timestamp time.Time, monitor
 		Up:          totalUp,
 		Down:        totalDown,
 		Maintenance: totalMaintenance,
+		Degraded:    totalDegraded,
 	}
 }
 
@@ -328,6 +403,7 @@ type Stats struct {
 	AvgPing     *float64 `json:"avgPing"`
 	Uptime      *float64 `json:"uptime"`
 	Maintenance *float64 `json:"maintenance"`
+	Degraded    *float64 `json:"degraded"`
 }
 
 // StatPointsSummary computes stat points and summary for a period using flatStatus logic
@@ -336,7 +412,7 @@ func (s *ServiceImpl) StatPointsSummary(statsList []*Stat) *Stats {
 	var minPing *float64
 	var sumPing float64
 	var upCount int
-	var totalUp, totalDown, totalMaintenance int
+	var totalUp, totalDown, totalMaintenance, totalDegraded int
 
 	for _, s := range statsList {
 		if s.Up > 0 {
@@ -354,6 +430,7 @@ func (s *ServiceImpl) StatPointsSummary(statsList []*Stat) *Stats {
 		totalUp += s.Up
 		totalDown += s.Down
 		totalMaintenance += s.Maintenance
+		totalDegraded += s.Degraded
 	}
 
 	var avgPing *float64
@@ -364,6 +441,7 @@ func (s *ServiceImpl) StatPointsSummary(statsList []*Stat) *Stats {
 
 	var uptime *float64
 	var maintenance *float64
+	var degraded *float64
 	total := totalUp + totalDown + totalMaintenance
 	if total > 0 {
 		uptimeV := float64(totalUp) / float64(total) * 100
@@ -371,6 +449,9 @@ func (s *ServiceImpl) StatPointsSummary(statsList []*Stat) *Stats {
 
 		maintenanceV := float64(totalMaintenance) / float64(total) * 100
 		maintenance = &maintenanceV
+
+		degradedV := float64(totalDegraded) / float64(total) * 100
+		degraded = &degradedV
 	}
 
 	return &Stats{
@@ -379,9 +460,14 @@ func (s *ServiceImpl) StatPointsSummary(statsList []*Stat) *Stats {
 		AvgPing:     avgPing,
 		Uptime:      uptime,
 		Maintenance: maintenance,
+		Degraded:    degraded,
 	}
 }
 
 func (s *ServiceImpl) DeleteByMonitorID(ctx context.Context, monitorID string) error {
 	return s.repo.DeleteByMonitorID(ctx, monitorID)
 }
+
+func (s *ServiceImpl) DeleteOlderThan(ctx context.Context, monitorID string, period StatPeriod, cutoff time.Time) (int64, error) {
+	return s.repo.DeleteOlderThan(ctx, monitorID, period, cutoff)
+}
@@ -13,6 +13,7 @@ type sqlModel struct {
 
 	ID          string    `bun:"id,pk"`
 	MonitorID   string    `bun:"monitor_id,notnull"`
+	Period      string    `bun:"period,notnull"`
 	Timestamp   time.Time `bun:"timestamp,notnull"`
 	Ping        float64   `bun:"ping,notnull,default:0"`
 	PingMin     float64   `bun:"ping_min,notnull,default:0"`
@@ -20,6 +21,7 @@ type sqlModel struct {
 	Up          int       `bun:"up,notnull,default:0"`
 	Down        int       `bun:"down,notnull,default:0"`
 	Maintenance int       `bun:"maintenance,notnull,default:0"`
+	Degraded    int       `bun:"degraded,notnull,default:0"`
 	CreatedAt   time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp"`
 	UpdatedAt   time.Time `bun:"updated_at,nullzero,notnull,default:current_timestamp"`
 }
@@ -35,13 +37,15 @@ func toDomainModelFromSQL(sm *sqlModel) *Stat {
 		Up:          sm.Up,
 		Down:        sm.Down,
 		Maintenance: sm.Maintenance,
+		Degraded:    sm.Degraded,
 	}
 }
 
-func toSQLModel(s *Stat) *sqlModel {
+func toSQLModel(s *Stat, period StatPeriod) *sqlModel {
 	return &sqlModel{
 		ID:          s.ID,
 		MonitorID:   s.MonitorID,
+		Period:      string(period),
 		Timestamp:   s.Timestamp,
 		Ping:        s.Ping,
 		PingMin:     s.PingMin,
@@ -49,6 +53,7 @@ func toSQLModel(s *Stat) *sqlModel {
 		Up:          s.Up,
 		Down:        s.Down,
 		Maintenance: s.Maintenance,
+		Degraded:    s.Degraded,
 	}
 }
 
@@ -66,7 +71,7 @@ func (r *SQLRepositoryImpl) GetOrCreateStat(ctx context.Context, monitorID strin
 	// Try to find existing stat
 	err := r.db.NewSelect().
 		Model(sm).
-		Where("monitor_id = ? AND timestamp = ?", monitorID, timestamp).
+		Where("monitor_id = ? AND period = ? AND timestamp = ?", monitorID, period, timestamp).
 		Scan(ctx)
 
 	if err != nil && err.Error() == "sql: no rows in result set" {
@@ -74,6 +79,7 @@ func (r *SQLRepositoryImpl) GetOrCreateStat(ctx context.Context, monitorID strin
 		sm = &sqlModel{
 			ID:          uuid.New().String(),
 			MonitorID:   monitorID,
+			Period:      string(period),
 			Timestamp:   timestamp,
 			Ping:        0,
 			PingMin:     0,
@@ -81,6 +87,7 @@ func (r *SQLRepositoryImpl) GetOrCreateStat(ctx context.Context, monitorID strin
 			Up:          0,
 			Down:        0,
 			Maintenance: 0,
+			Degraded:    0,
 			CreatedAt:   time.Now(),
 			UpdatedAt:   time.Now(),
 		}
@@ -97,19 +104,20 @@ func (r *SQLRepositoryImpl) GetOrCreateStat(ctx context.Context, monitorID strin
 }
 
 func (r *SQLRepositoryImpl) UpsertStat(ctx context.Context, stat *Stat, period StatPeriod) error {
-	sm := toSQLModel(stat)
+	sm := toSQLModel(stat, period)
 	sm.UpdatedAt = time.Now()
 
 	// Try to update existing record first
 	result, err := r.db.NewUpdate().
 		Model(sm).
-		Where("monitor_id = ? AND timestamp = ?", sm.MonitorID, sm.Timestamp).
+		Where("monitor_id = ? AND period = ? AND timestamp = ?", sm.MonitorID, sm.Period, sm.Timestamp).
 		Set("ping = ?", sm.Ping).
 		Set("ping_min = ?", sm.PingMin).
 		Set("ping_max = ?", sm.PingMax).
 		Set("up = ?", sm.Up).
 		Set("down = ?", sm.Down).
 		Set("maintenance = ?", sm.Maintenance).
+		Set("degraded = ?", sm.Degraded).
 		Set("updated_at = ?", sm.UpdatedAt).
 		Exec(ctx)
 
@@ -144,7 +152,7 @@ func (r *SQLRepositoryImpl) FindStatsByMonitorIDAndTimeRange(ctx context.Context
 	var sms []*sqlModel
 	err := r.db.NewSelect().
 		Model(&sms).
-		Where("monitor_id = ? AND timestamp BETWEEN ? AND ?", monitorID, since, until).
+		Where("monitor_id = ? AND period = ? AND timestamp BETWEEN ? AND ?", monitorID, period, since, until).
 		Order("timestamp ASC").
 		Scan(ctx)
 	if err != nil {
@@ -165,3 +173,16 @@ func (r *SQLRepositoryImpl) DeleteByMonitorID(ctx context.Context, monitorID str
 		Exec(ctx)
 	return err
 }
+
+func (r *SQLRepositoryImpl) DeleteOlderThan(ctx context.Context, monitorID string, period StatPeriod, cutoff time.Time) (int64, error) {
+	result, err := r.db.NewDelete().
+		Model((*sqlModel)(nil)).
+		Where("monitor_id = ? AND period = ? AND timestamp < ?", monitorID, period, cutoff).
+		Exec(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	return rowsAffected, nil
+}
@@ -4,7 +4,9 @@ import (
 	"net/http"
 	"time"
 	"vigi/internal/modules/heartbeat"
+	"vigi/internal/modules/incident"
 	"vigi/internal/modules/monitor"
+	"vigi/internal/modules/monitor_status_page"
 	"vigi/internal/utils"
 
 	"github.com/gin-gonic/gin"
@@ -15,14 +17,16 @@ type Controller struct {
 	service          Service
 	monitorService   monitor.Service
 	heartbeatService heartbeat.Service
+	incidentService  incident.Service
 	logger           *zap.SugaredLogger
 }
 
-func NewController(service Service, monitorService monitor.Service, heartbeatService heartbeat.Service, logger *zap.SugaredLogger) *Controller {
+func NewController(service Service, monitorService monitor.Service, heartbeatService heartbeat.Service, incidentService incident.Service, logger *zap.SugaredLogger) *Controller {
 	return &Controller{
 		service:          service,
 		monitorService:   monitorService,
 		heartbeatService: heartbeatService,
+		incidentService:  incidentService,
 		logger:           logger,
 	}
 }
@@ -75,6 +79,16 @@ func (c *Controller) Create(ctx *gin.Context) {
 			})
 			return
 		}
+		// Surface unknown monitor groups as 400
+		if groupErr, ok := err.(*GroupNotFoundError); ok {
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"error": gin.H{
+					"code":     groupErr.Code,
+					"group_id": groupErr.GroupID,
+				},
+			})
+			return
+		}
 		c.logger.Errorw("Failed to create status page", "error", err)
 		ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse("Internal server error"))
 		return
@@ -240,6 +254,16 @@ func (c *Controller) Update(ctx *gin.Context) {
 			})
 			return
 		}
+		// Surface unknown monitor groups as 400
+		if groupErr, ok := err.(*GroupNotFoundError); ok {
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"error": gin.H{
+					"code":     groupErr.Code,
+					"group_id": groupErr.GroupID,
+				},
+			})
+			return
+		}
 		c.logger.Errorw("Failed to update status page", "error", err, "id", id)
 		ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse("Internal server error"))
 		return
@@ -302,6 +326,9 @@ func (c *Controller) GetMonitorsBySlug(ctx *gin.Context) {
 		return
 	}
 
+	monitors = c.withGroupMonitors(ctx, page, monitors)
+	incidents := c.incidentsByMonitor(ctx, page, monitors)
+
 	// Convert monitor_status_page models to monitor models with heartbeats and uptime
 	monitorModels := make([]*MonitorWithHeartbeatsAndUptimeDTO, 0, len(monitors))
 	for _, msp := range monitors {
@@ -365,6 +392,11 @@ func (c *Controller) GetMonitorsBySlug(ctx *gin.Context) {
 			PublicMonitorDTO: publicMonitor,
 			Heartbeats:       publicHeartbeats,
 			Uptime24h:        uptime24h,
+			Incidents:        incidents[msp.MonitorID],
+		}
+
+		if monitorWithData.Incidents == nil {
+			monitorWithData.Incidents = []*PublicIncidentDTO{}
 		}
 
 		monitorModels = append(monitorModels, monitorWithData)
@@ -404,6 +436,9 @@ func (c *Controller) GetMonitorsBySlugForHomepage(ctx *gin.Context) {
 		return
 	}
 
+	monitors = c.withGroupMonitors(ctx, page, monitors)
+	incidents := c.incidentsByMonitor(ctx, page, monitors)
+
 	// Convert monitor_status_page models to monitor models with heartbeats and uptime
 	monitorModels := make([]*MonitorWithHeartbeatsAndUptimeDTO, 0, len(monitors))
 	for _, msp := range monitors {
@@ -467,6 +502,11 @@ func (c *Controller) GetMonitorsBySlugForHomepage(ctx *gin.Context) {
 			PublicMonitorDTO: publicMonitor,
 			Heartbeats:       publicHeartbeats,
 			Uptime24h:        uptime24h,
+			Incidents:        incidents[msp.MonitorID],
+		}
+
+		if monitorWithData.Incidents == nil {
+			monitorWithData.Incidents = []*PublicIncidentDTO{}
 		}
 
 		monitorModels = append(monitorModels, monitorWithData)
@@ -474,3 +514,114 @@ func (c *Controller) GetMonitorsBySlugForHomepage(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, utils.NewSuccessResponse("success", monitorModels))
 }
+
+// @Router    /status-pages/slug/{slug}/groups [get]
+// @Summary   Get the monitor group sections of a status page by slug
+// @Tags      Status Pages
+// @Produce   json
+// @Param     slug path      string  true  "Status Page Slug"
+// @Success   200  {object}  utils.ApiResponse[[]PublicGroupDTO]
+// @Failure   404  {object}  utils.APIError[any]
+// @Failure   500  {object}  utils.APIError[any]
+func (c *Controller) GetGroupsBySlug(ctx *gin.Context) {
+	slug := ctx.Param("slug")
+
+	page, err := c.service.FindBySlug(ctx, slug)
+	if err != nil {
+		c.logger.Errorw("Failed to get status page by slug", "error", err, "slug", slug)
+		ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse("Internal server error"))
+		return
+	}
+	if page == nil {
+		ctx.JSON(http.StatusNotFound, utils.NewFailResponse("Status page not found"))
+		return
+	}
+
+	groups, err := c.service.GetGroupsForStatusPage(ctx, page)
+	if err != nil {
+		c.logger.Errorw("Failed to get groups for status page", "error", err, "statusPageID", page.ID)
+		ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse("Internal server error"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.NewSuccessResponse("success", groups))
+}
+
+// withGroupMonitors appends the members of the page's monitor groups that are
+// not already shown on the page on their own
+func (c *Controller) withGroupMonitors(ctx *gin.Context, page *Model, monitors []*monitor_status_page.Model) []*monitor_status_page.Model {
+	if len(page.GroupIDs) == 0 {
+		return monitors
+	}
+
+	groups, err := c.service.GetGroupsForStatusPage(ctx, page)
+	if err != nil {
+		c.logger.Errorw("Failed to get groups for status page", "error", err, "statusPageID", page.ID)
+		return monitors
+	}
+
+	seen := make(map[string]bool, len(monitors))
+	for _, msp := range monitors {
+		seen[msp.MonitorID] = true
+	}
+	for _, group := range groups {
+		for _, monitorID := range group.MonitorIDs {
+			if seen[monitorID] {
+				continue
+			}
+			seen[monitorID] = true
+			monitors = append(monitors, &monitor_status_page.Model{
+				StatusPageID: page.ID,
+				MonitorID:    monitorID,
+				Order:        len(monitors),
+				Active:       true,
+			})
+		}
+	}
+	return monitors
+}
+
+// incidentsByMonitor loads the open and recently resolved incidents of the
+// status page monitors, keyed by monitor ID
+func (c *Controller) incidentsByMonitor(ctx *gin.Context, page *Model, monitors []*monitor_status_page.Model) map[string][]*PublicIncidentDTO {
+	result := make(map[string][]*PublicIncidentDTO)
+	if len(monitors) == 0 {
+		return result
+	}
+
+	monitorIDs := make([]string, 0, len(monitors))
+	for _, msp := range monitors {
+		monitorIDs = append(monitorIDs, msp.MonitorID)
+	}
+
+	incidents, err := c.incidentService.FindForMonitors(ctx, page.OrgID, monitorIDs)
+	if err != nil {
+		c.logger.Errorw("Failed to get incidents for status page monitors", "error", err)
+		return result
+	}
+
+	for _, inc := range incidents {
+		updates := make([]*PublicIncidentUpdateDTO, 0, len(inc.Updates))
+		for _, update := range inc.Updates {
+			updates = append(updates, &PublicIncidentUpdateDTO{
+				State:     update.State,
+				Message:   update.Message,
+				CreatedAt: update.CreatedAt,
+			})
+		}
+
+		publicIncident := &PublicIncidentDTO{
+			ID:         inc.ID,
+			Title:      inc.Title,
+			Severity:   inc.Severity,
+			State:      inc.State,
+			StartedAt:  inc.StartedAt,
+			ResolvedAt: inc.ResolvedAt,
+			Updates:    updates,
+		}
+		for _, monitorID := range inc.MonitorIDs {
+			result[monitorID] = append(result[monitorID], publicIncident)
+		}
+	}
+	return result
+}
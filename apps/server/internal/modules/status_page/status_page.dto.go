@@ -21,7 +21,9 @@ type CreateStatusPageDTO struct {
 	GoogleAnalyticsTagID  string   `json:"google_analytics_tag_id"`
 	ShowCertificateExpiry bool     `json:"show_certificate_expiry"`
 	AutoRefreshInterval   int      `json:"auto_refresh_interval"`
+	AutoIncidents         bool     `json:"auto_incidents"`
 	MonitorIDs            []string `json:"monitor_ids,omitempty"`
+	GroupIDs              []string `json:"group_ids,omitempty"`
 	Domains               []string `json:"domains,omitempty"`
 }
 
@@ -41,7 +43,9 @@ type UpdateStatusPageDTO struct {
 	GoogleAnalyticsTagID  *string   `json:"google_analytics_tag_id,omitempty"`
 	ShowCertificateExpiry *bool     `json:"show_certificate_expiry,omitempty"`
 	AutoRefreshInterval   *int      `json:"auto_refresh_interval,omitempty"`
+	AutoIncidents         *bool     `json:"auto_incidents,omitempty"`
 	MonitorIDs            *[]string `json:"monitor_ids,omitempty"`
+	GroupIDs              *[]string `json:"group_ids,omitempty"`
 	Domains               *[]string `json:"domains,omitempty"`
 }
 
@@ -64,7 +68,9 @@ type StatusPageWithMonitorsResponseDTO struct {
 	GoogleAnalyticsTagID  string    `json:"google_analytics_tag_id"`
 	ShowCertificateExpiry bool      `json:"show_certificate_expiry"`
 	AutoRefreshInterval   int       `json:"auto_refresh_interval"`
+	AutoIncidents         bool      `json:"auto_incidents"`
 	MonitorIDs            []string  `json:"monitor_ids"`
+	GroupIDs              []string  `json:"group_ids"`
 	Domains               []string  `json:"domains"`
 }
 
@@ -75,6 +81,15 @@ type PublicMonitorDTO struct {
 	Active bool   `json:"active"`
 }
 
+// PublicGroupDTO is a monitor group section of a status page
+type PublicGroupDTO struct {
+	ID          string               `json:"id"`
+	Name        string               `json:"name"`
+	Description string               `json:"description"`
+	Status      shared.MonitorStatus `json:"status"`
+	MonitorIDs  []string             `json:"monitor_ids"`
+}
+
 type PublicHeartbeatDTO struct {
 	ID      string               `json:"id"`
 	Status  shared.MonitorStatus `json:"status"`
@@ -83,8 +98,25 @@ type PublicHeartbeatDTO struct {
 	Ping    int                  `json:"ping"`
 }
 
+type PublicIncidentUpdateDTO struct {
+	State     string    `json:"state"`
+	Message   string    `json:"message"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type PublicIncidentDTO struct {
+	ID         string                     `json:"id"`
+	Title      string                     `json:"title"`
+	Severity   string                     `json:"severity"`
+	State      string                     `json:"state"`
+	StartedAt  time.Time                  `json:"started_at"`
+	ResolvedAt *time.Time                 `json:"resolved_at,omitempty"`
+	Updates    []*PublicIncidentUpdateDTO `json:"updates"`
+}
+
 type MonitorWithHeartbeatsAndUptimeDTO struct {
 	*PublicMonitorDTO
 	Heartbeats []*PublicHeartbeatDTO `json:"heartbeats"`
 	Uptime24h  float64               `json:"uptime_24h"`
+	Incidents  []*PublicIncidentDTO  `json:"incidents"`
 }
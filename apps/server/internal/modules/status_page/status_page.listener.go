@@ -0,0 +1,113 @@
+package status_page
+
+import (
+	"context"
+	"vigi/internal/infra"
+	"vigi/internal/modules/events"
+	"vigi/internal/modules/heartbeat"
+	"vigi/internal/modules/incident"
+	"vigi/internal/modules/monitor"
+	"vigi/internal/modules/monitor_status_page"
+	"vigi/internal/modules/shared"
+
+	"go.uber.org/dig"
+	"go.uber.org/zap"
+)
+
+// IncidentEventListener opens and resolves automatic incidents for monitors
+// shown on status pages that have auto incidents enabled
+type IncidentEventListener struct {
+	service                  Service
+	monitorService           monitor.Service
+	monitorStatusPageService monitor_status_page.Service
+	incidentService          incident.Service
+	logger                   *zap.SugaredLogger
+}
+
+type IncidentEventListenerParams struct {
+	dig.In
+	Service                  Service
+	MonitorService           monitor.Service
+	MonitorStatusPageService monitor_status_page.Service
+	IncidentService          incident.Service
+	Logger                   *zap.SugaredLogger
+}
+
+func NewIncidentEventListener(p IncidentEventListenerParams) *IncidentEventListener {
+	return &IncidentEventListener{
+		service:                  p.Service,
+		monitorService:           p.MonitorService,
+		monitorStatusPageService: p.MonitorStatusPageService,
+		incidentService:          p.IncidentService,
+		logger:                   p.Logger.Named("[status-page-incident-listener]"),
+	}
+}
+
+// Subscribe subscribes to MonitorStatusChanged events
+func (l *IncidentEventListener) Subscribe(eventBus events.EventBus) {
+	eventBus.Subscribe(events.MonitorStatusChanged, l.handleMonitorStatusChanged)
+}
+
+func (l *IncidentEventListener) handleMonitorStatusChanged(event events.Event) {
+	ctx := context.Background()
+
+	hb, ok := infra.UnmarshalEventPayload[heartbeat.Model](event)
+	if !ok {
+		l.logger.Errorf("Failed to unmarshal heartbeat event payload")
+		return
+	}
+
+	if hb.Status != shared.MonitorStatusDown && !hb.Status.IsAvailable() {
+		return
+	}
+
+	// Passing empty string for orgID as this is an internal listener
+	monitorModel, err := l.monitorService.FindByID(ctx, hb.MonitorID, "")
+	if err != nil {
+		l.logger.Errorf("Failed to get monitor %s: %v", hb.MonitorID, err)
+		return
+	}
+	if monitorModel == nil {
+		return
+	}
+
+	// A degraded monitor answers again, so it resolves the outage too
+	if hb.Status.IsAvailable() {
+		if err := l.incidentService.ResolveForMonitor(ctx, monitorModel.ID, monitorModel.Name); err != nil {
+			l.logger.Errorf("Failed to resolve incidents for monitor %s: %v", monitorModel.ID, err)
+		}
+		return
+	}
+
+	enabled, err := l.autoIncidentsEnabled(ctx, monitorModel)
+	if err != nil {
+		l.logger.Errorf("Failed to check status pages of monitor %s: %v", monitorModel.ID, err)
+		return
+	}
+	if !enabled {
+		return
+	}
+
+	if _, err := l.incidentService.OpenForMonitor(ctx, monitorModel.OrgID, monitorModel.ID, monitorModel.Name); err != nil {
+		l.logger.Errorf("Failed to open incident for monitor %s: %v", monitorModel.ID, err)
+	}
+}
+
+// autoIncidentsEnabled reports whether any status page showing the monitor has auto incidents on
+func (l *IncidentEventListener) autoIncidentsEnabled(ctx context.Context, monitorModel *monitor.Model) (bool, error) {
+	links, err := l.monitorStatusPageService.GetStatusPagesForMonitor(ctx, monitorModel.ID)
+	if err != nil {
+		return false, err
+	}
+
+	for _, link := range links {
+		page, err := l.service.FindByID(ctx, link.StatusPageID, monitorModel.OrgID)
+		if err != nil {
+			return false, err
+		}
+		if page != nil && page.AutoIncidents {
+			return true, nil
+		}
+	}
+	return false, nil
+}
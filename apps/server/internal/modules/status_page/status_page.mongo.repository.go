@@ -26,12 +26,19 @@ type mongoModel struct {
 	FooterText           string             `bson:"footer_text"`
 	GoogleAnalyticsTagID string             `bson:"google_analytics_tag_id"`
 	AutoRefreshInterval  int                `bson:"auto_refresh_interval"`
+	AutoIncidents        bool               `bson:"auto_incidents"`
+	GroupIDs             []string           `bson:"group_ids"`
 
 	CreatedAt time.Time `bson:"created_at"`
 	UpdatedAt time.Time `bson:"updated_at"`
 }
 
 func toDomainModel(m *mongoModel) *Model {
+	groupIDs := m.GroupIDs
+	if groupIDs == nil {
+		groupIDs = []string{}
+	}
+
 	return &Model{
 		ID:                  m.ID.Hex(),
 		OrgID:               m.OrgID,
@@ -43,6 +50,8 @@ func toDomainModel(m *mongoModel) *Model {
 		Published:           m.Published,
 		FooterText:          m.FooterText,
 		AutoRefreshInterval: m.AutoRefreshInterval,
+		AutoIncidents:       m.AutoIncidents,
+		GroupIDs:            groupIDs,
 
 		CreatedAt: m.CreatedAt,
 		UpdatedAt: m.UpdatedAt,
@@ -91,6 +100,11 @@ func (r *MongoRepository) Create(ctx context.Context, statusPage *Model) (*Model
 		UpdatedAt:           time.Now().UTC(),
 		FooterText:          statusPage.FooterText,
 		AutoRefreshInterval: statusPage.AutoRefreshInterval,
+		AutoIncidents:       statusPage.AutoIncidents,
+		GroupIDs:            statusPage.GroupIDs,
+	}
+	if mm.GroupIDs == nil {
+		mm.GroupIDs = []string{}
 	}
 
 	_, err := r.collection.InsertOne(ctx, mm)
@@ -204,6 +218,12 @@ func (r *MongoRepository) Update(ctx context.Context, id string, statusPage *Upd
 	if statusPage.AutoRefreshInterval != nil {
 		updatePayload["auto_refresh_interval"] = *statusPage.AutoRefreshInterval
 	}
+	if statusPage.AutoIncidents != nil {
+		updatePayload["auto_incidents"] = *statusPage.AutoIncidents
+	}
+	if statusPage.GroupIDs != nil {
+		updatePayload["group_ids"] = *statusPage.GroupIDs
+	}
 
 	if len(updatePayload) == 0 {
 		return nil // nothing to update
@@ -239,6 +259,25 @@ func (r *MongoRepository) Delete(ctx context.Context, id string, orgID string) e
 	return err
 }
 
+func (r *MongoRepository) FindByGroupID(ctx context.Context, groupID string) ([]*Model, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{"group_ids": groupID})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var mongoStatusPages []*mongoModel
+	if err = cursor.All(ctx, &mongoStatusPages); err != nil {
+		return nil, err
+	}
+
+	statusPages := make([]*Model, 0, len(mongoStatusPages))
+	for _, msp := range mongoStatusPages {
+		statusPages = append(statusPages, toDomainModel(msp))
+	}
+	return statusPages, nil
+}
+
 func (r *MongoRepository) Count(ctx context.Context, orgID string) (int64, error) {
 	filter := bson.M{"org_id": orgID}
 	count, err := r.collection.CountDocuments(ctx, filter)
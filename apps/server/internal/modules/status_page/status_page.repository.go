@@ -16,4 +16,6 @@ type Repository interface {
 	Update(ctx context.Context, id string, statusPage *UpdateModel, orgID string) error
 	Delete(ctx context.Context, id string, orgID string) error
 	Count(ctx context.Context, orgID string) (int64, error)
+	// FindByGroupID returns the status pages showing the monitor group as a section
+	FindByGroupID(ctx context.Context, groupID string) ([]*Model, error)
 }
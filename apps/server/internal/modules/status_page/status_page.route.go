@@ -1,6 +1,7 @@
 package status_page
 
 import (
+	"vigi/internal/modules/api_key"
 	"vigi/internal/modules/middleware"
 	"vigi/internal/modules/organization"
 
@@ -28,8 +29,9 @@ func (r *Route) ConnectRoute(rg *gin.RouterGroup, controller *Controller) {
 	sp.GET("/domain/:domain", r.controller.FindByDomain)
 	sp.GET("/slug/:slug/monitors", r.controller.GetMonitorsBySlug)
 	sp.GET("/slug/:slug/monitors/homepage", r.controller.GetMonitorsBySlugForHomepage)
+	sp.GET("/slug/:slug/groups", r.controller.GetGroupsBySlug)
 
-	sp.Use(r.middleware.AllAuth())
+	sp.Use(r.middleware.AllAuth(api_key.ScopeStatusPagesRead))
 	sp.Use(r.orgMiddleware.RequireOrganization())
 	{
 		sp.POST("", r.controller.Create)
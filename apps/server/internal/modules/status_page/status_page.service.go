@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"vigi/internal/modules/domain_status_page"
 	"vigi/internal/modules/events"
+	"vigi/internal/modules/monitor_group"
 	"vigi/internal/modules/monitor_status_page"
 
 	"go.uber.org/zap"
@@ -21,6 +22,10 @@ type Service interface {
 	Delete(ctx context.Context, id string, orgID string) error
 
 	GetMonitorsForStatusPage(ctx context.Context, statusPageID string) ([]*monitor_status_page.Model, error)
+	// GetGroupsForStatusPage returns the monitor group sections of the page with their rolled-up status
+	GetGroupsForStatusPage(ctx context.Context, statusPage *Model) ([]*PublicGroupDTO, error)
+	// FindByGroupID returns the status pages showing the monitor group
+	FindByGroupID(ctx context.Context, groupID string) ([]*Model, error)
 }
 
 type ServiceImpl struct {
@@ -28,6 +33,7 @@ type ServiceImpl struct {
 	eventBus                 events.EventBus
 	monitorStatusPageService monitor_status_page.Service
 	domainStatusPageService  domain_status_page.Service
+	monitorGroupService      monitor_group.Service
 	logger                   *zap.SugaredLogger
 }
 
@@ -36,6 +42,7 @@ func NewService(
 	eventBus events.EventBus,
 	monitorStatusPageService monitor_status_page.Service,
 	domainStatusPageService domain_status_page.Service,
+	monitorGroupService monitor_group.Service,
 	logger *zap.SugaredLogger,
 ) Service {
 	return &ServiceImpl{
@@ -43,6 +50,7 @@ func NewService(
 		eventBus:                 eventBus,
 		monitorStatusPageService: monitorStatusPageService,
 		domainStatusPageService:  domainStatusPageService,
+		monitorGroupService:      monitorGroupService,
 		logger:                   logger.Named("[status-page-service]"),
 	}
 }
@@ -82,6 +90,35 @@ func slugAlreadyUsedError(slug string) *SlugAlreadyUsedError {
 	}
 }
 
+// GroupNotFoundError represents a validation error when a monitor group does
+// not exist in the organization of the status page.
+type GroupNotFoundError struct {
+	Code    string `json:"code"`
+	GroupID string `json:"group_id"`
+}
+
+func (e *GroupNotFoundError) Error() string {
+	return fmt.Sprintf(`{"code":"%s", "group_id":"%s"}`, e.Code, e.GroupID)
+}
+
+func groupNotFoundError(groupID string) *GroupNotFoundError {
+	return &GroupNotFoundError{
+		Code:    "GROUP_NOT_FOUND",
+		GroupID: groupID,
+	}
+}
+
+// validateGroups ensures that provided monitor groups belong to the organization
+func (s *ServiceImpl) validateGroups(ctx context.Context, orgID string, groupIDs []string) error {
+	for _, groupID := range groupIDs {
+		group, err := s.monitorGroupService.FindByID(ctx, groupID, orgID)
+		if err != nil || group == nil {
+			return groupNotFoundError(groupID)
+		}
+	}
+	return nil
+}
+
 // validateDomains ensures that provided domains are not already used
 // by any existing status page.
 func (s *ServiceImpl) validateDomains(ctx context.Context, statusPageID string, domains []string) error {
@@ -122,6 +159,10 @@ func (s *ServiceImpl) Create(ctx context.Context, dto *CreateStatusPageDTO, orgI
 		return nil, err
 	}
 
+	if err := s.validateGroups(ctx, orgID, dto.GroupIDs); err != nil {
+		return nil, err
+	}
+
 	model := &Model{
 		Slug:                dto.Slug,
 		Title:               dto.Title,
@@ -131,6 +172,8 @@ func (s *ServiceImpl) Create(ctx context.Context, dto *CreateStatusPageDTO, orgI
 		Published:           dto.Published,
 		FooterText:          dto.FooterText,
 		AutoRefreshInterval: dto.AutoRefreshInterval,
+		AutoIncidents:       dto.AutoIncidents,
+		GroupIDs:            dto.GroupIDs,
 		OrgID:               orgID,
 	}
 
@@ -256,6 +299,8 @@ func (s *ServiceImpl) Update(ctx context.Context, id string, dto *UpdateStatusPa
 		Published:           dto.Published,
 		FooterText:          dto.FooterText,
 		AutoRefreshInterval: dto.AutoRefreshInterval,
+		AutoIncidents:       dto.AutoIncidents,
+		GroupIDs:            dto.GroupIDs,
 	}
 
 	if dto.Slug != nil {
@@ -264,6 +309,12 @@ func (s *ServiceImpl) Update(ctx context.Context, id string, dto *UpdateStatusPa
 		}
 	}
 
+	if dto.GroupIDs != nil {
+		if err := s.validateGroups(ctx, orgID, *dto.GroupIDs); err != nil {
+			return nil, err
+		}
+	}
+
 	err := s.repository.Update(ctx, id, updateModel, orgID)
 	if err != nil {
 		return nil, err
@@ -377,6 +428,49 @@ func (s *ServiceImpl) GetMonitorsForStatusPage(ctx context.Context, statusPageID
 	return s.monitorStatusPageService.GetMonitorsForStatusPage(ctx, statusPageID)
 }
 
+func (s *ServiceImpl) GetGroupsForStatusPage(ctx context.Context, statusPage *Model) ([]*PublicGroupDTO, error) {
+	groups := make([]*PublicGroupDTO, 0, len(statusPage.GroupIDs))
+	for _, groupID := range statusPage.GroupIDs {
+		group, err := s.monitorGroupService.FindByID(ctx, groupID, statusPage.OrgID)
+		if err != nil {
+			return nil, err
+		}
+		if group == nil {
+			// The group was deleted after it was added to the page
+			continue
+		}
+
+		status, err := s.monitorGroupService.GetStatus(ctx, groupID, statusPage.OrgID)
+		if err != nil {
+			return nil, err
+		}
+		if status == nil {
+			continue
+		}
+
+		monitorIDs, err := s.monitorGroupService.FindMonitorIDs(ctx, groupID, statusPage.OrgID)
+		if err != nil {
+			return nil, err
+		}
+		if monitorIDs == nil {
+			monitorIDs = []string{}
+		}
+
+		groups = append(groups, &PublicGroupDTO{
+			ID:          group.ID,
+			Name:        group.Name,
+			Description: group.Description,
+			Status:      status.Status,
+			MonitorIDs:  monitorIDs,
+		})
+	}
+	return groups, nil
+}
+
+func (s *ServiceImpl) FindByGroupID(ctx context.Context, groupID string) ([]*Model, error) {
+	return s.repository.FindByGroupID(ctx, groupID)
+}
+
 // mapModelToStatusPageWithMonitorsDTO converts a Model to StatusPageWithMonitorsDTO
 func (s *ServiceImpl) mapModelToStatusPageWithMonitorsDTO(model *Model, monitorIDs []string, domains []string) *StatusPageWithMonitorsResponseDTO {
 	return &StatusPageWithMonitorsResponseDTO{
@@ -391,7 +485,9 @@ func (s *ServiceImpl) mapModelToStatusPageWithMonitorsDTO(model *Model, monitorI
 		UpdatedAt:           model.UpdatedAt,
 		FooterText:          model.FooterText,
 		AutoRefreshInterval: model.AutoRefreshInterval,
+		AutoIncidents:       model.AutoIncidents,
 		MonitorIDs:          monitorIDs,
+		GroupIDs:            model.GroupIDs,
 		Domains:             domains,
 	}
 }
@@ -7,7 +7,9 @@ import (
 
 	"vigi/internal/modules/domain_status_page"
 	"vigi/internal/modules/events"
+	"vigi/internal/modules/monitor_group"
 	"vigi/internal/modules/monitor_status_page"
+	"vigi/internal/modules/shared"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -66,6 +68,14 @@ func (m *MockRepository) Count(ctx context.Context, orgID string) (int64, error)
 	return args.Get(0).(int64), args.Error(1)
 }
 
+func (m *MockRepository) FindByGroupID(ctx context.Context, groupID string) ([]*Model, error) {
+	args := m.Called(ctx, groupID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*Model), args.Error(1)
+}
+
 // MockEventBus
 type MockEventBus struct {
 	mock.Mock
@@ -199,6 +209,51 @@ func (m *MockDomainStatusPageService) FindByDomain(ctx context.Context, domain s
 	return args.Get(0).(*domain_status_page.Model), args.Error(1)
 }
 
+// MockMonitorGroupService
+type MockMonitorGroupService struct {
+	mock.Mock
+}
+
+func (m *MockMonitorGroupService) Create(ctx context.Context, dto *monitor_group.CreateUpdateDto, orgID string) (*monitor_group.Model, error) {
+	return nil, nil
+}
+func (m *MockMonitorGroupService) FindByID(ctx context.Context, id string, orgID string) (*monitor_group.Model, error) {
+	args := m.Called(ctx, id, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*monitor_group.Model), args.Error(1)
+}
+func (m *MockMonitorGroupService) FindAll(ctx context.Context, page int, limit int, q string, orgID string) ([]*monitor_group.Model, error) {
+	return nil, nil
+}
+func (m *MockMonitorGroupService) Update(ctx context.Context, id string, dto *monitor_group.CreateUpdateDto, orgID string) (*monitor_group.Model, error) {
+	return nil, nil
+}
+func (m *MockMonitorGroupService) Delete(ctx context.Context, id string, orgID string) error {
+	return nil
+}
+func (m *MockMonitorGroupService) GetStatus(ctx context.Context, id string, orgID string) (*monitor_group.StatusDto, error) {
+	args := m.Called(ctx, id, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*monitor_group.StatusDto), args.Error(1)
+}
+func (m *MockMonitorGroupService) FindMonitorIDs(ctx context.Context, id string, orgID string) ([]string, error) {
+	args := m.Called(ctx, id, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+func (m *MockMonitorGroupService) FindGroupIDsByMonitorID(ctx context.Context, monitorID string) ([]string, error) {
+	return nil, nil
+}
+func (m *MockMonitorGroupService) RefreshStatuses(ctx context.Context, monitorID string, status shared.MonitorStatus) ([]*monitor_group.StatusChangedEvent, error) {
+	return nil, nil
+}
+
 func createTestService(
 	mockRepo *MockRepository,
 	mockEventBus *MockEventBus,
@@ -206,7 +261,7 @@ func createTestService(
 	mockDomainStatusPageService *MockDomainStatusPageService,
 ) Service {
 	logger, _ := zap.NewDevelopment()
-	return NewService(mockRepo, mockEventBus, mockMonitorStatusPageService, mockDomainStatusPageService, logger.Sugar())
+	return NewService(mockRepo, mockEventBus, mockMonitorStatusPageService, mockDomainStatusPageService, &MockMonitorGroupService{}, logger.Sugar())
 }
 
 func TestServiceImpl_Create(t *testing.T) {
@@ -429,6 +484,51 @@ func TestServiceImpl_Delete(t *testing.T) {
 	}
 }
 
+func TestServiceImpl_Create_RejectsGroupOfAnotherOrganization(t *testing.T) {
+	mockRepo := &MockRepository{}
+	mockGroupService := &MockMonitorGroupService{}
+	logger, _ := zap.NewDevelopment()
+	service := NewService(mockRepo, &MockEventBus{}, &MockMonitorStatusPageService{}, &MockDomainStatusPageService{}, mockGroupService, logger.Sugar())
+
+	mockRepo.On("FindBySlug", mock.Anything, "test-slug").Return((*Model)(nil), nil)
+	mockGroupService.On("FindByID", mock.Anything, "group-1", "org-1").Return(nil, nil)
+
+	_, err := service.Create(context.Background(), &CreateStatusPageDTO{
+		Slug:     "test-slug",
+		Title:    "Test Page",
+		GroupIDs: []string{"group-1"},
+	}, "org-1")
+
+	var groupErr *GroupNotFoundError
+	assert.ErrorAs(t, err, &groupErr)
+	assert.Equal(t, "group-1", groupErr.GroupID)
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestServiceImpl_GetGroupsForStatusPage(t *testing.T) {
+	mockGroupService := &MockMonitorGroupService{}
+	logger, _ := zap.NewDevelopment()
+	service := NewService(&MockRepository{}, &MockEventBus{}, &MockMonitorStatusPageService{}, &MockDomainStatusPageService{}, mockGroupService, logger.Sugar())
+
+	page := &Model{ID: "page-1", OrgID: "org-1", GroupIDs: []string{"group-1", "deleted-group"}}
+
+	mockGroupService.On("FindByID", mock.Anything, "group-1", "org-1").Return(&monitor_group.Model{ID: "group-1", Name: "API"}, nil)
+	mockGroupService.On("GetStatus", mock.Anything, "group-1", "org-1").Return(&monitor_group.StatusDto{GroupID: "group-1", Status: shared.MonitorStatusDegraded}, nil)
+	mockGroupService.On("FindMonitorIDs", mock.Anything, "group-1", "org-1").Return([]string{"m1", "m2"}, nil)
+	mockGroupService.On("FindByID", mock.Anything, "deleted-group", "org-1").Return(nil, nil)
+
+	groups, err := service.GetGroupsForStatusPage(context.Background(), page)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*PublicGroupDTO{{
+		ID:         "group-1",
+		Name:       "API",
+		Status:     shared.MonitorStatusDegraded,
+		MonitorIDs: []string{"m1", "m2"},
+	}}, groups)
+	mockGroupService.AssertExpectations(t)
+}
+
 func stringPtr(s string) *string {
 	return &s
 }
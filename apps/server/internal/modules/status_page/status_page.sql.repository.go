@@ -2,6 +2,7 @@ package status_page
 
 import (
 	"context"
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
@@ -23,9 +24,16 @@ type sqlModel struct {
 	UpdatedAt           time.Time `bun:"updated_at,nullzero,notnull,default:current_timestamp"`
 	FooterText          string    `bun:"footer_text"`
 	AutoRefreshInterval int       `bun:"auto_refresh_interval,notnull,default:30"`
+	AutoIncidents       bool      `bun:"auto_incidents,notnull,default:false"`
+	GroupIDs            string    `bun:"group_ids,notnull,default:'[]'"` // Store as JSON string for compatibility
 }
 
 func toDomainModelFromSQL(sm *sqlModel) *Model {
+	groupIDs := []string{}
+	if sm.GroupIDs != "" {
+		json.Unmarshal([]byte(sm.GroupIDs), &groupIDs)
+	}
+
 	return &Model{
 		ID:                  sm.ID,
 		OrgID:               sm.OrgID,
@@ -39,6 +47,8 @@ func toDomainModelFromSQL(sm *sqlModel) *Model {
 		UpdatedAt:           sm.UpdatedAt,
 		FooterText:          sm.FooterText,
 		AutoRefreshInterval: sm.AutoRefreshInterval,
+		AutoIncidents:       sm.AutoIncidents,
+		GroupIDs:            groupIDs,
 	}
 }
 
@@ -56,7 +66,18 @@ func toSQLModel(m *Model) *sqlModel {
 		UpdatedAt:           m.UpdatedAt,
 		FooterText:          m.FooterText,
 		AutoRefreshInterval: m.AutoRefreshInterval,
+		AutoIncidents:       m.AutoIncidents,
+		GroupIDs:            marshalGroupIDs(m.GroupIDs),
+	}
+}
+
+// marshalGroupIDs stores the group IDs as a JSON array, never as null
+func marshalGroupIDs(groupIDs []string) string {
+	if groupIDs == nil {
+		groupIDs = []string{}
 	}
+	data, _ := json.Marshal(groupIDs)
+	return string(data)
 }
 
 type SQLRepositoryImpl struct {
@@ -174,6 +195,14 @@ func (r *SQLRepositoryImpl) Update(ctx context.Context, id string, statusPage *U
 		query = query.Set("auto_refresh_interval = ?", *statusPage.AutoRefreshInterval)
 		hasUpdates = true
 	}
+	if statusPage.AutoIncidents != nil {
+		query = query.Set("auto_incidents = ?", *statusPage.AutoIncidents)
+		hasUpdates = true
+	}
+	if statusPage.GroupIDs != nil {
+		query = query.Set("group_ids = ?", marshalGroupIDs(*statusPage.GroupIDs))
+		hasUpdates = true
+	}
 
 	if !hasUpdates {
 		return nil
@@ -191,6 +220,24 @@ func (r *SQLRepositoryImpl) Delete(ctx context.Context, id string, orgID string)
 	return err
 }
 
+func (r *SQLRepositoryImpl) FindByGroupID(ctx context.Context, groupID string) ([]*Model, error) {
+	var sms []*sqlModel
+	// group_ids is a JSON array, match the quoted ID inside it
+	err := r.db.NewSelect().
+		Model(&sms).
+		Where("group_ids LIKE ?", "%\""+groupID+"\"%").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	models := make([]*Model, 0, len(sms))
+	for _, sm := range sms {
+		models = append(models, toDomainModelFromSQL(sm))
+	}
+	return models, nil
+}
+
 func (r *SQLRepositoryImpl) Count(ctx context.Context, orgID string) (int64, error) {
 	count, err := r.db.NewSelect().Model((*sqlModel)(nil)).Where("org_id = ?", orgID).Count(ctx)
 	if err != nil {
@@ -0,0 +1,180 @@
+package status_page_subscriber
+
+import (
+	"errors"
+	"net/http"
+	"vigi/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type Controller struct {
+	service Service
+	logger  *zap.SugaredLogger
+}
+
+func NewController(
+	service Service,
+	logger *zap.SugaredLogger,
+) *Controller {
+	return &Controller{
+		service: service,
+		logger:  logger.Named("[status-page-subscriber-controller]"),
+	}
+}
+
+// @Router    /status-page-subscribers/slug/{slug} [post]
+// @Summary   Subscribe to a status page
+// @Description Email subscribers receive a confirmation email and webhook subscribers a confirmation request before any notification
+// @Tags      Status Page Subscribers
+// @Accept    json
+// @Produce   json
+// @Param     slug path      string        true  "Status Page Slug"
+// @Param     body body      SubscribeDTO  true  "Subscription"
+// @Success   201  {object}  utils.ApiResponse[any]
+// @Failure   400  {object}  utils.APIError[any]
+// @Failure   404  {object}  utils.APIError[any]
+// @Failure   500  {object}  utils.APIError[any]
+func (c *Controller) Subscribe(ctx *gin.Context) {
+	slug := ctx.Param("slug")
+
+	var dto SubscribeDTO
+	if err := ctx.ShouldBindJSON(&dto); err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.NewFailResponse(err.Error()))
+		return
+	}
+
+	if err := utils.Validate.Struct(&dto); err != nil {
+		ctx.JSON(http.StatusBadRequest, utils.NewFailResponse(err.Error()))
+		return
+	}
+
+	_, err := c.service.Subscribe(ctx, slug, &dto)
+	if err != nil {
+		if errors.Is(err, ErrStatusPageNotFound) {
+			ctx.JSON(http.StatusNotFound, utils.NewFailResponse("Status page not found"))
+			return
+		}
+		if errors.Is(err, ErrWebhookFailed) {
+			ctx.JSON(http.StatusBadRequest, utils.NewFailResponse(err.Error()))
+			return
+		}
+		c.logger.Errorw("Failed to subscribe to status page", "error", err, "slug", slug)
+		ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse("Internal server error"))
+		return
+	}
+
+	// The subscriber itself is not returned, it carries no information for the public
+	ctx.JSON(http.StatusCreated, utils.NewSuccessResponse[any]("Subscribed successfully", nil))
+}
+
+// @Router    /status-page-subscribers/confirm/{token} [post]
+// @Summary   Confirm a subscription
+// @Tags      Status Page Subscribers
+// @Produce   json
+// @Param     token path     string  true  "Confirmation token"
+// @Success   200  {object}  utils.ApiResponse[any]
+// @Failure   404  {object}  utils.APIError[any]
+// @Failure   500  {object}  utils.APIError[any]
+func (c *Controller) Confirm(ctx *gin.Context) {
+	subscriber, err := c.service.Confirm(ctx, ctx.Param("token"))
+	if err != nil {
+		c.logger.Errorw("Failed to confirm subscription", "error", err)
+		ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse("Internal server error"))
+		return
+	}
+	if subscriber == nil {
+		ctx.JSON(http.StatusNotFound, utils.NewFailResponse("Subscription not found"))
+		return
+	}
+	ctx.JSON(http.StatusOK, utils.NewSuccessResponse[any]("Subscription confirmed", nil))
+}
+
+// @Router    /status-page-subscribers/unsubscribe/{token} [post]
+// @Summary   Unsubscribe from a status page
+// @Tags      Status Page Subscribers
+// @Produce   json
+// @Param     token path     string  true  "Unsubscribe token"
+// @Success   200  {object}  utils.ApiResponse[any]
+// @Failure   404  {object}  utils.APIError[any]
+// @Failure   500  {object}  utils.APIError[any]
+func (c *Controller) Unsubscribe(ctx *gin.Context) {
+	subscriber, err := c.service.Unsubscribe(ctx, ctx.Param("token"))
+	if err != nil {
+		c.logger.Errorw("Failed to unsubscribe", "error", err)
+		ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse("Internal server error"))
+		return
+	}
+	if subscriber == nil {
+		ctx.JSON(http.StatusNotFound, utils.NewFailResponse("Subscription not found"))
+		return
+	}
+	ctx.JSON(http.StatusOK, utils.NewSuccessResponse[any]("Unsubscribed successfully", nil))
+}
+
+// @Router    /status-page-subscribers [get]
+// @Summary   Get the subscribers of a status page
+// @Tags      Status Page Subscribers
+// @Produce   json
+// @Security  JwtAuth
+// @Security  ApiKeyAuth
+// @Param     status_page_id query string true  "Status Page ID"
+// @Param     page           query int    false "Page number" default(0)
+// @Param     limit          query int    false "Items per page" default(10)
+// @Success   200  {object}  utils.ApiResponse[[]Model]
+// @Failure   400  {object}  utils.APIError[any]
+// @Failure   404  {object}  utils.APIError[any]
+// @Failure   500  {object}  utils.APIError[any]
+func (c *Controller) FindAll(ctx *gin.Context) {
+	statusPageID := ctx.Query("status_page_id")
+	if statusPageID == "" {
+		ctx.JSON(http.StatusBadRequest, utils.NewFailResponse("status_page_id is required"))
+		return
+	}
+	page, err := utils.GetQueryInt(ctx, "page", 0)
+	if err != nil || page < 0 {
+		ctx.JSON(http.StatusBadRequest, utils.NewFailResponse("Invalid page parameter"))
+		return
+	}
+	limit, err := utils.GetQueryInt(ctx, "limit", 10)
+	if err != nil || limit < 1 {
+		ctx.JSON(http.StatusBadRequest, utils.NewFailResponse("Invalid limit parameter"))
+		return
+	}
+
+	orgID := ctx.GetString("orgId")
+
+	subscribers, err := c.service.FindByStatusPage(ctx, statusPageID, page, limit, orgID)
+	if err != nil {
+		if errors.Is(err, ErrStatusPageNotFound) {
+			ctx.JSON(http.StatusNotFound, utils.NewFailResponse("Status page not found"))
+			return
+		}
+		c.logger.Errorw("Failed to get status page subscribers", "error", err, "statusPageID", statusPageID)
+		ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse("Internal server error"))
+		return
+	}
+	ctx.JSON(http.StatusOK, utils.NewSuccessResponse("success", subscribers))
+}
+
+// @Router    /status-page-subscribers/{id} [delete]
+// @Summary   Remove a status page subscriber
+// @Tags      Status Page Subscribers
+// @Produce   json
+// @Security  JwtAuth
+// @Security  ApiKeyAuth
+// @Param     id   path      string  true  "Subscriber ID"
+// @Success   200  {object}  utils.ApiResponse[any]
+// @Failure   500  {object}  utils.APIError[any]
+func (c *Controller) Delete(ctx *gin.Context) {
+	id := ctx.Param("id")
+	orgID := ctx.GetString("orgId")
+
+	if err := c.service.Delete(ctx, id, orgID); err != nil {
+		c.logger.Errorw("Failed to delete status page subscriber", "error", err, "id", id)
+		ctx.JSON(http.StatusInternalServerError, utils.NewFailResponse("Internal server error"))
+		return
+	}
+	ctx.JSON(http.StatusOK, utils.NewSuccessResponse[any]("Subscriber deleted successfully", nil))
+}
@@ -0,0 +1,154 @@
+package status_page_subscriber
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"net/http"
+	"time"
+	"vigi/internal/modules/status_page"
+	"vigi/internal/pkg/usesend"
+)
+
+var emailTemplate = template.Must(template.New("email").Parse(`<!DOCTYPE html>
+<html>
+<body style="margin:0;padding:24px;background:#f4f4f5;font-family:Arial,Helvetica,sans-serif;color:#18181b;">
+  <div style="max-width:560px;margin:0 auto;background:#ffffff;border-radius:8px;padding:32px;">
+    <p style="margin:0 0 8px;font-size:13px;color:#71717a;">{{.PageTitle}}</p>
+    <h1 style="margin:0 0 16px;font-size:20px;">{{.Subject}}</h1>
+    <p style="margin:0 0 24px;font-size:15px;line-height:1.5;">{{.Message}}</p>
+    <a href="{{.ActionURL}}" style="display:inline-block;padding:10px 20px;background:#18181b;color:#ffffff;border-radius:6px;text-decoration:none;font-size:14px;">{{.ActionLabel}}</a>
+    {{if .UnsubscribeURL}}
+    <p style="margin:32px 0 0;font-size:12px;color:#71717a;">
+      You are receiving this email because you subscribed to updates from {{.PageTitle}}.
+      <a href="{{.UnsubscribeURL}}" style="color:#71717a;">Unsubscribe</a>
+    </p>
+    {{end}}
+  </div>
+</body>
+</html>`))
+
+type emailData struct {
+	PageTitle      string
+	Subject        string
+	Message        string
+	ActionURL      string
+	ActionLabel    string
+	UnsubscribeURL string
+}
+
+func renderEmail(data emailData) (string, error) {
+	var buf bytes.Buffer
+	if err := emailTemplate.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func (s *ServiceImpl) sendConfirmationEmail(ctx context.Context, page *status_page.Model, subscriber *Model) error {
+	subject := fmt.Sprintf("Confirm your subscription to %s", page.Title)
+	html, err := renderEmail(emailData{
+		PageTitle:   page.Title,
+		Subject:     subject,
+		Message:     "Please confirm that you want to receive status updates by email. If you did not request this, you can ignore this email.",
+		ActionURL:   s.confirmURL(subscriber),
+		ActionLabel: "Confirm subscription",
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.sendEmail(ctx, subscriber, subject, html, "confirmation")
+}
+
+func (s *ServiceImpl) sendNotificationEmail(ctx context.Context, page *status_page.Model, subscriber *Model, notification *Notification) error {
+	html, err := renderEmail(emailData{
+		PageTitle:      page.Title,
+		Subject:        notification.Subject,
+		Message:        notification.Message,
+		ActionURL:      s.statusPageURL(page),
+		ActionLabel:    "View status page",
+		UnsubscribeURL: s.unsubscribeURL(subscriber),
+	})
+	if err != nil {
+		return err
+	}
+
+	subject := fmt.Sprintf("[%s] %s", page.Title, notification.Subject)
+	return s.sendEmail(ctx, subscriber, subject, html, notification.Event)
+}
+
+func (s *ServiceImpl) sendEmail(ctx context.Context, subscriber *Model, subject string, html string, kind string) error {
+	_, err := s.emailClient.SendEmail(ctx, usesend.SendEmailRequest{
+		To:      subscriber.Email,
+		From:    s.cfg.StatusPageEmailFrom,
+		Subject: subject,
+		HTML:    html,
+		Tags: map[string]string{
+			"status_page_id": subscriber.StatusPageID,
+			"type":           kind,
+		},
+	})
+	return err
+}
+
+func (s *ServiceImpl) sendConfirmationWebhook(ctx context.Context, page *status_page.Model, subscriber *Model) error {
+	payload := s.webhookPayload(page, subscriber, &Notification{
+		Event:   "subscription.confirm",
+		Subject: fmt.Sprintf("Confirm your subscription to %s", page.Title),
+		Message: "Send a POST request to confirm_url to start receiving status updates. If you did not request this, you can ignore this request.",
+	})
+	payload.ConfirmURL = s.confirmAPIURL(subscriber)
+
+	return s.postWebhook(ctx, subscriber, payload)
+}
+
+func (s *ServiceImpl) sendWebhook(ctx context.Context, page *status_page.Model, subscriber *Model, notification *Notification) error {
+	return s.postWebhook(ctx, subscriber, s.webhookPayload(page, subscriber, notification))
+}
+
+func (s *ServiceImpl) webhookPayload(page *status_page.Model, subscriber *Model, notification *Notification) *WebhookPayloadDTO {
+	return &WebhookPayloadDTO{
+		Event:   notification.Event,
+		Subject: notification.Subject,
+		Message: notification.Message,
+		StatusPage: WebhookStatusPageDTO{
+			ID:    page.ID,
+			Title: page.Title,
+			Slug:  page.Slug,
+			URL:   s.statusPageURL(page),
+		},
+		UnsubscribeURL: s.unsubscribeURL(subscriber),
+		SentAt:         time.Now().UTC().Format(time.RFC3339),
+	}
+}
+
+// postWebhook posts the payload to the subscriber URL. The client refuses
+// private and loopback addresses, the URL comes from an anonymous visitor.
+func (s *ServiceImpl) postWebhook(ctx context.Context, subscriber *Model, payload *WebhookPayloadDTO) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscriber.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package status_page_subscriber
+
+type SubscribeDTO struct {
+	Type       string `json:"type" validate:"required,oneof=email webhook" example:"email"`
+	Email      string `json:"email" validate:"required_if=Type email,omitempty,email" example:"user@example.com"`
+	WebhookURL string `json:"webhook_url" validate:"required_if=Type webhook,omitempty,http_url" example:"https://example.com/hooks/status"`
+}
+
+type WebhookStatusPageDTO struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	Slug  string `json:"slug"`
+	URL   string `json:"url"`
+}
+
+// WebhookPayloadDTO is the body posted to webhook subscribers
+type WebhookPayloadDTO struct {
+	Event      string               `json:"event"`
+	Subject    string               `json:"subject"`
+	Message    string               `json:"message"`
+	StatusPage WebhookStatusPageDTO `json:"status_page"`
+	// ConfirmURL is only set on the confirmation request, the receiver
+	// confirms the subscription with a POST to it
+	ConfirmURL     string `json:"confirm_url,omitempty"`
+	UnsubscribeURL string `json:"unsubscribe_url"`
+	SentAt         string `json:"sent_at"`
+}
@@ -0,0 +1,232 @@
+package status_page_subscriber
+
+import (
+	"context"
+	"fmt"
+	"vigi/internal/infra"
+	"vigi/internal/modules/events"
+	"vigi/internal/modules/heartbeat"
+	"vigi/internal/modules/incident"
+	"vigi/internal/modules/maintenance"
+	"vigi/internal/modules/monitor"
+	"vigi/internal/modules/monitor_group"
+	"vigi/internal/modules/monitor_status_page"
+	"vigi/internal/modules/shared"
+	"vigi/internal/modules/status_page"
+
+	"go.uber.org/dig"
+	"go.uber.org/zap"
+)
+
+// SubscriberEventListener notifies status page subscribers about monitor status
+// changes, incidents and scheduled maintenance
+type SubscriberEventListener struct {
+	service                  Service
+	monitorService           monitor.Service
+	monitorStatusPageService monitor_status_page.Service
+	maintenanceService       maintenance.Service
+	monitorGroupService      monitor_group.Service
+	statusPageService        status_page.Service
+	logger                   *zap.SugaredLogger
+}
+
+type SubscriberEventListenerParams struct {
+	dig.In
+	Service                  Service
+	MonitorService           monitor.Service
+	MonitorStatusPageService monitor_status_page.Service
+	MaintenanceService       maintenance.Service
+	MonitorGroupService      monitor_group.Service
+	StatusPageService        status_page.Service
+	Logger                   *zap.SugaredLogger
+}
+
+func NewSubscriberEventListener(p SubscriberEventListenerParams) *SubscriberEventListener {
+	return &SubscriberEventListener{
+		service:                  p.Service,
+		monitorService:           p.MonitorService,
+		monitorStatusPageService: p.MonitorStatusPageService,
+		maintenanceService:       p.MaintenanceService,
+		monitorGroupService:      p.MonitorGroupService,
+		statusPageService:        p.StatusPageService,
+		logger:                   p.Logger.Named("[status-page-subscriber-listener]"),
+	}
+}
+
+// Subscribe subscribes to the events status page subscribers are notified about
+func (l *SubscriberEventListener) Subscribe(eventBus events.EventBus) {
+	eventBus.Subscribe(events.ImportantHeartbeat, l.handleImportantHeartbeat)
+	eventBus.Subscribe(events.IncidentCreated, l.handleIncident)
+	eventBus.Subscribe(events.IncidentUpdated, l.handleIncident)
+	eventBus.Subscribe(events.MaintenanceScheduled, l.handleMaintenanceScheduled)
+}
+
+func (l *SubscriberEventListener) handleImportantHeartbeat(event events.Event) {
+	ctx := context.Background()
+
+	hb, ok := infra.UnmarshalEventPayload[heartbeat.Model](event)
+	if !ok {
+		l.logger.Errorf("Failed to unmarshal heartbeat event payload")
+		return
+	}
+	if hb.Status != shared.MonitorStatusDown && !hb.Status.IsAvailable() {
+		return
+	}
+
+	// Passing empty string for orgID as this is an internal listener
+	monitorModel, err := l.monitorService.FindByID(ctx, hb.MonitorID, "")
+	if err != nil {
+		l.logger.Errorf("Failed to get monitor %s: %v", hb.MonitorID, err)
+		return
+	}
+	if monitorModel == nil {
+		return
+	}
+
+	notification := &Notification{
+		Event:   "monitor.down",
+		Subject: fmt.Sprintf("%s is down", monitorModel.Name),
+		Message: fmt.Sprintf("%s is not responding. We will keep you posted as the situation evolves.", monitorModel.Name),
+	}
+	switch hb.Status {
+	case shared.MonitorStatusUp:
+		notification = &Notification{
+			Event:   "monitor.up",
+			Subject: fmt.Sprintf("%s is back up", monitorModel.Name),
+			Message: fmt.Sprintf("%s has recovered and is operating normally.", monitorModel.Name),
+		}
+	case shared.MonitorStatusDegraded:
+		notification = &Notification{
+			Event:   "monitor.degraded",
+			Subject: fmt.Sprintf("%s is degraded", monitorModel.Name),
+			Message: fmt.Sprintf("%s is reachable but not performing as expected. We are looking into it.", monitorModel.Name),
+		}
+	}
+
+	l.notifyMonitors(ctx, monitorModel.OrgID, []string{monitorModel.ID}, notification)
+}
+
+func (l *SubscriberEventListener) handleIncident(event events.Event) {
+	ctx := context.Background()
+
+	inc, ok := infra.UnmarshalEventPayload[incident.Model](event)
+	if !ok {
+		l.logger.Errorf("Failed to unmarshal incident event payload")
+		return
+	}
+
+	message := ""
+	if len(inc.Updates) > 0 {
+		message = inc.Updates[0].Message
+	}
+
+	subject := fmt.Sprintf("%s: %s", incidentStateLabel(inc.State), inc.Title)
+	if event.Type == events.IncidentCreated {
+		subject = fmt.Sprintf("New incident: %s", inc.Title)
+	}
+
+	l.notifyMonitors(ctx, inc.OrgID, inc.MonitorIDs, &Notification{
+		Event:   string(event.Type),
+		Subject: subject,
+		Message: message,
+	})
+}
+
+func (l *SubscriberEventListener) handleMaintenanceScheduled(event events.Event) {
+	ctx := context.Background()
+
+	m, ok := infra.UnmarshalEventPayload[maintenance.Model](event)
+	if !ok {
+		l.logger.Errorf("Failed to unmarshal maintenance event payload")
+		return
+	}
+	if !m.Active {
+		return
+	}
+
+	monitorIDs, err := l.maintenanceService.GetAffectedMonitors(ctx, m)
+	if err != nil {
+		l.logger.Errorf("Failed to get monitors of maintenance %s: %v", m.ID, err)
+		return
+	}
+
+	message := m.Description
+	if message == "" {
+		message = "Maintenance has been scheduled for services shown on this status page."
+	}
+	if m.StartDateTime != nil {
+		message = fmt.Sprintf("%s Starts at %s.", message, *m.StartDateTime)
+	}
+
+	l.notifyMonitors(ctx, m.OrgID, monitorIDs, &Notification{
+		Event:   string(event.Type),
+		Subject: fmt.Sprintf("Scheduled maintenance: %s", m.Title),
+		Message: message,
+	})
+}
+
+// notifyMonitors notifies the subscribers of every status page showing any of the monitors,
+// on their own or through a monitor group section
+func (l *SubscriberEventListener) notifyMonitors(ctx context.Context, orgID string, monitorIDs []string, notification *Notification) {
+	seen := make(map[string]bool)
+	statusPageIDs := make([]string, 0)
+	addStatusPage := func(statusPageID string) {
+		if !seen[statusPageID] {
+			seen[statusPageID] = true
+			statusPageIDs = append(statusPageIDs, statusPageID)
+		}
+	}
+
+	seenGroups := make(map[string]bool)
+	for _, monitorID := range monitorIDs {
+		links, err := l.monitorStatusPageService.GetStatusPagesForMonitor(ctx, monitorID)
+		if err != nil {
+			l.logger.Errorf("Failed to get status pages for monitor %s: %v", monitorID, err)
+			continue
+		}
+		for _, link := range links {
+			addStatusPage(link.StatusPageID)
+		}
+
+		groupIDs, err := l.monitorGroupService.FindGroupIDsByMonitorID(ctx, monitorID)
+		if err != nil {
+			l.logger.Errorf("Failed to get groups of monitor %s: %v", monitorID, err)
+			continue
+		}
+		for _, groupID := range groupIDs {
+			if seenGroups[groupID] {
+				continue
+			}
+			seenGroups[groupID] = true
+
+			pages, err := l.statusPageService.FindByGroupID(ctx, groupID)
+			if err != nil {
+				l.logger.Errorf("Failed to get status pages for group %s: %v", groupID, err)
+				continue
+			}
+			for _, page := range pages {
+				addStatusPage(page.ID)
+			}
+		}
+	}
+	if len(statusPageIDs) == 0 {
+		return
+	}
+
+	if err := l.service.Notify(ctx, orgID, statusPageIDs, notification); err != nil {
+		l.logger.Errorf("Failed to notify status page subscribers: %v", err)
+	}
+}
+
+func incidentStateLabel(state string) string {
+	switch state {
+	case incident.StateIdentified:
+		return "Identified"
+	case incident.StateMonitoring:
+		return "Monitoring"
+	case incident.StateResolved:
+		return "Resolved"
+	default:
+		return "Investigating"
+	}
+}
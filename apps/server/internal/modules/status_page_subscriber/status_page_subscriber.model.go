@@ -0,0 +1,30 @@
+package status_page_subscriber
+
+import "time"
+
+const (
+	TypeEmail   = "email"
+	TypeWebhook = "webhook"
+)
+
+type Model struct {
+	ID                 string     `json:"id"`
+	StatusPageID       string     `json:"status_page_id"`
+	Type               string     `json:"type"`
+	Email              string     `json:"email,omitempty"`
+	WebhookURL         string     `json:"webhook_url,omitempty"`
+	Confirmed          bool       `json:"confirmed"`
+	ConfirmToken       string     `json:"-"`
+	UnsubscribeToken   string     `json:"-"`
+	ConfirmedAt        *time.Time `json:"confirmed_at,omitempty"`
+	ConfirmationSentAt *time.Time `json:"-"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+}
+
+// Notification is a message delivered to the subscribers of a status page
+type Notification struct {
+	Event   string
+	Subject string
+	Message string
+}
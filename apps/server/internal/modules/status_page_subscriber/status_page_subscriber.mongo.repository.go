@@ -0,0 +1,218 @@
+package status_page_subscriber
+
+import (
+	"context"
+	"errors"
+	"time"
+	"vigi/internal/config"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoModel struct {
+	ID                 primitive.ObjectID `bson:"_id,omitempty"`
+	StatusPageID       string             `bson:"status_page_id"`
+	Type               string             `bson:"type"`
+	Email              string             `bson:"email,omitempty"`
+	WebhookURL         string             `bson:"webhook_url,omitempty"`
+	Confirmed          bool               `bson:"confirmed"`
+	ConfirmToken       string             `bson:"confirm_token"`
+	UnsubscribeToken   string             `bson:"unsubscribe_token"`
+	ConfirmedAt        *time.Time         `bson:"confirmed_at,omitempty"`
+	ConfirmationSentAt *time.Time         `bson:"confirmation_sent_at,omitempty"`
+	CreatedAt          time.Time          `bson:"created_at"`
+	UpdatedAt          time.Time          `bson:"updated_at"`
+}
+
+func toDomainModel(mm *mongoModel) *Model {
+	return &Model{
+		ID:                 mm.ID.Hex(),
+		StatusPageID:       mm.StatusPageID,
+		Type:               mm.Type,
+		Email:              mm.Email,
+		WebhookURL:         mm.WebhookURL,
+		Confirmed:          mm.Confirmed,
+		ConfirmToken:       mm.ConfirmToken,
+		UnsubscribeToken:   mm.UnsubscribeToken,
+		ConfirmedAt:        mm.ConfirmedAt,
+		ConfirmationSentAt: mm.ConfirmationSentAt,
+		CreatedAt:          mm.CreatedAt,
+		UpdatedAt:          mm.UpdatedAt,
+	}
+}
+
+type MongoRepository struct {
+	client     *mongo.Client
+	db         *mongo.Database
+	collection *mongo.Collection
+}
+
+func NewMongoRepository(client *mongo.Client, cfg *config.Config) Repository {
+	db := client.Database(cfg.DBName)
+	collection := db.Collection("status_page_subscribers")
+
+	// Create indexes
+	go func() {
+		_, _ = collection.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+			{Keys: bson.D{{Key: "status_page_id", Value: 1}}},
+			{Keys: bson.D{{Key: "confirm_token", Value: 1}}, Options: options.Index().SetUnique(true)},
+			{Keys: bson.D{{Key: "unsubscribe_token", Value: 1}}, Options: options.Index().SetUnique(true)},
+		})
+	}()
+
+	return &MongoRepository{
+		client:     client,
+		db:         db,
+		collection: collection,
+	}
+}
+
+func (r *MongoRepository) Create(ctx context.Context, subscriber *Model) (*Model, error) {
+	now := time.Now().UTC()
+	mm := &mongoModel{
+		ID:                 primitive.NewObjectID(),
+		StatusPageID:       subscriber.StatusPageID,
+		Type:               subscriber.Type,
+		Email:              subscriber.Email,
+		WebhookURL:         subscriber.WebhookURL,
+		Confirmed:          subscriber.Confirmed,
+		ConfirmToken:       subscriber.ConfirmToken,
+		UnsubscribeToken:   subscriber.UnsubscribeToken,
+		ConfirmedAt:        subscriber.ConfirmedAt,
+		ConfirmationSentAt: subscriber.ConfirmationSentAt,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	}
+
+	if _, err := r.collection.InsertOne(ctx, mm); err != nil {
+		return nil, err
+	}
+	return toDomainModel(mm), nil
+}
+
+func (r *MongoRepository) FindByID(ctx context.Context, id string) (*Model, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+	return r.findOne(ctx, bson.M{"_id": objectID})
+}
+
+func (r *MongoRepository) FindByStatusPageID(ctx context.Context, statusPageID string, page int, limit int) ([]*Model, error) {
+	skip := int64(page * limit)
+	limit64 := int64(limit)
+
+	opts := &options.FindOptions{
+		Skip:  &skip,
+		Limit: &limit64,
+		Sort:  bson.D{{Key: "created_at", Value: -1}},
+	}
+	return r.find(ctx, bson.M{"status_page_id": statusPageID}, opts)
+}
+
+func (r *MongoRepository) FindByTarget(ctx context.Context, statusPageID string, subscriberType string, target string) (*Model, error) {
+	field := "email"
+	if subscriberType == TypeWebhook {
+		field = "webhook_url"
+	}
+
+	return r.findOne(ctx, bson.M{
+		"status_page_id": statusPageID,
+		"type":           subscriberType,
+		field:            target,
+	})
+}
+
+func (r *MongoRepository) FindByConfirmToken(ctx context.Context, token string) (*Model, error) {
+	return r.findOne(ctx, bson.M{"confirm_token": token})
+}
+
+func (r *MongoRepository) FindByUnsubscribeToken(ctx context.Context, token string) (*Model, error) {
+	return r.findOne(ctx, bson.M{"unsubscribe_token": token})
+}
+
+func (r *MongoRepository) FindConfirmedByStatusPageIDs(ctx context.Context, statusPageIDs []string) ([]*Model, error) {
+	if len(statusPageIDs) == 0 {
+		return []*Model{}, nil
+	}
+
+	filter := bson.M{
+		"status_page_id": bson.M{"$in": statusPageIDs},
+		"confirmed":      true,
+	}
+	return r.find(ctx, filter, options.Find())
+}
+
+func (r *MongoRepository) Confirm(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	update := bson.M{"$set": bson.M{
+		"confirmed":    true,
+		"confirmed_at": now,
+		"updated_at":   now,
+	}}
+	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": objectID}, update)
+	return err
+}
+
+func (r *MongoRepository) MarkConfirmationSent(ctx context.Context, id string, sentAt time.Time) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	update := bson.M{"$set": bson.M{
+		"confirmation_sent_at": sentAt,
+		"updated_at":           time.Now().UTC(),
+	}}
+	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": objectID}, update)
+	return err
+}
+
+func (r *MongoRepository) Delete(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.collection.DeleteOne(ctx, bson.M{"_id": objectID})
+	return err
+}
+
+func (r *MongoRepository) findOne(ctx context.Context, filter bson.M) (*Model, error) {
+	var mm mongoModel
+	err := r.collection.FindOne(ctx, filter).Decode(&mm)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toDomainModel(&mm), nil
+}
+
+func (r *MongoRepository) find(ctx context.Context, filter bson.M, opts *options.FindOptions) ([]*Model, error) {
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var mms []*mongoModel
+	if err := cursor.All(ctx, &mms); err != nil {
+		return nil, err
+	}
+
+	models := make([]*Model, 0, len(mms))
+	for _, mm := range mms {
+		models = append(models, toDomainModel(mm))
+	}
+	return models, nil
+}
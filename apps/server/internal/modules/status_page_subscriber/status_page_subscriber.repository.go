@@ -0,0 +1,22 @@
+package status_page_subscriber
+
+import (
+	"context"
+	"time"
+)
+
+type Repository interface {
+	Create(ctx context.Context, subscriber *Model) (*Model, error)
+	FindByID(ctx context.Context, id string) (*Model, error)
+	FindByStatusPageID(ctx context.Context, statusPageID string, page int, limit int) ([]*Model, error)
+	// FindByTarget returns the subscriber of a status page with the given email or webhook URL
+	FindByTarget(ctx context.Context, statusPageID string, subscriberType string, target string) (*Model, error)
+	FindByConfirmToken(ctx context.Context, token string) (*Model, error)
+	FindByUnsubscribeToken(ctx context.Context, token string) (*Model, error)
+	// FindConfirmedByStatusPageIDs returns the confirmed subscribers of any of the status pages
+	FindConfirmedByStatusPageIDs(ctx context.Context, statusPageIDs []string) ([]*Model, error)
+	Confirm(ctx context.Context, id string) error
+	// MarkConfirmationSent records when the last confirmation request was sent
+	MarkConfirmationSent(ctx context.Context, id string, sentAt time.Time) error
+	Delete(ctx context.Context, id string) error
+}
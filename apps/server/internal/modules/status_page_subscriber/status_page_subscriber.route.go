@@ -0,0 +1,69 @@
+package status_page_subscriber
+
+import (
+	"net/http"
+	"time"
+	"vigi/internal/modules/bruteforce"
+	"vigi/internal/modules/middleware"
+	"vigi/internal/modules/organization"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const (
+	// Subscriptions accepted per client before further requests are refused, each
+	// one sends a confirmation email or webhook to a third party
+	subscribeRateLimit  = 10
+	subscribeRateWindow = time.Hour
+)
+
+type Route struct {
+	controller    *Controller
+	middleware    *middleware.AuthChain
+	orgMiddleware *organization.Middleware
+	// Counts every accepted subscription, like the password reset guard
+	subscribeGuard *bruteforce.Guard
+}
+
+func NewRoute(
+	controller *Controller,
+	middleware *middleware.AuthChain,
+	orgMiddleware *organization.Middleware,
+	bruteforceService bruteforce.Service,
+	logger *zap.SugaredLogger,
+) *Route {
+	return &Route{
+		controller:    controller,
+		middleware:    middleware,
+		orgMiddleware: orgMiddleware,
+		subscribeGuard: bruteforce.New(
+			bruteforce.Config{
+				MaxAttempts:     subscribeRateLimit,
+				Window:          subscribeRateWindow,
+				Lockout:         subscribeRateWindow,
+				FailureStatuses: []int{http.StatusCreated},
+			},
+			bruteforceService,
+			bruteforce.KeyWithPrefix("status-page-subscribe", func(c *gin.Context) (string, error) {
+				return c.ClientIP(), nil
+			}),
+			logger,
+		),
+	}
+}
+
+func (r *Route) ConnectRoute(rg *gin.RouterGroup, controller *Controller) {
+	// Public routes
+	router := rg.Group("status-page-subscribers")
+	router.POST("/slug/:slug", r.subscribeGuard.Middleware(), r.controller.Subscribe)
+	router.POST("/confirm/:token", r.controller.Confirm)
+	router.POST("/unsubscribe/:token", r.controller.Unsubscribe)
+
+	router.Use(r.middleware.AllAuth())
+	router.Use(r.orgMiddleware.RequireOrganization())
+	{
+		router.GET("", r.controller.FindAll)
+		router.DELETE("/:id", r.controller.Delete)
+	}
+}
@@ -0,0 +1,283 @@
+package status_page_subscriber
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+	"vigi/internal/config"
+	"vigi/internal/modules/status_page"
+	"vigi/internal/pkg/safehttp"
+	"vigi/internal/pkg/usesend"
+
+	"go.uber.org/zap"
+)
+
+var (
+	ErrStatusPageNotFound = errors.New("status page not found")
+	ErrWebhookFailed      = errors.New("the webhook URL did not accept the confirmation request")
+)
+
+const (
+	webhookTimeout = 10 * time.Second
+
+	// confirmationResendInterval is how long subscribing again waits before
+	// sending another confirmation request to the same target
+	confirmationResendInterval = 15 * time.Minute
+)
+
+// emailClient is the part of the usesend client used to deliver emails
+type emailClient interface {
+	SendEmail(ctx context.Context, req usesend.SendEmailRequest) (*usesend.SendEmailResponse, error)
+}
+
+type Service interface {
+	// Subscribe registers a subscriber on a published status page. Email subscribers
+	// receive a confirmation email and webhook subscribers a confirmation request,
+	// they only get notifications once they confirm.
+	Subscribe(ctx context.Context, slug string, dto *SubscribeDTO) (*Model, error)
+	Confirm(ctx context.Context, token string) (*Model, error)
+	Unsubscribe(ctx context.Context, token string) (*Model, error)
+
+	FindByStatusPage(ctx context.Context, statusPageID string, page int, limit int, orgID string) ([]*Model, error)
+	Delete(ctx context.Context, id string, orgID string) error
+
+	// Notify delivers the notification to the confirmed subscribers of the status pages
+	Notify(ctx context.Context, orgID string, statusPageIDs []string, notification *Notification) error
+}
+
+type ServiceImpl struct {
+	repository        Repository
+	statusPageService status_page.Service
+	emailClient       emailClient
+	httpClient        *http.Client
+	cfg               *config.Config
+	logger            *zap.SugaredLogger
+}
+
+func NewService(
+	repository Repository,
+	statusPageService status_page.Service,
+	usesendClient *usesend.Client,
+	cfg *config.Config,
+	logger *zap.SugaredLogger,
+) Service {
+	return &ServiceImpl{
+		repository:        repository,
+		statusPageService: statusPageService,
+		emailClient:       usesendClient,
+		httpClient:        safehttp.NewClient(webhookTimeout),
+		cfg:               cfg,
+		logger:            logger.Named("[status-page-subscriber-service]"),
+	}
+}
+
+func (s *ServiceImpl) Subscribe(ctx context.Context, slug string, dto *SubscribeDTO) (*Model, error) {
+	page, err := s.statusPageService.FindBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	if page == nil || !page.Published {
+		return nil, ErrStatusPageNotFound
+	}
+
+	target := strings.TrimSpace(dto.WebhookURL)
+	if dto.Type == TypeEmail {
+		target = strings.ToLower(strings.TrimSpace(dto.Email))
+	}
+
+	existing, err := s.repository.FindByTarget(ctx, page.ID, dto.Type, target)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		// Subscribing again resends the confirmation of a pending subscription,
+		// at most once per interval so the endpoint cannot be used to flood a target
+		if !existing.Confirmed && !confirmationRecentlySent(existing) {
+			if err := s.sendConfirmation(ctx, page, existing); err != nil {
+				return nil, err
+			}
+		}
+		return existing, nil
+	}
+
+	confirmToken, err := generateToken()
+	if err != nil {
+		return nil, err
+	}
+	unsubscribeToken, err := generateToken()
+	if err != nil {
+		return nil, err
+	}
+
+	model := &Model{
+		StatusPageID:     page.ID,
+		Type:             dto.Type,
+		ConfirmToken:     confirmToken,
+		UnsubscribeToken: unsubscribeToken,
+	}
+	if dto.Type == TypeEmail {
+		model.Email = target
+	} else {
+		model.WebhookURL = target
+	}
+
+	created, err := s.repository.Create(ctx, model)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.sendConfirmation(ctx, page, created); err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+func (s *ServiceImpl) Confirm(ctx context.Context, token string) (*Model, error) {
+	subscriber, err := s.repository.FindByConfirmToken(ctx, token)
+	if err != nil || subscriber == nil {
+		return nil, err
+	}
+	if subscriber.Confirmed {
+		return subscriber, nil
+	}
+
+	if err := s.repository.Confirm(ctx, subscriber.ID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	subscriber.Confirmed = true
+	subscriber.ConfirmedAt = &now
+	return subscriber, nil
+}
+
+func (s *ServiceImpl) Unsubscribe(ctx context.Context, token string) (*Model, error) {
+	subscriber, err := s.repository.FindByUnsubscribeToken(ctx, token)
+	if err != nil || subscriber == nil {
+		return nil, err
+	}
+
+	if err := s.repository.Delete(ctx, subscriber.ID); err != nil {
+		return nil, err
+	}
+	return subscriber, nil
+}
+
+func (s *ServiceImpl) FindByStatusPage(ctx context.Context, statusPageID string, page int, limit int, orgID string) ([]*Model, error) {
+	statusPage, err := s.statusPageService.FindByID(ctx, statusPageID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if statusPage == nil {
+		return nil, ErrStatusPageNotFound
+	}
+
+	return s.repository.FindByStatusPageID(ctx, statusPageID, page, limit)
+}
+
+func (s *ServiceImpl) Delete(ctx context.Context, id string, orgID string) error {
+	subscriber, err := s.repository.FindByID(ctx, id)
+	if err != nil || subscriber == nil {
+		return err
+	}
+
+	statusPage, err := s.statusPageService.FindByID(ctx, subscriber.StatusPageID, orgID)
+	if err != nil {
+		return err
+	}
+	if statusPage == nil {
+		return nil
+	}
+
+	return s.repository.Delete(ctx, id)
+}
+
+func (s *ServiceImpl) Notify(ctx context.Context, orgID string, statusPageIDs []string, notification *Notification) error {
+	subscribers, err := s.repository.FindConfirmedByStatusPageIDs(ctx, statusPageIDs)
+	if err != nil {
+		return err
+	}
+
+	pages := make(map[string]*status_page.Model)
+	var errs []error
+	for _, subscriber := range subscribers {
+		page, ok := pages[subscriber.StatusPageID]
+		if !ok {
+			page, err = s.statusPageService.FindByID(ctx, subscriber.StatusPageID, orgID)
+			if err != nil {
+				return err
+			}
+			pages[subscriber.StatusPageID] = page
+		}
+		// Unpublished pages keep their subscribers but stay quiet
+		if page == nil || !page.Published {
+			continue
+		}
+
+		if err := s.deliver(ctx, page, subscriber, notification); err != nil {
+			s.logger.Warnw("Failed to notify status page subscriber",
+				"subscriberID", subscriber.ID, "statusPageID", page.ID, "type", subscriber.Type, "error", err)
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (s *ServiceImpl) deliver(ctx context.Context, page *status_page.Model, subscriber *Model, notification *Notification) error {
+	if subscriber.Type == TypeWebhook {
+		return s.sendWebhook(ctx, page, subscriber, notification)
+	}
+	return s.sendNotificationEmail(ctx, page, subscriber, notification)
+}
+
+// sendConfirmation asks the subscriber to confirm the subscription, by email or
+// with a request to the webhook that the receiving system has to answer
+func (s *ServiceImpl) sendConfirmation(ctx context.Context, page *status_page.Model, subscriber *Model) error {
+	if subscriber.Type == TypeWebhook {
+		if err := s.sendConfirmationWebhook(ctx, page, subscriber); err != nil {
+			s.logger.Warnw("Failed to send webhook confirmation request",
+				"subscriberID", subscriber.ID, "statusPageID", page.ID, "error", err)
+			return ErrWebhookFailed
+		}
+	} else if err := s.sendConfirmationEmail(ctx, page, subscriber); err != nil {
+		return err
+	}
+
+	return s.repository.MarkConfirmationSent(ctx, subscriber.ID, time.Now().UTC())
+}
+
+func confirmationRecentlySent(subscriber *Model) bool {
+	return subscriber.ConfirmationSentAt != nil && time.Since(*subscriber.ConfirmationSentAt) < confirmationResendInterval
+}
+
+func (s *ServiceImpl) statusPageURL(page *status_page.Model) string {
+	return fmt.Sprintf("%s/status/%s", strings.TrimSuffix(s.cfg.ClientURL, "/"), page.Slug)
+}
+
+func (s *ServiceImpl) confirmURL(subscriber *Model) string {
+	return fmt.Sprintf("%s/status-subscriptions/confirm/%s", strings.TrimSuffix(s.cfg.ClientURL, "/"), subscriber.ConfirmToken)
+}
+
+// confirmAPIURL is the endpoint webhook receivers call to confirm their subscription
+func (s *ServiceImpl) confirmAPIURL(subscriber *Model) string {
+	return fmt.Sprintf("%s/api/v1/status-page-subscribers/confirm/%s", strings.TrimSuffix(s.cfg.ClientURL, "/"), subscriber.ConfirmToken)
+}
+
+func (s *ServiceImpl) unsubscribeURL(subscriber *Model) string {
+	return fmt.Sprintf("%s/status-subscriptions/unsubscribe/%s", strings.TrimSuffix(s.cfg.ClientURL, "/"), subscriber.UnsubscribeToken)
+}
+
+func generateToken() (string, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
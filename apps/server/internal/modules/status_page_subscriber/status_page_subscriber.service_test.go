@@ -0,0 +1,359 @@
+package status_page_subscriber
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"vigi/internal/config"
+	"vigi/internal/modules/monitor_status_page"
+	"vigi/internal/modules/status_page"
+	"vigi/internal/pkg/usesend"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+// MockRepository
+type MockRepository struct {
+	mock.Mock
+}
+
+func (m *MockRepository) Create(ctx context.Context, subscriber *Model) (*Model, error) {
+	args := m.Called(ctx, subscriber)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Model), args.Error(1)
+}
+
+func (m *MockRepository) FindByID(ctx context.Context, id string) (*Model, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Model), args.Error(1)
+}
+
+func (m *MockRepository) FindByStatusPageID(ctx context.Context, statusPageID string, page int, limit int) ([]*Model, error) {
+	args := m.Called(ctx, statusPageID, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*Model), args.Error(1)
+}
+
+func (m *MockRepository) FindByTarget(ctx context.Context, statusPageID string, subscriberType string, target string) (*Model, error) {
+	args := m.Called(ctx, statusPageID, subscriberType, target)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Model), args.Error(1)
+}
+
+func (m *MockRepository) FindByConfirmToken(ctx context.Context, token string) (*Model, error) {
+	args := m.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Model), args.Error(1)
+}
+
+func (m *MockRepository) FindByUnsubscribeToken(ctx context.Context, token string) (*Model, error) {
+	args := m.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Model), args.Error(1)
+}
+
+func (m *MockRepository) FindConfirmedByStatusPageIDs(ctx context.Context, statusPageIDs []string) ([]*Model, error) {
+	args := m.Called(ctx, statusPageIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*Model), args.Error(1)
+}
+
+func (m *MockRepository) Confirm(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockRepository) MarkConfirmationSent(ctx context.Context, id string, sentAt time.Time) error {
+	args := m.Called(ctx, id, sentAt)
+	return args.Error(0)
+}
+
+func (m *MockRepository) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+// MockStatusPageService
+type MockStatusPageService struct {
+	mock.Mock
+}
+
+func (m *MockStatusPageService) Create(ctx context.Context, dto *status_page.CreateStatusPageDTO, orgID string) (*status_page.Model, error) {
+	return nil, nil
+}
+func (m *MockStatusPageService) FindByID(ctx context.Context, id string, orgID string) (*status_page.Model, error) {
+	args := m.Called(ctx, id, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*status_page.Model), args.Error(1)
+}
+func (m *MockStatusPageService) FindByIDWithMonitors(ctx context.Context, id string, orgID string) (*status_page.StatusPageWithMonitorsResponseDTO, error) {
+	return nil, nil
+}
+func (m *MockStatusPageService) FindBySlug(ctx context.Context, slug string) (*status_page.Model, error) {
+	args := m.Called(ctx, slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*status_page.Model), args.Error(1)
+}
+func (m *MockStatusPageService) FindByDomain(ctx context.Context, domain string) (*status_page.Model, error) {
+	return nil, nil
+}
+func (m *MockStatusPageService) FindAll(ctx context.Context, page int, limit int, q string, orgID string) ([]*status_page.Model, error) {
+	return nil, nil
+}
+func (m *MockStatusPageService) Update(ctx context.Context, id string, dto *status_page.UpdateStatusPageDTO, orgID string) (*status_page.Model, error) {
+	return nil, nil
+}
+func (m *MockStatusPageService) Delete(ctx context.Context, id string, orgID string) error {
+	return nil
+}
+func (m *MockStatusPageService) GetMonitorsForStatusPage(ctx context.Context, statusPageID string) ([]*monitor_status_page.Model, error) {
+	return nil, nil
+}
+func (m *MockStatusPageService) GetGroupsForStatusPage(ctx context.Context, statusPage *status_page.Model) ([]*status_page.PublicGroupDTO, error) {
+	return nil, nil
+}
+func (m *MockStatusPageService) FindByGroupID(ctx context.Context, groupID string) ([]*status_page.Model, error) {
+	return nil, nil
+}
+
+// MockEmailClient
+type MockEmailClient struct {
+	mock.Mock
+}
+
+func (m *MockEmailClient) SendEmail(ctx context.Context, req usesend.SendEmailRequest) (*usesend.SendEmailResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usesend.SendEmailResponse), args.Error(1)
+}
+
+func setupService() (*ServiceImpl, *MockRepository, *MockStatusPageService, *MockEmailClient) {
+	repo := &MockRepository{}
+	statusPageService := &MockStatusPageService{}
+	emailClient := &MockEmailClient{}
+
+	service := &ServiceImpl{
+		repository:        repo,
+		statusPageService: statusPageService,
+		emailClient:       emailClient,
+		httpClient:        http.DefaultClient,
+		cfg: &config.Config{
+			ClientURL:           "https://status.example.com",
+			StatusPageEmailFrom: "Status <status@example.com>",
+		},
+		logger: zap.NewNop().Sugar(),
+	}
+	return service, repo, statusPageService, emailClient
+}
+
+func publishedPage() *status_page.Model {
+	return &status_page.Model{ID: "page1", OrgID: "org1", Slug: "acme", Title: "Acme", Published: true}
+}
+
+func TestServiceImpl_Subscribe(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("email subscriber must confirm", func(t *testing.T) {
+		service, repo, statusPageService, emailClient := setupService()
+
+		statusPageService.On("FindBySlug", ctx, "acme").Return(publishedPage(), nil)
+		repo.On("FindByTarget", ctx, "page1", TypeEmail, "user@example.com").Return(nil, nil)
+		repo.On("Create", ctx, mock.MatchedBy(func(m *Model) bool {
+			return !m.Confirmed && m.Email == "user@example.com" && m.ConfirmToken != "" && m.UnsubscribeToken != ""
+		})).Return(&Model{ID: "sub1", StatusPageID: "page1", Type: TypeEmail, Email: "user@example.com", ConfirmToken: "token"}, nil)
+		emailClient.On("SendEmail", ctx, mock.MatchedBy(func(req usesend.SendEmailRequest) bool {
+			return req.To == "user@example.com" && req.From == "Status <status@example.com>"
+		})).Return(&usesend.SendEmailResponse{EmailID: "email1"}, nil)
+		repo.On("MarkConfirmationSent", ctx, "sub1", mock.Anything).Return(nil)
+
+		created, err := service.Subscribe(ctx, "acme", &SubscribeDTO{Type: TypeEmail, Email: " User@Example.com "})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "sub1", created.ID)
+		repo.AssertExpectations(t)
+		emailClient.AssertExpectations(t)
+	})
+
+	t.Run("webhook subscriber must confirm", func(t *testing.T) {
+		service, repo, statusPageService, emailClient := setupService()
+
+		var received WebhookPayloadDTO
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewDecoder(r.Body).Decode(&received)
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		statusPageService.On("FindBySlug", ctx, "acme").Return(publishedPage(), nil)
+		repo.On("FindByTarget", ctx, "page1", TypeWebhook, server.URL).Return(nil, nil)
+		repo.On("Create", ctx, mock.MatchedBy(func(m *Model) bool {
+			return !m.Confirmed && m.ConfirmedAt == nil && m.WebhookURL == server.URL
+		})).Return(&Model{ID: "sub1", Type: TypeWebhook, WebhookURL: server.URL, ConfirmToken: "token"}, nil)
+		repo.On("MarkConfirmationSent", ctx, "sub1", mock.Anything).Return(nil)
+
+		_, err := service.Subscribe(ctx, "acme", &SubscribeDTO{Type: TypeWebhook, WebhookURL: server.URL})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "subscription.confirm", received.Event)
+		assert.Equal(t, "https://status.example.com/api/v1/status-page-subscribers/confirm/token", received.ConfirmURL)
+		repo.AssertExpectations(t)
+		emailClient.AssertNotCalled(t, "SendEmail", mock.Anything, mock.Anything)
+	})
+
+	t.Run("webhook refusing the confirmation request", func(t *testing.T) {
+		service, repo, statusPageService, _ := setupService()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		statusPageService.On("FindBySlug", ctx, "acme").Return(publishedPage(), nil)
+		repo.On("FindByTarget", ctx, "page1", TypeWebhook, server.URL).Return(nil, nil)
+		repo.On("Create", ctx, mock.Anything).Return(&Model{ID: "sub1", Type: TypeWebhook, WebhookURL: server.URL}, nil)
+
+		_, err := service.Subscribe(ctx, "acme", &SubscribeDTO{Type: TypeWebhook, WebhookURL: server.URL})
+
+		assert.ErrorIs(t, err, ErrWebhookFailed)
+		repo.AssertNotCalled(t, "MarkConfirmationSent", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("pending subscriber is not sent another confirmation right away", func(t *testing.T) {
+		service, repo, statusPageService, emailClient := setupService()
+
+		sentAt := time.Now().UTC().Add(-time.Minute)
+		existing := &Model{ID: "sub1", Type: TypeEmail, Email: "user@example.com", ConfirmationSentAt: &sentAt}
+		statusPageService.On("FindBySlug", ctx, "acme").Return(publishedPage(), nil)
+		repo.On("FindByTarget", ctx, "page1", TypeEmail, "user@example.com").Return(existing, nil)
+
+		_, err := service.Subscribe(ctx, "acme", &SubscribeDTO{Type: TypeEmail, Email: "user@example.com"})
+
+		assert.NoError(t, err)
+		emailClient.AssertNotCalled(t, "SendEmail", mock.Anything, mock.Anything)
+	})
+
+	t.Run("pending subscriber gets a new confirmation after the interval", func(t *testing.T) {
+		service, repo, statusPageService, emailClient := setupService()
+
+		sentAt := time.Now().UTC().Add(-confirmationResendInterval - time.Minute)
+		existing := &Model{ID: "sub1", Type: TypeEmail, Email: "user@example.com", ConfirmationSentAt: &sentAt}
+		statusPageService.On("FindBySlug", ctx, "acme").Return(publishedPage(), nil)
+		repo.On("FindByTarget", ctx, "page1", TypeEmail, "user@example.com").Return(existing, nil)
+		emailClient.On("SendEmail", ctx, mock.Anything).Return(&usesend.SendEmailResponse{EmailID: "email1"}, nil).Once()
+		repo.On("MarkConfirmationSent", ctx, "sub1", mock.Anything).Return(nil)
+
+		_, err := service.Subscribe(ctx, "acme", &SubscribeDTO{Type: TypeEmail, Email: "user@example.com"})
+
+		assert.NoError(t, err)
+		emailClient.AssertExpectations(t)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("already confirmed subscriber is not emailed again", func(t *testing.T) {
+		service, repo, statusPageService, emailClient := setupService()
+
+		existing := &Model{ID: "sub1", Type: TypeEmail, Email: "user@example.com", Confirmed: true}
+		statusPageService.On("FindBySlug", ctx, "acme").Return(publishedPage(), nil)
+		repo.On("FindByTarget", ctx, "page1", TypeEmail, "user@example.com").Return(existing, nil)
+
+		result, err := service.Subscribe(ctx, "acme", &SubscribeDTO{Type: TypeEmail, Email: "user@example.com"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, existing, result)
+		repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		emailClient.AssertNotCalled(t, "SendEmail", mock.Anything, mock.Anything)
+	})
+
+	t.Run("unpublished status page", func(t *testing.T) {
+		service, _, statusPageService, _ := setupService()
+
+		page := publishedPage()
+		page.Published = false
+		statusPageService.On("FindBySlug", ctx, "acme").Return(page, nil)
+
+		_, err := service.Subscribe(ctx, "acme", &SubscribeDTO{Type: TypeEmail, Email: "user@example.com"})
+
+		assert.ErrorIs(t, err, ErrStatusPageNotFound)
+	})
+}
+
+func TestServiceImpl_Confirm(t *testing.T) {
+	ctx := context.Background()
+	service, repo, _, _ := setupService()
+
+	repo.On("FindByConfirmToken", ctx, "token").Return(&Model{ID: "sub1"}, nil)
+	repo.On("Confirm", ctx, "sub1").Return(nil)
+	repo.On("FindByConfirmToken", ctx, "unknown").Return(nil, nil)
+
+	confirmed, err := service.Confirm(ctx, "token")
+	assert.NoError(t, err)
+	assert.True(t, confirmed.Confirmed)
+	assert.NotNil(t, confirmed.ConfirmedAt)
+
+	missing, err := service.Confirm(ctx, "unknown")
+	assert.NoError(t, err)
+	assert.Nil(t, missing)
+}
+
+func TestServiceImpl_Notify(t *testing.T) {
+	ctx := context.Background()
+	service, repo, statusPageService, emailClient := setupService()
+
+	var received WebhookPayloadDTO
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&received)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	hidden := &status_page.Model{ID: "page2", Slug: "hidden", Title: "Hidden", Published: false}
+	repo.On("FindConfirmedByStatusPageIDs", ctx, []string{"page1", "page2"}).Return([]*Model{
+		{ID: "sub1", StatusPageID: "page1", Type: TypeWebhook, WebhookURL: server.URL, UnsubscribeToken: "unsub1"},
+		{ID: "sub2", StatusPageID: "page1", Type: TypeEmail, Email: "user@example.com", UnsubscribeToken: "unsub2"},
+		{ID: "sub3", StatusPageID: "page2", Type: TypeEmail, Email: "other@example.com"},
+	}, nil)
+	statusPageService.On("FindByID", ctx, "page1", "org1").Return(publishedPage(), nil).Once()
+	statusPageService.On("FindByID", ctx, "page2", "org1").Return(hidden, nil).Once()
+	emailClient.On("SendEmail", ctx, mock.MatchedBy(func(req usesend.SendEmailRequest) bool {
+		return req.To == "user@example.com" && req.Subject == "[Acme] API is down"
+	})).Return(&usesend.SendEmailResponse{EmailID: "email1"}, nil).Once()
+
+	err := service.Notify(ctx, "org1", []string{"page1", "page2"}, &Notification{
+		Event:   "monitor.down",
+		Subject: "API is down",
+		Message: "API is not responding.",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "monitor.down", received.Event)
+	assert.Equal(t, "https://status.example.com/status/acme", received.StatusPage.URL)
+	assert.Equal(t, "https://status.example.com/status-subscriptions/unsubscribe/unsub1", received.UnsubscribeURL)
+	emailClient.AssertExpectations(t)
+	statusPageService.AssertExpectations(t)
+}
@@ -0,0 +1,198 @@
+package status_page_subscriber
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type sqlModel struct {
+	bun.BaseModel `bun:"table:status_page_subscribers,alias:sps"`
+
+	ID                 string     `bun:"id,pk"`
+	StatusPageID       string     `bun:"status_page_id,notnull"`
+	Type               string     `bun:"type,notnull"`
+	Email              *string    `bun:"email"`
+	WebhookURL         *string    `bun:"webhook_url"`
+	Confirmed          bool       `bun:"confirmed,notnull,default:false"`
+	ConfirmToken       string     `bun:"confirm_token,notnull"`
+	UnsubscribeToken   string     `bun:"unsubscribe_token,notnull"`
+	ConfirmedAt        *time.Time `bun:"confirmed_at"`
+	ConfirmationSentAt *time.Time `bun:"confirmation_sent_at"`
+	CreatedAt          time.Time  `bun:"created_at,nullzero,notnull,default:current_timestamp"`
+	UpdatedAt          time.Time  `bun:"updated_at,nullzero,notnull,default:current_timestamp"`
+}
+
+func toDomainModelFromSQL(sm *sqlModel) *Model {
+	m := &Model{
+		ID:                 sm.ID,
+		StatusPageID:       sm.StatusPageID,
+		Type:               sm.Type,
+		Confirmed:          sm.Confirmed,
+		ConfirmToken:       sm.ConfirmToken,
+		UnsubscribeToken:   sm.UnsubscribeToken,
+		ConfirmedAt:        sm.ConfirmedAt,
+		ConfirmationSentAt: sm.ConfirmationSentAt,
+		CreatedAt:          sm.CreatedAt,
+		UpdatedAt:          sm.UpdatedAt,
+	}
+	if sm.Email != nil {
+		m.Email = *sm.Email
+	}
+	if sm.WebhookURL != nil {
+		m.WebhookURL = *sm.WebhookURL
+	}
+	return m
+}
+
+func toSQLModel(m *Model) *sqlModel {
+	sm := &sqlModel{
+		ID:                 m.ID,
+		StatusPageID:       m.StatusPageID,
+		Type:               m.Type,
+		Confirmed:          m.Confirmed,
+		ConfirmToken:       m.ConfirmToken,
+		UnsubscribeToken:   m.UnsubscribeToken,
+		ConfirmedAt:        m.ConfirmedAt,
+		ConfirmationSentAt: m.ConfirmationSentAt,
+		CreatedAt:          m.CreatedAt,
+		UpdatedAt:          m.UpdatedAt,
+	}
+	if m.Email != "" {
+		sm.Email = &m.Email
+	}
+	if m.WebhookURL != "" {
+		sm.WebhookURL = &m.WebhookURL
+	}
+	return sm
+}
+
+type SQLRepositoryImpl struct {
+	db *bun.DB
+}
+
+func NewSQLRepository(db *bun.DB) Repository {
+	return &SQLRepositoryImpl{db: db}
+}
+
+func (r *SQLRepositoryImpl) Create(ctx context.Context, subscriber *Model) (*Model, error) {
+	sm := toSQLModel(subscriber)
+	sm.ID = uuid.New().String()
+	sm.CreatedAt = time.Now()
+	sm.UpdatedAt = time.Now()
+
+	if _, err := r.db.NewInsert().Model(sm).Exec(ctx); err != nil {
+		return nil, err
+	}
+	return toDomainModelFromSQL(sm), nil
+}
+
+func (r *SQLRepositoryImpl) FindByID(ctx context.Context, id string) (*Model, error) {
+	return r.findOne(ctx, func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q.Where("id = ?", id)
+	})
+}
+
+func (r *SQLRepositoryImpl) FindByStatusPageID(ctx context.Context, statusPageID string, page int, limit int) ([]*Model, error) {
+	var sms []*sqlModel
+	err := r.db.NewSelect().Model(&sms).
+		Where("status_page_id = ?", statusPageID).
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(page * limit).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return toDomainModels(sms), nil
+}
+
+func (r *SQLRepositoryImpl) FindByTarget(ctx context.Context, statusPageID string, subscriberType string, target string) (*Model, error) {
+	column := "email"
+	if subscriberType == TypeWebhook {
+		column = "webhook_url"
+	}
+
+	return r.findOne(ctx, func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q.Where("status_page_id = ?", statusPageID).
+			Where("type = ?", subscriberType).
+			Where("? = ?", bun.Ident(column), target)
+	})
+}
+
+func (r *SQLRepositoryImpl) FindByConfirmToken(ctx context.Context, token string) (*Model, error) {
+	return r.findOne(ctx, func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q.Where("confirm_token = ?", token)
+	})
+}
+
+func (r *SQLRepositoryImpl) FindByUnsubscribeToken(ctx context.Context, token string) (*Model, error) {
+	return r.findOne(ctx, func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q.Where("unsubscribe_token = ?", token)
+	})
+}
+
+func (r *SQLRepositoryImpl) FindConfirmedByStatusPageIDs(ctx context.Context, statusPageIDs []string) ([]*Model, error) {
+	if len(statusPageIDs) == 0 {
+		return []*Model{}, nil
+	}
+
+	var sms []*sqlModel
+	err := r.db.NewSelect().Model(&sms).
+		Where("status_page_id IN (?)", bun.In(statusPageIDs)).
+		Where("confirmed = ?", true).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return toDomainModels(sms), nil
+}
+
+func (r *SQLRepositoryImpl) Confirm(ctx context.Context, id string) error {
+	now := time.Now()
+	_, err := r.db.NewUpdate().Model((*sqlModel)(nil)).
+		Set("confirmed = ?", true).
+		Set("confirmed_at = ?", now).
+		Set("updated_at = ?", now).
+		Where("id = ?", id).
+		Exec(ctx)
+	return err
+}
+
+func (r *SQLRepositoryImpl) MarkConfirmationSent(ctx context.Context, id string, sentAt time.Time) error {
+	_, err := r.db.NewUpdate().Model((*sqlModel)(nil)).
+		Set("confirmation_sent_at = ?", sentAt).
+		Set("updated_at = ?", time.Now()).
+		Where("id = ?", id).
+		Exec(ctx)
+	return err
+}
+
+func (r *SQLRepositoryImpl) Delete(ctx context.Context, id string) error {
+	_, err := r.db.NewDelete().Model((*sqlModel)(nil)).Where("id = ?", id).Exec(ctx)
+	return err
+}
+
+func (r *SQLRepositoryImpl) findOne(ctx context.Context, where func(q *bun.SelectQuery) *bun.SelectQuery) (*Model, error) {
+	sm := new(sqlModel)
+	err := where(r.db.NewSelect().Model(sm)).Limit(1).Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toDomainModelFromSQL(sm), nil
+}
+
+func toDomainModels(sms []*sqlModel) []*Model {
+	models := make([]*Model, 0, len(sms))
+	for _, sm := range sms {
+		models = append(models, toDomainModelFromSQL(sm))
+	}
+	return models
+}
@@ -0,0 +1,309 @@
+package worker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+	"vigi/internal/modules/certificate"
+	"vigi/internal/modules/healthcheck"
+	"vigi/internal/modules/healthcheck/executor"
+	"vigi/internal/modules/metrics"
+	"vigi/internal/modules/shared"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// AgentChecksPath is where a probe agent pulls the checks assigned to it
+	AgentChecksPath = "/api/v1/probe-agent/checks"
+	// AgentResultsPath is where a probe agent submits check results
+	AgentResultsPath = "/api/v1/probe-agent/results"
+	// AgentTokenHeader carries the probe token
+	AgentTokenHeader = "X-Probe-Token"
+)
+
+// ErrAgentUnauthorized is returned when the API rejects the probe token
+var ErrAgentUnauthorized = errors.New("probe token was rejected by the API")
+
+// AgentResult is a check result submitted by a probe agent
+type AgentResult struct {
+	MonitorID string               `json:"monitor_id" validate:"required"`
+	Status    shared.MonitorStatus `json:"status" validate:"min=0,max=4"`
+	Message   string               `json:"message"`
+	PingMs    int                  `json:"ping_ms" validate:"min=0"`
+	StartTime time.Time            `json:"start_time" validate:"required"`
+	EndTime   time.Time            `json:"end_time" validate:"required"`
+	TLSInfo   *certificate.TLSInfo `json:"tls_info,omitempty"`
+}
+
+// AgentConfig configures a probe agent
+type AgentConfig struct {
+	APIURL       string
+	Token        string
+	SyncInterval time.Duration
+}
+
+// agentCheck is a check scheduled by the agent
+type agentCheck struct {
+	payload HealthCheckTaskPayload
+	cancel  context.CancelFunc
+}
+
+// Agent runs the checks assigned to a probe location. It pulls them from the
+// API and submits the results over HTTPS, so it needs no access to Redis or
+// the database.
+type Agent struct {
+	cfg          AgentConfig
+	client       *http.Client
+	execRegistry *executor.ExecutorRegistry
+	supervisor   *healthcheck.HealthCheckSupervisor
+	logger       *zap.SugaredLogger
+
+	mu     sync.Mutex
+	checks map[string]*agentCheck
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewAgent creates a new probe agent
+func NewAgent(
+	cfg AgentConfig,
+	execRegistry *executor.ExecutorRegistry,
+	logger *zap.SugaredLogger,
+) *Agent {
+	if cfg.SyncInterval <= 0 {
+		cfg.SyncInterval = 30 * time.Second
+	}
+
+	return &Agent{
+		cfg:          cfg,
+		client:       &http.Client{Timeout: 30 * time.Second},
+		execRegistry: execRegistry,
+		// Agents have no event bus, running a tick does not publish anything
+		supervisor: healthcheck.NewHealthCheck(nil, execRegistry, logger),
+		logger:     logger.With("component", "probe_agent"),
+		checks:     make(map[string]*agentCheck),
+	}
+}
+
+// Start pulls the assigned checks and keeps them in sync with the API. It
+// fails when the first pull is rejected so a wrong token is noticed at once.
+func (a *Agent) Start(ctx context.Context) error {
+	a.logger.Infow("Starting probe agent", "api_url", a.cfg.APIURL)
+
+	ctx, cancel := context.WithCancel(ctx)
+	a.cancel = cancel
+
+	if err := a.sync(ctx); err != nil {
+		if errors.Is(err, ErrAgentUnauthorized) {
+			cancel()
+			return err
+		}
+		a.logger.Warnw("Failed to pull checks, retrying on the next sync", "error", err)
+	}
+
+	a.wg.Add(1)
+	go func() {
+		defer a.wg.Done()
+		ticker := time.NewTicker(a.cfg.SyncInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := a.sync(ctx); err != nil {
+					a.logger.Warnw("Failed to pull checks", "error", err)
+				}
+			}
+		}
+	}()
+
+	a.logger.Info("Probe agent started successfully")
+	return nil
+}
+
+// Stop stops every check and waits for the running ones to finish
+func (a *Agent) Stop() {
+	a.logger.Info("Stopping probe agent")
+	if a.cancel != nil {
+		a.cancel()
+	}
+	a.wg.Wait()
+	a.logger.Info("Probe agent stopped")
+}
+
+// sync schedules new and changed checks and stops the ones no longer assigned
+func (a *Agent) sync(ctx context.Context) error {
+	payloads, err := a.fetchChecks(ctx)
+	if err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	assigned := make(map[string]bool, len(payloads))
+	for _, payload := range payloads {
+		assigned[payload.MonitorID] = true
+
+		if existing, ok := a.checks[payload.MonitorID]; ok {
+			if sameCheck(existing.payload, payload) {
+				continue
+			}
+			existing.cancel()
+		}
+
+		checkCtx, cancel := context.WithCancel(ctx)
+		a.checks[payload.MonitorID] = &agentCheck{payload: payload, cancel: cancel}
+
+		a.wg.Add(1)
+		go a.runCheck(checkCtx, payload)
+	}
+
+	for monitorID, check := range a.checks {
+		if !assigned[monitorID] {
+			check.cancel()
+			delete(a.checks, monitorID)
+		}
+	}
+
+	a.logger.Debugw("Synced checks", "count", len(a.checks))
+	return nil
+}
+
+// runCheck runs a check on its interval until ctx is cancelled
+func (a *Agent) runCheck(ctx context.Context, payload HealthCheckTaskPayload) {
+	defer a.wg.Done()
+
+	interval := time.Duration(payload.Interval) * time.Second
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		a.tick(ctx, &payload)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// tick runs the check once and submits the result
+func (a *Agent) tick(ctx context.Context, payload *HealthCheckTaskPayload) {
+	m := payload.monitorModel()
+
+	exec, ok := a.execRegistry.GetExecutor(m.Type)
+	if !ok {
+		a.logger.Errorw("Executor not found for monitor type", "monitor_type", m.Type)
+		return
+	}
+
+	tickResult := a.supervisor.HandleMonitorTick(ctx, m, exec, payload.proxyModel(), payload.IsUnderMaintenance)
+	if tickResult == nil || ctx.Err() != nil {
+		return
+	}
+
+	metrics.ObserveHealthCheck(m.Type, tickResult.ExecutionResult.Status)
+
+	result := AgentResult{
+		MonitorID: m.ID,
+		Status:    tickResult.ExecutionResult.Status,
+		Message:   tickResult.ExecutionResult.Message,
+		PingMs:    tickResult.PingMs,
+		StartTime: tickResult.ExecutionResult.StartTime,
+		EndTime:   tickResult.ExecutionResult.EndTime,
+		TLSInfo:   tickResult.ExecutionResult.TLSInfo,
+	}
+	if err := a.submitResult(ctx, &result); err != nil {
+		a.logger.Errorw("Failed to submit check result",
+			"monitor_id", m.ID,
+			"monitor_name", m.Name,
+			"error", err,
+		)
+		return
+	}
+
+	a.logger.Debugw("Submitted check result",
+		"monitor_id", m.ID,
+		"monitor_name", m.Name,
+		"status", result.Status,
+		"ping_ms", result.PingMs,
+	)
+}
+
+func (a *Agent) fetchChecks(ctx context.Context) ([]HealthCheckTaskPayload, error) {
+	var response struct {
+		Data []HealthCheckTaskPayload `json:"data"`
+	}
+	if err := a.do(ctx, http.MethodGet, AgentChecksPath, nil, &response); err != nil {
+		return nil, err
+	}
+	return response.Data, nil
+}
+
+func (a *Agent) submitResult(ctx context.Context, result *AgentResult) error {
+	return a.do(ctx, http.MethodPost, AgentResultsPath, result, nil)
+}
+
+func (a *Agent) do(ctx context.Context, method string, path string, body interface{}, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimRight(a.cfg.APIURL, "/")+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set(AgentTokenHeader, a.cfg.Token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return ErrAgentUnauthorized
+	}
+	if resp.StatusCode >= 300 {
+		var failure struct {
+			Message string `json:"message"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&failure)
+		return fmt.Errorf("%s %s returned %d: %s", method, path, resp.StatusCode, failure.Message)
+	}
+
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// sameCheck reports whether two payloads run the same check. ScheduledAt
+// changes on every pull and does not count.
+func sameCheck(a, b HealthCheckTaskPayload) bool {
+	a.ScheduledAt = time.Time{}
+	b.ScheduledAt = time.Time{}
+	return reflect.DeepEqual(a, b)
+}
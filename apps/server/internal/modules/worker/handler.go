@@ -7,6 +7,7 @@ import (
 	"vigi/internal/modules/certificate"
 	"vigi/internal/modules/healthcheck"
 	"vigi/internal/modules/healthcheck/executor"
+	"vigi/internal/modules/metrics"
 	"vigi/internal/modules/monitor"
 	"vigi/internal/modules/proxy"
 	"vigi/internal/modules/queue"
@@ -74,6 +75,38 @@ type IngesterTaskPayload struct {
 	CheckCertExpiry    bool                 `json:"check_cert_expiry"`
 }
 
+// monitorModel creates the monitor model the executors run against
+func (p *HealthCheckTaskPayload) monitorModel() *monitor.Model {
+	return &monitor.Model{
+		ID:             p.MonitorID,
+		Type:           p.MonitorType,
+		Name:           p.MonitorName,
+		Interval:       p.Interval,
+		Timeout:        p.Timeout,
+		MaxRetries:     p.MaxRetries,
+		RetryInterval:  p.RetryInterval,
+		ResendInterval: p.ResendInterval,
+		Config:         p.Config,
+		LastHeartbeat:  p.LastHeartbeat,
+	}
+}
+
+// proxyModel creates the proxy model from the payload, nil when no proxy is configured
+func (p *HealthCheckTaskPayload) proxyModel() *proxy.Model {
+	if p.Proxy == nil {
+		return nil
+	}
+	return &proxy.Model{
+		ID:       p.Proxy.ID,
+		Protocol: p.Proxy.Protocol,
+		Host:     p.Proxy.Host,
+		Port:     p.Proxy.Port,
+		Auth:     p.Proxy.Auth,
+		Username: p.Proxy.Username,
+		Password: p.Proxy.Password,
+	}
+}
+
 // HealthCheckTaskHandler handles health check tasks from the queue
 type HealthCheckTaskHandler struct {
 	execRegistry       *executor.ExecutorRegistry
@@ -132,37 +165,13 @@ func (h *HealthCheckTaskHandler) ProcessTask(ctx context.Context, task *asynq.Ta
 			"stale_threshold", staleThreshold,
 			"interval", intervalDuration,
 		)
+		metrics.StaleChecksSkipped.Inc()
 		// Return nil to mark task as successfully processed (not retried)
 		return nil
 	}
 
-	// Create monitor model from payload
-	m := &monitor.Model{
-		ID:             payload.MonitorID,
-		Type:           payload.MonitorType,
-		Name:           payload.MonitorName,
-		Interval:       payload.Interval,
-		Timeout:        payload.Timeout,
-		MaxRetries:     payload.MaxRetries,
-		RetryInterval:  payload.RetryInterval,
-		ResendInterval: payload.ResendInterval,
-		Config:         payload.Config,
-		LastHeartbeat:  payload.LastHeartbeat,
-	}
-
-	// Create proxy model from payload if present
-	var proxyModel *proxy.Model = nil
-	if payload.Proxy != nil {
-		proxyModel = &proxy.Model{
-			ID:       payload.Proxy.ID,
-			Protocol: payload.Proxy.Protocol,
-			Host:     payload.Proxy.Host,
-			Port:     payload.Proxy.Port,
-			Auth:     payload.Proxy.Auth,
-			Username: payload.Proxy.Username,
-			Password: payload.Proxy.Password,
-		}
-	}
+	m := payload.monitorModel()
+	proxyModel := payload.proxyModel()
 
 	// Get the appropriate executor for this monitor type
 	exec, ok := h.execRegistry.GetExecutor(m.Type)
@@ -183,6 +192,8 @@ func (h *HealthCheckTaskHandler) ProcessTask(ctx context.Context, task *asynq.Ta
 		return nil
 	}
 
+	metrics.ObserveHealthCheck(m.Type, tickResult.ExecutionResult.Status)
+
 	h.logger.Debugw("Health check executed",
 		"monitor_id", payload.MonitorID,
 		"monitor_name", payload.MonitorName,
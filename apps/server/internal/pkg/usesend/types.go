@@ -17,11 +17,18 @@ const (
 )
 
 type SendEmailRequest struct {
-	To      string            `json:"to"`
-	From    string            `json:"from"`
-	Subject string            `json:"subject"`
-	HTML    string            `json:"html"`
-	Tags    map[string]string `json:"tags,omitempty"`
+	To          string            `json:"to"`
+	From        string            `json:"from"`
+	Subject     string            `json:"subject"`
+	HTML        string            `json:"html"`
+	Tags        map[string]string `json:"tags,omitempty"`
+	Attachments []Attachment      `json:"attachments,omitempty"`
+}
+
+// Attachment is a file sent along with an email. Content is base64 encoded.
+type Attachment struct {
+	Filename string `json:"filename"`
+	Content  string `json:"content"`
 }
 
 type SendEmailResponse struct {
@@ -0,0 +1,245 @@
+package secret
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// Mask replaces secrets in API responses. Sending it back on update keeps
+// the stored value.
+const Mask = "********"
+
+// EncryptFields encrypts the given string fields of a JSON object, nested ones
+// included. Documents that are not JSON objects are returned unchanged.
+func (k *Keyring) EncryptFields(document string, fields []string) (string, error) {
+	return transformFields(document, fields, k.Encrypt)
+}
+
+// DecryptFields reverses EncryptFields.
+func (k *Keyring) DecryptFields(document string, fields []string) (string, error) {
+	return transformFields(document, fields, k.Decrypt)
+}
+
+// RotateFields applies Rotate to the given fields and reports whether any
+// of them changed.
+func (k *Keyring) RotateFields(document string, fields []string) (string, bool, error) {
+	changed := false
+	rotated, err := transformFields(document, fields, func(value string) (string, error) {
+		rotated, ok, err := k.Rotate(value)
+		changed = changed || ok
+		return rotated, err
+	})
+	return rotated, changed, err
+}
+
+// Redact replaces a non empty secret with Mask.
+func Redact(value string) string {
+	if value == "" {
+		return value
+	}
+	return Mask
+}
+
+// RedactFields replaces the given fields of a JSON object with Mask.
+func RedactFields(document string, fields []string) string {
+	redacted, err := transformFields(document, fields, func(value string) (string, error) {
+		return Redact(value), nil
+	})
+	if err != nil {
+		return document
+	}
+	return redacted
+}
+
+// RestoreFields puts back the stored value of every field that the client
+// sent as Mask, so redacted documents can be saved without losing secrets.
+func RestoreFields(document string, stored string, fields []string) string {
+	previous, ok := decodeJSON(stored)
+	if !ok {
+		previous = map[string]any{}
+	}
+
+	restored, err := transformFieldsWithPath(document, fields, func(path []string, value string) (string, error) {
+		if value != Mask {
+			return value, nil
+		}
+		if original, ok := lookup(previous, path).(string); ok {
+			return original, nil
+		}
+		return "", nil
+	})
+	if err != nil {
+		return document
+	}
+	return restored
+}
+
+// Fields are keys of a JSON object. A dot reaches into nested objects, arrays
+// and strings holding JSON documents, and * stands for every element:
+// "sasl_options.password" is nested in an object and "steps.*.headers.*" is
+// every header of every step.
+
+func transformFields(document string, fields []string, fn func(string) (string, error)) (string, error) {
+	return transformFieldsWithPath(document, fields, func(_ []string, value string) (string, error) {
+		return fn(value)
+	})
+}
+
+// transformFieldsWithPath calls fn with the path and the value of every string
+// the fields match and stores the result in their place
+func transformFieldsWithPath(document string, fields []string, fn func(path []string, value string) (string, error)) (string, error) {
+	if document == "" {
+		return document, nil
+	}
+
+	root, ok := decodeJSON(document)
+	if !ok {
+		return document, nil
+	}
+	if _, isObject := root.(map[string]any); !isObject {
+		return document, nil
+	}
+
+	changed := false
+	for _, field := range fields {
+		transformed, fieldChanged, err := transformPath(root, strings.Split(field, "."), nil, fn)
+		if err != nil {
+			return "", err
+		}
+		root = transformed
+		changed = changed || fieldChanged
+	}
+
+	if !changed {
+		return document, nil
+	}
+	return encodeJSON(root)
+}
+
+func transformPath(node any, segments []string, path []string, fn func(path []string, value string) (string, error)) (any, bool, error) {
+	if len(segments) == 0 {
+		value, ok := node.(string)
+		if !ok {
+			return node, false, nil
+		}
+		transformed, err := fn(path, value)
+		if err != nil {
+			return nil, false, err
+		}
+		return transformed, transformed != value, nil
+	}
+
+	switch n := node.(type) {
+	case map[string]any:
+		keys := []string{segments[0]}
+		if segments[0] == "*" {
+			keys = make([]string, 0, len(n))
+			for key := range n {
+				keys = append(keys, key)
+			}
+		}
+
+		changed := false
+		for _, key := range keys {
+			child, ok := n[key]
+			if !ok {
+				continue
+			}
+			transformed, childChanged, err := transformPath(child, segments[1:], append(path[:len(path):len(path)], key), fn)
+			if err != nil {
+				return nil, false, err
+			}
+			if childChanged {
+				n[key] = transformed
+				changed = true
+			}
+		}
+		return n, changed, nil
+
+	case []any:
+		if segments[0] != "*" {
+			return node, false, nil
+		}
+
+		changed := false
+		for i, child := range n {
+			transformed, childChanged, err := transformPath(child, segments[1:], append(path[:len(path):len(path)], strconv.Itoa(i)), fn)
+			if err != nil {
+				return nil, false, err
+			}
+			if childChanged {
+				n[i] = transformed
+				changed = true
+			}
+		}
+		return n, changed, nil
+
+	case string:
+		// A JSON document stored as a string, like the headers of a request
+		nested, ok := decodeJSON(n)
+		if !ok {
+			return node, false, nil
+		}
+		transformed, changed, err := transformPath(nested, segments, path, fn)
+		if err != nil || !changed {
+			return node, false, err
+		}
+		encoded, err := encodeJSON(transformed)
+		if err != nil {
+			return nil, false, err
+		}
+		return encoded, true, nil
+	}
+
+	return node, false, nil
+}
+
+// lookup returns the value at path, as reported by transformFieldsWithPath
+func lookup(node any, path []string) any {
+	for _, segment := range path {
+		if s, ok := node.(string); ok {
+			if node, ok = decodeJSON(s); !ok {
+				return nil
+			}
+		}
+
+		switch n := node.(type) {
+		case map[string]any:
+			node = n[segment]
+		case []any:
+			i, err := strconv.Atoi(segment)
+			if err != nil || i < 0 || i >= len(n) {
+				return nil
+			}
+			node = n[i]
+		default:
+			return nil
+		}
+	}
+	return node
+}
+
+// decodeJSON decodes a JSON object or array, keeping numbers as written
+func decodeJSON(document string) (any, bool) {
+	decoder := json.NewDecoder(strings.NewReader(document))
+	decoder.UseNumber()
+
+	var value any
+	if err := decoder.Decode(&value); err != nil || decoder.More() {
+		return nil, false
+	}
+	switch value.(type) {
+	case map[string]any, []any:
+		return value, true
+	}
+	return nil, false
+}
+
+func encodeJSON(value any) (string, error) {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
@@ -0,0 +1,260 @@
+package secret
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Encrypted values look like enc:v1:<key id>:<wrapped data key>:<ciphertext>.
+// Every value is sealed with its own random data key, and only that data key
+// is sealed with the master key, so rotating the master key re-wraps the data
+// keys without touching the ciphertexts.
+const prefix = "enc:v1:"
+
+const dataKeySize = 32
+
+var (
+	ErrUnknownKey = errors.New("secret: value was encrypted with an unknown master key")
+	ErrMalformed  = errors.New("secret: malformed encrypted value")
+	ErrNoKey      = errors.New("secret: encrypted value found but no master key is configured")
+)
+
+type masterKey struct {
+	id   string
+	aead cipher.AEAD
+}
+
+// Keyring holds the current master key, used for encryption, and the
+// previous ones, still accepted for decryption until a rotation ran.
+// A keyring without a current key stores values in plain text.
+type Keyring struct {
+	current *masterKey
+	keys    map[string]*masterKey
+}
+
+// NewKeyring builds a keyring from base64 encoded 32 byte master keys.
+// An empty current key disables encryption.
+func NewKeyring(current string, previous []string) (*Keyring, error) {
+	k := &Keyring{keys: make(map[string]*masterKey)}
+
+	current = strings.TrimSpace(current)
+	if current == "" {
+		if len(previous) > 0 {
+			return nil, errors.New("secret: previous master keys given without a current one")
+		}
+		return k, nil
+	}
+
+	key, err := parseMasterKey(current)
+	if err != nil {
+		return nil, err
+	}
+	k.current = key
+	k.keys[key.id] = key
+
+	for _, encoded := range previous {
+		encoded = strings.TrimSpace(encoded)
+		if encoded == "" {
+			continue
+		}
+		key, err := parseMasterKey(encoded)
+		if err != nil {
+			return nil, err
+		}
+		k.keys[key.id] = key
+	}
+
+	return k, nil
+}
+
+func parseMasterKey(encoded string) (*masterKey, error) {
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("secret: master key is not valid base64: %w", err)
+	}
+	if len(raw) != 32 {
+		return nil, fmt.Errorf("secret: master key must be 32 bytes, got %d", len(raw))
+	}
+
+	aead, err := newAEAD(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(raw)
+	return &masterKey{id: hex.EncodeToString(sum[:4]), aead: aead}, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// Enabled reports whether a master key is configured.
+func (k *Keyring) Enabled() bool {
+	return k.current != nil
+}
+
+// IsEncrypted reports whether value was produced by Encrypt.
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, prefix)
+}
+
+// Encrypt seals value with a fresh data key. Empty and already encrypted
+// values are returned unchanged, as is everything when encryption is disabled.
+func (k *Keyring) Encrypt(value string) (string, error) {
+	if value == "" || IsEncrypted(value) || !k.Enabled() {
+		return value, nil
+	}
+
+	dataKey := make([]byte, dataKeySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return "", err
+	}
+
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return "", err
+	}
+
+	ciphertext, err := seal(aead, []byte(value), nil)
+	if err != nil {
+		return "", err
+	}
+	wrapped, err := seal(k.current.aead, dataKey, []byte(k.current.id))
+	if err != nil {
+		return "", err
+	}
+
+	return format(k.current.id, wrapped, ciphertext), nil
+}
+
+// Decrypt opens a value produced by Encrypt. Values without the encryption
+// prefix are stored in plain text and returned unchanged.
+func (k *Keyring) Decrypt(value string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+
+	keyID, wrapped, ciphertext, err := parse(value)
+	if err != nil {
+		return "", err
+	}
+
+	dataKey, err := k.unwrap(keyID, wrapped)
+	if err != nil {
+		return "", err
+	}
+
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return "", err
+	}
+
+	plaintext, err := open(aead, ciphertext, nil)
+	if err != nil {
+		return "", err
+	}
+
+	return string(plaintext), nil
+}
+
+// Rotate brings value up to date with the current master key: plain text is
+// encrypted and data keys wrapped by a previous master key are re-wrapped.
+// It reports whether the value changed.
+func (k *Keyring) Rotate(value string) (string, bool, error) {
+	if !k.Enabled() {
+		return value, false, ErrNoKey
+	}
+	if value == "" {
+		return value, false, nil
+	}
+	if !IsEncrypted(value) {
+		encrypted, err := k.Encrypt(value)
+		return encrypted, err == nil, err
+	}
+
+	keyID, wrapped, ciphertext, err := parse(value)
+	if err != nil {
+		return "", false, err
+	}
+	if keyID == k.current.id {
+		return value, false, nil
+	}
+
+	dataKey, err := k.unwrap(keyID, wrapped)
+	if err != nil {
+		return "", false, err
+	}
+	rewrapped, err := seal(k.current.aead, dataKey, []byte(k.current.id))
+	if err != nil {
+		return "", false, err
+	}
+
+	return format(k.current.id, rewrapped, ciphertext), true, nil
+}
+
+func (k *Keyring) unwrap(keyID string, wrapped []byte) ([]byte, error) {
+	if len(k.keys) == 0 {
+		return nil, ErrNoKey
+	}
+	key, ok := k.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownKey, keyID)
+	}
+	return open(key.aead, wrapped, []byte(keyID))
+}
+
+func seal(aead cipher.AEAD, plaintext, additionalData []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+func open(aead cipher.AEAD, sealed, additionalData []byte) ([]byte, error) {
+	if len(sealed) < aead.NonceSize() {
+		return nil, ErrMalformed
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, additionalData)
+	if err != nil {
+		return nil, fmt.Errorf("secret: failed to decrypt value: %w", err)
+	}
+	return plaintext, nil
+}
+
+func format(keyID string, wrapped, ciphertext []byte) string {
+	return prefix + keyID + ":" +
+		base64.RawURLEncoding.EncodeToString(wrapped) + ":" +
+		base64.RawURLEncoding.EncodeToString(ciphertext)
+}
+
+func parse(value string) (string, []byte, []byte, error) {
+	parts := strings.Split(strings.TrimPrefix(value, prefix), ":")
+	if len(parts) != 3 {
+		return "", nil, nil, ErrMalformed
+	}
+
+	wrapped, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", nil, nil, ErrMalformed
+	}
+	ciphertext, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return "", nil, nil, ErrMalformed
+	}
+
+	return parts[0], wrapped, ciphertext, nil
+}
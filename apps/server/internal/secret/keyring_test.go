@@ -0,0 +1,235 @@
+package secret
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestKey(t *testing.T) string {
+	t.Helper()
+	raw := make([]byte, 32)
+	_, err := rand.Read(raw)
+	require.NoError(t, err)
+	return base64.StdEncoding.EncodeToString(raw)
+}
+
+func TestNewKeyring(t *testing.T) {
+	key := newTestKey(t)
+
+	tests := []struct {
+		name          string
+		current       string
+		previous      []string
+		expectEnabled bool
+		expectError   bool
+	}{
+		{name: "disabled", current: "", expectEnabled: false},
+		{name: "current key", current: key, expectEnabled: true},
+		{name: "current and previous keys", current: key, previous: []string{newTestKey(t), " "}, expectEnabled: true},
+		{name: "previous keys only", current: "", previous: []string{key}, expectError: true},
+		{name: "not base64", current: "not a key!", expectError: true},
+		{name: "wrong size", current: base64.StdEncoding.EncodeToString([]byte("too short")), expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keyring, err := NewKeyring(tt.current, tt.previous)
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectEnabled, keyring.Enabled())
+		})
+	}
+}
+
+func TestKeyring_EncryptDecrypt(t *testing.T) {
+	keyring, err := NewKeyring(newTestKey(t), nil)
+	require.NoError(t, err)
+
+	encrypted, err := keyring.Encrypt("hunter2")
+	require.NoError(t, err)
+	assert.True(t, IsEncrypted(encrypted))
+	assert.NotContains(t, encrypted, "hunter2")
+
+	again, err := keyring.Encrypt("hunter2")
+	require.NoError(t, err)
+	assert.NotEqual(t, encrypted, again, "every value gets its own data key and nonce")
+
+	decrypted, err := keyring.Decrypt(encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, "hunter2", decrypted)
+
+	t.Run("encrypting twice is a no-op", func(t *testing.T) {
+		twice, err := keyring.Encrypt(encrypted)
+		require.NoError(t, err)
+		assert.Equal(t, encrypted, twice)
+	})
+
+	t.Run("empty values stay empty", func(t *testing.T) {
+		empty, err := keyring.Encrypt("")
+		require.NoError(t, err)
+		assert.Equal(t, "", empty)
+	})
+
+	t.Run("plain text is returned as is", func(t *testing.T) {
+		plain, err := keyring.Decrypt("legacy-password")
+		require.NoError(t, err)
+		assert.Equal(t, "legacy-password", plain)
+	})
+
+	t.Run("tampered ciphertext", func(t *testing.T) {
+		tampered := encrypted[:len(encrypted)-2] + "AA"
+		if tampered == encrypted {
+			tampered = encrypted[:len(encrypted)-2] + "BB"
+		}
+		_, err := keyring.Decrypt(tampered)
+		assert.Error(t, err)
+	})
+
+	t.Run("malformed value", func(t *testing.T) {
+		_, err := keyring.Decrypt("enc:v1:abc")
+		assert.ErrorIs(t, err, ErrMalformed)
+	})
+
+	t.Run("unknown master key", func(t *testing.T) {
+		other, err := NewKeyring(newTestKey(t), nil)
+		require.NoError(t, err)
+		_, err = other.Decrypt(encrypted)
+		assert.ErrorIs(t, err, ErrUnknownKey)
+	})
+
+	t.Run("disabled keyring", func(t *testing.T) {
+		disabled, err := NewKeyring("", nil)
+		require.NoError(t, err)
+
+		plain, err := disabled.Encrypt("hunter2")
+		require.NoError(t, err)
+		assert.Equal(t, "hunter2", plain)
+
+		_, err = disabled.Decrypt(encrypted)
+		assert.ErrorIs(t, err, ErrNoKey)
+	})
+}
+
+func TestKeyring_Rotate(t *testing.T) {
+	oldKey, newKey := newTestKey(t), newTestKey(t)
+
+	before, err := NewKeyring(oldKey, nil)
+	require.NoError(t, err)
+	after, err := NewKeyring(newKey, []string{oldKey})
+	require.NoError(t, err)
+
+	sealed, err := before.Encrypt("hunter2")
+	require.NoError(t, err)
+
+	rotated, changed, err := after.Rotate(sealed)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.NotEqual(t, sealed, rotated)
+	assert.Equal(t, strings.Split(sealed, ":")[4], strings.Split(rotated, ":")[4], "the ciphertext is kept, only the data key is re-wrapped")
+
+	// The new key alone opens rotated values
+	newOnly, err := NewKeyring(newKey, nil)
+	require.NoError(t, err)
+	decrypted, err := newOnly.Decrypt(rotated)
+	require.NoError(t, err)
+	assert.Equal(t, "hunter2", decrypted)
+
+	t.Run("up to date values are left alone", func(t *testing.T) {
+		_, changed, err := after.Rotate(rotated)
+		require.NoError(t, err)
+		assert.False(t, changed)
+	})
+
+	t.Run("plain text gets encrypted", func(t *testing.T) {
+		encrypted, changed, err := after.Rotate("legacy-password")
+		require.NoError(t, err)
+		assert.True(t, changed)
+		assert.True(t, IsEncrypted(encrypted))
+	})
+}
+
+func TestKeyring_Fields(t *testing.T) {
+	keyring, err := NewKeyring(newTestKey(t), nil)
+	require.NoError(t, err)
+
+	fields := []string{"basic_auth_pass", "tls_key"}
+	document := `{"url":"https://example.com","basic_auth_user":"admin","basic_auth_pass":"hunter2","max_redirects":10}`
+
+	encrypted, err := keyring.EncryptFields(document, fields)
+	require.NoError(t, err)
+	assert.NotContains(t, encrypted, "hunter2")
+
+	var object map[string]any
+	require.NoError(t, json.Unmarshal([]byte(encrypted), &object))
+	assert.Equal(t, "admin", object["basic_auth_user"])
+	assert.Equal(t, float64(10), object["max_redirects"])
+	assert.True(t, IsEncrypted(object["basic_auth_pass"].(string)))
+
+	decrypted, err := keyring.DecryptFields(encrypted, fields)
+	require.NoError(t, err)
+	assert.JSONEq(t, document, decrypted)
+
+	t.Run("not a JSON object", func(t *testing.T) {
+		for _, document := range []string{"", "not json", `["a"]`} {
+			result, err := keyring.EncryptFields(document, fields)
+			require.NoError(t, err)
+			assert.Equal(t, document, result)
+		}
+	})
+
+	t.Run("redact", func(t *testing.T) {
+		redacted := RedactFields(decrypted, fields)
+		assert.JSONEq(t, `{"url":"https://example.com","basic_auth_user":"admin","basic_auth_pass":"********","max_redirects":10}`, redacted)
+	})
+
+	t.Run("restore", func(t *testing.T) {
+		sent := `{"url":"https://example.org","basic_auth_pass":"********","tls_key":"********"}`
+		restored := RestoreFields(sent, decrypted, fields)
+		assert.JSONEq(t, `{"url":"https://example.org","basic_auth_pass":"hunter2","tls_key":""}`, restored)
+
+		changed := RestoreFields(`{"basic_auth_pass":"new-password"}`, decrypted, fields)
+		assert.JSONEq(t, `{"basic_auth_pass":"new-password"}`, changed)
+	})
+}
+
+func TestKeyring_NestedFields(t *testing.T) {
+	keyring, err := NewKeyring(newTestKey(t), nil)
+	require.NoError(t, err)
+
+	fields := []string{"sasl_options.password", "steps.*.headers.*"}
+	document := `{"sasl_options":{"username":"kafka","password":"hunter2"},"steps":[` +
+		`{"name":"login","headers":"{\"Authorization\":\"Bearer abc\"}","timeout":1.50},` +
+		`{"name":"list"}]}`
+
+	encrypted, err := keyring.EncryptFields(document, fields)
+	require.NoError(t, err)
+	assert.NotContains(t, encrypted, "hunter2")
+	assert.NotContains(t, encrypted, "Bearer abc")
+	assert.Contains(t, encrypted, `"timeout":1.50`)
+
+	decrypted, err := keyring.DecryptFields(encrypted, fields)
+	require.NoError(t, err)
+	assert.JSONEq(t, document, decrypted)
+
+	redacted := RedactFields(decrypted, fields)
+	assert.JSONEq(t, `{"sasl_options":{"username":"kafka","password":"********"},"steps":[`+
+		`{"name":"login","headers":"{\"Authorization\":\"********\"}","timeout":1.50},`+
+		`{"name":"list"}]}`, redacted)
+
+	t.Run("restore", func(t *testing.T) {
+		sent := `{"sasl_options":{"password":"********"},"steps":[` +
+			`{"headers":"{\"Authorization\":\"********\",\"X-Trace\":\"********\"}"}]}`
+		restored := RestoreFields(sent, decrypted, fields)
+		assert.JSONEq(t, `{"sasl_options":{"password":"hunter2"},"steps":[`+
+			`{"headers":"{\"Authorization\":\"Bearer abc\",\"X-Trace\":\"\"}"}]}`, restored)
+	})
+}
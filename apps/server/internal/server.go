@@ -2,6 +2,7 @@ package internal
 
 import (
 	"net/http"
+	"strings"
 	_ "vigi/docs"
 	"vigi/internal/config"
 	"vigi/internal/modules/api_key"
@@ -11,24 +12,36 @@ import (
 	"vigi/internal/modules/badge"
 	"vigi/internal/modules/catalog_item"
 	"vigi/internal/modules/client"
+	"vigi/internal/modules/dunning"
+	"vigi/internal/modules/escalation"
 	"vigi/internal/modules/healthcheck"
 	"vigi/internal/modules/heartbeat"
+	"vigi/internal/modules/incident"
 	"vigi/internal/modules/inter"
 	"vigi/internal/modules/invoice"
 	"vigi/internal/modules/maintenance"
+	"vigi/internal/modules/metrics"
 	"vigi/internal/modules/middleware"
 	"vigi/internal/modules/monitor"
+	"vigi/internal/modules/monitor_group"
 	"vigi/internal/modules/notification_channel"
+	"vigi/internal/modules/notification_delivery"
 	"vigi/internal/modules/organization"
 	"vigi/internal/modules/payment"
+	"vigi/internal/modules/probe"
+	"vigi/internal/modules/probe_agent"
 	"vigi/internal/modules/proxy"
 	"vigi/internal/modules/queue"
 	"vigi/internal/modules/recurring_invoice"
+	"vigi/internal/modules/retention"
 	"vigi/internal/modules/setting"
+	"vigi/internal/modules/sso"
 	"vigi/internal/modules/status_page"
+	"vigi/internal/modules/status_page_subscriber"
 	"vigi/internal/modules/storage"
 	"vigi/internal/modules/tag"
 	"vigi/internal/modules/websocket"
+	"vigi/internal/secret"
 	"vigi/internal/version"
 
 	"github.com/gin-contrib/cors"
@@ -60,6 +73,18 @@ func healthHandler(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, gin.H{"status": "success"})
 }
 
+// trustedProxies splits the comma separated TRUSTED_PROXIES setting. An empty
+// list makes gin use the address of the connection as client IP.
+func trustedProxies(value string) []string {
+	proxies := []string{}
+	for _, proxy := range strings.Split(value, ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			proxies = append(proxies, proxy)
+		}
+	}
+	return proxies
+}
+
 type Server struct {
 	Router *gin.Engine
 	Cfg    *config.Config
@@ -72,9 +97,16 @@ func ProvideServer(
 	monitorController *monitor.MonitorController,
 	authRoute *auth.Route,
 	authController *auth.Controller,
+	ssoRoute *sso.Route,
 	wsServer *websocket.Server,
 	notificationChannelRoute *notification_channel.Route,
 	notificationChannelController *notification_channel.Controller,
+	notificationDeliveryRoute *notification_delivery.Route,
+	notificationDeliveryController *notification_delivery.Controller,
+	escalationRoute *escalation.Route,
+	escalationController *escalation.Controller,
+	retentionRoute *retention.Route,
+	retentionController *retention.Controller,
 	proxyRoute *proxy.Route,
 	proxyController *proxy.Controller,
 	settingRoute *setting.Route,
@@ -86,8 +118,18 @@ func ProvideServer(
 	maintenanceController *maintenance.Controller,
 	statusPageRoute *status_page.Route,
 	statusPageController *status_page.Controller,
+	incidentRoute *incident.Route,
+	incidentController *incident.Controller,
+	statusPageSubscriberRoute *status_page_subscriber.Route,
+	statusPageSubscriberController *status_page_subscriber.Controller,
 	tagRoute *tag.Route,
 	tagController *tag.Controller,
+	monitorGroupRoute *monitor_group.Route,
+	monitorGroupController *monitor_group.Controller,
+	probeRoute *probe.Route,
+	probeController *probe.Controller,
+	probeAgentRoute *probe_agent.Route,
+	probeAgentController *probe_agent.Controller,
 	badgeRoute *badge.Route,
 	badgeController *badge.Controller,
 	apiKeyRoute *api_key.Route,
@@ -103,23 +145,29 @@ func ProvideServer(
 	invoiceRoute *invoice.Route,
 	interRoute *inter.Route,
 	recurringInvoiceRoute *recurring_invoice.Route,
+	dunningRoute *dunning.Route,
 	// Dependencies for Asaas
 	db *bun.DB,
 	invoiceService *invoice.Service,
 	clientService *client.Service,
 	organizationRepo organization.OrganizationRepository, // Added dependency for PaymentService
+	orgMiddleware *organization.Middleware,
 	interService *inter.Service, // Added dependency for PaymentService
+	keyring *secret.Keyring,
 ) *Server {
 	// Asaas Module
-	asaasRepo := asaas.NewRepository(db)
+	asaasRepo := asaas.NewRepository(db, keyring)
 	asaasService := asaas.NewService(asaasRepo, invoiceService, clientService, logger)
 	asaasController := asaas.NewController(asaasService, logger)
-	asaasRoute := asaas.NewRoute(asaasController, authChain)
+	asaasRoute := asaas.NewRoute(asaasController, authChain, orgMiddleware)
 
 	// Payment Module (Unified Charge Generation)
 	// We need config to check enabled providers if needed, or organization repo to check org settings
 	// paymentRepo? No repo for logical service.
-	paymentService := payment.NewService(organizationRepo, invoiceService, interService, asaasService, logger)
+	payment.RegisterPaymentProvider(inter.ProviderName, inter.NewProvider(interService))
+	payment.RegisterPaymentProvider(asaas.ProviderName, asaas.NewProvider(asaasService))
+	paymentWebhookRepo := payment.NewSQLWebhookRepository(db)
+	paymentService := payment.NewService(organizationRepo, invoiceService, paymentWebhookRepo, logger)
 	paymentController := payment.NewController(paymentService, logger)
 	// Initialize server based on mode
 	var server *gin.Engine
@@ -135,6 +183,12 @@ func ProvideServer(
 
 	server.RedirectTrailingSlash = false
 
+	// Gin trusts forwarded headers from any peer by default, which lets clients spoof
+	// the IP seen by API key allowlists and bruteforce protection
+	if err := server.SetTrustedProxies(trustedProxies(cfg.TrustedProxies)); err != nil {
+		logger.Fatalw("Invalid TRUSTED_PROXIES", "error", err)
+	}
+
 	// CORS configuration
 	server.Use(cors.New(cors.Config{
 		AllowOrigins:     []string{"*"},
@@ -147,6 +201,10 @@ func ProvideServer(
 	// server.Use(LogMiddleware(logger))
 
 	server.GET("/health", healthHandler)
+	// The API is public, so its metrics are only served to scrapers holding the token
+	if cfg.MetricsToken != "" {
+		server.GET("/metrics", metrics.GinHandler(cfg.MetricsToken))
+	}
 	router := server.Group("/api/v1")
 	router.GET("/health", healthHandler)
 	router.GET("/version", versionHandler)
@@ -154,29 +212,39 @@ func ProvideServer(
 	// Connect routes
 	monitorRoute.ConnectRoute(router, monitorController)
 	authRoute.ConnectRoute(router, authController)
+	ssoRoute.ConnectRoute(router)
 	notificationChannelRoute.ConnectRoute(router, notificationChannelController)
+	notificationDeliveryRoute.ConnectRoute(router, notificationDeliveryController)
+	escalationRoute.ConnectRoute(router, escalationController)
+	retentionRoute.ConnectRoute(router, retentionController)
 	proxyRoute.ConnectRoute(router, proxyController)
 	settingRoute.ConnectRoute(router, settingController)
 	maintenanceRoute.ConnectRoute(router, maintenanceController)
 	statusPageRoute.ConnectRoute(router, statusPageController)
+	incidentRoute.ConnectRoute(router, incidentController)
+	statusPageSubscriberRoute.ConnectRoute(router, statusPageSubscriberController)
 	tagRoute.ConnectRoute(router, tagController)
+	monitorGroupRoute.ConnectRoute(router, monitorGroupController)
+	probeRoute.ConnectRoute(router, probeController)
+	probeAgentRoute.ConnectRoute(router, probeAgentController)
 	badgeRoute.ConnectRoute(router, badgeController)
-	apiKeyRoute.ConnectRoute(router, apiKeyController)
+	apiKeyRoute.ConnectRoute(router, apiKeyController, orgMiddleware)
 	// Invoice routes MUST be registered before organization routes
 	// to avoid /organizations/:id matching /invoices path segments
 	invoiceRoute.ConnectRoute(router, authChain)
 	recurringInvoiceRoute.ConnectRoute(router, authChain)
+	dunningRoute.ConnectRoute(router, authChain)
 	catalogItemRoute.ConnectRoute(router, authChain)
 	clientRoute.ConnectRoute(router)
 	organizationRoute.ConnectRoute(router)
 	interRoute.ConnectRoute(router)
 	asaasRoute.ConnectRoute(router)
-	paymentController.RegisterRoutes(router, authChain)
+	paymentController.RegisterRoutes(router, authChain, orgMiddleware)
 	backofficeRoute.ConnectRoute(router, backofficeController)
 	storageRoute.Register(router)
 
 	// Register push endpoint
-	healthcheck.RegisterPushEndpoint(router, monitorService, heartbeatService, queueService, logger)
+	healthcheck.RegisterPushEndpoint(router, monitorService, heartbeatService, queueService, authChain, orgMiddleware, logger)
 
 	// Swagger routes
 	url := ginSwagger.URL("/swagger/doc.json")
@@ -3,6 +3,7 @@ import { client } from "./client.gen";
 export interface AsaasConfig {
     apiKey?: string;
     environment?: string;
+    webhookToken?: string;
     // other fields if needed
 }
 
@@ -0,0 +1,17 @@
+import { client } from './client.gen';
+
+export const requestPasswordReset = async (email: string) => {
+    await client.post({ url: '/auth/password/forgot', body: { email }, throwOnError: true });
+};
+
+export const resetPassword = async (token: string, newPassword: string) => {
+    await client.post({ url: '/auth/password/reset', body: { token, newPassword }, throwOnError: true });
+};
+
+export const verifyEmail = async (token: string) => {
+    await client.post({ url: '/auth/email/verify', body: { token }, throwOnError: true });
+};
+
+export const resendEmailVerification = async () => {
+    await client.post({ url: '/auth/email/verification', body: {}, throwOnError: true });
+};
@@ -120,6 +120,11 @@ export const cloneInvoice = async (invoiceId: string) => {
     return mutationCall<Invoice>(client.post({ url: `/invoices/${invoiceId}/clone`, body: {} }));
 };
 
+export const downloadInvoicePdf = async (invoiceId: string) => {
+    const res = await client.get({ url: `/invoices/${invoiceId}/pdf`, responseType: 'blob', throwOnError: true });
+    return res.data as unknown as Blob;
+};
+
 export const getPublicInvoiceOptions = (id: string, enabled = true) => queryOptions({
     queryKey: ['public-invoice', id],
     queryFn: async () => {
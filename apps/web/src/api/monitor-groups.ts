@@ -0,0 +1,75 @@
+import { client } from './client.gen';
+
+export type MonitorGroupPolicy = 'all_up' | 'any_down' | 'quorum';
+
+export type MonitorGroupModel = {
+    id: string;
+    org_id: string;
+    parent_id?: string;
+    name: string;
+    description: string;
+    policy: MonitorGroupPolicy;
+    quorum: number;
+    status: number;
+    monitor_ids: string[];
+    notification_ids: string[];
+    created_at: string;
+    updated_at: string;
+};
+
+export type MonitorGroupDTO = {
+    name: string;
+    description?: string;
+    parent_id?: string;
+    policy: MonitorGroupPolicy;
+    quorum?: number;
+    monitor_ids?: string[];
+    notification_ids?: string[];
+};
+
+export type PublicMonitorGroup = {
+    id: string;
+    name: string;
+    description: string;
+    status: number;
+    monitor_ids: string[];
+};
+
+export const monitorGroupsQueryKey = ['monitorGroups'] as const;
+
+export const getMonitorGroups = async (query: { q?: string; page?: number; limit?: number } = {}) => {
+    const { data } = await client.get<{ 200: { data?: MonitorGroupModel[] } }>({
+        url: '/monitor-groups',
+        query,
+        throwOnError: true,
+    });
+    return data.data || [];
+};
+
+export const getMonitorGroup = async (id: string) => {
+    const { data } = await client.get<{ 200: { data?: MonitorGroupModel } }>({
+        url: `/monitor-groups/${id}`,
+        throwOnError: true,
+    });
+    return data.data;
+};
+
+export const createMonitorGroup = async (body: MonitorGroupDTO) => {
+    await client.post({ url: '/monitor-groups', body, throwOnError: true });
+};
+
+export const updateMonitorGroup = async (id: string, body: MonitorGroupDTO) => {
+    await client.put({ url: `/monitor-groups/${id}`, body, throwOnError: true });
+};
+
+export const deleteMonitorGroup = async (id: string) => {
+    await client.delete({ url: `/monitor-groups/${id}`, throwOnError: true });
+};
+
+export const getStatusPageGroups = async (slug: string) => {
+    const { data } = await client.get<{ 200: { data?: PublicMonitorGroup[] } }>({
+        url: `/status-pages/slug/${slug}/groups`,
+        throwOnError: true,
+    });
+    return data.data || [];
+};
@@ -0,0 +1,64 @@
+import { client } from './client.gen';
+
+export type ProbeModel = {
+    id: string;
+    org_id: string;
+    name: string;
+    description: string;
+    last_seen_at?: string;
+    created_at: string;
+    updated_at: string;
+};
+
+export type ProbeWithToken = ProbeModel & {
+    token: string;
+};
+
+export type ProbeDTO = {
+    name: string;
+    description?: string;
+};
+
+export const probesQueryKey = ['probes'] as const;
+
+export const getProbes = async (query: { q?: string; page?: number; limit?: number } = {}) => {
+    const { data } = await client.get<{ 200: { data?: ProbeModel[] } }>({
+        url: '/probes',
+        query,
+        throwOnError: true,
+    });
+    return data.data || [];
+};
+
+export const getProbe = async (id: string) => {
+    const { data } = await client.get<{ 200: { data?: ProbeModel } }>({
+        url: `/probes/${id}`,
+        throwOnError: true,
+    });
+    return data.data;
+};
+
+export const createProbe = async (body: ProbeDTO) => {
+    const { data } = await client.post<{ 201: { data?: ProbeWithToken } }>({
+        url: '/probes',
+        body,
+        throwOnError: true,
+    });
+    return data.data;
+};
+
+export const updateProbe = async (id: string, body: ProbeDTO) => {
+    await client.put({ url: `/probes/${id}`, body, throwOnError: true });
+};
+
+export const deleteProbe = async (id: string) => {
+    await client.delete({ url: `/probes/${id}`, throwOnError: true });
+};
+
+export const regenerateProbeToken = async (id: string) => {
+    const { data } = await client.post<{ 200: { data?: ProbeWithToken } }>({
+        url: `/probes/${id}/token`,
+        throwOnError: true,
+    });
+    return data.data;
+};
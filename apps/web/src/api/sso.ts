@@ -0,0 +1,17 @@
+import { client } from "./client.gen";
+import { getConfig } from "@/lib/config";
+
+export type SsoConfig = {
+  enabled: boolean;
+  providerName: string;
+  passwordLoginEnabled: boolean;
+};
+
+export const getSsoConfig = () => {
+  return client.get<{ 200: { data?: SsoConfig } }>({ url: '/auth/oidc/config' });
+};
+
+// The login is a full page redirect through the identity provider
+export const getSsoLoginUrl = () => {
+  return getConfig().API_URL + "/api/v1/auth/oidc/login";
+};
@@ -0,0 +1,21 @@
+import { client } from './client.gen';
+
+export type StatusPageSubscriptionType = 'email' | 'webhook';
+
+export type SubscribeToStatusPageDTO = {
+    type: StatusPageSubscriptionType;
+    email?: string;
+    webhook_url?: string;
+};
+
+export const subscribeToStatusPage = async (slug: string, body: SubscribeToStatusPageDTO) => {
+    await client.post({ url: `/status-page-subscribers/slug/${slug}`, body, throwOnError: true });
+};
+
+export const confirmStatusPageSubscription = async (token: string) => {
+    await client.post({ url: `/status-page-subscribers/confirm/${token}`, body: {}, throwOnError: true });
+};
+
+export const unsubscribeFromStatusPage = async (token: string) => {
+    await client.post({ url: `/status-page-subscribers/unsubscribe/${token}`, body: {}, throwOnError: true });
+};
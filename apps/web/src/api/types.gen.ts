@@ -2,9 +2,11 @@
 
 export type ApiKeyApiKeyConfigResponse = {
     prefix: string;
+    scopes: Array<string>;
 };
 
 export type ApiKeyApiKeyResponse = {
+    allowed_ips: Array<string>;
     created_at: string;
     /**
      * Masked key for display
@@ -13,13 +15,19 @@ export type ApiKeyApiKeyResponse = {
     expires_at?: string;
     id: string;
     last_used?: string;
+    last_used_ip?: string;
+    last_used_route?: string;
     max_usage_count?: number;
     name: string;
+    org_id: string;
+    scopes: Array<string>;
     updated_at: string;
     usage_count: number;
+    user_id: string;
 };
 
 export type ApiKeyApiKeyWithTokenResponse = {
+    allowed_ips: Array<string>;
     created_at: string;
     /**
      * Masked key for display
@@ -28,23 +36,32 @@ export type ApiKeyApiKeyWithTokenResponse = {
     expires_at?: string;
     id: string;
     last_used?: string;
+    last_used_ip?: string;
+    last_used_route?: string;
     max_usage_count?: number;
     name: string;
+    org_id: string;
+    scopes: Array<string>;
     token: string;
     updated_at: string;
     usage_count: number;
+    user_id: string;
 };
 
 export type ApiKeyCreateApiKeyDto = {
+    allowed_ips?: Array<string>;
     expires_at?: string;
     max_usage_count?: number;
     name: string;
+    scopes: Array<string>;
 };
 
 export type ApiKeyUpdateApiKeyDto = {
+    allowed_ips?: Array<string>;
     expires_at?: string;
     max_usage_count?: number;
     name?: string;
+    scopes?: Array<string>;
 };
 
 export type BackofficeStatsDto = {
@@ -103,6 +120,7 @@ export type AuthModel = {
     active?: boolean;
     createdAt?: string;
     email?: string;
+    emailVerified?: boolean;
     id?: string;
     imageUrl?: string;
     name?: string;
@@ -175,18 +193,20 @@ export type HeartbeatModel = {
     down_count?: number;
     duration?: number;
     end_time?: string;
+    flapping?: boolean;
     id?: string;
     important?: boolean;
     monitor_id?: string;
     msg?: string;
     notified?: boolean;
     ping?: number;
+    probe_id?: string;
     retries?: number;
     status?: SharedMonitorStatus;
     time?: string;
 };
 
-export type HeartbeatMonitorStatus = 0 | 1 | 2 | 3;
+export type HeartbeatMonitorStatus = 0 | 1 | 2 | 3 | 4;
 
 export type MaintenanceCreateUpdateDto = {
     active?: boolean;
@@ -196,6 +216,7 @@ export type MaintenanceCreateUpdateDto = {
     duration?: number;
     end_date_time?: string;
     end_time?: string;
+    group_ids?: Array<string>;
     interval_day?: number;
     monitor_ids?: Array<string>;
     org_id?: string;
@@ -216,6 +237,7 @@ export type MaintenanceMaintenanceResponseDto = {
     duration?: number;
     end_date_time?: string;
     end_time?: string;
+    group_ids?: Array<string>;
     id?: string;
     interval_day?: number;
     monitor_ids?: Array<string>;
@@ -237,6 +259,7 @@ export type MaintenanceModel = {
     duration?: number;
     end_date_time?: string;
     end_time?: string;
+    group_ids?: Array<string>;
     id?: string;
     interval_day?: number;
     org_id?: string;
@@ -257,6 +280,7 @@ export type MaintenancePartialUpdateDto = {
     duration?: number;
     end_date_time?: string;
     end_time?: string;
+    group_ids?: Array<string>;
     interval_day?: number;
     monitor_ids?: Array<string>;
     org_id?: string;
@@ -275,6 +299,9 @@ export type MonitorCreateUpdateDto = {
     max_retries?: number;
     name: string;
     notification_ids?: Array<string>;
+    parent_ids?: Array<string>;
+    probe_down_threshold?: number;
+    probe_ids?: Array<string>;
     proxy_id?: string;
     push_token?: string;
     resend_interval?: number;
@@ -347,6 +374,9 @@ export type MonitorMonitorResponseDto = {
     max_retries?: number;
     name?: string;
     notification_ids?: Array<string>;
+    parent_ids?: Array<string>;
+    probe_down_threshold?: number;
+    probe_ids?: Array<string>;
     proxy_id?: string;
     push_token?: string;
     resend_interval?: number;
@@ -366,6 +396,9 @@ export type MonitorPartialUpdateDto = {
     name?: string;
     notification_ids?: Array<string>;
     org_id?: string;
+    parent_ids?: Array<string>;
+    probe_down_threshold?: number;
+    probe_ids?: Array<string>;
     proxy_id?: string;
     push_token?: string;
     resend_interval?: number;
@@ -377,6 +410,7 @@ export type MonitorPartialUpdateDto = {
 };
 
 export type MonitorStatPoint = {
+    degraded?: number;
     down?: number;
     maintenance?: number;
     ping?: number;
@@ -391,6 +425,7 @@ export type MonitorStatPoint = {
  */
 export type MonitorStatPointsSummaryDto = {
     avgPing?: number;
+    degraded?: number;
     maxPing?: number;
     minPing?: number;
     points?: Array<MonitorStatPoint>;
@@ -534,12 +569,14 @@ export type SharedHeartBeatModel = {
     down_count?: number;
     duration?: number;
     end_time?: string;
+    flapping?: boolean;
     id?: string;
     important?: boolean;
     monitor_id?: string;
     msg?: string;
     notified?: boolean;
     ping?: number;
+    probe_id?: string;
     retries?: number;
     status?: SharedMonitorStatus;
     time?: string;
@@ -548,12 +585,14 @@ export type SharedHeartBeatModel = {
 export type SharedMonitorStatus = 0 | 1 | 2 | 3;
 
 export type StatusPageCreateStatusPageDto = {
+    auto_incidents?: boolean;
     auto_refresh_interval?: number;
     custom_css?: string;
     description?: string;
     domains?: Array<string>;
     footer_text?: string;
     google_analytics_tag_id?: string;
+    group_ids?: Array<string>;
     icon?: string;
     monitor_ids?: Array<string>;
     password?: string;
@@ -568,10 +607,12 @@ export type StatusPageCreateStatusPageDto = {
 };
 
 export type StatusPageModel = {
+    auto_incidents?: boolean;
     auto_refresh_interval?: number;
     created_at?: string;
     description?: string;
     footer_text?: string;
+    group_ids?: Array<string>;
     icon?: string;
     id?: string;
     org_id?: string;
@@ -586,6 +627,7 @@ export type StatusPageMonitorWithHeartbeatsAndUptimeDto = {
     active?: boolean;
     heartbeats?: Array<StatusPagePublicHeartbeatDto>;
     id?: string;
+    incidents?: Array<StatusPagePublicIncidentDto>;
     name?: string;
     type: string;
     uptime_24h?: number;
@@ -599,7 +641,24 @@ export type StatusPagePublicHeartbeatDto = {
     time?: string;
 };
 
+export type StatusPagePublicIncidentDto = {
+    id?: string;
+    resolved_at?: string;
+    severity?: string;
+    started_at?: string;
+    state?: string;
+    title?: string;
+    updates?: Array<StatusPagePublicIncidentUpdateDto>;
+};
+
+export type StatusPagePublicIncidentUpdateDto = {
+    created_at?: string;
+    message?: string;
+    state?: string;
+};
+
 export type StatusPageStatusPageWithMonitorsResponseDto = {
+    auto_incidents?: boolean;
     auto_refresh_interval?: number;
     created_at?: string;
     custom_css?: string;
@@ -607,6 +666,7 @@ export type StatusPageStatusPageWithMonitorsResponseDto = {
     domains?: Array<string>;
     footer_text?: string;
     google_analytics_tag_id?: string;
+    group_ids?: Array<string>;
     icon?: string;
     id?: string;
     monitor_ids?: Array<string>;
@@ -623,12 +683,14 @@ export type StatusPageStatusPageWithMonitorsResponseDto = {
 };
 
 export type StatusPageUpdateStatusPageDto = {
+    auto_incidents?: boolean;
     auto_refresh_interval?: number;
     custom_css?: string;
     description?: string;
     domains?: Array<string>;
     footer_text?: string;
     google_analytics_tag_id?: string;
+    group_ids?: Array<string>;
     icon?: string;
     monitor_ids?: Array<string>;
     password?: string;
@@ -2592,7 +2654,12 @@ export type PostNotificationChannelsTestData = {
      */
     body: NotificationChannelCreateUpdateDto;
     path?: never;
-    query?: never;
+    query?: {
+        /**
+         * Saved channel whose secrets replace the masked ones
+         */
+        id?: string;
+    };
     url: '/notification-channels/test';
 };
 
@@ -0,0 +1,110 @@
+import React from "react";
+import { Link } from "react-router-dom";
+import { useForm } from "react-hook-form";
+import { zodResolver } from "@hookform/resolvers/zod";
+import { z } from "zod";
+import { isAxiosError } from "axios";
+import { AlertCircle, CheckCircle, GalleryVerticalEnd, Loader2 } from "lucide-react";
+import { requestPasswordReset } from "@/api/auth-emails";
+import { Alert, AlertDescription, AlertTitle } from "@/components/ui/alert";
+import { Button } from "@/components/ui/button";
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from "@/components/ui/card";
+import { Form, FormControl, FormField, FormItem, FormLabel, FormMessage } from "@/components/ui/form";
+import { Input } from "@/components/ui/input";
+import { useLocalizedTranslation } from "@/hooks/useTranslation";
+
+const formSchema = z.object({
+    email: z.string().email("forms.validation.email_invalid"),
+});
+
+type FormValues = z.infer<typeof formSchema>;
+
+export default function ForgotPasswordPage() {
+    const { t } = useLocalizedTranslation();
+    const [sent, setSent] = React.useState(false);
+    const [submitting, setSubmitting] = React.useState(false);
+    const [serverError, setServerError] = React.useState<string | null>(null);
+
+    const form = useForm<FormValues>({
+        resolver: zodResolver(formSchema),
+        defaultValues: { email: "" },
+    });
+
+    async function onSubmit({ email }: FormValues) {
+        setServerError(null);
+        setSubmitting(true);
+        try {
+            await requestPasswordReset(email);
+            setSent(true);
+        } catch (error) {
+            setServerError(
+                (isAxiosError(error) && error.response?.data.message) || t("messages.unexpected_error")
+            );
+        } finally {
+            setSubmitting(false);
+        }
+    }
+
+    return (
+        <div className="flex min-h-svh flex-col items-center justify-center gap-6 bg-muted p-6 md:p-10">
+            <div className="flex w-full max-w-sm flex-col gap-6">
+                <a href="#" className="flex items-center gap-2 self-center font-medium">
+                    <div className="flex h-6 w-6 items-center justify-center rounded-md bg-primary text-primary-foreground">
+                        <GalleryVerticalEnd className="size-4" />
+                    </div>
+                    Vigi
+                </a>
+
+                <Card>
+                    <CardHeader className="text-center">
+                        <CardTitle className="text-xl">{t("auth.forgot_password.title")}</CardTitle>
+                        <CardDescription>{t("auth.forgot_password.description")}</CardDescription>
+                    </CardHeader>
+                    <CardContent className="grid gap-6">
+                        {sent ? (
+                            <div className="flex flex-col items-center gap-2 text-center text-sm">
+                                <CheckCircle className="h-8 w-8 text-green-500" />
+                                {t("auth.forgot_password.sent")}
+                            </div>
+                        ) : (
+                            <Form {...form}>
+                                <form onSubmit={form.handleSubmit(onSubmit)} className="grid gap-6">
+                                    <FormField
+                                        control={form.control}
+                                        name="email"
+                                        render={({ field }) => (
+                                            <FormItem>
+                                                <FormLabel>{t("forms.labels.email")}</FormLabel>
+                                                <FormControl>
+                                                    <Input placeholder="example@example.com" type="email" {...field} />
+                                                </FormControl>
+                                                <FormMessage />
+                                            </FormItem>
+                                        )}
+                                    />
+
+                                    {serverError && (
+                                        <Alert variant="destructive">
+                                            <AlertCircle className="h-4 w-4" />
+                                            <AlertTitle>{t("common.error")}</AlertTitle>
+                                            <AlertDescription>{serverError}</AlertDescription>
+                                        </Alert>
+                                    )}
+
+                                    <Button type="submit" className="w-full" disabled={submitting}>
+                                        {submitting && <Loader2 className="mr-2 h-4 w-4 animate-spin" />}
+                                        {t("auth.forgot_password.submit")}
+                                    </Button>
+                                </form>
+                            </Form>
+                        )}
+
+                        <Link to="/login" className="text-center text-sm font-medium text-primary hover:underline">
+                            {t("auth.sso.back_to_login")}
+                        </Link>
+                    </CardContent>
+                </Card>
+            </div>
+        </div>
+    );
+}
@@ -3,7 +3,7 @@ import { useTranslation } from "react-i18next";
 import { toast } from "sonner";
 import Layout from "@/layout";
 import { BackButton } from "@/components/back-button";
-import { getInvoiceOptions, useUpdateInvoiceMutation, getInvoiceEmailsOptions, cloneInvoice } from "@/api/invoice-manual";
+import { getInvoiceOptions, useUpdateInvoiceMutation, getInvoiceEmailsOptions, cloneInvoice, downloadInvoicePdf } from "@/api/invoice-manual";
 import { useQuery, useQueryClient } from "@tanstack/react-query";
 import { Skeleton } from "@/components/ui/skeleton";
 import { Card, CardContent, CardHeader, CardFooter } from "@/components/ui/card";
@@ -25,7 +25,7 @@ import {
   DropdownMenuSeparator,
   DropdownMenuTrigger,
 } from "@/components/ui/dropdown-menu";
-import { ChevronDown, Printer, QrCode, Link as LinkIcon, Files } from "lucide-react";
+import { ChevronDown, Printer, QrCode, Link as LinkIcon, Files, FileDown } from "lucide-react";
 
 export default function InvoiceDetailsPage() {
   const { id } = useParams<{ id: string }>();
@@ -92,6 +92,21 @@ export default function InvoiceDetailsPage() {
     }
   };
 
+  const handleDownloadPdf = async () => {
+    if (!id || !invoice) return;
+    try {
+      const blob = await downloadInvoicePdf(id);
+      const url = URL.createObjectURL(blob);
+      const link = document.createElement("a");
+      link.href = url;
+      link.download = `fatura-${invoice.number}.pdf`;
+      link.click();
+      URL.revokeObjectURL(url);
+    } catch (error) {
+      toast.error(t("invoice.download_pdf_error"));
+    }
+  };
+
   const handleCopyLink = () => {
     if (invoice?.nfLink) {
       navigator.clipboard.writeText(invoice.nfLink);
@@ -188,6 +203,10 @@ export default function InvoiceDetailsPage() {
                   {t("invoice.actions.copy_public_link")}
                 </DropdownMenuItem>
                 <DropdownMenuSeparator />
+                <DropdownMenuItem onClick={handleDownloadPdf}>
+                  <FileDown className="h-4 w-4 mr-2" />
+                  {t("invoice.actions.download_pdf")}
+                </DropdownMenuItem>
                 <DropdownMenuItem onClick={() => window.print()}>
                   <Printer className="h-4 w-4 mr-2" />
                   {t("common.print")}
@@ -20,7 +20,8 @@ import {
     FormMessage,
 } from "@/components/ui/form";
 import { postAuthLoginMutation } from "@/api/@tanstack/react-query.gen";
-import { useMutation } from "@tanstack/react-query";
+import { useMutation, useQuery } from "@tanstack/react-query";
+import { getSsoConfig, getSsoLoginUrl } from "@/api/sso";
 import { toast } from "sonner";
 import { isAxiosError } from "axios";
 import { Alert, AlertDescription, AlertTitle } from "@/components/ui/alert";
@@ -62,6 +63,13 @@ export function LoginForm({
     const [show2FAPrompt, setShow2FAPrompt] = React.useState(false);
     const [verifying2FA, setVerifying2FA] = React.useState(false);
 
+    const { data: ssoConfig } = useQuery({
+        queryKey: ["sso-config"],
+        queryFn: async () => (await getSsoConfig()).data?.data ?? null,
+    });
+    const ssoEnabled = ssoConfig?.enabled ?? false;
+    const passwordLoginEnabled = ssoConfig?.passwordLoginEnabled ?? true;
+
     const loginMutation = useMutation({
         ...postAuthLoginMutation(),
         onSuccess: (response) => {
@@ -134,9 +142,28 @@ export function LoginForm({
                 <Card>
                     <CardHeader className="text-center">
                         <CardTitle className="text-xl">{t("auth.login.title")}</CardTitle>
-                        <CardDescription>{t("auth.login.description")}</CardDescription>
+                        <CardDescription>
+                            {passwordLoginEnabled ? t("auth.login.description") : t("auth.login.sso_description")}
+                        </CardDescription>
                     </CardHeader>
-                    <CardContent>
+                    <CardContent className="grid gap-6">
+                        {ssoEnabled && (
+                            <Button asChild variant="outline" className="w-full">
+                                <a href={getSsoLoginUrl()}>
+                                    {t("auth.login.sso_button", { provider: ssoConfig?.providerName })}
+                                </a>
+                            </Button>
+                        )}
+
+                        {ssoEnabled && passwordLoginEnabled && (
+                            <div className="relative text-center text-sm after:absolute after:inset-0 after:top-1/2 after:z-0 after:flex after:items-center after:border-t after:border-border">
+                                <span className="relative z-10 bg-card px-2 text-muted-foreground">
+                                    {t("auth.login.or")}
+                                </span>
+                            </div>
+                        )}
+
+                        {passwordLoginEnabled && (
                         <Form {...form}>
                             <form
                                 onSubmit={form.handleSubmit(onSubmit)}
@@ -165,7 +192,15 @@ export function LoginForm({
                                     name="password"
                                     render={({ field }) => (
                                         <FormItem>
-                                            <FormLabel>{t("forms.labels.password")}</FormLabel>
+                                            <div className="flex items-center justify-between">
+                                                <FormLabel>{t("forms.labels.password")}</FormLabel>
+                                                <Link
+                                                    to="/forgot-password"
+                                                    className="text-sm text-muted-foreground hover:underline"
+                                                >
+                                                    {t("auth.login.forgot_password")}
+                                                </Link>
+                                            </div>
                                             <FormControl>
                                                 <PasswordInput {...field} placeholder="********" />
                                             </FormControl>
@@ -201,6 +236,7 @@ export function LoginForm({
                                 </div>
                             </form>
                         </Form>
+                        )}
                     </CardContent>
                 </Card>
             )}
@@ -0,0 +1,78 @@
+import React from "react";
+import { Link } from "react-router-dom";
+import { AlertCircle, GalleryVerticalEnd, Loader2 } from "lucide-react";
+import { postAuthRefresh } from "@/api/sdk.gen";
+import { Alert, AlertDescription, AlertTitle } from "@/components/ui/alert";
+import { useAuthStore } from "@/store/auth";
+import { useLocalizedTranslation } from "@/hooks/useTranslation";
+import { useSmartRedirect } from "@/hooks/use-smart-redirect";
+
+// Landing page of the single sign-on callback. The API passes a refresh
+// token (or an error) in the URL fragment, which is traded for a session.
+export default function SSOCallbackPage() {
+    const { t } = useLocalizedTranslation();
+    const { handleRedirect } = useSmartRedirect();
+    const setTokens = useAuthStore((state) => state.setTokens);
+    const setUser = useAuthStore((state) => state.setUser);
+    const [error, setError] = React.useState<string | null>(null);
+    const started = React.useRef(false);
+
+    React.useEffect(() => {
+        if (started.current) return;
+        started.current = true;
+
+        const params = new URLSearchParams(window.location.hash.slice(1));
+        // Drop the token from the address bar and history
+        window.history.replaceState(null, "", window.location.pathname);
+
+        const refreshToken = params.get("refresh_token");
+        if (!refreshToken) {
+            setError(params.get("error") || t("messages.login_error"));
+            return;
+        }
+
+        postAuthRefresh({ body: { refreshToken } })
+            .then((response) => {
+                const { accessToken, refreshToken, user } = response.data?.data || {};
+                if (!accessToken || !refreshToken) {
+                    setError(t("messages.login_no_tokens"));
+                    return;
+                }
+                setTokens(accessToken, refreshToken);
+                setUser(user ?? null);
+                handleRedirect();
+            })
+            .catch(() => setError(t("messages.login_error")));
+    }, [t, setTokens, setUser, handleRedirect]);
+
+    return (
+        <div className="flex min-h-svh flex-col items-center justify-center gap-6 bg-muted p-6 md:p-10">
+            <div className="flex w-full max-w-sm flex-col gap-6">
+                <a href="#" className="flex items-center gap-2 self-center font-medium">
+                    <div className="flex h-6 w-6 items-center justify-center rounded-md bg-primary text-primary-foreground">
+                        <GalleryVerticalEnd className="size-4" />
+                    </div>
+                    Vigi
+                </a>
+
+                {error ? (
+                    <>
+                        <Alert variant="destructive">
+                            <AlertCircle className="h-4 w-4" />
+                            <AlertTitle>{t("auth.sso.error_title")}</AlertTitle>
+                            <AlertDescription>{error}</AlertDescription>
+                        </Alert>
+                        <Link to="/login" className="text-center text-sm font-medium text-primary hover:underline">
+                            {t("auth.sso.back_to_login")}
+                        </Link>
+                    </>
+                ) : (
+                    <div className="flex items-center justify-center gap-2 text-sm text-muted-foreground">
+                        <Loader2 className="h-4 w-4 animate-spin" />
+                        {t("auth.sso.signing_in")}
+                    </div>
+                )}
+            </div>
+        </div>
+    );
+}
@@ -29,6 +29,7 @@ import RecurringWeekdayForm from "./recurring-weekday-form";
 import RecurringDayOfMonthForm from "./recurring-day-of-month-form";
 import { convertToDateTimeLocal } from "@/lib/utils";
 import SearchableMonitorSelector from "@/components/searchable-monitor-selector";
+import MonitorGroupSelector from "@/components/monitor-group-selector";
 import { useLocalizedTranslation } from "@/hooks/useTranslation";
 
 // Strategy options - will be populated with translations in component
@@ -44,6 +45,7 @@ const baseMaintenanceSchema = z.object({
       label: z.string(),
     })
   ),
+  group_ids: z.array(z.string()),
   showOnAllPages: z.boolean().optional(),
   status_page_ids: z
     .array(
@@ -169,6 +171,7 @@ const defaultValues: MaintenanceFormValues = {
   description: "",
   strategy: "single" as const,
   monitors: [],
+  group_ids: [],
   showOnAllPages: false,
   status_page_ids: [],
   timezone: "SAME_AS_SERVER",
@@ -268,6 +271,21 @@ export default function CreateEditMaintenance({
                 }}
               />
             </div>
+
+            <FormField
+              control={form.control}
+              name="group_ids"
+              render={({ field }) => (
+                <FormItem>
+                  <FormLabel>{t("maintenance.form.affected_groups_label")}</FormLabel>
+                  <FormControl>
+                    <MonitorGroupSelector value={field.value} onSelect={field.onChange} />
+                  </FormControl>
+                  <FormDescription>{t("maintenance.form.affected_groups_description")}</FormDescription>
+                  <FormMessage />
+                </FormItem>
+              )}
+            />
           </div>
 
           {/* Date and Time */}
@@ -72,6 +72,7 @@ const EditMaintenance = () => {
             active: data.active,
             strategy: data.strategy,
             monitor_ids: data.monitors.map((monitor) => monitor.value),
+            group_ids: data.group_ids,
             ...(data.strategy === "single" && {
                 timezone: data.timezone,
                 start_date_time: data.startDateTime,
@@ -154,6 +155,7 @@ const EditMaintenance = () => {
                     label: monitor.name || "",
                 }))
                 .filter((monitor) => monitor.value && monitor.label) || [],
+        group_ids: maintenance?.group_ids || [],
     };
 
     return (
@@ -42,6 +42,7 @@ const NewMaintenance = () => {
             active: data.active,
             strategy: data.strategy,
             monitor_ids: data.monitors.map((monitor) => monitor.value),
+            group_ids: data.group_ids,
             ...(data.strategy === "single" && {
                 timezone: data.timezone,
                 start_date_time: data.startDateTime,
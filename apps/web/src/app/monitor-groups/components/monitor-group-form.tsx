@@ -0,0 +1,316 @@
+import { useForm } from "react-hook-form";
+import { zodResolver } from "@hookform/resolvers/zod";
+import { z } from "zod";
+import { useMutation, useQuery, useQueryClient } from "@tanstack/react-query";
+import { getNotificationChannelsOptions } from "@/api/@tanstack/react-query.gen";
+import {
+    createMonitorGroup,
+    getMonitorGroups,
+    monitorGroupsQueryKey,
+    updateMonitorGroup,
+    type MonitorGroupDTO,
+    type MonitorGroupModel,
+} from "@/api/monitor-groups";
+import { toast } from "sonner";
+import { useNavigate } from "react-router-dom";
+import { Button } from "@/components/ui/button";
+import { Input } from "@/components/ui/input";
+import { Textarea } from "@/components/ui/textarea";
+import { Card, CardContent } from "@/components/ui/card";
+import {
+    Form,
+    FormControl,
+    FormDescription,
+    FormField,
+    FormItem,
+    FormLabel,
+    FormMessage,
+} from "@/components/ui/form";
+import {
+    Select,
+    SelectContent,
+    SelectItem,
+    SelectTrigger,
+    SelectValue,
+} from "@/components/ui/select";
+import { MultiSelect } from "@/components/multi-select";
+import SearchableMonitorSelector from "@/components/searchable-monitor-selector";
+import { Loader2 } from "lucide-react";
+import { commonMutationErrorHandler } from "@/lib/utils";
+import { useLocalizedTranslation } from "@/hooks/useTranslation";
+import { useOrganizationStore } from "@/store/organization";
+
+const NO_PARENT = "none";
+
+const monitorGroupSchema = z
+    .object({
+        name: z
+            .string()
+            .min(1, "monitor_groups.validation.name_required")
+            .max(255, "monitor_groups.validation.name_max_length"),
+        description: z.string().max(500).optional(),
+        parent_id: z.string(),
+        policy: z.enum(["all_up", "any_down", "quorum"]),
+        quorum: z.number().min(0).max(100),
+        monitors: z.array(
+            z.object({
+                label: z.string(),
+                value: z.string(),
+            })
+        ),
+        notification_ids: z.array(z.string()),
+    })
+    .refine((data) => data.policy !== "quorum" || data.quorum > 0, {
+        message: "monitor_groups.validation.quorum_required",
+        path: ["quorum"],
+    });
+
+export type MonitorGroupFormData = z.infer<typeof monitorGroupSchema>;
+
+interface MonitorGroupFormProps {
+    mode: "create" | "edit";
+    group?: MonitorGroupModel;
+    monitors?: MonitorGroupFormData["monitors"];
+}
+
+const MonitorGroupForm = ({ mode, group, monitors }: MonitorGroupFormProps) => {
+    const { t } = useLocalizedTranslation();
+    const navigate = useNavigate();
+    const queryClient = useQueryClient();
+    const { currentOrganization } = useOrganizationStore();
+    const slug = currentOrganization?.slug;
+    const listPath = slug ? `/${slug}/monitor-groups` : "/monitor-groups";
+
+    const form = useForm<MonitorGroupFormData>({
+        resolver: zodResolver(monitorGroupSchema),
+        defaultValues: {
+            name: group?.name || "",
+            description: group?.description || "",
+            parent_id: group?.parent_id || NO_PARENT,
+            policy: group?.policy || "all_up",
+            quorum: group?.quorum || 0,
+            monitors: monitors || [],
+            notification_ids: group?.notification_ids || [],
+        },
+    });
+
+    const policy = form.watch("policy");
+
+    const { data: groups } = useQuery({
+        queryKey: [...monitorGroupsQueryKey, "all"],
+        queryFn: () => getMonitorGroups({ limit: 100 }),
+    });
+
+    const { data: notifications } = useQuery({
+        ...getNotificationChannelsOptions(),
+    });
+
+    const notificationOptions = (notifications?.data || []).map((n) => ({
+        label: n.name || "",
+        value: n.id || "",
+    }));
+
+    const onSuccess = (message: string) => () => {
+        toast.success(message);
+        queryClient.invalidateQueries({ queryKey: monitorGroupsQueryKey });
+        navigate(listPath);
+    };
+
+    const createMutation = useMutation({
+        mutationFn: (body: MonitorGroupDTO) => createMonitorGroup(body),
+        onSuccess: onSuccess(t("monitor_groups.messages.created_successfully")),
+        onError: commonMutationErrorHandler(t("monitor_groups.messages.create_error")),
+    });
+
+    const editMutation = useMutation({
+        mutationFn: (body: MonitorGroupDTO) => updateMonitorGroup(group?.id || "", body),
+        onSuccess: onSuccess(t("monitor_groups.messages.updated_successfully")),
+        onError: commonMutationErrorHandler(t("monitor_groups.messages.update_error")),
+    });
+
+    const onSubmit = (data: MonitorGroupFormData) => {
+        const { monitors, parent_id, ...rest } = data;
+        const body: MonitorGroupDTO = {
+            ...rest,
+            parent_id: parent_id === NO_PARENT ? "" : parent_id,
+            monitor_ids: monitors.map((monitor) => monitor.value),
+        };
+
+        if (mode === "create") {
+            createMutation.mutate(body);
+        } else {
+            editMutation.mutate(body);
+        }
+    };
+
+    const isPending = createMutation.isPending || editMutation.isPending;
+
+    // A group can't be nested under itself
+    const parentOptions = (groups || []).filter((g) => g.id !== group?.id);
+
+    return (
+        <Form {...form}>
+            <form
+                onSubmit={form.handleSubmit(onSubmit)}
+                className="space-y-6 max-w-[600px]"
+            >
+                <Card>
+                    <CardContent className="space-y-4">
+                        <FormField
+                            control={form.control}
+                            name="name"
+                            render={({ field }) => (
+                                <FormItem>
+                                    <FormLabel>{t("monitor_groups.form.name_label")}</FormLabel>
+                                    <FormControl>
+                                        <Input placeholder={t("monitor_groups.form.name_placeholder")} {...field} />
+                                    </FormControl>
+                                    <FormMessage />
+                                </FormItem>
+                            )}
+                        />
+
+                        <FormField
+                            control={form.control}
+                            name="description"
+                            render={({ field }) => (
+                                <FormItem>
+                                    <FormLabel>{t("monitor_groups.form.description_label")}</FormLabel>
+                                    <FormControl>
+                                        <Textarea
+                                            placeholder={t("monitor_groups.form.description_placeholder")}
+                                            {...field}
+                                        />
+                                    </FormControl>
+                                    <FormMessage />
+                                </FormItem>
+                            )}
+                        />
+
+                        <FormField
+                            control={form.control}
+                            name="parent_id"
+                            render={({ field }) => (
+                                <FormItem>
+                                    <FormLabel>{t("monitor_groups.form.parent_label")}</FormLabel>
+                                    <Select onValueChange={field.onChange} value={field.value}>
+                                        <FormControl>
+                                            <SelectTrigger className="w-full">
+                                                <SelectValue />
+                                            </SelectTrigger>
+                                        </FormControl>
+                                        <SelectContent>
+                                            <SelectItem value={NO_PARENT}>
+                                                {t("monitor_groups.form.no_parent")}
+                                            </SelectItem>
+                                            {parentOptions.map((g) => (
+                                                <SelectItem key={g.id} value={g.id}>
+                                                    {g.name}
+                                                </SelectItem>
+                                            ))}
+                                        </SelectContent>
+                                    </Select>
+                                    <FormDescription>{t("monitor_groups.form.parent_description")}</FormDescription>
+                                    <FormMessage />
+                                </FormItem>
+                            )}
+                        />
+
+                        <FormField
+                            control={form.control}
+                            name="policy"
+                            render={({ field }) => (
+                                <FormItem>
+                                    <FormLabel>{t("monitor_groups.form.policy_label")}</FormLabel>
+                                    <Select onValueChange={field.onChange} value={field.value}>
+                                        <FormControl>
+                                            <SelectTrigger className="w-full">
+                                                <SelectValue />
+                                            </SelectTrigger>
+                                        </FormControl>
+                                        <SelectContent>
+                                            <SelectItem value="all_up">{t("monitor_groups.policy.all_up")}</SelectItem>
+                                            <SelectItem value="any_down">{t("monitor_groups.policy.any_down")}</SelectItem>
+                                            <SelectItem value="quorum">{t("monitor_groups.policy.quorum")}</SelectItem>
+                                        </SelectContent>
+                                    </Select>
+                                    <FormDescription>{t(`monitor_groups.policy.${policy}_description`)}</FormDescription>
+                                    <FormMessage />
+                                </FormItem>
+                            )}
+                        />
+
+                        {policy === "quorum" && (
+                            <FormField
+                                control={form.control}
+                                name="quorum"
+                                render={({ field }) => (
+                                    <FormItem>
+                                        <FormLabel>{t("monitor_groups.form.quorum_label")}</FormLabel>
+                                        <FormControl>
+                                            <Input
+                                                type="number"
+                                                min="1"
+                                                max="100"
+                                                {...field}
+                                                onChange={(e) => field.onChange(e.target.valueAsNumber || 0)}
+                                            />
+                                        </FormControl>
+                                        <FormDescription>{t("monitor_groups.form.quorum_description")}</FormDescription>
+                                        <FormMessage />
+                                    </FormItem>
+                                )}
+                            />
+                        )}
+
+                        <div className="space-y-2">
+                            <FormLabel>{t("monitor_groups.form.monitors_label")}</FormLabel>
+                            <SearchableMonitorSelector
+                                value={form.watch("monitors")}
+                                onSelect={(value) => {
+                                    form.setValue("monitors", value);
+                                }}
+                            />
+                        </div>
+
+                        <FormField
+                            control={form.control}
+                            name="notification_ids"
+                            render={({ field }) => (
+                                <FormItem>
+                                    <FormLabel>{t("monitor_groups.form.notifications_label")}</FormLabel>
+                                    <FormControl>
+                                        <MultiSelect
+                                            options={notificationOptions}
+                                            value={field.value}
+                                            onValueChange={field.onChange}
+                                        />
+                                    </FormControl>
+                                    <FormDescription>{t("monitor_groups.form.notifications_description")}</FormDescription>
+                                    <FormMessage />
+                                </FormItem>
+                            )}
+                        />
+                    </CardContent>
+                </Card>
+
+                <div className="flex gap-2">
+                    <Button
+                        type="button"
+                        variant="outline"
+                        onClick={() => navigate(listPath)}
+                        disabled={isPending}
+                    >
+                        {t("common.cancel")}
+                    </Button>
+                    <Button type="submit" disabled={isPending}>
+                        {isPending && <Loader2 className="animate-spin mr-2 h-4 w-4" />}
+                        {mode === "create" ? t("monitor_groups.form.create_button") : t("monitor_groups.form.update_button")}
+                    </Button>
+                </div>
+            </form>
+        </Form>
+    );
+};
+
+export default MonitorGroupForm;
@@ -0,0 +1,75 @@
+import Layout from "@/layout";
+import { BackButton } from "@/components/back-button";
+import { useParams } from "react-router-dom";
+import { useQuery } from "@tanstack/react-query";
+import { getMonitorsBatchOptions } from "@/api/@tanstack/react-query.gen";
+import { getMonitorGroup, monitorGroupsQueryKey } from "@/api/monitor-groups";
+import MonitorGroupForm from "../components/monitor-group-form";
+import { useLocalizedTranslation } from "@/hooks/useTranslation";
+
+import { useOrganizationStore } from "@/store/organization";
+
+const EditMonitorGroup = () => {
+    const { id } = useParams();
+    const { t } = useLocalizedTranslation();
+    const { currentOrganization } = useOrganizationStore();
+    const slug = currentOrganization?.slug;
+    const listPath = slug ? `/${slug}/monitor-groups` : "/monitor-groups";
+
+    const { data: group, isLoading, error } = useQuery({
+        queryKey: [...monitorGroupsQueryKey, id],
+        queryFn: () => getMonitorGroup(id!),
+        enabled: !!id,
+    });
+
+    const { data: monitorsData, isLoading: monitorsDataIsLoading } = useQuery({
+        ...getMonitorsBatchOptions({
+            query: {
+                ids: group?.monitor_ids?.join(",") || "",
+            },
+        }),
+        enabled: !!group?.monitor_ids?.length,
+    });
+
+    if (isLoading || monitorsDataIsLoading) {
+        return (
+            <Layout pageName={t("monitor_groups.edit_page_name")}>
+                <BackButton to={listPath} />
+                <div>{t("common.loading")}</div>
+            </Layout>
+        );
+    }
+
+    if (error || !group) {
+        return (
+            <Layout pageName={t("monitor_groups.edit_page_name")}>
+                <BackButton to={listPath} />
+                <div className="text-red-500">
+                    {t("monitor_groups.messages.failed_to_load")}
+                </div>
+            </Layout>
+        );
+    }
+
+    return (
+        <Layout pageName={`${t("monitor_groups.edit_page_name")}: ${group.name}`}>
+            <BackButton to={listPath} />
+            <div className="flex flex-col gap-4">
+                <p className="text-gray-500">
+                    {t("monitor_groups.messages.update_description")}
+                </p>
+
+                <MonitorGroupForm
+                    mode="edit"
+                    group={group}
+                    monitors={monitorsData?.data?.map((monitor) => ({
+                        label: monitor.name || "",
+                        value: monitor.id || "",
+                    }))}
+                />
+            </div>
+        </Layout>
+    );
+};
+
+export default EditMonitorGroup;
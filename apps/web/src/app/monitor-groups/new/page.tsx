@@ -0,0 +1,28 @@
+import Layout from "@/layout";
+import { BackButton } from "@/components/back-button";
+import MonitorGroupForm from "../components/monitor-group-form";
+import { useLocalizedTranslation } from "@/hooks/useTranslation";
+
+import { useOrganizationStore } from "@/store/organization";
+
+const NewMonitorGroup = () => {
+    const { t } = useLocalizedTranslation();
+    const { currentOrganization } = useOrganizationStore();
+    const slug = currentOrganization?.slug;
+    const listPath = slug ? `/${slug}/monitor-groups` : "/monitor-groups";
+
+    return (
+        <Layout pageName={t("monitor_groups.new_page_name")}>
+            <BackButton to={listPath} />
+            <div className="flex flex-col gap-4">
+                <p className="text-gray-500">
+                    {t("monitor_groups.messages.create_description")}
+                </p>
+
+                <MonitorGroupForm mode="create" />
+            </div>
+        </Layout>
+    );
+};
+
+export default NewMonitorGroup;
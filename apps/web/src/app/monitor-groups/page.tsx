@@ -0,0 +1,217 @@
+import { useQuery, useQueryClient, useMutation } from "@tanstack/react-query";
+import {
+    deleteMonitorGroup,
+    getMonitorGroups,
+    monitorGroupsQueryKey,
+    type MonitorGroupModel,
+} from "@/api/monitor-groups";
+import Layout from "@/layout";
+import { useState, useEffect } from "react";
+import { useDebounce } from "@/hooks/useDebounce";
+import { useSearchParams } from "@/hooks/useSearchParams";
+import { Input } from "@/components/ui/input";
+import { Skeleton } from "@/components/ui/skeleton";
+import { Label } from "@/components/ui/label";
+import EmptyList from "@/components/empty-list";
+import { useLocalizedTranslation } from "@/hooks/useTranslation";
+import { Button } from "@/components/ui/button";
+import { Card, CardContent } from "@/components/ui/card";
+import { Badge } from "@/components/ui/badge";
+import { Pencil, Trash2 } from "lucide-react";
+import {
+    AlertDialog,
+    AlertDialogAction,
+    AlertDialogCancel,
+    AlertDialogContent,
+    AlertDialogDescription,
+    AlertDialogFooter,
+    AlertDialogHeader,
+    AlertDialogTitle,
+} from "@/components/ui/alert-dialog";
+import { toast } from "sonner";
+import { cn, commonMutationErrorHandler } from "@/lib/utils";
+import { useNavigate } from "react-router-dom";
+
+const MonitorGroupsPage = () => {
+    const queryClient = useQueryClient();
+    const navigate = useNavigate();
+    const { t } = useLocalizedTranslation();
+    const { getParam, updateSearchParams, clearAllParams, hasParams } =
+        useSearchParams();
+
+    const [search, setSearch] = useState(getParam("q") || "");
+    const debouncedSearch = useDebounce(search, 400);
+
+    useEffect(() => {
+        updateSearchParams({ q: debouncedSearch });
+    }, [debouncedSearch, updateSearchParams]);
+
+    const clearAllFilters = () => {
+        setSearch("");
+        clearAllParams();
+    };
+
+    const [deleteDialogOpen, setDeleteDialogOpen] = useState(false);
+    const [selectedGroup, setSelectedGroup] = useState<MonitorGroupModel | null>(null);
+
+    const { data: groups = [], isLoading } = useQuery({
+        queryKey: [...monitorGroupsQueryKey, "list", debouncedSearch],
+        queryFn: () => getMonitorGroups({ limit: 100, q: debouncedSearch || undefined }),
+    });
+
+    const groupNames = new Map(groups.map((group) => [group.id, group.name]));
+
+    const deleteMutation = useMutation({
+        mutationFn: (id: string) => deleteMonitorGroup(id),
+        onSuccess: () => {
+            toast.success(t("monitor_groups.messages.deleted_successfully"));
+            setDeleteDialogOpen(false);
+            setSelectedGroup(null);
+            queryClient.invalidateQueries({ queryKey: monitorGroupsQueryKey });
+        },
+        onError: commonMutationErrorHandler(t("monitor_groups.messages.delete_error")),
+    });
+
+    const handleDelete = (group: MonitorGroupModel) => {
+        setSelectedGroup(group);
+        setDeleteDialogOpen(true);
+    };
+
+    const handleCreate = () => {
+        navigate("new");
+    };
+
+    return (
+        <Layout pageName={t("navigation.monitor_groups")} onCreate={handleCreate}>
+            <div>
+                <div className="mb-4 space-y-4">
+                    <div className="flex flex-col gap-4 sm:flex-row sm:justify-end sm:gap-4 items-end">
+                        {hasParams() && (
+                            <div className="flex justify-start">
+                                <Button
+                                    variant="outline"
+                                    size="sm"
+                                    onClick={clearAllFilters}
+                                    className="w-fit h-[36px]"
+                                >
+                                    {t("common.clear_all_filters")}
+                                </Button>
+                            </div>
+                        )}
+                        <div className="flex flex-col gap-1 w-full sm:w-auto">
+                            <Label htmlFor="search-monitor-groups">{t("common.search")}</Label>
+                            <Input
+                                id="search-monitor-groups"
+                                placeholder={t("monitor_groups.search_placeholder")}
+                                value={search}
+                                onChange={(e) => setSearch(e.target.value)}
+                                className="w-full sm:w-[400px]"
+                            />
+                        </div>
+                    </div>
+                </div>
+
+                {groups.length === 0 && isLoading && (
+                    <div className="space-y-4">
+                        {Array.from({ length: 6 }, (_, id) => (
+                            <Skeleton className="h-[68px] w-full rounded-xl" key={id} />
+                        ))}
+                    </div>
+                )}
+
+                {groups.length === 0 && !isLoading && (
+                    <EmptyList
+                        title={t("monitor_groups.empty_state.title")}
+                        text={t("monitor_groups.empty_state.description")}
+                        actionText={t("monitor_groups.empty_state.action")}
+                        onClick={handleCreate}
+                    />
+                )}
+
+                <div className="space-y-4">
+                    {groups.map((group) => (
+                        <Card
+                            key={group.id}
+                            className="hover:shadow-md transition-shadow py-2"
+                        >
+                            <CardContent className="px-3">
+                                <div className="flex items-center justify-between">
+                                    <div className="flex items-center gap-4 flex-1 min-w-0">
+                                        <Badge
+                                            className={cn("text-white flex-shrink-0", {
+                                                "bg-green-500 border-green-600": group.status === 1,
+                                                "bg-red-500 border-red-600": group.status === 0,
+                                                "bg-gray-500 border-gray-600": group.status === 2,
+                                                "bg-blue-500 border-blue-600": group.status === 3,
+                                                "bg-amber-500 border-amber-600": group.status === 4,
+                                            })}
+                                        >
+                                            {group.status === 1 && t("common.up")}
+                                            {group.status === 0 && t("common.down")}
+                                            {group.status === 2 && t("common.unknown")}
+                                            {group.status === 3 && t("common.maintenance")}
+                                            {group.status === 4 && t("common.degraded")}
+                                        </Badge>
+                                        <div className="flex-1 min-w-0">
+                                            <div className="font-medium truncate">{group.name}</div>
+                                            <div className="text-xs text-muted-foreground truncate">
+                                                {t(`monitor_groups.policy.${group.policy}`)}
+                                                {group.policy === "quorum" && ` (${group.quorum}%)`}
+                                                {" · "}
+                                                {t("monitor_groups.monitor_count", { count: group.monitor_ids?.length || 0 })}
+                                                {group.parent_id && groupNames.has(group.parent_id) && (
+                                                    <> · {t("monitor_groups.parent", { name: groupNames.get(group.parent_id) })}</>
+                                                )}
+                                            </div>
+                                        </div>
+                                    </div>
+                                    <div className="flex gap-2 flex-shrink-0">
+                                        <Button
+                                            variant="ghost"
+                                            size="sm"
+                                            onClick={() => navigate(`${group.id}/edit`)}
+                                            className="h-8 w-8 p-0"
+                                        >
+                                            <Pencil className="h-4 w-4" />
+                                        </Button>
+                                        <Button
+                                            variant="ghost"
+                                            size="sm"
+                                            onClick={() => handleDelete(group)}
+                                            className="h-8 w-8 p-0 text-red-500 hover:text-red-600"
+                                        >
+                                            <Trash2 className="h-4 w-4" />
+                                        </Button>
+                                    </div>
+                                </div>
+                            </CardContent>
+                        </Card>
+                    ))}
+                </div>
+
+                <AlertDialog open={deleteDialogOpen} onOpenChange={setDeleteDialogOpen}>
+                    <AlertDialogContent>
+                        <AlertDialogHeader>
+                            <AlertDialogTitle>{t("common.confirm_delete_title_short")}</AlertDialogTitle>
+                            <AlertDialogDescription>
+                                {t("monitor_groups.confirm_delete_description", { name: selectedGroup?.name })}
+                            </AlertDialogDescription>
+                        </AlertDialogHeader>
+                        <AlertDialogFooter>
+                            <AlertDialogCancel>{t("common.cancel")}</AlertDialogCancel>
+                            <AlertDialogAction
+                                onClick={() => deleteMutation.mutate(selectedGroup?.id || "")}
+                                className="bg-red-500 hover:bg-red-600"
+                                disabled={deleteMutation.isPending}
+                            >
+                                {t("common.delete")}
+                            </AlertDialogAction>
+                        </AlertDialogFooter>
+                    </AlertDialogContent>
+                </AlertDialog>
+            </div>
+        </Layout>
+    );
+};
+
+export default MonitorGroupsPage;
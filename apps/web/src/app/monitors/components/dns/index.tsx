@@ -18,16 +18,27 @@ import Tags, {
   tagsDefaultValues,
   tagsSchema,
 } from "../shared/tags";
+import Dependencies, {
+    dependenciesDefaultValues,
+    dependenciesSchema,
+} from "../shared/dependencies";
+import Locations, {
+    locationsDefaultValues,
+    locationsSchema,
+} from "../shared/locations";
 import { useMonitorFormContext } from "../../context/monitor-form-context";
 import {
   Form,
   FormControl,
+  FormDescription,
   FormField,
   FormItem,
   FormLabel,
   FormMessage,
 } from "@/components/ui/form";
 import { Input } from "@/components/ui/input";
+import { Textarea } from "@/components/ui/textarea";
+import { Checkbox } from "@/components/ui/checkbox";
 import {
   Select,
   SelectContent,
@@ -45,8 +56,23 @@ interface DNSConfig {
   resolver_server: string;
   port: number;
   resolve_type: string;
+  transport?: string;
+  tls_server_name?: string;
+  doh_path?: string;
+  ignore_tls_errors?: boolean;
+  expected_values?: string[];
+  match_mode?: string;
+  detect_soa_serial_change?: boolean;
+  dnssec?: boolean;
 }
 
+// Expected values are edited one per line and sent as a list
+const parseExpectedValues = (value: string): string[] =>
+  value
+    .split("\n")
+    .map((line) => line.trim())
+    .filter((line) => line.length > 0);
+
 export const dnsSchema = z
   .object({
     type: z.literal("dns"),
@@ -65,11 +91,21 @@ export const dnsSchema = z
       "SRV",
       "TXT",
     ]),
+    transport: z.enum(["udp", "tcp", "dot", "doh"]),
+    tls_server_name: z.string().optional(),
+    doh_path: z.string().optional(),
+    ignore_tls_errors: z.boolean(),
+    match_mode: z.enum(["none", "exact", "contains", "regex"]),
+    expected_values: z.string().optional(),
+    detect_soa_serial_change: z.boolean(),
+    dnssec: z.boolean(),
   })
   .merge(generalSchema)
   .merge(intervalsSchema)
   .merge(notificationsSchema)
-  .merge(tagsSchema);
+  .merge(tagsSchema)
+  .merge(dependenciesSchema)
+  .merge(locationsSchema);
 
 export type DNSForm = z.infer<typeof dnsSchema>;
 
@@ -79,10 +115,20 @@ export const dnsDefaultValues: DNSForm = {
   resolver_server: "1.1.1.1",
   port: 53,
   resolve_type: "A",
+  transport: "udp",
+  tls_server_name: "",
+  doh_path: "/dns-query",
+  ignore_tls_errors: false,
+  match_mode: "none",
+  expected_values: "",
+  detect_soa_serial_change: false,
+  dnssec: false,
   ...generalDefaultValues,
   ...intervalsDefaultValues,
   ...notificationsDefaultValues,
   ...tagsDefaultValues,
+  ...dependenciesDefaultValues,
+  ...locationsDefaultValues,
 };
 
 export const deserialize = (data: MonitorMonitorResponseDto): DNSForm => {
@@ -101,6 +147,14 @@ export const deserialize = (data: MonitorMonitorResponseDto): DNSForm => {
         resolver_server: parsedConfig.resolver_server || "1.1.1.1",
         port: parsedConfig.port ?? 53,
         resolve_type: parsedConfig.resolve_type || "A",
+        transport: parsedConfig.transport || "udp",
+        tls_server_name: parsedConfig.tls_server_name || "",
+        doh_path: parsedConfig.doh_path || "/dns-query",
+        ignore_tls_errors: parsedConfig.ignore_tls_errors ?? false,
+        expected_values: parsedConfig.expected_values || [],
+        match_mode: parsedConfig.match_mode || "none",
+        detect_soa_serial_change: parsedConfig.detect_soa_serial_change ?? false,
+        dnssec: parsedConfig.dnssec ?? false,
       };
     } catch (error) {
       console.error("Failed to parse DNS monitor config:", error);
@@ -114,6 +168,14 @@ export const deserialize = (data: MonitorMonitorResponseDto): DNSForm => {
     resolver_server: config.resolver_server,
     port: config.port,
     resolve_type: config.resolve_type as DNSForm["resolve_type"],
+    transport: (config.transport || "udp") as DNSForm["transport"],
+    tls_server_name: config.tls_server_name || "",
+    doh_path: config.doh_path || "/dns-query",
+    ignore_tls_errors: config.ignore_tls_errors ?? false,
+    match_mode: (config.match_mode || "none") as DNSForm["match_mode"],
+    expected_values: (config.expected_values || []).join("\n"),
+    detect_soa_serial_change: config.detect_soa_serial_change ?? false,
+    dnssec: config.dnssec ?? false,
     interval: data.interval || 60,
     timeout: data.timeout || 16,
     max_retries: data.max_retries ?? 3,
@@ -121,6 +183,9 @@ export const deserialize = (data: MonitorMonitorResponseDto): DNSForm => {
     resend_interval: data.resend_interval ?? 10,
     notification_ids: data.notification_ids || [],
     tag_ids: data.tag_ids || [],
+    parent_ids: data.parent_ids || [],
+    probe_ids: data.probe_ids || [],
+    probe_down_threshold: data.probe_down_threshold || 1,
   };
 };
 
@@ -130,8 +195,25 @@ export const serialize = (formData: DNSForm): MonitorCreateUpdateDto => {
     resolver_server: formData.resolver_server,
     port: formData.port,
     resolve_type: formData.resolve_type,
+    transport: formData.transport,
+    dnssec: formData.dnssec,
   };
 
+  if (formData.transport === "dot" || formData.transport === "doh") {
+    config.tls_server_name = formData.tls_server_name || undefined;
+    config.ignore_tls_errors = formData.ignore_tls_errors;
+  }
+  if (formData.transport === "doh") {
+    config.doh_path = formData.doh_path || "/dns-query";
+  }
+  if (formData.match_mode !== "none") {
+    config.match_mode = formData.match_mode;
+    config.expected_values = parseExpectedValues(formData.expected_values || "");
+  }
+  if (formData.resolve_type === "SOA") {
+    config.detect_soa_serial_change = formData.detect_soa_serial_change;
+  }
+
   return {
     type: "dns",
     name: formData.name,
@@ -143,6 +225,9 @@ export const serialize = (formData: DNSForm): MonitorCreateUpdateDto => {
     timeout: formData.timeout,
     config: JSON.stringify(config),
     tag_ids: formData.tag_ids,
+    parent_ids: formData.parent_ids,
+    probe_ids: formData.probe_ids,
+    probe_down_threshold: formData.probe_down_threshold,
   };
 };
 
@@ -159,6 +244,10 @@ const DNSForm = () => {
     monitor,
   } = useMonitorFormContext();
 
+  const transport = form.watch("transport");
+  const matchMode = form.watch("match_mode");
+  const resolveType = form.watch("resolve_type");
+
   const onSubmit = (data: DNSForm) => {
     const payload = serialize(data);
 
@@ -293,12 +382,212 @@ const DNSForm = () => {
                 </FormItem>
               )}
             />
+
+            <FormField
+              control={form.control}
+              name="transport"
+              render={({ field }) => (
+                <FormItem>
+                  <FormLabel>{t("monitors.form.dns.transport")}</FormLabel>
+                  <Select
+                    onValueChange={(val) => {
+                      if (!val) {
+                        return;
+                      }
+                      field.onChange(val);
+                    }}
+                    value={field.value}
+                  >
+                    <FormControl>
+                      <SelectTrigger>
+                        <SelectValue />
+                      </SelectTrigger>
+                    </FormControl>
+                    <SelectContent>
+                      <SelectItem value="udp">UDP</SelectItem>
+                      <SelectItem value="tcp">TCP</SelectItem>
+                      <SelectItem value="dot">{t("monitors.form.dns.transport_dot")}</SelectItem>
+                      <SelectItem value="doh">{t("monitors.form.dns.transport_doh")}</SelectItem>
+                    </SelectContent>
+                  </Select>
+                  <FormMessage />
+                </FormItem>
+              )}
+            />
+
+            {(transport === "dot" || transport === "doh") && (
+              <>
+                <FormField
+                  control={form.control}
+                  name="tls_server_name"
+                  render={({ field }) => (
+                    <FormItem>
+                      <FormLabel>{t("monitors.form.dns.tls_server_name")}</FormLabel>
+                      <FormControl>
+                        <Input placeholder="cloudflare-dns.com" {...field} />
+                      </FormControl>
+                      <FormDescription>
+                        {t("monitors.form.dns.tls_server_name_description")}
+                      </FormDescription>
+                      <FormMessage />
+                    </FormItem>
+                  )}
+                />
+
+                {transport === "doh" && (
+                  <FormField
+                    control={form.control}
+                    name="doh_path"
+                    render={({ field }) => (
+                      <FormItem>
+                        <FormLabel>{t("monitors.form.dns.doh_path")}</FormLabel>
+                        <FormControl>
+                          <Input placeholder="/dns-query" {...field} />
+                        </FormControl>
+                        <FormDescription>
+                          {t("monitors.form.dns.doh_path_description")}
+                        </FormDescription>
+                        <FormMessage />
+                      </FormItem>
+                    )}
+                  />
+                )}
+
+                <FormField
+                  control={form.control}
+                  name="ignore_tls_errors"
+                  render={({ field }) => (
+                    <FormItem className="flex flex-row items-start space-x-3 space-y-0">
+                      <FormControl>
+                        <Checkbox
+                          checked={field.value}
+                          onCheckedChange={field.onChange}
+                        />
+                      </FormControl>
+                      <div className="space-y-1 leading-none">
+                        <FormLabel>{t("monitors.form.dns.ignore_tls_label")}</FormLabel>
+                        <FormDescription>
+                          {t("monitors.form.dns.ignore_tls_description")}
+                        </FormDescription>
+                      </div>
+                    </FormItem>
+                  )}
+                />
+              </>
+            )}
+
+            <FormField
+              control={form.control}
+              name="dnssec"
+              render={({ field }) => (
+                <FormItem className="flex flex-row items-start space-x-3 space-y-0">
+                  <FormControl>
+                    <Checkbox
+                      checked={field.value}
+                      onCheckedChange={field.onChange}
+                    />
+                  </FormControl>
+                  <div className="space-y-1 leading-none">
+                    <FormLabel>{t("monitors.form.dns.dnssec_label")}</FormLabel>
+                    <FormDescription>
+                      {t("monitors.form.dns.dnssec_description")}
+                    </FormDescription>
+                  </div>
+                </FormItem>
+              )}
+            />
+
+            {resolveType === "SOA" && (
+              <FormField
+                control={form.control}
+                name="detect_soa_serial_change"
+                render={({ field }) => (
+                  <FormItem className="flex flex-row items-start space-x-3 space-y-0">
+                    <FormControl>
+                      <Checkbox
+                        checked={field.value}
+                        onCheckedChange={field.onChange}
+                      />
+                    </FormControl>
+                    <div className="space-y-1 leading-none">
+                      <FormLabel>{t("monitors.form.dns.soa_serial_label")}</FormLabel>
+                      <FormDescription>
+                        {t("monitors.form.dns.soa_serial_description")}
+                      </FormDescription>
+                    </div>
+                  </FormItem>
+                )}
+              />
+            )}
+          </CardContent>
+        </Card>
+
+        <Card>
+          <CardContent className="space-y-4">
+            <TypographyH4>{t("monitors.form.dns.assertions_title")}</TypographyH4>
+
+            <FormField
+              control={form.control}
+              name="match_mode"
+              render={({ field }) => (
+                <FormItem>
+                  <FormLabel>{t("monitors.form.dns.match_mode")}</FormLabel>
+                  <Select
+                    onValueChange={(val) => {
+                      if (!val) {
+                        return;
+                      }
+                      field.onChange(val);
+                    }}
+                    value={field.value}
+                  >
+                    <FormControl>
+                      <SelectTrigger>
+                        <SelectValue />
+                      </SelectTrigger>
+                    </FormControl>
+                    <SelectContent>
+                      <SelectItem value="none">{t("monitors.form.dns.match_mode_none")}</SelectItem>
+                      <SelectItem value="exact">{t("monitors.form.dns.match_mode_exact")}</SelectItem>
+                      <SelectItem value="contains">{t("monitors.form.dns.match_mode_contains")}</SelectItem>
+                      <SelectItem value="regex">{t("monitors.form.dns.match_mode_regex")}</SelectItem>
+                    </SelectContent>
+                  </Select>
+                  <FormMessage />
+                </FormItem>
+              )}
+            />
+
+            {matchMode !== "none" && (
+              <FormField
+                control={form.control}
+                name="expected_values"
+                render={({ field }) => (
+                  <FormItem>
+                    <FormLabel>{t("monitors.form.dns.expected_values")}</FormLabel>
+                    <FormControl>
+                      <Textarea
+                        placeholder={matchMode === "regex" ? "^93\\.184\\." : "93.184.215.14"}
+                        rows={4}
+                        {...field}
+                      />
+                    </FormControl>
+                    <FormDescription>
+                      {t("monitors.form.dns.expected_values_description")}
+                    </FormDescription>
+                    <FormMessage />
+                  </FormItem>
+                )}
+              />
+            )}
           </CardContent>
         </Card>
 
         <Card>
           <CardContent className="space-y-4">
             <Tags />
+            <Dependencies />
+            <Locations />
           </CardContent>
         </Card>
 
@@ -19,6 +19,14 @@ import Tags, {
   tagsDefaultValues,
   tagsSchema,
 } from "../shared/tags";
+import Dependencies, {
+    dependenciesDefaultValues,
+    dependenciesSchema,
+} from "../shared/dependencies";
+import Locations, {
+    locationsDefaultValues,
+    locationsSchema,
+} from "../shared/locations";
 import { useMonitorFormContext } from "../../context/monitor-form-context";
 import {
   Form,
@@ -74,7 +82,9 @@ export const dockerSchema = z
   .merge(intervalsSchema)
   .merge(notificationsSchema)
   .merge(proxiesSchema)
-  .merge(tagsSchema);
+  .merge(tagsSchema)
+  .merge(dependenciesSchema)
+  .merge(locationsSchema);
 
 export type DockerForm = z.infer<typeof dockerSchema>;
 
@@ -92,6 +102,8 @@ export const dockerDefaultValues: DockerForm = {
   ...intervalsDefaultValues,
   ...notificationsDefaultValues,
   ...tagsDefaultValues,
+  ...dependenciesDefaultValues,
+  ...locationsDefaultValues,
 };
 
 export const deserialize = (data: MonitorMonitorResponseDto): DockerForm => {
@@ -143,6 +155,9 @@ export const deserialize = (data: MonitorMonitorResponseDto): DockerForm => {
     notification_ids: data.notification_ids || [],
     proxy_id: data.proxy_id || "",
     tag_ids: data.tag_ids || [],
+    parent_ids: data.parent_ids || [],
+    probe_ids: data.probe_ids || [],
+    probe_down_threshold: data.probe_down_threshold || 1,
   };
 };
 
@@ -172,6 +187,9 @@ export const serialize = (formData: DockerForm): MonitorCreateUpdateDto => {
     timeout: formData.timeout,
     config: JSON.stringify(config),
     tag_ids: formData.tag_ids,
+    parent_ids: formData.parent_ids,
+    probe_ids: formData.probe_ids,
+    probe_down_threshold: formData.probe_down_threshold,
   };
 };
 
@@ -490,6 +508,8 @@ const DockerForm = () => {
         <Card>
           <CardContent className="space-y-4">
             <Tags />
+            <Dependencies />
+            <Locations />
           </CardContent>
         </Card>
 
@@ -20,6 +20,14 @@ import Tags, {
   tagsDefaultValues,
   tagsSchema,
 } from "../shared/tags";
+import Dependencies, {
+    dependenciesDefaultValues,
+    dependenciesSchema,
+} from "../shared/dependencies";
+import Locations, {
+    locationsDefaultValues,
+    locationsSchema,
+} from "../shared/locations";
 
 import { useMonitorFormContext } from "../../context/monitor-form-context";
 import {
@@ -62,7 +70,9 @@ export const grpcKeywordSchema = z
   .merge(generalSchema)
   .merge(intervalsSchema)
   .merge(notificationsSchema)
-  .merge(tagsSchema);
+  .merge(tagsSchema)
+  .merge(dependenciesSchema)
+  .merge(locationsSchema);
 
 export type GRPCKeywordForm = z.infer<typeof grpcKeywordSchema>;
 
@@ -89,6 +99,8 @@ service Health {
   ...intervalsDefaultValues,
   ...notificationsDefaultValues,
   ...tagsDefaultValues,
+  ...dependenciesDefaultValues,
+  ...locationsDefaultValues,
 };
 
 export const deserialize = (
@@ -150,6 +162,9 @@ service Health {
     resend_interval: data.resend_interval ?? 10,
     notification_ids: data.notification_ids || [],
     tag_ids: data.tag_ids || [],
+    parent_ids: data.parent_ids || [],
+    probe_ids: data.probe_ids || [],
+    probe_down_threshold: data.probe_down_threshold || 1,
   };
 };
 
@@ -178,6 +193,9 @@ export const serialize = (
     timeout: formData.timeout,
     config: JSON.stringify(config),
     tag_ids: formData.tag_ids,
+    parent_ids: formData.parent_ids,
+    probe_ids: formData.probe_ids,
+    probe_down_threshold: formData.probe_down_threshold,
   };
 };
 
@@ -396,6 +414,8 @@ service Health {
         <Card>
           <CardContent className="space-y-4">
             <Tags />
+            <Dependencies />
+            <Locations />
           </CardContent>
         </Card>
 
@@ -16,8 +16,11 @@ import { Card, CardContent } from "@/components/ui/card";
 import Notifications from "../shared/notifications";
 import Proxies from "../shared/proxies";
 import Intervals from "../shared/intervals";
+import DegradedThresholds from "../shared/degraded-thresholds";
 import General from "../shared/general";
 import Tags from "../shared/tags";
+import Dependencies from "../shared/dependencies";
+import Locations from "../shared/locations";
 import { useMonitorFormContext } from "../../context/monitor-form-context";
 import { Button } from "@/components/ui/button";
 import { Loader2 } from "lucide-react";
@@ -187,6 +190,8 @@ const HttpJsonQuery = () => {
         <Card>
           <CardContent className="space-y-4">
             <Tags />
+            <Dependencies />
+            <Locations />
           </CardContent>
         </Card>
 
@@ -202,6 +207,12 @@ const HttpJsonQuery = () => {
           </CardContent>
         </Card>
 
+        <Card>
+          <CardContent className="space-y-4">
+            <DegradedThresholds showTlsDays />
+          </CardContent>
+        </Card>
+
         <Card>
           <CardContent className="space-y-4">
             <Advanced />
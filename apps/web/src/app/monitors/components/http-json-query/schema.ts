@@ -7,6 +7,9 @@ import { intervalsDefaultValues, intervalsSchema } from "../shared/intervals";
 import { notificationsDefaultValues, notificationsSchema } from "../shared/notifications";
 import { proxiesDefaultValues, proxiesSchema } from "../shared/proxies";
 import { tagsDefaultValues, tagsSchema } from "../shared/tags";
+import { dependenciesDefaultValues, dependenciesSchema } from "../shared/dependencies";
+import { locationsDefaultValues, locationsSchema } from "../shared/locations";
+import { degradedThresholdsDefaultValues, degradedThresholdsSchema, deserializeDegradedThresholds, serializeDegradedThresholds, type DegradedThresholdsConfig } from "../shared/degraded-thresholds";
 import type { MonitorMonitorResponseDto, MonitorCreateUpdateDto } from "@/api";
 
 export const httpJsonQuerySchema = z
@@ -22,6 +25,9 @@ export const httpJsonQuerySchema = z
   .merge(notificationsSchema)
   .merge(proxiesSchema)
   .merge(tagsSchema)
+  .merge(dependenciesSchema)
+  .merge(locationsSchema)
+  .merge(degradedThresholdsSchema)
   .merge(advancedSchema)
   .merge(
     z.object({
@@ -48,6 +54,9 @@ export const httpJsonQueryDefaultValues: HttpJsonQueryForm = {
   ...notificationsDefaultValues,
   ...proxiesDefaultValues,
   ...tagsDefaultValues,
+  ...dependenciesDefaultValues,
+  ...locationsDefaultValues,
+  ...degradedThresholdsDefaultValues,
   ...advancedDefaultValues,
 
   httpOptions: httpOptionsDefaultValues,
@@ -120,6 +129,9 @@ export const deserialize = (data: MonitorMonitorResponseDto): HttpJsonQueryForm
     resend_interval: data.resend_interval || 10,
     notification_ids: data.notification_ids || [],
     tag_ids: data.tag_ids || [],
+    parent_ids: data.parent_ids || [],
+    probe_ids: data.probe_ids || [],
+    probe_down_threshold: data.probe_down_threshold || 1,
     proxy_id: data.proxy_id || "",
     url: config.url || "https://example.com",
     accepted_statuscodes: config.accepted_statuscodes || ["2XX"],
@@ -133,6 +145,7 @@ export const deserialize = (data: MonitorMonitorResponseDto): HttpJsonQueryForm
     },
     authentication,
     check_cert_expiry: config.check_cert_expiry ?? false,
+    ...deserializeDegradedThresholds(config),
     json_query: config.json_query || "",
     json_condition: (config.json_condition as "==" | "!=" | ">" | "<" | ">=" | "<=") || "==",
     expected_value: config.expected_value || "",
@@ -151,6 +164,7 @@ export const serialize = (formData: HttpJsonQueryForm): MonitorCreateUpdateDto =
     ignore_tls_errors: formData.ignore_tls_errors,
     authMethod: formData.authentication.authMethod,
     check_cert_expiry: formData.check_cert_expiry,
+    ...serializeDegradedThresholds(formData),
 
     // JSON query validation fields
     json_query: formData.json_query || "",
@@ -190,6 +204,9 @@ export const serialize = (formData: HttpJsonQueryForm): MonitorCreateUpdateDto =
     retry_interval: formData.retry_interval,
     notification_ids: formData.notification_ids,
     tag_ids: formData.tag_ids,
+    parent_ids: formData.parent_ids,
+    probe_ids: formData.probe_ids,
+    probe_down_threshold: formData.probe_down_threshold,
     proxy_id: formData.proxy_id,
     resend_interval: formData.resend_interval,
     timeout: formData.timeout,
@@ -197,7 +214,7 @@ export const serialize = (formData: HttpJsonQueryForm): MonitorCreateUpdateDto =
   };
 };
 
-export interface HttpJsonQueryExecutorConfig {
+export interface HttpJsonQueryExecutorConfig extends DegradedThresholdsConfig {
   url: string;
   method: "GET" | "POST" | "PUT" | "DELETE" | "PATCH" | "HEAD" | "OPTIONS";
   headers?: string;
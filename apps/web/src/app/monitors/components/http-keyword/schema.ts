@@ -7,6 +7,9 @@ import { intervalsDefaultValues, intervalsSchema } from "../shared/intervals";
 import { notificationsDefaultValues, notificationsSchema } from "../shared/notifications";
 import { proxiesDefaultValues, proxiesSchema } from "../shared/proxies";
 import { tagsDefaultValues, tagsSchema } from "../shared/tags";
+import { dependenciesDefaultValues, dependenciesSchema } from "../shared/dependencies";
+import { locationsDefaultValues, locationsSchema } from "../shared/locations";
+import { degradedThresholdsDefaultValues, degradedThresholdsSchema, deserializeDegradedThresholds, serializeDegradedThresholds, type DegradedThresholdsConfig } from "../shared/degraded-thresholds";
 import type { MonitorMonitorResponseDto, MonitorCreateUpdateDto } from "@/api";
 
 export const httpKeywordSchema = z
@@ -21,6 +24,9 @@ export const httpKeywordSchema = z
   .merge(notificationsSchema)
   .merge(proxiesSchema)
   .merge(tagsSchema)
+  .merge(dependenciesSchema)
+  .merge(locationsSchema)
+  .merge(degradedThresholdsSchema)
   .merge(advancedSchema)
   .merge(
     z.object({
@@ -46,6 +52,9 @@ export const httpKeywordDefaultValues: HttpKeywordForm = {
   ...notificationsDefaultValues,
   ...proxiesDefaultValues,
   ...tagsDefaultValues,
+  ...dependenciesDefaultValues,
+  ...locationsDefaultValues,
+  ...degradedThresholdsDefaultValues,
   ...advancedDefaultValues,
 
   httpOptions: httpOptionsDefaultValues,
@@ -118,6 +127,9 @@ export const deserialize = (data: MonitorMonitorResponseDto): HttpKeywordForm =>
     resend_interval: data.resend_interval || 10,
     notification_ids: data.notification_ids || [],
     tag_ids: data.tag_ids || [],
+    parent_ids: data.parent_ids || [],
+    probe_ids: data.probe_ids || [],
+    probe_down_threshold: data.probe_down_threshold || 1,
     proxy_id: data.proxy_id || "",
     url: config.url || "https://example.com",
     accepted_statuscodes: config.accepted_statuscodes || ["2XX"],
@@ -131,6 +143,7 @@ export const deserialize = (data: MonitorMonitorResponseDto): HttpKeywordForm =>
     },
     authentication,
     check_cert_expiry: config.check_cert_expiry ?? false,
+    ...deserializeDegradedThresholds(config),
     keyword: config.keyword || "",
     invert_keyword: config.invert_keyword || false,
   };
@@ -148,6 +161,7 @@ export const serialize = (formData: HttpKeywordForm): MonitorCreateUpdateDto =>
     ignore_tls_errors: formData.ignore_tls_errors,
     authMethod: formData.authentication.authMethod,
     check_cert_expiry: formData.check_cert_expiry,
+    ...serializeDegradedThresholds(formData),
 
     // Keyword validation fields
     keyword: formData.keyword,
@@ -186,6 +200,9 @@ export const serialize = (formData: HttpKeywordForm): MonitorCreateUpdateDto =>
     retry_interval: formData.retry_interval,
     notification_ids: formData.notification_ids,
     tag_ids: formData.tag_ids,
+    parent_ids: formData.parent_ids,
+    probe_ids: formData.probe_ids,
+    probe_down_threshold: formData.probe_down_threshold,
     proxy_id: formData.proxy_id,
     resend_interval: formData.resend_interval,
     timeout: formData.timeout,
@@ -193,7 +210,7 @@ export const serialize = (formData: HttpKeywordForm): MonitorCreateUpdateDto =>
   };
 };
 
-export interface HttpKeywordExecutorConfig {
+export interface HttpKeywordExecutorConfig extends DegradedThresholdsConfig {
   url: string;
   method: "GET" | "POST" | "PUT" | "DELETE" | "PATCH" | "HEAD" | "OPTIONS";
   headers?: string;
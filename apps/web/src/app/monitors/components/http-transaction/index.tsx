@@ -0,0 +1,233 @@
+import {
+  Form,
+  FormControl,
+  FormDescription,
+  FormField,
+  FormItem,
+  FormLabel,
+  FormMessage,
+} from "@/components/ui/form";
+import { Input } from "@/components/ui/input";
+import { Checkbox } from "@/components/ui/checkbox";
+import { Card, CardContent } from "@/components/ui/card";
+import { TypographyH4 } from "@/components/ui/typography";
+import Notifications from "../shared/notifications";
+import Proxies from "../shared/proxies";
+import Intervals from "../shared/intervals";
+import General from "../shared/general";
+import Tags from "../shared/tags";
+import Dependencies from "../shared/dependencies";
+import Locations from "../shared/locations";
+import { useMonitorFormContext } from "../../context/monitor-form-context";
+import { Button } from "@/components/ui/button";
+import { Loader2, Plus } from "lucide-react";
+import { useFieldArray } from "react-hook-form";
+import type { HttpTransactionForm } from "./schema";
+import { deserialize, httpTransactionDefaultValues, serialize, stepDefaultValues } from "./schema";
+import TransactionStep from "./step";
+import { useEffect } from "react";
+import { useLocalizedTranslation } from "@/hooks/useTranslation";
+
+const HttpTransaction = () => {
+  const { t } = useLocalizedTranslation();
+  const {
+    form,
+    setNotifierSheetOpen,
+    setProxySheetOpen,
+    isPending,
+    mode,
+    createMonitorMutation,
+    editMonitorMutation,
+    monitorId,
+    monitor,
+  } = useMonitorFormContext();
+
+  const { fields: stepFields, append: appendStep, remove: removeStep, move: moveStep } = useFieldArray({
+    control: form.control,
+    name: "steps",
+  });
+
+  const onSubmit = (data: HttpTransactionForm) => {
+    const payload = serialize(data);
+
+    if (mode === "create") {
+      createMonitorMutation.mutate({
+        body: {
+          ...payload,
+          active: true,
+        },
+      });
+    } else {
+      editMonitorMutation.mutate({
+        path: {
+          id: monitorId!,
+        },
+        body: {
+          ...payload,
+          active: monitor?.data?.active,
+        },
+      });
+    }
+  };
+
+  // Reset form with monitor data in edit mode, the steps are not part of the
+  // defaults of other monitor types so they are set when creating
+  useEffect(() => {
+    if (mode === "edit" && monitor?.data) {
+      const parsedConfig = deserialize(monitor.data);
+      form.reset(parsedConfig)
+    } else if (mode === "create") {
+      const currentName = form.getValues("name");
+      form.reset({
+        ...httpTransactionDefaultValues,
+        name: currentName || httpTransactionDefaultValues.name,
+      });
+    }
+  }, [form, monitor, mode]);
+
+  return (
+    <Form {...form}>
+      <form
+        onSubmit={form.handleSubmit((data) => onSubmit(data as HttpTransactionForm))}
+        className="space-y-6 max-w-[800px]"
+      >
+        <Card>
+          <CardContent className="space-y-4">
+            <General />
+          </CardContent>
+        </Card>
+
+        <Card>
+          <CardContent className="space-y-4">
+            <div className="flex items-center justify-between">
+              <div>
+                <TypographyH4>{t("monitors.form.http_transaction.steps_title")}</TypographyH4>
+                <p className="text-xs text-muted-foreground">
+                  {t("monitors.form.http_transaction.steps_description", { placeholder: "{{variable}}" })}
+                </p>
+              </div>
+              <Button
+                type="button"
+                variant="outline"
+                size="sm"
+                disabled={stepFields.length >= 20}
+                onClick={() => appendStep({ ...stepDefaultValues, name: `step${stepFields.length + 1}` })}
+              >
+                <Plus className="h-4 w-4 mr-1" />
+                {t("monitors.form.http_transaction.add_step")}
+              </Button>
+            </div>
+
+            {stepFields.map((stepField, index) => (
+              <TransactionStep
+                key={stepField.id}
+                index={index}
+                count={stepFields.length}
+                onRemove={() => removeStep(index)}
+                onMove={(to) => moveStep(index, to)}
+              />
+            ))}
+          </CardContent>
+        </Card>
+
+        <Card>
+          <CardContent className="space-y-4">
+            <TypographyH4>{t("monitors.form.http.advanced.title")}</TypographyH4>
+
+            <FormField
+              control={form.control}
+              name="max_redirects"
+              render={({ field }) => (
+                <FormItem>
+                  <FormLabel>{t("monitors.form.http.advanced.max_redirects")}</FormLabel>
+                  <FormControl>
+                    <Input placeholder="10" {...field} type="number" />
+                  </FormControl>
+                  <FormDescription>
+                    {t("monitors.form.http.advanced.max_redirects_description")}
+                  </FormDescription>
+                  <FormMessage />
+                </FormItem>
+              )}
+            />
+
+            <FormField
+              control={form.control}
+              name="ignore_tls_errors"
+              render={({ field }) => (
+                <FormItem className="flex flex-row items-start space-x-3 space-y-0">
+                  <FormControl>
+                    <Checkbox
+                      checked={field.value}
+                      onCheckedChange={field.onChange}
+                    />
+                  </FormControl>
+                  <div className="space-y-1 leading-none">
+                    <FormLabel>{t("monitors.form.http.advanced.ignore_tls")}</FormLabel>
+                    <FormDescription>
+                      {t("monitors.form.http.advanced.ignore_tls_description")}
+                    </FormDescription>
+                  </div>
+                </FormItem>
+              )}
+            />
+
+            <FormField
+              control={form.control}
+              name="check_cert_expiry"
+              render={({ field }) => (
+                <FormItem className="flex flex-row items-start space-x-3 space-y-0">
+                  <FormControl>
+                    <Checkbox
+                      checked={field.value}
+                      onCheckedChange={field.onChange}
+                    />
+                  </FormControl>
+                  <div className="space-y-1 leading-none">
+                    <FormLabel>{t("monitors.form.http_transaction.check_cert_expiry_label")}</FormLabel>
+                    <FormDescription>
+                      {t("monitors.form.http_transaction.check_cert_expiry_description")}
+                    </FormDescription>
+                  </div>
+                </FormItem>
+              )}
+            />
+          </CardContent>
+        </Card>
+
+        <Card>
+          <CardContent className="space-y-4">
+            <Notifications onNewNotifier={() => setNotifierSheetOpen(true)} />
+          </CardContent>
+        </Card>
+
+        <Card>
+          <CardContent className="space-y-4">
+            <Tags />
+            <Dependencies />
+            <Locations />
+          </CardContent>
+        </Card>
+
+        <Card>
+          <CardContent className="space-y-4">
+            <Proxies onNewProxy={() => setProxySheetOpen(true)} />
+          </CardContent>
+        </Card>
+
+        <Card>
+          <CardContent className="space-y-4">
+            <Intervals />
+          </CardContent>
+        </Card>
+
+        <Button type="submit">
+          {isPending && <Loader2 className="animate-spin" />}
+          {mode === "create" ? t("monitors.form.buttons.create") : t("monitors.form.buttons.update")}
+        </Button>
+      </form>
+    </Form>
+  );
+};
+
+export default HttpTransaction;
@@ -0,0 +1,207 @@
+import z from "zod";
+import { generalDefaultValues, generalSchema } from "../shared/general";
+import { intervalsDefaultValues, intervalsSchema } from "../shared/intervals";
+import { notificationsDefaultValues, notificationsSchema } from "../shared/notifications";
+import { proxiesDefaultValues, proxiesSchema } from "../shared/proxies";
+import { tagsDefaultValues, tagsSchema } from "../shared/tags";
+import { dependenciesDefaultValues, dependenciesSchema } from "../shared/dependencies";
+import { locationsDefaultValues, locationsSchema } from "../shared/locations";
+import type { MonitorMonitorResponseDto, MonitorCreateUpdateDto } from "@/api";
+
+type StatusCode = "2XX" | "3XX" | "4XX" | "5XX";
+type JsonCondition = "==" | "!=" | ">" | "<" | ">=" | "<=";
+
+export const extractSchema = z.object({
+  variable: z
+    .string()
+    .regex(/^[A-Za-z_][A-Za-z0-9_]*$/, "Use letters, digits and underscores"),
+  source: z.enum(["json", "regex", "header"]),
+  expression: z.string().min(1, "Expression is required"),
+});
+
+export const stepSchema = z.object({
+  name: z.string().min(1, "Step name is required").max(100),
+  // Placeholders such as {{token}} are allowed, the server checks the final URL
+  url: z.string().min(1, "URL is required"),
+  method: z.enum(["GET", "POST", "PUT", "DELETE", "PATCH", "HEAD", "OPTIONS"]),
+  encoding: z.enum(["json", "form", "xml", "text"]),
+  headers: z.string().optional(),
+  body: z.string().optional(),
+  accepted_statuscodes: z.array(z.string()).min(1),
+  keyword: z.string().optional(),
+  invert_keyword: z.boolean(),
+  json_query: z.string().optional(),
+  json_condition: z.enum(["==", "!=", ">", "<", ">=", "<="]),
+  expected_value: z.string().optional(),
+  extract: z.array(extractSchema).max(20),
+});
+
+export type TransactionStepForm = z.infer<typeof stepSchema>;
+
+export const httpTransactionSchema = z
+  .object({
+    type: z.literal("http-transaction"),
+    steps: z.array(stepSchema).min(1).max(20),
+    max_redirects: z.coerce.number().min(0).max(30),
+    ignore_tls_errors: z.boolean(),
+    check_cert_expiry: z.boolean(),
+  })
+  .merge(generalSchema)
+  .merge(intervalsSchema)
+  .merge(notificationsSchema)
+  .merge(proxiesSchema)
+  .merge(tagsSchema)
+  .merge(dependenciesSchema)
+  .merge(locationsSchema);
+
+export type HttpTransactionForm = z.infer<typeof httpTransactionSchema>;
+
+export const stepDefaultValues: TransactionStepForm = {
+  name: "",
+  url: "https://",
+  method: "GET",
+  encoding: "json",
+  headers: "",
+  body: "",
+  accepted_statuscodes: ["2XX"],
+  keyword: "",
+  invert_keyword: false,
+  json_query: "",
+  json_condition: "==",
+  expected_value: "",
+  extract: [],
+};
+
+export const httpTransactionDefaultValues: HttpTransactionForm = {
+  type: "http-transaction",
+  steps: [{ ...stepDefaultValues, name: "home", url: "https://example.com" }],
+  max_redirects: 10,
+  ignore_tls_errors: false,
+  check_cert_expiry: false,
+
+  ...generalDefaultValues,
+  ...intervalsDefaultValues,
+  ...notificationsDefaultValues,
+  ...proxiesDefaultValues,
+  ...tagsDefaultValues,
+  ...dependenciesDefaultValues,
+  ...locationsDefaultValues,
+};
+
+export interface HttpTransactionStepConfig {
+  name: string;
+  url: string;
+  method: TransactionStepForm["method"];
+  headers?: string;
+  encoding?: TransactionStepForm["encoding"];
+  body?: string;
+  accepted_statuscodes: StatusCode[];
+  keyword?: string;
+  invert_keyword?: boolean;
+  json_query?: string;
+  json_condition?: JsonCondition;
+  expected_value?: string;
+  extract?: Array<{
+    variable: string;
+    source: "json" | "regex" | "header";
+    expression: string;
+  }>;
+}
+
+export interface HttpTransactionExecutorConfig {
+  steps: HttpTransactionStepConfig[];
+  max_redirects: number;
+  ignore_tls_errors: boolean;
+  check_cert_expiry: boolean;
+}
+
+export const deserialize = (data: MonitorMonitorResponseDto): HttpTransactionForm => {
+  let config: Partial<HttpTransactionExecutorConfig> = {};
+  try {
+    config = data.config ? JSON.parse(data.config) : {};
+  } catch (error) {
+    console.error("Failed to parse HTTP transaction monitor config:", error);
+    config = {};
+  }
+
+  const steps = (config.steps || []).map((step) => ({
+    name: step.name || "",
+    url: step.url || "",
+    method: step.method || "GET",
+    encoding: step.encoding || "json",
+    headers: step.headers || "",
+    body: step.body || "",
+    accepted_statuscodes: step.accepted_statuscodes || ["2XX"],
+    keyword: step.keyword || "",
+    invert_keyword: step.invert_keyword ?? false,
+    json_query: step.json_query || "",
+    json_condition: step.json_condition || "==",
+    expected_value: step.expected_value || "",
+    extract: step.extract || [],
+  }));
+
+  return {
+    type: "http-transaction",
+    name: data.name || "My Monitor",
+    interval: data.interval || 60,
+    timeout: data.timeout || 16,
+    max_retries: data.max_retries || 3,
+    retry_interval: data.retry_interval || 60,
+    resend_interval: data.resend_interval || 10,
+    notification_ids: data.notification_ids || [],
+    tag_ids: data.tag_ids || [],
+    parent_ids: data.parent_ids || [],
+    probe_ids: data.probe_ids || [],
+    probe_down_threshold: data.probe_down_threshold || 1,
+    proxy_id: data.proxy_id || "",
+    steps: steps.length > 0 ? steps : httpTransactionDefaultValues.steps,
+    max_redirects: config.max_redirects ?? 10,
+    ignore_tls_errors: config.ignore_tls_errors ?? false,
+    check_cert_expiry: config.check_cert_expiry ?? false,
+  };
+};
+
+export const serialize = (formData: HttpTransactionForm): MonitorCreateUpdateDto => {
+  const config: HttpTransactionExecutorConfig = {
+    steps: formData.steps.map((step) => ({
+      name: step.name,
+      url: step.url,
+      method: step.method,
+      encoding: step.encoding,
+      accepted_statuscodes: step.accepted_statuscodes as StatusCode[],
+      ...(step.headers && { headers: step.headers }),
+      ...(step.body && { body: step.body }),
+      ...(step.keyword && {
+        keyword: step.keyword,
+        invert_keyword: step.invert_keyword,
+      }),
+      // An expected value without a query compares the whole response
+      ...((step.json_query || step.expected_value) && {
+        json_query: step.json_query || "",
+        json_condition: step.json_condition,
+        expected_value: step.expected_value || "",
+      }),
+      ...(step.extract.length > 0 && { extract: step.extract }),
+    })),
+    max_redirects: formData.max_redirects,
+    ignore_tls_errors: formData.ignore_tls_errors,
+    check_cert_expiry: formData.check_cert_expiry,
+  };
+
+  return {
+    type: "http-transaction",
+    name: formData.name,
+    interval: formData.interval,
+    max_retries: formData.max_retries,
+    retry_interval: formData.retry_interval,
+    notification_ids: formData.notification_ids,
+    tag_ids: formData.tag_ids,
+    parent_ids: formData.parent_ids,
+    probe_ids: formData.probe_ids,
+    probe_down_threshold: formData.probe_down_threshold,
+    proxy_id: formData.proxy_id,
+    resend_interval: formData.resend_interval,
+    timeout: formData.timeout,
+    config: JSON.stringify(config),
+  };
+};
@@ -0,0 +1,374 @@
+import { MultiSelect } from "@/components/multi-select";
+import {
+  FormControl,
+  FormDescription,
+  FormField,
+  FormItem,
+  FormLabel,
+  FormMessage,
+} from "@/components/ui/form";
+import { Input } from "@/components/ui/input";
+import { Textarea } from "@/components/ui/textarea";
+import { Checkbox } from "@/components/ui/checkbox";
+import { Button } from "@/components/ui/button";
+import { Select, SelectContent, SelectItem, SelectTrigger, SelectValue } from "@/components/ui/select";
+import { ArrowDown, ArrowUp, Plus, Trash2 } from "lucide-react";
+import { useFieldArray, useFormContext } from "react-hook-form";
+import { useLocalizedTranslation } from "@/hooks/useTranslation";
+import type { HttpTransactionForm } from "./schema";
+
+const acceptedStatusCodesOptions = [
+  { value: "2XX", label: "2XX" },
+  { value: "3XX", label: "3XX" },
+  { value: "4XX", label: "4XX" },
+  { value: "5XX", label: "5XX" },
+];
+
+interface TransactionStepProps {
+  index: number;
+  count: number;
+  onRemove: () => void;
+  onMove: (to: number) => void;
+}
+
+const TransactionStep = ({ index, count, onRemove, onMove }: TransactionStepProps) => {
+  const { t } = useLocalizedTranslation();
+  const form = useFormContext<HttpTransactionForm>();
+
+  const { fields: extractFields, append: appendExtract, remove: removeExtract } = useFieldArray({
+    control: form.control,
+    name: `steps.${index}.extract`,
+  });
+
+  const method = form.watch(`steps.${index}.method`);
+
+  return (
+    <div className="space-y-4 rounded-md border p-4">
+      <div className="flex items-end gap-2">
+        <span className="pb-2 text-sm font-semibold text-muted-foreground">{index + 1}.</span>
+        <FormField
+          control={form.control}
+          name={`steps.${index}.name`}
+          render={({ field }) => (
+            <FormItem className="flex-1">
+              <FormLabel>{t("monitors.form.http_transaction.step_name")}</FormLabel>
+              <FormControl>
+                <Input placeholder="login" {...field} />
+              </FormControl>
+              <FormMessage />
+            </FormItem>
+          )}
+        />
+        <Button
+          type="button"
+          variant="ghost"
+          size="sm"
+          disabled={index === 0}
+          onClick={() => onMove(index - 1)}
+        >
+          <ArrowUp className="h-4 w-4" />
+        </Button>
+        <Button
+          type="button"
+          variant="ghost"
+          size="sm"
+          disabled={index === count - 1}
+          onClick={() => onMove(index + 1)}
+        >
+          <ArrowDown className="h-4 w-4" />
+        </Button>
+        <Button
+          type="button"
+          variant="ghost"
+          size="sm"
+          disabled={count === 1}
+          onClick={onRemove}
+          className="text-destructive hover:text-destructive"
+        >
+          <Trash2 className="h-4 w-4" />
+        </Button>
+      </div>
+
+      <div className="flex items-start gap-2">
+        <FormField
+          control={form.control}
+          name={`steps.${index}.method`}
+          render={({ field }) => (
+            <FormItem className="w-32">
+              <FormLabel>{t("monitors.form.http_transaction.method")}</FormLabel>
+              <Select onValueChange={field.onChange} value={field.value}>
+                <FormControl>
+                  <SelectTrigger>
+                    <SelectValue />
+                  </SelectTrigger>
+                </FormControl>
+                <SelectContent>
+                  {["GET", "POST", "PUT", "DELETE", "PATCH", "HEAD", "OPTIONS"].map((m) => (
+                    <SelectItem key={m} value={m}>
+                      {m}
+                    </SelectItem>
+                  ))}
+                </SelectContent>
+              </Select>
+              <FormMessage />
+            </FormItem>
+          )}
+        />
+        <FormField
+          control={form.control}
+          name={`steps.${index}.url`}
+          render={({ field }) => (
+            <FormItem className="flex-1">
+              <FormLabel>URL</FormLabel>
+              <FormControl>
+                <Input placeholder="https://api.example.com/items/{{item_id}}" {...field} />
+              </FormControl>
+              <FormMessage />
+            </FormItem>
+          )}
+        />
+      </div>
+
+      <FormField
+        control={form.control}
+        name={`steps.${index}.headers`}
+        render={({ field }) => (
+          <FormItem>
+            <FormLabel>{t("monitors.form.http_transaction.headers")}</FormLabel>
+            <FormControl>
+              <Textarea placeholder='{ "Authorization": "Bearer {{token}}" }' rows={2} {...field} />
+            </FormControl>
+            <FormMessage />
+          </FormItem>
+        )}
+      />
+
+      {method !== "GET" && method !== "HEAD" && (
+        <div className="flex items-start gap-2">
+          <FormField
+            control={form.control}
+            name={`steps.${index}.encoding`}
+            render={({ field }) => (
+              <FormItem className="w-32">
+                <FormLabel>{t("monitors.form.http_transaction.encoding")}</FormLabel>
+                <Select onValueChange={field.onChange} value={field.value}>
+                  <FormControl>
+                    <SelectTrigger>
+                      <SelectValue />
+                    </SelectTrigger>
+                  </FormControl>
+                  <SelectContent>
+                    <SelectItem value="json">JSON</SelectItem>
+                    <SelectItem value="form">Form</SelectItem>
+                    <SelectItem value="xml">XML</SelectItem>
+                    <SelectItem value="text">Text</SelectItem>
+                  </SelectContent>
+                </Select>
+                <FormMessage />
+              </FormItem>
+            )}
+          />
+          <FormField
+            control={form.control}
+            name={`steps.${index}.body`}
+            render={({ field }) => (
+              <FormItem className="flex-1">
+                <FormLabel>{t("monitors.form.http_transaction.body")}</FormLabel>
+                <FormControl>
+                  <Textarea placeholder='{ "username": "monitor" }' rows={3} {...field} />
+                </FormControl>
+                <FormMessage />
+              </FormItem>
+            )}
+          />
+        </div>
+      )}
+
+      <FormField
+        control={form.control}
+        name={`steps.${index}.accepted_statuscodes`}
+        render={({ field }) => (
+          <FormItem>
+            <FormLabel>{t("monitors.form.http.advanced.accepted_status_codes")}</FormLabel>
+            <FormControl>
+              <MultiSelect
+                options={acceptedStatusCodesOptions}
+                onValueChange={(val) => {
+                  field.onChange(val)
+                }}
+                value={field.value || []}
+              />
+            </FormControl>
+            <FormMessage />
+          </FormItem>
+        )}
+      />
+
+      <div className="flex items-end gap-2">
+        <FormField
+          control={form.control}
+          name={`steps.${index}.keyword`}
+          render={({ field }) => (
+            <FormItem className="flex-1">
+              <FormLabel>{t("monitors.form.http_transaction.keyword")}</FormLabel>
+              <FormControl>
+                <Input placeholder="Welcome" {...field} />
+              </FormControl>
+              <FormMessage />
+            </FormItem>
+          )}
+        />
+        <FormField
+          control={form.control}
+          name={`steps.${index}.invert_keyword`}
+          render={({ field }) => (
+            <FormItem className="flex flex-row items-center space-x-2 space-y-0 pb-2">
+              <FormControl>
+                <Checkbox
+                  checked={field.value}
+                  onCheckedChange={field.onChange}
+                />
+              </FormControl>
+              <FormLabel>{t("monitors.form.http_transaction.invert_keyword")}</FormLabel>
+            </FormItem>
+          )}
+        />
+      </div>
+
+      <div className="flex items-start gap-2">
+        <FormField
+          control={form.control}
+          name={`steps.${index}.json_query`}
+          render={({ field }) => (
+            <FormItem className="flex-1">
+              <FormLabel>{t("monitors.form.http_json_query.json_query_label")}</FormLabel>
+              <FormControl>
+                <Input placeholder="data.status" {...field} />
+              </FormControl>
+              <FormMessage />
+            </FormItem>
+          )}
+        />
+        <FormField
+          control={form.control}
+          name={`steps.${index}.json_condition`}
+          render={({ field }) => (
+            <FormItem className="w-24">
+              <FormLabel>{t("monitors.form.http_json_query.condition_label")}</FormLabel>
+              <Select onValueChange={field.onChange} value={field.value}>
+                <FormControl>
+                  <SelectTrigger>
+                    <SelectValue />
+                  </SelectTrigger>
+                </FormControl>
+                <SelectContent>
+                  <SelectItem value="==">==</SelectItem>
+                  <SelectItem value="!=">!=</SelectItem>
+                  <SelectItem value=">">&gt;</SelectItem>
+                  <SelectItem value="<">&lt;</SelectItem>
+                  <SelectItem value=">=">&gt;=</SelectItem>
+                  <SelectItem value="<=">&lt;=</SelectItem>
+                </SelectContent>
+              </Select>
+              <FormMessage />
+            </FormItem>
+          )}
+        />
+        <FormField
+          control={form.control}
+          name={`steps.${index}.expected_value`}
+          render={({ field }) => (
+            <FormItem className="flex-1">
+              <FormLabel>{t("monitors.form.http_json_query.expected_value_label")}</FormLabel>
+              <FormControl>
+                <Input placeholder="ok" {...field} />
+              </FormControl>
+              <FormMessage />
+            </FormItem>
+          )}
+        />
+      </div>
+
+      <div className="space-y-2">
+        <div className="flex items-center justify-between">
+          <div>
+            <FormLabel>{t("monitors.form.http_transaction.extract_title")}</FormLabel>
+            <FormDescription>
+              {t("monitors.form.http_transaction.extract_description")}
+            </FormDescription>
+          </div>
+          <Button
+            type="button"
+            variant="outline"
+            size="sm"
+            onClick={() => appendExtract({ variable: "", source: "json", expression: "" })}
+          >
+            <Plus className="h-4 w-4 mr-1" />
+            {t("monitors.form.http_transaction.add_extract")}
+          </Button>
+        </div>
+
+        {extractFields.map((extractField, extractIndex) => (
+          <div key={extractField.id} className="flex items-start gap-2">
+            <FormField
+              control={form.control}
+              name={`steps.${index}.extract.${extractIndex}.variable`}
+              render={({ field }) => (
+                <FormItem className="flex-1">
+                  <FormControl>
+                    <Input placeholder="token" {...field} />
+                  </FormControl>
+                  <FormMessage />
+                </FormItem>
+              )}
+            />
+            <FormField
+              control={form.control}
+              name={`steps.${index}.extract.${extractIndex}.source`}
+              render={({ field }) => (
+                <FormItem className="w-28">
+                  <Select onValueChange={field.onChange} value={field.value}>
+                    <FormControl>
+                      <SelectTrigger>
+                        <SelectValue />
+                      </SelectTrigger>
+                    </FormControl>
+                    <SelectContent>
+                      <SelectItem value="json">JSON</SelectItem>
+                      <SelectItem value="regex">Regex</SelectItem>
+                      <SelectItem value="header">Header</SelectItem>
+                    </SelectContent>
+                  </Select>
+                  <FormMessage />
+                </FormItem>
+              )}
+            />
+            <FormField
+              control={form.control}
+              name={`steps.${index}.extract.${extractIndex}.expression`}
+              render={({ field }) => (
+                <FormItem className="flex-1">
+                  <FormControl>
+                    <Input placeholder="data.access_token" {...field} />
+                  </FormControl>
+                  <FormMessage />
+                </FormItem>
+              )}
+            />
+            <Button
+              type="button"
+              variant="ghost"
+              size="sm"
+              onClick={() => removeExtract(extractIndex)}
+              className="text-destructive hover:text-destructive"
+            >
+              <Trash2 className="h-4 w-4" />
+            </Button>
+          </div>
+        ))}
+      </div>
+    </div>
+  );
+};
+
+export default TransactionStep;
@@ -7,6 +7,9 @@ import { intervalsDefaultValues, intervalsSchema } from "../shared/intervals";
 import { notificationsDefaultValues, notificationsSchema } from "../shared/notifications";
 import { proxiesDefaultValues, proxiesSchema } from "../shared/proxies";
 import { tagsDefaultValues, tagsSchema } from "../shared/tags";
+import { dependenciesDefaultValues, dependenciesSchema } from "../shared/dependencies";
+import { locationsDefaultValues, locationsSchema } from "../shared/locations";
+import { degradedThresholdsDefaultValues, degradedThresholdsSchema, deserializeDegradedThresholds, serializeDegradedThresholds, type DegradedThresholdsConfig } from "../shared/degraded-thresholds";
 import type { MonitorMonitorResponseDto, MonitorCreateUpdateDto } from "@/api";
 
 export const httpSchema = z
@@ -19,6 +22,9 @@ export const httpSchema = z
   .merge(notificationsSchema)
   .merge(proxiesSchema)
   .merge(tagsSchema)
+  .merge(dependenciesSchema)
+  .merge(locationsSchema)
+  .merge(degradedThresholdsSchema)
   .merge(advancedSchema)
   .merge(
     z.object({
@@ -42,6 +48,9 @@ export const httpDefaultValues: HttpForm = {
   ...notificationsDefaultValues,
   ...proxiesDefaultValues,
   ...tagsDefaultValues,
+  ...dependenciesDefaultValues,
+  ...locationsDefaultValues,
+  ...degradedThresholdsDefaultValues,
   ...advancedDefaultValues,
 
   httpOptions: httpOptionsDefaultValues,
@@ -114,6 +123,9 @@ export const deserialize = (data: MonitorMonitorResponseDto): HttpForm => {
     resend_interval: data.resend_interval || 10,
     notification_ids: data.notification_ids || [],
     tag_ids: data.tag_ids || [],
+    parent_ids: data.parent_ids || [],
+    probe_ids: data.probe_ids || [],
+    probe_down_threshold: data.probe_down_threshold || 1,
     proxy_id: data.proxy_id || "",
     url: config.url || "https://example.com",
     accepted_statuscodes: config.accepted_statuscodes || ["2XX"],
@@ -127,6 +139,7 @@ export const deserialize = (data: MonitorMonitorResponseDto): HttpForm => {
     },
     authentication,
     check_cert_expiry: config.check_cert_expiry ?? false,
+    ...deserializeDegradedThresholds(config),
   };
 };
 
@@ -142,6 +155,7 @@ export const serialize = (formData: HttpForm): MonitorCreateUpdateDto => {
     ignore_tls_errors: formData.ignore_tls_errors,
     authMethod: formData.authentication.authMethod,
     check_cert_expiry: formData.check_cert_expiry,
+    ...serializeDegradedThresholds(formData),
 
     // Include authentication fields based on method
     ...(formData.authentication.authMethod === "basic" && {
@@ -176,6 +190,9 @@ export const serialize = (formData: HttpForm): MonitorCreateUpdateDto => {
     retry_interval: formData.retry_interval,
     notification_ids: formData.notification_ids,
     tag_ids: formData.tag_ids,
+    parent_ids: formData.parent_ids,
+    probe_ids: formData.probe_ids,
+    probe_down_threshold: formData.probe_down_threshold,
     proxy_id: formData.proxy_id,
     resend_interval: formData.resend_interval,
     timeout: formData.timeout,
@@ -183,7 +200,7 @@ export const serialize = (formData: HttpForm): MonitorCreateUpdateDto => {
   };
 };
 
-export interface HttpExecutorConfig {
+export interface HttpExecutorConfig extends DegradedThresholdsConfig {
   url: string; // required, must be a valid URL
 
   method: "GET" | "POST" | "PUT" | "DELETE" | "PATCH" | "HEAD" | "OPTIONS"; // required
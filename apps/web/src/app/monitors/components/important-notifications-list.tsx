@@ -71,12 +71,14 @@ const ImportantNotificationsList = ({ monitorId }: { monitorId: string }) => {
                   "bg-red-500 border-red-600":
                     hb.status === 0 || hb.status === 2,
                   "bg-blue-500 border-blue-600": hb.status === 3,
+                  "bg-amber-500 border-amber-600": hb.status === 4,
                 })}
               >
                 {hb.status === 1 && t("common.up")}
                 {hb.status === 0 && t("common.down")}
                 {hb.status === 2 && t("common.unknown")}
                 {hb.status === 3 && t("common.maintenance")}
+                {hb.status === 4 && t("common.degraded")}
               </Badge>
               <span className="text-xs text-muted-foreground">
                 {hb.time && formatDateToTimezone(hb.time, timezone)}
@@ -28,6 +28,8 @@ import General from "../shared/general";
 import Intervals from "../shared/intervals";
 import Notifications from "../shared/notifications";
 import Tags from "../shared/tags";
+import Dependencies from "../shared/dependencies";
+import Locations from "../shared/locations";
 import {
   type KafkaProducerForm as KafkaProducerFormType,
   kafkaProducerDefaultValues,
@@ -350,6 +352,8 @@ const KafkaProducerForm = () => {
         <Card>
           <CardContent className="space-y-4">
             <Tags />
+            <Dependencies />
+            <Locations />
           </CardContent>
         </Card>
 
@@ -3,6 +3,8 @@ import { generalDefaultValues, generalSchema } from "../shared/general";
 import { intervalsDefaultValues, intervalsSchema } from "../shared/intervals";
 import { notificationsSchema } from "../shared/notifications";
 import { tagsSchema } from "../shared/tags";
+import { dependenciesSchema } from "../shared/dependencies";
+import { locationsSchema } from "../shared/locations";
 import type { MonitorMonitorResponseDto } from "@/api";
 
 export const kafkaProducerSchema = z
@@ -20,7 +22,9 @@ export const kafkaProducerSchema = z
   .merge(generalSchema)
   .merge(intervalsSchema)
   .merge(notificationsSchema)
-  .merge(tagsSchema);
+  .merge(tagsSchema)
+  .merge(dependenciesSchema)
+  .merge(locationsSchema);
 
 export type KafkaProducerForm = z.infer<typeof kafkaProducerSchema>;
 
@@ -38,6 +42,9 @@ export const kafkaProducerDefaultValues: KafkaProducerForm = {
   ...intervalsDefaultValues,
   notification_ids: [],
   tag_ids: [],
+  parent_ids: [],
+  probe_ids: [],
+  probe_down_threshold: 1,
 };
 
 export interface KafkaProducerExecutorConfig {
@@ -77,6 +84,9 @@ export const serialize = (data: KafkaProducerForm) => {
     resend_interval: data.resend_interval,
     notification_ids: data.notification_ids,
     tag_ids: data.tag_ids,
+    parent_ids: data.parent_ids,
+    probe_ids: data.probe_ids,
+    probe_down_threshold: data.probe_down_threshold,
     config: JSON.stringify(config),
   };
 };
@@ -98,6 +108,9 @@ export const deserialize = (
     resend_interval: monitor.resend_interval || 10,
     notification_ids: monitor.notification_ids || [],
     tag_ids: monitor.tag_ids || [],
+    parent_ids: monitor.parent_ids || [],
+    probe_ids: monitor.probe_ids || [],
+    probe_down_threshold: monitor.probe_down_threshold || 1,
     brokers: parsedConfig.brokers || ["localhost:9092"],
     topic: parsedConfig.topic || "test-topic",
     message: parsedConfig.message || '{"status": "up"}',
@@ -15,6 +15,8 @@ import Notifications, {
   notificationsSchema,
 } from "../shared/notifications";
 import Tags, { tagsDefaultValues, tagsSchema } from "../shared/tags";
+import Dependencies, { dependenciesDefaultValues, dependenciesSchema } from "../shared/dependencies";
+import Locations, { locationsDefaultValues, locationsSchema } from "../shared/locations";
 import {
   useMonitorFormContext,
   type MonitorForm,
@@ -66,7 +68,9 @@ export const mongodbSchema = z
   .merge(generalSchema)
   .merge(intervalsSchema)
   .merge(notificationsSchema)
-  .merge(tagsSchema);
+  .merge(tagsSchema)
+  .merge(dependenciesSchema)
+  .merge(locationsSchema);
 
 export type MongoDBForm = z.infer<typeof mongodbSchema>;
 
@@ -80,6 +84,8 @@ export const mongodbDefaultValues: MongoDBForm = {
   ...intervalsDefaultValues,
   ...notificationsDefaultValues,
   ...tagsDefaultValues,
+  ...dependenciesDefaultValues,
+  ...locationsDefaultValues,
 };
 
 export const deserialize = (data: MonitorMonitorResponseDto): MongoDBForm => {
@@ -120,6 +126,9 @@ export const deserialize = (data: MonitorMonitorResponseDto): MongoDBForm => {
     resend_interval: data.resend_interval ?? 10,
     notification_ids: data.notification_ids || [],
     tag_ids: data.tag_ids || [],
+    parent_ids: data.parent_ids || [],
+    probe_ids: data.probe_ids || [],
+    probe_down_threshold: data.probe_down_threshold || 1,
   };
 };
 
@@ -142,6 +151,9 @@ export const serialize = (formData: MongoDBForm): MonitorCreateUpdateDto => {
     timeout: formData.timeout,
     config: JSON.stringify(config),
     tag_ids: formData.tag_ids,
+    parent_ids: formData.parent_ids,
+    probe_ids: formData.probe_ids,
+    probe_down_threshold: formData.probe_down_threshold,
   };
 };
 
@@ -299,6 +311,8 @@ const MongoDBForm = () => {
         <Card>
           <CardContent className="space-y-4">
             <Tags />
+            <Dependencies />
+            <Locations />
           </CardContent>
         </Card>
 
@@ -3,6 +3,7 @@ import type { MonitorForm } from "../context/monitor-form-context";
 import { deserialize as httpDeserialize } from "./http/schema";
 import { deserialize as httpKeywordDeserialize } from "./http-keyword/schema";
 import { deserialize as httpJsonQueryDeserialize } from "./http-json-query/schema";
+import { deserialize as httpTransactionDeserialize } from "./http-transaction/schema";
 import { deserialize as tcpDeserialize } from "./tcp";
 import { deserialize as pingDeserialize } from "./ping";
 import { deserialize as dnsDeserialize } from "./dns";
@@ -24,6 +25,7 @@ import DNSForm from "./dns";
 import HttpForm from "./http";
 import HttpKeywordForm from "./http-keyword";
 import HttpJsonQueryForm from "./http-json-query";
+import HttpTransactionForm from "./http-transaction";
 import PushForm from "./push";
 import DockerForm from "./docker";
 import GRPCKeywordForm from "./grpc-keyword";
@@ -68,6 +70,10 @@ const monitorTypeRegistry: Record<string, MonitorTypeConfig> = {
     deserialize: httpJsonQueryDeserialize,
     component: HttpJsonQueryForm,
   },
+  "http-transaction": {
+    deserialize: httpTransactionDeserialize,
+    component: HttpTransactionForm,
+  },
   tcp: {
     deserialize: tcpDeserialize,
     component: TCPForm,
@@ -19,6 +19,14 @@ import Tags, {
   tagsDefaultValues,
   tagsSchema,
 } from "../shared/tags";
+import Dependencies, {
+    dependenciesDefaultValues,
+    dependenciesSchema,
+} from "../shared/dependencies";
+import Locations, {
+    locationsDefaultValues,
+    locationsSchema,
+} from "../shared/locations";
 import { useMonitorFormContext } from "../../context/monitor-form-context";
 import {
   Form,
@@ -70,7 +78,9 @@ export const mqttSchema = z
   .merge(generalSchema)
   .merge(intervalsSchema)
   .merge(notificationsSchema)
-  .merge(tagsSchema);
+  .merge(tagsSchema)
+  .merge(dependenciesSchema)
+  .merge(locationsSchema);
 
 export type MQTTForm = z.infer<typeof mqttSchema>;
 
@@ -89,6 +99,8 @@ export const mqttDefaultValues: MQTTForm = {
   ...intervalsDefaultValues,
   ...notificationsDefaultValues,
   ...tagsDefaultValues,
+  ...dependenciesDefaultValues,
+  ...locationsDefaultValues,
 };
 
 const serialize = (data: MQTTForm): MonitorCreateUpdateDto => {
@@ -115,6 +127,9 @@ const serialize = (data: MQTTForm): MonitorCreateUpdateDto => {
     config: JSON.stringify(config),
     notification_ids: data.notification_ids,
     tag_ids: data.tag_ids,
+    parent_ids: data.parent_ids,
+    probe_ids: data.probe_ids,
+    probe_down_threshold: data.probe_down_threshold,
   };
 };
 
@@ -140,6 +155,9 @@ export const deserialize = (data: MonitorMonitorResponseDto): MQTTForm => {
     resend_interval: data.resend_interval || 10,
     notification_ids: data.notification_ids || [],
     tag_ids: data.tag_ids || [],
+    parent_ids: data.parent_ids || [],
+    probe_ids: data.probe_ids || [],
+    probe_down_threshold: data.probe_down_threshold || 1,
   };
 };
 
@@ -389,6 +407,8 @@ const MQTTForm = () => {
         <Card>
           <CardContent className="space-y-4">
             <Tags />
+            <Dependencies />
+            <Locations />
           </CardContent>
         </Card>
 
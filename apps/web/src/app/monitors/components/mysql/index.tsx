@@ -19,6 +19,14 @@ import Tags, {
   tagsDefaultValues,
   tagsSchema,
 } from "../shared/tags";
+import Dependencies, {
+    dependenciesDefaultValues,
+    dependenciesSchema,
+} from "../shared/dependencies";
+import Locations, {
+    locationsDefaultValues,
+    locationsSchema,
+} from "../shared/locations";
 import { useMonitorFormContext } from "../../context/monitor-form-context";
 import {
   Form,
@@ -60,7 +68,9 @@ export const mysqlSchema = z
   .merge(generalSchema)
   .merge(intervalsSchema)
   .merge(notificationsSchema)
-  .merge(tagsSchema);
+  .merge(tagsSchema)
+  .merge(dependenciesSchema)
+  .merge(locationsSchema);
 
 export type MySQLForm = z.infer<typeof mysqlSchema>;
 
@@ -72,6 +82,8 @@ export const mysqlDefaultValues: MySQLForm = {
   ...intervalsDefaultValues,
   ...notificationsDefaultValues,
   ...tagsDefaultValues,
+  ...dependenciesDefaultValues,
+  ...locationsDefaultValues,
 };
 
 export const deserialize = (data: MonitorMonitorResponseDto): MySQLForm => {
@@ -104,6 +116,9 @@ export const deserialize = (data: MonitorMonitorResponseDto): MySQLForm => {
     resend_interval: data.resend_interval ?? 10,
     notification_ids: data.notification_ids || [],
     tag_ids: data.tag_ids || [],
+    parent_ids: data.parent_ids || [],
+    probe_ids: data.probe_ids || [],
+    probe_down_threshold: data.probe_down_threshold || 1,
   };
 };
 
@@ -124,6 +139,9 @@ export const serialize = (formData: MySQLForm): MonitorCreateUpdateDto => {
     timeout: formData.timeout,
     config: JSON.stringify(config),
     tag_ids: formData.tag_ids,
+    parent_ids: formData.parent_ids,
+    probe_ids: formData.probe_ids,
+    probe_down_threshold: formData.probe_down_threshold,
   };
 };
 
@@ -228,6 +246,8 @@ const MySQLForm = () => {
         <Card>
           <CardContent className="space-y-4">
             <Tags />
+            <Dependencies />
+            <Locations />
           </CardContent>
         </Card>
 
@@ -18,6 +18,14 @@ import Tags, {
     tagsDefaultValues,
     tagsSchema,
 } from "../shared/tags";
+import Dependencies, {
+    dependenciesDefaultValues,
+    dependenciesSchema,
+} from "../shared/dependencies";
+import Locations, {
+    locationsDefaultValues,
+    locationsSchema,
+} from "../shared/locations";
 import { useMonitorFormContext } from "../../context/monitor-form-context";
 import {
     Form,
@@ -64,7 +72,9 @@ export const pingSchema = z
     .merge(generalSchema)
     .merge(intervalsSchema)
     .merge(notificationsSchema)
-    .merge(tagsSchema);
+    .merge(tagsSchema)
+    .merge(dependenciesSchema)
+    .merge(locationsSchema);
 
 export type PingForm = z.infer<typeof pingSchema>;
 
@@ -78,6 +88,8 @@ export const pingDefaultValues: PingForm = {
     ...intervalsDefaultValues,
     ...notificationsDefaultValues,
     ...tagsDefaultValues,
+    ...dependenciesDefaultValues,
+    ...locationsDefaultValues,
 };
 
 export const deserialize = (data: MonitorMonitorResponseDto): PingForm => {
@@ -116,6 +128,9 @@ export const deserialize = (data: MonitorMonitorResponseDto): PingForm => {
         resend_interval: data.resend_interval ?? 10,
         notification_ids: data.notification_ids || [],
         tag_ids: data.tag_ids || [],
+        parent_ids: data.parent_ids || [],
+        probe_ids: data.probe_ids || [],
+        probe_down_threshold: data.probe_down_threshold || 1,
     };
 };
 
@@ -138,6 +153,9 @@ export const serialize = (formData: PingForm): MonitorCreateUpdateDto => {
         timeout: formData.timeout,
         config: JSON.stringify(config),
         tag_ids: formData.tag_ids,
+        parent_ids: formData.parent_ids,
+        probe_ids: formData.probe_ids,
+        probe_down_threshold: formData.probe_down_threshold,
     };
 };
 
@@ -299,6 +317,8 @@ const PingForm = () => {
                 <Card>
                     <CardContent className="space-y-4">
                         <Tags />
+                        <Dependencies />
+                        <Locations />
                     </CardContent>
                 </Card>
 
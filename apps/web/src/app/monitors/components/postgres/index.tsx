@@ -6,7 +6,10 @@ import { Input } from "@/components/ui/input";
 import { Textarea } from "@/components/ui/textarea";
 import Notifications from "../shared/notifications";
 import Tags from "../shared/tags";
+import Dependencies from "../shared/dependencies";
+import Locations from "../shared/locations";
 import Intervals from "../shared/intervals";
+import DegradedThresholds from "../shared/degraded-thresholds";
 import General from "../shared/general";
 import { useMonitorFormContext } from "../../context/monitor-form-context";
 import { Loader2 } from "lucide-react";
@@ -111,6 +114,8 @@ const PostgresFormComponent = () => {
         <Card>
           <CardContent className="space-y-4">
             <Tags />
+            <Dependencies />
+            <Locations />
           </CardContent>
         </Card>
 
@@ -120,6 +125,12 @@ const PostgresFormComponent = () => {
           </CardContent>
         </Card>
 
+        <Card>
+          <CardContent className="space-y-4">
+            <DegradedThresholds />
+          </CardContent>
+        </Card>
+
         <Button type="submit">
           {isPending && <Loader2 className="animate-spin" />}
           {mode === "create" ? t("monitors.form.buttons.create") : t("monitors.form.buttons.update")}
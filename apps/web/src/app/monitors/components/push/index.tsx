@@ -19,6 +19,10 @@ import Tags, {
   tagsDefaultValues,
   tagsSchema,
 } from "../shared/tags";
+import Dependencies, {
+    dependenciesDefaultValues,
+    dependenciesSchema,
+} from "../shared/dependencies";
 import { useMonitorFormContext } from "../../context/monitor-form-context";
 import { Form } from "@/components/ui/form";
 import { Loader2 } from "lucide-react";
@@ -33,7 +37,8 @@ export const pushSchema = z
   .merge(generalSchema)
   .merge(intervalsSchema)
   .merge(notificationsSchema)
-  .merge(tagsSchema);
+  .merge(tagsSchema)
+  .merge(dependenciesSchema);
 
 export type PushForm = z.infer<typeof pushSchema>;
 export const pushDefaultValues: PushForm = {
@@ -43,6 +48,7 @@ export const pushDefaultValues: PushForm = {
   ...intervalsDefaultValues,
   ...notificationsDefaultValues,
   ...tagsDefaultValues,
+  ...dependenciesDefaultValues,
 };
 
 export interface PushConfig {
@@ -69,6 +75,7 @@ export const deserialize = (data: MonitorMonitorResponseDto): PushForm => {
     notification_ids: data.notification_ids || [],
     pushToken: data.push_token || config.pushToken || "", // Get from API or config
     tag_ids: data.tag_ids || [],
+    parent_ids: data.parent_ids || [],
   };
 };
 
@@ -89,6 +96,7 @@ export const serialize = (formData: PushForm): MonitorCreateUpdateDto => {
     config: JSON.stringify(config),
     push_token: formData.pushToken,
     tag_ids: formData.tag_ids,
+    parent_ids: formData.parent_ids,
   };
 };
 
@@ -202,6 +210,7 @@ const PushForm = () => {
         <Card>
           <CardContent className="space-y-4">
             <Tags />
+            <Dependencies />
           </CardContent>
         </Card>
 
@@ -19,6 +19,14 @@ import Tags, {
   tagsDefaultValues,
   tagsSchema,
 } from "../shared/tags";
+import Dependencies, {
+    dependenciesDefaultValues,
+    dependenciesSchema,
+} from "../shared/dependencies";
+import Locations, {
+    locationsDefaultValues,
+    locationsSchema,
+} from "../shared/locations";
 import { useMonitorFormContext } from "../../context/monitor-form-context";
 import {
   Form,
@@ -56,7 +64,9 @@ export const rabbitMQSchema = z
   .merge(generalSchema)
   .merge(intervalsSchema)
   .merge(notificationsSchema)
-  .merge(tagsSchema);
+  .merge(tagsSchema)
+  .merge(dependenciesSchema)
+  .merge(locationsSchema);
 
 export const rabbitMQDefaultValues: RabbitMQForm = {
   type: "rabbitmq",
@@ -67,6 +77,8 @@ export const rabbitMQDefaultValues: RabbitMQForm = {
   ...intervalsDefaultValues,
   ...notificationsDefaultValues,
   ...tagsDefaultValues,
+  ...dependenciesDefaultValues,
+  ...locationsDefaultValues,
 };
 
 export type RabbitMQForm = z.infer<typeof rabbitMQSchema>;
@@ -88,6 +100,9 @@ export const serialize = (data: RabbitMQForm): MonitorCreateUpdateDto => {
     resend_interval: data.resend_interval,
     notification_ids: data.notification_ids,
     tag_ids: data.tag_ids,
+    parent_ids: data.parent_ids,
+    probe_ids: data.probe_ids,
+    probe_down_threshold: data.probe_down_threshold,
     config: JSON.stringify(config),
   };
 };
@@ -105,6 +120,9 @@ export const deserialize = (data: MonitorMonitorResponseDto): RabbitMQForm => {
     resend_interval: data.resend_interval || 0,
     notification_ids: data.notification_ids || [],
     tag_ids: data.tag_ids || [],
+    parent_ids: data.parent_ids || [],
+    probe_ids: data.probe_ids || [],
+    probe_down_threshold: data.probe_down_threshold || 1,
     nodes: config.nodes ? config.nodes.map((url: string) => ({ url })) : [{ url: "https://localhost:15672" }], // Convert string[] to [{ url: "" }]
     username: config.username || "",
     password: config.password || "",
@@ -316,6 +334,8 @@ const RabbitMQForm = () => {
         <Card>
           <CardContent className="space-y-4">
             <Tags />
+            <Dependencies />
+            <Locations />
           </CardContent>
         </Card>
 
@@ -0,0 +1,101 @@
+import {
+  FormControl,
+  FormDescription,
+  FormField,
+  FormItem,
+  FormLabel,
+  FormMessage,
+} from "@/components/ui/form";
+import { Input } from "@/components/ui/input";
+import { TypographyH4 } from "@/components/ui/typography";
+import { useFormContext } from "react-hook-form";
+import { z } from "zod";
+import { useLocalizedTranslation } from "@/hooks/useTranslation";
+
+export const degradedThresholdsSchema = z.object({
+  degraded_response_time: z.coerce.number().min(0),
+  degraded_tls_days: z.coerce.number().min(0),
+});
+
+export type DegradedThresholdsForm = z.infer<typeof degradedThresholdsSchema>;
+
+export const degradedThresholdsDefaultValues: DegradedThresholdsForm = {
+  degraded_response_time: 0,
+  degraded_tls_days: 0,
+};
+
+export interface DegradedThresholdsConfig {
+  degraded_response_time?: number; // milliseconds, 0 disables
+  degraded_tls_days?: number; // days left on the certificate, 0 disables
+}
+
+export const deserializeDegradedThresholds = (
+  config: DegradedThresholdsConfig
+): DegradedThresholdsForm => ({
+  degraded_response_time: config.degraded_response_time || 0,
+  degraded_tls_days: config.degraded_tls_days || 0,
+});
+
+// Zero thresholds are left out so the executor treats them as disabled
+export const serializeDegradedThresholds = (
+  formData: DegradedThresholdsForm
+): DegradedThresholdsConfig => ({
+  ...(formData.degraded_response_time > 0 && {
+    degraded_response_time: formData.degraded_response_time,
+  }),
+  ...(formData.degraded_tls_days > 0 && {
+    degraded_tls_days: formData.degraded_tls_days,
+  }),
+});
+
+const DegradedThresholds = ({ showTlsDays = false }: { showTlsDays?: boolean }) => {
+  const { t } = useLocalizedTranslation();
+  const form = useFormContext();
+
+  return (
+    <>
+      <TypographyH4>{t("monitors.form.shared.degraded.title")}</TypographyH4>
+      <p className="text-sm text-muted-foreground">
+        {t("monitors.form.shared.degraded.description")}
+      </p>
+
+      <FormField
+        control={form.control}
+        name="degraded_response_time"
+        render={({ field }) => (
+          <FormItem>
+            <FormLabel>{t("monitors.form.shared.degraded.response_time")}</FormLabel>
+            <FormControl>
+              <Input placeholder="2000" {...field} type="number" min={0} />
+            </FormControl>
+            <FormDescription>
+              {t("monitors.form.shared.degraded.response_time_description")}
+            </FormDescription>
+            <FormMessage />
+          </FormItem>
+        )}
+      />
+
+      {showTlsDays && (
+        <FormField
+          control={form.control}
+          name="degraded_tls_days"
+          render={({ field }) => (
+            <FormItem>
+              <FormLabel>{t("monitors.form.shared.degraded.tls_days")}</FormLabel>
+              <FormControl>
+                <Input placeholder="14" {...field} type="number" min={0} />
+              </FormControl>
+              <FormDescription>
+                {t("monitors.form.shared.degraded.tls_days_description")}
+              </FormDescription>
+              <FormMessage />
+            </FormItem>
+          )}
+        />
+      )}
+    </>
+  );
+};
+
+export default DegradedThresholds;
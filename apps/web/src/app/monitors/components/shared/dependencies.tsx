@@ -0,0 +1,146 @@
+import {
+  FormControl,
+  FormDescription,
+  FormField,
+  FormItem,
+  FormLabel,
+  FormMessage,
+} from "@/components/ui/form";
+import { useFormContext } from "react-hook-form";
+import { z } from "zod";
+import { useQuery } from "@tanstack/react-query";
+import { getMonitorsOptions } from "@/api/@tanstack/react-query.gen";
+import { type MonitorModel } from "@/api";
+import { useState } from "react";
+import { Badge } from "@/components/ui/badge";
+import { Button } from "@/components/ui/button";
+import {
+  Popover,
+  PopoverContent,
+  PopoverTrigger,
+} from "@/components/ui/popover";
+import { Checkbox } from "@/components/ui/checkbox";
+import { X } from "lucide-react";
+import { useLocalizedTranslation } from "@/hooks/useTranslation";
+import { useMonitorFormContext } from "../../context/monitor-form-context";
+
+export const dependenciesDefaultValues = {
+  parent_ids: [] as string[],
+};
+
+export const dependenciesSchema = z.object({
+  parent_ids: z.array(z.string()),
+});
+
+const Dependencies = () => {
+  const { t } = useLocalizedTranslation();
+  const form = useFormContext();
+  const { monitorId } = useMonitorFormContext();
+  const [popoverOpen, setPopoverOpen] = useState(false);
+
+  const { data: monitorsData } = useQuery({
+    ...getMonitorsOptions({
+      query: {
+        limit: 100,
+      },
+    }),
+  });
+
+  // A monitor cannot depend on itself
+  const availableMonitors = ((monitorsData?.data || []) as MonitorModel[]).filter(
+    (monitor) => monitor.id !== monitorId
+  );
+  const selectedParentIds = (form.watch("parent_ids") || []) as string[];
+
+  const handleToggle = (parentId: string) => {
+    const current = (form.getValues("parent_ids") || []) as string[];
+    const next = current.includes(parentId)
+      ? current.filter((id: string) => id !== parentId)
+      : [...current, parentId];
+    form.setValue("parent_ids", next);
+  };
+
+  const handleRemove = (parentId: string) => {
+    const current = (form.getValues("parent_ids") || []) as string[];
+    form.setValue(
+      "parent_ids",
+      current.filter((id: string) => id !== parentId)
+    );
+  };
+
+  const selectedMonitors = availableMonitors.filter((monitor) =>
+    selectedParentIds.includes(monitor.id!)
+  );
+
+  return (
+    <FormField
+      control={form.control}
+      name="parent_ids"
+      render={() => (
+        <FormItem>
+          <FormLabel>{t("monitors.form.shared.dependencies.label")}</FormLabel>
+          <FormControl>
+            <div className="space-y-3">
+              {selectedMonitors.length > 0 && (
+                <div className="flex flex-wrap gap-2">
+                  {selectedMonitors.map((monitor) => (
+                    <Badge
+                      key={monitor.id}
+                      variant="secondary"
+                      className="flex items-center gap-1"
+                    >
+                      {monitor.name}
+                      <div
+                        role="button"
+                        onClick={() => handleRemove(monitor.id!)}
+                      >
+                        <X className="h-3 w-3 cursor-pointer" />
+                      </div>
+                    </Badge>
+                  ))}
+                </div>
+              )}
+
+              <Popover open={popoverOpen} onOpenChange={setPopoverOpen}>
+                <PopoverTrigger asChild>
+                  <Button variant="outline" className="px-3 font-normal w-full">
+                    <span className="text-muted-foreground">
+                      {t("monitors.form.shared.dependencies.placeholder")}
+                    </span>
+                  </Button>
+                </PopoverTrigger>
+                <PopoverContent className="w-auto p-0" align="start">
+                  <div className="max-h-60 overflow-y-auto">
+                    {availableMonitors.map((monitor) => (
+                      <div
+                        key={monitor.id}
+                        className="flex items-center space-x-2 p-2 hover:bg-accent hover:text-accent-foreground rounded-sm cursor-pointer"
+                        onClick={() => handleToggle(monitor.id!)}
+                      >
+                        <Checkbox
+                          checked={selectedParentIds.includes(monitor.id!)}
+                        />
+                        <span className="text-sm">{monitor.name}</span>
+                      </div>
+                    ))}
+                    {availableMonitors.length === 0 && (
+                      <div className="text-center text-muted-foreground text-sm p-4">
+                        {t("monitors.form.shared.dependencies.no_monitors")}
+                      </div>
+                    )}
+                  </div>
+                </PopoverContent>
+              </Popover>
+            </div>
+          </FormControl>
+          <FormDescription>
+            {t("monitors.form.shared.dependencies.description")}
+          </FormDescription>
+          <FormMessage />
+        </FormItem>
+      )}
+    />
+  );
+};
+
+export default Dependencies;
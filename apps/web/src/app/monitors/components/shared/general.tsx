@@ -44,6 +44,10 @@ const General = () => {
       type: "http-json-query",
       description: "HTTP(s) - Json Query",
     },
+    {
+      type: "http-transaction",
+      description: t("monitors.form.type.http_transaction"),
+    },
     {
       type: "tcp",
       description: t("monitors.form.type.tcp"),
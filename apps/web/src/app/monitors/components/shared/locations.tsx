@@ -0,0 +1,170 @@
+import {
+  FormControl,
+  FormDescription,
+  FormField,
+  FormItem,
+  FormLabel,
+  FormMessage,
+} from "@/components/ui/form";
+import { useFormContext } from "react-hook-form";
+import { z } from "zod";
+import { useQuery } from "@tanstack/react-query";
+import { getProbes, probesQueryKey } from "@/api/probes";
+import { useState } from "react";
+import { Badge } from "@/components/ui/badge";
+import { Button } from "@/components/ui/button";
+import { Input } from "@/components/ui/input";
+import {
+  Popover,
+  PopoverContent,
+  PopoverTrigger,
+} from "@/components/ui/popover";
+import { Checkbox } from "@/components/ui/checkbox";
+import { X } from "lucide-react";
+import { useLocalizedTranslation } from "@/hooks/useTranslation";
+
+export const locationsDefaultValues = {
+  probe_ids: [] as string[],
+  probe_down_threshold: 1,
+};
+
+export const locationsSchema = z.object({
+  probe_ids: z.array(z.string()),
+  probe_down_threshold: z.number().min(1),
+});
+
+const Locations = () => {
+  const { t } = useLocalizedTranslation();
+  const form = useFormContext();
+  const [popoverOpen, setPopoverOpen] = useState(false);
+
+  const { data: probes = [] } = useQuery({
+    queryKey: [...probesQueryKey, "list"],
+    queryFn: () => getProbes({ limit: 100 }),
+  });
+
+  const selectedProbeIds = (form.watch("probe_ids") || []) as string[];
+
+  const handleToggle = (probeId: string) => {
+    const current = (form.getValues("probe_ids") || []) as string[];
+    const next = current.includes(probeId)
+      ? current.filter((id: string) => id !== probeId)
+      : [...current, probeId];
+    form.setValue("probe_ids", next);
+  };
+
+  const handleRemove = (probeId: string) => {
+    const current = (form.getValues("probe_ids") || []) as string[];
+    form.setValue(
+      "probe_ids",
+      current.filter((id: string) => id !== probeId)
+    );
+  };
+
+  const selectedProbes = probes.filter((probe) =>
+    selectedProbeIds.includes(probe.id)
+  );
+
+  return (
+    <>
+      <FormField
+        control={form.control}
+        name="probe_ids"
+        render={() => (
+          <FormItem>
+            <FormLabel>{t("monitors.form.shared.locations.label")}</FormLabel>
+            <FormControl>
+              <div className="space-y-3">
+                {selectedProbes.length > 0 && (
+                  <div className="flex flex-wrap gap-2">
+                    {selectedProbes.map((probe) => (
+                      <Badge
+                        key={probe.id}
+                        variant="secondary"
+                        className="flex items-center gap-1"
+                      >
+                        {probe.name}
+                        <div
+                          role="button"
+                          onClick={() => handleRemove(probe.id)}
+                        >
+                          <X className="h-3 w-3 cursor-pointer" />
+                        </div>
+                      </Badge>
+                    ))}
+                  </div>
+                )}
+
+                <Popover open={popoverOpen} onOpenChange={setPopoverOpen}>
+                  <PopoverTrigger asChild>
+                    <Button variant="outline" className="px-3 font-normal w-full">
+                      <span className="text-muted-foreground">
+                        {t("monitors.form.shared.locations.placeholder")}
+                      </span>
+                    </Button>
+                  </PopoverTrigger>
+                  <PopoverContent className="w-auto p-0" align="start">
+                    <div className="max-h-60 overflow-y-auto">
+                      {probes.map((probe) => (
+                        <div
+                          key={probe.id}
+                          className="flex items-center space-x-2 p-2 hover:bg-accent hover:text-accent-foreground rounded-sm cursor-pointer"
+                          onClick={() => handleToggle(probe.id)}
+                        >
+                          <Checkbox
+                            checked={selectedProbeIds.includes(probe.id)}
+                          />
+                          <span className="text-sm">{probe.name}</span>
+                        </div>
+                      ))}
+                      {probes.length === 0 && (
+                        <div className="text-center text-muted-foreground text-sm p-4">
+                          {t("monitors.form.shared.locations.no_probes")}
+                        </div>
+                      )}
+                    </div>
+                  </PopoverContent>
+                </Popover>
+              </div>
+            </FormControl>
+            <FormDescription>
+              {t("monitors.form.shared.locations.description")}
+            </FormDescription>
+            <FormMessage />
+          </FormItem>
+        )}
+      />
+
+      {selectedProbeIds.length > 1 && (
+        <FormField
+          control={form.control}
+          name="probe_down_threshold"
+          render={({ field }) => (
+            <FormItem>
+              <FormLabel>
+                {t("monitors.form.shared.locations.down_threshold_label")}
+              </FormLabel>
+              <FormControl>
+                <Input
+                  type="number"
+                  min={1}
+                  max={selectedProbeIds.length}
+                  {...field}
+                  onChange={(e) => field.onChange(parseInt(e.target.value, 10) || 1)}
+                />
+              </FormControl>
+              <FormDescription>
+                {t("monitors.form.shared.locations.down_threshold_description", {
+                  count: selectedProbeIds.length,
+                })}
+              </FormDescription>
+              <FormMessage />
+            </FormItem>
+          )}
+        />
+      )}
+    </>
+  );
+};
+
+export default Locations;
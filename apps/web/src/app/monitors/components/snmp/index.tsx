@@ -18,6 +18,14 @@ import Tags, {
   tagsDefaultValues,
   tagsSchema,
 } from "../shared/tags";
+import Dependencies, {
+    dependenciesDefaultValues,
+    dependenciesSchema,
+} from "../shared/dependencies";
+import Locations, {
+    locationsDefaultValues,
+    locationsSchema,
+} from "../shared/locations";
 import { useMonitorFormContext } from "../../context/monitor-form-context";
 import {
   Form,
@@ -36,9 +44,11 @@ import {
   SelectTrigger,
   SelectValue,
 } from "@/components/ui/select";
-import { Loader2 } from "lucide-react";
+import { Checkbox } from "@/components/ui/checkbox";
+import { Loader2, Plus, Trash2 } from "lucide-react";
 import type { MonitorCreateUpdateDto, MonitorMonitorResponseDto } from "@/api";
 import { useEffect } from "react";
+import { useFieldArray } from "react-hook-form";
 import { useLocalizedTranslation } from "@/hooks/useTranslation";
 
 interface SnmpConfig {
@@ -50,8 +60,31 @@ interface SnmpConfig {
   json_path?: string;
   json_path_operator?: string;
   expected_value?: string;
+  security_level?: string;
+  username?: string;
+  auth_protocol?: string;
+  auth_passphrase?: string;
+  priv_protocol?: string;
+  priv_passphrase?: string;
+  context_name?: string;
+  checks?: SnmpCheck[];
 }
 
+interface SnmpCheck {
+  oid: string;
+  label?: string;
+  walk?: boolean;
+  operator: string;
+  value: string;
+  rate?: boolean;
+}
+
+const operators = ["eq", "ne", "lt", "gt", "le", "ge"] as const;
+
+const securityLevels = ["noAuthNoPriv", "authNoPriv", "authPriv"] as const;
+const authProtocols = ["MD5", "SHA", "SHA-256", "SHA-512"] as const;
+const privProtocols = ["DES", "AES", "AES-256", "AES-256C"] as const;
+
 export const snmpSchema = z
   .object({
     type: z.literal("snmp"),
@@ -61,19 +94,40 @@ export const snmpSchema = z
       .min(1, "Port must be greater than 0")
       .max(65535, "Port must be less than 65536")
       .optional(),
-    community: z.string().min(1, "Community is required"),
+    // Required for v1 and v2c, the server validates it together with the v3 settings
+    community: z.string(),
     snmp_version: z.enum(["v1", "v2c", "v3"], {
       required_error: "SNMP version is required",
     }),
-    oid: z.string().min(1, "OID is required"),
+    // Not used when checks are configured
+    oid: z.string(),
     json_path: z.string().optional(),
-    json_path_operator: z.enum(["eq", "ne", "lt", "gt", "le", "ge"]).optional(),
+    json_path_operator: z.enum(operators).optional(),
     expected_value: z.string().optional(),
+    security_level: z.enum(securityLevels).optional(),
+    username: z.string().optional(),
+    auth_protocol: z.enum(authProtocols).optional(),
+    auth_passphrase: z.string().optional(),
+    priv_protocol: z.enum(privProtocols).optional(),
+    priv_passphrase: z.string().optional(),
+    context_name: z.string().optional(),
+    checks: z.array(
+      z.object({
+        oid: z.string().min(1, "OID is required"),
+        label: z.string().optional(),
+        walk: z.boolean(),
+        operator: z.enum(operators),
+        value: z.string().min(1, "Value is required"),
+        rate: z.boolean(),
+      })
+    ),
   })
   .merge(generalSchema)
   .merge(intervalsSchema)
   .merge(notificationsSchema)
-  .merge(tagsSchema);
+  .merge(tagsSchema)
+  .merge(dependenciesSchema)
+  .merge(locationsSchema);
 
 export type SnmpForm = z.infer<typeof snmpSchema>;
 
@@ -87,10 +141,20 @@ export const snmpDefaultValues: SnmpForm = {
   json_path: "$",
   json_path_operator: "eq",
   expected_value: "",
+  security_level: "authPriv",
+  username: "",
+  auth_protocol: "SHA-256",
+  auth_passphrase: "",
+  priv_protocol: "AES",
+  priv_passphrase: "",
+  context_name: "",
+  checks: [],
   ...generalDefaultValues,
   ...intervalsDefaultValues,
   ...notificationsDefaultValues,
   ...tagsDefaultValues,
+  ...dependenciesDefaultValues,
+  ...locationsDefaultValues,
 };
 
 export const deserialize = (data: MonitorMonitorResponseDto): SnmpForm => {
@@ -117,6 +181,14 @@ export const deserialize = (data: MonitorMonitorResponseDto): SnmpForm => {
         json_path: parsedConfig.json_path || "$",
         json_path_operator: parsedConfig.json_path_operator || "eq",
         expected_value: parsedConfig.expected_value || "",
+        security_level: parsedConfig.security_level || "authPriv",
+        username: parsedConfig.username || "",
+        auth_protocol: parsedConfig.auth_protocol || "SHA-256",
+        auth_passphrase: parsedConfig.auth_passphrase || "",
+        priv_protocol: parsedConfig.priv_protocol || "AES",
+        priv_passphrase: parsedConfig.priv_passphrase || "",
+        context_name: parsedConfig.context_name || "",
+        checks: parsedConfig.checks || [],
       };
     } catch (error) {
       console.error("Failed to parse SNMP monitor config:", error);
@@ -141,6 +213,27 @@ export const deserialize = (data: MonitorMonitorResponseDto): SnmpForm => {
       | "ge"
       | undefined,
     expected_value: config.expected_value,
+    security_level: config.security_level as
+      | (typeof securityLevels)[number]
+      | undefined,
+    username: config.username,
+    auth_protocol: config.auth_protocol as
+      | (typeof authProtocols)[number]
+      | undefined,
+    auth_passphrase: config.auth_passphrase,
+    priv_protocol: config.priv_protocol as
+      | (typeof privProtocols)[number]
+      | undefined,
+    priv_passphrase: config.priv_passphrase,
+    context_name: config.context_name,
+    checks: (config.checks || []).map((check) => ({
+      oid: check.oid,
+      label: check.label || "",
+      walk: check.walk ?? false,
+      operator: check.operator as (typeof operators)[number],
+      value: check.value,
+      rate: check.rate ?? false,
+    })),
     interval: data.interval || 60,
     timeout: data.timeout || 16,
     max_retries: data.max_retries ?? 3,
@@ -148,6 +241,9 @@ export const deserialize = (data: MonitorMonitorResponseDto): SnmpForm => {
     resend_interval: data.resend_interval ?? 10,
     notification_ids: data.notification_ids || [],
     tag_ids: data.tag_ids || [],
+    parent_ids: data.parent_ids || [],
+    probe_ids: data.probe_ids || [],
+    probe_down_threshold: data.probe_down_threshold || 1,
   };
 };
 
@@ -163,6 +259,32 @@ export const serialize = (formData: SnmpForm): MonitorCreateUpdateDto => {
     expected_value: formData.expected_value || "",
   };
 
+  if (formData.checks.length > 0) {
+    config.checks = formData.checks.map((check) => ({
+      oid: check.oid,
+      label: check.label || undefined,
+      walk: check.walk,
+      operator: check.operator,
+      value: check.value,
+      rate: check.rate,
+    }));
+  }
+
+  if (formData.snmp_version === "v3") {
+    const securityLevel = formData.security_level || "noAuthNoPriv";
+    config.security_level = securityLevel;
+    config.username = formData.username;
+    config.context_name = formData.context_name || undefined;
+    if (securityLevel !== "noAuthNoPriv") {
+      config.auth_protocol = formData.auth_protocol;
+      config.auth_passphrase = formData.auth_passphrase;
+    }
+    if (securityLevel === "authPriv") {
+      config.priv_protocol = formData.priv_protocol;
+      config.priv_passphrase = formData.priv_passphrase;
+    }
+  }
+
   return {
     type: "snmp",
     name: formData.name,
@@ -174,6 +296,9 @@ export const serialize = (formData: SnmpForm): MonitorCreateUpdateDto => {
     timeout: formData.timeout,
     config: JSON.stringify(config),
     tag_ids: formData.tag_ids,
+    parent_ids: formData.parent_ids,
+    probe_ids: formData.probe_ids,
+    probe_down_threshold: formData.probe_down_threshold,
   };
 };
 
@@ -189,6 +314,13 @@ const SnmpForm = () => {
     monitor,
   } = useMonitorFormContext();
   const { t } = useLocalizedTranslation();
+  const snmpVersion = form.watch("snmp_version");
+  const securityLevel = form.watch("security_level");
+
+  const { fields: checkFields, append: appendCheck, remove: removeCheck } = useFieldArray({
+    control: form.control,
+    name: "checks",
+  });
 
   const onSubmit = (data: SnmpForm) => {
     const payload = serialize(data);
@@ -281,22 +413,24 @@ const SnmpForm = () => {
               )}
             />
 
-            <FormField
-              control={form.control}
-              name="community"
-              render={({ field }) => (
-                <FormItem>
-                  <FormLabel>{t("monitors.form.snmp.community_label")}</FormLabel>
-                  <FormControl>
-                    <Input placeholder="public" {...field} />
-                  </FormControl>
-                  <FormDescription>
-                    {t("monitors.form.snmp.community_description")}
-                  </FormDescription>
-                  <FormMessage />
-                </FormItem>
-              )}
-            />
+            {snmpVersion !== "v3" && (
+              <FormField
+                control={form.control}
+                name="community"
+                render={({ field }) => (
+                  <FormItem>
+                    <FormLabel>{t("monitors.form.snmp.community_label")}</FormLabel>
+                    <FormControl>
+                      <Input placeholder="public" {...field} />
+                    </FormControl>
+                    <FormDescription>
+                      {t("monitors.form.snmp.community_description")}
+                    </FormDescription>
+                    <FormMessage />
+                  </FormItem>
+                )}
+              />
+            )}
 
             <FormField
               control={form.control}
@@ -332,14 +466,16 @@ const SnmpForm = () => {
               )}
             />
 
-            <FormField
-              control={form.control}
-              name="oid"
-              render={({ field }) => (
-                <FormItem>
-                  <FormLabel>{t("monitors.form.snmp.oid_label")}</FormLabel>
-                  <FormControl>
-                    <Input placeholder="1.3.6.1.2.1.1.1.0" {...field} />
+            {checkFields.length === 0 && (
+              <FormField
+                control={form.control}
+                name="oid"
+                render={({ field }) => (
+                  <FormItem>
+                    <FormLabel>{t("monitors.form.snmp.oid_label")}</FormLabel>
+                    <FormControl>
+                      <Input placeholder="1.3.6.1.2.1.1.1.0" {...field} />
+            )}
                   </FormControl>
                   <FormDescription>
                     {t("monitors.form.snmp.oid_description")}
@@ -351,90 +487,447 @@ const SnmpForm = () => {
           </CardContent>
         </Card>
 
-        <Card>
-          <CardContent className="space-y-4">
-            <TypographyH4>{t("monitors.form.snmp.value_validation_label")}</TypographyH4>
-
-            <FormField
-              control={form.control}
-              name="json_path"
-              render={({ field }) => (
-                <FormItem>
-                  <FormLabel>{t("monitors.form.snmp.json_path_label")}</FormLabel>
-                  <FormControl>
-                    <Input placeholder="$" {...field} />
-                  </FormControl>
-                  <FormDescription>
-                    {t("monitors.form.snmp.json_path_description")}
-                  </FormDescription>
-                  <FormMessage />
-                </FormItem>
-              )}
-            />
+        {snmpVersion === "v3" && (
+          <Card>
+            <CardContent className="space-y-4">
+              <TypographyH4>{t("monitors.form.snmp.v3_title")}</TypographyH4>
 
-            <FormField
-              control={form.control}
-              name="json_path_operator"
-              render={({ field }) => (
-                <FormItem>
-                  <FormLabel>{t("monitors.form.snmp.json_path_operator_label")}</FormLabel>
-                  <Select
-                    onValueChange={(val) => {
-                      if (!val) {
-                        return;
-                      }
-                      field.onChange(val);
-                    }}
-                    value={field.value}
-                  >
+              <FormField
+                control={form.control}
+                name="username"
+                render={({ field }) => (
+                  <FormItem>
+                    <FormLabel>{t("monitors.form.snmp.username_label")}</FormLabel>
                     <FormControl>
-                      <SelectTrigger>
-                        <SelectValue placeholder="Select condition" />
-                      </SelectTrigger>
+                      <Input placeholder="monitor" {...field} />
                     </FormControl>
-                    <SelectContent>
-                      <SelectItem value="eq">== ({t("monitors.form.snmp.json_path_operator_eq")})</SelectItem>
-                      <SelectItem value="ne">!= ({t("monitors.form.snmp.json_path_operator_ne")})</SelectItem>
-                      <SelectItem value="lt">&lt; ({t("monitors.form.snmp.json_path_operator_lt")})</SelectItem>
-                      <SelectItem value="gt">&gt; ({t("monitors.form.snmp.json_path_operator_gt")})</SelectItem>
-                      <SelectItem value="le">
-                        &le; ({t("monitors.form.snmp.json_path_operator_le")})
-                      </SelectItem>
-                      <SelectItem value="ge">
-                        &ge; ({t("monitors.form.snmp.json_path_operator_ge")})
-                      </SelectItem>
-                    </SelectContent>
-                  </Select>
-                  <FormDescription>
-                    {t("monitors.form.snmp.json_path_operator_description")}
-                  </FormDescription>
-                  <FormMessage />
-                </FormItem>
+                    <FormDescription>
+                      {t("monitors.form.snmp.username_description")}
+                    </FormDescription>
+                    <FormMessage />
+                  </FormItem>
+                )}
+              />
+
+              <FormField
+                control={form.control}
+                name="security_level"
+                render={({ field }) => (
+                  <FormItem>
+                    <FormLabel>{t("monitors.form.snmp.security_level_label")}</FormLabel>
+                    <Select
+                      onValueChange={(val) => {
+                        if (!val) {
+                          return;
+                        }
+                        field.onChange(val);
+                      }}
+                      value={field.value}
+                    >
+                      <FormControl>
+                        <SelectTrigger>
+                          <SelectValue placeholder="Select security level" />
+                        </SelectTrigger>
+                      </FormControl>
+                      <SelectContent>
+                        {securityLevels.map((level) => (
+                          <SelectItem key={level} value={level}>
+                            {level}
+                          </SelectItem>
+                        ))}
+                      </SelectContent>
+                    </Select>
+                    <FormDescription>
+                      {t("monitors.form.snmp.security_level_description")}
+                    </FormDescription>
+                    <FormMessage />
+                  </FormItem>
+                )}
+              />
+
+              {securityLevel !== "noAuthNoPriv" && (
+                <>
+                  <FormField
+                    control={form.control}
+                    name="auth_protocol"
+                    render={({ field }) => (
+                      <FormItem>
+                        <FormLabel>{t("monitors.form.snmp.auth_protocol_label")}</FormLabel>
+                        <Select
+                          onValueChange={(val) => {
+                            if (!val) {
+                              return;
+                            }
+                            field.onChange(val);
+                          }}
+                          value={field.value}
+                        >
+                          <FormControl>
+                            <SelectTrigger>
+                              <SelectValue placeholder="Select protocol" />
+                            </SelectTrigger>
+                          </FormControl>
+                          <SelectContent>
+                            {authProtocols.map((protocol) => (
+                              <SelectItem key={protocol} value={protocol}>
+                                {protocol}
+                              </SelectItem>
+                            ))}
+                          </SelectContent>
+                        </Select>
+                        <FormMessage />
+                      </FormItem>
+                    )}
+                  />
+
+                  <FormField
+                    control={form.control}
+                    name="auth_passphrase"
+                    render={({ field }) => (
+                      <FormItem>
+                        <FormLabel>{t("monitors.form.snmp.auth_passphrase_label")}</FormLabel>
+                        <FormControl>
+                          <Input type="password" autoComplete="new-password" {...field} />
+                        </FormControl>
+                        <FormDescription>
+                          {t("monitors.form.snmp.passphrase_description")}
+                        </FormDescription>
+                        <FormMessage />
+                      </FormItem>
+                    )}
+                  />
+                </>
               )}
-            />
 
-            <FormField
-              control={form.control}
-              name="expected_value"
-              render={({ field }) => (
-                <FormItem>
-                  <FormLabel>{t("monitors.form.snmp.expected_value_label")}</FormLabel>
-                  <FormControl>
-                    <Input placeholder="Expected value" {...field} />
-                  </FormControl>
-                  <FormDescription>
-                    {t("monitors.form.snmp.expected_value_description")}
-                  </FormDescription>
-                  <FormMessage />
-                </FormItem>
+              {securityLevel === "authPriv" && (
+                <>
+                  <FormField
+                    control={form.control}
+                    name="priv_protocol"
+                    render={({ field }) => (
+                      <FormItem>
+                        <FormLabel>{t("monitors.form.snmp.priv_protocol_label")}</FormLabel>
+                        <Select
+                          onValueChange={(val) => {
+                            if (!val) {
+                              return;
+                            }
+                            field.onChange(val);
+                          }}
+                          value={field.value}
+                        >
+                          <FormControl>
+                            <SelectTrigger>
+                              <SelectValue placeholder="Select protocol" />
+                            </SelectTrigger>
+                          </FormControl>
+                          <SelectContent>
+                            {privProtocols.map((protocol) => (
+                              <SelectItem key={protocol} value={protocol}>
+                                {protocol}
+                              </SelectItem>
+                            ))}
+                          </SelectContent>
+                        </Select>
+                        <FormDescription>
+                          {t("monitors.form.snmp.priv_protocol_description")}
+                        </FormDescription>
+                        <FormMessage />
+                      </FormItem>
+                    )}
+                  />
+
+                  <FormField
+                    control={form.control}
+                    name="priv_passphrase"
+                    render={({ field }) => (
+                      <FormItem>
+                        <FormLabel>{t("monitors.form.snmp.priv_passphrase_label")}</FormLabel>
+                        <FormControl>
+                          <Input type="password" autoComplete="new-password" {...field} />
+                        </FormControl>
+                        <FormDescription>
+                          {t("monitors.form.snmp.passphrase_description")}
+                        </FormDescription>
+                        <FormMessage />
+                      </FormItem>
+                    )}
+                  />
+                </>
               )}
-            />
+
+              <FormField
+                control={form.control}
+                name="context_name"
+                render={({ field }) => (
+                  <FormItem>
+                    <FormLabel>{t("monitors.form.snmp.context_name_label")}</FormLabel>
+                    <FormControl>
+                      <Input {...field} />
+                    </FormControl>
+                    <FormDescription>
+                      {t("monitors.form.snmp.context_name_description")}
+                    </FormDescription>
+                    <FormMessage />
+                  </FormItem>
+                )}
+              />
+            </CardContent>
+          </Card>
+        )}
+
+        <Card>
+          <CardContent className="space-y-4">
+            <div className="flex items-center justify-between">
+              <div>
+                <TypographyH4>{t("monitors.form.snmp.checks_title")}</TypographyH4>
+                <p className="text-xs text-muted-foreground">
+                  {t("monitors.form.snmp.checks_description")}
+                </p>
+              </div>
+              <Button
+                type="button"
+                variant="outline"
+                size="sm"
+                onClick={() =>
+                  appendCheck({
+                    oid: "",
+                    label: "",
+                    walk: false,
+                    operator: "eq",
+                    value: "",
+                    rate: false,
+                  })
+                }
+              >
+                <Plus className="h-4 w-4 mr-1" />
+                {t("monitors.form.snmp.add_check")}
+              </Button>
+            </div>
+
+            {checkFields.map((checkField, index) => (
+              <div key={checkField.id} className="space-y-3 rounded-md border p-3">
+                <div className="flex items-end gap-2">
+                  <FormField
+                    control={form.control}
+                    name={`checks.${index}.oid`}
+                    render={({ field }) => (
+                      <FormItem className="flex-1">
+                        <FormLabel>{t("monitors.form.snmp.oid_label")}</FormLabel>
+                        <FormControl>
+                          <Input placeholder="1.3.6.1.2.1.2.2.1.8" {...field} />
+                        </FormControl>
+                        <FormMessage />
+                      </FormItem>
+                    )}
+                  />
+                  <FormField
+                    control={form.control}
+                    name={`checks.${index}.label`}
+                    render={({ field }) => (
+                      <FormItem className="flex-1">
+                        <FormLabel>{t("monitors.form.snmp.check_label_label")}</FormLabel>
+                        <FormControl>
+                          <Input placeholder="ifOperStatus" {...field} />
+                        </FormControl>
+                        <FormMessage />
+                      </FormItem>
+                    )}
+                  />
+                  <Button
+                    type="button"
+                    variant="ghost"
+                    size="sm"
+                    onClick={() => removeCheck(index)}
+                    className="text-destructive hover:text-destructive"
+                  >
+                    <Trash2 className="h-4 w-4" />
+                  </Button>
+                </div>
+
+                <div className="flex items-end gap-2">
+                  <FormField
+                    control={form.control}
+                    name={`checks.${index}.operator`}
+                    render={({ field }) => (
+                      <FormItem className="w-40">
+                        <FormLabel>{t("monitors.form.snmp.json_path_operator_label")}</FormLabel>
+                        <Select
+                          onValueChange={(val) => {
+                            if (!val) {
+                              return;
+                            }
+                            field.onChange(val);
+                          }}
+                          value={field.value}
+                        >
+                          <FormControl>
+                            <SelectTrigger>
+                              <SelectValue placeholder="Select condition" />
+                            </SelectTrigger>
+                          </FormControl>
+                          <SelectContent>
+                            {operators.map((operator) => (
+                              <SelectItem key={operator} value={operator}>
+                                {t(`monitors.form.snmp.json_path_operator_${operator}`)}
+                              </SelectItem>
+                            ))}
+                          </SelectContent>
+                        </Select>
+                        <FormMessage />
+                      </FormItem>
+                    )}
+                  />
+                  <FormField
+                    control={form.control}
+                    name={`checks.${index}.value`}
+                    render={({ field }) => (
+                      <FormItem className="flex-1">
+                        <FormLabel>{t("monitors.form.snmp.expected_value_label")}</FormLabel>
+                        <FormControl>
+                          <Input placeholder="1" {...field} />
+                        </FormControl>
+                        <FormMessage />
+                      </FormItem>
+                    )}
+                  />
+                </div>
+
+                <div className="flex gap-6">
+                  <FormField
+                    control={form.control}
+                    name={`checks.${index}.walk`}
+                    render={({ field }) => (
+                      <FormItem className="flex flex-row items-start space-x-3 space-y-0">
+                        <FormControl>
+                          <Checkbox
+                            checked={field.value}
+                            onCheckedChange={field.onChange}
+                          />
+                        </FormControl>
+                        <div className="space-y-1 leading-none">
+                          <FormLabel>{t("monitors.form.snmp.walk_label")}</FormLabel>
+                          <FormDescription>
+                            {t("monitors.form.snmp.walk_description")}
+                          </FormDescription>
+                        </div>
+                      </FormItem>
+                    )}
+                  />
+                  <FormField
+                    control={form.control}
+                    name={`checks.${index}.rate`}
+                    render={({ field }) => (
+                      <FormItem className="flex flex-row items-start space-x-3 space-y-0">
+                        <FormControl>
+                          <Checkbox
+                            checked={field.value}
+                            onCheckedChange={field.onChange}
+                          />
+                        </FormControl>
+                        <div className="space-y-1 leading-none">
+                          <FormLabel>{t("monitors.form.snmp.rate_label")}</FormLabel>
+                          <FormDescription>
+                            {t("monitors.form.snmp.rate_description")}
+                          </FormDescription>
+                        </div>
+                      </FormItem>
+                    )}
+                  />
+                </div>
+              </div>
+            ))}
           </CardContent>
         </Card>
 
+        {checkFields.length === 0 && (
+          <Card>
+            <CardContent className="space-y-4">
+              <TypographyH4>{t("monitors.form.snmp.value_validation_label")}</TypographyH4>
+
+              <FormField
+                control={form.control}
+                name="json_path"
+                render={({ field }) => (
+                  <FormItem>
+                    <FormLabel>{t("monitors.form.snmp.json_path_label")}</FormLabel>
+                    <FormControl>
+                      <Input placeholder="$" {...field} />
+                    </FormControl>
+                    <FormDescription>
+                      {t("monitors.form.snmp.json_path_description")}
+                    </FormDescription>
+                    <FormMessage />
+                  </FormItem>
+                )}
+              />
+
+              <FormField
+                control={form.control}
+                name="json_path_operator"
+                render={({ field }) => (
+                  <FormItem>
+                    <FormLabel>{t("monitors.form.snmp.json_path_operator_label")}</FormLabel>
+                    <Select
+                      onValueChange={(val) => {
+                        if (!val) {
+                          return;
+                        }
+                        field.onChange(val);
+                      }}
+                      value={field.value}
+                    >
+                      <FormControl>
+                        <SelectTrigger>
+                          <SelectValue placeholder="Select condition" />
+                        </SelectTrigger>
+                      </FormControl>
+                      <SelectContent>
+                        <SelectItem value="eq">== ({t("monitors.form.snmp.json_path_operator_eq")})</SelectItem>
+                        <SelectItem value="ne">!= ({t("monitors.form.snmp.json_path_operator_ne")})</SelectItem>
+                        <SelectItem value="lt">&lt; ({t("monitors.form.snmp.json_path_operator_lt")})</SelectItem>
+                        <SelectItem value="gt">&gt; ({t("monitors.form.snmp.json_path_operator_gt")})</SelectItem>
+                        <SelectItem value="le">
+                          &le; ({t("monitors.form.snmp.json_path_operator_le")})
+                        </SelectItem>
+                        <SelectItem value="ge">
+                          &ge; ({t("monitors.form.snmp.json_path_operator_ge")})
+                        </SelectItem>
+                      </SelectContent>
+                    </Select>
+                    <FormDescription>
+                      {t("monitors.form.snmp.json_path_operator_description")}
+                    </FormDescription>
+                    <FormMessage />
+                  </FormItem>
+                )}
+              />
+
+              <FormField
+                control={form.control}
+                name="expected_value"
+                render={({ field }) => (
+                  <FormItem>
+                    <FormLabel>{t("monitors.form.snmp.expected_value_label")}</FormLabel>
+                    <FormControl>
+                      <Input placeholder="Expected value" {...field} />
+                    </FormControl>
+                    <FormDescription>
+                      {t("monitors.form.snmp.expected_value_description")}
+                    </FormDescription>
+                    <FormMessage />
+                  </FormItem>
+                )}
+              />
+            </CardContent>
+          </Card>
+        )}
+
         <Card>
           <CardContent className="space-y-4">
             <Tags />
+            <Dependencies />
+            <Locations />
           </CardContent>
         </Card>
 
@@ -5,6 +5,8 @@ import Intervals from "../shared/intervals";
 import General from "../shared/general";
 import Notifications from "../shared/notifications";
 import Tags from "../shared/tags";
+import Dependencies from "../shared/dependencies";
+import Locations from "../shared/locations";
 import { useMonitorFormContext } from "../../context/monitor-form-context";
 import {
   Form,
@@ -140,6 +142,8 @@ const SQLServerForm = () => {
         <Card>
           <CardContent className="space-y-4">
             <Tags />
+            <Dependencies />
+            <Locations />
           </CardContent>
         </Card>
 
@@ -3,6 +3,8 @@ import { generalDefaultValues, generalSchema } from "../shared/general";
 import { intervalsDefaultValues, intervalsSchema } from "../shared/intervals";
 import { notificationsDefaultValues, notificationsSchema } from "../shared/notifications";
 import { tagsDefaultValues, tagsSchema } from "../shared/tags";
+import { dependenciesDefaultValues, dependenciesSchema } from "../shared/dependencies";
+import { locationsDefaultValues, locationsSchema } from "../shared/locations";
 import type { MonitorMonitorResponseDto, MonitorCreateUpdateDto } from "@/api";
 
 // Regex to validate SQL Server connection string format:
@@ -24,7 +26,9 @@ export const sqlServerSchema = z
   .merge(generalSchema)
   .merge(intervalsSchema)
   .merge(notificationsSchema)
-  .merge(tagsSchema);
+  .merge(tagsSchema)
+  .merge(dependenciesSchema)
+  .merge(locationsSchema);
 
 export type SQLServerForm = z.infer<typeof sqlServerSchema>;
 
@@ -36,6 +40,8 @@ export const sqlServerDefaultValues: SQLServerForm = {
   ...intervalsDefaultValues,
   ...notificationsDefaultValues,
   ...tagsDefaultValues,
+  ...dependenciesDefaultValues,
+  ...locationsDefaultValues,
 };
 
 export const deserialize = (data: MonitorMonitorResponseDto): SQLServerForm => {
@@ -57,6 +63,9 @@ export const deserialize = (data: MonitorMonitorResponseDto): SQLServerForm => {
     resend_interval: data.resend_interval ?? 10,
     notification_ids: data.notification_ids || [],
     tag_ids: data.tag_ids || [],
+    parent_ids: data.parent_ids || [],
+    probe_ids: data.probe_ids || [],
+    probe_down_threshold: data.probe_down_threshold || 1,
     database_connection_string: config.database_connection_string || "",
     database_query: config.database_query || "SELECT 1",
   };
@@ -79,5 +88,8 @@ export const serialize = (formData: SQLServerForm): MonitorCreateUpdateDto => {
     timeout: formData.timeout,
     config: JSON.stringify(config),
     tag_ids: formData.tag_ids,
+    parent_ids: formData.parent_ids,
+    probe_ids: formData.probe_ids,
+    probe_down_threshold: formData.probe_down_threshold,
   };
 };
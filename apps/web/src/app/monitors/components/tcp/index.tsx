@@ -18,24 +18,54 @@ import Tags, {
   tagsDefaultValues,
   tagsSchema,
 } from "../shared/tags";
+import Dependencies, {
+    dependenciesDefaultValues,
+    dependenciesSchema,
+} from "../shared/dependencies";
+import Locations, {
+    locationsDefaultValues,
+    locationsSchema,
+} from "../shared/locations";
 import { useMonitorFormContext } from "../../context/monitor-form-context";
 import {
   Form,
   FormControl,
+  FormDescription,
   FormField,
   FormItem,
   FormLabel,
   FormMessage,
 } from "@/components/ui/form";
 import { Input } from "@/components/ui/input";
-import { Loader2 } from "lucide-react";
+import { Checkbox } from "@/components/ui/checkbox";
+import {
+  Select,
+  SelectContent,
+  SelectItem,
+  SelectTrigger,
+  SelectValue,
+} from "@/components/ui/select";
+import { Loader2, Plus, Trash2 } from "lucide-react";
+import { useFieldArray } from "react-hook-form";
 import type { MonitorCreateUpdateDto, MonitorMonitorResponseDto } from "@/api";
 import { useEffect } from "react";
 import { useLocalizedTranslation } from "@/hooks/useTranslation";
 
+interface TCPScriptStep {
+  send?: string;
+  expect?: string;
+}
+
 interface TCPConfig {
   host: string;
   port: number;
+  tls_mode?: string;
+  starttls_protocol?: string;
+  tls_server_name?: string;
+  ignore_tls_errors?: boolean;
+  check_cert_expiry?: boolean;
+  banner?: string;
+  script?: TCPScriptStep[];
 }
 
 export const tcpSchema = z
@@ -43,11 +73,27 @@ export const tcpSchema = z
     type: z.literal("tcp"),
     host: z.string().min(1, "Host is required"),
     port: z.number().min(1, "Port must be at least 1").max(65535, "Port must be at most 65535"),
+    tls_mode: z.enum(["none", "implicit", "starttls"]),
+    starttls_protocol: z.enum(["smtp", "imap", "pop3", "ftp"]),
+    tls_server_name: z.string().optional(),
+    ignore_tls_errors: z.boolean(),
+    check_cert_expiry: z.boolean(),
+    banner: z.string().optional(),
+    script: z
+      .array(
+        z.object({
+          send: z.string(),
+          expect: z.string(),
+        })
+      )
+      .max(20),
   })
   .merge(generalSchema)
   .merge(intervalsSchema)
   .merge(notificationsSchema)
-  .merge(tagsSchema);
+  .merge(tagsSchema)
+  .merge(dependenciesSchema)
+  .merge(locationsSchema);
 
 export type TCPForm = z.infer<typeof tcpSchema>;
 
@@ -55,10 +101,19 @@ export const tcpDefaultValues: TCPForm = {
   type: "tcp",
   host: "example.com",
   port: 80,
+  tls_mode: "none",
+  starttls_protocol: "smtp",
+  tls_server_name: "",
+  ignore_tls_errors: false,
+  check_cert_expiry: false,
+  banner: "",
+  script: [],
   ...generalDefaultValues,
   ...intervalsDefaultValues,
   ...notificationsDefaultValues,
   ...tagsDefaultValues,
+  ...dependenciesDefaultValues,
+  ...locationsDefaultValues,
 };
 
 export const deserialize = (data: MonitorMonitorResponseDto): TCPForm => {
@@ -73,6 +128,13 @@ export const deserialize = (data: MonitorMonitorResponseDto): TCPForm => {
       config = {
         host: parsedConfig.host || "example.com",
         port: parsedConfig.port ?? 80,
+        tls_mode: parsedConfig.tls_mode || "none",
+        starttls_protocol: parsedConfig.starttls_protocol || "smtp",
+        tls_server_name: parsedConfig.tls_server_name || "",
+        ignore_tls_errors: parsedConfig.ignore_tls_errors ?? false,
+        check_cert_expiry: parsedConfig.check_cert_expiry ?? false,
+        banner: parsedConfig.banner || "",
+        script: parsedConfig.script || [],
       };
     } catch (error) {
       console.error("Failed to parse TCP monitor config:", error);
@@ -84,6 +146,16 @@ export const deserialize = (data: MonitorMonitorResponseDto): TCPForm => {
     name: data.name || "My TCP Monitor",
     host: config.host,
     port: config.port,
+    tls_mode: (config.tls_mode || "none") as TCPForm["tls_mode"],
+    starttls_protocol: (config.starttls_protocol || "smtp") as TCPForm["starttls_protocol"],
+    tls_server_name: config.tls_server_name || "",
+    ignore_tls_errors: config.ignore_tls_errors ?? false,
+    check_cert_expiry: config.check_cert_expiry ?? false,
+    banner: config.banner || "",
+    script: (config.script || []).map((step) => ({
+      send: step.send || "",
+      expect: step.expect || "",
+    })),
     interval: data.interval || 60,
     timeout: data.timeout || 16,
     max_retries: data.max_retries ?? 3,
@@ -91,6 +163,9 @@ export const deserialize = (data: MonitorMonitorResponseDto): TCPForm => {
     resend_interval: data.resend_interval ?? 10,
     notification_ids: data.notification_ids || [],
     tag_ids: data.tag_ids || [],
+    parent_ids: data.parent_ids || [],
+    probe_ids: data.probe_ids || [],
+    probe_down_threshold: data.probe_down_threshold || 1,
   };
 };
 
@@ -100,6 +175,25 @@ export const serialize = (formData: TCPForm): MonitorCreateUpdateDto => {
     port: formData.port,
   };
 
+  if (formData.tls_mode !== "none") {
+    config.tls_mode = formData.tls_mode;
+    config.tls_server_name = formData.tls_server_name || undefined;
+    config.ignore_tls_errors = formData.ignore_tls_errors;
+    config.check_cert_expiry = formData.check_cert_expiry;
+  }
+  if (formData.tls_mode === "starttls") {
+    config.starttls_protocol = formData.starttls_protocol;
+  }
+  if (formData.banner) {
+    config.banner = formData.banner;
+  }
+  if (formData.script.length > 0) {
+    config.script = formData.script.map((step) => ({
+      send: step.send || undefined,
+      expect: step.expect || undefined,
+    }));
+  }
+
   return {
     type: "tcp",
     name: formData.name,
@@ -111,6 +205,9 @@ export const serialize = (formData: TCPForm): MonitorCreateUpdateDto => {
     timeout: formData.timeout,
     config: JSON.stringify(config),
     tag_ids: formData.tag_ids,
+    parent_ids: formData.parent_ids,
+    probe_ids: formData.probe_ids,
+    probe_down_threshold: formData.probe_down_threshold,
   };
 };
 
@@ -127,6 +224,13 @@ const TCPForm = () => {
     monitor,
   } = useMonitorFormContext();
 
+  const tlsMode = form.watch("tls_mode");
+
+  const { fields: scriptFields, append: appendStep, remove: removeStep } = useFieldArray({
+    control: form.control,
+    name: "script",
+  });
+
   const onSubmit = (data: TCPForm) => {
     const payload = serialize(data);
 
@@ -209,9 +313,230 @@ const TCPForm = () => {
           </CardContent>
         </Card>
 
+        <Card>
+          <CardContent className="space-y-4">
+            <TypographyH4>{t("monitors.form.tcp.tls_title")}</TypographyH4>
+
+            <FormField
+              control={form.control}
+              name="tls_mode"
+              render={({ field }) => (
+                <FormItem>
+                  <FormLabel>{t("monitors.form.tcp.tls_mode")}</FormLabel>
+                  <Select
+                    onValueChange={(val) => {
+                      if (!val) {
+                        return;
+                      }
+                      field.onChange(val);
+                    }}
+                    value={field.value}
+                  >
+                    <FormControl>
+                      <SelectTrigger>
+                        <SelectValue />
+                      </SelectTrigger>
+                    </FormControl>
+                    <SelectContent>
+                      <SelectItem value="none">{t("monitors.form.tcp.tls_mode_none")}</SelectItem>
+                      <SelectItem value="implicit">{t("monitors.form.tcp.tls_mode_implicit")}</SelectItem>
+                      <SelectItem value="starttls">STARTTLS</SelectItem>
+                    </SelectContent>
+                  </Select>
+                  <FormMessage />
+                </FormItem>
+              )}
+            />
+
+            {tlsMode === "starttls" && (
+              <FormField
+                control={form.control}
+                name="starttls_protocol"
+                render={({ field }) => (
+                  <FormItem>
+                    <FormLabel>{t("monitors.form.tcp.starttls_protocol")}</FormLabel>
+                    <Select
+                      onValueChange={(val) => {
+                        if (!val) {
+                          return;
+                        }
+                        field.onChange(val);
+                      }}
+                      value={field.value}
+                    >
+                      <FormControl>
+                        <SelectTrigger>
+                          <SelectValue />
+                        </SelectTrigger>
+                      </FormControl>
+                      <SelectContent>
+                        <SelectItem value="smtp">SMTP</SelectItem>
+                        <SelectItem value="imap">IMAP</SelectItem>
+                        <SelectItem value="pop3">POP3</SelectItem>
+                        <SelectItem value="ftp">FTP</SelectItem>
+                      </SelectContent>
+                    </Select>
+                    <FormMessage />
+                  </FormItem>
+                )}
+              />
+            )}
+
+            {tlsMode !== "none" && (
+              <>
+                <FormField
+                  control={form.control}
+                  name="tls_server_name"
+                  render={({ field }) => (
+                    <FormItem>
+                      <FormLabel>{t("monitors.form.tcp.tls_server_name")}</FormLabel>
+                      <FormControl>
+                        <Input placeholder="mail.example.com" {...field} />
+                      </FormControl>
+                      <FormDescription>
+                        {t("monitors.form.tcp.tls_server_name_description")}
+                      </FormDescription>
+                      <FormMessage />
+                    </FormItem>
+                  )}
+                />
+
+                <FormField
+                  control={form.control}
+                  name="ignore_tls_errors"
+                  render={({ field }) => (
+                    <FormItem className="flex flex-row items-start space-x-3 space-y-0">
+                      <FormControl>
+                        <Checkbox
+                          checked={field.value}
+                          onCheckedChange={field.onChange}
+                        />
+                      </FormControl>
+                      <div className="space-y-1 leading-none">
+                        <FormLabel>{t("monitors.form.tcp.ignore_tls_label")}</FormLabel>
+                        <FormDescription>
+                          {t("monitors.form.tcp.ignore_tls_description")}
+                        </FormDescription>
+                      </div>
+                    </FormItem>
+                  )}
+                />
+
+                <FormField
+                  control={form.control}
+                  name="check_cert_expiry"
+                  render={({ field }) => (
+                    <FormItem className="flex flex-row items-start space-x-3 space-y-0">
+                      <FormControl>
+                        <Checkbox
+                          checked={field.value}
+                          onCheckedChange={field.onChange}
+                        />
+                      </FormControl>
+                      <div className="space-y-1 leading-none">
+                        <FormLabel>{t("monitors.form.tcp.check_cert_expiry_label")}</FormLabel>
+                        <FormDescription>
+                          {t("monitors.form.tcp.check_cert_expiry_description")}
+                        </FormDescription>
+                      </div>
+                    </FormItem>
+                  )}
+                />
+              </>
+            )}
+          </CardContent>
+        </Card>
+
+        <Card>
+          <CardContent className="space-y-4">
+            <div className="flex items-center justify-between">
+              <div>
+                <TypographyH4>{t("monitors.form.tcp.script_title")}</TypographyH4>
+                <p className="text-xs text-muted-foreground">
+                  {t("monitors.form.tcp.script_description")}
+                </p>
+              </div>
+              <Button
+                type="button"
+                variant="outline"
+                size="sm"
+                onClick={() => appendStep({ send: "", expect: "" })}
+              >
+                <Plus className="h-4 w-4 mr-1" />
+                {t("monitors.form.tcp.add_step")}
+              </Button>
+            </div>
+
+            <FormField
+              control={form.control}
+              name="banner"
+              render={({ field }) => (
+                <FormItem>
+                  <FormLabel>{t("monitors.form.tcp.banner")}</FormLabel>
+                  <FormControl>
+                    <Input placeholder="^220 .*ESMTP" {...field} />
+                  </FormControl>
+                  <FormDescription>
+                    {t("monitors.form.tcp.banner_description")}
+                  </FormDescription>
+                  <FormMessage />
+                </FormItem>
+              )}
+            />
+
+            {scriptFields.map((stepField, index) => (
+              <div key={stepField.id} className="flex items-end gap-2">
+                <FormField
+                  control={form.control}
+                  name={`script.${index}.send`}
+                  render={({ field }) => (
+                    <FormItem className="flex-1">
+                      <FormLabel>{t("monitors.form.tcp.send")}</FormLabel>
+                      <FormControl>
+                        <Input placeholder="EHLO vigi\r\n" {...field} />
+                      </FormControl>
+                      <FormMessage />
+                    </FormItem>
+                  )}
+                />
+                <FormField
+                  control={form.control}
+                  name={`script.${index}.expect`}
+                  render={({ field }) => (
+                    <FormItem className="flex-1">
+                      <FormLabel>{t("monitors.form.tcp.expect")}</FormLabel>
+                      <FormControl>
+                        <Input placeholder="^250 " {...field} />
+                      </FormControl>
+                      <FormMessage />
+                    </FormItem>
+                  )}
+                />
+                <Button
+                  type="button"
+                  variant="ghost"
+                  size="sm"
+                  onClick={() => removeStep(index)}
+                  className="text-destructive hover:text-destructive"
+                >
+                  <Trash2 className="h-4 w-4" />
+                </Button>
+              </div>
+            ))}
+
+            {scriptFields.length > 0 && (
+              <p className="text-xs text-muted-foreground">
+                {t("monitors.form.tcp.script_escapes")}
+              </p>
+            )}
+          </CardContent>
+        </Card>
+
         <Card>
           <CardContent className="space-y-4">
             <Tags />
+            <Dependencies />
+            <Locations />
           </CardContent>
         </Card>
 
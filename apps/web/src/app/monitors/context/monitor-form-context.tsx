@@ -42,6 +42,7 @@ import {
 } from "../components/http/schema";
 import { httpKeywordSchema, type HttpKeywordForm } from "../components/http-keyword/schema";
 import { httpJsonQuerySchema, type HttpJsonQueryForm } from "../components/http-json-query/schema";
+import { httpTransactionSchema, type HttpTransactionForm } from "../components/http-transaction/schema";
 import { tcpSchema, type TCPForm } from "../components/tcp";
 import { pingSchema, type PingForm } from "../components/ping";
 import { dnsSchema, type DNSForm } from "../components/dns";
@@ -75,6 +76,7 @@ const formSchema = z.discriminatedUnion("type", [
     httpSchema,
     httpKeywordSchema,
     httpJsonQuerySchema,
+    httpTransactionSchema,
     tcpSchema,
     pingSchema,
     dnsSchema,
@@ -96,6 +98,7 @@ export type MonitorForm =
     | HttpForm
     | HttpKeywordForm
     | HttpJsonQueryForm
+    | HttpTransactionForm
     | TCPForm
     | PingForm
     | DNSForm
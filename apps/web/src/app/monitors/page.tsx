@@ -59,8 +59,8 @@ const MonitorsPage = () => {
     >((getParam("active") as "all" | "active" | "inactive") || "all");
 
     const [statusFilter, setStatusFilter] = useState<
-        "all" | "up" | "down" | "maintenance"
-    >((getParam("status") as "all" | "up" | "down" | "maintenance") || "all");
+        "all" | "up" | "down" | "maintenance" | "degraded"
+    >((getParam("status") as "all" | "up" | "down" | "maintenance" | "degraded") || "all");
 
     const [selectedTagIds, setSelectedTagIds] = useState<string[]>(
         getParam("tags")?.split(",").filter(Boolean) || []
@@ -122,7 +122,9 @@ const MonitorsPage = () => {
                                     ? 0
                                     : statusFilter === "maintenance"
                                         ? 3
-                                        : undefined,
+                                        : statusFilter === "degraded"
+                                            ? 4
+                                            : undefined,
                     tag_ids:
                         selectedTagIds.length > 0 ? selectedTagIds.join(",") : undefined,
                 },
@@ -283,7 +285,7 @@ const MonitorsPage = () => {
                             <Select
                                 value={statusFilter}
                                 onValueChange={(v) =>
-                                    setStatusFilter(v as "all" | "up" | "down" | "maintenance")
+                                    setStatusFilter(v as "all" | "up" | "down" | "maintenance" | "degraded")
                                 }
                             >
                                 <SelectTrigger className="w-full md:w-[160px]">
@@ -296,6 +298,9 @@ const MonitorsPage = () => {
                                     <SelectItem value="maintenance">
                                         {t("common.maintenance")}
                                     </SelectItem>
+                                    <SelectItem value="degraded">
+                                        {t("common.degraded")}
+                                    </SelectItem>
                                 </SelectContent>
                             </Select>
                         </div>
@@ -122,9 +122,23 @@ const MonitorPage = () => {
 
     const monitor = data?.data;
 
+    // HTTP monitors and TCP monitors with TLS enabled report certificates
+    const supportsTLS = useMemo(() => {
+        const type = monitor?.type?.toLowerCase() ?? "";
+        if (type.startsWith("http")) return true;
+        if (type !== "tcp") return false;
+
+        try {
+            const config = JSON.parse(monitor?.config ?? "{}");
+            return !!config?.tls_mode && config.tls_mode !== "none";
+        } catch {
+            return false;
+        }
+    }, [monitor]);
+
     const hasCertCheckExpire = useMemo(() => {
         if (!monitor) return false;
-        if (!monitor?.type?.toLowerCase().startsWith("http")) return false;
+        if (!supportsTLS) return false;
 
         try {
             const config = JSON.parse(monitor?.config ?? "{}");
@@ -133,9 +147,9 @@ const MonitorPage = () => {
             console.error("Failed to parse monitor config:", err);
             return false;
         }
-    }, [monitor]);
+    }, [monitor, supportsTLS]);
 
-    // Fetch TLS info for HTTP monitors using React Query
+    // Fetch TLS info for HTTP and TLS-enabled TCP monitors using React Query
     const { data: tlsData, isLoading: tlsLoading } = useQuery({
         ...getMonitorsByIdTlsOptions({
             path: {
@@ -143,7 +157,7 @@ const MonitorPage = () => {
             },
         }),
         enabled:
-            !!id && !!monitor && monitor.type?.toLowerCase().startsWith("http"),
+            !!id && !!monitor && supportsTLS,
     });
 
     // Transform TLS data to match the expected format
@@ -544,18 +558,23 @@ const MonitorPage = () => {
                                         lastHeartbeat?.status === 1 && "text-green-400",
                                         lastHeartbeat?.status === 0 && "text-red-400",
                                         lastHeartbeat?.status === 2 && "text-red-400",
-                                        lastHeartbeat?.status === 3 && "text-blue-400"
+                                        lastHeartbeat?.status === 3 && "text-blue-400",
+                                        lastHeartbeat?.status === 4 && "text-amber-400"
                                     )}
                                 >
                                     {lastHeartbeat?.status === 1 && t("monitors.view.status.up")}
                                     {lastHeartbeat?.status === 0 && t("monitors.view.status.down")}
                                     {lastHeartbeat?.status === 2 && t("monitors.view.status.down")}
                                     {lastHeartbeat?.status === 3 && t("monitors.view.status.maintenance")}
+                                    {lastHeartbeat?.status === 4 && t("monitors.view.status.degraded")}
                                 </div>
                             ) : (
                                 <div className="font-semibold text-2xl">{t("monitors.view.status.paused")}</div>
                             )}
 
+                            {monitor?.active && lastHeartbeat?.flapping && (
+                                <div className="text-sm text-orange-400">{t("monitors.view.status.flapping")}</div>
+                            )}
                             {monitor?.active && lastImportantHeartbeatDuration > 0 && (
                                 <div className="text-sm text-gray-400">{lihText}</div>
                             )}
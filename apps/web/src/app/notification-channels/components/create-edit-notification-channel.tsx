@@ -138,11 +138,13 @@ export default function CreateEditNotificationChannel({
   },
   isLoading = false,
   mode = "create",
+  channelId,
 }: {
   onSubmit: (data: NotificationForm) => void;
   initialValues?: NotificationForm;
   isLoading?: boolean;
   mode?: "create" | "edit";
+  channelId?: string;
 }) {
   const { t } = useLocalizedTranslation();
   const baseForm = useForm<NotificationForm>({
@@ -187,6 +189,7 @@ export default function CreateEditNotificationChannel({
         active: true,
         is_default: false,
       },
+      query: channelId ? { id: channelId } : undefined,
     });
   }
 
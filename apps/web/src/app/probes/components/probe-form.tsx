@@ -0,0 +1,157 @@
+import { useForm } from "react-hook-form";
+import { zodResolver } from "@hookform/resolvers/zod";
+import { z } from "zod";
+import { useMutation, useQueryClient } from "@tanstack/react-query";
+import {
+    createProbe,
+    probesQueryKey,
+    updateProbe,
+    type ProbeDTO,
+    type ProbeModel,
+    type ProbeWithToken,
+} from "@/api/probes";
+import { toast } from "sonner";
+import { useNavigate } from "react-router-dom";
+import { Button } from "@/components/ui/button";
+import { Input } from "@/components/ui/input";
+import { Textarea } from "@/components/ui/textarea";
+import { Card, CardContent } from "@/components/ui/card";
+import {
+    Form,
+    FormControl,
+    FormField,
+    FormItem,
+    FormLabel,
+    FormMessage,
+} from "@/components/ui/form";
+import { Loader2 } from "lucide-react";
+import { commonMutationErrorHandler } from "@/lib/utils";
+import { useLocalizedTranslation } from "@/hooks/useTranslation";
+import { useOrganizationStore } from "@/store/organization";
+
+const probeSchema = z.object({
+    name: z
+        .string()
+        .min(1, "probes.validation.name_required")
+        .max(255, "probes.validation.name_max_length"),
+    description: z.string().max(500).optional(),
+});
+
+export type ProbeFormData = z.infer<typeof probeSchema>;
+
+interface ProbeFormProps {
+    mode: "create" | "edit";
+    probe?: ProbeModel;
+    // The token is only returned once, so the page shows it instead of leaving
+    onCreated?: (probe: ProbeWithToken) => void;
+}
+
+const ProbeForm = ({ mode, probe, onCreated }: ProbeFormProps) => {
+    const { t } = useLocalizedTranslation();
+    const navigate = useNavigate();
+    const queryClient = useQueryClient();
+    const { currentOrganization } = useOrganizationStore();
+    const slug = currentOrganization?.slug;
+    const listPath = slug ? `/${slug}/probes` : "/probes";
+
+    const form = useForm<ProbeFormData>({
+        resolver: zodResolver(probeSchema),
+        defaultValues: {
+            name: probe?.name || "",
+            description: probe?.description || "",
+        },
+    });
+
+    const createMutation = useMutation({
+        mutationFn: (body: ProbeDTO) => createProbe(body),
+        onSuccess: (created) => {
+            toast.success(t("probes.messages.created_successfully"));
+            queryClient.invalidateQueries({ queryKey: probesQueryKey });
+            if (created) {
+                onCreated?.(created);
+            }
+        },
+        onError: commonMutationErrorHandler(t("probes.messages.create_error")),
+    });
+
+    const editMutation = useMutation({
+        mutationFn: (body: ProbeDTO) => updateProbe(probe?.id || "", body),
+        onSuccess: () => {
+            toast.success(t("probes.messages.updated_successfully"));
+            queryClient.invalidateQueries({ queryKey: probesQueryKey });
+            navigate(listPath);
+        },
+        onError: commonMutationErrorHandler(t("probes.messages.update_error")),
+    });
+
+    const onSubmit = (data: ProbeFormData) => {
+        if (mode === "create") {
+            createMutation.mutate(data);
+        } else {
+            editMutation.mutate(data);
+        }
+    };
+
+    const isPending = createMutation.isPending || editMutation.isPending;
+
+    return (
+        <Form {...form}>
+            <form
+                onSubmit={form.handleSubmit(onSubmit)}
+                className="space-y-6 max-w-[600px]"
+            >
+                <Card>
+                    <CardContent className="space-y-4">
+                        <FormField
+                            control={form.control}
+                            name="name"
+                            render={({ field }) => (
+                                <FormItem>
+                                    <FormLabel>{t("probes.form.name_label")}</FormLabel>
+                                    <FormControl>
+                                        <Input placeholder={t("probes.form.name_placeholder")} {...field} />
+                                    </FormControl>
+                                    <FormMessage />
+                                </FormItem>
+                            )}
+                        />
+
+                        <FormField
+                            control={form.control}
+                            name="description"
+                            render={({ field }) => (
+                                <FormItem>
+                                    <FormLabel>{t("probes.form.description_label")}</FormLabel>
+                                    <FormControl>
+                                        <Textarea
+                                            placeholder={t("probes.form.description_placeholder")}
+                                            {...field}
+                                        />
+                                    </FormControl>
+                                    <FormMessage />
+                                </FormItem>
+                            )}
+                        />
+                    </CardContent>
+                </Card>
+
+                <div className="flex gap-2">
+                    <Button
+                        type="button"
+                        variant="outline"
+                        onClick={() => navigate(listPath)}
+                        disabled={isPending}
+                    >
+                        {t("common.cancel")}
+                    </Button>
+                    <Button type="submit" disabled={isPending}>
+                        {isPending && <Loader2 className="animate-spin mr-2 h-4 w-4" />}
+                        {mode === "create" ? t("probes.form.create_button") : t("probes.form.update_button")}
+                    </Button>
+                </div>
+            </form>
+        </Form>
+    );
+};
+
+export default ProbeForm;
@@ -0,0 +1,49 @@
+import { Alert, AlertTitle, AlertDescription } from "@/components/ui/alert";
+import { Button } from "@/components/ui/button";
+import { Copy, Key } from "lucide-react";
+import { toast } from "sonner";
+import { useLocalizedTranslation } from "@/hooks/useTranslation";
+
+interface ProbeTokenAlertProps {
+    token: string;
+}
+
+const ProbeTokenAlert = ({ token }: ProbeTokenAlertProps) => {
+    const { t } = useLocalizedTranslation();
+
+    // The agent is the worker binary started with these variables
+    const agentEnv = `PROBE_API_URL=${window.location.origin}\nPROBE_TOKEN=${token}`;
+
+    const copyToClipboard = (text: string) => {
+        navigator.clipboard.writeText(text);
+        toast.success(t("probes.messages.copy_success"));
+    };
+
+    return (
+        <Alert variant="success">
+            <Key className="h-4 w-4" />
+            <AlertTitle>{t("probes.token_alert.title")}</AlertTitle>
+            <AlertDescription>
+                <div className="mt-2 space-y-2">
+                    <p className="text-sm text-muted-foreground">
+                        {t("probes.token_alert.description")}
+                    </p>
+                    <div className="flex items-start gap-2">
+                        <pre className="px-2 py-1 rounded text-sm font-mono bg-green-500/20 whitespace-pre-wrap break-all">
+                            {agentEnv}
+                        </pre>
+                        <Button
+                            size="sm"
+                            variant="outline"
+                            onClick={() => copyToClipboard(agentEnv)}
+                        >
+                            <Copy className="h-4 w-4" />
+                        </Button>
+                    </div>
+                </div>
+            </AlertDescription>
+        </Alert>
+    );
+};
+
+export default ProbeTokenAlert;
@@ -0,0 +1,121 @@
+import Layout from "@/layout";
+import { BackButton } from "@/components/back-button";
+import { useState } from "react";
+import { useParams } from "react-router-dom";
+import { useMutation, useQuery } from "@tanstack/react-query";
+import { getProbe, probesQueryKey, regenerateProbeToken } from "@/api/probes";
+import { Button } from "@/components/ui/button";
+import {
+    AlertDialog,
+    AlertDialogAction,
+    AlertDialogCancel,
+    AlertDialogContent,
+    AlertDialogDescription,
+    AlertDialogFooter,
+    AlertDialogHeader,
+    AlertDialogTitle,
+} from "@/components/ui/alert-dialog";
+import { RefreshCw } from "lucide-react";
+import { commonMutationErrorHandler } from "@/lib/utils";
+import ProbeForm from "../components/probe-form";
+import ProbeTokenAlert from "../components/probe-token-alert";
+import { useLocalizedTranslation } from "@/hooks/useTranslation";
+
+import { useOrganizationStore } from "@/store/organization";
+
+const EditProbe = () => {
+    const { id } = useParams();
+    const { t } = useLocalizedTranslation();
+    const { currentOrganization } = useOrganizationStore();
+    const slug = currentOrganization?.slug;
+    const listPath = slug ? `/${slug}/probes` : "/probes";
+
+    const [regenerateDialogOpen, setRegenerateDialogOpen] = useState(false);
+    const [token, setToken] = useState<string | null>(null);
+
+    const { data: probe, isLoading, error } = useQuery({
+        queryKey: [...probesQueryKey, id],
+        queryFn: () => getProbe(id!),
+        enabled: !!id,
+    });
+
+    const regenerateMutation = useMutation({
+        mutationFn: () => regenerateProbeToken(id!),
+        onSuccess: (regenerated) => {
+            setRegenerateDialogOpen(false);
+            setToken(regenerated?.token || null);
+        },
+        onError: commonMutationErrorHandler(t("probes.messages.regenerate_error")),
+    });
+
+    if (isLoading) {
+        return (
+            <Layout pageName={t("probes.edit_page_name")}>
+                <BackButton to={listPath} />
+                <div>{t("common.loading")}</div>
+            </Layout>
+        );
+    }
+
+    if (error || !probe) {
+        return (
+            <Layout pageName={t("probes.edit_page_name")}>
+                <BackButton to={listPath} />
+                <div className="text-red-500">
+                    {t("probes.messages.failed_to_load")}
+                </div>
+            </Layout>
+        );
+    }
+
+    return (
+        <Layout pageName={`${t("probes.edit_page_name")}: ${probe.name}`}>
+            <BackButton to={listPath} />
+            <div className="flex flex-col gap-4">
+                <p className="text-gray-500">
+                    {t("probes.messages.update_description")}
+                </p>
+
+                {token && (
+                    <div className="max-w-[600px]">
+                        <ProbeTokenAlert token={token} />
+                    </div>
+                )}
+
+                <div>
+                    <Button
+                        variant="outline"
+                        onClick={() => setRegenerateDialogOpen(true)}
+                    >
+                        <RefreshCw className="mr-2 h-4 w-4" />
+                        {t("probes.regenerate_token")}
+                    </Button>
+                </div>
+
+                <ProbeForm mode="edit" probe={probe} />
+            </div>
+
+            <AlertDialog open={regenerateDialogOpen} onOpenChange={setRegenerateDialogOpen}>
+                <AlertDialogContent>
+                    <AlertDialogHeader>
+                        <AlertDialogTitle>{t("probes.regenerate_token")}</AlertDialogTitle>
+                        <AlertDialogDescription>
+                            {t("probes.confirm_regenerate_description", { name: probe.name })}
+                        </AlertDialogDescription>
+                    </AlertDialogHeader>
+                    <AlertDialogFooter>
+                        <AlertDialogCancel>{t("common.cancel")}</AlertDialogCancel>
+                        <AlertDialogAction
+                            onClick={() => regenerateMutation.mutate()}
+                            disabled={regenerateMutation.isPending}
+                        >
+                            {t("probes.regenerate_token")}
+                        </AlertDialogAction>
+                    </AlertDialogFooter>
+                </AlertDialogContent>
+            </AlertDialog>
+        </Layout>
+    );
+};
+
+export default EditProbe;
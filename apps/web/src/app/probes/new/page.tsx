@@ -0,0 +1,49 @@
+import Layout from "@/layout";
+import { BackButton } from "@/components/back-button";
+import { useState } from "react";
+import { useNavigate } from "react-router-dom";
+import { Button } from "@/components/ui/button";
+import type { ProbeWithToken } from "@/api/probes";
+import ProbeForm from "../components/probe-form";
+import ProbeTokenAlert from "../components/probe-token-alert";
+import { useLocalizedTranslation } from "@/hooks/useTranslation";
+
+import { useOrganizationStore } from "@/store/organization";
+
+const NewProbe = () => {
+    const { t } = useLocalizedTranslation();
+    const navigate = useNavigate();
+    const { currentOrganization } = useOrganizationStore();
+    const slug = currentOrganization?.slug;
+    const listPath = slug ? `/${slug}/probes` : "/probes";
+
+    const [created, setCreated] = useState<ProbeWithToken | null>(null);
+
+    return (
+        <Layout pageName={t("probes.new_page_name")}>
+            <BackButton to={listPath} />
+            <div className="flex flex-col gap-4 max-w-[600px]">
+                {created ? (
+                    <>
+                        <ProbeTokenAlert token={created.token} />
+                        <div>
+                            <Button onClick={() => navigate(listPath)}>
+                                {t("probes.token_alert.done")}
+                            </Button>
+                        </div>
+                    </>
+                ) : (
+                    <>
+                        <p className="text-gray-500">
+                            {t("probes.messages.create_description")}
+                        </p>
+
+                        <ProbeForm mode="create" onCreated={setCreated} />
+                    </>
+                )}
+            </div>
+        </Layout>
+    );
+};
+
+export default NewProbe;
@@ -0,0 +1,227 @@
+import { useQuery, useQueryClient, useMutation } from "@tanstack/react-query";
+import {
+    deleteProbe,
+    getProbes,
+    probesQueryKey,
+    type ProbeModel,
+} from "@/api/probes";
+import Layout from "@/layout";
+import { useState, useEffect } from "react";
+import { useDebounce } from "@/hooks/useDebounce";
+import { useSearchParams } from "@/hooks/useSearchParams";
+import { Input } from "@/components/ui/input";
+import { Skeleton } from "@/components/ui/skeleton";
+import { Label } from "@/components/ui/label";
+import EmptyList from "@/components/empty-list";
+import { useLocalizedTranslation } from "@/hooks/useTranslation";
+import { Button } from "@/components/ui/button";
+import { Card, CardContent } from "@/components/ui/card";
+import { Badge } from "@/components/ui/badge";
+import { Pencil, Trash2 } from "lucide-react";
+import {
+    AlertDialog,
+    AlertDialogAction,
+    AlertDialogCancel,
+    AlertDialogContent,
+    AlertDialogDescription,
+    AlertDialogFooter,
+    AlertDialogHeader,
+    AlertDialogTitle,
+} from "@/components/ui/alert-dialog";
+import { toast } from "sonner";
+import { cn, commonMutationErrorHandler } from "@/lib/utils";
+import { useNavigate } from "react-router-dom";
+import { useTimezone } from "@/context/timezone-context";
+import { formatDateToTimezone } from "@/lib/formatDateToTimezone";
+
+// Agents pull their checks every 30 seconds by default, a probe quiet for
+// longer than this is considered offline
+const ONLINE_WINDOW_MS = 2 * 60 * 1000;
+
+const isOnline = (probe: ProbeModel) =>
+    !!probe.last_seen_at && Date.now() - new Date(probe.last_seen_at).getTime() < ONLINE_WINDOW_MS;
+
+const ProbesPage = () => {
+    const queryClient = useQueryClient();
+    const navigate = useNavigate();
+    const { t } = useLocalizedTranslation();
+    const { timezone: userTimezone } = useTimezone();
+    const { getParam, updateSearchParams, clearAllParams, hasParams } =
+        useSearchParams();
+
+    const [search, setSearch] = useState(getParam("q") || "");
+    const debouncedSearch = useDebounce(search, 400);
+
+    useEffect(() => {
+        updateSearchParams({ q: debouncedSearch });
+    }, [debouncedSearch, updateSearchParams]);
+
+    const clearAllFilters = () => {
+        setSearch("");
+        clearAllParams();
+    };
+
+    const [deleteDialogOpen, setDeleteDialogOpen] = useState(false);
+    const [selectedProbe, setSelectedProbe] = useState<ProbeModel | null>(null);
+
+    const { data: probes = [], isLoading } = useQuery({
+        queryKey: [...probesQueryKey, "list", debouncedSearch],
+        queryFn: () => getProbes({ limit: 100, q: debouncedSearch || undefined }),
+        refetchInterval: 30000,
+    });
+
+    const deleteMutation = useMutation({
+        mutationFn: (id: string) => deleteProbe(id),
+        onSuccess: () => {
+            toast.success(t("probes.messages.deleted_successfully"));
+            setDeleteDialogOpen(false);
+            setSelectedProbe(null);
+            queryClient.invalidateQueries({ queryKey: probesQueryKey });
+        },
+        onError: commonMutationErrorHandler(t("probes.messages.delete_error")),
+    });
+
+    const handleDelete = (probe: ProbeModel) => {
+        setSelectedProbe(probe);
+        setDeleteDialogOpen(true);
+    };
+
+    const formatLastSeen = (probe: ProbeModel) => {
+        if (!probe.last_seen_at) return t("probes.never_seen");
+        return t("probes.last_seen", {
+            time: formatDateToTimezone(probe.last_seen_at, userTimezone, {
+                year: "numeric",
+                month: "2-digit",
+                day: "2-digit",
+                hour: "2-digit",
+                minute: "2-digit",
+            }),
+        });
+    };
+
+    const handleCreate = () => {
+        navigate("new");
+    };
+
+    return (
+        <Layout pageName={t("navigation.probes")} onCreate={handleCreate}>
+            <div>
+                <div className="mb-4 space-y-4">
+                    <div className="flex flex-col gap-4 sm:flex-row sm:justify-end sm:gap-4 items-end">
+                        {hasParams() && (
+                            <div className="flex justify-start">
+                                <Button
+                                    variant="outline"
+                                    size="sm"
+                                    onClick={clearAllFilters}
+                                    className="w-fit h-[36px]"
+                                >
+                                    {t("common.clear_all_filters")}
+                                </Button>
+                            </div>
+                        )}
+                        <div className="flex flex-col gap-1 w-full sm:w-auto">
+                            <Label htmlFor="search-probes">{t("common.search")}</Label>
+                            <Input
+                                id="search-probes"
+                                placeholder={t("probes.search_placeholder")}
+                                value={search}
+                                onChange={(e) => setSearch(e.target.value)}
+                                className="w-full sm:w-[400px]"
+                            />
+                        </div>
+                    </div>
+                </div>
+
+                {probes.length === 0 && isLoading && (
+                    <div className="space-y-4">
+                        {Array.from({ length: 6 }, (_, id) => (
+                            <Skeleton className="h-[68px] w-full rounded-xl" key={id} />
+                        ))}
+                    </div>
+                )}
+
+                {probes.length === 0 && !isLoading && (
+                    <EmptyList
+                        title={t("probes.empty_state.title")}
+                        text={t("probes.empty_state.description")}
+                        actionText={t("probes.empty_state.action")}
+                        onClick={handleCreate}
+                    />
+                )}
+
+                <div className="space-y-4">
+                    {probes.map((probe) => (
+                        <Card
+                            key={probe.id}
+                            className="hover:shadow-md transition-shadow py-2"
+                        >
+                            <CardContent className="px-3">
+                                <div className="flex items-center justify-between">
+                                    <div className="flex items-center gap-4 flex-1 min-w-0">
+                                        <Badge
+                                            className={cn("text-white flex-shrink-0", {
+                                                "bg-green-500 border-green-600": isOnline(probe),
+                                                "bg-gray-500 border-gray-600": !isOnline(probe),
+                                            })}
+                                        >
+                                            {isOnline(probe) ? t("probes.online") : t("probes.offline")}
+                                        </Badge>
+                                        <div className="flex-1 min-w-0">
+                                            <div className="font-medium truncate">{probe.name}</div>
+                                            <div className="text-xs text-muted-foreground truncate">
+                                                {probe.description && <>{probe.description} · </>}
+                                                {formatLastSeen(probe)}
+                                            </div>
+                                        </div>
+                                    </div>
+                                    <div className="flex gap-2 flex-shrink-0">
+                                        <Button
+                                            variant="ghost"
+                                            size="sm"
+                                            onClick={() => navigate(`${probe.id}/edit`)}
+                                            className="h-8 w-8 p-0"
+                                        >
+                                            <Pencil className="h-4 w-4" />
+                                        </Button>
+                                        <Button
+                                            variant="ghost"
+                                            size="sm"
+                                            onClick={() => handleDelete(probe)}
+                                            className="h-8 w-8 p-0 text-red-500 hover:text-red-600"
+                                        >
+                                            <Trash2 className="h-4 w-4" />
+                                        </Button>
+                                    </div>
+                                </div>
+                            </CardContent>
+                        </Card>
+                    ))}
+                </div>
+
+                <AlertDialog open={deleteDialogOpen} onOpenChange={setDeleteDialogOpen}>
+                    <AlertDialogContent>
+                        <AlertDialogHeader>
+                            <AlertDialogTitle>{t("common.confirm_delete_title_short")}</AlertDialogTitle>
+                            <AlertDialogDescription>
+                                {t("probes.confirm_delete_description", { name: selectedProbe?.name })}
+                            </AlertDialogDescription>
+                        </AlertDialogHeader>
+                        <AlertDialogFooter>
+                            <AlertDialogCancel>{t("common.cancel")}</AlertDialogCancel>
+                            <AlertDialogAction
+                                onClick={() => deleteMutation.mutate(selectedProbe?.id || "")}
+                                className="bg-red-500 hover:bg-red-600"
+                                disabled={deleteMutation.isPending}
+                            >
+                                {t("common.delete")}
+                            </AlertDialogAction>
+                        </AlertDialogFooter>
+                    </AlertDialogContent>
+                </AlertDialog>
+            </div>
+        </Layout>
+    );
+};
+
+export default ProbesPage;
@@ -79,6 +79,12 @@ export default function PublicInvoicePage() {
             <Printer className="h-4 w-4 mr-2" />
             {t("common.print")}
           </Button>
+          <Button variant="secondary" size="sm" asChild className="bg-secondary hover:bg-secondary/80 text-secondary-foreground border-border">
+            <a href={`/api/v1/public/invoices/${id}/pdf`}>
+              <Download className="h-4 w-4 mr-2" />
+              {t("invoice.actions.download_pdf")}
+            </a>
+          </Button>
           {invoice.nfLink && (
             <Button variant="secondary" size="sm" asChild className="bg-secondary hover:bg-secondary/80 text-secondary-foreground border-border">
               <a href={invoice.nfLink} target="_blank" rel="noopener noreferrer">
@@ -0,0 +1,135 @@
+import React from "react";
+import { Link, useSearchParams } from "react-router-dom";
+import { useForm } from "react-hook-form";
+import { zodResolver } from "@hookform/resolvers/zod";
+import { z } from "zod";
+import { isAxiosError } from "axios";
+import { AlertCircle, CheckCircle, GalleryVerticalEnd, Loader2 } from "lucide-react";
+import { resetPassword } from "@/api/auth-emails";
+import { Alert, AlertDescription, AlertTitle } from "@/components/ui/alert";
+import { Button } from "@/components/ui/button";
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from "@/components/ui/card";
+import { Form, FormControl, FormField, FormItem, FormLabel, FormMessage } from "@/components/ui/form";
+import { PasswordInput } from "@/components/ui/password-input";
+import { useLocalizedTranslation } from "@/hooks/useTranslation";
+
+const createFormSchema = (t: (key: string) => string) => z
+    .object({
+        password: z.string().min(8, t("forms.validation.password_min_length")),
+        confirmPassword: z.string().min(8, t("forms.validation.password_min_length")),
+    })
+    .refine((data) => data.password === data.confirmPassword, {
+        message: t("forms.validation.passwords_mismatch"),
+        path: ["confirmPassword"],
+    });
+
+type FormValues = z.infer<ReturnType<typeof createFormSchema>>;
+
+export default function ResetPasswordPage() {
+    const { t } = useLocalizedTranslation();
+    const [searchParams] = useSearchParams();
+    const token = searchParams.get("token");
+    const [done, setDone] = React.useState(false);
+    const [submitting, setSubmitting] = React.useState(false);
+    const [serverError, setServerError] = React.useState<string | null>(
+        token ? null : t("auth.reset_password.invalid_link")
+    );
+
+    const form = useForm<FormValues>({
+        resolver: zodResolver(createFormSchema(t)),
+        defaultValues: { password: "", confirmPassword: "" },
+    });
+
+    async function onSubmit({ password }: FormValues) {
+        if (!token) return;
+        setServerError(null);
+        setSubmitting(true);
+        try {
+            await resetPassword(token, password);
+            setDone(true);
+        } catch (error) {
+            setServerError(
+                (isAxiosError(error) && error.response?.data.message) || t("messages.unexpected_error")
+            );
+        } finally {
+            setSubmitting(false);
+        }
+    }
+
+    return (
+        <div className="flex min-h-svh flex-col items-center justify-center gap-6 bg-muted p-6 md:p-10">
+            <div className="flex w-full max-w-sm flex-col gap-6">
+                <a href="#" className="flex items-center gap-2 self-center font-medium">
+                    <div className="flex h-6 w-6 items-center justify-center rounded-md bg-primary text-primary-foreground">
+                        <GalleryVerticalEnd className="size-4" />
+                    </div>
+                    Vigi
+                </a>
+
+                <Card>
+                    <CardHeader className="text-center">
+                        <CardTitle className="text-xl">{t("auth.reset_password.title")}</CardTitle>
+                        <CardDescription>{t("auth.reset_password.description")}</CardDescription>
+                    </CardHeader>
+                    <CardContent className="grid gap-6">
+                        {done ? (
+                            <div className="flex flex-col items-center gap-2 text-center text-sm">
+                                <CheckCircle className="h-8 w-8 text-green-500" />
+                                {t("auth.reset_password.success")}
+                            </div>
+                        ) : (
+                            <Form {...form}>
+                                <form onSubmit={form.handleSubmit(onSubmit)} className="grid gap-6">
+                                    <FormField
+                                        control={form.control}
+                                        name="password"
+                                        render={({ field }) => (
+                                            <FormItem>
+                                                <FormLabel>{t("forms.labels.password")}</FormLabel>
+                                                <FormControl>
+                                                    <PasswordInput {...field} placeholder="********" />
+                                                </FormControl>
+                                                <FormMessage />
+                                            </FormItem>
+                                        )}
+                                    />
+
+                                    <FormField
+                                        control={form.control}
+                                        name="confirmPassword"
+                                        render={({ field }) => (
+                                            <FormItem>
+                                                <FormLabel>{t("forms.labels.confirm_password")}</FormLabel>
+                                                <FormControl>
+                                                    <PasswordInput {...field} placeholder="********" />
+                                                </FormControl>
+                                                <FormMessage />
+                                            </FormItem>
+                                        )}
+                                    />
+
+                                    {serverError && (
+                                        <Alert variant="destructive">
+                                            <AlertCircle className="h-4 w-4" />
+                                            <AlertTitle>{t("common.error")}</AlertTitle>
+                                            <AlertDescription>{serverError}</AlertDescription>
+                                        </Alert>
+                                    )}
+
+                                    <Button type="submit" className="w-full" disabled={submitting || !token}>
+                                        {submitting && <Loader2 className="mr-2 h-4 w-4 animate-spin" />}
+                                        {t("auth.reset_password.submit")}
+                                    </Button>
+                                </form>
+                            </Form>
+                        )}
+
+                        <Link to="/login" className="text-center text-sm font-medium text-primary hover:underline">
+                            {t("auth.sso.back_to_login")}
+                        </Link>
+                    </CardContent>
+                </Card>
+            </div>
+        </div>
+    );
+}
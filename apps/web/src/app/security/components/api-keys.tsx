@@ -267,7 +267,12 @@ const APIKeys = () => {
               <TableBody>
                 {apiKeys.map((apiKey) => (
                   <TableRow key={apiKey.id}>
-                    <TableCell className="font-medium">{apiKey.name}</TableCell>
+                    <TableCell className="font-medium">
+                      <div>{apiKey.name}</div>
+                      <div className="text-xs font-mono text-muted-foreground">
+                        {apiKey.scopes?.join(", ")}
+                      </div>
+                    </TableCell>
                     <TableCell>
                       <div className="flex items-center gap-2">
                         <code className="bg-muted px-2 py-1 rounded text-sm font-mono">
@@ -292,7 +297,12 @@ const APIKeys = () => {
                       </div>
                     </TableCell>
                     <TableCell className="text-sm text-muted-foreground">
-                      {formatDateTime(apiKey.last_used)}
+                      <div>{formatDateTime(apiKey.last_used)}</div>
+                      {apiKey.last_used_ip && (
+                        <div className="text-xs font-mono">
+                          {apiKey.last_used_ip} {apiKey.last_used_route}
+                        </div>
+                      )}
                     </TableCell>
                     <TableCell className="text-sm text-muted-foreground">
                       {formatDate(apiKey.expires_at)}
@@ -1,6 +1,7 @@
 // MARK: - Imports
 import { Button } from "@/components/ui/button";
 import { Input } from "@/components/ui/input";
+import { Checkbox } from "@/components/ui/checkbox";
 import {
   Dialog,
   DialogContent,
@@ -35,8 +36,25 @@ import type {
 } from "@/api/types.gen";
 
 // MARK: - Schema & Types
+const API_KEY_SCOPES = [
+  "monitors:read",
+  "monitors:write",
+  "push",
+  "invoices:read",
+  "invoices:write",
+  "status_pages:read",
+] as const;
+
+const parseAllowedIPs = (value?: string) =>
+  (value || "")
+    .split(/[\s,]+/)
+    .map((entry) => entry.trim())
+    .filter(Boolean);
+
 const createAPIKeySchema = z.object({
   name: z.string().min(1, "Name is required").max(255, "Name too long"),
+  scopes: z.array(z.string()).min(1, "Select at least one scope"),
+  allowedIPs: z.string().optional(),
   expiresAt: z
     .string()
     .optional()
@@ -90,6 +108,8 @@ const CreateAPIKeyModal = ({
     resolver: zodResolver(createAPIKeySchema),
     defaultValues: {
       name: "",
+      scopes: [],
+      allowedIPs: "",
       expiresAt: "",
       maxUsageCount: "",
     },
@@ -103,8 +123,11 @@ const CreateAPIKeyModal = ({
   };
 
   const onSubmit = (data: CreateAPIKeyForm) => {
+    const allowedIPs = parseAllowedIPs(data.allowedIPs);
     const createData: ApiKeyCreateApiKeyDto = {
       name: data.name,
+      scopes: data.scopes,
+      allowed_ips: allowedIPs.length > 0 ? allowedIPs : undefined,
       expires_at: data.expiresAt
         ? convertDateTimeLocalToUTC(data.expiresAt, userTimezone)
         : undefined,
@@ -177,6 +200,63 @@ const CreateAPIKeyModal = ({
                 </FormItem>
               )}
             />
+            <FormField
+              control={form.control}
+              name="scopes"
+              render={({ field }) => (
+                <FormItem>
+                  <FormLabel>
+                    {t("security.api_keys.create_dialog.form.scopes_label")}
+                  </FormLabel>
+                  <div className="grid grid-cols-2 gap-2">
+                    {API_KEY_SCOPES.map((scope) => (
+                      <FormItem
+                        key={scope}
+                        className="flex items-center space-x-2 space-y-0"
+                      >
+                        <FormControl>
+                          <Checkbox
+                            checked={field.value?.includes(scope)}
+                            onCheckedChange={(checked) => {
+                              const current = field.value || [];
+                              field.onChange(
+                                checked
+                                  ? [...current, scope]
+                                  : current.filter((s) => s !== scope)
+                              );
+                            }}
+                          />
+                        </FormControl>
+                        <FormLabel className="font-mono text-xs">
+                          {scope}
+                        </FormLabel>
+                      </FormItem>
+                    ))}
+                  </div>
+                  <FormMessage />
+                </FormItem>
+              )}
+            />
+            <FormField
+              control={form.control}
+              name="allowedIPs"
+              render={({ field }) => (
+                <FormItem>
+                  <FormLabel>
+                    {t("security.api_keys.create_dialog.form.allowed_ips_label")}
+                  </FormLabel>
+                  <FormControl>
+                    <Input
+                      {...field}
+                      placeholder={t(
+                        "security.api_keys.create_dialog.form.allowed_ips_placeholder"
+                      )}
+                    />
+                  </FormControl>
+                  <FormMessage />
+                </FormItem>
+              )}
+            />
             <FormField
               control={form.control}
               name="expiresAt"
@@ -0,0 +1,47 @@
+import { useMutation } from "@tanstack/react-query";
+import { MailWarning } from "lucide-react";
+import { toast } from "sonner";
+import { resendEmailVerification } from "@/api/auth-emails";
+import { Alert, AlertDescription, AlertTitle } from "@/components/ui/alert";
+import { Button } from "@/components/ui/button";
+import { commonMutationErrorHandler } from "@/lib/utils";
+import { useLocalizedTranslation } from "@/hooks/useTranslation";
+import { useAuthStore } from "@/store/auth";
+
+// Offers a new verification link to users who did not verify their email yet
+const VerifyEmail = () => {
+  const { t } = useLocalizedTranslation();
+  const user = useAuthStore((s) => s.user);
+
+  const resendMutation = useMutation({
+    mutationFn: resendEmailVerification,
+    onSuccess: () => {
+      toast.success(t("security.verify_email.messages.sent"));
+    },
+    onError: commonMutationErrorHandler(t("security.verify_email.messages.failed_to_send")),
+  });
+
+  // Sessions started before the field existed don't know the state, stay quiet
+  if (user?.emailVerified !== false) return null;
+
+  return (
+    <Alert className="mb-6">
+      <MailWarning className="h-4 w-4" />
+      <AlertTitle>{t("security.verify_email.title")}</AlertTitle>
+      <AlertDescription className="flex flex-col gap-3">
+        {t("security.verify_email.description", { email: user.email })}
+        <Button
+          variant="outline"
+          size="sm"
+          className="w-fit"
+          disabled={resendMutation.isPending}
+          onClick={() => resendMutation.mutate()}
+        >
+          {t("security.verify_email.resend_button")}
+        </Button>
+      </AlertDescription>
+    </Alert>
+  );
+};
+
+export default VerifyEmail;
@@ -2,6 +2,7 @@ import Layout from "@/layout";
 import UpdatePassword from "./components/update-password";
 import Enable2FA from "./components/enable-2fa";
 import APIKeys from "./components/api-keys";
+import VerifyEmail from "./components/verify-email";
 import { useAuthStore } from "@/store/auth";
 import {
   Card,
@@ -64,6 +65,7 @@ const SecurityPage = () => {
 
   return (
     <Layout pageName={t("security.page_name")}>
+      <VerifyEmail />
       <UpdatePassword />
 
       {user?.twofa_status ? (
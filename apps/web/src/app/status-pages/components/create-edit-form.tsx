@@ -12,6 +12,7 @@ import {
     FormMessage,
 } from "@/components/ui/form";
 import SearchableMonitorSelector from "@/components/searchable-monitor-selector";
+import MonitorGroupSelector from "@/components/monitor-group-selector";
 import { zodResolver } from "@hookform/resolvers/zod";
 import { z } from "zod";
 import { useForm } from "react-hook-form";
@@ -47,6 +48,7 @@ const statusPageSchema = z.object({
     footer_text: z.string().optional(),
     auto_refresh_interval: z.number().min(0).optional(),
     published: z.boolean(),
+    auto_incidents: z.boolean().optional(),
     monitors: z
         .array(
             z.object({
@@ -55,6 +57,7 @@ const statusPageSchema = z.object({
             })
         )
         .optional(),
+    group_ids: z.array(z.string()).optional(),
     domains: z.array(z.string()).optional(),
 });
 
@@ -70,6 +73,11 @@ type SlugAlreadyUsedError = {
     slug: string;
 };
 
+type GroupNotFoundError = {
+    code: "GROUP_NOT_FOUND";
+    group_id: string;
+};
+
 const formDefaultValues: StatusPageForm = {
     title: "",
     slug: "",
@@ -78,7 +86,9 @@ const formDefaultValues: StatusPageForm = {
     footer_text: "",
     auto_refresh_interval: 300,
     published: true,
+    auto_incidents: false,
     monitors: [],
+    group_ids: [],
     domains: [],
 };
 
@@ -104,7 +114,7 @@ const CreateEditForm = ({
         resolver: zodResolver(statusPageSchema),
     });
 
-    const handleMutationError = (error: AxiosError<{ error: DomainAlreadyUsedError | SlugAlreadyUsedError }>) => {
+    const handleMutationError = (error: AxiosError<{ error: DomainAlreadyUsedError | SlugAlreadyUsedError | GroupNotFoundError }>) => {
         // Fallback toast
         const fallbackMessage = mode === "create" ? "Failed to create status page" : "Failed to update status page";
         commonMutationErrorHandler(fallbackMessage)(error);
@@ -126,6 +136,10 @@ const CreateEditForm = ({
             form.setError("slug", { message: t("status_pages.slug_already_used", { slug: errorData.slug }) });
             window.scrollTo({ top: 0, behavior: "smooth" });
         }
+
+        if (errorData?.code === "GROUP_NOT_FOUND") {
+            form.setError("group_ids", { message: t("status_pages.group_not_found") });
+        }
     };
 
     const createStatusPageMutation = useMutation({
@@ -270,6 +284,28 @@ const CreateEditForm = ({
                                 />
                             </div>
                         </div>
+
+                        <div className="space-y-4">
+                            <h2 className="text-lg font-semibold">{t("status_pages.monitor_groups")}</h2>
+                            <FormField
+                                control={form.control}
+                                name="group_ids"
+                                render={({ field }) => (
+                                    <FormItem>
+                                        <p className="text-sm text-muted-foreground">
+                                            {t("status_pages.monitor_groups_info")}
+                                        </p>
+                                        <FormControl>
+                                            <MonitorGroupSelector
+                                                value={field.value || []}
+                                                onSelect={field.onChange}
+                                            />
+                                        </FormControl>
+                                        <FormMessage />
+                                    </FormItem>
+                                )}
+                            />
+                        </div>
                     </CardContent>
                 </Card>
 
@@ -342,6 +378,29 @@ const CreateEditForm = ({
                                 </FormItem>
                             )}
                         />
+                        <FormField
+                            control={form.control}
+                            name="auto_incidents"
+                            render={({ field }) => (
+                                <FormItem>
+                                    <div className="flex items-center justify-between">
+                                        <div className="space-y-0.5">
+                                            <FormLabel>{t("status_pages.auto_incidents")}</FormLabel>
+                                            <p className="text-sm text-muted-foreground">
+                                                {t("status_pages.auto_incidents_info")}
+                                            </p>
+                                        </div>
+                                        <FormControl>
+                                            <Switch
+                                                checked={Boolean(field.value)}
+                                                onCheckedChange={field.onChange}
+                                            />
+                                        </FormControl>
+                                    </div>
+                                    <FormMessage />
+                                </FormItem>
+                            )}
+                        />
                         <FormField
                             control={form.control}
                             name="domains"
@@ -69,7 +69,9 @@ const EditStatusPageContent = () => {
                         footer_text: statusPageData.footer_text || "",
                         auto_refresh_interval: statusPageData?.auto_refresh_interval || 0,
                         published: Boolean(statusPageData?.published),
+                        auto_incidents: Boolean(statusPageData?.auto_incidents),
                         domains: statusPageData.domains || [],
+                        group_ids: statusPageData.group_ids || [],
                         monitors: monitorsData?.data?.map((monitor) => ({
                             label: monitor.name || "",
                             value: monitor.id || "",
@@ -0,0 +1,124 @@
+import { Card, CardContent } from "@/components/ui/card";
+import { Badge } from "@/components/ui/badge";
+import { AlertTriangle, CheckCircle } from "lucide-react";
+import type {
+  StatusPageMonitorWithHeartbeatsAndUptimeDto,
+  StatusPagePublicIncidentDto,
+} from "@/api/types.gen";
+import { useLocalizedTranslation } from "@/hooks/useTranslation";
+
+// Incidents are attached to every monitor they affect, so collect them once
+export const collectIncidents = (
+  monitors: StatusPageMonitorWithHeartbeatsAndUptimeDto[]
+) => {
+  const byId = new Map<string, StatusPagePublicIncidentDto>();
+  monitors.forEach((monitor) => {
+    (monitor.incidents || []).forEach((incident) => {
+      if (incident.id && !byId.has(incident.id)) {
+        byId.set(incident.id, incident);
+      }
+    });
+  });
+
+  return Array.from(byId.values()).sort(
+    (a, b) =>
+      new Date(b.started_at || 0).getTime() -
+      new Date(a.started_at || 0).getTime()
+  );
+};
+
+const formatDate = (value?: string) =>
+  value ? new Date(value).toLocaleString() : "";
+
+const IncidentCard = ({ incident }: { incident: StatusPagePublicIncidentDto }) => {
+  const { t } = useLocalizedTranslation();
+  const resolved = incident.state === "resolved";
+
+  return (
+    <Card className={resolved ? undefined : "border-red-500/50"}>
+      <CardContent className="space-y-3 text-left">
+        <div className="flex items-start justify-between gap-2">
+          <div className="flex items-center gap-2">
+            {resolved ? (
+              <CheckCircle className="h-5 w-5 text-green-500" />
+            ) : (
+              <AlertTriangle className="h-5 w-5 text-red-500" />
+            )}
+            <h3 className="font-semibold">{incident.title}</h3>
+          </div>
+          <div className="flex items-center gap-2">
+            <Badge variant="outline">
+              {t(`status.incidents.severity.${incident.severity}`)}
+            </Badge>
+            <Badge variant={resolved ? "secondary" : "destructive"}>
+              {t(`status.incidents.state.${incident.state}`)}
+            </Badge>
+          </div>
+        </div>
+
+        <p className="text-xs text-muted-foreground">
+          {resolved && incident.resolved_at
+            ? t("status.incidents.resolved_at", { date: formatDate(incident.resolved_at) })
+            : t("status.incidents.started_at", { date: formatDate(incident.started_at) })}
+        </p>
+
+        <ol className="space-y-2 border-l pl-4">
+          {(incident.updates || []).map((update, index) => (
+            <li key={index} className="text-sm">
+              <span className="font-medium">
+                {t(`status.incidents.state.${update.state}`)}
+              </span>
+              {" - "}
+              <span>{update.message}</span>
+              <div className="text-xs text-muted-foreground">
+                {formatDate(update.created_at)}
+              </div>
+            </li>
+          ))}
+        </ol>
+      </CardContent>
+    </Card>
+  );
+};
+
+const Incidents = ({
+  monitors,
+}: {
+  monitors: StatusPageMonitorWithHeartbeatsAndUptimeDto[];
+}) => {
+  const { t } = useLocalizedTranslation();
+  const incidents = collectIncidents(monitors);
+
+  if (incidents.length === 0) return null;
+
+  const active = incidents.filter((incident) => incident.state !== "resolved");
+  const recent = incidents.filter((incident) => incident.state === "resolved");
+
+  return (
+    <div className="space-y-6 mb-8">
+      {active.length > 0 && (
+        <div className="space-y-4">
+          <h2 className="text-xl font-semibold text-left">
+            {t("status.incidents.active")}
+          </h2>
+          {active.map((incident) => (
+            <IncidentCard key={incident.id} incident={incident} />
+          ))}
+        </div>
+      )}
+
+      {recent.length > 0 && (
+        <div className="space-y-4">
+          <h2 className="text-xl font-semibold text-left">
+            {t("status.incidents.recent")}
+          </h2>
+          {recent.map((incident) => (
+            <IncidentCard key={incident.id} incident={incident} />
+          ))}
+        </div>
+      )}
+    </div>
+  );
+};
+
+export default Incidents;
@@ -11,6 +11,7 @@ import { Skeleton } from "@/components/ui/skeleton";
 import { Alert, AlertDescription } from "@/components/ui/alert";
 import {
   AlertCircle,
+  AlertTriangle,
   CheckCircle,
   XCircle,
   Clock,
@@ -23,6 +24,9 @@ import BarHistory from "@/components/bars";
 import { last } from "@/lib/utils";
 import { ThemeToggle } from "../../../components/theme-toggle";
 import { useLocalizedTranslation } from "@/hooks/useTranslation";
+import Incidents from "./incidents";
+import Subscribe from "./subscribe";
+import { getStatusPageGroups } from "@/api/monitor-groups";
 
 const PublicStatusPage = ({ incomingSlug }: { incomingSlug?: string }) => {
   const params = useParams<{ slug: string }>();
@@ -76,6 +80,19 @@ const PublicStatusPage = ({ incomingSlug }: { incomingSlug?: string }) => {
 
   const monitors = monitorsData?.data || [];
 
+  const { data: groups = [], refetch: refetchGroups } = useQuery({
+    queryKey: ["statusPageGroups", slug],
+    queryFn: () => getStatusPageGroups(slug!),
+    enabled: !!slug && !!statusPage,
+  });
+
+  // Monitors shown inside a group section are not repeated below them
+  const groupedMonitorIds = new Set(groups.flatMap((group) => group.monitor_ids));
+  const ungroupedMonitors = monitors.filter(
+    (monitor: StatusPageMonitorWithHeartbeatsAndUptimeDto) =>
+      !groupedMonitorIds.has(monitor.id || "")
+  );
+
   // Auto-refresh logic
   useEffect(() => {
     if (!slug || !statusPage) return;
@@ -86,6 +103,7 @@ const PublicStatusPage = ({ incomingSlug }: { incomingSlug?: string }) => {
           // Time to refresh
           refetchStatusPage();
           refetchMonitors();
+          refetchGroups();
           setLastUpdated(new Date());
           return refreshInterval;
         }
@@ -94,7 +112,7 @@ const PublicStatusPage = ({ incomingSlug }: { incomingSlug?: string }) => {
     }, 1000);
 
     return () => clearInterval(interval);
-  }, [slug, statusPage, refreshInterval, refetchStatusPage, refetchMonitors]);
+  }, [slug, statusPage, refreshInterval, refetchStatusPage, refetchMonitors, refetchGroups]);
 
   // Format countdown as MM:SS
   const formatCountdown = (seconds: number) => {
@@ -178,6 +196,8 @@ const PublicStatusPage = ({ incomingSlug }: { incomingSlug?: string }) => {
         return <XCircle className="h-5 w-5 text-red-500" />;
       case 3: // Maintenance
         return <Clock className="h-5 w-5 text-blue-500" />;
+      case 4: // Degraded
+        return <AlertTriangle className="h-5 w-5 text-amber-500" />;
       default:
         return <Activity className="h-5 w-5 text-gray-500" />;
     }
@@ -192,6 +212,8 @@ const PublicStatusPage = ({ incomingSlug }: { incomingSlug?: string }) => {
         return t("status.messages.down");
       case 3:
         return t("status.messages.maintenance");
+      case 4:
+        return t("status.messages.degraded");
       default:
         return t("status.messages.unknown");
     }
@@ -214,8 +236,16 @@ const PublicStatusPage = ({ incomingSlug }: { incomingSlug?: string }) => {
       }
     );
 
+    const hasDegraded = monitors.some(
+      (m: StatusPageMonitorWithHeartbeatsAndUptimeDto) => {
+        const lastHeartbeat = last(m.heartbeats || []);
+        return lastHeartbeat?.status === 4;
+      }
+    );
+
     if (hasDown) return { status: 0, text: t("status.messages.partial_system_outage") };
     if (hasMaintenance) return { status: 3, text: t("status.messages.under_maintenance") };
+    if (hasDegraded) return { status: 4, text: t("status.messages.degraded") };
     return { status: 1, text: t("status.messages.all_systems_operational") };
   };
 
@@ -225,6 +255,68 @@ const PublicStatusPage = ({ incomingSlug }: { incomingSlug?: string }) => {
 
   const overallStatus = getOverallStatus();
 
+  const renderMonitor = (monitor: StatusPageMonitorWithHeartbeatsAndUptimeDto) => {
+    const lastHeartbeat = last(monitor.heartbeats || []);
+    const lastHeartbeatStatus = lastHeartbeat?.status;
+
+    return (
+      <Card key={monitor.id}>
+        <CardContent className="flex flex-col items-center justify-between gap-2 md:flex-row">
+          <div className="flex justify-between items-center gap-2 w-full">
+            <div className="flex items-center gap-2">
+              {getStatusIcon(lastHeartbeatStatus || 0)}
+              <div>
+                <h3 className="font-semibold">{monitor.name}</h3>
+              </div>
+            </div>
+
+            <div className="flex items-center gap-2">
+              {monitor.uptime_24h !== undefined && (
+                <Badge
+                  variant="outline"
+                  className="flex items-center gap-1"
+                >
+                  <TrendingUp className="h-3 w-3" />
+                  {formatUptime(monitor.uptime_24h)}
+                </Badge>
+              )}
+
+              <Badge
+                variant={
+                  lastHeartbeatStatus === 1
+                    ? "default"
+                    : lastHeartbeatStatus === 0 ||
+                      lastHeartbeatStatus === 2
+                    ? "destructive"
+                    : lastHeartbeatStatus === 3
+                    ? "secondary"
+                    : "outline"
+                }
+              >
+                {getStatusText(lastHeartbeatStatus || 0)}
+              </Badge>
+            </div>
+          </div>
+
+          {/* Heartbeats Section - Only show if there are heartbeats */}
+          {monitor.heartbeats &&
+            monitor.heartbeats.length > 0 && (
+              <div className="w-full">
+                <BarHistory
+                  data={monitor.heartbeats}
+                  segmentWidth={6}
+                  gap={2}
+                  barHeight={16}
+                  borderRadius={2}
+                  tooltip={false}
+                />
+              </div>
+            )}
+        </CardContent>
+      </Card>
+    );
+  };
+
   return (
     <div className="min-h-screen bg-background">
       <div className="max-w-4xl mx-auto p-4">
@@ -255,12 +347,20 @@ const PublicStatusPage = ({ incomingSlug }: { incomingSlug?: string }) => {
             <ThemeToggle />
           </div>
 
+          {/* Subscribe */}
+          <div className="flex justify-center mb-4">
+            <Subscribe slug={slug!} />
+          </div>
+
           {/* Overall Status */}
           <div className="flex items-center justify-center gap-2 mb-6">
             {getStatusIcon(overallStatus.status)}
             <span className="text-lg font-semibold">{overallStatus.text}</span>
           </div>
 
+          {/* Incidents */}
+          {!monitorsLoading && <Incidents monitors={monitors} />}
+
           {/* Monitors */}
           <div className="space-y-4">
             {monitorsLoading && (
@@ -282,70 +382,34 @@ const PublicStatusPage = ({ incomingSlug }: { incomingSlug?: string }) => {
             )}
 
             {!monitorsLoading &&
-              monitors.length > 0 &&
-              monitors.map(
-                (monitor: StatusPageMonitorWithHeartbeatsAndUptimeDto) => {
-                  const lastHeartbeat = last(monitor.heartbeats || []);
-                  const lastHeartbeatStatus = lastHeartbeat?.status;
-
-                  return (
-                    <Card key={monitor.id}>
-                      <CardContent className="flex flex-col items-center justify-between gap-2 md:flex-row">
-                        <div className="flex justify-between items-center gap-2 w-full">
-                          <div className="flex items-center gap-2">
-                            {getStatusIcon(lastHeartbeatStatus || 0)}
-                            <div>
-                              <h3 className="font-semibold">{monitor.name}</h3>
-                            </div>
-                          </div>
-
-                          <div className="flex items-center gap-2">
-                            {monitor.uptime_24h !== undefined && (
-                              <Badge
-                                variant="outline"
-                                className="flex items-center gap-1"
-                              >
-                                <TrendingUp className="h-3 w-3" />
-                                {formatUptime(monitor.uptime_24h)}
-                              </Badge>
-                            )}
-
-                            <Badge
-                              variant={
-                                lastHeartbeatStatus === 1
-                                  ? "default"
-                                  : lastHeartbeatStatus === 0 ||
-                                    lastHeartbeatStatus === 2
-                                  ? "destructive"
-                                  : lastHeartbeatStatus === 3
-                                  ? "secondary"
-                                  : "outline"
-                              }
-                            >
-                              {getStatusText(lastHeartbeatStatus || 0)}
-                            </Badge>
-                          </div>
-                        </div>
-
-                        {/* Heartbeats Section - Only show if there are heartbeats */}
-                        {monitor.heartbeats &&
-                          monitor.heartbeats.length > 0 && (
-                            <div className="w-full">
-                              <BarHistory
-                                data={monitor.heartbeats}
-                                segmentWidth={6}
-                                gap={2}
-                                barHeight={16}
-                                borderRadius={2}
-                                tooltip={false}
-                              />
-                            </div>
+              groups.map((group) => {
+                const groupMonitors = monitors.filter(
+                  (monitor: StatusPageMonitorWithHeartbeatsAndUptimeDto) =>
+                    group.monitor_ids.includes(monitor.id || "")
+                );
+
+                return (
+                  <div key={group.id} className="space-y-2 text-left">
+                    <div className="flex items-center justify-between gap-2 px-1">
+                      <div className="flex items-center gap-2">
+                        {getStatusIcon(group.status)}
+                        <div>
+                          <h2 className="text-lg font-semibold">{group.name}</h2>
+                          {group.description && (
+                            <p className="text-sm text-muted-foreground">
+                              {group.description}
+                            </p>
                           )}
-                      </CardContent>
-                    </Card>
-                  );
-                }
-              )}
+                        </div>
+                      </div>
+                      <Badge variant="outline">{getStatusText(group.status)}</Badge>
+                    </div>
+                    {groupMonitors.map(renderMonitor)}
+                  </div>
+                );
+              })}
+
+            {!monitorsLoading && ungroupedMonitors.map(renderMonitor)}
           </div>
 
           {statusPage.footer_text && (
@@ -372,6 +436,7 @@ const PublicStatusPage = ({ incomingSlug }: { incomingSlug?: string }) => {
               onClick={() => {
                 refetchStatusPage();
                 refetchMonitors();
+                refetchGroups();
                 setLastUpdated(new Date());
                 setCountdown(refreshInterval);
               }}
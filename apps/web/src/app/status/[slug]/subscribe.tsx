@@ -0,0 +1,90 @@
+import { useState } from "react";
+import { useMutation } from "@tanstack/react-query";
+import { Bell } from "lucide-react";
+import { toast } from "sonner";
+import {
+  subscribeToStatusPage,
+  type StatusPageSubscriptionType,
+} from "@/api/status-page-subscribers";
+import { Button } from "@/components/ui/button";
+import { Card, CardContent } from "@/components/ui/card";
+import { Input } from "@/components/ui/input";
+import { Tabs, TabsList, TabsTrigger } from "@/components/ui/tabs";
+import { useLocalizedTranslation } from "@/hooks/useTranslation";
+
+const Subscribe = ({ slug }: { slug: string }) => {
+  const { t } = useLocalizedTranslation();
+  const [open, setOpen] = useState(false);
+  const [type, setType] = useState<StatusPageSubscriptionType>("email");
+  const [value, setValue] = useState("");
+
+  const mutation = useMutation({
+    mutationFn: () =>
+      subscribeToStatusPage(
+        slug,
+        type === "email" ? { type, email: value } : { type, webhook_url: value }
+      ),
+    onSuccess: () => {
+      toast.success(
+        type === "email"
+          ? t("status.subscribe.email_sent")
+          : t("status.subscribe.webhook_registered")
+      );
+      setValue("");
+      setOpen(false);
+    },
+    onError: () => {
+      toast.error(t("status.subscribe.failed"));
+    },
+  });
+
+  if (!open) {
+    return (
+      <Button variant="outline" size="sm" onClick={() => setOpen(true)}>
+        <Bell className="h-4 w-4" />
+        {t("status.subscribe.button")}
+      </Button>
+    );
+  }
+
+  return (
+    <Card className="w-full max-w-md mx-auto">
+      <CardContent className="space-y-3">
+        <Tabs
+          value={type}
+          onValueChange={(next) => setType(next as StatusPageSubscriptionType)}
+        >
+          <TabsList className="w-full">
+            <TabsTrigger value="email">{t("status.subscribe.email")}</TabsTrigger>
+            <TabsTrigger value="webhook">{t("status.subscribe.webhook")}</TabsTrigger>
+          </TabsList>
+        </Tabs>
+
+        <form
+          className="flex gap-2"
+          onSubmit={(e) => {
+            e.preventDefault();
+            mutation.mutate();
+          }}
+        >
+          <Input
+            type={type === "email" ? "email" : "url"}
+            required
+            value={value}
+            onChange={(e) => setValue(e.target.value)}
+            placeholder={
+              type === "email"
+                ? t("status.subscribe.email_placeholder")
+                : t("status.subscribe.webhook_placeholder")
+            }
+          />
+          <Button type="submit" disabled={mutation.isPending}>
+            {t("status.subscribe.submit")}
+          </Button>
+        </form>
+      </CardContent>
+    </Card>
+  );
+};
+
+export default Subscribe;
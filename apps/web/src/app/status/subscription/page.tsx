@@ -0,0 +1,64 @@
+import { useEffect, useRef, useState } from "react";
+import { useParams } from "react-router-dom";
+import { AlertTriangle, CheckCircle } from "lucide-react";
+import {
+  confirmStatusPageSubscription,
+  unsubscribeFromStatusPage,
+} from "@/api/status-page-subscribers";
+import { Card, CardContent } from "@/components/ui/card";
+import { Skeleton } from "@/components/ui/skeleton";
+import { useLocalizedTranslation } from "@/hooks/useTranslation";
+
+type Result = "pending" | "success" | "error";
+
+const StatusSubscriptionPage = () => {
+  const { action, token } = useParams<{ action: string; token: string }>();
+  const { t } = useLocalizedTranslation();
+  const [result, setResult] = useState<Result>("pending");
+  const requested = useRef(false);
+
+  const isUnsubscribe = action === "unsubscribe";
+
+  useEffect(() => {
+    // Tokens are single use for unsubscribing, avoid sending the request twice
+    if (!token || requested.current) return;
+    requested.current = true;
+
+    const request = isUnsubscribe
+      ? unsubscribeFromStatusPage(token)
+      : confirmStatusPageSubscription(token);
+    request.then(() => setResult("success")).catch(() => setResult("error"));
+  }, [token, isUnsubscribe]);
+
+  return (
+    <div className="min-h-screen bg-background flex items-center justify-center p-4">
+      <Card className="w-full max-w-md text-center">
+        <CardContent className="space-y-4 p-8">
+          {result === "pending" && <Skeleton className="h-12 w-full" />}
+
+          {result === "success" && (
+            <>
+              <CheckCircle className="h-12 w-12 text-green-500 mx-auto" />
+              <p className="text-lg font-semibold">
+                {isUnsubscribe
+                  ? t("status.subscription.unsubscribed")
+                  : t("status.subscription.confirmed")}
+              </p>
+            </>
+          )}
+
+          {result === "error" && (
+            <>
+              <AlertTriangle className="h-12 w-12 text-yellow-500 mx-auto" />
+              <p className="text-lg font-semibold">
+                {t("status.subscription.invalid_link")}
+              </p>
+            </>
+          )}
+        </CardContent>
+      </Card>
+    </div>
+  );
+};
+
+export default StatusSubscriptionPage;
@@ -0,0 +1,58 @@
+import { useEffect, useRef, useState } from "react";
+import { Link, useSearchParams } from "react-router-dom";
+import { AlertTriangle, CheckCircle } from "lucide-react";
+import { verifyEmail } from "@/api/auth-emails";
+import { Card, CardContent } from "@/components/ui/card";
+import { Skeleton } from "@/components/ui/skeleton";
+import { useLocalizedTranslation } from "@/hooks/useTranslation";
+
+type Result = "pending" | "success" | "error";
+
+// Reached from the verification email, whether or not the user is signed in
+const VerifyEmailPage = () => {
+  const [searchParams] = useSearchParams();
+  const token = searchParams.get("token");
+  const { t } = useLocalizedTranslation();
+  const [result, setResult] = useState<Result>(token ? "pending" : "error");
+  const requested = useRef(false);
+
+  useEffect(() => {
+    // Tokens are single use, avoid sending the request twice
+    if (!token || requested.current) return;
+    requested.current = true;
+
+    verifyEmail(token).then(() => setResult("success")).catch(() => setResult("error"));
+  }, [token]);
+
+  return (
+    <div className="min-h-screen bg-background flex items-center justify-center p-4">
+      <Card className="w-full max-w-md text-center">
+        <CardContent className="space-y-4 p-8">
+          {result === "pending" && <Skeleton className="h-12 w-full" />}
+
+          {result === "success" && (
+            <>
+              <CheckCircle className="h-12 w-12 text-green-500 mx-auto" />
+              <p className="text-lg font-semibold">{t("auth.verify_email.verified")}</p>
+            </>
+          )}
+
+          {result === "error" && (
+            <>
+              <AlertTriangle className="h-12 w-12 text-yellow-500 mx-auto" />
+              <p className="text-lg font-semibold">{t("auth.verify_email.invalid_link")}</p>
+            </>
+          )}
+
+          {result !== "pending" && (
+            <Link to="/" className="block text-sm font-medium text-primary hover:underline">
+              {t("auth.verify_email.continue")}
+            </Link>
+          )}
+        </CardContent>
+      </Card>
+    </div>
+  );
+};
+
+export default VerifyEmailPage;
@@ -48,6 +48,7 @@ function getStatusRanges(data: MonitorStatPoint[]) {
     if (!point) return null;
     if (point.maintenance) return "rgba(0, 0, 255, 0.5)";
     if (point.up !== 0 && point.down !== 0) return "rgba(255, 215, 0, 0.15)";
+    if (point.degraded && point.down === 0) return "rgba(245, 158, 11, 0.15)";
     if (point.up === 0 && point.down !== 0) return "rgba(255, 0, 0, 0.15)";
     if (point.up !== 0 && point.down === 0) return "none";
     return null;
@@ -7,6 +7,7 @@ import { Button } from "@/components/ui/button";
 import {
     Form,
     FormControl,
+    FormDescription,
     FormField,
     FormItem,
     FormLabel,
@@ -27,6 +28,7 @@ import { useTranslation } from "react-i18next";
 const formSchema = z.object({
     apiKey: z.string().min(1, "API Key is required"),
     environment: z.enum(["sandbox", "production"]),
+    webhookToken: z.string().optional(),
 });
 
 export function AsaasConfigForm() {
@@ -39,6 +41,7 @@ export function AsaasConfigForm() {
         defaultValues: {
             apiKey: "",
             environment: "sandbox",
+            webhookToken: "",
         },
     });
 
@@ -52,6 +55,7 @@ export function AsaasConfigForm() {
                     form.reset({
                         apiKey: data.data.apiKey,
                         environment: data.data.environment,
+                        webhookToken: data.data.webhookToken ?? "",
                     });
                 }
             } catch (error) {
@@ -70,6 +74,9 @@ export function AsaasConfigForm() {
                 // @ts-ignore
                 delete payload.apiKey;
             }
+            if (payload.webhookToken === "********") {
+                delete payload.webhookToken;
+            }
             await saveAsaasConfig(currentOrganization.id, payload);
             toast.success(t("organization.integrations.form.toast_success"));
         } catch (error) {
@@ -137,6 +144,41 @@ export function AsaasConfigForm() {
                     )}
                 />
 
+                <FormField
+                    control={form.control}
+                    name="webhookToken"
+                    render={({ field }) => (
+                        <FormItem>
+                            <FormLabel>{t("organization.integrations.form.webhook_token")}</FormLabel>
+                            <FormControl>
+                                {field.value === "********" ? (
+                                    <div className="flex items-center gap-4 p-4 border rounded-md bg-muted/20">
+                                        <div className="flex-1 text-sm text-muted-foreground italic">
+                                            {t("organization.integrations.form.encrypted_secret")}
+                                        </div>
+                                        <Button
+                                            type="button"
+                                            variant="outline"
+                                            size="sm"
+                                            onClick={() => field.onChange("")}
+                                        >
+                                            {t("organization.integrations.form.replace_content")}
+                                        </Button>
+                                    </div>
+                                ) : (
+                                    <Input type="password" placeholder="Webhook Token" {...field} />
+                                )}
+                            </FormControl>
+                            <FormDescription>
+                                {t("organization.integrations.form.webhook_token_description", {
+                                    organizationId: currentOrganization?.id,
+                                })}
+                            </FormDescription>
+                            <FormMessage />
+                        </FormItem>
+                    )}
+                />
+
                 <Button type="submit" disabled={isLoading}>
                     {isLoading ? t("organization.integrations.form.saving") : t("organization.integrations.form.save")}
                 </Button>
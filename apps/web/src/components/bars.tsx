@@ -81,6 +81,7 @@ const BarHistory: React.FC<BarHistoryProps> = ({
                       "bg-green-500": value?.status === 1,
                       "bg-red-500": value?.status === 0 || value?.status === 2,
                       "bg-blue-500": value?.status === 3,
+                      "bg-amber-500": value?.status === 4,
                     })}
                     style={{
                       width: `${segmentWidth}px`,
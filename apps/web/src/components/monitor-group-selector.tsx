@@ -0,0 +1,37 @@
+import { useQuery } from "@tanstack/react-query";
+import { useMemo } from "react";
+import { MultiSelect } from "./multi-select";
+import { getMonitorGroups, monitorGroupsQueryKey } from "@/api/monitor-groups";
+import { useLocalizedTranslation } from "@/hooks/useTranslation";
+
+const MonitorGroupSelector = ({
+  value,
+  onSelect,
+}: {
+  value: string[];
+  onSelect: (value: string[]) => void;
+}) => {
+  const { t } = useLocalizedTranslation();
+
+  const { data: groups } = useQuery({
+    queryKey: [...monitorGroupsQueryKey, "all"],
+    queryFn: () => getMonitorGroups({ limit: 100 }),
+  });
+
+  const options = useMemo(
+    () =>
+      (groups || []).map((group) => ({ label: group.name, value: group.id })),
+    [groups]
+  );
+
+  return (
+    <MultiSelect
+      options={options}
+      value={value}
+      onValueChange={onSelect}
+      placeholder={t("monitor_groups.select_groups")}
+    />
+  );
+};
+
+export default MonitorGroupSelector;
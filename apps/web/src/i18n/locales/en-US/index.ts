@@ -11,11 +11,13 @@ import invitation from './invitation.json';
 import invoice from './invoice.json';
 import maintenance from './maintenance.json';
 import messages from './messages.json';
+import monitorGroups from './monitor_groups.json';
 import monitors from './monitors.json';
 import navigation from './navigation.json';
 import notifications from './notifications.json';
 import onboarding from './onboarding.json';
 import organization from './organization.json';
+import probes from './probes.json';
 import proxies from './proxies.json';
 import security from './security.json';
 import sendgrid from './sendgrid.json';
@@ -41,11 +43,13 @@ export default {
     invoice,
     maintenance,
     messages,
+    monitor_groups: monitorGroups,
     monitors,
     navigation,
     notifications,
     onboarding,
     organization,
+    probes,
     proxies,
     security,
     sendgrid,
@@ -7,6 +7,7 @@ import clients from './clients.json';
 import common from './common.json';
 import maintenance from './maintenance.json';
 import messages from './messages.json';
+import monitorGroups from './monitor_groups.json';
 import monitors from './monitors.json';
 import organization from './organization.json';
 import onboarding from './onboarding.json';
@@ -14,6 +15,7 @@ import user_invitations from './user_invitations.json';
 import invitation from './invitation.json';
 import navigation from './navigation.json';
 import notifications from './notifications.json';
+import probes from './probes.json';
 import proxies from './proxies.json';
 import security from './security.json';
 import sendgrid from './sendgrid.json';
@@ -37,6 +39,7 @@ const ptBR = {
     common,
     maintenance,
     messages,
+    monitor_groups: monitorGroups,
     monitors,
     organization,
     onboarding,
@@ -44,6 +47,7 @@ const ptBR = {
     invitation,
     navigation,
     notifications,
+    probes,
     proxies,
     security,
     sendgrid,
@@ -1,9 +1,15 @@
 import { Route, Navigate } from "react-router-dom";
 import SHLoginPage from "@/app/login/page";
 import SHRegisterPage from "@/app/register/page";
+import SSOCallbackPage from "@/app/login/sso/page";
+import ForgotPasswordPage from "@/app/forgot-password/page";
+import ResetPasswordPage from "@/app/reset-password/page";
 
 export const authRoutes = [
   <Route path="/login" element={<SHLoginPage />} />,
+  <Route path="/login/sso" element={<SSOCallbackPage />} />,
   <Route path="/register" element={<SHRegisterPage />} />,
+  <Route path="/forgot-password" element={<ForgotPasswordPage />} />,
+  <Route path="/reset-password" element={<ResetPasswordPage />} />,
   <Route path="*" element={<Navigate to="/login" replace />} />
 ]; 
\ No newline at end of file
@@ -20,6 +20,12 @@ import EditStatusPage from "@/app/status-pages/edit/page";
 import TagsPage from "@/app/tags/page";
 import NewTag from "@/app/tags/new/page";
 import EditTag from "@/app/tags/edit/page";
+import MonitorGroupsPage from "@/app/monitor-groups/page";
+import NewMonitorGroup from "@/app/monitor-groups/new/page";
+import EditMonitorGroup from "@/app/monitor-groups/edit/page";
+import ProbesPage from "@/app/probes/page";
+import NewProbe from "@/app/probes/new/page";
+import EditProbe from "@/app/probes/edit/page";
 import { OrganizationLayout } from "@/components/organization-layout";
 import CreateOrganizationPage from "@/app/create-organization/page";
 import OrganizationSettingsPage from "@/app/organization/settings/page";
@@ -72,6 +78,16 @@ export const protectedRoutes = [
         <Route path="monitors/new" element={<NewMonitor />} />
         <Route path="monitors/:id/edit" element={<EditMonitor />} />
 
+        {/* Monitor group routes */}
+        <Route path="monitor-groups" element={<MonitorGroupsPage />} />
+        <Route path="monitor-groups/new" element={<NewMonitorGroup />} />
+        <Route path="monitor-groups/:id/edit" element={<EditMonitorGroup />} />
+
+        {/* Probe routes */}
+        <Route path="probes" element={<ProbesPage />} />
+        <Route path="probes/new" element={<NewProbe />} />
+        <Route path="probes/:id/edit" element={<EditProbe />} />
+
         {/* Status page routes */}
         <Route path="status-pages" element={<StatusPagesPage />} />
         <Route path="status-pages/new" element={<NewStatusPage />} />
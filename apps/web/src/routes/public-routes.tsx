@@ -2,11 +2,15 @@ import { Navigate, Route } from "react-router-dom";
 import PublicStatusPage from "@/app/status/[slug]/page";
 import InvitationPage from "@/app/invite/[token]/page";
 import PublicInvoicePage from "@/app/public/invoice/page";
+import StatusSubscriptionPage from "@/app/status/subscription/page";
+import VerifyEmailPage from "@/app/verify-email/page";
 
 export const publicRoutes = [
     <Route path="/status/:slug" element={<PublicStatusPage />} />,
+    <Route path="/status-subscriptions/:action/:token" element={<StatusSubscriptionPage />} />,
     <Route path="/invite/:token" element={<InvitationPage />} />,
-    <Route path="/p/invoices/:id" element={<PublicInvoicePage />} />
+    <Route path="/p/invoices/:id" element={<PublicInvoicePage />} />,
+    <Route path="/verify-email" element={<VerifyEmailPage />} />
 ];
 
 export const createCustomDomainRoute = (slug: string) => (